@@ -0,0 +1,10 @@
+// Package api holds the published description of the HTTP API
+package api
+
+import _ "embed"
+
+// OpenAPISpec is the OpenAPI 3 description of the billing API (openapi.yaml)
+// Embedded so tests and tooling use exactly the document that is published
+//
+//go:embed openapi.yaml
+var OpenAPISpec []byte
@@ -10,6 +10,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -17,6 +18,7 @@ import (
 	"time"
 
 	"github.com/gjaminon-go-labs/billing-api/internal/config"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/listener"
 )
 
 // Build-time variables (set via -ldflags)
@@ -66,21 +68,36 @@ func run() error {
 	}
 	log.Println("✅ HTTP server created")
 
-	// 4. Configure and start HTTP server
-	server := &http.Server{
-		Addr:         fmt.Sprintf("%s:%d", appConfig.Server.Host, appConfig.Server.Port),
-		Handler:      httpServer.Handler(),
-		ReadTimeout:  appConfig.Server.ReadTimeout,
-		WriteTimeout: appConfig.Server.WriteTimeout,
-		IdleTimeout:  appConfig.Server.IdleTimeout,
+	// 4. Configure HTTP servers and open their sockets (TCP port, Unix socket or systemd-activated socket)
+	// Health checks, the admin API and the profiler get their own server when an admin listener is configured
+	server := newHTTPServer(httpServer.Handler(), appConfig.Server)
+	socket, err := listener.Listen(appConfig.Server.ListenerConfig())
+	if err != nil {
+		return fmt.Errorf("failed to open server socket: %w", err)
+	}
+	var adminServer *http.Server
+	var adminSocket net.Listener
+	if appConfig.Server.SeparateAdminListener() {
+		adminServer = newHTTPServer(httpServer.AdminHandler(), appConfig.Server)
+		adminSocket, err = listener.Listen(appConfig.Server.AdminListenerConfig())
+		if err != nil {
+			socket.Close()
+			return fmt.Errorf("failed to open admin server socket: %w", err)
+		}
 	}
 
-	// 5. Start server in goroutine
-	serverErrors := make(chan error, 1)
+	// 5. Start servers in goroutines
+	serverErrors := make(chan error, 2)
 	go func() {
-		log.Printf("🌐 HTTP server starting on %s", server.Addr)
-		serverErrors <- server.ListenAndServe()
+		log.Printf("🌐 HTTP server starting on %s", listener.Describe(socket))
+		serverErrors <- server.Serve(socket)
 	}()
+	if adminServer != nil {
+		go func() {
+			log.Printf("🔧 Admin HTTP server starting on %s", listener.Describe(adminSocket))
+			serverErrors <- adminServer.Serve(adminSocket)
+		}()
+	}
 
 	// 6. Set up signal handling for Kubernetes
 	signals := make(chan os.Signal, 1)
@@ -92,6 +109,10 @@ func run() error {
 	// 7. Wait for shutdown signal or server error
 	select {
 	case err := <-serverErrors:
+		server.Close()
+		if adminServer != nil {
+			adminServer.Close()
+		}
 		if err != nil && err != http.ErrServerClosed {
 			return fmt.Errorf("server error: %w", err)
 		}
@@ -100,16 +121,40 @@ func run() error {
 	case sig := <-signals:
 		log.Printf("🛑 Received signal: %s, starting graceful shutdown...", sig)
 
-		// 8. Graceful shutdown sequence
+		// 8. Graceful shutdown sequence (the admin server keeps answering health checks while the API drains)
 		if err := gracefulShutdown(server, appConfig.Server.ShutdownTimeout); err != nil {
 			return fmt.Errorf("graceful shutdown failed: %w", err)
 		}
+		if adminServer != nil {
+			if err := gracefulShutdown(adminServer, appConfig.Server.ShutdownTimeout); err != nil {
+				return fmt.Errorf("admin server graceful shutdown failed: %w", err)
+			}
+		}
+	}
+
+	// 9. Let async event subscribers handle the events of the last requests (audit entries)
+	if events, err := container.GetEventBus(); err == nil {
+		ctx, cancel := context.WithTimeout(context.Background(), appConfig.Server.ShutdownTimeout)
+		defer cancel()
+		if err := events.Wait(ctx); err != nil {
+			log.Printf("❌ Event subscribers did not finish before shutdown: %v", err)
+		}
 	}
 
 	log.Println("✅ Billing Service stopped gracefully")
 	return nil
 }
 
+// newHTTPServer creates an HTTP server of the handler with the configured timeouts
+func newHTTPServer(handler http.Handler, serverConfig config.ServerConfig) *http.Server {
+	return &http.Server{
+		Handler:      handler,
+		ReadTimeout:  serverConfig.ReadTimeout,
+		WriteTimeout: serverConfig.WriteTimeout,
+		IdleTimeout:  serverConfig.IdleTimeout,
+	}
+}
+
 // gracefulShutdown performs graceful shutdown of the HTTP server
 func gracefulShutdown(server *http.Server, timeout time.Duration) error {
 	log.Printf("⏳ Starting graceful shutdown (timeout: %s)...", timeout)
@@ -0,0 +1,127 @@
+// Billing Operations CLI Tool
+//
+// This is a standalone CLI tool for operating a billing deployment.
+// Provides: Read model (projection) maintenance from the event store
+// Features: Snapshot of current states, rebuild by replaying the event store with progress reporting
+// Usage: billingctl <group> <command> [args]
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/config"
+)
+
+// Build-time variables (set via -ldflags)
+var (
+	Version = "dev"
+)
+
+const (
+	cmdProjections = "projections"
+	cmdRebuild     = "rebuild"
+	cmdSnapshot    = "snapshot"
+	cmdHelp        = "help"
+)
+
+// projectionClientSummaries is the client list read model (balances and last invoice per client)
+const projectionClientSummaries = "client-summaries"
+
+func main() {
+	log.SetFlags(log.LstdFlags)
+	log.SetPrefix("[BILLINGCTL] ")
+
+	if err := run(os.Args[1:]); err != nil {
+		log.Fatalf("Command failed: %v", err)
+	}
+}
+
+// run dispatches the command line to its command
+func run(args []string) error {
+	if len(args) == 0 || args[0] == cmdHelp || args[0] == "-h" || args[0] == "--help" {
+		printUsage()
+		return nil
+	}
+	if args[0] != cmdProjections {
+		return fmt.Errorf("unknown command: %s", args[0])
+	}
+	if len(args) != 3 {
+		printUsage()
+		return fmt.Errorf("projections commands take a command and a projection name")
+	}
+
+	command, name := args[1], args[2]
+	if command != cmdRebuild && command != cmdSnapshot {
+		return fmt.Errorf("unknown projections command: %s", command)
+	}
+	if name != projectionClientSummaries {
+		return fmt.Errorf("unknown projection: %s (available: %s)", name, projectionClientSummaries)
+	}
+
+	// Load configuration and build the services the API runs with
+	environment := config.GetEnvironment()
+	log.Printf("📋 Environment: %s", environment)
+
+	container, err := config.NewProductionContainerFromEnvironmentWithVersion(environment, Version)
+	if err != nil {
+		return fmt.Errorf("failed to create DI container: %w", err)
+	}
+	billingService, err := container.GetBillingService()
+	if err != nil {
+		return fmt.Errorf("failed to create billing service: %w", err)
+	}
+
+	if command == cmdSnapshot {
+		return snapshotClientSummaries(billingService)
+	}
+	return rebuildClientSummaries(billingService)
+}
+
+// snapshotClientSummaries records the current state of every client and invoice in the event store
+func snapshotClientSummaries(billingService *application.BillingService) error {
+	log.Println("📸 Recording the states of clients and invoices in the event store...")
+	recorded, err := billingService.SnapshotClientSummaryStates(func(recorded int) {
+		log.Printf("   %d states recorded", recorded)
+	})
+	if err != nil {
+		return err
+	}
+	log.Printf("✅ %d states recorded", recorded)
+	return nil
+}
+
+// rebuildClientSummaries replays the event store into the client list read model
+func rebuildClientSummaries(billingService *application.BillingService) error {
+	log.Println("🔄 Replaying the event store into the client summaries...")
+	started := time.Now()
+	rebuilt, err := billingService.RebuildClientSummaries(started, func(replayed int) {
+		log.Printf("   %d events replayed", replayed)
+	})
+	if err != nil {
+		return err
+	}
+	log.Printf("✅ %d client summaries rebuilt in %s", rebuilt, time.Since(started).Round(time.Millisecond))
+	return nil
+}
+
+func printUsage() {
+	fmt.Printf("Billing Operations CLI Tool\n\n")
+	fmt.Printf("Usage: billingctl <command> [args]\n\n")
+	fmt.Printf("Commands:\n")
+	fmt.Printf("  projections rebuild <name>   Rebuild a read model by replaying the event store\n")
+	fmt.Printf("  projections snapshot <name>  Record the current states a read model is rebuilt from in the event store\n")
+	fmt.Printf("  help                         Show this help message\n\n")
+	fmt.Printf("Projections:\n")
+	fmt.Printf("  %s             Client list with balances and last invoice\n\n", projectionClientSummaries)
+	fmt.Printf("The event store must be enabled (event_store.enabled). Run snapshot once when enabling it on existing\n")
+	fmt.Printf("data, so states saved before are replayed too.\n\n")
+	fmt.Printf("Environment Variables:\n")
+	fmt.Printf("  ENVIRONMENT    Set environment (development, production)\n\n")
+	fmt.Printf("Examples:\n")
+	fmt.Printf("  billingctl projections snapshot client-summaries\n")
+	fmt.Printf("  ENVIRONMENT=production billingctl projections rebuild client-summaries\n")
+}
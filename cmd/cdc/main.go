@@ -0,0 +1,79 @@
+// Change Data Capture Relay Entry Point
+//
+// This is the standalone entry point for the CDC relay.
+// Provides: PostgreSQL logical replication (wal2json) -> normalized change events on the message bus
+// Features: Configuration loading, slot bootstrap, graceful shutdown on SIGTERM/SIGINT
+// Deployment: Runs as its own Kubernetes deployment (single replica per slot), independent from the API
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/cdc"
+	"github.com/gjaminon-go-labs/billing-api/internal/config"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/messaging"
+)
+
+// Build-time variables (set via -ldflags)
+var (
+	Version = "dev"
+)
+
+func main() {
+	log.Printf("🚀 Starting Billing CDC relay (version %s)", Version)
+
+	if err := run(); err != nil {
+		log.Fatalf("CDC relay failed: %v", err)
+	}
+}
+
+// run contains the relay lifecycle
+func run() error {
+	// 1. Load configuration
+	environment := config.GetEnvironment()
+	log.Printf("📋 Environment: %s", environment)
+
+	appConfig, err := config.LoadConfig(environment)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	cdcConfig := appConfig.ToCDCConfig()
+
+	// 2. Connect with the replication-capable role
+	db, err := gorm.Open(postgres.Open(appConfig.CDCDatabaseURL()), &gorm.Config{})
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get underlying SQL DB: %w", err)
+	}
+	defer sqlDB.Close()
+
+	// 3. Cancel on SIGTERM (Kubernetes) or SIGINT (local development)
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+
+	source := cdc.NewSlotSource(sqlDB, cdcConfig.SlotName, cdcConfig.Tables)
+	if err := source.EnsureSlot(ctx); err != nil {
+		return err
+	}
+	log.Printf("✅ Replication slot %s ready, capturing %v", cdcConfig.SlotName, cdcConfig.Tables)
+
+	// 4. Relay until shutdown; stdout is the message bus transport until a broker adapter is configured
+	relay := cdc.NewRelay(cdcConfig, source, messaging.NewWriterPublisher(os.Stdout))
+	if err := relay.Run(ctx); err != nil {
+		return err
+	}
+
+	log.Println("✅ CDC relay stopped gracefully")
+	return nil
+}
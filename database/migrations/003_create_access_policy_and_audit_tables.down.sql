@@ -0,0 +1,10 @@
+-- Drop triggers first
+DROP TRIGGER IF EXISTS update_audit_log_records_updated_at ON billing.audit_log_records;
+DROP TRIGGER IF EXISTS update_ip_access_policy_records_updated_at ON billing.ip_access_policy_records;
+
+-- Drop indexes
+DROP INDEX IF EXISTS billing.idx_audit_log_records_created_at;
+
+-- Drop tables
+DROP TABLE IF EXISTS billing.audit_log_records;
+DROP TABLE IF EXISTS billing.ip_access_policy_records;
@@ -0,0 +1,34 @@
+-- Create storage collections for tenant IP access policies and the audit log
+-- Both tables share the storage_records shape used by the PostgreSQL storage abstraction
+
+CREATE TABLE billing.ip_access_policy_records (
+    key VARCHAR(255) PRIMARY KEY,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE billing.audit_log_records (
+    key VARCHAR(255) PRIMARY KEY,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for better query performance
+CREATE INDEX idx_audit_log_records_created_at ON billing.audit_log_records(created_at);
+
+-- Add comments for documentation
+COMMENT ON TABLE billing.ip_access_policy_records IS 'Tenant source IP allow/deny policies keyed by tenant ID';
+COMMENT ON TABLE billing.audit_log_records IS 'Append-only audit log of administrative changes';
+
+-- Create triggers to automatically update updated_at
+CREATE TRIGGER update_ip_access_policy_records_updated_at 
+    BEFORE UPDATE ON billing.ip_access_policy_records 
+    FOR EACH ROW 
+    EXECUTE FUNCTION billing.update_updated_at_column();
+
+CREATE TRIGGER update_audit_log_records_updated_at 
+    BEFORE UPDATE ON billing.audit_log_records 
+    FOR EACH ROW 
+    EXECUTE FUNCTION billing.update_updated_at_column();
@@ -0,0 +1,8 @@
+-- Drop trigger first
+DROP TRIGGER IF EXISTS update_form_token_records_updated_at ON billing.form_token_records;
+
+-- Drop indexes
+DROP INDEX IF EXISTS billing.idx_form_token_records_created_at;
+
+-- Drop table
+DROP TABLE IF EXISTS billing.form_token_records;
@@ -0,0 +1,21 @@
+-- Create storage collection for one-time form tokens (duplicate submission guard)
+-- The table shares the storage_records shape used by the PostgreSQL storage abstraction
+
+CREATE TABLE billing.form_token_records (
+    key VARCHAR(255) PRIMARY KEY,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for better query performance (expired token purges)
+CREATE INDEX idx_form_token_records_created_at ON billing.form_token_records(created_at);
+
+-- Add comments for documentation
+COMMENT ON TABLE billing.form_token_records IS 'Single-use form tokens keyed by token value';
+
+-- Create trigger to automatically update updated_at
+CREATE TRIGGER update_form_token_records_updated_at 
+    BEFORE UPDATE ON billing.form_token_records 
+    FOR EACH ROW 
+    EXECUTE FUNCTION billing.update_updated_at_column();
@@ -0,0 +1,9 @@
+-- Drop trigger first
+DROP TRIGGER IF EXISTS update_client_change_records_updated_at ON billing.client_change_records;
+
+-- Drop indexes
+DROP INDEX IF EXISTS billing.idx_client_change_records_created_at;
+
+-- Drop sequence and table
+DROP SEQUENCE IF EXISTS billing.client_change_records_seq;
+DROP TABLE IF EXISTS billing.client_change_records;
@@ -0,0 +1,25 @@
+-- Create storage collection for the client change log (incremental sync feed)
+-- The table shares the storage_records shape used by the PostgreSQL storage abstraction
+
+CREATE TABLE billing.client_change_records (
+    key VARCHAR(255) PRIMARY KEY,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Monotonic change sequence allocated by the storage layer (<table>_seq convention)
+CREATE SEQUENCE billing.client_change_records_seq;
+
+-- Create indexes for better query performance
+CREATE INDEX idx_client_change_records_created_at ON billing.client_change_records(created_at);
+
+-- Add comments for documentation
+COMMENT ON TABLE billing.client_change_records IS 'Client change log keyed by zero-padded change sequence';
+COMMENT ON SEQUENCE billing.client_change_records_seq IS 'Monotonic sequence of client changes';
+
+-- Create trigger to automatically update updated_at
+CREATE TRIGGER update_client_change_records_updated_at 
+    BEFORE UPDATE ON billing.client_change_records 
+    FOR EACH ROW 
+    EXECUTE FUNCTION billing.update_updated_at_column();
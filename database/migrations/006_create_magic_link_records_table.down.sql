@@ -0,0 +1,8 @@
+-- Drop trigger first
+DROP TRIGGER IF EXISTS update_magic_link_records_updated_at ON billing.magic_link_records;
+
+-- Drop indexes
+DROP INDEX IF EXISTS billing.idx_magic_link_records_created_at;
+
+-- Drop table
+DROP TABLE IF EXISTS billing.magic_link_records;
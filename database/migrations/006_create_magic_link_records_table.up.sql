@@ -0,0 +1,21 @@
+-- Create storage collection for magic-link tokens (portal sign-in and other emailed links)
+-- The table shares the storage_records shape used by the PostgreSQL storage abstraction
+
+CREATE TABLE billing.magic_link_records (
+    key VARCHAR(255) PRIMARY KEY,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for better query performance (expired link purges)
+CREATE INDEX idx_magic_link_records_created_at ON billing.magic_link_records(created_at);
+
+-- Add comments for documentation
+COMMENT ON TABLE billing.magic_link_records IS 'Single-use magic links keyed by link ID, including consumption state';
+
+-- Create trigger to automatically update updated_at
+CREATE TRIGGER update_magic_link_records_updated_at 
+    BEFORE UPDATE ON billing.magic_link_records 
+    FOR EACH ROW 
+    EXECUTE FUNCTION billing.update_updated_at_column();
@@ -0,0 +1,8 @@
+-- Drop trigger first
+DROP TRIGGER IF EXISTS update_usage_records_updated_at ON billing.usage_records;
+
+-- Drop indexes
+DROP INDEX IF EXISTS billing.idx_usage_records_created_at;
+
+-- Drop table
+DROP TABLE IF EXISTS billing.usage_records;
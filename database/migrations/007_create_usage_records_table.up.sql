@@ -0,0 +1,21 @@
+-- Create storage collection for metered usage records
+-- The table shares the storage_records shape used by the PostgreSQL storage abstraction
+
+CREATE TABLE billing.usage_records (
+    key VARCHAR(255) PRIMARY KEY,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for better query performance (period aggregation)
+CREATE INDEX idx_usage_records_created_at ON billing.usage_records(created_at);
+
+-- Add comments for documentation
+COMMENT ON TABLE billing.usage_records IS 'Metered usage events keyed by subscription ID and idempotency key';
+
+-- Create trigger to automatically update updated_at
+CREATE TRIGGER update_usage_records_updated_at 
+    BEFORE UPDATE ON billing.usage_records 
+    FOR EACH ROW 
+    EXECUTE FUNCTION billing.update_updated_at_column();
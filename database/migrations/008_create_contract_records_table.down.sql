@@ -0,0 +1,8 @@
+-- Drop trigger first
+DROP TRIGGER IF EXISTS update_contract_records_updated_at ON billing.contract_records;
+
+-- Drop indexes
+DROP INDEX IF EXISTS billing.idx_contract_records_created_at;
+
+-- Drop table
+DROP TABLE IF EXISTS billing.contract_records;
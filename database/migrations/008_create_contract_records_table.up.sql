@@ -0,0 +1,21 @@
+-- Create storage collection for customer contracts
+-- The table shares the storage_records shape used by the PostgreSQL storage abstraction
+
+CREATE TABLE billing.contract_records (
+    key VARCHAR(255) PRIMARY KEY,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for better query performance (contract listing)
+CREATE INDEX idx_contract_records_created_at ON billing.contract_records(created_at);
+
+-- Add comments for documentation
+COMMENT ON TABLE billing.contract_records IS 'Customer contracts with committed spend, linked subscriptions and invoices';
+
+-- Create trigger to automatically update updated_at
+CREATE TRIGGER update_contract_records_updated_at 
+    BEFORE UPDATE ON billing.contract_records 
+    FOR EACH ROW 
+    EXECUTE FUNCTION billing.update_updated_at_column();
@@ -0,0 +1,8 @@
+-- Drop trigger first
+DROP TRIGGER IF EXISTS update_approval_request_records_updated_at ON billing.approval_request_records;
+
+-- Drop indexes
+DROP INDEX IF EXISTS billing.idx_approval_request_records_created_at;
+
+-- Drop table
+DROP TABLE IF EXISTS billing.approval_request_records;
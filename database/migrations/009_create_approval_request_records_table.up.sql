@@ -0,0 +1,21 @@
+-- Create storage collection for credit note and refund approval requests
+-- The table shares the storage_records shape used by the PostgreSQL storage abstraction
+
+CREATE TABLE billing.approval_request_records (
+    key VARCHAR(255) PRIMARY KEY,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for better query performance (approval queue listing)
+CREATE INDEX idx_approval_request_records_created_at ON billing.approval_request_records(created_at);
+
+-- Add comments for documentation
+COMMENT ON TABLE billing.approval_request_records IS 'Credit note and refund approval requests (two-step approval above a threshold)';
+
+-- Create trigger to automatically update updated_at
+CREATE TRIGGER update_approval_request_records_updated_at 
+    BEFORE UPDATE ON billing.approval_request_records 
+    FOR EACH ROW 
+    EXECUTE FUNCTION billing.update_updated_at_column();
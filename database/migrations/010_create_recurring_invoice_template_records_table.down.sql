@@ -0,0 +1,8 @@
+-- Drop trigger first
+DROP TRIGGER IF EXISTS update_recurring_invoice_template_records_updated_at ON billing.recurring_invoice_template_records;
+
+-- Drop indexes
+DROP INDEX IF EXISTS billing.idx_recurring_invoice_template_records_created_at;
+
+-- Drop table
+DROP TABLE IF EXISTS billing.recurring_invoice_template_records;
@@ -0,0 +1,21 @@
+-- Create storage collection for invoice delivery events
+-- The table shares the storage_records shape used by the PostgreSQL storage abstraction
+
+CREATE TABLE billing.invoice_delivery_event_records (
+    key VARCHAR(255) PRIMARY KEY,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for better query performance (delivery history)
+CREATE INDEX idx_invoice_delivery_event_records_created_at ON billing.invoice_delivery_event_records(created_at);
+
+-- Add comments for documentation
+COMMENT ON TABLE billing.invoice_delivery_event_records IS 'Invoice delivery events (sent, delivered, viewed, bounced)';
+
+-- Create trigger to automatically update updated_at
+CREATE TRIGGER update_invoice_delivery_event_records_updated_at 
+    BEFORE UPDATE ON billing.invoice_delivery_event_records 
+    FOR EACH ROW 
+    EXECUTE FUNCTION billing.update_updated_at_column();
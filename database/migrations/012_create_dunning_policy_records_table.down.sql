@@ -0,0 +1,8 @@
+-- Drop trigger first
+DROP TRIGGER IF EXISTS update_dunning_policy_records_updated_at ON billing.dunning_policy_records;
+
+-- Drop indexes
+DROP INDEX IF EXISTS billing.idx_dunning_policy_records_created_at;
+
+-- Drop table
+DROP TABLE IF EXISTS billing.dunning_policy_records;
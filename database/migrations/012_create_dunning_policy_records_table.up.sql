@@ -0,0 +1,21 @@
+-- Create storage collection for tenant dunning policies
+-- The table shares the storage_records shape used by the PostgreSQL storage abstraction
+
+CREATE TABLE billing.dunning_policy_records (
+    key VARCHAR(255) PRIMARY KEY,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for better query performance (policy listing)
+CREATE INDEX idx_dunning_policy_records_created_at ON billing.dunning_policy_records(created_at);
+
+-- Add comments for documentation
+COMMENT ON TABLE billing.dunning_policy_records IS 'Tenant payment reminder cadences (dunning policies)';
+
+-- Create trigger to automatically update updated_at
+CREATE TRIGGER update_dunning_policy_records_updated_at 
+    BEFORE UPDATE ON billing.dunning_policy_records 
+    FOR EACH ROW 
+    EXECUTE FUNCTION billing.update_updated_at_column();
@@ -0,0 +1,8 @@
+-- Drop trigger first
+DROP TRIGGER IF EXISTS update_bank_transaction_records_updated_at ON billing.bank_transaction_records;
+
+-- Drop indexes
+DROP INDEX IF EXISTS billing.idx_bank_transaction_records_created_at;
+
+-- Drop table
+DROP TABLE IF EXISTS billing.bank_transaction_records;
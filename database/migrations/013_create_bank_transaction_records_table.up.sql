@@ -0,0 +1,21 @@
+-- Create storage collection for bank transactions imported from statements
+-- The table shares the storage_records shape used by the PostgreSQL storage abstraction
+
+CREATE TABLE billing.bank_transaction_records (
+    key VARCHAR(255) PRIMARY KEY,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for better query performance (reconciliation queue)
+CREATE INDEX idx_bank_transaction_records_created_at ON billing.bank_transaction_records(created_at);
+
+-- Add comments for documentation
+COMMENT ON TABLE billing.bank_transaction_records IS 'Bank transactions imported from statements for cash application';
+
+-- Create trigger to automatically update updated_at
+CREATE TRIGGER update_bank_transaction_records_updated_at 
+    BEFORE UPDATE ON billing.bank_transaction_records 
+    FOR EACH ROW 
+    EXECUTE FUNCTION billing.update_updated_at_column();
@@ -0,0 +1,8 @@
+-- Drop trigger first
+DROP TRIGGER IF EXISTS update_payout_reconciliation_records_updated_at ON billing.payout_reconciliation_records;
+
+-- Drop indexes
+DROP INDEX IF EXISTS billing.idx_payout_reconciliation_records_created_at;
+
+-- Drop table
+DROP TABLE IF EXISTS billing.payout_reconciliation_records;
@@ -0,0 +1,21 @@
+-- Create storage collection for gateway payout discrepancy reports
+-- The table shares the storage_records shape used by the PostgreSQL storage abstraction
+
+CREATE TABLE billing.payout_reconciliation_records (
+    key VARCHAR(255) PRIMARY KEY,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for better query performance (report listing)
+CREATE INDEX idx_payout_reconciliation_records_created_at ON billing.payout_reconciliation_records(created_at);
+
+-- Add comments for documentation
+COMMENT ON TABLE billing.payout_reconciliation_records IS 'Discrepancy reports between gateway payouts and the ledger';
+
+-- Create trigger to automatically update updated_at
+CREATE TRIGGER update_payout_reconciliation_records_updated_at 
+    BEFORE UPDATE ON billing.payout_reconciliation_records 
+    FOR EACH ROW 
+    EXECUTE FUNCTION billing.update_updated_at_column();
@@ -0,0 +1,8 @@
+-- Drop trigger first
+DROP TRIGGER IF EXISTS update_legal_entity_records_updated_at ON billing.legal_entity_records;
+
+-- Drop indexes
+DROP INDEX IF EXISTS billing.idx_legal_entity_records_created_at;
+
+-- Drop table
+DROP TABLE IF EXISTS billing.legal_entity_records;
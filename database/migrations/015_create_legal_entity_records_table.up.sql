@@ -0,0 +1,21 @@
+-- Create storage collection for the legal entities invoices are issued from
+-- The table shares the storage_records shape used by the PostgreSQL storage abstraction
+
+CREATE TABLE billing.legal_entity_records (
+    key VARCHAR(255) PRIMARY KEY,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for better query performance (entity listing)
+CREATE INDEX idx_legal_entity_records_created_at ON billing.legal_entity_records(created_at);
+
+-- Add comments for documentation
+COMMENT ON TABLE billing.legal_entity_records IS 'Legal entities with their invoice numbering sequences, bank details and tax registrations';
+
+-- Create trigger to automatically update updated_at
+CREATE TRIGGER update_legal_entity_records_updated_at 
+    BEFORE UPDATE ON billing.legal_entity_records 
+    FOR EACH ROW 
+    EXECUTE FUNCTION billing.update_updated_at_column();
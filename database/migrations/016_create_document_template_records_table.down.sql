@@ -0,0 +1,8 @@
+-- Drop trigger first
+DROP TRIGGER IF EXISTS update_document_template_records_updated_at ON billing.document_template_records;
+
+-- Drop indexes
+DROP INDEX IF EXISTS billing.idx_document_template_records_created_at;
+
+-- Drop table
+DROP TABLE IF EXISTS billing.document_template_records;
@@ -0,0 +1,21 @@
+-- Create storage collection for tenant invoice document templates
+-- The table shares the storage_records shape used by the PostgreSQL storage abstraction
+
+CREATE TABLE billing.document_template_records (
+    key VARCHAR(255) PRIMARY KEY,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for better query performance (template listing)
+CREATE INDEX idx_document_template_records_created_at ON billing.document_template_records(created_at);
+
+-- Add comments for documentation
+COMMENT ON TABLE billing.document_template_records IS 'Tenant invoice layouts (logo, colors, column visibility, footer) consumed by the document renderers';
+
+-- Create trigger to automatically update updated_at
+CREATE TRIGGER update_document_template_records_updated_at 
+    BEFORE UPDATE ON billing.document_template_records 
+    FOR EACH ROW 
+    EXECUTE FUNCTION billing.update_updated_at_column();
@@ -0,0 +1,8 @@
+-- Drop trigger first
+DROP TRIGGER IF EXISTS update_client_credit_limit_records_updated_at ON billing.client_credit_limit_records;
+
+-- Drop indexes
+DROP INDEX IF EXISTS billing.idx_client_credit_limit_records_created_at;
+
+-- Drop table
+DROP TABLE IF EXISTS billing.client_credit_limit_records;
@@ -0,0 +1,8 @@
+-- Drop trigger first
+DROP TRIGGER IF EXISTS update_risk_assessment_records_updated_at ON billing.risk_assessment_records;
+
+-- Drop indexes
+DROP INDEX IF EXISTS billing.idx_risk_assessment_records_created_at;
+
+-- Drop table
+DROP TABLE IF EXISTS billing.risk_assessment_records;
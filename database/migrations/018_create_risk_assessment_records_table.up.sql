@@ -0,0 +1,21 @@
+-- Create storage collection for client risk assessments
+-- The table shares the storage_records shape used by the PostgreSQL storage abstraction
+
+CREATE TABLE billing.risk_assessment_records (
+    key VARCHAR(255) PRIMARY KEY,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for better query performance (assessment listing)
+CREATE INDEX idx_risk_assessment_records_created_at ON billing.risk_assessment_records(created_at);
+
+-- Add comments for documentation
+COMMENT ON TABLE billing.risk_assessment_records IS 'Latest risk score of each client, cached until it expires';
+
+-- Create trigger to automatically update updated_at
+CREATE TRIGGER update_risk_assessment_records_updated_at 
+    BEFORE UPDATE ON billing.risk_assessment_records 
+    FOR EACH ROW 
+    EXECUTE FUNCTION billing.update_updated_at_column();
@@ -0,0 +1,8 @@
+-- Drop trigger first
+DROP TRIGGER IF EXISTS update_webhook_event_records_updated_at ON billing.webhook_event_records;
+
+-- Drop indexes
+DROP INDEX IF EXISTS billing.idx_webhook_event_records_created_at;
+
+-- Drop table
+DROP TABLE IF EXISTS billing.webhook_event_records;
@@ -0,0 +1,21 @@
+-- Create storage collection for inbound webhook events
+-- The table shares the storage_records shape used by the PostgreSQL storage abstraction
+
+CREATE TABLE billing.webhook_event_records (
+    key VARCHAR(255) PRIMARY KEY,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for better query performance (queue and dead letter listing)
+CREATE INDEX idx_webhook_event_records_created_at ON billing.webhook_event_records(created_at);
+
+-- Add comments for documentation
+COMMENT ON TABLE billing.webhook_event_records IS 'Inbound webhook events keyed by provider event, with their processing state';
+
+-- Create trigger to automatically update updated_at
+CREATE TRIGGER update_webhook_event_records_updated_at 
+    BEFORE UPDATE ON billing.webhook_event_records 
+    FOR EACH ROW 
+    EXECUTE FUNCTION billing.update_updated_at_column();
@@ -0,0 +1,8 @@
+-- Drop trigger first
+DROP TRIGGER IF EXISTS update_failed_message_records_updated_at ON billing.failed_message_records;
+
+-- Drop indexes
+DROP INDEX IF EXISTS billing.idx_failed_message_records_created_at;
+
+-- Drop table
+DROP TABLE IF EXISTS billing.failed_message_records;
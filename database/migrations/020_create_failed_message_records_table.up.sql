@@ -0,0 +1,21 @@
+-- Create storage collection for message bus publications that failed
+-- The table shares the storage_records shape used by the PostgreSQL storage abstraction
+
+CREATE TABLE billing.failed_message_records (
+    key VARCHAR(255) PRIMARY KEY,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for better query performance (dead letter listing)
+CREATE INDEX idx_failed_message_records_created_at ON billing.failed_message_records(created_at);
+
+-- Add comments for documentation
+COMMENT ON TABLE billing.failed_message_records IS 'Integration events the message bus did not accept, parked in the dead letter queue';
+
+-- Create trigger to automatically update updated_at
+CREATE TRIGGER update_failed_message_records_updated_at 
+    BEFORE UPDATE ON billing.failed_message_records 
+    FOR EACH ROW 
+    EXECUTE FUNCTION billing.update_updated_at_column();
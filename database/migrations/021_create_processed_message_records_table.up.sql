@@ -0,0 +1,21 @@
+-- Create storage collection for the records of processed messages (idempotent consumers)
+-- The table shares the storage_records shape used by the PostgreSQL storage abstraction
+
+CREATE TABLE billing.processed_message_records (
+    key VARCHAR(255) PRIMARY KEY,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for better query performance (expired record cleanup)
+CREATE INDEX idx_processed_message_records_created_at ON billing.processed_message_records(created_at);
+
+-- Add comments for documentation
+COMMENT ON TABLE billing.processed_message_records IS 'Messages already processed, so redeliveries are skipped until the record expires';
+
+-- Create trigger to automatically update updated_at
+CREATE TRIGGER update_processed_message_records_updated_at 
+    BEFORE UPDATE ON billing.processed_message_records 
+    FOR EACH ROW 
+    EXECUTE FUNCTION billing.update_updated_at_column();
@@ -0,0 +1,8 @@
+-- Drop trigger first
+DROP TRIGGER IF EXISTS update_saga_records_updated_at ON billing.saga_records;
+
+-- Drop indexes
+DROP INDEX IF EXISTS billing.idx_saga_records_updated_at;
+
+-- Drop table
+DROP TABLE IF EXISTS billing.saga_records;
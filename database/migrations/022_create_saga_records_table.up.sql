@@ -0,0 +1,21 @@
+-- Create storage collection for sagas (multi-step flows with compensation, e.g. invoice payments)
+-- The table shares the storage_records shape used by the PostgreSQL storage abstraction
+
+CREATE TABLE billing.saga_records (
+    key VARCHAR(255) PRIMARY KEY,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for better query performance (stuck saga listing)
+CREATE INDEX idx_saga_records_updated_at ON billing.saga_records(updated_at);
+
+-- Add comments for documentation
+COMMENT ON TABLE billing.saga_records IS 'Saga state persisted after every step so sagas can be resumed and compensated';
+
+-- Create trigger to automatically update updated_at
+CREATE TRIGGER update_saga_records_updated_at 
+    BEFORE UPDATE ON billing.saga_records 
+    FOR EACH ROW 
+    EXECUTE FUNCTION billing.update_updated_at_column();
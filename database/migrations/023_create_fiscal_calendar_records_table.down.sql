@@ -0,0 +1,8 @@
+-- Drop trigger first
+DROP TRIGGER IF EXISTS update_fiscal_calendar_records_updated_at ON billing.fiscal_calendar_records;
+
+-- Drop indexes
+DROP INDEX IF EXISTS billing.idx_fiscal_calendar_records_created_at;
+
+-- Drop table
+DROP TABLE IF EXISTS billing.fiscal_calendar_records;
@@ -0,0 +1,21 @@
+-- Create storage collection for tenant fiscal calendars
+-- The table shares the storage_records shape used by the PostgreSQL storage abstraction
+
+CREATE TABLE billing.fiscal_calendar_records (
+    key VARCHAR(255) PRIMARY KEY,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for better query performance (calendar listing)
+CREATE INDEX idx_fiscal_calendar_records_created_at ON billing.fiscal_calendar_records(created_at);
+
+-- Add comments for documentation
+COMMENT ON TABLE billing.fiscal_calendar_records IS 'Tenant fiscal years, accounting periods and period locks';
+
+-- Create trigger to automatically update updated_at
+CREATE TRIGGER update_fiscal_calendar_records_updated_at 
+    BEFORE UPDATE ON billing.fiscal_calendar_records 
+    FOR EACH ROW 
+    EXECUTE FUNCTION billing.update_updated_at_column();
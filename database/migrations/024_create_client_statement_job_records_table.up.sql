@@ -0,0 +1,21 @@
+-- Create storage collection for client statement requests
+-- The table shares the storage_records shape used by the PostgreSQL storage abstraction
+
+CREATE TABLE billing.client_statement_job_records (
+    key VARCHAR(255) PRIMARY KEY,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for better query performance (pending statement scan)
+CREATE INDEX idx_client_statement_job_records_created_at ON billing.client_statement_job_records(created_at);
+
+-- Add comments for documentation
+COMMENT ON TABLE billing.client_statement_job_records IS 'Client statement requests, their status and generated documents';
+
+-- Create trigger to automatically update updated_at
+CREATE TRIGGER update_client_statement_job_records_updated_at 
+    BEFORE UPDATE ON billing.client_statement_job_records 
+    FOR EACH ROW 
+    EXECUTE FUNCTION billing.update_updated_at_column();
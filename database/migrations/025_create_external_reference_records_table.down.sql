@@ -0,0 +1,8 @@
+-- Drop trigger first
+DROP TRIGGER IF EXISTS update_external_reference_records_updated_at ON billing.external_reference_records;
+
+-- Drop indexes
+DROP INDEX IF EXISTS billing.idx_external_reference_records_created_at;
+
+-- Drop table
+DROP TABLE IF EXISTS billing.external_reference_records;
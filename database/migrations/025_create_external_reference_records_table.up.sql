@@ -0,0 +1,22 @@
+-- Create storage collection for the per-tenant index of client and invoice external references
+-- The table shares the storage_records shape used by the PostgreSQL storage abstraction;
+-- keys are "<kind>|<tenant>|<reference>", so the primary key is the per-tenant unique index
+
+CREATE TABLE billing.external_reference_records (
+    key VARCHAR(255) PRIMARY KEY,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for better query performance
+CREATE INDEX idx_external_reference_records_created_at ON billing.external_reference_records(created_at);
+
+-- Add comments for documentation
+COMMENT ON TABLE billing.external_reference_records IS 'External references of clients and invoices, keyed by kind, tenant and reference (unique per tenant)';
+
+-- Create trigger to automatically update updated_at
+CREATE TRIGGER update_external_reference_records_updated_at 
+    BEFORE UPDATE ON billing.external_reference_records 
+    FOR EACH ROW 
+    EXECUTE FUNCTION billing.update_updated_at_column();
@@ -0,0 +1,10 @@
+-- Drop trigger and function first
+DROP TRIGGER IF EXISTS reject_event_store_records_changes ON billing.event_store_records;
+DROP FUNCTION IF EXISTS billing.reject_event_store_changes();
+
+-- Drop indexes
+DROP INDEX IF EXISTS billing.idx_event_store_records_created_at;
+
+-- Drop sequence and table
+DROP SEQUENCE IF EXISTS billing.event_store_records_seq;
+DROP TABLE IF EXISTS billing.event_store_records;
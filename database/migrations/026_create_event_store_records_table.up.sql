@@ -0,0 +1,32 @@
+-- Create storage collection for the event store (append-only log of published domain events)
+-- The table shares the storage_records shape used by the PostgreSQL storage abstraction
+
+CREATE TABLE billing.event_store_records (
+    key VARCHAR(255) PRIMARY KEY,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Monotonic event sequence allocated by the storage layer (<table>_seq convention)
+CREATE SEQUENCE billing.event_store_records_seq;
+
+-- Create indexes for better query performance
+CREATE INDEX idx_event_store_records_created_at ON billing.event_store_records(created_at);
+
+-- Add comments for documentation
+COMMENT ON TABLE billing.event_store_records IS 'Append-only event store keyed by zero-padded event sequence';
+COMMENT ON SEQUENCE billing.event_store_records_seq IS 'Monotonic sequence of stored events';
+
+-- Reject updates so stored events stay as published (the repository never deletes either)
+CREATE OR REPLACE FUNCTION billing.reject_event_store_changes()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'event store records are append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER reject_event_store_records_changes
+    BEFORE UPDATE ON billing.event_store_records
+    FOR EACH ROW
+    EXECUTE FUNCTION billing.reject_event_store_changes();
@@ -0,0 +1,8 @@
+-- Drop trigger first
+DROP TRIGGER IF EXISTS update_invoice_records_updated_at ON billing.invoice_records;
+
+-- Drop indexes
+DROP INDEX IF EXISTS billing.idx_invoice_records_created_at;
+
+-- Drop table
+DROP TABLE IF EXISTS billing.invoice_records;
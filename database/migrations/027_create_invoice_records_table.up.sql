@@ -0,0 +1,21 @@
+-- Create storage collection for invoices
+-- The table shares the storage_records shape used by the PostgreSQL storage abstraction
+
+CREATE TABLE billing.invoice_records (
+    key VARCHAR(255) PRIMARY KEY,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for better query performance (invoice listing)
+CREATE INDEX idx_invoice_records_created_at ON billing.invoice_records(created_at);
+
+-- Add comments for documentation
+COMMENT ON TABLE billing.invoice_records IS 'Invoices (line items, status lifecycle from draft to paid or void)';
+
+-- Create trigger to automatically update updated_at
+CREATE TRIGGER update_invoice_records_updated_at 
+    BEFORE UPDATE ON billing.invoice_records 
+    FOR EACH ROW 
+    EXECUTE FUNCTION billing.update_updated_at_column();
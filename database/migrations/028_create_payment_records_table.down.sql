@@ -0,0 +1,8 @@
+-- Drop trigger first
+DROP TRIGGER IF EXISTS update_payment_records_updated_at ON billing.payment_records;
+
+-- Drop indexes
+DROP INDEX IF EXISTS billing.idx_payment_records_created_at;
+
+-- Drop table
+DROP TABLE IF EXISTS billing.payment_records;
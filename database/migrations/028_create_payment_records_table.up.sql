@@ -0,0 +1,21 @@
+-- Create storage collection for invoice payments
+-- The table shares the storage_records shape used by the PostgreSQL storage abstraction
+
+CREATE TABLE billing.payment_records (
+    key VARCHAR(255) PRIMARY KEY,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for better query performance (payment listing)
+CREATE INDEX idx_payment_records_created_at ON billing.payment_records(created_at);
+
+-- Add comments for documentation
+COMMENT ON TABLE billing.payment_records IS 'Payments received against invoices (partial payments until the balance is zero)';
+
+-- Create trigger to automatically update updated_at
+CREATE TRIGGER update_payment_records_updated_at 
+    BEFORE UPDATE ON billing.payment_records 
+    FOR EACH ROW 
+    EXECUTE FUNCTION billing.update_updated_at_column();
@@ -0,0 +1,22 @@
+-- Create storage collection for the client list read model
+-- The table shares the storage_records shape used by the PostgreSQL storage abstraction
+-- Rows are recomputed by the application when a client or one of its invoices changes
+
+CREATE TABLE billing.client_summary_records (
+    key VARCHAR(255) PRIMARY KEY,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for better query performance (client list pages)
+CREATE INDEX idx_client_summary_records_created_at ON billing.client_summary_records(created_at);
+
+-- Add comments for documentation
+COMMENT ON TABLE billing.client_summary_records IS 'Client list read model: balances and last invoice per client, rebuilt from clients and invoices';
+
+-- Create trigger to automatically update updated_at
+CREATE TRIGGER update_client_summary_records_updated_at 
+    BEFORE UPDATE ON billing.client_summary_records 
+    FOR EACH ROW 
+    EXECUTE FUNCTION billing.update_updated_at_column();
@@ -0,0 +1,47 @@
+-- Move the partitioned collections back to plain tables
+
+-- usage_records
+ALTER TABLE billing.usage_records RENAME TO usage_records_partitioned;
+
+CREATE TABLE billing.usage_records (
+    key VARCHAR(255) PRIMARY KEY,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO billing.usage_records (key, value, created_at, updated_at)
+SELECT key, value, created_at, updated_at FROM billing.usage_records_partitioned;
+DROP TABLE billing.usage_records_partitioned;
+
+CREATE INDEX idx_usage_records_created_at ON billing.usage_records(created_at);
+COMMENT ON TABLE billing.usage_records IS 'Metered usage events keyed by subscription ID and idempotency key';
+
+CREATE TRIGGER update_usage_records_updated_at 
+    BEFORE UPDATE ON billing.usage_records 
+    FOR EACH ROW 
+    EXECUTE FUNCTION billing.update_updated_at_column();
+
+-- audit_log_records
+ALTER TABLE billing.audit_log_records RENAME TO audit_log_records_partitioned;
+
+CREATE TABLE billing.audit_log_records (
+    key VARCHAR(255) PRIMARY KEY,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO billing.audit_log_records (key, value, created_at, updated_at)
+SELECT key, value, created_at, updated_at FROM billing.audit_log_records_partitioned;
+DROP TABLE billing.audit_log_records_partitioned;
+
+CREATE INDEX idx_audit_log_records_created_at ON billing.audit_log_records(created_at);
+COMMENT ON TABLE billing.audit_log_records IS 'Append-only audit log of administrative changes';
+
+CREATE TRIGGER update_audit_log_records_updated_at 
+    BEFORE UPDATE ON billing.audit_log_records 
+    FOR EACH ROW 
+    EXECUTE FUNCTION billing.update_updated_at_column();
+
+DROP FUNCTION IF EXISTS billing.ensure_monthly_partitions(REGCLASS, DATE, DATE);
@@ -0,0 +1,113 @@
+-- Partition the high-volume collections by month of creation
+-- usage_records and audit_log_records only grow; monthly range partitions on created_at keep indexes small,
+-- let time-bounded queries skip older months and let old months be detached or dropped as a whole
+-- Partitions are named <table>_pYYYY_MM and created ahead of time by POST /api/v1/admin/partitions/run
+-- Rows outside every partition land in <table>_default, so an insert never fails when the job is late
+
+-- Creates the monthly partitions of a table partitioned by created_at, from the month of from_month through
+-- the month of through_month, and returns the names of the partitions it created (existing ones are left untouched)
+-- SECURITY DEFINER: the application user (DML only) runs the maintenance job, partitions are created as the table owner
+CREATE OR REPLACE FUNCTION billing.ensure_monthly_partitions(parent REGCLASS, from_month DATE, through_month DATE)
+RETURNS SETOF TEXT
+LANGUAGE plpgsql
+SECURITY DEFINER
+SET search_path = pg_catalog, pg_temp
+AS $$
+DECLARE
+    parent_schema TEXT;
+    parent_name TEXT;
+    month_start DATE := date_trunc('month', from_month)::DATE;
+    partition_name TEXT;
+BEGIN
+    SELECT n.nspname, c.relname INTO parent_schema, parent_name
+    FROM pg_class c JOIN pg_namespace n ON n.oid = c.relnamespace
+    WHERE c.oid = parent AND c.relkind = 'p';
+    IF parent_name IS NULL THEN
+        RAISE EXCEPTION '% is not a partitioned table', parent;
+    END IF;
+
+    WHILE month_start <= through_month LOOP
+        partition_name := format('%s_p%s', parent_name, to_char(month_start, 'YYYY_MM'));
+        IF to_regclass(format('%I.%I', parent_schema, partition_name)) IS NULL THEN
+            EXECUTE format(
+                'CREATE TABLE %I.%I PARTITION OF %s FOR VALUES FROM (%L) TO (%L)',
+                parent_schema, partition_name, parent, month_start, (month_start + INTERVAL '1 month')::DATE
+            );
+            RETURN NEXT partition_name;
+        END IF;
+        month_start := (month_start + INTERVAL '1 month')::DATE;
+    END LOOP;
+END;
+$$;
+
+-- usage_records
+ALTER TABLE billing.usage_records RENAME TO usage_records_unpartitioned;
+DROP TRIGGER update_usage_records_updated_at ON billing.usage_records_unpartitioned;
+DROP INDEX billing.idx_usage_records_created_at;
+
+-- The partition key must be part of the primary key; keys stay unique because the storage
+-- updates an existing key in place (its created_at, hence its partition, never changes)
+CREATE TABLE billing.usage_records (
+    key VARCHAR(255) NOT NULL,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (key, created_at)
+) PARTITION BY RANGE (created_at);
+
+CREATE TABLE billing.usage_records_default PARTITION OF billing.usage_records DEFAULT;
+CREATE INDEX idx_usage_records_created_at ON billing.usage_records(created_at);
+CREATE INDEX idx_usage_records_key ON billing.usage_records(key);
+
+-- Partitions for the existing rows and the next three months
+SELECT billing.ensure_monthly_partitions(
+    'billing.usage_records',
+    COALESCE((SELECT MIN(created_at) FROM billing.usage_records_unpartitioned), NOW())::DATE,
+    (NOW() + INTERVAL '3 months')::DATE
+);
+
+INSERT INTO billing.usage_records (key, value, created_at, updated_at)
+SELECT key, value, created_at, updated_at FROM billing.usage_records_unpartitioned;
+DROP TABLE billing.usage_records_unpartitioned;
+
+COMMENT ON TABLE billing.usage_records IS 'Metered usage events keyed by subscription ID and idempotency key (partitioned by month of creation)';
+
+CREATE TRIGGER update_usage_records_updated_at 
+    BEFORE UPDATE ON billing.usage_records 
+    FOR EACH ROW 
+    EXECUTE FUNCTION billing.update_updated_at_column();
+
+-- audit_log_records
+ALTER TABLE billing.audit_log_records RENAME TO audit_log_records_unpartitioned;
+DROP TRIGGER update_audit_log_records_updated_at ON billing.audit_log_records_unpartitioned;
+DROP INDEX billing.idx_audit_log_records_created_at;
+
+CREATE TABLE billing.audit_log_records (
+    key VARCHAR(255) NOT NULL,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (key, created_at)
+) PARTITION BY RANGE (created_at);
+
+CREATE TABLE billing.audit_log_records_default PARTITION OF billing.audit_log_records DEFAULT;
+CREATE INDEX idx_audit_log_records_created_at ON billing.audit_log_records(created_at);
+CREATE INDEX idx_audit_log_records_key ON billing.audit_log_records(key);
+
+-- Partitions for the existing rows and the next three months
+SELECT billing.ensure_monthly_partitions(
+    'billing.audit_log_records',
+    COALESCE((SELECT MIN(created_at) FROM billing.audit_log_records_unpartitioned), NOW())::DATE,
+    (NOW() + INTERVAL '3 months')::DATE
+);
+
+INSERT INTO billing.audit_log_records (key, value, created_at, updated_at)
+SELECT key, value, created_at, updated_at FROM billing.audit_log_records_unpartitioned;
+DROP TABLE billing.audit_log_records_unpartitioned;
+
+COMMENT ON TABLE billing.audit_log_records IS 'Append-only audit log of administrative changes (partitioned by month of creation)';
+
+CREATE TRIGGER update_audit_log_records_updated_at 
+    BEFORE UPDATE ON billing.audit_log_records 
+    FOR EACH ROW 
+    EXECUTE FUNCTION billing.update_updated_at_column();
@@ -0,0 +1,23 @@
+-- Create storage collection for the archive of closed invoices (cold storage)
+-- The table shares the storage_records shape used by the PostgreSQL storage abstraction
+-- Each row holds a paid or void invoice with its payments, moved out of invoice_records and payment_records by
+-- POST /api/v1/admin/invoice-archive/run and moved back by POST /api/v1/admin/invoice-archive/{id}/rehydrate
+
+CREATE TABLE billing.invoice_archive_records (
+    key VARCHAR(255) PRIMARY KEY,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for better query performance (retention reviews by archive date)
+CREATE INDEX idx_invoice_archive_records_created_at ON billing.invoice_archive_records(created_at);
+
+-- Add comments for documentation
+COMMENT ON TABLE billing.invoice_archive_records IS 'Archive of closed invoices with their payments, kept for retention obligations out of the hot tables';
+
+-- Create trigger to automatically update updated_at
+CREATE TRIGGER update_invoice_archive_records_updated_at 
+    BEFORE UPDATE ON billing.invoice_archive_records 
+    FOR EACH ROW 
+    EXECUTE FUNCTION billing.update_updated_at_column();
@@ -0,0 +1,8 @@
+-- Drop trigger first
+DROP TRIGGER IF EXISTS update_subscription_records_updated_at ON billing.subscription_records;
+
+-- Drop indexes
+DROP INDEX IF EXISTS billing.idx_subscription_records_created_at;
+
+-- Drop table
+DROP TABLE IF EXISTS billing.subscription_records;
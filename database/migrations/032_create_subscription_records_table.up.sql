@@ -0,0 +1,22 @@
+-- Create storage collection for subscriptions (recurring billing of a plan)
+-- The table shares the storage_records shape used by the PostgreSQL storage abstraction
+-- POST /api/v1/admin/subscriptions/run bills every subscription whose next billing date has come
+
+CREATE TABLE billing.subscription_records (
+    key VARCHAR(255) PRIMARY KEY,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for better query performance
+CREATE INDEX idx_subscription_records_created_at ON billing.subscription_records(created_at);
+
+-- Add comments for documentation
+COMMENT ON TABLE billing.subscription_records IS 'Subscriptions billing clients for a plan at the start of every interval';
+
+-- Create trigger to automatically update updated_at
+CREATE TRIGGER update_subscription_records_updated_at 
+    BEFORE UPDATE ON billing.subscription_records 
+    FOR EACH ROW 
+    EXECUTE FUNCTION billing.update_updated_at_column();
@@ -0,0 +1,8 @@
+-- Drop trigger first
+DROP TRIGGER IF EXISTS update_quote_records_updated_at ON billing.quote_records;
+
+-- Drop indexes
+DROP INDEX IF EXISTS billing.idx_quote_records_created_at;
+
+-- Drop table
+DROP TABLE IF EXISTS billing.quote_records;
@@ -0,0 +1,22 @@
+-- Create storage collection for quotes (estimates converted to invoices once accepted)
+-- The table shares the storage_records shape used by the PostgreSQL storage abstraction
+-- POST /api/v1/admin/quotes/run expires open quotes past their validity date
+
+CREATE TABLE billing.quote_records (
+    key VARCHAR(255) PRIMARY KEY,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for better query performance
+CREATE INDEX idx_quote_records_created_at ON billing.quote_records(created_at);
+
+-- Add comments for documentation
+COMMENT ON TABLE billing.quote_records IS 'Quotes offered to clients, accepted, rejected or expired, and the invoices accepted quotes became';
+
+-- Create trigger to automatically update updated_at
+CREATE TRIGGER update_quote_records_updated_at 
+    BEFORE UPDATE ON billing.quote_records 
+    FOR EACH ROW 
+    EXECUTE FUNCTION billing.update_updated_at_column();
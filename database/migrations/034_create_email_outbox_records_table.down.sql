@@ -0,0 +1,8 @@
+-- Drop trigger first
+DROP TRIGGER IF EXISTS update_email_outbox_records_updated_at ON billing.email_outbox_records;
+
+-- Drop indexes
+DROP INDEX IF EXISTS billing.idx_email_outbox_records_created_at;
+
+-- Drop table
+DROP TABLE IF EXISTS billing.email_outbox_records;
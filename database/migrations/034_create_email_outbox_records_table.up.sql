@@ -0,0 +1,22 @@
+-- Create storage collection for the outbox of notification emails
+-- The table shares the storage_records shape used by the PostgreSQL storage abstraction
+-- POST /api/v1/admin/email-outbox/run dispatches the pending emails that are due
+
+CREATE TABLE billing.email_outbox_records (
+    key VARCHAR(255) PRIMARY KEY,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for better query performance
+CREATE INDEX idx_email_outbox_records_created_at ON billing.email_outbox_records(created_at);
+
+-- Add comments for documentation
+COMMENT ON TABLE billing.email_outbox_records IS 'Notification emails persisted until the mailer accepts them, with their attempts and next retry';
+
+-- Create trigger to automatically update updated_at
+CREATE TRIGGER update_email_outbox_records_updated_at 
+    BEFORE UPDATE ON billing.email_outbox_records 
+    FOR EACH ROW 
+    EXECUTE FUNCTION billing.update_updated_at_column();
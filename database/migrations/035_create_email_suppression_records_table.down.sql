@@ -0,0 +1,8 @@
+-- Drop trigger first
+DROP TRIGGER IF EXISTS update_email_suppression_records_updated_at ON billing.email_suppression_records;
+
+-- Drop indexes
+DROP INDEX IF EXISTS billing.idx_email_suppression_records_created_at;
+
+-- Drop table
+DROP TABLE IF EXISTS billing.email_suppression_records;
@@ -0,0 +1,22 @@
+-- Create storage collection for the bounces of email addresses and the suppression list
+-- The table shares the storage_records shape used by the PostgreSQL storage abstraction
+-- Records are keyed by lowercased address
+
+CREATE TABLE billing.email_suppression_records (
+    key VARCHAR(255) PRIMARY KEY,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for better query performance
+CREATE INDEX idx_email_suppression_records_created_at ON billing.email_suppression_records(created_at);
+
+-- Add comments for documentation
+COMMENT ON TABLE billing.email_suppression_records IS 'Email addresses with bounces, and the ones suppressed by a hard bounce, repeated soft bounces or an admin';
+
+-- Create trigger to automatically update updated_at
+CREATE TRIGGER update_email_suppression_records_updated_at 
+    BEFORE UPDATE ON billing.email_suppression_records 
+    FOR EACH ROW 
+    EXECUTE FUNCTION billing.update_updated_at_column();
@@ -0,0 +1,2 @@
+-- Drop table
+DROP TABLE IF EXISTS billing.invoice_records_counters;
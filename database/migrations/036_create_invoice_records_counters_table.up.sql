@@ -0,0 +1,15 @@
+-- Create the gap-free invoice number counters, one row per year (<table>_counters convention of the storage layer)
+-- Issuing an invoice increments the row of its year in the transaction saving the invoice: the row stays locked
+-- until that transaction ends, so concurrent issues wait and a rolled back issue releases its number
+
+CREATE TABLE billing.invoice_records_counters (
+    scope VARCHAR(255) PRIMARY KEY,
+    value BIGINT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Add comments for documentation
+COMMENT ON TABLE billing.invoice_records_counters IS 'Last invoice number allocated per issue year, incremented under row lock';
+COMMENT ON COLUMN billing.invoice_records_counters.scope IS 'Issue year the invoice numbers belong to';
+COMMENT ON COLUMN billing.invoice_records_counters.value IS 'Last sequence number allocated in the year';
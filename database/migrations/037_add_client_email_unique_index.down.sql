@@ -0,0 +1,2 @@
+-- Drop indexes
+DROP INDEX IF EXISTS billing.idx_storage_records_client_email;
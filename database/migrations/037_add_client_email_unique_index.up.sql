@@ -0,0 +1,9 @@
+-- Enforce unique client email addresses
+-- Clients are stored in storage_records as JSON documents; the expression matches the client repository lookup
+-- (GetByEmail), so the index also serves it. Emails are normalized to lower case by the application
+-- The migration fails if duplicates already exist: merge or rename them before applying it
+
+CREATE UNIQUE INDEX idx_storage_records_client_email ON billing.storage_records ((value::jsonb #>> '{email,value}'));
+
+-- Add comments for documentation
+COMMENT ON INDEX billing.idx_storage_records_client_email IS 'One client per email address (normalized, lower case)';
@@ -0,0 +1,2 @@
+-- Drop indexes (the backfilled status is kept: clients without one are active anyway)
+DROP INDEX IF EXISTS billing.idx_storage_records_client_status;
@@ -0,0 +1,12 @@
+-- Add the client lifecycle status (active, suspended, closed)
+-- Clients stored before statuses existed are active; the backfill makes the index serve every status filter of the
+-- client list (ListByStatus). The expression matches the client repository lookup
+
+UPDATE billing.storage_records
+SET value = jsonb_set(value::jsonb, '{status}', '"active"')::text
+WHERE value::jsonb #>> '{status}' IS NULL;
+
+CREATE INDEX idx_storage_records_client_status ON billing.storage_records ((value::jsonb #>> '{status}'));
+
+-- Add comments for documentation
+COMMENT ON INDEX billing.idx_storage_records_client_status IS 'Clients by lifecycle status';
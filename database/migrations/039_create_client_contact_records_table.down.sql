@@ -0,0 +1,9 @@
+-- Drop trigger first
+DROP TRIGGER IF EXISTS update_client_contact_records_updated_at ON billing.client_contact_records;
+
+-- Drop indexes
+DROP INDEX IF EXISTS billing.idx_client_contact_records_client_id;
+DROP INDEX IF EXISTS billing.idx_client_contact_records_created_at;
+
+-- Drop table
+DROP TABLE IF EXISTS billing.client_contact_records;
@@ -0,0 +1,23 @@
+-- Create storage collection for client contacts
+-- The table shares the storage_records shape used by the PostgreSQL storage abstraction
+
+CREATE TABLE billing.client_contact_records (
+    key VARCHAR(255) PRIMARY KEY,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for better query performance (contacts of a client)
+-- The expression matches the client contact repository lookup
+CREATE INDEX idx_client_contact_records_created_at ON billing.client_contact_records(created_at);
+CREATE INDEX idx_client_contact_records_client_id ON billing.client_contact_records ((value::jsonb #>> '{clientId}'));
+
+-- Add comments for documentation
+COMMENT ON TABLE billing.client_contact_records IS 'Contacts of clients (name, role, email, phone)';
+
+-- Create trigger to automatically update updated_at
+CREATE TRIGGER update_client_contact_records_updated_at 
+    BEFORE UPDATE ON billing.client_contact_records 
+    FOR EACH ROW 
+    EXECUTE FUNCTION billing.update_updated_at_column();
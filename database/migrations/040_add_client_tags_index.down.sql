@@ -0,0 +1,2 @@
+-- Drop indexes (tags stay on the clients)
+DROP INDEX IF EXISTS billing.idx_storage_records_client_tags;
@@ -0,0 +1,8 @@
+-- Index client tags used to segment the client base (?tag= filter of the client list)
+-- Tags are an array of the client value; the GIN index serves the containment expression of the client repository
+-- lookup (ListByTag)
+
+CREATE INDEX idx_storage_records_client_tags ON billing.storage_records USING GIN ((value::jsonb #> '{tags}'));
+
+-- Add comments for documentation
+COMMENT ON INDEX billing.idx_storage_records_client_tags IS 'Clients by tag';
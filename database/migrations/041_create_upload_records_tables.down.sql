@@ -0,0 +1,10 @@
+-- Drop triggers first
+DROP TRIGGER IF EXISTS update_upload_chunk_records_updated_at ON billing.upload_chunk_records;
+DROP TRIGGER IF EXISTS update_upload_records_updated_at ON billing.upload_records;
+
+-- Drop indexes
+DROP INDEX IF EXISTS billing.idx_upload_records_created_at;
+
+-- Drop tables
+DROP TABLE IF EXISTS billing.upload_chunk_records;
+DROP TABLE IF EXISTS billing.upload_records;
@@ -0,0 +1,35 @@
+-- Create storage collections for resumable uploads and their chunks
+-- The tables share the storage_records shape used by the PostgreSQL storage abstraction
+
+CREATE TABLE billing.upload_records (
+    key VARCHAR(255) PRIMARY KEY,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Chunks are keyed by upload ID and chunk index, and deleted once the upload is processed
+CREATE TABLE billing.upload_chunk_records (
+    key VARCHAR(255) PRIMARY KEY,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for better query performance (pending upload scan)
+CREATE INDEX idx_upload_records_created_at ON billing.upload_records(created_at);
+
+-- Add comments for documentation
+COMMENT ON TABLE billing.upload_records IS 'Resumable uploads of large files, their progress and processing status';
+COMMENT ON TABLE billing.upload_chunk_records IS 'Chunks of resumable uploads, kept until the assembled file is processed';
+
+-- Create triggers to automatically update updated_at
+CREATE TRIGGER update_upload_records_updated_at 
+    BEFORE UPDATE ON billing.upload_records 
+    FOR EACH ROW 
+    EXECUTE FUNCTION billing.update_updated_at_column();
+
+CREATE TRIGGER update_upload_chunk_records_updated_at 
+    BEFORE UPDATE ON billing.upload_chunk_records 
+    FOR EACH ROW 
+    EXECUTE FUNCTION billing.update_updated_at_column();
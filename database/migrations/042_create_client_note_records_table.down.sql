@@ -0,0 +1,9 @@
+-- Drop trigger first
+DROP TRIGGER IF EXISTS update_client_note_records_updated_at ON billing.client_note_records;
+
+-- Drop indexes
+DROP INDEX IF EXISTS billing.idx_client_note_records_client_id;
+DROP INDEX IF EXISTS billing.idx_client_note_records_created_at;
+
+-- Drop table
+DROP TABLE IF EXISTS billing.client_note_records;
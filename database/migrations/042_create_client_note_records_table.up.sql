@@ -0,0 +1,23 @@
+-- Create storage collection for client notes
+-- The table shares the storage_records shape used by the PostgreSQL storage abstraction
+
+CREATE TABLE billing.client_note_records (
+    key VARCHAR(255) PRIMARY KEY,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for better query performance (activity timeline of a client)
+-- The expression matches the client note repository lookup
+CREATE INDEX idx_client_note_records_created_at ON billing.client_note_records(created_at);
+CREATE INDEX idx_client_note_records_client_id ON billing.client_note_records ((value::jsonb #>> '{clientId}'));
+
+-- Add comments for documentation
+COMMENT ON TABLE billing.client_note_records IS 'Notes recording interactions with clients (activity timeline)';
+
+-- Create trigger to automatically update updated_at
+CREATE TRIGGER update_client_note_records_updated_at 
+    BEFORE UPDATE ON billing.client_note_records 
+    FOR EACH ROW 
+    EXECUTE FUNCTION billing.update_updated_at_column();
@@ -0,0 +1,6 @@
+-- Restore the unique index of the addresses as entered (the emailKey of clients is left in place)
+DROP INDEX IF EXISTS billing.idx_storage_records_client_email_key;
+CREATE UNIQUE INDEX idx_storage_records_client_email ON billing.storage_records ((value::jsonb #>> '{email,value}'));
+
+-- Add comments for documentation
+COMMENT ON INDEX billing.idx_storage_records_client_email IS 'One client per email address (normalized, lower case)';
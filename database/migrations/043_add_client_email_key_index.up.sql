@@ -0,0 +1,17 @@
+-- Enforce unique canonical client email addresses
+-- Clients carry the canonical address compared by uniqueness checks (emailKey: lower case, with subaddress tags and
+-- dots folded when the email normalization policy says so). The expression matches the client repository lookup
+-- (GetByEmailKey), so the index also serves it
+-- Clients saved before carry no key: their lower-cased address is the key of the default policy
+-- The migration fails if duplicates already exist: merge or rename them before applying it
+
+UPDATE billing.storage_records
+SET value = jsonb_set(value::jsonb, '{emailKey}', to_jsonb(value::jsonb #>> '{email,value}'))::text
+WHERE value::jsonb #>> '{email,value}' IS NOT NULL
+  AND value::jsonb #>> '{emailKey}' IS NULL;
+
+DROP INDEX IF EXISTS billing.idx_storage_records_client_email;
+CREATE UNIQUE INDEX idx_storage_records_client_email_key ON billing.storage_records ((value::jsonb #>> '{emailKey}'));
+
+-- Add comments for documentation
+COMMENT ON INDEX billing.idx_storage_records_client_email_key IS 'One client per canonical email address';
@@ -0,0 +1,2 @@
+-- Drop the client phone index (the phoneE164 of clients is left in place)
+DROP INDEX IF EXISTS billing.idx_storage_records_client_phone;
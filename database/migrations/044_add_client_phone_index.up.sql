@@ -0,0 +1,17 @@
+-- Index client phone numbers in E.164 form
+-- Clients carry the phone number normalized when it was entered (phoneE164: country code and significant digits,
+-- national numbers resolved in the region of the request locale). The expression matches the client repository
+-- lookup (ListByPhone), which serves GET /clients?phone=
+-- Clients saved before carry no normalized number: their number without formatting characters is used, which is
+-- already the E.164 form for numbers entered with a country code
+
+UPDATE billing.storage_records
+SET value = jsonb_set(value::jsonb, '{phoneE164}',
+                      to_jsonb(regexp_replace(value::jsonb #>> '{phone,value}', '[ ().-]', '', 'g')))::text
+WHERE COALESCE(value::jsonb #>> '{phone,value}', '') <> ''
+  AND value::jsonb #>> '{phoneE164}' IS NULL;
+
+CREATE INDEX idx_storage_records_client_phone ON billing.storage_records ((value::jsonb #>> '{phoneE164}'));
+
+-- Add comments for documentation
+COMMENT ON INDEX billing.idx_storage_records_client_phone IS 'Client lookup by E.164 phone number';
@@ -0,0 +1,21 @@
+-- Create storage collection for the tombstones of deleted clients
+-- The table shares the storage_records shape used by the PostgreSQL storage abstraction
+
+CREATE TABLE billing.client_tombstone_records (
+    key VARCHAR(255) PRIMARY KEY,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create index for better query performance (tombstones are looked up by key, the client ID)
+CREATE INDEX idx_client_tombstone_records_created_at ON billing.client_tombstone_records(created_at);
+
+-- Add comments for documentation
+COMMENT ON TABLE billing.client_tombstone_records IS 'Tombstones of deleted clients (idempotent deletes answer 204 for them)';
+
+-- Create trigger to automatically update updated_at
+CREATE TRIGGER update_client_tombstone_records_updated_at 
+    BEFORE UPDATE ON billing.client_tombstone_records 
+    FOR EACH ROW 
+    EXECUTE FUNCTION billing.update_updated_at_column();
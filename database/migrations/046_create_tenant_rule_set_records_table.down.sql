@@ -0,0 +1,8 @@
+-- Drop trigger first
+DROP TRIGGER IF EXISTS update_tenant_rule_set_records_updated_at ON billing.tenant_rule_set_records;
+
+-- Drop indexes
+DROP INDEX IF EXISTS billing.idx_tenant_rule_set_records_created_at;
+
+-- Drop table
+DROP TABLE IF EXISTS billing.tenant_rule_set_records;
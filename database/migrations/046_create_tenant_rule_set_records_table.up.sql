@@ -0,0 +1,21 @@
+-- Create storage collection for tenant business rule sets
+-- The table shares the storage_records shape used by the PostgreSQL storage abstraction
+
+CREATE TABLE billing.tenant_rule_set_records (
+    key VARCHAR(255) PRIMARY KEY,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for better query performance (rule set listing)
+CREATE INDEX idx_tenant_rule_set_records_created_at ON billing.tenant_rule_set_records(created_at);
+
+-- Add comments for documentation
+COMMENT ON TABLE billing.tenant_rule_set_records IS 'Tenant business rules, stored as expressions evaluated before client and invoice operations';
+
+-- Create trigger to automatically update updated_at
+CREATE TRIGGER update_tenant_rule_set_records_updated_at 
+    BEFORE UPDATE ON billing.tenant_rule_set_records 
+    FOR EACH ROW 
+    EXECUTE FUNCTION billing.update_updated_at_column();
@@ -0,0 +1,7 @@
+-- One-off invoice references are dropped: before this migration only templates could claim invoice references
+DELETE FROM billing.external_reference_records WHERE key LIKE 'invoice|%';
+
+UPDATE billing.external_reference_records
+SET key = 'invoice|' || substr(key, length('recurring_invoice|') + 1),
+    value = jsonb_set(value::jsonb, '{kind}', '"invoice"')::text
+WHERE key LIKE 'recurring_invoice|%';
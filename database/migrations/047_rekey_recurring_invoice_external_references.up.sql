@@ -0,0 +1,7 @@
+-- References of recurring invoice templates move to their own kind, so one-off invoices can claim references too
+-- Until now only templates claimed invoice references
+
+UPDATE billing.external_reference_records
+SET key = 'recurring_invoice|' || substr(key, length('invoice|') + 1),
+    value = jsonb_set(value::jsonb, '{kind}', '"recurring_invoice"')::text
+WHERE key LIKE 'invoice|%';
@@ -0,0 +1,23 @@
+-- Entity sequences move back onto the entities, continuing from their latest counter
+-- With yearly reset only the counter of the latest year is kept, as entities only stored their current year
+
+UPDATE billing.legal_entity_records AS e
+SET value = jsonb_set(
+        jsonb_set(e.value::jsonb, '{nextSequence}', to_jsonb(c.value + 1)),
+        '{sequenceYear}', to_jsonb(COALESCE(NULLIF(split_part(c.scope, ':', 3), '')::int, 0))
+    )::text
+FROM (
+    SELECT DISTINCT ON (split_part(scope, ':', 2)) split_part(scope, ':', 2) AS entity_id, scope, value
+    FROM billing.invoice_records_counters
+    WHERE scope LIKE 'entity:%'
+    ORDER BY split_part(scope, ':', 2), NULLIF(split_part(scope, ':', 3), '')::int DESC NULLS LAST
+) AS c
+WHERE c.entity_id = e.key;
+
+UPDATE billing.legal_entity_records
+SET value = jsonb_set(value::jsonb, '{nextSequence}', '1')::text
+WHERE NOT (value::jsonb ? 'nextSequence');
+
+DELETE FROM billing.invoice_records_counters WHERE scope LIKE 'entity:%';
+
+COMMENT ON COLUMN billing.invoice_records_counters.scope IS 'Issue year the invoice numbers belong to';
@@ -0,0 +1,19 @@
+-- Legal entities number their invoices from the gap-free invoice counters, allocated in the transaction issuing
+-- the invoice, instead of a sequence stored on the entity
+-- Counters carry on from the last number each entity allocated: "entity:<id>", or "entity:<id>:<year>" with yearly reset
+
+INSERT INTO billing.invoice_records_counters (scope, value)
+SELECT CASE
+           WHEN (value::jsonb -> 'numbering' ->> 'yearlyReset')::boolean
+               THEN 'entity:' || key || ':' || (value::jsonb ->> 'sequenceYear')
+           ELSE 'entity:' || key
+       END,
+       (value::jsonb ->> 'nextSequence')::bigint - 1
+FROM billing.legal_entity_records
+WHERE (value::jsonb ->> 'nextSequence')::bigint > 1
+ON CONFLICT (scope) DO NOTHING;
+
+UPDATE billing.legal_entity_records
+SET value = (value::jsonb - 'nextSequence' - 'sequenceYear')::text;
+
+COMMENT ON COLUMN billing.invoice_records_counters.scope IS 'Issue year, or legal entity ("entity:<id>[:<year>]"), the invoice numbers belong to';
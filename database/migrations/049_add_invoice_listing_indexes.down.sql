@@ -0,0 +1,3 @@
+-- Drop indexes
+DROP INDEX IF EXISTS billing.idx_invoice_records_client_id;
+DROP INDEX IF EXISTS billing.idx_invoice_records_status;
@@ -0,0 +1,10 @@
+-- Index the invoice fields listings filter on
+-- Invoice listings, dunning runs and open invoice lookups filter by status, duplicate checks by client; the
+-- expressions match the invoice repository queries, and the created_at index serves their keyset order
+
+CREATE INDEX idx_invoice_records_status ON billing.invoice_records ((value::jsonb #>> '{status}'), created_at, key);
+CREATE INDEX idx_invoice_records_client_id ON billing.invoice_records ((value::jsonb #>> '{clientId}'), created_at, key);
+
+-- Add comments for documentation
+COMMENT ON INDEX billing.idx_invoice_records_status IS 'Invoices by status, in creation order';
+COMMENT ON INDEX billing.idx_invoice_records_client_id IS 'Invoices by client, in creation order';
@@ -0,0 +1,7 @@
+-- Restore the unique index of canonical email addresses across tenants
+-- The migration fails if tenants share an address: merge or rename those clients before reverting
+DROP INDEX IF EXISTS billing.idx_storage_records_client_email_key;
+CREATE UNIQUE INDEX idx_storage_records_client_email_key ON billing.storage_records ((value::jsonb #>> '{emailKey}'));
+
+-- Add comments for documentation
+COMMENT ON INDEX billing.idx_storage_records_client_email_key IS 'One client per canonical email address';
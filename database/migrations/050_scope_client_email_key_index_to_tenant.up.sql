@@ -0,0 +1,13 @@
+-- Scope unique canonical client email addresses to tenants
+-- Two tenants may bill the same company, so a client email address is only unique among the clients of its tenant
+-- (UniqueClientEmailRule). Clients created without a tenant share the empty tenant, as in the client repository
+-- lookup (GetByEmailKey); the email key comes first so the index still serves that lookup
+
+DROP INDEX IF EXISTS billing.idx_storage_records_client_email_key;
+CREATE UNIQUE INDEX idx_storage_records_client_email_key ON billing.storage_records (
+    (value::jsonb #>> '{emailKey}'),
+    (COALESCE(value::jsonb #>> '{tenantId}', ''))
+);
+
+-- Add comments for documentation
+COMMENT ON INDEX billing.idx_storage_records_client_email_key IS 'One client per canonical email address in each tenant';
@@ -0,0 +1,21 @@
+-- Create storage collection for the webhook subscriptions events are delivered and replayed to
+-- The table shares the storage_records shape used by the PostgreSQL storage abstraction
+
+CREATE TABLE billing.webhook_subscription_records (
+    key VARCHAR(255) PRIMARY KEY,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for better query performance (subscription listing)
+CREATE INDEX idx_webhook_subscription_records_created_at ON billing.webhook_subscription_records(created_at);
+
+-- Add comments for documentation
+COMMENT ON TABLE billing.webhook_subscription_records IS 'Consumer webhook endpoints of tenants with their signing secrets and last replay time';
+
+-- Create trigger to automatically update updated_at
+CREATE TRIGGER update_webhook_subscription_records_updated_at 
+    BEFORE UPDATE ON billing.webhook_subscription_records 
+    FOR EACH ROW 
+    EXECUTE FUNCTION billing.update_updated_at_column();
@@ -0,0 +1,2 @@
+-- Drop indexes
+DROP INDEX IF EXISTS billing.idx_event_store_records_tenant_id;
@@ -0,0 +1,8 @@
+-- Index stored events by the tenant header of their message
+-- Webhook replays list the events of one tenant in key (sequence) order; the expression matches the event store
+-- repository filter
+
+CREATE INDEX idx_event_store_records_tenant_id ON billing.event_store_records ((value::jsonb #>> '{headers,tenant_id}'), key);
+
+-- Add comments for documentation
+COMMENT ON INDEX billing.idx_event_store_records_tenant_id IS 'Stored events by tenant, in sequence order';
@@ -0,0 +1,58 @@
+package http
+
+import (
+	"net/http"
+	"net/http/pprof"
+	"strings"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/middleware"
+)
+
+// ProfilingPath serves the Go profiler (net/http/pprof) on the admin listener
+const ProfilingPath = "/debug/pprof"
+
+// operationalRoutes are the routes served by the admin listener when it is separate from the public API:
+// health checks, the admin API and the profiler
+var operationalRoutes = []string{"/health", middleware.AdminRoutePrefix, ProfilingPath}
+
+// isOperationalRoute checks if a path belongs to an operational route
+func isOperationalRoute(path string) bool {
+	for _, route := range operationalRoutes {
+		if path == route || strings.HasPrefix(path, route+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// AdminHandler returns the handler of the separate admin listener, serving only the operational routes
+// Without a separate admin listener (ServerOptions.SeparateAdminListener) Handler serves every route and
+// AdminHandler returns nil
+func (s *Server) AdminHandler() http.Handler {
+	if !s.separateAdmin {
+		return nil
+	}
+	return restrictRoutes(s.SetupRoutes(), true)
+}
+
+// restrictRoutes serves the operational routes only (or every other route only), so the public ingress never
+// reaches operational surfaces; other paths are unknown to the listener
+func restrictRoutes(next http.Handler, operational bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isOperationalRoute(r.URL.Path) != operational {
+			http.NotFound(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// registerProfiling serves the Go profiler: the index and named profiles, plus the handlers pprof.Index
+// does not cover
+func registerProfiling(mux *http.ServeMux) {
+	mux.HandleFunc(ProfilingPath+"/", pprof.Index)
+	mux.HandleFunc(ProfilingPath+"/cmdline", pprof.Cmdline)
+	mux.HandleFunc(ProfilingPath+"/profile", pprof.Profile)
+	mux.HandleFunc(ProfilingPath+"/symbol", pprof.Symbol)
+	mux.HandleFunc(ProfilingPath+"/trace", pprof.Trace)
+}
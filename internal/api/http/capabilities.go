@@ -0,0 +1,53 @@
+package http
+
+import (
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/handlers"
+)
+
+// capabilities describes the features enabled in this deployment, as wired from the configuration
+// Features are named after the API areas they enable; grpc stays false as the API is only served over HTTP
+// Integrations count as enabled once configured: webhooks with a provider, risk scoring with a credit bureau
+func capabilities(services Services, options ServerOptions, version string) *handlers.CapabilitiesHandler {
+	features := map[string]bool{
+		"approvals":             services.Approvals != nil,
+		"captcha":               options.Captcha.Enabled,
+		"card_payments":         services.InvoicePayments != nil,
+		"cash_application":      services.Cash != nil,
+		"client_portal":         services.Portal != nil,
+		"client_statements":     services.Statements != nil,
+		"contracts":             services.Contracts != nil,
+		"credit_control":        services.Credit != nil,
+		"dunning":               services.DunningRuns != nil,
+		"event_store":           services.Events != nil,
+		"fiscal_calendars":      services.FiscalCalendars != nil,
+		"grpc":                  false,
+		"invoice_archive":       services.InvoiceArchive != nil,
+		"invoice_delivery":      services.Delivery != nil,
+		"legal_entities":        services.LegalEntities != nil,
+		"payout_reconciliation": services.Payouts != nil,
+		"quotes":                services.Quotes != nil,
+		"recurring_invoices":    services.Recurring != nil,
+		"request_signing":       options.RequestSigning.Enabled,
+		"risk_scoring":          services.Risk != nil && services.Risk.Enabled(),
+		"sandbox":               options.Sandbox.Environment != nil,
+		"subscriptions":         services.Subscriptions != nil,
+		"uploads":               services.Uploads != nil,
+		"usage_metering":        services.Usage != nil,
+		"webhooks":              services.Webhooks != nil && len(services.Webhooks.Providers()) > 0,
+	}
+
+	response := dtos.CapabilitiesResponse{Version: version, Features: features}
+	if services.Currencies != nil {
+		response.Currencies = dtos.CurrencyCapabilities{
+			Default:    services.Currencies.Default().Currency(),
+			Configured: services.Currencies.Currencies(),
+		}
+	}
+
+	var maxUploadBytes int64
+	if services.Uploads != nil {
+		maxUploadBytes = services.Uploads.MaxSize()
+	}
+	return handlers.NewCapabilitiesHandler(response, maxUploadBytes)
+}
@@ -0,0 +1,483 @@
+package dtos
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// IPAccessRuleRequest represents a single allow/deny rule in an IP access policy request
+type IPAccessRuleRequest struct {
+	RoutePrefix string   `json:"route_prefix,omitempty"`
+	Allow       []string `json:"allow,omitempty"`
+	Deny        []string `json:"deny,omitempty"`
+}
+
+// SetIPAccessPolicyRequest represents the HTTP request body for replacing a tenant IP access policy
+type SetIPAccessPolicyRequest struct {
+	Rules []IPAccessRuleRequest `json:"rules"`
+}
+
+// IPAccessRuleResponse represents a single rule of an IP access policy
+type IPAccessRuleResponse struct {
+	RoutePrefix string   `json:"route_prefix"`
+	Allow       []string `json:"allow"`
+	Deny        []string `json:"deny"`
+}
+
+// IPAccessPolicyResponse represents the HTTP response body for a tenant IP access policy
+type IPAccessPolicyResponse struct {
+	TenantID  string                 `json:"tenant_id"`
+	Rules     []IPAccessRuleResponse `json:"rules"`
+	UpdatedAt time.Time              `json:"updated_at"`
+}
+
+// AuditEntryResponse represents the HTTP response body for an audit log entry
+type AuditEntryResponse struct {
+	ID           string                 `json:"id"`
+	Action       string                 `json:"action"`
+	Actor        string                 `json:"actor"`
+	TenantID     string                 `json:"tenant_id,omitempty"`
+	ResourceType string                 `json:"resource_type"`
+	ResourceID   string                 `json:"resource_id"`
+	Details      map[string]interface{} `json:"details,omitempty"`
+	OccurredAt   time.Time              `json:"occurred_at"`
+}
+
+// ReminderStageRequest represents a single stage of a payment reminder cadence
+type ReminderStageRequest struct {
+	DayOffset int    `json:"day_offset"` // Days after the invoice due date (negative before it)
+	Channel   string `json:"channel"`    // email, sms, letter
+	Template  string `json:"template"`   // Message template rendered for the stage
+}
+
+// SetDunningPolicyRequest represents the HTTP request body for replacing a tenant dunning policy
+type SetDunningPolicyRequest struct {
+	Stages []ReminderStageRequest `json:"stages"`
+}
+
+// ReminderStageResponse represents a single stage of a payment reminder cadence
+type ReminderStageResponse struct {
+	DayOffset int    `json:"day_offset"`
+	Channel   string `json:"channel"`
+	Template  string `json:"template"`
+}
+
+// DunningPolicyResponse represents the HTTP response body for a tenant dunning policy
+type DunningPolicyResponse struct {
+	TenantID  string                  `json:"tenant_id"`
+	Stages    []ReminderStageResponse `json:"stages"`
+	Default   bool                    `json:"default"` // True when the tenant has no policy and uses the default cadence
+	UpdatedAt *time.Time              `json:"updated_at,omitempty"`
+}
+
+// TenantRuleRequest represents a business rule of a tenant, refusing operations its expression is false for
+type TenantRuleRequest struct {
+	Name       string `json:"name"`
+	Operation  string `json:"operation"`         // client.create, client.update, invoice.create
+	Expression string `json:"expression"`        // e.g. invoice.total <= 500000 || "trusted" in client.tags
+	Message    string `json:"message,omitempty"` // Explanation given when the rule refuses an operation
+}
+
+// SetTenantRulesRequest represents the HTTP request body for replacing the business rules of a tenant
+type SetTenantRulesRequest struct {
+	Rules []TenantRuleRequest `json:"rules"`
+}
+
+// TenantRuleResponse represents a business rule of a tenant
+type TenantRuleResponse struct {
+	Name       string `json:"name"`
+	Operation  string `json:"operation"`
+	Expression string `json:"expression"`
+	Message    string `json:"message,omitempty"`
+}
+
+// TenantRuleSetResponse represents the HTTP response body for the business rules of a tenant
+type TenantRuleSetResponse struct {
+	TenantID  string               `json:"tenant_id"`
+	Rules     []TenantRuleResponse `json:"rules"`
+	UpdatedAt *time.Time           `json:"updated_at,omitempty"` // Absent for tenants without rules
+}
+
+// DunningReminderResponse represents a payment reminder sent by a dunning run
+type DunningReminderResponse struct {
+	InvoiceID   string `json:"invoice_id"`
+	Number      string `json:"number,omitempty"`
+	ClientID    string `json:"client_id"`
+	Level       int    `json:"level"`
+	Channel     string `json:"channel"`
+	Template    string `json:"template"`
+	DaysOverdue int    `json:"days_overdue"`
+}
+
+// DunningRunResponse represents the outcome of a dunning run
+type DunningRunResponse struct {
+	Sent      int                       `json:"sent"`
+	Reminders []DunningReminderResponse `json:"reminders"`
+}
+
+// PayoutDiscrepancyResponse represents a difference between a gateway payout and the ledger
+type PayoutDiscrepancyResponse struct {
+	Kind          string `json:"kind"` // missing_in_ledger, amount_mismatch, fee_mismatch, total_mismatch
+	Reference     string `json:"reference,omitempty"`
+	PaymentID     string `json:"payment_id,omitempty"`
+	GatewayAmount int64  `json:"gateway_amount"`
+	LedgerAmount  int64  `json:"ledger_amount"`
+}
+
+// PayoutReconciliationResponse represents the HTTP response body for the discrepancy report of a gateway payout
+type PayoutReconciliationResponse struct {
+	PayoutID      string                      `json:"payout_id"`
+	ArrivalDate   time.Time                   `json:"arrival_date"`
+	Amount        MoneyResponse               `json:"amount"`
+	Status        string                      `json:"status"` // balanced, discrepant
+	ItemCount     int                         `json:"item_count"`
+	MatchedCount  int                         `json:"matched_count"`
+	Discrepancies []PayoutDiscrepancyResponse `json:"discrepancies"`
+	ReconciledAt  time.Time                   `json:"reconciled_at"`
+}
+
+// PayoutReconciliationRunResponse represents the outcome of a payout reconciliation run
+type PayoutReconciliationRunResponse struct {
+	Reconciled int                            `json:"reconciled"`
+	Discrepant int                            `json:"discrepant"`
+	Reports    []PayoutReconciliationResponse `json:"reports"`
+}
+
+// TaxRegistrationRequest represents a tax identifier of a legal entity
+type TaxRegistrationRequest struct {
+	Country string `json:"country"` // ISO 3166-1 alpha-2
+	Number  string `json:"number"`
+}
+
+// BankAccountRequest represents the bank details printed on a legal entity's invoices
+type BankAccountRequest struct {
+	AccountHolder string `json:"account_holder"`
+	IBAN          string `json:"iban"`
+	BIC           string `json:"bic,omitempty"`
+	BankName      string `json:"bank_name,omitempty"`
+}
+
+// InvoiceNumberingRequest represents the invoice numbering of a legal entity
+type InvoiceNumberingRequest struct {
+	Prefix      string `json:"prefix"`
+	Padding     int    `json:"padding,omitempty"` // Minimum sequence digits (default 6)
+	YearlyReset bool   `json:"yearly_reset"`      // Include the year and restart the sequence each year
+}
+
+// LegalEntityRequest represents the HTTP request body for creating or replacing a legal entity
+type LegalEntityRequest struct {
+	Name             string                   `json:"name"`
+	Address          string                   `json:"address,omitempty"`
+	Country          string                   `json:"country"`
+	TaxRegistrations []TaxRegistrationRequest `json:"tax_registrations,omitempty"`
+	BankAccount      *BankAccountRequest      `json:"bank_account,omitempty"`
+	Numbering        InvoiceNumberingRequest  `json:"numbering"`
+	DocumentTemplate string                   `json:"document_template,omitempty"` // Invoice layout (default "default")
+	Default          bool                     `json:"default"`                     // Issue the invoices not assigned to an entity
+}
+
+// TaxRegistrationResponse represents a tax identifier of a legal entity
+type TaxRegistrationResponse struct {
+	Country string `json:"country"`
+	Number  string `json:"number"`
+}
+
+// BankAccountResponse represents the bank details of a legal entity
+type BankAccountResponse struct {
+	AccountHolder string `json:"account_holder"`
+	IBAN          string `json:"iban"`
+	BIC           string `json:"bic,omitempty"`
+	BankName      string `json:"bank_name,omitempty"`
+}
+
+// InvoiceNumberingResponse represents the invoice numbering of a legal entity
+type InvoiceNumberingResponse struct {
+	Prefix      string `json:"prefix"`
+	Padding     int    `json:"padding"`
+	YearlyReset bool   `json:"yearly_reset"`
+}
+
+// LegalEntityResponse represents the HTTP response body for a legal entity
+type LegalEntityResponse struct {
+	ID                string                    `json:"id"`
+	Name              string                    `json:"name"`
+	Address           string                    `json:"address,omitempty"`
+	Country           string                    `json:"country"`
+	TaxRegistrations  []TaxRegistrationResponse `json:"tax_registrations"`
+	BankAccount       *BankAccountResponse      `json:"bank_account,omitempty"`
+	Numbering         InvoiceNumberingResponse  `json:"numbering"`
+	DocumentTemplate  string                    `json:"document_template"`
+	Default           bool                      `json:"default"`
+	HasIssuedInvoices bool                      `json:"has_issued_invoices"`
+	CreatedAt         time.Time                 `json:"created_at"`
+	UpdatedAt         time.Time                 `json:"updated_at"`
+}
+
+// DocumentTemplateRequest represents the HTTP request body for replacing (or previewing) a tenant document template
+type DocumentTemplateRequest struct {
+	LogoURL      string   `json:"logo_url,omitempty"`
+	PrimaryColor string   `json:"primary_color,omitempty"` // #RRGGBB (default #1F2937)
+	AccentColor  string   `json:"accent_color,omitempty"`  // #RRGGBB (default #2563EB)
+	Columns      []string `json:"columns,omitempty"`       // Visible line item columns, in order
+	FooterText   string   `json:"footer_text,omitempty"`
+}
+
+// DocumentTemplateResponse represents the HTTP response body for a tenant document template
+type DocumentTemplateResponse struct {
+	TenantID     string     `json:"tenant_id"`
+	LogoURL      string     `json:"logo_url,omitempty"`
+	PrimaryColor string     `json:"primary_color"`
+	AccentColor  string     `json:"accent_color"`
+	Columns      []string   `json:"columns"`
+	FooterText   string     `json:"footer_text,omitempty"`
+	Default      bool       `json:"default"` // True when the tenant has no template and uses the default layout
+	UpdatedAt    *time.Time `json:"updated_at,omitempty"`
+}
+
+// DeadLetterBulkRequest represents the HTTP request body for retrying or discarding dead letters
+// Without IDs, every dead letter of the source is selected
+type DeadLetterBulkRequest struct {
+	IDs    []string `json:"ids,omitempty"`
+	Source string   `json:"source,omitempty"`
+}
+
+// DeadLetterResponse represents the HTTP response body for a dead letter
+type DeadLetterResponse struct {
+	ID        string            `json:"id"`
+	Source    string            `json:"source"`
+	Reference string            `json:"reference"`
+	Payload   json.RawMessage   `json:"payload"`
+	Headers   map[string]string `json:"headers,omitempty"`
+	Reason    string            `json:"reason"`
+	Attempts  int               `json:"attempts"`
+	FailedAt  time.Time         `json:"failed_at"`
+}
+
+// DeadLetterFailureResponse represents a dead letter a bulk operation could not retry or discard
+type DeadLetterFailureResponse struct {
+	ID    string `json:"id"`
+	Error string `json:"error"`
+}
+
+// DeadLetterBulkResponse represents the HTTP response body for a bulk retry or discard
+type DeadLetterBulkResponse struct {
+	Succeeded []string                    `json:"succeeded"`
+	Failed    []DeadLetterFailureResponse `json:"failed"`
+}
+
+// ProcessedMessageCleanupResponse represents the HTTP response body for a cleanup of expired processed message records
+type ProcessedMessageCleanupResponse struct {
+	Removed int `json:"removed"`
+}
+
+// OutboundClientStatsResponse represents the call statistics of an outbound HTTP destination
+type OutboundClientStatsResponse struct {
+	Destination      string `json:"destination"`
+	Requests         int64  `json:"requests"`
+	Failures         int64  `json:"failures"`
+	Retries          int64  `json:"retries"`
+	Rejected         int64  `json:"rejected"`
+	Circuit          string `json:"circuit"`
+	AverageLatencyMs int64  `json:"average_latency_ms"`
+}
+
+// IntegrationLogResponse represents the HTTP response body for a logged outbound integration call
+type IntegrationLogResponse struct {
+	ID           string    `json:"id"`
+	Destination  string    `json:"destination"`
+	Method       string    `json:"method"`
+	URL          string    `json:"url"`
+	StatusCode   int       `json:"status_code,omitempty"`
+	DurationMs   int64     `json:"duration_ms"`
+	RequestBody  string    `json:"request_body,omitempty"`
+	ResponseBody string    `json:"response_body,omitempty"`
+	Error        string    `json:"error,omitempty"`
+	RequestID    string    `json:"request_id,omitempty"`
+	Failed       bool      `json:"failed"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// IntegrationLogCleanupResponse represents the HTTP response body for a cleanup of expired integration logs
+type IntegrationLogCleanupResponse struct {
+	Removed int `json:"removed"`
+}
+
+// SagaStepResponse represents a step of a saga
+type SagaStepResponse struct {
+	Name      string `json:"name"`
+	Status    string `json:"status"` // pending, completed, failed, compensated
+	Attempts  int    `json:"attempts"`
+	LastError string `json:"last_error,omitempty"`
+}
+
+// SagaResponse represents the HTTP response body for a saga
+type SagaResponse struct {
+	ID          string             `json:"id"`
+	Type        string             `json:"type"`
+	Reference   string             `json:"reference"`
+	Status      string             `json:"status"` // running, compensating, completed, compensated
+	CurrentStep string             `json:"current_step,omitempty"`
+	LastError   string             `json:"last_error,omitempty"`
+	Stuck       bool               `json:"stuck"`
+	Steps       []SagaStepResponse `json:"steps"`
+	Data        map[string]string  `json:"data,omitempty"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+}
+
+// SagaRunResponse represents the HTTP response body for a run resuming the stuck sagas
+type SagaRunResponse struct {
+	Resumed int            `json:"resumed"`
+	Sagas   []SagaResponse `json:"sagas"`
+}
+
+// SetFiscalCalendarRequest represents the HTTP request body for replacing a tenant fiscal calendar
+type SetFiscalCalendarRequest struct {
+	StartMonth   int   `json:"start_month"`             // Month fiscal years start on (1-12)
+	PeriodMonths []int `json:"period_months,omitempty"` // Length of every period in months, summing to 12 (default monthly)
+}
+
+// FiscalCalendarResponse represents the HTTP response body for a tenant fiscal calendar
+type FiscalCalendarResponse struct {
+	TenantID      string     `json:"tenant_id"`
+	StartMonth    int        `json:"start_month"`
+	PeriodMonths  []int      `json:"period_months"`
+	ClosedPeriods []string   `json:"closed_periods"`
+	Default       bool       `json:"default"` // True when the tenant has no calendar and uses calendar years split into months
+	UpdatedAt     *time.Time `json:"updated_at,omitempty"`
+}
+
+// FiscalPeriodResponse represents an accounting period of a fiscal year
+type FiscalPeriodResponse struct {
+	Period     string    `json:"period"` // e.g. 2026-P03
+	FiscalYear int       `json:"fiscal_year"`
+	Number     int       `json:"number"`
+	StartDate  time.Time `json:"start_date"`
+	EndDate    time.Time `json:"end_date"` // Last day of the period
+	Status     string    `json:"status"`   // open, closed
+}
+
+// FiscalPeriodListResponse represents the HTTP response body for the periods of a tenant fiscal year
+type FiscalPeriodListResponse struct {
+	TenantID   string                 `json:"tenant_id"`
+	FiscalYear int                    `json:"fiscal_year"`
+	Periods    []FiscalPeriodResponse `json:"periods"`
+}
+
+// PartitionRunResponse represents the HTTP response body of a partition maintenance run
+type PartitionRunResponse struct {
+	Created []string `json:"created"` // Names of the partitions created by this run
+}
+
+// InvoiceArchiveRunResponse represents the HTTP response body of an invoice archive run
+type InvoiceArchiveRunResponse struct {
+	Archived int `json:"archived"` // Invoices moved to the archive by this run
+}
+
+// ArchivedInvoiceResponse represents an archived invoice with its payments
+type ArchivedInvoiceResponse struct {
+	Invoice    InvoiceResponse   `json:"invoice"`
+	Payments   []PaymentResponse `json:"payments"`
+	ArchivedAt time.Time         `json:"archived_at"`
+}
+
+// OutboundEmailResponse represents a notification email of the outbox
+type OutboundEmailResponse struct {
+	ID            string            `json:"id"`
+	Topic         string            `json:"topic"`
+	Key           string            `json:"key,omitempty"`
+	Recipient     string            `json:"recipient,omitempty"`
+	Payload       json.RawMessage   `json:"payload"`
+	Headers       map[string]string `json:"headers,omitempty"`
+	Status        string            `json:"status"` // pending, sent, failed, suppressed
+	Attempts      int               `json:"attempts"`
+	LastError     string            `json:"last_error,omitempty"`
+	NextAttemptAt *time.Time        `json:"next_attempt_at,omitempty"` // Set while the email is pending
+	SentAt        *time.Time        `json:"sent_at,omitempty"`
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+}
+
+// EmailOutboxRunResponse represents the HTTP response body of an email outbox run, with the IDs of the emails per outcome
+type EmailOutboxRunResponse struct {
+	Sent       []string `json:"sent"`
+	Retrying   []string `json:"retrying"` // Failed attempts that will be retried
+	Failed     []string `json:"failed"`   // Last attempt failed
+	Suppressed []string `json:"suppressed"`
+}
+
+// EmailOutboxMetricsResponse represents the delivery metrics of the email outbox
+type EmailOutboxMetricsResponse struct {
+	ByStatus                map[string]int `json:"by_status"`
+	ByTopic                 map[string]int `json:"by_topic"`
+	Attempts                int            `json:"attempts"`
+	Retries                 int            `json:"retries"`
+	OldestPendingAgeSeconds *int64         `json:"oldest_pending_age_seconds,omitempty"`
+	SuppressedAddresses     int            `json:"suppressed_addresses"`
+}
+
+// SuppressEmailRequest represents the HTTP request body for adding an address to the suppression list
+type SuppressEmailRequest struct {
+	Email string `json:"email"`
+	Note  string `json:"note,omitempty"` // e.g. complaint, unsubscribe request
+}
+
+// EmailBounceRequest represents the HTTP request body for a bounce the mailer reports for an address
+type EmailBounceRequest struct {
+	Email      string     `json:"email"`
+	BounceType string     `json:"bounce_type"` // hard, soft
+	Detail     string     `json:"detail,omitempty"`
+	OccurredAt *time.Time `json:"occurred_at,omitempty"`
+}
+
+// EmailSuppressionResponse represents an address on the suppression list
+type EmailSuppressionResponse struct {
+	Email        string     `json:"email"`
+	Reason       string     `json:"reason,omitempty"` // hard_bounce, soft_bounces, manual (empty while only tracked)
+	Detail       string     `json:"detail,omitempty"`
+	SoftBounces  int        `json:"soft_bounces"`
+	SuppressedAt *time.Time `json:"suppressed_at,omitempty"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// DashboardResponse represents every number of the operational dashboard at once
+type DashboardResponse struct {
+	Requests        RequestStatsResponse           `json:"requests"`
+	Queues          []QueueDepthResponse           `json:"queues"`
+	OverdueInvoices []OverdueInvoiceTotalsResponse `json:"overdue_invoices"`
+	GeneratedAt     time.Time                      `json:"generated_at"`
+}
+
+// RequestStatsResponse represents the requests served by the API over a recent window
+type RequestStatsResponse struct {
+	WindowSeconds     int64   `json:"window_seconds"`
+	Requests          int     `json:"requests"`
+	ClientErrors      int     `json:"client_errors"` // 4xx responses
+	ServerErrors      int     `json:"server_errors"` // 5xx responses
+	RequestsPerMinute float64 `json:"requests_per_minute"`
+	ErrorRate         float64 `json:"error_rate"` // Share of the requests answered with a 5xx status
+}
+
+// QueueDepthResponse represents the number of items waiting in an asynchronous queue
+type QueueDepthResponse struct {
+	Name  string `json:"name"` // email_outbox, webhook_events, statement_jobs, dead_letters
+	Depth int    `json:"depth"`
+}
+
+// OverdueInvoiceTotalsResponse represents the overdue invoices of one currency
+type OverdueInvoiceTotalsResponse struct {
+	Currency      string                       `json:"currency"`
+	Invoices      int                          `json:"invoices"`
+	Outstanding   MoneyResponse                `json:"outstanding"`
+	OldestDueDate time.Time                    `json:"oldest_due_date"`
+	Aging         []OverdueAgingBucketResponse `json:"aging"`
+}
+
+// OverdueAgingBucketResponse represents the overdue invoices of a range of days past due
+type OverdueAgingBucketResponse struct {
+	Label       string        `json:"label"` // 1-30, 31-60, 61-90, 90+
+	MinDays     int           `json:"min_days"`
+	MaxDays     *int          `json:"max_days,omitempty"` // Absent for the open-ended last bucket
+	Invoices    int           `json:"invoices"`
+	Outstanding MoneyResponse `json:"outstanding"`
+}
@@ -66,4 +66,20 @@ type PaginatedResponse struct {
 	Data       interface{}         `json:"data"`
 	Pagination *PaginationResponse `json:"pagination"`
 	Success    bool                `json:"success"`
+	Warnings   []Warning           `json:"warnings,omitempty"` // Early signals that did not fail the request
+}
+
+// CursorPaginationResponse represents the metadata of a page listed by cursor
+type CursorPaginationResponse struct {
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor"` // Passed back as ?cursor= to continue after the page
+	HasMore    bool   `json:"has_more"`
+}
+
+// CursorPaginatedResponse represents a page of an API listing paged by cursor
+type CursorPaginatedResponse struct {
+	Data       interface{}               `json:"data"`
+	Pagination *CursorPaginationResponse `json:"pagination"`
+	Success    bool                      `json:"success"`
+	Warnings   []Warning                 `json:"warnings,omitempty"` // Early signals that did not fail the request
 }
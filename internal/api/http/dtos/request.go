@@ -1,17 +1,316 @@
 package dtos
 
+import "time"
+
 // CreateClientRequest represents the HTTP request body for creating a client
 type CreateClientRequest struct {
-	Name    string `json:"name" binding:"required"`
-	Email   string `json:"email" binding:"required"`
-	Phone   string `json:"phone,omitempty"`
-	Address string `json:"address,omitempty"`
+	Name         string `json:"name" binding:"required"`
+	Email        string `json:"email" binding:"required"`
+	Phone        string `json:"phone,omitempty"`
+	Address      string `json:"address,omitempty"`
+	Country      string `json:"country,omitempty"`       // ISO 3166-1 alpha-2 country of the address, whose phone and address rules apply
+	ExternalRef  string `json:"external_ref,omitempty"`  // Order or contract ID in the integrator's system, unique per tenant
+	PaymentTerms string `json:"payment_terms,omitempty"` // due_on_receipt or net_<days> (e.g. net_30), due dates of invoices issued without one
 }
 
 // UpdateClientRequest represents the HTTP request body for updating a client
 // Note: Email is intentionally excluded for security/audit reasons
 type UpdateClientRequest struct {
-	Name    string `json:"name" binding:"required"`
+	Name         string `json:"name" binding:"required"`
+	Phone        string `json:"phone,omitempty"`
+	Address      string `json:"address,omitempty"`
+	Country      string `json:"country,omitempty"`       // Omitting the country removes it
+	PaymentTerms string `json:"payment_terms,omitempty"` // Omitting the terms removes them
+}
+
+// BatchGetClientsRequest represents the HTTP request body for fetching several clients by ID
+type BatchGetClientsRequest struct {
+	IDs []string `json:"ids"` // At most MaxLimit IDs
+}
+
+// UpdateClientStatusRequest represents the HTTP request body for moving a client through its lifecycle
+type UpdateClientStatusRequest struct {
+	Status string `json:"status"` // active, suspended or closed
+}
+
+// AddClientTagsRequest represents the HTTP request body for tagging a client
+type AddClientTagsRequest struct {
+	Tags []string `json:"tags"` // Free-form, matched whatever their case
+}
+
+// CreateUploadRequest represents the HTTP request body for starting a resumable upload
+type CreateUploadRequest struct {
+	Kind   string `json:"kind"`             // bank_statement
+	Format string `json:"format,omitempty"` // Passed to the job, e.g. camt053 or mt940 (empty: detected)
+	Size   int64  `json:"size"`             // Size of the whole file in bytes
+}
+
+// ClientNoteRequest represents the HTTP request body for recording a note on a client
+type ClientNoteRequest struct {
+	Body string `json:"body"`
+}
+
+// ClientContactRequest represents the HTTP request body for adding or replacing a contact of a client
+type ClientContactRequest struct {
+	Name  string `json:"name"`
+	Role  string `json:"role,omitempty"` // e.g. "Accounts payable"
+	Email string `json:"email"`
+	Phone string `json:"phone,omitempty"`
+}
+
+// PortalContactRequest represents the HTTP request body for a client updating its own contact details
+// Note: Name and email are managed by the billing team and cannot be changed from the portal
+type PortalContactRequest struct {
 	Phone   string `json:"phone,omitempty"`
 	Address string `json:"address,omitempty"`
 }
+
+// UsageRecordRequest represents one metered usage event in an ingestion batch
+// Timestamp defaults to the ingestion time; the idempotency key makes retries safe
+type UsageRecordRequest struct {
+	SubscriptionID string     `json:"subscription_id"`
+	Metric         string     `json:"metric"`
+	Quantity       int64      `json:"quantity"`
+	Timestamp      *time.Time `json:"timestamp,omitempty"`
+	IdempotencyKey string     `json:"idempotency_key"`
+}
+
+// IngestUsageRecordsRequest represents the HTTP request body for reporting a batch of usage events
+type IngestUsageRecordsRequest struct {
+	Records []UsageRecordRequest `json:"records"`
+}
+
+// CreateContractRequest represents the HTTP request body for creating a customer contract
+// Amounts are in minor units of the currency; the renewal date defaults to the end date
+type CreateContractRequest struct {
+	ClientID        string     `json:"client_id"`
+	Name            string     `json:"name"`
+	AccountManager  string     `json:"account_manager,omitempty"`
+	StartDate       time.Time  `json:"start_date"`
+	EndDate         time.Time  `json:"end_date"`
+	RenewalDate     *time.Time `json:"renewal_date,omitempty"`
+	CommittedAmount int64      `json:"committed_amount"`
+	Currency        string     `json:"currency"`
+}
+
+// CreateWebhookSubscriptionRequest represents the HTTP request body for subscribing an endpoint to the events of
+// the caller's tenant; an empty event_types list subscribes to every event type
+type CreateWebhookSubscriptionRequest struct {
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types,omitempty"`
+}
+
+// LinkContractSubscriptionRequest represents the HTTP request body for linking a subscription to a contract
+type LinkContractSubscriptionRequest struct {
+	SubscriptionID string `json:"subscription_id"`
+}
+
+// LinkContractInvoiceRequest represents the HTTP request body for counting an invoice towards a contract
+type LinkContractInvoiceRequest struct {
+	InvoiceID  string     `json:"invoice_id"`
+	InvoicedAt *time.Time `json:"invoiced_at,omitempty"` // Defaults to the issue date of the invoice
+}
+
+// CreateApprovalRequest represents the HTTP request body for submitting a credit note or refund for approval
+type CreateApprovalRequest struct {
+	Kind        string `json:"kind"`         // credit_note, refund
+	ClientID    string `json:"client_id"`    // Client receiving the money
+	ReferenceID string `json:"reference_id"` // Invoice credited or payment refunded
+	Amount      int64  `json:"amount"`       // Minor units
+	Currency    string `json:"currency"`
+	Reason      string `json:"reason,omitempty"`
+}
+
+// RecurringInvoiceLineRequest represents a fixed line item of a recurring invoice template
+type RecurringInvoiceLineRequest struct {
+	Description string `json:"description"`
+	Quantity    int64  `json:"quantity"`
+	UnitAmount  int64  `json:"unit_amount"`            // Minor units
+	TaxRateBps  int64  `json:"tax_rate_bps,omitempty"` // 2000 = 20% (0 = not taxed)
+
+	// Currency of the unit amount, defaults to the template currency; line items in another currency
+	// require the ExchangeRate (template currency units per line currency unit, e.g. "1.0835")
+	Currency     string `json:"currency,omitempty"`
+	ExchangeRate string `json:"exchange_rate,omitempty"`
+}
+
+// CreateRecurringInvoiceTemplateRequest represents the HTTP request body for creating a recurring invoice template
+type CreateRecurringInvoiceTemplateRequest struct {
+	ClientID       string                        `json:"client_id"`
+	Name           string                        `json:"name"`
+	Currency       string                        `json:"currency"` // Defaults to the tenant currency
+	LineItems      []RecurringInvoiceLineRequest `json:"line_items"`
+	Frequency      string                        `json:"frequency"` // weekly, monthly, quarterly, yearly
+	FirstIssueDate time.Time                     `json:"first_issue_date"`
+	EndDate        *time.Time                    `json:"end_date,omitempty"`
+	AutoSend       bool                          `json:"auto_send"`
+	LegalEntityID  string                        `json:"legal_entity_id,omitempty"` // Defaults to the default legal entity
+	BuyerCountry   string                        `json:"buyer_country,omitempty"`   // ISO 3166-1 alpha-2 country the client is taxed in
+	BuyerTaxID     string                        `json:"buyer_tax_id,omitempty"`    // VAT number of the client (reverse charge)
+	ExternalRef    string                        `json:"external_ref,omitempty"`    // Purchase order or order number; a second invoice of the client with it is rejected
+	AllowDuplicate bool                          `json:"allow_duplicate,omitempty"` // Create even when the client has an invoice with the same line items
+}
+
+// UpdateRecurringInvoiceTemplateRequest represents the HTTP request body for replacing a recurring invoice template's settings
+type UpdateRecurringInvoiceTemplateRequest struct {
+	Name          string                        `json:"name"`
+	LineItems     []RecurringInvoiceLineRequest `json:"line_items"`
+	Frequency     string                        `json:"frequency"`
+	EndDate       *time.Time                    `json:"end_date,omitempty"`
+	AutoSend      bool                          `json:"auto_send"`
+	Active        *bool                         `json:"active,omitempty"`          // Pause (false) or resume (true) issuing
+	LegalEntityID string                        `json:"legal_entity_id,omitempty"` // Empty reverts to the default legal entity
+	BuyerCountry  string                        `json:"buyer_country,omitempty"`
+	BuyerTaxID    string                        `json:"buyer_tax_id,omitempty"`
+}
+
+// CreateSubscriptionRequest represents the HTTP request body for subscribing a client to a plan
+type CreateSubscriptionRequest struct {
+	ClientID   string    `json:"client_id"`
+	Plan       string    `json:"plan"`
+	Currency   string    `json:"currency"`
+	UnitAmount int64     `json:"unit_amount"`            // Price of one unit per interval, in minor units
+	Quantity   int64     `json:"quantity"`               // Defaults to 1
+	TaxRateBps int64     `json:"tax_rate_bps,omitempty"` // 2000 = 20%, 0 = not taxed
+	Interval   string    `json:"interval"`               // weekly, monthly, quarterly, yearly
+	StartDate  time.Time `json:"start_date"`             // First billing date
+
+	Pricing     *PriceSchemeRequest           `json:"pricing,omitempty"`      // Tiered price of the quantity instead of the unit amount
+	UsagePrices map[string]PriceSchemeRequest `json:"usage_prices,omitempty"` // Price of the metered usage per metric, billed in arrears
+}
+
+// UpdateSubscriptionRequest represents the HTTP request body for changing the plan of a subscription
+type UpdateSubscriptionRequest struct {
+	Plan       string `json:"plan"`
+	UnitAmount int64  `json:"unit_amount"`
+	Quantity   int64  `json:"quantity"` // Defaults to 1
+	TaxRateBps int64  `json:"tax_rate_bps,omitempty"`
+
+	Pricing     *PriceSchemeRequest           `json:"pricing,omitempty"`      // Omitted reverts to the unit amount
+	UsagePrices map[string]PriceSchemeRequest `json:"usage_prices,omitempty"` // Replaces the usage prices (omitted: none)
+}
+
+// PriceSchemeRequest represents a tiered, volume or graduated price of a quantity
+type PriceSchemeRequest struct {
+	Model      string             `json:"model"`                 // per_unit, tiered, volume, graduated
+	UnitAmount int64              `json:"unit_amount,omitempty"` // Per-unit usage prices (the pricing of a subscription uses its unit amount)
+	UnitsPer   int64              `json:"units_per,omitempty"`   // Units the unit amounts are quoted for (default 1)
+	Tiers      []PriceTierRequest `json:"tiers,omitempty"`       // Brackets in increasing order, the last one unbounded
+}
+
+// PriceTierRequest represents one bracket of a tiered price
+type PriceTierRequest struct {
+	UpTo       int64 `json:"up_to,omitempty"` // Inclusive upper bound (omitted for the last, unbounded tier)
+	UnitAmount int64 `json:"unit_amount,omitempty"`
+	FlatAmount int64 `json:"flat_amount,omitempty"`
+}
+
+// InvoiceLineRequest represents a line item of an invoice
+type InvoiceLineRequest struct {
+	Description string `json:"description"`
+	Quantity    int64  `json:"quantity"`
+	UnitAmount  int64  `json:"unit_amount"`            // Minor units
+	TaxRateBps  *int64 `json:"tax_rate_bps,omitempty"` // 2000 = 20%, 0 = not taxed (omitted = rate of the tax jurisdiction)
+}
+
+// CreateInvoiceRequest represents the HTTP request body for creating a draft invoice
+type CreateInvoiceRequest struct {
+	ClientID        string               `json:"client_id"`
+	Currency        string               `json:"currency"`
+	LineItems       []InvoiceLineRequest `json:"line_items"`
+	TaxPricing      string               `json:"tax_pricing,omitempty"`      // exclusive (default, tax added to unit amounts) or inclusive
+	TaxJurisdiction string               `json:"tax_jurisdiction,omitempty"` // e.g. "FR" or "US-CA", rates line items without one
+	DueDate         *time.Time           `json:"due_date,omitempty"`
+	PaymentTerms    string               `json:"payment_terms,omitempty"`   // Due date computed when issued without one (default: the client's terms)
+	ExternalRef     string               `json:"external_ref,omitempty"`    // Purchase order or order number, unique per tenant
+	AllowDuplicate  bool                 `json:"allow_duplicate,omitempty"` // Create even when the client has an invoice with the same line items
+	LegalEntityID   string               `json:"legal_entity_id,omitempty"` // Defaults to the default legal entity
+	BuyerCountry    string               `json:"buyer_country,omitempty"`   // ISO 3166-1 alpha-2 country the client is taxed in
+	BuyerTaxID      string               `json:"buyer_tax_id,omitempty"`    // Tax number of the client, required by some compliance rules
+	Installments    *InstallmentsRequest `json:"installments,omitempty"`    // Splits the balance once issued (omitted = paid at once)
+}
+
+// InstallmentsRequest represents how the balance of an invoice is split into installments
+type InstallmentsRequest struct {
+	Count          int `json:"count"`                     // 2 to 60
+	IntervalMonths int `json:"interval_months,omitempty"` // 1 to 12 (default 1); the first installment is due on the due date
+}
+
+// UpdateInvoiceRequest represents the HTTP request body for replacing the content of a draft invoice
+type UpdateInvoiceRequest struct {
+	Currency        string               `json:"currency"`
+	LineItems       []InvoiceLineRequest `json:"line_items"`
+	TaxPricing      string               `json:"tax_pricing,omitempty"`
+	TaxJurisdiction string               `json:"tax_jurisdiction,omitempty"`
+	DueDate         *time.Time           `json:"due_date,omitempty"`
+	PaymentTerms    string               `json:"payment_terms,omitempty"`
+	LegalEntityID   string               `json:"legal_entity_id,omitempty"` // Empty reverts to the default legal entity
+	BuyerCountry    string               `json:"buyer_country,omitempty"`
+	BuyerTaxID      string               `json:"buyer_tax_id,omitempty"`
+	Installments    *InstallmentsRequest `json:"installments,omitempty"` // Omitted = paid at once
+}
+
+// CreateQuoteRequest represents the HTTP request body for offering a quote to a client
+type CreateQuoteRequest struct {
+	ClientID        string               `json:"client_id"`
+	Currency        string               `json:"currency"`
+	LineItems       []InvoiceLineRequest `json:"line_items"`
+	TaxPricing      string               `json:"tax_pricing,omitempty"`      // exclusive (default) or inclusive
+	TaxJurisdiction string               `json:"tax_jurisdiction,omitempty"` // Rates line items without one
+	ValidUntil      time.Time            `json:"valid_until"`                // Last moment the client can accept the quote
+}
+
+// RejectQuoteRequest represents the HTTP request body for recording a client's refusal of a quote
+type RejectQuoteRequest struct {
+	Reason string `json:"reason,omitempty"`
+}
+
+// RecordDeliveryEventRequest represents the HTTP request body for recording an invoice delivery event (mailer callback)
+type RecordDeliveryEventRequest struct {
+	Type       string     `json:"type"`                  // sent, delivered, viewed, bounced
+	Channel    string     `json:"channel,omitempty"`     // email (default), portal
+	Recipient  string     `json:"recipient,omitempty"`   // Email address the invoice went to
+	BounceType string     `json:"bounce_type,omitempty"` // hard, soft (bounced events only)
+	Detail     string     `json:"detail,omitempty"`      // Provider diagnostic, e.g. SMTP response
+	OccurredAt *time.Time `json:"occurred_at,omitempty"`
+}
+
+// ReconcileBankTransactionRequest represents the HTTP request body for confirming the invoice a bank transaction pays
+type ReconcileBankTransactionRequest struct {
+	InvoiceID string `json:"invoice_id"`
+	ClientID  string `json:"client_id"`
+}
+
+// SetCreditLimitRequest represents the HTTP request body for setting the credit limit of a client
+type SetCreditLimitRequest struct {
+	Amount   int64  `json:"amount"` // Minor units
+	Currency string `json:"currency"`
+	Policy   string `json:"policy"` // block, warn, require_approval
+}
+
+// PayInvoiceRequest represents the HTTP request body for paying an invoice by card
+// The invoice balance is charged to the invoice client, in the invoice currency
+type PayInvoiceRequest struct {
+	PaymentMethod string `json:"payment_method"` // Gateway token of the card
+}
+
+// RecordPaymentRequest represents the HTTP request body for recording a payment received against an invoice
+// Requests with a card PaymentMethod token charge the invoice balance through the payment gateway instead
+// (see PayInvoiceRequest)
+type RecordPaymentRequest struct {
+	Amount        int64      `json:"amount"` // Minor units, at most the invoice balance
+	Currency      string     `json:"currency"`
+	Method        string     `json:"method"`                   // bank_transfer, card, cash, check, other
+	Reference     string     `json:"reference,omitempty"`      // Transfer reference, check or receipt number
+	ReceivedAt    *time.Time `json:"received_at,omitempty"`    // Defaults to now
+	PaymentMethod string     `json:"payment_method,omitempty"` // Gateway token of the card to charge
+}
+
+// ClientStatementRequest represents the HTTP request body for requesting the account statement of a client
+type ClientStatementRequest struct {
+	From      time.Time `json:"from"`
+	To        time.Time `json:"to"`
+	Currency  string    `json:"currency,omitempty"`  // Required when the client has activity in several currencies
+	Email     bool      `json:"email,omitempty"`     // Email the statement once generated
+	Recipient string    `json:"recipient,omitempty"` // Defaults to the client email address
+}
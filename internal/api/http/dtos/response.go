@@ -1,18 +1,89 @@
 package dtos
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // ClientResponse represents the HTTP response body for a client
 type ClientResponse struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	Email        string    `json:"email"`
+	Phone        string    `json:"phone,omitempty"`
+	Address      string    `json:"address,omitempty"`
+	Country      string    `json:"country,omitempty"`
+	ExternalRef  string    `json:"external_ref,omitempty"`
+	Status       string    `json:"status"` // active, suspended or closed
+	Tags         []string  `json:"tags,omitempty"`
+	PaymentTerms string    `json:"payment_terms,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+
+	// Latest risk score, only returned to admins
+	Risk *ClientRiskResponse `json:"risk,omitempty"`
+}
+
+// ClientRiskResponse represents the latest risk assessment of a client
+type ClientRiskResponse struct {
+	Score      int                  `json:"score"`
+	Decision   string               `json:"decision"`
+	Reasons    []RiskReasonResponse `json:"reasons"`
+	Provider   string               `json:"provider"`
+	Trigger    string               `json:"trigger"`
+	AssessedAt time.Time            `json:"assessed_at"`
+	ExpiresAt  time.Time            `json:"expires_at"`
+	Expired    bool                 `json:"expired"` // Refreshed from the provider on the next large invoice
+}
+
+// RiskReasonResponse represents a factor behind a risk score
+type RiskReasonResponse struct {
+	Code        string `json:"code"`
+	Description string `json:"description,omitempty"`
+}
+
+// ClientContactResponse represents a contact of a client in HTTP responses
+type ClientContactResponse struct {
 	ID        string    `json:"id"`
+	ClientID  string    `json:"client_id"`
 	Name      string    `json:"name"`
+	Role      string    `json:"role,omitempty"`
 	Email     string    `json:"email"`
 	Phone     string    `json:"phone,omitempty"`
-	Address   string    `json:"address,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// ClientNoteResponse represents a note on the activity timeline of a client in HTTP responses
+type ClientNoteResponse struct {
+	ID        string    `json:"id"`
+	ClientID  string    `json:"client_id"`
+	Body      string    `json:"body"`
+	Author    string    `json:"author,omitempty"` // Admin who wrote the note, omitted when unknown
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// UploadResponse represents a resumable upload in HTTP responses
+type UploadResponse struct {
+	ID          string     `json:"id"`
+	Kind        string     `json:"kind"`
+	Format      string     `json:"format,omitempty"`
+	Size        int64      `json:"size"`
+	Offset      int64      `json:"offset"` // Bytes received; the next chunk is sent from there
+	Status      string     `json:"status"` // uploading, pending, processed or failed
+	Result      string     `json:"result,omitempty"`
+	Failure     string     `json:"failure,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// UploadRunResponse represents the outcome of an upload processing run
+type UploadRunResponse struct {
+	Processed int              `json:"processed"`
+	Uploads   []UploadResponse `json:"uploads"`
+}
+
 // ErrorResponse represents a structured error response
 type ErrorResponse struct {
 	Error   ErrorDetail `json:"error"`
@@ -21,13 +92,778 @@ type ErrorResponse struct {
 
 // ErrorDetail contains specific error information
 type ErrorDetail struct {
+	Code    string                 `json:"code"`
+	Message string                 `json:"message"`
+	Field   string                 `json:"field,omitempty"`
+	Details map[string]interface{} `json:"details,omitempty"` // Context of the rule broken, e.g. the existing resource of a duplicate
+}
+
+// SuccessResponse represents a successful operation response
+type SuccessResponse struct {
+	Data     interface{} `json:"data"`
+	Success  bool        `json:"success"`
+	Warnings []Warning   `json:"warnings,omitempty"` // Early signals that did not fail the request, e.g. approaching a credit limit
+}
+
+// Warning is a non-blocking signal returned with a successful response
+type Warning struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
 	Field   string `json:"field,omitempty"`
 }
 
-// SuccessResponse represents a successful operation response
-type SuccessResponse struct {
-	Data    interface{} `json:"data"`
-	Success bool        `json:"success"`
+// FormTokenResponse represents the HTTP response body for an issued form token
+type FormTokenResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// ClientSummaryResponse represents a row of the client list with its balances and last invoice
+type ClientSummaryResponse struct {
+	ClientID     string          `json:"client_id"`
+	Name         string          `json:"name"`
+	Email        string          `json:"email"`
+	Balances     []MoneyResponse `json:"balances"`
+	OpenInvoices int             `json:"open_invoices"`
+	LastInvoice  *LastInvoiceRef `json:"last_invoice,omitempty"`
+	RefreshedAt  time.Time       `json:"refreshed_at"`
+}
+
+// LastInvoiceRef represents the most recently issued invoice of a client
+type LastInvoiceRef struct {
+	ID       string        `json:"id"`
+	IssuedAt time.Time     `json:"issued_at"`
+	Total    MoneyResponse `json:"total"`
+}
+
+// ClientSummaryRebuildResponse represents the HTTP response body of a client list read model rebuild
+type ClientSummaryRebuildResponse struct {
+	Rebuilt int `json:"rebuilt"`
+}
+
+// BatchGetClientsResponse represents the HTTP response body of a batch get of clients
+type BatchGetClientsResponse struct {
+	Clients  []ClientResponse `json:"clients"`   // In the order of the requested IDs
+	NotFound []string         `json:"not_found"` // Requested IDs without a client
+}
+
+// ClientCountResponse represents the HTTP response body for a client count
+type ClientCountResponse struct {
+	Count int `json:"count"`
+}
+
+// ClientChangeResponse represents a single entry of the client change feed
+// Client is omitted for deletions (tombstones)
+type ClientChangeResponse struct {
+	Sequence   int64           `json:"sequence"`
+	Type       string          `json:"type"`
+	ClientID   string          `json:"client_id"`
+	Client     *ClientResponse `json:"client,omitempty"`
+	OccurredAt time.Time       `json:"occurred_at"`
+}
+
+// ClientChangesResponse represents the HTTP response body for the client change feed
+type ClientChangesResponse struct {
+	Changes    []ClientChangeResponse `json:"changes"`
+	NextCursor string                 `json:"next_cursor"`
+	HasMore    bool                   `json:"has_more"`
+}
+
+// EventResponse represents a single event of the event log
+type EventResponse struct {
+	Sequence    int64             `json:"sequence"`
+	Type        string            `json:"type"`
+	AggregateID string            `json:"aggregate_id"`
+	Payload     json.RawMessage   `json:"payload"`
+	Headers     map[string]string `json:"headers,omitempty"`
+	OccurredAt  time.Time         `json:"occurred_at"`
+}
+
+// EventLogResponse represents the HTTP response body for the event log
+type EventLogResponse struct {
+	Events     []EventResponse `json:"events"`
+	NextCursor string          `json:"next_cursor"`
+	HasMore    bool            `json:"has_more"`
+}
+
+// PortalAccessTokenResponse represents the HTTP response body for an issued portal access token
+type PortalAccessTokenResponse struct {
+	Token     string    `json:"token"`
+	ClientID  string    `json:"client_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// PortalSignInLinkResponse represents the HTTP response body for an issued portal sign-in link
+type PortalSignInLinkResponse struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// PortalProfileResponse represents the account details a client sees in the self-service portal
+type PortalProfileResponse struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Email   string `json:"email"`
+	Phone   string `json:"phone,omitempty"`
+	Address string `json:"address,omitempty"`
+}
+
+// PortalInvoiceResponse represents an invoice as its client sees it in the self-service portal: the document and its
+// payment status, without the tenant, legal entity and dunning details of the admin view
+type PortalInvoiceResponse struct {
+	ID           string                   `json:"id"`
+	Number       string                   `json:"number,omitempty"`
+	Status       string                   `json:"status"`
+	Currency     string                   `json:"currency"`
+	LineItems    []InvoiceLineResponse    `json:"line_items"`
+	Subtotal     MoneyResponse            `json:"subtotal"`
+	Tax          MoneyResponse            `json:"tax"`
+	TaxBreakdown []InvoiceTaxRateResponse `json:"tax_breakdown"`
+	Total        MoneyResponse            `json:"total"`
+	AmountPaid   MoneyResponse            `json:"amount_paid"`
+	Balance      MoneyResponse            `json:"balance"`
+	DueDate      *time.Time               `json:"due_date,omitempty"`
+	PaymentTerms string                   `json:"payment_terms,omitempty"`
+	ExternalRef  string                   `json:"external_ref,omitempty"`
+	Installments *InstallmentPlanResponse `json:"installments,omitempty"`
+	IssuedAt     *time.Time               `json:"issued_at,omitempty"`
+	PaidAt       *time.Time               `json:"paid_at,omitempty"`
+	VoidedAt     *time.Time               `json:"voided_at,omitempty"`
+}
+
+// UsageRecordResult represents the outcome of one event in a usage ingestion batch
+// Status is "created" for new records and "duplicate" when the idempotency key was already ingested
+type UsageRecordResult struct {
+	ID             string `json:"id"`
+	SubscriptionID string `json:"subscription_id"`
+	IdempotencyKey string `json:"idempotency_key"`
+	Status         string `json:"status"`
+}
+
+// IngestUsageRecordsResponse represents the HTTP response body for a usage ingestion batch
+type IngestUsageRecordsResponse struct {
+	Accepted   int                 `json:"accepted"`
+	Duplicates int                 `json:"duplicates"`
+	Records    []UsageRecordResult `json:"records"`
+}
+
+// UsageMetricSummary represents the total usage of one metric over a billing period
+type UsageMetricSummary struct {
+	Metric      string `json:"metric"`
+	Quantity    int64  `json:"quantity"`
+	RecordCount int    `json:"record_count"`
+}
+
+// UsageSummaryResponse represents the usage of a subscription aggregated over a billing period
+type UsageSummaryResponse struct {
+	SubscriptionID string               `json:"subscription_id"`
+	PeriodStart    time.Time            `json:"period_start"`
+	PeriodEnd      time.Time            `json:"period_end"`
+	Metrics        []UsageMetricSummary `json:"metrics"`
+}
+
+// MoneyResponse represents an amount in minor units of its currency
+type MoneyResponse struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// ContractInvoiceResponse represents an invoice counted towards a contract
+type ContractInvoiceResponse struct {
+	InvoiceID  string        `json:"invoice_id"`
+	Amount     MoneyResponse `json:"amount"`
+	InvoicedAt time.Time     `json:"invoiced_at"`
+}
+
+// ContractAttainmentResponse represents commitment attainment of a contract
+type ContractAttainmentResponse struct {
+	Committed      MoneyResponse `json:"committed"`
+	Spent          MoneyResponse `json:"spent"`
+	Remaining      MoneyResponse `json:"remaining"`
+	AttainedBps    int64         `json:"attained_bps"`
+	TermElapsedBps int64         `json:"term_elapsed_bps"`
+	Attained       bool          `json:"attained"`
+	OnTrack        bool          `json:"on_track"`
+}
+
+// ContractResponse represents the HTTP response body for a customer contract
+type ContractResponse struct {
+	ID                    string                     `json:"id"`
+	ClientID              string                     `json:"client_id"`
+	Name                  string                     `json:"name"`
+	AccountManager        string                     `json:"account_manager,omitempty"`
+	StartDate             time.Time                  `json:"start_date"`
+	EndDate               time.Time                  `json:"end_date"`
+	RenewalDate           time.Time                  `json:"renewal_date"`
+	CommittedAmount       MoneyResponse              `json:"committed_amount"`
+	SubscriptionIDs       []string                   `json:"subscription_ids"`
+	Invoices              []ContractInvoiceResponse  `json:"invoices"`
+	Attainment            ContractAttainmentResponse `json:"attainment"`
+	RenewalReminderSentAt *time.Time                 `json:"renewal_reminder_sent_at,omitempty"`
+	CreatedAt             time.Time                  `json:"created_at"`
+	UpdatedAt             time.Time                  `json:"updated_at"`
+}
+
+// ContractAttainmentReportEntry represents one contract in the commitment attainment report
+type ContractAttainmentReportEntry struct {
+	ContractID     string                     `json:"contract_id"`
+	ClientID       string                     `json:"client_id"`
+	Name           string                     `json:"name"`
+	AccountManager string                     `json:"account_manager,omitempty"`
+	RenewalDate    time.Time                  `json:"renewal_date"`
+	Attainment     ContractAttainmentResponse `json:"attainment"`
+}
+
+// RenewalRemindersResponse represents the outcome of a renewal reminder run
+type RenewalRemindersResponse struct {
+	Sent        int      `json:"sent"`
+	ContractIDs []string `json:"contract_ids"`
+}
+
+// ApprovalRequestResponse represents the HTTP response body for a credit note or refund approval request
+type ApprovalRequestResponse struct {
+	ID          string        `json:"id"`
+	Kind        string        `json:"kind"`
+	ClientID    string        `json:"client_id"`
+	ReferenceID string        `json:"reference_id"`
+	Amount      MoneyResponse `json:"amount"`
+	Reason      string        `json:"reason,omitempty"`
+	Status      string        `json:"status"`
+	RequestedBy string        `json:"requested_by"`
+	ApprovedBy  string        `json:"approved_by,omitempty"`
+	ApprovedAt  *time.Time    `json:"approved_at,omitempty"`
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+}
+
+// InvoiceLineResponse represents a line item of an invoice
+type InvoiceLineResponse struct {
+	Description string        `json:"description"`
+	Quantity    int64         `json:"quantity"`
+	UnitAmount  int64         `json:"unit_amount"`
+	TaxRateBps  int64         `json:"tax_rate_bps"`
+	Amount      MoneyResponse `json:"amount"`     // Quantity × unit amount (tax included with inclusive pricing)
+	NetAmount   MoneyResponse `json:"net_amount"` // Amount before tax
+	TaxAmount   MoneyResponse `json:"tax_amount"`
+}
+
+// InvoiceTaxRateResponse represents the taxable amount and tax of the line items of an invoice taxed at one rate
+type InvoiceTaxRateResponse struct {
+	RateBps int64         `json:"rate_bps"`
+	Taxable MoneyResponse `json:"taxable"`
+	Tax     MoneyResponse `json:"tax"`
+}
+
+// InvoiceResponse represents the HTTP response body for an invoice
+type InvoiceResponse struct {
+	ID              string                     `json:"id"`
+	TenantID        string                     `json:"tenant_id,omitempty"`
+	ClientID        string                     `json:"client_id"`
+	Currency        string                     `json:"currency"`
+	Status          string                     `json:"status"`
+	Number          string                     `json:"number,omitempty"` // Assigned when the invoice is issued
+	LineItems       []InvoiceLineResponse      `json:"line_items"`
+	TaxPricing      string                     `json:"tax_pricing"`
+	TaxJurisdiction string                     `json:"tax_jurisdiction,omitempty"`
+	Subtotal        MoneyResponse              `json:"subtotal"`
+	Tax             MoneyResponse              `json:"tax"`
+	TaxBreakdown    []InvoiceTaxRateResponse   `json:"tax_breakdown"` // Per tax rate, in ascending rate order
+	Total           MoneyResponse              `json:"total"`
+	AmountPaid      MoneyResponse              `json:"amount_paid"`
+	Balance         MoneyResponse              `json:"balance"`
+	DueDate         *time.Time                 `json:"due_date,omitempty"`
+	PaymentTerms    string                     `json:"payment_terms,omitempty"`
+	ExternalRef     string                     `json:"external_ref,omitempty"`
+	LegalEntityID   string                     `json:"legal_entity_id,omitempty"` // Set on drafts assigned to an entity, and once issued
+	BuyerCountry    string                     `json:"buyer_country,omitempty"`
+	BuyerTaxID      string                     `json:"buyer_tax_id,omitempty"`
+	FiscalYear      int                        `json:"fiscal_year,omitempty"`   // Set when issued with fiscal calendars
+	FiscalPeriod    string                     `json:"fiscal_period,omitempty"` // e.g. 2026-P03
+	Compliance      *InvoiceComplianceResponse `json:"compliance,omitempty"`    // Set when issued with compliance rules
+	Installments    *InstallmentPlanResponse   `json:"installments,omitempty"`  // Set when the balance is split into installments
+	IssuedAt        *time.Time                 `json:"issued_at,omitempty"`
+	PaidAt          *time.Time                 `json:"paid_at,omitempty"`
+	VoidedAt        *time.Time                 `json:"voided_at,omitempty"`
+	Dunning         *InvoiceDunningResponse    `json:"dunning,omitempty"` // Set once a payment reminder was sent
+	CreatedAt       time.Time                  `json:"created_at"`
+	UpdatedAt       time.Time                  `json:"updated_at"`
+}
+
+// InvoiceComplianceResponse represents what the compliance rules of the seller and buyer countries require on an invoice
+type InvoiceComplianceResponse struct {
+	TaxBreakdown  string   `json:"tax_breakdown"` // total, per_rate or per_line
+	LegalMentions []string `json:"legal_mentions"`
+}
+
+// InstallmentPlanResponse represents the installments the balance of an invoice is split into
+type InstallmentPlanResponse struct {
+	Count          int                   `json:"count"`
+	IntervalMonths int                   `json:"interval_months"`
+	Status         string                `json:"status,omitempty"` // active, overdue or completed; set once issued
+	Outstanding    *MoneyResponse        `json:"outstanding,omitempty"`
+	Schedule       []InstallmentResponse `json:"schedule,omitempty"` // Set once issued, earliest first
+}
+
+// InstallmentResponse represents one installment of an invoice
+type InstallmentResponse struct {
+	Sequence int           `json:"sequence"`
+	DueDate  time.Time     `json:"due_date"`
+	Amount   MoneyResponse `json:"amount"`
+	Paid     MoneyResponse `json:"paid"`
+	Status   string        `json:"status"` // pending, partially_paid, paid or overdue
+}
+
+// InvoiceDunningResponse represents the dunning status of an overdue invoice
+type InvoiceDunningResponse struct {
+	Level          int                    `json:"level"` // Level of the last reminder sent; levels only escalate
+	LastRemindedAt time.Time              `json:"last_reminded_at"`
+	Events         []DunningEventResponse `json:"events"` // Oldest first
+}
+
+// DunningEventResponse represents a payment reminder sent for an overdue invoice
+type DunningEventResponse struct {
+	Level       int            `json:"level"`
+	Channel     string         `json:"channel"`
+	Template    string         `json:"template"`
+	DaysOverdue int            `json:"days_overdue"`
+	SentAt      time.Time      `json:"sent_at"`
+	LateFee     *MoneyResponse `json:"late_fee,omitempty"` // Late fee charged with the reminder, added to the balance
+}
+
+// QuoteResponse represents a quote in HTTP responses
+type QuoteResponse struct {
+	ID              string                `json:"id"`
+	TenantID        string                `json:"tenant_id,omitempty"`
+	ClientID        string                `json:"client_id"`
+	Currency        string                `json:"currency"`
+	Status          string                `json:"status"`
+	LineItems       []InvoiceLineResponse `json:"line_items"`
+	TaxPricing      string                `json:"tax_pricing"`
+	TaxJurisdiction string                `json:"tax_jurisdiction,omitempty"`
+	Subtotal        MoneyResponse         `json:"subtotal"`
+	Tax             MoneyResponse         `json:"tax"`
+	Total           MoneyResponse         `json:"total"`
+	ValidUntil      time.Time             `json:"valid_until"`
+	AcceptedAt      *time.Time            `json:"accepted_at,omitempty"`
+	RejectedAt      *time.Time            `json:"rejected_at,omitempty"`
+	RejectionReason string                `json:"rejection_reason,omitempty"`
+	ExpiredAt       *time.Time            `json:"expired_at,omitempty"`
+	InvoiceID       string                `json:"invoice_id,omitempty"` // Invoice the quote was converted to
+	ConvertedAt     *time.Time            `json:"converted_at,omitempty"`
+	CreatedAt       time.Time             `json:"created_at"`
+	UpdatedAt       time.Time             `json:"updated_at"`
+}
+
+// QuoteExpiryRunResponse represents the outcome of a quote expiry run
+type QuoteExpiryRunResponse struct {
+	Expired []string `json:"expired"` // Open quotes past their validity date, expired by this run
+}
+
+// PaymentResponse represents a payment received against an invoice
+type PaymentResponse struct {
+	ID         string        `json:"id"`
+	InvoiceID  string        `json:"invoice_id"`
+	Amount     MoneyResponse `json:"amount"`
+	Method     string        `json:"method"`
+	Reference  string        `json:"reference,omitempty"`
+	ReceivedAt time.Time     `json:"received_at"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+// RecordPaymentResponse represents the HTTP response body for a recorded payment, with the invoice it was applied to
+type RecordPaymentResponse struct {
+	Payment PaymentResponse `json:"payment"`
+	Invoice InvoiceResponse `json:"invoice"`
+}
+
+// RecurringInvoiceLineResponse represents a fixed line item of a recurring invoice template
+type RecurringInvoiceLineResponse struct {
+	Description string        `json:"description"`
+	Quantity    int64         `json:"quantity"`
+	UnitAmount  int64         `json:"unit_amount"`
+	TaxRateBps  int64         `json:"tax_rate_bps"`
+	Amount      MoneyResponse `json:"amount"`
+}
+
+// RecurringInvoiceTemplateResponse represents the HTTP response body for a recurring invoice template
+type RecurringInvoiceTemplateResponse struct {
+	ID             string                         `json:"id"`
+	TenantID       string                         `json:"tenant_id,omitempty"`
+	ClientID       string                         `json:"client_id"`
+	Name           string                         `json:"name"`
+	Currency       string                         `json:"currency"`
+	LineItems      []RecurringInvoiceLineResponse `json:"line_items"`
+	Total          MoneyResponse                  `json:"total"`
+	Frequency      string                         `json:"frequency"`
+	FirstIssueDate time.Time                      `json:"first_issue_date"`
+	EndDate        *time.Time                     `json:"end_date,omitempty"`
+	NextIssueDate  *time.Time                     `json:"next_issue_date,omitempty"`
+	AutoSend       bool                           `json:"auto_send"`
+	LegalEntityID  string                         `json:"legal_entity_id,omitempty"`
+	BuyerCountry   string                         `json:"buyer_country,omitempty"`
+	BuyerTaxID     string                         `json:"buyer_tax_id,omitempty"`
+	ExternalRef    string                         `json:"external_ref,omitempty"`
+	Active         bool                           `json:"active"`
+	IssuedCount    int                            `json:"issued_count"`
+	LastIssuedAt   *time.Time                     `json:"last_issued_at,omitempty"`
+	CreatedAt      time.Time                      `json:"created_at"`
+	UpdatedAt      time.Time                      `json:"updated_at"`
+}
+
+// SubscriptionResponse represents a subscription in HTTP responses
+type SubscriptionResponse struct {
+	ID              string                         `json:"id"`
+	TenantID        string                         `json:"tenant_id,omitempty"`
+	ClientID        string                         `json:"client_id"`
+	Plan            string                         `json:"plan"`
+	Currency        string                         `json:"currency"`
+	UnitAmount      int64                          `json:"unit_amount"`
+	Quantity        int64                          `json:"quantity"`
+	Pricing         *PriceSchemeResponse           `json:"pricing,omitempty"`
+	UsagePrices     map[string]PriceSchemeResponse `json:"usage_prices,omitempty"`
+	TaxRateBps      int64                          `json:"tax_rate_bps"`
+	Amount          MoneyResponse                  `json:"amount"` // Price of one period before tax
+	Interval        string                         `json:"interval"`
+	StartDate       time.Time                      `json:"start_date"`
+	NextBillingDate *time.Time                     `json:"next_billing_date,omitempty"` // Omitted once canceled
+	Status          string                         `json:"status"`
+	BilledCount     int                            `json:"billed_count"`
+	LastInvoiceID   string                         `json:"last_invoice_id,omitempty"`
+	LastBilledAt    *time.Time                     `json:"last_billed_at,omitempty"`
+	CanceledAt      *time.Time                     `json:"canceled_at,omitempty"`
+	CreatedAt       time.Time                      `json:"created_at"`
+	UpdatedAt       time.Time                      `json:"updated_at"`
+
+	ProrationCredit *MoneyResponse                 `json:"proration_credit,omitempty"` // Deducted from the next billed periods
+	Proration       *SubscriptionProrationResponse `json:"proration,omitempty"`        // Only in responses to plan changes
+}
+
+// PriceSchemeResponse represents a tiered, volume or graduated price of a quantity
+type PriceSchemeResponse struct {
+	Model      string              `json:"model"`
+	UnitAmount int64               `json:"unit_amount,omitempty"`
+	UnitsPer   int64               `json:"units_per"`
+	Tiers      []PriceTierResponse `json:"tiers,omitempty"`
+}
+
+// PriceTierResponse represents one bracket of a tiered price
+type PriceTierResponse struct {
+	UpTo       int64 `json:"up_to,omitempty"`
+	UnitAmount int64 `json:"unit_amount"`
+	FlatAmount int64 `json:"flat_amount"`
+}
+
+// SubscriptionProrationResponse represents the proration of a plan change made during a billed period
+// A positive net is invoiced right away (invoice_id), a negative one is credited on the next billed periods
+type SubscriptionProrationResponse struct {
+	PeriodDays    int           `json:"period_days"`
+	RemainingDays int           `json:"remaining_days"`
+	Credit        MoneyResponse `json:"credit"`
+	Charge        MoneyResponse `json:"charge"`
+	Net           MoneyResponse `json:"net"`
+	InvoiceID     string        `json:"invoice_id,omitempty"`
+}
+
+// SubscriptionBillResponse represents one period of a subscription billed by a scheduler run
+type SubscriptionBillResponse struct {
+	SubscriptionID string        `json:"subscription_id"`
+	ClientID       string        `json:"client_id"`
+	BillingDate    time.Time     `json:"billing_date"`
+	InvoiceID      string        `json:"invoice_id"`
+	Total          MoneyResponse `json:"total"`
+}
+
+// SubscriptionBillingRunResponse represents the outcome of a subscription billing run
+type SubscriptionBillingRunResponse struct {
+	Billed   []SubscriptionBillResponse `json:"billed"`
+	Canceled []string                   `json:"canceled"` // Subscriptions of deleted clients, canceled by the run
+}
+
+// RecurringInvoiceIssueResponse represents one invoice issued from a template by a scheduler run
+type RecurringInvoiceIssueResponse struct {
+	TemplateID    string    `json:"template_id"`
+	InvoiceID     string    `json:"invoice_id"`
+	ClientID      string    `json:"client_id"`
+	IssueDate     time.Time `json:"issue_date"`
+	LegalEntityID string    `json:"legal_entity_id,omitempty"`
+	InvoiceNumber string    `json:"invoice_number,omitempty"`
+	CreditWarning bool      `json:"credit_warning,omitempty"` // Issued over the client's credit limit (warn policy)
+	FiscalPeriod  string    `json:"fiscal_period,omitempty"`  // Fiscal period the invoice is reported in
+}
+
+// RiskDeclinedHold is the decision reported for invoices held because the client risk score was declined
+const RiskDeclinedHold = "risk_declined"
+
+// PeriodClosedHold is the decision reported for invoices held because their issue date falls in a closed fiscal period
+const PeriodClosedHold = "period_closed"
+
+// NonCompliantHold is the decision reported for invoices held because they break a compliance rule of their countries
+const NonCompliantHold = "non_compliant"
+
+// ComplianceViolationResponse represents a compliance requirement an invoice does not meet
+type ComplianceViolationResponse struct {
+	Rule    string `json:"rule"`
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// RecurringInvoiceHoldResponse represents an invoice held by a period lock, compliance rules, credit control or risk scoring during a scheduler run
+type RecurringInvoiceHoldResponse struct {
+	TemplateID   string                        `json:"template_id"`
+	ClientID     string                        `json:"client_id"`
+	IssueDate    time.Time                     `json:"issue_date"`
+	Decision     string                        `json:"decision"`                // period_closed, non_compliant, blocked, approval_required, risk_declined
+	ApprovalID   string                        `json:"approval_id,omitempty"`   // Override awaiting approval
+	Violations   []ComplianceViolationResponse `json:"violations,omitempty"`    // Requirements a non-compliant invoice misses
+	FiscalPeriod string                        `json:"fiscal_period,omitempty"` // Closed period the issue date falls in
+}
+
+// RecurringInvoiceRunResponse represents the outcome of a recurring invoice scheduler run
+type RecurringInvoiceRunResponse struct {
+	Issued       int                             `json:"issued"`
+	Invoices     []RecurringInvoiceIssueResponse `json:"invoices"`
+	Held         int                             `json:"held"`
+	HeldInvoices []RecurringInvoiceHoldResponse  `json:"held_invoices"`
+}
+
+// DeliveryEventResponse represents the HTTP response body for an invoice delivery event
+type DeliveryEventResponse struct {
+	ID                string    `json:"id"`
+	InvoiceID         string    `json:"invoice_id"`
+	Type              string    `json:"type"`
+	Channel           string    `json:"channel"`
+	Recipient         string    `json:"recipient,omitempty"`
+	BounceType        string    `json:"bounce_type,omitempty"`
+	Detail            string    `json:"detail,omitempty"`
+	OccurredAt        time.Time `json:"occurred_at"`
+	RecordedAt        time.Time `json:"recorded_at"`
+	TrackingPixelPath string    `json:"tracking_pixel_path,omitempty"` // Returned for sent emails when view tracking is enabled
+}
+
+// InvoiceDeliveryResponse represents the HTTP response body for the delivery history of an invoice
+type InvoiceDeliveryResponse struct {
+	InvoiceID     string                  `json:"invoice_id"`
+	Status        string                  `json:"status"`
+	LastSentAt    *time.Time              `json:"last_sent_at,omitempty"`
+	FirstViewedAt *time.Time              `json:"first_viewed_at,omitempty"`
+	LastViewedAt  *time.Time              `json:"last_viewed_at,omitempty"`
+	ViewCount     int                     `json:"view_count"`
+	BounceCount   int                     `json:"bounce_count"`
+	LastBouncedAt *time.Time              `json:"last_bounced_at,omitempty"`
+	Events        []DeliveryEventResponse `json:"events"`
+}
+
+// CashMatchResponse represents an open invoice suggested for a bank transaction
+type CashMatchResponse struct {
+	InvoiceID   string        `json:"invoice_id"`
+	Number      string        `json:"number"`
+	ClientID    string        `json:"client_id"`
+	Outstanding MoneyResponse `json:"outstanding"`
+	Score       int           `json:"score"`
+	Reasons     []string      `json:"reasons"` // reference, exact_amount, counterparty
+}
+
+// BankTransactionResponse represents the HTTP response body for an imported bank transaction
+type BankTransactionResponse struct {
+	ID               string              `json:"id"`
+	AccountIBAN      string              `json:"account_iban,omitempty"`
+	BankReference    string              `json:"bank_reference,omitempty"`
+	BookingDate      time.Time           `json:"booking_date"`
+	Amount           MoneyResponse       `json:"amount"`
+	Reference        string              `json:"reference,omitempty"`
+	CounterpartyName string              `json:"counterparty_name,omitempty"`
+	CounterpartyIBAN string              `json:"counterparty_iban,omitempty"`
+	Status           string              `json:"status"`
+	InvoiceID        string              `json:"invoice_id,omitempty"`
+	ClientID         string              `json:"client_id,omitempty"`
+	ReconciledBy     string              `json:"reconciled_by,omitempty"`
+	ReconciledAt     *time.Time          `json:"reconciled_at,omitempty"`
+	ImportedAt       time.Time           `json:"imported_at"`
+	Suggestions      []CashMatchResponse `json:"suggestions"`
+}
+
+// StatementImportResponse represents the HTTP response body for a bank statement import
+type StatementImportResponse struct {
+	Format        string                    `json:"format"`
+	AccountIBAN   string                    `json:"account_iban,omitempty"`
+	Imported      int                       `json:"imported"`
+	Duplicates    int                       `json:"duplicates"`
+	SkippedDebits int                       `json:"skipped_debits"`
+	Transactions  []BankTransactionResponse `json:"transactions"`
+}
+
+// ImportFieldResponse describes a client field the columns of an imported file can be mapped to
+type ImportFieldResponse struct {
+	Name     string `json:"name"`
+	Required bool   `json:"required"`
+}
+
+// ImportPreviewResponse represents the HTTP response body for the mapping step of a client import
+type ImportPreviewResponse struct {
+	Delimiter        string                `json:"delimiter"`
+	Columns          []string              `json:"columns"`
+	SampleRows       [][]string            `json:"sample_rows"`
+	Fields           []ImportFieldResponse `json:"fields"`
+	SuggestedMapping map[string]string     `json:"suggested_mapping"` // Client field -> column
+}
+
+// ImportRowErrorResponse is a row of an imported file that was not imported
+type ImportRowErrorResponse struct {
+	Line  int         `json:"line"`
+	Error ErrorDetail `json:"error"`
+}
+
+// ClientImportResponse represents the HTTP response body for a client import
+type ClientImportResponse struct {
+	Rows     int                      `json:"rows"`
+	Imported int                      `json:"imported"`
+	Failed   []ImportRowErrorResponse `json:"failed"`
+}
+
+// ImportRowResultResponse is the outcome of a row of an imported file: the client created, or why none was
+type ImportRowResultResponse struct {
+	Line     int          `json:"line"`
+	Status   string       `json:"status"` // "imported" or "failed"
+	ClientID string       `json:"client_id,omitempty"`
+	Error    *ErrorDetail `json:"error,omitempty"`
+}
+
+// ClientImportReportResponse represents the HTTP response body for a client import reported row by row
+type ClientImportReportResponse struct {
+	Mapping  map[string]string         `json:"mapping"` // Client field -> column
+	Rows     int                       `json:"rows"`
+	Imported int                       `json:"imported"`
+	Failed   int                       `json:"failed"`
+	Results  []ImportRowResultResponse `json:"results"` // In file order
+}
+
+// CreditLimitResponse represents the credit limit of a client
+type CreditLimitResponse struct {
+	Amount    int64     `json:"amount"`
+	Currency  string    `json:"currency"`
+	Policy    string    `json:"policy"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CreditExposureResponse represents what a client owes in a currency, net of its unapplied credit
+type CreditExposureResponse struct {
+	Currency         string `json:"currency"`
+	OpenInvoices     int64  `json:"open_invoices"`
+	OpenInvoiceCount int    `json:"open_invoice_count"`
+	UnappliedCredit  int64  `json:"unapplied_credit"`
+	Exposure         int64  `json:"exposure"`
+}
+
+// ClientCreditResponse represents the HTTP response body for the credit position of a client
+type ClientCreditResponse struct {
+	ClientID    string                   `json:"client_id"`
+	CreditLimit *CreditLimitResponse     `json:"credit_limit"` // null when the client has no limit
+	Exposure    []CreditExposureResponse `json:"exposure"`
+	Available   *int64                   `json:"available,omitempty"` // Room left under the limit, in the limit currency
+	OverLimit   bool                     `json:"over_limit"`
+}
+
+// ClientStatementJobResponse represents a client statement request and, once generated, where to download it
+type ClientStatementJobResponse struct {
+	ID             string     `json:"id"`
+	ClientID       string     `json:"client_id"`
+	Status         string     `json:"status"` // pending, completed, failed
+	From           time.Time  `json:"from"`
+	To             time.Time  `json:"to"`
+	Currency       string     `json:"currency,omitempty"`
+	Locale         string     `json:"locale,omitempty"`
+	Recipient      string     `json:"recipient,omitempty"` // Set when the statement is emailed
+	ClosingBalance *int64     `json:"closing_balance,omitempty"`
+	Failure        string     `json:"failure,omitempty"`
+	DocumentURL    string     `json:"document_url,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	CompletedAt    *time.Time `json:"completed_at,omitempty"`
+}
+
+// ClientStatementRunResponse represents the outcome of a client statement generation run
+type ClientStatementRunResponse struct {
+	Processed int                          `json:"processed"`
+	Jobs      []ClientStatementJobResponse `json:"jobs"`
+}
+
+// WebhookEventResponse represents an inbound webhook event with its processing state
+type WebhookEventResponse struct {
+	ID          string          `json:"id"`
+	Provider    string          `json:"provider"`
+	EventID     string          `json:"event_id"`
+	EventType   string          `json:"event_type"`
+	Status      string          `json:"status"`
+	Attempts    int             `json:"attempts"`
+	LastError   string          `json:"last_error,omitempty"`
+	ReceivedAt  time.Time       `json:"received_at"`
+	ProcessedAt *time.Time      `json:"processed_at,omitempty"`
+	Payload     json.RawMessage `json:"payload,omitempty"` // Admin endpoints only
+}
+
+// WebhookReceiptResponse represents the HTTP response body acknowledging a webhook delivery
+type WebhookReceiptResponse struct {
+	Event     WebhookEventResponse `json:"event"`
+	Duplicate bool                 `json:"duplicate"`
+}
+
+// WebhookRunResponse represents the HTTP response body for a webhook queue processing run
+type WebhookRunResponse struct {
+	Processed int                    `json:"processed"`
+	Events    []WebhookEventResponse `json:"events"`
+}
+
+// WebhookSubscriptionResponse represents a consumer webhook subscription
+// The signing secret is only returned when the subscription is created
+type WebhookSubscriptionResponse struct {
+	ID           string     `json:"id"`
+	URL          string     `json:"url"`
+	EventTypes   []string   `json:"event_types"`
+	Secret       string     `json:"secret,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	LastReplayAt *time.Time `json:"last_replay_at,omitempty"`
+}
+
+// WebhookReplayResponse represents the outcome of a replay of historical events to a webhook subscription
+type WebhookReplayResponse struct {
+	SubscriptionID string `json:"subscription_id"`
+	From           string `json:"from"`
+	Delivered      int    `json:"delivered"`
+	LastEventID    string `json:"last_event_id,omitempty"`
+	FailedEventID  string `json:"failed_event_id,omitempty"`
+	Error          string `json:"error,omitempty"`
+	HasMore        bool   `json:"has_more"`
+	NextFrom       string `json:"next_from,omitempty"` // Event ID to replay from to resume
+}
+
+// CapabilitiesResponse describes what this deployment of the API supports, so clients adapt without configuration
+type CapabilitiesResponse struct {
+	Version    string                 `json:"version"`
+	Features   map[string]bool        `json:"features"`
+	Pagination PaginationCapabilities `json:"pagination"`
+	Limits     LimitCapabilities      `json:"limits"`
+	Currencies CurrencyCapabilities   `json:"currencies"`
+}
+
+// PaginationCapabilities describes how list endpoints page their results
+type PaginationCapabilities struct {
+	Modes           []string `json:"modes"` // page: ?page=&limit= on lists; cursor: ?since= on change feeds, ?cursor= on the client list
+	DefaultPageSize int      `json:"default_page_size"`
+	MaxPageSize     int      `json:"max_page_size"`
+}
+
+// LimitCapabilities lists the size limits of request bodies (0 when the feature is disabled)
+type LimitCapabilities struct {
+	MaxPageSize         int   `json:"max_page_size"`
+	MaxWebhookBytes     int64 `json:"max_webhook_bytes"`
+	MaxStatementBytes   int64 `json:"max_statement_bytes"`
+	MaxImportBytes      int64 `json:"max_import_bytes"`
+	MaxUploadBytes      int64 `json:"max_upload_bytes"`
+	MaxUploadChunkBytes int64 `json:"max_upload_chunk_bytes"`
+}
+
+// CurrencyCapabilities lists the currencies of the configured currency policies
+// Invoices accept any ISO 4217 currency; these are the defaults applied when none is given
+type CurrencyCapabilities struct {
+	Default    string   `json:"default,omitempty"`
+	Configured []string `json:"configured"`
 }
@@ -0,0 +1,134 @@
+package dtos
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"sync"
+	"time"
+)
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+	// timeCarriers caches whether values of a type can hold a timestamp to convert
+	timeCarriers sync.Map
+)
+
+// UTC returns a copy of a response body with every timestamp converted to UTC, so that responses encode them in
+// RFC 3339 with an explicit Z whatever location they were read in (database sessions, offsets of request dates)
+// Timestamps are found in nested structs, pointers, slices, maps and interfaces; values encoding themselves
+// (json.Marshaler) are left as they are. data itself is not modified
+func UTC(data interface{}) interface{} {
+	if data == nil {
+		return nil
+	}
+	return utcValue(reflect.ValueOf(data)).Interface()
+}
+
+// utcValue returns v with its timestamps converted to UTC, copying what it changes
+func utcValue(v reflect.Value) reflect.Value {
+	t := v.Type()
+	if t == timeType {
+		return reflect.ValueOf(v.Interface().(time.Time).UTC())
+	}
+	if !carriesTime(t) {
+		return v
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.New(t.Elem())
+		copied.Elem().Set(utcValue(v.Elem()))
+		return copied
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.New(t).Elem()
+		copied.Set(utcValue(v.Elem()))
+		return copied
+	case reflect.Struct:
+		copied := reflect.New(t).Elem()
+		copied.Set(v)
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).IsExported() {
+				copied.Field(i).Set(utcValue(v.Field(i)))
+			}
+		}
+		return copied
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.MakeSlice(t, v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			copied.Index(i).Set(utcValue(v.Index(i)))
+		}
+		return copied
+	case reflect.Array:
+		copied := reflect.New(t).Elem()
+		for i := 0; i < v.Len(); i++ {
+			copied.Index(i).Set(utcValue(v.Index(i)))
+		}
+		return copied
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.MakeMapWithSize(t, v.Len())
+		entries := v.MapRange()
+		for entries.Next() {
+			copied.SetMapIndex(entries.Key(), utcValue(entries.Value()))
+		}
+		return copied
+	default:
+		return v
+	}
+}
+
+// carriesTime reports whether values of type t can hold a timestamp encoded by encoding/json
+func carriesTime(t reflect.Type) bool {
+	if cached, ok := timeCarriers.Load(t); ok {
+		return cached.(bool)
+	}
+	// Recursive types are explored once: a reference back to t does not add timestamps
+	timeCarriers.Store(t, false)
+
+	carries := false
+	switch {
+	case t == timeType:
+		carries = true
+	case implementsMarshaler(t):
+		// The type decides its own encoding
+	default:
+		switch t.Kind() {
+		case reflect.Interface:
+			carries = true
+		case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+			carries = carriesTime(t.Elem())
+		case reflect.Struct:
+			for i := 0; i < t.NumField() && !carries; i++ {
+				carries = t.Field(i).IsExported() && carriesTime(t.Field(i).Type)
+			}
+		}
+	}
+
+	timeCarriers.Store(t, carries)
+	return carries
+}
+
+// implementsMarshaler reports whether t, or a pointer to it, encodes itself
+func implementsMarshaler(t reflect.Type) bool {
+	for _, marshaler := range []reflect.Type{jsonMarshalerType, textMarshalerType} {
+		if t.Implements(marshaler) || reflect.PointerTo(t).Implements(marshaler) {
+			return true
+		}
+	}
+	return false
+}
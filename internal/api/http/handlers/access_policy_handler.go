@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/middleware"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// AccessPolicyHandler handles HTTP requests for tenant IP access policy administration
+type AccessPolicyHandler struct {
+	policyService *application.AccessPolicyService
+}
+
+// NewAccessPolicyHandler creates a new access policy handler
+func NewAccessPolicyHandler(policyService *application.AccessPolicyService) *AccessPolicyHandler {
+	return &AccessPolicyHandler{
+		policyService: policyService,
+	}
+}
+
+// ListPolicies handles GET /admin/ip-access-policies requests
+func (h *AccessPolicyHandler) ListPolicies(w http.ResponseWriter, r *http.Request) {
+	policies, err := h.policyService.ListPolicies()
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	responses := make([]dtos.IPAccessPolicyResponse, len(policies))
+	for i, policy := range policies {
+		responses[i] = toIPAccessPolicyResponse(policy)
+	}
+
+	writeSuccessResponse(w, http.StatusOK, responses)
+}
+
+// GetPolicy handles GET /admin/ip-access-policies/{tenant} requests
+func (h *AccessPolicyHandler) GetPolicy(w http.ResponseWriter, r *http.Request, tenantID string) {
+	policy, err := h.policyService.GetPolicy(tenantID)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toIPAccessPolicyResponse(policy))
+}
+
+// SetPolicy handles PUT /admin/ip-access-policies/{tenant} requests
+func (h *AccessPolicyHandler) SetPolicy(w http.ResponseWriter, r *http.Request, tenantID string) {
+	var req dtos.SetIPAccessPolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format", "")
+		return
+	}
+
+	actor := middleware.AdminActorFromContext(r.Context())
+	policy, err := h.policyService.SetPolicy(actor, tenantID, req)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toIPAccessPolicyResponse(policy))
+}
+
+// DeletePolicy handles DELETE /admin/ip-access-policies/{tenant} requests
+func (h *AccessPolicyHandler) DeletePolicy(w http.ResponseWriter, r *http.Request, tenantID string) {
+	actor := middleware.AdminActorFromContext(r.Context())
+	if err := h.policyService.DeletePolicy(actor, tenantID); err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// toIPAccessPolicyResponse converts a domain IPAccessPolicy entity to HTTP response DTO
+func toIPAccessPolicyResponse(policy *entity.IPAccessPolicy) dtos.IPAccessPolicyResponse {
+	rules := make([]dtos.IPAccessRuleResponse, len(policy.Rules()))
+	for i, rule := range policy.Rules() {
+		rules[i] = dtos.IPAccessRuleResponse{
+			RoutePrefix: rule.RoutePrefix(),
+			Allow:       rule.Allow(),
+			Deny:        rule.Deny(),
+		}
+	}
+
+	return dtos.IPAccessPolicyResponse{
+		TenantID:  policy.TenantID(),
+		Rules:     rules,
+		UpdatedAt: policy.UpdatedAt(),
+	}
+}
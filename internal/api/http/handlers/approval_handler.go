@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/middleware"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// ApprovalHandler handles HTTP requests for credit note and refund approvals
+type ApprovalHandler struct {
+	approvalService *application.ApprovalService
+}
+
+// NewApprovalHandler creates a new approval handler
+func NewApprovalHandler(approvalService *application.ApprovalService) *ApprovalHandler {
+	return &ApprovalHandler{
+		approvalService: approvalService,
+	}
+}
+
+// CreateApproval handles POST /approvals requests
+// Requests above the approval threshold are created in the pending_approval state
+func (h *ApprovalHandler) CreateApproval(w http.ResponseWriter, r *http.Request) {
+	var req dtos.CreateApprovalRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format", "")
+		return
+	}
+
+	actor := middleware.AdminActorFromContext(r.Context())
+	request, err := h.approvalService.RequestApproval(actor, req)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusCreated, toApprovalRequestResponse(request))
+}
+
+// ListApprovals handles GET /approvals requests (optional ?status= filter)
+func (h *ApprovalHandler) ListApprovals(w http.ResponseWriter, r *http.Request) {
+	requests, err := h.approvalService.ListRequests(r.URL.Query().Get("status"))
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	responses := make([]dtos.ApprovalRequestResponse, len(requests))
+	for i, request := range requests {
+		responses[i] = toApprovalRequestResponse(request)
+	}
+
+	writeSuccessResponse(w, http.StatusOK, responses)
+}
+
+// GetApproval handles GET /approvals/{id} requests
+func (h *ApprovalHandler) GetApproval(w http.ResponseWriter, r *http.Request, approvalID string) {
+	request, err := h.approvalService.GetRequest(approvalID)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toApprovalRequestResponse(request))
+}
+
+// Approve handles POST /approvals/{id}/approve requests
+func (h *ApprovalHandler) Approve(w http.ResponseWriter, r *http.Request, approvalID string) {
+	actor := middleware.AdminActorFromContext(r.Context())
+	request, err := h.approvalService.Approve(actor, approvalID)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toApprovalRequestResponse(request))
+}
+
+// toApprovalRequestResponse converts a domain ApprovalRequest entity to HTTP response DTO
+func toApprovalRequestResponse(request *entity.ApprovalRequest) dtos.ApprovalRequestResponse {
+	return dtos.ApprovalRequestResponse{
+		ID:          request.ID(),
+		Kind:        string(request.Kind()),
+		ClientID:    request.ClientID(),
+		ReferenceID: request.ReferenceID(),
+		Amount:      toMoneyResponse(request.Amount()),
+		Reason:      request.Reason(),
+		Status:      string(request.Status()),
+		RequestedBy: request.RequestedBy(),
+		ApprovedBy:  request.ApprovedBy(),
+		ApprovedAt:  request.ApprovedAt(),
+		CreatedAt:   request.CreatedAt(),
+		UpdatedAt:   request.UpdatedAt(),
+	}
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// AuditHandler handles HTTP requests for the audit log
+type AuditHandler struct {
+	auditService *application.AuditService
+}
+
+// NewAuditHandler creates a new audit handler
+func NewAuditHandler(auditService *application.AuditService) *AuditHandler {
+	return &AuditHandler{
+		auditService: auditService,
+	}
+}
+
+// ListEntries handles GET /admin/audit-log requests (optional ?tenant_id= and ?since= filters)
+func (h *AuditHandler) ListEntries(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	query := r.URL.Query()
+	var since time.Time
+	if value := query.Get("since"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETER", "since must be an RFC 3339 timestamp", "since")
+			return
+		}
+		since = parsed
+	}
+
+	entries, err := h.auditService.ListEntriesSince(query.Get("tenant_id"), since)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	responses := make([]dtos.AuditEntryResponse, len(entries))
+	for i, entry := range entries {
+		responses[i] = toAuditEntryResponse(entry)
+	}
+
+	writeSuccessResponse(w, http.StatusOK, responses)
+}
+
+// toAuditEntryResponse converts a domain AuditEntry entity to HTTP response DTO
+func toAuditEntryResponse(entry *entity.AuditEntry) dtos.AuditEntryResponse {
+	return dtos.AuditEntryResponse{
+		ID:           entry.ID(),
+		Action:       entry.Action(),
+		Actor:        entry.Actor(),
+		TenantID:     entry.TenantID(),
+		ResourceType: entry.ResourceType(),
+		ResourceID:   entry.ResourceID(),
+		Details:      entry.Details(),
+		OccurredAt:   entry.OccurredAt(),
+	}
+}
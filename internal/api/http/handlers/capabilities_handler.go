@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+)
+
+// CapabilitiesPath is the route of the capabilities endpoint
+const CapabilitiesPath = "/api/v1/capabilities"
+
+// CapabilitiesHandler handles the capabilities endpoint
+type CapabilitiesHandler struct {
+	capabilities dtos.CapabilitiesResponse
+}
+
+// NewCapabilitiesHandler creates a new capabilities handler reporting the given features and currencies
+// Pagination and body size limits are those enforced by the handlers; maxUploadBytes is 0 without uploads
+func NewCapabilitiesHandler(capabilities dtos.CapabilitiesResponse, maxUploadBytes int64) *CapabilitiesHandler {
+	capabilities.Pagination = dtos.PaginationCapabilities{
+		Modes:           []string{"page", "cursor"},
+		DefaultPageSize: dtos.DefaultLimit,
+		MaxPageSize:     dtos.MaxLimit,
+	}
+	capabilities.Limits = dtos.LimitCapabilities{
+		MaxPageSize:       dtos.MaxLimit,
+		MaxWebhookBytes:   maxWebhookSize,
+		MaxStatementBytes: maxStatementSize,
+		MaxImportBytes:    maxImportSize,
+	}
+	if maxUploadBytes > 0 {
+		capabilities.Limits.MaxUploadBytes = maxUploadBytes
+		capabilities.Limits.MaxUploadChunkBytes = maxUploadChunkSize
+	}
+	if capabilities.Currencies.Configured == nil {
+		capabilities.Currencies.Configured = []string{}
+	}
+
+	return &CapabilitiesHandler{capabilities: capabilities}
+}
+
+// GetCapabilities handles GET /capabilities requests
+func (h *CapabilitiesHandler) GetCapabilities(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+	writeSuccessResponse(w, http.StatusOK, h.capabilities)
+}
@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/middleware"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+)
+
+// maxStatementSize bounds the size of an uploaded bank statement file
+const maxStatementSize = 10 << 20
+
+// CashApplicationHandler handles HTTP requests for bank statement import and reconciliation
+type CashApplicationHandler struct {
+	cashService *application.CashApplicationService
+}
+
+// NewCashApplicationHandler creates a new cash application handler
+func NewCashApplicationHandler(cashService *application.CashApplicationService) *CashApplicationHandler {
+	return &CashApplicationHandler{
+		cashService: cashService,
+	}
+}
+
+// ImportStatement handles POST /bank-statements requests (raw camt.053 or MT940 file, optional ?format=)
+func (h *CashApplicationHandler) ImportStatement(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxStatementSize))
+	if err != nil {
+		writeErrorResponse(w, http.StatusRequestEntityTooLarge, "STATEMENT_TOO_LARGE", "Statement file must be at most 10 MB", "")
+		return
+	}
+
+	result, err := h.cashService.ImportStatement(r.URL.Query().Get("format"), data)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	transactions, err := h.toBankTransactionResponses(result.Imported)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusCreated, dtos.StatementImportResponse{
+		Format:        string(result.Format),
+		AccountIBAN:   result.AccountIBAN,
+		Imported:      len(result.Imported),
+		Duplicates:    result.Duplicates,
+		SkippedDebits: result.SkippedDebits,
+		Transactions:  transactions,
+	})
+}
+
+// ListTransactions handles GET /bank-transactions requests (optional ?status= filter)
+func (h *CashApplicationHandler) ListTransactions(w http.ResponseWriter, r *http.Request) {
+	transactions, err := h.cashService.ListTransactions(r.URL.Query().Get("status"))
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	responses, err := h.toBankTransactionResponses(transactions)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, responses)
+}
+
+// GetTransaction handles GET /bank-transactions/{id} requests
+func (h *CashApplicationHandler) GetTransaction(w http.ResponseWriter, r *http.Request, transactionID string) {
+	transaction, err := h.cashService.GetTransaction(transactionID)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	response, err := h.toBankTransactionResponse(transaction)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, response)
+}
+
+// Reconcile handles POST /bank-transactions/{id}/reconcile requests
+func (h *CashApplicationHandler) Reconcile(w http.ResponseWriter, r *http.Request, transactionID string) {
+	var req dtos.ReconcileBankTransactionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format", "")
+		return
+	}
+
+	actor := middleware.AdminActorFromContext(r.Context())
+	transaction, err := h.cashService.Reconcile(actor, transactionID, req)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	response, err := h.toBankTransactionResponse(transaction)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, response)
+}
+
+// toBankTransactionResponses converts bank transactions to HTTP responses with their match suggestions
+func (h *CashApplicationHandler) toBankTransactionResponses(transactions []*entity.BankTransaction) ([]dtos.BankTransactionResponse, error) {
+	responses := make([]dtos.BankTransactionResponse, len(transactions))
+	for i, transaction := range transactions {
+		response, err := h.toBankTransactionResponse(transaction)
+		if err != nil {
+			return nil, err
+		}
+		responses[i] = response
+	}
+	return responses, nil
+}
+
+// toBankTransactionResponse converts a bank transaction to its HTTP response with its match suggestions
+func (h *CashApplicationHandler) toBankTransactionResponse(transaction *entity.BankTransaction) (dtos.BankTransactionResponse, error) {
+	matches, err := h.cashService.SuggestMatches(transaction)
+	if err != nil {
+		return dtos.BankTransactionResponse{}, err
+	}
+
+	return dtos.BankTransactionResponse{
+		ID:               transaction.ID(),
+		AccountIBAN:      transaction.AccountIBAN(),
+		BankReference:    transaction.BankReference(),
+		BookingDate:      transaction.BookingDate(),
+		Amount:           toMoneyResponse(transaction.Amount()),
+		Reference:        transaction.Reference(),
+		CounterpartyName: transaction.CounterpartyName(),
+		CounterpartyIBAN: transaction.CounterpartyIBAN(),
+		Status:           string(transaction.Status()),
+		InvoiceID:        transaction.InvoiceID(),
+		ClientID:         transaction.ClientID(),
+		ReconciledBy:     transaction.ReconciledBy(),
+		ReconciledAt:     transaction.ReconciledAt(),
+		ImportedAt:       transaction.ImportedAt(),
+		Suggestions:      toCashMatchResponses(matches),
+	}, nil
+}
+
+// toCashMatchResponses converts match suggestions to HTTP responses
+func toCashMatchResponses(matches []service.CashMatch) []dtos.CashMatchResponse {
+	responses := make([]dtos.CashMatchResponse, len(matches))
+	for i, match := range matches {
+		responses[i] = dtos.CashMatchResponse{
+			InvoiceID:   match.Invoice.InvoiceID,
+			Number:      match.Invoice.Number,
+			ClientID:    match.Invoice.ClientID,
+			Outstanding: toMoneyResponse(match.Invoice.Outstanding),
+			Score:       match.Score,
+			Reasons:     match.Reasons,
+		}
+	}
+	return responses
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// ClientContactHandler handles HTTP requests for the contacts of a client
+type ClientContactHandler struct {
+	billingService *application.BillingService
+}
+
+// NewClientContactHandler creates a new client contact handler
+func NewClientContactHandler(billingService *application.BillingService) *ClientContactHandler {
+	return &ClientContactHandler{
+		billingService: billingService,
+	}
+}
+
+// ListContacts handles GET /clients/{id}/contacts requests
+func (h *ClientContactHandler) ListContacts(w http.ResponseWriter, r *http.Request, clientID string) {
+	contacts, err := h.billingService.ListClientContacts(clientID)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	responses := make([]dtos.ClientContactResponse, len(contacts))
+	for i, contact := range contacts {
+		responses[i] = toClientContactResponse(contact)
+	}
+	writeSuccessResponse(w, http.StatusOK, responses)
+}
+
+// CreateContact handles POST /clients/{id}/contacts requests
+func (h *ClientContactHandler) CreateContact(w http.ResponseWriter, r *http.Request, clientID string) {
+	var req dtos.ClientContactRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format", "")
+		return
+	}
+
+	contact, err := h.billingService.AddClientContact(clientID, req)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusCreated, toClientContactResponse(contact))
+}
+
+// GetContact handles GET /clients/{id}/contacts/{contactID} requests
+func (h *ClientContactHandler) GetContact(w http.ResponseWriter, r *http.Request, clientID, contactID string) {
+	contact, err := h.billingService.GetClientContact(clientID, contactID)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toClientContactResponse(contact))
+}
+
+// UpdateContact handles PUT /clients/{id}/contacts/{contactID} requests
+func (h *ClientContactHandler) UpdateContact(w http.ResponseWriter, r *http.Request, clientID, contactID string) {
+	var req dtos.ClientContactRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format", "")
+		return
+	}
+
+	contact, err := h.billingService.UpdateClientContact(clientID, contactID, req)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toClientContactResponse(contact))
+}
+
+// DeleteContact handles DELETE /clients/{id}/contacts/{contactID} requests
+func (h *ClientContactHandler) DeleteContact(w http.ResponseWriter, r *http.Request, clientID, contactID string) {
+	if err := h.billingService.DeleteClientContact(clientID, contactID); err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// toClientContactResponse converts a client contact to HTTP response DTO
+func toClientContactResponse(contact *entity.ClientContact) dtos.ClientContactResponse {
+	return dtos.ClientContactResponse{
+		ID:        contact.ID(),
+		ClientID:  contact.ClientID(),
+		Name:      contact.Name(),
+		Role:      contact.Role(),
+		Email:     contact.Email().String(),
+		Phone:     contact.Phone().String(),
+		CreatedAt: contact.CreatedAt(),
+		UpdatedAt: contact.UpdatedAt(),
+	}
+}
@@ -1,19 +1,39 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"log"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/middleware"
 	"github.com/gjaminon-go-labs/billing-api/internal/application"
 	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
 	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
 )
 
+// FormTokenHeader carries the one-time form token on browser submissions
+const FormTokenHeader = "X-Form-Token"
+
+// DeleteModeHeader overrides the configured delete mode of a request
+const DeleteModeHeader = "X-Delete-Mode"
+
+// Delete modes
+const (
+	DeleteModeStrict     = "strict"     // Deleting an already deleted client fails with 404
+	DeleteModeIdempotent = "idempotent" // Deleting an already deleted client succeeds with 204
+)
+
 // ClientHandler handles HTTP requests for client operations
 type ClientHandler struct {
-	billingService *application.BillingService
+	billingService    *application.BillingService
+	formTokens        *application.FormTokenService
+	risk              *application.RiskScoringService
+	idempotentDeletes bool // Default delete mode, overridden by the X-Delete-Mode header
 }
 
 // NewClientHandler creates a new client handler
@@ -23,6 +43,51 @@ func NewClientHandler(billingService *application.BillingService) *ClientHandler
 	}
 }
 
+// WithFormTokens enables one-time form token issuance and validation on create
+func (h *ClientHandler) WithFormTokens(formTokens *application.FormTokenService) *ClientHandler {
+	h.formTokens = formTokens
+	return h
+}
+
+// WithIdempotentDeletes sets the default delete mode: deleting an already deleted client succeeds rather than
+// failing with 404
+func (h *ClientHandler) WithIdempotentDeletes(idempotent bool) *ClientHandler {
+	h.idempotentDeletes = idempotent
+	return h
+}
+
+// WithRiskScoring returns the latest client risk score to admins
+func (h *ClientHandler) WithRiskScoring(risk *application.RiskScoringService) *ClientHandler {
+	h.risk = risk
+	return h
+}
+
+// IssueFormToken handles GET /clients/new-token requests
+func (h *ClientHandler) IssueFormToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	if h.formTokens == nil {
+		h.writeErrorResponse(w, http.StatusNotFound, "NOT_FOUND", "Form tokens are not enabled", "")
+		return
+	}
+
+	token, err := h.formTokens.Issue(application.FormPurposeCreateClient)
+	if err != nil {
+		h.handleDomainError(w, err)
+		return
+	}
+
+	// Tokens are single-use and must never be served from a cache
+	w.Header().Set("Cache-Control", "no-store")
+	h.writeSuccessResponse(w, http.StatusCreated, dtos.FormTokenResponse{
+		Token:     token.Token(),
+		ExpiresAt: token.ExpiresAt(),
+	})
+}
+
 // CreateClient handles POST /clients requests
 func (h *ClientHandler) CreateClient(w http.ResponseWriter, r *http.Request) {
 	// Only allow POST method
@@ -48,21 +113,42 @@ func (h *ClientHandler) CreateClient(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Redeem the one-time form token (browser clients) before creating anything
+	var formToken *entity.FormToken
+	if token := r.Header.Get(FormTokenHeader); token != "" && h.formTokens != nil {
+		redeemed, err := h.formTokens.Redeem(token, application.FormPurposeCreateClient)
+		if err != nil {
+			h.handleDomainError(w, err)
+			return
+		}
+		formToken = redeemed
+	}
+
 	// Call application service
-	client, err := h.billingService.CreateClient(req.Name, req.Email, req.Phone, req.Address)
+	client, err := h.billingService.CreateClient(middleware.RequestContextFromRequest(r), req)
 	if err != nil {
+		// Nothing was created: give the token back so the user can correct and resubmit
+		if formToken != nil {
+			if restoreErr := h.formTokens.Restore(formToken); restoreErr != nil {
+				log.Printf("Failed to restore form token: %v", restoreErr)
+			}
+		}
 		h.handleDomainError(w, err)
 		return
 	}
 
 	// Convert domain entity to response DTO
 	response := h.toClientResponse(client)
+	h.addRisk(r, &response)
 
 	// Write success response
 	h.writeSuccessResponse(w, http.StatusCreated, response)
 }
 
-// ListClients handles GET /clients requests
+// ListClients handles GET /clients requests (optional ?status=, ?tag=, ?phone=, ?email=, ?q=, ?created_after= and
+// ?created_before= filters, all combined, and ?sort= ordering such as name,-created_at)
+// Pages are selected by ?page= and ?limit=, or by ?cursor= and ?limit= (keyset pagination in creation order, an
+// empty cursor starting from the first client)
 func (h *ClientHandler) ListClients(w http.ResponseWriter, r *http.Request) {
 	// Only allow GET method
 	if r.Method != http.MethodGet {
@@ -70,56 +156,47 @@ func (h *ClientHandler) ListClients(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Parse pagination parameters
-	pageStr := r.URL.Query().Get("page")
-	limitStr := r.URL.Query().Get("limit")
-
 	// Always use pagination (with defaults if not specified)
 	{
-		// Parse and validate pagination
-		paginationReq := dtos.PaginationRequest{}
-
-		if pageStr != "" {
-			page := 0
-			_, err := fmt.Sscanf(pageStr, "%d", &page)
-			if err != nil {
-				h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETER", "invalid page parameter", "")
-				return
-			}
-			paginationReq.Page = page
+		paginationReq, ok := parsePagination(w, r)
+		if !ok {
+			return
 		}
 
-		if limitStr != "" {
-			limit := 0
-			_, err := fmt.Sscanf(limitStr, "%d", &limit)
-			if err != nil {
-				h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETER", "invalid limit parameter", "")
+		// Call paginated service method
+		var result *application.PaginatedClients
+		var err error
+		query := r.URL.Query()
+		for _, param := range []string{"tag", "phone", "q", "email", "sort"} {
+			if query.Has(param) && strings.TrimSpace(query.Get(param)) == "" {
+				h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETER", param+" parameter cannot be empty", "")
 				return
 			}
-			paginationReq.Limit = limit
 		}
-
-		// Validate before setting defaults (to catch invalid values like 0 or negative)
-		if pageStr != "" && paginationReq.Page <= 0 {
-			h.writeErrorResponse(w, http.StatusBadRequest, "VALIDATION_ERROR", "page must be greater than 0", "")
+		createdAfter, ok := parseTimestampParam(w, r, "created_after")
+		if !ok {
 			return
 		}
-		if limitStr != "" && (paginationReq.Limit <= 0 || paginationReq.Limit > dtos.MaxLimit) {
-			h.writeErrorResponse(w, http.StatusBadRequest, "VALIDATION_ERROR", "limit must be between 1 and 100", "")
+		createdBefore, ok := parseTimestampParam(w, r, "created_before")
+		if !ok {
 			return
 		}
-
-		// Set defaults
-		paginationReq.SetDefaults()
-
-		// Final validation
-		if err := paginationReq.Validate(); err != nil {
-			h.writeErrorResponse(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), "")
+		filter := application.ClientFilter{
+			Status:        entity.ClientStatus(query.Get("status")),
+			Tag:           query.Get("tag"),
+			Phone:         query.Get("phone"),
+			Locale:        middleware.LocaleFromContext(r.Context()),
+			Email:         query.Get("email"),
+			Search:        query.Get("q"),
+			CreatedAfter:  createdAfter,
+			CreatedBefore: createdBefore,
+			Sort:          query.Get("sort"),
+		}
+		if query.Has("cursor") {
+			h.listClientsAfterCursor(w, r, filter, paginationReq.Limit)
 			return
 		}
-
-		// Call paginated service method
-		result, err := h.billingService.ListClientsWithPagination(paginationReq.Page, paginationReq.Limit)
+		result, err = h.billingService.ListClientsWithFilter(middleware.RequestContextFromRequest(r), filter, paginationReq.Page, paginationReq.Limit)
 		if err != nil {
 			h.handleDomainError(w, err)
 			return
@@ -144,102 +221,161 @@ func (h *ClientHandler) ListClients(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// handleDomainError converts domain errors to appropriate HTTP responses
-func (h *ClientHandler) handleDomainError(w http.ResponseWriter, err error) {
-	// Check error type and map to HTTP status code
-	if errors.IsValidationError(err) || errors.IsValidationErrors(err) {
-		code := string(errors.GetErrorCode(err))
-		message := errors.GetUserMessage(err)
-
-		// Try to extract field information from validation error
-		var field string
-		if validationErr, ok := err.(*errors.ValidationError); ok {
-			field = validationErr.Field
-		}
-
-		h.writeErrorResponse(w, http.StatusBadRequest, code, message, field)
+// listClientsAfterCursor writes the page of a client listing selected by ?cursor=
+func (h *ClientHandler) listClientsAfterCursor(w http.ResponseWriter, r *http.Request, filter application.ClientFilter, limit int) {
+	if r.URL.Query().Has("page") {
+		h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETER", "page cannot be combined with cursor", "page")
 		return
 	}
 
-	if errors.IsBusinessRuleError(err) {
-		code := string(errors.GetErrorCode(err))
-		message := errors.GetUserMessage(err)
-		h.writeErrorResponse(w, http.StatusUnprocessableEntity, code, message, "")
+	page, err := h.billingService.ListClientsAfterCursor(middleware.RequestContextFromRequest(r), filter, r.URL.Query().Get("cursor"), limit)
+	if err != nil {
+		h.handleDomainError(w, err)
 		return
 	}
 
-	if errors.IsRepositoryError(err) {
-		code := errors.GetErrorCode(err)
-		message := errors.GetUserMessage(err)
+	clientResponses := make([]dtos.ClientResponse, len(page.Clients))
+	for i, client := range page.Clients {
+		clientResponses[i] = h.toClientResponse(client)
+	}
+	writeCursorPaginatedResponse(w, http.StatusOK, clientResponses, &dtos.CursorPaginationResponse{
+		Limit:      page.Limit,
+		NextCursor: page.NextCursor,
+		HasMore:    page.HasMore,
+	})
+}
 
-		// Map specific repository error codes to appropriate HTTP status codes
-		var statusCode int
-		switch code {
-		case errors.RepositoryNotFound:
-			statusCode = http.StatusNotFound
-		case errors.RepositoryConstraint:
-			statusCode = http.StatusConflict
-		default:
-			statusCode = http.StatusInternalServerError
+// parseTimestampParam parses an optional RFC 3339 timestamp query parameter
+// It writes the error response and returns false when the parameter is invalid
+func parseTimestampParam(w http.ResponseWriter, r *http.Request, param string) (time.Time, bool) {
+	value := r.URL.Query().Get(param)
+	if value == "" {
+		return time.Time{}, true
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETER", param+" must be an RFC 3339 timestamp", param)
+		return time.Time{}, false
+	}
+	return parsed, true
+}
+
+// parsePagination parses and validates the ?page= and ?limit= parameters, applying the defaults
+// It writes the error response and returns false when a parameter is invalid
+func parsePagination(w http.ResponseWriter, r *http.Request) (dtos.PaginationRequest, bool) {
+	pageStr := r.URL.Query().Get("page")
+	limitStr := r.URL.Query().Get("limit")
+	paginationReq := dtos.PaginationRequest{}
+
+	if pageStr != "" {
+		page := 0
+		_, err := fmt.Sscanf(pageStr, "%d", &page)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETER", "invalid page parameter", "")
+			return paginationReq, false
 		}
+		paginationReq.Page = page
+	}
 
-		h.writeErrorResponse(w, statusCode, string(code), message, "")
-		return
+	if limitStr != "" {
+		limit := 0
+		_, err := fmt.Sscanf(limitStr, "%d", &limit)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETER", "invalid limit parameter", "")
+			return paginationReq, false
+		}
+		paginationReq.Limit = limit
 	}
 
-	// Fallback for unknown errors
-	h.writeErrorResponse(w, http.StatusInternalServerError, "INTERNAL_ERROR", "An internal error occurred", "")
+	// Validate before setting defaults (to catch invalid values like 0 or negative)
+	if pageStr != "" && paginationReq.Page <= 0 {
+		writeErrorResponse(w, http.StatusBadRequest, "VALIDATION_ERROR", "page must be greater than 0", "")
+		return paginationReq, false
+	}
+	if limitStr != "" && (paginationReq.Limit <= 0 || paginationReq.Limit > dtos.MaxLimit) {
+		writeErrorResponse(w, http.StatusBadRequest, "VALIDATION_ERROR", "limit must be between 1 and 100", "")
+		return paginationReq, false
+	}
+
+	// Set defaults
+	paginationReq.SetDefaults()
+
+	// Final validation
+	if err := paginationReq.Validate(); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "VALIDATION_ERROR", err.Error(), "")
+		return paginationReq, false
+	}
+	return paginationReq, true
+}
+
+// handleDomainError converts domain errors to appropriate HTTP responses
+func (h *ClientHandler) handleDomainError(w http.ResponseWriter, err error) {
+	handleDomainError(w, err)
 }
 
 // toClientResponse converts a domain Client entity to HTTP response DTO
 func (h *ClientHandler) toClientResponse(client *entity.Client) dtos.ClientResponse {
 	return dtos.ClientResponse{
-		ID:        client.ID(),
-		Name:      client.Name(),
-		Email:     client.EmailString(),
-		Phone:     client.PhoneString(),
-		Address:   client.Address(),
-		CreatedAt: client.CreatedAt(),
-		UpdatedAt: client.UpdatedAt(),
+		ID:           client.ID(),
+		Name:         client.Name(),
+		Email:        client.EmailString(),
+		Phone:        client.PhoneString(),
+		Address:      client.Address(),
+		Country:      client.Country(),
+		ExternalRef:  client.ExternalReference(),
+		Status:       string(client.Status()),
+		Tags:         client.Tags(),
+		PaymentTerms: string(client.PaymentTerms()),
+		CreatedAt:    client.CreatedAt(),
+		UpdatedAt:    client.UpdatedAt(),
 	}
 }
 
-// writeSuccessResponse writes a successful JSON response
-func (h *ClientHandler) writeSuccessResponse(w http.ResponseWriter, statusCode int, data interface{}) {
-	response := dtos.SuccessResponse{
-		Data:    data,
-		Success: true,
+// addRisk adds the latest risk score of the client to responses served to admins
+// A client that was never scored, or a failed lookup, leaves the field out rather than failing the request
+func (h *ClientHandler) addRisk(r *http.Request, response *dtos.ClientResponse) {
+	if h.risk == nil || !middleware.RequestContextFromContext(r.Context()).IsAdmin() {
+		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
-	json.NewEncoder(w).Encode(response)
-}
+	assessment, err := h.risk.LatestAssessment(response.ID)
+	if err != nil {
+		if errors.GetErrorCode(err) != errors.RepositoryNotFound {
+			log.Printf("Failed to load risk assessment of client %s: %v", response.ID, err)
+		}
+		return
+	}
 
-// writeErrorResponse writes an error JSON response
-func (h *ClientHandler) writeErrorResponse(w http.ResponseWriter, statusCode int, code, message, field string) {
-	errorDetail := dtos.ErrorDetail{
-		Code:    code,
-		Message: message,
+	reasons := make([]dtos.RiskReasonResponse, 0, len(assessment.Reasons()))
+	for _, reason := range assessment.Reasons() {
+		reasons = append(reasons, dtos.RiskReasonResponse{Code: reason.Code, Description: reason.Description})
 	}
-	if field != "" {
-		errorDetail.Field = field
+	response.Risk = &dtos.ClientRiskResponse{
+		Score:      assessment.Score(),
+		Decision:   string(assessment.Decision()),
+		Reasons:    reasons,
+		Provider:   assessment.Provider(),
+		Trigger:    string(assessment.Trigger()),
+		AssessedAt: assessment.AssessedAt(),
+		ExpiresAt:  assessment.ExpiresAt(),
+		Expired:    assessment.IsExpired(time.Now()),
 	}
+}
 
-	response := dtos.ErrorResponse{
-		Error:   errorDetail,
-		Success: false,
-	}
+// writeSuccessResponse writes a successful JSON response
+func (h *ClientHandler) writeSuccessResponse(w http.ResponseWriter, statusCode int, data interface{}) {
+	writeSuccessResponse(w, statusCode, data)
+}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
-	json.NewEncoder(w).Encode(response)
+// writeErrorResponse writes an error JSON response
+func (h *ClientHandler) writeErrorResponse(w http.ResponseWriter, statusCode int, code, message, field string) {
+	writeErrorResponse(w, statusCode, code, message, field)
 }
 
 // GetClient handles GET /clients/{id} requests
 func (h *ClientHandler) GetClient(w http.ResponseWriter, r *http.Request, clientID string) {
 	// Get client from service
-	client, err := h.billingService.GetClientByID(clientID)
+	client, err := h.billingService.GetOwnedClient(middleware.RequestContextFromRequest(r), clientID)
 	if err != nil {
 		h.handleDomainError(w, err)
 		return
@@ -247,11 +383,163 @@ func (h *ClientHandler) GetClient(w http.ResponseWriter, r *http.Request, client
 
 	// Convert domain entity to response DTO
 	response := h.toClientResponse(client)
+	h.addRisk(r, &response)
 
 	// Write success response
 	h.writeSuccessResponse(w, http.StatusOK, response)
 }
 
+// BatchGetClients handles POST /clients/batch-get requests: the clients of up to MaxLimit IDs in one round trip
+func (h *ClientHandler) BatchGetClients(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	var req dtos.BatchGetClientsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format", "")
+		return
+	}
+
+	batch, err := h.billingService.GetClientsByIDs(middleware.RequestContextFromRequest(r), req.IDs)
+	if err != nil {
+		h.handleDomainError(w, err)
+		return
+	}
+
+	response := dtos.BatchGetClientsResponse{
+		Clients:  make([]dtos.ClientResponse, len(batch.Clients)),
+		NotFound: batch.NotFound,
+	}
+	for i, client := range batch.Clients {
+		response.Clients[i] = h.toClientResponse(client)
+	}
+	h.writeSuccessResponse(w, http.StatusOK, response)
+}
+
+// GetClientByExternalRef handles GET /clients/by-external-ref/{ref} requests (reference of the X-Tenant-ID tenant)
+// References containing a slash must be URL-encoded
+func (h *ClientHandler) GetClientByExternalRef(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	reference := strings.TrimPrefix(r.URL.Path, "/api/v1/clients/by-external-ref/")
+	client, err := h.billingService.GetClientByExternalReference(middleware.RequestContextFromRequest(r), reference)
+	if err != nil {
+		h.handleDomainError(w, err)
+		return
+	}
+
+	h.writeSuccessResponse(w, http.StatusOK, h.toClientResponse(client))
+}
+
+// ClientExists handles HEAD /clients/{id} requests (status only, no body)
+func (h *ClientHandler) ClientExists(w http.ResponseWriter, r *http.Request, clientID string) {
+	exists, err := h.billingService.ClientExists(middleware.RequestContextFromRequest(r), clientID)
+	switch {
+	case err != nil && errors.IsValidationError(err):
+		w.WriteHeader(http.StatusBadRequest)
+	case err != nil:
+		w.WriteHeader(http.StatusInternalServerError)
+	case exists:
+		w.WriteHeader(http.StatusOK)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// ResolveOwnedClient returns the client with the given ID when the caller of the request context may access it
+// (see application.BillingService.GetOwnedClient)
+func (h *ClientHandler) ResolveOwnedClient(ctx context.Context, clientID string) (*entity.Client, error) {
+	return h.billingService.GetOwnedClient(middleware.RequestContextFromContext(ctx), clientID)
+}
+
+// RequireOwnedClient checks the parent client of a nested client route, writing the error response when the
+// caller may not act on it
+func (h *ClientHandler) RequireOwnedClient(w http.ResponseWriter, r *http.Request, clientID string) bool {
+	if _, err := h.ResolveOwnedClient(r.Context(), clientID); err != nil {
+		h.handleDomainError(w, err)
+		return false
+	}
+	return true
+}
+
+// RequireOwnedClientIfExists is RequireOwnedClient for nested resources outliving their client (statement jobs):
+// once the client is deleted there is no tenant left to check, and requests go through
+func (h *ClientHandler) RequireOwnedClientIfExists(w http.ResponseWriter, r *http.Request, clientID string) bool {
+	if deleted, err := h.billingService.ClientDeleted(clientID); err == nil && deleted {
+		return true
+	}
+	return h.RequireOwnedClient(w, r, clientID)
+}
+
+// CountClients handles GET /clients/count requests (optional ?filter= on name or email, ?count=exact|estimated)
+func (h *ClientHandler) CountClients(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	query := r.URL.Query()
+	count, err := h.billingService.CountClients(middleware.RequestContextFromRequest(r), query.Get("filter"), query.Get("count"))
+	if err != nil {
+		h.handleDomainError(w, err)
+		return
+	}
+
+	h.writeSuccessResponse(w, http.StatusOK, dtos.ClientCountResponse{Count: count})
+}
+
+// ListClientChanges handles GET /clients/changes?since=<timestamp|cursor>&limit= requests
+func (h *ClientHandler) ListClientChanges(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	limit := 0
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		_, err := fmt.Sscanf(limitStr, "%d", &limit)
+		if err != nil {
+			h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETER", "invalid limit parameter", "")
+			return
+		}
+		if limit <= 0 {
+			h.writeErrorResponse(w, http.StatusBadRequest, "VALIDATION_ERROR", "limit must be greater than 0", "")
+			return
+		}
+	}
+
+	page, err := h.billingService.ListClientChanges(middleware.RequestContextFromRequest(r), r.URL.Query().Get("since"), limit)
+	if err != nil {
+		h.handleDomainError(w, err)
+		return
+	}
+
+	changes := make([]dtos.ClientChangeResponse, len(page.Changes))
+	for i, change := range page.Changes {
+		changes[i] = dtos.ClientChangeResponse{
+			Sequence:   change.Sequence(),
+			Type:       string(change.ChangeType()),
+			ClientID:   change.ClientID(),
+			OccurredAt: change.OccurredAt(),
+		}
+		if client := change.Client(); client != nil {
+			response := h.toClientResponse(client)
+			changes[i].Client = &response
+		}
+	}
+
+	h.writeSuccessResponse(w, http.StatusOK, dtos.ClientChangesResponse{
+		Changes:    changes,
+		NextCursor: page.NextCursor,
+		HasMore:    page.HasMore,
+	})
+}
+
 // UpdateClient handles PUT /clients/{id} requests
 func (h *ClientHandler) UpdateClient(w http.ResponseWriter, r *http.Request, clientID string) {
 	// Parse request body
@@ -262,7 +550,7 @@ func (h *ClientHandler) UpdateClient(w http.ResponseWriter, r *http.Request, cli
 	}
 
 	// Update client via service
-	client, err := h.billingService.UpdateClient(clientID, req)
+	client, err := h.billingService.UpdateClient(middleware.RequestContextFromRequest(r), clientID, req)
 	if err != nil {
 		h.handleDomainError(w, err)
 		return
@@ -275,10 +563,78 @@ func (h *ClientHandler) UpdateClient(w http.ResponseWriter, r *http.Request, cli
 	h.writeSuccessResponse(w, http.StatusOK, response)
 }
 
-// DeleteClient handles DELETE /clients/{id} requests
+// UpdateClientStatus handles PATCH /clients/{id}/status requests
+func (h *ClientHandler) UpdateClientStatus(w http.ResponseWriter, r *http.Request, clientID string) {
+	if r.Method != http.MethodPatch {
+		h.writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	var req dtos.UpdateClientStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format", "")
+		return
+	}
+
+	client, err := h.billingService.ChangeClientStatus(middleware.RequestContextFromRequest(r), clientID, entity.ClientStatus(req.Status))
+	if err != nil {
+		h.handleDomainError(w, err)
+		return
+	}
+
+	h.writeSuccessResponse(w, http.StatusOK, h.toClientResponse(client))
+}
+
+// AddClientTags handles POST /clients/{id}/tags requests and responds with the tagged client
+func (h *ClientHandler) AddClientTags(w http.ResponseWriter, r *http.Request, clientID string) {
+	var req dtos.AddClientTagsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format", "")
+		return
+	}
+
+	client, err := h.billingService.AddClientTags(middleware.RequestContextFromRequest(r), clientID, req.Tags)
+	if err != nil {
+		h.handleDomainError(w, err)
+		return
+	}
+
+	h.writeSuccessResponse(w, http.StatusOK, h.toClientResponse(client))
+}
+
+// RemoveClientTag handles DELETE /clients/{id}/tags/{tag} requests and responds with the client
+func (h *ClientHandler) RemoveClientTag(w http.ResponseWriter, r *http.Request, clientID, tag string) {
+	client, err := h.billingService.RemoveClientTag(middleware.RequestContextFromRequest(r), clientID, tag)
+	if err != nil {
+		h.handleDomainError(w, err)
+		return
+	}
+
+	h.writeSuccessResponse(w, http.StatusOK, h.toClientResponse(client))
+}
+
+// DeleteClient handles DELETE /clients/{id} requests (billing admins only)
 func (h *ClientHandler) DeleteClient(w http.ResponseWriter, r *http.Request, clientID string) {
+	idempotent := h.idempotentDeletes
+	switch mode := strings.ToLower(strings.TrimSpace(r.Header.Get(DeleteModeHeader))); mode {
+	case "":
+	case DeleteModeStrict:
+		idempotent = false
+	case DeleteModeIdempotent:
+		idempotent = true
+	default:
+		h.writeErrorResponse(w, http.StatusBadRequest, "INVALID_HEADER", "X-Delete-Mode must be strict or idempotent", DeleteModeHeader)
+		return
+	}
+
 	// Delete client via service
-	err := h.billingService.DeleteClient(clientID)
+	rc := middleware.RequestContextFromRequest(r)
+	var err error
+	if idempotent {
+		err = h.billingService.DeleteClientIdempotently(rc, clientID)
+	} else {
+		err = h.billingService.DeleteClient(rc, clientID)
+	}
 	if err != nil {
 		h.handleDomainError(w, err)
 		return
@@ -290,13 +646,5 @@ func (h *ClientHandler) DeleteClient(w http.ResponseWriter, r *http.Request, cli
 
 // writePaginatedResponse writes a paginated response with metadata
 func (h *ClientHandler) writePaginatedResponse(w http.ResponseWriter, statusCode int, data interface{}, pagination *dtos.PaginationResponse) {
-	response := dtos.PaginatedResponse{
-		Data:       data,
-		Pagination: pagination,
-		Success:    true,
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
-	json.NewEncoder(w).Encode(response)
+	writePaginatedResponse(w, statusCode, data, pagination)
 }
@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/middleware"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+)
+
+// maxImportSize bounds the size of an imported CSV file
+const maxImportSize = 10 << 20
+
+// ClientImportHandler handles HTTP requests for CSV imports of clients
+type ClientImportHandler struct {
+	billingService *application.BillingService
+}
+
+// NewClientImportHandler creates a new client import handler
+func NewClientImportHandler(billingService *application.BillingService) *ClientImportHandler {
+	return &ClientImportHandler{
+		billingService: billingService,
+	}
+}
+
+// Preview handles POST /imports/preview requests (raw CSV file, optional ?sample= row count)
+// Only the header and the sample rows are read, so the mapping step stays fast on large files
+func (h *ClientImportHandler) Preview(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	sample := 0
+	if sampleStr := r.URL.Query().Get("sample"); sampleStr != "" {
+		parsed, err := strconv.Atoi(sampleStr)
+		if err != nil || parsed <= 0 {
+			writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETER", "sample must be a positive number of rows", "sample")
+			return
+		}
+		sample = parsed
+	}
+
+	preview, err := h.billingService.PreviewClientImport(http.MaxBytesReader(w, r.Body, maxImportSize), sample)
+	if err != nil {
+		h.handleImportError(w, err)
+		return
+	}
+
+	fields := make([]dtos.ImportFieldResponse, len(application.ClientImportFields))
+	for i, info := range application.ClientImportFields {
+		fields[i] = dtos.ImportFieldResponse{Name: string(info.Field), Required: info.Required}
+	}
+	suggested := make(map[string]string, len(preview.Suggested))
+	for field, column := range preview.Suggested {
+		suggested[string(field)] = column
+	}
+	writeSuccessResponse(w, http.StatusOK, dtos.ImportPreviewResponse{
+		Delimiter:        preview.Delimiter,
+		Columns:          preview.Columns,
+		SampleRows:       preview.SampleRows,
+		Fields:           fields,
+		SuggestedMapping: suggested,
+	})
+}
+
+// ImportClients handles POST /imports/clients requests: a multipart form whose "mapping" part (a JSON object of
+// client field -> column, as confirmed after the preview) comes before the "file" part, which is streamed
+func (h *ClientImportHandler) ImportClients(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+	form, err := r.MultipartReader()
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_FORM", "Import must be a multipart form with mapping and file parts", "")
+		return
+	}
+
+	var mapping application.ClientImportMapping
+	for {
+		part, err := form.NextPart()
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, "INVALID_FORM", "Import form must have a mapping part followed by a file part", "")
+			return
+		}
+		switch part.FormName() {
+		case "mapping":
+			if err := json.NewDecoder(part).Decode(&mapping); err != nil {
+				writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "mapping must be a JSON object of client field to column", "mapping")
+				return
+			}
+		case "file":
+			if mapping == nil {
+				writeErrorResponse(w, http.StatusBadRequest, "INVALID_FORM", "The mapping part must come before the file part", "mapping")
+				return
+			}
+			h.importFile(w, r, part, mapping)
+			return
+		}
+	}
+}
+
+// importFile imports the clients of the file part of an import form
+func (h *ClientImportHandler) importFile(w http.ResponseWriter, r *http.Request, file *multipart.Part, mapping application.ClientImportMapping) {
+	result, err := h.billingService.ImportClients(middleware.RequestContextFromRequest(r), file, mapping)
+	if err != nil {
+		h.handleImportError(w, err)
+		return
+	}
+
+	failed := make([]dtos.ImportRowErrorResponse, len(result.Failed))
+	for i, row := range result.Failed {
+		failed[i] = dtos.ImportRowErrorResponse{Line: row.Line, Error: importErrorDetail(row.Err)}
+	}
+	writeSuccessResponse(w, http.StatusOK, dtos.ClientImportResponse{
+		Rows:     result.Rows,
+		Imported: result.Imported,
+		Failed:   failed,
+	})
+}
+
+// Statuses of the rows of an import report
+const (
+	ImportRowImported = "imported"
+	ImportRowFailed   = "failed"
+)
+
+// ImportCSV handles POST /clients/import requests: a raw CSV file whose columns are read by the suggested mapping
+// (columns named like the client fields), imported in one step and reported row by row
+func (h *ClientImportHandler) ImportCSV(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	body := http.MaxBytesReader(w, r.Body, maxImportSize)
+	result, err := h.billingService.ImportClientsWithSuggestedMapping(middleware.RequestContextFromRequest(r), body)
+	if err != nil {
+		h.handleImportError(w, err)
+		return
+	}
+
+	results := make([]dtos.ImportRowResultResponse, 0, len(result.Created)+len(result.Failed))
+	for _, row := range result.Created {
+		results = append(results, dtos.ImportRowResultResponse{Line: row.Line, Status: ImportRowImported, ClientID: row.ClientID})
+	}
+	for _, row := range result.Failed {
+		detail := importErrorDetail(row.Err)
+		results = append(results, dtos.ImportRowResultResponse{Line: row.Line, Status: ImportRowFailed, Error: &detail})
+	}
+	slices.SortFunc(results, func(a, b dtos.ImportRowResultResponse) int { return a.Line - b.Line })
+
+	mapping := make(map[string]string, len(result.Mapping))
+	for field, column := range result.Mapping {
+		mapping[string(field)] = column
+	}
+	writeSuccessResponse(w, http.StatusOK, dtos.ClientImportReportResponse{
+		Mapping:  mapping,
+		Rows:     result.Rows,
+		Imported: result.Imported,
+		Failed:   len(result.Failed),
+		Results:  results,
+	})
+}
+
+// handleImportError writes the error of a preview or import, files over the size limit being reported as such
+func (h *ClientImportHandler) handleImportError(w http.ResponseWriter, err error) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		writeErrorResponse(w, http.StatusRequestEntityTooLarge, "IMPORT_TOO_LARGE", "Import file must be at most 10 MB", "")
+		return
+	}
+	handleDomainError(w, err)
+}
+
+// importErrorDetail describes why a row was not imported, like handleDomainError describes a failed request
+func importErrorDetail(err error) dtos.ErrorDetail {
+	detail := dtos.ErrorDetail{Code: string(domainErrors.GetErrorCode(err)), Message: domainErrors.GetUserMessage(err)}
+	var validationErr *domainErrors.ValidationError
+	if errors.As(err, &validationErr) {
+		detail.Field = validationErr.Field
+	}
+	var ruleErr *domainErrors.BusinessRuleError
+	if errors.As(err, &ruleErr) && len(ruleErr.Context) > 0 {
+		detail.Details = ruleErr.Context
+	}
+	return detail
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/middleware"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// ClientNoteHandler handles HTTP requests for the activity timeline of a client
+type ClientNoteHandler struct {
+	billingService *application.BillingService
+}
+
+// NewClientNoteHandler creates a new client note handler
+func NewClientNoteHandler(billingService *application.BillingService) *ClientNoteHandler {
+	return &ClientNoteHandler{
+		billingService: billingService,
+	}
+}
+
+// ListNotes handles GET /clients/{id}/notes?page=&limit= requests
+func (h *ClientNoteHandler) ListNotes(w http.ResponseWriter, r *http.Request, clientID string) {
+	paginationReq, ok := parsePagination(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := h.billingService.ListClientNotes(clientID, paginationReq.Page, paginationReq.Limit)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	responses := make([]dtos.ClientNoteResponse, len(result.Notes))
+	for i, note := range result.Notes {
+		responses[i] = toClientNoteResponse(note)
+	}
+
+	writePaginatedResponse(w, http.StatusOK, responses, &dtos.PaginationResponse{
+		Page:       result.Pagination.Page,
+		Limit:      result.Pagination.Limit,
+		TotalCount: result.Pagination.TotalCount,
+		TotalPages: result.Pagination.TotalPages,
+	})
+}
+
+// CreateNote handles POST /clients/{id}/notes requests; the note is attributed to the identified admin
+func (h *ClientNoteHandler) CreateNote(w http.ResponseWriter, r *http.Request, clientID string) {
+	var req dtos.ClientNoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format", "")
+		return
+	}
+
+	note, err := h.billingService.AddClientNote(middleware.RequestContextFromRequest(r), clientID, req)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusCreated, toClientNoteResponse(note))
+}
+
+// toClientNoteResponse converts a domain ClientNote entity to HTTP response DTO
+func toClientNoteResponse(note *entity.ClientNote) dtos.ClientNoteResponse {
+	return dtos.ClientNoteResponse{
+		ID:        note.ID(),
+		ClientID:  note.ClientID(),
+		Body:      note.Body(),
+		Author:    note.Author(),
+		CreatedAt: note.CreatedAt(),
+	}
+}
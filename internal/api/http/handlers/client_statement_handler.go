@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/middleware"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// ClientStatementHandler handles HTTP requests for client account statements
+type ClientStatementHandler struct {
+	statementService *application.ClientStatementService
+}
+
+// NewClientStatementHandler creates a new client statement handler
+func NewClientStatementHandler(statementService *application.ClientStatementService) *ClientStatementHandler {
+	return &ClientStatementHandler{
+		statementService: statementService,
+	}
+}
+
+// RequestStatement handles POST /clients/{id}/statements requests
+// The statement is generated (and emailed) by the next scheduler run; the response is the job to poll
+func (h *ClientStatementHandler) RequestStatement(w http.ResponseWriter, r *http.Request, clientID string) {
+	var req dtos.ClientStatementRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format", "")
+		return
+	}
+
+	rc := middleware.RequestContextFromRequest(r)
+	job, err := h.statementService.RequestStatement(rc.TenantID, clientID, req, rc.Locale)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusAccepted, toClientStatementJobResponse(job))
+}
+
+// GetJob handles GET /clients/{id}/statements/{job} requests
+func (h *ClientStatementHandler) GetJob(w http.ResponseWriter, r *http.Request, clientID, jobID string) {
+	job, err := h.statementService.GetJob(clientID, jobID)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toClientStatementJobResponse(job))
+}
+
+// GetDocument handles GET /clients/{id}/statements/{job}/document requests
+// The statement is served in its print layout, which the PDF pipeline prints
+func (h *ClientStatementHandler) GetDocument(w http.ResponseWriter, r *http.Request, clientID, jobID string) {
+	content, err := h.statementService.GetDocument(clientID, jobID)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	w.Write(content)
+}
+
+// ProcessPending handles POST /admin/statements/run requests (scheduler trigger)
+func (h *ClientStatementHandler) ProcessPending(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	jobs, err := h.statementService.ProcessPending(r.Context(), time.Now())
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	responses := make([]dtos.ClientStatementJobResponse, len(jobs))
+	for i, job := range jobs {
+		responses[i] = toClientStatementJobResponse(job)
+	}
+
+	writeSuccessResponse(w, http.StatusOK, dtos.ClientStatementRunResponse{
+		Processed: len(jobs),
+		Jobs:      responses,
+	})
+}
+
+// statementJobPath returns the path of a statement job
+func statementJobPath(job *entity.ClientStatementJob) string {
+	return "/api/v1/clients/" + job.ClientID() + "/statements/" + job.ID()
+}
+
+// toClientStatementJobResponse converts a domain ClientStatementJob entity to HTTP response DTO
+func toClientStatementJobResponse(job *entity.ClientStatementJob) dtos.ClientStatementJobResponse {
+	response := dtos.ClientStatementJobResponse{
+		ID:          job.ID(),
+		ClientID:    job.ClientID(),
+		Status:      string(job.Status()),
+		From:        job.From(),
+		To:          job.To(),
+		Currency:    job.Currency(),
+		Locale:      job.Locale(),
+		Recipient:   job.Recipient(),
+		Failure:     job.Failure(),
+		CreatedAt:   job.CreatedAt(),
+		CompletedAt: job.CompletedAt(),
+	}
+	if balance := job.ClosingBalance(); balance != nil {
+		amount := balance.Amount()
+		response.ClosingBalance = &amount
+	}
+	if job.Status() == entity.StatementJobCompleted {
+		response.DocumentURL = statementJobPath(job) + "/document"
+	}
+	return response
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// ClientSummaryHandler handles HTTP requests for the client list read model
+type ClientSummaryHandler struct {
+	billingService *application.BillingService
+}
+
+// NewClientSummaryHandler creates a new client summary handler
+func NewClientSummaryHandler(billingService *application.BillingService) *ClientSummaryHandler {
+	return &ClientSummaryHandler{
+		billingService: billingService,
+	}
+}
+
+// ListSummaries handles GET /clients/summaries?page=&limit= requests
+func (h *ClientSummaryHandler) ListSummaries(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	paginationReq, ok := parsePagination(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := h.billingService.ListClientSummaries(paginationReq.Page, paginationReq.Limit)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	responses := make([]dtos.ClientSummaryResponse, len(result.Summaries))
+	for i, summary := range result.Summaries {
+		responses[i] = toClientSummaryResponse(summary)
+	}
+
+	writePaginatedResponse(w, http.StatusOK, responses, &dtos.PaginationResponse{
+		Page:       result.Pagination.Page,
+		Limit:      result.Pagination.Limit,
+		TotalCount: result.Pagination.TotalCount,
+		TotalPages: result.Pagination.TotalPages,
+	})
+}
+
+// Rebuild handles POST /admin/read-models/client-summaries/rebuild requests
+func (h *ClientSummaryHandler) Rebuild(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	rebuilt, err := h.billingService.RebuildClientSummaries(time.Now(), nil)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, dtos.ClientSummaryRebuildResponse{Rebuilt: rebuilt})
+}
+
+// toClientSummaryResponse converts a domain ClientSummary to HTTP response DTO
+func toClientSummaryResponse(summary *entity.ClientSummary) dtos.ClientSummaryResponse {
+	balances := summary.Balances()
+	response := dtos.ClientSummaryResponse{
+		ClientID:     summary.ClientID(),
+		Name:         summary.Name(),
+		Email:        summary.Email(),
+		Balances:     make([]dtos.MoneyResponse, len(balances)),
+		OpenInvoices: summary.OpenInvoices(),
+		RefreshedAt:  summary.RefreshedAt(),
+	}
+	for i, balance := range balances {
+		response.Balances[i] = toMoneyResponse(balance)
+	}
+	if summary.LastInvoiceID() != "" {
+		response.LastInvoice = &dtos.LastInvoiceRef{
+			ID:       summary.LastInvoiceID(),
+			IssuedAt: *summary.LastInvoiceAt(),
+			Total:    toMoneyResponse(summary.LastInvoiceTotal()),
+		}
+	}
+	return response
+}
@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/middleware"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+)
+
+// ContractHandler handles HTTP requests for customer contracts
+type ContractHandler struct {
+	contractService *application.ContractService
+}
+
+// NewContractHandler creates a new contract handler
+func NewContractHandler(contractService *application.ContractService) *ContractHandler {
+	return &ContractHandler{
+		contractService: contractService,
+	}
+}
+
+// CreateContract handles POST /contracts requests
+func (h *ContractHandler) CreateContract(w http.ResponseWriter, r *http.Request) {
+	var req dtos.CreateContractRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format", "")
+		return
+	}
+
+	contract, err := h.contractService.CreateContract(middleware.RequestContextFromRequest(r), req)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusCreated, toContractResponse(contract, time.Now()))
+}
+
+// ListContracts handles GET /contracts requests (optional ?client_id= filter)
+func (h *ContractHandler) ListContracts(w http.ResponseWriter, r *http.Request) {
+	contracts, err := h.contractService.ListContracts(middleware.RequestContextFromRequest(r), r.URL.Query().Get("client_id"))
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	now := time.Now()
+	responses := make([]dtos.ContractResponse, len(contracts))
+	for i, contract := range contracts {
+		responses[i] = toContractResponse(contract, now)
+	}
+
+	writeSuccessResponse(w, http.StatusOK, responses)
+}
+
+// GetContract handles GET /contracts/{id} requests
+func (h *ContractHandler) GetContract(w http.ResponseWriter, r *http.Request, contractID string) {
+	contract, err := h.contractService.GetContract(middleware.RequestContextFromRequest(r), contractID)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toContractResponse(contract, time.Now()))
+}
+
+// LinkSubscription handles POST /contracts/{id}/subscriptions requests
+func (h *ContractHandler) LinkSubscription(w http.ResponseWriter, r *http.Request, contractID string) {
+	var req dtos.LinkContractSubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format", "")
+		return
+	}
+
+	contract, err := h.contractService.LinkSubscription(middleware.RequestContextFromRequest(r), contractID, req)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toContractResponse(contract, time.Now()))
+}
+
+// LinkInvoice handles POST /contracts/{id}/invoices requests
+func (h *ContractHandler) LinkInvoice(w http.ResponseWriter, r *http.Request, contractID string) {
+	var req dtos.LinkContractInvoiceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format", "")
+		return
+	}
+
+	contract, err := h.contractService.LinkInvoice(middleware.RequestContextFromRequest(r), contractID, req)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toContractResponse(contract, time.Now()))
+}
+
+// GetAttainmentReport handles GET /contracts/attainment requests
+// Lists the commitment attainment of every contract active today, soonest renewal first
+func (h *ContractHandler) GetAttainmentReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	now := time.Now()
+	contracts, err := h.contractService.ActiveContracts(middleware.RequestContextFromRequest(r), now)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	entries := make([]dtos.ContractAttainmentReportEntry, len(contracts))
+	for i, contract := range contracts {
+		entries[i] = dtos.ContractAttainmentReportEntry{
+			ContractID:     contract.ID(),
+			ClientID:       contract.ClientID(),
+			Name:           contract.Name(),
+			AccountManager: contract.AccountManager(),
+			RenewalDate:    contract.RenewalDate(),
+			Attainment:     toContractAttainmentResponse(contract.Attainment(now)),
+		}
+	}
+
+	writeSuccessResponse(w, http.StatusOK, entries)
+}
+
+// SendRenewalReminders handles POST /admin/contract-renewal-reminders requests (scheduled job trigger)
+func (h *ContractHandler) SendRenewalReminders(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	reminded, err := h.contractService.SendRenewalReminders(r.Context(), time.Now())
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	contractIDs := make([]string, len(reminded))
+	for i, contract := range reminded {
+		contractIDs[i] = contract.ID()
+	}
+
+	writeSuccessResponse(w, http.StatusOK, dtos.RenewalRemindersResponse{
+		Sent:        len(reminded),
+		ContractIDs: contractIDs,
+	})
+}
+
+// toContractResponse converts a domain Contract entity to HTTP response DTO
+func toContractResponse(contract *entity.Contract, now time.Time) dtos.ContractResponse {
+	invoices := make([]dtos.ContractInvoiceResponse, 0)
+	for _, invoice := range contract.Invoices() {
+		invoices = append(invoices, dtos.ContractInvoiceResponse{
+			InvoiceID:  invoice.InvoiceID,
+			Amount:     toMoneyResponse(invoice.Amount),
+			InvoicedAt: invoice.InvoicedAt,
+		})
+	}
+
+	subscriptionIDs := contract.SubscriptionIDs()
+	if subscriptionIDs == nil {
+		subscriptionIDs = make([]string, 0)
+	}
+
+	return dtos.ContractResponse{
+		ID:                    contract.ID(),
+		ClientID:              contract.ClientID(),
+		Name:                  contract.Name(),
+		AccountManager:        contract.AccountManager(),
+		StartDate:             contract.StartDate(),
+		EndDate:               contract.EndDate(),
+		RenewalDate:           contract.RenewalDate(),
+		CommittedAmount:       toMoneyResponse(contract.CommittedAmount()),
+		SubscriptionIDs:       subscriptionIDs,
+		Invoices:              invoices,
+		Attainment:            toContractAttainmentResponse(contract.Attainment(now)),
+		RenewalReminderSentAt: contract.RenewalReminderSentAt(),
+		CreatedAt:             contract.CreatedAt(),
+		UpdatedAt:             contract.UpdatedAt(),
+	}
+}
+
+// toContractAttainmentResponse converts commitment attainment to HTTP response DTO
+func toContractAttainmentResponse(attainment entity.ContractAttainment) dtos.ContractAttainmentResponse {
+	return dtos.ContractAttainmentResponse{
+		Committed:      toMoneyResponse(attainment.Committed),
+		Spent:          toMoneyResponse(attainment.Spent),
+		Remaining:      toMoneyResponse(attainment.Remaining),
+		AttainedBps:    attainment.AttainedBps,
+		TermElapsedBps: attainment.TermElapsedBps,
+		Attained:       attainment.Attained,
+		OnTrack:        attainment.OnTrack,
+	}
+}
+
+// toMoneyResponse converts a Money value object to HTTP response DTO
+func toMoneyResponse(money valueobject.Money) dtos.MoneyResponse {
+	return dtos.MoneyResponse{
+		Amount:   money.Amount(),
+		Currency: money.Currency(),
+	}
+}
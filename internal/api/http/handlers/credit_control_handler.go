@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/middleware"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+)
+
+// CreditControlHandler handles HTTP requests for client credit limits and exposure
+type CreditControlHandler struct {
+	creditService *application.CreditControlService
+}
+
+// NewCreditControlHandler creates a new credit control handler
+func NewCreditControlHandler(creditService *application.CreditControlService) *CreditControlHandler {
+	return &CreditControlHandler{
+		creditService: creditService,
+	}
+}
+
+// GetCredit handles GET /clients/{id}/credit requests
+func (h *CreditControlHandler) GetCredit(w http.ResponseWriter, r *http.Request, clientID string) {
+	credit, err := h.creditService.GetCredit(clientID)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toClientCreditResponse(credit))
+}
+
+// SetLimit handles PUT /clients/{id}/credit requests and responds with the resulting credit position
+func (h *CreditControlHandler) SetLimit(w http.ResponseWriter, r *http.Request, clientID string) {
+	var req dtos.SetCreditLimitRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format", "")
+		return
+	}
+
+	actor := middleware.AdminActorFromContext(r.Context())
+	if _, err := h.creditService.SetLimit(actor, clientID, req); err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	credit, err := h.creditService.GetCredit(clientID)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toClientCreditResponse(credit))
+}
+
+// DeleteLimit handles DELETE /clients/{id}/credit requests
+func (h *CreditControlHandler) DeleteLimit(w http.ResponseWriter, r *http.Request, clientID string) {
+	actor := middleware.AdminActorFromContext(r.Context())
+	if err := h.creditService.DeleteLimit(actor, clientID); err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// toClientCreditResponse converts the credit position of a client to HTTP response DTO
+func toClientCreditResponse(credit *application.ClientCredit) dtos.ClientCreditResponse {
+	response := dtos.ClientCreditResponse{
+		ClientID: credit.ClientID,
+		Exposure: make([]dtos.CreditExposureResponse, len(credit.Exposures)),
+	}
+	for i, exposure := range credit.Exposures {
+		response.Exposure[i] = dtos.CreditExposureResponse{
+			Currency:         exposure.Currency,
+			OpenInvoices:     exposure.OpenInvoices.Amount(),
+			OpenInvoiceCount: exposure.OpenInvoiceCount,
+			UnappliedCredit:  exposure.UnappliedCredit.Amount(),
+			Exposure:         exposure.Exposure().Amount(),
+		}
+	}
+
+	if credit.Limit != nil {
+		response.CreditLimit = &dtos.CreditLimitResponse{
+			Amount:    credit.Limit.Limit().Amount(),
+			Currency:  credit.Limit.Limit().Currency(),
+			Policy:    string(credit.Limit.Policy()),
+			UpdatedAt: credit.Limit.UpdatedAt(),
+		}
+	}
+	if available, ok := credit.Available(); ok {
+		amount := available.Amount()
+		response.Available = &amount
+		response.OverLimit = amount < 0
+	}
+
+	return response
+}
+
+// CreditLimitWarnings warns when an invoice change brings the exposure of its client to threshold (0-1) of its
+// credit limit or over it
+// Only the invoices returned by non-GET requests are checked; credit positions that cannot be read raise no warning
+func CreditLimitWarnings(creditService *application.CreditControlService, threshold float64) middleware.WarningRule {
+	return func(r *http.Request, data interface{}) []middleware.Warning {
+		invoice, ok := data.(dtos.InvoiceResponse)
+		if !ok || r.Method == http.MethodGet {
+			return nil
+		}
+
+		credit, err := creditService.GetCredit(invoice.ClientID)
+		if err != nil {
+			return nil
+		}
+		utilization, ok := credit.Utilization()
+		if !ok || utilization < threshold {
+			return nil
+		}
+
+		limit := credit.Limit.Limit()
+		if utilization > 1 {
+			return []middleware.Warning{{
+				Code:    "CREDIT_LIMIT_EXCEEDED",
+				Message: fmt.Sprintf("Client exposure is over its credit limit of %s (%.0f%% used)", limit, utilization*100),
+			}}
+		}
+		return []middleware.Warning{{
+			Code:    "CREDIT_LIMIT_APPROACHING",
+			Message: fmt.Sprintf("Client exposure is approaching its credit limit of %s (%.0f%% used)", limit, utilization*100),
+		}}
+	}
+}
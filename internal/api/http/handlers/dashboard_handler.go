@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/middleware"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+)
+
+// defaultDashboardWindow is the window request statistics cover without a ?window= parameter
+const defaultDashboardWindow = 15 * time.Minute
+
+// DashboardHandler handles the read-only admin requests feeding the operational dashboard
+type DashboardHandler struct {
+	dashboardService *application.DashboardService
+	requestMetrics   *middleware.RequestMetrics
+}
+
+// NewDashboardHandler creates a new dashboard handler
+func NewDashboardHandler(dashboardService *application.DashboardService, requestMetrics *middleware.RequestMetrics) *DashboardHandler {
+	return &DashboardHandler{
+		dashboardService: dashboardService,
+		requestMetrics:   requestMetrics,
+	}
+}
+
+// GetDashboard handles GET /admin/dashboard requests (optional ?window= for the request statistics)
+func (h *DashboardHandler) GetDashboard(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	window, ok := parseDashboardWindow(w, r)
+	if !ok {
+		return
+	}
+	queues, err := h.dashboardService.Queues()
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+	now := time.Now()
+	overdue, err := h.dashboardService.OverdueInvoices(now)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, dtos.DashboardResponse{
+		Requests:        toRequestStatsResponse(h.requestMetrics.Stats(window)),
+		Queues:          toQueueDepthResponses(queues),
+		OverdueInvoices: toOverdueInvoiceTotalsResponses(overdue),
+		GeneratedAt:     now.UTC(),
+	})
+}
+
+// GetRequests handles GET /admin/dashboard/requests requests (optional ?window=, e.g. 5m, at most 1h)
+func (h *DashboardHandler) GetRequests(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	window, ok := parseDashboardWindow(w, r)
+	if !ok {
+		return
+	}
+	writeSuccessResponse(w, http.StatusOK, toRequestStatsResponse(h.requestMetrics.Stats(window)))
+}
+
+// GetQueues handles GET /admin/dashboard/queues requests
+func (h *DashboardHandler) GetQueues(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	queues, err := h.dashboardService.Queues()
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+	writeSuccessResponse(w, http.StatusOK, toQueueDepthResponses(queues))
+}
+
+// GetOverdueInvoices handles GET /admin/dashboard/overdue-invoices requests
+func (h *DashboardHandler) GetOverdueInvoices(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	overdue, err := h.dashboardService.OverdueInvoices(time.Now())
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+	writeSuccessResponse(w, http.StatusOK, toOverdueInvoiceTotalsResponses(overdue))
+}
+
+// parseDashboardWindow reads the ?window= duration, writing a 400 response when it is invalid
+func parseDashboardWindow(w http.ResponseWriter, r *http.Request) (time.Duration, bool) {
+	raw := r.URL.Query().Get("window")
+	if raw == "" {
+		return defaultDashboardWindow, true
+	}
+
+	window, err := time.ParseDuration(raw)
+	if err != nil || window <= 0 || window > middleware.MaxRequestMetricsWindow {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETER", "window must be a positive duration of at most 1h, e.g. 15m", "window")
+		return 0, false
+	}
+	return window, true
+}
+
+// toRequestStatsResponse converts request statistics to HTTP response DTO
+func toRequestStatsResponse(stats middleware.RequestStats) dtos.RequestStatsResponse {
+	return dtos.RequestStatsResponse{
+		WindowSeconds:     int64(stats.Window.Seconds()),
+		Requests:          stats.Requests,
+		ClientErrors:      stats.ClientErrors,
+		ServerErrors:      stats.ServerErrors,
+		RequestsPerMinute: stats.RequestsPerMinute,
+		ErrorRate:         stats.ErrorRate,
+	}
+}
+
+// toQueueDepthResponses converts queue depths to HTTP response DTOs
+func toQueueDepthResponses(queues []application.QueueDepth) []dtos.QueueDepthResponse {
+	responses := make([]dtos.QueueDepthResponse, len(queues))
+	for i, queue := range queues {
+		responses[i] = dtos.QueueDepthResponse{
+			Name:  queue.Name,
+			Depth: queue.Depth,
+		}
+	}
+	return responses
+}
+
+// toOverdueInvoiceTotalsResponses converts overdue invoice totals to HTTP response DTOs
+func toOverdueInvoiceTotalsResponses(overdue []application.OverdueInvoiceTotals) []dtos.OverdueInvoiceTotalsResponse {
+	responses := make([]dtos.OverdueInvoiceTotalsResponse, len(overdue))
+	for i, totals := range overdue {
+		aging := make([]dtos.OverdueAgingBucketResponse, len(totals.Aging))
+		for j, bucket := range totals.Aging {
+			aging[j] = dtos.OverdueAgingBucketResponse{
+				Label:       bucket.Label,
+				MinDays:     bucket.MinDays,
+				Invoices:    bucket.Invoices,
+				Outstanding: toMoneyResponse(bucket.Outstanding),
+			}
+			if bucket.MaxDays > 0 {
+				maxDays := bucket.MaxDays
+				aging[j].MaxDays = &maxDays
+			}
+		}
+
+		responses[i] = dtos.OverdueInvoiceTotalsResponse{
+			Currency:      totals.Currency,
+			Invoices:      totals.Invoices,
+			Outstanding:   toMoneyResponse(totals.Outstanding),
+			OldestDueDate: totals.OldestDueDate,
+			Aging:         aging,
+		}
+	}
+	return responses
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/middleware"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+)
+
+// DeadLetterHandler handles admin requests on the dead letter queue
+type DeadLetterHandler struct {
+	deadLetterService *application.DeadLetterService
+}
+
+// NewDeadLetterHandler creates a new dead letter handler
+func NewDeadLetterHandler(deadLetterService *application.DeadLetterService) *DeadLetterHandler {
+	return &DeadLetterHandler{
+		deadLetterService: deadLetterService,
+	}
+}
+
+// ListDeadLetters handles GET /admin/dead-letters requests (optional ?source= filter)
+func (h *DeadLetterHandler) ListDeadLetters(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	letters, err := h.deadLetterService.List(r.URL.Query().Get("source"))
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	responses := make([]dtos.DeadLetterResponse, len(letters))
+	for i, letter := range letters {
+		responses[i] = toDeadLetterResponse(letter)
+	}
+	writeSuccessResponse(w, http.StatusOK, responses)
+}
+
+// GetDeadLetter handles GET /admin/dead-letters/{id} requests
+func (h *DeadLetterHandler) GetDeadLetter(w http.ResponseWriter, r *http.Request, id string) {
+	letter, err := h.deadLetterService.Get(id)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toDeadLetterResponse(*letter))
+}
+
+// Retry handles POST /admin/dead-letters/retry requests
+func (h *DeadLetterHandler) Retry(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	var req dtos.DeadLetterBulkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format", "")
+		return
+	}
+
+	result, err := h.deadLetterService.Retry(r.Context(), middleware.AdminActorFromContext(r.Context()), req, time.Now())
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toDeadLetterBulkResponse(result))
+}
+
+// Discard handles POST /admin/dead-letters/discard requests
+func (h *DeadLetterHandler) Discard(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	var req dtos.DeadLetterBulkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format", "")
+		return
+	}
+
+	result, err := h.deadLetterService.Discard(middleware.AdminActorFromContext(r.Context()), req)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toDeadLetterBulkResponse(result))
+}
+
+// toDeadLetterResponse converts an application DeadLetter to HTTP response DTO
+func toDeadLetterResponse(letter application.DeadLetter) dtos.DeadLetterResponse {
+	return dtos.DeadLetterResponse{
+		ID:        letter.ID,
+		Source:    letter.Source,
+		Reference: letter.Reference,
+		Payload:   letter.Payload,
+		Headers:   letter.Headers,
+		Reason:    letter.Reason,
+		Attempts:  letter.Attempts,
+		FailedAt:  letter.FailedAt,
+	}
+}
+
+// toDeadLetterBulkResponse converts an application DeadLetterBulkResult to HTTP response DTO
+func toDeadLetterBulkResponse(result *application.DeadLetterBulkResult) dtos.DeadLetterBulkResponse {
+	response := dtos.DeadLetterBulkResponse{
+		Succeeded: result.Succeeded,
+		Failed:    make([]dtos.DeadLetterFailureResponse, len(result.Failed)),
+	}
+	for i, failure := range result.Failed {
+		response.Failed[i] = dtos.DeadLetterFailureResponse{ID: failure.ID, Error: failure.Error}
+	}
+	return response
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Routes of the published API description
+const (
+	OpenAPIPath = "/api/v1/openapi.json"
+	DocsPath    = "/docs"
+)
+
+// DefaultSwaggerUIAssets is where the Swagger UI page loads its scripts and styles from (a pinned release)
+const DefaultSwaggerUIAssets = "https://cdn.jsdelivr.net/npm/swagger-ui-dist@5.17.14"
+
+// DocsHandler serves the OpenAPI description of the API as JSON and a Swagger UI page rendering it
+type DocsHandler struct {
+	spec   []byte // JSON document
+	page   []byte
+	assets string
+}
+
+// NewDocsHandler converts an OpenAPI 3 document from YAML to the JSON served; assets is the base URL of the
+// swagger-ui-dist files (DefaultSwaggerUIAssets when empty), for deployments hosting them next to the API
+func NewDocsHandler(spec []byte, assets string) (*DocsHandler, error) {
+	var doc interface{}
+	if err := yaml.Unmarshal(spec, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document: %w", err)
+	}
+	doc = jsonCompatible(doc)
+	root, _ := doc.(map[string]interface{})
+	if version, _ := root["openapi"].(string); !strings.HasPrefix(version, "3.") {
+		return nil, fmt.Errorf("document is not an OpenAPI 3 description")
+	}
+	encoded, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode OpenAPI document: %w", err)
+	}
+
+	if assets == "" {
+		assets = DefaultSwaggerUIAssets
+	}
+	var page strings.Builder
+	if err := docsTemplate.Execute(&page, map[string]string{"Assets": strings.TrimSuffix(assets, "/"), "Spec": OpenAPIPath}); err != nil {
+		return nil, fmt.Errorf("failed to render API docs page: %w", err)
+	}
+	return &DocsHandler{spec: encoded, page: []byte(page.String()), assets: assets}, nil
+}
+
+// jsonCompatible turns the maps YAML decodes with non-string keys (e.g. unquoted status codes) into string-keyed
+// maps, which JSON objects require
+func jsonCompatible(node interface{}) interface{} {
+	switch value := node.(type) {
+	case map[string]interface{}:
+		for key, child := range value {
+			value[key] = jsonCompatible(child)
+		}
+		return value
+	case map[interface{}]interface{}:
+		converted := make(map[string]interface{}, len(value))
+		for key, child := range value {
+			converted[fmt.Sprint(key)] = jsonCompatible(child)
+		}
+		return converted
+	case []interface{}:
+		for i, child := range value {
+			value[i] = jsonCompatible(child)
+		}
+		return value
+	default:
+		return value
+	}
+}
+
+// Spec handles GET /api/v1/openapi.json requests
+func (h *DocsHandler) Spec(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(h.spec)
+}
+
+// Page handles GET /docs requests
+func (h *DocsHandler) Page(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Content-Security-Policy", fmt.Sprintf(
+		"default-src 'self'; script-src 'self' 'unsafe-inline' %[1]s; style-src 'self' 'unsafe-inline' %[1]s; img-src 'self' data: %[1]s",
+		assetsOrigin(h.assets)))
+	w.Write(h.page)
+}
+
+// assetsOrigin returns the origin of an assets URL ("'self'" for assets served by the API)
+func assetsOrigin(assets string) string {
+	scheme, rest, found := strings.Cut(assets, "://")
+	if !found {
+		return "'self'"
+	}
+	host, _, _ := strings.Cut(rest, "/")
+	return scheme + "://" + host
+}
+
+var docsTemplate = template.Must(template.New("docs").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Billing API Reference</title>
+<link rel="stylesheet" href="{{.Assets}}/swagger-ui.css">
+</head>
+<body>
+<div id="swagger-ui"></div>
+<script src="{{.Assets}}/swagger-ui-bundle.js"></script>
+<script>
+window.onload = function () {
+  window.ui = SwaggerUIBundle({ url: "{{.Spec}}", dom_id: "#swagger-ui", deepLinking: true });
+};
+</script>
+</body>
+</html>
+`))
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/middleware"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+)
+
+// DocumentTemplateHandler handles HTTP requests for tenant invoice layout administration
+type DocumentTemplateHandler struct {
+	templateService *application.DocumentTemplateService
+}
+
+// NewDocumentTemplateHandler creates a new document template handler
+func NewDocumentTemplateHandler(templateService *application.DocumentTemplateService) *DocumentTemplateHandler {
+	return &DocumentTemplateHandler{
+		templateService: templateService,
+	}
+}
+
+// ListTemplates handles GET /admin/document-templates requests
+func (h *DocumentTemplateHandler) ListTemplates(w http.ResponseWriter, r *http.Request) {
+	templates, err := h.templateService.ListTemplates()
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	responses := make([]dtos.DocumentTemplateResponse, len(templates))
+	for i, template := range templates {
+		responses[i] = toDocumentTemplateResponse(template)
+	}
+
+	writeSuccessResponse(w, http.StatusOK, responses)
+}
+
+// GetTemplate handles GET /admin/document-templates/{tenant} requests
+// Tenants without a template get the default layout the renderers apply to them
+func (h *DocumentTemplateHandler) GetTemplate(w http.ResponseWriter, r *http.Request, tenantID string) {
+	template, err := h.templateService.GetTemplate(tenantID)
+	if err != nil {
+		if errors.GetErrorCode(err) != errors.RepositoryNotFound {
+			handleDomainError(w, err)
+			return
+		}
+
+		response := toDocumentTemplateResponse(entity.DefaultDocumentTemplateFor(tenantID))
+		response.Default = true
+		response.UpdatedAt = nil
+		writeSuccessResponse(w, http.StatusOK, response)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toDocumentTemplateResponse(template))
+}
+
+// SetTemplate handles PUT /admin/document-templates/{tenant} requests
+func (h *DocumentTemplateHandler) SetTemplate(w http.ResponseWriter, r *http.Request, tenantID string) {
+	var req dtos.DocumentTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format", "")
+		return
+	}
+
+	actor := middleware.AdminActorFromContext(r.Context())
+	template, err := h.templateService.SetTemplate(actor, tenantID, req)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toDocumentTemplateResponse(template))
+}
+
+// DeleteTemplate handles DELETE /admin/document-templates/{tenant} requests
+func (h *DocumentTemplateHandler) DeleteTemplate(w http.ResponseWriter, r *http.Request, tenantID string) {
+	actor := middleware.AdminActorFromContext(r.Context())
+	if err := h.templateService.DeleteTemplate(actor, tenantID); err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Preview handles GET and POST /admin/document-templates/{tenant}/preview requests
+// GET renders the tenant's saved layout; POST renders the draft template in the body without saving it
+// The ?locale= query selects the language and formats of the sample, defaulting to the request locale
+func (h *DocumentTemplateHandler) Preview(w http.ResponseWriter, r *http.Request, tenantID string) {
+	locale := middleware.LocaleFromContext(r.Context())
+	if tag := r.URL.Query().Get("locale"); tag != "" {
+		parsed, err := valueobject.NewLocale(tag)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETER", "invalid locale parameter", "")
+			return
+		}
+		locale = parsed
+	}
+
+	var draft *dtos.DocumentTemplateRequest
+	if r.Method == http.MethodPost {
+		draft = &dtos.DocumentTemplateRequest{}
+		if err := json.NewDecoder(r.Body).Decode(draft); err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format", "")
+			return
+		}
+	}
+
+	preview, err := h.templateService.Preview(tenantID, draft, locale, time.Now())
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	w.Write(preview)
+}
+
+// toDocumentTemplateResponse converts a domain DocumentTemplate entity to HTTP response DTO
+func toDocumentTemplateResponse(template *entity.DocumentTemplate) dtos.DocumentTemplateResponse {
+	visible := template.Columns()
+	columns := make([]string, len(visible))
+	for i, column := range visible {
+		columns[i] = string(column)
+	}
+
+	updatedAt := template.UpdatedAt()
+	return dtos.DocumentTemplateResponse{
+		TenantID:     template.TenantID(),
+		LogoURL:      template.LogoURL(),
+		PrimaryColor: template.PrimaryColor(),
+		AccentColor:  template.AccentColor(),
+		Columns:      columns,
+		FooterText:   template.FooterText(),
+		UpdatedAt:    &updatedAt,
+	}
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+)
+
+// DunningHandler handles the admin requests running the overdue invoice reminder job
+type DunningHandler struct {
+	dunningService *application.DunningService
+}
+
+// NewDunningHandler creates a new dunning handler
+func NewDunningHandler(dunningService *application.DunningService) *DunningHandler {
+	return &DunningHandler{
+		dunningService: dunningService,
+	}
+}
+
+// SendDueReminders handles POST /admin/dunning/run requests (scheduler trigger)
+func (h *DunningHandler) SendDueReminders(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	reminders, err := h.dunningService.SendDueReminders(r.Context(), time.Now())
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	responses := make([]dtos.DunningReminderResponse, len(reminders))
+	for i, reminder := range reminders {
+		responses[i] = dtos.DunningReminderResponse{
+			InvoiceID:   reminder.Invoice.ID(),
+			Number:      reminder.Invoice.Number(),
+			ClientID:    reminder.Invoice.ClientID(),
+			Level:       reminder.Event.Level,
+			Channel:     string(reminder.Event.Channel),
+			Template:    reminder.Event.Template,
+			DaysOverdue: reminder.Event.DaysOverdue,
+		}
+	}
+
+	writeSuccessResponse(w, http.StatusOK, dtos.DunningRunResponse{
+		Sent:      len(reminders),
+		Reminders: responses,
+	})
+}
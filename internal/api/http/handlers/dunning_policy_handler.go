@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/middleware"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+)
+
+// DunningPolicyHandler handles HTTP requests for tenant payment reminder cadence administration
+type DunningPolicyHandler struct {
+	policyService *application.DunningPolicyService
+}
+
+// NewDunningPolicyHandler creates a new dunning policy handler
+func NewDunningPolicyHandler(policyService *application.DunningPolicyService) *DunningPolicyHandler {
+	return &DunningPolicyHandler{
+		policyService: policyService,
+	}
+}
+
+// ListPolicies handles GET /admin/dunning-policies requests
+func (h *DunningPolicyHandler) ListPolicies(w http.ResponseWriter, r *http.Request) {
+	policies, err := h.policyService.ListPolicies()
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	responses := make([]dtos.DunningPolicyResponse, len(policies))
+	for i, policy := range policies {
+		responses[i] = toDunningPolicyResponse(policy)
+	}
+
+	writeSuccessResponse(w, http.StatusOK, responses)
+}
+
+// GetPolicy handles GET /admin/dunning-policies/{tenant} requests
+// Tenants without a policy get the default cadence the dunning worker applies to them
+func (h *DunningPolicyHandler) GetPolicy(w http.ResponseWriter, r *http.Request, tenantID string) {
+	policy, err := h.policyService.GetPolicy(tenantID)
+	if err != nil {
+		if errors.GetErrorCode(err) != errors.RepositoryNotFound {
+			handleDomainError(w, err)
+			return
+		}
+
+		writeSuccessResponse(w, http.StatusOK, dtos.DunningPolicyResponse{
+			TenantID: tenantID,
+			Stages:   toReminderStageResponses(entity.DefaultReminderStages()),
+			Default:  true,
+		})
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toDunningPolicyResponse(policy))
+}
+
+// SetPolicy handles PUT /admin/dunning-policies/{tenant} requests
+func (h *DunningPolicyHandler) SetPolicy(w http.ResponseWriter, r *http.Request, tenantID string) {
+	var req dtos.SetDunningPolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format", "")
+		return
+	}
+
+	actor := middleware.AdminActorFromContext(r.Context())
+	policy, err := h.policyService.SetPolicy(actor, tenantID, req)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toDunningPolicyResponse(policy))
+}
+
+// DeletePolicy handles DELETE /admin/dunning-policies/{tenant} requests
+func (h *DunningPolicyHandler) DeletePolicy(w http.ResponseWriter, r *http.Request, tenantID string) {
+	actor := middleware.AdminActorFromContext(r.Context())
+	if err := h.policyService.DeletePolicy(actor, tenantID); err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// toDunningPolicyResponse converts a domain DunningPolicy entity to HTTP response DTO
+func toDunningPolicyResponse(policy *entity.DunningPolicy) dtos.DunningPolicyResponse {
+	updatedAt := policy.UpdatedAt()
+	return dtos.DunningPolicyResponse{
+		TenantID:  policy.TenantID(),
+		Stages:    toReminderStageResponses(policy.Stages()),
+		UpdatedAt: &updatedAt,
+	}
+}
+
+// toReminderStageResponses converts reminder stages to HTTP response DTOs
+func toReminderStageResponses(stages []entity.ReminderStage) []dtos.ReminderStageResponse {
+	responses := make([]dtos.ReminderStageResponse, len(stages))
+	for i, stage := range stages {
+		responses[i] = dtos.ReminderStageResponse{
+			DayOffset: stage.DayOffset(),
+			Channel:   string(stage.Channel()),
+			Template:  stage.Template(),
+		}
+	}
+	return responses
+}
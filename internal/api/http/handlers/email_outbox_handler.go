@@ -0,0 +1,228 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// EmailOutboxHandler handles admin requests on the outbox of notification emails and the suppression list
+type EmailOutboxHandler struct {
+	outboxService *application.EmailOutboxService
+}
+
+// NewEmailOutboxHandler creates a new email outbox handler
+func NewEmailOutboxHandler(outboxService *application.EmailOutboxService) *EmailOutboxHandler {
+	return &EmailOutboxHandler{
+		outboxService: outboxService,
+	}
+}
+
+// ListEmails handles GET /admin/email-outbox requests (optional ?status= filter)
+func (h *EmailOutboxHandler) ListEmails(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	emails, err := h.outboxService.ListEmails(entity.OutboundEmailStatus(r.URL.Query().Get("status")))
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	responses := make([]dtos.OutboundEmailResponse, len(emails))
+	for i, email := range emails {
+		responses[i] = toOutboundEmailResponse(email)
+	}
+	writeSuccessResponse(w, http.StatusOK, responses)
+}
+
+// GetEmail handles GET /admin/email-outbox/{id} requests
+func (h *EmailOutboxHandler) GetEmail(w http.ResponseWriter, r *http.Request, emailID string) {
+	email, err := h.outboxService.GetEmail(emailID)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toOutboundEmailResponse(email))
+}
+
+// RetryEmail handles POST /admin/email-outbox/{id}/retry requests
+func (h *EmailOutboxHandler) RetryEmail(w http.ResponseWriter, r *http.Request, emailID string) {
+	email, err := h.outboxService.RetryEmail(emailID, time.Now())
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toOutboundEmailResponse(email))
+}
+
+// ProcessOutbox handles POST /admin/email-outbox/run requests (worker trigger)
+func (h *EmailOutboxHandler) ProcessOutbox(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	emails, err := h.outboxService.ProcessOutbox(r.Context(), time.Now())
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	response := dtos.EmailOutboxRunResponse{
+		Sent:       []string{},
+		Retrying:   []string{},
+		Failed:     []string{},
+		Suppressed: []string{},
+	}
+	for _, email := range emails {
+		switch email.Status() {
+		case entity.OutboundEmailSent:
+			response.Sent = append(response.Sent, email.ID())
+		case entity.OutboundEmailPending:
+			response.Retrying = append(response.Retrying, email.ID())
+		case entity.OutboundEmailFailed:
+			response.Failed = append(response.Failed, email.ID())
+		case entity.OutboundEmailSuppressed:
+			response.Suppressed = append(response.Suppressed, email.ID())
+		}
+	}
+	writeSuccessResponse(w, http.StatusOK, response)
+}
+
+// Metrics handles GET /admin/email-outbox/metrics requests
+func (h *EmailOutboxHandler) Metrics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	metrics, err := h.outboxService.Metrics(time.Now())
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	response := dtos.EmailOutboxMetricsResponse{
+		ByStatus:            make(map[string]int, len(metrics.ByStatus)),
+		ByTopic:             metrics.ByTopic,
+		Attempts:            metrics.Attempts,
+		Retries:             metrics.Retries,
+		SuppressedAddresses: metrics.SuppressedAddresses,
+	}
+	for status, count := range metrics.ByStatus {
+		response.ByStatus[string(status)] = count
+	}
+	if metrics.OldestPendingAge != nil {
+		seconds := int64(metrics.OldestPendingAge.Seconds())
+		response.OldestPendingAgeSeconds = &seconds
+	}
+	writeSuccessResponse(w, http.StatusOK, response)
+}
+
+// ListSuppressions handles GET /admin/email-suppressions requests
+func (h *EmailOutboxHandler) ListSuppressions(w http.ResponseWriter, r *http.Request) {
+	suppressions, err := h.outboxService.ListSuppressions()
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	responses := make([]dtos.EmailSuppressionResponse, len(suppressions))
+	for i, suppression := range suppressions {
+		responses[i] = toEmailSuppressionResponse(suppression)
+	}
+	writeSuccessResponse(w, http.StatusOK, responses)
+}
+
+// Suppress handles POST /admin/email-suppressions requests
+func (h *EmailOutboxHandler) Suppress(w http.ResponseWriter, r *http.Request) {
+	var req dtos.SuppressEmailRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format", "")
+		return
+	}
+
+	suppression, err := h.outboxService.Suppress(req, time.Now())
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusCreated, toEmailSuppressionResponse(suppression))
+}
+
+// ReportBounce handles POST /admin/email-suppressions/bounces requests (mailer callback)
+func (h *EmailOutboxHandler) ReportBounce(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	var req dtos.EmailBounceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format", "")
+		return
+	}
+
+	suppression, err := h.outboxService.ReportBounce(req, time.Now())
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toEmailSuppressionResponse(suppression))
+}
+
+// Unsuppress handles DELETE /admin/email-suppressions/{email} requests
+func (h *EmailOutboxHandler) Unsuppress(w http.ResponseWriter, r *http.Request, address string) {
+	if err := h.outboxService.Unsuppress(address); err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// toOutboundEmailResponse converts an outbox email to HTTP response DTO
+func toOutboundEmailResponse(email *entity.OutboundEmail) dtos.OutboundEmailResponse {
+	response := dtos.OutboundEmailResponse{
+		ID:        email.ID(),
+		Topic:     email.Topic(),
+		Key:       email.Key(),
+		Recipient: email.Recipient(),
+		Payload:   email.Payload(),
+		Headers:   email.Headers(),
+		Status:    string(email.Status()),
+		Attempts:  email.Attempts(),
+		LastError: email.LastError(),
+		SentAt:    email.SentAt(),
+		CreatedAt: email.CreatedAt(),
+		UpdatedAt: email.UpdatedAt(),
+	}
+	if email.Status() == entity.OutboundEmailPending {
+		nextAttemptAt := email.NextAttemptAt()
+		response.NextAttemptAt = &nextAttemptAt
+	}
+	return response
+}
+
+// toEmailSuppressionResponse converts a suppressed address to HTTP response DTO
+func toEmailSuppressionResponse(suppression *entity.EmailSuppression) dtos.EmailSuppressionResponse {
+	return dtos.EmailSuppressionResponse{
+		Email:        suppression.Address(),
+		Reason:       string(suppression.Reason()),
+		Detail:       suppression.Detail(),
+		SoftBounces:  suppression.SoftBounces(),
+		SuppressedAt: suppression.SuppressedAt(),
+		UpdatedAt:    suppression.UpdatedAt(),
+	}
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+)
+
+// EventHandler handles requests on the event log (the events of the append-only event store)
+type EventHandler struct {
+	eventStore *application.EventStore
+}
+
+// NewEventHandler creates a new event handler
+func NewEventHandler(eventStore *application.EventStore) *EventHandler {
+	return &EventHandler{
+		eventStore: eventStore,
+	}
+}
+
+// ListEvents handles GET /events?type=&aggregate_id=&since=<timestamp|cursor>&limit= requests
+func (h *EventHandler) ListEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	params := r.URL.Query()
+	query := application.EventLogQuery{
+		Type:        params.Get("type"),
+		AggregateID: params.Get("aggregate_id"),
+		Since:       params.Get("since"),
+	}
+	if limit := params.Get("limit"); limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil || parsed <= 0 {
+			writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETER", "limit must be a positive integer", "limit")
+			return
+		}
+		query.Limit = parsed
+	}
+
+	page, err := h.eventStore.ListEvents(query)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	events := make([]dtos.EventResponse, len(page.Events))
+	for i, event := range page.Events {
+		events[i] = dtos.EventResponse{
+			Sequence:    event.Sequence(),
+			Type:        event.Type(),
+			AggregateID: event.AggregateID(),
+			Payload:     event.Payload(),
+			Headers:     event.Headers(),
+			OccurredAt:  event.OccurredAt(),
+		}
+	}
+
+	writeSuccessResponse(w, http.StatusOK, dtos.EventLogResponse{
+		Events:     events,
+		NextCursor: page.NextCursor,
+		HasMore:    page.HasMore,
+	})
+}
@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/middleware"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+)
+
+// FiscalCalendarHandler handles HTTP requests for tenant fiscal calendar and period lock administration
+type FiscalCalendarHandler struct {
+	calendarService *application.FiscalCalendarService
+}
+
+// NewFiscalCalendarHandler creates a new fiscal calendar handler
+func NewFiscalCalendarHandler(calendarService *application.FiscalCalendarService) *FiscalCalendarHandler {
+	return &FiscalCalendarHandler{
+		calendarService: calendarService,
+	}
+}
+
+// ListCalendars handles GET /admin/fiscal-calendars requests
+func (h *FiscalCalendarHandler) ListCalendars(w http.ResponseWriter, r *http.Request) {
+	calendars, err := h.calendarService.ListCalendars()
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	responses := make([]dtos.FiscalCalendarResponse, len(calendars))
+	for i, calendar := range calendars {
+		responses[i] = toFiscalCalendarResponse(calendar)
+	}
+
+	writeSuccessResponse(w, http.StatusOK, responses)
+}
+
+// GetCalendar handles GET /admin/fiscal-calendars/{tenant} requests
+// Tenants without a calendar get the default calendar years split into months
+func (h *FiscalCalendarHandler) GetCalendar(w http.ResponseWriter, r *http.Request, tenantID string) {
+	calendar, err := h.calendarService.GetCalendar(tenantID)
+	if err != nil {
+		if errors.GetErrorCode(err) != errors.RepositoryNotFound {
+			handleDomainError(w, err)
+			return
+		}
+
+		response := toFiscalCalendarResponse(entity.DefaultFiscalCalendar(tenantID))
+		response.Default = true
+		response.UpdatedAt = nil
+		writeSuccessResponse(w, http.StatusOK, response)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toFiscalCalendarResponse(calendar))
+}
+
+// SetCalendar handles PUT /admin/fiscal-calendars/{tenant} requests
+func (h *FiscalCalendarHandler) SetCalendar(w http.ResponseWriter, r *http.Request, tenantID string) {
+	var req dtos.SetFiscalCalendarRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format", "")
+		return
+	}
+
+	actor := middleware.AdminActorFromContext(r.Context())
+	calendar, err := h.calendarService.SetCalendar(actor, tenantID, req)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toFiscalCalendarResponse(calendar))
+}
+
+// DeleteCalendar handles DELETE /admin/fiscal-calendars/{tenant} requests
+func (h *FiscalCalendarHandler) DeleteCalendar(w http.ResponseWriter, r *http.Request, tenantID string) {
+	actor := middleware.AdminActorFromContext(r.Context())
+	if err := h.calendarService.DeleteCalendar(actor, tenantID); err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListPeriods handles GET /admin/fiscal-calendars/{tenant}/periods?year= requests
+// Without a year, the periods of the current fiscal year are listed
+func (h *FiscalCalendarHandler) ListPeriods(w http.ResponseWriter, r *http.Request, tenantID string) {
+	fiscalYear := 0
+	if yearStr := r.URL.Query().Get("year"); yearStr != "" {
+		year, err := strconv.Atoi(yearStr)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETER", "year must be a fiscal year, e.g. 2026", "year")
+			return
+		}
+		fiscalYear = year
+	}
+
+	fiscalYear, periods, err := h.calendarService.ListPeriods(tenantID, fiscalYear, time.Now())
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	responses := make([]dtos.FiscalPeriodResponse, len(periods))
+	for i, period := range periods {
+		responses[i] = toFiscalPeriodResponse(period)
+	}
+
+	writeSuccessResponse(w, http.StatusOK, dtos.FiscalPeriodListResponse{
+		TenantID:   tenantID,
+		FiscalYear: fiscalYear,
+		Periods:    responses,
+	})
+}
+
+// ClosePeriod handles POST /admin/fiscal-calendars/{tenant}/periods/{period}/close requests
+func (h *FiscalCalendarHandler) ClosePeriod(w http.ResponseWriter, r *http.Request, tenantID, periodKey string) {
+	actor := middleware.AdminActorFromContext(r.Context())
+	period, err := h.calendarService.ClosePeriod(actor, tenantID, periodKey)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toFiscalPeriodResponse(period))
+}
+
+// ReopenPeriod handles POST /admin/fiscal-calendars/{tenant}/periods/{period}/reopen requests
+func (h *FiscalCalendarHandler) ReopenPeriod(w http.ResponseWriter, r *http.Request, tenantID, periodKey string) {
+	actor := middleware.AdminActorFromContext(r.Context())
+	period, err := h.calendarService.ReopenPeriod(actor, tenantID, periodKey)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toFiscalPeriodResponse(period))
+}
+
+// toFiscalCalendarResponse converts a domain FiscalCalendar entity to HTTP response DTO
+func toFiscalCalendarResponse(calendar *entity.FiscalCalendar) dtos.FiscalCalendarResponse {
+	updatedAt := calendar.UpdatedAt()
+	return dtos.FiscalCalendarResponse{
+		TenantID:      calendar.TenantID(),
+		StartMonth:    int(calendar.StartMonth()),
+		PeriodMonths:  calendar.PeriodMonths(),
+		ClosedPeriods: calendar.ClosedPeriods(),
+		UpdatedAt:     &updatedAt,
+	}
+}
+
+// toFiscalPeriodResponse converts a fiscal period to HTTP response DTO
+func toFiscalPeriodResponse(period entity.FiscalPeriod) dtos.FiscalPeriodResponse {
+	status := "open"
+	if period.Closed {
+		status = "closed"
+	}
+	return dtos.FiscalPeriodResponse{
+		Period:     period.Key(),
+		FiscalYear: period.FiscalYear,
+		Number:     period.Number,
+		StartDate:  period.Start,
+		EndDate:    period.LastDay(),
+		Status:     status,
+	}
+}
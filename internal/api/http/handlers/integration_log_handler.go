@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+)
+
+// IntegrationLogHandler handles admin requests on the logs of outbound integration calls
+type IntegrationLogHandler struct {
+	logService *application.IntegrationLogService
+}
+
+// NewIntegrationLogHandler creates a new integration log handler
+func NewIntegrationLogHandler(logService *application.IntegrationLogService) *IntegrationLogHandler {
+	return &IntegrationLogHandler{
+		logService: logService,
+	}
+}
+
+// ListLogs handles GET /admin/integration-logs requests
+// (optional ?destination=, ?request_id=, ?failed=true and ?since= RFC 3339 filters)
+func (h *IntegrationLogHandler) ListLogs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	query := r.URL.Query()
+	filter := application.IntegrationLogFilter{
+		Destination: query.Get("destination"),
+		RequestID:   query.Get("request_id"),
+	}
+	switch failed := query.Get("failed"); failed {
+	case "", "false":
+	case "true":
+		filter.FailedOnly = true
+	default:
+		handleDomainError(w, errors.NewValidationError("failed", failed, errors.ValidationFormat, "failed must be true or false"))
+		return
+	}
+	if since := query.Get("since"); since != "" {
+		parsed, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETER", "since must be an RFC 3339 timestamp", "since")
+			return
+		}
+		filter.Since = parsed
+	}
+
+	logs, err := h.logService.List(filter)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	responses := make([]dtos.IntegrationLogResponse, len(logs))
+	for i, log := range logs {
+		responses[i] = toIntegrationLogResponse(log)
+	}
+	writeSuccessResponse(w, http.StatusOK, responses)
+}
+
+// GetLog handles GET /admin/integration-logs/{id} requests
+func (h *IntegrationLogHandler) GetLog(w http.ResponseWriter, r *http.Request, id string) {
+	log, err := h.logService.Get(id)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toIntegrationLogResponse(log))
+}
+
+// Cleanup handles POST /admin/integration-logs/cleanup requests (scheduler trigger)
+func (h *IntegrationLogHandler) Cleanup(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	removed, err := h.logService.Cleanup()
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, dtos.IntegrationLogCleanupResponse{Removed: removed})
+}
+
+// toIntegrationLogResponse converts an integration log to its response
+func toIntegrationLogResponse(log *entity.IntegrationLog) dtos.IntegrationLogResponse {
+	call := log.Call()
+	return dtos.IntegrationLogResponse{
+		ID:           log.ID(),
+		Destination:  call.Destination,
+		Method:       call.Method,
+		URL:          call.URL,
+		StatusCode:   call.StatusCode,
+		DurationMs:   call.Duration.Milliseconds(),
+		RequestBody:  call.RequestBody,
+		ResponseBody: call.ResponseBody,
+		Error:        call.Error,
+		RequestID:    call.RequestID,
+		Failed:       log.IsFailed(),
+		CreatedAt:    log.CreatedAt(),
+	}
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+)
+
+// InvoiceArchiveHandler handles admin requests on the archive of closed invoices
+type InvoiceArchiveHandler struct {
+	archive *application.InvoiceArchiveService
+}
+
+// NewInvoiceArchiveHandler creates a new invoice archive handler
+func NewInvoiceArchiveHandler(archive *application.InvoiceArchiveService) *InvoiceArchiveHandler {
+	return &InvoiceArchiveHandler{
+		archive: archive,
+	}
+}
+
+// Run handles POST /admin/invoice-archive/run requests (scheduler trigger)
+func (h *InvoiceArchiveHandler) Run(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	archived, err := h.archive.Run(time.Now())
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, dtos.InvoiceArchiveRunResponse{Archived: archived})
+}
+
+// GetArchived handles GET /admin/invoice-archive/{id} requests
+func (h *InvoiceArchiveHandler) GetArchived(w http.ResponseWriter, r *http.Request, invoiceID string) {
+	archived, err := h.archive.Get(invoiceID)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	payments := archived.Payments()
+	responses := make([]dtos.PaymentResponse, len(payments))
+	for i, payment := range payments {
+		responses[i] = toPaymentResponse(payment)
+	}
+
+	writeSuccessResponse(w, http.StatusOK, dtos.ArchivedInvoiceResponse{
+		Invoice:    toInvoiceResponse(archived.Invoice()),
+		Payments:   responses,
+		ArchivedAt: archived.ArchivedAt(),
+	})
+}
+
+// Rehydrate handles POST /admin/invoice-archive/{id}/rehydrate requests
+func (h *InvoiceArchiveHandler) Rehydrate(w http.ResponseWriter, r *http.Request, invoiceID string) {
+	invoice, err := h.archive.Rehydrate(invoiceID, time.Now())
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toInvoiceResponse(invoice))
+}
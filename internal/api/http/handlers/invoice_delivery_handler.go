@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// trackingPixel is a transparent 1x1 GIF served by the invoice view tracking endpoint
+var trackingPixel = []byte{
+	0x47, 0x49, 0x46, 0x38, 0x39, 0x61, 0x01, 0x00, 0x01, 0x00, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0xff, 0xff, 0xff, 0x21, 0xf9, 0x04, 0x01, 0x00, 0x00, 0x00, 0x00, 0x2c, 0x00, 0x00, 0x00, 0x00,
+	0x01, 0x00, 0x01, 0x00, 0x00, 0x02, 0x02, 0x44, 0x01, 0x00, 0x3b,
+}
+
+// InvoiceDeliveryHandler handles HTTP requests for invoice delivery tracking
+type InvoiceDeliveryHandler struct {
+	deliveryService *application.InvoiceDeliveryService
+}
+
+// NewInvoiceDeliveryHandler creates a new invoice delivery handler
+func NewInvoiceDeliveryHandler(deliveryService *application.InvoiceDeliveryService) *InvoiceDeliveryHandler {
+	return &InvoiceDeliveryHandler{
+		deliveryService: deliveryService,
+	}
+}
+
+// RecordEvent handles POST /invoices/{id}/delivery-events requests (mailer callbacks)
+// Sent emails get the tracking pixel path to embed when view tracking is enabled
+func (h *InvoiceDeliveryHandler) RecordEvent(w http.ResponseWriter, r *http.Request, invoiceID string) {
+	var req dtos.RecordDeliveryEventRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format", "")
+		return
+	}
+
+	event, err := h.deliveryService.RecordEvent(r.Context(), invoiceID, req)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	response := toDeliveryEventResponse(event)
+	if event.Type() == entity.DeliverySent {
+		if token := h.deliveryService.TrackingToken(invoiceID, event.Channel(), event.Recipient()); token != "" {
+			response.TrackingPixelPath = application.TrackingPixelPath(invoiceID, token)
+		}
+	}
+
+	writeSuccessResponse(w, http.StatusCreated, response)
+}
+
+// GetDelivery handles GET /invoices/{id}/delivery-events requests
+func (h *InvoiceDeliveryHandler) GetDelivery(w http.ResponseWriter, r *http.Request, invoiceID string) {
+	delivery, err := h.deliveryService.GetDelivery(invoiceID)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toInvoiceDeliveryResponse(delivery))
+}
+
+// TrackView handles GET /invoices/{id}/view.gif?token= requests from email clients and portal pages
+// The pixel is always served so invalid tokens reveal nothing; only verified views are recorded
+func (h *InvoiceDeliveryHandler) TrackView(w http.ResponseWriter, r *http.Request, invoiceID string) {
+	h.deliveryService.RecordView(invoiceID, r.URL.Query().Get("token"), time.Now())
+
+	w.Header().Set("Content-Type", "image/gif")
+	w.Header().Set("Cache-Control", "no-store, max-age=0")
+	w.WriteHeader(http.StatusOK)
+	w.Write(trackingPixel)
+}
+
+// toDeliveryEventResponse converts a delivery event entity to its HTTP response
+func toDeliveryEventResponse(event *entity.InvoiceDeliveryEvent) dtos.DeliveryEventResponse {
+	return dtos.DeliveryEventResponse{
+		ID:         event.ID(),
+		InvoiceID:  event.InvoiceID(),
+		Type:       string(event.Type()),
+		Channel:    string(event.Channel()),
+		Recipient:  event.Recipient(),
+		BounceType: string(event.BounceType()),
+		Detail:     event.Detail(),
+		OccurredAt: event.OccurredAt(),
+		RecordedAt: event.RecordedAt(),
+	}
+}
+
+// toInvoiceDeliveryResponse converts an invoice delivery history to its HTTP response
+func toInvoiceDeliveryResponse(delivery *application.InvoiceDelivery) dtos.InvoiceDeliveryResponse {
+	events := make([]dtos.DeliveryEventResponse, len(delivery.Events))
+	for i, event := range delivery.Events {
+		events[i] = toDeliveryEventResponse(event)
+	}
+
+	return dtos.InvoiceDeliveryResponse{
+		InvoiceID:     delivery.InvoiceID,
+		Status:        string(delivery.Summary.Status),
+		LastSentAt:    delivery.Summary.LastSentAt,
+		FirstViewedAt: delivery.Summary.FirstViewAt,
+		LastViewedAt:  delivery.Summary.LastViewAt,
+		ViewCount:     delivery.Summary.ViewCount,
+		BounceCount:   delivery.Summary.BounceCount,
+		LastBouncedAt: delivery.Summary.LastBounceAt,
+		Events:        events,
+	}
+}
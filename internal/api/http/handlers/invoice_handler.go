@@ -0,0 +1,274 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/middleware"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+)
+
+// InvoiceHandler handles HTTP requests for invoices
+type InvoiceHandler struct {
+	billingService *application.BillingService
+}
+
+// NewInvoiceHandler creates a new invoice handler
+func NewInvoiceHandler(billingService *application.BillingService) *InvoiceHandler {
+	return &InvoiceHandler{
+		billingService: billingService,
+	}
+}
+
+// CreateInvoice handles POST /invoices requests
+func (h *InvoiceHandler) CreateInvoice(w http.ResponseWriter, r *http.Request) {
+	var req dtos.CreateInvoiceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format", "")
+		return
+	}
+
+	invoice, err := h.billingService.CreateInvoice(middleware.RequestContextFromRequest(r), req)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusCreated, toInvoiceResponse(invoice))
+}
+
+// ListInvoices handles GET /invoices requests (optional ?client_id= and ?status= filters)
+// Pages are selected by ?cursor= and ?limit= (keyset pagination in creation order, no cursor starting from the
+// first invoice)
+func (h *InvoiceHandler) ListInvoices(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Has("page") {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETER", "invoices are paged by cursor, not page", "page")
+		return
+	}
+	pagination, ok := parsePagination(w, r)
+	if !ok {
+		return
+	}
+
+	page, err := h.billingService.ListInvoices(middleware.RequestContextFromRequest(r), application.InvoiceFilter{
+		ClientID: query.Get("client_id"),
+		Status:   entity.InvoiceStatus(query.Get("status")),
+	}, query.Get("cursor"), pagination.Limit)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	responses := make([]dtos.InvoiceResponse, len(page.Invoices))
+	for i, invoice := range page.Invoices {
+		responses[i] = toInvoiceResponse(invoice)
+	}
+
+	writeCursorPaginatedResponse(w, http.StatusOK, responses, &dtos.CursorPaginationResponse{
+		Limit:      page.Limit,
+		NextCursor: page.NextCursor,
+		HasMore:    page.HasMore,
+	})
+}
+
+// GetInvoice handles GET /invoices/{id} requests
+func (h *InvoiceHandler) GetInvoice(w http.ResponseWriter, r *http.Request, invoiceID string) {
+	invoice, err := h.billingService.GetInvoice(middleware.RequestContextFromRequest(r), invoiceID)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toInvoiceResponse(invoice))
+}
+
+// UpdateInvoice handles PUT /invoices/{id} requests
+func (h *InvoiceHandler) UpdateInvoice(w http.ResponseWriter, r *http.Request, invoiceID string) {
+	var req dtos.UpdateInvoiceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format", "")
+		return
+	}
+
+	invoice, err := h.billingService.UpdateInvoice(middleware.RequestContextFromRequest(r), invoiceID, req)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toInvoiceResponse(invoice))
+}
+
+// DeleteInvoice handles DELETE /invoices/{id} requests
+func (h *InvoiceHandler) DeleteInvoice(w http.ResponseWriter, r *http.Request, invoiceID string) {
+	if err := h.billingService.DeleteInvoice(middleware.RequestContextFromRequest(r), invoiceID); err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// IssueInvoice handles POST /invoices/{id}/issue requests
+func (h *InvoiceHandler) IssueInvoice(w http.ResponseWriter, r *http.Request, invoiceID string) {
+	invoice, err := h.billingService.IssueInvoice(middleware.RequestContextFromRequest(r), invoiceID, time.Now())
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toInvoiceResponse(invoice))
+}
+
+// VoidInvoice handles POST /invoices/{id}/void requests
+func (h *InvoiceHandler) VoidInvoice(w http.ResponseWriter, r *http.Request, invoiceID string) {
+	invoice, err := h.billingService.VoidInvoice(middleware.RequestContextFromRequest(r), invoiceID, time.Now())
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toInvoiceResponse(invoice))
+}
+
+// toInvoiceResponse converts a domain Invoice entity to HTTP response DTO
+func toInvoiceResponse(invoice *entity.Invoice) dtos.InvoiceResponse {
+	// Amounts and rates were validated on creation, so taxing a stored invoice cannot fail
+	tax, _ := service.CalculateInvoiceTax(invoice)
+	lines := invoice.Lines()
+	lineItems := make([]dtos.InvoiceLineResponse, len(lines))
+	for i, line := range lines {
+		amount, _ := invoice.LineAmount(line)
+		taxed, _ := invoice.LineTax(line)
+		lineItems[i] = dtos.InvoiceLineResponse{
+			Description: line.Description,
+			Quantity:    line.Quantity,
+			UnitAmount:  line.UnitAmount,
+			TaxRateBps:  line.TaxRateBps,
+			Amount:      toMoneyResponse(amount),
+			NetAmount:   toMoneyResponse(taxed.Net),
+			TaxAmount:   toMoneyResponse(taxed.Tax),
+		}
+	}
+	breakdown := make([]dtos.InvoiceTaxRateResponse, len(tax.Rates))
+	for i, rate := range tax.Rates {
+		breakdown[i] = dtos.InvoiceTaxRateResponse{
+			RateBps: rate.RateBps,
+			Taxable: toMoneyResponse(rate.Taxable),
+			Tax:     toMoneyResponse(rate.Tax),
+		}
+	}
+	balance, _ := invoice.Balance()
+
+	return dtos.InvoiceResponse{
+		ID:              invoice.ID(),
+		TenantID:        invoice.TenantID(),
+		ClientID:        invoice.ClientID(),
+		Currency:        invoice.Currency(),
+		Status:          string(invoice.Status()),
+		Number:          invoice.Number(),
+		LineItems:       lineItems,
+		TaxPricing:      string(invoice.TaxPricing()),
+		TaxJurisdiction: invoice.TaxJurisdiction(),
+		Subtotal:        toMoneyResponse(tax.Subtotal),
+		Tax:             toMoneyResponse(tax.Tax),
+		TaxBreakdown:    breakdown,
+		Total:           toMoneyResponse(tax.Total),
+		AmountPaid:      toMoneyResponse(invoice.AmountPaid()),
+		Balance:         toMoneyResponse(balance),
+		DueDate:         invoice.DueDate(),
+		PaymentTerms:    string(invoice.PaymentTerms()),
+		ExternalRef:     invoice.ExternalReference(),
+		LegalEntityID:   invoice.LegalEntityID(),
+		BuyerCountry:    invoice.BuyerCountry(),
+		BuyerTaxID:      invoice.BuyerTaxID(),
+		FiscalYear:      invoice.FiscalYear(),
+		FiscalPeriod:    invoice.FiscalPeriod(),
+		Compliance:      toInvoiceComplianceResponse(invoice),
+		Installments:    toInstallmentPlanResponse(invoice, time.Now()),
+		IssuedAt:        invoice.IssuedAt(),
+		PaidAt:          invoice.PaidAt(),
+		VoidedAt:        invoice.VoidedAt(),
+		Dunning:         toInvoiceDunningResponse(invoice.DunningEvents(), invoice.Currency()),
+		CreatedAt:       invoice.CreatedAt(),
+		UpdatedAt:       invoice.UpdatedAt(),
+	}
+}
+
+// toInvoiceComplianceResponse converts the document requirements recorded when an invoice was issued (nil without)
+func toInvoiceComplianceResponse(invoice *entity.Invoice) *dtos.InvoiceComplianceResponse {
+	if invoice.TaxBreakdown() == "" {
+		return nil
+	}
+	mentions := invoice.LegalMentions()
+	if mentions == nil {
+		mentions = []string{}
+	}
+	return &dtos.InvoiceComplianceResponse{TaxBreakdown: invoice.TaxBreakdown(), LegalMentions: mentions}
+}
+
+// toInstallmentPlanResponse converts the installments of an invoice at now (nil when it is paid at once)
+// Drafts only show how they will be split; the schedule is set once issued
+func toInstallmentPlanResponse(invoice *entity.Invoice, now time.Time) *dtos.InstallmentPlanResponse {
+	installments := invoice.Installments()
+	if installments.Count == 0 {
+		return nil
+	}
+	response := &dtos.InstallmentPlanResponse{Count: installments.Count, IntervalMonths: installments.IntervalMonths}
+
+	// The plan was validated when the invoice was issued, so building it again cannot fail
+	plan, _ := invoice.InstallmentPlan()
+	if plan == nil {
+		return response
+	}
+	outstanding := toMoneyResponse(plan.Outstanding())
+	response.Status = string(plan.Status(now))
+	response.Outstanding = &outstanding
+	for _, installment := range plan.Installments() {
+		response.Schedule = append(response.Schedule, dtos.InstallmentResponse{
+			Sequence: installment.Sequence(),
+			DueDate:  installment.DueDate(),
+			Amount:   toMoneyResponse(installment.Amount()),
+			Paid:     toMoneyResponse(installment.PaidAmount()),
+			Status:   string(installment.Status(now)),
+		})
+	}
+	return response
+}
+
+// toInvoiceDunningResponse converts the payment reminders sent for an invoice to its dunning status (nil before any)
+func toInvoiceDunningResponse(events []entity.DunningEvent, currency string) *dtos.InvoiceDunningResponse {
+	if len(events) == 0 {
+		return nil
+	}
+
+	response := &dtos.InvoiceDunningResponse{
+		Level:          events[len(events)-1].Level,
+		LastRemindedAt: events[len(events)-1].SentAt,
+		Events:         make([]dtos.DunningEventResponse, len(events)),
+	}
+	for i, event := range events {
+		response.Events[i] = toDunningEventResponse(event, currency)
+	}
+	return response
+}
+
+// toDunningEventResponse converts a payment reminder sent for an invoice to HTTP response DTO
+func toDunningEventResponse(event entity.DunningEvent, currency string) dtos.DunningEventResponse {
+	response := dtos.DunningEventResponse{
+		Level:       event.Level,
+		Channel:     string(event.Channel),
+		Template:    event.Template,
+		DaysOverdue: event.DaysOverdue,
+		SentAt:      event.SentAt,
+	}
+	if event.LateFee > 0 {
+		response.LateFee = &dtos.MoneyResponse{Amount: event.LateFee, Currency: currency}
+	}
+	return response
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/middleware"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+)
+
+// InvoicePaymentHandler handles HTTP requests for invoice payments: payments received and recorded against
+// invoices, and card payments charged through the payment gateway
+type InvoicePaymentHandler struct {
+	billingService *application.BillingService
+	paymentService *application.InvoicePaymentService
+}
+
+// NewInvoicePaymentHandler creates a new invoice payment handler
+func NewInvoicePaymentHandler(billingService *application.BillingService) *InvoicePaymentHandler {
+	return &InvoicePaymentHandler{
+		billingService: billingService,
+	}
+}
+
+// WithCardPayments charges cards through the payment saga for requests with a card payment method token
+func (h *InvoicePaymentHandler) WithCardPayments(paymentService *application.InvoicePaymentService) *InvoicePaymentHandler {
+	h.paymentService = paymentService
+	return h
+}
+
+// PayInvoice handles POST /invoices/{id}/payments requests
+// A payment received is recorded against the invoice and returned with the updated invoice; with a card payment
+// method token, the payment saga is returned with its outcome: completed, compensated (refunded), or running
+// until it is resumed
+func (h *InvoicePaymentHandler) PayInvoice(w http.ResponseWriter, r *http.Request, invoiceID string) {
+	var req dtos.RecordPaymentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format", "")
+		return
+	}
+
+	if req.PaymentMethod != "" {
+		h.chargeCard(w, r, invoiceID, req)
+		return
+	}
+
+	payment, invoice, err := h.billingService.RecordPayment(middleware.RequestContextFromRequest(r), invoiceID, req, time.Now())
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusCreated, dtos.RecordPaymentResponse{
+		Payment: toPaymentResponse(payment),
+		Invoice: toInvoiceResponse(invoice),
+	})
+}
+
+// ListPayments handles GET /invoices/{id}/payments requests
+func (h *InvoicePaymentHandler) ListPayments(w http.ResponseWriter, r *http.Request, invoiceID string) {
+	payments, err := h.billingService.ListPayments(invoiceID)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	responses := make([]dtos.PaymentResponse, len(payments))
+	for i, payment := range payments {
+		responses[i] = toPaymentResponse(payment)
+	}
+
+	writeSuccessResponse(w, http.StatusOK, responses)
+}
+
+// chargeCard starts the card payment saga of an invoice
+func (h *InvoicePaymentHandler) chargeCard(w http.ResponseWriter, r *http.Request, invoiceID string, req dtos.RecordPaymentRequest) {
+	if h.paymentService == nil {
+		handleDomainError(w, errors.NewValidationError("payment_method", req.PaymentMethod, errors.ValidationFormat, "card payments are not configured"))
+		return
+	}
+
+	saga, err := h.paymentService.PayInvoice(r.Context(), middleware.RequestContextFromRequest(r), invoiceID, dtos.PayInvoiceRequest{
+		PaymentMethod: req.PaymentMethod,
+	}, time.Now())
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusCreated, toSagaResponse(saga, false))
+}
+
+// toPaymentResponse converts a domain Payment entity to HTTP response DTO
+func toPaymentResponse(payment *entity.Payment) dtos.PaymentResponse {
+	return dtos.PaymentResponse{
+		ID:         payment.ID(),
+		InvoiceID:  payment.InvoiceID(),
+		Amount:     toMoneyResponse(payment.Amount()),
+		Method:     string(payment.Method()),
+		Reference:  payment.Reference(),
+		ReceivedAt: payment.ReceivedAt(),
+		CreatedAt:  payment.CreatedAt(),
+	}
+}
@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/middleware"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// LegalEntityHandler handles HTTP requests for legal entity administration
+type LegalEntityHandler struct {
+	entityService *application.LegalEntityService
+}
+
+// NewLegalEntityHandler creates a new legal entity handler
+func NewLegalEntityHandler(entityService *application.LegalEntityService) *LegalEntityHandler {
+	return &LegalEntityHandler{
+		entityService: entityService,
+	}
+}
+
+// CreateEntity handles POST /admin/legal-entities requests
+func (h *LegalEntityHandler) CreateEntity(w http.ResponseWriter, r *http.Request) {
+	var req dtos.LegalEntityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format", "")
+		return
+	}
+
+	actor := middleware.AdminActorFromContext(r.Context())
+	legalEntity, err := h.entityService.CreateEntity(actor, req)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusCreated, toLegalEntityResponse(legalEntity))
+}
+
+// ListEntities handles GET /admin/legal-entities requests
+func (h *LegalEntityHandler) ListEntities(w http.ResponseWriter, r *http.Request) {
+	legalEntities, err := h.entityService.ListEntities()
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	responses := make([]dtos.LegalEntityResponse, len(legalEntities))
+	for i, legalEntity := range legalEntities {
+		responses[i] = toLegalEntityResponse(legalEntity)
+	}
+
+	writeSuccessResponse(w, http.StatusOK, responses)
+}
+
+// GetEntity handles GET /admin/legal-entities/{id} requests
+func (h *LegalEntityHandler) GetEntity(w http.ResponseWriter, r *http.Request, entityID string) {
+	legalEntity, err := h.entityService.GetEntity(entityID)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toLegalEntityResponse(legalEntity))
+}
+
+// UpdateEntity handles PUT /admin/legal-entities/{id} requests
+func (h *LegalEntityHandler) UpdateEntity(w http.ResponseWriter, r *http.Request, entityID string) {
+	var req dtos.LegalEntityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format", "")
+		return
+	}
+
+	actor := middleware.AdminActorFromContext(r.Context())
+	legalEntity, err := h.entityService.UpdateEntity(actor, entityID, req)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toLegalEntityResponse(legalEntity))
+}
+
+// DeleteEntity handles DELETE /admin/legal-entities/{id} requests
+func (h *LegalEntityHandler) DeleteEntity(w http.ResponseWriter, r *http.Request, entityID string) {
+	actor := middleware.AdminActorFromContext(r.Context())
+	if err := h.entityService.DeleteEntity(actor, entityID); err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// toLegalEntityResponse converts a domain LegalEntity entity to HTTP response DTO
+func toLegalEntityResponse(legalEntity *entity.LegalEntity) dtos.LegalEntityResponse {
+	registrations := legalEntity.TaxRegistrations()
+	taxRegistrations := make([]dtos.TaxRegistrationResponse, len(registrations))
+	for i, registration := range registrations {
+		taxRegistrations[i] = dtos.TaxRegistrationResponse{
+			Country: registration.Country,
+			Number:  registration.Number,
+		}
+	}
+
+	numbering := legalEntity.Numbering()
+	response := dtos.LegalEntityResponse{
+		ID:               legalEntity.ID(),
+		Name:             legalEntity.Name(),
+		Address:          legalEntity.Address(),
+		Country:          legalEntity.Country(),
+		TaxRegistrations: taxRegistrations,
+		Numbering: dtos.InvoiceNumberingResponse{
+			Prefix:      numbering.Prefix,
+			Padding:     numbering.Padding,
+			YearlyReset: numbering.YearlyReset,
+		},
+		DocumentTemplate:  legalEntity.DocumentTemplate(),
+		Default:           legalEntity.IsDefault(),
+		HasIssuedInvoices: legalEntity.HasIssuedInvoices(),
+		CreatedAt:         legalEntity.CreatedAt(),
+		UpdatedAt:         legalEntity.UpdatedAt(),
+	}
+
+	if account := legalEntity.BankAccount(); !account.IsEmpty() {
+		response.BankAccount = &dtos.BankAccountResponse{
+			AccountHolder: account.AccountHolder(),
+			IBAN:          account.IBAN(),
+			BIC:           account.BIC(),
+			BankName:      account.BankName(),
+		}
+	}
+
+	return response
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/httpclient"
+)
+
+// OutboundClientHandler handles admin requests on the outbound HTTP clients
+type OutboundClientHandler struct {
+	clients *httpclient.Registry
+}
+
+// NewOutboundClientHandler creates a new outbound client handler
+func NewOutboundClientHandler(clients *httpclient.Registry) *OutboundClientHandler {
+	return &OutboundClientHandler{
+		clients: clients,
+	}
+}
+
+// ListStats handles GET /admin/outbound-clients requests: the call statistics and circuit state of every destination called so far
+func (h *OutboundClientHandler) ListStats(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	stats := h.clients.Stats()
+	response := make([]dtos.OutboundClientStatsResponse, len(stats))
+	for i, destination := range stats {
+		response[i] = dtos.OutboundClientStatsResponse{
+			Destination:      destination.Destination,
+			Requests:         destination.Requests,
+			Failures:         destination.Failures,
+			Retries:          destination.Retries,
+			Rejected:         destination.Rejected,
+			Circuit:          destination.Circuit,
+			AverageLatencyMs: destination.AverageLatency.Milliseconds(),
+		}
+	}
+
+	writeSuccessResponse(w, http.StatusOK, response)
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+)
+
+// PartitionHandler handles admin requests on the partitions of the high-volume tables
+type PartitionHandler struct {
+	maintenance *application.PartitionMaintenanceService
+}
+
+// NewPartitionHandler creates a new partition handler
+func NewPartitionHandler(maintenance *application.PartitionMaintenanceService) *PartitionHandler {
+	return &PartitionHandler{
+		maintenance: maintenance,
+	}
+}
+
+// Run handles POST /admin/partitions/run requests (scheduler trigger)
+func (h *PartitionHandler) Run(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	created, err := h.maintenance.Run(time.Now())
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, dtos.PartitionRunResponse{Created: created})
+}
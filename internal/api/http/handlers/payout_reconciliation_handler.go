@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// PayoutReconciliationHandler handles HTTP requests for gateway payout reconciliation
+type PayoutReconciliationHandler struct {
+	reconciliationService *application.PayoutReconciliationService
+}
+
+// NewPayoutReconciliationHandler creates a new payout reconciliation handler
+func NewPayoutReconciliationHandler(reconciliationService *application.PayoutReconciliationService) *PayoutReconciliationHandler {
+	return &PayoutReconciliationHandler{
+		reconciliationService: reconciliationService,
+	}
+}
+
+// Reconcile handles POST /admin/payout-reconciliations/run requests (scheduler trigger)
+func (h *PayoutReconciliationHandler) Reconcile(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	reports, err := h.reconciliationService.Reconcile(r.Context(), time.Now())
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	response := dtos.PayoutReconciliationRunResponse{
+		Reconciled: len(reports),
+		Reports:    make([]dtos.PayoutReconciliationResponse, len(reports)),
+	}
+	for i, report := range reports {
+		if report.Status() == entity.PayoutDiscrepant {
+			response.Discrepant++
+		}
+		response.Reports[i] = toPayoutReconciliationResponse(report)
+	}
+
+	writeSuccessResponse(w, http.StatusOK, response)
+}
+
+// ListReports handles GET /admin/payout-reconciliations requests (optional ?status= filter)
+func (h *PayoutReconciliationHandler) ListReports(w http.ResponseWriter, r *http.Request) {
+	reports, err := h.reconciliationService.ListReports(r.URL.Query().Get("status"))
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	responses := make([]dtos.PayoutReconciliationResponse, len(reports))
+	for i, report := range reports {
+		responses[i] = toPayoutReconciliationResponse(report)
+	}
+
+	writeSuccessResponse(w, http.StatusOK, responses)
+}
+
+// GetReport handles GET /admin/payout-reconciliations/{payoutID} requests
+func (h *PayoutReconciliationHandler) GetReport(w http.ResponseWriter, r *http.Request, payoutID string) {
+	report, err := h.reconciliationService.GetReport(payoutID)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toPayoutReconciliationResponse(report))
+}
+
+// toPayoutReconciliationResponse converts a domain PayoutReconciliation entity to HTTP response DTO
+func toPayoutReconciliationResponse(report *entity.PayoutReconciliation) dtos.PayoutReconciliationResponse {
+	discrepancies := report.Discrepancies()
+	responses := make([]dtos.PayoutDiscrepancyResponse, len(discrepancies))
+	for i, discrepancy := range discrepancies {
+		responses[i] = dtos.PayoutDiscrepancyResponse{
+			Kind:          string(discrepancy.Kind),
+			Reference:     discrepancy.Reference,
+			PaymentID:     discrepancy.PaymentID,
+			GatewayAmount: discrepancy.GatewayAmount,
+			LedgerAmount:  discrepancy.LedgerAmount,
+		}
+	}
+
+	return dtos.PayoutReconciliationResponse{
+		PayoutID:      report.PayoutID(),
+		ArrivalDate:   report.ArrivalDate(),
+		Amount:        toMoneyResponse(report.Amount()),
+		Status:        string(report.Status()),
+		ItemCount:     report.ItemCount(),
+		MatchedCount:  report.MatchedCount(),
+		Discrepancies: responses,
+		ReconciledAt:  report.ReconciledAt(),
+	}
+}
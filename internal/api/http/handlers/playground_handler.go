@@ -0,0 +1,268 @@
+package handlers
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PlaygroundPath is where the interactive API playground is served
+const PlaygroundPath = "/playground"
+
+// PlaygroundHandler serves an HTML page with one form per documented endpoint
+// The forms are generated from the OpenAPI description so they follow the routes and DTOs it documents
+type PlaygroundHandler struct {
+	spec       []byte
+	operations []PlaygroundOperation
+}
+
+// PlaygroundOperation is one documented endpoint rendered as a form
+type PlaygroundOperation struct {
+	ID          string
+	Method      string
+	Path        string
+	Summary     string
+	Tag         string
+	Params      []PlaygroundField
+	BodyFields  []PlaygroundField
+	NeedsBearer bool
+}
+
+// PlaygroundField is a form input for a parameter or request body property
+type PlaygroundField struct {
+	Name     string
+	In       string // path, query, header or body
+	Type     string
+	Required bool
+}
+
+// playgroundMethods fixes the order operations appear in for each path
+var playgroundMethods = []string{"get", "head", "post", "put", "patch", "delete"}
+
+// NewPlaygroundHandler creates a playground from an OpenAPI document
+func NewPlaygroundHandler(spec []byte) (*PlaygroundHandler, error) {
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(spec, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document: %w", err)
+	}
+
+	paths, ok := doc["paths"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("OpenAPI document has no paths")
+	}
+
+	pathNames := make([]string, 0, len(paths))
+	for path := range paths {
+		pathNames = append(pathNames, path)
+	}
+	sort.Strings(pathNames)
+
+	operations := make([]PlaygroundOperation, 0)
+	for _, path := range pathNames {
+		item, _ := paths[path].(map[string]interface{})
+		shared := playgroundParams(doc, item["parameters"])
+
+		for _, method := range playgroundMethods {
+			operation, ok := item[method].(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			id, _ := operation["operationId"].(string)
+			if id == "" {
+				id = method + strings.NewReplacer("/", "_", "{", "", "}", "").Replace(path)
+			}
+			summary, _ := operation["summary"].(string)
+			tag := "default"
+			if tags, ok := operation["tags"].([]interface{}); ok && len(tags) > 0 {
+				tag = fmt.Sprint(tags[0])
+			}
+			_, secured := operation["security"]
+
+			operations = append(operations, PlaygroundOperation{
+				ID:          id,
+				Method:      strings.ToUpper(method),
+				Path:        path,
+				Summary:     summary,
+				Tag:         tag,
+				Params:      append(append([]PlaygroundField{}, shared...), playgroundParams(doc, operation["parameters"])...),
+				BodyFields:  playgroundBodyFields(doc, operation["requestBody"]),
+				NeedsBearer: secured,
+			})
+		}
+	}
+
+	return &PlaygroundHandler{
+		spec:       spec,
+		operations: operations,
+	}, nil
+}
+
+// Operations returns the endpoints rendered by the playground
+func (h *PlaygroundHandler) Operations() []PlaygroundOperation {
+	return h.operations
+}
+
+// Page handles GET /playground requests
+func (h *PlaygroundHandler) Page(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := playgroundTemplate.Execute(w, h.operations); err != nil {
+		http.Error(w, "failed to render playground", http.StatusInternalServerError)
+	}
+}
+
+// Spec handles GET /playground/openapi.yaml requests
+func (h *PlaygroundHandler) Spec(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/yaml")
+	w.Write(h.spec)
+}
+
+// playgroundParams converts OpenAPI parameters (resolving $ref) into form fields
+func playgroundParams(doc map[string]interface{}, raw interface{}) []PlaygroundField {
+	list, _ := raw.([]interface{})
+	fields := make([]PlaygroundField, 0, len(list))
+	for _, entry := range list {
+		param := resolveOpenAPIRef(doc, entry)
+		name, _ := param["name"].(string)
+		in, _ := param["in"].(string)
+		required, _ := param["required"].(bool)
+		schema := resolveOpenAPIRef(doc, param["schema"])
+		kind, _ := schema["type"].(string)
+		fields = append(fields, PlaygroundField{Name: name, In: in, Type: kind, Required: required})
+	}
+	return fields
+}
+
+// playgroundBodyFields lists the top-level properties of a JSON request body schema
+func playgroundBodyFields(doc map[string]interface{}, raw interface{}) []PlaygroundField {
+	body := resolveOpenAPIRef(doc, raw)
+	content, _ := body["content"].(map[string]interface{})
+	media, _ := content["application/json"].(map[string]interface{})
+	schema := resolveOpenAPIRef(doc, media["schema"])
+
+	required := make(map[string]bool)
+	if names, ok := schema["required"].([]interface{}); ok {
+		for _, name := range names {
+			required[fmt.Sprint(name)] = true
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]interface{})
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		// Required fields first, then alphabetical
+		if required[names[i]] != required[names[j]] {
+			return required[names[i]]
+		}
+		return names[i] < names[j]
+	})
+
+	fields := make([]PlaygroundField, 0, len(names))
+	for _, name := range names {
+		property := resolveOpenAPIRef(doc, properties[name])
+		kind, _ := property["type"].(string)
+		fields = append(fields, PlaygroundField{Name: name, In: "body", Type: kind, Required: required[name]})
+	}
+	return fields
+}
+
+// resolveOpenAPIRef follows a local "#/..." $ref; non-objects resolve to an empty map
+func resolveOpenAPIRef(doc map[string]interface{}, raw interface{}) map[string]interface{} {
+	object, _ := raw.(map[string]interface{})
+	ref, ok := object["$ref"].(string)
+	if !ok || !strings.HasPrefix(ref, "#/") {
+		return object
+	}
+
+	var current interface{} = doc
+	for _, segment := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		node, _ := current.(map[string]interface{})
+		current = node[segment]
+	}
+	return resolveOpenAPIRef(doc, current)
+}
+
+var playgroundTemplate = template.Must(template.New("playground").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Billing API Playground</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 2rem; max-width: 960px; }
+details { border: 1px solid #ccc; border-radius: 4px; margin-bottom: .5rem; padding: .5rem 1rem; }
+summary { cursor: pointer; }
+.method { display: inline-block; min-width: 4.5rem; font-weight: bold; font-family: monospace; }
+label { display: block; margin: .4rem 0; }
+label span { display: inline-block; min-width: 11rem; font-family: monospace; }
+pre { background: #f6f6f6; padding: .5rem; overflow-x: auto; white-space: pre-wrap; }
+</style>
+</head>
+<body>
+<h1>Billing API Playground</h1>
+<p>Development only. Generated from <a href="/playground/openapi.yaml">openapi.yaml</a>; requests go to this server.</p>
+{{range .}}
+<details>
+<summary><span class="method">{{.Method}}</span> <code>{{.Path}}</code> {{.Summary}}</summary>
+<form data-method="{{.Method}}" data-path="{{.Path}}">
+{{if .NeedsBearer}}<label><span>Authorization (Bearer)</span><input name="bearer" data-in="bearer"></label>{{end}}
+{{range .Params}}<label><span>{{.Name}} ({{.In}}){{if .Required}} *{{end}}</span><input name="{{.Name}}" data-in="{{.In}}" data-type="{{.Type}}"{{if .Required}} required{{end}}></label>
+{{end}}{{range .BodyFields}}<label><span>{{.Name}}{{if .Required}} *{{end}}</span><input name="{{.Name}}" data-in="body" data-type="{{.Type}}"></label>
+{{end}}<button type="submit">Send</button>
+<pre class="result"></pre>
+</form>
+</details>
+{{end}}
+<script>
+document.querySelectorAll("form[data-method]").forEach(function (form) {
+  form.addEventListener("submit", async function (event) {
+    event.preventDefault();
+    var path = form.dataset.path, query = new URLSearchParams(), headers = {}, body = {}, hasBody = false;
+    form.querySelectorAll("input").forEach(function (input) {
+      var value = input.value;
+      if (value === "") return;
+      switch (input.dataset.in) {
+        case "path": path = path.replace("{" + input.name + "}", encodeURIComponent(value)); break;
+        case "query": query.append(input.name, value); break;
+        case "header": headers[input.name] = value; break;
+        case "bearer": headers["Authorization"] = "Bearer " + value; break;
+        case "body":
+          hasBody = true;
+          body[input.name] = input.dataset.type === "integer" || input.dataset.type === "number" ? Number(value)
+            : input.dataset.type === "array" || input.dataset.type === "object" ? JSON.parse(value) : value;
+      }
+    });
+    if (hasBody || form.querySelector("input[data-in=body]")) headers["Content-Type"] = "application/json";
+    var url = path + (query.toString() ? "?" + query : "");
+    var result = form.querySelector(".result");
+    try {
+      var response = await fetch(url, { method: form.dataset.method, headers: headers, body: form.querySelector("input[data-in=body]") ? JSON.stringify(body) : undefined });
+      var text = await response.text();
+      try { text = JSON.stringify(JSON.parse(text), null, 2); } catch (ignored) {}
+      result.textContent = form.dataset.method + " " + url + "\n" + response.status + " " + response.statusText + "\n\n" + text;
+    } catch (error) {
+      result.textContent = String(error);
+    }
+  });
+});
+</script>
+</body>
+</html>
+`))
@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/middleware"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// PortalHandler handles HTTP requests for the client self-service portal
+// Portal routes never take a client ID from the request: it comes from the verified access token
+type PortalHandler struct {
+	portalService *application.PortalService
+}
+
+// NewPortalHandler creates a new portal handler
+func NewPortalHandler(portalService *application.PortalService) *PortalHandler {
+	return &PortalHandler{
+		portalService: portalService,
+	}
+}
+
+// GetProfile handles GET /portal/v1/me requests
+func (h *PortalHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
+	client, err := h.portalService.GetProfile(middleware.PortalClientFromContext(r.Context()))
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toPortalProfileResponse(client))
+}
+
+// UpdateContactInfo handles PUT /portal/v1/me/contact requests
+func (h *PortalHandler) UpdateContactInfo(w http.ResponseWriter, r *http.Request) {
+	var req dtos.PortalContactRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format", "")
+		return
+	}
+
+	clientID := middleware.PortalClientFromContext(r.Context())
+	locale := middleware.LocaleFromContext(r.Context())
+	client, err := h.portalService.UpdateContactInfo(clientID, req, locale)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toPortalProfileResponse(client))
+}
+
+// ListInvoices handles GET /portal/v1/me/invoices requests
+func (h *PortalHandler) ListInvoices(w http.ResponseWriter, r *http.Request) {
+	pagination, ok := parsePagination(w, r)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	clientID := middleware.PortalClientFromContext(r.Context())
+	page, err := h.portalService.ListInvoices(clientID, entity.InvoiceStatus(query.Get("status")), query.Get("cursor"), pagination.Limit)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	responses := make([]dtos.PortalInvoiceResponse, len(page.Invoices))
+	for i, invoice := range page.Invoices {
+		responses[i] = toPortalInvoiceResponse(invoice)
+	}
+
+	writeCursorPaginatedResponse(w, http.StatusOK, responses, &dtos.CursorPaginationResponse{
+		Limit:      page.Limit,
+		NextCursor: page.NextCursor,
+		HasMore:    page.HasMore,
+	})
+}
+
+// GetInvoice handles GET /portal/v1/me/invoices/{id} requests
+func (h *PortalHandler) GetInvoice(w http.ResponseWriter, r *http.Request, invoiceID string) {
+	invoice, err := h.portalService.GetInvoice(middleware.PortalClientFromContext(r.Context()), invoiceID)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toPortalInvoiceResponse(invoice))
+}
+
+// GetInvoiceDocument handles GET /portal/v1/me/invoices/{id}/document requests
+// The invoice is served in its print layout, which the PDF pipeline prints
+func (h *PortalHandler) GetInvoiceDocument(w http.ResponseWriter, r *http.Request, invoiceID string) {
+	clientID := middleware.PortalClientFromContext(r.Context())
+	content, err := h.portalService.GetInvoiceDocument(clientID, invoiceID, middleware.LocaleFromContext(r.Context()))
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	w.Write(content)
+}
+
+// CreateSession handles POST /portal/v1/session requests
+// The sign-in link has already been verified and consumed by the RequireMagicLink middleware
+func (h *PortalHandler) CreateSession(w http.ResponseWriter, r *http.Request) {
+	link := middleware.MagicLinkFromContext(r.Context())
+	if link == nil {
+		writeErrorResponse(w, http.StatusUnauthorized, "UNAUTHORIZED", "A sign-in link is required", "")
+		return
+	}
+
+	h.writeAccessToken(w, link.Subject())
+}
+
+// IssueSignInLink handles POST /admin/portal-links/{clientID} requests
+func (h *PortalHandler) IssueSignInLink(w http.ResponseWriter, r *http.Request, clientID string) {
+	link, err := h.portalService.IssueSignInLink(clientID)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeSuccessResponse(w, http.StatusCreated, dtos.PortalSignInLinkResponse{
+		URL:       link.URL,
+		ExpiresAt: link.ExpiresAt,
+	})
+}
+
+// IssueAccessToken handles POST /admin/portal-tokens/{clientID} requests
+func (h *PortalHandler) IssueAccessToken(w http.ResponseWriter, r *http.Request, clientID string) {
+	h.writeAccessToken(w, clientID)
+}
+
+// writeAccessToken issues an access token for clientID and writes it as the response
+func (h *PortalHandler) writeAccessToken(w http.ResponseWriter, clientID string) {
+	token, err := h.portalService.IssueAccessToken(clientID)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeSuccessResponse(w, http.StatusCreated, dtos.PortalAccessTokenResponse{
+		Token:     token.Token,
+		ClientID:  token.ClientID,
+		ExpiresAt: token.ExpiresAt,
+	})
+}
+
+// toPortalInvoiceResponse converts an invoice to the portal view of it, a subset of the admin view
+func toPortalInvoiceResponse(invoice *entity.Invoice) dtos.PortalInvoiceResponse {
+	full := toInvoiceResponse(invoice)
+	return dtos.PortalInvoiceResponse{
+		ID:           full.ID,
+		Number:       full.Number,
+		Status:       full.Status,
+		Currency:     full.Currency,
+		LineItems:    full.LineItems,
+		Subtotal:     full.Subtotal,
+		Tax:          full.Tax,
+		TaxBreakdown: full.TaxBreakdown,
+		Total:        full.Total,
+		AmountPaid:   full.AmountPaid,
+		Balance:      full.Balance,
+		DueDate:      full.DueDate,
+		PaymentTerms: full.PaymentTerms,
+		ExternalRef:  full.ExternalRef,
+		Installments: full.Installments,
+		IssuedAt:     full.IssuedAt,
+		PaidAt:       full.PaidAt,
+		VoidedAt:     full.VoidedAt,
+	}
+}
+
+// toPortalProfileResponse converts a client to the portal view of its account
+func toPortalProfileResponse(client *entity.Client) dtos.PortalProfileResponse {
+	return dtos.PortalProfileResponse{
+		ID:      client.ID(),
+		Name:    client.Name(),
+		Email:   client.EmailString(),
+		Phone:   client.PhoneString(),
+		Address: client.Address(),
+	}
+}
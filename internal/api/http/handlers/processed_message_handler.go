@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+)
+
+// ProcessedMessageHandler handles admin requests on the records of processed messages
+type ProcessedMessageHandler struct {
+	consumer *application.IdempotentConsumer
+}
+
+// NewProcessedMessageHandler creates a new processed message handler
+func NewProcessedMessageHandler(consumer *application.IdempotentConsumer) *ProcessedMessageHandler {
+	return &ProcessedMessageHandler{
+		consumer: consumer,
+	}
+}
+
+// Cleanup handles POST /admin/processed-messages/cleanup requests (scheduler trigger)
+func (h *ProcessedMessageHandler) Cleanup(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	removed, err := h.consumer.Cleanup()
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, dtos.ProcessedMessageCleanupResponse{Removed: removed})
+}
@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/middleware"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// QuoteHandler handles HTTP requests for quotes
+type QuoteHandler struct {
+	quoteService *application.QuoteService
+}
+
+// NewQuoteHandler creates a new quote handler
+func NewQuoteHandler(quoteService *application.QuoteService) *QuoteHandler {
+	return &QuoteHandler{
+		quoteService: quoteService,
+	}
+}
+
+// CreateQuote handles POST /quotes requests
+func (h *QuoteHandler) CreateQuote(w http.ResponseWriter, r *http.Request) {
+	var req dtos.CreateQuoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format", "")
+		return
+	}
+
+	quote, err := h.quoteService.CreateQuote(middleware.RequestContextFromRequest(r), req)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusCreated, toQuoteResponse(quote))
+}
+
+// ListQuotes handles GET /quotes requests (optional ?client_id= and ?status= filters)
+func (h *QuoteHandler) ListQuotes(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	quotes, err := h.quoteService.ListQuotes(middleware.RequestContextFromRequest(r), query.Get("client_id"), entity.QuoteStatus(query.Get("status")))
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	responses := make([]dtos.QuoteResponse, len(quotes))
+	for i, quote := range quotes {
+		responses[i] = toQuoteResponse(quote)
+	}
+
+	writeSuccessResponse(w, http.StatusOK, responses)
+}
+
+// GetQuote handles GET /quotes/{id} requests
+func (h *QuoteHandler) GetQuote(w http.ResponseWriter, r *http.Request, quoteID string) {
+	quote, err := h.quoteService.GetQuote(middleware.RequestContextFromRequest(r), quoteID)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toQuoteResponse(quote))
+}
+
+// AcceptQuote handles POST /quotes/{id}/accept requests
+func (h *QuoteHandler) AcceptQuote(w http.ResponseWriter, r *http.Request, quoteID string) {
+	quote, err := h.quoteService.AcceptQuote(middleware.RequestContextFromRequest(r), quoteID, time.Now())
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toQuoteResponse(quote))
+}
+
+// RejectQuote handles POST /quotes/{id}/reject requests
+func (h *QuoteHandler) RejectQuote(w http.ResponseWriter, r *http.Request, quoteID string) {
+	var req dtos.RejectQuoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format", "")
+		return
+	}
+
+	quote, err := h.quoteService.RejectQuote(middleware.RequestContextFromRequest(r), quoteID, req, time.Now())
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toQuoteResponse(quote))
+}
+
+// ExpireQuote handles POST /quotes/{id}/expire requests
+func (h *QuoteHandler) ExpireQuote(w http.ResponseWriter, r *http.Request, quoteID string) {
+	quote, err := h.quoteService.ExpireQuote(middleware.RequestContextFromRequest(r), quoteID, time.Now())
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toQuoteResponse(quote))
+}
+
+// ConvertQuote handles POST /quotes/{id}/convert requests, responding with the created draft invoice
+func (h *QuoteHandler) ConvertQuote(w http.ResponseWriter, r *http.Request, quoteID string) {
+	_, invoice, err := h.quoteService.ConvertQuote(middleware.RequestContextFromRequest(r), quoteID, time.Now())
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusCreated, toInvoiceResponse(invoice))
+}
+
+// ExpireLapsedQuotes handles POST /admin/quotes/run requests (scheduler trigger)
+func (h *QuoteHandler) ExpireLapsedQuotes(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	quotes, err := h.quoteService.ExpireLapsedQuotes(time.Now())
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	expired := make([]string, len(quotes))
+	for i, quote := range quotes {
+		expired[i] = quote.ID()
+	}
+
+	writeSuccessResponse(w, http.StatusOK, dtos.QuoteExpiryRunResponse{Expired: expired})
+}
+
+// toQuoteResponse converts a quote entity to HTTP response DTO
+func toQuoteResponse(quote *entity.Quote) dtos.QuoteResponse {
+	// Amounts and rates were validated on creation, so taxing a stored quote cannot fail
+	totals, _ := quote.Totals()
+	lines := quote.Lines()
+	lineItems := make([]dtos.InvoiceLineResponse, len(lines))
+	for i, line := range lines {
+		amount, _ := quote.LineAmount(line)
+		taxed, _ := quote.LineTax(line)
+		lineItems[i] = dtos.InvoiceLineResponse{
+			Description: line.Description,
+			Quantity:    line.Quantity,
+			UnitAmount:  line.UnitAmount,
+			TaxRateBps:  line.TaxRateBps,
+			Amount:      toMoneyResponse(amount),
+			NetAmount:   toMoneyResponse(taxed.Net),
+			TaxAmount:   toMoneyResponse(taxed.Tax),
+		}
+	}
+
+	return dtos.QuoteResponse{
+		ID:              quote.ID(),
+		TenantID:        quote.TenantID(),
+		ClientID:        quote.ClientID(),
+		Currency:        quote.Currency(),
+		Status:          string(quote.Status()),
+		LineItems:       lineItems,
+		TaxPricing:      string(quote.TaxPricing()),
+		TaxJurisdiction: quote.TaxJurisdiction(),
+		Subtotal:        toMoneyResponse(totals.Net),
+		Tax:             toMoneyResponse(totals.Tax),
+		Total:           toMoneyResponse(totals.Gross),
+		ValidUntil:      quote.ValidUntil(),
+		AcceptedAt:      quote.AcceptedAt(),
+		RejectedAt:      quote.RejectedAt(),
+		RejectionReason: quote.RejectionReason(),
+		ExpiredAt:       quote.ExpiredAt(),
+		InvoiceID:       quote.InvoiceID(),
+		ConvertedAt:     quote.ConvertedAt(),
+		CreatedAt:       quote.CreatedAt(),
+		UpdatedAt:       quote.UpdatedAt(),
+	}
+}
@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/middleware"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+)
+
+// RecurringInvoiceHandler handles HTTP requests for recurring invoice templates
+type RecurringInvoiceHandler struct {
+	recurringService *application.RecurringInvoiceService
+}
+
+// NewRecurringInvoiceHandler creates a new recurring invoice handler
+func NewRecurringInvoiceHandler(recurringService *application.RecurringInvoiceService) *RecurringInvoiceHandler {
+	return &RecurringInvoiceHandler{
+		recurringService: recurringService,
+	}
+}
+
+// CreateTemplate handles POST /recurring-invoices requests
+func (h *RecurringInvoiceHandler) CreateTemplate(w http.ResponseWriter, r *http.Request) {
+	var req dtos.CreateRecurringInvoiceTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format", "")
+		return
+	}
+
+	template, err := h.recurringService.CreateTemplate(middleware.RequestContextFromRequest(r), req)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusCreated, toRecurringInvoiceTemplateResponse(template))
+}
+
+// ListTemplates handles GET /recurring-invoices requests (optional ?client_id= filter)
+func (h *RecurringInvoiceHandler) ListTemplates(w http.ResponseWriter, r *http.Request) {
+	templates, err := h.recurringService.ListTemplates(middleware.RequestContextFromRequest(r), r.URL.Query().Get("client_id"))
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	responses := make([]dtos.RecurringInvoiceTemplateResponse, len(templates))
+	for i, template := range templates {
+		responses[i] = toRecurringInvoiceTemplateResponse(template)
+	}
+
+	writeSuccessResponse(w, http.StatusOK, responses)
+}
+
+// GetTemplate handles GET /recurring-invoices/{id} requests
+func (h *RecurringInvoiceHandler) GetTemplate(w http.ResponseWriter, r *http.Request, templateID string) {
+	template, err := h.recurringService.GetTemplate(middleware.RequestContextFromRequest(r), templateID)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toRecurringInvoiceTemplateResponse(template))
+}
+
+// UpdateTemplate handles PUT /recurring-invoices/{id} requests
+func (h *RecurringInvoiceHandler) UpdateTemplate(w http.ResponseWriter, r *http.Request, templateID string) {
+	var req dtos.UpdateRecurringInvoiceTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format", "")
+		return
+	}
+
+	template, err := h.recurringService.UpdateTemplate(middleware.RequestContextFromRequest(r), templateID, req, time.Now())
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toRecurringInvoiceTemplateResponse(template))
+}
+
+// DeleteTemplate handles DELETE /recurring-invoices/{id} requests
+func (h *RecurringInvoiceHandler) DeleteTemplate(w http.ResponseWriter, r *http.Request, templateID string) {
+	if err := h.recurringService.DeleteTemplate(middleware.RequestContextFromRequest(r), templateID); err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// IssueDueInvoices handles POST /admin/recurring-invoices/run requests (scheduler trigger)
+func (h *RecurringInvoiceHandler) IssueDueInvoices(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	run, err := h.recurringService.IssueDueInvoices(r.Context(), time.Now())
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	invoices := make([]dtos.RecurringInvoiceIssueResponse, len(run.Issued))
+	for i, issue := range run.Issued {
+		invoices[i] = dtos.RecurringInvoiceIssueResponse{
+			TemplateID:    issue.Template.ID(),
+			InvoiceID:     issue.Invoice.ID(),
+			ClientID:      issue.Template.ClientID(),
+			IssueDate:     issue.IssueDate,
+			InvoiceNumber: issue.InvoiceNumber,
+			CreditWarning: issue.Credit != nil && issue.Credit.Decision == service.CreditOverLimitWarned,
+		}
+		if issue.LegalEntity != nil {
+			invoices[i].LegalEntityID = issue.LegalEntity.ID()
+		}
+		if issue.FiscalPeriod != nil {
+			invoices[i].FiscalPeriod = issue.FiscalPeriod.Key()
+		}
+	}
+
+	held := make([]dtos.RecurringInvoiceHoldResponse, len(run.Held))
+	for i, hold := range run.Held {
+		held[i] = dtos.RecurringInvoiceHoldResponse{
+			TemplateID: hold.Template.ID(),
+			ClientID:   hold.Template.ClientID(),
+			IssueDate:  hold.IssueDate,
+		}
+		switch {
+		case hold.ClosedPeriod != nil:
+			held[i].Decision = dtos.PeriodClosedHold
+			held[i].FiscalPeriod = hold.ClosedPeriod.Key()
+		case hold.Compliance != nil:
+			held[i].Decision = dtos.NonCompliantHold
+			for _, violation := range hold.Compliance.Violations {
+				held[i].Violations = append(held[i].Violations, dtos.ComplianceViolationResponse{
+					Rule:    violation.Rule,
+					Field:   violation.Field,
+					Message: violation.Message,
+				})
+			}
+		case hold.Risk != nil:
+			held[i].Decision = dtos.RiskDeclinedHold
+		case hold.Credit != nil:
+			held[i].Decision = string(hold.Credit.Decision)
+			if hold.Credit.Approval != nil {
+				held[i].ApprovalID = hold.Credit.Approval.ID()
+			}
+		}
+	}
+
+	writeSuccessResponse(w, http.StatusOK, dtos.RecurringInvoiceRunResponse{
+		Issued:       len(run.Issued),
+		Invoices:     invoices,
+		Held:         len(run.Held),
+		HeldInvoices: held,
+	})
+}
+
+// toRecurringInvoiceTemplateResponse converts a domain RecurringInvoiceTemplate entity to HTTP response DTO
+func toRecurringInvoiceTemplateResponse(template *entity.RecurringInvoiceTemplate) dtos.RecurringInvoiceTemplateResponse {
+	lines := template.Lines()
+	lineItems := make([]dtos.RecurringInvoiceLineResponse, len(lines))
+	for i, line := range lines {
+		// Amounts were validated on creation, so rating a stored line cannot fail
+		amount, _ := template.LineAmount(line)
+		lineItems[i] = dtos.RecurringInvoiceLineResponse{
+			Description: line.Description,
+			Quantity:    line.Quantity,
+			UnitAmount:  line.UnitAmount,
+			TaxRateBps:  line.TaxRateBps,
+			Amount:      toMoneyResponse(amount),
+		}
+	}
+	total, _ := template.Total()
+
+	response := dtos.RecurringInvoiceTemplateResponse{
+		ID:             template.ID(),
+		TenantID:       template.TenantID(),
+		ClientID:       template.ClientID(),
+		Name:           template.Name(),
+		Currency:       template.Currency(),
+		LineItems:      lineItems,
+		Total:          toMoneyResponse(total),
+		Frequency:      string(template.Frequency()),
+		FirstIssueDate: template.FirstIssueDate(),
+		EndDate:        template.EndDate(),
+		AutoSend:       template.AutoSend(),
+		LegalEntityID:  template.LegalEntityID(),
+		BuyerCountry:   template.BuyerCountry(),
+		BuyerTaxID:     template.BuyerTaxID(),
+		ExternalRef:    template.ExternalReference(),
+		Active:         template.IsActive(),
+		IssuedCount:    template.IssuedCount(),
+		LastIssuedAt:   template.LastIssuedAt(),
+		CreatedAt:      template.CreatedAt(),
+		UpdatedAt:      template.UpdatedAt(),
+	}
+	if next, ok := template.NextIssueDate(); ok {
+		response.NextIssueDate = &next
+	}
+	return response
+}
@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/middleware"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+)
+
+// handleDomainError converts domain errors to appropriate HTTP responses
+func handleDomainError(w http.ResponseWriter, err error) {
+	// Check error type and map to HTTP status code
+	if errors.IsValidationError(err) || errors.IsValidationErrors(err) {
+		code := string(errors.GetErrorCode(err))
+		message := errors.GetUserMessage(err)
+
+		// Try to extract field information from validation error
+		var field string
+		if validationErr, ok := err.(*errors.ValidationError); ok {
+			field = validationErr.Field
+		}
+
+		writeErrorResponse(w, http.StatusBadRequest, code, message, field)
+		return
+	}
+
+	if errors.IsBusinessRuleError(err) {
+		code := errors.GetErrorCode(err)
+		message := errors.GetUserMessage(err)
+
+		// The rule context points to the resources involved, e.g. the existing resource of a duplicate
+		errorDetail := dtos.ErrorDetail{Code: string(code), Message: message}
+		if ruleErr, ok := err.(*errors.BusinessRuleError); ok && len(ruleErr.Context) > 0 {
+			errorDetail.Details = ruleErr.Context
+		}
+
+		// Duplicates conflict with an existing resource; dependents block a change of the resource they depend on
+		if code == errors.BusinessRuleDuplicate || code == errors.BusinessRuleDependent {
+			writeErrorDetail(w, http.StatusConflict, errorDetail)
+			return
+		}
+
+		writeErrorDetail(w, http.StatusUnprocessableEntity, errorDetail)
+		return
+	}
+
+	if errors.IsRepositoryError(err) {
+		code := errors.GetErrorCode(err)
+		message := errors.GetUserMessage(err)
+
+		// Map specific repository error codes to appropriate HTTP status codes
+		var statusCode int
+		switch code {
+		case errors.RepositoryNotFound:
+			statusCode = http.StatusNotFound
+		case errors.RepositoryConstraint:
+			statusCode = http.StatusConflict
+		default:
+			statusCode = http.StatusInternalServerError
+		}
+
+		writeErrorResponse(w, statusCode, string(code), message, "")
+		return
+	}
+
+	if errors.IsAuthorizationError(err) {
+		// Callers without credentials may authenticate; authenticated callers lack the permission
+		statusCode := http.StatusForbidden
+		if errors.GetErrorCode(err) == errors.AuthorizationRequired {
+			statusCode = http.StatusUnauthorized
+		}
+		writeErrorResponse(w, statusCode, string(errors.GetErrorCode(err)), errors.GetUserMessage(err), "")
+		return
+	}
+
+	// Fallback for unknown errors
+	writeErrorResponse(w, http.StatusInternalServerError, "INTERNAL_ERROR", "An internal error occurred", "")
+}
+
+// writeSuccessResponse writes a successful JSON response (timestamps in UTC, see dtos.UTC)
+func writeSuccessResponse(w http.ResponseWriter, statusCode int, data interface{}) {
+	response := dtos.SuccessResponse{
+		Data:     dtos.UTC(data),
+		Success:  true,
+		Warnings: responseWarnings(w, data),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(response)
+}
+
+// writeErrorResponse writes an error JSON response
+func writeErrorResponse(w http.ResponseWriter, statusCode int, code, message, field string) {
+	errorDetail := dtos.ErrorDetail{
+		Code:    code,
+		Message: message,
+	}
+	if field != "" {
+		errorDetail.Field = field
+	}
+	writeErrorDetail(w, statusCode, errorDetail)
+}
+
+// writeErrorDetail writes an error JSON response with the given detail
+func writeErrorDetail(w http.ResponseWriter, statusCode int, errorDetail dtos.ErrorDetail) {
+	response := dtos.ErrorResponse{
+		Error:   errorDetail,
+		Success: false,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(response)
+}
+
+// writePaginatedResponse writes a paginated response with metadata
+func writePaginatedResponse(w http.ResponseWriter, statusCode int, data interface{}, pagination *dtos.PaginationResponse) {
+	response := dtos.PaginatedResponse{
+		Data:       dtos.UTC(data),
+		Pagination: pagination,
+		Success:    true,
+		Warnings:   responseWarnings(w, data),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(response)
+}
+
+// writeCursorPaginatedResponse writes a page listed by cursor with its metadata
+func writeCursorPaginatedResponse(w http.ResponseWriter, statusCode int, data interface{}, pagination *dtos.CursorPaginationResponse) {
+	response := dtos.CursorPaginatedResponse{
+		Data:       dtos.UTC(data),
+		Pagination: pagination,
+		Success:    true,
+		Warnings:   responseWarnings(w, data),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(response)
+}
+
+// responseWarnings runs the warning pipeline of the response writer, if any, for a successful response carrying data
+func responseWarnings(w http.ResponseWriter, data interface{}) []dtos.Warning {
+	source, ok := w.(middleware.WarningSource)
+	if !ok {
+		return nil
+	}
+
+	var warnings []dtos.Warning
+	for _, warning := range source.ResponseWarnings(data) {
+		warnings = append(warnings, dtos.Warning{
+			Code:    warning.Code,
+			Message: warning.Message,
+			Field:   warning.Field,
+		})
+	}
+	return warnings
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/middleware"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+)
+
+// SagaHandler handles admin requests on sagas (multi-step flows such as invoice card payments)
+type SagaHandler struct {
+	orchestrator *application.SagaOrchestrator
+}
+
+// NewSagaHandler creates a new saga handler
+func NewSagaHandler(orchestrator *application.SagaOrchestrator) *SagaHandler {
+	return &SagaHandler{
+		orchestrator: orchestrator,
+	}
+}
+
+// ListSagas handles GET /admin/sagas requests (optional ?status= filter, ?stuck=true for stuck sagas only)
+func (h *SagaHandler) ListSagas(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	stuckOnly := false
+	switch stuck := r.URL.Query().Get("stuck"); stuck {
+	case "", "false":
+	case "true":
+		stuckOnly = true
+	default:
+		handleDomainError(w, errors.NewValidationError("stuck", stuck, errors.ValidationFormat, "stuck must be true or false"))
+		return
+	}
+
+	now := time.Now()
+	sagas, err := h.orchestrator.List(r.URL.Query().Get("status"), stuckOnly, now)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	responses := make([]dtos.SagaResponse, len(sagas))
+	for i, saga := range sagas {
+		responses[i] = toSagaResponse(saga, h.orchestrator.IsStuck(saga, now))
+	}
+	writeSuccessResponse(w, http.StatusOK, responses)
+}
+
+// GetSaga handles GET /admin/sagas/{id} requests
+func (h *SagaHandler) GetSaga(w http.ResponseWriter, r *http.Request, id string) {
+	saga, err := h.orchestrator.Get(id)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toSagaResponse(saga, h.orchestrator.IsStuck(saga, time.Now())))
+}
+
+// Resume handles POST /admin/sagas/{id}/resume requests
+func (h *SagaHandler) Resume(w http.ResponseWriter, r *http.Request, id string) {
+	now := time.Now()
+	saga, err := h.orchestrator.Resume(r.Context(), middleware.AdminActorFromContext(r.Context()), id, now)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toSagaResponse(saga, h.orchestrator.IsStuck(saga, now)))
+}
+
+// ResumeStuck handles POST /admin/sagas/run requests (scheduler trigger)
+func (h *SagaHandler) ResumeStuck(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	now := time.Now()
+	sagas, err := h.orchestrator.ResumeStuck(r.Context(), now)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	response := dtos.SagaRunResponse{
+		Resumed: len(sagas),
+		Sagas:   make([]dtos.SagaResponse, len(sagas)),
+	}
+	for i, saga := range sagas {
+		response.Sagas[i] = toSagaResponse(saga, h.orchestrator.IsStuck(saga, now))
+	}
+	writeSuccessResponse(w, http.StatusOK, response)
+}
+
+// toSagaResponse converts a domain Saga to HTTP response DTO
+func toSagaResponse(saga *entity.Saga, stuck bool) dtos.SagaResponse {
+	steps := saga.Steps()
+	response := dtos.SagaResponse{
+		ID:        saga.ID(),
+		Type:      saga.Type(),
+		Reference: saga.Reference(),
+		Status:    string(saga.Status()),
+		LastError: saga.LastError(),
+		Stuck:     stuck,
+		Steps:     make([]dtos.SagaStepResponse, len(steps)),
+		Data:      saga.Data(),
+		CreatedAt: saga.CreatedAt(),
+		UpdatedAt: saga.UpdatedAt(),
+	}
+	if current := saga.CurrentStep(); current >= 0 {
+		response.CurrentStep = steps[current].Name
+	}
+	for i, step := range steps {
+		response.Steps[i] = dtos.SagaStepResponse{
+			Name:      step.Name,
+			Status:    string(step.Status),
+			Attempts:  step.Attempts,
+			LastError: step.LastError,
+		}
+	}
+	return response
+}
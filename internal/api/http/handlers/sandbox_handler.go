@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/middleware"
+)
+
+// SandboxHandler handles HTTP requests administering the sandbox environment
+type SandboxHandler struct {
+	environment middleware.SandboxEnvironment
+}
+
+// NewSandboxHandler creates a new sandbox handler
+func NewSandboxHandler(environment middleware.SandboxEnvironment) *SandboxHandler {
+	return &SandboxHandler{
+		environment: environment,
+	}
+}
+
+// Wipe handles DELETE /admin/sandbox requests, discarding every record written by sandbox tenants and API keys
+func (h *SandboxHandler) Wipe(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	if err := h.environment.Wipe(middleware.AdminActorFromContext(r.Context())); err != nil {
+		log.Printf("Failed to wipe sandbox data: %v", err)
+		writeErrorResponse(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to wipe sandbox data", "")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
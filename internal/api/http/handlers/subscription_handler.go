@@ -0,0 +1,233 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/middleware"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+)
+
+// SubscriptionHandler handles HTTP requests for subscriptions
+type SubscriptionHandler struct {
+	subscriptionService *application.SubscriptionService
+}
+
+// NewSubscriptionHandler creates a new subscription handler
+func NewSubscriptionHandler(subscriptionService *application.SubscriptionService) *SubscriptionHandler {
+	return &SubscriptionHandler{
+		subscriptionService: subscriptionService,
+	}
+}
+
+// CreateSubscription handles POST /subscriptions requests
+func (h *SubscriptionHandler) CreateSubscription(w http.ResponseWriter, r *http.Request) {
+	var req dtos.CreateSubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format", "")
+		return
+	}
+
+	subscription, err := h.subscriptionService.CreateSubscription(middleware.RequestContextFromRequest(r), req)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusCreated, toSubscriptionResponse(subscription))
+}
+
+// ListSubscriptions handles GET /subscriptions requests (optional ?client_id= filter)
+func (h *SubscriptionHandler) ListSubscriptions(w http.ResponseWriter, r *http.Request) {
+	subscriptions, err := h.subscriptionService.ListSubscriptions(middleware.RequestContextFromRequest(r), r.URL.Query().Get("client_id"))
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	responses := make([]dtos.SubscriptionResponse, len(subscriptions))
+	for i, subscription := range subscriptions {
+		responses[i] = toSubscriptionResponse(subscription)
+	}
+
+	writeSuccessResponse(w, http.StatusOK, responses)
+}
+
+// GetSubscription handles GET /subscriptions/{id} requests
+func (h *SubscriptionHandler) GetSubscription(w http.ResponseWriter, r *http.Request, subscriptionID string) {
+	subscription, err := h.subscriptionService.GetSubscription(middleware.RequestContextFromRequest(r), subscriptionID)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toSubscriptionResponse(subscription))
+}
+
+// UpdateSubscription handles PUT /subscriptions/{id} requests
+func (h *SubscriptionHandler) UpdateSubscription(w http.ResponseWriter, r *http.Request, subscriptionID string) {
+	var req dtos.UpdateSubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format", "")
+		return
+	}
+
+	change, err := h.subscriptionService.UpdateSubscription(middleware.RequestContextFromRequest(r), subscriptionID, req, time.Now())
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	response := toSubscriptionResponse(change.Subscription)
+	if proration := change.Proration; proration != nil {
+		response.Proration = &dtos.SubscriptionProrationResponse{
+			PeriodDays:    proration.PeriodDays,
+			RemainingDays: proration.RemainingDays,
+			Credit:        toMoneyResponse(proration.Credit),
+			Charge:        toMoneyResponse(proration.Charge),
+			Net:           toMoneyResponse(proration.Net),
+		}
+		if change.Invoice != nil {
+			response.Proration.InvoiceID = change.Invoice.ID()
+		}
+	}
+	writeSuccessResponse(w, http.StatusOK, response)
+}
+
+// PauseSubscription handles POST /subscriptions/{id}/pause requests
+func (h *SubscriptionHandler) PauseSubscription(w http.ResponseWriter, r *http.Request, subscriptionID string) {
+	subscription, err := h.subscriptionService.PauseSubscription(middleware.RequestContextFromRequest(r), subscriptionID)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toSubscriptionResponse(subscription))
+}
+
+// ResumeSubscription handles POST /subscriptions/{id}/resume requests
+func (h *SubscriptionHandler) ResumeSubscription(w http.ResponseWriter, r *http.Request, subscriptionID string) {
+	subscription, err := h.subscriptionService.ResumeSubscription(middleware.RequestContextFromRequest(r), subscriptionID, time.Now())
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toSubscriptionResponse(subscription))
+}
+
+// CancelSubscription handles POST /subscriptions/{id}/cancel requests
+func (h *SubscriptionHandler) CancelSubscription(w http.ResponseWriter, r *http.Request, subscriptionID string) {
+	subscription, err := h.subscriptionService.CancelSubscription(middleware.RequestContextFromRequest(r), subscriptionID, time.Now())
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toSubscriptionResponse(subscription))
+}
+
+// BillDueSubscriptions handles POST /admin/subscriptions/run requests (scheduler trigger)
+func (h *SubscriptionHandler) BillDueSubscriptions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	run, err := h.subscriptionService.BillDueSubscriptions(time.Now())
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	billed := make([]dtos.SubscriptionBillResponse, len(run.Billed))
+	for i, bill := range run.Billed {
+		// Issued invoices were validated on creation, so totaling them cannot fail
+		total, _ := bill.Invoice.Total()
+		billed[i] = dtos.SubscriptionBillResponse{
+			SubscriptionID: bill.Subscription.ID(),
+			ClientID:       bill.Subscription.ClientID(),
+			BillingDate:    bill.BillingDate,
+			InvoiceID:      bill.Invoice.ID(),
+			Total:          toMoneyResponse(total),
+		}
+	}
+
+	canceled := make([]string, len(run.Canceled))
+	for i, subscription := range run.Canceled {
+		canceled[i] = subscription.ID()
+	}
+
+	writeSuccessResponse(w, http.StatusOK, dtos.SubscriptionBillingRunResponse{
+		Billed:   billed,
+		Canceled: canceled,
+	})
+}
+
+// toSubscriptionResponse converts a subscription entity to HTTP response DTO
+func toSubscriptionResponse(subscription *entity.Subscription) dtos.SubscriptionResponse {
+	// Amounts were validated on creation, so pricing a stored subscription cannot fail
+	amount, _ := subscription.Amount()
+
+	response := dtos.SubscriptionResponse{
+		ID:            subscription.ID(),
+		TenantID:      subscription.TenantID(),
+		ClientID:      subscription.ClientID(),
+		Plan:          subscription.Plan(),
+		Currency:      subscription.Currency(),
+		UnitAmount:    subscription.UnitAmount(),
+		Quantity:      subscription.Quantity(),
+		TaxRateBps:    subscription.TaxRateBps(),
+		Amount:        toMoneyResponse(amount),
+		Interval:      string(subscription.Interval()),
+		StartDate:     subscription.StartDate(),
+		Status:        string(subscription.Status()),
+		BilledCount:   subscription.BilledCount(),
+		LastInvoiceID: subscription.LastInvoiceID(),
+		LastBilledAt:  subscription.LastBilledAt(),
+		CanceledAt:    subscription.CanceledAt(),
+		CreatedAt:     subscription.CreatedAt(),
+		UpdatedAt:     subscription.UpdatedAt(),
+	}
+	if pricing := subscription.Pricing(); pricing != nil {
+		pricingResponse := toPriceSchemeResponse(*pricing)
+		pricingResponse.UnitAmount = 0 // Per-unit pricing is the unit amount of the subscription
+		response.Pricing = &pricingResponse
+	}
+	if usagePrices := subscription.UsagePrices(); len(usagePrices) > 0 {
+		response.UsagePrices = make(map[string]dtos.PriceSchemeResponse, len(usagePrices))
+		for metric, price := range usagePrices {
+			response.UsagePrices[metric] = toPriceSchemeResponse(price)
+		}
+	}
+	if subscription.ProrationCredit() > 0 {
+		credit, _ := valueobject.NewMoney(subscription.ProrationCredit(), subscription.Currency())
+		creditResponse := toMoneyResponse(credit)
+		response.ProrationCredit = &creditResponse
+	}
+	if subscription.Status() != entity.SubscriptionCanceled {
+		next := subscription.NextBillingDate()
+		response.NextBillingDate = &next
+	}
+	return response
+}
+
+// toPriceSchemeResponse converts a price scheme to HTTP response DTO
+func toPriceSchemeResponse(scheme valueobject.PriceScheme) dtos.PriceSchemeResponse {
+	config := scheme.Config()
+	response := dtos.PriceSchemeResponse{Model: string(config.Model), UnitsPer: config.UnitsPer}
+	if config.Model == valueobject.PricingPerUnit {
+		response.UnitAmount = config.UnitAmount
+	}
+	if len(config.Tiers) > 0 {
+		response.Tiers = make([]dtos.PriceTierResponse, len(config.Tiers))
+		for i, tier := range config.Tiers {
+			response.Tiers[i] = dtos.PriceTierResponse{UpTo: tier.UpTo, UnitAmount: tier.UnitAmount, FlatAmount: tier.FlatAmount}
+		}
+	}
+	return response
+}
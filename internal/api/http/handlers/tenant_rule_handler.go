@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/middleware"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+)
+
+// TenantRuleHandler handles HTTP requests for tenant business rule administration
+type TenantRuleHandler struct {
+	ruleService *application.TenantRuleService
+}
+
+// NewTenantRuleHandler creates a new tenant rule handler
+func NewTenantRuleHandler(ruleService *application.TenantRuleService) *TenantRuleHandler {
+	return &TenantRuleHandler{
+		ruleService: ruleService,
+	}
+}
+
+// ListRules handles GET /admin/tenant-rules requests
+func (h *TenantRuleHandler) ListRules(w http.ResponseWriter, r *http.Request) {
+	sets, err := h.ruleService.ListRules()
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	responses := make([]dtos.TenantRuleSetResponse, len(sets))
+	for i, set := range sets {
+		responses[i] = toTenantRuleSetResponse(set)
+	}
+
+	writeSuccessResponse(w, http.StatusOK, responses)
+}
+
+// GetRules handles GET /admin/tenant-rules/{tenant} requests
+// Tenants without rules get an empty rule list
+func (h *TenantRuleHandler) GetRules(w http.ResponseWriter, r *http.Request, tenantID string) {
+	set, err := h.ruleService.GetRules(tenantID)
+	if err != nil {
+		if errors.GetErrorCode(err) != errors.RepositoryNotFound {
+			handleDomainError(w, err)
+			return
+		}
+
+		writeSuccessResponse(w, http.StatusOK, dtos.TenantRuleSetResponse{
+			TenantID: tenantID,
+			Rules:    []dtos.TenantRuleResponse{},
+		})
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toTenantRuleSetResponse(set))
+}
+
+// SetRules handles PUT /admin/tenant-rules/{tenant} requests
+func (h *TenantRuleHandler) SetRules(w http.ResponseWriter, r *http.Request, tenantID string) {
+	var req dtos.SetTenantRulesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format", "")
+		return
+	}
+
+	actor := middleware.AdminActorFromContext(r.Context())
+	set, err := h.ruleService.SetRules(actor, tenantID, req)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toTenantRuleSetResponse(set))
+}
+
+// DeleteRules handles DELETE /admin/tenant-rules/{tenant} requests
+func (h *TenantRuleHandler) DeleteRules(w http.ResponseWriter, r *http.Request, tenantID string) {
+	actor := middleware.AdminActorFromContext(r.Context())
+	if err := h.ruleService.DeleteRules(actor, tenantID); err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// toTenantRuleSetResponse converts a domain TenantRuleSet entity to HTTP response DTO
+func toTenantRuleSetResponse(set *entity.TenantRuleSet) dtos.TenantRuleSetResponse {
+	rules := make([]dtos.TenantRuleResponse, len(set.Rules()))
+	for i, rule := range set.Rules() {
+		rules[i] = dtos.TenantRuleResponse{
+			Name:       rule.Name(),
+			Operation:  rule.Operation(),
+			Expression: rule.Expression(),
+			Message:    rule.Message(),
+		}
+	}
+
+	updatedAt := set.UpdatedAt()
+	return dtos.TenantRuleSetResponse{
+		TenantID:  set.TenantID(),
+		Rules:     rules,
+		UpdatedAt: &updatedAt,
+	}
+}
@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// maxUploadChunkSize bounds each chunk of a resumable upload
+const maxUploadChunkSize = 8 << 20
+
+// Headers of the resumable upload protocol (tus style)
+const (
+	// UploadOffsetHeader carries the offset a chunk is sent from, and the bytes received in responses
+	UploadOffsetHeader = "Upload-Offset"
+	// UploadLengthHeader carries the declared size of the whole file in responses
+	UploadLengthHeader = "Upload-Length"
+)
+
+// UploadHandler handles HTTP requests for resumable uploads of large files
+type UploadHandler struct {
+	uploadService *application.UploadService
+}
+
+// NewUploadHandler creates a new upload handler
+func NewUploadHandler(uploadService *application.UploadService) *UploadHandler {
+	return &UploadHandler{
+		uploadService: uploadService,
+	}
+}
+
+// CreateUpload handles POST /uploads requests; chunks are then sent to the Location of the upload
+func (h *UploadHandler) CreateUpload(w http.ResponseWriter, r *http.Request) {
+	var req dtos.CreateUploadRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format", "")
+		return
+	}
+
+	upload, err := h.uploadService.CreateUpload(req, time.Now())
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	w.Header().Set("Location", "/api/v1/uploads/"+upload.ID())
+	setUploadHeaders(w, upload)
+	writeSuccessResponse(w, http.StatusCreated, toUploadResponse(upload))
+}
+
+// GetUpload handles GET /uploads/{id} requests
+func (h *UploadHandler) GetUpload(w http.ResponseWriter, r *http.Request, uploadID string) {
+	upload, err := h.uploadService.GetUpload(uploadID)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	setUploadHeaders(w, upload)
+	writeSuccessResponse(w, http.StatusOK, toUploadResponse(upload))
+}
+
+// UploadOffset handles HEAD /uploads/{id} requests: clients resuming an upload send the next chunk from the offset
+func (h *UploadHandler) UploadOffset(w http.ResponseWriter, r *http.Request, uploadID string) {
+	upload, err := h.uploadService.GetUpload(uploadID)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	setUploadHeaders(w, upload)
+	w.WriteHeader(http.StatusOK)
+}
+
+// AppendChunk handles PATCH /uploads/{id} requests carrying the chunk sent from the Upload-Offset header
+func (h *UploadHandler) AppendChunk(w http.ResponseWriter, r *http.Request, uploadID string) {
+	offset, err := strconv.ParseInt(r.Header.Get(UploadOffsetHeader), 10, 64)
+	if err != nil || offset < 0 {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_UPLOAD_OFFSET", "Upload-Offset header must be a non-negative integer", "")
+		return
+	}
+
+	chunk, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxUploadChunkSize))
+	if err != nil {
+		writeErrorResponse(w, http.StatusRequestEntityTooLarge, "CHUNK_TOO_LARGE", "Upload chunks must be at most 8 MB", "")
+		return
+	}
+
+	upload, err := h.uploadService.AppendChunk(uploadID, offset, chunk, time.Now())
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	setUploadHeaders(w, upload)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ProcessPending handles POST /admin/uploads/run requests (scheduler trigger)
+func (h *UploadHandler) ProcessPending(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	uploads, err := h.uploadService.ProcessPending(r.Context(), time.Now())
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	responses := make([]dtos.UploadResponse, len(uploads))
+	for i, upload := range uploads {
+		responses[i] = toUploadResponse(upload)
+	}
+
+	writeSuccessResponse(w, http.StatusOK, dtos.UploadRunResponse{
+		Processed: len(uploads),
+		Uploads:   responses,
+	})
+}
+
+// setUploadHeaders reports the progress of an upload in the protocol headers
+func setUploadHeaders(w http.ResponseWriter, upload *entity.Upload) {
+	w.Header().Set(UploadOffsetHeader, strconv.FormatInt(upload.Offset(), 10))
+	w.Header().Set(UploadLengthHeader, strconv.FormatInt(upload.Size(), 10))
+}
+
+// toUploadResponse converts a domain Upload entity to HTTP response DTO
+func toUploadResponse(upload *entity.Upload) dtos.UploadResponse {
+	return dtos.UploadResponse{
+		ID:          upload.ID(),
+		Kind:        string(upload.Kind()),
+		Format:      upload.Format(),
+		Size:        upload.Size(),
+		Offset:      upload.Offset(),
+		Status:      string(upload.Status()),
+		Result:      upload.Result(),
+		Failure:     upload.Failure(),
+		CreatedAt:   upload.CreatedAt(),
+		ExpiresAt:   upload.ExpiresAt(),
+		CompletedAt: upload.CompletedAt(),
+	}
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/middleware"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+)
+
+// UsageHandler handles HTTP requests for metered usage
+type UsageHandler struct {
+	usageService *application.UsageService
+}
+
+// NewUsageHandler creates a new usage handler
+func NewUsageHandler(usageService *application.UsageService) *UsageHandler {
+	return &UsageHandler{
+		usageService: usageService,
+	}
+}
+
+// IngestUsageRecords handles POST /usage-records requests
+// Replayed events are acknowledged as duplicates, so producers can safely retry a whole batch
+func (h *UsageHandler) IngestUsageRecords(w http.ResponseWriter, r *http.Request) {
+	var req dtos.IngestUsageRecordsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format", "")
+		return
+	}
+
+	result, err := h.usageService.IngestBatch(middleware.RequestContextFromRequest(r), req.Records)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	records := make([]dtos.UsageRecordResult, len(result.Records))
+	for i, record := range result.Records {
+		records[i] = dtos.UsageRecordResult{
+			ID:             record.ID(),
+			SubscriptionID: record.SubscriptionID(),
+			IdempotencyKey: record.IdempotencyKey(),
+			Status:         result.Statuses[i],
+		}
+	}
+
+	writeSuccessResponse(w, http.StatusOK, dtos.IngestUsageRecordsResponse{
+		Accepted:   result.Accepted,
+		Duplicates: result.Duplicates,
+		Records:    records,
+	})
+}
+
+// GetUsageSummary handles GET /usage-records/summary?subscription_id=&period_start=&period_end= requests
+func (h *UsageHandler) GetUsageSummary(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	query := r.URL.Query()
+	periodStart, err := time.Parse(time.RFC3339, query.Get("period_start"))
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETER", "period_start must be an RFC 3339 timestamp", "period_start")
+		return
+	}
+	periodEnd, err := time.Parse(time.RFC3339, query.Get("period_end"))
+	if err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETER", "period_end must be an RFC 3339 timestamp", "period_end")
+		return
+	}
+
+	subscriptionID := query.Get("subscription_id")
+	aggregates, err := h.usageService.Summarize(middleware.RequestContextFromRequest(r), subscriptionID, periodStart, periodEnd)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	metrics := make([]dtos.UsageMetricSummary, len(aggregates))
+	for i, aggregate := range aggregates {
+		metrics[i] = dtos.UsageMetricSummary{
+			Metric:      aggregate.Metric,
+			Quantity:    aggregate.Quantity,
+			RecordCount: aggregate.RecordCount,
+		}
+	}
+
+	writeSuccessResponse(w, http.StatusOK, dtos.UsageSummaryResponse{
+		SubscriptionID: subscriptionID,
+		PeriodStart:    periodStart.UTC(),
+		PeriodEnd:      periodEnd.UTC(),
+		Metrics:        metrics,
+	})
+}
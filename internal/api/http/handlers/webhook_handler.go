@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/middleware"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+)
+
+// maxWebhookSize bounds the size of a webhook delivery
+const maxWebhookSize = 1 << 20
+
+// WebhookHandler handles inbound webhook deliveries and the administration of their dead letter queue
+type WebhookHandler struct {
+	webhookService *application.WebhookService
+}
+
+// NewWebhookHandler creates a new webhook handler
+func NewWebhookHandler(webhookService *application.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+// Receive handles POST /webhooks/{provider} requests
+// Redeliveries are acknowledged with duplicate set, so providers stop retrying them
+func (h *WebhookHandler) Receive(w http.ResponseWriter, r *http.Request, provider string) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookSize))
+	if err != nil {
+		writeErrorResponse(w, http.StatusRequestEntityTooLarge, "WEBHOOK_TOO_LARGE", "Webhook body must be at most 1 MB", "")
+		return
+	}
+
+	receipt, err := h.webhookService.Receive(r.Context(), provider, r.Header.Get, body, time.Now())
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrWebhookSignatureInvalid) {
+			writeErrorResponse(w, http.StatusUnauthorized, "WEBHOOK_SIGNATURE_INVALID", "Webhook signature is invalid", "")
+			return
+		}
+		handleDomainError(w, err)
+		return
+	}
+
+	event := toWebhookEventResponse(receipt.Event)
+	event.Payload = nil
+	writeSuccessResponse(w, http.StatusOK, dtos.WebhookReceiptResponse{
+		Event:     event,
+		Duplicate: receipt.Duplicate,
+	})
+}
+
+// ProcessPending handles POST /admin/webhooks/run requests (scheduler trigger)
+func (h *WebhookHandler) ProcessPending(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	events, err := h.webhookService.ProcessPending(r.Context(), time.Now())
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, dtos.WebhookRunResponse{
+		Processed: len(events),
+		Events:    toWebhookEventResponses(events),
+	})
+}
+
+// ListDeadLetters handles GET /admin/webhooks/dead-letters requests (optional ?provider= filter)
+func (h *WebhookHandler) ListDeadLetters(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorResponse(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed", "")
+		return
+	}
+
+	events, err := h.webhookService.ListDeadLetters(r.URL.Query().Get("provider"))
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toWebhookEventResponses(events))
+}
+
+// GetEvent handles GET /admin/webhooks/events/{id} requests
+func (h *WebhookHandler) GetEvent(w http.ResponseWriter, r *http.Request, id string) {
+	event, err := h.webhookService.GetEvent(id)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toWebhookEventResponse(event))
+}
+
+// Retry handles POST /admin/webhooks/events/{id}/retry requests, requeueing a dead-lettered event
+func (h *WebhookHandler) Retry(w http.ResponseWriter, r *http.Request, id string) {
+	event, err := h.webhookService.Retry(r.Context(), middleware.AdminActorFromContext(r.Context()), id, time.Now())
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toWebhookEventResponse(event))
+}
+
+// toWebhookEventResponses converts domain WebhookEvent entities to HTTP response DTOs
+func toWebhookEventResponses(events []*entity.WebhookEvent) []dtos.WebhookEventResponse {
+	responses := make([]dtos.WebhookEventResponse, len(events))
+	for i, event := range events {
+		responses[i] = toWebhookEventResponse(event)
+	}
+	return responses
+}
+
+// toWebhookEventResponse converts a domain WebhookEvent entity to HTTP response DTO
+func toWebhookEventResponse(event *entity.WebhookEvent) dtos.WebhookEventResponse {
+	return dtos.WebhookEventResponse{
+		ID:          event.ID(),
+		Provider:    event.Provider(),
+		EventID:     event.EventID(),
+		EventType:   event.EventType(),
+		Status:      string(event.Status()),
+		Attempts:    event.Attempts(),
+		LastError:   event.LastError(),
+		ReceivedAt:  event.ReceivedAt(),
+		ProcessedAt: event.ProcessedAt(),
+		Payload:     event.Payload(),
+	}
+}
@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/middleware"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+)
+
+// WebhookSubscriptionHandler handles HTTP requests for the webhook subscriptions of consumers and event replays
+type WebhookSubscriptionHandler struct {
+	subscriptionService *application.WebhookSubscriptionService
+}
+
+// NewWebhookSubscriptionHandler creates a new webhook subscription handler
+func NewWebhookSubscriptionHandler(subscriptionService *application.WebhookSubscriptionService) *WebhookSubscriptionHandler {
+	return &WebhookSubscriptionHandler{
+		subscriptionService: subscriptionService,
+	}
+}
+
+// CreateSubscription handles POST /webhooks requests
+// The response carries the signing secret, which is not returned again
+func (h *WebhookSubscriptionHandler) CreateSubscription(w http.ResponseWriter, r *http.Request) {
+	var req dtos.CreateWebhookSubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format", "")
+		return
+	}
+
+	subscription, err := h.subscriptionService.CreateSubscription(middleware.RequestContextFromRequest(r), req)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	response := toWebhookSubscriptionResponse(subscription)
+	response.Secret = subscription.Secret()
+	writeSuccessResponse(w, http.StatusCreated, response)
+}
+
+// ListSubscriptions handles GET /webhooks requests
+func (h *WebhookSubscriptionHandler) ListSubscriptions(w http.ResponseWriter, r *http.Request) {
+	subscriptions, err := h.subscriptionService.ListSubscriptions(middleware.RequestContextFromRequest(r))
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	responses := make([]dtos.WebhookSubscriptionResponse, len(subscriptions))
+	for i, subscription := range subscriptions {
+		responses[i] = toWebhookSubscriptionResponse(subscription)
+	}
+
+	writeSuccessResponse(w, http.StatusOK, responses)
+}
+
+// GetSubscription handles GET /webhooks/{id} requests
+func (h *WebhookSubscriptionHandler) GetSubscription(w http.ResponseWriter, r *http.Request, subscriptionID string) {
+	subscription, err := h.subscriptionService.GetSubscription(middleware.RequestContextFromRequest(r), subscriptionID)
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, toWebhookSubscriptionResponse(subscription))
+}
+
+// DeleteSubscription handles DELETE /webhooks/{id} requests
+func (h *WebhookSubscriptionHandler) DeleteSubscription(w http.ResponseWriter, r *http.Request, subscriptionID string) {
+	if err := h.subscriptionService.DeleteSubscription(middleware.RequestContextFromRequest(r), subscriptionID); err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ReplayEvents handles POST /webhooks/{id}/replay?from= requests
+// Replays within the cooldown of the subscription are rejected with 429 Too Many Requests and a Retry-After header
+func (h *WebhookSubscriptionHandler) ReplayEvents(w http.ResponseWriter, r *http.Request, subscriptionID string) {
+	replay, err := h.subscriptionService.ReplayEvents(r.Context(), middleware.RequestContextFromRequest(r), subscriptionID, r.URL.Query().Get("from"))
+	if err != nil {
+		var ruleErr *domainErrors.BusinessRuleError
+		if errors.As(err, &ruleErr) && ruleErr.Rule == application.WebhookReplayRateRule {
+			writeReplayRateLimited(w, ruleErr)
+			return
+		}
+		handleDomainError(w, err)
+		return
+	}
+
+	writeSuccessResponse(w, http.StatusOK, dtos.WebhookReplayResponse{
+		SubscriptionID: replay.Subscription.ID(),
+		From:           replay.From,
+		Delivered:      replay.Delivered,
+		LastEventID:    replay.LastEventID,
+		FailedEventID:  replay.FailedEventID,
+		Error:          replay.Error,
+		HasMore:        replay.HasMore,
+		NextFrom:       replay.NextFrom,
+	})
+}
+
+// writeReplayRateLimited writes the rejection of a replay within the cooldown, telling when to retry
+func writeReplayRateLimited(w http.ResponseWriter, ruleErr *domainErrors.BusinessRuleError) {
+	if retryAt, ok := ruleErr.Context["retry_at"].(string); ok {
+		if at, err := time.Parse(time.RFC3339, retryAt); err == nil {
+			seconds := math.Ceil(time.Until(at).Seconds())
+			w.Header().Set("Retry-After", strconv.Itoa(max(int(seconds), 1)))
+		}
+	}
+	writeErrorDetail(w, http.StatusTooManyRequests, dtos.ErrorDetail{
+		Code:    string(ruleErr.Code),
+		Message: ruleErr.Message,
+		Details: ruleErr.Context,
+	})
+}
+
+// toWebhookSubscriptionResponse converts a webhook subscription entity to its response DTO, without its secret
+func toWebhookSubscriptionResponse(subscription *entity.WebhookSubscription) dtos.WebhookSubscriptionResponse {
+	return dtos.WebhookSubscriptionResponse{
+		ID:           subscription.ID(),
+		URL:          subscription.URL(),
+		EventTypes:   subscription.EventTypes(),
+		CreatedAt:    subscription.CreatedAt(),
+		LastReplayAt: subscription.LastReplayAt(),
+	}
+}
@@ -0,0 +1,164 @@
+package middleware
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// AdminRoutePrefix is the path prefix of administrative endpoints
+const AdminRoutePrefix = "/api/v1/admin"
+
+// adminActorContextKey is the context key for the authenticated admin actor
+type adminActorContextKey struct{}
+
+// adminScopesContextKey is the context key for the scopes the authenticated admin actor is restricted to
+type adminScopesContextKey struct{}
+
+// AdminGuard authenticates administrators with static bearer tokens
+type AdminGuard struct {
+	// tokens maps actor names to their bearer token
+	tokens map[string]string
+	// scopes restricts actors to admin scopes; actors without an entry hold every scope
+	scopes map[string][]string
+	// tenants restricts actors to the tenants they own; actors without an entry reach every tenant
+	tenants map[string][]string
+}
+
+// NewAdminGuard creates an admin guard from actor name to token pairs
+// With no tokens configured every admin request is rejected
+func NewAdminGuard(tokens map[string]string) *AdminGuard {
+	return &AdminGuard{
+		tokens: tokens,
+	}
+}
+
+// WithScopes restricts actors to the admin scopes listed for them (e.g. "ops": ["operations"])
+func (g *AdminGuard) WithScopes(scopes map[string][]string) *AdminGuard {
+	g.scopes = scopes
+	return g
+}
+
+// WithTenants restricts actors to the tenants listed for them; restricted actors can only reach admin routes
+// acting on a tenant they own
+func (g *AdminGuard) WithTenants(tenants map[string][]string) *AdminGuard {
+	g.tenants = tenants
+	return g
+}
+
+// Require wraps a handler with admin authentication and stores the actor in the context
+func (g *AdminGuard) Require(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Admin credentials are required")
+			return
+		}
+
+		actor, ok := g.authenticate(token)
+		if !ok {
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Admin credentials are invalid")
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(g.withAdmin(r, actor)))
+	})
+}
+
+// Identify stores the admin actor in the context when the request carries a valid admin token
+// Requests without one are served anonymously, so open routes can reveal privileged fields to admins only
+func (g *AdminGuard) Identify(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if ok && token != "" {
+			if actor, ok := g.authenticate(token); ok {
+				r = r.WithContext(g.withAdmin(r, actor))
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// grantsScope reports whether actor holds the admin scope (an empty scope is held by every actor)
+func (g *AdminGuard) grantsScope(actor, scope string) bool {
+	granted, restricted := g.scopes[actor]
+	if scope == "" || !restricted {
+		return true
+	}
+	return slices.Contains(granted, scope)
+}
+
+// grantsTenant reports whether actor may act on the tenant; an empty tenant (a route spanning every tenant)
+// is only granted to actors not restricted to tenants
+func (g *AdminGuard) grantsTenant(actor, tenantID string) bool {
+	owned, restricted := g.tenants[actor]
+	if !restricted {
+		return true
+	}
+	return tenantID != "" && slices.Contains(owned, tenantID)
+}
+
+// tenantOf returns the tenant an actor acts in for a request asking for the requested tenant (X-Tenant-ID)
+// Actors restricted to tenants act in the requested tenant when they own it, else in their only tenant; other
+// actors may act in any tenant and get the one requested. Empty when no tenant resolves
+func (g *AdminGuard) tenantOf(actor, requested string) string {
+	owned, restricted := g.tenants[actor]
+	switch {
+	case !restricted:
+		return requested
+	case requested == "" && len(owned) == 1:
+		return owned[0]
+	case requested != "" && slices.Contains(owned, requested):
+		return requested
+	}
+	return ""
+}
+
+// withAdmin returns a copy of the request context carrying the authenticated admin actor, the scopes it is
+// restricted to and the tenant it acts in
+// Actors restricted to tenants always act within a tenant, so their requests are tenant scoped
+func (g *AdminGuard) withAdmin(r *http.Request, actor string) context.Context {
+	ctx := r.Context()
+	if scopes, restricted := g.scopes[actor]; restricted {
+		ctx = context.WithValue(ctx, adminScopesContextKey{}, append([]string{}, scopes...))
+	}
+	if _, restricted := g.tenants[actor]; restricted {
+		ctx = WithTenantScope(ctx)
+	}
+	if tenantID := g.tenantOf(actor, TenantIDFromRequest(r)); tenantID != "" {
+		ctx = WithTenantID(ctx, tenantID)
+	}
+	return WithAdminActor(ctx, actor)
+}
+
+// authenticate returns the actor name owning the token
+func (g *AdminGuard) authenticate(token string) (string, bool) {
+	for actor, expected := range g.tokens {
+		if expected != "" && subtle.ConstantTimeCompare([]byte(expected), []byte(token)) == 1 {
+			return actor, true
+		}
+	}
+	return "", false
+}
+
+// WithAdminActor returns a copy of ctx carrying the authenticated admin actor
+func WithAdminActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, adminActorContextKey{}, actor)
+}
+
+// adminScopesFromContext returns the scopes the authenticated admin actor is restricted to (nil: every scope)
+func adminScopesFromContext(ctx context.Context) []string {
+	scopes, _ := ctx.Value(adminScopesContextKey{}).([]string)
+	return scopes
+}
+
+// AdminActorFromContext returns the authenticated admin actor (empty when not authenticated)
+func AdminActorFromContext(ctx context.Context) string {
+	if actor, ok := ctx.Value(adminActorContextKey{}).(string); ok {
+		return actor
+	}
+	return ""
+}
@@ -0,0 +1,257 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Role is the kind of caller a route is open to
+type Role string
+
+const (
+	// RolePublic routes are open to anonymous callers; admins presenting their token are still identified
+	RolePublic Role = "public"
+	// RoleAdmin routes require an admin bearer token
+	RoleAdmin Role = "admin"
+	// RolePortal routes require a portal bearer token scoped to one client
+	RolePortal Role = "portal"
+)
+
+// RoutePolicy is the authorization policy of a route
+type RoutePolicy struct {
+	Role Role
+	// Scope is the admin scope required on top of the admin role (empty: any admin)
+	Scope string
+	// Tenant names the path wildcard holding the tenant the route acts on; admins restricted to tenants must own it
+	Tenant string
+	// CallerTenant routes act in the tenant the admin acts in (X-Tenant-ID, or the only tenant of an admin restricted
+	// to one) rather than spanning every tenant, so admins restricted to tenants may call them
+	CallerTenant bool
+	// Cache is the caching policy of the responses of the route (zero: no-store), see Authorizer.CacheControl
+	Cache CachePolicy
+}
+
+// PublicRoute is the policy of routes open to anonymous callers
+func PublicRoute() RoutePolicy {
+	return RoutePolicy{Role: RolePublic}
+}
+
+// AdminRoute is the policy of routes requiring an admin holding scope
+func AdminRoute(scope string) RoutePolicy {
+	return RoutePolicy{Role: RoleAdmin, Scope: scope}
+}
+
+// TenantAdminRoute is the policy of admin routes acting on the tenant held by the tenant path wildcard
+func TenantAdminRoute(scope, tenant string) RoutePolicy {
+	return RoutePolicy{Role: RoleAdmin, Scope: scope, Tenant: tenant}
+}
+
+// CallerTenantAdminRoute is the policy of admin routes acting in the tenant of the calling admin, whose services
+// only reach the resources of that tenant
+func CallerTenantAdminRoute(scope string) RoutePolicy {
+	return RoutePolicy{Role: RoleAdmin, Scope: scope, CallerTenant: true}
+}
+
+// PortalRoute is the policy of routes requiring a portal client
+func PortalRoute() RoutePolicy {
+	return RoutePolicy{Role: RolePortal}
+}
+
+// RoutePolicies maps route patterns to their policy
+// Patterns are "[METHOD ]/path/{wildcard}" like http.ServeMux patterns: a pattern without a method covers every
+// method, GET also covers HEAD and a trailing slash in the request path is ignored. When several patterns match,
+// literal segments win over wildcards from left to right, then a pattern with a method wins over one without
+type RoutePolicies map[string]RoutePolicy
+
+// Authorizer enforces the route policies declared alongside the route table
+// Requests matching no pattern fall back to the policy of their route group: admin routes require an admin,
+// portal routes a portal client and every other route is public
+type Authorizer struct {
+	admin  *AdminGuard
+	portal *PortalGuard
+	rules  []routeRule
+}
+
+// routeRule is a parsed route pattern and its policy
+type routeRule struct {
+	method   string
+	segments []string
+	policy   RoutePolicy
+}
+
+// NewAuthorizer creates an authorizer enforcing policies with the admin and portal guards
+// It panics on a malformed pattern, like http.ServeMux, since policies are declared in code
+func NewAuthorizer(admin *AdminGuard, portal *PortalGuard, policies RoutePolicies) *Authorizer {
+	authorizer := &Authorizer{admin: admin, portal: portal}
+	for pattern, policy := range policies {
+		rule, err := parseRouteRule(pattern, policy)
+		if err != nil {
+			panic(err)
+		}
+		authorizer.rules = append(authorizer.rules, rule)
+	}
+	return authorizer
+}
+
+// Policy returns the policy of a request and whether a pattern declares it explicitly
+func (a *Authorizer) Policy(method, path string) (RoutePolicy, bool) {
+	rule, _ := a.match(method, path)
+	if rule == nil {
+		return fallbackPolicy(path), false
+	}
+	return rule.policy, true
+}
+
+// Middleware authenticates each request as required by its route policy and checks the scope and tenant of admins
+func (a *Authorizer) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		policy := fallbackPolicy(r.URL.Path)
+		var tenantID string
+		if rule, wildcards := a.match(r.Method, r.URL.Path); rule != nil {
+			policy = rule.policy
+			tenantID = wildcards[policy.Tenant]
+		}
+
+		switch policy.Role {
+		case RoleAdmin:
+			handler := next
+			if policy.Tenant != "" {
+				handler = withRouteTenant(tenantID, next)
+			}
+			a.admin.Require(a.authorizeAdmin(policy, tenantID, handler)).ServeHTTP(w, r)
+		case RolePortal:
+			a.portal.Require(next).ServeHTTP(w, r)
+		default:
+			a.admin.Identify(next).ServeHTTP(w, r)
+		}
+	})
+}
+
+// authorizeAdmin checks that the authenticated admin holds the scope and owns the tenant of the route
+func (a *Authorizer) authorizeAdmin(policy RoutePolicy, tenantID string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actor := AdminActorFromContext(r.Context())
+		if !a.admin.grantsScope(actor, policy.Scope) {
+			writeError(w, http.StatusForbidden, "SCOPE_NOT_GRANTED", "Admin credentials do not grant the "+policy.Scope+" scope")
+			return
+		}
+		if policy.CallerTenant {
+			tenantID = TenantIDFromContext(r.Context())
+		}
+		if !a.admin.grantsTenant(actor, tenantID) {
+			writeError(w, http.StatusForbidden, "TENANT_NOT_ALLOWED", "Admin credentials do not grant access to this tenant")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// withRouteTenant scopes requests to the tenant of the route they act on, which the admin was checked to own
+func withRouteTenant(tenantID string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(WithTenantScope(WithTenantID(r.Context(), tenantID))))
+	})
+}
+
+// match returns the most specific rule matching the request and the values of its wildcards
+func (a *Authorizer) match(method, path string) (*routeRule, map[string]string) {
+	segments := pathSegments(path)
+	var best *routeRule
+	var bestWildcards map[string]string
+	for i := range a.rules {
+		rule := &a.rules[i]
+		wildcards, ok := rule.match(method, segments)
+		if ok && (best == nil || rule.moreSpecific(best)) {
+			best, bestWildcards = rule, wildcards
+		}
+	}
+	return best, bestWildcards
+}
+
+// fallbackPolicy is the policy of a request matching no pattern
+func fallbackPolicy(path string) RoutePolicy {
+	switch {
+	case strings.HasPrefix(path, AdminRoutePrefix):
+		return AdminRoute("")
+	case strings.HasPrefix(path, PortalRoutePrefix) && path != PortalSessionPath:
+		return PortalRoute()
+	default:
+		return PublicRoute()
+	}
+}
+
+// parseRouteRule parses a "[METHOD ]/path/{wildcard}" pattern
+func parseRouteRule(pattern string, policy RoutePolicy) (routeRule, error) {
+	rule := routeRule{policy: policy}
+	path := pattern
+	if method, rest, ok := strings.Cut(pattern, " "); ok {
+		rule.method, path = method, strings.TrimSpace(rest)
+	}
+	if !strings.HasPrefix(path, "/") {
+		return rule, fmt.Errorf("route policy %q: path must start with /", pattern)
+	}
+
+	rule.segments = pathSegments(path)
+	hasTenant := policy.Tenant == ""
+	for _, segment := range rule.segments {
+		if name, ok := wildcardName(segment); ok {
+			if name == "" || strings.ContainsAny(name, "{}.") {
+				return rule, fmt.Errorf("route policy %q: invalid wildcard %s", pattern, segment)
+			}
+			hasTenant = hasTenant || name == policy.Tenant
+		}
+	}
+	if !hasTenant {
+		return rule, fmt.Errorf("route policy %q: no {%s} wildcard holds the tenant", pattern, policy.Tenant)
+	}
+	return rule, nil
+}
+
+// match reports whether the rule covers the method and path segments and returns the values of its wildcards
+func (r *routeRule) match(method string, segments []string) (map[string]string, bool) {
+	if r.method != "" && r.method != method && !(r.method == http.MethodGet && method == http.MethodHead) {
+		return nil, false
+	}
+	if len(segments) != len(r.segments) {
+		return nil, false
+	}
+
+	wildcards := make(map[string]string)
+	for i, segment := range r.segments {
+		if name, ok := wildcardName(segment); ok {
+			wildcards[name] = segments[i]
+			continue
+		}
+		if segment != segments[i] {
+			return nil, false
+		}
+	}
+	return wildcards, true
+}
+
+// moreSpecific reports whether the rule takes precedence over other when both match a request
+func (r *routeRule) moreSpecific(other *routeRule) bool {
+	for i, segment := range r.segments {
+		_, wildcard := wildcardName(segment)
+		_, otherWildcard := wildcardName(other.segments[i])
+		if wildcard != otherWildcard {
+			return otherWildcard
+		}
+	}
+	return r.method != "" && other.method == ""
+}
+
+// pathSegments splits a path into its segments, ignoring a trailing slash
+func pathSegments(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+// wildcardName returns the name of a {wildcard} path segment
+func wildcardName(segment string) (string, bool) {
+	if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+		return segment[1 : len(segment)-1], true
+	}
+	return "", false
+}
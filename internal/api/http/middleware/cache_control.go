@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// CachePolicy is the caching policy of the responses of a route
+// The zero value forbids caching: most routes return client data (PII) that must not linger in caches
+type CachePolicy struct {
+	// MaxAge lets callers reuse successful GET responses for that long (zero: no-store)
+	MaxAge time.Duration
+}
+
+// Cached returns the policy with successful GET responses cacheable for maxAge
+// Responses of public routes may be kept by shared caches, those of admin and portal routes by the caller only
+func (p RoutePolicy) Cached(maxAge time.Duration) RoutePolicy {
+	p.Cache = CachePolicy{MaxAge: maxAge}
+	return p
+}
+
+// cacheControl returns the Cache-Control header of a response to method under the policy
+func (p RoutePolicy) cacheControl(method string) string {
+	if p.Cache.MaxAge <= 0 || (method != http.MethodGet && method != http.MethodHead) {
+		return "no-store"
+	}
+
+	visibility := "private"
+	if p.Role == RolePublic {
+		visibility = "public"
+	}
+	return visibility + ", max-age=" + strconv.Itoa(int(p.Cache.MaxAge.Seconds()))
+}
+
+// CacheControl sets the Cache-Control header declared by the route policies on every response
+// Routes without a cache policy are not stored; handlers may still set a stricter header themselves. Errors of
+// cacheable routes are not stored either, so a transient failure is not served from caches
+func (a *Authorizer) CacheControl(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		policy, _ := a.Policy(r.Method, r.URL.Path)
+		header := policy.cacheControl(r.Method)
+		w.Header().Set("Cache-Control", header)
+		if header == "no-store" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		next.ServeHTTP(&cacheWriter{ResponseWriter: w}, r)
+	})
+}
+
+// cacheWriter withdraws the cache header of a cacheable route when the response is not a success
+type cacheWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+// WriteHeader replaces the cache header of unsuccessful responses
+func (w *cacheWriter) WriteHeader(statusCode int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		if statusCode >= http.StatusMultipleChoices && statusCode != http.StatusNotModified {
+			w.Header().Set("Cache-Control", "no-store")
+		}
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Write sends the response body (200 unless a status was written)
+func (w *cacheWriter) Write(data []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(data)
+}
+
+// Flush lets streaming handlers flush through the writer
+func (w *cacheWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
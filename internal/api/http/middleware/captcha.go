@@ -0,0 +1,119 @@
+package middleware
+
+import (
+	"context"
+	"crypto/subtle"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// CaptchaTokenHeader carries the challenge token solved by the browser
+const CaptchaTokenHeader = "X-Captcha-Token"
+
+// ChallengeVerifier verifies anti-automation challenge tokens (hCaptcha, Turnstile, ...)
+type ChallengeVerifier interface {
+	Verify(ctx context.Context, token, remoteIP string) (bool, error)
+}
+
+// CaptchaConfig configures challenge verification for public endpoints
+type CaptchaConfig struct {
+	// Enabled turns verification on for the configured routes
+	Enabled bool
+
+	// Routes lists path prefixes requiring a solved challenge on state-changing requests
+	Routes []string
+
+	// TrustedAPIKeys maps integration names to API keys that bypass the challenge
+	TrustedAPIKeys map[string]string
+
+	// Verifier checks tokens against the challenge provider
+	Verifier ChallengeVerifier
+}
+
+// CaptchaGuard requires a solved challenge on configured public routes
+type CaptchaGuard struct {
+	config CaptchaConfig
+}
+
+// NewCaptchaGuard creates a challenge guard
+func NewCaptchaGuard(config CaptchaConfig) *CaptchaGuard {
+	return &CaptchaGuard{
+		config: config,
+	}
+}
+
+// Middleware rejects unverified POST/PUT/PATCH/DELETE requests to configured routes
+// Safe methods are never challenged; trusted API keys skip the challenge entirely
+func (g *CaptchaGuard) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !g.config.Enabled || g.config.Verifier == nil || !isStateChanging(r.Method) || !g.requiresChallenge(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if g.isTrusted(r.Header.Get(APIKeyHeader)) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		token := strings.TrimSpace(r.Header.Get(CaptchaTokenHeader))
+		if token == "" {
+			writeError(w, http.StatusForbidden, "CAPTCHA_REQUIRED", "A challenge token is required")
+			return
+		}
+
+		remoteIP := ""
+		if addr, ok := ClientAddr(r); ok {
+			remoteIP = addr.String()
+		}
+
+		valid, err := g.config.Verifier.Verify(r.Context(), token, remoteIP)
+		if err != nil {
+			// Fail closed: an unreachable provider must not open the endpoint to automation
+			log.Printf("Captcha verification failed: %v", err)
+			writeError(w, http.StatusServiceUnavailable, "CAPTCHA_UNAVAILABLE", "Challenge verification is temporarily unavailable")
+			return
+		}
+
+		if !valid {
+			writeError(w, http.StatusForbidden, "CAPTCHA_INVALID", "Challenge token is invalid or expired")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// requiresChallenge checks if a path belongs to a challenged route
+func (g *CaptchaGuard) requiresChallenge(path string) bool {
+	for _, prefix := range g.config.Routes {
+		if prefix != "" && strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isTrusted checks if the API key belongs to a trusted integration
+func (g *CaptchaGuard) isTrusted(apiKey string) bool {
+	if apiKey == "" {
+		return false
+	}
+	for _, trusted := range g.config.TrustedAPIKeys {
+		if trusted != "" && subtle.ConstantTimeCompare([]byte(trusted), []byte(apiKey)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// isStateChanging reports whether the HTTP method modifies resources
+func isStateChanging(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,150 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// Forwarding headers set by proxies and load balancers
+const (
+	ForwardedForHeader = "X-Forwarded-For"
+	RealIPHeader       = "X-Real-IP"
+)
+
+// clientAddrContextKey is the context key for the resolved client address
+type clientAddrContextKey struct{}
+
+// ClientIPResolver resolves the address of the client behind trusted proxies and load balancers
+// Forwarding headers are only honored when the connection comes from a trusted proxy, since any caller can set them.
+// Peers of a Unix socket the API is served on are local proxies, trusted through the socket permissions
+type ClientIPResolver struct {
+	trusted []netip.Prefix
+}
+
+// NewClientIPResolver creates a client IP resolver trusting the given proxy addresses and CIDR ranges
+// Invalid entries are logged and ignored so a bad configuration entry cannot break requests
+func NewClientIPResolver(trustedProxies []string) *ClientIPResolver {
+	resolver := &ClientIPResolver{}
+	for _, entry := range trustedProxies {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(entry)
+		if err != nil {
+			addr, addrErr := netip.ParseAddr(entry)
+			if addrErr != nil {
+				log.Printf("Ignoring invalid trusted proxy %q: %v", entry, err)
+				continue
+			}
+			prefix = netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen())
+		}
+		resolver.trusted = append(resolver.trusted, prefix.Masked())
+	}
+	return resolver
+}
+
+// Middleware stores the resolved client address in the request context (see ClientAddr)
+func (c *ClientIPResolver) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		addr, ok := c.Resolve(r)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), clientAddrContextKey{}, addr)))
+	})
+}
+
+// Resolve returns the client address of a request
+// X-Forwarded-For is read right to left, skipping trusted proxies: the first untrusted hop is the client.
+// X-Real-IP is used when a trusted proxy sent no X-Forwarded-For. Requests on a Unix socket have no source address:
+// their client is unknown unless the proxy forwarded it
+func (c *ClientIPResolver) Resolve(r *http.Request) (netip.Addr, bool) {
+	remote, ok := remoteAddr(r)
+	switch {
+	case ok && !c.isTrusted(remote):
+		return remote, true
+	case !ok && !isUnixSocket(r):
+		return remote, false
+	}
+
+	if forwarded := r.Header.Values(ForwardedForHeader); len(forwarded) > 0 {
+		hops := strings.Split(strings.Join(forwarded, ","), ",")
+		client := remote
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop, ok := parseForwardedAddr(hops[i])
+			if !ok {
+				// Anything left of a malformed hop cannot be trusted
+				break
+			}
+			client = hop
+			if !c.isTrusted(hop) {
+				break
+			}
+		}
+		return client, client.IsValid()
+	}
+
+	if realIP, ok := parseForwardedAddr(r.Header.Get(RealIPHeader)); ok {
+		return realIP, true
+	}
+	return remote, ok
+}
+
+// isUnixSocket reports whether the request came in on a Unix socket
+func isUnixSocket(r *http.Request) bool {
+	local, ok := r.Context().Value(http.LocalAddrContextKey).(net.Addr)
+	return ok && (local.Network() == "unix" || local.Network() == "unixpacket")
+}
+
+// isTrusted reports whether addr belongs to a trusted proxy
+func (c *ClientIPResolver) isTrusted(addr netip.Addr) bool {
+	for _, prefix := range c.trusted {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientAddr returns the client address of the request: the address resolved by ClientIPResolver,
+// or the source address of the request connection when the resolver did not run
+func ClientAddr(r *http.Request) (netip.Addr, bool) {
+	if addr, ok := r.Context().Value(clientAddrContextKey{}).(netip.Addr); ok {
+		return addr, true
+	}
+	return remoteAddr(r)
+}
+
+// remoteAddr returns the source address of the request connection
+func remoteAddr(r *http.Request) (netip.Addr, bool) {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return addr.Unmap(), true
+}
+
+// parseForwardedAddr parses an address from a forwarding header, with or without port ("203.0.113.7:4711", "[2001:db8::1]:80")
+func parseForwardedAddr(value string) (netip.Addr, bool) {
+	value = strings.TrimSpace(value)
+	if addr, err := netip.ParseAddr(value); err == nil {
+		return addr.Unmap(), true
+	}
+	if addrPort, err := netip.ParseAddrPort(value); err == nil {
+		return addrPort.Addr().Unmap(), true
+	}
+	return netip.Addr{}, false
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/tracing"
 )
 
 // ErrorHandler provides middleware for handling panics and errors
@@ -32,10 +33,20 @@ func (e *ErrorHandler) RecoverMiddleware(next http.Handler) http.Handler {
 	})
 }
 
-// LoggingMiddleware logs HTTP requests
+// LoggingMiddleware logs HTTP requests with their client address (see ClientAddr),
+// and their request ID when the request tracer runs first
 func (e *ErrorHandler) LoggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		log.Printf("%s %s - %s", r.Method, r.URL.Path, r.RemoteAddr)
+		client := r.RemoteAddr
+		if addr, ok := ClientAddr(r); ok {
+			client = addr.String()
+		}
+
+		if requestID := tracing.RequestIDFromContext(r.Context()); requestID != "" {
+			log.Printf("%s %s - %s request_id=%s", r.Method, r.URL.Path, client, requestID)
+		} else {
+			log.Printf("%s %s - %s", r.Method, r.URL.Path, client)
+		}
 		next.ServeHTTP(w, r)
 	})
 }
@@ -44,8 +55,9 @@ func (e *ErrorHandler) LoggingMiddleware(next http.Handler) http.Handler {
 func (e *ErrorHandler) CORSMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, HEAD, POST, PUT, PATCH, DELETE, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Form-Token, X-Captcha-Token, Upload-Offset")
+		w.Header().Set("Access-Control-Expose-Headers", "Location, Upload-Offset, Upload-Length")
 
 		// Handle preflight requests
 		if r.Method == http.MethodOptions {
@@ -59,6 +71,11 @@ func (e *ErrorHandler) CORSMiddleware(next http.Handler) http.Handler {
 
 // writeErrorResponse writes a structured error response
 func (e *ErrorHandler) writeErrorResponse(w http.ResponseWriter, statusCode int, code, message string) {
+	writeError(w, statusCode, code, message)
+}
+
+// writeError writes a structured error response (shared by all middleware)
+func writeError(w http.ResponseWriter, statusCode int, code, message string) {
 	errorDetail := dtos.ErrorDetail{
 		Code:    code,
 		Message: message,
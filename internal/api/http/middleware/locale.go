@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+)
+
+// localeContextKey is the context key for the resolved request locale
+type localeContextKey struct{}
+
+// LocaleResolver resolves the caller's locale from Accept-Language with per-tenant defaults
+type LocaleResolver struct {
+	defaultLocale valueobject.Locale
+	tenantLocales map[string]valueobject.Locale
+}
+
+// NewLocaleResolver creates a locale resolver
+// Invalid locale tags are logged and ignored so a bad configuration entry cannot break requests
+func NewLocaleResolver(defaultLocale string, tenantLocales map[string]string) *LocaleResolver {
+	resolver := &LocaleResolver{
+		defaultLocale: valueobject.DefaultLocale(),
+		tenantLocales: make(map[string]valueobject.Locale),
+	}
+
+	if defaultLocale != "" {
+		if locale, err := valueobject.NewLocale(defaultLocale); err == nil {
+			resolver.defaultLocale = locale
+		} else {
+			log.Printf("Ignoring invalid default locale %q: %v", defaultLocale, err)
+		}
+	}
+
+	for tenantID, tag := range tenantLocales {
+		locale, err := valueobject.NewLocale(tag)
+		if err != nil {
+			log.Printf("Ignoring invalid locale %q for tenant %s: %v", tag, tenantID, err)
+			continue
+		}
+		resolver.tenantLocales[tenantID] = locale
+	}
+
+	return resolver
+}
+
+// Resolve determines the locale for a request
+// Priority: Accept-Language (region filled from tenant default when missing) > tenant default > global default
+func (l *LocaleResolver) Resolve(r *http.Request) valueobject.Locale {
+	fallback := l.defaultLocale
+	if tenantLocale, ok := l.tenantLocales[TenantIDFromRequest(r)]; ok {
+		fallback = tenantLocale
+	}
+
+	preferred := valueobject.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+	if len(preferred) == 0 {
+		return fallback
+	}
+
+	locale := preferred[0]
+	if !locale.HasRegion() && fallback.HasRegion() {
+		locale = locale.WithRegion(fallback.Region())
+	}
+	return locale
+}
+
+// Middleware stores the resolved locale in the request context and advertises it in Content-Language
+func (l *LocaleResolver) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		locale := l.Resolve(r)
+		w.Header().Set("Content-Language", locale.String())
+		next.ServeHTTP(w, r.WithContext(WithLocale(r.Context(), locale)))
+	})
+}
+
+// WithLocale returns a copy of ctx carrying the locale
+func WithLocale(ctx context.Context, locale valueobject.Locale) context.Context {
+	return context.WithValue(ctx, localeContextKey{}, locale)
+}
+
+// LocaleFromContext returns the request locale, or the default locale when none was resolved
+func LocaleFromContext(ctx context.Context) valueobject.Locale {
+	if locale, ok := ctx.Value(localeContextKey{}).(valueobject.Locale); ok {
+		return locale
+	}
+	return valueobject.DefaultLocale()
+}
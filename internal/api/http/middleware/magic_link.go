@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+)
+
+// MagicLinkHeader carries a magic link token when it is not passed as the "token" query parameter
+const MagicLinkHeader = "X-Magic-Link-Token"
+
+// magicLinkContextKey is the context key for the verified magic link
+type magicLinkContextKey struct{}
+
+// MagicLinkVerifier verifies and consumes a followed magic link
+type MagicLinkVerifier interface {
+	Verify(token, purpose string) (*entity.MagicLink, error)
+}
+
+// RequireMagicLink returns middleware that consumes a magic link issued for purpose before calling the handler
+// The verified link is available to the handler through MagicLinkFromContext
+func RequireMagicLink(verifier MagicLinkVerifier, purpose string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := r.Header.Get(MagicLinkHeader)
+			if token == "" {
+				token = r.URL.Query().Get("token")
+			}
+			if token == "" {
+				writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "A sign-in link is required")
+				return
+			}
+
+			link, err := verifier.Verify(token, purpose)
+			switch {
+			case err == nil:
+				next.ServeHTTP(w, r.WithContext(WithMagicLink(r.Context(), link)))
+			case errors.Is(err, domainErrors.ErrMagicLinkUsed):
+				writeError(w, http.StatusUnauthorized, "LINK_ALREADY_USED", "This link has already been used")
+			case errors.Is(err, domainErrors.ErrMagicLinkExpired):
+				writeError(w, http.StatusUnauthorized, "LINK_EXPIRED", "This link has expired")
+			case errors.Is(err, domainErrors.ErrMagicLinkInvalid):
+				writeError(w, http.StatusUnauthorized, "LINK_INVALID", "This link is invalid")
+			default:
+				writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "An internal error occurred")
+			}
+		})
+	}
+}
+
+// WithMagicLink returns a copy of ctx carrying a verified magic link
+func WithMagicLink(ctx context.Context, link *entity.MagicLink) context.Context {
+	return context.WithValue(ctx, magicLinkContextKey{}, link)
+}
+
+// MagicLinkFromContext returns the verified magic link (nil when none was verified)
+func MagicLinkFromContext(ctx context.Context) *entity.MagicLink {
+	if link, ok := ctx.Value(magicLinkContextKey{}).(*entity.MagicLink); ok {
+		return link
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+)
+
+// PortalRoutePrefix is the path prefix of the client self-service portal
+const PortalRoutePrefix = "/portal/v1"
+
+// PortalSessionPath exchanges a sign-in link for an access token, so it is open to anonymous callers
+const PortalSessionPath = PortalRoutePrefix + "/session"
+
+// portalClientContextKey is the context key for the authenticated portal client
+type portalClientContextKey struct{}
+
+// PortalAuthenticator resolves a portal access token to the client it is scoped to
+type PortalAuthenticator interface {
+	Authenticate(token string) (string, error)
+}
+
+// PortalTenantResolver resolves the tenant of a portal client; authenticators implementing it scope portal requests
+// to the tenant of their client
+type PortalTenantResolver interface {
+	ClientTenant(clientID string) (string, error)
+}
+
+// PortalGuard authenticates portal clients with client-scoped bearer tokens
+type PortalGuard struct {
+	authenticator PortalAuthenticator
+}
+
+// NewPortalGuard creates a portal guard; with no authenticator the portal is disabled and requests pass through
+func NewPortalGuard(authenticator PortalAuthenticator) *PortalGuard {
+	return &PortalGuard{
+		authenticator: authenticator,
+	}
+}
+
+// Require wraps a handler with portal authentication and stores the client ID in the context
+func (g *PortalGuard) Require(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if g.authenticator == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Portal credentials are required")
+			return
+		}
+
+		clientID, err := g.authenticator.Authenticate(token)
+		if err != nil {
+			message := "Portal credentials are invalid"
+			if errors.Is(err, domainErrors.ErrPortalTokenExpired) {
+				message = "Portal credentials have expired"
+			}
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", message)
+			return
+		}
+
+		ctx := WithPortalClient(r.Context(), clientID)
+		if resolver, ok := g.authenticator.(PortalTenantResolver); ok {
+			tenantID, err := resolver.ClientTenant(clientID)
+			if err != nil {
+				writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Portal credentials are invalid")
+				return
+			}
+			if tenantID != "" {
+				ctx = WithTenantScope(WithTenantID(ctx, tenantID))
+			}
+		}
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// WithPortalClient returns a copy of ctx carrying the authenticated portal client ID
+func WithPortalClient(ctx context.Context, clientID string) context.Context {
+	return context.WithValue(ctx, portalClientContextKey{}, clientID)
+}
+
+// PortalClientFromContext returns the authenticated portal client ID (empty when not authenticated)
+func PortalClientFromContext(ctx context.Context) string {
+	if clientID, ok := ctx.Value(portalClientContextKey{}).(string); ok {
+		return clientID
+	}
+	return ""
+}
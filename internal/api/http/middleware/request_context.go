@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+)
+
+// requestContextKey is the context key for the request context handed to application services
+type requestContextKey struct{}
+
+// sandboxContextKey marks requests served by the sandbox environment
+type sandboxContextKey struct{}
+
+// RequestScope builds the request context handed to application services (tenant, principal, locale, flags)
+// It runs after authentication, innermost in the middleware chain, so the principal is known
+func RequestScope(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		next.ServeHTTP(w, r.WithContext(WithRequestContext(ctx, buildRequestContext(ctx))))
+	})
+}
+
+// WithRequestContext returns a copy of ctx carrying the request context of application services
+func WithRequestContext(ctx context.Context, rc application.RequestContext) context.Context {
+	return context.WithValue(ctx, requestContextKey{}, rc)
+}
+
+// RequestContextFromContext returns the request context of application services
+// Handlers called outside the middleware chain get one built from what the context carries
+func RequestContextFromContext(ctx context.Context) application.RequestContext {
+	if rc, ok := ctx.Value(requestContextKey{}).(application.RequestContext); ok {
+		return rc
+	}
+	return buildRequestContext(ctx)
+}
+
+// RequestContextFromRequest returns the request context of application services for a request
+// The tenant is the one resolved from the credentials of the caller, never the X-Tenant-ID header alone
+func RequestContextFromRequest(r *http.Request) application.RequestContext {
+	return RequestContextFromContext(r.Context())
+}
+
+// buildRequestContext assembles the request context from the values stored by the other middlewares
+func buildRequestContext(ctx context.Context) application.RequestContext {
+	principal := application.Principal{Kind: application.PrincipalAnonymous}
+	if actor := AdminActorFromContext(ctx); actor != "" {
+		principal = application.Principal{Kind: application.PrincipalAdmin, ID: actor, Scopes: adminScopesFromContext(ctx)}
+	} else if clientID := PortalClientFromContext(ctx); clientID != "" {
+		principal = application.Principal{Kind: application.PrincipalPortal, ID: clientID}
+	}
+
+	return application.RequestContext{
+		TenantID:  TenantIDFromContext(ctx),
+		Principal: principal,
+		Locale:    LocaleFromContext(ctx),
+		Flags: application.RequestFlags{
+			Sandbox: ctx.Value(sandboxContextKey{}) != nil,
+		},
+	}
+}
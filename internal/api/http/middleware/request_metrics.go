@@ -0,0 +1,134 @@
+package middleware
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// MaxRequestMetricsWindow is the longest window request statistics are kept for
+const MaxRequestMetricsWindow = time.Hour
+
+// RequestStats summarizes the requests served over a window of whole minutes ending with the current minute
+type RequestStats struct {
+	Window            time.Duration
+	Requests          int
+	ClientErrors      int // 4xx responses
+	ServerErrors      int // 5xx responses
+	RequestsPerMinute float64
+	ErrorRate         float64 // Share of the requests answered with a 5xx status (0 without requests)
+}
+
+// requestBucket counts the requests of one minute
+type requestBucket struct {
+	minute       int64 // Unix minute the counts belong to
+	requests     int
+	clientErrors int
+	serverErrors int
+}
+
+// RequestMetrics counts requests and error responses per minute over the last hour, for operational dashboards
+type RequestMetrics struct {
+	mu      sync.Mutex
+	buckets [60]requestBucket
+	now     func() time.Time
+}
+
+// NewRequestMetrics creates empty request metrics
+func NewRequestMetrics() *RequestMetrics {
+	return &RequestMetrics{
+		now: time.Now,
+	}
+}
+
+// WithClock sets the clock requests are counted with (tests)
+func (m *RequestMetrics) WithClock(now func() time.Time) *RequestMetrics {
+	m.now = now
+	return m
+}
+
+// Middleware counts every request with the status code of its response
+func (m *RequestMetrics) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		defer func() {
+			m.Record(recorder.status)
+		}()
+
+		next.ServeHTTP(recorder, r)
+	})
+}
+
+// Record counts one request answered with the given status code
+func (m *RequestMetrics) Record(status int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	bucket := m.bucket(m.now().Unix() / 60)
+	bucket.requests++
+	switch {
+	case status >= 500:
+		bucket.serverErrors++
+	case status >= 400:
+		bucket.clientErrors++
+	}
+}
+
+// Stats summarizes the requests of the last window, rounded up to whole minutes and capped at an hour
+func (m *RequestMetrics) Stats(window time.Duration) RequestStats {
+	minutes := int((window + time.Minute - 1) / time.Minute)
+	if minutes < 1 {
+		minutes = 1
+	}
+	if minutes > len(m.buckets) {
+		minutes = len(m.buckets)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats := RequestStats{Window: time.Duration(minutes) * time.Minute}
+	current := m.now().Unix() / 60
+	for minute := current - int64(minutes) + 1; minute <= current; minute++ {
+		bucket := m.buckets[minute%int64(len(m.buckets))]
+		if bucket.minute != minute {
+			continue
+		}
+		stats.Requests += bucket.requests
+		stats.ClientErrors += bucket.clientErrors
+		stats.ServerErrors += bucket.serverErrors
+	}
+
+	stats.RequestsPerMinute = float64(stats.Requests) / float64(minutes)
+	if stats.Requests > 0 {
+		stats.ErrorRate = float64(stats.ServerErrors) / float64(stats.Requests)
+	}
+	return stats
+}
+
+// bucket returns the bucket of a minute, recycling the one of the same minute an hour earlier
+func (m *RequestMetrics) bucket(minute int64) *requestBucket {
+	bucket := &m.buckets[minute%int64(len(m.buckets))]
+	if bucket.minute != minute {
+		*bucket = requestBucket{minute: minute}
+	}
+	return bucket
+}
+
+// statusRecorder captures the status code written by the next handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Flush lets streaming handlers flush through the recorder
+func (r *statusRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// TenantHeader is the request header naming the tenant the caller asks to act in
+const TenantHeader = "X-Tenant-ID"
+
+// tenantContextKey is the context key for the caller's tenant identifier
+type tenantContextKey struct{}
+
+// tenantScopedContextKey marks requests that must act within a tenant
+type tenantScopedContextKey struct{}
+
+// TenantIDFromRequest extracts the tenant the caller asks to act in (empty when not provided)
+// The header is not a credential: it only selects among the tenants the authenticated principal may act in (see
+// TenantIDFromContext), and routes requests to the sandbox or picks the tenant locale
+func TenantIDFromRequest(r *http.Request) string {
+	return strings.TrimSpace(r.Header.Get(TenantHeader))
+}
+
+// WithTenantID returns a copy of ctx carrying the caller's tenant identifier
+func WithTenantID(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenantID)
+}
+
+// TenantIDFromContext returns the tenant the authenticated caller acts in (empty when none was resolved)
+// It is set by the admin and portal guards from the credentials of the caller; anonymous callers have none
+func TenantIDFromContext(ctx context.Context) string {
+	if tenantID, ok := ctx.Value(tenantContextKey{}).(string); ok {
+		return tenantID
+	}
+	return ""
+}
+
+// WithTenantScope returns a copy of ctx marking the request as acting within a tenant: a route acting on a
+// tenant, or a caller restricted to tenants
+func WithTenantScope(ctx context.Context) context.Context {
+	return context.WithValue(ctx, tenantScopedContextKey{}, true)
+}
+
+// IsTenantScoped reports whether the request must act within a tenant; such requests without a resolved tenant
+// are refused rather than served outside of any tenant
+func IsTenantScoped(ctx context.Context) bool {
+	return ctx.Value(tenantScopedContextKey{}) != nil
+}
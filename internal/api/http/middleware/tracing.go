@@ -0,0 +1,113 @@
+package middleware
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/tracing"
+	"github.com/google/uuid"
+)
+
+// maxRequestIDLength bounds incoming request IDs; longer ones are replaced
+const maxRequestIDLength = 128
+
+// maxTraceHeaderLength bounds incoming trace headers (tracestate allows 32 list members); longer ones are dropped
+const maxTraceHeaderLength = 512
+
+// requestIDPattern matches the request IDs honored from upstream (UUIDs, gateway and load balancer IDs)
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:/+=-]+$`)
+
+// traceparentPattern matches a W3C traceparent header: version-traceid-parentid-flags
+var traceparentPattern = regexp.MustCompile(`^[0-9a-f]{2}-[0-9a-f]{32}-[0-9a-f]{16}-[0-9a-f]{2}$`)
+
+// TracingConfig configures how request and trace identifiers are taken from upstream
+type TracingConfig struct {
+	// RequestIDHeader is the request ID header read and echoed (tracing.DefaultRequestIDHeader when empty)
+	RequestIDHeader string
+
+	// IgnoreIncoming generates a request ID and drops trace headers on every request, for deployments
+	// exposed directly to clients instead of behind a trusted API gateway
+	IgnoreIncoming bool
+}
+
+// RequestTracer honors the request ID and trace headers set by the API gateway and makes them available
+// to downstream calls through the request context; a request ID is generated when none was received
+type RequestTracer struct {
+	config TracingConfig
+}
+
+// NewRequestTracer creates a request tracer
+func NewRequestTracer(config TracingConfig) *RequestTracer {
+	if config.RequestIDHeader == "" {
+		config.RequestIDHeader = tracing.DefaultRequestIDHeader
+	}
+
+	return &RequestTracer{
+		config: config,
+	}
+}
+
+// Middleware stores the request propagation in the context and echoes the request ID on the response
+func (t *RequestTracer) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		propagation := t.Propagation(r)
+
+		w.Header().Set(propagation.RequestIDHeader, propagation.RequestID)
+		next.ServeHTTP(w, r.WithContext(tracing.WithPropagation(r.Context(), propagation)))
+	})
+}
+
+// Propagation returns the identifiers of a request: the incoming request ID and valid trace headers,
+// unless incoming headers are ignored
+func (t *RequestTracer) Propagation(r *http.Request) tracing.Propagation {
+	propagation := tracing.Propagation{
+		RequestIDHeader: t.config.RequestIDHeader,
+		Trace:           make(map[string]string),
+	}
+	if t.config.IgnoreIncoming {
+		propagation.RequestID = uuid.New().String()
+		return propagation
+	}
+
+	propagation.RequestID = strings.TrimSpace(r.Header.Get(t.config.RequestIDHeader))
+	if len(propagation.RequestID) > maxRequestIDLength || !requestIDPattern.MatchString(propagation.RequestID) {
+		propagation.RequestID = uuid.New().String()
+	}
+
+	for _, name := range tracing.TraceHeaders {
+		value := strings.TrimSpace(r.Header.Get(name))
+		if value == "" || len(value) > maxTraceHeaderLength || !isPrintableASCII(value) {
+			continue
+		}
+		propagation.Trace[name] = value
+	}
+
+	// A tracestate is only meaningful with the traceparent it belongs to
+	if traceparent, ok := propagation.Trace[tracing.TraceparentHeader]; ok && !isValidTraceparent(traceparent) {
+		delete(propagation.Trace, tracing.TraceparentHeader)
+	}
+	if _, ok := propagation.Trace[tracing.TraceparentHeader]; !ok {
+		delete(propagation.Trace, tracing.TracestateHeader)
+	}
+	return propagation
+}
+
+// isValidTraceparent reports whether a traceparent header is well formed, with a known version and non-zero IDs
+func isValidTraceparent(value string) bool {
+	if !traceparentPattern.MatchString(value) {
+		return false
+	}
+	parts := strings.Split(value, "-")
+	return parts[0] != "ff" && strings.Trim(parts[1], "0") != "" && strings.Trim(parts[2], "0") != ""
+}
+
+// isPrintableASCII reports whether a header value only contains printable ASCII characters
+func isPrintableASCII(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if value[i] < 0x20 || value[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,133 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// warningsContextKey is the context key for the warnings collected while serving a request
+type warningsContextKey struct{}
+
+// Warning is an early signal returned with a successful response, e.g. a client approaching its credit limit
+// Warnings never fail a request: clients may act on them or ignore them
+type Warning struct {
+	Code    string
+	Message string
+	Field   string // Request field or parameter the warning is about, if any
+}
+
+// WarningRule produces the warnings of a successful response from the request and the data it returns
+type WarningRule func(r *http.Request, data interface{}) []Warning
+
+// WarningSource is implemented by the response writers of the warning pipeline
+type WarningSource interface {
+	// ResponseWarnings returns the warnings of a successful response carrying data
+	ResponseWarnings(data interface{}) []Warning
+}
+
+// ResponseWarnings is the pluggable warning pipeline of successful responses
+// Rules run when the response is written, so they see its data; handlers add their own warnings with AddWarning
+type ResponseWarnings struct {
+	rules []WarningRule
+}
+
+// NewResponseWarnings creates a warning pipeline running rules in order
+func NewResponseWarnings(rules ...WarningRule) *ResponseWarnings {
+	return &ResponseWarnings{rules: rules}
+}
+
+// With adds a rule to the pipeline
+func (p *ResponseWarnings) With(rule WarningRule) *ResponseWarnings {
+	p.rules = append(p.rules, rule)
+	return p
+}
+
+// Middleware collects the warnings of each request and hands the pipeline to the response writer
+func (p *ResponseWarnings) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		collected := &warningList{}
+		r = r.WithContext(context.WithValue(r.Context(), warningsContextKey{}, collected))
+		next.ServeHTTP(&warningWriter{ResponseWriter: w, request: r, pipeline: p, collected: collected}, r)
+	})
+}
+
+// AddWarning adds a warning to the response of the request of ctx (ignored outside the warning pipeline)
+func AddWarning(ctx context.Context, warning Warning) {
+	if collected, ok := ctx.Value(warningsContextKey{}).(*warningList); ok {
+		collected.add(warning)
+	}
+}
+
+// DeprecatedParameterWarnings warns about the deprecated query parameters of a request
+// parameters maps each deprecated parameter to the advice returned with the warning, e.g. "use sort instead"
+func DeprecatedParameterWarnings(parameters map[string]string) WarningRule {
+	return func(r *http.Request, data interface{}) []Warning {
+		var warnings []Warning
+		query := r.URL.Query()
+		for parameter, advice := range parameters {
+			if !query.Has(parameter) {
+				continue
+			}
+			message := "Query parameter " + parameter + " is deprecated"
+			if advice != "" {
+				message += ": " + advice
+			}
+			warnings = append(warnings, Warning{Code: "DEPRECATED_PARAMETER", Message: message, Field: parameter})
+		}
+		sort.Slice(warnings, func(i, j int) bool { return warnings[i].Field < warnings[j].Field })
+		return warnings
+	}
+}
+
+// warningList holds the warnings handlers added while serving a request
+type warningList struct {
+	mu       sync.Mutex
+	warnings []Warning
+}
+
+func (l *warningList) add(warning Warning) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.warnings = append(l.warnings, warning)
+}
+
+func (l *warningList) list() []Warning {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]Warning(nil), l.warnings...)
+}
+
+// warningWriter gives the response writer access to the request and its warnings
+type warningWriter struct {
+	http.ResponseWriter
+	request   *http.Request
+	pipeline  *ResponseWarnings
+	collected *warningList
+}
+
+// ResponseWarnings returns the warnings added by handlers followed by those of the rules, without duplicates
+func (w *warningWriter) ResponseWarnings(data interface{}) []Warning {
+	warnings := w.collected.list()
+	for _, rule := range w.pipeline.rules {
+		warnings = append(warnings, rule(w.request, data)...)
+	}
+
+	seen := make(map[Warning]bool, len(warnings))
+	unique := warnings[:0]
+	for _, warning := range warnings {
+		if !seen[warning] {
+			seen[warning] = true
+			unique = append(unique, warning)
+		}
+	}
+	return unique
+}
+
+// Flush lets streaming handlers flush through the writer
+func (w *warningWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
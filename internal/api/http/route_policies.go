@@ -0,0 +1,204 @@
+package http
+
+import (
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/handlers"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/middleware"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+)
+
+// catalogMaxAge is how long callers may reuse reference data that changes only with a deployment
+const catalogMaxAge = 5 * time.Minute
+
+// Admin scopes granted to admin actors (see ServerOptions.AdminScopes), defined by the application services
+const (
+	ScopeFinance    = application.ScopeFinance
+	ScopeTenants    = application.ScopeTenants
+	ScopeSupport    = application.ScopeSupport
+	ScopeOperations = application.ScopeOperations
+)
+
+// routePolicies declares who may call each route of SetupRoutes
+// Every mutating route has an explicit policy; routes missing from the table fall back to the policy of their
+// route group (admin, portal or public), see middleware.Authorizer
+// Responses are not cached unless a policy opts in with Cached: client data (PII) must not linger in caches, only
+// reference data such as catalogs may be reused for a short while
+func routePolicies() middleware.RoutePolicies {
+	public := middleware.PublicRoute()
+	portal := middleware.PortalRoute()
+	tenantAdmin := middleware.CallerTenantAdminRoute("")
+	finance := middleware.AdminRoute(ScopeFinance)
+	tenantConfig := middleware.AdminRoute(ScopeTenants)
+	tenantOwned := middleware.TenantAdminRoute(ScopeTenants, "tenant")
+	tenantFinance := middleware.TenantAdminRoute(ScopeFinance, "tenant")
+	support := middleware.AdminRoute(ScopeSupport)
+	operations := middleware.AdminRoute(ScopeOperations)
+
+	return middleware.RoutePolicies{
+		// Client management API (the services require the finance scope for changes)
+		"/api/v1/clients":                                    tenantAdmin,
+		"/api/v1/clients/{id}":                               tenantAdmin,
+		"POST /api/v1/clients/batch-get":                     tenantAdmin,
+		"GET /api/v1/clients/changes":                        tenantAdmin,
+		"/api/v1/clients/by-external-ref/{ref}":              tenantAdmin,
+		"POST /api/v1/clients/import":                        tenantAdmin,
+		"DELETE /api/v1/clients/{id}":                        finance,
+		"/api/v1/clients/{id}/credit":                        tenantAdmin,
+		"PUT /api/v1/clients/{id}/credit":                    finance,
+		"DELETE /api/v1/clients/{id}/credit":                 finance,
+		"PATCH /api/v1/clients/{id}/status":                  tenantAdmin,
+		"POST /api/v1/clients/{id}/tags":                     tenantAdmin,
+		"DELETE /api/v1/clients/{id}/tags/{tag}":             tenantAdmin,
+		"/api/v1/clients/{id}/contacts":                      tenantAdmin,
+		"/api/v1/clients/{id}/contacts/{contact}":            tenantAdmin,
+		"/api/v1/clients/{id}/notes":                         tenantAdmin,
+		"POST /api/v1/clients/{id}/statements":               tenantAdmin,
+		"GET /api/v1/clients/{id}/statements/{job}":          tenantAdmin,
+		"GET /api/v1/clients/{id}/statements/{job}/document": tenantAdmin,
+		"POST /api/v1/imports/preview":                       tenantAdmin,
+		"POST /api/v1/imports/clients":                       tenantAdmin,
+
+		// Usage metering, contracts, recurring billing and quotes
+		"/api/v1/usage-records":                     tenantAdmin,
+		"GET /api/v1/usage-records/summary":         tenantAdmin,
+		"/api/v1/contracts":                         tenantAdmin,
+		"/api/v1/contracts/{id}":                    tenantAdmin,
+		"GET /api/v1/contracts/attainment":          tenantAdmin,
+		"POST /api/v1/contracts/{id}/subscriptions": tenantAdmin,
+		"POST /api/v1/contracts/{id}/invoices":      tenantAdmin,
+		"/api/v1/recurring-invoices":                tenantAdmin,
+		"/api/v1/recurring-invoices/{id}":           tenantAdmin,
+		"/api/v1/subscriptions":                     tenantAdmin,
+		"/api/v1/subscriptions/{id}":                tenantAdmin,
+		"POST /api/v1/subscriptions/{id}/pause":     tenantAdmin,
+		"POST /api/v1/subscriptions/{id}/resume":    tenantAdmin,
+		"POST /api/v1/subscriptions/{id}/cancel":    tenantAdmin,
+		"/api/v1/quotes":                            tenantAdmin,
+		"/api/v1/quotes/{id}":                       tenantAdmin,
+		"POST /api/v1/quotes/{id}/accept":           tenantAdmin,
+		"POST /api/v1/quotes/{id}/reject":           tenantAdmin,
+		"POST /api/v1/quotes/{id}/expire":           tenantAdmin,
+		"POST /api/v1/quotes/{id}/convert":          tenantAdmin,
+
+		// Invoice management API (the services require the finance scope for changes)
+		"/api/v1/invoices":                      tenantAdmin,
+		"/api/v1/invoices/{id}":                 tenantAdmin,
+		"POST /api/v1/invoices/{id}/issue":      tenantAdmin,
+		"POST /api/v1/invoices/{id}/void":       tenantAdmin,
+		"/api/v1/invoices/{id}/payments":        finance,
+		"/api/v1/invoices/{id}/delivery-events": support,
+		"GET /api/v1/invoices/{id}/view.gif":    public,
+
+		// Back-office workflows outside the admin prefix
+		"/api/v1/approvals":                             finance,
+		"/api/v1/approvals/{id}":                        finance,
+		"POST /api/v1/approvals/{id}/approve":           finance,
+		"/api/v1/bank-statements":                       finance,
+		"/api/v1/bank-transactions":                     finance,
+		"/api/v1/bank-transactions/{id}":                finance,
+		"POST /api/v1/bank-transactions/{id}/reconcile": finance,
+		"/api/v1/uploads":                               finance,
+		"/api/v1/uploads/{id}":                          finance,
+		"/api/v1/events":                                operations,
+
+		// Inbound provider webhooks (authenticated by the provider signature) and consumer webhook subscriptions
+		// (the services require the tenants scope)
+		"POST /api/v1/webhooks/{provider}":  public,
+		"/api/v1/webhooks":                  tenantAdmin,
+		"/api/v1/webhooks/{id}":             tenantAdmin,
+		"POST /api/v1/webhooks/{id}/replay": tenantAdmin,
+
+		// Tenant configuration
+		"/api/v1/admin/ip-access-policies":                  tenantConfig,
+		"/api/v1/admin/ip-access-policies/{tenant}":         tenantOwned,
+		"/api/v1/admin/dunning-policies":                    tenantConfig,
+		"/api/v1/admin/dunning-policies/{tenant}":           tenantOwned,
+		"/api/v1/admin/tenant-rules":                        tenantConfig,
+		"/api/v1/admin/tenant-rules/{tenant}":               tenantOwned,
+		"/api/v1/admin/document-templates":                  tenantConfig,
+		"/api/v1/admin/document-templates/{tenant}":         tenantOwned,
+		"/api/v1/admin/document-templates/{tenant}/preview": tenantOwned,
+
+		// Finance administration
+		"/api/v1/admin/fiscal-calendars":                                       finance,
+		"/api/v1/admin/fiscal-calendars/{tenant}":                              tenantFinance,
+		"/api/v1/admin/fiscal-calendars/{tenant}/periods":                      tenantFinance,
+		"POST /api/v1/admin/fiscal-calendars/{tenant}/periods/{period}/close":  tenantFinance,
+		"POST /api/v1/admin/fiscal-calendars/{tenant}/periods/{period}/reopen": tenantFinance,
+		"/api/v1/admin/payout-reconciliations":                                 finance,
+		"/api/v1/admin/payout-reconciliations/{id}":                            finance,
+		"POST /api/v1/admin/payout-reconciliations/run":                        finance,
+		"/api/v1/admin/legal-entities":                                         finance,
+		"/api/v1/admin/legal-entities/{id}":                                    finance,
+
+		// Customer support
+		"POST /api/v1/admin/portal-tokens/{id}":         support,
+		"POST /api/v1/admin/portal-links/{id}":          support,
+		"/api/v1/admin/email-suppressions":              support,
+		"POST /api/v1/admin/email-suppressions/bounces": support,
+		"/api/v1/admin/email-suppressions/{email}":      support,
+
+		// Scheduler jobs
+		"POST /api/v1/admin/dunning/run":                          operations,
+		"POST /api/v1/admin/contract-renewal-reminders":           operations,
+		"POST /api/v1/admin/subscriptions/run":                    operations,
+		"POST /api/v1/admin/quotes/run":                           operations,
+		"POST /api/v1/admin/recurring-invoices/run":               operations,
+		"POST /api/v1/admin/webhooks/run":                         operations,
+		"POST /api/v1/admin/email-outbox/run":                     operations,
+		"POST /api/v1/admin/partitions/run":                       operations,
+		"POST /api/v1/admin/invoice-archive/run":                  operations,
+		"POST /api/v1/admin/statements/run":                       operations,
+		"POST /api/v1/admin/uploads/run":                          operations,
+		"POST /api/v1/admin/sagas/run":                            operations,
+		"POST /api/v1/admin/processed-messages/cleanup":           operations,
+		"POST /api/v1/admin/integration-logs/cleanup":             operations,
+		"POST /api/v1/admin/read-models/client-summaries/rebuild": operations,
+
+		// Operational tooling
+		"/api/v1/admin/audit-log":                           operations,
+		"/api/v1/admin/webhooks/dead-letters":               operations,
+		"/api/v1/admin/webhooks/events/{id}":                operations,
+		"POST /api/v1/admin/webhooks/events/{id}/retry":     operations,
+		"/api/v1/admin/dead-letters":                        operations,
+		"/api/v1/admin/dead-letters/{id}":                   operations,
+		"POST /api/v1/admin/dead-letters/retry":             operations,
+		"POST /api/v1/admin/dead-letters/discard":           operations,
+		"/api/v1/admin/email-outbox":                        operations,
+		"/api/v1/admin/email-outbox/metrics":                operations,
+		"/api/v1/admin/email-outbox/{id}":                   operations,
+		"POST /api/v1/admin/email-outbox/{id}/retry":        operations,
+		"/api/v1/admin/dashboard":                           operations,
+		"/api/v1/admin/dashboard/{panel}":                   operations,
+		"/api/v1/admin/invoice-archive/{id}":                operations,
+		"POST /api/v1/admin/invoice-archive/{id}/rehydrate": operations,
+		"/api/v1/admin/sagas":                               operations,
+		"/api/v1/admin/sagas/{id}":                          operations,
+		"POST /api/v1/admin/sagas/{id}/resume":              operations,
+		"/api/v1/admin/outbound-clients":                    operations,
+		"/api/v1/admin/integration-logs":                    operations,
+		"/api/v1/admin/integration-logs/{id}":               operations,
+		middleware.SandboxPath:                              operations,
+		ProfilingPath:                                       operations,
+		ProfilingPath + "/{profile}":                        operations,
+
+		// Client self-service portal
+		"POST " + middleware.PortalSessionPath:                               public,
+		middleware.PortalRoutePrefix + "/me":                                 portal,
+		middleware.PortalRoutePrefix + "/me/contact":                         portal,
+		middleware.PortalRoutePrefix + "/me/invoices":                        portal,
+		middleware.PortalRoutePrefix + "/me/invoices/{id}":                   portal,
+		"GET " + middleware.PortalRoutePrefix + "/me/invoices/{id}/document": portal,
+
+		// Deployment capabilities
+		"GET " + handlers.CapabilitiesPath: public.Cached(catalogMaxAge),
+
+		// Published API description
+		"GET " + handlers.OpenAPIPath: public.Cached(catalogMaxAge),
+		"GET " + handlers.DocsPath:    public.Cached(catalogMaxAge),
+
+		// Development tooling
+		"GET " + handlers.PlaygroundPath + "/openapi.yaml": public.Cached(catalogMaxAge),
+	}
+}
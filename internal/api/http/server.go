@@ -1,21 +1,192 @@
 package http
 
 import (
+	"log"
 	"net/http"
 	"strings"
 
+	"github.com/gjaminon-go-labs/billing-api/api"
 	"github.com/gjaminon-go-labs/billing-api/internal/api/http/handlers"
 	"github.com/gjaminon-go-labs/billing-api/internal/api/http/middleware"
 	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/httpclient"
 )
 
 // Server represents the HTTP server with all dependencies
 type Server struct {
-	billingService *application.BillingService
-	clientHandler  *handlers.ClientHandler
-	healthHandler  *handlers.HealthHandler
-	errorHandler   *middleware.ErrorHandler
-	version        string
+	billingService             *application.BillingService
+	clientHandler              *handlers.ClientHandler
+	healthHandler              *handlers.HealthHandler
+	capabilitiesHandler        *handlers.CapabilitiesHandler
+	accessPolicyHandler        *handlers.AccessPolicyHandler
+	auditHandler               *handlers.AuditHandler
+	portalHandler              *handlers.PortalHandler
+	usageHandler               *handlers.UsageHandler
+	contractHandler            *handlers.ContractHandler
+	approvalHandler            *handlers.ApprovalHandler
+	invoiceHandler             *handlers.InvoiceHandler
+	recurringHandler           *handlers.RecurringInvoiceHandler
+	subscriptionHandler        *handlers.SubscriptionHandler
+	quoteHandler               *handlers.QuoteHandler
+	deliveryHandler            *handlers.InvoiceDeliveryHandler
+	dunningHandler             *handlers.DunningPolicyHandler
+	dunningRunHandler          *handlers.DunningHandler
+	tenantRuleHandler          *handlers.TenantRuleHandler
+	fiscalCalendarHandler      *handlers.FiscalCalendarHandler
+	cashHandler                *handlers.CashApplicationHandler
+	payoutHandler              *handlers.PayoutReconciliationHandler
+	legalEntityHandler         *handlers.LegalEntityHandler
+	documentHandler            *handlers.DocumentTemplateHandler
+	creditHandler              *handlers.CreditControlHandler
+	statementHandler           *handlers.ClientStatementHandler
+	sandboxHandler             *handlers.SandboxHandler
+	webhookHandler             *handlers.WebhookHandler
+	webhookSubscriptionHandler *handlers.WebhookSubscriptionHandler
+	deadLetterHandler          *handlers.DeadLetterHandler
+	emailOutboxHandler         *handlers.EmailOutboxHandler
+	dashboardHandler           *handlers.DashboardHandler
+	processedMessageHandler    *handlers.ProcessedMessageHandler
+	sagaHandler                *handlers.SagaHandler
+	outboundClientHandler      *handlers.OutboundClientHandler
+	integrationLogHandler      *handlers.IntegrationLogHandler
+	invoicePaymentHandler      *handlers.InvoicePaymentHandler
+	clientSummaryHandler       *handlers.ClientSummaryHandler
+	contactHandler             *handlers.ClientContactHandler
+	noteHandler                *handlers.ClientNoteHandler
+	importHandler              *handlers.ClientImportHandler
+	docsHandler                *handlers.DocsHandler
+	eventHandler               *handlers.EventHandler
+	partitionHandler           *handlers.PartitionHandler
+	invoiceArchiveHandler      *handlers.InvoiceArchiveHandler
+	uploadHandler              *handlers.UploadHandler
+	portalSession              http.Handler
+	errorHandler               *middleware.ErrorHandler
+	localeResolver             *middleware.LocaleResolver
+	signatures                 *middleware.SignatureVerifier
+	ipAccess                   *middleware.IPAccessFilter
+	adminGuard                 *middleware.AdminGuard
+	captcha                    *middleware.CaptchaGuard
+	portalGuard                *middleware.PortalGuard
+	authorizer                 *middleware.Authorizer
+	sandbox                    *middleware.SandboxRouter
+	requestTracer              *middleware.RequestTracer
+	requestMetrics             *middleware.RequestMetrics
+	clientIP                   *middleware.ClientIPResolver
+	warnings                   *middleware.ResponseWarnings
+	playgroundHandler          *handlers.PlaygroundHandler
+	separateAdmin              bool // Operational routes are served by AdminHandler only
+	profiling                  bool
+	version                    string
+}
+
+// Services groups the application services exposed over HTTP
+// Optional services (nil) disable their routes
+type Services struct {
+	Billing              *application.BillingService
+	AccessPolicies       *application.AccessPolicyService
+	Audit                *application.AuditService
+	FormTokens           *application.FormTokenService
+	Portal               *application.PortalService
+	MagicLinks           *application.MagicLinkService
+	Usage                *application.UsageService
+	Contracts            *application.ContractService
+	Approvals            *application.ApprovalService
+	Recurring            *application.RecurringInvoiceService
+	Subscriptions        *application.SubscriptionService
+	Quotes               *application.QuoteService
+	Delivery             *application.InvoiceDeliveryService
+	Dunning              *application.DunningPolicyService
+	DunningRuns          *application.DunningService
+	TenantRules          *application.TenantRuleService
+	FiscalCalendars      *application.FiscalCalendarService
+	Cash                 *application.CashApplicationService
+	Payouts              *application.PayoutReconciliationService
+	LegalEntities        *application.LegalEntityService
+	Documents            *application.DocumentTemplateService
+	Credit               *application.CreditControlService
+	Statements           *application.ClientStatementService
+	Risk                 *application.RiskScoringService
+	Webhooks             *application.WebhookService
+	WebhookSubscriptions *application.WebhookSubscriptionService
+	DeadLetters          *application.DeadLetterService
+	EmailOutbox          *application.EmailOutboxService
+	Dashboard            *application.DashboardService
+	Idempotency          *application.IdempotentConsumer
+	Sagas                *application.SagaOrchestrator
+	InvoicePayments      *application.InvoicePaymentService
+	OutboundClients      *httpclient.Registry
+	IntegrationLogs      *application.IntegrationLogService
+	Events               *application.EventStore
+	Partitions           *application.PartitionMaintenanceService
+	InvoiceArchive       *application.InvoiceArchiveService
+	Uploads              *application.UploadService
+	Currencies           *application.CurrencyPolicies
+}
+
+// ServerOptions holds optional HTTP server settings
+type ServerOptions struct {
+	// Version reported by the health endpoint
+	Version string
+
+	// DefaultLocale is used when neither Accept-Language nor a tenant default is available
+	DefaultLocale string
+
+	// TenantLocales maps tenant IDs to their default locale (e.g. "fr-FR")
+	TenantLocales map[string]string
+
+	// RequestSigning configures HMAC signature verification per route group
+	RequestSigning middleware.SignatureConfig
+
+	// AdminTokens maps admin actor names to the bearer token granting access to /api/v1/admin
+	AdminTokens map[string]string
+
+	// AdminScopes restricts admin actors to the listed scopes (ScopeFinance, ScopeTenants, ScopeSupport,
+	// ScopeOperations); actors without an entry hold every scope
+	AdminScopes map[string][]string
+
+	// AdminTenants restricts admin actors to the listed tenants: they can only reach admin routes acting on a
+	// tenant they own; actors without an entry reach every tenant
+	AdminTenants map[string][]string
+
+	// Captcha configures anti-automation challenges on public routes
+	Captcha middleware.CaptchaConfig
+
+	// Sandbox routes flagged tenants and API keys to an isolated environment
+	Sandbox middleware.SandboxConfig
+
+	// Tracing configures the request ID and trace headers honored from the API gateway
+	Tracing middleware.TracingConfig
+
+	// TrustedProxies lists the proxy and load balancer addresses or CIDR ranges whose forwarding headers
+	// (X-Forwarded-For, X-Real-IP) are honored when resolving the client address
+	TrustedProxies []string
+
+	// IdempotentDeletes makes deleting an already deleted client succeed (204) instead of failing with 404 unless
+	// the request asks for the strict mode (X-Delete-Mode header)
+	IdempotentDeletes bool
+
+	// DeprecatedParameters maps deprecated query parameters to the advice returned in response warnings
+	// when they are used (e.g. "use status instead")
+	DeprecatedParameters map[string]string
+
+	// CreditWarningThreshold is the share (0-1) of its credit limit a client's exposure reaches before invoice
+	// changes return a warning (0: no credit limit warnings)
+	CreditWarningThreshold float64
+
+	// EnablePlayground serves the interactive API playground at /playground (development only)
+	EnablePlayground bool
+
+	// DocsAssetsURL is the base URL of the swagger-ui-dist files the API reference at /docs loads
+	// (handlers.DefaultSwaggerUIAssets when empty)
+	DocsAssetsURL string
+
+	// SeparateAdminListener moves the operational routes (health checks, the admin API and the profiler) from
+	// Handler to AdminHandler, served on a listener the public ingress does not reach
+	SeparateAdminListener bool
+
+	// EnableProfiling serves the Go profiler at /debug/pprof to admins holding the operations scope; it is only
+	// served with a separate admin listener
+	EnableProfiling bool
 }
 
 // NewServer creates a new HTTP server with dependencies
@@ -25,13 +196,183 @@ func NewServer(billingService *application.BillingService) *Server {
 
 // NewServerWithVersion creates a new HTTP server with dependencies and version
 func NewServerWithVersion(billingService *application.BillingService, version string) *Server {
-	return &Server{
-		billingService: billingService,
-		clientHandler:  handlers.NewClientHandler(billingService),
+	return NewServerWithOptions(billingService, ServerOptions{Version: version})
+}
+
+// NewServerWithOptions creates a new HTTP server with dependencies and options
+func NewServerWithOptions(billingService *application.BillingService, options ServerOptions) *Server {
+	return NewServerWithServices(Services{Billing: billingService}, options)
+}
+
+// NewServerWithServices creates a new HTTP server exposing the given services
+func NewServerWithServices(services Services, options ServerOptions) *Server {
+	version := options.Version
+	if version == "" {
+		version = "dev"
+	}
+
+	server := &Server{
+		billingService: services.Billing,
+		clientHandler:  handlers.NewClientHandler(services.Billing).WithFormTokens(services.FormTokens).WithIdempotentDeletes(options.IdempotentDeletes),
+		invoiceHandler: handlers.NewInvoiceHandler(services.Billing),
 		healthHandler:  handlers.NewHealthHandler(version),
 		errorHandler:   middleware.NewErrorHandler(),
+		localeResolver: middleware.NewLocaleResolver(options.DefaultLocale, options.TenantLocales),
+		signatures:     middleware.NewSignatureVerifier(options.RequestSigning),
+		ipAccess:       middleware.NewIPAccessFilter(nil),
+		adminGuard:     middleware.NewAdminGuard(options.AdminTokens).WithScopes(options.AdminScopes).WithTenants(options.AdminTenants),
+		captcha:        middleware.NewCaptchaGuard(options.Captcha),
+		portalGuard:    middleware.NewPortalGuard(nil),
+		sandbox:        middleware.NewSandboxRouter(options.Sandbox),
+		requestTracer:  middleware.NewRequestTracer(options.Tracing),
+		requestMetrics: middleware.NewRequestMetrics(),
+		clientIP:       middleware.NewClientIPResolver(options.TrustedProxies),
+		warnings:       middleware.NewResponseWarnings(),
+		separateAdmin:  options.SeparateAdminListener,
+		profiling:      options.EnableProfiling && options.SeparateAdminListener,
 		version:        version,
 	}
+
+	if services.AccessPolicies != nil {
+		server.accessPolicyHandler = handlers.NewAccessPolicyHandler(services.AccessPolicies)
+		server.ipAccess = middleware.NewIPAccessFilter(services.AccessPolicies)
+	}
+	if services.Audit != nil {
+		server.auditHandler = handlers.NewAuditHandler(services.Audit)
+	}
+	if services.Portal != nil {
+		server.portalHandler = handlers.NewPortalHandler(services.Portal)
+		server.portalGuard = middleware.NewPortalGuard(services.Portal)
+	}
+	if services.Portal != nil && services.MagicLinks != nil {
+		requireSignInLink := middleware.RequireMagicLink(services.MagicLinks, application.MagicLinkPurposePortalSignIn)
+		server.portalSession = requireSignInLink(http.HandlerFunc(server.portalHandler.CreateSession))
+	}
+	if services.Usage != nil {
+		server.usageHandler = handlers.NewUsageHandler(services.Usage)
+	}
+	if services.Contracts != nil {
+		server.contractHandler = handlers.NewContractHandler(services.Contracts)
+	}
+	if services.Approvals != nil {
+		server.approvalHandler = handlers.NewApprovalHandler(services.Approvals)
+	}
+	if services.Recurring != nil {
+		server.recurringHandler = handlers.NewRecurringInvoiceHandler(services.Recurring)
+	}
+	if services.Subscriptions != nil {
+		server.subscriptionHandler = handlers.NewSubscriptionHandler(services.Subscriptions)
+	}
+	if services.Quotes != nil {
+		server.quoteHandler = handlers.NewQuoteHandler(services.Quotes)
+	}
+	if services.Delivery != nil {
+		server.deliveryHandler = handlers.NewInvoiceDeliveryHandler(services.Delivery)
+	}
+	if services.Dunning != nil {
+		server.dunningHandler = handlers.NewDunningPolicyHandler(services.Dunning)
+	}
+	if services.DunningRuns != nil {
+		server.dunningRunHandler = handlers.NewDunningHandler(services.DunningRuns)
+	}
+	if services.TenantRules != nil {
+		server.tenantRuleHandler = handlers.NewTenantRuleHandler(services.TenantRules)
+	}
+	if services.FiscalCalendars != nil {
+		server.fiscalCalendarHandler = handlers.NewFiscalCalendarHandler(services.FiscalCalendars)
+	}
+	if services.Cash != nil {
+		server.cashHandler = handlers.NewCashApplicationHandler(services.Cash)
+	}
+	if services.Payouts != nil {
+		server.payoutHandler = handlers.NewPayoutReconciliationHandler(services.Payouts)
+	}
+	if services.LegalEntities != nil {
+		server.legalEntityHandler = handlers.NewLegalEntityHandler(services.LegalEntities)
+	}
+	if services.Documents != nil {
+		server.documentHandler = handlers.NewDocumentTemplateHandler(services.Documents)
+	}
+	if services.Credit != nil {
+		server.creditHandler = handlers.NewCreditControlHandler(services.Credit)
+		if options.CreditWarningThreshold > 0 {
+			server.warnings.With(handlers.CreditLimitWarnings(services.Credit, options.CreditWarningThreshold))
+		}
+	}
+	if len(options.DeprecatedParameters) > 0 {
+		server.warnings.With(middleware.DeprecatedParameterWarnings(options.DeprecatedParameters))
+	}
+	if services.Statements != nil {
+		server.statementHandler = handlers.NewClientStatementHandler(services.Statements)
+	}
+	if services.Risk != nil {
+		server.clientHandler.WithRiskScoring(services.Risk)
+	}
+	if services.Webhooks != nil {
+		server.webhookHandler = handlers.NewWebhookHandler(services.Webhooks)
+	}
+	if services.WebhookSubscriptions != nil {
+		server.webhookSubscriptionHandler = handlers.NewWebhookSubscriptionHandler(services.WebhookSubscriptions)
+	}
+	if services.DeadLetters != nil {
+		server.deadLetterHandler = handlers.NewDeadLetterHandler(services.DeadLetters)
+	}
+	if services.EmailOutbox != nil {
+		server.emailOutboxHandler = handlers.NewEmailOutboxHandler(services.EmailOutbox)
+	}
+	if services.Dashboard != nil {
+		server.dashboardHandler = handlers.NewDashboardHandler(services.Dashboard, server.requestMetrics)
+	}
+	if services.Idempotency != nil {
+		server.processedMessageHandler = handlers.NewProcessedMessageHandler(services.Idempotency)
+	}
+	if services.Sagas != nil {
+		server.sagaHandler = handlers.NewSagaHandler(services.Sagas)
+	}
+	if services.OutboundClients != nil {
+		server.outboundClientHandler = handlers.NewOutboundClientHandler(services.OutboundClients)
+	}
+	if services.IntegrationLogs != nil {
+		server.integrationLogHandler = handlers.NewIntegrationLogHandler(services.IntegrationLogs)
+	}
+	server.invoicePaymentHandler = handlers.NewInvoicePaymentHandler(services.Billing).WithCardPayments(services.InvoicePayments)
+	server.clientSummaryHandler = handlers.NewClientSummaryHandler(services.Billing)
+	server.contactHandler = handlers.NewClientContactHandler(services.Billing)
+	server.noteHandler = handlers.NewClientNoteHandler(services.Billing)
+	server.importHandler = handlers.NewClientImportHandler(services.Billing)
+	if services.Events != nil {
+		server.eventHandler = handlers.NewEventHandler(services.Events)
+	}
+	if services.Partitions != nil {
+		server.partitionHandler = handlers.NewPartitionHandler(services.Partitions)
+	}
+	if services.InvoiceArchive != nil {
+		server.invoiceArchiveHandler = handlers.NewInvoiceArchiveHandler(services.InvoiceArchive)
+	}
+	if services.Uploads != nil {
+		server.uploadHandler = handlers.NewUploadHandler(services.Uploads)
+	}
+	if options.Sandbox.Environment != nil {
+		server.sandboxHandler = handlers.NewSandboxHandler(options.Sandbox.Environment)
+	}
+	server.capabilitiesHandler = capabilities(services, options, version)
+	server.authorizer = middleware.NewAuthorizer(server.adminGuard, server.portalGuard, routePolicies())
+	docs, err := handlers.NewDocsHandler(api.OpenAPISpec, options.DocsAssetsURL)
+	if err != nil {
+		log.Printf("API reference disabled: %v", err)
+	} else {
+		server.docsHandler = docs
+	}
+	if options.EnablePlayground {
+		playground, err := handlers.NewPlaygroundHandler(api.OpenAPISpec)
+		if err != nil {
+			log.Printf("API playground disabled: %v", err)
+		} else {
+			server.playgroundHandler = playground
+		}
+	}
+
+	return server
 }
 
 // SetupRoutes configures HTTP routes and middleware
@@ -40,15 +381,245 @@ func (s *Server) SetupRoutes() http.Handler {
 
 	// Health check endpoint
 	mux.HandleFunc("/health", s.healthHandler.Health)
+	mux.HandleFunc(handlers.CapabilitiesPath, s.capabilitiesHandler.GetCapabilities) // Features, limits and currencies of this deployment
 
 	// API routes
-	mux.HandleFunc("/api/v1/clients/", s.handleClientWithIDRoute) // Individual client operations
-	mux.HandleFunc("/api/v1/clients", s.handleClientsRoute)       // Collection operations
+	mux.HandleFunc("/api/v1/clients/", s.handleClientWithIDRoute)                              // Individual client operations
+	mux.HandleFunc("/api/v1/clients", s.handleClientsRoute)                                    // Collection operations
+	mux.HandleFunc("/api/v1/clients/new-token", s.clientHandler.IssueFormToken)                // One-time form tokens for browser clients
+	mux.HandleFunc("/api/v1/clients/count", s.clientHandler.CountClients)                      // Lightweight count for dashboards
+	mux.HandleFunc("/api/v1/clients/batch-get", s.clientHandler.BatchGetClients)               // Several clients by ID in one round trip
+	mux.HandleFunc("/api/v1/clients/import", s.importHandler.ImportCSV)                        // One-step CSV import with a row-by-row report
+	mux.HandleFunc("/api/v1/clients/changes", s.clientHandler.ListClientChanges)               // Incremental sync feed
+	mux.HandleFunc("/api/v1/clients/by-external-ref/", s.clientHandler.GetClientByExternalRef) // Lookup by integrator reference
+	mux.HandleFunc("/api/v1/clients/summaries", s.clientSummaryHandler.ListSummaries)          // Client list with balances (read model)
+
+	// CSV imports of clients: the preview lets callers map the columns before the file is imported
+	mux.HandleFunc("/api/v1/imports/preview", s.importHandler.Preview)
+	mux.HandleFunc("/api/v1/imports/clients", s.importHandler.ImportClients)
+
+	// Metered usage
+	if s.usageHandler != nil {
+		mux.HandleFunc("/api/v1/usage-records", s.handleUsageRecordsRoute)
+		mux.HandleFunc("/api/v1/usage-records/summary", s.usageHandler.GetUsageSummary)
+	}
+
+	// Customer contracts
+	if s.contractHandler != nil {
+		mux.HandleFunc("/api/v1/contracts", s.handleContractsRoute)
+		mux.HandleFunc("/api/v1/contracts/", s.handleContractWithIDRoute)
+		mux.HandleFunc("/api/v1/contracts/attainment", s.contractHandler.GetAttainmentReport)
+	}
+
+	// Recurring invoice templates
+	if s.recurringHandler != nil {
+		mux.HandleFunc("/api/v1/recurring-invoices", s.handleRecurringInvoicesRoute)
+		mux.HandleFunc("/api/v1/recurring-invoices/", s.handleRecurringInvoiceWithIDRoute)
+	}
+
+	// Subscriptions
+	if s.subscriptionHandler != nil {
+		mux.HandleFunc("/api/v1/subscriptions", s.handleSubscriptionsRoute)
+		mux.HandleFunc("/api/v1/subscriptions/", s.handleSubscriptionWithIDRoute)
+	}
+
+	// Quotes
+	if s.quoteHandler != nil {
+		mux.HandleFunc("/api/v1/quotes", s.handleQuotesRoute)
+		mux.HandleFunc("/api/v1/quotes/", s.handleQuoteWithIDRoute)
+	}
+
+	// Invoices, their payments and delivery tracking (the view pixel is public, delivery events and payments need admin credentials)
+	mux.HandleFunc("/api/v1/invoices", s.handleInvoicesRoute)
+	mux.HandleFunc("/api/v1/invoices/", s.handleInvoiceWithIDRoute)
+
+	// Credit note and refund approvals (admin credentials identify requester and approver)
+	if s.approvalHandler != nil {
+		mux.HandleFunc("/api/v1/approvals", s.handleApprovalsRoute)
+		mux.HandleFunc("/api/v1/approvals/", s.handleApprovalWithIDRoute)
+	}
+
+	// Cash application (admin credentials identify the finance user confirming matches)
+	if s.cashHandler != nil {
+		mux.HandleFunc("/api/v1/bank-statements", s.cashHandler.ImportStatement)
+		mux.HandleFunc("/api/v1/bank-transactions", s.handleBankTransactionsRoute)
+		mux.HandleFunc("/api/v1/bank-transactions/", s.handleBankTransactionWithIDRoute)
+	}
+
+	// Resumable uploads of large files (bank statements), processed by the scheduler run
+	if s.uploadHandler != nil {
+		mux.HandleFunc("/api/v1/uploads", s.handleUploadsRoute)
+		mux.HandleFunc("/api/v1/uploads/", s.handleUploadWithIDRoute)
+		mux.HandleFunc("/api/v1/admin/uploads/run", s.uploadHandler.ProcessPending)
+	}
+
+	// Event log (admin credentials, the events carry client and payment data)
+	if s.eventHandler != nil {
+		mux.HandleFunc("/api/v1/events", s.eventHandler.ListEvents)
+	}
+
+	// Inbound webhooks (authenticated by the provider signature, not by API credentials) and the webhook
+	// subscriptions of consumers (tenant admins)
+	if s.webhookSubscriptionHandler != nil {
+		mux.HandleFunc("/api/v1/webhooks", s.handleWebhookSubscriptionsRoute)
+	}
+	if s.webhookHandler != nil || s.webhookSubscriptionHandler != nil {
+		mux.HandleFunc("/api/v1/webhooks/", s.handleWebhookRoute)
+	}
+
+	// Admin routes
+	mux.HandleFunc("/api/v1/admin/read-models/client-summaries/rebuild", s.clientSummaryHandler.Rebuild)
+	if s.accessPolicyHandler != nil {
+		mux.HandleFunc("/api/v1/admin/ip-access-policies/", s.handleIPAccessPolicyWithTenantRoute)
+		mux.HandleFunc("/api/v1/admin/ip-access-policies", s.handleIPAccessPoliciesRoute)
+	}
+	if s.dunningHandler != nil {
+		mux.HandleFunc("/api/v1/admin/dunning-policies/", s.handleDunningPolicyWithTenantRoute)
+		mux.HandleFunc("/api/v1/admin/dunning-policies", s.handleDunningPoliciesRoute)
+	}
+	if s.dunningRunHandler != nil {
+		mux.HandleFunc("/api/v1/admin/dunning/run", s.dunningRunHandler.SendDueReminders)
+	}
+	if s.tenantRuleHandler != nil {
+		mux.HandleFunc("/api/v1/admin/tenant-rules/", s.handleTenantRulesWithTenantRoute)
+		mux.HandleFunc("/api/v1/admin/tenant-rules", s.handleTenantRulesRoute)
+	}
+	if s.fiscalCalendarHandler != nil {
+		mux.HandleFunc("/api/v1/admin/fiscal-calendars/", s.handleFiscalCalendarWithTenantRoute)
+		mux.HandleFunc("/api/v1/admin/fiscal-calendars", s.handleFiscalCalendarsRoute)
+	}
+	if s.auditHandler != nil {
+		mux.HandleFunc("/api/v1/admin/audit-log", s.auditHandler.ListEntries)
+	}
+	if s.contractHandler != nil {
+		mux.HandleFunc("/api/v1/admin/contract-renewal-reminders", s.contractHandler.SendRenewalReminders)
+	}
+	if s.recurringHandler != nil {
+		mux.HandleFunc("/api/v1/admin/recurring-invoices/run", s.recurringHandler.IssueDueInvoices)
+	}
+	if s.subscriptionHandler != nil {
+		mux.HandleFunc("/api/v1/admin/subscriptions/run", s.subscriptionHandler.BillDueSubscriptions)
+	}
+	if s.quoteHandler != nil {
+		mux.HandleFunc("/api/v1/admin/quotes/run", s.quoteHandler.ExpireLapsedQuotes)
+	}
+	if s.payoutHandler != nil {
+		mux.HandleFunc("/api/v1/admin/payout-reconciliations/run", s.payoutHandler.Reconcile)
+		mux.HandleFunc("/api/v1/admin/payout-reconciliations/", s.handlePayoutReconciliationWithIDRoute)
+		mux.HandleFunc("/api/v1/admin/payout-reconciliations", s.handlePayoutReconciliationsRoute)
+	}
+	if s.legalEntityHandler != nil {
+		mux.HandleFunc("/api/v1/admin/legal-entities/", s.handleLegalEntityWithIDRoute)
+		mux.HandleFunc("/api/v1/admin/legal-entities", s.handleLegalEntitiesRoute)
+	}
+	if s.documentHandler != nil {
+		mux.HandleFunc("/api/v1/admin/document-templates/", s.handleDocumentTemplateWithTenantRoute)
+		mux.HandleFunc("/api/v1/admin/document-templates", s.handleDocumentTemplatesRoute)
+	}
+	if s.webhookHandler != nil {
+		mux.HandleFunc("/api/v1/admin/webhooks/run", s.webhookHandler.ProcessPending)
+		mux.HandleFunc("/api/v1/admin/webhooks/dead-letters", s.webhookHandler.ListDeadLetters)
+		mux.HandleFunc("/api/v1/admin/webhooks/events/", s.handleWebhookEventWithIDRoute)
+	}
+	if s.deadLetterHandler != nil {
+		mux.HandleFunc("/api/v1/admin/dead-letters", s.deadLetterHandler.ListDeadLetters)
+		mux.HandleFunc("/api/v1/admin/dead-letters/retry", s.deadLetterHandler.Retry)
+		mux.HandleFunc("/api/v1/admin/dead-letters/discard", s.deadLetterHandler.Discard)
+		mux.HandleFunc("/api/v1/admin/dead-letters/", s.handleDeadLetterWithIDRoute)
+	}
+	if s.emailOutboxHandler != nil {
+		mux.HandleFunc("/api/v1/admin/email-outbox", s.emailOutboxHandler.ListEmails)
+		mux.HandleFunc("/api/v1/admin/email-outbox/run", s.emailOutboxHandler.ProcessOutbox)
+		mux.HandleFunc("/api/v1/admin/email-outbox/metrics", s.emailOutboxHandler.Metrics)
+		mux.HandleFunc("/api/v1/admin/email-outbox/", s.handleOutboundEmailWithIDRoute)
+		mux.HandleFunc("/api/v1/admin/email-suppressions", s.handleEmailSuppressionsRoute)
+		mux.HandleFunc("/api/v1/admin/email-suppressions/bounces", s.emailOutboxHandler.ReportBounce)
+		mux.HandleFunc("/api/v1/admin/email-suppressions/", s.handleEmailSuppressionWithAddressRoute)
+	}
+	if s.dashboardHandler != nil {
+		mux.HandleFunc("/api/v1/admin/dashboard", s.dashboardHandler.GetDashboard)
+		mux.HandleFunc("/api/v1/admin/dashboard/requests", s.dashboardHandler.GetRequests)
+		mux.HandleFunc("/api/v1/admin/dashboard/queues", s.dashboardHandler.GetQueues)
+		mux.HandleFunc("/api/v1/admin/dashboard/overdue-invoices", s.dashboardHandler.GetOverdueInvoices)
+	}
+	if s.processedMessageHandler != nil {
+		mux.HandleFunc("/api/v1/admin/processed-messages/cleanup", s.processedMessageHandler.Cleanup)
+	}
+	if s.sagaHandler != nil {
+		mux.HandleFunc("/api/v1/admin/sagas", s.sagaHandler.ListSagas)
+		mux.HandleFunc("/api/v1/admin/sagas/run", s.sagaHandler.ResumeStuck)
+		mux.HandleFunc("/api/v1/admin/sagas/", s.handleSagaWithIDRoute)
+	}
+	if s.outboundClientHandler != nil {
+		mux.HandleFunc("/api/v1/admin/outbound-clients", s.outboundClientHandler.ListStats)
+	}
+	if s.integrationLogHandler != nil {
+		mux.HandleFunc("/api/v1/admin/integration-logs", s.integrationLogHandler.ListLogs)
+		mux.HandleFunc("/api/v1/admin/integration-logs/cleanup", s.integrationLogHandler.Cleanup)
+		mux.HandleFunc("/api/v1/admin/integration-logs/", s.handleIntegrationLogWithIDRoute)
+	}
+	if s.partitionHandler != nil {
+		mux.HandleFunc("/api/v1/admin/partitions/run", s.partitionHandler.Run)
+	}
+	if s.invoiceArchiveHandler != nil {
+		mux.HandleFunc("/api/v1/admin/invoice-archive/run", s.invoiceArchiveHandler.Run)
+		mux.HandleFunc("/api/v1/admin/invoice-archive/", s.handleInvoiceArchiveWithIDRoute)
+	}
+	if s.statementHandler != nil {
+		mux.HandleFunc("/api/v1/admin/statements/run", s.statementHandler.ProcessPending)
+	}
+	if s.sandboxHandler != nil {
+		mux.HandleFunc(middleware.SandboxPath, s.sandboxHandler.Wipe)
+	}
+	if s.portalHandler != nil {
+		mux.HandleFunc("/api/v1/admin/portal-tokens/", s.handlePortalTokenRoute)
+		mux.HandleFunc("/api/v1/admin/portal-links/", s.handlePortalLinkRoute)
+	}
+
+	// Client self-service portal (client-scoped tokens, see PortalGuard)
+	if s.portalHandler != nil {
+		mux.HandleFunc(middleware.PortalRoutePrefix+"/me", s.handlePortalProfileRoute)
+		mux.HandleFunc(middleware.PortalRoutePrefix+"/me/contact", s.handlePortalContactRoute)
+		mux.HandleFunc(middleware.PortalRoutePrefix+"/me/invoices", s.handlePortalInvoicesRoute)
+		mux.HandleFunc(middleware.PortalRoutePrefix+"/me/invoices/", s.handlePortalInvoiceRoute)
+	}
+	if s.portalSession != nil {
+		mux.HandleFunc(middleware.PortalSessionPath, s.handlePortalSessionRoute)
+	}
+
+	// Published API description
+	if s.docsHandler != nil {
+		mux.HandleFunc(handlers.OpenAPIPath, s.docsHandler.Spec)
+		mux.HandleFunc(handlers.DocsPath, s.docsHandler.Page)
+	}
+
+	// Development tooling
+	if s.playgroundHandler != nil {
+		mux.HandleFunc(handlers.PlaygroundPath, s.playgroundHandler.Page)
+		mux.HandleFunc(handlers.PlaygroundPath+"/openapi.yaml", s.playgroundHandler.Spec)
+	}
+
+	// Operational tooling (admin listener only)
+	if s.profiling {
+		registerProfiling(mux)
+	}
 
 	// Apply middleware chain
-	handler := s.errorHandler.RecoverMiddleware(mux)
+	handler := middleware.RequestScope(mux)
+	handler = s.warnings.Middleware(handler)
+	handler = s.captcha.Middleware(handler)
+	handler = s.ipAccess.Middleware(handler)
+	handler = s.authorizer.Middleware(handler)
+	handler = s.authorizer.CacheControl(handler)
+	handler = s.signatures.Middleware(handler)
+	handler = s.localeResolver.Middleware(handler)
+	handler = s.errorHandler.RecoverMiddleware(handler)
+	handler = s.requestMetrics.Middleware(handler)
 	handler = s.errorHandler.LoggingMiddleware(handler)
 	handler = s.errorHandler.CORSMiddleware(handler)
+	handler = s.clientIP.Middleware(handler)
+	handler = s.requestTracer.Middleware(handler)
+	handler = s.sandbox.Middleware(handler)
 
 	return handler
 }
@@ -57,6 +628,7 @@ func (s *Server) SetupRoutes() http.Handler {
 func (s *Server) handleClientsRoute(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodPost:
+		// Admins also see the onboarding risk score of the new client
 		s.clientHandler.CreateClient(w, r)
 	case http.MethodGet:
 		s.clientHandler.ListClients(w, r)
@@ -68,7 +640,7 @@ func (s *Server) handleClientsRoute(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// handleClientWithIDRoute handles individual client operations (GET, PUT, DELETE /api/v1/clients/{id})
+// handleClientWithIDRoute handles individual client operations (GET, HEAD, PUT, DELETE /api/v1/clients/{id})
 func (s *Server) handleClientWithIDRoute(w http.ResponseWriter, r *http.Request) {
 	// Extract client ID from URL path
 	clientID := extractClientIDFromPath(r.URL.Path)
@@ -80,10 +652,54 @@ func (s *Server) handleClientWithIDRoute(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// Nested routes only act on clients of the caller's tenant: the client services check it themselves, the
+	// services of nested resources are given a client checked here
+	route := strings.TrimPrefix(r.URL.Path, "/api/v1/clients/"+clientID)
+	if route == "/credit" {
+		if s.clientHandler.RequireOwnedClient(w, r, clientID) {
+			s.handleClientCreditRoute(w, r, clientID)
+		}
+		return
+	}
+	if route == "/status" {
+		s.clientHandler.UpdateClientStatus(w, r, clientID)
+		return
+	}
+	if route == "/tags" || strings.HasPrefix(route, "/tags/") {
+		s.handleClientTagRoute(w, r, clientID, strings.TrimPrefix(route, "/tags"))
+		return
+	}
+	if route == "/contacts" || strings.HasPrefix(route, "/contacts/") {
+		if s.clientHandler.RequireOwnedClient(w, r, clientID) {
+			s.handleClientContactRoute(w, r, clientID, strings.TrimPrefix(route, "/contacts"))
+		}
+		return
+	}
+	if route == "/notes" || route == "/notes/" {
+		if s.clientHandler.RequireOwnedClient(w, r, clientID) {
+			s.handleClientNotesRoute(w, r, clientID)
+		}
+		return
+	}
+	if route == "/statements" || strings.HasPrefix(route, "/statements/") {
+		// Statement jobs stay readable after the client is deleted, so callers learn why they failed
+		requireOwned := s.clientHandler.RequireOwnedClient
+		if r.Method == http.MethodGet {
+			requireOwned = s.clientHandler.RequireOwnedClientIfExists
+		}
+		if requireOwned(w, r, clientID) {
+			s.handleClientStatementRoute(w, r, clientID, strings.TrimPrefix(route, "/statements"))
+		}
+		return
+	}
+
 	// Route based on HTTP method
 	switch r.Method {
 	case http.MethodGet:
+		// Admins also see the latest risk score of the client
 		s.clientHandler.GetClient(w, r, clientID)
+	case http.MethodHead:
+		s.clientHandler.ClientExists(w, r, clientID)
 	case http.MethodPut:
 		s.clientHandler.UpdateClient(w, r, clientID)
 	case http.MethodDelete:
@@ -96,32 +712,1132 @@ func (s *Server) handleClientWithIDRoute(w http.ResponseWriter, r *http.Request)
 	}
 }
 
-// extractClientIDFromPath extracts the client ID from URL path like /api/v1/clients/{id}
-func extractClientIDFromPath(path string) string {
-	// Expected path format: /api/v1/clients/{id}
-	const prefix = "/api/v1/clients/"
+// handleClientCreditRoute handles the credit position of a client (GET, PUT, DELETE /api/v1/clients/{id}/credit)
+// Reading needs an admin like the other client routes; setting or removing the limit needs the finance scope (see
+// routePolicies)
+func (s *Server) handleClientCreditRoute(w http.ResponseWriter, r *http.Request, clientID string) {
+	if s.creditHandler == nil {
+		http.NotFound(w, r)
+		return
+	}
 
-	if !strings.HasPrefix(path, prefix) {
-		return ""
+	switch r.Method {
+	case http.MethodGet:
+		s.creditHandler.GetCredit(w, r, clientID)
+	case http.MethodPut:
+		s.creditHandler.SetLimit(w, r, clientID)
+	case http.MethodDelete:
+		s.creditHandler.DeleteLimit(w, r, clientID)
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+	}
+}
+
+// handleClientTagRoute handles the tags of a client (POST /api/v1/clients/{id}/tags and
+// DELETE /api/v1/clients/{id}/tags/{tag})
+func (s *Server) handleClientTagRoute(w http.ResponseWriter, r *http.Request, clientID, route string) {
+	tag := extractPathSegment(route, "/")
+	switch {
+	case (route == "" || route == "/") && r.Method == http.MethodPost:
+		s.clientHandler.AddClientTags(w, r, clientID)
+	case tag != "" && route == "/"+tag && r.Method == http.MethodDelete:
+		s.clientHandler.RemoveClientTag(w, r, clientID, tag)
+	case route == "" || route == "/" || (tag != "" && route == "/"+tag):
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+	default:
+		http.NotFound(w, r)
 	}
+}
 
-	// Extract the ID part after the prefix
-	clientID := strings.TrimPrefix(path, prefix)
+// handleClientNotesRoute handles the activity timeline of a client (GET, POST /api/v1/clients/{id}/notes)
+func (s *Server) handleClientNotesRoute(w http.ResponseWriter, r *http.Request, clientID string) {
+	switch r.Method {
+	case http.MethodGet:
+		s.noteHandler.ListNotes(w, r, clientID)
+	case http.MethodPost:
+		s.noteHandler.CreateNote(w, r, clientID)
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+	}
+}
 
-	// Remove any trailing slash or path segments
-	if slashIndex := strings.Index(clientID, "/"); slashIndex != -1 {
-		clientID = clientID[:slashIndex]
+// handleClientContactRoute handles the contacts of a client (GET, POST /api/v1/clients/{id}/contacts and
+// GET, PUT, DELETE /api/v1/clients/{id}/contacts/{contactID})
+func (s *Server) handleClientContactRoute(w http.ResponseWriter, r *http.Request, clientID, route string) {
+	contactID := extractPathSegment(route, "/")
+	switch {
+	case (route == "" || route == "/") && r.Method == http.MethodGet:
+		s.contactHandler.ListContacts(w, r, clientID)
+	case (route == "" || route == "/") && r.Method == http.MethodPost:
+		s.contactHandler.CreateContact(w, r, clientID)
+	case contactID != "" && route == "/"+contactID && r.Method == http.MethodGet:
+		s.contactHandler.GetContact(w, r, clientID, contactID)
+	case contactID != "" && route == "/"+contactID && r.Method == http.MethodPut:
+		s.contactHandler.UpdateContact(w, r, clientID, contactID)
+	case contactID != "" && route == "/"+contactID && r.Method == http.MethodDelete:
+		s.contactHandler.DeleteContact(w, r, clientID, contactID)
+	case route == "" || route == "/" || (contactID != "" && route == "/"+contactID):
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+	default:
+		http.NotFound(w, r)
 	}
+}
 
-	// Basic validation - not empty
-	if strings.TrimSpace(clientID) == "" {
-		return ""
+// handleClientStatementRoute handles the statements of a client (POST /api/v1/clients/{id}/statements,
+// GET /api/v1/clients/{id}/statements/{job} and GET /api/v1/clients/{id}/statements/{job}/document)
+func (s *Server) handleClientStatementRoute(w http.ResponseWriter, r *http.Request, clientID, route string) {
+	if s.statementHandler == nil {
+		http.NotFound(w, r)
+		return
 	}
 
-	return clientID
+	jobID := extractPathSegment(route, "/")
+	switch {
+	case (route == "" || route == "/") && r.Method == http.MethodPost:
+		s.statementHandler.RequestStatement(w, r, clientID)
+	case jobID != "" && route == "/"+jobID && r.Method == http.MethodGet:
+		s.statementHandler.GetJob(w, r, clientID, jobID)
+	case jobID != "" && route == "/"+jobID+"/document" && r.Method == http.MethodGet:
+		s.statementHandler.GetDocument(w, r, clientID, jobID)
+	case route == "" || route == "/" || (jobID != "" && (route == "/"+jobID || route == "/"+jobID+"/document")):
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+	default:
+		http.NotFound(w, r)
+	}
 }
 
-// Handler returns the configured HTTP handler
-func (s *Server) Handler() http.Handler {
+// handleUsageRecordsRoute handles POST /api/v1/usage-records
+func (s *Server) handleUsageRecordsRoute(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+		return
+	}
+
+	s.usageHandler.IngestUsageRecords(w, r)
+}
+
+// handleContractsRoute routes contract collection requests (GET, POST /api/v1/contracts)
+func (s *Server) handleContractsRoute(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		s.contractHandler.CreateContract(w, r)
+	case http.MethodGet:
+		s.contractHandler.ListContracts(w, r)
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+	}
+}
+
+// handleContractWithIDRoute handles individual contract operations
+// (GET /api/v1/contracts/{id}, POST /api/v1/contracts/{id}/subscriptions, POST /api/v1/contracts/{id}/invoices)
+func (s *Server) handleContractWithIDRoute(w http.ResponseWriter, r *http.Request) {
+	contractID := extractPathSegment(r.URL.Path, "/api/v1/contracts/")
+	if contractID == "" {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"code":"INVALID_PATH","message":"Invalid contract ID in path"},"success":false}`))
+		return
+	}
+
+	route := strings.TrimPrefix(r.URL.Path, "/api/v1/contracts/"+contractID)
+	switch {
+	case (route == "" || route == "/") && r.Method == http.MethodGet:
+		s.contractHandler.GetContract(w, r, contractID)
+	case route == "/subscriptions" && r.Method == http.MethodPost:
+		s.contractHandler.LinkSubscription(w, r, contractID)
+	case route == "/invoices" && r.Method == http.MethodPost:
+		s.contractHandler.LinkInvoice(w, r, contractID)
+	case route == "" || route == "/" || route == "/subscriptions" || route == "/invoices":
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// handleRecurringInvoicesRoute routes recurring invoice template collection requests (GET, POST /api/v1/recurring-invoices)
+func (s *Server) handleRecurringInvoicesRoute(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		s.recurringHandler.CreateTemplate(w, r)
+	case http.MethodGet:
+		s.recurringHandler.ListTemplates(w, r)
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+	}
+}
+
+// handleRecurringInvoiceWithIDRoute handles individual template operations (GET, PUT, DELETE /api/v1/recurring-invoices/{id})
+func (s *Server) handleRecurringInvoiceWithIDRoute(w http.ResponseWriter, r *http.Request) {
+	templateID := extractPathSegment(r.URL.Path, "/api/v1/recurring-invoices/")
+	if templateID == "" {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"code":"INVALID_PATH","message":"Invalid template ID in path"},"success":false}`))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.recurringHandler.GetTemplate(w, r, templateID)
+	case http.MethodPut:
+		s.recurringHandler.UpdateTemplate(w, r, templateID)
+	case http.MethodDelete:
+		s.recurringHandler.DeleteTemplate(w, r, templateID)
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+	}
+}
+
+// handleSubscriptionsRoute routes subscription collection requests (GET, POST /api/v1/subscriptions)
+func (s *Server) handleSubscriptionsRoute(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		s.subscriptionHandler.CreateSubscription(w, r)
+	case http.MethodGet:
+		s.subscriptionHandler.ListSubscriptions(w, r)
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+	}
+}
+
+// handleSubscriptionWithIDRoute routes individual subscription requests (GET, PUT /api/v1/subscriptions/{id},
+// POST /api/v1/subscriptions/{id}/pause, /resume, /cancel)
+func (s *Server) handleSubscriptionWithIDRoute(w http.ResponseWriter, r *http.Request) {
+	subscriptionID := extractPathSegment(r.URL.Path, "/api/v1/subscriptions/")
+	if subscriptionID == "" {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"code":"INVALID_PATH","message":"Invalid subscription ID in path"},"success":false}`))
+		return
+	}
+
+	route := strings.TrimPrefix(r.URL.Path, "/api/v1/subscriptions/"+subscriptionID)
+	switch {
+	case (route == "" || route == "/") && r.Method == http.MethodGet:
+		s.subscriptionHandler.GetSubscription(w, r, subscriptionID)
+	case (route == "" || route == "/") && r.Method == http.MethodPut:
+		s.subscriptionHandler.UpdateSubscription(w, r, subscriptionID)
+	case route == "/pause" && r.Method == http.MethodPost:
+		s.subscriptionHandler.PauseSubscription(w, r, subscriptionID)
+	case route == "/resume" && r.Method == http.MethodPost:
+		s.subscriptionHandler.ResumeSubscription(w, r, subscriptionID)
+	case route == "/cancel" && r.Method == http.MethodPost:
+		s.subscriptionHandler.CancelSubscription(w, r, subscriptionID)
+	case route == "" || route == "/" || route == "/pause" || route == "/resume" || route == "/cancel":
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// handleQuotesRoute routes quote collection requests (GET, POST /api/v1/quotes)
+func (s *Server) handleQuotesRoute(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		s.quoteHandler.CreateQuote(w, r)
+	case http.MethodGet:
+		s.quoteHandler.ListQuotes(w, r)
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+	}
+}
+
+// handleQuoteWithIDRoute routes individual quote requests (GET /api/v1/quotes/{id},
+// POST /api/v1/quotes/{id}/accept, /reject, /expire, /convert)
+func (s *Server) handleQuoteWithIDRoute(w http.ResponseWriter, r *http.Request) {
+	quoteID := extractPathSegment(r.URL.Path, "/api/v1/quotes/")
+	if quoteID == "" {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"code":"INVALID_PATH","message":"Invalid quote ID in path"},"success":false}`))
+		return
+	}
+
+	route := strings.TrimPrefix(r.URL.Path, "/api/v1/quotes/"+quoteID)
+	switch {
+	case (route == "" || route == "/") && r.Method == http.MethodGet:
+		s.quoteHandler.GetQuote(w, r, quoteID)
+	case route == "/accept" && r.Method == http.MethodPost:
+		s.quoteHandler.AcceptQuote(w, r, quoteID)
+	case route == "/reject" && r.Method == http.MethodPost:
+		s.quoteHandler.RejectQuote(w, r, quoteID)
+	case route == "/expire" && r.Method == http.MethodPost:
+		s.quoteHandler.ExpireQuote(w, r, quoteID)
+	case route == "/convert" && r.Method == http.MethodPost:
+		s.quoteHandler.ConvertQuote(w, r, quoteID)
+	case route == "" || route == "/" || route == "/accept" || route == "/reject" || route == "/expire" || route == "/convert":
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// handleInvoicesRoute routes invoice collection requests (GET, POST /api/v1/invoices)
+func (s *Server) handleInvoicesRoute(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		s.invoiceHandler.CreateInvoice(w, r)
+	case http.MethodGet:
+		s.invoiceHandler.ListInvoices(w, r)
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+	}
+}
+
+// handleInvoiceWithIDRoute handles individual invoice operations
+// (GET, PUT, DELETE /api/v1/invoices/{id}, POST /api/v1/invoices/{id}/issue, POST /api/v1/invoices/{id}/void,
+// GET, POST /api/v1/invoices/{id}/payments, GET, POST /api/v1/invoices/{id}/delivery-events, GET /api/v1/invoices/{id}/view.gif)
+func (s *Server) handleInvoiceWithIDRoute(w http.ResponseWriter, r *http.Request) {
+	invoiceID := extractPathSegment(r.URL.Path, "/api/v1/invoices/")
+	if invoiceID == "" {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"code":"INVALID_PATH","message":"Invalid invoice ID in path"},"success":false}`))
+		return
+	}
+
+	route := strings.TrimPrefix(r.URL.Path, "/api/v1/invoices/"+invoiceID)
+	switch {
+	case route == "" && r.Method == http.MethodGet:
+		s.invoiceHandler.GetInvoice(w, r, invoiceID)
+	case route == "" && r.Method == http.MethodPut:
+		s.invoiceHandler.UpdateInvoice(w, r, invoiceID)
+	case route == "" && r.Method == http.MethodDelete:
+		s.invoiceHandler.DeleteInvoice(w, r, invoiceID)
+	case route == "/issue" && r.Method == http.MethodPost:
+		s.invoiceHandler.IssueInvoice(w, r, invoiceID)
+	case route == "/void" && r.Method == http.MethodPost:
+		s.invoiceHandler.VoidInvoice(w, r, invoiceID)
+	case route == "/payments" && r.Method == http.MethodGet:
+		s.invoicePaymentHandler.ListPayments(w, r, invoiceID)
+	case route == "/payments" && r.Method == http.MethodPost:
+		s.invoicePaymentHandler.PayInvoice(w, r, invoiceID)
+	case route == "" || route == "/issue" || route == "/void" || route == "/payments":
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+	case s.deliveryHandler == nil:
+		http.NotFound(w, r)
+	case route == "/view.gif" && r.Method == http.MethodGet:
+		s.deliveryHandler.TrackView(w, r, invoiceID)
+	case route == "/delivery-events" && r.Method == http.MethodGet:
+		s.deliveryHandler.GetDelivery(w, r, invoiceID)
+	case route == "/delivery-events" && r.Method == http.MethodPost:
+		s.deliveryHandler.RecordEvent(w, r, invoiceID)
+	case route == "/view.gif" || route == "/delivery-events":
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// handleApprovalsRoute routes approval collection requests (GET, POST /api/v1/approvals)
+func (s *Server) handleApprovalsRoute(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		s.approvalHandler.CreateApproval(w, r)
+	case http.MethodGet:
+		s.approvalHandler.ListApprovals(w, r)
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+	}
+}
+
+// handleApprovalWithIDRoute handles individual approval operations
+// (GET /api/v1/approvals/{id}, POST /api/v1/approvals/{id}/approve)
+func (s *Server) handleApprovalWithIDRoute(w http.ResponseWriter, r *http.Request) {
+	approvalID := extractPathSegment(r.URL.Path, "/api/v1/approvals/")
+	if approvalID == "" {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"code":"INVALID_PATH","message":"Invalid approval ID in path"},"success":false}`))
+		return
+	}
+
+	route := strings.TrimPrefix(r.URL.Path, "/api/v1/approvals/"+approvalID)
+	switch {
+	case (route == "" || route == "/") && r.Method == http.MethodGet:
+		s.approvalHandler.GetApproval(w, r, approvalID)
+	case route == "/approve" && r.Method == http.MethodPost:
+		s.approvalHandler.Approve(w, r, approvalID)
+	case route == "" || route == "/" || route == "/approve":
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// handleBankTransactionsRoute handles GET /api/v1/bank-transactions
+func (s *Server) handleBankTransactionsRoute(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+		return
+	}
+
+	s.cashHandler.ListTransactions(w, r)
+}
+
+// handleBankTransactionWithIDRoute handles individual bank transaction operations
+// (GET /api/v1/bank-transactions/{id}, POST /api/v1/bank-transactions/{id}/reconcile)
+func (s *Server) handleBankTransactionWithIDRoute(w http.ResponseWriter, r *http.Request) {
+	transactionID := extractPathSegment(r.URL.Path, "/api/v1/bank-transactions/")
+	if transactionID == "" {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"code":"INVALID_PATH","message":"Invalid bank transaction ID in path"},"success":false}`))
+		return
+	}
+
+	route := strings.TrimPrefix(r.URL.Path, "/api/v1/bank-transactions/"+transactionID)
+	switch {
+	case (route == "" || route == "/") && r.Method == http.MethodGet:
+		s.cashHandler.GetTransaction(w, r, transactionID)
+	case route == "/reconcile" && r.Method == http.MethodPost:
+		s.cashHandler.Reconcile(w, r, transactionID)
+	case route == "" || route == "/" || route == "/reconcile":
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// handleIPAccessPoliciesRoute handles GET /api/v1/admin/ip-access-policies
+func (s *Server) handleIPAccessPoliciesRoute(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+		return
+	}
+
+	s.accessPolicyHandler.ListPolicies(w, r)
+}
+
+// handleIPAccessPolicyWithTenantRoute handles tenant policy operations (GET, PUT, DELETE /api/v1/admin/ip-access-policies/{tenant})
+func (s *Server) handleIPAccessPolicyWithTenantRoute(w http.ResponseWriter, r *http.Request) {
+	tenantID := extractPathSegment(r.URL.Path, "/api/v1/admin/ip-access-policies/")
+	if tenantID == "" {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"code":"INVALID_PATH","message":"Invalid tenant ID in path"},"success":false}`))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.accessPolicyHandler.GetPolicy(w, r, tenantID)
+	case http.MethodPut:
+		s.accessPolicyHandler.SetPolicy(w, r, tenantID)
+	case http.MethodDelete:
+		s.accessPolicyHandler.DeletePolicy(w, r, tenantID)
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+	}
+}
+
+// handleDunningPoliciesRoute handles GET /api/v1/admin/dunning-policies
+func (s *Server) handleDunningPoliciesRoute(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+		return
+	}
+
+	s.dunningHandler.ListPolicies(w, r)
+}
+
+// handleDunningPolicyWithTenantRoute handles tenant cadence operations (GET, PUT, DELETE /api/v1/admin/dunning-policies/{tenant})
+func (s *Server) handleDunningPolicyWithTenantRoute(w http.ResponseWriter, r *http.Request) {
+	tenantID := extractPathSegment(r.URL.Path, "/api/v1/admin/dunning-policies/")
+	if tenantID == "" {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"code":"INVALID_PATH","message":"Invalid tenant ID in path"},"success":false}`))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.dunningHandler.GetPolicy(w, r, tenantID)
+	case http.MethodPut:
+		s.dunningHandler.SetPolicy(w, r, tenantID)
+	case http.MethodDelete:
+		s.dunningHandler.DeletePolicy(w, r, tenantID)
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+	}
+}
+
+// handleTenantRulesRoute handles GET /api/v1/admin/tenant-rules
+func (s *Server) handleTenantRulesRoute(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+		return
+	}
+
+	s.tenantRuleHandler.ListRules(w, r)
+}
+
+// handleTenantRulesWithTenantRoute handles tenant rule set operations (GET, PUT, DELETE /api/v1/admin/tenant-rules/{tenant})
+func (s *Server) handleTenantRulesWithTenantRoute(w http.ResponseWriter, r *http.Request) {
+	tenantID := extractPathSegment(r.URL.Path, "/api/v1/admin/tenant-rules/")
+	if tenantID == "" {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"code":"INVALID_PATH","message":"Invalid tenant ID in path"},"success":false}`))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.tenantRuleHandler.GetRules(w, r, tenantID)
+	case http.MethodPut:
+		s.tenantRuleHandler.SetRules(w, r, tenantID)
+	case http.MethodDelete:
+		s.tenantRuleHandler.DeleteRules(w, r, tenantID)
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+	}
+}
+
+// handleFiscalCalendarsRoute handles GET /api/v1/admin/fiscal-calendars
+func (s *Server) handleFiscalCalendarsRoute(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+		return
+	}
+
+	s.fiscalCalendarHandler.ListCalendars(w, r)
+}
+
+// handleFiscalCalendarWithTenantRoute handles tenant calendar operations (GET, PUT, DELETE /api/v1/admin/fiscal-calendars/{tenant}),
+// period listing (GET .../{tenant}/periods) and period locks (POST .../{tenant}/periods/{period}/close or /reopen)
+func (s *Server) handleFiscalCalendarWithTenantRoute(w http.ResponseWriter, r *http.Request) {
+	tenantID := extractPathSegment(r.URL.Path, "/api/v1/admin/fiscal-calendars/")
+	if tenantID == "" {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"code":"INVALID_PATH","message":"Invalid tenant ID in path"},"success":false}`))
+		return
+	}
+
+	route := strings.TrimPrefix(r.URL.Path, "/api/v1/admin/fiscal-calendars/"+tenantID)
+	if periodKey := extractPathSegment(route, "/periods/"); periodKey != "" {
+		action := strings.TrimPrefix(route, "/periods/"+periodKey)
+		switch {
+		case action == "/close" && r.Method == http.MethodPost:
+			s.fiscalCalendarHandler.ClosePeriod(w, r, tenantID, periodKey)
+		case action == "/reopen" && r.Method == http.MethodPost:
+			s.fiscalCalendarHandler.ReopenPeriod(w, r, tenantID, periodKey)
+		case action == "/close" || action == "/reopen":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+		default:
+			http.NotFound(w, r)
+		}
+		return
+	}
+
+	switch {
+	case (route == "" || route == "/") && r.Method == http.MethodGet:
+		s.fiscalCalendarHandler.GetCalendar(w, r, tenantID)
+	case (route == "" || route == "/") && r.Method == http.MethodPut:
+		s.fiscalCalendarHandler.SetCalendar(w, r, tenantID)
+	case (route == "" || route == "/") && r.Method == http.MethodDelete:
+		s.fiscalCalendarHandler.DeleteCalendar(w, r, tenantID)
+	case (route == "/periods" || route == "/periods/") && r.Method == http.MethodGet:
+		s.fiscalCalendarHandler.ListPeriods(w, r, tenantID)
+	case route == "" || route == "/" || route == "/periods" || route == "/periods/":
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// handlePayoutReconciliationsRoute handles GET /api/v1/admin/payout-reconciliations
+func (s *Server) handlePayoutReconciliationsRoute(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+		return
+	}
+
+	s.payoutHandler.ListReports(w, r)
+}
+
+// handlePayoutReconciliationWithIDRoute handles GET /api/v1/admin/payout-reconciliations/{payoutID}
+func (s *Server) handlePayoutReconciliationWithIDRoute(w http.ResponseWriter, r *http.Request) {
+	payoutID := extractPathSegment(r.URL.Path, "/api/v1/admin/payout-reconciliations/")
+	if payoutID == "" {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"code":"INVALID_PATH","message":"Invalid payout ID in path"},"success":false}`))
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+		return
+	}
+
+	s.payoutHandler.GetReport(w, r, payoutID)
+}
+
+// handleWebhookSubscriptionsRoute routes webhook subscription collection requests (GET, POST /api/v1/webhooks)
+func (s *Server) handleWebhookSubscriptionsRoute(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		s.webhookSubscriptionHandler.CreateSubscription(w, r)
+	case http.MethodGet:
+		s.webhookSubscriptionHandler.ListSubscriptions(w, r)
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+	}
+}
+
+// handleWebhookRoute routes inbound deliveries (POST /api/v1/webhooks/{provider}) and webhook subscription requests
+// (GET, DELETE /api/v1/webhooks/{id}, POST /api/v1/webhooks/{id}/replay)
+func (s *Server) handleWebhookRoute(w http.ResponseWriter, r *http.Request) {
+	segment := extractPathSegment(r.URL.Path, "/api/v1/webhooks/")
+	if segment == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	route := strings.TrimPrefix(r.URL.Path, "/api/v1/webhooks/"+segment)
+	subscriptions := s.webhookSubscriptionHandler != nil
+	switch {
+	case route == "" && r.Method == http.MethodPost && s.webhookHandler != nil:
+		s.webhookHandler.Receive(w, r, segment)
+	case route == "" && r.Method == http.MethodGet && subscriptions:
+		s.webhookSubscriptionHandler.GetSubscription(w, r, segment)
+	case route == "" && r.Method == http.MethodDelete && subscriptions:
+		s.webhookSubscriptionHandler.DeleteSubscription(w, r, segment)
+	case route == "/replay" && r.Method == http.MethodPost && subscriptions:
+		s.webhookSubscriptionHandler.ReplayEvents(w, r, segment)
+	case route == "" || route == "/replay" && subscriptions:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// handleWebhookEventWithIDRoute routes webhook event requests (GET /api/v1/admin/webhooks/events/{id}, POST .../retry)
+func (s *Server) handleWebhookEventWithIDRoute(w http.ResponseWriter, r *http.Request) {
+	eventID := extractPathSegment(r.URL.Path, "/api/v1/admin/webhooks/events/")
+	if eventID == "" {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"code":"INVALID_PATH","message":"Invalid webhook event ID in path"},"success":false}`))
+		return
+	}
+
+	route := strings.TrimPrefix(r.URL.Path, "/api/v1/admin/webhooks/events/"+eventID)
+	switch {
+	case (route == "" || route == "/") && r.Method == http.MethodGet:
+		s.webhookHandler.GetEvent(w, r, eventID)
+	case route == "/retry" && r.Method == http.MethodPost:
+		s.webhookHandler.Retry(w, r, eventID)
+	case route == "" || route == "/" || route == "/retry":
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// handleDeadLetterWithIDRoute routes dead letter requests (GET /api/v1/admin/dead-letters/{id})
+func (s *Server) handleDeadLetterWithIDRoute(w http.ResponseWriter, r *http.Request) {
+	letterID := extractPathSegment(r.URL.Path, "/api/v1/admin/dead-letters/")
+	if letterID == "" {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"code":"INVALID_PATH","message":"Invalid dead letter ID in path"},"success":false}`))
+		return
+	}
+
+	route := strings.TrimPrefix(r.URL.Path, "/api/v1/admin/dead-letters/"+letterID)
+	switch {
+	case (route == "" || route == "/") && r.Method == http.MethodGet:
+		s.deadLetterHandler.GetDeadLetter(w, r, letterID)
+	case route == "" || route == "/":
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// handleOutboundEmailWithIDRoute routes outbox email requests (GET /api/v1/admin/email-outbox/{id},
+// POST /api/v1/admin/email-outbox/{id}/retry)
+func (s *Server) handleOutboundEmailWithIDRoute(w http.ResponseWriter, r *http.Request) {
+	emailID := extractPathSegment(r.URL.Path, "/api/v1/admin/email-outbox/")
+	if emailID == "" {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"code":"INVALID_PATH","message":"Invalid email ID in path"},"success":false}`))
+		return
+	}
+
+	route := strings.TrimPrefix(r.URL.Path, "/api/v1/admin/email-outbox/"+emailID)
+	switch {
+	case (route == "" || route == "/") && r.Method == http.MethodGet:
+		s.emailOutboxHandler.GetEmail(w, r, emailID)
+	case route == "/retry" && r.Method == http.MethodPost:
+		s.emailOutboxHandler.RetryEmail(w, r, emailID)
+	case route == "" || route == "/" || route == "/retry":
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// handleEmailSuppressionsRoute routes suppression list requests (GET, POST /api/v1/admin/email-suppressions)
+func (s *Server) handleEmailSuppressionsRoute(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.emailOutboxHandler.ListSuppressions(w, r)
+	case http.MethodPost:
+		s.emailOutboxHandler.Suppress(w, r)
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+	}
+}
+
+// handleEmailSuppressionWithAddressRoute routes suppressed address requests (DELETE /api/v1/admin/email-suppressions/{email})
+func (s *Server) handleEmailSuppressionWithAddressRoute(w http.ResponseWriter, r *http.Request) {
+	address := extractPathSegment(r.URL.Path, "/api/v1/admin/email-suppressions/")
+	if address == "" {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"code":"INVALID_PATH","message":"Invalid email address in path"},"success":false}`))
+		return
+	}
+
+	route := strings.TrimPrefix(r.URL.Path, "/api/v1/admin/email-suppressions/"+address)
+	switch {
+	case (route == "" || route == "/") && r.Method == http.MethodDelete:
+		s.emailOutboxHandler.Unsuppress(w, r, address)
+	case route == "" || route == "/":
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// handleIntegrationLogWithIDRoute routes integration log requests (GET /api/v1/admin/integration-logs/{id})
+func (s *Server) handleIntegrationLogWithIDRoute(w http.ResponseWriter, r *http.Request) {
+	logID := extractPathSegment(r.URL.Path, "/api/v1/admin/integration-logs/")
+	if logID == "" {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"code":"INVALID_PATH","message":"Invalid integration log ID in path"},"success":false}`))
+		return
+	}
+
+	route := strings.TrimPrefix(r.URL.Path, "/api/v1/admin/integration-logs/"+logID)
+	switch {
+	case (route == "" || route == "/") && r.Method == http.MethodGet:
+		s.integrationLogHandler.GetLog(w, r, logID)
+	case route == "" || route == "/":
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// handleSagaWithIDRoute routes saga requests (GET /api/v1/admin/sagas/{id}, POST /api/v1/admin/sagas/{id}/resume)
+func (s *Server) handleSagaWithIDRoute(w http.ResponseWriter, r *http.Request) {
+	sagaID := extractPathSegment(r.URL.Path, "/api/v1/admin/sagas/")
+	if sagaID == "" {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"code":"INVALID_PATH","message":"Invalid saga ID in path"},"success":false}`))
+		return
+	}
+
+	route := strings.TrimPrefix(r.URL.Path, "/api/v1/admin/sagas/"+sagaID)
+	switch {
+	case (route == "" || route == "/") && r.Method == http.MethodGet:
+		s.sagaHandler.GetSaga(w, r, sagaID)
+	case route == "/resume" && r.Method == http.MethodPost:
+		s.sagaHandler.Resume(w, r, sagaID)
+	case route == "" || route == "/" || route == "/resume":
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// handleInvoiceArchiveWithIDRoute routes archived invoice requests (GET /api/v1/admin/invoice-archive/{id},
+// POST /api/v1/admin/invoice-archive/{id}/rehydrate)
+func (s *Server) handleInvoiceArchiveWithIDRoute(w http.ResponseWriter, r *http.Request) {
+	invoiceID := extractPathSegment(r.URL.Path, "/api/v1/admin/invoice-archive/")
+	if invoiceID == "" {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"code":"INVALID_PATH","message":"Invalid invoice ID in path"},"success":false}`))
+		return
+	}
+
+	route := strings.TrimPrefix(r.URL.Path, "/api/v1/admin/invoice-archive/"+invoiceID)
+	switch {
+	case (route == "" || route == "/") && r.Method == http.MethodGet:
+		s.invoiceArchiveHandler.GetArchived(w, r, invoiceID)
+	case route == "/rehydrate" && r.Method == http.MethodPost:
+		s.invoiceArchiveHandler.Rehydrate(w, r, invoiceID)
+	case route == "" || route == "/" || route == "/rehydrate":
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// handleUploadsRoute handles POST /api/v1/uploads
+func (s *Server) handleUploadsRoute(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+		return
+	}
+
+	s.uploadHandler.CreateUpload(w, r)
+}
+
+// handleUploadWithIDRoute routes upload requests (GET, HEAD, PATCH /api/v1/uploads/{id})
+func (s *Server) handleUploadWithIDRoute(w http.ResponseWriter, r *http.Request) {
+	uploadID := extractPathSegment(r.URL.Path, "/api/v1/uploads/")
+	if uploadID == "" || strings.TrimPrefix(r.URL.Path, "/api/v1/uploads/"+uploadID) != "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.uploadHandler.GetUpload(w, r, uploadID)
+	case http.MethodHead:
+		s.uploadHandler.UploadOffset(w, r, uploadID)
+	case http.MethodPatch:
+		s.uploadHandler.AppendChunk(w, r, uploadID)
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+	}
+}
+
+// handleLegalEntitiesRoute routes legal entity collection requests (GET, POST /api/v1/admin/legal-entities)
+func (s *Server) handleLegalEntitiesRoute(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.legalEntityHandler.ListEntities(w, r)
+	case http.MethodPost:
+		s.legalEntityHandler.CreateEntity(w, r)
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+	}
+}
+
+// handleLegalEntityWithIDRoute handles legal entity operations (GET, PUT, DELETE /api/v1/admin/legal-entities/{id})
+func (s *Server) handleLegalEntityWithIDRoute(w http.ResponseWriter, r *http.Request) {
+	entityID := extractPathSegment(r.URL.Path, "/api/v1/admin/legal-entities/")
+	if entityID == "" {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"code":"INVALID_PATH","message":"Invalid legal entity ID in path"},"success":false}`))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.legalEntityHandler.GetEntity(w, r, entityID)
+	case http.MethodPut:
+		s.legalEntityHandler.UpdateEntity(w, r, entityID)
+	case http.MethodDelete:
+		s.legalEntityHandler.DeleteEntity(w, r, entityID)
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+	}
+}
+
+// handleDocumentTemplatesRoute handles GET /api/v1/admin/document-templates
+func (s *Server) handleDocumentTemplatesRoute(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+		return
+	}
+
+	s.documentHandler.ListTemplates(w, r)
+}
+
+// handleDocumentTemplateWithTenantRoute handles tenant layout operations
+// (GET, PUT, DELETE /api/v1/admin/document-templates/{tenant}, GET, POST /api/v1/admin/document-templates/{tenant}/preview)
+func (s *Server) handleDocumentTemplateWithTenantRoute(w http.ResponseWriter, r *http.Request) {
+	tenantID := extractPathSegment(r.URL.Path, "/api/v1/admin/document-templates/")
+	if tenantID == "" {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"code":"INVALID_PATH","message":"Invalid tenant ID in path"},"success":false}`))
+		return
+	}
+
+	route := strings.TrimPrefix(r.URL.Path, "/api/v1/admin/document-templates/"+tenantID)
+	switch {
+	case (route == "" || route == "/") && r.Method == http.MethodGet:
+		s.documentHandler.GetTemplate(w, r, tenantID)
+	case (route == "" || route == "/") && r.Method == http.MethodPut:
+		s.documentHandler.SetTemplate(w, r, tenantID)
+	case (route == "" || route == "/") && r.Method == http.MethodDelete:
+		s.documentHandler.DeleteTemplate(w, r, tenantID)
+	case route == "/preview" && (r.Method == http.MethodGet || r.Method == http.MethodPost):
+		s.documentHandler.Preview(w, r, tenantID)
+	case route == "" || route == "/" || route == "/preview":
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// handlePortalTokenRoute handles POST /api/v1/admin/portal-tokens/{clientID}
+func (s *Server) handlePortalTokenRoute(w http.ResponseWriter, r *http.Request) {
+	clientID := extractPathSegment(r.URL.Path, "/api/v1/admin/portal-tokens/")
+	if clientID == "" {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"code":"INVALID_PATH","message":"Invalid client ID in path"},"success":false}`))
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+		return
+	}
+
+	s.portalHandler.IssueAccessToken(w, r, clientID)
+}
+
+// handlePortalLinkRoute handles POST /api/v1/admin/portal-links/{clientID}
+func (s *Server) handlePortalLinkRoute(w http.ResponseWriter, r *http.Request) {
+	clientID := extractPathSegment(r.URL.Path, "/api/v1/admin/portal-links/")
+	if clientID == "" {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"code":"INVALID_PATH","message":"Invalid client ID in path"},"success":false}`))
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+		return
+	}
+
+	s.portalHandler.IssueSignInLink(w, r, clientID)
+}
+
+// handlePortalSessionRoute handles POST /portal/v1/session (the sign-in link is consumed only for POST)
+func (s *Server) handlePortalSessionRoute(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+		return
+	}
+
+	s.portalSession.ServeHTTP(w, r)
+}
+
+// handlePortalProfileRoute handles GET /portal/v1/me
+func (s *Server) handlePortalProfileRoute(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+		return
+	}
+
+	s.portalHandler.GetProfile(w, r)
+}
+
+// handlePortalContactRoute handles PUT /portal/v1/me/contact
+func (s *Server) handlePortalContactRoute(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+		return
+	}
+
+	s.portalHandler.UpdateContactInfo(w, r)
+}
+
+// handlePortalInvoicesRoute handles GET /portal/v1/me/invoices
+func (s *Server) handlePortalInvoicesRoute(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+		return
+	}
+
+	s.portalHandler.ListInvoices(w, r)
+}
+
+// handlePortalInvoiceRoute handles GET /portal/v1/me/invoices/{id} and GET /portal/v1/me/invoices/{id}/document
+func (s *Server) handlePortalInvoiceRoute(w http.ResponseWriter, r *http.Request) {
+	invoiceID := extractPathSegment(r.URL.Path, middleware.PortalRoutePrefix+"/me/invoices/")
+	if invoiceID == "" {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"code":"INVALID_PATH","message":"Invalid invoice ID in path"},"success":false}`))
+		return
+	}
+
+	route := strings.TrimPrefix(r.URL.Path, middleware.PortalRoutePrefix+"/me/invoices/"+invoiceID)
+	if route != "" && route != "/document" {
+		http.NotFound(w, r)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"error":{"code":"METHOD_NOT_ALLOWED","message":"Method not allowed"},"success":false}`))
+		return
+	}
+
+	if route == "/document" {
+		s.portalHandler.GetInvoiceDocument(w, r, invoiceID)
+		return
+	}
+	s.portalHandler.GetInvoice(w, r, invoiceID)
+}
+
+// extractClientIDFromPath extracts the client ID from URL path like /api/v1/clients/{id}
+func extractClientIDFromPath(path string) string {
+	// Expected path format: /api/v1/clients/{id}
+	return extractPathSegment(path, "/api/v1/clients/")
+}
+
+// extractPathSegment extracts the path segment directly following prefix
+func extractPathSegment(path, prefix string) string {
+	if !strings.HasPrefix(path, prefix) {
+		return ""
+	}
+
+	// Extract the part after the prefix
+	segment := strings.TrimPrefix(path, prefix)
+
+	// Remove any trailing slash or path segments
+	if slashIndex := strings.Index(segment, "/"); slashIndex != -1 {
+		segment = segment[:slashIndex]
+	}
+
+	// Basic validation - not empty
+	if strings.TrimSpace(segment) == "" {
+		return ""
+	}
+
+	return segment
+}
+
+// RoutePolicy returns the authorization policy of a request and whether the route table declares it explicitly
+func (s *Server) RoutePolicy(method, path string) (middleware.RoutePolicy, bool) {
+	return s.authorizer.Policy(method, path)
+}
+
+// Handler returns the configured HTTP handler
+// With a separate admin listener it serves the public API only, operational routes being served by AdminHandler
+func (s *Server) Handler() http.Handler {
+	if s.separateAdmin {
+		return restrictRoutes(s.SetupRoutes(), false)
+	}
 	return s.SetupRoutes()
 }
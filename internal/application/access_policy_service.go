@@ -0,0 +1,111 @@
+package application
+
+import (
+	"net/netip"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+)
+
+// ipAccessPolicyResource is the audit resource type for tenant IP access policies
+const ipAccessPolicyResource = "ip_access_policy"
+
+// AccessPolicyService manages tenant IP access policies and evaluates them for incoming requests
+type AccessPolicyService struct {
+	policyRepo   repository.IPAccessPolicyRepository
+	auditService *AuditService
+}
+
+// NewAccessPolicyService creates a new access policy service
+func NewAccessPolicyService(policyRepo repository.IPAccessPolicyRepository, auditService *AuditService) *AccessPolicyService {
+	return &AccessPolicyService{
+		policyRepo:   policyRepo,
+		auditService: auditService,
+	}
+}
+
+// GetPolicy retrieves the IP access policy of a tenant
+func (s *AccessPolicyService) GetPolicy(tenantID string) (*entity.IPAccessPolicy, error) {
+	return s.policyRepo.GetByTenantID(tenantID)
+}
+
+// ListPolicies retrieves all tenant IP access policies
+func (s *AccessPolicyService) ListPolicies() ([]*entity.IPAccessPolicy, error) {
+	return s.policyRepo.GetAll()
+}
+
+// SetPolicy replaces the IP access policy of a tenant and records the change in the audit log
+func (s *AccessPolicyService) SetPolicy(actor, tenantID string, req dtos.SetIPAccessPolicyRequest) (*entity.IPAccessPolicy, error) {
+	if len(req.Rules) == 0 {
+		return nil, errors.NewValidationError("rules", "", errors.ValidationRequired, "at least one rule is required")
+	}
+
+	rules := make([]entity.IPAccessRule, 0, len(req.Rules))
+	for _, ruleReq := range req.Rules {
+		rule, err := entity.NewIPAccessRule(ruleReq.RoutePrefix, ruleReq.Allow, ruleReq.Deny)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	policy, err := entity.NewIPAccessPolicy(tenantID, rules)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the previous policy (if any) for the audit trail
+	previous, err := s.policyRepo.GetByTenantID(policy.TenantID())
+	if err != nil && errors.GetErrorCode(err) != errors.RepositoryNotFound {
+		return nil, err
+	}
+
+	if err := s.policyRepo.Save(policy); err != nil {
+		return nil, err
+	}
+
+	details := map[string]interface{}{"after": policy}
+	if previous != nil {
+		details["before"] = previous
+	}
+	if err := s.auditService.Record(AuditActionIPAccessPolicySet, actor, policy.TenantID(), ipAccessPolicyResource, policy.TenantID(), details); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// DeletePolicy removes the IP access policy of a tenant and records the change in the audit log
+func (s *AccessPolicyService) DeletePolicy(actor, tenantID string) error {
+	previous, err := s.policyRepo.GetByTenantID(tenantID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.policyRepo.Delete(tenantID); err != nil {
+		return err
+	}
+
+	details := map[string]interface{}{"before": previous}
+	return s.auditService.Record(AuditActionIPAccessPolicyDeleted, actor, tenantID, ipAccessPolicyResource, tenantID, details)
+}
+
+// IsAllowed checks if a request from addr to path is permitted for the tenant
+// Tenants without a policy are unrestricted; tenants with a policy refuse unknown (zero) addresses
+func (s *AccessPolicyService) IsAllowed(tenantID, path string, addr netip.Addr) (bool, error) {
+	if tenantID == "" {
+		return true, nil
+	}
+
+	policy, err := s.policyRepo.GetByTenantID(tenantID)
+	if err != nil {
+		if errors.GetErrorCode(err) == errors.RepositoryNotFound {
+			return true, nil
+		}
+		return false, err
+	}
+
+	return addr.IsValid() && policy.Permits(path, addr), nil
+}
@@ -0,0 +1,163 @@
+package application
+
+import (
+	"strings"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+)
+
+// approvalRequestResource is the audit resource type for credit note, refund and credit limit override approvals
+const approvalRequestResource = "approval_request"
+
+// ApprovalService runs the two-step approval of high-value credit notes and refunds, and of credit limit overrides
+type ApprovalService struct {
+	approvalRepo repository.ApprovalRequestRepository
+	auditService *AuditService
+	threshold    int64
+	approvers    map[string]bool
+}
+
+// NewApprovalService creates a new approval service
+// Requests above threshold (minor units) must be approved by one of approvers, who cannot be the requester
+func NewApprovalService(approvalRepo repository.ApprovalRequestRepository, auditService *AuditService, threshold int64, approvers []string) *ApprovalService {
+	approverSet := make(map[string]bool, len(approvers))
+	for _, approver := range approvers {
+		if approver = strings.TrimSpace(approver); approver != "" {
+			approverSet[approver] = true
+		}
+	}
+
+	return &ApprovalService{
+		approvalRepo: approvalRepo,
+		auditService: auditService,
+		threshold:    threshold,
+		approvers:    approverSet,
+	}
+}
+
+// RequestApproval submits a credit note or refund and records it in the audit log
+// Amounts up to the threshold are approved immediately
+func (s *ApprovalService) RequestApproval(actor string, req dtos.CreateApprovalRequest) (*entity.ApprovalRequest, error) {
+	// Overrides are raised by the operation they unblock, never submitted directly
+	if entity.ApprovalKind(req.Kind) == entity.ApprovalKindCreditLimitOverride {
+		return nil, errors.NewValidationError("kind", req.Kind, errors.ValidationFormat, "kind must be one of: credit_note, refund")
+	}
+
+	amount, err := valueobject.NewMoney(req.Amount, req.Currency)
+	if err != nil {
+		return nil, err
+	}
+	return s.submit(actor, entity.ApprovalKind(req.Kind), req.ClientID, req.ReferenceID, amount, req.Reason, s.threshold)
+}
+
+// RequestOverride holds an operation for an approver whatever its amount and records it in the audit log
+func (s *ApprovalService) RequestOverride(actor string, kind entity.ApprovalKind, clientID, referenceID string, amount valueobject.Money, reason string) (*entity.ApprovalRequest, error) {
+	return s.submit(actor, kind, clientID, referenceID, amount, reason, 0)
+}
+
+// FindRequest retrieves the latest approval request of a kind for a reference
+func (s *ApprovalService) FindRequest(kind entity.ApprovalKind, referenceID string) (*entity.ApprovalRequest, error) {
+	requests, err := s.approvalRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(requests) - 1; i >= 0; i-- {
+		if requests[i].Kind() == kind && requests[i].ReferenceID() == referenceID {
+			return requests[i], nil
+		}
+	}
+	return nil, errors.ErrApprovalRequestNotFound
+}
+
+// submit creates an approval request, approved immediately when amount is up to threshold
+func (s *ApprovalService) submit(actor string, kind entity.ApprovalKind, clientID, referenceID string, amount valueobject.Money, reason string, threshold int64) (*entity.ApprovalRequest, error) {
+	request, err := entity.NewApprovalRequest(kind, clientID, referenceID, amount, reason, actor, threshold)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.approvalRepo.Save(request); err != nil {
+		return nil, err
+	}
+
+	details := map[string]interface{}{
+		"kind":         request.Kind(),
+		"client_id":    request.ClientID(),
+		"reference_id": request.ReferenceID(),
+		"amount":       request.Amount().Amount(),
+		"currency":     request.Amount().Currency(),
+		"status":       request.Status(),
+	}
+	if err := s.auditService.Record(AuditActionApprovalRequested, actor, "", approvalRequestResource, request.ID(), details); err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+// Approve confirms a pending request on behalf of an approver and records it in the audit log
+func (s *ApprovalService) Approve(actor, id string) (*entity.ApprovalRequest, error) {
+	if !s.IsApprover(actor) {
+		return nil, errors.ErrApproverRoleRequired
+	}
+
+	request, err := s.approvalRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := request.Approve(actor); err != nil {
+		return nil, err
+	}
+
+	if err := s.approvalRepo.Save(request); err != nil {
+		return nil, err
+	}
+
+	details := map[string]interface{}{
+		"kind":         request.Kind(),
+		"requested_by": request.RequestedBy(),
+		"amount":       request.Amount().Amount(),
+		"currency":     request.Amount().Currency(),
+	}
+	if err := s.auditService.Record(AuditActionApprovalApproved, actor, "", approvalRequestResource, request.ID(), details); err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+// IsApprover checks if an actor holds the approver role
+func (s *ApprovalService) IsApprover(actor string) bool {
+	return s.approvers[actor]
+}
+
+// GetRequest retrieves an approval request by its ID
+func (s *ApprovalService) GetRequest(id string) (*entity.ApprovalRequest, error) {
+	return s.approvalRepo.GetByID(id)
+}
+
+// ListRequests retrieves approval requests, oldest first, optionally filtered by status
+func (s *ApprovalService) ListRequests(status string) ([]*entity.ApprovalRequest, error) {
+	requests, err := s.approvalRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if status == "" {
+		return requests, nil
+	}
+
+	filtered := make([]*entity.ApprovalRequest, 0, len(requests))
+	for _, request := range requests {
+		if string(request.Status()) == status {
+			filtered = append(filtered, request)
+		}
+	}
+	return filtered, nil
+}
@@ -0,0 +1,95 @@
+package application
+
+import (
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+)
+
+// Audit actions recorded by application services
+const (
+	AuditActionIPAccessPolicySet         = "ip_access_policy.set"
+	AuditActionIPAccessPolicyDeleted     = "ip_access_policy.deleted"
+	AuditActionApprovalRequested         = "approval.requested"
+	AuditActionApprovalApproved          = "approval.approved"
+	AuditActionDunningPolicySet          = "dunning_policy.set"
+	AuditActionDunningPolicyDeleted      = "dunning_policy.deleted"
+	AuditActionBankTransactionReconciled = "bank_transaction.reconciled"
+	AuditActionLegalEntityCreated        = "legal_entity.created"
+	AuditActionLegalEntityUpdated        = "legal_entity.updated"
+	AuditActionLegalEntityDeleted        = "legal_entity.deleted"
+	AuditActionDocumentTemplateSet       = "document_template.set"
+	AuditActionDocumentTemplateDeleted   = "document_template.deleted"
+	AuditActionCreditLimitSet            = "credit_limit.set"
+	AuditActionCreditLimitDeleted        = "credit_limit.deleted"
+	AuditActionSandboxWiped              = "sandbox.wiped"
+	AuditActionWebhookEventRetried       = "webhook_event.retried"
+	AuditActionDeadLetterRetried         = "dead_letter.retried"
+	AuditActionDeadLetterDiscarded       = "dead_letter.discarded"
+	AuditActionSagaResumed               = "saga.resumed"
+	AuditActionFiscalCalendarSet         = "fiscal_calendar.set"
+	AuditActionFiscalCalendarDeleted     = "fiscal_calendar.deleted"
+	AuditActionFiscalPeriodClosed        = "fiscal_period.closed"
+	AuditActionFiscalPeriodReopened      = "fiscal_period.reopened"
+	AuditActionClientCreated             = "client.created"
+	AuditActionClientUpdated             = "client.updated"
+	AuditActionClientDeleted             = "client.deleted"
+	AuditActionInvoiceIssued             = "invoice.issued"
+	AuditActionLateFeeCharged            = "invoice.late_fee_charged"
+	AuditActionTenantRulesSet            = "tenant_rules.set"
+	AuditActionTenantRulesDeleted        = "tenant_rules.deleted"
+	AuditActionWebhookSubscribed         = "webhook_subscription.created"
+	AuditActionWebhookUnsubscribed       = "webhook_subscription.deleted"
+	AuditActionWebhookEventsReplayed     = "webhook_subscription.replayed"
+)
+
+// AuditService records and exposes the audit log
+type AuditService struct {
+	auditRepo repository.AuditRepository
+}
+
+// NewAuditService creates a new audit service
+func NewAuditService(auditRepo repository.AuditRepository) *AuditService {
+	return &AuditService{
+		auditRepo: auditRepo,
+	}
+}
+
+// Record appends an audit entry for an action performed by actor
+func (s *AuditService) Record(action, actor, tenantID, resourceType, resourceID string, details map[string]interface{}) error {
+	entry := entity.NewAuditEntry(action, actor, tenantID, resourceType, resourceID, details)
+	return s.auditRepo.Append(entry)
+}
+
+// ListEntries retrieves audit entries, most recent first, optionally filtered by tenant
+func (s *AuditService) ListEntries(tenantID string) ([]*entity.AuditEntry, error) {
+	return s.ListEntriesSince(tenantID, time.Time{})
+}
+
+// ListEntriesSince retrieves the audit entries that occurred at or after since (zero for all), most recent first,
+// optionally filtered by tenant; bounding the time reads only the recent partitions of the audit log
+func (s *AuditService) ListEntriesSince(tenantID string, since time.Time) ([]*entity.AuditEntry, error) {
+	var entries []*entity.AuditEntry
+	var err error
+	if since.IsZero() {
+		entries, err = s.auditRepo.GetAll()
+	} else {
+		entries, err = s.auditRepo.ListSince(since)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if tenantID == "" {
+		return entries, nil
+	}
+
+	filtered := make([]*entity.AuditEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.TenantID() == tenantID {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered, nil
+}
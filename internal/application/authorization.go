@@ -0,0 +1,98 @@
+package application
+
+import (
+	"slices"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+)
+
+// Admin scopes granted to admin principals
+// They partition the back office: an admin restricted to some scopes only holds the permissions requiring them
+const (
+	// ScopeFinance covers money movements: payments, credit limits, bank reconciliation, approvals and fiscal periods
+	// Admins holding it are the billing admins
+	ScopeFinance = "finance"
+	// ScopeTenants covers the per-tenant configuration: IP access, dunning cadences, document templates and webhook
+	// subscriptions
+	ScopeTenants = "tenants"
+	// ScopeSupport covers customer support: portal access, email suppressions and invoice delivery
+	ScopeSupport = "support"
+	// ScopeOperations covers the scheduler jobs and the operational tooling (queues, sagas, logs, dashboards)
+	ScopeOperations = "operations"
+)
+
+// Permission is an operation of the application services that only some principals may perform
+// Services check it themselves (RequestContext.Authorize), so every entry point gets the same protection
+type Permission string
+
+const (
+	// PermissionManageClients creates clients and changes their details, status and tags
+	PermissionManageClients Permission = "clients:write"
+	// PermissionDeleteClient deletes a client and the data attached to it
+	PermissionDeleteClient Permission = "clients:delete"
+	// PermissionReadInvoices reads invoices
+	PermissionReadInvoices Permission = "invoices:read"
+	// PermissionManageInvoices creates, changes, issues, voids and deletes invoices
+	PermissionManageInvoices Permission = "invoices:write"
+	// PermissionRecordPayment records a payment received against an invoice
+	PermissionRecordPayment Permission = "payments:record"
+	// PermissionReadContracts reads contracts and their commitment attainment
+	PermissionReadContracts Permission = "contracts:read"
+	// PermissionManageContracts creates contracts and links subscriptions and invoices to them
+	PermissionManageContracts Permission = "contracts:write"
+	// PermissionReadQuotes reads quotes
+	PermissionReadQuotes Permission = "quotes:read"
+	// PermissionManageQuotes offers quotes to clients and records their answers
+	PermissionManageQuotes Permission = "quotes:write"
+	// PermissionReadSubscriptions reads subscriptions
+	PermissionReadSubscriptions Permission = "subscriptions:read"
+	// PermissionManageSubscriptions creates subscriptions, changes their plan and moves them through their lifecycle
+	PermissionManageSubscriptions Permission = "subscriptions:write"
+	// PermissionRecordUsage reports the metered usage of subscriptions
+	PermissionRecordUsage Permission = "usage:write"
+	// PermissionManageWebhooks subscribes consumer endpoints to events and replays events to them
+	PermissionManageWebhooks Permission = "webhooks:write"
+)
+
+// permissionScopes maps each permission to the admin scope it requires ("": any admin)
+var permissionScopes = map[Permission]string{
+	PermissionManageClients:       ScopeFinance,
+	PermissionDeleteClient:        ScopeFinance,
+	PermissionReadInvoices:        "",
+	PermissionManageInvoices:      ScopeFinance,
+	PermissionRecordPayment:       ScopeFinance,
+	PermissionReadContracts:       "",
+	PermissionManageContracts:     ScopeFinance,
+	PermissionReadQuotes:          "",
+	PermissionManageQuotes:        ScopeFinance,
+	PermissionReadSubscriptions:   "",
+	PermissionManageSubscriptions: ScopeFinance,
+	PermissionRecordUsage:         "",
+	PermissionManageWebhooks:      ScopeTenants,
+}
+
+// Authorize checks that the caller may perform an operation
+// System principals hold every permission; admins hold the permissions of their scopes; anonymous and portal
+// callers hold none
+func (rc RequestContext) Authorize(permission Permission) error {
+	switch {
+	case rc.Principal.Kind == PrincipalSystem:
+		return nil
+	case !rc.IsAdmin():
+		return errors.NewAuthorizationError(string(permission), errors.AuthorizationRequired,
+			"Admin credentials are required")
+	case !rc.Principal.HoldsScope(permissionScopes[permission]):
+		return errors.NewAuthorizationError(string(permission), errors.AuthorizationScope,
+			"Admin credentials do not grant the "+permissionScopes[permission]+" scope")
+	}
+	return nil
+}
+
+// HoldsScope checks if the principal holds an admin scope
+// Admins without a scope restriction hold every scope; other principals hold none
+func (p Principal) HoldsScope(scope string) bool {
+	if p.Kind != PrincipalAdmin {
+		return false
+	}
+	return scope == "" || p.Scopes == nil || slices.Contains(p.Scopes, scope)
+}
@@ -0,0 +1,869 @@
+package application
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+)
+
+// InvoiceFilter narrows an invoice listing (empty fields match every invoice)
+type InvoiceFilter struct {
+	TenantID      string
+	ClientID      string
+	Status        entity.InvoiceStatus
+	ExcludeDrafts bool // Only invoices issued to the client (issued, paid or void)
+}
+
+// InvoiceCursorPage is a page of an invoice listing paged by cursor
+type InvoiceCursorPage struct {
+	Invoices []*entity.Invoice
+	Limit    int
+	// NextCursor is passed back as "cursor" to continue after the last returned invoice
+	NextCursor string
+	HasMore    bool
+}
+
+// CreateInvoice creates a draft invoice of the caller's tenant for a client of the tenant
+// Only billing admins may create, change, issue, void or delete invoices (PermissionManageInvoices)
+// Plugin pricing hooks price its lines before it is saved
+// With duplicate detection, an invoice duplicating another invoice of the client is rejected; with external
+// references, its reference must be unique in the tenant
+func (s *BillingService) CreateInvoice(rc RequestContext, req dtos.CreateInvoiceRequest) (*entity.Invoice, error) {
+	if err := rc.Authorize(PermissionManageInvoices); err != nil {
+		return nil, err
+	}
+	invoice, err := s.draftInvoice(rc, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.saveDraft(invoice); err != nil {
+		return nil, err
+	}
+	return invoice, nil
+}
+
+// draftInvoice builds a draft invoice from a request and runs the checks of CreateInvoice on it, without saving it
+func (s *BillingService) draftInvoice(rc RequestContext, req dtos.CreateInvoiceRequest) (*entity.Invoice, error) {
+	if err := s.requireInvoices(); err != nil {
+		return nil, err
+	}
+
+	pricing, err := valueobject.ParseTaxPricing(req.TaxPricing)
+	if err != nil {
+		return nil, err
+	}
+	terms, err := valueobject.ParsePaymentTerms(req.PaymentTerms)
+	if err != nil {
+		return nil, err
+	}
+	lines, err := s.toInvoiceLines(req.TaxJurisdiction, req.LineItems)
+	if err != nil {
+		return nil, err
+	}
+	invoice, err := entity.NewInvoice(req.ClientID, req.Currency, lines, req.DueDate)
+	if err != nil {
+		return nil, err
+	}
+	if err := invoice.SetTaxTerms(pricing, req.TaxJurisdiction); err != nil {
+		return nil, err
+	}
+	invoice.AssignTenant(rc.TenantID)
+	if err := invoice.SetExternalReference(req.ExternalRef); err != nil {
+		return nil, err
+	}
+	if err := s.setInvoiceDetails(invoice, req.LegalEntityID, req.BuyerCountry, req.BuyerTaxID, req.Installments); err != nil {
+		return nil, err
+	}
+
+	client, err := s.GetOwnedClient(rc, invoice.ClientID())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.rules.Check(RuleSubject{Operation: RuleCreateInvoice, Context: rc, Client: client, Invoice: invoice}); err != nil {
+		return nil, err
+	}
+	if err := invoice.SetPaymentTerms(invoicePaymentTerms(terms, client)); err != nil {
+		return nil, err
+	}
+	if err := s.priceInvoice(invoice, client); err != nil {
+		return nil, err
+	}
+	if err := s.checkDuplicateInvoice(invoice, req.AllowDuplicate); err != nil {
+		return nil, err
+	}
+	return invoice, nil
+}
+
+// saveDraft claims the external reference of a new draft and saves it
+func (s *BillingService) saveDraft(invoice *entity.Invoice) error {
+	if s.references != nil {
+		if err := s.references.Claim(entity.ExternalReferenceInvoice, invoice.TenantID(), invoice.ExternalReference(), invoice.ID()); err != nil {
+			return err
+		}
+	}
+	if err := s.invoices.Save(invoice); err != nil {
+		s.releaseInvoiceReference(invoice)
+		return err
+	}
+	return nil
+}
+
+// setInvoiceDetails assigns a draft to an existing legal entity (empty for the default entity) and sets the tax
+// details of its buyer and the installments its balance is split into
+func (s *BillingService) setInvoiceDetails(invoice *entity.Invoice, legalEntityID, buyerCountry, buyerTaxID string, installments *dtos.InstallmentsRequest) error {
+	if legalEntityID != "" {
+		// Without legal entity administration no entity can exist
+		if s.entities == nil {
+			return errors.ErrLegalEntityNotFound
+		}
+		if _, err := s.entities.GetEntity(legalEntityID); err != nil {
+			return err
+		}
+	}
+	if err := invoice.AssignLegalEntity(legalEntityID); err != nil {
+		return err
+	}
+	if err := invoice.SetBuyerTaxDetails(buyerCountry, buyerTaxID); err != nil {
+		return err
+	}
+
+	var split entity.InvoiceInstallments
+	if installments != nil {
+		split = entity.InvoiceInstallments{Count: installments.Count, IntervalMonths: installments.IntervalMonths}
+	}
+	return invoice.SetInstallments(split)
+}
+
+// checkDuplicateInvoice rejects a new invoice duplicating an existing invoice of its client, pointing to the existing one
+// External references are always compared; line items are not when the duplicate was allowed
+func (s *BillingService) checkDuplicateInvoice(invoice *entity.Invoice, allowDuplicate bool) error {
+	if s.duplicates == nil {
+		return nil
+	}
+	check := *s.duplicates
+	if allowDuplicate {
+		check.LineItemWindow = 0
+	}
+
+	// Only the invoices of the client that may match are read: those sharing the external reference (compared
+	// case-insensitively by the check), and those created within the line item window
+	var existing []*entity.Invoice
+	if invoice.ExternalReference() != "" {
+		page, err := s.invoices.ListAfter(repository.InvoiceListFilter{
+			ClientID:        invoice.ClientID(),
+			ReferenceSearch: invoice.ExternalReference(),
+		}, repository.InvoicePosition{}, repository.MaxListResults)
+		if err != nil {
+			return err
+		}
+		existing = append(existing, page.Invoices...)
+	}
+	if check.LineItemWindow > 0 {
+		page, err := s.invoices.ListAfter(repository.InvoiceListFilter{
+			ClientID:     invoice.ClientID(),
+			CreatedAfter: invoice.CreatedAt().Add(-check.LineItemWindow),
+		}, repository.InvoicePosition{}, repository.MaxListResults)
+		if err != nil {
+			return err
+		}
+		existing = append(existing, page.Invoices...)
+	}
+	duplicate, match := check.FindDuplicateInvoice(invoice, existing)
+	if duplicate == nil {
+		return nil
+	}
+
+	message := "client already has an invoice with this external reference"
+	if match == service.DuplicateByLineItems {
+		message = "client already has an invoice with the same line items; set allow_duplicate to create it anyway"
+	}
+	duplicateErr := errors.NewBusinessRuleError("invoice_unique", errors.BusinessRuleDuplicate, message)
+	duplicateErr.Context["match"] = string(match)
+	duplicateErr.Context["existing_id"] = duplicate.ID()
+	duplicateErr.Context["existing_url"] = "/api/v1/invoices/" + duplicate.ID()
+	return duplicateErr
+}
+
+// releaseInvoiceReference frees the external reference of an invoice that was deleted or could not be saved
+// A reference left behind only blocks its own reuse, so failures are logged rather than returned
+func (s *BillingService) releaseInvoiceReference(invoice *entity.Invoice) {
+	if s.references == nil {
+		return
+	}
+	if err := s.references.Release(entity.ExternalReferenceInvoice, invoice.TenantID(), invoice.ExternalReference(), invoice.ID()); err != nil {
+		log.Printf("Failed to release external reference of invoice %s: %v", invoice.ID(), err)
+	}
+}
+
+// GetInvoice retrieves an invoice of the caller's tenant by its ID; only admins may read invoices
+// (PermissionReadInvoices)
+func (s *BillingService) GetInvoice(rc RequestContext, id string) (*entity.Invoice, error) {
+	if err := rc.Authorize(PermissionReadInvoices); err != nil {
+		return nil, err
+	}
+	return s.ownedInvoice(rc, id)
+}
+
+// ownedInvoice retrieves an invoice the caller may act on: invoices of another tenant are reported as not found,
+// like clients (see GetOwnedClient)
+func (s *BillingService) ownedInvoice(rc RequestContext, id string) (*entity.Invoice, error) {
+	invoice, err := s.invoiceByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if !rc.CanAccessTenant(invoice.TenantID()) {
+		return nil, errors.ErrInvoiceNotFound
+	}
+	return invoice, nil
+}
+
+// invoiceByID retrieves an invoice by its ID, whatever its tenant
+// Entry points acting for a caller go through GetInvoice; it serves the services acting on invoices they already
+// reached through the caller or their own records (a portal session, a saga, a subscription period)
+func (s *BillingService) invoiceByID(id string) (*entity.Invoice, error) {
+	if err := s.requireInvoices(); err != nil {
+		return nil, err
+	}
+	if !isValidUUID(id) {
+		return nil, errors.ErrInvoiceNotFound
+	}
+	return s.invoices.GetByID(id)
+}
+
+// ListInvoices retrieves the invoices of the caller's tenant matching a filter that come after cursor in creation
+// order (keyset pagination). An empty cursor starts from the first invoice
+// The filter is applied by the repository, so a page reads only its invoices. The cursor does not carry the filter:
+// callers pass the same filter with every page. The tenant of the filter is replaced by the caller's
+func (s *BillingService) ListInvoices(rc RequestContext, filter InvoiceFilter, cursor string, limit int) (*InvoiceCursorPage, error) {
+	if err := rc.Authorize(PermissionReadInvoices); err != nil {
+		return nil, err
+	}
+	criteria, err := invoiceListCriteria(filter)
+	if err != nil {
+		return nil, err
+	}
+	criteria.TenantScoped = true
+	criteria.TenantID = rc.TenantID
+	return s.listInvoices(criteria, cursor, limit)
+}
+
+// listInvoices retrieves a page of the invoices matching repository criteria
+func (s *BillingService) listInvoices(criteria repository.InvoiceListFilter, cursor string, limit int) (*InvoiceCursorPage, error) {
+	if err := s.requireInvoices(); err != nil {
+		return nil, err
+	}
+	if err := validatePageLimit(limit); err != nil {
+		return nil, err
+	}
+	createdAt, id, err := decodePositionCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := s.invoices.ListAfter(criteria, repository.InvoicePosition{CreatedAt: createdAt, ID: id}, limit)
+	if err != nil {
+		return nil, err
+	}
+	result := &InvoiceCursorPage{Invoices: page.Invoices, Limit: limit, HasMore: page.HasMore}
+	if !page.Next.IsZero() {
+		result.NextCursor = encodePositionCursor(page.Next.CreatedAt, page.Next.ID)
+	}
+	return result, nil
+}
+
+// eachInvoice calls fn with every invoice matching a filter in creation order, reading them page by page so a
+// run never holds more than repository.MaxListResults invoices
+func (s *BillingService) eachInvoice(filter InvoiceFilter, fn func(invoice *entity.Invoice) error) error {
+	if err := s.requireInvoices(); err != nil {
+		return err
+	}
+	criteria, err := invoiceListCriteria(filter)
+	if err != nil {
+		return err
+	}
+
+	var after repository.InvoicePosition
+	for {
+		page, err := s.invoices.ListAfter(criteria, after, repository.MaxListResults)
+		if err != nil {
+			return err
+		}
+		for _, invoice := range page.Invoices {
+			if err := fn(invoice); err != nil {
+				return err
+			}
+		}
+		if !page.HasMore {
+			return nil
+		}
+		after = page.Next
+	}
+}
+
+// invoiceListCriteria validates an invoice listing filter and converts it to repository criteria
+func invoiceListCriteria(filter InvoiceFilter) (repository.InvoiceListFilter, error) {
+	switch filter.Status {
+	case "", entity.InvoiceDraft, entity.InvoiceIssued, entity.InvoicePaid, entity.InvoiceVoid:
+	default:
+		return repository.InvoiceListFilter{}, errors.NewValidationError("status", filter.Status, errors.ValidationFormat, "status must be one of: draft, issued, paid, void")
+	}
+	criteria := repository.InvoiceListFilter{
+		TenantID: strings.TrimSpace(filter.TenantID),
+		ClientID: strings.TrimSpace(filter.ClientID),
+		Status:   filter.Status,
+	}
+	if filter.ExcludeDrafts {
+		criteria.ExcludeStatus = entity.InvoiceDraft
+	}
+	return criteria, nil
+}
+
+// UpdateInvoice replaces the currency, line items, tax terms, due date, payment terms, legal entity, buyer tax
+// details and installments of a draft invoice
+// Plugin pricing hooks price the new lines before it is saved; only billing admins may change invoices
+func (s *BillingService) UpdateInvoice(rc RequestContext, id string, req dtos.UpdateInvoiceRequest) (*entity.Invoice, error) {
+	if err := rc.Authorize(PermissionManageInvoices); err != nil {
+		return nil, err
+	}
+	invoice, err := s.ownedInvoice(rc, id)
+	if err != nil {
+		return nil, err
+	}
+
+	pricing, err := valueobject.ParseTaxPricing(req.TaxPricing)
+	if err != nil {
+		return nil, err
+	}
+	terms, err := valueobject.ParsePaymentTerms(req.PaymentTerms)
+	if err != nil {
+		return nil, err
+	}
+	if !terms.IsSet() {
+		client, err := s.clientByID(invoice.ClientID())
+		if err != nil {
+			return nil, err
+		}
+		terms = invoicePaymentTerms(terms, client)
+	}
+	lines, err := s.toInvoiceLines(req.TaxJurisdiction, req.LineItems)
+	if err != nil {
+		return nil, err
+	}
+	if err := invoice.Update(req.Currency, lines, req.DueDate); err != nil {
+		return nil, err
+	}
+	if err := invoice.SetTaxTerms(pricing, req.TaxJurisdiction); err != nil {
+		return nil, err
+	}
+	if err := invoice.SetPaymentTerms(terms); err != nil {
+		return nil, err
+	}
+	if err := s.setInvoiceDetails(invoice, req.LegalEntityID, req.BuyerCountry, req.BuyerTaxID, req.Installments); err != nil {
+		return nil, err
+	}
+	if err := s.priceInvoice(invoice, nil); err != nil {
+		return nil, err
+	}
+	if err := s.invoices.Save(invoice); err != nil {
+		return nil, err
+	}
+	return invoice, nil
+}
+
+// invoicePaymentTerms returns the payment terms requested for an invoice, defaulting to those of its client
+func invoicePaymentTerms(requested valueobject.PaymentTerms, client *entity.Client) valueobject.PaymentTerms {
+	if requested.IsSet() {
+		return requested
+	}
+	return client.PaymentTerms()
+}
+
+// DeleteInvoice removes a draft invoice of the caller's tenant; issued invoices are voided instead, so they stay on
+// record. Only billing admins may delete invoices
+func (s *BillingService) DeleteInvoice(rc RequestContext, id string) error {
+	if err := rc.Authorize(PermissionManageInvoices); err != nil {
+		return err
+	}
+	invoice, err := s.ownedInvoice(rc, id)
+	if err != nil {
+		return err
+	}
+	if !invoice.IsDraft() {
+		return errors.ErrInvoiceNotDraft
+	}
+	if err := s.invoices.Delete(id); err != nil {
+		return err
+	}
+	s.releaseInvoiceReference(invoice)
+	return nil
+}
+
+// invoiceIssuance is what the checks run before issuing a draft settled for it
+type invoiceIssuance struct {
+	issue       entity.InvoiceIssue
+	legalEntity *entity.LegalEntity       // Entity numbering the invoice (nil when no legal entity is configured)
+	period      *entity.FiscalPeriod      // Period of the issue date (nil when fiscal calendars are not configured)
+	compliance  *service.ComplianceResult // nil when compliance rules are not configured
+}
+
+// refusal returns why the draft cannot be issued: its issue date falls in a closed fiscal period, or it lacks what
+// the compliance rules of its countries require (nil when it can be issued)
+func (i invoiceIssuance) refusal() error {
+	if i.period != nil && i.period.Closed {
+		return fiscalPeriodClosed(*i.period)
+	}
+	if i.compliance != nil {
+		return i.compliance.Err()
+	}
+	return nil
+}
+
+// IssueInvoice finalizes a draft invoice of the caller's tenant, numbering it in the sequence of the issue year when
+// numbering is enabled; only billing admins may issue invoices
+// Plugin pre-issue hooks are run first, and may refuse the issue
+// The number is allocated in the transaction saving the invoice, so a failed issue leaves no gap in the sequence
+// With legal entities, the invoice is issued from its entity (the default one unless assigned) and numbered from
+// the entity sequence; with fiscal calendars, invoices dated in a closed period are refused and numbered in the
+// sequence of their fiscal year; with compliance rules, invoices missing what their countries require are refused
+// With credit control, an invoice taking the client's outstanding balance over its credit limit is refused; with
+// risk scoring, so is a large invoice of a client whose risk score is declined
+func (s *BillingService) IssueInvoice(rc RequestContext, id string, now time.Time) (*entity.Invoice, error) {
+	if err := rc.Authorize(PermissionManageInvoices); err != nil {
+		return nil, err
+	}
+	invoice, err := s.ownedInvoice(rc, id)
+	if err != nil {
+		return nil, err
+	}
+	if !invoice.IsDraft() {
+		return nil, errors.ErrInvoiceNotDraft
+	}
+	if err := s.beforeInvoiceIssue(invoice, now); err != nil {
+		return nil, err
+	}
+	issuance, err := s.checkIssuance(invoice, now)
+	if err != nil {
+		return nil, err
+	}
+	if err := issuance.refusal(); err != nil {
+		return nil, err
+	}
+	if s.credit != nil {
+		if err := s.checkCredit(invoice); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.checkRisk(invoice, now); err != nil {
+		return nil, err
+	}
+	return s.issueInvoice(invoice, now, issuance)
+}
+
+// issueInvoice issues a draft whose checks passed, numbering it from the gap-free sequence of its legal entity, or
+// of the issue (fiscal) year, and records what was settled for it
+func (s *BillingService) issueInvoice(invoice *entity.Invoice, now time.Time, issuance invoiceIssuance) (*entity.Invoice, error) {
+	year := now.UTC().Year()
+	if issuance.issue.FiscalYear != 0 {
+		year = issuance.issue.FiscalYear
+	}
+
+	switch {
+	case issuance.legalEntity != nil:
+		legalEntity, err := s.entities.RecordNumbering(issuance.legalEntity.ID())
+		if err != nil {
+			return nil, err
+		}
+		invoice, err = s.invoices.UpdateNumbered(invoice.ID(), legalEntity.NumberingScope(year), func(invoice *entity.Invoice, number int64) error {
+			if err := invoice.IssueWith(now, issuance.issue); err != nil {
+				return err
+			}
+			return invoice.AssignNumber(legalEntity.FormatInvoiceNumber(year, number))
+		})
+		if err != nil {
+			return nil, err
+		}
+	case s.numbering != nil:
+		var err error
+		invoice, err = s.invoices.UpdateNumbered(invoice.ID(), strconv.Itoa(year), func(invoice *entity.Invoice, number int64) error {
+			if err := invoice.IssueWith(now, issuance.issue); err != nil {
+				return err
+			}
+			return invoice.AssignNumber(s.numbering.Format(year, number))
+		})
+		if err != nil {
+			return nil, err
+		}
+	default:
+		if err := invoice.IssueWith(now, issuance.issue); err != nil {
+			return nil, err
+		}
+		if err := s.invoices.Save(invoice); err != nil {
+			return nil, err
+		}
+	}
+	s.invoiceChanged(invoice)
+	s.publishInvoiceIssued(invoice)
+	return invoice, nil
+}
+
+// checkIssuance resolves the legal entity a draft is issued from and the fiscal period of its issue date, and
+// checks it against the compliance rules of its seller and buyer countries (see invoiceIssuance.refusal)
+func (s *BillingService) checkIssuance(invoice *entity.Invoice, now time.Time) (invoiceIssuance, error) {
+	var issuance invoiceIssuance
+	if s.entities != nil {
+		legalEntity, err := s.entities.ResolveEntity(invoice.LegalEntityID())
+		if err != nil {
+			return issuance, err
+		}
+		if legalEntity != nil {
+			issuance.legalEntity = legalEntity
+			issuance.issue.LegalEntityID = legalEntity.ID()
+		}
+	}
+
+	if s.fiscal != nil {
+		period, err := s.fiscal.PeriodOf(invoice.TenantID(), now)
+		if err != nil {
+			return issuance, err
+		}
+		issuance.period = &period
+		issuance.issue.FiscalYear = period.FiscalYear
+		issuance.issue.FiscalPeriod = period.Key()
+	}
+
+	if len(s.compliance) > 0 {
+		rates := make([]int64, 0, len(invoice.Lines()))
+		for _, line := range invoice.Lines() {
+			rates = append(rates, line.TaxRateBps)
+		}
+		result := service.EvaluateCompliance(complianceInvoice(issuance.legalEntity, invoice.BuyerCountry(), invoice.BuyerTaxID(), rates), s.compliance)
+		issuance.compliance = &result
+		issuance.issue.TaxBreakdown = string(result.TaxBreakdown)
+		issuance.issue.LegalMentions = result.Mentions
+	}
+	return issuance, nil
+}
+
+// complianceInvoice describes an invoice issued from a legal entity (nil when none is configured) to compliance rules
+func complianceInvoice(seller *entity.LegalEntity, buyerCountry, buyerTaxID string, taxRatesBps []int64) service.ComplianceInvoice {
+	invoice := service.ComplianceInvoice{
+		BuyerCountry: buyerCountry,
+		BuyerTaxID:   buyerTaxID,
+		TaxRatesBps:  taxRatesBps,
+	}
+	if seller != nil {
+		invoice.SellerCountry = seller.Country()
+		for _, registration := range seller.TaxRegistrations() {
+			invoice.SellerTaxIDs = append(invoice.SellerTaxIDs, registration.Number)
+		}
+	}
+	return invoice
+}
+
+// fiscalPeriodClosed builds the violation of an invoice dated in a closed fiscal period
+func fiscalPeriodClosed(period entity.FiscalPeriod) error {
+	closed := errors.NewBusinessRuleError("fiscal_period_open", errors.BusinessRuleViolation, "invoice date falls in a closed fiscal period")
+	closed.Context["fiscal_period"] = period.Key()
+	return closed
+}
+
+// checkRisk refuses a large draft of a client whose risk score is declined, consulting the risk provider when
+// no unexpired score is cached
+func (s *BillingService) checkRisk(invoice *entity.Invoice, now time.Time) error {
+	if s.risk == nil || !s.risk.Enabled() {
+		return nil
+	}
+	total, err := invoice.Total()
+	if err != nil {
+		return err
+	}
+	if !s.risk.IsLargeInvoice(total.Amount()) {
+		return nil
+	}
+
+	client, err := s.clientByID(invoice.ClientID())
+	if err != nil {
+		return err
+	}
+	assessment, err := s.risk.Assess(context.Background(), client, entity.RiskTriggerLargeInvoice, now)
+	if err != nil {
+		return err
+	}
+	if assessment.Decision() != entity.RiskDecisionDecline {
+		return nil
+	}
+	declined := errors.NewBusinessRuleError("client_risk", errors.BusinessRuleViolation, "client risk score is too high for an invoice this large")
+	declined.Context["risk_score"] = assessment.Score()
+	declined.Context["decision"] = string(assessment.Decision())
+	return declined
+}
+
+// OpenInvoices lists the issued invoices with a balance left to pay (none when invoices are not enabled)
+// Only issued invoices are read, page by page
+func (s *BillingService) OpenInvoices() ([]service.OpenInvoice, error) {
+	if s.invoices == nil {
+		return nil, nil
+	}
+
+	var open []service.OpenInvoice
+	err := s.eachInvoice(InvoiceFilter{Status: entity.InvoiceIssued}, func(invoice *entity.Invoice) error {
+		balance, err := invoice.Balance()
+		if err != nil {
+			return err
+		}
+		if balance.IsPositive() {
+			open = append(open, service.OpenInvoice{
+				InvoiceID:   invoice.ID(),
+				Number:      invoice.Number(),
+				ClientID:    invoice.ClientID(),
+				Outstanding: balance,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return open, nil
+}
+
+// checkCredit checks a draft invoice against the credit limit of its client
+// Under the require_approval policy the first check requests an override, and the invoice is issued once approved
+func (s *BillingService) checkCredit(invoice *entity.Invoice) error {
+	total, err := invoice.Total()
+	if err != nil {
+		return err
+	}
+
+	check, err := s.credit.CheckIssuance(invoice.ClientID(), "invoice:"+invoice.ID(), total)
+	if err != nil {
+		return err
+	}
+	if check.Allowed() {
+		return nil
+	}
+	return creditLimitExceeded(check, total)
+}
+
+// creditLimitExceeded builds the violation of an invoice held by the credit limit of its client,
+// pointing to the override request when the policy requires one
+func creditLimitExceeded(check *CreditCheck, amount valueobject.Money) error {
+	exceeded := errors.NewBusinessRuleError("credit_limit", errors.BusinessRuleViolation, "invoice would take the client's outstanding balance over its credit limit")
+	exceeded.Context["decision"] = string(check.Decision)
+	exceeded.Context["credit_limit"] = check.Limit.Limit().String()
+	exceeded.Context["outstanding"] = check.Exposure.Exposure().String()
+	exceeded.Context["amount"] = amount.String()
+	if check.Approval != nil {
+		exceeded.Context["approval_id"] = check.Approval.ID()
+		exceeded.Context["approval_url"] = "/api/v1/approvals/" + check.Approval.ID()
+	}
+	return exceeded
+}
+
+// VoidInvoice cancels a draft of the caller's tenant, or an issued invoice without payments; only billing admins
+// may void invoices
+func (s *BillingService) VoidInvoice(rc RequestContext, id string, now time.Time) (*entity.Invoice, error) {
+	if err := rc.Authorize(PermissionManageInvoices); err != nil {
+		return nil, err
+	}
+
+	s.paymentsMu.Lock()
+	defer s.paymentsMu.Unlock()
+
+	invoice, err := s.ownedInvoice(rc, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := invoice.Void(now); err != nil {
+		return nil, err
+	}
+	if err := s.invoices.Save(invoice); err != nil {
+		return nil, err
+	}
+	s.invoiceChanged(invoice)
+	return invoice, nil
+}
+
+// RecordDunning records on an overdue invoice the payment reminder of the stage at level (1-based) sent at now,
+// charging the late fee sent with it (zero: none)
+func (s *BillingService) RecordDunning(id string, level int, stage entity.ReminderStage, lateFee valueobject.Money, now time.Time) (*entity.Invoice, error) {
+	s.paymentsMu.Lock()
+	defer s.paymentsMu.Unlock()
+
+	invoice, err := s.invoiceByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := invoice.RecordDunningWithLateFee(level, stage, lateFee, now); err != nil {
+		return nil, err
+	}
+	if err := s.invoices.Save(invoice); err != nil {
+		return nil, err
+	}
+	if lateFee.IsPositive() {
+		s.invoiceChanged(invoice)
+	}
+	return invoice, nil
+}
+
+// RecordPayment records a payment received against an issued invoice and applies it to the invoice balance
+// Partial payments leave the invoice issued; the payment that brings the balance to zero marks it paid
+// Only billing admins may record payments (PermissionRecordPayment)
+func (s *BillingService) RecordPayment(rc RequestContext, invoiceID string, req dtos.RecordPaymentRequest, now time.Time) (*entity.Payment, *entity.Invoice, error) {
+	if err := rc.Authorize(PermissionRecordPayment); err != nil {
+		return nil, nil, err
+	}
+	if s.payments == nil {
+		return nil, nil, errors.NewBusinessRuleError("payments_enabled", errors.BusinessRuleViolation, "payment recording is not enabled")
+	}
+
+	amount, err := valueobject.NewMoney(req.Amount, req.Currency)
+	if err != nil {
+		return nil, nil, err
+	}
+	receivedAt := now
+	if req.ReceivedAt != nil {
+		receivedAt = *req.ReceivedAt
+	}
+
+	s.paymentsMu.Lock()
+	defer s.paymentsMu.Unlock()
+
+	invoice, err := s.invoiceByID(invoiceID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	payment, err := entity.NewPayment(invoice.ID(), amount, entity.PaymentMethod(req.Method), req.Reference, receivedAt)
+	if err != nil {
+		return nil, nil, err
+	}
+	payment.AssignTenant(invoice.TenantID())
+	if err := invoice.ApplyPayment(payment.Amount(), payment.ReceivedAt()); err != nil {
+		return nil, nil, err
+	}
+
+	// The payment is saved first: a failed invoice save leaves a payment to reconcile, never a balance without one
+	if err := s.payments.Save(payment); err != nil {
+		return nil, nil, err
+	}
+	if err := s.invoices.Save(invoice); err != nil {
+		return nil, nil, err
+	}
+	s.invoiceChanged(invoice)
+	return payment, invoice, nil
+}
+
+// ReversePayment removes the payment recorded against an invoice under a reference and restores it to the invoice
+// balance, for payments undone before they completed (a card charge refunded by the payment saga)
+// Reversing a reference without a payment is a no-op, so a compensation can be retried
+// Only billing admins may reverse payments (PermissionRecordPayment)
+func (s *BillingService) ReversePayment(rc RequestContext, invoiceID, reference string) (*entity.Invoice, error) {
+	if err := rc.Authorize(PermissionRecordPayment); err != nil {
+		return nil, err
+	}
+	if s.payments == nil {
+		return nil, errors.NewBusinessRuleError("payments_enabled", errors.BusinessRuleViolation, "payment recording is not enabled")
+	}
+
+	s.paymentsMu.Lock()
+	defer s.paymentsMu.Unlock()
+
+	invoice, err := s.invoiceByID(invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	payment, err := s.findPayment(invoiceID, reference)
+	if err != nil || payment == nil {
+		return invoice, err
+	}
+
+	if err := invoice.ReversePayment(payment.Amount()); err != nil {
+		return nil, err
+	}
+	// The invoice is saved first: a failed payment delete leaves a payment to reconcile, never a balance without one
+	if err := s.invoices.Save(invoice); err != nil {
+		return nil, err
+	}
+	if err := s.payments.Delete(payment.ID()); err != nil {
+		return nil, err
+	}
+	s.invoiceChanged(invoice)
+	return invoice, nil
+}
+
+// FindPayment retrieves the payment recorded against an invoice under a reference, nil when there is none
+func (s *BillingService) FindPayment(invoiceID, reference string) (*entity.Payment, error) {
+	if s.payments == nil {
+		return nil, errors.NewBusinessRuleError("payments_enabled", errors.BusinessRuleViolation, "payment recording is not enabled")
+	}
+	return s.findPayment(invoiceID, reference)
+}
+
+// findPayment retrieves the payment of an invoice with a reference, nil when there is none
+func (s *BillingService) findPayment(invoiceID, reference string) (*entity.Payment, error) {
+	payments, err := s.payments.ListByInvoice(invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	for _, payment := range payments {
+		if payment.Reference() == reference {
+			return payment, nil
+		}
+	}
+	return nil, nil
+}
+
+// ListPayments retrieves the payments received against an invoice, oldest first
+func (s *BillingService) ListPayments(invoiceID string) ([]*entity.Payment, error) {
+	if s.payments == nil {
+		return nil, errors.NewBusinessRuleError("payments_enabled", errors.BusinessRuleViolation, "payment recording is not enabled")
+	}
+	if _, err := s.invoiceByID(invoiceID); err != nil {
+		return nil, err
+	}
+	return s.payments.ListByInvoice(invoiceID)
+}
+
+// requireInvoices reports a service built without an invoice repository
+func (s *BillingService) requireInvoices() error {
+	if s.invoices == nil {
+		return errors.NewBusinessRuleError("invoices_enabled", errors.BusinessRuleViolation, "invoices are not enabled")
+	}
+	return nil
+}
+
+// toInvoiceLines converts requested line items, which the invoice validates
+// Line items without a tax rate take the rate of the tax jurisdiction
+func (s *BillingService) toInvoiceLines(jurisdiction string, items []dtos.InvoiceLineRequest) ([]entity.InvoiceLine, error) {
+	explicit := make([]*int64, len(items))
+	for i, item := range items {
+		explicit[i] = item.TaxRateBps
+	}
+	rates, err := s.taxes.LineRates(jurisdiction, explicit)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]entity.InvoiceLine, len(items))
+	for i, item := range items {
+		lines[i] = entity.InvoiceLine{
+			Description: item.Description,
+			Quantity:    item.Quantity,
+			UnitAmount:  item.UnitAmount,
+			TaxRateBps:  rates[i],
+		}
+	}
+	return lines, nil
+}
@@ -1,45 +1,348 @@
 package application
 
 import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
 	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
 	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
 	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/eventbus"
 	"github.com/google/uuid"
 )
 
 // BillingService orchestrates billing domain operations and use cases
 type BillingService struct {
-	clientRepo repository.ClientRepository
+	clientRepo  repository.ClientRepository
+	changeRepo  repository.ClientChangeRepository
+	risk        *RiskScoringService
+	references  *ExternalReferenceService
+	invoices    repository.InvoiceRepository
+	numbering   *valueobject.InvoiceNumberFormat // Nil leaves issued invoices unnumbered
+	entities    *LegalEntityService              // Nil issues invoices from no legal entity
+	fiscal      *FiscalCalendarService           // Nil reports invoices in calendar years, whatever the periods closed
+	compliance  []service.ComplianceRule         // Empty issues invoices without checking country requirements
+	duplicates  *service.DuplicateInvoiceCheck   // Nil creates invoices without looking for duplicates
+	taxes       *service.TaxEngine
+	deletion    service.ClientDeletionPolicy
+	emails      valueobject.EmailNormalization // Spellings of an address counted as one client (lower case only by default)
+	payments    repository.PaymentRepository
+	paymentsMu  sync.Mutex // Serializes payments, so two payments cannot both take the balance of an invoice
+	summaries   repository.ClientSummaryRepository
+	summariesMu sync.Mutex  // Serializes summary refreshes, so a stale refresh cannot overwrite a newer one
+	eventStore  *EventStore // Nil records no client and invoice states: summaries cannot be rebuilt
+	contacts    repository.ClientContactRepository
+	notes       repository.ClientNoteRepository
+	tombstones  repository.ClientTombstoneRepository
+	credit      InvoiceCreditChecker // Nil issues invoices whatever their clients owe
+	rules       *RuleChecker         // Business rules spanning several entities, checked before saving
+	events      *eventbus.Bus        // Nil publishes no events
+	plugins     *Plugins             // Nil runs no plugin hooks
+}
+
+// InvoiceCreditChecker checks an invoice against the credit limit of its client before it is issued
+type InvoiceCreditChecker interface {
+	CheckIssuance(clientID, reference string, amount valueobject.Money) (*CreditCheck, error)
 }
 
 // NewBillingService creates a new billing service
 func NewBillingService(clientRepo repository.ClientRepository) *BillingService {
 	return &BillingService{
 		clientRepo: clientRepo,
+		taxes:      service.NewTaxEngine(service.StaticTaxRates{}),
+		deletion:   service.DefaultClientDeletionPolicy(),
+		rules:      &RuleChecker{rules: DefaultRules(clientRepo)},
 	}
 }
 
-// CreateClient creates a new client with the provided details and persists it
-func (s *BillingService) CreateClient(name, email, phone, address string) (*entity.Client, error) {
-	client, err := entity.NewClient(name, email, phone, address)
+// WithChangeLog records every client create/update/delete in the change log for incremental sync
+func (s *BillingService) WithChangeLog(changeRepo repository.ClientChangeRepository) *BillingService {
+	s.changeRepo = changeRepo
+	return s
+}
+
+// WithRiskScoring scores every new client with the risk provider
+func (s *BillingService) WithRiskScoring(risk *RiskScoringService) *BillingService {
+	s.risk = risk
+	return s
+}
+
+// WithExternalReferences keeps client external references unique per tenant and looks clients up by them
+func (s *BillingService) WithExternalReferences(references *ExternalReferenceService) *BillingService {
+	s.references = references
+	return s
+}
+
+// WithInvoices enables one-off invoices billed to clients
+func (s *BillingService) WithInvoices(invoiceRepo repository.InvoiceRepository) *BillingService {
+	s.invoices = invoiceRepo
+	return s
+}
+
+// WithInvoiceNumbering numbers invoices when they are issued, from a gap-free sequence restarting every year
+func (s *BillingService) WithInvoiceNumbering(format valueobject.InvoiceNumberFormat) *BillingService {
+	s.numbering = &format
+	return s
+}
+
+// WithLegalEntities issues invoices from the legal entity they are assigned to, or the default entity, numbering them
+// from its sequence
+func (s *BillingService) WithLegalEntities(entities *LegalEntityService) *BillingService {
+	s.entities = entities
+	return s
+}
+
+// WithFiscalCalendars refuses to issue invoices dated in a closed fiscal period of their tenant and numbers them in
+// the sequence of their fiscal year rather than their calendar year
+func (s *BillingService) WithFiscalCalendars(fiscal *FiscalCalendarService) *BillingService {
+	s.fiscal = fiscal
+	return s
+}
+
+// WithComplianceRules checks invoices against the rules of their seller and buyer countries before issuing them;
+// issued invoices record the tax breakdown and legal mentions to print
+func (s *BillingService) WithComplianceRules(rules []service.ComplianceRule) *BillingService {
+	s.compliance = rules
+	return s
+}
+
+// WithDuplicateCheck rejects new invoices duplicating an existing invoice of the same client
+func (s *BillingService) WithDuplicateCheck(check *service.DuplicateInvoiceCheck) *BillingService {
+	s.duplicates = check
+	return s
+}
+
+// WithTaxRates sets the provider the tax rates of invoice jurisdictions are looked up with
+// Without it no jurisdiction has a rate, so line items need explicit rates
+func (s *BillingService) WithTaxRates(rates service.TaxRateProvider) *BillingService {
+	s.taxes = service.NewTaxEngine(rates)
+	return s
+}
+
+// WithClientDeletionPolicy sets what happens to the invoices of a client when it is deleted
+func (s *BillingService) WithClientDeletionPolicy(policy service.ClientDeletionPolicy) *BillingService {
+	s.deletion = policy
+	return s
+}
+
+// WithEmailNormalization folds the spellings of an address reaching the same mailbox (subaddress tags, dots) in
+// email uniqueness checks
+func (s *BillingService) WithEmailNormalization(policy valueobject.EmailNormalization) *BillingService {
+	s.emails = policy
+	return s
+}
+
+// WithPayments records payments received against invoices, which are paid once their balance reaches zero
+func (s *BillingService) WithPayments(paymentRepo repository.PaymentRepository) *BillingService {
+	s.payments = paymentRepo
+	return s
+}
+
+// WithClientSummaries maintains the client list read model (balances and last invoice per client)
+// Summaries are refreshed when a client is saved or deleted and when an invoice is issued, voided or paid
+func (s *BillingService) WithClientSummaries(summaryRepo repository.ClientSummaryRepository) *BillingService {
+	s.summaries = summaryRepo
+	return s
+}
+
+// WithEventStore records the states of clients and invoices in an event store, so the client list read model can
+// be rebuilt by replaying them (see RebuildClientSummaries)
+func (s *BillingService) WithEventStore(store *EventStore) *BillingService {
+	s.eventStore = store
+	return s
+}
+
+// WithContacts enables the contacts of clients, which are deleted along with their client
+func (s *BillingService) WithContacts(contactRepo repository.ClientContactRepository) *BillingService {
+	s.contacts = contactRepo
+	return s
+}
+
+// WithNotes enables the notes of clients, which are deleted along with their client
+func (s *BillingService) WithNotes(noteRepo repository.ClientNoteRepository) *BillingService {
+	s.notes = noteRepo
+	return s
+}
+
+// WithTombstones records a tombstone of every deleted client, so idempotent deletes can tell a client deleted
+// before from one that never existed
+func (s *BillingService) WithTombstones(tombstoneRepo repository.ClientTombstoneRepository) *BillingService {
+	s.tombstones = tombstoneRepo
+	return s
+}
+
+// WithCreditControl holds invoices taking their client over its credit limit when they are issued
+func (s *BillingService) WithCreditControl(credit InvoiceCreditChecker) *BillingService {
+	s.credit = credit
+	return s
+}
+
+// WithRuleChecker replaces the business rules checked before saving (DefaultRules unless set)
+func (s *BillingService) WithRuleChecker(rules *RuleChecker) *BillingService {
+	s.rules = rules
+	return s
+}
+
+// BusinessRules describes the business rules checked before saving, in the order they are checked
+func (s *BillingService) BusinessRules() []RuleInfo {
+	return s.rules.Rules()
+}
+
+// recordChange appends a client change to the change log when one is configured
+// The change is published on the event bus as well
+func (s *BillingService) recordChange(changeType entity.ClientChangeType, clientID, tenantID string, client *entity.Client) error {
+	s.publishClientChange(changeType, clientID, client)
+	if s.changeRepo == nil {
+		return nil
+	}
+
+	sequence, err := s.changeRepo.NextSequence()
 	if err != nil {
+		return err
+	}
+
+	return s.changeRepo.Append(entity.NewClientChange(sequence, changeType, clientID, tenantID, client))
+}
+
+// CreateClient creates a new client of the caller's tenant with the external reference of the request, which
+// must be unique in the tenant when external references are configured; phone and address follow the rules of the
+// client's country, never the caller's locale
+// The email address must not belong to another client, spellings being folded by the email normalization policy
+// Only billing admins may create clients (PermissionManageClients)
+func (s *BillingService) CreateClient(rc RequestContext, req dtos.CreateClientRequest) (*entity.Client, error) {
+	if err := rc.Authorize(PermissionManageClients); err != nil {
+		return nil, err
+	}
+	client, err := entity.NewClientInCountry(req.Name, req.Email, req.Phone, req.Address, req.Country)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.AssignExternalReference(rc.TenantID, req.ExternalRef); err != nil {
+		return nil, err
+	}
+	if err := client.SetPaymentTerms(req.PaymentTerms); err != nil {
+		return nil, err
+	}
+
+	client.NormalizeEmail(s.emails)
+	if err := s.beforeClientSave(RuleSubject{Operation: RuleCreateClient, Context: rc, Client: client}); err != nil {
+		return nil, err
+	}
+	if err := s.rules.Check(RuleSubject{Operation: RuleCreateClient, Context: rc, Client: client}); err != nil {
 		return nil, err
 	}
 
+	if s.references != nil {
+		if err := s.references.Claim(entity.ExternalReferenceClient, client.TenantID(), client.ExternalReference(), client.ID()); err != nil {
+			return nil, err
+		}
+	}
 	err = s.clientRepo.Save(client)
 	if err != nil {
+		s.releaseReference(client)
+		return nil, err
+	}
+
+	if err := s.recordChange(entity.ClientCreated, client.ID(), client.TenantID(), client); err != nil {
 		return nil, err
 	}
+	s.clientChanged(entity.ClientCreated, client.ID(), client.TenantID(), client)
+
+	// A provider outage must not block onboarding: the client is scored again on its first large invoice
+	if s.risk != nil && s.risk.Enabled() {
+		if _, err := s.risk.Assess(context.Background(), client, entity.RiskTriggerOnboarding, time.Now()); err != nil {
+			log.Printf("Failed to score new client %s: %v", client.ID(), err)
+		}
+	}
 
 	return client, nil
 }
 
-// ListClients retrieves all clients from the repository
-func (s *BillingService) ListClients() ([]*entity.Client, error) {
-	return s.clientRepo.GetAll()
+// clientEmailConflict builds the conflict of an email address already held, pointing to the client holding it
+// when the caller may see that client
+func clientEmailConflict(rc RequestContext, existing *entity.Client) error {
+	conflict := errors.NewBusinessRuleError("email_uniqueness", errors.BusinessRuleDuplicate, "another client already has this email address")
+	conflict.Context["match"] = "email"
+	if rc.CanAccessTenant(existing.TenantID()) {
+		conflict.Context["existing_id"] = existing.ID()
+		conflict.Context["existing_url"] = "/api/v1/clients/" + existing.ID()
+	}
+	return conflict
+}
+
+// releaseReference frees the external reference of a client that was deleted or could not be saved
+// A reference left behind only blocks its own reuse, so failures are logged rather than returned
+func (s *BillingService) releaseReference(client *entity.Client) {
+	if s.references == nil {
+		return
+	}
+	if err := s.references.Release(entity.ExternalReferenceClient, client.TenantID(), client.ExternalReference(), client.ID()); err != nil {
+		log.Printf("Failed to release external reference of client %s: %v", client.ID(), err)
+	}
+}
+
+// GetClientByExternalReference retrieves the client of the caller's tenant with an external reference
+func (s *BillingService) GetClientByExternalReference(rc RequestContext, reference string) (*entity.Client, error) {
+	if s.references == nil {
+		return nil, errors.ErrExternalReferenceNotFound
+	}
+
+	clientID, err := s.references.Resolve(entity.ExternalReferenceClient, rc.TenantID, reference)
+	if err != nil {
+		return nil, err
+	}
+	return s.clientRepo.GetByID(clientID)
+}
+
+// GetOwnedClient retrieves a client the caller may act on: clients of another tenant are reported as not found, so
+// callers neither act on nor learn about them; clients created without a tenant are only visible to admins
+// (see RequestContext.CanAccessTenant)
+func (s *BillingService) GetOwnedClient(rc RequestContext, id string) (*entity.Client, error) {
+	client, err := s.clientByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if !rc.CanAccessTenant(client.TenantID()) {
+		return nil, errors.ErrClientNotFound
+	}
+	return client, nil
+}
+
+// ListClients retrieves all clients of the caller's tenant
+// Beyond repository.MaxListResults clients it fails with a result limit error: callers page with
+// ListClientsWithFilter or ListClientsAfterCursor
+func (s *BillingService) ListClients(rc RequestContext) ([]*entity.Client, error) {
+	if !seesClients(rc) {
+		return []*entity.Client{}, nil
+	}
+	clients, totalCount, err := s.clientRepo.List(tenantClients(rc), 0, repository.MaxListResults)
+	if err != nil {
+		return nil, err
+	}
+	if totalCount > repository.MaxListResults {
+		return nil, errors.NewResultLimitError(repository.MaxListResults, "list clients page by page (page and limit, or cursor)")
+	}
+	return clients, nil
+}
+
+// tenantClients returns the repository criteria of the clients the caller may see: those of its tenant, or the
+// clients created without a tenant for callers acting in none (see RequestContext.CanAccessTenant)
+func tenantClients(rc RequestContext) repository.ClientListFilter {
+	return repository.ClientListFilter{TenantScoped: true, TenantID: rc.TenantID}
+}
+
+// seesClients reports whether the caller may see any client: callers acting in no tenant only see the clients
+// created without a tenant, which only admins and the system see
+func seesClients(rc RequestContext) bool {
+	return rc.TenantID != "" || rc.CanAccessTenant("")
 }
 
 // PaginatedClients represents paginated client results
@@ -56,24 +359,90 @@ type PaginationMeta struct {
 	TotalPages int
 }
 
-// ListClientsWithPagination retrieves clients with pagination
-func (s *BillingService) ListClientsWithPagination(page, limit int) (*PaginatedClients, error) {
-	// Calculate offset
-	offset := (page - 1) * limit
+// ListClientsWithPagination retrieves the clients of the caller's tenant with pagination, ordered by sort (see
+// ClientFilter.Sort)
+func (s *BillingService) ListClientsWithPagination(rc RequestContext, page, limit int, sort string) (*PaginatedClients, error) {
+	return s.ListClientsWithFilter(rc, ClientFilter{Sort: sort}, page, limit)
+}
+
+// validatePageLimit checks the size of a page of a listing against the largest page the API serves
+func validatePageLimit(limit int) error {
+	if limit < 1 || limit > dtos.MaxLimit {
+		return errors.NewValidationError("limit", limit, errors.ValidationRange,
+			fmt.Sprintf("limit must be between 1 and %d", dtos.MaxLimit))
+	}
+	return nil
+}
+
+// ClientFilter narrows a client listing (empty fields match every client)
+type ClientFilter struct {
+	Status        entity.ClientStatus
+	Tag           string
+	Phone         string             // Any formatting; matched on its E.164 form
+	Locale        valueobject.Locale // Region national phone numbers are interpreted in
+	Email         string             // Any spelling reaching the same mailbox (see WithEmailNormalization)
+	Search        string             // Case-insensitive substring of the name or email
+	CreatedAfter  time.Time          // Clients created strictly after (zero: no lower bound)
+	CreatedBefore time.Time          // Clients created strictly before (zero: no upper bound)
+	Sort          string             // Comma-separated fields, "-" prefixed for descending order (empty: creation order)
+}
+
+// parseClientSort parses a client listing ordering such as "name,-created_at" against the sortable fields
+func parseClientSort(sort string) ([]repository.ClientSortKey, error) {
+	if strings.TrimSpace(sort) == "" {
+		return nil, nil
+	}
+	supported := make([]string, len(repository.ClientSortFields))
+	for i, field := range repository.ClientSortFields {
+		supported[i] = string(field)
+	}
+	var keys []repository.ClientSortKey
+	seen := make(map[repository.ClientSortField]bool)
+	for _, part := range strings.Split(sort, ",") {
+		part = strings.TrimSpace(part)
+		key := repository.ClientSortKey{
+			Field:      repository.ClientSortField(strings.TrimPrefix(part, "-")),
+			Descending: strings.HasPrefix(part, "-"),
+		}
+		if !slices.Contains(repository.ClientSortFields, key.Field) {
+			return nil, errors.NewValidationError("sort", sort, errors.ValidationFormat,
+				fmt.Sprintf("cannot sort by %q, supported fields are %s", part, strings.Join(supported, ", ")))
+		}
+		if seen[key.Field] {
+			return nil, errors.NewValidationError("sort", sort, errors.ValidationFormat,
+				fmt.Sprintf("cannot sort by %s more than once", key.Field))
+		}
+		seen[key.Field] = true
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
 
-	// Get total count
-	totalCount, err := s.clientRepo.CountClients()
+// ListClientsWithFilter retrieves the clients of the caller's tenant matching a filter with pagination. An empty
+// filter lists every client of the tenant, like ListClientsWithPagination
+// Every criterion and the ordering are handed to the repository, so backends able to query filter, sort and paginate
+// in the database, and pages stay stable (ties are broken by creation order)
+func (s *BillingService) ListClientsWithFilter(rc RequestContext, filter ClientFilter, page, limit int) (*PaginatedClients, error) {
+	if page < 1 {
+		return nil, errors.NewValidationError("page", page, errors.ValidationRange, "page must be greater than 0")
+	}
+	if err := validatePageLimit(limit); err != nil {
+		return nil, err
+	}
+	criteria, err := s.clientListCriteria(rc, filter)
 	if err != nil {
 		return nil, err
 	}
 
-	// Get paginated clients
-	clients, err := s.clientRepo.ListClientsWithPagination(offset, limit)
+	if !seesClients(rc) {
+		return &PaginatedClients{Clients: []*entity.Client{}, Pagination: PaginationMeta{Page: page, Limit: limit}}, nil
+	}
+
+	clients, totalCount, err := s.clientRepo.List(criteria, (page-1)*limit, limit)
 	if err != nil {
 		return nil, err
 	}
 
-	// Calculate total pages
 	totalPages := totalCount / limit
 	if totalCount%limit > 0 {
 		totalPages++
@@ -90,8 +459,56 @@ func (s *BillingService) ListClientsWithPagination(page, limit int) (*PaginatedC
 	}, nil
 }
 
-// GetClientByID retrieves a client by ID
-func (s *BillingService) GetClientByID(id string) (*entity.Client, error) {
+// clientListCriteria validates a client listing filter and converts it to the criteria of the repository, scoped to
+// the clients the caller may see
+func (s *BillingService) clientListCriteria(rc RequestContext, filter ClientFilter) (repository.ClientListFilter, error) {
+	sortKeys, err := parseClientSort(filter.Sort)
+	if err != nil {
+		return repository.ClientListFilter{}, err
+	}
+	criteria := tenantClients(rc)
+	criteria.Sort = sortKeys
+	criteria.Status = filter.Status
+	criteria.Search = strings.TrimSpace(filter.Search)
+	criteria.CreatedAfter = filter.CreatedAfter
+	criteria.CreatedBefore = filter.CreatedBefore
+	if filter.Status != "" {
+		if err := entity.ValidateClientStatus(filter.Status); err != nil {
+			return repository.ClientListFilter{}, err
+		}
+	}
+	if filter.Tag != "" {
+		tag, err := entity.NormalizeClientTag(filter.Tag)
+		if err != nil {
+			return repository.ClientListFilter{}, err
+		}
+		criteria.Tag = tag
+	}
+	if filter.Phone != "" {
+		phone, err := valueobject.NewPhoneWithLocale(filter.Phone, filter.Locale)
+		if err != nil {
+			return repository.ClientListFilter{}, err
+		}
+		criteria.PhoneE164 = phone.E164()
+	}
+	if filter.Email != "" {
+		email, err := valueobject.NewEmail(filter.Email)
+		if err != nil {
+			return repository.ClientListFilter{}, err
+		}
+		criteria.EmailKey = email.Canonical(s.emails)
+	}
+	if !filter.CreatedAfter.IsZero() && !filter.CreatedBefore.IsZero() && !filter.CreatedAfter.Before(filter.CreatedBefore) {
+		return repository.ClientListFilter{}, errors.NewValidationError("created_before", filter.CreatedBefore.Format(time.RFC3339),
+			errors.ValidationRange, "created_before must be later than created_after")
+	}
+	return criteria, nil
+}
+
+// clientByID retrieves a client by ID, whatever its tenant
+// Entry points acting for a caller go through GetOwnedClient; it serves the services acting on clients they
+// already reached through the caller (the client of an owned invoice, the client of a portal session)
+func (s *BillingService) clientByID(id string) (*entity.Client, error) {
 	// Basic UUID validation
 	if strings.TrimSpace(id) == "" {
 		return nil, errors.NewValidationError("id", id, errors.ValidationRequired, "client ID is required")
@@ -106,54 +523,202 @@ func (s *BillingService) GetClientByID(id string) (*entity.Client, error) {
 	return s.clientRepo.GetByID(id)
 }
 
+// ClientBatch is the outcome of a batch get of clients
+type ClientBatch struct {
+	Clients  []*entity.Client // In the order of the requested IDs
+	NotFound []string         // Requested IDs without a client
+}
+
+// GetClientsByIDs retrieves several clients of the caller's tenant in one repository round trip instead of one
+// GetOwnedClient per client; clients the caller may not see are reported as not found, like GetOwnedClient does
+// Each ID is validated; an ID requested twice is returned once. At most dtos.MaxLimit IDs can be requested
+func (s *BillingService) GetClientsByIDs(rc RequestContext, ids []string) (*ClientBatch, error) {
+	if len(ids) == 0 {
+		return nil, errors.NewValidationError("ids", ids, errors.ValidationRequired, "at least one client ID is required")
+	}
+	if len(ids) > dtos.MaxLimit {
+		return nil, errors.NewValidationError("ids", len(ids), errors.ValidationRange,
+			fmt.Sprintf("at most %d client IDs can be requested at once", dtos.MaxLimit))
+	}
+
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		id = strings.TrimSpace(id)
+		if !isValidUUID(id) {
+			return nil, errors.NewValidationError("ids", id, errors.ValidationFormat,
+				fmt.Sprintf("client ID %q must be a valid UUID", id))
+		}
+		if !slices.Contains(unique, id) {
+			unique = append(unique, id)
+		}
+	}
+
+	found, err := s.clientRepo.GetByIDs(unique)
+	if err != nil {
+		return nil, err
+	}
+	clients := make([]*entity.Client, 0, len(found))
+	for _, client := range found {
+		if rc.CanAccessTenant(client.TenantID()) {
+			clients = append(clients, client)
+		}
+	}
+	batch := &ClientBatch{Clients: clients, NotFound: []string{}}
+	for _, id := range unique {
+		if !slices.ContainsFunc(clients, func(client *entity.Client) bool { return client.ID() == id }) {
+			batch.NotFound = append(batch.NotFound, id)
+		}
+	}
+	return batch, nil
+}
+
+// ClientExists checks if a client the caller may see exists; clients of other tenants do not exist for it (see
+// GetOwnedClient)
+func (s *BillingService) ClientExists(rc RequestContext, id string) (bool, error) {
+	_, err := s.GetOwnedClient(rc, id)
+	if err != nil {
+		if errors.GetErrorCode(err) == errors.RepositoryNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// ClientDeleted checks if no client has an ID, whatever its tenant, for nested resources outliving their client
+// (statement jobs): it tells whether there is a client left to check the ownership of, never reveals a client
+func (s *BillingService) ClientDeleted(id string) (bool, error) {
+	exists, err := s.clientExists(id)
+	return !exists, err
+}
+
+// clientExists checks if a client exists without loading it, whatever its tenant
+func (s *BillingService) clientExists(id string) (bool, error) {
+	if strings.TrimSpace(id) == "" {
+		return false, errors.NewValidationError("id", id, errors.ValidationRequired, "client ID is required")
+	}
+
+	if !isValidUUID(id) {
+		return false, errors.NewValidationError("id", id, errors.ValidationFormat, "client ID must be a valid UUID")
+	}
+
+	return s.clientRepo.Exists(id)
+}
+
+// CountClients returns the number of clients of the caller's tenant whose name or email contains filter
+// (case-insensitive). An empty filter counts all clients of the tenant; mode "estimated" trades precision for speed
+// on very large tables and is only available without a filter. Estimates span every tenant, so the clients of a
+// tenant are always counted exactly
+func (s *BillingService) CountClients(rc RequestContext, filter, count string) (int, error) {
+	filter = strings.TrimSpace(filter)
+
+	mode := repository.CountMode(count)
+	switch mode {
+	case "", repository.CountExact:
+	case repository.CountEstimated:
+		if filter != "" {
+			return 0, errors.NewValidationError("count", mode, errors.ValidationFormat, "estimated counts cannot be filtered")
+		}
+	default:
+		return 0, errors.NewValidationError("count", mode, errors.ValidationFormat, "count must be one of: exact, estimated")
+	}
+
+	if !seesClients(rc) {
+		return 0, nil
+	}
+	return s.clientRepo.Count(repository.ClientCountFilter{Search: filter, Mode: mode, TenantScoped: true, TenantID: rc.TenantID})
+}
+
 // isValidUUID validates UUID format using the standard library
 func isValidUUID(id string) bool {
 	_, err := uuid.Parse(id)
 	return err == nil
 }
 
-// DeleteClient removes a client by ID
-func (s *BillingService) DeleteClient(id string) error {
-	// Basic UUID validation (reuse validation logic)
-	if strings.TrimSpace(id) == "" {
-		return errors.NewValidationError("id", id, errors.ValidationRequired, "client ID is required")
+// DeleteClient removes a client by ID; only billing admins may delete clients (PermissionDeleteClient)
+func (s *BillingService) DeleteClient(rc RequestContext, id string) error {
+	if err := rc.Authorize(PermissionDeleteClient); err != nil {
+		return err
 	}
 
-	if !isValidUUID(id) {
-		return errors.NewValidationError("id", id, errors.ValidationFormat, "client ID must be a valid UUID")
+	// Clients of other tenants are not found; the loaded client also gives the external reference to release
+	client, err := s.GetOwnedClient(rc, id)
+	if err != nil {
+		return err
+	}
+
+	// Apply the deletion policy to the invoices of the client; cascaded drafts are voided before the client goes,
+	// so a retry after a failed deletion finds them voided rather than orphaned
+	if s.invoices != nil {
+		invoices, err := s.invoices.GetAll()
+		if err != nil {
+			return err
+		}
+		cascade, err := s.deletion.Cascade(id, invoices)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		for _, invoice := range cascade {
+			if err := invoice.Void(now); err != nil {
+				return err
+			}
+			if err := s.invoices.Save(invoice); err != nil {
+				return err
+			}
+		}
 	}
 
 	// Delegate to repository
-	return s.clientRepo.Delete(id)
+	if err := s.clientRepo.Delete(id); err != nil {
+		return err
+	}
+	s.releaseReference(client)
+	s.deleteClientContacts(id)
+	s.deleteClientNotes(id)
+	s.recordTombstone(id)
+	s.clientChanged(entity.ClientDeleted, id, client.TenantID(), nil)
+
+	return s.recordChange(entity.ClientDeleted, id, client.TenantID(), nil)
 }
 
-// UpdateClient updates a client by ID
-func (s *BillingService) UpdateClient(id string, req dtos.UpdateClientRequest) (*entity.Client, error) {
-	// Basic UUID validation (reuse validation logic)
-	if strings.TrimSpace(id) == "" {
-		return nil, errors.NewValidationError("id", id, errors.ValidationRequired, "client ID is required")
+// UpdateClient updates a client of the caller's tenant by ID, validating regional fields against the caller's locale
+// Only billing admins may change clients (PermissionManageClients)
+func (s *BillingService) UpdateClient(rc RequestContext, id string, req dtos.UpdateClientRequest) (*entity.Client, error) {
+	if err := rc.Authorize(PermissionManageClients); err != nil {
+		return nil, err
 	}
 
-	if !isValidUUID(id) {
-		return nil, errors.NewValidationError("id", id, errors.ValidationFormat, "client ID must be a valid UUID")
+	// Get existing client (validates the ID; clients of other tenants are not found)
+	client, err := s.GetOwnedClient(rc, id)
+	if err != nil {
+		return nil, err
 	}
+	return s.updateClient(rc, client, req)
+}
 
+// updateClient updates the details of a loaded client the caller may act on
+func (s *BillingService) updateClient(rc RequestContext, client *entity.Client, req dtos.UpdateClientRequest) (*entity.Client, error) {
 	// Validate request data
 	if err := validateUpdateRequest(req); err != nil {
 		return nil, err
 	}
 
-	// Get existing client
-	client, err := s.clientRepo.GetByID(id)
-	if err != nil {
-		return nil, err // Repository error (including not found)
-	}
-
 	// Update client details using domain method
-	err = client.UpdateDetails(req.Name, req.Phone, req.Address)
+	err := client.UpdateDetailsInCountry(req.Name, req.Phone, req.Address, req.Country)
 	if err != nil {
 		return nil, err // Domain validation error
 	}
+	if err := client.SetPaymentTerms(req.PaymentTerms); err != nil {
+		return nil, err
+	}
+	client.NormalizeEmail(s.emails) // Re-key clients saved under an earlier policy
+	if err := s.beforeClientSave(RuleSubject{Operation: RuleUpdateClient, Context: rc, Client: client}); err != nil {
+		return nil, err
+	}
+	if err := s.rules.Check(RuleSubject{Operation: RuleUpdateClient, Context: rc, Client: client}); err != nil {
+		return nil, err
+	}
 
 	// Save updated client
 	err = s.clientRepo.Save(client)
@@ -161,6 +726,82 @@ func (s *BillingService) UpdateClient(id string, req dtos.UpdateClientRequest) (
 		return nil, err // Repository error
 	}
 
+	if err := s.recordChange(entity.ClientUpdated, client.ID(), client.TenantID(), client); err != nil {
+		return nil, err
+	}
+	s.clientChanged(entity.ClientUpdated, client.ID(), client.TenantID(), client)
+
+	return client, nil
+}
+
+// ChangeClientStatus moves a client of the caller's tenant to another lifecycle status (see
+// entity.Client.ChangeStatus); only billing admins may change clients (PermissionManageClients)
+func (s *BillingService) ChangeClientStatus(rc RequestContext, id string, status entity.ClientStatus) (*entity.Client, error) {
+	if err := rc.Authorize(PermissionManageClients); err != nil {
+		return nil, err
+	}
+	client, err := s.GetOwnedClient(rc, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.ChangeStatus(status); err != nil {
+		return nil, err
+	}
+	if err := s.clientRepo.Save(client); err != nil {
+		return nil, err
+	}
+
+	if err := s.recordChange(entity.ClientUpdated, client.ID(), client.TenantID(), client); err != nil {
+		return nil, err
+	}
+	s.clientChanged(entity.ClientUpdated, client.ID(), client.TenantID(), client)
+
+	return client, nil
+}
+
+// AddClientTags tags a client of the caller's tenant; tags it already carries are ignored
+// Only billing admins may change clients (PermissionManageClients)
+func (s *BillingService) AddClientTags(rc RequestContext, id string, tags []string) (*entity.Client, error) {
+	if err := rc.Authorize(PermissionManageClients); err != nil {
+		return nil, err
+	}
+	client, err := s.GetOwnedClient(rc, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.AddTags(tags...); err != nil {
+		return nil, err
+	}
+	return s.saveClientTags(client)
+}
+
+// RemoveClientTag removes a tag from a client of the caller's tenant; removing a tag the client does not carry
+// changes nothing. Only billing admins may change clients (PermissionManageClients)
+func (s *BillingService) RemoveClientTag(rc RequestContext, id, tag string) (*entity.Client, error) {
+	if err := rc.Authorize(PermissionManageClients); err != nil {
+		return nil, err
+	}
+	client, err := s.GetOwnedClient(rc, id)
+	if err != nil {
+		return nil, err
+	}
+	if !client.RemoveTag(tag) {
+		return client, nil
+	}
+	return s.saveClientTags(client)
+}
+
+// saveClientTags saves a client whose tags changed
+func (s *BillingService) saveClientTags(client *entity.Client) (*entity.Client, error) {
+	if err := s.clientRepo.Save(client); err != nil {
+		return nil, err
+	}
+
+	if err := s.recordChange(entity.ClientUpdated, client.ID(), client.TenantID(), client); err != nil {
+		return nil, err
+	}
+	s.clientChanged(entity.ClientUpdated, client.ID(), client.TenantID(), client)
+
 	return client, nil
 }
 
@@ -185,7 +826,10 @@ func validateUpdateRequest(req dtos.UpdateClientRequest) error {
 	}
 
 	// Basic phone format validation if provided
-	if req.Phone != "" && !isValidPhoneFormat(req.Phone) {
+	// National numbers are allowed when the client's country provides a default phone region
+	_, hasPhoneRegion := valueobject.LookupPhoneRegion(strings.ToUpper(strings.TrimSpace(req.Country)))
+	isNationalNumber := hasPhoneRegion && !strings.HasPrefix(strings.TrimSpace(req.Phone), "+")
+	if req.Phone != "" && !isNationalNumber && !isValidPhoneFormat(req.Phone) {
 		return errors.NewValidationError("phone", req.Phone, errors.ValidationFormat, "phone number format is invalid")
 	}
 
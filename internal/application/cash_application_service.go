@@ -0,0 +1,215 @@
+package application
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/bankstatement"
+)
+
+// bankTransactionResource is the audit resource type for imported bank transactions
+const bankTransactionResource = "bank_transaction"
+
+// OpenInvoiceSource lists the invoices awaiting payment that bank transactions are matched against
+type OpenInvoiceSource interface {
+	OpenInvoices() ([]service.OpenInvoice, error)
+}
+
+// StatementImport is the outcome of importing a bank statement file
+type StatementImport struct {
+	Format        bankstatement.Format
+	AccountIBAN   string
+	Imported      []*entity.BankTransaction
+	Duplicates    int // Bookings already imported from an earlier (overlapping) statement
+	SkippedDebits int // Outgoing payments, which cash application ignores
+}
+
+// CashApplicationService imports bank statements and applies the money received to open invoices
+type CashApplicationService struct {
+	transactionRepo repository.BankTransactionRepository
+	auditService    *AuditService
+	billingService  *BillingService
+	openInvoices    OpenInvoiceSource
+}
+
+// NewCashApplicationService creates a new cash application service
+// Reconciled transactions are recorded as payments of their invoice through billingService, whose open invoices
+// the transactions are matched against
+func NewCashApplicationService(transactionRepo repository.BankTransactionRepository, auditService *AuditService, billingService *BillingService) *CashApplicationService {
+	return &CashApplicationService{
+		transactionRepo: transactionRepo,
+		auditService:    auditService,
+		billingService:  billingService,
+		openInvoices:    billingService,
+	}
+}
+
+// WithOpenInvoices matches transactions against the open invoices listed by source instead
+func (s *CashApplicationService) WithOpenInvoices(source OpenInvoiceSource) *CashApplicationService {
+	s.openInvoices = source
+	return s
+}
+
+// ImportStatement parses a camt.053 or MT940 statement (detected when format is empty)
+// and stores the money received that was not imported before
+func (s *CashApplicationService) ImportStatement(format string, data []byte) (*StatementImport, error) {
+	if format != "" && format != string(bankstatement.FormatCAMT053) && format != string(bankstatement.FormatMT940) {
+		return nil, errors.NewValidationError("format", format, errors.ValidationFormat, "format must be one of: camt053, mt940")
+	}
+	if len(data) == 0 {
+		return nil, errors.NewValidationError("statement", "", errors.ValidationRequired, "statement file is required")
+	}
+
+	statement, err := bankstatement.Parse(bankstatement.Format(format), data)
+	if err != nil {
+		return nil, errors.NewValidationError("statement", format, errors.ValidationFormat, err.Error())
+	}
+
+	existing, err := s.transactionRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	imported := make(map[string]bool, len(existing))
+	for _, transaction := range existing {
+		imported[transaction.Fingerprint()] = true
+	}
+
+	result := &StatementImport{
+		Format:      statement.Format,
+		AccountIBAN: statement.AccountIBAN,
+		Imported:    make([]*entity.BankTransaction, 0, len(statement.Transactions)),
+	}
+	for _, line := range statement.Transactions {
+		if line.Amount <= 0 {
+			result.SkippedDebits++
+			continue
+		}
+
+		amount, err := valueobject.NewMoney(line.Amount, line.Currency)
+		if err != nil {
+			return nil, err
+		}
+		transaction, err := entity.NewBankTransaction(statement.AccountIBAN, line.BankReference, line.BookingDate, amount, line.Reference, line.CounterpartyName, line.CounterpartyIBAN)
+		if err != nil {
+			return nil, err
+		}
+		if imported[transaction.Fingerprint()] {
+			result.Duplicates++
+			continue
+		}
+
+		if err := s.transactionRepo.Save(transaction); err != nil {
+			return nil, err
+		}
+		result.Imported = append(result.Imported, transaction)
+	}
+
+	return result, nil
+}
+
+// GetTransaction retrieves an imported bank transaction by its ID
+func (s *CashApplicationService) GetTransaction(id string) (*entity.BankTransaction, error) {
+	return s.transactionRepo.GetByID(id)
+}
+
+// ListTransactions retrieves imported bank transactions by booking date, optionally filtered by status
+func (s *CashApplicationService) ListTransactions(status string) ([]*entity.BankTransaction, error) {
+	transactions, err := s.transactionRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if status == "" {
+		return transactions, nil
+	}
+
+	filtered := make([]*entity.BankTransaction, 0, len(transactions))
+	for _, transaction := range transactions {
+		if string(transaction.Status()) == status {
+			filtered = append(filtered, transaction)
+		}
+	}
+	return filtered, nil
+}
+
+// SuggestMatches ranks the open invoices an unmatched transaction may pay
+// No suggestions are made for reconciled transactions or without an open invoice source
+func (s *CashApplicationService) SuggestMatches(transaction *entity.BankTransaction) ([]service.CashMatch, error) {
+	if s.openInvoices == nil || transaction.Status() != entity.BankTransactionUnmatched {
+		return []service.CashMatch{}, nil
+	}
+
+	openInvoices, err := s.openInvoices.OpenInvoices()
+	if err != nil {
+		return nil, err
+	}
+	return service.SuggestCashMatches(transaction, openInvoices), nil
+}
+
+// Reconcile applies a transaction to the invoice finance confirmed it pays, records it as a bank transfer paying
+// the invoice and writes the decision to the audit log
+// The payment is keyed by the transaction ID: a reconciliation retried after a failure records it once, and it is
+// reversed when the transaction cannot be saved, so a payment is never recorded for an unmatched transaction
+func (s *CashApplicationService) Reconcile(actor, id string, req dtos.ReconcileBankTransactionRequest) (*entity.BankTransaction, error) {
+	transaction, err := s.transactionRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if invoiceID := strings.TrimSpace(req.InvoiceID); invoiceID != "" {
+		invoice, err := s.billingService.invoiceByID(invoiceID)
+		if err != nil {
+			return nil, err
+		}
+		if invoice.ClientID() != strings.TrimSpace(req.ClientID) {
+			return nil, errors.NewValidationError("client_id", req.ClientID, errors.ValidationFormat, "client_id must be the client of the invoice")
+		}
+	}
+	if err := transaction.Reconcile(req.InvoiceID, req.ClientID, actor); err != nil {
+		return nil, err
+	}
+
+	rc := AdminContext(actor)
+	existing, err := s.billingService.FindPayment(transaction.InvoiceID(), transaction.ID())
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		bookingDate := transaction.BookingDate()
+		_, _, err := s.billingService.RecordPayment(rc, transaction.InvoiceID(), dtos.RecordPaymentRequest{
+			Amount:     transaction.Amount().Amount(),
+			Currency:   transaction.Amount().Currency(),
+			Method:     string(entity.PaymentBankTransfer),
+			Reference:  transaction.ID(),
+			ReceivedAt: &bookingDate,
+		}, *transaction.ReconciledAt())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.transactionRepo.Save(transaction); err != nil {
+		if _, reverseErr := s.billingService.ReversePayment(rc, transaction.InvoiceID(), transaction.ID()); reverseErr != nil {
+			return nil, fmt.Errorf("%w (reversing the payment recorded for it failed: %v)", err, reverseErr)
+		}
+		return nil, err
+	}
+
+	details := map[string]interface{}{
+		"invoice_id": transaction.InvoiceID(),
+		"client_id":  transaction.ClientID(),
+		"amount":     transaction.Amount().Amount(),
+		"currency":   transaction.Amount().Currency(),
+	}
+	if err := s.auditService.Record(AuditActionBankTransactionReconciled, actor, "", bankTransactionResource, transaction.ID(), details); err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
@@ -0,0 +1,77 @@
+package application
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+)
+
+// Client change feed limits
+const (
+	DefaultClientChangesLimit = 100
+	MaxClientChangesLimit     = 1000
+)
+
+// ClientChangesPage is a batch of the client change feed
+type ClientChangesPage struct {
+	Changes []*entity.ClientChange
+	// NextCursor is passed back as "since" to resume after the last returned change
+	NextCursor string
+	HasMore    bool
+}
+
+// ListClientChanges returns the changes of the clients of the caller's tenant after since, ordered by change
+// sequence. since is either a cursor (a change sequence returned by a previous call) or an RFC 3339 timestamp;
+// an empty since starts from the beginning of the change log
+// Changes of other tenants are skipped, and the cursor moves past them: a page may hold fewer changes than limit
+func (s *BillingService) ListClientChanges(rc RequestContext, since string, limit int) (*ClientChangesPage, error) {
+	if s.changeRepo == nil {
+		return nil, errors.NewBusinessRuleError("change_log_enabled", errors.BusinessRuleViolation, "client change log is not enabled")
+	}
+
+	if limit <= 0 {
+		limit = DefaultClientChangesLimit
+	}
+	if limit > MaxClientChangesLimit {
+		return nil, errors.NewValidationError("limit", limit, errors.ValidationRange, "limit must not exceed 1000")
+	}
+
+	since = strings.TrimSpace(since)
+
+	// Fetch one extra change to know whether more are available
+	var changes []*entity.ClientChange
+	var err error
+	if sequence, parseErr := strconv.ParseInt(since, 10, 64); since == "" || parseErr == nil {
+		changes, err = s.changeRepo.ListAfterSequence(sequence, limit+1)
+	} else if timestamp, parseErr := time.Parse(time.RFC3339, since); parseErr == nil {
+		changes, err = s.changeRepo.ListSince(timestamp, limit+1)
+	} else {
+		return nil, errors.NewValidationError("since", since, errors.ValidationFormat, "since must be a change cursor or an RFC 3339 timestamp")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	page := &ClientChangesPage{
+		Changes:    make([]*entity.ClientChange, 0, len(changes)),
+		NextCursor: since,
+	}
+	scanned := changes
+	if len(changes) > limit {
+		scanned = changes[:limit]
+		page.HasMore = true
+	}
+	for _, change := range scanned {
+		if rc.CanAccessTenant(change.TenantID()) {
+			page.Changes = append(page.Changes, change)
+		}
+	}
+	if len(scanned) > 0 {
+		page.NextCursor = strconv.FormatInt(scanned[len(scanned)-1].Sequence(), 10)
+	}
+
+	return page, nil
+}
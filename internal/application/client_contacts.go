@@ -0,0 +1,110 @@
+package application
+
+import (
+	"log"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+)
+
+// ListClientContacts retrieves the contacts of a client, oldest first
+func (s *BillingService) ListClientContacts(clientID string) ([]*entity.ClientContact, error) {
+	if err := s.requireContacts(); err != nil {
+		return nil, err
+	}
+	if _, err := s.clientByID(clientID); err != nil {
+		return nil, err
+	}
+	return s.contacts.ListByClient(clientID)
+}
+
+// AddClientContact adds a contact to an existing client
+func (s *BillingService) AddClientContact(clientID string, req dtos.ClientContactRequest) (*entity.ClientContact, error) {
+	if err := s.requireContacts(); err != nil {
+		return nil, err
+	}
+	if _, err := s.clientByID(clientID); err != nil {
+		return nil, err
+	}
+
+	contact, err := entity.NewClientContact(clientID, req.Name, req.Role, req.Email, req.Phone)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.contacts.Save(contact); err != nil {
+		return nil, err
+	}
+	return contact, nil
+}
+
+// GetClientContact retrieves a contact of a client
+// Contacts of other clients are not found, so a contact is only reached through its own client
+func (s *BillingService) GetClientContact(clientID, contactID string) (*entity.ClientContact, error) {
+	if err := s.requireContacts(); err != nil {
+		return nil, err
+	}
+	if _, err := s.clientByID(clientID); err != nil {
+		return nil, err
+	}
+
+	contact, err := s.contacts.GetByID(contactID)
+	if err != nil {
+		return nil, err
+	}
+	if contact.ClientID() != clientID {
+		return nil, errors.ErrClientContactNotFound
+	}
+	return contact, nil
+}
+
+// UpdateClientContact replaces the details of a contact of a client
+func (s *BillingService) UpdateClientContact(clientID, contactID string, req dtos.ClientContactRequest) (*entity.ClientContact, error) {
+	contact, err := s.GetClientContact(clientID, contactID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := contact.Update(req.Name, req.Role, req.Email, req.Phone); err != nil {
+		return nil, err
+	}
+	if err := s.contacts.Save(contact); err != nil {
+		return nil, err
+	}
+	return contact, nil
+}
+
+// DeleteClientContact removes a contact of a client
+func (s *BillingService) DeleteClientContact(clientID, contactID string) error {
+	if _, err := s.GetClientContact(clientID, contactID); err != nil {
+		return err
+	}
+	return s.contacts.Delete(contactID)
+}
+
+// deleteClientContacts removes the contacts of a deleted client
+// The client is already deleted, so a failure is logged rather than returned: its contacts are unreachable anyway
+func (s *BillingService) deleteClientContacts(clientID string) {
+	if s.contacts == nil {
+		return
+	}
+
+	contacts, err := s.contacts.ListByClient(clientID)
+	if err != nil {
+		log.Printf("Failed to list contacts of deleted client %s: %v", clientID, err)
+		return
+	}
+	for _, contact := range contacts {
+		if err := s.contacts.Delete(contact.ID()); err != nil {
+			log.Printf("Failed to delete contact %s of deleted client %s: %v", contact.ID(), clientID, err)
+		}
+	}
+}
+
+// requireContacts reports a service built without a client contact repository
+func (s *BillingService) requireContacts() error {
+	if s.contacts == nil {
+		return errors.NewBusinessRuleError("contacts_enabled", errors.BusinessRuleViolation, "client contacts are not enabled")
+	}
+	return nil
+}
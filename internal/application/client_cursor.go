@@ -0,0 +1,102 @@
+package application
+
+import (
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+)
+
+// ClientCursorPage is a page of a client listing paged by cursor
+type ClientCursorPage struct {
+	Clients []*entity.Client
+	Limit   int
+	// NextCursor is passed back as "cursor" to continue after the last returned client
+	NextCursor string
+	HasMore    bool
+}
+
+// ListClientsAfterCursor retrieves the clients of the caller's tenant matching a filter that come after cursor in
+// creation order (keyset pagination). An empty cursor starts from the first client
+// Unlike ListClientsWithFilter, reading a page does not skip over the clients before it, and clients created or
+// deleted between two pages neither repeat nor skip clients. The cursor does not carry the filter: callers pass the
+// same filter with every page. Cursor pages are always in creation order, so the filter cannot have a sort
+func (s *BillingService) ListClientsAfterCursor(rc RequestContext, filter ClientFilter, cursor string, limit int) (*ClientCursorPage, error) {
+	if strings.TrimSpace(filter.Sort) != "" {
+		return nil, errors.NewValidationError("sort", filter.Sort, errors.ValidationFormat,
+			"sort cannot be combined with cursor pagination, cursor pages are in creation order")
+	}
+	if err := validatePageLimit(limit); err != nil {
+		return nil, err
+	}
+	after, err := decodeClientCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	criteria, err := s.clientListCriteria(rc, filter)
+	if err != nil {
+		return nil, err
+	}
+	if !seesClients(rc) {
+		return &ClientCursorPage{Clients: []*entity.Client{}, Limit: limit, NextCursor: encodeClientCursor(after)}, nil
+	}
+
+	page, err := s.clientRepo.ListAfter(criteria, after, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientCursorPage{
+		Clients:    page.Clients,
+		Limit:      limit,
+		NextCursor: encodeClientCursor(page.Next),
+		HasMore:    page.HasMore,
+	}, nil
+}
+
+// encodeClientCursor encodes a position in creation order as an opaque cursor (empty for the start)
+func encodeClientCursor(position repository.ClientPosition) string {
+	if position.IsZero() {
+		return ""
+	}
+	return encodePositionCursor(position.CreatedAt, position.ID)
+}
+
+// decodeClientCursor decodes a cursor returned by encodeClientCursor
+func decodeClientCursor(cursor string) (repository.ClientPosition, error) {
+	createdAt, id, err := decodePositionCursor(cursor)
+	if err != nil {
+		return repository.ClientPosition{}, err
+	}
+	return repository.ClientPosition{CreatedAt: createdAt, ID: id}, nil
+}
+
+// encodePositionCursor encodes the creation time and ID of the last entity of a page as an opaque cursor
+func encodePositionCursor(createdAt time.Time, id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(createdAt.UTC().Format(time.RFC3339Nano) + "," + id))
+}
+
+// decodePositionCursor decodes a cursor returned by encodePositionCursor (zero values for an empty cursor)
+func decodePositionCursor(cursor string) (time.Time, string, error) {
+	cursor = strings.TrimSpace(cursor)
+	if cursor == "" {
+		return time.Time{}, "", nil
+	}
+	invalid := errors.NewValidationError("cursor", cursor, errors.ValidationFormat,
+		"cursor must be the next_cursor of a previous page")
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, "", invalid
+	}
+	timestamp, id, found := strings.Cut(string(decoded), ",")
+	if !found || id == "" {
+		return time.Time{}, "", invalid
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, timestamp)
+	if err != nil {
+		return time.Time{}, "", invalid
+	}
+	return createdAt, id, nil
+}
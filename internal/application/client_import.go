@@ -0,0 +1,284 @@
+package application
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/csvimport"
+)
+
+// Client import previews
+const (
+	DefaultImportSampleRows = 5
+	MaxImportSampleRows     = 20
+)
+
+// ClientImportField is a client field the columns of an imported CSV file are mapped to
+type ClientImportField string
+
+const (
+	ClientImportName         ClientImportField = "name"
+	ClientImportEmail        ClientImportField = "email"
+	ClientImportPhone        ClientImportField = "phone"
+	ClientImportAddress      ClientImportField = "address"
+	ClientImportCountry      ClientImportField = "country"
+	ClientImportExternalRef  ClientImportField = "external_ref"
+	ClientImportPaymentTerms ClientImportField = "payment_terms"
+)
+
+// ClientImportFieldInfo describes a client field columns can be mapped to
+type ClientImportFieldInfo struct {
+	Field    ClientImportField
+	Required bool
+}
+
+// ClientImportFields lists the client fields columns can be mapped to, in the order of the client form
+var ClientImportFields = []ClientImportFieldInfo{
+	{Field: ClientImportName, Required: true},
+	{Field: ClientImportEmail, Required: true},
+	{Field: ClientImportPhone},
+	{Field: ClientImportAddress},
+	{Field: ClientImportCountry},
+	{Field: ClientImportExternalRef},
+	{Field: ClientImportPaymentTerms},
+}
+
+// clientImportAliases are the column names (lower case, letters and digits only) suggested for each field
+var clientImportAliases = map[ClientImportField][]string{
+	ClientImportName:         {"name", "clientname", "client", "company", "companyname", "customer", "customername"},
+	ClientImportEmail:        {"email", "emailaddress", "mail", "billingemail"},
+	ClientImportPhone:        {"phone", "phonenumber", "telephone", "tel", "mobile"},
+	ClientImportAddress:      {"address", "billingaddress", "postaladdress"},
+	ClientImportCountry:      {"country", "countrycode"},
+	ClientImportExternalRef:  {"externalref", "externalreference", "reference", "ref", "customerid", "clientid"},
+	ClientImportPaymentTerms: {"paymentterms", "terms"},
+}
+
+// ClientImportMapping maps client fields to the CSV columns they are read from; unmapped fields are left empty
+type ClientImportMapping map[ClientImportField]string
+
+// ClientImportPreview is what the mapping step of an import shows before the file is imported
+type ClientImportPreview struct {
+	Delimiter  string
+	Columns    []string
+	SampleRows [][]string
+	Suggested  ClientImportMapping // Columns whose name matches a field; callers confirm or change it
+}
+
+// ClientImportRowError is a row of an imported file that was not imported
+type ClientImportRowError struct {
+	Line int
+	Err  error
+}
+
+// ClientImportRow is a row of an imported file that was imported
+type ClientImportRow struct {
+	Line     int
+	ClientID string
+}
+
+// ClientImport is the outcome of a client import
+type ClientImport struct {
+	Mapping  ClientImportMapping // Columns the client fields were read from
+	Rows     int                 // Data rows read
+	Imported int
+	Created  []ClientImportRow
+	Failed   []ClientImportRowError
+}
+
+// PreviewClientImport reads the header and the first rows of a CSV file of clients, so callers can map its columns
+// to client fields. Only the rows previewed are read from r
+func (s *BillingService) PreviewClientImport(r io.Reader, sampleRows int) (*ClientImportPreview, error) {
+	if sampleRows <= 0 {
+		sampleRows = DefaultImportSampleRows
+	}
+	if sampleRows > MaxImportSampleRows {
+		return nil, errors.NewValidationError("sample", sampleRows, errors.ValidationRange,
+			fmt.Sprintf("sample must not exceed %d rows", MaxImportSampleRows))
+	}
+
+	reader, err := csvimport.NewReader(r)
+	if err != nil {
+		return nil, errors.NewValidationError("file", "", errors.ValidationFormat, err.Error())
+	}
+	preview := &ClientImportPreview{
+		Delimiter:  string(reader.Delimiter()),
+		Columns:    reader.Columns(),
+		SampleRows: make([][]string, 0, sampleRows),
+		Suggested:  suggestClientImportMapping(reader.Columns()),
+	}
+	for len(preview.SampleRows) < sampleRows {
+		row, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil && row.Line == 0 {
+			return nil, err
+		}
+		if err != nil {
+			continue // Malformed rows are reported by the import
+		}
+		preview.SampleRows = append(preview.SampleRows, row.Values)
+	}
+	return preview, nil
+}
+
+// suggestClientImportMapping maps the fields to the first column named like one of their aliases
+func suggestClientImportMapping(columns []string) ClientImportMapping {
+	mapping := ClientImportMapping{}
+	for _, info := range ClientImportFields {
+		for _, column := range columns {
+			if slices.Contains(clientImportAliases[info.Field], aliasKey(column)) && !slices.Contains(mappedColumns(mapping), column) {
+				mapping[info.Field] = column
+				break
+			}
+		}
+	}
+	return mapping
+}
+
+// aliasKey lowercases a column name and keeps its letters and digits ("E-mail Address" -> "emailaddress")
+func aliasKey(column string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, column)
+}
+
+// mappedColumns lists the columns a mapping reads from
+func mappedColumns(mapping ClientImportMapping) []string {
+	columns := make([]string, 0, len(mapping))
+	for _, column := range mapping {
+		columns = append(columns, column)
+	}
+	return columns
+}
+
+// validateClientImportMapping checks a mapping against the columns of a file: fields are known, required fields
+// are mapped, and every column exists and is read by one field only. It returns the index of the column of each
+// mapped field
+func validateClientImportMapping(mapping ClientImportMapping, columns []string) (map[ClientImportField]int, error) {
+	indexes := make(map[ClientImportField]int, len(mapping))
+	fieldOf := make(map[string]ClientImportField, len(mapping))
+	for field, column := range mapping {
+		if !slices.ContainsFunc(ClientImportFields, func(info ClientImportFieldInfo) bool { return info.Field == field }) {
+			return nil, errors.NewValidationError("mapping", field, errors.ValidationFormat,
+				fmt.Sprintf("%q is not a client field", field))
+		}
+		index := slices.Index(columns, column)
+		if index < 0 {
+			return nil, errors.NewValidationError("mapping", column, errors.ValidationFormat,
+				fmt.Sprintf("column %q of %s is not in the file", column, field))
+		}
+		if other, mapped := fieldOf[column]; mapped {
+			return nil, errors.NewValidationError("mapping", column, errors.ValidationFormat,
+				fmt.Sprintf("column %q is mapped to both %s and %s", column, min(field, other), max(field, other)))
+		}
+		fieldOf[column] = field
+		indexes[field] = index
+	}
+	for _, info := range ClientImportFields {
+		if _, mapped := indexes[info.Field]; info.Required && !mapped {
+			return nil, errors.NewValidationError("mapping", "", errors.ValidationRequired,
+				fmt.Sprintf("%s must be mapped to a column", info.Field))
+		}
+	}
+	return indexes, nil
+}
+
+// ImportClients creates the clients of a CSV file of the caller's tenant, reading each row's fields from the
+// columns of mapping. The file is streamed: each row is created as it is read, with the validation and business
+// rules of CreateClient, and rows that fail are reported without stopping the import. A row with the email
+// address of a row imported before it is a duplicate, spellings being folded by the email normalization policy
+// A server error stops the import; the rows created before it stay created
+func (s *BillingService) ImportClients(rc RequestContext, r io.Reader, mapping ClientImportMapping) (*ClientImport, error) {
+	reader, err := csvimport.NewReader(r)
+	if err != nil {
+		return nil, errors.NewValidationError("file", "", errors.ValidationFormat, err.Error())
+	}
+	return s.importClients(rc, reader, mapping)
+}
+
+// ImportClientsWithSuggestedMapping imports the clients of a CSV file like ImportClients, reading each field from
+// the column suggested by the preview. Files whose columns are not named like the required fields are refused
+func (s *BillingService) ImportClientsWithSuggestedMapping(rc RequestContext, r io.Reader) (*ClientImport, error) {
+	reader, err := csvimport.NewReader(r)
+	if err != nil {
+		return nil, errors.NewValidationError("file", "", errors.ValidationFormat, err.Error())
+	}
+	return s.importClients(rc, reader, suggestClientImportMapping(reader.Columns()))
+}
+
+// importClients creates the clients of the rows left in reader
+func (s *BillingService) importClients(rc RequestContext, reader *csvimport.Reader, mapping ClientImportMapping) (*ClientImport, error) {
+	indexes, err := validateClientImportMapping(mapping, reader.Columns())
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ClientImport{Mapping: mapping}
+	importedEmails := make(map[string]int) // Canonical address -> line it was imported from
+	for {
+		row, err := reader.Next()
+		if err == io.EOF {
+			return result, nil
+		}
+		if err != nil && row.Line == 0 {
+			return nil, err
+		}
+		result.Rows++
+		if err != nil {
+			result.Failed = append(result.Failed, ClientImportRowError{Line: row.Line,
+				Err: errors.NewValidationError("file", row.Line, errors.ValidationFormat, err.Error())})
+			continue
+		}
+
+		value := func(field ClientImportField) string {
+			if index, mapped := indexes[field]; mapped {
+				return row.Values[index]
+			}
+			return ""
+		}
+		email, emailErr := valueobject.NewEmail(value(ClientImportEmail))
+		if line, imported := importedEmails[email.Canonical(s.emails)]; emailErr == nil && imported {
+			result.Failed = append(result.Failed, ClientImportRowError{Line: row.Line, Err: duplicateImportRow(line)})
+			continue
+		}
+		client, err := s.CreateClient(rc, dtos.CreateClientRequest{
+			Name:         value(ClientImportName),
+			Email:        value(ClientImportEmail),
+			Phone:        value(ClientImportPhone),
+			Address:      value(ClientImportAddress),
+			Country:      value(ClientImportCountry),
+			ExternalRef:  value(ClientImportExternalRef),
+			PaymentTerms: value(ClientImportPaymentTerms),
+		})
+		if err != nil && !errors.IsClientError(err) {
+			return nil, err
+		}
+		if err != nil {
+			result.Failed = append(result.Failed, ClientImportRowError{Line: row.Line, Err: err})
+			continue
+		}
+		importedEmails[client.EmailKey()] = row.Line
+		result.Created = append(result.Created, ClientImportRow{Line: row.Line, ClientID: client.ID()})
+		result.Imported++
+	}
+}
+
+// duplicateImportRow refuses a row with the email address of the row imported from line
+func duplicateImportRow(line int) error {
+	duplicate := errors.NewBusinessRuleError("email_uniqueness", errors.BusinessRuleDuplicate,
+		fmt.Sprintf("the email address was already imported from line %d of the file", line))
+	duplicate.Context["match"] = "email"
+	duplicate.Context["duplicate_of_line"] = line
+	return duplicate
+}
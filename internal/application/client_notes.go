@@ -0,0 +1,101 @@
+package application
+
+import (
+	"log"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+)
+
+// PaginatedClientNotes represents a page of the activity timeline of a client
+type PaginatedClientNotes struct {
+	Notes      []*entity.ClientNote
+	Pagination PaginationMeta
+}
+
+// ListClientNotes retrieves a page of the notes of a client, newest first
+func (s *BillingService) ListClientNotes(clientID string, page, limit int) (*PaginatedClientNotes, error) {
+	if err := s.requireNotes(); err != nil {
+		return nil, err
+	}
+	if _, err := s.clientByID(clientID); err != nil {
+		return nil, err
+	}
+
+	notes, err := s.notes.ListByClient(clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	totalCount := len(notes)
+	totalPages := totalCount / limit
+	if totalCount%limit > 0 {
+		totalPages++
+	}
+
+	offset := (page - 1) * limit
+	if offset > totalCount {
+		offset = totalCount
+	}
+	end := offset + limit
+	if end > totalCount {
+		end = totalCount
+	}
+
+	return &PaginatedClientNotes{
+		Notes: notes[offset:end],
+		Pagination: PaginationMeta{
+			Page:       page,
+			Limit:      limit,
+			TotalCount: totalCount,
+			TotalPages: totalPages,
+		},
+	}, nil
+}
+
+// AddClientNote records a note on the timeline of an existing client, attributed to the caller when known
+func (s *BillingService) AddClientNote(rc RequestContext, clientID string, req dtos.ClientNoteRequest) (*entity.ClientNote, error) {
+	if err := s.requireNotes(); err != nil {
+		return nil, err
+	}
+	if _, err := s.clientByID(clientID); err != nil {
+		return nil, err
+	}
+
+	note, err := entity.NewClientNote(clientID, req.Body, rc.Actor())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.notes.Save(note); err != nil {
+		return nil, err
+	}
+	return note, nil
+}
+
+// deleteClientNotes removes the notes of a deleted client
+// The client is already deleted, so a failure is logged rather than returned: its notes are unreachable anyway
+func (s *BillingService) deleteClientNotes(clientID string) {
+	if s.notes == nil {
+		return
+	}
+
+	notes, err := s.notes.ListByClient(clientID)
+	if err != nil {
+		log.Printf("Failed to list notes of deleted client %s: %v", clientID, err)
+		return
+	}
+	for _, note := range notes {
+		if err := s.notes.Delete(note.ID()); err != nil {
+			log.Printf("Failed to delete note %s of deleted client %s: %v", note.ID(), clientID, err)
+		}
+	}
+}
+
+// requireNotes reports a service built without a client note repository
+func (s *BillingService) requireNotes() error {
+	if s.notes == nil {
+		return errors.NewBusinessRuleError("notes_enabled", errors.BusinessRuleViolation, "client notes are not enabled")
+	}
+	return nil
+}
@@ -0,0 +1,294 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/document"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/messaging"
+)
+
+// ClientStatementTopic is the message bus topic of the statements notifications email to clients
+const ClientStatementTopic = "billing.notifications.client_statements"
+
+// ClientActivitySource lists the invoices, credit notes and payments on the account of a client
+type ClientActivitySource interface {
+	ClientActivity(clientID string) ([]service.StatementEntry, error)
+}
+
+// ClientStatementEvent is the payload published for a statement to email, with the document to attach
+type ClientStatementEvent struct {
+	StatementID    string    `json:"statement_id"`
+	TenantID       string    `json:"tenant_id,omitempty"`
+	ClientID       string    `json:"client_id"`
+	Recipient      string    `json:"recipient"`
+	Locale         string    `json:"locale,omitempty"`
+	From           time.Time `json:"from"`
+	To             time.Time `json:"to"`
+	Currency       string    `json:"currency"`
+	ClosingBalance int64     `json:"closing_balance"`
+	ContentType    string    `json:"content_type"`
+	Document       string    `json:"document"`
+	GeneratedAt    time.Time `json:"generated_at"`
+}
+
+// ClientStatementService takes client statement requests and generates them on scheduler runs:
+// the statement is built from the client activity, laid out with the tenant document template and
+// emailed through the notifications when asked
+type ClientStatementService struct {
+	jobRepo        repository.ClientStatementJobRepository
+	billingService *BillingService
+	documents      *DocumentTemplateService
+	publisher      messaging.Publisher
+	activity       ClientActivitySource
+	legalEntities  *LegalEntityService
+	currencies     *CurrencyPolicies
+}
+
+// NewClientStatementService creates a new client statement service
+// Without an activity source every statement is empty
+func NewClientStatementService(jobRepo repository.ClientStatementJobRepository, billingService *BillingService, documents *DocumentTemplateService, publisher messaging.Publisher) *ClientStatementService {
+	return &ClientStatementService{
+		jobRepo:        jobRepo,
+		billingService: billingService,
+		documents:      documents,
+		publisher:      publisher,
+	}
+}
+
+// WithActivity builds statements from the invoices, credit notes and payments listed by source
+func (s *ClientStatementService) WithActivity(source ClientActivitySource) *ClientStatementService {
+	s.activity = source
+	return s
+}
+
+// WithLegalEntities prints the default legal entity as the sender of statements
+func (s *ClientStatementService) WithLegalEntities(legalEntities *LegalEntityService) *ClientStatementService {
+	s.legalEntities = legalEntities
+	return s
+}
+
+// WithCurrencyPolicies states statements of clients without activity in the default currency of their tenant
+func (s *ClientStatementService) WithCurrencyPolicies(currencies *CurrencyPolicies) *ClientStatementService {
+	s.currencies = currencies
+	return s
+}
+
+// RequestStatement records a statement request for a client, generated by the next scheduler run
+// Statements are emailed when asked, or when a recipient is given, to the client email address by default
+func (s *ClientStatementService) RequestStatement(tenantID, clientID string, req dtos.ClientStatementRequest, locale valueobject.Locale) (*entity.ClientStatementJob, error) {
+	client, err := s.billingService.clientByID(clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	recipient := req.Recipient
+	if req.Email && recipient == "" {
+		recipient = client.EmailString()
+	}
+
+	job, err := entity.NewClientStatementJob(tenantID, client.ID(), req.Currency, req.From, req.To, locale.String(), recipient)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.jobRepo.Save(job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// GetJob retrieves a statement request of a client
+func (s *ClientStatementService) GetJob(clientID, jobID string) (*entity.ClientStatementJob, error) {
+	job, err := s.jobRepo.GetByID(jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job.ClientID() != clientID {
+		return nil, errors.ErrStatementJobNotFound
+	}
+	return job, nil
+}
+
+// GetDocument retrieves the generated document of a statement request of a client
+func (s *ClientStatementService) GetDocument(clientID, jobID string) ([]byte, error) {
+	job, err := s.GetJob(clientID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status() != entity.StatementJobCompleted {
+		return nil, errors.ErrStatementNotReady
+	}
+	return []byte(job.Document()), nil
+}
+
+// QueueDepth counts the statements waiting for generation (operational dashboard)
+func (s *ClientStatementService) QueueDepth() (int, error) {
+	jobs, err := s.jobRepo.GetPending()
+	if err != nil {
+		return 0, err
+	}
+	return len(jobs), nil
+}
+
+// ProcessPending generates the pending statements, oldest first, and emails those asked for (scheduler entry point)
+// Statements that cannot be built are marked failed; a failed publication leaves the statement pending for the next run
+func (s *ClientStatementService) ProcessPending(ctx context.Context, now time.Time) ([]*entity.ClientStatementJob, error) {
+	jobs, err := s.jobRepo.GetPending()
+	if err != nil {
+		return nil, err
+	}
+
+	processed := make([]*entity.ClientStatementJob, 0, len(jobs))
+	for _, job := range jobs {
+		statement, html, err := s.generate(job)
+		if err != nil {
+			if !isStatementFailure(err) {
+				return processed, err
+			}
+			job.Fail(err.Error(), now)
+		} else {
+			if job.Recipient() != "" {
+				message, err := clientStatementMessage(job, statement, html, now)
+				if err != nil {
+					return processed, err
+				}
+				if err := s.publisher.Publish(ctx, message); err != nil {
+					return processed, err
+				}
+			}
+			job.Complete(statement.Currency, html, statement.ClosingBalance, now)
+		}
+
+		if err := s.jobRepo.Save(job); err != nil {
+			return processed, err
+		}
+		processed = append(processed, job)
+	}
+	return processed, nil
+}
+
+// generate builds the statement of a request and renders its document
+func (s *ClientStatementService) generate(job *entity.ClientStatementJob) (service.ClientStatement, string, error) {
+	client, err := s.billingService.clientByID(job.ClientID())
+	if err != nil {
+		return service.ClientStatement{}, "", err
+	}
+
+	var entries []service.StatementEntry
+	if s.activity != nil {
+		if entries, err = s.activity.ClientActivity(client.ID()); err != nil {
+			return service.ClientStatement{}, "", err
+		}
+	}
+
+	currency, err := s.statementCurrency(job, entries)
+	if err != nil {
+		return service.ClientStatement{}, "", err
+	}
+	statement, err := service.BuildClientStatement(currency, job.From(), job.To(), entries)
+	if err != nil {
+		return service.ClientStatement{}, "", err
+	}
+
+	layout, err := s.documents.TemplateFor(job.TenantID())
+	if err != nil {
+		return service.ClientStatement{}, "", err
+	}
+	locale, _ := valueobject.NewLocale(job.Locale()) // Zero (English) for requests without a locale
+	content := document.Statement{
+		Client:    document.Party{Name: client.Name(), Address: client.Address()},
+		Statement: statement,
+		Locale:    locale,
+	}
+	if s.legalEntities != nil {
+		seller, err := s.legalEntities.ResolveEntity("")
+		if err != nil {
+			return service.ClientStatement{}, "", err
+		}
+		if seller != nil {
+			content.Seller = document.Party{Name: seller.Name(), Address: seller.Address()}
+			for _, registration := range seller.TaxRegistrations() {
+				content.Seller.TaxIDs = append(content.Seller.TaxIDs, registration.Number)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := document.RenderStatementHTML(&buf, layout, content); err != nil {
+		return service.ClientStatement{}, "", err
+	}
+	return statement, buf.String(), nil
+}
+
+// statementCurrency returns the currency of a statement: the requested one, else the single currency of the client
+// activity, else the default currency of the tenant; clients with activity in several currencies must choose one
+func (s *ClientStatementService) statementCurrency(job *entity.ClientStatementJob, entries []service.StatementEntry) (string, error) {
+	if job.Currency() != "" {
+		return job.Currency(), nil
+	}
+
+	seen := make(map[string]bool)
+	for _, entry := range entries {
+		seen[entry.Amount.Currency()] = true
+	}
+	currencies := make([]string, 0, len(seen))
+	for currency := range seen {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+
+	switch len(currencies) {
+	case 0:
+		if s.currencies != nil && !s.currencies.For(job.TenantID()).IsZero() {
+			return s.currencies.For(job.TenantID()).Currency(), nil
+		}
+		return "", errors.NewValidationError("currency", "", errors.ValidationRequired, "currency is required for clients without activity")
+	case 1:
+		return currencies[0], nil
+	default:
+		return "", errors.NewValidationError("currency", currencies, errors.ValidationRequired, "client has activity in several currencies; request a statement per currency")
+	}
+}
+
+// isStatementFailure checks if a generation error is final for the statement (bad request, client gone)
+// rather than a storage or source outage worth retrying on the next run
+func isStatementFailure(err error) bool {
+	return errors.IsClientError(err) || errors.GetErrorCode(err) == errors.RepositoryNotFound
+}
+
+// clientStatementMessage builds the message asking the notifications to email a statement
+func clientStatementMessage(job *entity.ClientStatementJob, statement service.ClientStatement, html string, now time.Time) (messaging.Message, error) {
+	payload, err := json.Marshal(ClientStatementEvent{
+		StatementID:    job.ID(),
+		TenantID:       job.TenantID(),
+		ClientID:       job.ClientID(),
+		Recipient:      job.Recipient(),
+		Locale:         job.Locale(),
+		From:           job.From(),
+		To:             job.To(),
+		Currency:       statement.Currency,
+		ClosingBalance: statement.ClosingBalance.Amount(),
+		ContentType:    "text/html; charset=utf-8",
+		Document:       html,
+		GeneratedAt:    now.UTC(),
+	})
+	if err != nil {
+		return messaging.Message{}, err
+	}
+
+	return messaging.Message{
+		Topic:   ClientStatementTopic,
+		Key:     job.ClientID(),
+		Payload: payload,
+		Headers: map[string]string{"content-type": "application/json", EventTenantHeader: job.TenantID()},
+	}, nil
+}
@@ -0,0 +1,298 @@
+package application
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/messaging"
+)
+
+// Topics of the event store events the client list read model is rebuilt from; their payload is the client or
+// invoice as saved (null for a deleted client)
+const (
+	ClientStateTopic  = "billing.clients.state"
+	InvoiceStateTopic = "billing.invoices.state"
+)
+
+// Operations of state events, in their "operation" header: client change types for clients
+const (
+	stateOperationHeader = "operation"
+	invoiceSavedState    = "saved"
+	snapshotState        = "snapshot"
+)
+
+// clientSummaryTopics are the event store topics replayed to rebuild the client list read model
+var clientSummaryTopics = []string{ClientStateTopic, InvoiceStateTopic}
+
+// summaryProgressInterval is the number of events or records between two progress reports of a rebuild or snapshot
+const summaryProgressInterval = 1000
+
+// PaginatedClientSummaries represents a page of the client list read model
+type PaginatedClientSummaries struct {
+	Summaries  []*entity.ClientSummary
+	Pagination PaginationMeta
+}
+
+// ListClientSummaries retrieves a page of clients with their balances and last invoice from the read model
+func (s *BillingService) ListClientSummaries(page, limit int) (*PaginatedClientSummaries, error) {
+	if err := s.requireClientSummaries(); err != nil {
+		return nil, err
+	}
+
+	summaries, totalCount, err := s.summaries.ListPage((page-1)*limit, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	totalPages := totalCount / limit
+	if totalCount%limit > 0 {
+		totalPages++
+	}
+
+	return &PaginatedClientSummaries{
+		Summaries: summaries,
+		Pagination: PaginationMeta{
+			Page:       page,
+			Limit:      limit,
+			TotalCount: totalCount,
+			TotalPages: totalPages,
+		},
+	}, nil
+}
+
+// RebuildClientSummaries rebuilds the whole read model by replaying the client and invoice states recorded in the
+// event store, and returns the number of summaries written; summaries of clients that no longer exist are removed
+// Used after a migration, or to repair summaries whose refresh failed. States saved before the event store was
+// enabled are recorded first by SnapshotClientSummaryStates. progress, when not nil, is called with the number of
+// events replayed so far
+func (s *BillingService) RebuildClientSummaries(now time.Time, progress func(replayed int)) (int, error) {
+	if err := s.requireClientSummaries(); err != nil {
+		return 0, err
+	}
+	if err := s.requireEventStore(); err != nil {
+		return 0, err
+	}
+
+	s.summariesMu.Lock()
+	defer s.summariesMu.Unlock()
+
+	// The latest state of every client, in the order clients first appear, and of the invoices of every client
+	clients := make(map[string]*entity.Client)
+	var order []string
+	invoicesByClient := make(map[string]map[string]*entity.Invoice)
+	_, err := s.eventStore.Replay(clientSummaryTopics, func(event *entity.StoredEvent) error {
+		switch event.Type() {
+		case ClientStateTopic:
+			if event.Headers()[stateOperationHeader] == string(entity.ClientDeleted) {
+				delete(clients, event.AggregateID())
+				break
+			}
+			var client entity.Client
+			if err := json.Unmarshal(event.Payload(), &client); err != nil {
+				return err
+			}
+			if _, ok := clients[client.ID()]; !ok {
+				order = append(order, client.ID())
+			}
+			clients[client.ID()] = &client
+		case InvoiceStateTopic:
+			var invoice entity.Invoice
+			if err := json.Unmarshal(event.Payload(), &invoice); err != nil {
+				return err
+			}
+			if invoicesByClient[invoice.ClientID()] == nil {
+				invoicesByClient[invoice.ClientID()] = make(map[string]*entity.Invoice)
+			}
+			invoicesByClient[invoice.ClientID()][invoice.ID()] = &invoice
+		}
+		return nil
+	}, progress)
+	if err != nil {
+		return 0, err
+	}
+
+	existing := make(map[string]bool, len(clients))
+	for _, clientID := range order {
+		client, ok := clients[clientID]
+		if !ok || existing[clientID] {
+			continue
+		}
+		invoices := make([]*entity.Invoice, 0, len(invoicesByClient[clientID]))
+		for _, invoice := range invoicesByClient[clientID] {
+			invoices = append(invoices, invoice)
+		}
+		summary, err := entity.NewClientSummary(client, invoices, now)
+		if err != nil {
+			return 0, err
+		}
+		if err := s.summaries.Save(summary); err != nil {
+			return 0, err
+		}
+		existing[clientID] = true
+	}
+
+	stored, err := s.summaries.GetAll()
+	if err != nil {
+		return 0, err
+	}
+	for _, summary := range stored {
+		if existing[summary.ClientID()] {
+			continue
+		}
+		if err := s.summaries.Delete(summary.ClientID()); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(existing), nil
+}
+
+// SnapshotClientSummaryStates records the current state of every client and invoice in the event store and
+// returns the number of states recorded, so the read model can be rebuilt from the event store alone: run once
+// when enabling the event store on existing data. progress, when not nil, is called with the number of states
+// recorded so far
+func (s *BillingService) SnapshotClientSummaryStates(progress func(recorded int)) (int, error) {
+	if err := s.requireEventStore(); err != nil {
+		return 0, err
+	}
+
+	// Changes record their state under summariesMu too, so none is overtaken by an older state of the snapshot
+	s.summariesMu.Lock()
+	defer s.summariesMu.Unlock()
+
+	recorded := 0
+	record := func(topic, key, tenantID string, state interface{}) error {
+		if err := s.appendState(topic, key, tenantID, snapshotState, state); err != nil {
+			return err
+		}
+		recorded++
+		if progress != nil && recorded%summaryProgressInterval == 0 {
+			progress(recorded)
+		}
+		return nil
+	}
+
+	// Clients are read page by page, listings being bounded by repository.MaxListResults
+	page := &repository.ClientPage{HasMore: true}
+	for page.HasMore {
+		next, err := s.clientRepo.ListAfter(repository.ClientListFilter{}, page.Next, repository.MaxListResults)
+		if err != nil {
+			return recorded, err
+		}
+		page = next
+		for _, client := range page.Clients {
+			if err := record(ClientStateTopic, client.ID(), client.TenantID(), client); err != nil {
+				return recorded, err
+			}
+		}
+	}
+	if s.invoices != nil {
+		if err := s.eachInvoice(InvoiceFilter{}, func(invoice *entity.Invoice) error {
+			return record(InvoiceStateTopic, invoice.ID(), invoice.TenantID(), invoice)
+		}); err != nil {
+			return recorded, err
+		}
+	}
+
+	if progress != nil {
+		progress(recorded)
+	}
+	return recorded, nil
+}
+
+// clientChanged records the state of a client saved or deleted and refreshes its summary
+func (s *BillingService) clientChanged(changeType entity.ClientChangeType, clientID, tenantID string, client *entity.Client) {
+	s.summaryChanged(clientID, ClientStateTopic, clientID, tenantID, string(changeType), client)
+}
+
+// invoiceChanged records the state of an invoice issued, voided or paid and refreshes the summary of its client
+func (s *BillingService) invoiceChanged(invoice *entity.Invoice) {
+	s.summaryChanged(invoice.ClientID(), InvoiceStateTopic, invoice.ID(), invoice.TenantID(), invoiceSavedState, invoice)
+}
+
+// summaryChanged records the state of a client or invoice in the event store and refreshes the summary of the
+// client, both under summariesMu so a rebuild or snapshot sees either none or both
+// The change itself is already saved, so failures are logged rather than returned: a rebuild repairs the summary
+func (s *BillingService) summaryChanged(clientID, topic, key, tenantID, operation string, state interface{}) {
+	if s.summaries == nil && s.eventStore == nil {
+		return
+	}
+
+	s.summariesMu.Lock()
+	defer s.summariesMu.Unlock()
+
+	if s.eventStore != nil {
+		if err := s.appendState(topic, key, tenantID, operation, state); err != nil {
+			log.Printf("Failed to record state %s of %s in the event store: %v", key, topic, err)
+		}
+	}
+	if s.summaries != nil {
+		if err := s.refreshClientSummaryLocked(clientID); err != nil {
+			log.Printf("Failed to refresh summary of client %s: %v", clientID, err)
+		}
+	}
+}
+
+// appendState appends the state of a client or invoice of a tenant to the event store
+func (s *BillingService) appendState(topic, key, tenantID, operation string, state interface{}) error {
+	payload, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	_, err = s.eventStore.Append(messaging.Message{
+		Topic:   topic,
+		Key:     key,
+		Payload: payload,
+		Headers: map[string]string{
+			"content-type":       "application/json",
+			stateOperationHeader: operation,
+			EventTenantHeader:    tenantID,
+		},
+	})
+	return err
+}
+
+// refreshClientSummaryLocked reads the current client and invoices, so refreshes serialized by summariesMu
+// always leave the summary of the latest state
+func (s *BillingService) refreshClientSummaryLocked(clientID string) error {
+	client, err := s.clientRepo.GetByID(clientID)
+	if errors.GetErrorCode(err) == errors.RepositoryNotFound {
+		return s.summaries.Delete(clientID)
+	}
+	if err != nil {
+		return err
+	}
+
+	var invoices []*entity.Invoice
+	if s.invoices != nil {
+		if invoices, err = s.invoices.GetAll(); err != nil {
+			return err
+		}
+	}
+
+	summary, err := entity.NewClientSummary(client, invoices, time.Now())
+	if err != nil {
+		return err
+	}
+	return s.summaries.Save(summary)
+}
+
+// requireEventStore reports a service recording no states in an event store
+func (s *BillingService) requireEventStore() error {
+	if s.eventStore == nil {
+		return errors.NewBusinessRuleError("event_store_enabled", errors.BusinessRuleViolation, "the event store is not enabled")
+	}
+	return nil
+}
+
+// requireClientSummaries reports a service built without the client list read model
+func (s *BillingService) requireClientSummaries() error {
+	if s.summaries == nil {
+		return errors.NewBusinessRuleError("client_summaries_enabled", errors.BusinessRuleViolation, "client summaries are not enabled")
+	}
+	return nil
+}
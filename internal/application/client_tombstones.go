@@ -0,0 +1,40 @@
+package application
+
+import (
+	"log"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+)
+
+// DeleteClientIdempotently deletes a client like DeleteClient, but succeeds when the client was already deleted, so
+// a retried or repeated deletion gets the same outcome as the first one
+// Only clients with a tombstone count as deleted: deleting a client that never existed still fails with not found,
+// as does every deletion when the service keeps no tombstones
+func (s *BillingService) DeleteClientIdempotently(rc RequestContext, id string) error {
+	err := s.DeleteClient(rc, id)
+	if err == nil || errors.GetErrorCode(err) != errors.RepositoryNotFound || s.tombstones == nil {
+		return err
+	}
+
+	if _, tombstoneErr := s.tombstones.GetByClientID(id); tombstoneErr != nil {
+		if errors.GetErrorCode(tombstoneErr) == errors.RepositoryNotFound {
+			return err
+		}
+		return tombstoneErr
+	}
+	return nil
+}
+
+// recordTombstone records the deletion of a client when tombstones are enabled
+// The client is already deleted, so a failure is only logged: the next deletion of the client fails with not found
+func (s *BillingService) recordTombstone(clientID string) {
+	if s.tombstones == nil {
+		return
+	}
+
+	if err := s.tombstones.Save(entity.NewClientTombstone(clientID, time.Now())); err != nil {
+		log.Printf("Failed to record tombstone of deleted client %s: %v", clientID, err)
+	}
+}
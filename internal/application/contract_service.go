@@ -0,0 +1,275 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/messaging"
+)
+
+// ContractRenewalReminderTopic is the message bus topic account manager tooling subscribes to
+const ContractRenewalReminderTopic = "billing.contracts.renewal_reminder"
+
+// DefaultContractRenewalReminderLead is how long before the renewal date account managers are reminded
+const DefaultContractRenewalReminderLead = 60 * 24 * time.Hour
+
+// ContractRenewalReminderEvent is the payload published when a contract approaches its renewal date
+type ContractRenewalReminderEvent struct {
+	ContractID      string    `json:"contract_id"`
+	ClientID        string    `json:"client_id"`
+	Name            string    `json:"name"`
+	AccountManager  string    `json:"account_manager,omitempty"`
+	RenewalDate     time.Time `json:"renewal_date"`
+	CommittedAmount int64     `json:"committed_amount"`
+	SpentAmount     int64     `json:"spent_amount"`
+	Currency        string    `json:"currency"`
+	AttainedBps     int64     `json:"attained_bps"`
+	RemindedAt      time.Time `json:"reminded_at"`
+}
+
+// ContractService manages customer contracts, their committed spend and renewal reminders
+type ContractService struct {
+	contractRepo   repository.ContractRepository
+	billingService *BillingService
+	publisher      messaging.Publisher
+	reminderLead   time.Duration
+}
+
+// NewContractService creates a new contract service
+// Renewal reminders are published on publisher, reminderLead before each renewal date
+func NewContractService(contractRepo repository.ContractRepository, billingService *BillingService, publisher messaging.Publisher, reminderLead time.Duration) *ContractService {
+	if reminderLead <= 0 {
+		reminderLead = DefaultContractRenewalReminderLead
+	}
+
+	return &ContractService{
+		contractRepo:   contractRepo,
+		billingService: billingService,
+		publisher:      publisher,
+		reminderLead:   reminderLead,
+	}
+}
+
+// CreateContract creates a contract of the caller's tenant for an existing client of the tenant
+func (s *ContractService) CreateContract(rc RequestContext, req dtos.CreateContractRequest) (*entity.Contract, error) {
+	if err := rc.Authorize(PermissionManageContracts); err != nil {
+		return nil, err
+	}
+	committed, err := valueobject.NewMoney(req.CommittedAmount, req.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	var renewalDate time.Time
+	if req.RenewalDate != nil {
+		renewalDate = *req.RenewalDate
+	}
+
+	contract, err := entity.NewContract(req.ClientID, req.Name, req.AccountManager, req.StartDate, req.EndDate, renewalDate, committed)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.billingService.GetOwnedClient(rc, contract.ClientID()); err != nil {
+		return nil, err
+	}
+	contract.AssignTenant(rc.TenantID)
+
+	if err := s.contractRepo.Save(contract); err != nil {
+		return nil, err
+	}
+	return contract, nil
+}
+
+// GetContract retrieves a contract of the caller's tenant by its ID
+func (s *ContractService) GetContract(rc RequestContext, id string) (*entity.Contract, error) {
+	if err := rc.Authorize(PermissionReadContracts); err != nil {
+		return nil, err
+	}
+	return s.ownedContract(rc, id)
+}
+
+// ownedContract retrieves a contract the caller may act on: contracts of another tenant are reported as not found,
+// like clients (see BillingService.GetOwnedClient)
+func (s *ContractService) ownedContract(rc RequestContext, id string) (*entity.Contract, error) {
+	contract, err := s.contractRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if !rc.CanAccessTenant(contract.TenantID()) {
+		return nil, errors.ErrContractNotFound
+	}
+	return contract, nil
+}
+
+// ListContracts retrieves the contracts of the caller's tenant ordered by renewal date, optionally filtered by client
+func (s *ContractService) ListContracts(rc RequestContext, clientID string) ([]*entity.Contract, error) {
+	if err := rc.Authorize(PermissionReadContracts); err != nil {
+		return nil, err
+	}
+	contracts, err := s.contractRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]*entity.Contract, 0, len(contracts))
+	for _, contract := range contracts {
+		if !rc.CanAccessTenant(contract.TenantID()) {
+			continue
+		}
+		if clientID != "" && contract.ClientID() != clientID {
+			continue
+		}
+		filtered = append(filtered, contract)
+	}
+	return filtered, nil
+}
+
+// ActiveContracts retrieves the contracts of the caller's tenant whose term covers now (the commitment attainment
+// report)
+func (s *ContractService) ActiveContracts(rc RequestContext, now time.Time) ([]*entity.Contract, error) {
+	if err := rc.Authorize(PermissionReadContracts); err != nil {
+		return nil, err
+	}
+	contracts, err := s.contractRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	active := make([]*entity.Contract, 0, len(contracts))
+	for _, contract := range contracts {
+		if contract.IsActive(now) && rc.CanAccessTenant(contract.TenantID()) {
+			active = append(active, contract)
+		}
+	}
+	return active, nil
+}
+
+// LinkSubscription attaches a subscription to a contract of the caller's tenant
+func (s *ContractService) LinkSubscription(rc RequestContext, contractID string, req dtos.LinkContractSubscriptionRequest) (*entity.Contract, error) {
+	if err := rc.Authorize(PermissionManageContracts); err != nil {
+		return nil, err
+	}
+	contract, err := s.ownedContract(rc, contractID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := contract.LinkSubscription(req.SubscriptionID); err != nil {
+		return nil, err
+	}
+
+	if err := s.contractRepo.Save(contract); err != nil {
+		return nil, err
+	}
+	return contract, nil
+}
+
+// LinkInvoice counts an issued invoice towards the committed spend of a contract of the caller's tenant
+// The invoice must bill the contract's client in the contract's tenant; it counts for its total, on its issue date
+// unless the request dates it
+func (s *ContractService) LinkInvoice(rc RequestContext, contractID string, req dtos.LinkContractInvoiceRequest) (*entity.Contract, error) {
+	if err := rc.Authorize(PermissionManageContracts); err != nil {
+		return nil, err
+	}
+	contract, err := s.ownedContract(rc, contractID)
+	if err != nil {
+		return nil, err
+	}
+
+	invoice, err := s.billingService.GetInvoice(rc, req.InvoiceID)
+	if err != nil {
+		return nil, err
+	}
+	if invoice.ClientID() != contract.ClientID() || invoice.TenantID() != contract.TenantID() {
+		return nil, errors.NewBusinessRuleError("contract_invoice_client", errors.BusinessRuleViolation, "invoice must bill the client of the contract")
+	}
+	if invoice.Status() != entity.InvoiceIssued && invoice.Status() != entity.InvoicePaid {
+		return nil, errors.NewBusinessRuleError("contract_invoice_issued", errors.BusinessRuleViolation, "only issued and paid invoices count towards a contract")
+	}
+	amount, err := invoice.Total()
+	if err != nil {
+		return nil, err
+	}
+
+	var invoicedAt time.Time
+	if req.InvoicedAt != nil {
+		invoicedAt = *req.InvoicedAt
+	} else if issuedAt := invoice.IssuedAt(); issuedAt != nil {
+		invoicedAt = *issuedAt
+	}
+	if err := contract.LinkInvoice(invoice.ID(), amount, invoicedAt); err != nil {
+		return nil, err
+	}
+
+	if err := s.contractRepo.Save(contract); err != nil {
+		return nil, err
+	}
+	return contract, nil
+}
+
+// SendRenewalReminders publishes a reminder event for every contract entering its renewal window
+// Each contract is reminded once; the reminder is recorded only after the event is published,
+// so a failed run is retried on the next call
+func (s *ContractService) SendRenewalReminders(ctx context.Context, now time.Time) ([]*entity.Contract, error) {
+	contracts, err := s.contractRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	reminded := make([]*entity.Contract, 0)
+	for _, contract := range contracts {
+		if !contract.NeedsRenewalReminder(now, s.reminderLead) {
+			continue
+		}
+
+		message, err := renewalReminderMessage(contract, now)
+		if err != nil {
+			return reminded, err
+		}
+		if err := s.publisher.Publish(ctx, message); err != nil {
+			return reminded, err
+		}
+
+		contract.MarkRenewalReminderSent(now)
+		if err := s.contractRepo.Save(contract); err != nil {
+			return reminded, err
+		}
+		reminded = append(reminded, contract)
+	}
+
+	return reminded, nil
+}
+
+// renewalReminderMessage builds the bus message announcing a contract's upcoming renewal
+func renewalReminderMessage(contract *entity.Contract, now time.Time) (messaging.Message, error) {
+	attainment := contract.Attainment(now)
+	payload, err := json.Marshal(ContractRenewalReminderEvent{
+		ContractID:      contract.ID(),
+		ClientID:        contract.ClientID(),
+		Name:            contract.Name(),
+		AccountManager:  contract.AccountManager(),
+		RenewalDate:     contract.RenewalDate(),
+		CommittedAmount: attainment.Committed.Amount(),
+		SpentAmount:     attainment.Spent.Amount(),
+		Currency:        attainment.Committed.Currency(),
+		AttainedBps:     attainment.AttainedBps,
+		RemindedAt:      now.UTC(),
+	})
+	if err != nil {
+		return messaging.Message{}, err
+	}
+
+	return messaging.Message{
+		Topic:   ContractRenewalReminderTopic,
+		Key:     contract.ID(),
+		Payload: payload,
+		Headers: map[string]string{"content-type": "application/json", EventTenantHeader: contract.TenantID()},
+	}, nil
+}
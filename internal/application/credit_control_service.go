@@ -0,0 +1,287 @@
+package application
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+)
+
+// clientCreditLimitResource is the audit resource type for client credit limits
+const clientCreditLimitResource = "client_credit_limit"
+
+// CreditControlActor requests the credit limit overrides raised by invoice issuance
+const CreditControlActor = "credit_control"
+
+// UnappliedCreditSource lists the credit notes and payments clients hold that were not applied to an invoice yet
+type UnappliedCreditSource interface {
+	UnappliedCredits() ([]service.UnappliedCredit, error)
+}
+
+// ClientCredit is the credit position of a client
+type ClientCredit struct {
+	ClientID  string
+	Limit     *entity.ClientCreditLimit // nil when the client has no credit limit
+	Exposures []service.CreditExposure  // Per currency; includes the limit currency when a limit is set
+}
+
+// Available returns the room left under the credit limit (negative when over it), false without a limit
+func (c *ClientCredit) Available() (valueobject.Money, bool) {
+	if c.Limit == nil {
+		return valueobject.Money{}, false
+	}
+	exposure := service.ExposureIn(c.Exposures, c.Limit.Limit().Currency())
+	available, _ := c.Limit.Limit().Subtract(exposure.Exposure())
+	return available, true
+}
+
+// Utilization returns the share of the credit limit used by the exposure (above 1 when over it), false without a
+// positive limit
+func (c *ClientCredit) Utilization() (float64, bool) {
+	if c.Limit == nil || !c.Limit.Limit().IsPositive() {
+		return 0, false
+	}
+	exposure := service.ExposureIn(c.Exposures, c.Limit.Limit().Currency())
+	return float64(exposure.Exposure().Amount()) / float64(c.Limit.Limit().Amount()), true
+}
+
+// CreditCheck is the outcome of checking an invoice against the credit limit of its client
+type CreditCheck struct {
+	Decision service.CreditDecision
+	Limit    *entity.ClientCreditLimit // nil when the client has no credit limit
+	Exposure service.CreditExposure    // Exposure in the invoice currency before the invoice
+	Approval *entity.ApprovalRequest   // Override request, when the policy requires approval
+}
+
+// Allowed reports whether the invoice may be issued
+func (c *CreditCheck) Allowed() bool {
+	switch c.Decision {
+	case service.CreditWithinLimit, service.CreditOverLimitWarned:
+		return true
+	case service.CreditApprovalRequired:
+		return c.Approval != nil && c.Approval.Status() == entity.ApprovalStatusApproved
+	default:
+		return false
+	}
+}
+
+// CreditControlService manages client credit limits and checks invoice issuance against them
+type CreditControlService struct {
+	limitRepo      repository.ClientCreditLimitRepository
+	billingService *BillingService
+	auditService   *AuditService
+	openInvoices   OpenInvoiceSource
+	credits        UnappliedCreditSource
+	approvals      *ApprovalService
+}
+
+// NewCreditControlService creates a new credit control service
+// Without open invoice and unapplied credit sources every client has a zero exposure
+func NewCreditControlService(limitRepo repository.ClientCreditLimitRepository, billingService *BillingService, auditService *AuditService) *CreditControlService {
+	return &CreditControlService{
+		limitRepo:      limitRepo,
+		billingService: billingService,
+		auditService:   auditService,
+	}
+}
+
+// WithOpenInvoices counts the open invoices listed by source towards client exposure
+func (s *CreditControlService) WithOpenInvoices(source OpenInvoiceSource) *CreditControlService {
+	s.openInvoices = source
+	return s
+}
+
+// WithUnappliedCredits deducts the unapplied credit listed by source from client exposure
+func (s *CreditControlService) WithUnappliedCredits(source UnappliedCreditSource) *CreditControlService {
+	s.credits = source
+	return s
+}
+
+// WithApprovals raises credit limit overrides for approval under the require_approval policy
+// Without approvals, invoices over a require_approval limit stay held
+func (s *CreditControlService) WithApprovals(approvals *ApprovalService) *CreditControlService {
+	s.approvals = approvals
+	return s
+}
+
+// GetCredit retrieves the credit limit and exposure of a client
+func (s *CreditControlService) GetCredit(clientID string) (*ClientCredit, error) {
+	if err := s.requireClient(clientID); err != nil {
+		return nil, err
+	}
+
+	limit, err := s.findLimit(clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	exposures, err := s.exposures(clientID)
+	if err != nil {
+		return nil, err
+	}
+	if limit != nil && !hasExposureIn(exposures, limit.Limit().Currency()) {
+		exposures = append([]service.CreditExposure{service.ExposureIn(nil, limit.Limit().Currency())}, exposures...)
+	}
+
+	return &ClientCredit{ClientID: clientID, Limit: limit, Exposures: exposures}, nil
+}
+
+// SetLimit sets the credit limit and over-limit policy of a client and records the change in the audit log
+func (s *CreditControlService) SetLimit(actor, clientID string, req dtos.SetCreditLimitRequest) (*entity.ClientCreditLimit, error) {
+	if err := s.requireClient(clientID); err != nil {
+		return nil, err
+	}
+
+	amount, err := valueobject.NewMoney(req.Amount, req.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := s.findLimit(clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	details := map[string]interface{}{}
+	var limit *entity.ClientCreditLimit
+	if previous != nil {
+		// Snapshot before updating, the entity is modified in place
+		before, err := json.Marshal(previous)
+		if err != nil {
+			return nil, err
+		}
+		details["before"] = json.RawMessage(before)
+
+		limit = previous
+		if err := limit.Update(amount, entity.CreditPolicy(req.Policy)); err != nil {
+			return nil, err
+		}
+	} else {
+		limit, err = entity.NewClientCreditLimit(clientID, amount, entity.CreditPolicy(req.Policy))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.limitRepo.Save(limit); err != nil {
+		return nil, err
+	}
+
+	details["after"] = limit
+	if err := s.auditService.Record(AuditActionCreditLimitSet, actor, "", clientCreditLimitResource, clientID, details); err != nil {
+		return nil, err
+	}
+	return limit, nil
+}
+
+// DeleteLimit removes the credit limit of a client and records the change in the audit log
+func (s *CreditControlService) DeleteLimit(actor, clientID string) error {
+	previous, err := s.limitRepo.GetByClientID(clientID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.limitRepo.Delete(clientID); err != nil {
+		return err
+	}
+
+	details := map[string]interface{}{"before": previous}
+	return s.auditService.Record(AuditActionCreditLimitDeleted, actor, "", clientCreditLimitResource, clientID, details)
+}
+
+// CheckIssuance checks an invoice of amount for a client against its credit limit before it is issued
+// reference identifies the invoice across retries: under the require_approval policy, an override is requested
+// on the first check and the invoice is allowed once that override is approved
+func (s *CreditControlService) CheckIssuance(clientID, reference string, amount valueobject.Money) (*CreditCheck, error) {
+	limit, err := s.findLimit(clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	exposures, err := s.exposures(clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	check := &CreditCheck{Limit: limit, Exposure: service.ExposureIn(exposures, amount.Currency())}
+	check.Decision = service.CheckCredit(limit, check.Exposure, amount)
+	if check.Decision != service.CreditApprovalRequired || s.approvals == nil {
+		return check, nil
+	}
+
+	check.Approval, err = s.approvals.FindRequest(entity.ApprovalKindCreditLimitOverride, reference)
+	if err != nil && errors.GetErrorCode(err) != errors.RepositoryNotFound {
+		return nil, err
+	}
+	if check.Approval == nil {
+		reason := fmt.Sprintf("invoice of %s with an exposure of %s exceeds the credit limit of %s",
+			amount, check.Exposure.Exposure(), limit.Limit())
+		check.Approval, err = s.approvals.RequestOverride(CreditControlActor, entity.ApprovalKindCreditLimitOverride, clientID, reference, amount, reason)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return check, nil
+}
+
+// requireClient checks that a client exists
+func (s *CreditControlService) requireClient(clientID string) error {
+	exists, err := s.billingService.clientExists(clientID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errors.ErrClientNotFound
+	}
+	return nil
+}
+
+// findLimit retrieves the credit limit of a client (nil when none is set)
+func (s *CreditControlService) findLimit(clientID string) (*entity.ClientCreditLimit, error) {
+	limit, err := s.limitRepo.GetByClientID(clientID)
+	if err != nil {
+		if errors.GetErrorCode(err) == errors.RepositoryNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return limit, nil
+}
+
+// exposures calculates the exposure of a client per currency from the configured sources
+func (s *CreditControlService) exposures(clientID string) ([]service.CreditExposure, error) {
+	var openInvoices []service.OpenInvoice
+	if s.openInvoices != nil {
+		invoices, err := s.openInvoices.OpenInvoices()
+		if err != nil {
+			return nil, err
+		}
+		openInvoices = invoices
+	}
+
+	var credits []service.UnappliedCredit
+	if s.credits != nil {
+		unapplied, err := s.credits.UnappliedCredits()
+		if err != nil {
+			return nil, err
+		}
+		credits = unapplied
+	}
+
+	return service.CalculateExposure(clientID, openInvoices, credits), nil
+}
+
+// hasExposureIn checks if exposures include a currency
+func hasExposureIn(exposures []service.CreditExposure, currency string) bool {
+	for _, exposure := range exposures {
+		if exposure.Currency == currency {
+			return true
+		}
+	}
+	return false
+}
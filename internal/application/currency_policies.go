@@ -0,0 +1,55 @@
+package application
+
+import (
+	"sort"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+)
+
+// CurrencyPolicies resolves the default currency and rounding of a tenant's invoices
+// Tenants without a policy of their own use the default policy
+type CurrencyPolicies struct {
+	defaultPolicy valueobject.CurrencyPolicy
+	tenants       map[string]valueobject.CurrencyPolicy
+}
+
+// NewCurrencyPolicies creates a currency policy resolver
+func NewCurrencyPolicies(defaultPolicy valueobject.CurrencyPolicy, tenants map[string]valueobject.CurrencyPolicy) *CurrencyPolicies {
+	copied := make(map[string]valueobject.CurrencyPolicy, len(tenants))
+	for tenantID, policy := range tenants {
+		copied[tenantID] = policy
+	}
+
+	return &CurrencyPolicies{
+		defaultPolicy: defaultPolicy,
+		tenants:       copied,
+	}
+}
+
+// For returns the currency policy of a tenant (the default policy for an empty or unknown tenant)
+func (p *CurrencyPolicies) For(tenantID string) valueobject.CurrencyPolicy {
+	if policy, ok := p.tenants[tenantID]; ok {
+		return policy
+	}
+	return p.defaultPolicy
+}
+
+// Default returns the policy of tenants without a policy of their own
+func (p *CurrencyPolicies) Default() valueobject.CurrencyPolicy {
+	return p.defaultPolicy
+}
+
+// Currencies lists the currencies of the default and tenant policies, in alphabetical order
+func (p *CurrencyPolicies) Currencies() []string {
+	seen := map[string]bool{p.defaultPolicy.Currency(): true}
+	for _, policy := range p.tenants {
+		seen[policy.Currency()] = true
+	}
+
+	currencies := make([]string, 0, len(seen))
+	for currency := range seen {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+	return currencies
+}
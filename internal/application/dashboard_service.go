@@ -0,0 +1,181 @@
+package application
+
+import (
+	"sort"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+)
+
+// Queues reported on the operational dashboard
+const (
+	DashboardQueueEmailOutbox   = "email_outbox"   // Notification emails waiting for dispatch
+	DashboardQueueWebhookEvents = "webhook_events" // Inbound webhook events waiting for processing
+	DashboardQueueStatementJobs = "statement_jobs" // Client statements waiting for generation
+	DashboardQueueDeadLetters   = "dead_letters"   // Failed messages and webhook events waiting for an admin
+)
+
+// QueueDepthSource reports how many items of an asynchronous process wait to be handled
+type QueueDepthSource interface {
+	QueueDepth() (int, error)
+}
+
+// QueueDepth is the number of waiting items of one queue
+type QueueDepth struct {
+	Name  string
+	Depth int
+}
+
+// OverdueAgingBucket groups overdue invoices by how many days they are past due
+type OverdueAgingBucket struct {
+	Label       string // e.g. "1-30"
+	MinDays     int
+	MaxDays     int // 0 for the open-ended last bucket
+	Invoices    int
+	Outstanding valueobject.Money
+}
+
+// OverdueInvoiceTotals summarizes the overdue invoices of one currency
+type OverdueInvoiceTotals struct {
+	Currency      string
+	Invoices      int
+	Outstanding   valueobject.Money
+	OldestDueDate time.Time
+	Aging         []OverdueAgingBucket
+}
+
+// overdueAging are the day ranges overdue invoices are grouped by
+var overdueAging = []struct {
+	label   string
+	minDays int
+	maxDays int
+}{
+	{"1-30", 1, 30},
+	{"31-60", 31, 60},
+	{"61-90", 61, 90},
+	{"90+", 91, 0},
+}
+
+// DashboardService aggregates read-only operational numbers for the admin dashboard
+type DashboardService struct {
+	billing *BillingService
+	queues  map[string]QueueDepthSource
+}
+
+// NewDashboardService creates a dashboard service reporting the overdue invoices of the billing service
+func NewDashboardService(billingService *BillingService) *DashboardService {
+	return &DashboardService{
+		billing: billingService,
+		queues:  make(map[string]QueueDepthSource),
+	}
+}
+
+// WithQueue reports the depth of a queue under the given name
+func (s *DashboardService) WithQueue(name string, source QueueDepthSource) *DashboardService {
+	s.queues[name] = source
+	return s
+}
+
+// Queues returns the depth of every registered queue, ordered by name
+func (s *DashboardService) Queues() ([]QueueDepth, error) {
+	names := make([]string, 0, len(s.queues))
+	for name := range s.queues {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	depths := make([]QueueDepth, 0, len(names))
+	for _, name := range names {
+		depth, err := s.queues[name].QueueDepth()
+		if err != nil {
+			return nil, err
+		}
+		depths = append(depths, QueueDepth{Name: name, Depth: depth})
+	}
+	return depths, nil
+}
+
+// OverdueInvoices totals the issued invoices past their due date per currency, ordered by currency
+// An invoice is overdue from the day after its due date
+func (s *DashboardService) OverdueInvoices(now time.Time) ([]OverdueInvoiceTotals, error) {
+	byCurrency := make(map[string]*OverdueInvoiceTotals)
+	err := s.billing.eachInvoice(InvoiceFilter{Status: entity.InvoiceIssued}, func(invoice *entity.Invoice) error {
+		daysOverdue := invoice.DaysOverdue(now)
+		if daysOverdue == 0 {
+			return nil
+		}
+		dueDate := invoice.DueDate().UTC().Truncate(24 * time.Hour)
+
+		balance, err := invoice.Balance()
+		if err != nil {
+			return err
+		}
+		totals, err := s.overdueTotals(byCurrency, invoice.Currency())
+		if err != nil {
+			return err
+		}
+		if totals.Outstanding, err = totals.Outstanding.Add(balance); err != nil {
+			return err
+		}
+		totals.Invoices++
+		if totals.OldestDueDate.IsZero() || dueDate.Before(totals.OldestDueDate) {
+			totals.OldestDueDate = dueDate
+		}
+
+		for i := range totals.Aging {
+			bucket := &totals.Aging[i]
+			if daysOverdue < bucket.MinDays || (bucket.MaxDays > 0 && daysOverdue > bucket.MaxDays) {
+				continue
+			}
+			bucket.Invoices++
+			if bucket.Outstanding, err = bucket.Outstanding.Add(balance); err != nil {
+				return err
+			}
+			break
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	currencies := make([]string, 0, len(byCurrency))
+	for currency := range byCurrency {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+
+	result := make([]OverdueInvoiceTotals, len(currencies))
+	for i, currency := range currencies {
+		result[i] = *byCurrency[currency]
+	}
+	return result, nil
+}
+
+// overdueTotals returns the totals of a currency, starting them with empty aging buckets
+func (s *DashboardService) overdueTotals(byCurrency map[string]*OverdueInvoiceTotals, currency string) (*OverdueInvoiceTotals, error) {
+	if totals, ok := byCurrency[currency]; ok {
+		return totals, nil
+	}
+
+	zero, err := valueobject.NewMoney(0, currency)
+	if err != nil {
+		return nil, err
+	}
+	totals := &OverdueInvoiceTotals{
+		Currency:    zero.Currency(),
+		Outstanding: zero,
+		Aging:       make([]OverdueAgingBucket, len(overdueAging)),
+	}
+	for i, aging := range overdueAging {
+		totals.Aging[i] = OverdueAgingBucket{
+			Label:       aging.label,
+			MinDays:     aging.minDays,
+			MaxDays:     aging.maxDays,
+			Outstanding: zero,
+		}
+	}
+	byCurrency[currency] = totals
+	return totals, nil
+}
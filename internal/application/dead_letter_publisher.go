@@ -0,0 +1,98 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/messaging"
+)
+
+// DeadLetterPublisher publishes integration events and parks the ones the message bus does not accept
+// in the dead letter queue, so the operation producing them is not failed by a message bus outage
+type DeadLetterPublisher struct {
+	next        messaging.Publisher
+	messageRepo repository.FailedMessageRepository
+}
+
+// NewDeadLetterPublisher creates a publisher parking the messages next fails to publish
+func NewDeadLetterPublisher(next messaging.Publisher, messageRepo repository.FailedMessageRepository) *DeadLetterPublisher {
+	return &DeadLetterPublisher{
+		next:        next,
+		messageRepo: messageRepo,
+	}
+}
+
+// Publish publishes each message, parking the ones that fail
+// An error is only returned when a failed message cannot be parked either
+func (p *DeadLetterPublisher) Publish(ctx context.Context, messages ...messaging.Message) error {
+	for _, message := range messages {
+		publishErr := p.next.Publish(ctx, message)
+		if publishErr == nil {
+			continue
+		}
+
+		failed, err := entity.NewFailedMessage(message.Topic, message.Key, message.Payload, message.Headers, publishErr.Error(), time.Now())
+		if err != nil {
+			return err
+		}
+		if err := p.messageRepo.Save(failed); err != nil {
+			return fmt.Errorf("failed to publish message (%v) and to park it in the dead letter queue: %w", publishErr, err)
+		}
+	}
+	return nil
+}
+
+// DeadLetters lists the parked messages
+func (p *DeadLetterPublisher) DeadLetters() ([]DeadLetter, error) {
+	messages, err := p.messageRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	letters := make([]DeadLetter, len(messages))
+	for i, message := range messages {
+		letters[i] = DeadLetter{
+			ID:        message.ID(),
+			Source:    DeadLetterSourceMessages,
+			Reference: message.Topic(),
+			Payload:   message.Payload(),
+			Headers:   message.Headers(),
+			Reason:    message.Reason(),
+			Attempts:  message.Attempts(),
+			FailedAt:  message.FailedAt(),
+		}
+	}
+	return letters, nil
+}
+
+// RetryDeadLetter publishes a parked message again, removing it from the queue once the message bus accepts it
+func (p *DeadLetterPublisher) RetryDeadLetter(ctx context.Context, id string, now time.Time) error {
+	failed, err := p.messageRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	publishErr := p.next.Publish(ctx, messaging.Message{
+		Topic:   failed.Topic(),
+		Key:     failed.Key(),
+		Payload: failed.Payload(),
+		Headers: failed.Headers(),
+	})
+	if publishErr != nil {
+		failed.RecordFailure(publishErr.Error(), now)
+		if err := p.messageRepo.Save(failed); err != nil {
+			return err
+		}
+		return publishErr
+	}
+
+	return p.messageRepo.Delete(id)
+}
+
+// DiscardDeadLetter removes a parked message without publishing it
+func (p *DeadLetterPublisher) DiscardDeadLetter(id string) error {
+	return p.messageRepo.Delete(id)
+}
@@ -0,0 +1,217 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+)
+
+// Dead letter sources
+const (
+	DeadLetterSourceWebhooks = "webhooks" // Inbound webhook events that failed processing
+	DeadLetterSourceMessages = "messages" // Integration events the message bus did not accept
+)
+
+// MaxDeadLetterBatch bounds the dead letters retried or discarded by a single bulk operation
+const MaxDeadLetterBatch = 500
+
+// deadLetterResource is the audit resource type for dead letters
+const deadLetterResource = "dead_letter"
+
+// DeadLetter is an item of asynchronous processing that failed for good and waits for an admin decision
+type DeadLetter struct {
+	ID        string
+	Source    string
+	Reference string // What the item is within its source (webhook provider and event type, message topic)
+	Payload   json.RawMessage
+	Headers   map[string]string
+	Reason    string // Error of the last failed attempt
+	Attempts  int
+	FailedAt  time.Time
+}
+
+// DeadLetterSource is an asynchronous process parking the items it failed to process
+type DeadLetterSource interface {
+	// DeadLetters lists the parked items, in any order
+	DeadLetters() ([]DeadLetter, error)
+
+	// RetryDeadLetter processes a parked item again; it leaves the dead letter queue on success
+	RetryDeadLetter(ctx context.Context, id string, now time.Time) error
+
+	// DiscardDeadLetter removes a parked item without processing it
+	DiscardDeadLetter(id string) error
+}
+
+// DeadLetterFailure is a dead letter a bulk operation could not retry or discard
+type DeadLetterFailure struct {
+	ID    string
+	Error string
+}
+
+// DeadLetterBulkResult is the outcome of a bulk retry or discard
+type DeadLetterBulkResult struct {
+	Succeeded []string
+	Failed    []DeadLetterFailure
+}
+
+// DeadLetterService exposes the dead letters of every asynchronous process in one queue
+// and records the retries and discards admins perform in the audit log
+type DeadLetterService struct {
+	auditService *AuditService
+	sources      map[string]DeadLetterSource
+}
+
+// NewDeadLetterService creates a new dead letter service
+func NewDeadLetterService(auditService *AuditService) *DeadLetterService {
+	return &DeadLetterService{
+		auditService: auditService,
+		sources:      make(map[string]DeadLetterSource),
+	}
+}
+
+// WithSource adds the dead letters of an asynchronous process under a source name
+func (s *DeadLetterService) WithSource(name string, source DeadLetterSource) *DeadLetterService {
+	s.sources[name] = source
+	return s
+}
+
+// List retrieves the dead letters, oldest failure first, optionally filtered by source
+func (s *DeadLetterService) List(source string) ([]DeadLetter, error) {
+	if source != "" {
+		if _, ok := s.sources[source]; !ok {
+			return nil, errors.NewValidationError("source", source, errors.ValidationFormat, "source must be one of: "+strings.Join(s.sourceNames(), ", "))
+		}
+	}
+
+	letters := make([]DeadLetter, 0)
+	for _, name := range s.sourceNames() {
+		if source != "" && name != source {
+			continue
+		}
+
+		sourceLetters, err := s.sources[name].DeadLetters()
+		if err != nil {
+			return nil, err
+		}
+		for _, letter := range sourceLetters {
+			letter.Source = name
+			letters = append(letters, letter)
+		}
+	}
+
+	sort.SliceStable(letters, func(i, j int) bool {
+		return letters[i].FailedAt.Before(letters[j].FailedAt)
+	})
+	return letters, nil
+}
+
+// QueueDepth counts the dead letters of every source (operational dashboard)
+func (s *DeadLetterService) QueueDepth() (int, error) {
+	letters, err := s.List("")
+	if err != nil {
+		return 0, err
+	}
+	return len(letters), nil
+}
+
+// Get retrieves a dead letter by its ID
+func (s *DeadLetterService) Get(id string) (*DeadLetter, error) {
+	letters, err := s.List("")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, letter := range letters {
+		if letter.ID == id {
+			return &letter, nil
+		}
+	}
+	return nil, errors.ErrDeadLetterNotFound
+}
+
+// Retry processes the selected dead letters again on behalf of actor
+// Dead letters failing again stay in the queue and are reported in the result
+func (s *DeadLetterService) Retry(ctx context.Context, actor string, req dtos.DeadLetterBulkRequest, now time.Time) (*DeadLetterBulkResult, error) {
+	return s.apply(actor, req, AuditActionDeadLetterRetried, func(source DeadLetterSource, id string) error {
+		return source.RetryDeadLetter(ctx, id, now)
+	})
+}
+
+// Discard removes the selected dead letters from the queue without processing them, on behalf of actor
+func (s *DeadLetterService) Discard(actor string, req dtos.DeadLetterBulkRequest) (*DeadLetterBulkResult, error) {
+	return s.apply(actor, req, AuditActionDeadLetterDiscarded, func(source DeadLetterSource, id string) error {
+		return source.DiscardDeadLetter(id)
+	})
+}
+
+// apply runs an operation on each selected dead letter and records every success in the audit log
+// Dead letters are selected by ID or, without IDs, all the dead letters of a source
+func (s *DeadLetterService) apply(actor string, req dtos.DeadLetterBulkRequest, action string, operation func(source DeadLetterSource, id string) error) (*DeadLetterBulkResult, error) {
+	if len(req.IDs) == 0 && req.Source == "" {
+		return nil, errors.NewValidationError("ids", "", errors.ValidationRequired, "ids or source is required")
+	}
+	if len(req.IDs) > MaxDeadLetterBatch {
+		return nil, errors.NewValidationError("ids", len(req.IDs), errors.ValidationLength, "at most 500 dead letters can be selected at once")
+	}
+
+	letters, err := s.List(req.Source)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]DeadLetter, len(letters))
+	for _, letter := range letters {
+		byID[letter.ID] = letter
+	}
+
+	selected := req.IDs
+	if len(selected) == 0 {
+		for _, letter := range letters {
+			selected = append(selected, letter.ID)
+		}
+		if len(selected) > MaxDeadLetterBatch {
+			selected = selected[:MaxDeadLetterBatch]
+		}
+	}
+
+	result := &DeadLetterBulkResult{
+		Succeeded: make([]string, 0, len(selected)),
+		Failed:    make([]DeadLetterFailure, 0),
+	}
+	for _, id := range selected {
+		letter, ok := byID[id]
+		if !ok {
+			result.Failed = append(result.Failed, DeadLetterFailure{ID: id, Error: errors.GetUserMessage(errors.ErrDeadLetterNotFound)})
+			continue
+		}
+
+		if err := operation(s.sources[letter.Source], id); err != nil {
+			result.Failed = append(result.Failed, DeadLetterFailure{ID: id, Error: err.Error()})
+			continue
+		}
+		result.Succeeded = append(result.Succeeded, id)
+
+		if err := s.auditService.Record(action, actor, "", deadLetterResource, id, map[string]interface{}{
+			"source":    letter.Source,
+			"reference": letter.Reference,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// sourceNames returns the names of the configured sources in alphabetical order
+func (s *DeadLetterService) sourceNames() []string {
+	names := make([]string, 0, len(s.sources))
+	for name := range s.sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
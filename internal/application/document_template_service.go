@@ -0,0 +1,287 @@
+package application
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/document"
+)
+
+// documentTemplateResource is the audit resource type for tenant document templates
+const documentTemplateResource = "document_template"
+
+// DocumentTemplateService manages tenant invoice layouts and renders previews of them
+type DocumentTemplateService struct {
+	templateRepo  repository.DocumentTemplateRepository
+	auditService  *AuditService
+	legalEntities *LegalEntityService
+	compliance    []service.ComplianceRule
+}
+
+// NewDocumentTemplateService creates a new document template service
+func NewDocumentTemplateService(templateRepo repository.DocumentTemplateRepository, auditService *AuditService) *DocumentTemplateService {
+	return &DocumentTemplateService{
+		templateRepo: templateRepo,
+		auditService: auditService,
+	}
+}
+
+// WithLegalEntities prints the default legal entity as the seller of preview invoices
+func (s *DocumentTemplateService) WithLegalEntities(legalEntities *LegalEntityService) *DocumentTemplateService {
+	s.legalEntities = legalEntities
+	return s
+}
+
+// WithComplianceRules prints the tax breakdown and legal mentions the seller country requires on preview invoices
+func (s *DocumentTemplateService) WithComplianceRules(rules []service.ComplianceRule) *DocumentTemplateService {
+	s.compliance = rules
+	return s
+}
+
+// GetTemplate retrieves the document template of a tenant
+func (s *DocumentTemplateService) GetTemplate(tenantID string) (*entity.DocumentTemplate, error) {
+	return s.templateRepo.GetByTenantID(tenantID)
+}
+
+// ListTemplates retrieves all tenant document templates
+func (s *DocumentTemplateService) ListTemplates() ([]*entity.DocumentTemplate, error) {
+	return s.templateRepo.GetAll()
+}
+
+// SetTemplate replaces the document template of a tenant and records the change in the audit log
+func (s *DocumentTemplateService) SetTemplate(actor, tenantID string, req dtos.DocumentTemplateRequest) (*entity.DocumentTemplate, error) {
+	template, err := toDocumentTemplate(tenantID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the previous template (if any) for the audit trail
+	previous, err := s.templateRepo.GetByTenantID(template.TenantID())
+	if err != nil && errors.GetErrorCode(err) != errors.RepositoryNotFound {
+		return nil, err
+	}
+
+	if err := s.templateRepo.Save(template); err != nil {
+		return nil, err
+	}
+
+	details := map[string]interface{}{"after": template}
+	if previous != nil {
+		details["before"] = previous
+	}
+	if err := s.auditService.Record(AuditActionDocumentTemplateSet, actor, template.TenantID(), documentTemplateResource, template.TenantID(), details); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// DeleteTemplate removes the document template of a tenant, reverting it to the default layout,
+// and records the change in the audit log
+func (s *DocumentTemplateService) DeleteTemplate(actor, tenantID string) error {
+	previous, err := s.templateRepo.GetByTenantID(tenantID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.templateRepo.Delete(tenantID); err != nil {
+		return err
+	}
+
+	details := map[string]interface{}{"before": previous}
+	return s.auditService.Record(AuditActionDocumentTemplateDeleted, actor, tenantID, documentTemplateResource, tenantID, details)
+}
+
+// TemplateFor returns the layout invoices of a tenant are rendered with
+// Tenants without a template use entity.DefaultDocumentTemplateFor
+func (s *DocumentTemplateService) TemplateFor(tenantID string) (*entity.DocumentTemplate, error) {
+	template, err := s.templateRepo.GetByTenantID(tenantID)
+	if err != nil {
+		if errors.GetErrorCode(err) == errors.RepositoryNotFound {
+			return entity.DefaultDocumentTemplateFor(tenantID), nil
+		}
+		return nil, err
+	}
+	return template, nil
+}
+
+// Preview renders a sample invoice as HTML with the template of a tenant,
+// or with a draft template when one is given, so layouts can be checked before they are saved
+// The sample is formatted for locale, as clients with that locale would receive it
+func (s *DocumentTemplateService) Preview(tenantID string, draft *dtos.DocumentTemplateRequest, locale valueobject.Locale, now time.Time) ([]byte, error) {
+	var template *entity.DocumentTemplate
+	var err error
+	if draft != nil {
+		template, err = toDocumentTemplate(tenantID, *draft)
+	} else {
+		template, err = s.TemplateFor(tenantID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	invoice, err := s.sampleInvoice(now)
+	if err != nil {
+		return nil, err
+	}
+	invoice.Locale = locale
+
+	var buf bytes.Buffer
+	if err := document.RenderHTML(&buf, template, invoice); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// RenderInvoice renders an invoice issued to buyer as HTML with the template of its tenant, formatted for locale
+// The seller is the legal entity that issued the invoice, else the default legal entity when one is configured
+func (s *DocumentTemplateService) RenderInvoice(invoice *entity.Invoice, buyer *entity.Client, locale valueobject.Locale) ([]byte, error) {
+	template, err := s.TemplateFor(invoice.TenantID())
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := invoiceDocument(invoice, buyer)
+	if err != nil {
+		return nil, err
+	}
+	content.Locale = locale
+	if content, err = s.withSeller(content, invoice.LegalEntityID()); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := document.RenderHTML(&buf, template, content); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// invoiceDocument converts an invoice issued to buyer to the content of its document
+func invoiceDocument(invoice *entity.Invoice, buyer *entity.Client) (document.Invoice, error) {
+	content := document.Invoice{
+		Number: invoice.Number(),
+		Buyer:  document.Party{Name: buyer.Name(), Address: buyer.Address()},
+		Lines:  make([]document.Line, 0, len(invoice.Lines())),
+	}
+	if issuedAt := invoice.IssuedAt(); issuedAt != nil {
+		content.IssueDate = *issuedAt
+	}
+	if dueDate := invoice.DueDate(); dueDate != nil {
+		content.DueDate = *dueDate
+	}
+
+	for _, line := range invoice.Lines() {
+		unitPrice, err := valueobject.NewMoney(line.UnitAmount, invoice.Currency())
+		if err != nil {
+			return document.Invoice{}, err
+		}
+		taxed, err := invoice.LineTax(line)
+		if err != nil {
+			return document.Invoice{}, err
+		}
+		content.Lines = append(content.Lines, document.Line{
+			Description: line.Description,
+			Quantity:    line.Quantity,
+			UnitPrice:   unitPrice,
+			TaxRateBps:  line.TaxRateBps,
+			TaxAmount:   taxed.Tax,
+			Amount:      taxed.Net,
+		})
+	}
+
+	var err error
+	if content.Subtotal, err = invoice.Subtotal(); err != nil {
+		return document.Invoice{}, err
+	}
+	if content.Tax, err = invoice.TaxTotal(); err != nil {
+		return document.Invoice{}, err
+	}
+	if content.Total, err = invoice.Total(); err != nil {
+		return document.Invoice{}, err
+	}
+	return content, nil
+}
+
+// sampleInvoice builds the invoice rendered by previews, issued by the default legal entity when one is configured
+func (s *DocumentTemplateService) sampleInvoice(now time.Time) (document.Invoice, error) {
+	eur := func(amount int64) valueobject.Money {
+		money, _ := valueobject.NewMoney(amount, "EUR")
+		return money
+	}
+
+	invoice := document.Invoice{
+		Number:    "PREVIEW-000001",
+		IssueDate: now.UTC(),
+		DueDate:   now.UTC().AddDate(0, 0, 30),
+		Seller:    document.Party{Name: "Your Company", Address: "1 Example Street, Example City"},
+		Buyer:     document.Party{Name: "Sample Client Ltd", Address: "2 Client Road, Client Town"},
+		Lines: []document.Line{
+			{Description: "Professional services", Quantity: 10, UnitPrice: eur(12000), TaxRateBps: 2000, TaxAmount: eur(24000), Amount: eur(120000)},
+			{Description: "Hosting", Quantity: 1, UnitPrice: eur(4900), TaxRateBps: 2000, TaxAmount: eur(980), Amount: eur(4900)},
+		},
+		Subtotal: eur(124900),
+		Tax:      eur(24980),
+		Total:    eur(149880),
+	}
+	return s.withSeller(invoice, "")
+}
+
+// withSeller sets the legal entity of ID legalEntityID (the default one when empty) as the seller of an invoice,
+// with the compliance rules of its country; the invoice is left as is when no legal entity is configured
+func (s *DocumentTemplateService) withSeller(invoice document.Invoice, legalEntityID string) (document.Invoice, error) {
+	if s.legalEntities == nil {
+		return s.withCompliance(invoice, ""), nil
+	}
+	seller, err := s.legalEntities.ResolveEntity(legalEntityID)
+	if err != nil {
+		return invoice, err
+	}
+	if seller == nil {
+		return s.withCompliance(invoice, ""), nil
+	}
+
+	invoice.Seller = document.Party{Name: seller.Name(), Address: seller.Address()}
+	for _, registration := range seller.TaxRegistrations() {
+		invoice.Seller.TaxIDs = append(invoice.Seller.TaxIDs, registration.Number)
+	}
+	invoice.BankAccount = seller.BankAccount()
+	return s.withCompliance(invoice, seller.Country()), nil
+}
+
+// withCompliance sets the tax breakdown and legal mentions required on invoices issued from a seller country
+// The country of buyers is not known, so only the rules for any buyer apply
+func (s *DocumentTemplateService) withCompliance(invoice document.Invoice, sellerCountry string) document.Invoice {
+	if len(s.compliance) == 0 {
+		return invoice
+	}
+
+	taxRates := make([]int64, len(invoice.Lines))
+	for i, line := range invoice.Lines {
+		taxRates[i] = line.TaxRateBps
+	}
+	result := service.EvaluateCompliance(service.ComplianceInvoice{
+		SellerCountry: sellerCountry,
+		SellerTaxIDs:  invoice.Seller.TaxIDs,
+		TaxRatesBps:   taxRates,
+	}, s.compliance)
+
+	invoice.TaxBreakdown = result.TaxBreakdown
+	invoice.LegalMentions = result.Mentions
+	return invoice
+}
+
+// toDocumentTemplate converts a request to a validated tenant document template
+func toDocumentTemplate(tenantID string, req dtos.DocumentTemplateRequest) (*entity.DocumentTemplate, error) {
+	columns := make([]entity.InvoiceColumn, len(req.Columns))
+	for i, column := range req.Columns {
+		columns[i] = entity.InvoiceColumn(column)
+	}
+	return entity.NewDocumentTemplate(tenantID, req.LogoURL, req.PrimaryColor, req.AccentColor, columns, req.FooterText)
+}
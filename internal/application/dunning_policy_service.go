@@ -0,0 +1,127 @@
+package application
+
+import (
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+)
+
+// dunningPolicyResource is the audit resource type for tenant dunning policies
+const dunningPolicyResource = "dunning_policy"
+
+// DueReminder is the reminder stage an overdue invoice has reached
+type DueReminder struct {
+	Stage entity.ReminderStage
+	Index int // Position of the stage in the cadence, so the worker can tell which stages were already sent
+}
+
+// DunningPolicyService manages tenant payment reminder cadences and resolves them for the dunning worker
+type DunningPolicyService struct {
+	policyRepo   repository.DunningPolicyRepository
+	auditService *AuditService
+}
+
+// NewDunningPolicyService creates a new dunning policy service
+func NewDunningPolicyService(policyRepo repository.DunningPolicyRepository, auditService *AuditService) *DunningPolicyService {
+	return &DunningPolicyService{
+		policyRepo:   policyRepo,
+		auditService: auditService,
+	}
+}
+
+// GetPolicy retrieves the dunning policy of a tenant
+func (s *DunningPolicyService) GetPolicy(tenantID string) (*entity.DunningPolicy, error) {
+	return s.policyRepo.GetByTenantID(tenantID)
+}
+
+// ListPolicies retrieves all tenant dunning policies
+func (s *DunningPolicyService) ListPolicies() ([]*entity.DunningPolicy, error) {
+	return s.policyRepo.GetAll()
+}
+
+// SetPolicy replaces the reminder cadence of a tenant and records the change in the audit log
+func (s *DunningPolicyService) SetPolicy(actor, tenantID string, req dtos.SetDunningPolicyRequest) (*entity.DunningPolicy, error) {
+	stages := make([]entity.ReminderStage, 0, len(req.Stages))
+	for _, stageReq := range req.Stages {
+		stage, err := entity.NewReminderStage(stageReq.DayOffset, entity.ReminderChannel(stageReq.Channel), stageReq.Template)
+		if err != nil {
+			return nil, err
+		}
+		stages = append(stages, stage)
+	}
+
+	policy, err := entity.NewDunningPolicy(tenantID, stages)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the previous policy (if any) for the audit trail
+	previous, err := s.policyRepo.GetByTenantID(policy.TenantID())
+	if err != nil && errors.GetErrorCode(err) != errors.RepositoryNotFound {
+		return nil, err
+	}
+
+	if err := s.policyRepo.Save(policy); err != nil {
+		return nil, err
+	}
+
+	details := map[string]interface{}{"after": policy}
+	if previous != nil {
+		details["before"] = previous
+	}
+	if err := s.auditService.Record(AuditActionDunningPolicySet, actor, policy.TenantID(), dunningPolicyResource, policy.TenantID(), details); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// DeletePolicy removes the dunning policy of a tenant, reverting it to the default cadence,
+// and records the change in the audit log
+func (s *DunningPolicyService) DeletePolicy(actor, tenantID string) error {
+	previous, err := s.policyRepo.GetByTenantID(tenantID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.policyRepo.Delete(tenantID); err != nil {
+		return err
+	}
+
+	details := map[string]interface{}{"before": previous}
+	return s.auditService.Record(AuditActionDunningPolicyDeleted, actor, tenantID, dunningPolicyResource, tenantID, details)
+}
+
+// StagesFor returns the reminder cadence of a tenant
+// Tenants without a policy use entity.DefaultReminderStages
+func (s *DunningPolicyService) StagesFor(tenantID string) ([]entity.ReminderStage, error) {
+	if tenantID == "" {
+		return entity.DefaultReminderStages(), nil
+	}
+
+	policy, err := s.policyRepo.GetByTenantID(tenantID)
+	if err != nil {
+		if errors.GetErrorCode(err) == errors.RepositoryNotFound {
+			return entity.DefaultReminderStages(), nil
+		}
+		return nil, err
+	}
+
+	return policy.Stages(), nil
+}
+
+// DueReminder returns the latest reminder stage reached by an invoice of a tenant daysOverdue days after
+// its due date (dunning worker entry point); it returns nil before the first stage
+func (s *DunningPolicyService) DueReminder(tenantID string, daysOverdue int) (*DueReminder, error) {
+	stages, err := s.StagesFor(tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	stage, index, ok := entity.CurrentReminderStage(stages, daysOverdue)
+	if !ok {
+		return nil, nil
+	}
+	return &DueReminder{Stage: stage, Index: index}, nil
+}
@@ -0,0 +1,205 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/messaging"
+)
+
+// InvoiceDunningReminderTopic is the message bus topic the mailer subscribes to for payment reminders
+const InvoiceDunningReminderTopic = "billing.invoices.dunning_reminder"
+
+// InvoiceDunningReminderEvent is the payload published when an overdue invoice reaches a new reminder level
+type InvoiceDunningReminderEvent struct {
+	InvoiceID   string    `json:"invoice_id"`
+	Number      string    `json:"number,omitempty"`
+	TenantID    string    `json:"tenant_id,omitempty"`
+	ClientID    string    `json:"client_id"`
+	Level       int       `json:"level"`
+	Channel     string    `json:"channel"`
+	Template    string    `json:"template"`
+	DaysOverdue int       `json:"days_overdue"`
+	DueDate     time.Time `json:"due_date"`
+	Balance     int64     `json:"balance"`
+	Currency    string    `json:"currency"`
+	RemindedAt  time.Time `json:"reminded_at"`
+
+	// Set when late fees are configured: the late fees charged on the invoice so far, this reminder's included, and
+	// the balance once this reminder's fee is added
+	LateFee   int64 `json:"late_fee,omitempty"`
+	AmountDue int64 `json:"amount_due,omitempty"`
+}
+
+// DunningReminder is a payment reminder sent by a dunning run
+type DunningReminder struct {
+	Invoice *entity.Invoice
+	Event   entity.DunningEvent
+}
+
+// DunningService detects overdue invoices and escalates them through the reminder cadence of their tenant
+type DunningService struct {
+	billingService *BillingService
+	policies       *DunningPolicyService
+	publisher      messaging.Publisher
+	lateFees       *valueobject.LateFeePolicy
+	auditService   *AuditService // Records the late fees charged (nil: not audited)
+}
+
+// dunningActor is the audit actor of the late fees charged by dunning runs
+const dunningActor = "dunning"
+
+// NewDunningService creates a new dunning service
+// Reminders follow the tenant cadences of policies and are published on publisher
+func NewDunningService(billingService *BillingService, policies *DunningPolicyService, publisher messaging.Publisher) *DunningService {
+	return &DunningService{
+		billingService: billingService,
+		policies:       policies,
+		publisher:      publisher,
+	}
+}
+
+// WithLateFees charges the fees owed under policy on the overdue balance with every reminder (nil: none)
+func (s *DunningService) WithLateFees(policy *valueobject.LateFeePolicy) *DunningService {
+	s.lateFees = policy
+	return s
+}
+
+// WithAudit records an audit entry for every late fee charged
+func (s *DunningService) WithAudit(auditService *AuditService) *DunningService {
+	s.auditService = auditService
+	return s
+}
+
+// SendDueReminders publishes a reminder for every overdue invoice that reached a new stage of its cadence and
+// records it on the invoice as a dunning event, charging the late fee owed since the previous reminder
+// Only the latest stage reached is sent, so an invoice found late skips the gentler stages it missed. The event is
+// recorded only after the reminder is published, so a failed run is retried on the next call; the fee is recorded
+// on the event, so each stage charges it once
+func (s *DunningService) SendDueReminders(ctx context.Context, now time.Time) ([]DunningReminder, error) {
+	reminders := make([]DunningReminder, 0)
+	err := s.billingService.eachInvoice(InvoiceFilter{Status: entity.InvoiceIssued}, func(invoice *entity.Invoice) error {
+		daysOverdue := invoice.DaysOverdue(now)
+		if daysOverdue == 0 {
+			return nil
+		}
+		due, err := s.policies.DueReminder(invoice.TenantID(), daysOverdue)
+		if err != nil {
+			return err
+		}
+		level := 0
+		if due != nil {
+			level = due.Index + 1
+		}
+		if level <= invoice.DunningLevel() {
+			return nil
+		}
+
+		lateFee, err := s.lateFeeDue(invoice, daysOverdue, now)
+		if err != nil {
+			return err
+		}
+		message, err := dunningReminderMessage(invoice, level, due.Stage, daysOverdue, now, s.lateFees != nil, lateFee)
+		if err != nil {
+			return err
+		}
+		if err := s.publisher.Publish(ctx, message); err != nil {
+			return err
+		}
+
+		reminded, err := s.billingService.RecordDunning(invoice.ID(), level, due.Stage, lateFee, now)
+		if err != nil {
+			return err
+		}
+		if lateFee.IsPositive() && s.auditService != nil {
+			balance, err := reminded.Balance()
+			if err != nil {
+				return err
+			}
+			if err := s.auditService.Record(AuditActionLateFeeCharged, dunningActor, reminded.TenantID(), invoiceResource, reminded.ID(), map[string]interface{}{
+				"level":    level,
+				"lateFee":  lateFee.Amount(),
+				"currency": lateFee.Currency(),
+				"balance":  balance.Amount(),
+			}); err != nil {
+				return err
+			}
+		}
+		events := reminded.DunningEvents()
+		reminders = append(reminders, DunningReminder{Invoice: reminded, Event: events[len(events)-1]})
+		return nil
+	})
+	return reminders, err
+}
+
+// lateFeeDue returns the late fee to charge with a reminder: the fees owed on the overdue balance, less the fees
+// already charged with earlier reminders (fees are not charged on fees)
+// Late fees run from the date the invoice became overdue, the due date of its earliest unpaid installment when
+// its balance is split
+func (s *DunningService) lateFeeDue(invoice *entity.Invoice, daysOverdue int, now time.Time) (valueobject.Money, error) {
+	charged := invoice.LateFees()
+	if s.lateFees == nil {
+		return valueobject.ZeroMoney(invoice.Currency()), nil
+	}
+	balance, err := invoice.Balance()
+	if err != nil {
+		return valueobject.Money{}, err
+	}
+	overdue, err := balance.Subtract(charged)
+	if err != nil {
+		return valueobject.Money{}, err
+	}
+	assessment, err := s.lateFees.Assess(overdue, now.AddDate(0, 0, -daysOverdue), now)
+	if err != nil {
+		return valueobject.Money{}, err
+	}
+	fee, err := assessment.Total.Subtract(charged)
+	if err != nil {
+		return valueobject.Money{}, err
+	}
+	if fee.IsNegative() {
+		return valueobject.ZeroMoney(invoice.Currency()), nil
+	}
+	return fee, nil
+}
+
+// dunningReminderMessage builds the bus message asking the mailer to send the reminder of a stage, with the late fee
+// charged with it when late fees are configured
+func dunningReminderMessage(invoice *entity.Invoice, level int, stage entity.ReminderStage, daysOverdue int, now time.Time, withLateFees bool, lateFee valueobject.Money) (messaging.Message, error) {
+	balance, err := invoice.Balance()
+	if err != nil {
+		return messaging.Message{}, err
+	}
+	event := InvoiceDunningReminderEvent{
+		InvoiceID:   invoice.ID(),
+		Number:      invoice.Number(),
+		TenantID:    invoice.TenantID(),
+		ClientID:    invoice.ClientID(),
+		Level:       level,
+		Channel:     string(stage.Channel()),
+		Template:    stage.Template(),
+		DaysOverdue: daysOverdue,
+		DueDate:     *invoice.DueDate(),
+		Balance:     balance.Amount(),
+		Currency:    balance.Currency(),
+		RemindedAt:  now.UTC(),
+	}
+	if withLateFees {
+		event.LateFee = invoice.LateFees().Amount() + lateFee.Amount()
+		event.AmountDue = balance.Amount() + lateFee.Amount()
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return messaging.Message{}, err
+	}
+
+	return messaging.Message{
+		Topic:   InvoiceDunningReminderTopic,
+		Key:     invoice.ID(),
+		Payload: payload,
+		Headers: map[string]string{"content-type": "application/json", EventTenantHeader: invoice.TenantID()},
+	}, nil
+}
@@ -0,0 +1,447 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/messaging"
+)
+
+// NotificationTopicPrefix prefixes the message bus topics of the emails sent to clients (statements, payment receipts)
+const NotificationTopicPrefix = "billing.notifications."
+
+// Email outbox dispatch defaults, used when the service is created with zero values
+const (
+	DefaultEmailOutboxMaxAttempts  = 5
+	DefaultEmailOutboxRetryBackoff = time.Minute
+	DefaultEmailOutboxMaxBackoff   = time.Hour
+	DefaultEmailSoftBounceLimit    = 3
+)
+
+// Headers added to the emails dispatched from the outbox, so the mailer knows where to send them and which email a bounce is about
+const (
+	EmailOutboxRecipientHeader = "recipient"
+	EmailOutboxIDHeader        = "email-id"
+)
+
+// EmailOutboxMetrics summarizes the outbox for monitoring
+type EmailOutboxMetrics struct {
+	ByStatus            map[entity.OutboundEmailStatus]int
+	ByTopic             map[string]int
+	Attempts            int            // Dispatch attempts made, over all emails
+	Retries             int            // Attempts beyond the first of each email
+	OldestPendingAge    *time.Duration // Age of the oldest pending email (nil when none is pending)
+	SuppressedAddresses int            // Addresses on the suppression list
+}
+
+// EmailOutboxService persists the notification emails to clients in an outbox and dispatches them to the mailer
+// Failed dispatches are retried with an exponential backoff, and emails to addresses on the suppression list,
+// maintained from the bounces the mailer reports, are not sent
+type EmailOutboxService struct {
+	emails          repository.OutboundEmailRepository
+	suppressions    repository.EmailSuppressionRepository
+	clients         repository.ClientRepository
+	mailer          messaging.Publisher
+	maxAttempts     int
+	retryBackoff    time.Duration
+	maxBackoff      time.Duration
+	softBounceLimit int
+}
+
+// NewEmailOutboxService creates a new email outbox service dispatching emails through mailer
+// Emails fail for good after maxAttempts, waiting retryBackoff before the first retry, doubled up to maxBackoff;
+// softBounceLimit consecutive soft bounces suppress an address (defaults apply to zero values)
+func NewEmailOutboxService(emailRepo repository.OutboundEmailRepository, suppressionRepo repository.EmailSuppressionRepository, mailer messaging.Publisher, maxAttempts int, retryBackoff, maxBackoff time.Duration, softBounceLimit int) *EmailOutboxService {
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultEmailOutboxMaxAttempts
+	}
+	if retryBackoff <= 0 {
+		retryBackoff = DefaultEmailOutboxRetryBackoff
+	}
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultEmailOutboxMaxBackoff
+	}
+	if softBounceLimit <= 0 {
+		softBounceLimit = DefaultEmailSoftBounceLimit
+	}
+
+	return &EmailOutboxService{
+		emails:          emailRepo,
+		suppressions:    suppressionRepo,
+		mailer:          mailer,
+		maxAttempts:     maxAttempts,
+		retryBackoff:    retryBackoff,
+		maxBackoff:      maxBackoff,
+		softBounceLimit: softBounceLimit,
+	}
+}
+
+// WithClients resolves the recipient of emails whose payload only names the client from the client's email address
+func (s *EmailOutboxService) WithClients(clientRepo repository.ClientRepository) *EmailOutboxService {
+	s.clients = clientRepo
+	return s
+}
+
+// Publisher wraps next so notification messages published through it are persisted in the outbox instead of
+// being published; the other messages are published through next
+func (s *EmailOutboxService) Publisher(next messaging.Publisher) messaging.Publisher {
+	return &emailOutboxPublisher{
+		outbox: s,
+		next:   next,
+	}
+}
+
+// Enqueue persists a notification message in the outbox, due immediately
+func (s *EmailOutboxService) Enqueue(message messaging.Message, now time.Time) (*entity.OutboundEmail, error) {
+	email, err := entity.NewOutboundEmail(message.Topic, message.Key, message.Payload, message.Headers, s.resolveRecipient(message.Payload), now)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.emails.Save(email); err != nil {
+		return nil, err
+	}
+	return email, nil
+}
+
+// resolveRecipient reads the recipient of a notification payload, falling back to the email address of its client
+// An email whose recipient cannot be resolved is still dispatched; the mailer resolves it as before the outbox
+func (s *EmailOutboxService) resolveRecipient(payload []byte) string {
+	var notification struct {
+		Recipient string `json:"recipient"`
+		ClientID  string `json:"client_id"`
+	}
+	if err := json.Unmarshal(payload, &notification); err != nil {
+		return ""
+	}
+	if notification.Recipient != "" || notification.ClientID == "" || s.clients == nil {
+		return notification.Recipient
+	}
+
+	client, err := s.clients.GetByID(notification.ClientID)
+	if err != nil {
+		return ""
+	}
+	return client.Email().String()
+}
+
+// ProcessOutbox dispatches the pending emails that are due (worker entry point)
+// Emails to suppressed addresses are dropped; failed dispatches are retried later or fail after the last attempt
+func (s *EmailOutboxService) ProcessOutbox(ctx context.Context, now time.Time) ([]*entity.OutboundEmail, error) {
+	emails, err := s.emails.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	processed := make([]*entity.OutboundEmail, 0)
+	for _, email := range emails {
+		if !email.IsDue(now) {
+			continue
+		}
+		if err := s.dispatch(ctx, email, now); err != nil {
+			return processed, err
+		}
+		processed = append(processed, email)
+	}
+	return processed, nil
+}
+
+// dispatch hands one email to the mailer unless its recipient is suppressed, and saves the outcome
+func (s *EmailOutboxService) dispatch(ctx context.Context, email *entity.OutboundEmail, now time.Time) error {
+	suppressed, err := s.IsSuppressed(email.Recipient())
+	if err != nil {
+		return err
+	}
+	if suppressed {
+		email.MarkSuppressed(now)
+		return s.emails.Save(email)
+	}
+
+	headers := make(map[string]string, len(email.Headers())+2)
+	for name, value := range email.Headers() {
+		headers[name] = value
+	}
+	headers[EmailOutboxIDHeader] = email.ID()
+	if email.Recipient() != "" {
+		headers[EmailOutboxRecipientHeader] = email.Recipient()
+	}
+
+	publishErr := s.mailer.Publish(ctx, messaging.Message{
+		Topic:   email.Topic(),
+		Key:     email.Key(),
+		Payload: email.Payload(),
+		Headers: headers,
+	})
+	if publishErr == nil {
+		email.MarkSent(now)
+		return s.emails.Save(email)
+	}
+
+	var retryAt *time.Time
+	if email.Attempts()+1 < s.maxAttempts {
+		next := now.Add(s.backoff(email.Attempts() + 1))
+		retryAt = &next
+	}
+	email.RecordFailure(publishErr.Error(), now, retryAt)
+	return s.emails.Save(email)
+}
+
+// backoff returns the wait after the given number of failed attempts: retryBackoff doubled per further attempt, up to maxBackoff
+func (s *EmailOutboxService) backoff(failedAttempts int) time.Duration {
+	wait := s.retryBackoff
+	for i := 1; i < failedAttempts && wait < s.maxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > s.maxBackoff {
+		return s.maxBackoff
+	}
+	return wait
+}
+
+// GetEmail retrieves an email of the outbox by its ID
+func (s *EmailOutboxService) GetEmail(id string) (*entity.OutboundEmail, error) {
+	if !isValidUUID(id) {
+		return nil, errors.ErrOutboundEmailNotFound
+	}
+	return s.emails.GetByID(id)
+}
+
+// ListEmails retrieves the emails of the outbox, oldest first, optionally filtered by status
+func (s *EmailOutboxService) ListEmails(status entity.OutboundEmailStatus) ([]*entity.OutboundEmail, error) {
+	switch status {
+	case "", entity.OutboundEmailPending, entity.OutboundEmailSent, entity.OutboundEmailFailed, entity.OutboundEmailSuppressed:
+	default:
+		return nil, errors.NewValidationError("status", status, errors.ValidationFormat, "status must be one of: pending, sent, failed, suppressed")
+	}
+
+	emails, err := s.emails.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	if status == "" {
+		return emails, nil
+	}
+
+	filtered := make([]*entity.OutboundEmail, 0, len(emails))
+	for _, email := range emails {
+		if email.Status() == status {
+			filtered = append(filtered, email)
+		}
+	}
+	return filtered, nil
+}
+
+// QueueDepth counts the emails waiting for dispatch (operational dashboard)
+func (s *EmailOutboxService) QueueDepth() (int, error) {
+	emails, err := s.ListEmails(entity.OutboundEmailPending)
+	if err != nil {
+		return 0, err
+	}
+	return len(emails), nil
+}
+
+// RetryEmail makes a failed or suppressed email pending again, to be dispatched by the next run
+// A suppressed email stays suppressed until its recipient is removed from the suppression list
+func (s *EmailOutboxService) RetryEmail(id string, now time.Time) (*entity.OutboundEmail, error) {
+	email, err := s.GetEmail(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := email.Requeue(now); err != nil {
+		return nil, err
+	}
+	if err := s.emails.Save(email); err != nil {
+		return nil, err
+	}
+	return email, nil
+}
+
+// Metrics summarizes the outbox: emails per status and topic, attempts and retries, age of the oldest pending email
+func (s *EmailOutboxService) Metrics(now time.Time) (*EmailOutboxMetrics, error) {
+	emails, err := s.emails.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	suppressions, err := s.ListSuppressions()
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := &EmailOutboxMetrics{
+		ByStatus:            make(map[entity.OutboundEmailStatus]int),
+		ByTopic:             make(map[string]int),
+		SuppressedAddresses: len(suppressions),
+	}
+	for _, email := range emails {
+		metrics.ByStatus[email.Status()]++
+		metrics.ByTopic[email.Topic()]++
+		metrics.Attempts += email.Attempts()
+		if email.Attempts() > 1 {
+			metrics.Retries += email.Attempts() - 1
+		}
+		if email.Status() == entity.OutboundEmailPending {
+			age := now.Sub(email.CreatedAt())
+			if metrics.OldestPendingAge == nil || age > *metrics.OldestPendingAge {
+				metrics.OldestPendingAge = &age
+			}
+		}
+	}
+	return metrics, nil
+}
+
+// IsSuppressed checks if emails to an address must not be sent
+func (s *EmailOutboxService) IsSuppressed(address string) (bool, error) {
+	address = normalizeEmailAddress(address)
+	if address == "" {
+		return false, nil
+	}
+
+	suppression, err := s.suppressions.GetByAddress(address)
+	if errors.GetErrorCode(err) == errors.RepositoryNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return suppression.IsSuppressed(), nil
+}
+
+// ListSuppressions retrieves the suppressed addresses, oldest first
+func (s *EmailOutboxService) ListSuppressions() ([]*entity.EmailSuppression, error) {
+	tracked, err := s.suppressions.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	suppressed := make([]*entity.EmailSuppression, 0, len(tracked))
+	for _, suppression := range tracked {
+		if suppression.IsSuppressed() {
+			suppressed = append(suppressed, suppression)
+		}
+	}
+	return suppressed, nil
+}
+
+// Suppress adds an address to the suppression list by hand (complaint, unsubscribe request)
+func (s *EmailOutboxService) Suppress(req dtos.SuppressEmailRequest, now time.Time) (*entity.EmailSuppression, error) {
+	suppression, err := s.trackedAddress(req.Email, now)
+	if err != nil {
+		return nil, err
+	}
+	if err := suppression.Suppress(strings.TrimSpace(req.Note), now); err != nil {
+		return nil, err
+	}
+	if err := s.suppressions.Save(suppression); err != nil {
+		return nil, err
+	}
+	return suppression, nil
+}
+
+// Unsuppress removes an address from the suppression list, clearing its bounces
+func (s *EmailOutboxService) Unsuppress(address string) error {
+	address = normalizeEmailAddress(address)
+	suppression, err := s.suppressions.GetByAddress(address)
+	if err != nil {
+		return err
+	}
+	if !suppression.IsSuppressed() {
+		return errors.ErrEmailSuppressionNotFound
+	}
+	return s.suppressions.Delete(address)
+}
+
+// ReportBounce records a bounce the mailer reports for an email sent outside invoice delivery tracking
+func (s *EmailOutboxService) ReportBounce(req dtos.EmailBounceRequest, now time.Time) (*entity.EmailSuppression, error) {
+	bounceType := entity.BounceType(req.BounceType)
+	if bounceType != entity.BounceHard && bounceType != entity.BounceSoft {
+		return nil, errors.NewValidationError("bounce_type", req.BounceType, errors.ValidationFormat, "bounce type must be hard or soft")
+	}
+	occurredAt := now
+	if req.OccurredAt != nil {
+		occurredAt = *req.OccurredAt
+	}
+
+	suppression, err := s.trackedAddress(req.Email, now)
+	if err != nil {
+		return nil, err
+	}
+	suppression.RecordBounce(bounceType, strings.TrimSpace(req.Detail), s.softBounceLimit, occurredAt)
+	if err := s.suppressions.Save(suppression); err != nil {
+		return nil, err
+	}
+	return suppression, nil
+}
+
+// RecordBounce counts a bounce the mailer reported for an address, suppressing it on a hard bounce or once its
+// consecutive soft bounces reach the limit
+// Delivery events may name a recipient that is not an email address; their bounces have nothing to suppress
+func (s *EmailOutboxService) RecordBounce(address string, bounceType entity.BounceType, detail string, at time.Time) error {
+	suppression, err := s.trackedAddress(address, at)
+	if errors.IsValidationError(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	suppression.RecordBounce(bounceType, detail, s.softBounceLimit, at)
+	return s.suppressions.Save(suppression)
+}
+
+// RecordDelivery resets the soft bounces of an address the mailer delivered an email to
+func (s *EmailOutboxService) RecordDelivery(address string, at time.Time) error {
+	suppression, err := s.suppressions.GetByAddress(normalizeEmailAddress(address))
+	if errors.GetErrorCode(err) == errors.RepositoryNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if suppression.SoftBounces() == 0 {
+		return nil
+	}
+	suppression.RecordDelivery(at)
+	return s.suppressions.Save(suppression)
+}
+
+// trackedAddress retrieves the bounce record of an address, starting one when the address has none
+func (s *EmailOutboxService) trackedAddress(address string, now time.Time) (*entity.EmailSuppression, error) {
+	suppression, err := s.suppressions.GetByAddress(normalizeEmailAddress(address))
+	if errors.GetErrorCode(err) == errors.RepositoryNotFound {
+		return entity.NewEmailSuppression(address, now)
+	}
+	return suppression, err
+}
+
+// normalizeEmailAddress returns an address in the form suppressions are keyed by
+func normalizeEmailAddress(address string) string {
+	return strings.ToLower(strings.TrimSpace(address))
+}
+
+// emailOutboxPublisher persists notification messages in the outbox and publishes the others
+type emailOutboxPublisher struct {
+	outbox *EmailOutboxService
+	next   messaging.Publisher
+}
+
+// Publish persists the notification messages, then publishes the others
+func (p *emailOutboxPublisher) Publish(ctx context.Context, messages ...messaging.Message) error {
+	others := make([]messaging.Message, 0, len(messages))
+	for _, message := range messages {
+		if !strings.HasPrefix(message.Topic, NotificationTopicPrefix) {
+			others = append(others, message)
+			continue
+		}
+		if _, err := p.outbox.Enqueue(message, time.Now()); err != nil {
+			return err
+		}
+	}
+	if len(others) == 0 {
+		return nil
+	}
+	return p.next.Publish(ctx, others...)
+}
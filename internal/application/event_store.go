@@ -0,0 +1,181 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/messaging"
+)
+
+// Event log limits
+const (
+	DefaultEventLogLimit = 100
+	MaxEventLogLimit     = 1000
+)
+
+// EventTenantHeader is the message header naming the tenant an event belongs to
+// Only events carrying it are replayed to webhook subscriptions, and only to those of their tenant
+const EventTenantHeader = entity.StoredEventTenantHeader
+
+// EventLogQuery selects the events listed from the event store
+type EventLogQuery struct {
+	Type        string // Only events of this type (topic)
+	AggregateID string // Only events about this entity (message key)
+	TenantID    string // Only events of this tenant (EventTenantHeader)
+	Since       string // Cursor from a previous page or RFC 3339 timestamp (empty: from the first event)
+	Limit       int    // DefaultEventLogLimit when zero
+}
+
+// EventLogPage is a batch of the event log
+type EventLogPage struct {
+	Events []*entity.StoredEvent
+	// NextCursor is passed back as "since" to resume after the last returned event
+	NextCursor string
+	HasMore    bool
+}
+
+// EventStore keeps every domain event published on the message bus in an append-only log, so audits and
+// downstream backfills do not depend on the message bus retention
+type EventStore struct {
+	eventRepo repository.EventStoreRepository
+	now       func() time.Time
+}
+
+// NewEventStore creates a new event store
+func NewEventStore(eventRepo repository.EventStoreRepository) *EventStore {
+	return &EventStore{
+		eventRepo: eventRepo,
+		now:       time.Now,
+	}
+}
+
+// WithClock sets the clock event times are read from (tests)
+func (s *EventStore) WithClock(now func() time.Time) *EventStore {
+	s.now = now
+	return s
+}
+
+// Append records a published message in the event store
+func (s *EventStore) Append(message messaging.Message) (*entity.StoredEvent, error) {
+	sequence, err := s.eventRepo.NextSequence()
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := entity.NewStoredEvent(sequence, message.Topic, message.Key, message.Payload, message.Headers, s.now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.eventRepo.Append(event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// Publisher wraps next so every message published through it is first appended to the event store
+// A message that cannot be appended is not published, so the store holds every published event
+func (s *EventStore) Publisher(next messaging.Publisher) messaging.Publisher {
+	return &eventStorePublisher{
+		store: s,
+		next:  next,
+	}
+}
+
+// ListEvents returns the events matching query after its since cursor or timestamp, ordered by sequence
+func (s *EventStore) ListEvents(query EventLogQuery) (*EventLogPage, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = DefaultEventLogLimit
+	}
+	if limit > MaxEventLogLimit {
+		return nil, errors.NewValidationError("limit", limit, errors.ValidationRange, "limit must not exceed 1000")
+	}
+
+	since := strings.TrimSpace(query.Since)
+	filter := repository.StoredEventFilter{
+		Type:        strings.TrimSpace(query.Type),
+		AggregateID: strings.TrimSpace(query.AggregateID),
+		TenantID:    strings.TrimSpace(query.TenantID),
+	}
+
+	// Fetch one extra event to know whether more are available
+	var events []*entity.StoredEvent
+	var err error
+	if sequence, parseErr := strconv.ParseInt(since, 10, 64); since == "" || parseErr == nil {
+		events, err = s.eventRepo.ListAfterSequence(sequence, filter, limit+1)
+	} else if timestamp, parseErr := time.Parse(time.RFC3339, since); parseErr == nil {
+		events, err = s.eventRepo.ListSince(timestamp, filter, limit+1)
+	} else {
+		return nil, errors.NewValidationError("since", since, errors.ValidationFormat, "since must be an event cursor or an RFC 3339 timestamp")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	page := &EventLogPage{
+		Events:     events,
+		NextCursor: since,
+	}
+	if len(events) > limit {
+		page.Events = events[:limit]
+		page.HasMore = true
+	}
+	if len(page.Events) > 0 {
+		page.NextCursor = strconv.FormatInt(page.Events[len(page.Events)-1].Sequence(), 10)
+	}
+
+	return page, nil
+}
+
+// Replay calls fn with every event of one of types, in sequence order, reading the log a page at a time; it
+// returns the number of events replayed; progress, when not nil, is called after every page with that number
+// Projections are rebuilt by replaying the events they are derived from
+func (s *EventStore) Replay(types []string, fn func(event *entity.StoredEvent) error, progress func(replayed int)) (int, error) {
+	replayed := 0
+	var sequence int64
+	for {
+		events, err := s.eventRepo.ListAfterSequence(sequence, repository.StoredEventFilter{}, MaxEventLogLimit)
+		if err != nil {
+			return replayed, err
+		}
+		for _, event := range events {
+			sequence = event.Sequence()
+			if !slices.Contains(types, event.Type()) {
+				continue
+			}
+			if err := fn(event); err != nil {
+				return replayed, fmt.Errorf("failed to replay event %d (%s): %w", event.Sequence(), event.Type(), err)
+			}
+			replayed++
+		}
+		if progress != nil {
+			progress(replayed)
+		}
+		if len(events) < MaxEventLogLimit {
+			return replayed, nil
+		}
+	}
+}
+
+// eventStorePublisher appends messages to the event store before publishing them
+type eventStorePublisher struct {
+	store *EventStore
+	next  messaging.Publisher
+}
+
+// Publish appends every message, then publishes them all
+func (p *eventStorePublisher) Publish(ctx context.Context, messages ...messaging.Message) error {
+	for _, message := range messages {
+		if _, err := p.store.Append(message); err != nil {
+			return fmt.Errorf("failed to append %s event to the event store: %w", message.Topic, err)
+		}
+	}
+	return p.next.Publish(ctx, messages...)
+}
@@ -0,0 +1,103 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/eventbus"
+)
+
+// Topics of the events application services publish on the event bus
+var (
+	ClientCreatedTopic = eventbus.NewTopic[ClientEvent]("client.created")
+	ClientUpdatedTopic = eventbus.NewTopic[ClientEvent]("client.updated")
+	ClientDeletedTopic = eventbus.NewTopic[ClientEvent]("client.deleted")
+	InvoiceIssuedTopic = eventbus.NewTopic[InvoiceEvent]("invoice.issued")
+)
+
+// clientChangeTopics are the topics client changes are published on
+var clientChangeTopics = map[entity.ClientChangeType]eventbus.Topic[ClientEvent]{
+	entity.ClientCreated: ClientCreatedTopic,
+	entity.ClientUpdated: ClientUpdatedTopic,
+	entity.ClientDeleted: ClientDeletedTopic,
+}
+
+// ClientEvent is a client created, updated or deleted
+type ClientEvent struct {
+	ClientID   string
+	Client     *entity.Client // Client as saved, nil when deleted
+	OccurredAt time.Time
+}
+
+// InvoiceEvent is an invoice that changed status
+type InvoiceEvent struct {
+	InvoiceID  string
+	ClientID   string
+	Invoice    *entity.Invoice
+	OccurredAt time.Time
+}
+
+// Audit resource types of the entries recorded from events, which do not carry their caller: the billing service
+// is their actor
+const (
+	clientResource  = "client"
+	invoiceResource = "invoice"
+	eventsActor     = "billing_service"
+)
+
+// SubscribeAuditLog records an audit entry for every client change and issued invoice published on bus
+// Entries are recorded asynchronously, so a slow audit log does not slow requests down
+func (s *AuditService) SubscribeAuditLog(bus *eventbus.Bus) {
+	clientActions := map[eventbus.Topic[ClientEvent]]string{
+		ClientCreatedTopic: AuditActionClientCreated,
+		ClientUpdatedTopic: AuditActionClientUpdated,
+		ClientDeletedTopic: AuditActionClientDeleted,
+	}
+	for topic, action := range clientActions {
+		eventbus.Subscribe(bus, topic, "audit_log", eventbus.Async, func(ctx context.Context, event ClientEvent) error {
+			tenantID := ""
+			details := map[string]interface{}{}
+			if event.Client != nil {
+				tenantID = event.Client.TenantID()
+				details["after"] = event.Client
+			}
+			return s.Record(action, eventsActor, tenantID, clientResource, event.ClientID, details)
+		})
+	}
+	eventbus.Subscribe(bus, InvoiceIssuedTopic, "audit_log", eventbus.Async, func(ctx context.Context, event InvoiceEvent) error {
+		return s.Record(AuditActionInvoiceIssued, eventsActor, event.Invoice.TenantID(), invoiceResource, event.InvoiceID,
+			map[string]interface{}{"clientId": event.ClientID, "number": event.Invoice.Number()})
+	})
+}
+
+// WithEvents publishes client changes and issued invoices on an event bus
+func (s *BillingService) WithEvents(bus *eventbus.Bus) *BillingService {
+	s.events = bus
+	return s
+}
+
+// publishClientChange publishes a client change when an event bus is configured
+func (s *BillingService) publishClientChange(changeType entity.ClientChangeType, clientID string, client *entity.Client) {
+	if s.events == nil {
+		return
+	}
+	eventbus.Publish(context.Background(), s.events, clientChangeTopics[changeType], ClientEvent{
+		ClientID:   clientID,
+		Client:     client,
+		OccurredAt: time.Now().UTC(),
+	})
+}
+
+// publishInvoiceIssued publishes an issued invoice when an event bus is configured
+func (s *BillingService) publishInvoiceIssued(invoice *entity.Invoice) {
+	if s.events == nil {
+		return
+	}
+	eventbus.Publish(context.Background(), s.events, InvoiceIssuedTopic, InvoiceEvent{
+		InvoiceID:  invoice.ID(),
+		ClientID:   invoice.ClientID(),
+		Invoice:    invoice,
+		OccurredAt: time.Now().UTC(),
+	})
+}
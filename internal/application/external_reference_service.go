@@ -0,0 +1,108 @@
+package application
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+)
+
+// externalReferencePaths are the API paths of the resources an external reference can identify
+var externalReferencePaths = map[entity.ExternalReferenceKind]string{
+	entity.ExternalReferenceClient:           "/api/v1/clients/",
+	entity.ExternalReferenceInvoice:          "/api/v1/invoices/",
+	entity.ExternalReferenceRecurringInvoice: "/api/v1/recurring-invoices/",
+}
+
+// ExternalReferenceService keeps the per-tenant index of the references integrators store on clients and
+// invoices (their order or contract IDs), so a reference identifies at most one client, and one invoice, of a tenant
+type ExternalReferenceService struct {
+	referenceRepo repository.ExternalReferenceRepository
+	mu            sync.Mutex // Serializes claims, so two resources cannot take the same reference
+}
+
+// NewExternalReferenceService creates a new external reference service
+func NewExternalReferenceService(referenceRepo repository.ExternalReferenceRepository) *ExternalReferenceService {
+	return &ExternalReferenceService{
+		referenceRepo: referenceRepo,
+	}
+}
+
+// Claim records the reference of a client or invoice of a tenant (empty references are not recorded)
+// A reference held by another resource of the same kind is a conflict pointing to that resource
+func (s *ExternalReferenceService) Claim(kind entity.ExternalReferenceKind, tenantID, reference, resourceID string) error {
+	reference, err := entity.NormalizeExternalReference(reference)
+	if err != nil || reference == "" {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, err := s.referenceRepo.Get(kind, tenantID, reference)
+	if err == nil {
+		if existing.ResourceID() == resourceID {
+			return nil
+		}
+		return externalReferenceConflict(existing)
+	}
+	if errors.GetErrorCode(err) != errors.RepositoryNotFound {
+		return err
+	}
+
+	entry, err := entity.NewExternalReference(kind, tenantID, reference, resourceID)
+	if err != nil {
+		return err
+	}
+	return s.referenceRepo.Save(entry)
+}
+
+// Release removes the reference of a resource from the index, once the resource is deleted or could not be saved
+// References held by another resource are left alone
+func (s *ExternalReferenceService) Release(kind entity.ExternalReferenceKind, tenantID, reference, resourceID string) error {
+	reference = strings.TrimSpace(reference)
+	if reference == "" {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, err := s.referenceRepo.Get(kind, tenantID, reference)
+	if err != nil {
+		if errors.GetErrorCode(err) == errors.RepositoryNotFound {
+			return nil
+		}
+		return err
+	}
+	if existing.ResourceID() != resourceID {
+		return nil
+	}
+	return s.referenceRepo.Delete(kind, tenantID, reference)
+}
+
+// Resolve returns the ID of the client or invoice of a tenant with a reference
+func (s *ExternalReferenceService) Resolve(kind entity.ExternalReferenceKind, tenantID, reference string) (string, error) {
+	reference = strings.TrimSpace(reference)
+	if reference == "" {
+		return "", errors.NewValidationError("external_ref", reference, errors.ValidationRequired, "external reference is required")
+	}
+
+	existing, err := s.referenceRepo.Get(kind, tenantID, reference)
+	if err != nil {
+		return "", err
+	}
+	return existing.ResourceID(), nil
+}
+
+// externalReferenceConflict builds the conflict of a reference already held, pointing to its resource
+func externalReferenceConflict(existing *entity.ExternalReference) error {
+	conflict := errors.NewBusinessRuleError("external_ref_unique", errors.BusinessRuleDuplicate,
+		"another "+strings.ReplaceAll(string(existing.Kind()), "_", " ")+" of the tenant already has this external reference")
+	conflict.Context["match"] = "external_ref"
+	conflict.Context["existing_id"] = existing.ResourceID()
+	conflict.Context["existing_url"] = externalReferencePaths[existing.Kind()] + existing.ResourceID()
+	return conflict
+}
@@ -0,0 +1,185 @@
+package application
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+)
+
+// fiscalCalendarResource is the audit resource type for tenant fiscal calendars
+const fiscalCalendarResource = "fiscal_calendar"
+
+// FiscalCalendarService manages tenant fiscal calendars and period locks, and resolves the fiscal period
+// of invoice dates for issuance and numbering
+type FiscalCalendarService struct {
+	calendarRepo repository.FiscalCalendarRepository
+	auditService *AuditService
+	mu           sync.Mutex // Serializes calendar changes, so concurrent period closes are not lost
+}
+
+// NewFiscalCalendarService creates a new fiscal calendar service
+func NewFiscalCalendarService(calendarRepo repository.FiscalCalendarRepository, auditService *AuditService) *FiscalCalendarService {
+	return &FiscalCalendarService{
+		calendarRepo: calendarRepo,
+		auditService: auditService,
+	}
+}
+
+// GetCalendar retrieves the fiscal calendar of a tenant
+func (s *FiscalCalendarService) GetCalendar(tenantID string) (*entity.FiscalCalendar, error) {
+	return s.calendarRepo.GetByTenantID(tenantID)
+}
+
+// ListCalendars retrieves all tenant fiscal calendars
+func (s *FiscalCalendarService) ListCalendars() ([]*entity.FiscalCalendar, error) {
+	return s.calendarRepo.GetAll()
+}
+
+// SetCalendar replaces the start month and periods of a tenant calendar and records the change in the audit log
+// Closed periods are kept, so the definition can only change once they are all reopened
+func (s *FiscalCalendarService) SetCalendar(actor, tenantID string, req dtos.SetFiscalCalendarRequest) (*entity.FiscalCalendar, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	calendar, err := entity.NewFiscalCalendar(tenantID, req.StartMonth, req.PeriodMonths)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the previous calendar (if any) for the audit trail
+	previous, err := s.calendarRepo.GetByTenantID(calendar.TenantID())
+	if err != nil && errors.GetErrorCode(err) != errors.RepositoryNotFound {
+		return nil, err
+	}
+
+	details := make(map[string]interface{})
+	if previous != nil {
+		details["before"] = previous
+		// Reload rather than reuse previous, which is recorded in the audit entry as it was
+		if calendar, err = s.calendarRepo.GetByTenantID(calendar.TenantID()); err != nil {
+			return nil, err
+		}
+		if err := calendar.Redefine(req.StartMonth, req.PeriodMonths); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.calendarRepo.Save(calendar); err != nil {
+		return nil, err
+	}
+
+	details["after"] = calendar
+	if err := s.auditService.Record(AuditActionFiscalCalendarSet, actor, calendar.TenantID(), fiscalCalendarResource, calendar.TenantID(), details); err != nil {
+		return nil, err
+	}
+
+	return calendar, nil
+}
+
+// DeleteCalendar removes the fiscal calendar of a tenant, reverting it to calendar years split into months,
+// and records the change in the audit log; calendars with closed periods cannot be removed
+func (s *FiscalCalendarService) DeleteCalendar(actor, tenantID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous, err := s.calendarRepo.GetByTenantID(tenantID)
+	if err != nil {
+		return err
+	}
+	if len(previous.ClosedPeriods()) > 0 {
+		return errors.ErrFiscalCalendarHasClosedPeriods
+	}
+
+	if err := s.calendarRepo.Delete(tenantID); err != nil {
+		return err
+	}
+
+	details := map[string]interface{}{"before": previous}
+	return s.auditService.Record(AuditActionFiscalCalendarDeleted, actor, tenantID, fiscalCalendarResource, tenantID, details)
+}
+
+// CalendarFor returns the fiscal calendar of a tenant
+// Tenants without a calendar (and invoices without a tenant) use entity.DefaultFiscalCalendar
+func (s *FiscalCalendarService) CalendarFor(tenantID string) (*entity.FiscalCalendar, error) {
+	if tenantID == "" {
+		return entity.DefaultFiscalCalendar(tenantID), nil
+	}
+
+	calendar, err := s.calendarRepo.GetByTenantID(tenantID)
+	if err != nil {
+		if errors.GetErrorCode(err) == errors.RepositoryNotFound {
+			return entity.DefaultFiscalCalendar(tenantID), nil
+		}
+		return nil, err
+	}
+	return calendar, nil
+}
+
+// ListPeriods returns the periods of a tenant fiscal year with their open or closed status
+// A zero fiscal year selects the fiscal year of now
+func (s *FiscalCalendarService) ListPeriods(tenantID string, fiscalYear int, now time.Time) (int, []entity.FiscalPeriod, error) {
+	calendar, err := s.CalendarFor(tenantID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if fiscalYear == 0 {
+		fiscalYear = calendar.FiscalYear(now)
+	}
+	if fiscalYear < 1 || fiscalYear > 9999 {
+		return 0, nil, errors.NewValidationError("year", fiscalYear, errors.ValidationRange, "fiscal year must be between 1 and 9999")
+	}
+	return fiscalYear, calendar.Periods(fiscalYear), nil
+}
+
+// PeriodOf returns the fiscal period of a tenant a date falls in (issuance and numbering entry point)
+func (s *FiscalCalendarService) PeriodOf(tenantID string, date time.Time) (entity.FiscalPeriod, error) {
+	calendar, err := s.CalendarFor(tenantID)
+	if err != nil {
+		return entity.FiscalPeriod{}, err
+	}
+	return calendar.PeriodOf(date), nil
+}
+
+// ClosePeriod locks a period of a tenant, so no invoice is issued on its dates, and records it in the audit log
+// Closing a period of a tenant without a calendar saves the default calendar for it
+func (s *FiscalCalendarService) ClosePeriod(actor, tenantID, key string) (entity.FiscalPeriod, error) {
+	return s.changePeriod(actor, tenantID, key, AuditActionFiscalPeriodClosed, (*entity.FiscalCalendar).ClosePeriod)
+}
+
+// ReopenPeriod unlocks a closed period of a tenant and records it in the audit log
+func (s *FiscalCalendarService) ReopenPeriod(actor, tenantID, key string) (entity.FiscalPeriod, error) {
+	return s.changePeriod(actor, tenantID, key, AuditActionFiscalPeriodReopened, (*entity.FiscalCalendar).ReopenPeriod)
+}
+
+// changePeriod applies a period status change to the calendar of a tenant, saves it and audits it
+func (s *FiscalCalendarService) changePeriod(actor, tenantID, key, action string, change func(*entity.FiscalCalendar, string) (entity.FiscalPeriod, error)) (entity.FiscalPeriod, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if tenantID == "" {
+		return entity.FiscalPeriod{}, errors.NewValidationError("tenant_id", tenantID, errors.ValidationRequired, "tenant ID is required")
+	}
+	calendar, err := s.CalendarFor(tenantID)
+	if err != nil {
+		return entity.FiscalPeriod{}, err
+	}
+
+	period, err := change(calendar, key)
+	if err != nil {
+		return entity.FiscalPeriod{}, err
+	}
+	if err := s.calendarRepo.Save(calendar); err != nil {
+		return entity.FiscalPeriod{}, err
+	}
+
+	details := map[string]interface{}{"period": period.Key()}
+	if err := s.auditService.Record(action, actor, tenantID, fiscalCalendarResource, tenantID, details); err != nil {
+		return entity.FiscalPeriod{}, err
+	}
+	return period, nil
+}
@@ -0,0 +1,73 @@
+package application
+
+import (
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+)
+
+// Form token purposes
+const (
+	FormPurposeCreateClient = "client.create"
+)
+
+// DefaultFormTokenTTL is how long an issued form token stays redeemable
+const DefaultFormTokenTTL = 30 * time.Minute
+
+// FormTokenService issues and redeems single-use form tokens for browser clients
+type FormTokenService struct {
+	tokenRepo repository.FormTokenRepository
+	ttl       time.Duration
+	now       func() time.Time
+}
+
+// NewFormTokenService creates a new form token service
+func NewFormTokenService(tokenRepo repository.FormTokenRepository, ttl time.Duration) *FormTokenService {
+	if ttl <= 0 {
+		ttl = DefaultFormTokenTTL
+	}
+
+	return &FormTokenService{
+		tokenRepo: tokenRepo,
+		ttl:       ttl,
+		now:       time.Now,
+	}
+}
+
+// Issue creates and persists a new token for purpose
+func (s *FormTokenService) Issue(purpose string) (*entity.FormToken, error) {
+	token, err := entity.NewFormToken(purpose, s.ttl)
+	if err != nil {
+		return nil, errors.NewRepositoryError("issue_form_token", errors.RepositoryInternal, "failed to generate form token", err)
+	}
+
+	if err := s.tokenRepo.Save(token); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// Redeem consumes a token for purpose; a second redemption of the same token fails
+func (s *FormTokenService) Redeem(token, purpose string) (*entity.FormToken, error) {
+	formToken, err := s.tokenRepo.Consume(token)
+	if err != nil {
+		return nil, err
+	}
+
+	if formToken.Purpose() != purpose {
+		return nil, errors.ErrFormTokenInvalid
+	}
+
+	if formToken.IsExpired(s.now()) {
+		return nil, errors.ErrFormTokenExpired
+	}
+
+	return formToken, nil
+}
+
+// Restore makes a redeemed token usable again (e.g. after the submission failed validation)
+func (s *FormTokenService) Restore(token *entity.FormToken) error {
+	return s.tokenRepo.Save(token)
+}
@@ -0,0 +1,153 @@
+package application
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+)
+
+// DefaultProcessedMessageTTL is how long a processed message is remembered, covering the redelivery window of providers and brokers
+const DefaultProcessedMessageTTL = 7 * 24 * time.Hour
+
+// IdempotentConsumer runs the processing of each message once, whatever the number of times the message is delivered
+// Event consumers and webhook processors wrap their side effects (e.g. billing) in ProcessOnce
+type IdempotentConsumer struct {
+	messageRepo repository.ProcessedMessageRepository
+	ttl         time.Duration
+	now         func() time.Time
+
+	// mu guards locks, which serialize the deliveries of the same message
+	mu    sync.Mutex
+	locks map[string]*messageLock
+}
+
+// messageLock serializes the deliveries of a message; refs counts the deliveries holding or waiting for it
+type messageLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// NewIdempotentConsumer creates a new idempotent consumer
+// Processed messages are remembered for ttl (DefaultProcessedMessageTTL when zero)
+func NewIdempotentConsumer(messageRepo repository.ProcessedMessageRepository, ttl time.Duration) *IdempotentConsumer {
+	if ttl <= 0 {
+		ttl = DefaultProcessedMessageTTL
+	}
+
+	return &IdempotentConsumer{
+		messageRepo: messageRepo,
+		ttl:         ttl,
+		now:         time.Now,
+		locks:       make(map[string]*messageLock),
+	}
+}
+
+// WithClock sets the clock processing times and expirations are read from (tests)
+func (c *IdempotentConsumer) WithClock(now func() time.Time) *IdempotentConsumer {
+	c.now = now
+	return c
+}
+
+// ProcessOnce runs fn unless messageID was already processed; processed reports whether fn ran
+// The message is only recorded when fn succeeds, so a failed processing runs again on the next delivery
+// Concurrent deliveries of the same message wait for each other instead of running fn twice
+func (c *IdempotentConsumer) ProcessOnce(messageID string, fn func() error) (processed bool, err error) {
+	unlock := c.lock(messageID)
+	defer unlock()
+
+	now := c.now()
+	record, err := c.messageRepo.GetByMessageID(messageID)
+	if err != nil && errors.GetErrorCode(err) != errors.RepositoryNotFound {
+		return false, err
+	}
+	if record != nil && !record.IsExpired(now) {
+		return false, nil
+	}
+
+	record, err = entity.NewProcessedMessage(messageID, now, c.ttl)
+	if err != nil {
+		return false, err
+	}
+	if err := fn(); err != nil {
+		return false, err
+	}
+	if err := c.messageRepo.Save(record); err != nil {
+		return true, err
+	}
+	return true, nil
+}
+
+// Cleanup deletes the records of messages processed longer than the TTL ago and returns how many were deleted
+func (c *IdempotentConsumer) Cleanup() (int, error) {
+	records, err := c.messageRepo.GetAll()
+	if err != nil {
+		return 0, err
+	}
+
+	now := c.now()
+	removed := 0
+	for _, record := range records {
+		if !record.IsExpired(now) {
+			continue
+		}
+
+		deleted, err := c.deleteExpired(record.MessageID(), now)
+		if err != nil {
+			return removed, err
+		}
+		if deleted {
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+// deleteExpired deletes the record of a message if it is still expired once the message is locked,
+// so a redelivery processed meanwhile keeps its fresh record
+func (c *IdempotentConsumer) deleteExpired(messageID string, now time.Time) (bool, error) {
+	unlock := c.lock(messageID)
+	defer unlock()
+
+	record, err := c.messageRepo.GetByMessageID(messageID)
+	if err != nil {
+		if errors.GetErrorCode(err) == errors.RepositoryNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	if !record.IsExpired(now) {
+		return false, nil
+	}
+
+	if err := c.messageRepo.Delete(messageID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// lock acquires the lock of a message and returns its release
+func (c *IdempotentConsumer) lock(messageID string) func() {
+	c.mu.Lock()
+	lock, ok := c.locks[messageID]
+	if !ok {
+		lock = &messageLock{}
+		c.locks[messageID] = lock
+	}
+	lock.refs++
+	c.mu.Unlock()
+
+	lock.mu.Lock()
+	return func() {
+		lock.mu.Unlock()
+
+		c.mu.Lock()
+		lock.refs--
+		if lock.refs == 0 {
+			delete(c.locks, messageID)
+		}
+		c.mu.Unlock()
+	}
+}
@@ -0,0 +1,122 @@
+package application
+
+import (
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+)
+
+// DefaultIntegrationLogRetention is how long outbound integration calls are kept for support investigations
+const DefaultIntegrationLogRetention = 30 * 24 * time.Hour
+
+// IntegrationLogFilter narrows the integration logs listed for an investigation
+type IntegrationLogFilter struct {
+	Destination string    // Only calls to this destination (e.g. "payment_gateway")
+	RequestID   string    // Only calls made while serving this API request
+	FailedOnly  bool      // Only calls without a response or with a 429/5xx response
+	Since       time.Time // Only calls made at or after this time
+}
+
+// IntegrationLogService records outbound integration calls with their personal data and credentials redacted,
+// and deletes them once older than the retention period
+type IntegrationLogService struct {
+	logRepo   repository.IntegrationLogRepository
+	retention time.Duration
+	now       func() time.Time
+}
+
+// NewIntegrationLogService creates a new integration log service
+// Logs are kept for retention (DefaultIntegrationLogRetention when zero)
+func NewIntegrationLogService(logRepo repository.IntegrationLogRepository, retention time.Duration) *IntegrationLogService {
+	if retention <= 0 {
+		retention = DefaultIntegrationLogRetention
+	}
+
+	return &IntegrationLogService{
+		logRepo:   logRepo,
+		retention: retention,
+		now:       time.Now,
+	}
+}
+
+// WithClock sets the clock call times and expirations are read from (tests)
+func (s *IntegrationLogService) WithClock(now func() time.Time) *IntegrationLogService {
+	s.now = now
+	return s
+}
+
+// Record redacts and persists an outbound call; contentTypes are those of the request and response bodies
+func (s *IntegrationLogService) Record(call entity.IntegrationCall, requestContentType, responseContentType string) (*entity.IntegrationLog, error) {
+	call.URL = service.RedactURL(call.URL)
+	call.RequestBody = service.RedactBody(call.RequestBody, requestContentType)
+	call.ResponseBody = service.RedactBody(call.ResponseBody, responseContentType)
+
+	log, err := entity.NewIntegrationLog(call, s.now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.logRepo.Save(log); err != nil {
+		return nil, err
+	}
+	return log, nil
+}
+
+// Get retrieves an integration log by its ID
+func (s *IntegrationLogService) Get(id string) (*entity.IntegrationLog, error) {
+	return s.logRepo.GetByID(id)
+}
+
+// List retrieves the integration logs matching filter, most recent first
+func (s *IntegrationLogService) List(filter IntegrationLogFilter) ([]*entity.IntegrationLog, error) {
+	logs, err := s.logRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]*entity.IntegrationLog, 0, len(logs))
+	for _, log := range logs {
+		call := log.Call()
+		if filter.Destination != "" && call.Destination != filter.Destination {
+			continue
+		}
+		if filter.RequestID != "" && call.RequestID != filter.RequestID {
+			continue
+		}
+		if filter.FailedOnly && !log.IsFailed() {
+			continue
+		}
+		if !filter.Since.IsZero() && log.CreatedAt().Before(filter.Since) {
+			continue
+		}
+		filtered = append(filtered, log)
+	}
+	return filtered, nil
+}
+
+// Cleanup deletes the logs older than the retention period and returns how many were deleted
+func (s *IntegrationLogService) Cleanup() (int, error) {
+	logs, err := s.logRepo.GetAll()
+	if err != nil {
+		return 0, err
+	}
+
+	now := s.now()
+	removed := 0
+	for _, log := range logs {
+		if !log.IsExpired(now, s.retention) {
+			continue
+		}
+		if err := s.logRepo.Delete(log.ID()); err != nil {
+			// Deleted by a concurrent cleanup
+			if errors.GetErrorCode(err) == errors.RepositoryNotFound {
+				continue
+			}
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
@@ -0,0 +1,130 @@
+package application
+
+import (
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+)
+
+// DefaultInvoiceArchiveAfterYears is how many years after their issue date closed invoices move to the archive
+const DefaultInvoiceArchiveAfterYears = 2
+
+// DefaultInvoiceRehydrationHold is how long a rehydrated invoice stays in the hot tables before it is archived again
+const DefaultInvoiceRehydrationHold = 30 * 24 * time.Hour
+
+// InvoiceArchiveService moves closed invoices and their payments out of the hot tables into the archive once they
+// are older than the archive age, and restores (rehydrates) them on demand
+// Archived invoices are kept as they were, so they still meet retention obligations
+type InvoiceArchiveService struct {
+	invoices   repository.InvoiceRepository
+	payments   repository.PaymentRepository
+	archive    repository.InvoiceArchiveRepository
+	afterYears int
+	hold       time.Duration
+}
+
+// NewInvoiceArchiveService creates a new invoice archive service
+// Invoices are archived afterYears after their issue date (DefaultInvoiceArchiveAfterYears when zero) and stay hot
+// for hold after a rehydration (DefaultInvoiceRehydrationHold when zero)
+func NewInvoiceArchiveService(invoiceRepo repository.InvoiceRepository, paymentRepo repository.PaymentRepository, archiveRepo repository.InvoiceArchiveRepository, afterYears int, hold time.Duration) *InvoiceArchiveService {
+	if afterYears <= 0 {
+		afterYears = DefaultInvoiceArchiveAfterYears
+	}
+	if hold <= 0 {
+		hold = DefaultInvoiceRehydrationHold
+	}
+
+	return &InvoiceArchiveService{
+		invoices:   invoiceRepo,
+		payments:   paymentRepo,
+		archive:    archiveRepo,
+		afterYears: afterYears,
+		hold:       hold,
+	}
+}
+
+// Run archives the paid and void invoices issued more than the archive age ago and returns how many were archived
+// The archive is written before the hot rows are deleted: an interrupted run leaves an invoice in both places, which
+// the next run archives again, never an invoice in neither
+func (s *InvoiceArchiveService) Run(now time.Time) (int, error) {
+	invoices, err := s.invoices.GetAll()
+	if err != nil {
+		return 0, err
+	}
+
+	cutoff := now.AddDate(-s.afterYears, 0, 0)
+	archived := 0
+	for _, invoice := range invoices {
+		if !invoice.ClosedBefore(cutoff) {
+			continue
+		}
+		if rehydratedAt := invoice.RehydratedAt(); rehydratedAt != nil && now.Sub(*rehydratedAt) < s.hold {
+			continue
+		}
+		if err := s.archiveInvoice(invoice, now); err != nil {
+			return archived, err
+		}
+		archived++
+	}
+	return archived, nil
+}
+
+// Get retrieves an archived invoice with its payments without rehydrating it
+func (s *InvoiceArchiveService) Get(invoiceID string) (*entity.ArchivedInvoice, error) {
+	if !isValidUUID(invoiceID) {
+		return nil, errors.ErrArchivedInvoiceNotFound
+	}
+	return s.archive.GetByInvoiceID(invoiceID)
+}
+
+// Rehydrate moves an archived invoice and its payments back to the hot tables
+// The invoice stays hot for the rehydration hold before a run archives it again
+func (s *InvoiceArchiveService) Rehydrate(invoiceID string, now time.Time) (*entity.Invoice, error) {
+	archived, err := s.Get(invoiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Payments are restored before the invoice, so a restored invoice always has its payments
+	for _, payment := range archived.Payments() {
+		if err := s.payments.Save(payment); err != nil {
+			return nil, err
+		}
+	}
+	invoice := archived.Invoice()
+	invoice.MarkRehydrated(now)
+	if err := s.invoices.Save(invoice); err != nil {
+		return nil, err
+	}
+	if err := s.archive.Delete(invoiceID); err != nil && errors.GetErrorCode(err) != errors.RepositoryNotFound {
+		return nil, err
+	}
+	return invoice, nil
+}
+
+// archiveInvoice copies an invoice with its payments to the archive, then deletes them from the hot tables
+func (s *InvoiceArchiveService) archiveInvoice(invoice *entity.Invoice, now time.Time) error {
+	payments, err := s.payments.ListByInvoice(invoice.ID())
+	if err != nil {
+		return err
+	}
+	archived, err := entity.NewArchivedInvoice(invoice, payments, now)
+	if err != nil {
+		return err
+	}
+	if err := s.archive.Save(archived); err != nil {
+		return err
+	}
+
+	for _, payment := range payments {
+		if err := s.payments.Delete(payment.ID()); err != nil {
+			return err
+		}
+	}
+	if err := s.invoices.Delete(invoice.ID()); err != nil && errors.GetErrorCode(err) != errors.RepositoryNotFound {
+		return err
+	}
+	return nil
+}
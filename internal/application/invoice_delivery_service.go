@@ -0,0 +1,241 @@
+package application
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/messaging"
+)
+
+// DeliveryFollowUpTopic is the message bus topic follow-up actions for bounced invoices are published on
+const DeliveryFollowUpTopic = "billing.invoices.delivery_follow_up"
+
+// DefaultBounceFollowUpRules are the follow-up actions triggered per bounce type when none are configured
+var DefaultBounceFollowUpRules = map[string][]string{
+	string(entity.BounceHard): {"flag_client_email", "notify_account_manager"},
+	string(entity.BounceSoft): {"retry_send"},
+}
+
+// DeliveryFollowUpEvent is the payload published for every follow-up action a bounce triggers
+type DeliveryFollowUpEvent struct {
+	InvoiceID  string    `json:"invoice_id"`
+	EventID    string    `json:"event_id"`
+	Recipient  string    `json:"recipient,omitempty"`
+	BounceType string    `json:"bounce_type"`
+	Action     string    `json:"action"`
+	BouncedAt  time.Time `json:"bounced_at"`
+}
+
+// BounceRecorder keeps the email suppression list up to date with the bounces and deliveries the mailer reports
+type BounceRecorder interface {
+	RecordBounce(address string, bounceType entity.BounceType, detail string, at time.Time) error
+	RecordDelivery(address string, at time.Time) error
+}
+
+// InvoiceDelivery is the delivery history of an invoice with its derived state
+type InvoiceDelivery struct {
+	InvoiceID string
+	Summary   entity.InvoiceDeliverySummary
+	Events    []*entity.InvoiceDeliveryEvent
+}
+
+// InvoiceDeliveryService tracks how invoices reach clients: emails sent, delivered or bounced and views
+// reported by the tracking pixel embedded in invoice emails and portal pages
+type InvoiceDeliveryService struct {
+	eventRepo   repository.InvoiceDeliveryEventRepository
+	publisher   messaging.Publisher
+	secret      []byte
+	bounceRules map[string][]string
+	bounces     BounceRecorder
+}
+
+// NewInvoiceDeliveryService creates a new invoice delivery service
+// View tracking tokens are signed with trackingSecret and disabled when it is empty;
+// bounceRules maps a bounce type to the follow-up actions published when it happens
+func NewInvoiceDeliveryService(eventRepo repository.InvoiceDeliveryEventRepository, publisher messaging.Publisher, trackingSecret string, bounceRules map[string][]string) *InvoiceDeliveryService {
+	if bounceRules == nil {
+		bounceRules = DefaultBounceFollowUpRules
+	}
+
+	return &InvoiceDeliveryService{
+		eventRepo:   eventRepo,
+		publisher:   publisher,
+		secret:      []byte(trackingSecret),
+		bounceRules: bounceRules,
+	}
+}
+
+// WithBounceRecorder reports the bounces and deliveries of invoice emails to the email suppression list
+func (s *InvoiceDeliveryService) WithBounceRecorder(bounces BounceRecorder) *InvoiceDeliveryService {
+	s.bounces = bounces
+	return s
+}
+
+// RecordEvent records a delivery event reported by the mailer and publishes the follow-ups of a bounce
+func (s *InvoiceDeliveryService) RecordEvent(ctx context.Context, invoiceID string, req dtos.RecordDeliveryEventRequest) (*entity.InvoiceDeliveryEvent, error) {
+	var occurredAt time.Time
+	if req.OccurredAt != nil {
+		occurredAt = *req.OccurredAt
+	}
+
+	event, err := entity.NewInvoiceDeliveryEvent(
+		invoiceID,
+		entity.DeliveryEventType(req.Type),
+		entity.DeliveryChannel(req.Channel),
+		req.Recipient,
+		entity.BounceType(req.BounceType),
+		req.Detail,
+		occurredAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.eventRepo.Append(event); err != nil {
+		return nil, err
+	}
+
+	if err := s.recordBounce(event); err != nil {
+		return nil, err
+	}
+
+	if event.Type() == entity.DeliveryBounced {
+		if err := s.publishFollowUps(ctx, event); err != nil {
+			return nil, err
+		}
+	}
+
+	return event, nil
+}
+
+// recordBounce reports the bounce or delivery of an invoice email to the suppression list
+func (s *InvoiceDeliveryService) recordBounce(event *entity.InvoiceDeliveryEvent) error {
+	if s.bounces == nil || event.Channel() != entity.DeliveryChannelEmail || event.Recipient() == "" {
+		return nil
+	}
+
+	switch event.Type() {
+	case entity.DeliveryBounced:
+		return s.bounces.RecordBounce(event.Recipient(), event.BounceType(), event.Detail(), event.OccurredAt())
+	case entity.DeliveryDelivered:
+		return s.bounces.RecordDelivery(event.Recipient(), event.OccurredAt())
+	}
+	return nil
+}
+
+// TrackingEnabled checks if view tracking tokens can be issued
+func (s *InvoiceDeliveryService) TrackingEnabled() bool {
+	return len(s.secret) > 0
+}
+
+// TrackingToken creates the view tracking token embedded in an invoice sent to recipient on channel
+// It returns an empty token when view tracking is disabled
+func (s *InvoiceDeliveryService) TrackingToken(invoiceID string, channel entity.DeliveryChannel, recipient string) string {
+	if !s.TrackingEnabled() {
+		return ""
+	}
+
+	payload := string(channel) + ":" + invoiceID + ":" + recipient
+	return base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." + s.sign(payload)
+}
+
+// TrackingPixelPath returns the path of the tracking pixel for a token, relative to the API base URL
+func TrackingPixelPath(invoiceID, token string) string {
+	return "/api/v1/invoices/" + url.PathEscape(invoiceID) + "/view.gif?token=" + url.QueryEscape(token)
+}
+
+// RecordView verifies a tracking token issued for invoiceID and records the view it reports
+func (s *InvoiceDeliveryService) RecordView(invoiceID, token string, now time.Time) (*entity.InvoiceDeliveryEvent, error) {
+	if !s.TrackingEnabled() {
+		return nil, errors.ErrInvoiceTrackingTokenInvalid
+	}
+
+	encodedPayload, signature, found := strings.Cut(token, ".")
+	if !found {
+		return nil, errors.ErrInvoiceTrackingTokenInvalid
+	}
+
+	rawPayload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return nil, errors.ErrInvoiceTrackingTokenInvalid
+	}
+	payload := string(rawPayload)
+
+	if !hmac.Equal([]byte(signature), []byte(s.sign(payload))) {
+		return nil, errors.ErrInvoiceTrackingTokenInvalid
+	}
+
+	parts := strings.SplitN(payload, ":", 3)
+	if len(parts) != 3 || parts[1] != invoiceID {
+		return nil, errors.ErrInvoiceTrackingTokenInvalid
+	}
+
+	event, err := entity.NewInvoiceDeliveryEvent(invoiceID, entity.DeliveryViewed, entity.DeliveryChannel(parts[0]), parts[2], "", "", now)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.eventRepo.Append(event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// GetDelivery retrieves the delivery events of an invoice with the delivery state they add up to
+func (s *InvoiceDeliveryService) GetDelivery(invoiceID string) (*InvoiceDelivery, error) {
+	events, err := s.eventRepo.ListByInvoice(invoiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &InvoiceDelivery{
+		InvoiceID: invoiceID,
+		Summary:   entity.SummarizeInvoiceDelivery(events),
+		Events:    events,
+	}, nil
+}
+
+// publishFollowUps publishes one message per follow-up action configured for the bounce type
+func (s *InvoiceDeliveryService) publishFollowUps(ctx context.Context, event *entity.InvoiceDeliveryEvent) error {
+	for _, action := range s.bounceRules[string(event.BounceType())] {
+		payload, err := json.Marshal(DeliveryFollowUpEvent{
+			InvoiceID:  event.InvoiceID(),
+			EventID:    event.ID(),
+			Recipient:  event.Recipient(),
+			BounceType: string(event.BounceType()),
+			Action:     action,
+			BouncedAt:  event.OccurredAt(),
+		})
+		if err != nil {
+			return err
+		}
+
+		message := messaging.Message{
+			Topic:   DeliveryFollowUpTopic,
+			Key:     event.InvoiceID(),
+			Payload: payload,
+			Headers: map[string]string{"content-type": "application/json"},
+		}
+		if err := s.publisher.Publish(ctx, message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sign returns the URL-safe HMAC-SHA256 signature of a tracking token payload
+func (s *InvoiceDeliveryService) sign(payload string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte("invoice-view:" + payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
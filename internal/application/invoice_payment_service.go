@@ -0,0 +1,366 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/messaging"
+)
+
+// InvoicePaymentSagaType is the saga type of invoice card payments
+const InvoicePaymentSagaType = "invoice_payment"
+
+// Message bus topics of the invoice payment saga
+const (
+	InvoicePaymentTopic = "billing.payments.invoice_payments"      // Invoicing marks invoices paid (or unpaid again on reversal)
+	LedgerPostingTopic  = "billing.ledger.postings"                // The ledger posts (or reverses) payment entries
+	PaymentReceiptTopic = "billing.notifications.payment_receipts" // Notifications send payment receipts to clients
+)
+
+// Ledger accounts of invoice card payments
+const (
+	LedgerAccountGatewayClearing    = "gateway_clearing"
+	LedgerAccountAccountsReceivable = "accounts_receivable"
+)
+
+// Invoice payment saga steps
+const (
+	invoicePaymentStepCharge   = "charge_card"
+	invoicePaymentStepMarkPaid = "mark_invoice_paid"
+	invoicePaymentStepLedger   = "post_ledger_entries"
+	invoicePaymentStepNotify   = "notify_client"
+)
+
+// Invoice payment saga data keys
+const (
+	invoicePaymentInvoiceID = "invoice_id"
+	invoicePaymentClientID  = "client_id"
+	invoicePaymentTenantID  = "tenant_id"
+	invoicePaymentAmount    = "amount"
+	invoicePaymentCurrency  = "currency"
+	invoicePaymentMethod    = "payment_method"
+	invoicePaymentChargeID  = "charge_id"
+	invoicePaymentID        = "payment_id"
+)
+
+// CardCharger charges cards through the payment gateway
+type CardCharger interface {
+	// Charge charges a card and returns the gateway charge ID; charges with the same reference are charged once
+	Charge(ctx context.Context, charge service.CardCharge) (string, error)
+
+	// Refund refunds a charge in full; refunds with the same reference are refunded once
+	Refund(ctx context.Context, chargeID, reference string) error
+}
+
+// InvoicePaymentEvent is the payload published when an invoice is paid by card, or when the payment is reversed
+type InvoicePaymentEvent struct {
+	SagaID     string    `json:"saga_id"`
+	InvoiceID  string    `json:"invoice_id"`
+	ClientID   string    `json:"client_id"`
+	Amount     int64     `json:"amount"`
+	Currency   string    `json:"currency"`
+	ChargeID   string    `json:"charge_id"`
+	Reversed   bool      `json:"reversed"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// LedgerPostingLine is a line of a LedgerPostingEvent; exactly one of debit and credit is set
+type LedgerPostingLine struct {
+	Account string `json:"account"`
+	Debit   int64  `json:"debit,omitempty"`
+	Credit  int64  `json:"credit,omitempty"`
+}
+
+// LedgerPostingEvent is the payload published for the ledger entries of an invoice payment, or their reversal
+type LedgerPostingEvent struct {
+	Reference string              `json:"reference"` // Saga ID, identical for the entries and their reversal
+	InvoiceID string              `json:"invoice_id"`
+	Currency  string              `json:"currency"`
+	Lines     []LedgerPostingLine `json:"lines"`
+	Reversal  bool                `json:"reversal"`
+	PostedAt  time.Time           `json:"posted_at"`
+}
+
+// PaymentReceiptEvent is the payload published for the payment receipt sent to the client
+type PaymentReceiptEvent struct {
+	InvoiceID string    `json:"invoice_id"`
+	ClientID  string    `json:"client_id"`
+	Amount    int64     `json:"amount"`
+	Currency  string    `json:"currency"`
+	ChargeID  string    `json:"charge_id"`
+	PaidAt    time.Time `json:"paid_at"`
+}
+
+// InvoicePaymentService pays invoices by card with a saga: the card is charged, the invoice marked paid,
+// the ledger entries posted and the client notified. When a step before the notification keeps failing,
+// the ledger entries are reversed, the invoice marked unpaid and the charge refunded
+type InvoicePaymentService struct {
+	orchestrator   *SagaOrchestrator
+	billingService *BillingService
+	charger        CardCharger
+	publisher      messaging.Publisher
+	now            func() time.Time
+
+	// mu serializes payments, so an invoice never has two payments running
+	mu sync.Mutex
+}
+
+// NewInvoicePaymentService creates a new invoice payment service and registers its saga with orchestrator
+func NewInvoicePaymentService(orchestrator *SagaOrchestrator, billingService *BillingService, charger CardCharger, publisher messaging.Publisher) *InvoicePaymentService {
+	s := &InvoicePaymentService{
+		orchestrator:   orchestrator,
+		billingService: billingService,
+		charger:        charger,
+		publisher:      publisher,
+		now:            time.Now,
+	}
+	orchestrator.WithDefinition(s.sagaDefinition())
+	return s
+}
+
+// PayInvoice charges the card of the invoice client for the invoice balance and runs the payment saga as far as it goes
+// The saga is returned whatever its outcome; unfinished sagas are resumed by the scheduler
+// Only billing admins may pay invoices, as they may record payments (PermissionRecordPayment)
+func (s *InvoicePaymentService) PayInvoice(ctx context.Context, rc RequestContext, invoiceID string, req dtos.PayInvoiceRequest, now time.Time) (*entity.Saga, error) {
+	if err := rc.Authorize(PermissionRecordPayment); err != nil {
+		return nil, err
+	}
+	paymentMethod := strings.TrimSpace(req.PaymentMethod)
+	if paymentMethod == "" {
+		return nil, errors.NewValidationError("payment_method", req.PaymentMethod, errors.ValidationRequired, "payment method is required")
+	}
+
+	// The amount, currency and client charged are the invoice's, never the caller's
+	invoice, err := s.billingService.GetInvoice(rc, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	if invoice.Status() != entity.InvoiceIssued {
+		return nil, errors.ErrInvoiceNotIssued
+	}
+	balance, err := invoice.Balance()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	payments, err := s.orchestrator.List("", false, now)
+	if err != nil {
+		return nil, err
+	}
+	for _, payment := range payments {
+		if payment.Type() == InvoicePaymentSagaType && payment.Reference() == invoiceID && payment.Status() != entity.SagaCompensated {
+			return nil, errors.ErrInvoicePaymentInProgress
+		}
+	}
+
+	return s.orchestrator.Start(ctx, InvoicePaymentSagaType, invoiceID, map[string]string{
+		invoicePaymentInvoiceID: invoice.ID(),
+		invoicePaymentClientID:  invoice.ClientID(),
+		invoicePaymentTenantID:  invoice.TenantID(),
+		invoicePaymentAmount:    strconv.FormatInt(balance.Amount(), 10),
+		invoicePaymentCurrency:  balance.Currency(),
+		invoicePaymentMethod:    paymentMethod,
+	}, now)
+}
+
+// sagaDefinition returns the steps of the invoice payment saga
+// The notification comes after the point of no return: it is retried until it succeeds and never refunds the payment
+func (s *InvoicePaymentService) sagaDefinition() SagaDefinition {
+	return SagaDefinition{
+		Type: InvoicePaymentSagaType,
+		Steps: []SagaStep{
+			{
+				Name:       invoicePaymentStepCharge,
+				Execute:    s.chargeCard,
+				Compensate: s.refundCharge,
+			},
+			{
+				Name:       invoicePaymentStepMarkPaid,
+				Execute:    s.markInvoicePaid,
+				Compensate: s.markInvoiceUnpaid,
+			},
+			{
+				Name: invoicePaymentStepLedger,
+				Execute: func(ctx context.Context, saga *entity.Saga) (map[string]string, error) {
+					return nil, s.publishLedgerPosting(ctx, saga, false)
+				},
+				Compensate: func(ctx context.Context, saga *entity.Saga) error {
+					return s.publishLedgerPosting(ctx, saga, true)
+				},
+			},
+			{
+				Name:      invoicePaymentStepNotify,
+				Execute:   s.notifyClient,
+				Retriable: true,
+			},
+		},
+	}
+}
+
+// chargeCard charges the client card, keyed by the saga so a retried charge is not charged twice
+func (s *InvoicePaymentService) chargeCard(ctx context.Context, saga *entity.Saga) (map[string]string, error) {
+	amount, err := strconv.ParseInt(saga.Value(invoicePaymentAmount), 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	chargeID, err := s.charger.Charge(ctx, service.CardCharge{
+		Reference:     saga.ID() + "/charge",
+		InvoiceID:     saga.Value(invoicePaymentInvoiceID),
+		ClientID:      saga.Value(invoicePaymentClientID),
+		Amount:        amount,
+		Currency:      saga.Value(invoicePaymentCurrency),
+		PaymentMethod: saga.Value(invoicePaymentMethod),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{invoicePaymentChargeID: chargeID}, nil
+}
+
+// refundCharge refunds the charge of a compensated payment
+func (s *InvoicePaymentService) refundCharge(ctx context.Context, saga *entity.Saga) error {
+	return s.charger.Refund(ctx, saga.Value(invoicePaymentChargeID), saga.ID()+"/refund")
+}
+
+// markInvoicePaid records the charge as a card payment of the invoice, as payments received are recorded, and
+// publishes it
+// The payment is recorded under the charge ID, so a retried step does not record it twice; a payment whose
+// publication fails is reversed, as a failed step is not compensated
+func (s *InvoicePaymentService) markInvoicePaid(ctx context.Context, saga *entity.Saga) (map[string]string, error) {
+	rc := s.sagaContext(saga)
+	invoiceID, chargeID := saga.Value(invoicePaymentInvoiceID), saga.Value(invoicePaymentChargeID)
+
+	payment, err := s.billingService.FindPayment(invoiceID, chargeID)
+	if err != nil {
+		return nil, err
+	}
+	if payment == nil {
+		amount, err := strconv.ParseInt(saga.Value(invoicePaymentAmount), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		payment, _, err = s.billingService.RecordPayment(rc, invoiceID, dtos.RecordPaymentRequest{
+			Amount:    amount,
+			Currency:  saga.Value(invoicePaymentCurrency),
+			Method:    string(entity.PaymentCard),
+			Reference: chargeID,
+		}, s.now())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.publishPayment(ctx, saga, false); err != nil {
+		if _, reverseErr := s.billingService.ReversePayment(rc, invoiceID, chargeID); reverseErr != nil {
+			return nil, reverseErr
+		}
+		return nil, err
+	}
+	return map[string]string{invoicePaymentID: payment.ID()}, nil
+}
+
+// markInvoiceUnpaid reverses the card payment recorded against the invoice and publishes the reversal
+func (s *InvoicePaymentService) markInvoiceUnpaid(ctx context.Context, saga *entity.Saga) error {
+	if _, err := s.billingService.ReversePayment(s.sagaContext(saga), saga.Value(invoicePaymentInvoiceID), saga.Value(invoicePaymentChargeID)); err != nil {
+		return err
+	}
+	return s.publishPayment(ctx, saga, true)
+}
+
+// sagaContext is the context saga steps act in: the payment was authorized when started, and steps resumed by the
+// scheduler have no caller
+func (s *InvoicePaymentService) sagaContext(saga *entity.Saga) RequestContext {
+	return SystemContext(InvoicePaymentSagaType, saga.Value(invoicePaymentTenantID))
+}
+
+// publishPayment publishes the payment of the invoice, or its reversal
+func (s *InvoicePaymentService) publishPayment(ctx context.Context, saga *entity.Saga, reversed bool) error {
+	amount, err := strconv.ParseInt(saga.Value(invoicePaymentAmount), 10, 64)
+	if err != nil {
+		return err
+	}
+
+	return s.publish(ctx, InvoicePaymentTopic, saga, InvoicePaymentEvent{
+		SagaID:     saga.ID(),
+		InvoiceID:  saga.Value(invoicePaymentInvoiceID),
+		ClientID:   saga.Value(invoicePaymentClientID),
+		Amount:     amount,
+		Currency:   saga.Value(invoicePaymentCurrency),
+		ChargeID:   saga.Value(invoicePaymentChargeID),
+		Reversed:   reversed,
+		OccurredAt: s.now().UTC(),
+	})
+}
+
+// publishLedgerPosting publishes the ledger entries of the payment (gateway clearing debited, receivable credited),
+// or their reversal
+func (s *InvoicePaymentService) publishLedgerPosting(ctx context.Context, saga *entity.Saga, reversal bool) error {
+	amount, err := strconv.ParseInt(saga.Value(invoicePaymentAmount), 10, 64)
+	if err != nil {
+		return err
+	}
+
+	lines := []LedgerPostingLine{
+		{Account: LedgerAccountGatewayClearing, Debit: amount},
+		{Account: LedgerAccountAccountsReceivable, Credit: amount},
+	}
+	if reversal {
+		lines = []LedgerPostingLine{
+			{Account: LedgerAccountAccountsReceivable, Debit: amount},
+			{Account: LedgerAccountGatewayClearing, Credit: amount},
+		}
+	}
+
+	return s.publish(ctx, LedgerPostingTopic, saga, LedgerPostingEvent{
+		Reference: saga.ID(),
+		InvoiceID: saga.Value(invoicePaymentInvoiceID),
+		Currency:  saga.Value(invoicePaymentCurrency),
+		Lines:     lines,
+		Reversal:  reversal,
+		PostedAt:  s.now().UTC(),
+	})
+}
+
+// notifyClient publishes the payment receipt sent to the client
+func (s *InvoicePaymentService) notifyClient(ctx context.Context, saga *entity.Saga) (map[string]string, error) {
+	amount, err := strconv.ParseInt(saga.Value(invoicePaymentAmount), 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, s.publish(ctx, PaymentReceiptTopic, saga, PaymentReceiptEvent{
+		InvoiceID: saga.Value(invoicePaymentInvoiceID),
+		ClientID:  saga.Value(invoicePaymentClientID),
+		Amount:    amount,
+		Currency:  saga.Value(invoicePaymentCurrency),
+		ChargeID:  saga.Value(invoicePaymentChargeID),
+		PaidAt:    s.now().UTC(),
+	})
+}
+
+// publish publishes a saga event keyed by invoice, so the events of an invoice are consumed in order
+func (s *InvoicePaymentService) publish(ctx context.Context, topic string, saga *entity.Saga, event interface{}) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return s.publisher.Publish(ctx, messaging.Message{
+		Topic:   topic,
+		Key:     saga.Value(invoicePaymentInvoiceID),
+		Payload: payload,
+		Headers: map[string]string{"content-type": "application/json", "saga_id": saga.ID(), EventTenantHeader: saga.Value(invoicePaymentTenantID)},
+	})
+}
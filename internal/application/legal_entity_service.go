@@ -0,0 +1,231 @@
+package application
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+)
+
+// legalEntityResource is the audit resource type for legal entities
+const legalEntityResource = "legal_entity"
+
+// LegalEntityService manages the legal entities of the deployment and allocates their invoice numbers
+type LegalEntityService struct {
+	entityRepo   repository.LegalEntityRepository
+	auditService *AuditService
+
+	// mu serializes changes spanning several entities (default flag, unique prefixes) and number allocation,
+	// so two invoices never receive the same number from this process
+	mu sync.Mutex
+}
+
+// NewLegalEntityService creates a new legal entity service
+func NewLegalEntityService(entityRepo repository.LegalEntityRepository, auditService *AuditService) *LegalEntityService {
+	return &LegalEntityService{
+		entityRepo:   entityRepo,
+		auditService: auditService,
+	}
+}
+
+// GetEntity retrieves a legal entity by its ID
+func (s *LegalEntityService) GetEntity(id string) (*entity.LegalEntity, error) {
+	return s.entityRepo.GetByID(id)
+}
+
+// ListEntities retrieves all legal entities, oldest first
+func (s *LegalEntityService) ListEntities() ([]*entity.LegalEntity, error) {
+	return s.entityRepo.GetAll()
+}
+
+// CreateEntity creates a legal entity and records it in the audit log
+// The first entity of the deployment becomes the default one
+func (s *LegalEntityService) CreateEntity(actor string, req dtos.LegalEntityRequest) (*entity.LegalEntity, error) {
+	taxRegistrations, bankAccount, numbering, err := toLegalEntityDetails(req)
+	if err != nil {
+		return nil, err
+	}
+
+	legalEntity, err := entity.NewLegalEntity(req.Name, req.Address, req.Country, taxRegistrations, bankAccount, numbering, req.DocumentTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, err := s.entityRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.saveWithInvariants(legalEntity, existing, req.Default || len(existing) == 0); err != nil {
+		return nil, err
+	}
+
+	if err := s.auditService.Record(AuditActionLegalEntityCreated, actor, "", legalEntityResource, legalEntity.ID(), map[string]interface{}{"after": legalEntity}); err != nil {
+		return nil, err
+	}
+	return legalEntity, nil
+}
+
+// UpdateEntity replaces the details of a legal entity and records the change in the audit log
+// An entity stays the default until another one is made the default
+func (s *LegalEntityService) UpdateEntity(actor, id string, req dtos.LegalEntityRequest) (*entity.LegalEntity, error) {
+	taxRegistrations, bankAccount, numbering, err := toLegalEntityDetails(req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	legalEntity, err := s.entityRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	// Snapshot the entity before it is changed in place, for the audit trail
+	before, err := legalEntity.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := legalEntity.Update(req.Name, req.Address, req.Country, taxRegistrations, bankAccount, numbering, req.DocumentTemplate); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.entityRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.saveWithInvariants(legalEntity, existing, req.Default || legalEntity.IsDefault()); err != nil {
+		return nil, err
+	}
+
+	details := map[string]interface{}{"before": json.RawMessage(before), "after": legalEntity}
+	if err := s.auditService.Record(AuditActionLegalEntityUpdated, actor, "", legalEntityResource, legalEntity.ID(), details); err != nil {
+		return nil, err
+	}
+	return legalEntity, nil
+}
+
+// DeleteEntity removes a legal entity no invoice was issued from and records it in the audit log
+func (s *LegalEntityService) DeleteEntity(actor, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	legalEntity, err := s.entityRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if legalEntity.HasIssuedInvoices() {
+		return errors.ErrLegalEntityHasInvoices
+	}
+	if legalEntity.IsDefault() {
+		existing, err := s.entityRepo.GetAll()
+		if err != nil {
+			return err
+		}
+		if len(existing) > 1 {
+			return errors.ErrDefaultLegalEntityRequired
+		}
+	}
+
+	if err := s.entityRepo.Delete(id); err != nil {
+		return err
+	}
+
+	return s.auditService.Record(AuditActionLegalEntityDeleted, actor, "", legalEntityResource, id, map[string]interface{}{"before": legalEntity})
+}
+
+// ResolveEntity returns the legal entity invoices assigned to id are issued from, or the default entity when id is empty
+// It returns nil when no entity is assigned and none is configured
+func (s *LegalEntityService) ResolveEntity(id string) (*entity.LegalEntity, error) {
+	if id != "" {
+		return s.entityRepo.GetByID(id)
+	}
+
+	legalEntities, err := s.entityRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	for _, legalEntity := range legalEntities {
+		if legalEntity.IsDefault() {
+			return legalEntity, nil
+		}
+	}
+	return nil, nil
+}
+
+// RecordNumbering records that an invoice is being numbered from the sequence of an entity, fixing its yearly reset
+// Numbers themselves are allocated from the gap-free invoice counters (see LegalEntity.NumberingScope)
+func (s *LegalEntityService) RecordNumbering(id string) (*entity.LegalEntity, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	legalEntity, err := s.entityRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	legalEntity.RecordNumbering()
+	if err := s.entityRepo.Save(legalEntity); err != nil {
+		return nil, err
+	}
+	return legalEntity, nil
+}
+
+// saveWithInvariants saves an entity after checking its prefix is unique, making it the only default when requested
+// The caller holds s.mu
+func (s *LegalEntityService) saveWithInvariants(legalEntity *entity.LegalEntity, existing []*entity.LegalEntity, makeDefault bool) error {
+	prefix := strings.ToUpper(legalEntity.Numbering().Prefix)
+	for _, other := range existing {
+		if other.ID() != legalEntity.ID() && strings.ToUpper(other.Numbering().Prefix) == prefix {
+			return errors.ErrInvoiceNumberPrefixTaken
+		}
+	}
+
+	if makeDefault {
+		for _, other := range existing {
+			if other.ID() != legalEntity.ID() && other.IsDefault() {
+				other.SetDefault(false)
+				if err := s.entityRepo.Save(other); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	legalEntity.SetDefault(makeDefault)
+
+	return s.entityRepo.Save(legalEntity)
+}
+
+// toLegalEntityDetails converts the request's tax registrations, bank details and numbering to domain values
+func toLegalEntityDetails(req dtos.LegalEntityRequest) ([]entity.TaxRegistration, valueobject.BankAccount, entity.InvoiceNumbering, error) {
+	taxRegistrations := make([]entity.TaxRegistration, len(req.TaxRegistrations))
+	for i, registration := range req.TaxRegistrations {
+		taxRegistrations[i] = entity.TaxRegistration{Country: registration.Country, Number: registration.Number}
+	}
+
+	var bankAccount valueobject.BankAccount
+	if req.BankAccount != nil {
+		account, err := valueobject.NewBankAccount(req.BankAccount.AccountHolder, req.BankAccount.IBAN, req.BankAccount.BIC, req.BankAccount.BankName)
+		if err != nil {
+			if validationErr, ok := err.(*errors.ValidationError); ok {
+				validationErr.Field = "bank_account." + validationErr.Field
+			}
+			return nil, valueobject.BankAccount{}, entity.InvoiceNumbering{}, err
+		}
+		bankAccount = account
+	}
+
+	numbering := entity.InvoiceNumbering{
+		Prefix:      req.Numbering.Prefix,
+		Padding:     req.Numbering.Padding,
+		YearlyReset: req.Numbering.YearlyReset,
+	}
+	return taxRegistrations, bankAccount, numbering, nil
+}
@@ -0,0 +1,123 @@
+package application
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+)
+
+// Magic link purposes
+const (
+	MagicLinkPurposePortalSignIn  = "portal.sign_in"
+	MagicLinkPurposePasswordReset = "account.password_reset"
+)
+
+// DefaultMagicLinkTTL is how long an issued magic link can be followed
+const DefaultMagicLinkTTL = 15 * time.Minute
+
+// IssuedMagicLink is a link ready to be sent to its recipient
+type IssuedMagicLink struct {
+	Token     string
+	URL       string
+	ExpiresAt time.Time
+}
+
+// MagicLinkService mints signed, single-use, expiring links and verifies them when followed
+// Links are persisted so that consumption survives restarts and is shared across instances
+type MagicLinkService struct {
+	linkRepo  repository.MagicLinkRepository
+	secret    []byte
+	ttl       time.Duration
+	clockSkew time.Duration
+	baseURL   string
+	now       func() time.Time
+}
+
+// NewMagicLinkService creates a new magic link service signing links with secret
+// baseURL is the origin of the pages links point to (e.g. the customer-facing web app)
+func NewMagicLinkService(linkRepo repository.MagicLinkRepository, secret string, ttl time.Duration, baseURL string) *MagicLinkService {
+	if ttl <= 0 {
+		ttl = DefaultMagicLinkTTL
+	}
+
+	return &MagicLinkService{
+		linkRepo: linkRepo,
+		secret:   []byte(secret),
+		ttl:      ttl,
+		baseURL:  strings.TrimSuffix(baseURL, "/"),
+		now:      time.Now,
+	}
+}
+
+// WithClockSkew accepts links up to skew past their expiry, for instances whose clocks drift from the issuer's
+func (s *MagicLinkService) WithClockSkew(skew time.Duration) *MagicLinkService {
+	s.clockSkew = max(skew, 0)
+	return s
+}
+
+// Issue creates and persists a link for purpose and subject pointing at path
+func (s *MagicLinkService) Issue(purpose, subject, path string) (*IssuedMagicLink, error) {
+	link, err := entity.NewMagicLink(purpose, subject, s.ttl)
+	if err != nil {
+		return nil, errors.NewRepositoryError("issue_magic_link", errors.RepositoryInternal, "failed to generate magic link", err)
+	}
+
+	if err := s.linkRepo.Save(link); err != nil {
+		return nil, err
+	}
+
+	token := link.ID() + "." + s.sign(link)
+	return &IssuedMagicLink{
+		Token:     token,
+		URL:       s.baseURL + path + "?token=" + url.QueryEscape(token),
+		ExpiresAt: link.ExpiresAt(),
+	}, nil
+}
+
+// Verify checks a followed link for purpose and consumes it; a second verification of the same link fails
+func (s *MagicLinkService) Verify(token, purpose string) (*entity.MagicLink, error) {
+	id, signature, found := strings.Cut(token, ".")
+	if !found || id == "" {
+		return nil, errors.ErrMagicLinkInvalid
+	}
+
+	link, err := s.linkRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(s.sign(link))) || link.Purpose() != purpose {
+		return nil, errors.ErrMagicLinkInvalid
+	}
+
+	if link.IsConsumed() {
+		return nil, errors.ErrMagicLinkUsed
+	}
+
+	if link.IsExpired(s.now().Add(-s.clockSkew)) {
+		return nil, errors.ErrMagicLinkExpired
+	}
+
+	return s.linkRepo.Consume(id, s.now())
+}
+
+// sign returns the URL-safe HMAC-SHA256 signature binding a link's ID to its purpose, subject and expiry
+func (s *MagicLinkService) sign(link *entity.MagicLink) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(strings.Join([]string{
+		"magic-link",
+		link.Purpose(),
+		link.Subject(),
+		link.ID(),
+		strconv.FormatInt(link.ExpiresAt().Unix(), 10),
+	}, ":")))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
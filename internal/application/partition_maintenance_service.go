@@ -0,0 +1,53 @@
+package application
+
+import (
+	"fmt"
+	"time"
+)
+
+// PartitionManager creates the monthly partitions of a table partitioned by month of creation
+type PartitionManager interface {
+	// EnsureMonthlyPartitions creates the missing partitions from the month of from through the month of through
+	// and returns the names of the partitions it created
+	EnsureMonthlyPartitions(from, through time.Time) ([]string, error)
+}
+
+// PartitionedTable is a table the maintenance job keeps partitions ahead for
+type PartitionedTable struct {
+	Name    string
+	Manager PartitionManager
+}
+
+// PartitionMaintenanceService creates the partitions of the coming months of the high-volume tables ahead of time
+// Rows of a month without a partition land in the default partition, which then blocks creating that month's
+// partition, so the job runs at least monthly (the admin run endpoint, called by a scheduler)
+type PartitionMaintenanceService struct {
+	tables      []PartitionedTable
+	monthsAhead int
+}
+
+// NewPartitionMaintenanceService creates a partition maintenance service keeping monthsAhead months of partitions
+// after the current one
+func NewPartitionMaintenanceService(tables []PartitionedTable, monthsAhead int) *PartitionMaintenanceService {
+	return &PartitionMaintenanceService{
+		tables:      tables,
+		monthsAhead: monthsAhead,
+	}
+}
+
+// Run creates the missing partitions from the current month through monthsAhead months later and returns their names
+// Running it again creates nothing, so a scheduler can call it as often as it likes
+func (s *PartitionMaintenanceService) Run(now time.Time) ([]string, error) {
+	from := now.UTC()
+	through := from.AddDate(0, s.monthsAhead, 0)
+
+	created := make([]string, 0)
+	for _, table := range s.tables {
+		partitions, err := table.Manager.EnsureMonthlyPartitions(from, through)
+		if err != nil {
+			return created, fmt.Errorf("failed to create partitions of %s: %w", table.Name, err)
+		}
+		created = append(created, partitions...)
+	}
+	return created, nil
+}
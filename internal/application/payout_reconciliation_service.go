@@ -0,0 +1,120 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+)
+
+// DefaultPayoutLookback is how far back a reconciliation run pulls payouts when none is configured
+// Runs overlap so payouts reported late by the gateway are still reconciled; reports are replaced, not duplicated
+const DefaultPayoutLookback = 7 * 24 * time.Hour
+
+// PayoutSource lists the payouts reported by the payment gateway
+type PayoutSource interface {
+	ListPayouts(ctx context.Context, since time.Time) ([]service.GatewayPayout, error)
+}
+
+// LedgerSource looks up the payments and fees recorded in the ledger for gateway references
+type LedgerSource interface {
+	EntriesByGatewayReference(references []string) ([]service.LedgerEntry, error)
+}
+
+// PayoutReconciliationService reconciles gateway payouts with the ledger and keeps their discrepancy reports
+type PayoutReconciliationService struct {
+	reportRepo repository.PayoutReconciliationRepository
+	payouts    PayoutSource
+	ledger     LedgerSource
+	lookback   time.Duration
+}
+
+// NewPayoutReconciliationService creates a new payout reconciliation service
+// Each run reconciles the payouts that arrived within lookback (DefaultPayoutLookback when zero)
+func NewPayoutReconciliationService(reportRepo repository.PayoutReconciliationRepository, lookback time.Duration) *PayoutReconciliationService {
+	if lookback <= 0 {
+		lookback = DefaultPayoutLookback
+	}
+
+	return &PayoutReconciliationService{
+		reportRepo: reportRepo,
+		lookback:   lookback,
+	}
+}
+
+// WithGateway sets the payment gateway payouts are pulled from
+func (s *PayoutReconciliationService) WithGateway(payouts PayoutSource) *PayoutReconciliationService {
+	s.payouts = payouts
+	return s
+}
+
+// WithLedger sets the ledger payouts are reconciled against
+func (s *PayoutReconciliationService) WithLedger(ledger LedgerSource) *PayoutReconciliationService {
+	s.ledger = ledger
+	return s
+}
+
+// Reconcile pulls the recent payouts from the gateway and stores the discrepancy report of each (scheduler entry point)
+func (s *PayoutReconciliationService) Reconcile(ctx context.Context, now time.Time) ([]*entity.PayoutReconciliation, error) {
+	if s.payouts == nil || s.ledger == nil {
+		return nil, errors.ErrPayoutReconciliationUnavailable
+	}
+
+	payouts, err := s.payouts.ListPayouts(ctx, now.Add(-s.lookback))
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull payouts from the payment gateway: %w", err)
+	}
+
+	reports := make([]*entity.PayoutReconciliation, 0, len(payouts))
+	for _, payout := range payouts {
+		references := make([]string, len(payout.Items))
+		for i, item := range payout.Items {
+			references[i] = item.Reference
+		}
+
+		entries, err := s.ledger.EntriesByGatewayReference(references)
+		if err != nil {
+			return nil, err
+		}
+
+		report, err := service.ReconcilePayout(payout, entries)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.reportRepo.Save(report); err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+
+	return reports, nil
+}
+
+// GetReport retrieves the discrepancy report of a payout
+func (s *PayoutReconciliationService) GetReport(payoutID string) (*entity.PayoutReconciliation, error) {
+	return s.reportRepo.GetByPayoutID(payoutID)
+}
+
+// ListReports retrieves payout reports, most recent payout first, optionally filtered by status (balanced, discrepant)
+func (s *PayoutReconciliationService) ListReports(status string) ([]*entity.PayoutReconciliation, error) {
+	reports, err := s.reportRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if status == "" {
+		return reports, nil
+	}
+
+	filtered := make([]*entity.PayoutReconciliation, 0, len(reports))
+	for _, report := range reports {
+		if string(report.Status()) == status {
+			filtered = append(filtered, report)
+		}
+	}
+	return filtered, nil
+}
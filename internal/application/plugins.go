@@ -0,0 +1,154 @@
+package application
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// Plugin is an extension customizing billing without changing the services (custom pricing, extra validation)
+// A plugin implements one or more of the hook interfaces below. Hook errors refuse the operation and are reported
+// like service errors, so hooks return domain errors (e.g. business rule errors) for callers to see why
+type Plugin interface {
+	// Name identifies the plugin
+	Name() string
+}
+
+// PricingHook prices the lines of draft invoices when they are created or updated (negotiated prices, discounts)
+type PricingHook interface {
+	Plugin
+
+	// PriceInvoice returns the lines to bill, from the lines of the subject as priced by the hooks before it
+	// The lines returned are validated like the lines of a request
+	PriceInvoice(subject PricingSubject) ([]entity.InvoiceLine, error)
+}
+
+// PricingSubject is the invoice a pricing hook prices
+type PricingSubject struct {
+	Client  *entity.Client
+	Invoice *entity.Invoice      // Draft invoice, with the lines requested
+	Lines   []entity.InvoiceLine // Lines to price
+}
+
+// InvoicePreIssueHook is run before a draft invoice is issued; an error refuses the issue
+type InvoicePreIssueHook interface {
+	Plugin
+
+	BeforeInvoiceIssue(subject InvoiceIssueSubject) error
+}
+
+// InvoiceIssueSubject is the invoice an invoice pre-issue hook checks
+type InvoiceIssueSubject struct {
+	Client   *entity.Client
+	Invoice  *entity.Invoice
+	IssuedAt time.Time
+}
+
+// ClientPreSaveHook is run before a client is created or its details updated, before the business rules; an
+// error refuses the save. Unlike rules, hooks may change the client (e.g. complete its details)
+type ClientPreSaveHook interface {
+	Plugin
+
+	BeforeClientSave(subject RuleSubject) error
+}
+
+// Plugins are the plugins registered with the billing service, their hooks run in registration order
+type Plugins struct {
+	pricing  []PricingHook
+	preIssue []InvoicePreIssueHook
+	preSave  []ClientPreSaveHook
+}
+
+// NewPlugins registers plugins; names are unique and every plugin implements at least one hook
+func NewPlugins(plugins ...Plugin) (*Plugins, error) {
+	registered := &Plugins{}
+	names := make(map[string]bool, len(plugins))
+	for _, plugin := range plugins {
+		if plugin.Name() == "" {
+			return nil, fmt.Errorf("plugin %T has no name", plugin)
+		}
+		if names[plugin.Name()] {
+			return nil, fmt.Errorf("plugin %s is already registered", plugin.Name())
+		}
+		names[plugin.Name()] = true
+
+		hooked := false
+		if hook, ok := plugin.(PricingHook); ok {
+			registered.pricing = append(registered.pricing, hook)
+			hooked = true
+		}
+		if hook, ok := plugin.(InvoicePreIssueHook); ok {
+			registered.preIssue = append(registered.preIssue, hook)
+			hooked = true
+		}
+		if hook, ok := plugin.(ClientPreSaveHook); ok {
+			registered.preSave = append(registered.preSave, hook)
+			hooked = true
+		}
+		if !hooked {
+			return nil, fmt.Errorf("plugin %s implements no hook", plugin.Name())
+		}
+	}
+	return registered, nil
+}
+
+// WithPlugins runs the hooks of plugins in client saves, invoice pricing and invoice issues
+func (s *BillingService) WithPlugins(plugins *Plugins) *BillingService {
+	s.plugins = plugins
+	return s
+}
+
+// priceInvoice runs the pricing hooks on the lines of a draft invoice; client is looked up when nil
+func (s *BillingService) priceInvoice(invoice *entity.Invoice, client *entity.Client) error {
+	if s.plugins == nil || len(s.plugins.pricing) == 0 {
+		return nil
+	}
+	if client == nil {
+		var err error
+		if client, err = s.clientByID(invoice.ClientID()); err != nil {
+			return err
+		}
+	}
+
+	lines := invoice.Lines()
+	for _, hook := range s.plugins.pricing {
+		priced, err := hook.PriceInvoice(PricingSubject{Client: client, Invoice: invoice, Lines: lines})
+		if err != nil {
+			return err
+		}
+		lines = priced
+	}
+	return invoice.Update(invoice.Currency(), lines, invoice.DueDate())
+}
+
+// beforeInvoiceIssue runs the invoice pre-issue hooks on a draft invoice
+func (s *BillingService) beforeInvoiceIssue(invoice *entity.Invoice, issuedAt time.Time) error {
+	if s.plugins == nil || len(s.plugins.preIssue) == 0 {
+		return nil
+	}
+	client, err := s.clientByID(invoice.ClientID())
+	if err != nil {
+		return err
+	}
+
+	for _, hook := range s.plugins.preIssue {
+		if err := hook.BeforeInvoiceIssue(InvoiceIssueSubject{Client: client, Invoice: invoice, IssuedAt: issuedAt}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// beforeClientSave runs the client pre-save hooks on a client created or updated
+func (s *BillingService) beforeClientSave(subject RuleSubject) error {
+	if s.plugins == nil {
+		return nil
+	}
+	for _, hook := range s.plugins.preSave {
+		if err := hook.BeforeClientSave(subject); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,236 @@
+package application
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+)
+
+// DefaultPortalTokenTTL is how long a portal access token stays valid
+const DefaultPortalTokenTTL = 24 * time.Hour
+
+// PortalSignInPath is the web app page sign-in links point to; it exchanges the link for an access token
+const PortalSignInPath = "/portal/sign-in"
+
+// PortalAccessToken is a signed token scoping portal requests to a single client
+type PortalAccessToken struct {
+	Token     string
+	ClientID  string
+	ExpiresAt time.Time
+}
+
+// PortalService exposes the restricted operations a client may perform on its own account
+// Every operation is scoped to the client ID carried by a verified access token
+type PortalService struct {
+	billingService *BillingService
+	magicLinks     *MagicLinkService
+	documents      *DocumentTemplateService
+	secret         []byte
+	ttl            time.Duration
+	clockSkew      time.Duration
+	now            func() time.Time
+}
+
+// NewPortalService creates a new portal service signing access tokens with secret
+func NewPortalService(billingService *BillingService, secret string, ttl time.Duration) *PortalService {
+	if ttl <= 0 {
+		ttl = DefaultPortalTokenTTL
+	}
+
+	return &PortalService{
+		billingService: billingService,
+		secret:         []byte(secret),
+		ttl:            ttl,
+		now:            time.Now,
+	}
+}
+
+// WithMagicLinks enables emailed sign-in links for the portal
+func (s *PortalService) WithMagicLinks(magicLinks *MagicLinkService) *PortalService {
+	s.magicLinks = magicLinks
+	return s
+}
+
+// WithDocuments enables downloading invoice documents, rendered with the template of the client's tenant
+func (s *PortalService) WithDocuments(documents *DocumentTemplateService) *PortalService {
+	s.documents = documents
+	return s
+}
+
+// WithClockSkew accepts access tokens up to skew past their expiry, for instances whose clocks drift from the issuer's
+func (s *PortalService) WithClockSkew(skew time.Duration) *PortalService {
+	s.clockSkew = max(skew, 0)
+	return s
+}
+
+// IssueSignInLink creates a single-use sign-in link for an existing client
+func (s *PortalService) IssueSignInLink(clientID string) (*IssuedMagicLink, error) {
+	if s.magicLinks == nil {
+		return nil, errors.NewBusinessRuleError("portal_sign_in_links", errors.BusinessRuleViolation, "portal sign-in links are not configured")
+	}
+
+	client, err := s.billingService.clientByID(clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.magicLinks.Issue(MagicLinkPurposePortalSignIn, client.ID(), PortalSignInPath)
+}
+
+// IssueAccessToken creates an access token for an existing client
+func (s *PortalService) IssueAccessToken(clientID string) (*PortalAccessToken, error) {
+	client, err := s.billingService.clientByID(clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := s.now().UTC().Add(s.ttl).Truncate(time.Second)
+	payload := client.ID() + "." + strconv.FormatInt(expiresAt.Unix(), 10)
+
+	return &PortalAccessToken{
+		Token:     base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." + s.sign(payload),
+		ClientID:  client.ID(),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// Authenticate verifies an access token and returns the client it is scoped to
+func (s *PortalService) Authenticate(token string) (string, error) {
+	encodedPayload, signature, found := strings.Cut(token, ".")
+	if !found {
+		return "", errors.ErrPortalTokenInvalid
+	}
+
+	rawPayload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return "", errors.ErrPortalTokenInvalid
+	}
+	payload := string(rawPayload)
+
+	if !hmac.Equal([]byte(signature), []byte(s.sign(payload))) {
+		return "", errors.ErrPortalTokenInvalid
+	}
+
+	clientID, expiry, found := strings.Cut(payload, ".")
+	if !found || clientID == "" {
+		return "", errors.ErrPortalTokenInvalid
+	}
+
+	expiresAt, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil {
+		return "", errors.ErrPortalTokenInvalid
+	}
+	if !s.now().Add(-s.clockSkew).Before(time.Unix(expiresAt, 0)) {
+		return "", errors.ErrPortalTokenExpired
+	}
+
+	return clientID, nil
+}
+
+// ClientTenant returns the tenant of a client, which the requests of its portal sessions act in
+func (s *PortalService) ClientTenant(clientID string) (string, error) {
+	client, err := s.billingService.clientByID(clientID)
+	if err != nil {
+		return "", err
+	}
+	return client.TenantID(), nil
+}
+
+// GetProfile returns the authenticated client's account
+func (s *PortalService) GetProfile(clientID string) (*entity.Client, error) {
+	return s.billingService.clientByID(clientID)
+}
+
+// UpdateContactInfo updates the authenticated client's phone and address, keeping its name
+func (s *PortalService) UpdateContactInfo(clientID string, req dtos.PortalContactRequest, locale valueobject.Locale) (*entity.Client, error) {
+	client, err := s.billingService.clientByID(clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	rc := RequestContext{
+		TenantID:  client.TenantID(),
+		Principal: Principal{Kind: PrincipalPortal, ID: client.ID()},
+		Locale:    locale,
+	}
+	return s.billingService.updateClient(rc, client, dtos.UpdateClientRequest{
+		Name:    client.Name(),
+		Phone:   req.Phone,
+		Address: req.Address,
+	})
+}
+
+// ListInvoices retrieves a page of the invoices issued to the authenticated client, in its tenant (see
+// BillingService.ListInvoices for the cursor); drafts are not shown to clients
+func (s *PortalService) ListInvoices(clientID string, status entity.InvoiceStatus, cursor string, limit int) (*InvoiceCursorPage, error) {
+	if status == entity.InvoiceDraft {
+		return nil, errors.NewValidationError("status", status, errors.ValidationFormat, "status must be one of: issued, paid, void")
+	}
+	client, err := s.billingService.clientByID(clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	criteria, err := invoiceListCriteria(InvoiceFilter{
+		TenantID:      client.TenantID(),
+		ClientID:      client.ID(),
+		Status:        status,
+		ExcludeDrafts: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	criteria.TenantScoped = true
+	return s.billingService.listInvoices(criteria, cursor, limit)
+}
+
+// GetInvoice retrieves an invoice issued to the authenticated client
+// Drafts and invoices of other clients or tenants are reported as not found, so their IDs cannot be probed
+func (s *PortalService) GetInvoice(clientID, invoiceID string) (*entity.Invoice, error) {
+	client, err := s.billingService.clientByID(clientID)
+	if err != nil {
+		return nil, err
+	}
+	invoice, err := s.billingService.invoiceByID(invoiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if invoice.ClientID() != client.ID() || invoice.TenantID() != client.TenantID() || invoice.IsDraft() {
+		return nil, errors.ErrInvoiceNotFound
+	}
+	return invoice, nil
+}
+
+// GetInvoiceDocument renders an invoice issued to the authenticated client in its print layout, formatted for locale
+// Invoices are owner-checked like GetInvoice
+func (s *PortalService) GetInvoiceDocument(clientID, invoiceID string, locale valueobject.Locale) ([]byte, error) {
+	if s.documents == nil {
+		return nil, errors.NewBusinessRuleError("portal_invoice_documents", errors.BusinessRuleViolation, "portal invoice documents are not configured")
+	}
+
+	invoice, err := s.GetInvoice(clientID, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	client, err := s.billingService.clientByID(clientID)
+	if err != nil {
+		return nil, err
+	}
+	return s.documents.RenderInvoice(invoice, client, locale)
+}
+
+// sign returns the URL-safe HMAC-SHA256 signature of a token payload
+func (s *PortalService) sign(payload string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte("portal:" + payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,255 @@
+package application
+
+import (
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+)
+
+// QuoteService manages quotes offered to clients and converts accepted quotes to invoices
+type QuoteService struct {
+	quotes  repository.QuoteRepository
+	billing *BillingService
+}
+
+// NewQuoteService creates a new quote service invoicing through the billing service
+func NewQuoteService(quoteRepo repository.QuoteRepository, billingService *BillingService) *QuoteService {
+	return &QuoteService{
+		quotes:  quoteRepo,
+		billing: billingService,
+	}
+}
+
+// CreateQuote offers a quote to a client of the caller's tenant
+// Only billing admins may manage quotes (PermissionManageQuotes), like every change below
+// Line items without a tax rate take the rate of the tax jurisdiction, as on invoices
+func (s *QuoteService) CreateQuote(rc RequestContext, req dtos.CreateQuoteRequest) (*entity.Quote, error) {
+	if err := rc.Authorize(PermissionManageQuotes); err != nil {
+		return nil, err
+	}
+	if err := s.billing.requireInvoices(); err != nil {
+		return nil, err
+	}
+
+	pricing, err := valueobject.ParseTaxPricing(req.TaxPricing)
+	if err != nil {
+		return nil, err
+	}
+	lines, err := s.billing.toInvoiceLines(req.TaxJurisdiction, req.LineItems)
+	if err != nil {
+		return nil, err
+	}
+	quote, err := entity.NewQuote(req.ClientID, req.Currency, lines, req.ValidUntil)
+	if err != nil {
+		return nil, err
+	}
+	if err := quote.SetTaxTerms(pricing, req.TaxJurisdiction); err != nil {
+		return nil, err
+	}
+	quote.AssignTenant(rc.TenantID)
+
+	if _, err := s.billing.GetOwnedClient(rc, quote.ClientID()); err != nil {
+		return nil, err
+	}
+
+	if err := s.quotes.Save(quote); err != nil {
+		return nil, err
+	}
+	return quote, nil
+}
+
+// GetQuote retrieves a quote of the caller's tenant by its ID; only admins may read quotes (PermissionReadQuotes)
+func (s *QuoteService) GetQuote(rc RequestContext, id string) (*entity.Quote, error) {
+	if err := rc.Authorize(PermissionReadQuotes); err != nil {
+		return nil, err
+	}
+	return s.ownedQuote(rc, id)
+}
+
+// ownedQuote retrieves a quote the caller may act on: quotes of another tenant are reported as not found, like
+// clients (see BillingService.GetOwnedClient)
+func (s *QuoteService) ownedQuote(rc RequestContext, id string) (*entity.Quote, error) {
+	if !isValidUUID(id) {
+		return nil, errors.ErrQuoteNotFound
+	}
+	quote, err := s.quotes.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if !rc.CanAccessTenant(quote.TenantID()) {
+		return nil, errors.ErrQuoteNotFound
+	}
+	return quote, nil
+}
+
+// ListQuotes retrieves the quotes of the caller's tenant, oldest first, optionally filtered by client and status
+func (s *QuoteService) ListQuotes(rc RequestContext, clientID string, status entity.QuoteStatus) ([]*entity.Quote, error) {
+	if err := rc.Authorize(PermissionReadQuotes); err != nil {
+		return nil, err
+	}
+	switch status {
+	case "", entity.QuoteOpen, entity.QuoteAccepted, entity.QuoteRejected, entity.QuoteExpired:
+	default:
+		return nil, errors.NewValidationError("status", status, errors.ValidationFormat, "status must be one of: open, accepted, rejected, expired")
+	}
+
+	quotes, err := s.quotes.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]*entity.Quote, 0, len(quotes))
+	for _, quote := range quotes {
+		if !rc.CanAccessTenant(quote.TenantID()) {
+			continue
+		}
+		if clientID != "" && quote.ClientID() != clientID {
+			continue
+		}
+		if status != "" && quote.Status() != status {
+			continue
+		}
+		filtered = append(filtered, quote)
+	}
+	return filtered, nil
+}
+
+// AcceptQuote records the client's acceptance of an open quote
+// A quote past its validity date is expired instead, and the acceptance fails with ErrQuoteExpired
+func (s *QuoteService) AcceptQuote(rc RequestContext, id string, now time.Time) (*entity.Quote, error) {
+	if err := rc.Authorize(PermissionManageQuotes); err != nil {
+		return nil, err
+	}
+	quote, err := s.ownedQuote(rc, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if quote.IsLapsed(now) {
+		if err := quote.Expire(now); err != nil {
+			return nil, err
+		}
+		if err := s.quotes.Save(quote); err != nil {
+			return nil, err
+		}
+		return nil, errors.ErrQuoteExpired
+	}
+
+	if err := quote.Accept(now); err != nil {
+		return nil, err
+	}
+	if err := s.quotes.Save(quote); err != nil {
+		return nil, err
+	}
+	return quote, nil
+}
+
+// RejectQuote records the client's refusal of an open quote
+func (s *QuoteService) RejectQuote(rc RequestContext, id string, req dtos.RejectQuoteRequest, now time.Time) (*entity.Quote, error) {
+	return s.change(rc, id, func(quote *entity.Quote) error {
+		return quote.Reject(req.Reason, now)
+	})
+}
+
+// ExpireQuote withdraws an open quote before the client answers it
+func (s *QuoteService) ExpireQuote(rc RequestContext, id string, now time.Time) (*entity.Quote, error) {
+	return s.change(rc, id, func(quote *entity.Quote) error {
+		return quote.Expire(now)
+	})
+}
+
+// change applies a lifecycle change to a quote of the caller's tenant and saves it
+func (s *QuoteService) change(rc RequestContext, id string, apply func(*entity.Quote) error) (*entity.Quote, error) {
+	if err := rc.Authorize(PermissionManageQuotes); err != nil {
+		return nil, err
+	}
+	quote, err := s.ownedQuote(rc, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := apply(quote); err != nil {
+		return nil, err
+	}
+	if err := s.quotes.Save(quote); err != nil {
+		return nil, err
+	}
+	return quote, nil
+}
+
+// ConvertQuote creates a draft invoice from an accepted quote, copying its client, line items and tax terms
+// The invoice is recorded on the quote, so a quote converts once; the draft is voided if that record fails
+// The invoice is created for the caller, who must hold the permissions of both quotes and invoices
+func (s *QuoteService) ConvertQuote(rc RequestContext, id string, now time.Time) (*entity.Quote, *entity.Invoice, error) {
+	if err := rc.Authorize(PermissionManageQuotes); err != nil {
+		return nil, nil, err
+	}
+	if err := rc.Authorize(PermissionManageInvoices); err != nil {
+		return nil, nil, err
+	}
+	quote, err := s.ownedQuote(rc, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := quote.CanConvert(); err != nil {
+		return nil, nil, err
+	}
+
+	lines := quote.Lines()
+	items := make([]dtos.InvoiceLineRequest, len(lines))
+	for i, line := range lines {
+		taxRate := line.TaxRateBps
+		items[i] = dtos.InvoiceLineRequest{
+			Description: line.Description,
+			Quantity:    line.Quantity,
+			UnitAmount:  line.UnitAmount,
+			TaxRateBps:  &taxRate,
+		}
+	}
+	invoice, err := s.billing.CreateInvoice(rc, dtos.CreateInvoiceRequest{
+		ClientID:        quote.ClientID(),
+		Currency:        quote.Currency(),
+		LineItems:       items,
+		TaxPricing:      string(quote.TaxPricing()),
+		TaxJurisdiction: quote.TaxJurisdiction(),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := quote.MarkConverted(invoice.ID(), now); err != nil {
+		return nil, nil, err
+	}
+	if err := s.quotes.Save(quote); err != nil {
+		// Leave no draft behind that a retry would duplicate; the save error is the one reported
+		_, _ = s.billing.VoidInvoice(rc, invoice.ID(), now)
+		return nil, nil, err
+	}
+	return quote, invoice, nil
+}
+
+// ExpireLapsedQuotes expires every open quote past its validity date (scheduler entry point)
+func (s *QuoteService) ExpireLapsedQuotes(now time.Time) ([]*entity.Quote, error) {
+	quotes, err := s.quotes.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	expired := make([]*entity.Quote, 0)
+	for _, quote := range quotes {
+		if !quote.IsLapsed(now) {
+			continue
+		}
+		if err := quote.Expire(now); err != nil {
+			return expired, err
+		}
+		if err := s.quotes.Save(quote); err != nil {
+			return expired, err
+		}
+		expired = append(expired, quote)
+	}
+	return expired, nil
+}
@@ -0,0 +1,679 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/messaging"
+)
+
+// RecurringInvoiceIssuedTopic is the message bus topic announcing the invoices issued from templates
+const RecurringInvoiceIssuedTopic = "billing.invoices.recurring_issued"
+
+// RecurringInvoiceIssuedLine is a line item of a RecurringInvoiceIssuedEvent
+type RecurringInvoiceIssuedLine struct {
+	Description string `json:"description"`
+	Quantity    int64  `json:"quantity"`
+	UnitAmount  int64  `json:"unit_amount"`
+	Amount      int64  `json:"amount"`
+	TaxRateBps  int64  `json:"tax_rate_bps"`
+}
+
+// RecurringInvoiceIssuedEvent is the payload published for every invoice issued from a recurring template
+type RecurringInvoiceIssuedEvent struct {
+	TemplateID string                       `json:"template_id"`
+	InvoiceID  string                       `json:"invoice_id"`
+	ClientID   string                       `json:"client_id"`
+	Name       string                       `json:"name"`
+	IssueDate  time.Time                    `json:"issue_date"`
+	Currency   string                       `json:"currency"`
+	LineItems  []RecurringInvoiceIssuedLine `json:"line_items"`
+	Total      int64                        `json:"total"`
+	AutoSend   bool                         `json:"auto_send"`
+	IssuedAt   time.Time                    `json:"issued_at"`
+
+	// Purchase order or order number the invoice was created with (printed on the document)
+	ExternalRef string `json:"external_ref,omitempty"`
+
+	// Number of the invoice, when numbering or legal entities are configured
+	InvoiceNumber string `json:"invoice_number,omitempty"`
+
+	// Set when legal entities are configured: the entity the invoice is issued from
+	// and the document template to render it with
+	LegalEntityID    string `json:"legal_entity_id,omitempty"`
+	DocumentTemplate string `json:"document_template,omitempty"`
+
+	// Set when the invoice takes the client over a credit limit with the warn policy
+	CreditWarning bool `json:"credit_warning,omitempty"`
+
+	// Set when compliance rules are configured: the buyer tax details, how taxes are itemized
+	// and the legal mentions the seller and buyer countries require on the document
+	BuyerCountry  string   `json:"buyer_country,omitempty"`
+	BuyerTaxID    string   `json:"buyer_tax_id,omitempty"`
+	TaxBreakdown  string   `json:"tax_breakdown,omitempty"`
+	LegalMentions []string `json:"legal_mentions,omitempty"`
+
+	// Set when fiscal calendars are configured: the tenant and the fiscal period the invoice is reported in
+	TenantID     string `json:"tenant_id,omitempty"`
+	FiscalYear   int    `json:"fiscal_year,omitempty"`
+	FiscalPeriod string `json:"fiscal_period,omitempty"`
+}
+
+// RecurringInvoiceIssue is an invoice issued from a template by a scheduler run
+type RecurringInvoiceIssue struct {
+	Template      *entity.RecurringInvoiceTemplate
+	IssueDate     time.Time
+	Invoice       *entity.Invoice
+	LegalEntity   *entity.LegalEntity       // nil when no legal entity is configured
+	InvoiceNumber string                    // Empty when invoices are not numbered
+	Credit        *CreditCheck              // nil when credit control is not configured
+	Risk          *entity.RiskAssessment    // Set when the invoice is large enough to be risk scored
+	Compliance    *service.ComplianceResult // nil when compliance rules are not configured
+	FiscalPeriod  *entity.FiscalPeriod      // nil when fiscal calendars are not configured
+}
+
+// RecurringInvoiceHold is an invoice due from a template that a period lock, compliance rules, credit control
+// or risk scoring kept from being issued
+// The template stays due, so the invoice is checked again on the next run
+type RecurringInvoiceHold struct {
+	Template     *entity.RecurringInvoiceTemplate
+	IssueDate    time.Time
+	Credit       *CreditCheck              // Set when held by the client's credit policy
+	Risk         *entity.RiskAssessment    // Set when held by a declined risk score
+	Compliance   *service.ComplianceResult // Set when held by compliance rule violations
+	ClosedPeriod *entity.FiscalPeriod      // Set when the issue date falls in a closed fiscal period
+}
+
+// RecurringInvoiceRun is the outcome of a scheduler run
+type RecurringInvoiceRun struct {
+	Issued []RecurringInvoiceIssue
+	Held   []RecurringInvoiceHold
+}
+
+// recurringInvoiceActor issues the invoices due from templates, on behalf of the template's tenant: templates are
+// only created by callers of that tenant for its clients, and invoices are drafted for the clients of that tenant only
+const recurringInvoiceActor = "recurring_invoices"
+
+// RecurringInvoiceService manages recurring invoice templates and issues the invoices they schedule
+type RecurringInvoiceService struct {
+	templateRepo   repository.RecurringInvoiceTemplateRepository
+	billingService *BillingService
+	publisher      messaging.Publisher
+	legalEntities  *LegalEntityService
+	creditControl  *CreditControlService
+	risk           *RiskScoringService
+	currencies     *CurrencyPolicies
+	duplicates     *service.DuplicateInvoiceCheck
+	references     *ExternalReferenceService
+}
+
+// NewRecurringInvoiceService creates a new recurring invoice service
+// Invoices due from templates are published on publisher
+func NewRecurringInvoiceService(templateRepo repository.RecurringInvoiceTemplateRepository, billingService *BillingService, publisher messaging.Publisher) *RecurringInvoiceService {
+	return &RecurringInvoiceService{
+		templateRepo:   templateRepo,
+		billingService: billingService,
+		publisher:      publisher,
+	}
+}
+
+// WithLegalEntities assigns templates to legal entities; their invoices are issued from the assigned entity
+func (s *RecurringInvoiceService) WithLegalEntities(legalEntities *LegalEntityService) *RecurringInvoiceService {
+	s.legalEntities = legalEntities
+	return s
+}
+
+// WithCreditControl checks every invoice against the credit limit of its client before it is issued
+func (s *RecurringInvoiceService) WithCreditControl(creditControl *CreditControlService) *RecurringInvoiceService {
+	s.creditControl = creditControl
+	return s
+}
+
+// WithRiskScoring checks the client risk score before issuing invoices above the large invoice threshold
+func (s *RecurringInvoiceService) WithRiskScoring(risk *RiskScoringService) *RecurringInvoiceService {
+	s.risk = risk
+	return s
+}
+
+// WithCurrencyPolicies defaults the currency of new templates to the currency of their tenant and converts line items
+// priced in another currency with the tenant rounding
+func (s *RecurringInvoiceService) WithCurrencyPolicies(currencies *CurrencyPolicies) *RecurringInvoiceService {
+	s.currencies = currencies
+	return s
+}
+
+// WithDuplicateCheck rejects new templates duplicating an existing template of the same client, so the same
+// invoice is not issued twice
+func (s *RecurringInvoiceService) WithDuplicateCheck(check *service.DuplicateInvoiceCheck) *RecurringInvoiceService {
+	s.duplicates = check
+	return s
+}
+
+// WithExternalReferences keeps template external references unique per tenant, rejecting new templates
+// with the reference of another invoice of their tenant
+func (s *RecurringInvoiceService) WithExternalReferences(references *ExternalReferenceService) *RecurringInvoiceService {
+	s.references = references
+	return s
+}
+
+// CreateTemplate creates a recurring invoice template of the caller's tenant for an existing client of the tenant
+func (s *RecurringInvoiceService) CreateTemplate(rc RequestContext, req dtos.CreateRecurringInvoiceTemplateRequest) (*entity.RecurringInvoiceTemplate, error) {
+	if err := rc.Authorize(PermissionManageInvoices); err != nil {
+		return nil, err
+	}
+	policy := s.currencyPolicy(rc.TenantID)
+	currency := req.Currency
+	if strings.TrimSpace(currency) == "" {
+		currency = policy.Currency()
+	}
+
+	lines, err := toRecurringInvoiceLines(currency, req.LineItems, policy.Rounding())
+	if err != nil {
+		return nil, err
+	}
+	template, err := entity.NewRecurringInvoiceTemplate(
+		req.ClientID,
+		req.Name,
+		currency,
+		lines,
+		entity.RecurrenceFrequency(req.Frequency),
+		req.FirstIssueDate,
+		req.EndDate,
+		req.AutoSend,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := template.SetBuyerTaxDetails(req.BuyerCountry, req.BuyerTaxID); err != nil {
+		return nil, err
+	}
+	if err := template.SetExternalReference(req.ExternalRef); err != nil {
+		return nil, err
+	}
+	template.AssignTenant(rc.TenantID)
+
+	if _, err := s.billingService.GetOwnedClient(rc, template.ClientID()); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkDuplicate(template, req.AllowDuplicate); err != nil {
+		return nil, err
+	}
+
+	if err := s.assignLegalEntity(template, req.LegalEntityID); err != nil {
+		return nil, err
+	}
+
+	if s.references != nil {
+		if err := s.references.Claim(entity.ExternalReferenceRecurringInvoice, template.TenantID(), template.ExternalReference(), template.ID()); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.templateRepo.Save(template); err != nil {
+		s.releaseReference(template)
+		return nil, err
+	}
+	return template, nil
+}
+
+// releaseReference frees the external reference of a template that was deleted or could not be saved
+// A reference left behind only blocks its own reuse, so failures are logged rather than returned
+func (s *RecurringInvoiceService) releaseReference(template *entity.RecurringInvoiceTemplate) {
+	if s.references == nil {
+		return
+	}
+	if err := s.references.Release(entity.ExternalReferenceRecurringInvoice, template.TenantID(), template.ExternalReference(), template.ID()); err != nil {
+		log.Printf("Failed to release external reference of template %s: %v", template.ID(), err)
+	}
+}
+
+// checkDuplicate rejects a new template duplicating an existing template of its client, pointing to the existing one
+// External references are always compared; line items are not when the duplicate was allowed
+func (s *RecurringInvoiceService) checkDuplicate(template *entity.RecurringInvoiceTemplate, allowDuplicate bool) error {
+	if s.duplicates == nil {
+		return nil
+	}
+	check := *s.duplicates
+	if allowDuplicate {
+		check.LineItemWindow = 0
+	}
+
+	existing, err := s.templateRepo.GetAll()
+	if err != nil {
+		return err
+	}
+	duplicate, match := check.FindDuplicate(template, existing)
+	if duplicate == nil {
+		return nil
+	}
+
+	message := "client already has an invoice with this external reference"
+	if match == service.DuplicateByLineItems {
+		message = "client already has an invoice with the same line items; set allow_duplicate to create it anyway"
+	}
+	duplicateErr := errors.NewBusinessRuleError("invoice_unique", errors.BusinessRuleDuplicate, message)
+	duplicateErr.Context["match"] = string(match)
+	duplicateErr.Context["existing_id"] = duplicate.ID()
+	duplicateErr.Context["existing_url"] = "/api/v1/recurring-invoices/" + duplicate.ID()
+	return duplicateErr
+}
+
+// GetTemplate retrieves a recurring invoice template of the caller's tenant by its ID
+func (s *RecurringInvoiceService) GetTemplate(rc RequestContext, id string) (*entity.RecurringInvoiceTemplate, error) {
+	if err := rc.Authorize(PermissionReadInvoices); err != nil {
+		return nil, err
+	}
+	return s.ownedTemplate(rc, id)
+}
+
+// ownedTemplate retrieves a template the caller may act on: templates of another tenant are reported as not found,
+// like clients (see BillingService.GetOwnedClient)
+func (s *RecurringInvoiceService) ownedTemplate(rc RequestContext, id string) (*entity.RecurringInvoiceTemplate, error) {
+	template, err := s.templateRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if !rc.CanAccessTenant(template.TenantID()) {
+		return nil, errors.ErrRecurringInvoiceTemplateNotFound
+	}
+	return template, nil
+}
+
+// ListTemplates retrieves the recurring invoice templates of the caller's tenant, oldest first, optionally filtered
+// by client
+func (s *RecurringInvoiceService) ListTemplates(rc RequestContext, clientID string) ([]*entity.RecurringInvoiceTemplate, error) {
+	if err := rc.Authorize(PermissionReadInvoices); err != nil {
+		return nil, err
+	}
+	templates, err := s.templateRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]*entity.RecurringInvoiceTemplate, 0, len(templates))
+	for _, template := range templates {
+		if !rc.CanAccessTenant(template.TenantID()) {
+			continue
+		}
+		if clientID != "" && template.ClientID() != clientID {
+			continue
+		}
+		filtered = append(filtered, template)
+	}
+	return filtered, nil
+}
+
+// UpdateTemplate replaces the settings of a template of the caller's tenant, pausing or resuming it when requested
+func (s *RecurringInvoiceService) UpdateTemplate(rc RequestContext, id string, req dtos.UpdateRecurringInvoiceTemplateRequest, now time.Time) (*entity.RecurringInvoiceTemplate, error) {
+	if err := rc.Authorize(PermissionManageInvoices); err != nil {
+		return nil, err
+	}
+	template, err := s.ownedTemplate(rc, id)
+	if err != nil {
+		return nil, err
+	}
+
+	lines, err := toRecurringInvoiceLines(template.Currency(), req.LineItems, s.currencyPolicy(rc.TenantID).Rounding())
+	if err != nil {
+		return nil, err
+	}
+	if err := template.Update(req.Name, lines, entity.RecurrenceFrequency(req.Frequency), req.EndDate, req.AutoSend); err != nil {
+		return nil, err
+	}
+	if err := template.SetBuyerTaxDetails(req.BuyerCountry, req.BuyerTaxID); err != nil {
+		return nil, err
+	}
+	if req.Active != nil {
+		if *req.Active {
+			template.Resume(now)
+		} else {
+			template.Pause()
+		}
+	}
+	if err := s.assignLegalEntity(template, req.LegalEntityID); err != nil {
+		return nil, err
+	}
+
+	if err := s.templateRepo.Save(template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// DeleteTemplate removes a template of the caller's tenant; invoices already issued from it are unaffected
+func (s *RecurringInvoiceService) DeleteTemplate(rc RequestContext, id string) error {
+	if err := rc.Authorize(PermissionManageInvoices); err != nil {
+		return err
+	}
+	template, err := s.ownedTemplate(rc, id)
+	if err != nil {
+		return err
+	}
+	if err := s.templateRepo.Delete(id); err != nil {
+		return err
+	}
+	s.releaseReference(template)
+	return nil
+}
+
+// IssueDueInvoices issues an invoice for every invoice due from active templates and announces it on the bus
+// (scheduler entry point)
+// Each invoice is created and issued by the billing service, so it is numbered, checked against the fiscal
+// calendar and compliance rules of its tenant and issued from the legal entity of its template like any invoice
+// Templates behind schedule catch up one invoice per missed issue date; each issue is recorded on its template
+// before its event is published, so a failed publish never issues the same invoice twice
+// Invoices dated in a closed fiscal period or missing what compliance rules require are held until the period is
+// reopened or their template fixed; with credit control, invoices the client's credit policy does not allow are
+// held until exposure leaves room for them or an override is approved; with risk scoring, large invoices of
+// clients whose score is declined are held until the score expires and the provider scores the client again
+func (s *RecurringInvoiceService) IssueDueInvoices(ctx context.Context, now time.Time) (*RecurringInvoiceRun, error) {
+	templates, err := s.templateRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	run := &RecurringInvoiceRun{
+		Issued: make([]RecurringInvoiceIssue, 0),
+		Held:   make([]RecurringInvoiceHold, 0),
+	}
+	for _, template := range templates {
+		for template.IsDue(now) {
+			issueDate, _ := template.NextIssueDate()
+			issue, hold, err := s.issueDue(ctx, template, issueDate, now)
+			if err != nil {
+				return run, err
+			}
+			if hold != nil {
+				run.Held = append(run.Held, *hold)
+				break
+			}
+
+			template.MarkIssued(now)
+			if err := s.templateRepo.Save(template); err != nil {
+				return run, err
+			}
+			run.Issued = append(run.Issued, *issue)
+
+			message, err := recurringInvoiceIssuedMessage(*issue, now)
+			if err != nil {
+				return run, err
+			}
+			if err := s.publisher.Publish(ctx, message); err != nil {
+				return run, err
+			}
+		}
+	}
+
+	return run, nil
+}
+
+// issueDue issues the invoice due from a template on issueDate, or returns why it is held
+func (s *RecurringInvoiceService) issueDue(ctx context.Context, template *entity.RecurringInvoiceTemplate, issueDate, now time.Time) (*RecurringInvoiceIssue, *RecurringInvoiceHold, error) {
+	billing := s.billingService
+	draft, err := billing.draftInvoice(SystemContext(recurringInvoiceActor, template.TenantID()), recurringInvoiceRequest(template))
+	if err != nil {
+		return nil, nil, err
+	}
+	issuance, err := billing.checkIssuance(draft, issueDate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	issue := &RecurringInvoiceIssue{
+		Template:     template,
+		IssueDate:    issueDate,
+		LegalEntity:  issuance.legalEntity,
+		Compliance:   issuance.compliance,
+		FiscalPeriod: issuance.period,
+	}
+	if issuance.period != nil && issuance.period.Closed {
+		return nil, &RecurringInvoiceHold{Template: template, IssueDate: issueDate, ClosedPeriod: issuance.period}, nil
+	}
+	if issuance.compliance != nil && !issuance.compliance.Compliant() {
+		return nil, &RecurringInvoiceHold{Template: template, IssueDate: issueDate, Compliance: issuance.compliance}, nil
+	}
+	if s.creditControl != nil {
+		issue.Credit, err = s.checkCredit(template, issueDate)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !issue.Credit.Allowed() {
+			return nil, &RecurringInvoiceHold{Template: template, IssueDate: issueDate, Credit: issue.Credit}, nil
+		}
+	}
+	if s.risk != nil && s.risk.Enabled() {
+		issue.Risk, err = s.checkRisk(ctx, template, now)
+		if err != nil {
+			return nil, nil, err
+		}
+		if issue.Risk != nil && issue.Risk.Decision() == entity.RiskDecisionDecline {
+			return nil, &RecurringInvoiceHold{Template: template, IssueDate: issueDate, Risk: issue.Risk}, nil
+		}
+	}
+
+	if err := billing.beforeInvoiceIssue(draft, issueDate); err != nil {
+		return nil, nil, err
+	}
+	if err := billing.saveDraft(draft); err != nil {
+		return nil, nil, err
+	}
+	issue.Invoice, err = billing.issueInvoice(draft, issueDate, issuance)
+	if err != nil {
+		// Leave no draft behind: the template stays due and the next run creates the invoice again
+		if deleteErr := billing.invoices.Delete(draft.ID()); deleteErr != nil {
+			log.Printf("Failed to delete draft %s of template %s: %v", draft.ID(), template.ID(), deleteErr)
+		}
+		return nil, nil, err
+	}
+	issue.InvoiceNumber = issue.Invoice.Number()
+	return issue, nil, nil
+}
+
+// recurringInvoiceRequest is the request creating the invoice due from a template
+// Templates are deliberately invoiced every period, so the line item duplicate check does not apply to them; their
+// external reference is claimed by the template, and printed from the issued event
+func recurringInvoiceRequest(template *entity.RecurringInvoiceTemplate) dtos.CreateInvoiceRequest {
+	lines := make([]dtos.InvoiceLineRequest, len(template.Lines()))
+	for i, line := range template.Lines() {
+		rate := line.TaxRateBps
+		lines[i] = dtos.InvoiceLineRequest{
+			Description: line.Description,
+			Quantity:    line.Quantity,
+			UnitAmount:  line.UnitAmount,
+			TaxRateBps:  &rate,
+		}
+	}
+	return dtos.CreateInvoiceRequest{
+		ClientID:       template.ClientID(),
+		Currency:       template.Currency(),
+		LineItems:      lines,
+		LegalEntityID:  template.LegalEntityID(),
+		BuyerCountry:   template.BuyerCountry(),
+		BuyerTaxID:     template.BuyerTaxID(),
+		AllowDuplicate: true,
+	}
+}
+
+// checkCredit checks the invoice due from a template on issueDate against the credit limit of its client
+// The template and issue date identify the invoice, so an override approved for it is found on later runs
+func (s *RecurringInvoiceService) checkCredit(template *entity.RecurringInvoiceTemplate, issueDate time.Time) (*CreditCheck, error) {
+	total, err := template.Total()
+	if err != nil {
+		return nil, err
+	}
+
+	reference := "recurring:" + template.ID() + ":" + issueDate.UTC().Format("2006-01-02")
+	return s.creditControl.CheckIssuance(template.ClientID(), reference, total)
+}
+
+// checkRisk scores the client of a template when its invoice is large, returning nil for smaller invoices
+func (s *RecurringInvoiceService) checkRisk(ctx context.Context, template *entity.RecurringInvoiceTemplate, now time.Time) (*entity.RiskAssessment, error) {
+	total, err := template.Total()
+	if err != nil {
+		return nil, err
+	}
+	if !s.risk.IsLargeInvoice(total.Amount()) {
+		return nil, nil
+	}
+
+	client, err := s.billingService.clientByID(template.ClientID())
+	if err != nil {
+		return nil, err
+	}
+	return s.risk.Assess(ctx, client, entity.RiskTriggerLargeInvoice, now)
+}
+
+// assignLegalEntity assigns a template to an existing legal entity (empty reverts to the default entity)
+func (s *RecurringInvoiceService) assignLegalEntity(template *entity.RecurringInvoiceTemplate, legalEntityID string) error {
+	if legalEntityID != "" {
+		// Without legal entity administration no entity can exist
+		if s.legalEntities == nil {
+			return errors.ErrLegalEntityNotFound
+		}
+		if _, err := s.legalEntities.GetEntity(legalEntityID); err != nil {
+			return err
+		}
+	}
+
+	if legalEntityID != template.LegalEntityID() {
+		template.AssignLegalEntity(legalEntityID)
+	}
+	return nil
+}
+
+// recurringInvoiceIssuedMessage builds the bus message for an invoice issued from a template
+func recurringInvoiceIssuedMessage(issue RecurringInvoiceIssue, now time.Time) (messaging.Message, error) {
+	template := issue.Template
+	lines := template.Lines()
+	lineItems := make([]RecurringInvoiceIssuedLine, len(lines))
+	for i, line := range lines {
+		amount, err := template.LineAmount(line)
+		if err != nil {
+			return messaging.Message{}, err
+		}
+		lineItems[i] = RecurringInvoiceIssuedLine{
+			Description: line.Description,
+			Quantity:    line.Quantity,
+			UnitAmount:  line.UnitAmount,
+			Amount:      amount.Amount(),
+			TaxRateBps:  line.TaxRateBps,
+		}
+	}
+
+	total, err := template.Total()
+	if err != nil {
+		return messaging.Message{}, err
+	}
+
+	event := RecurringInvoiceIssuedEvent{
+		TemplateID: template.ID(),
+		InvoiceID:  issue.Invoice.ID(),
+		ClientID:   template.ClientID(),
+		Name:       template.Name(),
+		IssueDate:  issue.IssueDate,
+		Currency:   template.Currency(),
+		LineItems:  lineItems,
+		Total:      total.Amount(),
+		AutoSend:   template.AutoSend(),
+		IssuedAt:   now.UTC(),
+
+		ExternalRef:   template.ExternalReference(),
+		InvoiceNumber: issue.InvoiceNumber,
+	}
+	if issue.LegalEntity != nil {
+		event.LegalEntityID = issue.LegalEntity.ID()
+		event.DocumentTemplate = issue.LegalEntity.DocumentTemplate()
+	}
+	if issue.Credit != nil && issue.Credit.Decision == service.CreditOverLimitWarned {
+		event.CreditWarning = true
+	}
+	if issue.FiscalPeriod != nil {
+		event.TenantID = template.TenantID()
+		event.FiscalYear = issue.FiscalPeriod.FiscalYear
+		event.FiscalPeriod = issue.FiscalPeriod.Key()
+	}
+	if issue.Compliance != nil {
+		event.BuyerCountry = template.BuyerCountry()
+		event.BuyerTaxID = template.BuyerTaxID()
+		event.TaxBreakdown = string(issue.Compliance.TaxBreakdown)
+		event.LegalMentions = issue.Compliance.Mentions
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return messaging.Message{}, err
+	}
+
+	return messaging.Message{
+		Topic:   RecurringInvoiceIssuedTopic,
+		Key:     template.ID(),
+		Payload: payload,
+		Headers: map[string]string{"content-type": "application/json", EventTenantHeader: template.TenantID()},
+	}, nil
+}
+
+// currencyPolicy returns the currency policy of a tenant (a zero policy without currency policies)
+func (s *RecurringInvoiceService) currencyPolicy(tenantID string) valueobject.CurrencyPolicy {
+	if s.currencies == nil {
+		return valueobject.CurrencyPolicy{}
+	}
+	return s.currencies.For(tenantID)
+}
+
+// toRecurringInvoiceLines converts request line items to domain line items in the template currency
+// Items priced in another currency must carry an exchange rate; their unit amounts are converted with rounding
+func toRecurringInvoiceLines(currency string, items []dtos.RecurringInvoiceLineRequest, rounding valueobject.RoundingMode) ([]entity.RecurringInvoiceLine, error) {
+	lines := make([]entity.RecurringInvoiceLine, len(items))
+	for i, item := range items {
+		lines[i] = entity.RecurringInvoiceLine{
+			Description: item.Description,
+			Quantity:    item.Quantity,
+			UnitAmount:  item.UnitAmount,
+			TaxRateBps:  item.TaxRateBps,
+		}
+	}
+
+	// An invalid template currency is reported by the template itself
+	if _, err := valueobject.NewMoney(0, currency); err != nil {
+		return lines, nil
+	}
+
+	invoiceLines := make([]service.InvoiceLine, len(items))
+	for i, item := range items {
+		lineCurrency := item.Currency
+		if strings.TrimSpace(lineCurrency) == "" {
+			lineCurrency = currency
+		}
+		unit, err := valueobject.NewMoney(item.UnitAmount, lineCurrency)
+		if err != nil {
+			return nil, err
+		}
+
+		invoiceLines[i] = service.InvoiceLine{Quantity: item.Quantity, UnitAmount: unit}
+		if item.ExchangeRate != "" && !strings.EqualFold(unit.Currency(), currency) {
+			rate, err := valueobject.NewExchangeRate(unit.Currency(), currency, item.ExchangeRate)
+			if err != nil {
+				return nil, err
+			}
+			invoiceLines[i].ExchangeRate = &rate
+		}
+	}
+
+	totals, err := service.CalculateInvoiceTotals(currency, invoiceLines, rounding)
+	if err != nil {
+		return nil, err
+	}
+	for i := range lines {
+		lines[i].UnitAmount = totals.UnitAmounts[i].Amount()
+	}
+	return lines, nil
+}
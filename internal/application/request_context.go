@@ -0,0 +1,90 @@
+package application
+
+import (
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+)
+
+// PrincipalKind is the kind of caller a service acts for
+type PrincipalKind string
+
+const (
+	// PrincipalAnonymous is a public API caller, only identified by the tenant it sends
+	PrincipalAnonymous PrincipalKind = "anonymous"
+
+	// PrincipalAdmin is an admin actor authenticated by its bearer token
+	PrincipalAdmin PrincipalKind = "admin"
+
+	// PrincipalPortal is a client signed in to the self-service portal
+	PrincipalPortal PrincipalKind = "portal"
+
+	// PrincipalSystem is the service itself: scheduler jobs and services calling each other
+	PrincipalSystem PrincipalKind = "system"
+)
+
+// Principal is the caller a service acts for
+type Principal struct {
+	Kind   PrincipalKind
+	ID     string   // Admin actor name, portal client ID or system component (empty for anonymous callers)
+	Scopes []string // Admin scopes the admin is restricted to (nil: every scope)
+}
+
+// RequestFlags are the request-wide switches services may act on
+type RequestFlags struct {
+	Sandbox bool // Served by the sandbox environment (synthetic data, payment gateway in test mode)
+}
+
+// RequestContext is what application services know about the request they serve: who calls, for which tenant,
+// in which locale. It is built once per request by the HTTP middleware and passed to services in place of loose
+// tenant, actor and locale strings, so services can make authorization decisions themselves
+type RequestContext struct {
+	TenantID  string // Caller's tenant (X-Tenant-ID), empty when not provided
+	Principal Principal
+	Locale    valueobject.Locale
+	Flags     RequestFlags
+}
+
+// SystemContext returns the context of work a service component does on a tenant's behalf outside a request
+// (scheduler jobs, quote conversions, credit control overrides)
+func SystemContext(component, tenantID string) RequestContext {
+	return RequestContext{
+		TenantID:  tenantID,
+		Principal: Principal{Kind: PrincipalSystem, ID: component},
+		Locale:    valueobject.DefaultLocale(),
+	}
+}
+
+// AdminContext returns the context of an admin actor calling without a tenant
+func AdminContext(actor string) RequestContext {
+	return RequestContext{
+		Principal: Principal{Kind: PrincipalAdmin, ID: actor},
+		Locale:    valueobject.DefaultLocale(),
+	}
+}
+
+// Actor returns the name the caller is recorded under in audit entries and notes (empty for anonymous callers)
+func (rc RequestContext) Actor() string {
+	return rc.Principal.ID
+}
+
+// IsAdmin checks if the caller is an authenticated admin actor
+func (rc RequestContext) IsAdmin() bool {
+	return rc.Principal.Kind == PrincipalAdmin && rc.Principal.ID != ""
+}
+
+// CanAccessTenant checks if the caller may see a resource of a tenant
+// Resources of a tenant are only visible to callers acting in it; resources created without a tenant belong to
+// no caller, so only admins and the system see them
+func (rc RequestContext) CanAccessTenant(tenantID string) bool {
+	if tenantID == "" {
+		return rc.IsAdmin() || rc.Principal.Kind == PrincipalSystem
+	}
+	return tenantID == rc.TenantID
+}
+
+// locale returns the caller's locale, the default locale when none was resolved
+func (rc RequestContext) locale() valueobject.Locale {
+	if rc.Locale.IsZero() {
+		return valueobject.DefaultLocale()
+	}
+	return rc.Locale
+}
@@ -0,0 +1,102 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+)
+
+// DefaultRiskScoreTTL is how long a risk score is reused before the provider is consulted again
+const DefaultRiskScoreTTL = 7 * 24 * time.Hour
+
+// RiskProvider scores clients with an external provider (e.g. a credit bureau)
+type RiskProvider interface {
+	// Name identifies the provider on the assessments it produced
+	Name() string
+
+	// ScoreClient returns the risk score of a subject
+	ScoreClient(ctx context.Context, subject service.RiskSubject) (service.RiskScore, error)
+}
+
+// RiskScoringService consults the risk provider on client onboarding and large invoices and caches the scores
+type RiskScoringService struct {
+	assessmentRepo        repository.RiskAssessmentRepository
+	provider              RiskProvider
+	ttl                   time.Duration
+	largeInvoiceThreshold int64
+}
+
+// NewRiskScoringService creates a new risk scoring service
+// Scores are cached for ttl (DefaultRiskScoreTTL when zero)
+func NewRiskScoringService(assessmentRepo repository.RiskAssessmentRepository, ttl time.Duration) *RiskScoringService {
+	if ttl <= 0 {
+		ttl = DefaultRiskScoreTTL
+	}
+
+	return &RiskScoringService{
+		assessmentRepo: assessmentRepo,
+		ttl:            ttl,
+	}
+}
+
+// WithProvider sets the provider clients are scored by
+func (s *RiskScoringService) WithProvider(provider RiskProvider) *RiskScoringService {
+	s.provider = provider
+	return s
+}
+
+// WithLargeInvoiceThreshold consults the provider before issuing invoices of at least threshold (minor units, any currency)
+// Without a threshold only onboarding is scored
+func (s *RiskScoringService) WithLargeInvoiceThreshold(threshold int64) *RiskScoringService {
+	s.largeInvoiceThreshold = threshold
+	return s
+}
+
+// Enabled reports whether a risk provider is configured
+func (s *RiskScoringService) Enabled() bool {
+	return s.provider != nil
+}
+
+// IsLargeInvoice reports whether an invoice total must be checked against the client risk score
+func (s *RiskScoringService) IsLargeInvoice(total int64) bool {
+	return s.largeInvoiceThreshold > 0 && total >= s.largeInvoiceThreshold
+}
+
+// Assess returns the risk assessment of a client, consulting the provider when no unexpired score is cached
+func (s *RiskScoringService) Assess(ctx context.Context, client *entity.Client, trigger entity.RiskTrigger, now time.Time) (*entity.RiskAssessment, error) {
+	if s.provider == nil {
+		return nil, errors.ErrRiskScoringUnavailable
+	}
+
+	cached, err := s.LatestAssessment(client.ID())
+	if err != nil && errors.GetErrorCode(err) != errors.RepositoryNotFound {
+		return nil, err
+	}
+	if cached != nil && !cached.IsExpired(now) {
+		return cached, nil
+	}
+
+	score, err := s.provider.ScoreClient(ctx, service.RiskSubjectFor(client))
+	if err != nil {
+		return nil, fmt.Errorf("failed to score client with the risk provider: %w", err)
+	}
+
+	assessment, err := entity.NewRiskAssessment(client.ID(), s.provider.Name(), score.Score, service.DecideRisk(score), score.Reasons, trigger, now, s.ttl)
+	if err != nil {
+		return nil, fmt.Errorf("risk provider returned an invalid score: %w", err)
+	}
+	if err := s.assessmentRepo.Save(assessment); err != nil {
+		return nil, err
+	}
+	return assessment, nil
+}
+
+// LatestAssessment retrieves the latest assessment of a client, expired or not
+func (s *RiskScoringService) LatestAssessment(clientID string) (*entity.RiskAssessment, error) {
+	return s.assessmentRepo.GetByClientID(clientID)
+}
@@ -0,0 +1,176 @@
+package application
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+)
+
+// RuleOperation is an operation of the application services that business rules are checked before
+type RuleOperation string
+
+const (
+	// RuleCreateClient is checked before a new client is saved
+	RuleCreateClient RuleOperation = "client.create"
+
+	// RuleUpdateClient is checked before the details of an existing client are saved
+	RuleUpdateClient RuleOperation = "client.update"
+
+	// RuleCreateInvoice is checked before a new invoice is saved
+	RuleCreateInvoice RuleOperation = "invoice.create"
+)
+
+// RuleSubject is what a business rule is checked against: the operation, its caller and the entities it changes
+type RuleSubject struct {
+	Operation RuleOperation
+	Context   RequestContext
+	Client    *entity.Client  // Client created or updated, or client billed by the invoice
+	Invoice   *entity.Invoice // Invoice created (nil for client operations)
+}
+
+// Rule is a business rule spanning several entities, which entities cannot check on their own because it needs
+// repository access (e.g. "email addresses are unique")
+type Rule interface {
+	// Name identifies the rule, and is the rule reported by the business rule errors it returns
+	Name() string
+
+	// Description explains the rule to operators listing the rules
+	Description() string
+
+	// Operations lists the operations the rule is checked before
+	Operations() []RuleOperation
+
+	// Check returns the error refusing the operation, nil when the subject follows the rule
+	Check(subject RuleSubject) error
+}
+
+// RuleInfo describes a registered rule
+type RuleInfo struct {
+	Name        string
+	Description string
+	Operations  []RuleOperation
+}
+
+// RuleChecker is the pipeline of business rules application services run before saving
+// Rules are checked in registration order and the first violation refuses the operation
+type RuleChecker struct {
+	rules []Rule
+}
+
+// NewRuleChecker creates a rule pipeline checking the given rules
+func NewRuleChecker(rules ...Rule) (*RuleChecker, error) {
+	checker := &RuleChecker{}
+	for _, rule := range rules {
+		if err := checker.Register(rule); err != nil {
+			return nil, err
+		}
+	}
+	return checker, nil
+}
+
+// Register adds a rule at the end of the pipeline; rule names are unique
+func (c *RuleChecker) Register(rule Rule) error {
+	if rule.Name() == "" {
+		return fmt.Errorf("business rule %T has no name", rule)
+	}
+	for _, registered := range c.rules {
+		if registered.Name() == rule.Name() {
+			return fmt.Errorf("business rule %s is already registered", rule.Name())
+		}
+	}
+	c.rules = append(c.rules, rule)
+	return nil
+}
+
+// Rules describes the registered rules in the order they are checked
+func (c *RuleChecker) Rules() []RuleInfo {
+	infos := make([]RuleInfo, len(c.rules))
+	for i, rule := range c.rules {
+		infos[i] = RuleInfo{Name: rule.Name(), Description: rule.Description(), Operations: rule.Operations()}
+	}
+	return infos
+}
+
+// Check runs the rules of the subject's operation and returns the first violation
+func (c *RuleChecker) Check(subject RuleSubject) error {
+	for _, rule := range c.rules {
+		if !slices.Contains(rule.Operations(), subject.Operation) {
+			continue
+		}
+		if err := rule.Check(subject); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DefaultRules returns the business rules every billing service checks
+func DefaultRules(clients repository.ClientRepository) []Rule {
+	return []Rule{
+		NewUniqueClientEmailRule(clients),
+		InvoiceableClientRule{},
+	}
+}
+
+// UniqueClientEmailRule refuses a client whose email address belongs to another client of its tenant
+// Addresses are compared on their canonical form, so the client email must be normalized first
+type UniqueClientEmailRule struct {
+	clients repository.ClientRepository
+}
+
+// NewUniqueClientEmailRule creates the email uniqueness rule looking clients up in a repository
+func NewUniqueClientEmailRule(clients repository.ClientRepository) UniqueClientEmailRule {
+	return UniqueClientEmailRule{clients: clients}
+}
+
+// Name identifies the rule
+func (UniqueClientEmailRule) Name() string { return "email_uniqueness" }
+
+// Description explains the rule
+func (UniqueClientEmailRule) Description() string {
+	return "A client cannot have the email address of another client of its tenant, spellings folded by the email normalization policy"
+}
+
+// Operations lists the client operations the rule is checked before
+func (UniqueClientEmailRule) Operations() []RuleOperation {
+	return []RuleOperation{RuleCreateClient, RuleUpdateClient}
+}
+
+// Check refuses the client when another client of its tenant holds its canonical email address
+func (r UniqueClientEmailRule) Check(subject RuleSubject) error {
+	existing, err := r.clients.GetByEmailKey(subject.Client.TenantID(), subject.Client.EmailKey())
+	if err != nil && errors.GetErrorCode(err) != errors.RepositoryNotFound {
+		return err
+	}
+	if existing != nil && existing.ID() != subject.Client.ID() {
+		return clientEmailConflict(subject.Context, existing)
+	}
+	return nil
+}
+
+// InvoiceableClientRule refuses invoices billed to suspended or closed clients
+type InvoiceableClientRule struct{}
+
+// Name identifies the rule
+func (InvoiceableClientRule) Name() string { return "client_active" }
+
+// Description explains the rule
+func (InvoiceableClientRule) Description() string {
+	return "Suspended and closed clients cannot be invoiced"
+}
+
+// Operations lists the invoice operations the rule is checked before
+func (InvoiceableClientRule) Operations() []RuleOperation {
+	return []RuleOperation{RuleCreateInvoice}
+}
+
+// Check refuses the invoice when its client cannot be invoiced
+func (InvoiceableClientRule) Check(subject RuleSubject) error {
+	if !subject.Client.CanBeInvoiced() {
+		return errors.ErrClientNotActive
+	}
+	return nil
+}
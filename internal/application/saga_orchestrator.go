@@ -0,0 +1,256 @@
+package application
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+)
+
+// DefaultSagaMaxAttempts is the number of failed attempts of a step after which its saga is compensated
+const DefaultSagaMaxAttempts = 5
+
+// DefaultSagaStuckAfter is how long an unfinished saga can go without progress before it is reported as stuck
+const DefaultSagaStuckAfter = 15 * time.Minute
+
+// sagaResource is the audit resource type for sagas
+const sagaResource = "saga"
+
+// SagaStep is a step of a saga definition
+type SagaStep struct {
+	Name string
+
+	// Execute performs the step; the data it returns is merged into the saga data for the next steps
+	Execute func(ctx context.Context, saga *entity.Saga) (map[string]string, error)
+
+	// Compensate undoes the effects of the completed step; nil when there is nothing to undo
+	Compensate func(ctx context.Context, saga *entity.Saga) error
+
+	// Retriable steps are retried until they succeed instead of compensating the saga
+	// (steps after the point of no return, e.g. notifying the client of a payment)
+	Retriable bool
+}
+
+// SagaDefinition describes the steps of a type of saga
+type SagaDefinition struct {
+	Type  string
+	Steps []SagaStep
+}
+
+// SagaOrchestrator runs sagas step by step, persisting their state after every step
+// A failing step is attempted once per run; sagas left unfinished are resumed by the scheduler or an admin,
+// and the completed steps are compensated in reverse order once a step has failed too many times
+type SagaOrchestrator struct {
+	sagaRepo     repository.SagaRepository
+	auditService *AuditService
+	maxAttempts  int
+	stuckAfter   time.Duration
+	definitions  map[string]SagaDefinition
+
+	// mu serializes saga runs, so a saga is never executed twice at the same time
+	mu sync.Mutex
+}
+
+// NewSagaOrchestrator creates a new saga orchestrator
+// Steps failing maxAttempts times are compensated (DefaultSagaMaxAttempts when zero) and sagas without progress
+// for stuckAfter are reported as stuck (DefaultSagaStuckAfter when zero)
+func NewSagaOrchestrator(sagaRepo repository.SagaRepository, auditService *AuditService, maxAttempts int, stuckAfter time.Duration) *SagaOrchestrator {
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultSagaMaxAttempts
+	}
+	if stuckAfter <= 0 {
+		stuckAfter = DefaultSagaStuckAfter
+	}
+
+	return &SagaOrchestrator{
+		sagaRepo:     sagaRepo,
+		auditService: auditService,
+		maxAttempts:  maxAttempts,
+		stuckAfter:   stuckAfter,
+		definitions:  make(map[string]SagaDefinition),
+	}
+}
+
+// WithDefinition registers the steps of a type of saga
+func (o *SagaOrchestrator) WithDefinition(definition SagaDefinition) *SagaOrchestrator {
+	o.definitions[definition.Type] = definition
+	return o
+}
+
+// Start creates a saga of sagaType acting on reference and runs it as far as it goes
+func (o *SagaOrchestrator) Start(ctx context.Context, sagaType, reference string, data map[string]string, now time.Time) (*entity.Saga, error) {
+	definition, ok := o.definitions[sagaType]
+	if !ok {
+		return nil, errors.ErrSagaTypeNotFound
+	}
+
+	stepNames := make([]string, len(definition.Steps))
+	for i, step := range definition.Steps {
+		stepNames[i] = step.Name
+	}
+	saga, err := entity.NewSaga(sagaType, reference, stepNames, data, now)
+	if err != nil {
+		return nil, err
+	}
+	if err := o.sagaRepo.Save(saga); err != nil {
+		return nil, err
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if err := o.run(ctx, saga, definition, now); err != nil {
+		return nil, err
+	}
+	return saga, nil
+}
+
+// Resume runs an unfinished saga again on behalf of actor and records the resume in the audit log
+func (o *SagaOrchestrator) Resume(ctx context.Context, actor, id string, now time.Time) (*entity.Saga, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	saga, err := o.sagaRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if saga.IsFinished() {
+		return nil, errors.ErrSagaFinished
+	}
+	definition, ok := o.definitions[saga.Type()]
+	if !ok {
+		return nil, errors.ErrSagaTypeNotFound
+	}
+
+	if err := o.auditService.Record(AuditActionSagaResumed, actor, "", sagaResource, saga.ID(), map[string]interface{}{
+		"type":      saga.Type(),
+		"reference": saga.Reference(),
+		"status":    string(saga.Status()),
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := o.run(ctx, saga, definition, now); err != nil {
+		return nil, err
+	}
+	return saga, nil
+}
+
+// ResumeStuck runs every stuck saga again (scheduler) and returns them
+func (o *SagaOrchestrator) ResumeStuck(ctx context.Context, now time.Time) ([]*entity.Saga, error) {
+	stuck, err := o.List("", true, now)
+	if err != nil {
+		return nil, err
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	resumed := make([]*entity.Saga, 0, len(stuck))
+	for _, saga := range stuck {
+		// The saga may have progressed since it was listed
+		current, err := o.sagaRepo.GetByID(saga.ID())
+		if err != nil {
+			return nil, err
+		}
+		definition, ok := o.definitions[current.Type()]
+		if !ok || !current.IsStuck(now, o.stuckAfter) {
+			continue
+		}
+
+		if err := o.run(ctx, current, definition, now); err != nil {
+			return nil, err
+		}
+		resumed = append(resumed, current)
+	}
+	return resumed, nil
+}
+
+// Get retrieves a saga by its ID
+func (o *SagaOrchestrator) Get(id string) (*entity.Saga, error) {
+	return o.sagaRepo.GetByID(id)
+}
+
+// List retrieves the sagas, oldest first, optionally filtered by status and restricted to stuck sagas
+func (o *SagaOrchestrator) List(status string, stuckOnly bool, now time.Time) ([]*entity.Saga, error) {
+	if status != "" && !isSagaStatus(entity.SagaStatus(status)) {
+		return nil, errors.NewValidationError("status", status, errors.ValidationFormat, "status must be one of: running, compensating, completed, compensated")
+	}
+
+	sagas, err := o.sagaRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]*entity.Saga, 0, len(sagas))
+	for _, saga := range sagas {
+		if status != "" && saga.Status() != entity.SagaStatus(status) {
+			continue
+		}
+		if stuckOnly && !saga.IsStuck(now, o.stuckAfter) {
+			continue
+		}
+		filtered = append(filtered, saga)
+	}
+	return filtered, nil
+}
+
+// IsStuck reports whether a saga made no progress for longer than the stuck threshold
+func (o *SagaOrchestrator) IsStuck(saga *entity.Saga, now time.Time) bool {
+	return saga.IsStuck(now, o.stuckAfter)
+}
+
+// run executes the steps of a saga, or compensates them, until the saga finishes or a step fails
+// The saga is saved after every step, so a crash resumes from the last recorded step
+func (o *SagaOrchestrator) run(ctx context.Context, saga *entity.Saga, definition SagaDefinition, now time.Time) error {
+	for !saga.IsFinished() {
+		step := definition.Steps[saga.CurrentStep()]
+
+		progressed, err := o.runStep(ctx, saga, step, now)
+		if err != nil {
+			return err
+		}
+		if err := o.sagaRepo.Save(saga); err != nil {
+			return err
+		}
+		if !progressed {
+			return nil
+		}
+	}
+	return nil
+}
+
+// runStep executes (running) or compensates (compensating) the current step of a saga
+// progressed is false when the step failed and the saga must wait for its next run
+func (o *SagaOrchestrator) runStep(ctx context.Context, saga *entity.Saga, step SagaStep, now time.Time) (progressed bool, err error) {
+	if saga.Status() == entity.SagaCompensating {
+		if step.Compensate != nil {
+			if err := step.Compensate(ctx, saga); err != nil {
+				return false, saga.FailCompensation(err.Error(), now)
+			}
+		}
+		return true, saga.CompensateStep(now)
+	}
+
+	output, err := step.Execute(ctx, saga)
+	if err != nil {
+		if err := saga.FailStep(err.Error(), now, o.maxAttempts, step.Retriable); err != nil {
+			return false, err
+		}
+		// A step failing for good starts the compensation right away
+		return saga.Status() == entity.SagaCompensating || saga.IsFinished(), nil
+	}
+	return true, saga.CompleteStep(output, now)
+}
+
+// isSagaStatus reports whether status is a known saga status
+func isSagaStatus(status entity.SagaStatus) bool {
+	switch status {
+	case entity.SagaRunning, entity.SagaCompensating, entity.SagaCompleted, entity.SagaCompensated:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,564 @@
+package application
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+)
+
+// SubscriptionBill is one period of a subscription billed by a scheduler run
+type SubscriptionBill struct {
+	Subscription *entity.Subscription
+	BillingDate  time.Time
+	Invoice      *entity.Invoice
+}
+
+// SubscriptionBillingRun is the outcome of a scheduler run
+type SubscriptionBillingRun struct {
+	Billed   []SubscriptionBill
+	Canceled []*entity.Subscription // Subscriptions of clients deleted since they subscribed
+}
+
+// SubscriptionPlanChange is the outcome of a plan change
+// Proration is nil when the change only applies from the next billing date; Invoice is the invoice of a positive
+// proration, while a negative one is credited on the subscription
+type SubscriptionPlanChange struct {
+	Subscription *entity.Subscription
+	Proration    *service.ProrationResult
+	Invoice      *entity.Invoice
+}
+
+// subscriptionBillingActor creates the invoices of billed subscription periods, on behalf of the subscription's tenant
+const subscriptionBillingActor = "subscription_billing"
+
+// SubscriptionService manages client subscriptions to plans and bills them on their billing dates
+// Each billed period becomes an issued invoice of the billing service
+type SubscriptionService struct {
+	subscriptions repository.SubscriptionRepository
+	billing       *BillingService
+	prorator      *service.Prorator                // Nil applies plan changes from the next billing date only
+	usage         repository.UsageRecordRepository // Nil bills no metered usage
+}
+
+// NewSubscriptionService creates a new subscription service invoicing through the billing service
+func NewSubscriptionService(subscriptionRepo repository.SubscriptionRepository, billingService *BillingService) *SubscriptionService {
+	return &SubscriptionService{
+		subscriptions: subscriptionRepo,
+		billing:       billingService,
+	}
+}
+
+// WithProration prorates plan changes made during a billed period: the difference for the remaining days is invoiced
+// right away, or credited on the next billed periods
+func (s *SubscriptionService) WithProration(prorator *service.Prorator) *SubscriptionService {
+	s.prorator = prorator
+	return s
+}
+
+// WithUsage bills the metered usage of each period with the next period, at the usage prices of the subscription
+func (s *SubscriptionService) WithUsage(usageRepo repository.UsageRecordRepository) *SubscriptionService {
+	s.usage = usageRepo
+	return s
+}
+
+// CreateSubscription subscribes a client of the caller's tenant to a plan
+// Only billing admins may manage subscriptions (PermissionManageSubscriptions), like every change below
+func (s *SubscriptionService) CreateSubscription(rc RequestContext, req dtos.CreateSubscriptionRequest) (*entity.Subscription, error) {
+	if err := rc.Authorize(PermissionManageSubscriptions); err != nil {
+		return nil, err
+	}
+	if err := s.billing.requireInvoices(); err != nil {
+		return nil, err
+	}
+
+	quantity := req.Quantity
+	if quantity == 0 {
+		quantity = 1
+	}
+	subscription, err := entity.NewSubscription(req.ClientID, req.Plan, req.Currency, req.UnitAmount, quantity, req.TaxRateBps, entity.RecurrenceFrequency(req.Interval), req.StartDate)
+	if err != nil {
+		return nil, err
+	}
+	pricing, err := toPriceScheme(req.Pricing, subscription.Currency(), req.UnitAmount)
+	if err != nil {
+		return nil, err
+	}
+	if err := subscription.ChangePricing(pricing); err != nil {
+		return nil, err
+	}
+	usagePrices, err := toUsagePrices(req.UsagePrices, subscription.Currency())
+	if err != nil {
+		return nil, err
+	}
+	if err := subscription.ChangeUsagePrices(usagePrices); err != nil {
+		return nil, err
+	}
+	subscription.AssignTenant(rc.TenantID)
+
+	if _, err := s.billing.GetOwnedClient(rc, subscription.ClientID()); err != nil {
+		return nil, err
+	}
+
+	if err := s.subscriptions.Save(subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// GetSubscription retrieves a subscription of the caller's tenant by its ID; only admins may read subscriptions
+// (PermissionReadSubscriptions)
+func (s *SubscriptionService) GetSubscription(rc RequestContext, id string) (*entity.Subscription, error) {
+	if err := rc.Authorize(PermissionReadSubscriptions); err != nil {
+		return nil, err
+	}
+	return s.ownedSubscription(rc, id)
+}
+
+// ownedSubscription retrieves a subscription the caller may act on: subscriptions of another tenant are reported as
+// not found, like clients (see BillingService.GetOwnedClient)
+func (s *SubscriptionService) ownedSubscription(rc RequestContext, id string) (*entity.Subscription, error) {
+	if !isValidUUID(id) {
+		return nil, errors.ErrSubscriptionNotFound
+	}
+	subscription, err := s.subscriptions.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if !rc.CanAccessTenant(subscription.TenantID()) {
+		return nil, errors.ErrSubscriptionNotFound
+	}
+	return subscription, nil
+}
+
+// ListSubscriptions retrieves the subscriptions of the caller's tenant, oldest first, optionally filtered by client
+func (s *SubscriptionService) ListSubscriptions(rc RequestContext, clientID string) ([]*entity.Subscription, error) {
+	if err := rc.Authorize(PermissionReadSubscriptions); err != nil {
+		return nil, err
+	}
+	subscriptions, err := s.subscriptions.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]*entity.Subscription, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		if rc.CanAccessTenant(subscription.TenantID()) && (clientID == "" || subscription.ClientID() == clientID) {
+			filtered = append(filtered, subscription)
+		}
+	}
+	return filtered, nil
+}
+
+// UpdateSubscription changes the plan, price and quantity of a subscription
+// Without proration the change applies from the next billing date. With proration, a change made during a billed
+// period also settles the remaining days of that period: an upgrade is invoiced right away, a downgrade is credited
+// on the next billed periods
+func (s *SubscriptionService) UpdateSubscription(rc RequestContext, id string, req dtos.UpdateSubscriptionRequest, now time.Time) (*SubscriptionPlanChange, error) {
+	if err := rc.Authorize(PermissionManageSubscriptions); err != nil {
+		return nil, err
+	}
+	subscription, err := s.ownedSubscription(rc, id)
+	if err != nil {
+		return nil, err
+	}
+
+	oldPrice, err := subscription.Amount()
+	if err != nil {
+		return nil, err
+	}
+	quantity := req.Quantity
+	if quantity == 0 {
+		quantity = 1
+	}
+	pricing, err := toPriceScheme(req.Pricing, subscription.Currency(), req.UnitAmount)
+	if err != nil {
+		return nil, err
+	}
+	usagePrices, err := toUsagePrices(req.UsagePrices, subscription.Currency())
+	if err != nil {
+		return nil, err
+	}
+	// The new quantity is checked against the new pricing, not the one it replaces
+	if err := subscription.ChangePricing(nil); err != nil {
+		return nil, err
+	}
+	if err := subscription.ChangePlan(req.Plan, req.UnitAmount, quantity, req.TaxRateBps); err != nil {
+		return nil, err
+	}
+	if err := subscription.ChangePricing(pricing); err != nil {
+		return nil, err
+	}
+	if err := subscription.ChangeUsagePrices(usagePrices); err != nil {
+		return nil, err
+	}
+	change := &SubscriptionPlanChange{Subscription: subscription}
+
+	periodStart, periodEnd, billed := subscription.BilledPeriod(now)
+	if s.prorator == nil || subscription.Status() != entity.SubscriptionActive || !billed {
+		if err := s.subscriptions.Save(subscription); err != nil {
+			return nil, err
+		}
+		return change, nil
+	}
+
+	newPrice, err := subscription.Amount()
+	if err != nil {
+		return nil, err
+	}
+	proration, err := s.prorator.Prorate(service.PlanChange{
+		PeriodStart: periodStart,
+		PeriodEnd:   periodEnd,
+		ChangeAt:    now,
+		OldPrice:    oldPrice,
+		NewPrice:    newPrice,
+	})
+	if err != nil {
+		return nil, err
+	}
+	change.Proration = &proration
+
+	if proration.Net.IsNegative() {
+		credit, err := valueobject.ZeroMoney(proration.Net.Currency()).Subtract(proration.Net)
+		if err != nil {
+			return nil, err
+		}
+		if err := subscription.AddProrationCredit(credit); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.subscriptions.Save(subscription); err != nil {
+		return nil, err
+	}
+	if proration.Net.IsPositive() {
+		description := fmt.Sprintf("%s proration (%s to %s)", subscription.Plan(), now.UTC().Format("2006-01-02"), periodEnd.AddDate(0, 0, -1).Format("2006-01-02"))
+		if change.Invoice, err = s.billOnce(subscription, description, proration.Net.Amount(), now); err != nil {
+			return nil, err
+		}
+	}
+	return change, nil
+}
+
+// toPriceScheme converts the pricing of a subscription request (nil: priced at the unit amount)
+// Per-unit schemes without a unit amount of their own use the given one
+func toPriceScheme(req *dtos.PriceSchemeRequest, currency string, unitAmount int64) (*valueobject.PriceScheme, error) {
+	if req == nil {
+		return nil, nil
+	}
+	if req.UnitAmount != 0 {
+		unitAmount = req.UnitAmount
+	}
+	tiers := make([]valueobject.PriceTier, len(req.Tiers))
+	for i, tier := range req.Tiers {
+		tiers[i] = valueobject.PriceTier{UpTo: tier.UpTo, UnitAmount: tier.UnitAmount, FlatAmount: tier.FlatAmount}
+	}
+	scheme, err := valueobject.NewPriceScheme(valueobject.PriceSchemeConfig{
+		Model:      valueobject.PricingModel(req.Model),
+		Currency:   currency,
+		UnitAmount: unitAmount,
+		UnitsPer:   req.UnitsPer,
+		Tiers:      tiers,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &scheme, nil
+}
+
+// toUsagePrices converts the usage prices of a subscription request
+func toUsagePrices(req map[string]dtos.PriceSchemeRequest, currency string) (map[string]valueobject.PriceScheme, error) {
+	prices := make(map[string]valueobject.PriceScheme, len(req))
+	for metric, priceReq := range req {
+		price, err := toPriceScheme(&priceReq, currency, 0)
+		if err != nil {
+			if validationErr, ok := err.(*errors.ValidationError); ok {
+				return nil, errors.NewValidationError("usage_prices."+metric+"."+validationErr.Field, validationErr.Value, validationErr.Code, validationErr.Message)
+			}
+			return nil, err
+		}
+		prices[metric] = *price
+	}
+	return prices, nil
+}
+
+// PauseSubscription stops billing a subscription until it is resumed
+func (s *SubscriptionService) PauseSubscription(rc RequestContext, id string) (*entity.Subscription, error) {
+	return s.change(rc, id, func(subscription *entity.Subscription) error {
+		return subscription.Pause()
+	})
+}
+
+// ResumeSubscription restarts billing a paused subscription; periods that started while paused are not billed
+func (s *SubscriptionService) ResumeSubscription(rc RequestContext, id string, now time.Time) (*entity.Subscription, error) {
+	return s.change(rc, id, func(subscription *entity.Subscription) error {
+		return subscription.Resume(now)
+	})
+}
+
+// CancelSubscription ends a subscription; invoices already issued for it are unaffected
+func (s *SubscriptionService) CancelSubscription(rc RequestContext, id string, now time.Time) (*entity.Subscription, error) {
+	return s.change(rc, id, func(subscription *entity.Subscription) error {
+		return subscription.Cancel(now)
+	})
+}
+
+// change applies a lifecycle change to a subscription of the caller's tenant and saves it
+func (s *SubscriptionService) change(rc RequestContext, id string, apply func(*entity.Subscription) error) (*entity.Subscription, error) {
+	if err := rc.Authorize(PermissionManageSubscriptions); err != nil {
+		return nil, err
+	}
+	subscription, err := s.ownedSubscription(rc, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := apply(subscription); err != nil {
+		return nil, err
+	}
+	if err := s.subscriptions.Save(subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// BillDueSubscriptions issues an invoice for every period due from active subscriptions (scheduler entry point)
+// Subscriptions behind schedule catch up one invoice per missed billing date. Each invoice is created as a draft,
+// recorded on its subscription, then issued: a run interrupted before the issue leaves the draft as the last
+// invoice of the subscription, which the next run issues instead of billing the period again
+// Subscriptions of clients deleted since they subscribed are canceled
+func (s *SubscriptionService) BillDueSubscriptions(now time.Time) (*SubscriptionBillingRun, error) {
+	if err := s.billing.requireInvoices(); err != nil {
+		return nil, err
+	}
+
+	subscriptions, err := s.subscriptions.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	run := &SubscriptionBillingRun{
+		Billed:   make([]SubscriptionBill, 0),
+		Canceled: make([]*entity.Subscription, 0),
+	}
+	for _, subscription := range subscriptions {
+		if err := s.issuePendingInvoice(subscription, now); err != nil {
+			return run, err
+		}
+		if !subscription.IsDue(now) {
+			continue
+		}
+
+		exists, err := s.billing.clientExists(subscription.ClientID())
+		if err != nil {
+			return run, err
+		}
+		if !exists {
+			if err := subscription.Cancel(now); err != nil {
+				return run, err
+			}
+			if err := s.subscriptions.Save(subscription); err != nil {
+				return run, err
+			}
+			run.Canceled = append(run.Canceled, subscription)
+			continue
+		}
+
+		for subscription.IsDue(now) {
+			bill, err := s.billPeriod(subscription, now)
+			if err != nil {
+				return run, err
+			}
+			run.Billed = append(run.Billed, bill)
+		}
+	}
+
+	return run, nil
+}
+
+// billPeriod invoices the next period of a subscription, less any proration credit
+func (s *SubscriptionService) billPeriod(subscription *entity.Subscription, now time.Time) (SubscriptionBill, error) {
+	billingDate := subscription.NextBillingDate()
+	taxRate := subscription.TaxRateBps()
+	lines, err := subscriptionPeriodLines(subscription)
+	if err != nil {
+		return SubscriptionBill{}, err
+	}
+	if subscription.ProrationCredit() > 0 {
+		amount, err := subscription.Amount()
+		if err != nil {
+			return SubscriptionBill{}, err
+		}
+		due, err := subscription.ApplyProrationCredit(amount)
+		if err != nil {
+			return SubscriptionBill{}, err
+		}
+		// Lines cannot be negative, so the credited period is billed as a single line
+		credited, err := amount.Subtract(due)
+		if err != nil {
+			return SubscriptionBill{}, err
+		}
+		lines = []dtos.InvoiceLineRequest{{
+			Description: fmt.Sprintf("%s, less %s proration credit", subscription.PeriodDescription(), credited),
+			Quantity:    1,
+			UnitAmount:  due.Amount(),
+			TaxRateBps:  &taxRate,
+		}}
+	}
+
+	usageLines, err := s.usageLines(subscription)
+	if err != nil {
+		return SubscriptionBill{}, err
+	}
+	lines = append(lines, usageLines...)
+
+	invoice, err := s.billing.CreateInvoice(SystemContext(subscriptionBillingActor, subscription.TenantID()), dtos.CreateInvoiceRequest{
+		ClientID:  subscription.ClientID(),
+		Currency:  subscription.Currency(),
+		LineItems: lines,
+		// Every period bills the same line, which is not a duplicate
+		AllowDuplicate: true,
+	})
+	if err != nil {
+		return SubscriptionBill{}, err
+	}
+
+	subscription.MarkBilled(invoice.ID(), now)
+	if err := s.subscriptions.Save(subscription); err != nil {
+		return SubscriptionBill{}, err
+	}
+
+	invoice, err = s.billing.IssueInvoice(SystemContext(subscriptionBillingActor, subscription.TenantID()), invoice.ID(), now)
+	if err != nil {
+		return SubscriptionBill{}, err
+	}
+	return SubscriptionBill{Subscription: subscription, BillingDate: billingDate, Invoice: invoice}, nil
+}
+
+// subscriptionPeriodLines returns the invoice lines of the next period of a subscription: the quantity at the unit
+// amount, or one line per rated bracket of its pricing
+func subscriptionPeriodLines(subscription *entity.Subscription) ([]dtos.InvoiceLineRequest, error) {
+	taxRate := subscription.TaxRateBps()
+	if subscription.Pricing() == nil {
+		return []dtos.InvoiceLineRequest{{
+			Description: subscription.PeriodDescription(),
+			Quantity:    subscription.Quantity(),
+			UnitAmount:  subscription.UnitAmount(),
+			TaxRateBps:  &taxRate,
+		}}, nil
+	}
+
+	rating, err := subscription.Pricing().Rate(subscription.Quantity())
+	if err != nil {
+		return nil, err
+	}
+	lines := make([]dtos.InvoiceLineRequest, 0, len(rating.Lines))
+	for _, rated := range rating.Lines {
+		// Rated lines are rounded on their own, so each becomes one line of its amount to keep the invoice total exact
+		amount, err := rated.Amount.Add(rated.FlatAmount)
+		if err != nil {
+			return nil, err
+		}
+		description := fmt.Sprintf("%s, %d units", subscription.PeriodDescription(), rated.Quantity)
+		if rated.Tier > 0 {
+			description = fmt.Sprintf("%s, tier %d: %d units", subscription.PeriodDescription(), rated.Tier, rated.Quantity)
+		}
+		lines = append(lines, dtos.InvoiceLineRequest{
+			Description: description,
+			Quantity:    1,
+			UnitAmount:  amount.Amount(),
+			TaxRateBps:  &taxRate,
+		})
+	}
+	return lines, nil
+}
+
+// usageLines returns the invoice lines of the metered usage billed on the next billing date of a subscription, one
+// per rated bracket of each priced metric; metrics without a price and without usage are not billed
+func (s *SubscriptionService) usageLines(subscription *entity.Subscription) ([]dtos.InvoiceLineRequest, error) {
+	periodStart, periodEnd, ok := subscription.UsagePeriod()
+	prices := subscription.UsagePrices()
+	if s.usage == nil || !ok || len(prices) == 0 {
+		return nil, nil
+	}
+
+	records, err := s.usage.ListBySubscription(subscription.ID(), periodStart, periodEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	taxRate := subscription.TaxRateBps()
+	period := fmt.Sprintf("%s to %s", periodStart.Format("2006-01-02"), periodEnd.AddDate(0, 0, -1).Format("2006-01-02"))
+	lines := make([]dtos.InvoiceLineRequest, 0)
+	for _, aggregate := range aggregateUsage(records) {
+		price, priced := prices[aggregate.Metric]
+		if !priced || aggregate.Quantity == 0 {
+			continue
+		}
+		rating, err := price.Rate(aggregate.Quantity)
+		if err != nil {
+			return nil, err
+		}
+		for _, rated := range rating.Lines {
+			amount, err := rated.Amount.Add(rated.FlatAmount)
+			if err != nil {
+				return nil, err
+			}
+			description := fmt.Sprintf("%s usage (%s), %d units", aggregate.Metric, period, rated.Quantity)
+			if rated.Tier > 0 {
+				description = fmt.Sprintf("%s usage (%s), tier %d: %d units", aggregate.Metric, period, rated.Tier, rated.Quantity)
+			}
+			lines = append(lines, dtos.InvoiceLineRequest{
+				Description: description,
+				Quantity:    1,
+				UnitAmount:  amount.Amount(),
+				TaxRateBps:  &taxRate,
+			})
+		}
+	}
+	return lines, nil
+}
+
+// billOnce creates and issues a one-off invoice of a subscription, such as the proration of an upgrade
+func (s *SubscriptionService) billOnce(subscription *entity.Subscription, description string, amount int64, now time.Time) (*entity.Invoice, error) {
+	rc := SystemContext(subscriptionBillingActor, subscription.TenantID())
+	taxRate := subscription.TaxRateBps()
+	invoice, err := s.billing.CreateInvoice(rc, dtos.CreateInvoiceRequest{
+		ClientID: subscription.ClientID(),
+		Currency: subscription.Currency(),
+		LineItems: []dtos.InvoiceLineRequest{{
+			Description: description,
+			Quantity:    1,
+			UnitAmount:  amount,
+			TaxRateBps:  &taxRate,
+		}},
+		AllowDuplicate: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.billing.IssueInvoice(rc, invoice.ID(), now)
+}
+
+// issuePendingInvoice issues the last invoice of a subscription when a previous run stopped before issuing it
+func (s *SubscriptionService) issuePendingInvoice(subscription *entity.Subscription, now time.Time) error {
+	if subscription.LastInvoiceID() == "" {
+		return nil
+	}
+
+	invoice, err := s.billing.invoiceByID(subscription.LastInvoiceID())
+	if err != nil {
+		if errors.GetErrorCode(err) == errors.RepositoryNotFound {
+			return nil
+		}
+		return err
+	}
+	if !invoice.IsDraft() {
+		return nil
+	}
+	_, err = s.billing.IssueInvoice(SystemContext(subscriptionBillingActor, subscription.TenantID()), invoice.ID(), now)
+	return err
+}
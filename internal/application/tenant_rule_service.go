@@ -0,0 +1,260 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/expression"
+)
+
+// tenantRuleSetResource is the audit resource type for tenant business rule sets
+const tenantRuleSetResource = "tenant_rule_set"
+
+// tenantRuleOperations are the operations tenant rules may be checked before
+var tenantRuleOperations = []RuleOperation{RuleCreateClient, RuleUpdateClient, RuleCreateInvoice}
+
+// TenantRuleFields are the fields tenant rule expressions may read; amounts are in minor units of the invoice
+// currency and invoice fields are empty for client operations
+var TenantRuleFields = map[string]expression.Type{
+	"tenant":                   expression.String,
+	"operation":                expression.String,
+	"client.name":              expression.String,
+	"client.email":             expression.String,
+	"client.status":            expression.String,
+	"client.tags":              expression.StringList,
+	"client.payment_terms":     expression.Int, // Days, 0 when not set
+	"invoice.currency":         expression.String,
+	"invoice.subtotal":         expression.Int,
+	"invoice.total":            expression.Int,
+	"invoice.line_count":       expression.Int,
+	"invoice.tax_jurisdiction": expression.String,
+}
+
+// tenantRuleEnv is the sandbox tenant rule expressions are compiled and evaluated in
+var tenantRuleEnv = func() *expression.Env {
+	env, err := expression.NewEnv(TenantRuleFields, expression.DefaultLimits())
+	if err != nil {
+		panic(err)
+	}
+	return env
+}()
+
+// TenantRuleService manages the business rules tenants configure as expressions, and checks them
+type TenantRuleService struct {
+	ruleRepo     repository.TenantRuleSetRepository
+	auditService *AuditService
+}
+
+// NewTenantRuleService creates a new tenant rule service
+func NewTenantRuleService(ruleRepo repository.TenantRuleSetRepository, auditService *AuditService) *TenantRuleService {
+	return &TenantRuleService{
+		ruleRepo:     ruleRepo,
+		auditService: auditService,
+	}
+}
+
+// GetRules retrieves the rule set of a tenant
+func (s *TenantRuleService) GetRules(tenantID string) (*entity.TenantRuleSet, error) {
+	return s.ruleRepo.GetByTenantID(tenantID)
+}
+
+// ListRules retrieves the rule sets of all tenants
+func (s *TenantRuleService) ListRules() ([]*entity.TenantRuleSet, error) {
+	return s.ruleRepo.GetAll()
+}
+
+// SetRules replaces the rules of a tenant and records the change in the audit log
+// Expressions are compiled first, so a set with an invalid rule is refused as a whole
+func (s *TenantRuleService) SetRules(actor, tenantID string, req dtos.SetTenantRulesRequest) (*entity.TenantRuleSet, error) {
+	rules := make([]entity.TenantRule, 0, len(req.Rules))
+	for i, ruleReq := range req.Rules {
+		rule, err := entity.NewTenantRule(ruleReq.Name, ruleReq.Operation, ruleReq.Expression, ruleReq.Message)
+		if err != nil {
+			return nil, err
+		}
+		field := "rules[" + strconv.Itoa(i) + "]"
+		if !slices.Contains(tenantRuleOperations, RuleOperation(rule.Operation())) {
+			return nil, errors.NewValidationError(field+".operation", rule.Operation(), errors.ValidationFormat,
+				"operation must be one of: client.create, client.update, invoice.create")
+		}
+		if _, err := compileTenantRule(rule); err != nil {
+			return nil, errors.NewValidationError(field+".expression", rule.Expression(), errors.ValidationFormat, err.Error())
+		}
+		rules = append(rules, rule)
+	}
+
+	set, err := entity.NewTenantRuleSet(tenantID, rules)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the previous rules (if any) for the audit trail
+	previous, err := s.ruleRepo.GetByTenantID(set.TenantID())
+	if err != nil && errors.GetErrorCode(err) != errors.RepositoryNotFound {
+		return nil, err
+	}
+
+	if err := s.ruleRepo.Save(set); err != nil {
+		return nil, err
+	}
+
+	details := map[string]interface{}{"after": set}
+	if previous != nil {
+		details["before"] = previous
+	}
+	if err := s.auditService.Record(AuditActionTenantRulesSet, actor, set.TenantID(), tenantRuleSetResource, set.TenantID(), details); err != nil {
+		return nil, err
+	}
+
+	return set, nil
+}
+
+// DeleteRules removes the rules of a tenant and records the change in the audit log
+func (s *TenantRuleService) DeleteRules(actor, tenantID string) error {
+	previous, err := s.ruleRepo.GetByTenantID(tenantID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.ruleRepo.Delete(tenantID); err != nil {
+		return err
+	}
+
+	details := map[string]interface{}{"before": previous}
+	return s.auditService.Record(AuditActionTenantRulesDeleted, actor, tenantID, tenantRuleSetResource, tenantID, details)
+}
+
+// compileTenantRule compiles the expression of a tenant rule, which must evaluate to a bool
+func compileTenantRule(rule entity.TenantRule) (*expression.Program, error) {
+	program, err := expression.Compile(tenantRuleEnv, rule.Expression())
+	if err != nil {
+		return nil, err
+	}
+	if program.Type() != expression.Bool {
+		return nil, fmt.Errorf("expression evaluates to %s, rules need a bool", program.Type())
+	}
+	return program, nil
+}
+
+// TenantExpressionRule checks the rules of the tenant of the subject, so tenants can tighten the business rules
+// without a deployment (discount eligibility, approval thresholds)
+type TenantExpressionRule struct {
+	rules *TenantRuleService
+}
+
+// NewTenantExpressionRule creates the rule checking the tenant rules managed by a tenant rule service
+func NewTenantExpressionRule(rules *TenantRuleService) TenantExpressionRule {
+	return TenantExpressionRule{rules: rules}
+}
+
+// Name identifies the rule
+func (TenantExpressionRule) Name() string { return "tenant_rules" }
+
+// Description explains the rule
+func (TenantExpressionRule) Description() string {
+	return "An operation must meet the rules its tenant configured for it, expressions evaluated in a sandbox"
+}
+
+// Operations lists the operations tenant rules may be checked before
+func (TenantExpressionRule) Operations() []RuleOperation {
+	return tenantRuleOperations
+}
+
+// Check refuses the subject when a rule of its tenant evaluates to false or cannot be evaluated
+func (r TenantExpressionRule) Check(subject RuleSubject) error {
+	tenantID := subjectTenant(subject)
+	if tenantID == "" {
+		return nil
+	}
+	set, err := r.rules.ruleRepo.GetByTenantID(tenantID)
+	if err != nil {
+		if errors.GetErrorCode(err) == errors.RepositoryNotFound {
+			return nil
+		}
+		return err
+	}
+
+	rules := set.RulesFor(string(subject.Operation))
+	if len(rules) == 0 {
+		return nil
+	}
+	vars, err := tenantRuleVars(tenantID, subject)
+	if err != nil {
+		return err
+	}
+	for _, rule := range rules {
+		// Rules were compiled when set; one failing now (e.g. reading a field since removed) refuses the operation
+		program, err := compileTenantRule(rule)
+		if err != nil {
+			return tenantRuleViolation(rule, fmt.Sprintf("tenant rule %s is invalid: %v", rule.Name(), err))
+		}
+		ok, err := program.EvalBool(context.Background(), vars)
+		if err != nil {
+			return tenantRuleViolation(rule, fmt.Sprintf("tenant rule %s could not be evaluated: %v", rule.Name(), err))
+		}
+		if !ok {
+			message := rule.Message()
+			if message == "" {
+				message = fmt.Sprintf("refused by tenant rule %s", rule.Name())
+			}
+			return tenantRuleViolation(rule, message)
+		}
+	}
+	return nil
+}
+
+// subjectTenant returns the tenant whose rules apply to a subject: the tenant of the invoice or client changed,
+// else the tenant of the caller
+func subjectTenant(subject RuleSubject) string {
+	if subject.Invoice != nil && subject.Invoice.TenantID() != "" {
+		return subject.Invoice.TenantID()
+	}
+	if subject.Client != nil && subject.Client.TenantID() != "" {
+		return subject.Client.TenantID()
+	}
+	return subject.Context.TenantID
+}
+
+// tenantRuleVars exposes a subject to tenant rule expressions (see TenantRuleFields)
+func tenantRuleVars(tenantID string, subject RuleSubject) (expression.Vars, error) {
+	vars := expression.Vars{
+		"tenant":    tenantID,
+		"operation": string(subject.Operation),
+	}
+	if client := subject.Client; client != nil {
+		vars["client.name"] = client.Name()
+		vars["client.email"] = client.EmailString()
+		vars["client.status"] = string(client.Status())
+		vars["client.tags"] = client.Tags()
+		vars["client.payment_terms"] = client.PaymentTerms().Days()
+	}
+	if invoice := subject.Invoice; invoice != nil {
+		subtotal, err := invoice.Subtotal()
+		if err != nil {
+			return nil, err
+		}
+		total, err := invoice.Total()
+		if err != nil {
+			return nil, err
+		}
+		vars["invoice.currency"] = invoice.Currency()
+		vars["invoice.subtotal"] = subtotal.Amount()
+		vars["invoice.total"] = total.Amount()
+		vars["invoice.line_count"] = len(invoice.Lines())
+		vars["invoice.tax_jurisdiction"] = invoice.TaxJurisdiction()
+	}
+	return vars, nil
+}
+
+// tenantRuleViolation is the error of a tenant rule refusing an operation
+func tenantRuleViolation(rule entity.TenantRule, message string) error {
+	violation := errors.NewBusinessRuleError("tenant_rules", errors.BusinessRuleViolation, message)
+	violation.Context["tenant_rule"] = rule.Name()
+	return violation
+}
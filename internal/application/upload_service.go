@@ -0,0 +1,205 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+)
+
+const (
+	// DefaultMaxUploadSize bounds the files sent through resumable uploads
+	DefaultMaxUploadSize int64 = 200 << 20
+
+	// DefaultUploadExpiry is how long an upload may stay in progress before it is abandoned
+	DefaultUploadExpiry = 24 * time.Hour
+)
+
+// UploadProcessor is the job taking the assembled file of a completed upload
+// It returns a summary of the processing; client errors (e.g. an unreadable file) fail the upload, other errors
+// leave it pending for the next run
+type UploadProcessor func(ctx context.Context, upload *entity.Upload, data []byte) (string, error)
+
+// UploadService receives large files in chunks, resumable after a dropped connection, and hands each assembled
+// file to the job of its kind on scheduler runs. Chunks are kept in the object store until the file is processed
+type UploadService struct {
+	uploadRepo repository.UploadRepository
+	objects    repository.ObjectStore
+	processors map[entity.UploadKind]UploadProcessor
+	maxSize    int64
+	expiry     time.Duration
+	chunksMu   sync.Mutex // Serializes chunks, so two retries of a chunk cannot both advance the offset
+}
+
+// NewUploadService creates a new upload service storing chunks in objects
+// Kinds without a processor cannot be uploaded
+func NewUploadService(uploadRepo repository.UploadRepository, objects repository.ObjectStore) *UploadService {
+	return &UploadService{
+		uploadRepo: uploadRepo,
+		objects:    objects,
+		processors: make(map[entity.UploadKind]UploadProcessor),
+		maxSize:    DefaultMaxUploadSize,
+		expiry:     DefaultUploadExpiry,
+	}
+}
+
+// WithProcessor hands the completed uploads of a kind to processor
+func (s *UploadService) WithProcessor(kind entity.UploadKind, processor UploadProcessor) *UploadService {
+	s.processors[kind] = processor
+	return s
+}
+
+// WithLimits bounds the size of uploaded files and how long an upload may stay in progress
+func (s *UploadService) WithLimits(maxSize int64, expiry time.Duration) *UploadService {
+	if maxSize > 0 {
+		s.maxSize = maxSize
+	}
+	if expiry > 0 {
+		s.expiry = expiry
+	}
+	return s
+}
+
+// MaxSize returns the size limit of uploaded files
+func (s *UploadService) MaxSize() int64 {
+	return s.maxSize
+}
+
+// CreateUpload starts the upload of a file of the declared size
+func (s *UploadService) CreateUpload(req dtos.CreateUploadRequest, now time.Time) (*entity.Upload, error) {
+	upload, err := entity.NewUpload(entity.UploadKind(req.Kind), req.Format, req.Size, s.maxSize, now.Add(s.expiry))
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := s.processors[upload.Kind()]; !ok {
+		return nil, errors.NewBusinessRuleError("upload_kind_enabled", errors.BusinessRuleViolation, fmt.Sprintf("%s uploads are not enabled", upload.Kind()))
+	}
+
+	if err := s.uploadRepo.Save(upload); err != nil {
+		return nil, err
+	}
+	return upload, nil
+}
+
+// GetUpload retrieves an upload, e.g. for a client resuming it from its offset
+func (s *UploadService) GetUpload(id string) (*entity.Upload, error) {
+	return s.uploadRepo.GetByID(id)
+}
+
+// AppendChunk stores the chunk of an upload sent from offset
+// The chunk is stored before the upload advances, so a failure leaves the upload where it was for a retry
+func (s *UploadService) AppendChunk(id string, offset int64, data []byte, now time.Time) (*entity.Upload, error) {
+	s.chunksMu.Lock()
+	defer s.chunksMu.Unlock()
+
+	upload, err := s.uploadRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	key, err := upload.AppendChunk(offset, int64(len(data)), now)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.objects.Put(key, data); err != nil {
+		return nil, err
+	}
+	if err := s.uploadRepo.Save(upload); err != nil {
+		return nil, err
+	}
+	return upload, nil
+}
+
+// ProcessPending hands the completed uploads to their job, oldest first, and abandons the expired ones
+// (scheduler entry point). Chunks are deleted once the upload is processed or failed
+func (s *UploadService) ProcessPending(ctx context.Context, now time.Time) ([]*entity.Upload, error) {
+	inProgress, err := s.uploadRepo.GetByStatus(entity.UploadInProgress)
+	if err != nil {
+		return nil, err
+	}
+	processed := make([]*entity.Upload, 0)
+	for _, upload := range inProgress {
+		if !upload.IsExpired(now) {
+			continue
+		}
+		upload.Fail("upload expired before completion", now)
+		if err := s.finish(upload); err != nil {
+			return processed, err
+		}
+		processed = append(processed, upload)
+	}
+
+	pending, err := s.uploadRepo.GetByStatus(entity.UploadPending)
+	if err != nil {
+		return processed, err
+	}
+	for _, upload := range pending {
+		data, err := s.assemble(upload)
+		if err != nil {
+			return processed, err
+		}
+
+		result, err := s.processors[upload.Kind()](ctx, upload, data)
+		if err != nil {
+			if !errors.IsClientError(err) {
+				return processed, err
+			}
+			upload.Fail(err.Error(), now)
+		} else {
+			upload.MarkProcessed(result, now)
+		}
+
+		if err := s.finish(upload); err != nil {
+			return processed, err
+		}
+		processed = append(processed, upload)
+	}
+	return processed, nil
+}
+
+// assemble reads the chunks of an upload back into the whole file
+func (s *UploadService) assemble(upload *entity.Upload) ([]byte, error) {
+	var file bytes.Buffer
+	file.Grow(int(upload.Size()))
+	for _, key := range upload.ChunkKeys() {
+		chunk, err := s.objects.Get(key)
+		if err != nil {
+			return nil, err
+		}
+		file.Write(chunk)
+	}
+	return file.Bytes(), nil
+}
+
+// finish saves an upload that reached its final status and deletes its chunks
+// The upload is saved first so a failure retries the whole upload; chunks left behind are only logged
+func (s *UploadService) finish(upload *entity.Upload) error {
+	if err := s.uploadRepo.Save(upload); err != nil {
+		return err
+	}
+	for _, key := range upload.ChunkKeys() {
+		if err := s.objects.Delete(key); err != nil {
+			log.Printf("Failed to delete chunk %s of upload %s: %v", key, upload.ID(), err)
+		}
+	}
+	return nil
+}
+
+// BankStatementUploadProcessor imports uploaded bank statements with cash application
+func BankStatementUploadProcessor(cash *CashApplicationService) UploadProcessor {
+	return func(ctx context.Context, upload *entity.Upload, data []byte) (string, error) {
+		result, err := cash.ImportStatement(upload.Format(), data)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s statement: %d transactions imported, %d duplicates, %d debits skipped",
+			result.Format, len(result.Imported), result.Duplicates, result.SkippedDebits), nil
+	}
+}
@@ -0,0 +1,181 @@
+package application
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+)
+
+// MaxUsageBatchSize is the largest number of usage events accepted in one ingestion request
+const MaxUsageBatchSize = 1000
+
+// maxUsageClockSkew is how far in the future a usage timestamp may be before it is rejected
+const maxUsageClockSkew = 5 * time.Minute
+
+// Usage ingestion outcomes per event
+const (
+	UsageRecordCreated   = "created"
+	UsageRecordDuplicate = "duplicate"
+)
+
+// UsageIngestResult is the outcome of ingesting a batch of usage events
+type UsageIngestResult struct {
+	Records    []*entity.UsageRecord
+	Statuses   []string
+	Accepted   int
+	Duplicates int
+}
+
+// UsageAggregate is the total usage of one metric over a period
+type UsageAggregate struct {
+	Metric      string
+	Quantity    int64
+	RecordCount int
+}
+
+// UsageService ingests metered usage events and aggregates them per subscription and billing period
+// Usage belongs to the tenant of its subscription: callers may only report and read usage of their tenant's
+// subscriptions
+type UsageService struct {
+	usageRepo     repository.UsageRecordRepository
+	subscriptions *SubscriptionService
+}
+
+// NewUsageService creates a new usage service for the subscriptions of the subscription service
+func NewUsageService(usageRepo repository.UsageRecordRepository, subscriptionService *SubscriptionService) *UsageService {
+	return &UsageService{
+		usageRepo:     usageRepo,
+		subscriptions: subscriptionService,
+	}
+}
+
+// IngestBatch validates a batch of usage events and records them
+// The whole batch is rejected if any event is invalid or reports usage of a subscription the caller cannot access;
+// events whose idempotency key was already ingested for the subscription are reported as duplicates and not counted
+// again. Only admins may report usage (PermissionRecordUsage)
+func (s *UsageService) IngestBatch(rc RequestContext, requests []dtos.UsageRecordRequest) (*UsageIngestResult, error) {
+	if err := rc.Authorize(PermissionRecordUsage); err != nil {
+		return nil, err
+	}
+	if len(requests) == 0 {
+		return nil, errors.NewValidationError("records", 0, errors.ValidationRequired, "at least one usage record is required")
+	}
+	if len(requests) > MaxUsageBatchSize {
+		return nil, errors.NewValidationError("records", len(requests), errors.ValidationRange,
+			fmt.Sprintf("a batch may contain at most %d usage records", MaxUsageBatchSize))
+	}
+
+	now := time.Now().UTC()
+	records := make([]*entity.UsageRecord, len(requests))
+	for index, req := range requests {
+		occurredAt := now
+		if req.Timestamp != nil {
+			occurredAt = *req.Timestamp
+		}
+		if occurredAt.After(now.Add(maxUsageClockSkew)) {
+			return nil, errors.NewValidationError(fmt.Sprintf("records[%d].timestamp", index), occurredAt, errors.ValidationRange, "usage timestamp must not be in the future")
+		}
+
+		record, err := entity.NewUsageRecord(req.SubscriptionID, req.Metric, req.Quantity, occurredAt, req.IdempotencyKey)
+		if err != nil {
+			if validationErr, ok := err.(*errors.ValidationError); ok {
+				return nil, errors.NewValidationError(fmt.Sprintf("records[%d].%s", index, validationErr.Field), validationErr.Value, validationErr.Code, validationErr.Message)
+			}
+			return nil, err
+		}
+		records[index] = record
+	}
+
+	owned := make(map[string]bool)
+	for index, record := range records {
+		if owned[record.SubscriptionID()] {
+			continue
+		}
+		if _, err := s.subscriptions.ownedSubscription(rc, record.SubscriptionID()); err != nil {
+			if err == errors.ErrSubscriptionNotFound {
+				return nil, errors.NewValidationError(fmt.Sprintf("records[%d].subscription_id", index), record.SubscriptionID(), errors.ValidationFormat, "subscription not found")
+			}
+			return nil, err
+		}
+		owned[record.SubscriptionID()] = true
+	}
+
+	result := &UsageIngestResult{
+		Records:  make([]*entity.UsageRecord, len(records)),
+		Statuses: make([]string, len(records)),
+	}
+	for index, record := range records {
+		stored, created, err := s.usageRepo.Record(record)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Records[index] = stored
+		if created {
+			result.Statuses[index] = UsageRecordCreated
+			result.Accepted++
+		} else {
+			result.Statuses[index] = UsageRecordDuplicate
+			result.Duplicates++
+		}
+	}
+
+	return result, nil
+}
+
+// ListUsage retrieves the usage records of a subscription of the caller's tenant within the billing period
+// [periodStart, periodEnd); reading usage requires PermissionReadSubscriptions
+func (s *UsageService) ListUsage(rc RequestContext, subscriptionID string, periodStart, periodEnd time.Time) ([]*entity.UsageRecord, error) {
+	if err := rc.Authorize(PermissionReadSubscriptions); err != nil {
+		return nil, err
+	}
+	if subscriptionID == "" {
+		return nil, errors.NewValidationError("subscription_id", subscriptionID, errors.ValidationRequired, "subscription ID is required")
+	}
+	if !periodEnd.After(periodStart) {
+		return nil, errors.NewValidationError("period_end", periodEnd, errors.ValidationRange, "billing period must end after it starts")
+	}
+	if _, err := s.subscriptions.ownedSubscription(rc, subscriptionID); err != nil {
+		return nil, err
+	}
+
+	return s.usageRepo.ListBySubscription(subscriptionID, periodStart.UTC(), periodEnd.UTC())
+}
+
+// Summarize aggregates the usage of a subscription per metric over the billing period [periodStart, periodEnd)
+// This is the quantity basis of the usage lines billed with the next period (see SubscriptionService.WithUsage)
+func (s *UsageService) Summarize(rc RequestContext, subscriptionID string, periodStart, periodEnd time.Time) ([]UsageAggregate, error) {
+	records, err := s.ListUsage(rc, subscriptionID, periodStart, periodEnd)
+	if err != nil {
+		return nil, err
+	}
+	return aggregateUsage(records), nil
+}
+
+// aggregateUsage totals usage records per metric, sorted by metric
+func aggregateUsage(records []*entity.UsageRecord) []UsageAggregate {
+	totals := make(map[string]*UsageAggregate)
+	for _, record := range records {
+		aggregate, ok := totals[record.Metric()]
+		if !ok {
+			aggregate = &UsageAggregate{Metric: record.Metric()}
+			totals[record.Metric()] = aggregate
+		}
+		aggregate.Quantity += record.Quantity()
+		aggregate.RecordCount++
+	}
+
+	aggregates := make([]UsageAggregate, 0, len(totals))
+	for _, aggregate := range totals {
+		aggregates = append(aggregates, *aggregate)
+	}
+	sort.Slice(aggregates, func(i, j int) bool {
+		return aggregates[i].Metric < aggregates[j].Metric
+	})
+	return aggregates
+}
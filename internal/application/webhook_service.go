@@ -0,0 +1,378 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/messaging"
+)
+
+// WebhookEventTopic is the message bus topic processed inbound webhook events are published on
+const WebhookEventTopic = "billing.webhooks.received"
+
+// DefaultWebhookMaxAttempts is the number of failed processing attempts after which an event is dead-lettered
+const DefaultWebhookMaxAttempts = 5
+
+// webhookEventResource is the audit resource type for inbound webhook events
+const webhookEventResource = "webhook_event"
+
+// WebhookVerifier authenticates the deliveries of a webhook provider
+type WebhookVerifier interface {
+	// Verify checks the signature of a delivery; header returns a request header by name
+	Verify(header func(name string) string, body []byte, now time.Time) error
+
+	// ParseEvent extracts the provider event ID and type from a verified delivery
+	ParseEvent(body []byte) (service.WebhookEnvelope, error)
+}
+
+// WebhookProcessor handles the events of a webhook provider before they are published on the message bus
+type WebhookProcessor interface {
+	Process(ctx context.Context, event *entity.WebhookEvent) error
+}
+
+// WebhookReceipt is the outcome of a webhook delivery
+type WebhookReceipt struct {
+	Event     *entity.WebhookEvent
+	Duplicate bool // The event was received before; it is not processed again
+}
+
+// WebhookService receives inbound webhook events: it verifies their signature, drops redeliveries,
+// processes each provider's events in the order they were received and dead-letters events that keep failing
+type WebhookService struct {
+	eventRepo    repository.WebhookEventRepository
+	auditService *AuditService
+	publisher    messaging.Publisher
+	maxAttempts  int
+	verifiers    map[string]WebhookVerifier
+	processors   map[string]WebhookProcessor
+	consumer     *IdempotentConsumer
+
+	// mu serializes deliveries and queue processing, so an event is stored once and processed in order
+	mu sync.Mutex
+}
+
+// NewWebhookService creates a new webhook service
+// Events are dead-lettered after maxAttempts failed processing attempts (DefaultWebhookMaxAttempts when zero)
+func NewWebhookService(eventRepo repository.WebhookEventRepository, auditService *AuditService, publisher messaging.Publisher, maxAttempts int) *WebhookService {
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultWebhookMaxAttempts
+	}
+
+	return &WebhookService{
+		eventRepo:    eventRepo,
+		auditService: auditService,
+		publisher:    publisher,
+		maxAttempts:  maxAttempts,
+		verifiers:    make(map[string]WebhookVerifier),
+		processors:   make(map[string]WebhookProcessor),
+	}
+}
+
+// WithProvider accepts deliveries from provider, authenticated by verifier
+func (s *WebhookService) WithProvider(provider string, verifier WebhookVerifier) *WebhookService {
+	s.verifiers[provider] = verifier
+	return s
+}
+
+// Providers lists the providers deliveries are accepted from, in alphabetical order
+func (s *WebhookService) Providers() []string {
+	providers := make([]string, 0, len(s.verifiers))
+	for provider := range s.verifiers {
+		providers = append(providers, provider)
+	}
+	sort.Strings(providers)
+	return providers
+}
+
+// WithProcessor sets the processor handling the events of provider
+// Events of providers without a processor are only published on the message bus
+func (s *WebhookService) WithProcessor(provider string, processor WebhookProcessor) *WebhookService {
+	s.processors[provider] = processor
+	return s
+}
+
+// WithIdempotentConsumer runs each event processor once per event, so an event processed before its publication
+// failed is only published again when it is retried
+func (s *WebhookService) WithIdempotentConsumer(consumer *IdempotentConsumer) *WebhookService {
+	s.consumer = consumer
+	return s
+}
+
+// Receive verifies a delivery from provider, queues the event it carries and processes the provider queue
+// Redeliveries of an event already received are acknowledged without being processed again
+func (s *WebhookService) Receive(ctx context.Context, provider string, header func(name string) string, body []byte, now time.Time) (*WebhookReceipt, error) {
+	verifier, ok := s.verifiers[provider]
+	if !ok {
+		return nil, errors.ErrWebhookProviderNotFound
+	}
+	if err := verifier.Verify(header, body, now); err != nil {
+		return nil, errors.ErrWebhookSignatureInvalid
+	}
+
+	envelope, err := verifier.ParseEvent(body)
+	if err != nil {
+		return nil, errors.NewValidationError("body", "", errors.ValidationFormat, err.Error())
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, err := s.eventRepo.GetByID(entity.WebhookEventID(provider, envelope.ID))
+	if err == nil {
+		return &WebhookReceipt{Event: existing, Duplicate: true}, nil
+	}
+	if errors.GetErrorCode(err) != errors.RepositoryNotFound {
+		return nil, err
+	}
+
+	event, err := entity.NewWebhookEvent(provider, envelope.ID, envelope.Type, body, now)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.eventRepo.Save(event); err != nil {
+		return nil, err
+	}
+
+	if err := s.processQueue(ctx, provider, now); err != nil {
+		return nil, err
+	}
+
+	event, err = s.eventRepo.GetByID(event.ID())
+	if err != nil {
+		return nil, err
+	}
+	return &WebhookReceipt{Event: event}, nil
+}
+
+// ProcessPending processes the queued events of every provider (scheduler entry point)
+// Returns the events processed successfully
+func (s *WebhookService) ProcessPending(ctx context.Context, now time.Time) ([]*entity.WebhookEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pending, err := s.listEvents("", entity.WebhookEventPending)
+	if err != nil {
+		return nil, err
+	}
+
+	providers := make(map[string]bool)
+	for _, event := range pending {
+		if providers[event.Provider()] {
+			continue
+		}
+		providers[event.Provider()] = true
+
+		if err := s.processQueue(ctx, event.Provider(), now); err != nil {
+			return nil, err
+		}
+	}
+
+	processed := make([]*entity.WebhookEvent, 0, len(pending))
+	for _, event := range pending {
+		event, err := s.eventRepo.GetByID(event.ID())
+		if err != nil {
+			return nil, err
+		}
+		if event.Status() == entity.WebhookEventProcessed {
+			processed = append(processed, event)
+		}
+	}
+	return processed, nil
+}
+
+// Retry requeues a dead-lettered event, processes its provider queue and records the retry in the audit log
+func (s *WebhookService) Retry(ctx context.Context, actor, id string, now time.Time) (*entity.WebhookEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	event, err := s.requeue(ctx, id, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.auditService.Record(AuditActionWebhookEventRetried, actor, "", webhookEventResource, event.ID(), map[string]interface{}{
+		"provider":   event.Provider(),
+		"event_id":   event.EventID(),
+		"event_type": event.EventType(),
+	}); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// GetEvent retrieves a webhook event by its ID
+func (s *WebhookService) GetEvent(id string) (*entity.WebhookEvent, error) {
+	return s.eventRepo.GetByID(id)
+}
+
+// QueueDepth counts the events waiting for processing (operational dashboard)
+func (s *WebhookService) QueueDepth() (int, error) {
+	events, err := s.listEvents("", entity.WebhookEventPending)
+	if err != nil {
+		return 0, err
+	}
+	return len(events), nil
+}
+
+// ListDeadLetters retrieves the dead-lettered events, oldest first, optionally filtered by provider
+func (s *WebhookService) ListDeadLetters(provider string) ([]*entity.WebhookEvent, error) {
+	return s.listEvents(provider, entity.WebhookEventDeadLetter)
+}
+
+// DeadLetters lists the dead-lettered events for the dead letter queue
+func (s *WebhookService) DeadLetters() ([]DeadLetter, error) {
+	events, err := s.ListDeadLetters("")
+	if err != nil {
+		return nil, err
+	}
+
+	letters := make([]DeadLetter, len(events))
+	for i, event := range events {
+		letter := DeadLetter{
+			ID:        event.ID(),
+			Source:    DeadLetterSourceWebhooks,
+			Reference: event.Provider() + "/" + event.EventType(),
+			Payload:   event.Payload(),
+			Headers:   map[string]string{"provider": event.Provider(), "event_id": event.EventID(), "event_type": event.EventType()},
+			Reason:    event.LastError(),
+			Attempts:  event.Attempts(),
+			FailedAt:  event.ReceivedAt(),
+		}
+		if failedAt := event.FailedAt(); failedAt != nil {
+			letter.FailedAt = *failedAt
+		}
+		letters[i] = letter
+	}
+	return letters, nil
+}
+
+// RetryDeadLetter requeues a dead-lettered event and processes its provider queue
+// An error is returned when the event still fails, in which case it waits in the queue for its next attempt
+func (s *WebhookService) RetryDeadLetter(ctx context.Context, id string, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	event, err := s.requeue(ctx, id, now)
+	if err != nil {
+		return err
+	}
+	if event.Status() != entity.WebhookEventProcessed {
+		return fmt.Errorf("webhook event was not processed: %s", event.LastError())
+	}
+	return nil
+}
+
+// DiscardDeadLetter marks a dead-lettered event as discarded; it is kept so redeliveries are still deduplicated
+func (s *WebhookService) DiscardDeadLetter(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	event, err := s.eventRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if err := event.Discard(); err != nil {
+		return err
+	}
+	return s.eventRepo.Save(event)
+}
+
+// requeue moves a dead-lettered event back to its provider queue and processes the queue
+// The caller must hold mu
+func (s *WebhookService) requeue(ctx context.Context, id string, now time.Time) (*entity.WebhookEvent, error) {
+	event, err := s.eventRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := event.Requeue(); err != nil {
+		return nil, err
+	}
+	if err := s.eventRepo.Save(event); err != nil {
+		return nil, err
+	}
+
+	if err := s.processQueue(ctx, event.Provider(), now); err != nil {
+		return nil, err
+	}
+	return s.eventRepo.GetByID(event.ID())
+}
+
+// processQueue processes the pending events of provider in the order they were received
+// Processing stops at the first event that fails without being dead-lettered, so later events never overtake it
+func (s *WebhookService) processQueue(ctx context.Context, provider string, now time.Time) error {
+	pending, err := s.listEvents(provider, entity.WebhookEventPending)
+	if err != nil {
+		return err
+	}
+
+	for _, event := range pending {
+		if err := s.process(ctx, event); err != nil {
+			event.RecordFailure(err.Error(), now, s.maxAttempts)
+		} else {
+			event.MarkProcessed(now)
+		}
+		if err := s.eventRepo.Save(event); err != nil {
+			return err
+		}
+
+		if event.Status() == entity.WebhookEventPending {
+			return nil
+		}
+	}
+	return nil
+}
+
+// process runs the provider processor on an event and publishes it on the message bus
+func (s *WebhookService) process(ctx context.Context, event *entity.WebhookEvent) error {
+	if processor, ok := s.processors[event.Provider()]; ok {
+		run := func() error {
+			return processor.Process(ctx, event)
+		}
+		if s.consumer != nil {
+			if _, err := s.consumer.ProcessOnce(webhookMessageID(event), run); err != nil {
+				return err
+			}
+		} else if err := run(); err != nil {
+			return err
+		}
+	}
+
+	return s.publisher.Publish(ctx, messaging.Message{
+		Topic:   WebhookEventTopic,
+		Key:     event.ID(),
+		Payload: event.Payload(),
+		Headers: map[string]string{
+			"content-type": "application/json",
+			"provider":     event.Provider(),
+			"event_type":   event.EventType(),
+		},
+	})
+}
+
+// listEvents retrieves the events with a status, oldest first, optionally filtered by provider
+func (s *WebhookService) listEvents(provider string, status entity.WebhookEventStatus) ([]*entity.WebhookEvent, error) {
+	events, err := s.eventRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]*entity.WebhookEvent, 0, len(events))
+	for _, event := range events {
+		if event.Status() == status && (provider == "" || event.Provider() == provider) {
+			filtered = append(filtered, event)
+		}
+	}
+	return filtered, nil
+}
+
+// webhookMessageID identifies an event for the idempotent consumer, apart from the other messages it processes
+func webhookMessageID(event *entity.WebhookEvent) string {
+	return "webhooks/" + event.Provider() + "/" + event.EventID()
+}
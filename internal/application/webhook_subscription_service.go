@@ -0,0 +1,337 @@
+package application
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+)
+
+// DefaultWebhookReplayCooldown is how long after a replay the events of a subscription can be replayed again
+const DefaultWebhookReplayCooldown = 5 * time.Minute
+
+// MaxWebhookReplayEvents bounds the events delivered by one replay request; larger backlogs are replayed in several
+// requests
+const MaxWebhookReplayEvents = 100
+
+// DefaultWebhookReplayTimeout bounds the time a replay request delivers events for, so it answers within the server
+// write timeout
+const DefaultWebhookReplayTimeout = 20 * time.Second
+
+// WebhookReplayRateRule is the business rule reported when a subscription is replayed again within the cooldown
+const WebhookReplayRateRule = "webhook_replay_rate"
+
+// webhookSubscriptionResource is the audit resource type for webhook subscriptions
+const webhookSubscriptionResource = "webhook_subscription"
+
+// WebhookSender delivers events to the endpoints of webhook subscriptions
+type WebhookSender interface {
+	// Send posts a delivery to endpointURL signed with secret
+	Send(ctx context.Context, endpointURL, secret string, delivery service.WebhookDelivery, now time.Time) error
+}
+
+// WebhookReplay is the outcome of a replay of historical events to a subscription
+type WebhookReplay struct {
+	Subscription *entity.WebhookSubscription
+	From         string // RFC 3339 timestamp or event ID the events were replayed after
+	Delivered    int
+	LastEventID  string // Last event delivered
+	// FailedEventID is the event the endpoint did not acknowledge, with the delivery error; the replay stops there so
+	// the consumer receives the events in order
+	FailedEventID string
+	Error         string
+	// HasMore is set when events remain to be replayed (delivery failure, MaxWebhookReplayEvents or the replay
+	// timeout reached); replaying from NextFrom, an event ID, resumes with the first event not delivered without
+	// waiting for the cooldown
+	HasMore  bool
+	NextFrom string
+}
+
+// stopAt ends the replay before event, which remains to be delivered
+func (r *WebhookReplay) stopAt(event *entity.StoredEvent) {
+	r.HasMore = true
+	r.NextFrom = strconv.FormatInt(event.Sequence()-1, 10)
+}
+
+// WebhookSubscriptionService manages the endpoints consumers receive the events of their tenant on, and replays
+// historical events from the event store to them when consumers lost some
+type WebhookSubscriptionService struct {
+	subscriptionRepo repository.WebhookSubscriptionRepository
+	events           *EventStore
+	sender           WebhookSender
+	auditService     *AuditService
+	cooldown         time.Duration
+	timeout          time.Duration
+	now              func() time.Time
+
+	// mu serializes the cooldown checks and cursor updates, so concurrent replays of a subscription are rejected
+	mu sync.Mutex
+}
+
+// NewWebhookSubscriptionService creates a new webhook subscription service
+// Events are replayed from events (no replay when nil) through sender, at most once per cooldown and subscription
+// (DefaultWebhookReplayCooldown when zero)
+func NewWebhookSubscriptionService(subscriptionRepo repository.WebhookSubscriptionRepository, events *EventStore, sender WebhookSender, auditService *AuditService, cooldown time.Duration) *WebhookSubscriptionService {
+	if cooldown <= 0 {
+		cooldown = DefaultWebhookReplayCooldown
+	}
+
+	return &WebhookSubscriptionService{
+		subscriptionRepo: subscriptionRepo,
+		events:           events,
+		sender:           sender,
+		auditService:     auditService,
+		cooldown:         cooldown,
+		timeout:          DefaultWebhookReplayTimeout,
+		now:              time.Now,
+	}
+}
+
+// WithReplayTimeout bounds the time a replay request delivers events for (DefaultWebhookReplayTimeout when zero)
+func (s *WebhookSubscriptionService) WithReplayTimeout(timeout time.Duration) *WebhookSubscriptionService {
+	if timeout > 0 {
+		s.timeout = timeout
+	}
+	return s
+}
+
+// WithClock sets the clock replays are timed with (tests)
+func (s *WebhookSubscriptionService) WithClock(now func() time.Time) *WebhookSubscriptionService {
+	s.now = now
+	return s
+}
+
+// CreateSubscription subscribes an endpoint to the events of the caller's tenant
+func (s *WebhookSubscriptionService) CreateSubscription(rc RequestContext, req dtos.CreateWebhookSubscriptionRequest) (*entity.WebhookSubscription, error) {
+	if err := rc.Authorize(PermissionManageWebhooks); err != nil {
+		return nil, err
+	}
+	subscription, err := entity.NewWebhookSubscription(rc.TenantID, req.URL, req.EventTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.subscriptionRepo.Save(subscription); err != nil {
+		return nil, err
+	}
+
+	details := map[string]interface{}{"url": subscription.URL(), "event_types": subscription.EventTypes()}
+	if err := s.auditService.Record(AuditActionWebhookSubscribed, rc.Actor(), subscription.TenantID(), webhookSubscriptionResource, subscription.ID(), details); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// GetSubscription retrieves a subscription of the caller's tenant by its ID
+func (s *WebhookSubscriptionService) GetSubscription(rc RequestContext, id string) (*entity.WebhookSubscription, error) {
+	if err := rc.Authorize(PermissionManageWebhooks); err != nil {
+		return nil, err
+	}
+	return s.ownedSubscription(rc, id)
+}
+
+// ListSubscriptions retrieves the subscriptions of the caller's tenant, oldest first
+func (s *WebhookSubscriptionService) ListSubscriptions(rc RequestContext) ([]*entity.WebhookSubscription, error) {
+	if err := rc.Authorize(PermissionManageWebhooks); err != nil {
+		return nil, err
+	}
+	subscriptions, err := s.subscriptionRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	owned := make([]*entity.WebhookSubscription, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		if rc.CanAccessTenant(subscription.TenantID()) {
+			owned = append(owned, subscription)
+		}
+	}
+	return owned, nil
+}
+
+// DeleteSubscription removes a subscription of the caller's tenant
+func (s *WebhookSubscriptionService) DeleteSubscription(rc RequestContext, id string) error {
+	if err := rc.Authorize(PermissionManageWebhooks); err != nil {
+		return err
+	}
+	subscription, err := s.ownedSubscription(rc, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.subscriptionRepo.Delete(subscription.ID()); err != nil {
+		return err
+	}
+
+	details := map[string]interface{}{"url": subscription.URL(), "event_types": subscription.EventTypes()}
+	return s.auditService.Record(AuditActionWebhookUnsubscribed, rc.Actor(), subscription.TenantID(), webhookSubscriptionResource, subscription.ID(), details)
+}
+
+// ReplayEvents delivers again the events of the subscription's tenant and types recorded in the event store after
+// from (an RFC 3339 timestamp or an event ID), in the order they were recorded, and records the replay in the audit
+// log
+// A replay stops at the first event its endpoint does not acknowledge, after MaxWebhookReplayEvents events or when the
+// replay timeout is reached, so the request answers in time with the event to resume from. A subscription is
+// replayed at most once per cooldown, except to resume the replay where it stopped
+func (s *WebhookSubscriptionService) ReplayEvents(ctx context.Context, rc RequestContext, id, from string) (*WebhookReplay, error) {
+	if err := rc.Authorize(PermissionManageWebhooks); err != nil {
+		return nil, err
+	}
+	if s.events == nil {
+		return nil, errors.NewBusinessRuleError("event_store_enabled", errors.BusinessRuleViolation, "the event store is not enabled")
+	}
+	now := s.now()
+	from = strings.TrimSpace(from)
+	if from == "" {
+		return nil, errors.NewValidationError("from", from, errors.ValidationRequired, "from is required")
+	}
+	if _, err := strconv.ParseInt(from, 10, 64); err != nil {
+		timestamp, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			return nil, errors.NewValidationError("from", from, errors.ValidationFormat, "from must be an RFC 3339 timestamp or an event ID")
+		}
+		if timestamp.After(now) {
+			return nil, errors.NewValidationError("from", from, errors.ValidationRange, "from must not be in the future")
+		}
+	}
+
+	subscription, err := s.startReplay(rc, id, from, now)
+	if err != nil {
+		return nil, err
+	}
+
+	replay, err := s.deliver(ctx, subscription, from)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.finishReplay(subscription.ID(), replay.NextFrom); err != nil {
+		return nil, err
+	}
+
+	details := map[string]interface{}{
+		"from":      replay.From,
+		"delivered": replay.Delivered,
+	}
+	if replay.FailedEventID != "" {
+		details["failed_event_id"] = replay.FailedEventID
+		details["error"] = replay.Error
+	}
+	if err := s.auditService.Record(AuditActionWebhookEventsReplayed, rc.Actor(), subscription.TenantID(), webhookSubscriptionResource, subscription.ID(), details); err != nil {
+		return nil, err
+	}
+	return replay, nil
+}
+
+// deliver sends the events of the subscription's tenant and types recorded after from to its endpoint, one page of the
+// tenant's events at a time, until one is not acknowledged or the replay limits are reached
+func (s *WebhookSubscriptionService) deliver(ctx context.Context, subscription *entity.WebhookSubscription, from string) (*WebhookReplay, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	replay := &WebhookReplay{Subscription: subscription, From: from}
+	query := EventLogQuery{TenantID: subscription.TenantID(), Since: from, Limit: MaxWebhookReplayEvents}
+	for {
+		page, err := s.events.ListEvents(query)
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range page.Events {
+			if !subscription.Delivers(event.Type()) {
+				continue
+			}
+			if replay.Delivered == MaxWebhookReplayEvents || ctx.Err() != nil {
+				replay.stopAt(event)
+				return replay, nil
+			}
+
+			eventID := strconv.FormatInt(event.Sequence(), 10)
+			if err := s.sender.Send(ctx, subscription.URL(), subscription.Secret(), service.WebhookDelivery{
+				ID:          eventID,
+				Type:        event.Type(),
+				AggregateID: event.AggregateID(),
+				Payload:     event.Payload(),
+				OccurredAt:  event.OccurredAt(),
+				Replayed:    true,
+			}, s.now()); err != nil {
+				// A delivery cut off by the replay timeout (or the caller leaving) is not the endpoint's failure
+				if ctx.Err() == nil {
+					replay.FailedEventID = eventID
+					replay.Error = err.Error()
+				}
+				replay.stopAt(event)
+				return replay, nil
+			}
+			replay.Delivered++
+			replay.LastEventID = eventID
+		}
+		if !page.HasMore {
+			return replay, nil
+		}
+		query.Since = page.NextCursor
+	}
+}
+
+// startReplay records a replay of a subscription of the caller's tenant starting now, unless the subscription was
+// replayed within the cooldown and the replay does not resume the previous one where it stopped
+func (s *WebhookSubscriptionService) startReplay(rc RequestContext, id, from string, now time.Time) (*entity.WebhookSubscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	subscription, err := s.ownedSubscription(rc, id)
+	if err != nil {
+		return nil, err
+	}
+	resuming := subscription.ReplayCursor() != "" && from == subscription.ReplayCursor()
+	if last := subscription.LastReplayAt(); !resuming && last != nil && now.Before(last.Add(s.cooldown)) {
+		limited := errors.NewBusinessRuleError(WebhookReplayRateRule, errors.BusinessRuleConflict, "events were replayed to this subscription too recently")
+		limited.Context["retry_at"] = last.Add(s.cooldown).UTC().Format(time.RFC3339)
+		return nil, limited
+	}
+
+	subscription.StartReplay(now)
+	if err := s.subscriptionRepo.Save(subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// finishReplay records where the replay of a subscription stopped (empty: every event was replayed), so it can be
+// resumed from there; a subscription deleted during the replay is left deleted
+func (s *WebhookSubscriptionService) finishReplay(id, cursor string) error {
+	if cursor == "" {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	subscription, err := s.subscriptionRepo.GetByID(id)
+	if err != nil {
+		if errors.GetErrorCode(err) == errors.RepositoryNotFound {
+			return nil
+		}
+		return err
+	}
+	subscription.StopReplay(cursor)
+	return s.subscriptionRepo.Save(subscription)
+}
+
+// ownedSubscription retrieves a subscription the caller may act on: subscriptions of another tenant are reported as
+// not found
+func (s *WebhookSubscriptionService) ownedSubscription(rc RequestContext, id string) (*entity.WebhookSubscription, error) {
+	subscription, err := s.subscriptionRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if !rc.CanAccessTenant(subscription.TenantID()) {
+		return nil, errors.ErrWebhookSubscriptionNotFound
+	}
+	return subscription, nil
+}
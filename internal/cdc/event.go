@@ -0,0 +1,160 @@
+// Package cdc republishes PostgreSQL logical replication changes (wal2json) as normalized events
+//
+// This package implements Change Data Capture for billing tables.
+// Provides: wal2json decoding, event normalization, replication slot polling, relay to the message bus
+// Pattern: Peek changes -> publish -> advance slot (at-least-once delivery)
+// Used by: cmd/cdc (deployed independently from the API)
+package cdc
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Operation is the normalized kind of change
+type Operation string
+
+// Normalized operations
+const (
+	OperationInsert   Operation = "insert"
+	OperationUpdate   Operation = "update"
+	OperationDelete   Operation = "delete"
+	OperationTruncate Operation = "truncate"
+)
+
+// ChangeEvent is the normalized change event published on the message bus
+type ChangeEvent struct {
+	// ID uniquely identifies the event (LSN and position) so consumers can deduplicate redeliveries
+	ID            string          `json:"id"`
+	Source        string          `json:"source"`
+	Schema        string          `json:"schema"`
+	Table         string          `json:"table"`
+	Entity        string          `json:"entity"`
+	Operation     Operation       `json:"operation"`
+	Key           string          `json:"key,omitempty"`
+	Data          json.RawMessage `json:"data,omitempty"`
+	LSN           string          `json:"lsn"`
+	TransactionID string          `json:"transaction_id,omitempty"`
+	CapturedAt    time.Time       `json:"captured_at"`
+}
+
+// wal2jsonColumn is a column entry of a wal2json format-version 2 record
+type wal2jsonColumn struct {
+	Name  string      `json:"name"`
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+// wal2jsonRecord is a wal2json format-version 2 record
+type wal2jsonRecord struct {
+	Action   string           `json:"action"`
+	Schema   string           `json:"schema"`
+	Table    string           `json:"table"`
+	Columns  []wal2jsonColumn `json:"columns"`
+	Identity []wal2jsonColumn `json:"identity"`
+}
+
+// wal2jsonActions maps wal2json row actions to normalized operations
+// Transaction markers (B, C) and logical messages (M) are not row changes
+var wal2jsonActions = map[string]Operation{
+	"I": OperationInsert,
+	"U": OperationUpdate,
+	"D": OperationDelete,
+	"T": OperationTruncate,
+}
+
+// Normalizer turns raw wal2json records into change events
+type Normalizer struct {
+	// entities maps table names to the entity name used in events and topics
+	entities map[string]string
+	now      func() time.Time
+}
+
+// NewNormalizer creates a normalizer; tables without an entity mapping use the table name
+func NewNormalizer(entities map[string]string) *Normalizer {
+	return &Normalizer{
+		entities: entities,
+		now:      time.Now,
+	}
+}
+
+// Normalize decodes a wal2json record; ok is false for records that are not row changes
+func (n *Normalizer) Normalize(raw RawChange, position int) (event ChangeEvent, ok bool, err error) {
+	var record wal2jsonRecord
+	if err := json.Unmarshal([]byte(raw.Data), &record); err != nil {
+		return ChangeEvent{}, false, fmt.Errorf("failed to decode wal2json record at %s: %w", raw.LSN, err)
+	}
+
+	operation, isRowChange := wal2jsonActions[record.Action]
+	if !isRowChange {
+		return ChangeEvent{}, false, nil
+	}
+
+	entity := record.Table
+	if mapped, exists := n.entities[record.Table]; exists && mapped != "" {
+		entity = mapped
+	}
+
+	event = ChangeEvent{
+		ID:            fmt.Sprintf("%s:%d", raw.LSN, position),
+		Source:        "postgres",
+		Schema:        record.Schema,
+		Table:         record.Table,
+		Entity:        entity,
+		Operation:     operation,
+		LSN:           raw.LSN,
+		TransactionID: raw.XID,
+		CapturedAt:    n.now().UTC(),
+	}
+
+	// Deletes only carry the replica identity; inserts and updates carry the new row
+	keyColumns := record.Identity
+	if len(keyColumns) == 0 {
+		keyColumns = record.Columns
+	}
+	event.Key = primaryKey(keyColumns)
+
+	if operation == OperationInsert || operation == OperationUpdate {
+		data, err := rowData(record.Columns)
+		if err != nil {
+			return ChangeEvent{}, false, fmt.Errorf("failed to normalize %s.%s row at %s: %w", record.Schema, record.Table, raw.LSN, err)
+		}
+		event.Data = data
+	}
+
+	return event, true, nil
+}
+
+// primaryKey extracts the record key ("key" column of key-value tables, else "id")
+func primaryKey(columns []wal2jsonColumn) string {
+	for _, name := range []string{"key", "id"} {
+		for _, column := range columns {
+			if column.Name == name && column.Value != nil {
+				return fmt.Sprint(column.Value)
+			}
+		}
+	}
+	return ""
+}
+
+// rowData returns the event payload of a row
+// Key-value storage tables hold the aggregate as JSON text in "value", which is republished as-is;
+// other tables are published as a column name -> value object
+func rowData(columns []wal2jsonColumn) (json.RawMessage, error) {
+	for _, column := range columns {
+		if column.Name != "value" {
+			continue
+		}
+		if text, ok := column.Value.(string); ok && json.Valid([]byte(strings.TrimSpace(text))) {
+			return json.RawMessage(text), nil
+		}
+	}
+
+	row := make(map[string]interface{}, len(columns))
+	for _, column := range columns {
+		row[column.Name] = column.Value
+	}
+	return json.Marshal(row)
+}
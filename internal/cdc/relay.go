@@ -0,0 +1,136 @@
+package cdc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/messaging"
+)
+
+// Config defines the CDC relay settings
+type Config struct {
+	// SlotName is the logical replication slot owned by the relay
+	SlotName string
+
+	// Tables lists the captured tables as "schema.table"
+	Tables []string
+
+	// TableEntities maps table names to entity names used in events and topics
+	TableEntities map[string]string
+
+	// TopicPrefix prefixes the topic of every event ("<prefix>.<entity>")
+	TopicPrefix string
+
+	// BatchSize bounds the number of changes read per poll
+	BatchSize int
+
+	// PollInterval is the wait between polls when the slot is drained
+	PollInterval time.Duration
+}
+
+// Relay republishes replication slot changes as normalized events
+// Changes are only confirmed on the slot after publishing succeeded (at-least-once delivery)
+type Relay struct {
+	config     Config
+	source     ChangeSource
+	publisher  messaging.Publisher
+	normalizer *Normalizer
+}
+
+// NewRelay creates a relay reading from source and publishing to publisher
+func NewRelay(config Config, source ChangeSource, publisher messaging.Publisher) *Relay {
+	if config.BatchSize <= 0 {
+		config.BatchSize = 500
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = time.Second
+	}
+	if config.TopicPrefix == "" {
+		config.TopicPrefix = "billing.cdc"
+	}
+
+	return &Relay{
+		config:     config,
+		source:     source,
+		publisher:  publisher,
+		normalizer: NewNormalizer(config.TableEntities),
+	}
+}
+
+// Run polls until the context is cancelled
+func (r *Relay) Run(ctx context.Context) error {
+	for {
+		published, err := r.RelayOnce(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			log.Printf("CDC relay error: %v", err)
+		}
+
+		// Keep draining while there is a backlog
+		if err == nil && published > 0 {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(r.config.PollInterval):
+		}
+	}
+}
+
+// RelayOnce publishes one batch of changes and returns the number of events published
+func (r *Relay) RelayOnce(ctx context.Context) (int, error) {
+	changes, err := r.source.Peek(ctx, r.config.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+	if len(changes) == 0 {
+		return 0, nil
+	}
+
+	messages := make([]messaging.Message, 0, len(changes))
+	for position, change := range changes {
+		event, ok, err := r.normalizer.Normalize(change, position)
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			continue
+		}
+
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return 0, fmt.Errorf("failed to encode change event %s: %w", event.ID, err)
+		}
+
+		messages = append(messages, messaging.Message{
+			Topic:   r.config.TopicPrefix + "." + event.Entity,
+			Key:     event.Key,
+			Payload: payload,
+			Headers: map[string]string{
+				"content-type": "application/json",
+				"event-id":     event.ID,
+				"operation":    string(event.Operation),
+			},
+		})
+	}
+
+	if len(messages) > 0 {
+		if err := r.publisher.Publish(ctx, messages...); err != nil {
+			return 0, fmt.Errorf("failed to publish change events: %w", err)
+		}
+	}
+
+	// Confirm the batch only once it is safely published
+	if err := r.source.Advance(ctx, changes[len(changes)-1].LSN); err != nil {
+		return 0, err
+	}
+
+	return len(messages), nil
+}
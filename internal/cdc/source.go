@@ -0,0 +1,97 @@
+package cdc
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// wal2jsonPlugin is the logical decoding output plugin used by the slot
+const wal2jsonPlugin = "wal2json"
+
+// RawChange is a row returned by the logical decoding SQL interface
+type RawChange struct {
+	LSN  string
+	XID  string
+	Data string
+}
+
+// ChangeSource reads changes from a replication slot
+type ChangeSource interface {
+	// Peek returns pending changes without consuming them
+	Peek(ctx context.Context, limit int) ([]RawChange, error)
+
+	// Advance confirms every change up to and including lsn
+	Advance(ctx context.Context, lsn string) error
+}
+
+// SlotSource reads wal2json changes through the SQL interface of a logical replication slot
+// The database role needs the REPLICATION attribute and the server wal_level=logical
+type SlotSource struct {
+	db       *sql.DB
+	slotName string
+	tables   []string
+}
+
+// NewSlotSource creates a source for the named slot, restricted to tables ("schema.table")
+func NewSlotSource(db *sql.DB, slotName string, tables []string) *SlotSource {
+	return &SlotSource{
+		db:       db,
+		slotName: slotName,
+		tables:   tables,
+	}
+}
+
+// EnsureSlot creates the replication slot if it does not exist yet
+func (s *SlotSource) EnsureSlot(ctx context.Context) error {
+	var exists bool
+	err := s.db.QueryRowContext(ctx,
+		"SELECT EXISTS (SELECT 1 FROM pg_replication_slots WHERE slot_name = $1)", s.slotName).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to look up replication slot %s: %w", s.slotName, err)
+	}
+	if exists {
+		return nil
+	}
+
+	if _, err := s.db.ExecContext(ctx,
+		"SELECT pg_create_logical_replication_slot($1, $2)", s.slotName, wal2jsonPlugin); err != nil {
+		return fmt.Errorf("failed to create replication slot %s: %w", s.slotName, err)
+	}
+	return nil
+}
+
+// Peek returns up to limit pending changes (whole transactions may exceed the limit)
+func (s *SlotSource) Peek(ctx context.Context, limit int) ([]RawChange, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT lsn::text, xid::text, data
+		   FROM pg_logical_slot_peek_changes($1, NULL, $2,
+		        'format-version', '2',
+		        'include-transaction', 'false',
+		        'add-tables', $3)`,
+		s.slotName, limit, strings.Join(s.tables, ","))
+	if err != nil {
+		return nil, fmt.Errorf("failed to peek changes from slot %s: %w", s.slotName, err)
+	}
+	defer rows.Close()
+
+	var changes []RawChange
+	for rows.Next() {
+		var change RawChange
+		if err := rows.Scan(&change.LSN, &change.XID, &change.Data); err != nil {
+			return nil, fmt.Errorf("failed to read change from slot %s: %w", s.slotName, err)
+		}
+		changes = append(changes, change)
+	}
+	return changes, rows.Err()
+}
+
+// Advance moves the slot past lsn so confirmed changes are not returned again
+func (s *SlotSource) Advance(ctx context.Context, lsn string) error {
+	if _, err := s.db.ExecContext(ctx,
+		"SELECT pg_replication_slot_advance($1, $2::pg_lsn)", s.slotName, lsn); err != nil {
+		return fmt.Errorf("failed to advance slot %s to %s: %w", s.slotName, lsn, err)
+	}
+	return nil
+}
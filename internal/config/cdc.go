@@ -0,0 +1,25 @@
+package config
+
+import (
+	"github.com/gjaminon-go-labs/billing-api/internal/cdc"
+)
+
+// ToCDCConfig converts the application configuration to the CDC relay configuration
+func (c *Config) ToCDCConfig() cdc.Config {
+	return cdc.Config{
+		SlotName:      c.CDC.SlotName,
+		Tables:        c.CDC.Tables,
+		TableEntities: c.CDC.TableEntities,
+		TopicPrefix:   c.CDC.TopicPrefix,
+		BatchSize:     c.CDC.BatchSize,
+		PollInterval:  c.CDC.PollInterval,
+	}
+}
+
+// CDCDatabaseURL returns the replication connection URL, defaulting to the application database
+func (c *Config) CDCDatabaseURL() string {
+	if c.CDC.DatabaseURL != "" {
+		return c.CDC.DatabaseURL
+	}
+	return c.buildDatabaseURL()
+}
@@ -8,8 +8,15 @@ package config
 
 import (
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
 
 	"github.com/gjaminon-go-labs/billing-api/internal/di"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/httpclient"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/listener"
 )
 
 // ToDIConfig converts application config to DI container config
@@ -46,8 +53,172 @@ func (c *Config) ToDIConfig() *di.ContainerConfig {
 		LogLevel: c.Logging.Level,
 
 		// Server configuration
-		ServerPort: c.Server.Port,
-		ServerHost: c.Server.Host,
+		ServerPort:            c.Server.Port,
+		ServerHost:            c.Server.Host,
+		TrustedProxies:        c.Server.TrustedProxies,
+		SeparateAdminListener: c.Server.SeparateAdminListener(),
+		Profiling:             c.Server.Profiling,
+		DocsAssetsURL:         c.Server.DocsAssetsURL,
+
+		// Localization configuration
+		DefaultLocale: c.Localization.DefaultLocale,
+		TenantLocales: c.Localization.TenantLocales,
+
+		// Currency configuration
+		DefaultCurrency:  c.Currency.Default,
+		CurrencyRounding: c.Currency.Rounding,
+		TenantCurrencies: c.Currency.tenantCurrencies(),
+		TenantRoundings:  c.Currency.tenantRoundings(),
+
+		// Compliance configuration
+		ComplianceEnabled: c.Compliance.Enabled,
+		ComplianceRules:   c.Compliance.complianceRules(),
+
+		// Duplicate invoice detection configuration
+		DuplicateInvoicesEnabled: c.DuplicateInvoices.Enabled,
+		DuplicateInvoiceWindow:   c.DuplicateInvoices.Window,
+
+		// Late fees configuration
+		LateFeesEnabled: c.LateFees.Enabled,
+		LateFees:        c.LateFees.policyConfig(),
+
+		// Request signing configuration
+		RequestSigningEnabled:     c.RequestSigning.Enabled,
+		RequestSigningRouteGroups: c.RequestSigning.RouteGroups,
+		RequestSigningSecrets:     c.RequestSigning.Secrets,
+		RequestSigningTolerance:   c.RequestSigning.Tolerance,
+		RequestSigningMaxBody:     c.RequestSigning.MaxBodyBytes,
+
+		// Admin configuration
+		AdminTokens:  c.Admin.Tokens,
+		AdminScopes:  c.Admin.Scopes,
+		AdminTenants: c.Admin.Tenants,
+
+		// Captcha configuration
+		CaptchaEnabled:        c.Captcha.Enabled,
+		CaptchaProvider:       c.Captcha.Provider,
+		CaptchaRoutes:         c.Captcha.Routes,
+		CaptchaSecret:         c.Captcha.Secret,
+		CaptchaTrustedAPIKeys: c.Captcha.TrustedAPIKeys,
+
+		// Forms configuration
+		FormTokenTTL: c.Forms.TokenTTL,
+
+		// Portal configuration
+		PortalEnabled:     c.Portal.Enabled,
+		PortalTokenSecret: c.Portal.TokenSecret,
+		PortalTokenTTL:    c.Portal.TokenTTL,
+		PortalClockSkew:   c.Portal.ClockSkew,
+
+		// Magic links configuration
+		MagicLinkSecret:    c.MagicLinks.Secret,
+		MagicLinkTTL:       c.MagicLinks.TTL,
+		MagicLinkBaseURL:   c.MagicLinks.BaseURL,
+		MagicLinkClockSkew: c.MagicLinks.ClockSkew,
+
+		// Contracts configuration
+		ContractRenewalReminderLead: c.Contracts.RenewalReminderLead,
+
+		// Approvals configuration
+		ApprovalThreshold: c.Approvals.Threshold,
+		Approvers:         c.Approvals.Approvers,
+
+		// Invoice delivery configuration
+		InvoiceTrackingSecret: c.InvoiceDelivery.TrackingSecret,
+		BounceFollowUpRules:   c.InvoiceDelivery.BounceRules,
+
+		// Payouts configuration
+		PaymentGatewayURL:    c.Payouts.GatewayURL,
+		PaymentGatewayAPIKey: c.Payouts.GatewayAPIKey,
+		PayoutLookback:       c.Payouts.Lookback,
+
+		// Risk configuration
+		RiskProvider:              c.Risk.Provider,
+		RiskProviderURL:           c.Risk.ProviderURL,
+		RiskProviderAPIKey:        c.Risk.APIKey,
+		RiskScoreTTL:              c.Risk.ScoreTTL,
+		RiskLargeInvoiceThreshold: c.Risk.LargeInvoiceThreshold,
+
+		// Sandbox configuration
+		SandboxEnabled:       c.Sandbox.Enabled,
+		SandboxTenants:       c.Sandbox.Tenants,
+		SandboxAPIKeys:       c.Sandbox.APIKeys,
+		SandboxGatewayURL:    c.Sandbox.GatewayURL,
+		SandboxGatewayAPIKey: c.Sandbox.GatewayAPIKey,
+		SandboxCatchAllEmail: c.Sandbox.CatchAllEmail,
+		SandboxSeedClients:   c.Sandbox.SeedClients,
+
+		// Webhook configuration
+		WebhookSchemes:          c.Webhooks.Schemes,
+		WebhookSecrets:          c.Webhooks.Secrets,
+		WebhookSignatureHeaders: c.Webhooks.SignatureHeaders,
+		WebhookTimestampHeaders: c.Webhooks.TimestampHeaders,
+		WebhookMaxAttempts:      c.Webhooks.MaxAttempts,
+		WebhookReplayCooldown:   c.Webhooks.ReplayCooldown,
+		WebhookReplayTimeout:    c.Webhooks.ReplayTimeout,
+
+		// Idempotent consumer configuration
+		ProcessedMessageTTL: c.Idempotency.ProcessedMessageTTL,
+
+		// Request tracing configuration
+		RequestIDHeader:            c.Tracing.RequestIDHeader,
+		IgnoreIncomingTraceHeaders: c.Tracing.IgnoreIncoming,
+
+		// Saga orchestrator configuration
+		SagaMaxAttempts: c.Sagas.MaxAttempts,
+		SagaStuckAfter:  c.Sagas.StuckAfter,
+
+		// Outbound HTTP client configuration
+		OutboundHTTP:             c.OutboundHTTP.OutboundClientConfig.toHTTPClientConfig(),
+		OutboundHTTPDestinations: c.OutboundHTTP.destinationConfigs(),
+
+		// Integration call log configuration
+		IntegrationLogsEnabled:     c.IntegrationLogs.Enabled,
+		IntegrationLogRetention:    c.IntegrationLogs.Retention,
+		IntegrationLogMaxBodyBytes: c.IntegrationLogs.MaxBodyBytes,
+
+		// Event store configuration
+		EventStoreEnabled: c.EventStore.Enabled,
+
+		// Partition maintenance configuration
+		PartitionMonthsAhead: c.Partitions.MonthsAhead,
+
+		// Tax configuration
+		TaxRates: c.Tax.Rates,
+
+		// Invoice archive configuration
+		InvoiceArchiveAfterYears: c.InvoiceArchive.AfterYears,
+		InvoiceRehydrationHold:   c.InvoiceArchive.RehydrationHold,
+
+		// Client deletion configuration
+		ClientDeletionDraftInvoices:  c.ClientDeletion.DraftInvoices,
+		ClientDeletionClosedInvoices: c.ClientDeletion.ClosedInvoices,
+		ClientDeletionIdempotent:     c.ClientDeletion.Idempotent,
+
+		// Email normalization configuration
+		EmailFoldSubaddress: c.EmailNormalization.FoldSubaddress,
+		EmailFoldDotDomains: c.EmailNormalization.FoldDotDomains,
+
+		// Email outbox configuration
+		EmailOutboxMaxAttempts:  c.EmailOutbox.MaxAttempts,
+		EmailOutboxRetryBackoff: c.EmailOutbox.RetryBackoff,
+		EmailOutboxMaxBackoff:   c.EmailOutbox.MaxBackoff,
+		EmailSoftBounceLimit:    c.EmailOutbox.SoftBounceLimit,
+
+		// Invoice numbering configuration
+		InvoiceNumberFormat: c.InvoiceNumbering.Format,
+
+		// Subscriptions configuration
+		SubscriptionProration: c.Subscriptions.Proration,
+
+		// Response warnings configuration
+		CreditWarningThreshold: c.ResponseWarnings.CreditLimitThreshold,
+		DeprecatedParameters:   c.ResponseWarnings.DeprecatedParameters,
+
+		// Demo configuration
+		DemoSeedEnabled: c.Demo.Seed,
+		DemoClients:     c.Demo.Clients,
+		DemoRandomSeed:  c.Demo.RandomSeed,
 
 		// Environment detection
 		Environment: detectEnvironment(c),
@@ -95,8 +266,136 @@ func (c *Config) buildMigrationDatabaseURL() string {
 	return url
 }
 
+// ListenerConfig converts the server config to the listener configuration of the API socket
+func (c ServerConfig) ListenerConfig() listener.Config {
+	mode, _ := c.socketMode() // Checked by validateConfig
+	return listener.Config{
+		Address:          fmt.Sprintf("%s:%d", c.Host, c.Port),
+		UnixSocket:       c.UnixSocket,
+		SocketMode:       mode,
+		SocketActivation: c.SocketActivation,
+	}
+}
+
+// SeparateAdminListener checks if the operational routes are served on their own listener
+func (c ServerConfig) SeparateAdminListener() bool {
+	return c.AdminPort != 0 || c.AdminUnixSocket != ""
+}
+
+// AdminListenerConfig converts the server config to the listener configuration of the admin socket
+func (c ServerConfig) AdminListenerConfig() listener.Config {
+	host := c.AdminHost
+	if host == "" {
+		host = c.Host
+	}
+	mode, _ := c.socketMode() // Checked by validateConfig
+	return listener.Config{
+		Address:    fmt.Sprintf("%s:%d", host, c.AdminPort),
+		UnixSocket: c.AdminUnixSocket,
+		SocketMode: mode,
+	}
+}
+
+// socketMode parses the octal permissions of the Unix socket (0 when not configured)
+func (c ServerConfig) socketMode() (os.FileMode, error) {
+	if c.UnixSocketMode == "" {
+		return 0, nil
+	}
+	mode, err := strconv.ParseUint(c.UnixSocketMode, 8, 32)
+	if err != nil || mode > 0o777 {
+		return 0, fmt.Errorf("invalid unix socket mode: %q", c.UnixSocketMode)
+	}
+	return os.FileMode(mode), nil
+}
+
+// toHTTPClientConfig converts an outbound client config to the httpclient configuration
+func (c OutboundClientConfig) toHTTPClientConfig() httpclient.Config {
+	return httpclient.Config{
+		Timeout:             c.Timeout,
+		MaxAttempts:         c.MaxAttempts,
+		RetryBackoff:        c.RetryBackoff,
+		RateLimit:           c.RateLimit,
+		Burst:               c.Burst,
+		BreakerThreshold:    c.BreakerThreshold,
+		BreakerCooldown:     c.BreakerCooldown,
+		MaxIdleConnsPerHost: c.MaxIdleConnsPerHost,
+	}
+}
+
+// destinationConfigs converts the per-destination overrides to httpclient configurations
+func (c OutboundHTTPConfig) destinationConfigs() map[string]httpclient.Config {
+	configs := make(map[string]httpclient.Config, len(c.Destinations))
+	for destination, override := range c.Destinations {
+		configs[destination] = override.toHTTPClientConfig()
+	}
+	return configs
+}
+
+// tenantCurrencies returns the tenants overriding the default currency
+func (c CurrencyConfig) tenantCurrencies() map[string]string {
+	currencies := make(map[string]string)
+	for tenantID, tenant := range c.Tenants {
+		if tenant.Currency != "" {
+			currencies[tenantID] = tenant.Currency
+		}
+	}
+	return currencies
+}
+
+// tenantRoundings returns the tenants overriding the default rounding
+func (c CurrencyConfig) tenantRoundings() map[string]string {
+	roundings := make(map[string]string)
+	for tenantID, tenant := range c.Tenants {
+		if tenant.Rounding != "" {
+			roundings[tenantID] = tenant.Rounding
+		}
+	}
+	return roundings
+}
+
+// complianceRules converts the configured compliance rules to domain rules
+func (c ComplianceConfig) complianceRules() []service.ComplianceRule {
+	rules := make([]service.ComplianceRule, len(c.Rules))
+	for i, rule := range c.Rules {
+		rules[i] = rule.toComplianceRule()
+	}
+	return rules
+}
+
+// toComplianceRule converts a configured compliance rule to a domain rule
+func (c ComplianceRuleConfig) toComplianceRule() service.ComplianceRule {
+	return service.ComplianceRule{
+		Name:               c.Name,
+		SellerCountry:      strings.ToUpper(c.SellerCountry),
+		BuyerCountry:       strings.ToUpper(c.BuyerCountry),
+		CrossBorder:        c.CrossBorder,
+		ZeroRated:          c.ZeroRated,
+		RequireSellerTaxID: c.RequireSellerTaxID,
+		RequireBuyerTaxID:  c.RequireBuyerTaxID,
+		TaxBreakdown:       service.TaxBreakdownStyle(c.TaxBreakdown),
+		Mentions:           c.Mentions,
+	}
+}
+
+// policyConfig converts the configured late fees to a domain late fee policy configuration
+func (c LateFeesConfig) policyConfig() valueobject.LateFeePolicyConfig {
+	return valueobject.LateFeePolicyConfig{
+		GraceDays:          c.GraceDays,
+		FlatFee:            c.FlatFee,
+		PercentageBps:      c.PercentageBps,
+		AnnualInterestBps:  c.AnnualInterestBps,
+		MaxFee:             c.MaxFee,
+		InterestAfterGrace: c.InterestAfterGrace,
+	}
+}
+
 // detectEnvironment determines the environment from configuration
 func detectEnvironment(c *Config) string {
+	// The demo profile runs without a database
+	if c.Demo.Seed && c.Storage.Type == "memory" {
+		return "demo"
+	}
+
 	// Try to detect from new shared database name patterns
 	if c.Database.DBName == "go-labs-dev" {
 		return "development"
@@ -8,28 +8,65 @@ package config
 
 import (
 	"fmt"
+	"net/netip"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
 	"gopkg.in/yaml.v3"
 )
 
 // Config represents the complete application configuration
 type Config struct {
-	Storage           StorageConfig   `yaml:"storage"`
-	Migration         MigrationConfig `yaml:"migration"`
-	Server            ServerConfig    `yaml:"server"`
-	Database          DatabaseConfig  `yaml:"database"`
-	MigrationDatabase DatabaseConfig  `yaml:"migration_database"`
-	Logging           LoggingConfig   `yaml:"logging"`
-	API               APIConfig       `yaml:"api"`
-	RateLimit         RateLimitConfig `yaml:"rate_limit"`
-	Health            HealthConfig    `yaml:"health"`
-	Metrics           MetricsConfig   `yaml:"metrics"`
-	Tracing           TracingConfig   `yaml:"tracing"`
+	Storage            StorageConfig            `yaml:"storage"`
+	Migration          MigrationConfig          `yaml:"migration"`
+	Server             ServerConfig             `yaml:"server"`
+	Database           DatabaseConfig           `yaml:"database"`
+	MigrationDatabase  DatabaseConfig           `yaml:"migration_database"`
+	Logging            LoggingConfig            `yaml:"logging"`
+	API                APIConfig                `yaml:"api"`
+	RateLimit          RateLimitConfig          `yaml:"rate_limit"`
+	Health             HealthConfig             `yaml:"health"`
+	Metrics            MetricsConfig            `yaml:"metrics"`
+	Tracing            TracingConfig            `yaml:"tracing"`
+	Localization       LocalizationConfig       `yaml:"localization"`
+	Currency           CurrencyConfig           `yaml:"currency"`
+	Compliance         ComplianceConfig         `yaml:"compliance"`
+	DuplicateInvoices  DuplicateInvoicesConfig  `yaml:"duplicate_invoices"`
+	LateFees           LateFeesConfig           `yaml:"late_fees"`
+	RequestSigning     RequestSigningConfig     `yaml:"request_signing"`
+	Admin              AdminConfig              `yaml:"admin"`
+	Captcha            CaptchaConfig            `yaml:"captcha"`
+	Forms              FormsConfig              `yaml:"forms"`
+	Portal             PortalConfig             `yaml:"portal"`
+	MagicLinks         MagicLinksConfig         `yaml:"magic_links"`
+	Contracts          ContractsConfig          `yaml:"contracts"`
+	Approvals          ApprovalsConfig          `yaml:"approvals"`
+	InvoiceDelivery    InvoiceDeliveryConfig    `yaml:"invoice_delivery"`
+	Payouts            PayoutsConfig            `yaml:"payouts"`
+	Risk               RiskConfig               `yaml:"risk"`
+	Sandbox            SandboxConfig            `yaml:"sandbox"`
+	Webhooks           WebhooksConfig           `yaml:"webhooks"`
+	Idempotency        IdempotencyConfig        `yaml:"idempotency"`
+	Sagas              SagasConfig              `yaml:"sagas"`
+	OutboundHTTP       OutboundHTTPConfig       `yaml:"outbound_http"`
+	IntegrationLogs    IntegrationLogsConfig    `yaml:"integration_logs"`
+	EventStore         EventStoreConfig         `yaml:"event_store"`
+	Partitions         PartitionsConfig         `yaml:"partitions"`
+	Tax                TaxConfig                `yaml:"tax"`
+	InvoiceArchive     InvoiceArchiveConfig     `yaml:"invoice_archive"`
+	ClientDeletion     ClientDeletionConfig     `yaml:"client_deletion"`
+	EmailNormalization EmailNormalizationConfig `yaml:"email_normalization"`
+	EmailOutbox        EmailOutboxConfig        `yaml:"email_outbox"`
+	InvoiceNumbering   InvoiceNumberingConfig   `yaml:"invoice_numbering"`
+	Subscriptions      SubscriptionsConfig      `yaml:"subscriptions"`
+	ResponseWarnings   ResponseWarningsConfig   `yaml:"response_warnings"`
+	CDC                CDCConfig                `yaml:"cdc"`
+	Demo               DemoConfig               `yaml:"demo"`
 }
 
 // StorageConfig defines storage configuration
@@ -53,6 +90,21 @@ type ServerConfig struct {
 	WriteTimeout    time.Duration `yaml:"write_timeout"`
 	IdleTimeout     time.Duration `yaml:"idle_timeout"`
 	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+	TrustedProxies  []string      `yaml:"trusted_proxies"` // Proxy/load balancer addresses or CIDRs whose forwarding headers are honored (prefer TRUSTED_PROXIES)
+
+	// Listening on a Unix socket or a systemd-activated socket replaces the TCP host and port
+	UnixSocket       string `yaml:"unix_socket"`       // Path of the Unix domain socket served to a local proxy
+	UnixSocketMode   string `yaml:"unix_socket_mode"`  // Octal permissions of the Unix socket (default "0660")
+	SocketActivation bool   `yaml:"socket_activation"` // Serve the socket systemd passes to the service
+
+	// Health checks, the admin API and the profiler move to the admin listener when it has a port or socket,
+	// so the public ingress never exposes them
+	AdminPort       int    `yaml:"admin_port"`        // 0 serves them with the public API
+	AdminHost       string `yaml:"admin_host"`        // Defaults to the public host
+	AdminUnixSocket string `yaml:"admin_unix_socket"` // Replaces the admin host and port, same mode as unix_socket
+	Profiling       bool   `yaml:"profiling"`         // Serve the Go profiler at /debug/pprof on the admin listener
+
+	DocsAssetsURL string `yaml:"docs_assets_url"` // Base URL of the swagger-ui-dist files of /docs (a pinned CDN release when empty)
 }
 
 // DatabaseConfig defines database connection configuration
@@ -109,10 +161,283 @@ type MetricsConfig struct {
 }
 
 // TracingConfig defines tracing configuration
+// The request ID and trace headers (W3C traceparent, B3) set by the API gateway are honored and propagated to downstream calls
 type TracingConfig struct {
-	Enabled        bool   `yaml:"enabled"`
-	ServiceName    string `yaml:"service_name"`
-	JaegerEndpoint string `yaml:"jaeger_endpoint"`
+	Enabled         bool   `yaml:"enabled"`
+	ServiceName     string `yaml:"service_name"`
+	JaegerEndpoint  string `yaml:"jaeger_endpoint"`
+	RequestIDHeader string `yaml:"request_id_header"` // Request ID header read and echoed (default X-Request-ID)
+	IgnoreIncoming  bool   `yaml:"ignore_incoming"`   // Generate request IDs and drop trace headers (no trusted gateway in front)
+}
+
+// LocalizationConfig defines locale resolution defaults
+type LocalizationConfig struct {
+	DefaultLocale string            `yaml:"default_locale"` // Used when Accept-Language is absent (e.g. "en")
+	TenantLocales map[string]string `yaml:"tenant_locales"` // Tenant ID -> default locale (e.g. "fr-FR")
+}
+
+// CurrencyConfig defines the default invoice currency and rounding of tenants
+type CurrencyConfig struct {
+	Default  string                          `yaml:"default"`  // ISO 4217 code used when a template omits its currency (e.g. "EUR")
+	Rounding string                          `yaml:"rounding"` // half_up, half_even, down, up
+	Tenants  map[string]TenantCurrencyConfig `yaml:"tenants"`  // Tenant ID -> overrides (empty values inherit the defaults)
+}
+
+// TenantCurrencyConfig defines the invoice currency and rounding of a tenant
+type TenantCurrencyConfig struct {
+	Currency string `yaml:"currency"`
+	Rounding string `yaml:"rounding"`
+}
+
+// ComplianceConfig defines the country rules invoices are checked against at issuance
+type ComplianceConfig struct {
+	Enabled bool                   `yaml:"enabled"` // Check invoices against the built-in EU rules and the configured ones
+	Rules   []ComplianceRuleConfig `yaml:"rules"`   // Additional rules, evaluated after the built-in ones
+}
+
+// ComplianceRuleConfig defines a legal requirement on the invoices a seller country issues to a buyer country
+type ComplianceRuleConfig struct {
+	Name               string   `yaml:"name"`
+	SellerCountry      string   `yaml:"seller_country"` // Country code, EU, NON_EU or empty for any
+	BuyerCountry       string   `yaml:"buyer_country"`  // Country code, EU, NON_EU or empty for any
+	CrossBorder        bool     `yaml:"cross_border"`   // Only when the buyer is in another country than the seller
+	ZeroRated          bool     `yaml:"zero_rated"`     // Only when no line item is taxed
+	RequireSellerTaxID bool     `yaml:"require_seller_tax_id"`
+	RequireBuyerTaxID  bool     `yaml:"require_buyer_tax_id"`
+	TaxBreakdown       string   `yaml:"tax_breakdown"` // total, per_rate, per_line
+	Mentions           []string `yaml:"mentions"`      // Legal mentions printed on the invoice document
+}
+
+// DuplicateInvoicesConfig defines the detection of invoices created twice for the same client
+type DuplicateInvoicesConfig struct {
+	Enabled bool          `yaml:"enabled"` // Reject invoices with the external reference of another invoice of the client
+	Window  time.Duration `yaml:"window"`  // Also reject invoices with the line items of one created within the window (0: not compared)
+}
+
+// LateFeesConfig defines the fees payment reminders announce on overdue balances; components add up
+type LateFeesConfig struct {
+	Enabled            bool  `yaml:"enabled"`
+	GraceDays          int   `yaml:"grace_days"`           // Days after the due date before any fee applies
+	FlatFee            int64 `yaml:"flat_fee"`             // Minor units
+	PercentageBps      int64 `yaml:"percentage_bps"`       // Of the overdue balance (150 = 1.5%)
+	AnnualInterestBps  int64 `yaml:"annual_interest_bps"`  // Accrued daily
+	MaxFee             int64 `yaml:"max_fee"`              // Minor units (0: uncapped)
+	InterestAfterGrace bool  `yaml:"interest_after_grace"` // Accrue interest from the end of the grace period
+}
+
+// RequestSigningConfig defines HMAC request signature verification
+type RequestSigningConfig struct {
+	Enabled      bool              `yaml:"enabled"`
+	RouteGroups  []string          `yaml:"route_groups"`   // Path prefixes requiring a signature
+	Tolerance    time.Duration     `yaml:"tolerance"`      // Accepted timestamp skew/age
+	MaxBodyBytes int64             `yaml:"max_body_bytes"` // Largest body read to verify a signature
+	Secrets      map[string]string `yaml:"secrets"`        // API key ID -> shared secret (prefer REQUEST_SIGNING_SECRETS)
+}
+
+// AdminConfig defines access to administrative endpoints
+type AdminConfig struct {
+	Tokens  map[string]string   `yaml:"tokens"`  // Actor name -> bearer token (prefer ADMIN_TOKENS)
+	Scopes  map[string][]string `yaml:"scopes"`  // Actor name -> admin scopes (unlisted actors hold every scope)
+	Tenants map[string][]string `yaml:"tenants"` // Actor name -> owned tenants (unlisted actors reach every tenant)
+}
+
+// CaptchaConfig defines anti-automation challenge verification on public routes
+type CaptchaConfig struct {
+	Enabled        bool              `yaml:"enabled"`
+	Provider       string            `yaml:"provider"`         // hcaptcha, turnstile
+	Routes         []string          `yaml:"routes"`           // Path prefixes challenged on state-changing requests
+	Secret         string            `yaml:"secret"`           // Provider secret (prefer CAPTCHA_SECRET)
+	TrustedAPIKeys map[string]string `yaml:"trusted_api_keys"` // Integration name -> API key bypassing the challenge (prefer CAPTCHA_TRUSTED_API_KEYS)
+}
+
+// FormsConfig defines one-time form token settings for browser clients
+type FormsConfig struct {
+	TokenTTL time.Duration `yaml:"token_ttl"` // How long an issued form token can be redeemed
+}
+
+// PortalConfig defines the client self-service portal (/portal/v1)
+type PortalConfig struct {
+	Enabled     bool          `yaml:"enabled"`
+	TokenSecret string        `yaml:"token_secret"` // HMAC key signing portal access tokens (prefer PORTAL_TOKEN_SECRET)
+	TokenTTL    time.Duration `yaml:"token_ttl"`    // How long a portal access token stays valid
+	ClockSkew   time.Duration `yaml:"clock_skew"`   // How long past its expiry a token is still accepted (clock drift between instances)
+}
+
+// MagicLinksConfig defines signed single-use links (portal sign-in)
+type MagicLinksConfig struct {
+	Secret    string        `yaml:"secret"`     // HMAC key signing links (prefer MAGIC_LINK_SECRET); links are disabled without it
+	TTL       time.Duration `yaml:"ttl"`        // How long an issued link can be followed
+	BaseURL   string        `yaml:"base_url"`   // Origin of the web app pages links point to
+	ClockSkew time.Duration `yaml:"clock_skew"` // How long past its expiry a link is still accepted (clock drift between instances)
+}
+
+// ContractsConfig defines customer contract tracking
+type ContractsConfig struct {
+	RenewalReminderLead time.Duration `yaml:"renewal_reminder_lead"` // How long before the renewal date account managers are reminded
+}
+
+// ApprovalsConfig defines the two-step approval of high-value credit notes and refunds
+type ApprovalsConfig struct {
+	Threshold int64    `yaml:"threshold"` // Amounts above this (minor units) need a second user's approval
+	Approvers []string `yaml:"approvers"` // Admin actors holding the approver role
+}
+
+// InvoiceDeliveryConfig defines invoice delivery tracking (view pixel and bounce follow-ups)
+type InvoiceDeliveryConfig struct {
+	TrackingSecret string              `yaml:"tracking_secret"` // HMAC key signing view tracking tokens (prefer INVOICE_TRACKING_SECRET); views are not tracked without it
+	BounceRules    map[string][]string `yaml:"bounce_rules"`    // Follow-up actions published per bounce type (hard, soft)
+}
+
+// PayoutsConfig defines the reconciliation of payment gateway payouts with the ledger
+type PayoutsConfig struct {
+	GatewayURL    string        `yaml:"gateway_url"`     // Base URL of the gateway reporting API; reconciliation is disabled without it
+	GatewayAPIKey string        `yaml:"gateway_api_key"` // Reporting API key (prefer PAYMENT_GATEWAY_API_KEY)
+	Lookback      time.Duration `yaml:"lookback"`        // How far back each run pulls payouts
+}
+
+// RiskConfig defines client risk scoring with an external provider (e.g. a credit bureau)
+type RiskConfig struct {
+	Provider              string        `yaml:"provider"`                // Name recorded on assessments
+	ProviderURL           string        `yaml:"provider_url"`            // Base URL of the provider scoring API; scoring is disabled without it
+	APIKey                string        `yaml:"api_key"`                 // Provider API key (prefer RISK_PROVIDER_API_KEY)
+	ScoreTTL              time.Duration `yaml:"score_ttl"`               // How long a score is reused before the provider is consulted again
+	LargeInvoiceThreshold int64         `yaml:"large_invoice_threshold"` // Invoices from this total (minor units) are scored; 0 scores onboarding only
+}
+
+// SandboxConfig defines the sandbox environment integrators test against without touching live data
+type SandboxConfig struct {
+	Enabled       bool              `yaml:"enabled"`
+	Tenants       []string          `yaml:"tenants"`         // Tenant IDs whose requests are served by the sandbox
+	APIKeys       map[string]string `yaml:"api_keys"`        // Integration name -> API key served by the sandbox (prefer SANDBOX_API_KEYS)
+	GatewayURL    string            `yaml:"gateway_url"`     // Payment gateway test mode reporting API; sandbox payouts are disabled without it
+	GatewayAPIKey string            `yaml:"gateway_api_key"` // Test mode API key (prefer SANDBOX_PAYMENT_GATEWAY_API_KEY)
+	CatchAllEmail string            `yaml:"catch_all_email"` // Every sandbox email is delivered here instead of its recipients
+	SeedClients   int               `yaml:"seed_clients"`    // Synthetic clients seeded into the sandbox on startup and after each wipe
+}
+
+// WebhooksConfig defines the inbound webhook receivers (payment gateway, bank, e-invoicing network)
+type WebhooksConfig struct {
+	Schemes          map[string]string `yaml:"schemes"`           // Provider -> signature scheme (stripe, hmac); deliveries from unlisted providers are rejected
+	Secrets          map[string]string `yaml:"secrets"`           // Provider -> signing secret (prefer WEBHOOK_SECRETS)
+	SignatureHeaders map[string]string `yaml:"signature_headers"` // Provider -> signature header of hmac providers (default X-Webhook-Signature)
+	TimestampHeaders map[string]string `yaml:"timestamp_headers"` // Provider -> signed timestamp header of hmac providers; stale deliveries are rejected
+	MaxAttempts      int               `yaml:"max_attempts"`      // Failed processing attempts before an event is dead-lettered
+	ReplayCooldown   time.Duration     `yaml:"replay_cooldown"`   // Minimum time between two event replays to a consumer subscription
+	ReplayTimeout    time.Duration     `yaml:"replay_timeout"`    // Time a replay request delivers events for; shorter than the server write timeout
+}
+
+// IdempotencyConfig defines exactly-once processing of redelivered messages (event consumers, webhook processors)
+type IdempotencyConfig struct {
+	ProcessedMessageTTL time.Duration `yaml:"processed_message_ttl"` // How long a processed message is remembered; redeliveries within it are skipped
+}
+
+// SagasConfig defines the saga orchestrator of multi-step flows (e.g. invoice card payments)
+type SagasConfig struct {
+	MaxAttempts int           `yaml:"max_attempts"` // Failed attempts of a step before the saga is compensated
+	StuckAfter  time.Duration `yaml:"stuck_after"`  // Time without progress after which an unfinished saga is reported as stuck
+}
+
+// OutboundClientConfig defines the HTTP client of an outbound destination (payment gateway, credit bureau, captcha provider)
+type OutboundClientConfig struct {
+	Timeout             time.Duration `yaml:"timeout"`                 // Bounds a call, retries included
+	MaxAttempts         int           `yaml:"max_attempts"`            // Attempts of idempotent requests failing with a network error, 429 or 5xx
+	RetryBackoff        time.Duration `yaml:"retry_backoff"`           // Wait before the first retry, doubled on every further retry
+	RateLimit           float64       `yaml:"rate_limit"`              // Requests per second (0: unlimited)
+	Burst               int           `yaml:"burst"`                   // Requests sent at once before the rate limit applies
+	BreakerThreshold    int           `yaml:"breaker_threshold"`       // Consecutive failed calls opening the circuit breaker
+	BreakerCooldown     time.Duration `yaml:"breaker_cooldown"`        // Time an open circuit rejects calls before a trial call
+	MaxIdleConnsPerHost int           `yaml:"max_idle_conns_per_host"` // Pooled idle connections to the destination
+}
+
+// OutboundHTTPConfig defines the shared defaults of outbound HTTP clients and their per-destination overrides
+type OutboundHTTPConfig struct {
+	OutboundClientConfig `yaml:",inline"`
+	Destinations         map[string]OutboundClientConfig `yaml:"destinations"` // Destination name -> overrides (zero values inherit the defaults)
+}
+
+// IntegrationLogsConfig defines the logging of outbound integration calls for support investigations
+type IntegrationLogsConfig struct {
+	Enabled      bool          `yaml:"enabled"`        // Record every outbound call with its redacted bodies
+	Retention    time.Duration `yaml:"retention"`      // How long calls are kept before the cleanup deletes them
+	MaxBodyBytes int           `yaml:"max_body_bytes"` // Request and response bodies are truncated to this size
+}
+
+// EventStoreConfig defines the append-only log of published domain events
+type EventStoreConfig struct {
+	Enabled bool `yaml:"enabled"` // Append every integration event to the event store before it is published
+}
+
+// PartitionsConfig defines the maintenance of the monthly partitions of usage records and the audit log
+type PartitionsConfig struct {
+	MonthsAhead int `yaml:"months_ahead"` // Months of partitions created ahead of the current one
+}
+
+// InvoiceArchiveConfig defines when closed invoices move from the hot tables to the invoice archive
+type InvoiceArchiveConfig struct {
+	AfterYears      int           `yaml:"after_years"`      // Years after their issue date paid and void invoices are archived
+	RehydrationHold time.Duration `yaml:"rehydration_hold"` // How long a rehydrated invoice stays hot before it is archived again
+}
+
+// ClientDeletionConfig defines what happens to the invoices of a client when it is deleted
+// Issued invoices always block the deletion
+type ClientDeletionConfig struct {
+	DraftInvoices  string `yaml:"draft_invoices"`  // cascade (void them with the client) or block
+	ClosedInvoices string `yaml:"closed_invoices"` // block (orphan protection) or retain (keep them on record)
+	Idempotent     bool   `yaml:"idempotent"`      // Deleting an already deleted client succeeds (204) instead of 404 by default
+}
+
+// EmailNormalizationConfig defines which spellings of an address count as one client in email uniqueness checks
+// Addresses are always compared lower-cased; clients keep the address as entered
+type EmailNormalizationConfig struct {
+	FoldSubaddress bool     `yaml:"fold_subaddress"`  // "alice+billing@example.com" is "alice@example.com"
+	FoldDotDomains []string `yaml:"fold_dot_domains"` // Domains ignoring dots in the local part, e.g. gmail.com
+}
+
+// EmailOutboxConfig defines the dispatch of notification emails from the outbox and the suppression of bouncing addresses
+type EmailOutboxConfig struct {
+	MaxAttempts     int           `yaml:"max_attempts"`      // Dispatch attempts before an email fails for good
+	RetryBackoff    time.Duration `yaml:"retry_backoff"`     // Wait before the first retry, doubled on every further retry
+	MaxBackoff      time.Duration `yaml:"max_backoff"`       // Upper bound of the wait between retries
+	SoftBounceLimit int           `yaml:"soft_bounce_limit"` // Consecutive soft bounces suppressing an address (hard bounces suppress at once)
+}
+
+// InvoiceNumberingConfig defines how invoices are numbered when they are issued
+type InvoiceNumberingConfig struct {
+	Format string `yaml:"format"` // e.g. "INV-{YYYY}-{00000}": {YYYY}/{YY} year, {00000} zero-padded sequence of the year
+}
+
+// SubscriptionsConfig defines how subscriptions are billed
+type SubscriptionsConfig struct {
+	Proration string `yaml:"proration"` // Plan changes during a billed period: day_based, credit_only or none
+}
+
+// ResponseWarningsConfig defines the soft limits reported in the warnings of successful responses
+type ResponseWarningsConfig struct {
+	CreditLimitThreshold float64           `yaml:"credit_limit_threshold"` // Share (0-1) of the credit limit used before invoice changes warn (0: disabled)
+	DeprecatedParameters map[string]string `yaml:"deprecated_parameters"`  // Deprecated query parameter -> advice returned when it is used
+}
+
+// TaxConfig defines the tax rates invoice line items without an explicit rate are taxed at
+type TaxConfig struct {
+	Rates map[string]int64 `yaml:"rates"` // Jurisdiction code (e.g. "FR", "US-CA") -> rate in basis points (2000 = 20%)
+}
+
+// DemoConfig defines sample data seeding for the demo profile (in-memory storage only)
+type DemoConfig struct {
+	Seed       bool  `yaml:"seed"`        // Pre-populate storage with factory-generated sample data on startup
+	Clients    int   `yaml:"clients"`     // Number of sample clients
+	RandomSeed int64 `yaml:"random_seed"` // Makes the sample data identical across restarts
+}
+
+// CDCConfig defines the change data capture relay (cmd/cdc)
+type CDCConfig struct {
+	DatabaseURL   string            `yaml:"database_url"`   // Connection with REPLICATION privilege (prefer CDC_DATABASE_URL); defaults to the application database
+	SlotName      string            `yaml:"slot_name"`      // Logical replication slot (wal2json)
+	Tables        []string          `yaml:"tables"`         // Captured tables as "schema.table"
+	TableEntities map[string]string `yaml:"table_entities"` // Table name -> entity name used in topics
+	TopicPrefix   string            `yaml:"topic_prefix"`   // Topic prefix ("<prefix>.<entity>")
+	BatchSize     int               `yaml:"batch_size"`     // Changes read per poll
+	PollInterval  time.Duration     `yaml:"poll_interval"`  // Wait between polls when drained
 }
 
 // LoadConfig loads configuration from YAML files with environment overrides
@@ -205,6 +530,32 @@ func applyEnvironmentVariables(config *Config) {
 	if host := os.Getenv("SERVER_HOST"); host != "" {
 		config.Server.Host = host
 	}
+	if proxies := os.Getenv("TRUSTED_PROXIES"); proxies != "" {
+		config.Server.TrustedProxies = strings.Split(proxies, ",")
+	}
+	if socket := os.Getenv("SERVER_UNIX_SOCKET"); socket != "" {
+		config.Server.UnixSocket = socket
+	}
+	if mode := os.Getenv("SERVER_UNIX_SOCKET_MODE"); mode != "" {
+		config.Server.UnixSocketMode = mode
+	}
+	if activation := os.Getenv("SERVER_SOCKET_ACTIVATION"); activation != "" {
+		config.Server.SocketActivation = activation == "true"
+	}
+	if port := os.Getenv("SERVER_ADMIN_PORT"); port != "" {
+		if p, err := strconv.Atoi(port); err == nil {
+			config.Server.AdminPort = p
+		}
+	}
+	if host := os.Getenv("SERVER_ADMIN_HOST"); host != "" {
+		config.Server.AdminHost = host
+	}
+	if socket := os.Getenv("SERVER_ADMIN_UNIX_SOCKET"); socket != "" {
+		config.Server.AdminUnixSocket = socket
+	}
+	if profiling := os.Getenv("SERVER_PROFILING"); profiling != "" {
+		config.Server.Profiling = profiling == "true"
+	}
 
 	// Database configuration (Kubernetes secrets)
 	if dbHost := os.Getenv("DB_HOST"); dbHost != "" {
@@ -249,6 +600,11 @@ func applyEnvironmentVariables(config *Config) {
 		config.Storage.Type = storageType
 	}
 
+	// Demo seeding
+	if demoSeed := os.Getenv("DEMO_SEED"); demoSeed != "" {
+		config.Demo.Seed = demoSeed == "true"
+	}
+
 	// Migration configuration
 	if autoMigrate := os.Getenv("AUTO_MIGRATE"); autoMigrate != "" {
 		config.Migration.AutoMigrate = autoMigrate == "true"
@@ -258,6 +614,85 @@ func applyEnvironmentVariables(config *Config) {
 	if logLevel := os.Getenv("LOG_LEVEL"); logLevel != "" {
 		config.Logging.Level = logLevel
 	}
+
+	// Localization configuration
+	if defaultLocale := os.Getenv("DEFAULT_LOCALE"); defaultLocale != "" {
+		config.Localization.DefaultLocale = defaultLocale
+	}
+
+	// Request signing secrets (Kubernetes secrets) formatted as "keyID:secret,keyID:secret"
+	if secrets := os.Getenv("REQUEST_SIGNING_SECRETS"); secrets != "" {
+		config.RequestSigning.Secrets = parseKeyValueList(secrets)
+	}
+
+	// Admin tokens (Kubernetes secrets) formatted as "actor:token,actor:token"
+	if tokens := os.Getenv("ADMIN_TOKENS"); tokens != "" {
+		config.Admin.Tokens = parseKeyValueList(tokens)
+	}
+
+	// Captcha secrets (Kubernetes secrets)
+	if secret := os.Getenv("CAPTCHA_SECRET"); secret != "" {
+		config.Captcha.Secret = secret
+	}
+	if keys := os.Getenv("CAPTCHA_TRUSTED_API_KEYS"); keys != "" {
+		config.Captcha.TrustedAPIKeys = parseKeyValueList(keys)
+	}
+
+	// Portal token signing key (Kubernetes secrets)
+	if secret := os.Getenv("PORTAL_TOKEN_SECRET"); secret != "" {
+		config.Portal.TokenSecret = secret
+	}
+
+	// Invoice view tracking signing key (Kubernetes secrets)
+	if secret := os.Getenv("INVOICE_TRACKING_SECRET"); secret != "" {
+		config.InvoiceDelivery.TrackingSecret = secret
+	}
+
+	// Payment gateway reporting API key (Kubernetes secrets)
+	if key := os.Getenv("PAYMENT_GATEWAY_API_KEY"); key != "" {
+		config.Payouts.GatewayAPIKey = key
+	}
+
+	// Risk provider API key (Kubernetes secrets)
+	if key := os.Getenv("RISK_PROVIDER_API_KEY"); key != "" {
+		config.Risk.APIKey = key
+	}
+
+	// Sandbox API keys and payment gateway test mode key (Kubernetes secrets)
+	if keys := os.Getenv("SANDBOX_API_KEYS"); keys != "" {
+		config.Sandbox.APIKeys = parseKeyValueList(keys)
+	}
+	if key := os.Getenv("SANDBOX_PAYMENT_GATEWAY_API_KEY"); key != "" {
+		config.Sandbox.GatewayAPIKey = key
+	}
+
+	// Webhook signing secrets (Kubernetes secrets)
+	if secrets := os.Getenv("WEBHOOK_SECRETS"); secrets != "" {
+		config.Webhooks.Secrets = parseKeyValueList(secrets)
+	}
+
+	// Magic link signing key (Kubernetes secrets)
+	if secret := os.Getenv("MAGIC_LINK_SECRET"); secret != "" {
+		config.MagicLinks.Secret = secret
+	}
+
+	// CDC replication connection (Kubernetes secrets)
+	if cdcURL := os.Getenv("CDC_DATABASE_URL"); cdcURL != "" {
+		config.CDC.DatabaseURL = cdcURL
+	}
+}
+
+// parseKeyValueList parses a "key:value,key:value" list, skipping malformed entries
+func parseKeyValueList(raw string) map[string]string {
+	result := make(map[string]string)
+	for _, entry := range strings.Split(raw, ",") {
+		key, value, found := strings.Cut(strings.TrimSpace(entry), ":")
+		if !found || key == "" || value == "" {
+			continue
+		}
+		result[key] = value
+	}
+	return result
 }
 
 // mergeConfigs merges source configuration into target configuration
@@ -288,6 +723,29 @@ func mergeConfigs(target, source *Config) {
 	if source.Server.Host != "" {
 		target.Server.Host = source.Server.Host
 	}
+	if len(source.Server.TrustedProxies) > 0 {
+		target.Server.TrustedProxies = source.Server.TrustedProxies
+	}
+	if source.Server.UnixSocket != "" {
+		target.Server.UnixSocket = source.Server.UnixSocket
+	}
+	if source.Server.UnixSocketMode != "" {
+		target.Server.UnixSocketMode = source.Server.UnixSocketMode
+	}
+	target.Server.SocketActivation = source.Server.SocketActivation || target.Server.SocketActivation
+	if source.Server.AdminPort != 0 {
+		target.Server.AdminPort = source.Server.AdminPort
+	}
+	if source.Server.AdminHost != "" {
+		target.Server.AdminHost = source.Server.AdminHost
+	}
+	if source.Server.AdminUnixSocket != "" {
+		target.Server.AdminUnixSocket = source.Server.AdminUnixSocket
+	}
+	target.Server.Profiling = source.Server.Profiling || target.Server.Profiling
+	if source.Server.DocsAssetsURL != "" {
+		target.Server.DocsAssetsURL = source.Server.DocsAssetsURL
+	}
 
 	// Database config
 	if source.Database.Host != "" {
@@ -339,6 +797,370 @@ func mergeConfigs(target, source *Config) {
 	if source.Logging.Format != "" {
 		target.Logging.Format = source.Logging.Format
 	}
+
+	// Localization config (tenant entries are merged key by key)
+	if source.Localization.DefaultLocale != "" {
+		target.Localization.DefaultLocale = source.Localization.DefaultLocale
+	}
+	for tenantID, locale := range source.Localization.TenantLocales {
+		if target.Localization.TenantLocales == nil {
+			target.Localization.TenantLocales = make(map[string]string)
+		}
+		target.Localization.TenantLocales[tenantID] = locale
+	}
+
+	// Request signing config
+	target.RequestSigning.Enabled = source.RequestSigning.Enabled || target.RequestSigning.Enabled
+	if len(source.RequestSigning.RouteGroups) > 0 {
+		target.RequestSigning.RouteGroups = source.RequestSigning.RouteGroups
+	}
+	if source.RequestSigning.Tolerance != 0 {
+		target.RequestSigning.Tolerance = source.RequestSigning.Tolerance
+	}
+	if source.RequestSigning.MaxBodyBytes != 0 {
+		target.RequestSigning.MaxBodyBytes = source.RequestSigning.MaxBodyBytes
+	}
+	if len(source.RequestSigning.Secrets) > 0 {
+		target.RequestSigning.Secrets = source.RequestSigning.Secrets
+	}
+
+	// Admin config
+	if len(source.Admin.Tokens) > 0 {
+		target.Admin.Tokens = source.Admin.Tokens
+	}
+	if len(source.Admin.Scopes) > 0 {
+		target.Admin.Scopes = source.Admin.Scopes
+	}
+	if len(source.Admin.Tenants) > 0 {
+		target.Admin.Tenants = source.Admin.Tenants
+	}
+
+	// Captcha config
+	target.Captcha.Enabled = source.Captcha.Enabled || target.Captcha.Enabled
+	if source.Captcha.Provider != "" {
+		target.Captcha.Provider = source.Captcha.Provider
+	}
+	if len(source.Captcha.Routes) > 0 {
+		target.Captcha.Routes = source.Captcha.Routes
+	}
+	if source.Captcha.Secret != "" {
+		target.Captcha.Secret = source.Captcha.Secret
+	}
+	if len(source.Captcha.TrustedAPIKeys) > 0 {
+		target.Captcha.TrustedAPIKeys = source.Captcha.TrustedAPIKeys
+	}
+
+	// Forms config
+	if source.Forms.TokenTTL != 0 {
+		target.Forms.TokenTTL = source.Forms.TokenTTL
+	}
+
+	// Portal config
+	target.Portal.Enabled = source.Portal.Enabled || target.Portal.Enabled
+	if source.Portal.TokenSecret != "" {
+		target.Portal.TokenSecret = source.Portal.TokenSecret
+	}
+	if source.Portal.TokenTTL != 0 {
+		target.Portal.TokenTTL = source.Portal.TokenTTL
+	}
+	if source.Portal.ClockSkew != 0 {
+		target.Portal.ClockSkew = source.Portal.ClockSkew
+	}
+
+	// Magic links config
+	if source.MagicLinks.Secret != "" {
+		target.MagicLinks.Secret = source.MagicLinks.Secret
+	}
+	if source.MagicLinks.TTL != 0 {
+		target.MagicLinks.TTL = source.MagicLinks.TTL
+	}
+	if source.MagicLinks.BaseURL != "" {
+		target.MagicLinks.BaseURL = source.MagicLinks.BaseURL
+	}
+	if source.MagicLinks.ClockSkew != 0 {
+		target.MagicLinks.ClockSkew = source.MagicLinks.ClockSkew
+	}
+
+	// Contracts config
+	if source.Contracts.RenewalReminderLead != 0 {
+		target.Contracts.RenewalReminderLead = source.Contracts.RenewalReminderLead
+	}
+
+	// Approvals config
+	if source.Approvals.Threshold != 0 {
+		target.Approvals.Threshold = source.Approvals.Threshold
+	}
+	if len(source.Approvals.Approvers) > 0 {
+		target.Approvals.Approvers = source.Approvals.Approvers
+	}
+
+	// Invoice delivery config
+	if source.InvoiceDelivery.TrackingSecret != "" {
+		target.InvoiceDelivery.TrackingSecret = source.InvoiceDelivery.TrackingSecret
+	}
+	if len(source.InvoiceDelivery.BounceRules) > 0 {
+		target.InvoiceDelivery.BounceRules = source.InvoiceDelivery.BounceRules
+	}
+
+	// Payouts config
+	if source.Payouts.GatewayURL != "" {
+		target.Payouts.GatewayURL = source.Payouts.GatewayURL
+	}
+	if source.Payouts.GatewayAPIKey != "" {
+		target.Payouts.GatewayAPIKey = source.Payouts.GatewayAPIKey
+	}
+	if source.Payouts.Lookback != 0 {
+		target.Payouts.Lookback = source.Payouts.Lookback
+	}
+
+	// Risk config
+	if source.Risk.Provider != "" {
+		target.Risk.Provider = source.Risk.Provider
+	}
+	if source.Risk.ProviderURL != "" {
+		target.Risk.ProviderURL = source.Risk.ProviderURL
+	}
+	if source.Risk.APIKey != "" {
+		target.Risk.APIKey = source.Risk.APIKey
+	}
+	if source.Risk.ScoreTTL != 0 {
+		target.Risk.ScoreTTL = source.Risk.ScoreTTL
+	}
+	if source.Risk.LargeInvoiceThreshold != 0 {
+		target.Risk.LargeInvoiceThreshold = source.Risk.LargeInvoiceThreshold
+	}
+
+	// Sandbox config
+	target.Sandbox.Enabled = source.Sandbox.Enabled || target.Sandbox.Enabled
+	if len(source.Sandbox.Tenants) > 0 {
+		target.Sandbox.Tenants = source.Sandbox.Tenants
+	}
+	if len(source.Sandbox.APIKeys) > 0 {
+		target.Sandbox.APIKeys = source.Sandbox.APIKeys
+	}
+	if source.Sandbox.GatewayURL != "" {
+		target.Sandbox.GatewayURL = source.Sandbox.GatewayURL
+	}
+	if source.Sandbox.GatewayAPIKey != "" {
+		target.Sandbox.GatewayAPIKey = source.Sandbox.GatewayAPIKey
+	}
+	if source.Sandbox.CatchAllEmail != "" {
+		target.Sandbox.CatchAllEmail = source.Sandbox.CatchAllEmail
+	}
+	if source.Sandbox.SeedClients != 0 {
+		target.Sandbox.SeedClients = source.Sandbox.SeedClients
+	}
+
+	// Webhooks config
+	if len(source.Webhooks.Schemes) > 0 {
+		target.Webhooks.Schemes = source.Webhooks.Schemes
+	}
+	if len(source.Webhooks.Secrets) > 0 {
+		target.Webhooks.Secrets = source.Webhooks.Secrets
+	}
+	if len(source.Webhooks.SignatureHeaders) > 0 {
+		target.Webhooks.SignatureHeaders = source.Webhooks.SignatureHeaders
+	}
+	if len(source.Webhooks.TimestampHeaders) > 0 {
+		target.Webhooks.TimestampHeaders = source.Webhooks.TimestampHeaders
+	}
+	if source.Webhooks.MaxAttempts != 0 {
+		target.Webhooks.MaxAttempts = source.Webhooks.MaxAttempts
+	}
+	if source.Webhooks.ReplayCooldown != 0 {
+		target.Webhooks.ReplayCooldown = source.Webhooks.ReplayCooldown
+	}
+	if source.Webhooks.ReplayTimeout != 0 {
+		target.Webhooks.ReplayTimeout = source.Webhooks.ReplayTimeout
+	}
+
+	// Idempotency config
+	if source.Idempotency.ProcessedMessageTTL != 0 {
+		target.Idempotency.ProcessedMessageTTL = source.Idempotency.ProcessedMessageTTL
+	}
+
+	// Sagas config
+	if source.Sagas.MaxAttempts != 0 {
+		target.Sagas.MaxAttempts = source.Sagas.MaxAttempts
+	}
+	if source.Sagas.StuckAfter != 0 {
+		target.Sagas.StuckAfter = source.Sagas.StuckAfter
+	}
+
+	// Outbound HTTP config
+	target.OutboundHTTP.OutboundClientConfig = mergeOutboundClientConfig(target.OutboundHTTP.OutboundClientConfig, source.OutboundHTTP.OutboundClientConfig)
+	for destination, override := range source.OutboundHTTP.Destinations {
+		if target.OutboundHTTP.Destinations == nil {
+			target.OutboundHTTP.Destinations = make(map[string]OutboundClientConfig)
+		}
+		target.OutboundHTTP.Destinations[destination] = mergeOutboundClientConfig(target.OutboundHTTP.Destinations[destination], override)
+	}
+
+	// Currency config (tenant entries are merged key by key)
+	if source.Currency.Default != "" {
+		target.Currency.Default = source.Currency.Default
+	}
+	if source.Currency.Rounding != "" {
+		target.Currency.Rounding = source.Currency.Rounding
+	}
+	for tenantID, tenant := range source.Currency.Tenants {
+		if target.Currency.Tenants == nil {
+			target.Currency.Tenants = make(map[string]TenantCurrencyConfig)
+		}
+		target.Currency.Tenants[tenantID] = tenant
+	}
+
+	// Compliance config (configured rules replace those of the base file)
+	target.Compliance.Enabled = source.Compliance.Enabled || target.Compliance.Enabled
+	if len(source.Compliance.Rules) > 0 {
+		target.Compliance.Rules = source.Compliance.Rules
+	}
+
+	// Duplicate invoices config
+	target.DuplicateInvoices.Enabled = source.DuplicateInvoices.Enabled || target.DuplicateInvoices.Enabled
+	if source.DuplicateInvoices.Window != 0 {
+		target.DuplicateInvoices.Window = source.DuplicateInvoices.Window
+	}
+
+	// Late fees config
+	target.LateFees.Enabled = source.LateFees.Enabled || target.LateFees.Enabled
+	if source.LateFees.GraceDays != 0 {
+		target.LateFees.GraceDays = source.LateFees.GraceDays
+	}
+	if source.LateFees.FlatFee != 0 {
+		target.LateFees.FlatFee = source.LateFees.FlatFee
+	}
+	if source.LateFees.PercentageBps != 0 {
+		target.LateFees.PercentageBps = source.LateFees.PercentageBps
+	}
+	if source.LateFees.AnnualInterestBps != 0 {
+		target.LateFees.AnnualInterestBps = source.LateFees.AnnualInterestBps
+	}
+	if source.LateFees.MaxFee != 0 {
+		target.LateFees.MaxFee = source.LateFees.MaxFee
+	}
+	target.LateFees.InterestAfterGrace = source.LateFees.InterestAfterGrace || target.LateFees.InterestAfterGrace
+
+	// Integration logs config
+	target.IntegrationLogs.Enabled = source.IntegrationLogs.Enabled || target.IntegrationLogs.Enabled
+	if source.IntegrationLogs.Retention != 0 {
+		target.IntegrationLogs.Retention = source.IntegrationLogs.Retention
+	}
+	if source.IntegrationLogs.MaxBodyBytes != 0 {
+		target.IntegrationLogs.MaxBodyBytes = source.IntegrationLogs.MaxBodyBytes
+	}
+
+	// Event store config
+	target.EventStore.Enabled = source.EventStore.Enabled || target.EventStore.Enabled
+
+	// Partitions config
+	if source.Partitions.MonthsAhead != 0 {
+		target.Partitions.MonthsAhead = source.Partitions.MonthsAhead
+	}
+
+	// Tax config
+	for jurisdiction, rate := range source.Tax.Rates {
+		if target.Tax.Rates == nil {
+			target.Tax.Rates = make(map[string]int64)
+		}
+		target.Tax.Rates[jurisdiction] = rate
+	}
+
+	// Invoice archive config
+	if source.InvoiceArchive.AfterYears != 0 {
+		target.InvoiceArchive.AfterYears = source.InvoiceArchive.AfterYears
+	}
+	if source.InvoiceArchive.RehydrationHold != 0 {
+		target.InvoiceArchive.RehydrationHold = source.InvoiceArchive.RehydrationHold
+	}
+
+	// Client deletion config
+	if source.ClientDeletion.DraftInvoices != "" {
+		target.ClientDeletion.DraftInvoices = source.ClientDeletion.DraftInvoices
+	}
+	if source.ClientDeletion.ClosedInvoices != "" {
+		target.ClientDeletion.ClosedInvoices = source.ClientDeletion.ClosedInvoices
+	}
+	target.ClientDeletion.Idempotent = source.ClientDeletion.Idempotent || target.ClientDeletion.Idempotent
+
+	// Email normalization config (configured domains replace those of the base file)
+	target.EmailNormalization.FoldSubaddress = source.EmailNormalization.FoldSubaddress || target.EmailNormalization.FoldSubaddress
+	if len(source.EmailNormalization.FoldDotDomains) > 0 {
+		target.EmailNormalization.FoldDotDomains = source.EmailNormalization.FoldDotDomains
+	}
+
+	// Email outbox config
+	if source.EmailOutbox.MaxAttempts != 0 {
+		target.EmailOutbox.MaxAttempts = source.EmailOutbox.MaxAttempts
+	}
+	if source.EmailOutbox.RetryBackoff != 0 {
+		target.EmailOutbox.RetryBackoff = source.EmailOutbox.RetryBackoff
+	}
+	if source.EmailOutbox.MaxBackoff != 0 {
+		target.EmailOutbox.MaxBackoff = source.EmailOutbox.MaxBackoff
+	}
+	if source.EmailOutbox.SoftBounceLimit != 0 {
+		target.EmailOutbox.SoftBounceLimit = source.EmailOutbox.SoftBounceLimit
+	}
+
+	// Invoice numbering config
+	if source.InvoiceNumbering.Format != "" {
+		target.InvoiceNumbering.Format = source.InvoiceNumbering.Format
+	}
+
+	// Subscriptions config
+	if source.Subscriptions.Proration != "" {
+		target.Subscriptions.Proration = source.Subscriptions.Proration
+	}
+
+	// Response warnings config
+	if source.ResponseWarnings.CreditLimitThreshold != 0 {
+		target.ResponseWarnings.CreditLimitThreshold = source.ResponseWarnings.CreditLimitThreshold
+	}
+	if len(source.ResponseWarnings.DeprecatedParameters) > 0 {
+		target.ResponseWarnings.DeprecatedParameters = source.ResponseWarnings.DeprecatedParameters
+	}
+
+	// Tracing config
+	if source.Tracing.RequestIDHeader != "" {
+		target.Tracing.RequestIDHeader = source.Tracing.RequestIDHeader
+	}
+	target.Tracing.IgnoreIncoming = source.Tracing.IgnoreIncoming || target.Tracing.IgnoreIncoming
+
+	// Demo config
+	target.Demo.Seed = source.Demo.Seed || target.Demo.Seed
+	if source.Demo.Clients != 0 {
+		target.Demo.Clients = source.Demo.Clients
+	}
+	if source.Demo.RandomSeed != 0 {
+		target.Demo.RandomSeed = source.Demo.RandomSeed
+	}
+
+	// CDC config (table entities are merged key by key)
+	if source.CDC.DatabaseURL != "" {
+		target.CDC.DatabaseURL = source.CDC.DatabaseURL
+	}
+	if source.CDC.SlotName != "" {
+		target.CDC.SlotName = source.CDC.SlotName
+	}
+	if len(source.CDC.Tables) > 0 {
+		target.CDC.Tables = source.CDC.Tables
+	}
+	for table, entity := range source.CDC.TableEntities {
+		if target.CDC.TableEntities == nil {
+			target.CDC.TableEntities = make(map[string]string)
+		}
+		target.CDC.TableEntities[table] = entity
+	}
+	if source.CDC.TopicPrefix != "" {
+		target.CDC.TopicPrefix = source.CDC.TopicPrefix
+	}
+	if source.CDC.BatchSize != 0 {
+		target.CDC.BatchSize = source.CDC.BatchSize
+	}
+	if source.CDC.PollInterval != 0 {
+		target.CDC.PollInterval = source.CDC.PollInterval
+	}
 }
 
 // validateConfig validates the loaded configuration
@@ -349,10 +1171,235 @@ func validateConfig(config *Config) error {
 		return fmt.Errorf("invalid storage type: %s (must be one of: %s)", config.Storage.Type, strings.Join(validStorageTypes, ", "))
 	}
 
-	// Server validation
-	if config.Server.Port <= 0 || config.Server.Port > 65535 {
+	// Demo data must never be written into a real database
+	if config.Demo.Seed && config.Storage.Type != "memory" {
+		return fmt.Errorf("demo seeding requires memory storage (got %s)", config.Storage.Type)
+	}
+
+	// Portal tokens cannot be verified without a signing key
+	if config.Portal.Enabled && config.Portal.TokenSecret == "" {
+		return fmt.Errorf("portal requires a token secret (set PORTAL_TOKEN_SECRET)")
+	}
+
+	// Clock skew tolerances extend token lifetimes, they cannot shorten them
+	if config.Portal.ClockSkew < 0 {
+		return fmt.Errorf("invalid portal clock skew: %s (must not be negative)", config.Portal.ClockSkew)
+	}
+	if config.MagicLinks.ClockSkew < 0 {
+		return fmt.Errorf("invalid magic link clock skew: %s (must not be negative)", config.MagicLinks.ClockSkew)
+	}
+
+	// Approval threshold is an amount in minor units
+	if config.Approvals.Threshold < 0 {
+		return fmt.Errorf("invalid approval threshold: %d (must not be negative)", config.Approvals.Threshold)
+	}
+
+	// Bounce follow-up rules are keyed by bounce type
+	for bounceType := range config.InvoiceDelivery.BounceRules {
+		if bounceType != "hard" && bounceType != "soft" {
+			return fmt.Errorf("invalid invoice delivery bounce rule: %s (must be hard or soft)", bounceType)
+		}
+	}
+
+	// Payout reports cannot be pulled without credentials
+	if config.Payouts.GatewayURL != "" && config.Payouts.GatewayAPIKey == "" {
+		return fmt.Errorf("payout reconciliation requires a gateway API key (set PAYMENT_GATEWAY_API_KEY)")
+	}
+	if config.Payouts.Lookback < 0 {
+		return fmt.Errorf("invalid payout lookback: %s (must not be negative)", config.Payouts.Lookback)
+	}
+
+	// Risk scores cannot be requested without credentials
+	if config.Risk.ProviderURL != "" && config.Risk.APIKey == "" {
+		return fmt.Errorf("risk scoring requires a provider API key (set RISK_PROVIDER_API_KEY)")
+	}
+	if config.Risk.ScoreTTL < 0 {
+		return fmt.Errorf("invalid risk score TTL: %s (must not be negative)", config.Risk.ScoreTTL)
+	}
+	if config.Risk.LargeInvoiceThreshold < 0 {
+		return fmt.Errorf("invalid large invoice threshold: %d (must not be negative)", config.Risk.LargeInvoiceThreshold)
+	}
+
+	// Sandbox traffic must never reach the live payment gateway
+	if config.Sandbox.Enabled && config.Sandbox.GatewayURL != "" && config.Sandbox.GatewayURL == config.Payouts.GatewayURL {
+		return fmt.Errorf("sandbox gateway URL must point to the payment gateway test mode, not the live gateway")
+	}
+	if config.Sandbox.Enabled && config.Sandbox.GatewayURL != "" && config.Sandbox.GatewayAPIKey == "" {
+		return fmt.Errorf("sandbox payouts require a test mode gateway API key (set SANDBOX_PAYMENT_GATEWAY_API_KEY)")
+	}
+	if config.Sandbox.SeedClients < 0 {
+		return fmt.Errorf("invalid sandbox seed clients: %d (must not be negative)", config.Sandbox.SeedClients)
+	}
+
+	// Every webhook provider needs a known signature scheme and a secret to verify deliveries with
+	for provider, scheme := range config.Webhooks.Schemes {
+		if scheme != "stripe" && scheme != "hmac" {
+			return fmt.Errorf("invalid webhook signature scheme for %s: %s (must be stripe or hmac)", provider, scheme)
+		}
+		if config.Webhooks.Secrets[provider] == "" {
+			return fmt.Errorf("webhook provider %s requires a signing secret (set WEBHOOK_SECRETS)", provider)
+		}
+	}
+	if config.Webhooks.MaxAttempts < 0 {
+		return fmt.Errorf("invalid webhook max attempts: %d (must not be negative)", config.Webhooks.MaxAttempts)
+	}
+	if config.Webhooks.ReplayCooldown < 0 {
+		return fmt.Errorf("invalid webhook replay cooldown: %s (must not be negative)", config.Webhooks.ReplayCooldown)
+	}
+	if config.Webhooks.ReplayTimeout < 0 {
+		return fmt.Errorf("invalid webhook replay timeout: %s (must not be negative)", config.Webhooks.ReplayTimeout)
+	}
+	if config.Server.WriteTimeout > 0 && config.Webhooks.ReplayTimeout >= config.Server.WriteTimeout {
+		return fmt.Errorf("invalid webhook replay timeout: %s (must be shorter than the server write timeout %s)", config.Webhooks.ReplayTimeout, config.Server.WriteTimeout)
+	}
+	if config.Idempotency.ProcessedMessageTTL < 0 {
+		return fmt.Errorf("invalid processed message TTL: %s (must not be negative)", config.Idempotency.ProcessedMessageTTL)
+	}
+	if config.Sagas.MaxAttempts < 0 {
+		return fmt.Errorf("invalid saga max attempts: %d (must not be negative)", config.Sagas.MaxAttempts)
+	}
+	if config.Sagas.StuckAfter < 0 {
+		return fmt.Errorf("invalid saga stuck threshold: %s (must not be negative)", config.Sagas.StuckAfter)
+	}
+	if err := validateOutboundClientConfig("outbound_http", config.OutboundHTTP.OutboundClientConfig); err != nil {
+		return err
+	}
+	for destination, override := range config.OutboundHTTP.Destinations {
+		if err := validateOutboundClientConfig("outbound_http.destinations."+destination, override); err != nil {
+			return err
+		}
+	}
+	// Currency validation
+	if err := validateCurrencyPolicy("currency", config.Currency.Default, config.Currency.Rounding); err != nil {
+		return err
+	}
+	for tenantID, tenant := range config.Currency.Tenants {
+		if err := validateCurrencyPolicy("currency.tenants."+tenantID, tenant.Currency, tenant.Rounding); err != nil {
+			return err
+		}
+	}
+	// Compliance validation
+	for _, rule := range config.Compliance.Rules {
+		if err := rule.toComplianceRule().Validate(); err != nil {
+			return fmt.Errorf("invalid compliance rule %q: %w", rule.Name, err)
+		}
+	}
+
+	if config.DuplicateInvoices.Window < 0 {
+		return fmt.Errorf("invalid duplicate invoice window: %s (must not be negative)", config.DuplicateInvoices.Window)
+	}
+
+	if _, err := valueobject.NewLateFeePolicy(config.LateFees.policyConfig()); err != nil {
+		return fmt.Errorf("invalid late fees: %w", err)
+	}
+
+	if config.IntegrationLogs.Retention < 0 {
+		return fmt.Errorf("invalid integration log retention: %s (must not be negative)", config.IntegrationLogs.Retention)
+	}
+	if config.RequestSigning.MaxBodyBytes < 0 {
+		return fmt.Errorf("invalid request signing max body bytes: %d (must not be negative)", config.RequestSigning.MaxBodyBytes)
+	}
+	if config.IntegrationLogs.MaxBodyBytes < 0 {
+		return fmt.Errorf("invalid integration log max body bytes: %d (must not be negative)", config.IntegrationLogs.MaxBodyBytes)
+	}
+
+	if config.Partitions.MonthsAhead < 0 {
+		return fmt.Errorf("invalid partition months ahead: %d (must not be negative)", config.Partitions.MonthsAhead)
+	}
+
+	for jurisdiction, rate := range config.Tax.Rates {
+		if strings.TrimSpace(jurisdiction) == "" {
+			return fmt.Errorf("invalid tax rate: jurisdiction code is required")
+		}
+		if rate < 0 || rate > 10000 {
+			return fmt.Errorf("invalid tax rate of %s: %d (must be between 0 and 10000 basis points)", jurisdiction, rate)
+		}
+	}
+
+	if config.InvoiceArchive.AfterYears < 0 {
+		return fmt.Errorf("invalid invoice archive after years: %d (must not be negative)", config.InvoiceArchive.AfterYears)
+	}
+	if config.InvoiceArchive.RehydrationHold < 0 {
+		return fmt.Errorf("invalid invoice rehydration hold: %v (must not be negative)", config.InvoiceArchive.RehydrationHold)
+	}
+
+	switch config.ClientDeletion.DraftInvoices {
+	case "", "cascade", "block":
+	default:
+		return fmt.Errorf("invalid client deletion rule of draft invoices: %s (must be cascade or block)", config.ClientDeletion.DraftInvoices)
+	}
+	switch config.ClientDeletion.ClosedInvoices {
+	case "", "block", "retain":
+	default:
+		return fmt.Errorf("invalid client deletion rule of closed invoices: %s (must be block or retain)", config.ClientDeletion.ClosedInvoices)
+	}
+
+	if _, err := valueobject.NewEmailNormalization(config.EmailNormalization.FoldSubaddress, config.EmailNormalization.FoldDotDomains); err != nil {
+		return fmt.Errorf("invalid email normalization: %w", err)
+	}
+
+	if config.EmailOutbox.MaxAttempts < 0 {
+		return fmt.Errorf("invalid email outbox max attempts: %d (must not be negative)", config.EmailOutbox.MaxAttempts)
+	}
+	if config.EmailOutbox.RetryBackoff < 0 || config.EmailOutbox.MaxBackoff < 0 {
+		return fmt.Errorf("invalid email outbox backoff: %v, max %v (must not be negative)", config.EmailOutbox.RetryBackoff, config.EmailOutbox.MaxBackoff)
+	}
+	if config.EmailOutbox.SoftBounceLimit < 0 {
+		return fmt.Errorf("invalid email soft bounce limit: %d (must not be negative)", config.EmailOutbox.SoftBounceLimit)
+	}
+
+	if config.InvoiceNumbering.Format != "" {
+		if _, err := valueobject.NewInvoiceNumberFormat(config.InvoiceNumbering.Format); err != nil {
+			return fmt.Errorf("invalid invoice number format: %w", err)
+		}
+	}
+
+	if config.Subscriptions.Proration != "" {
+		if _, err := service.NewProrator(service.ProrationStrategy(config.Subscriptions.Proration)); err != nil {
+			return fmt.Errorf("invalid subscription proration: %w", err)
+		}
+	}
+
+	if config.ResponseWarnings.CreditLimitThreshold < 0 || config.ResponseWarnings.CreditLimitThreshold > 1 {
+		return fmt.Errorf("invalid credit limit warning threshold: %g (must be between 0 and 1)", config.ResponseWarnings.CreditLimitThreshold)
+	}
+
+	// Server validation (the port is only listened on without a Unix or systemd-activated socket)
+	if config.Server.UnixSocket != "" && config.Server.SocketActivation {
+		return fmt.Errorf("server unix_socket and socket_activation cannot both be set")
+	}
+	if config.Server.UnixSocket == "" && !config.Server.SocketActivation && (config.Server.Port <= 0 || config.Server.Port > 65535) {
 		return fmt.Errorf("invalid server port: %d", config.Server.Port)
 	}
+	if config.Server.UnixSocketMode != "" {
+		if _, err := config.Server.socketMode(); err != nil {
+			return fmt.Errorf("invalid unix socket mode: %q (must be octal permissions such as 0660)", config.Server.UnixSocketMode)
+		}
+	}
+	if config.Server.AdminPort < 0 || config.Server.AdminPort > 65535 {
+		return fmt.Errorf("invalid server admin port: %d", config.Server.AdminPort)
+	}
+	if config.Server.AdminPort != 0 && config.Server.AdminUnixSocket != "" {
+		return fmt.Errorf("server admin_port and admin_unix_socket cannot both be set")
+	}
+	if config.Server.AdminPort != 0 && config.Server.AdminPort == config.Server.Port && config.Server.UnixSocket == "" && !config.Server.SocketActivation {
+		return fmt.Errorf("server admin port must differ from the public port: %d", config.Server.AdminPort)
+	}
+	if config.Server.AdminUnixSocket != "" && config.Server.AdminUnixSocket == config.Server.UnixSocket {
+		return fmt.Errorf("server admin unix socket must differ from the public socket: %s", config.Server.AdminUnixSocket)
+	}
+	if config.Server.Profiling && !config.Server.SeparateAdminListener() {
+		return fmt.Errorf("server profiling requires a separate admin listener (admin_port or admin_unix_socket)")
+	}
+	for _, proxy := range config.Server.TrustedProxies {
+		proxy = strings.TrimSpace(proxy)
+		if _, err := netip.ParsePrefix(proxy); err == nil {
+			continue
+		}
+		if _, err := netip.ParseAddr(proxy); err != nil {
+			return fmt.Errorf("invalid trusted proxy: %q (must be an IP address or CIDR range)", proxy)
+		}
+	}
 
 	// Database validation
 	if config.Database.Host == "" {
@@ -377,6 +1424,62 @@ func validateConfig(config *Config) error {
 	return nil
 }
 
+// mergeOutboundClientConfig overrides the non-zero settings of target with those of source
+func mergeOutboundClientConfig(target, source OutboundClientConfig) OutboundClientConfig {
+	if source.Timeout != 0 {
+		target.Timeout = source.Timeout
+	}
+	if source.MaxAttempts != 0 {
+		target.MaxAttempts = source.MaxAttempts
+	}
+	if source.RetryBackoff != 0 {
+		target.RetryBackoff = source.RetryBackoff
+	}
+	if source.RateLimit != 0 {
+		target.RateLimit = source.RateLimit
+	}
+	if source.Burst != 0 {
+		target.Burst = source.Burst
+	}
+	if source.BreakerThreshold != 0 {
+		target.BreakerThreshold = source.BreakerThreshold
+	}
+	if source.BreakerCooldown != 0 {
+		target.BreakerCooldown = source.BreakerCooldown
+	}
+	if source.MaxIdleConnsPerHost != 0 {
+		target.MaxIdleConnsPerHost = source.MaxIdleConnsPerHost
+	}
+	return target
+}
+
+// validateCurrencyPolicy rejects unknown currency codes and rounding modes
+func validateCurrencyPolicy(section, currency, rounding string) error {
+	if currency != "" {
+		if _, err := valueobject.NewMoney(0, currency); err != nil {
+			return fmt.Errorf("invalid %s currency: %s", section, currency)
+		}
+	}
+	if _, err := valueobject.ParseRoundingMode(rounding); err != nil {
+		return fmt.Errorf("invalid %s rounding: %s (must be half_up, half_even, down or up)", section, rounding)
+	}
+	return nil
+}
+
+// validateOutboundClientConfig rejects negative outbound client settings
+func validateOutboundClientConfig(section string, config OutboundClientConfig) error {
+	if config.Timeout < 0 || config.RetryBackoff < 0 || config.BreakerCooldown < 0 {
+		return fmt.Errorf("invalid %s durations (must not be negative)", section)
+	}
+	if config.MaxAttempts < 0 || config.Burst < 0 || config.BreakerThreshold < 0 || config.MaxIdleConnsPerHost < 0 {
+		return fmt.Errorf("invalid %s limits (must not be negative)", section)
+	}
+	if config.RateLimit < 0 {
+		return fmt.Errorf("invalid %s rate limit: %g (must not be negative)", section, config.RateLimit)
+	}
+	return nil
+}
+
 // contains checks if a slice contains a string
 func contains(slice []string, item string) bool {
 	for _, s := range slice {
@@ -0,0 +1,56 @@
+// Demo Data Seeding
+//
+// This file pre-populates a fresh (in-memory) deployment with sample data for product demos.
+// Provides: Deterministic seeding through the application services
+// Pattern: Factory-generated attributes created via the same use cases as real API calls
+// Used by: DI container when the demo profile enables seeding
+package demo
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/factory"
+)
+
+// seedActor creates the demo clients, which belong to no tenant
+const seedActor = "demo_seed"
+
+// DefaultClients is the number of sample clients seeded when none is configured
+const DefaultClients = 25
+
+// Config controls demo seeding
+type Config struct {
+	// Clients is the number of sample clients to create
+	Clients int
+
+	// RandomSeed makes the generated data reproducible between restarts
+	RandomSeed int64
+}
+
+// Seed creates sample data through the billing service and returns the number of clients created
+// Clients are the only aggregate this service owns today; invoices and payments belong here once they exist
+func Seed(billingService *application.BillingService, config Config) (int, error) {
+	if config.Clients <= 0 {
+		config.Clients = DefaultClients
+	}
+
+	rc := application.SystemContext(seedActor, "")
+	clients := factory.NewClientFactory(config.RandomSeed)
+	for i := 0; i < config.Clients; i++ {
+		attributes := clients.Next()
+		if _, err := billingService.CreateClient(rc, dtos.CreateClientRequest{
+			Name:    attributes.Name,
+			Email:   attributes.Email,
+			Phone:   attributes.Phone,
+			Address: attributes.Address,
+		}); err != nil {
+			return i, fmt.Errorf("failed to seed demo client %q: %w", attributes.Name, err)
+		}
+	}
+
+	log.Printf("🌱 Seeded %d demo clients", config.Clients)
+	return config.Clients, nil
+}
@@ -5,6 +5,14 @@
 // Used by: Container builders, test setups, production initialization
 package di
 
+import (
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/httpclient"
+)
+
 // ContainerConfig defines configuration for dependency injection
 type ContainerConfig struct {
 	// Storage configuration
@@ -45,6 +53,182 @@ type ContainerConfig struct {
 	ServerPort int    `yaml:"server_port" json:"server_port"`
 	ServerHost string `yaml:"server_host" json:"server_host"`
 
+	// TrustedProxies lists the proxies and load balancers (addresses or CIDR ranges) whose X-Forwarded-For
+	// and X-Real-IP headers are honored when resolving client addresses
+	TrustedProxies []string `yaml:"trusted_proxies" json:"trusted_proxies"`
+
+	// SeparateAdminListener serves health checks and the admin API on the admin listener only, and Profiling
+	// serves the Go profiler there
+	SeparateAdminListener bool `yaml:"separate_admin_listener" json:"separate_admin_listener"`
+	Profiling             bool `yaml:"profiling" json:"profiling"`
+
+	// DocsAssetsURL is the base URL the API reference at /docs loads the Swagger UI files from
+	DocsAssetsURL string `yaml:"docs_assets_url" json:"docs_assets_url"`
+
+	// Localization configuration
+	DefaultLocale string            `yaml:"default_locale" json:"default_locale"`
+	TenantLocales map[string]string `yaml:"tenant_locales" json:"tenant_locales"`
+
+	// Currency configuration (without a default currency, templates must specify theirs)
+	DefaultCurrency  string            `yaml:"default_currency" json:"default_currency"`
+	CurrencyRounding string            `yaml:"currency_rounding" json:"currency_rounding"`
+	TenantCurrencies map[string]string `yaml:"tenant_currencies" json:"tenant_currencies"`
+	TenantRoundings  map[string]string `yaml:"tenant_roundings" json:"tenant_roundings"`
+
+	// Compliance configuration (configured rules are evaluated after the built-in ones)
+	ComplianceEnabled bool                     `yaml:"compliance_enabled" json:"compliance_enabled"`
+	ComplianceRules   []service.ComplianceRule `yaml:"compliance_rules" json:"compliance_rules"`
+
+	// Duplicate invoice detection configuration (same external reference, or same line items within the window)
+	DuplicateInvoicesEnabled bool          `yaml:"duplicate_invoices_enabled" json:"duplicate_invoices_enabled"`
+	DuplicateInvoiceWindow   time.Duration `yaml:"duplicate_invoice_window" json:"duplicate_invoice_window"`
+
+	// Late fees configuration (fees announced by payment reminders on overdue balances)
+	LateFeesEnabled bool                            `yaml:"late_fees_enabled" json:"late_fees_enabled"`
+	LateFees        valueobject.LateFeePolicyConfig `yaml:"late_fees" json:"late_fees"`
+
+	// Request signing configuration (HMAC verification for inbound integrations)
+	RequestSigningEnabled     bool              `yaml:"request_signing_enabled" json:"request_signing_enabled"`
+	RequestSigningRouteGroups []string          `yaml:"request_signing_route_groups" json:"request_signing_route_groups"`
+	RequestSigningSecrets     map[string]string `yaml:"request_signing_secrets" json:"-"`
+	RequestSigningTolerance   time.Duration     `yaml:"request_signing_tolerance" json:"request_signing_tolerance"`
+	RequestSigningMaxBody     int64             `yaml:"request_signing_max_body" json:"request_signing_max_body"`
+
+	// Admin configuration (actor name -> bearer token for /api/v1/admin, and the scopes and tenants it is restricted to)
+	AdminTokens  map[string]string   `yaml:"admin_tokens" json:"-"`
+	AdminScopes  map[string][]string `yaml:"admin_scopes" json:"admin_scopes"`
+	AdminTenants map[string][]string `yaml:"admin_tenants" json:"admin_tenants"`
+
+	// Captcha configuration (anti-automation challenge on public routes)
+	CaptchaEnabled        bool              `yaml:"captcha_enabled" json:"captcha_enabled"`
+	CaptchaProvider       string            `yaml:"captcha_provider" json:"captcha_provider"`
+	CaptchaRoutes         []string          `yaml:"captcha_routes" json:"captcha_routes"`
+	CaptchaSecret         string            `yaml:"captcha_secret" json:"-"`
+	CaptchaTrustedAPIKeys map[string]string `yaml:"captcha_trusted_api_keys" json:"-"`
+
+	// Form token configuration (duplicate submission guard for browser clients)
+	FormTokenTTL time.Duration `yaml:"form_token_ttl" json:"form_token_ttl"`
+
+	// Portal configuration (client self-service API on /portal/v1)
+	PortalEnabled     bool          `yaml:"portal_enabled" json:"portal_enabled"`
+	PortalTokenSecret string        `yaml:"portal_token_secret" json:"-"`
+	PortalTokenTTL    time.Duration `yaml:"portal_token_ttl" json:"portal_token_ttl"`
+	PortalClockSkew   time.Duration `yaml:"portal_clock_skew" json:"portal_clock_skew"`
+
+	// Magic link configuration (signed single-use links; disabled without a secret)
+	MagicLinkSecret    string        `yaml:"magic_link_secret" json:"-"`
+	MagicLinkTTL       time.Duration `yaml:"magic_link_ttl" json:"magic_link_ttl"`
+	MagicLinkBaseURL   string        `yaml:"magic_link_base_url" json:"magic_link_base_url"`
+	MagicLinkClockSkew time.Duration `yaml:"magic_link_clock_skew" json:"magic_link_clock_skew"`
+
+	// Contract configuration (renewal reminders published for account managers)
+	ContractRenewalReminderLead time.Duration `yaml:"contract_renewal_reminder_lead" json:"contract_renewal_reminder_lead"`
+
+	// Approval configuration (two-step approval of credit notes and refunds above the threshold)
+	ApprovalThreshold int64    `yaml:"approval_threshold" json:"approval_threshold"`
+	Approvers         []string `yaml:"approvers" json:"approvers"`
+
+	// Invoice delivery configuration (view tracking tokens and follow-ups published on bounce)
+	InvoiceTrackingSecret string              `yaml:"invoice_tracking_secret" json:"-"`
+	BounceFollowUpRules   map[string][]string `yaml:"bounce_follow_up_rules" json:"bounce_follow_up_rules"`
+
+	// Payout configuration (gateway payouts reconciled with the ledger; disabled without a gateway URL)
+	PaymentGatewayURL    string        `yaml:"payment_gateway_url" json:"payment_gateway_url"`
+	PaymentGatewayAPIKey string        `yaml:"payment_gateway_api_key" json:"-"`
+	PayoutLookback       time.Duration `yaml:"payout_lookback" json:"payout_lookback"`
+
+	// Risk configuration (clients scored on onboarding and large invoices; disabled without a provider URL)
+	RiskProvider              string        `yaml:"risk_provider" json:"risk_provider"`
+	RiskProviderURL           string        `yaml:"risk_provider_url" json:"risk_provider_url"`
+	RiskProviderAPIKey        string        `yaml:"risk_provider_api_key" json:"-"`
+	RiskScoreTTL              time.Duration `yaml:"risk_score_ttl" json:"risk_score_ttl"`
+	RiskLargeInvoiceThreshold int64         `yaml:"risk_large_invoice_threshold" json:"risk_large_invoice_threshold"`
+
+	// Sandbox configuration (flagged tenants and API keys served from isolated in-memory data)
+	SandboxEnabled       bool              `yaml:"sandbox_enabled" json:"sandbox_enabled"`
+	SandboxTenants       []string          `yaml:"sandbox_tenants" json:"sandbox_tenants"`
+	SandboxAPIKeys       map[string]string `yaml:"sandbox_api_keys" json:"-"`
+	SandboxGatewayURL    string            `yaml:"sandbox_gateway_url" json:"sandbox_gateway_url"`
+	SandboxGatewayAPIKey string            `yaml:"sandbox_gateway_api_key" json:"-"`
+	SandboxCatchAllEmail string            `yaml:"sandbox_catch_all_email" json:"sandbox_catch_all_email"`
+	SandboxSeedClients   int               `yaml:"sandbox_seed_clients" json:"sandbox_seed_clients"`
+
+	// Webhook configuration (inbound deliveries are rejected for providers without a scheme; events are replayed to a
+	// consumer subscription at most once per replay cooldown, each request delivering them for the replay timeout)
+	WebhookSchemes          map[string]string `yaml:"webhook_schemes" json:"webhook_schemes"`
+	WebhookSecrets          map[string]string `yaml:"webhook_secrets" json:"-"`
+	WebhookSignatureHeaders map[string]string `yaml:"webhook_signature_headers" json:"webhook_signature_headers"`
+	WebhookTimestampHeaders map[string]string `yaml:"webhook_timestamp_headers" json:"webhook_timestamp_headers"`
+	WebhookMaxAttempts      int               `yaml:"webhook_max_attempts" json:"webhook_max_attempts"`
+	WebhookReplayCooldown   time.Duration     `yaml:"webhook_replay_cooldown" json:"webhook_replay_cooldown"`
+	WebhookReplayTimeout    time.Duration     `yaml:"webhook_replay_timeout" json:"webhook_replay_timeout"`
+
+	// Idempotent consumer configuration (how long processed messages are remembered)
+	ProcessedMessageTTL time.Duration `yaml:"processed_message_ttl" json:"processed_message_ttl"`
+
+	// Request tracing configuration (request ID and trace headers honored from the API gateway)
+	RequestIDHeader            string `yaml:"request_id_header" json:"request_id_header"`
+	IgnoreIncomingTraceHeaders bool   `yaml:"ignore_incoming_trace_headers" json:"ignore_incoming_trace_headers"`
+
+	// Saga orchestrator configuration (invoice card payments run when PaymentGatewayURL is set)
+	SagaMaxAttempts int           `yaml:"saga_max_attempts" json:"saga_max_attempts"`
+	SagaStuckAfter  time.Duration `yaml:"saga_stuck_after" json:"saga_stuck_after"`
+
+	// Outbound HTTP client configuration (defaults of every destination and per-destination overrides)
+	OutboundHTTP             httpclient.Config            `yaml:"outbound_http" json:"outbound_http"`
+	OutboundHTTPDestinations map[string]httpclient.Config `yaml:"outbound_http_destinations" json:"outbound_http_destinations"`
+
+	// Integration call log configuration (outbound calls recorded with redacted bodies when enabled)
+	IntegrationLogsEnabled     bool          `yaml:"integration_logs_enabled" json:"integration_logs_enabled"`
+	IntegrationLogRetention    time.Duration `yaml:"integration_log_retention" json:"integration_log_retention"`
+	IntegrationLogMaxBodyBytes int           `yaml:"integration_log_max_body_bytes" json:"integration_log_max_body_bytes"`
+
+	// Event store configuration (published integration events appended to an append-only log when enabled)
+	EventStoreEnabled bool `yaml:"event_store_enabled" json:"event_store_enabled"`
+
+	// Partition maintenance configuration (months of usage record and audit log partitions created ahead)
+	PartitionMonthsAhead int `yaml:"partition_months_ahead" json:"partition_months_ahead"`
+
+	// Tax configuration (jurisdiction code -> rate in basis points, for line items without an explicit rate)
+	TaxRates map[string]int64 `yaml:"tax_rates" json:"tax_rates"`
+
+	// Invoice archive configuration (age of closed invoices moved to the archive, hold after a rehydration)
+	InvoiceArchiveAfterYears int           `yaml:"invoice_archive_after_years" json:"invoice_archive_after_years"`
+	InvoiceRehydrationHold   time.Duration `yaml:"invoice_rehydration_hold" json:"invoice_rehydration_hold"`
+
+	// Client deletion configuration (rules of the draft and closed invoices of a deleted client, default delete mode)
+	ClientDeletionDraftInvoices  string `yaml:"client_deletion_draft_invoices" json:"client_deletion_draft_invoices"`
+	ClientDeletionClosedInvoices string `yaml:"client_deletion_closed_invoices" json:"client_deletion_closed_invoices"`
+	ClientDeletionIdempotent     bool   `yaml:"client_deletion_idempotent" json:"client_deletion_idempotent"`
+
+	// Email normalization configuration (spellings of an address counted as one client: subaddress tags, dots)
+	EmailFoldSubaddress bool     `yaml:"email_fold_subaddress" json:"email_fold_subaddress"`
+	EmailFoldDotDomains []string `yaml:"email_fold_dot_domains" json:"email_fold_dot_domains"`
+
+	// Email outbox configuration (dispatch attempts and backoff of notification emails, soft bounces suppressing an address)
+	EmailOutboxMaxAttempts  int           `yaml:"email_outbox_max_attempts" json:"email_outbox_max_attempts"`
+	EmailOutboxRetryBackoff time.Duration `yaml:"email_outbox_retry_backoff" json:"email_outbox_retry_backoff"`
+	EmailOutboxMaxBackoff   time.Duration `yaml:"email_outbox_max_backoff" json:"email_outbox_max_backoff"`
+	EmailSoftBounceLimit    int           `yaml:"email_soft_bounce_limit" json:"email_soft_bounce_limit"`
+
+	// Invoice numbering configuration (format of the numbers assigned when invoices are issued, default INV-{YYYY}-{00000})
+	InvoiceNumberFormat string `yaml:"invoice_number_format" json:"invoice_number_format"`
+
+	// Subscriptions configuration (proration of plan changes during a billed period, default day_based)
+	SubscriptionProration string `yaml:"subscription_proration" json:"subscription_proration"`
+
+	// Response warnings configuration (soft limits reported with successful responses)
+	CreditWarningThreshold float64           `yaml:"credit_warning_threshold" json:"credit_warning_threshold"` // Share of the credit limit used (0: disabled)
+	DeprecatedParameters   map[string]string `yaml:"deprecated_parameters" json:"deprecated_parameters"`       // Query parameter -> advice
+
+	// SandboxMode is set on the configuration of the sandbox environment itself (see NewSandbox)
+	SandboxMode bool `yaml:"-" json:"sandbox_mode"`
+
+	// Demo configuration (sample data seeded into in-memory storage)
+	DemoSeedEnabled bool  `yaml:"demo_seed_enabled" json:"demo_seed_enabled"`
+	DemoClients     int   `yaml:"demo_clients" json:"demo_clients"`
+	DemoRandomSeed  int64 `yaml:"demo_random_seed" json:"demo_random_seed"`
+
 	// Environment
 	Environment string `yaml:"environment" json:"environment"`
 
@@ -52,6 +236,9 @@ type ContainerConfig struct {
 	Version string `yaml:"version" json:"version"`
 }
 
+// TestAdminToken is the bearer token of the "test-admin" actor of the test configurations (every admin scope)
+const TestAdminToken = "test-admin-token"
+
 // UnitTestConfig returns a configuration suitable for unit testing (memory storage)
 func UnitTestConfig() *ContainerConfig {
 	return &ContainerConfig{
@@ -60,6 +247,7 @@ func UnitTestConfig() *ContainerConfig {
 		ServerPort:  8080,
 		ServerHost:  "localhost",
 		Environment: "test",
+		AdminTokens: map[string]string{"test-admin": TestAdminToken},
 	}
 }
 
@@ -94,6 +282,7 @@ func IntegrationTestConfig() *ContainerConfig {
 		ServerPort:           8080,
 		ServerHost:           "localhost",
 		Environment:          "test",
+		AdminTokens:          map[string]string{"test-admin": TestAdminToken},
 	}
 }
 
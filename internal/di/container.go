@@ -12,27 +12,198 @@ import (
 	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
 	"github.com/gjaminon-go-labs/billing-api/internal/application"
 	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/eventbus"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/httpclient"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/messaging"
 	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
 	"github.com/gjaminon-go-labs/billing-api/internal/migration"
 )
 
 // Container manages all application dependencies using lazy initialization
 type Container struct {
-	config *ContainerConfig
+	config  *ContainerConfig
+	plugins []application.Plugin // Registered before the billing service is resolved, kept by Reset
 
 	// Singleton instances (created once, reused)
-	storage          storage.Storage
-	migrationService *migration.Service
-	clientRepo       repository.ClientRepository
-	billingService   *application.BillingService
-	httpServer       *httpserver.Server
+	storage                    storage.Storage
+	migrationService           *migration.Service
+	clientRepo                 repository.ClientRepository
+	changeRepo                 repository.ClientChangeRepository
+	auditRepo                  repository.AuditRepository
+	ipPolicyRepo               repository.IPAccessPolicyRepository
+	formTokenRepo              repository.FormTokenRepository
+	magicLinkRepo              repository.MagicLinkRepository
+	usageRepo                  repository.UsageRecordRepository
+	contractRepo               repository.ContractRepository
+	approvalRepo               repository.ApprovalRequestRepository
+	recurringRepo              repository.RecurringInvoiceTemplateRepository
+	deliveryRepo               repository.InvoiceDeliveryEventRepository
+	dunningRepo                repository.DunningPolicyRepository
+	fiscalCalendarRepo         repository.FiscalCalendarRepository
+	bankTxRepo                 repository.BankTransactionRepository
+	payoutRepo                 repository.PayoutReconciliationRepository
+	legalEntityRepo            repository.LegalEntityRepository
+	documentRepo               repository.DocumentTemplateRepository
+	creditLimitRepo            repository.ClientCreditLimitRepository
+	statementJobRepo           repository.ClientStatementJobRepository
+	externalRefRepo            repository.ExternalReferenceRepository
+	invoiceRepo                repository.InvoiceRepository
+	paymentRepo                repository.PaymentRepository
+	clientSummaryRepo          repository.ClientSummaryRepository
+	clientContactRepo          repository.ClientContactRepository
+	clientNoteRepo             repository.ClientNoteRepository
+	clientTombstoneRepo        repository.ClientTombstoneRepository
+	eventStoreRepo             repository.EventStoreRepository
+	riskRepo                   repository.RiskAssessmentRepository
+	webhookEventRepo           repository.WebhookEventRepository
+	webhookSubscriptionRepo    repository.WebhookSubscriptionRepository
+	failedMessageRepo          repository.FailedMessageRepository
+	processedMessageRepo       repository.ProcessedMessageRepository
+	integrationLogRepo         repository.IntegrationLogRepository
+	sagaRepo                   repository.SagaRepository
+	eventPublisher             messaging.Publisher
+	outboundClients            *httpclient.Registry
+	billingService             *application.BillingService
+	auditService               *application.AuditService
+	eventBus                   *eventbus.Bus
+	policyService              *application.AccessPolicyService
+	formTokenService           *application.FormTokenService
+	portalService              *application.PortalService
+	magicLinkService           *application.MagicLinkService
+	usageService               *application.UsageService
+	contractService            *application.ContractService
+	approvalService            *application.ApprovalService
+	recurringService           *application.RecurringInvoiceService
+	subscriptionRepo           repository.SubscriptionRepository
+	subscriptionService        *application.SubscriptionService
+	quoteRepo                  repository.QuoteRepository
+	quoteService               *application.QuoteService
+	deliveryService            *application.InvoiceDeliveryService
+	dunningService             *application.DunningPolicyService
+	dunningRunService          *application.DunningService
+	tenantRuleRepo             repository.TenantRuleSetRepository
+	tenantRuleService          *application.TenantRuleService
+	fiscalCalendarService      *application.FiscalCalendarService
+	cashService                *application.CashApplicationService
+	payoutService              *application.PayoutReconciliationService
+	legalEntityService         *application.LegalEntityService
+	documentService            *application.DocumentTemplateService
+	creditService              *application.CreditControlService
+	statementService           *application.ClientStatementService
+	externalRefService         *application.ExternalReferenceService
+	eventStore                 *application.EventStore
+	partitionService           *application.PartitionMaintenanceService
+	invoiceArchiveRepo         repository.InvoiceArchiveRepository
+	invoiceArchiveService      *application.InvoiceArchiveService
+	uploadRepo                 repository.UploadRepository
+	objectStore                repository.ObjectStore
+	uploadService              *application.UploadService
+	integrationPublisher       messaging.Publisher
+	riskService                *application.RiskScoringService
+	webhookService             *application.WebhookService
+	webhookSubscriptionService *application.WebhookSubscriptionService
+	deadLetterPublisher        *application.DeadLetterPublisher
+	deadLetterService          *application.DeadLetterService
+	outboundEmailRepo          repository.OutboundEmailRepository
+	emailSuppressionRepo       repository.EmailSuppressionRepository
+	emailOutboxService         *application.EmailOutboxService
+	dashboardService           *application.DashboardService
+	idempotentConsumer         *application.IdempotentConsumer
+	integrationLogService      *application.IntegrationLogService
+	sagaOrchestrator           *application.SagaOrchestrator
+	invoicePaymentService      *application.InvoicePaymentService
+	httpServer                 *httpserver.Server
+	sandbox                    *Sandbox
 
 	// Synchronization for thread-safe lazy initialization
-	storageOnce          sync.Once
-	migrationServiceOnce sync.Once
-	clientRepoOnce       sync.Once
-	billingServiceOnce   sync.Once
-	httpServerOnce       sync.Once
+	storageOnce                    sync.Once
+	migrationServiceOnce           sync.Once
+	clientRepoOnce                 sync.Once
+	changeRepoOnce                 sync.Once
+	auditRepoOnce                  sync.Once
+	ipPolicyRepoOnce               sync.Once
+	formTokenRepoOnce              sync.Once
+	magicLinkRepoOnce              sync.Once
+	usageRepoOnce                  sync.Once
+	contractRepoOnce               sync.Once
+	approvalRepoOnce               sync.Once
+	recurringRepoOnce              sync.Once
+	deliveryRepoOnce               sync.Once
+	dunningRepoOnce                sync.Once
+	fiscalCalendarRepoOnce         sync.Once
+	bankTxRepoOnce                 sync.Once
+	payoutRepoOnce                 sync.Once
+	legalEntityRepoOnce            sync.Once
+	documentRepoOnce               sync.Once
+	creditLimitRepoOnce            sync.Once
+	statementJobRepoOnce           sync.Once
+	externalRefRepoOnce            sync.Once
+	invoiceRepoOnce                sync.Once
+	paymentRepoOnce                sync.Once
+	clientSummaryRepoOnce          sync.Once
+	clientContactRepoOnce          sync.Once
+	clientNoteRepoOnce             sync.Once
+	clientTombstoneRepoOnce        sync.Once
+	eventStoreRepoOnce             sync.Once
+	riskRepoOnce                   sync.Once
+	webhookEventRepoOnce           sync.Once
+	webhookSubscriptionRepoOnce    sync.Once
+	eventPublisherOnce             sync.Once
+	outboundClientsOnce            sync.Once
+	billingServiceOnce             sync.Once
+	auditServiceOnce               sync.Once
+	eventBusOnce                   sync.Once
+	policyServiceOnce              sync.Once
+	formTokenServiceOnce           sync.Once
+	portalServiceOnce              sync.Once
+	magicLinkServiceOnce           sync.Once
+	usageServiceOnce               sync.Once
+	contractServiceOnce            sync.Once
+	approvalServiceOnce            sync.Once
+	recurringServiceOnce           sync.Once
+	subscriptionRepoOnce           sync.Once
+	subscriptionServiceOnce        sync.Once
+	quoteRepoOnce                  sync.Once
+	quoteServiceOnce               sync.Once
+	deliveryServiceOnce            sync.Once
+	dunningServiceOnce             sync.Once
+	dunningRunServiceOnce          sync.Once
+	tenantRuleRepoOnce             sync.Once
+	tenantRuleServiceOnce          sync.Once
+	fiscalCalendarServiceOnce      sync.Once
+	cashServiceOnce                sync.Once
+	payoutServiceOnce              sync.Once
+	legalEntityServiceOnce         sync.Once
+	documentServiceOnce            sync.Once
+	creditServiceOnce              sync.Once
+	statementServiceOnce           sync.Once
+	externalRefServiceOnce         sync.Once
+	eventStoreOnce                 sync.Once
+	partitionServiceOnce           sync.Once
+	invoiceArchiveRepoOnce         sync.Once
+	invoiceArchiveOnce             sync.Once
+	uploadRepoOnce                 sync.Once
+	objectStoreOnce                sync.Once
+	uploadServiceOnce              sync.Once
+	integrationPublisherOnce       sync.Once
+	riskServiceOnce                sync.Once
+	webhookServiceOnce             sync.Once
+	webhookSubscriptionServiceOnce sync.Once
+	failedMessageRepoOnce          sync.Once
+	deadLetterPublisherOnce        sync.Once
+	deadLetterServiceOnce          sync.Once
+	outboundEmailRepoOnce          sync.Once
+	emailSuppressionRepoOnce       sync.Once
+	emailOutboxServiceOnce         sync.Once
+	dashboardServiceOnce           sync.Once
+	processedMessageRepoOnce       sync.Once
+	integrationLogRepoOnce         sync.Once
+	idempotentConsumerOnce         sync.Once
+	integrationLogServiceOnce      sync.Once
+	sagaRepoOnce                   sync.Once
+	sagaOrchestratorOnce           sync.Once
+	httpServerOnce                 sync.Once
+	sandboxOnce                    sync.Once
 
 	// Error tracking for failed initializations
 	errors      map[string]error
@@ -47,6 +218,12 @@ func NewContainer(config *ContainerConfig) *Container {
 	}
 }
 
+// RegisterPlugin registers a plugin whose hooks the billing service runs (see application.Plugin)
+// Plugins must be registered before the billing service is first resolved
+func (c *Container) RegisterPlugin(plugin application.Plugin) {
+	c.plugins = append(c.plugins, plugin)
+}
+
 // GetStorage returns the storage instance, creating it if necessary
 func (c *Container) GetStorage() (storage.Storage, error) {
 	c.storageOnce.Do(func() {
@@ -98,6 +275,116 @@ func (c *Container) GetClientRepository() (repository.ClientRepository, error) {
 	return c.clientRepo, nil
 }
 
+// GetClientChangeRepository returns the client change log repository instance, creating it if necessary
+func (c *Container) GetClientChangeRepository() (repository.ClientChangeRepository, error) {
+	c.changeRepoOnce.Do(func() {
+		storage, err := c.GetStorage()
+		if err != nil {
+			c.setError("client_change_repository", NewProviderError("client_change_repository", err))
+			return
+		}
+		repo, err := ClientChangeRepositoryProvider(storage)
+		if err != nil {
+			c.setError("client_change_repository", err)
+			return
+		}
+		c.changeRepo = repo
+	})
+
+	if err := c.getError("client_change_repository"); err != nil {
+		return nil, err
+	}
+	return c.changeRepo, nil
+}
+
+// GetAuditRepository returns the audit repository instance, creating it if necessary
+func (c *Container) GetAuditRepository() (repository.AuditRepository, error) {
+	c.auditRepoOnce.Do(func() {
+		storage, err := c.GetStorage()
+		if err != nil {
+			c.setError("audit_repository", NewProviderError("audit_repository", err))
+			return
+		}
+		repo, err := AuditRepositoryProvider(storage)
+		if err != nil {
+			c.setError("audit_repository", err)
+			return
+		}
+		c.auditRepo = repo
+	})
+
+	if err := c.getError("audit_repository"); err != nil {
+		return nil, err
+	}
+	return c.auditRepo, nil
+}
+
+// GetIPAccessPolicyRepository returns the IP access policy repository instance, creating it if necessary
+func (c *Container) GetIPAccessPolicyRepository() (repository.IPAccessPolicyRepository, error) {
+	c.ipPolicyRepoOnce.Do(func() {
+		storage, err := c.GetStorage()
+		if err != nil {
+			c.setError("ip_access_policy_repository", NewProviderError("ip_access_policy_repository", err))
+			return
+		}
+		repo, err := IPAccessPolicyRepositoryProvider(storage)
+		if err != nil {
+			c.setError("ip_access_policy_repository", err)
+			return
+		}
+		c.ipPolicyRepo = repo
+	})
+
+	if err := c.getError("ip_access_policy_repository"); err != nil {
+		return nil, err
+	}
+	return c.ipPolicyRepo, nil
+}
+
+// GetFormTokenRepository returns the form token repository instance, creating it if necessary
+func (c *Container) GetFormTokenRepository() (repository.FormTokenRepository, error) {
+	c.formTokenRepoOnce.Do(func() {
+		storage, err := c.GetStorage()
+		if err != nil {
+			c.setError("form_token_repository", NewProviderError("form_token_repository", err))
+			return
+		}
+		repo, err := FormTokenRepositoryProvider(storage)
+		if err != nil {
+			c.setError("form_token_repository", err)
+			return
+		}
+		c.formTokenRepo = repo
+	})
+
+	if err := c.getError("form_token_repository"); err != nil {
+		return nil, err
+	}
+	return c.formTokenRepo, nil
+}
+
+// GetMagicLinkRepository returns the magic link repository instance, creating it if necessary
+func (c *Container) GetMagicLinkRepository() (repository.MagicLinkRepository, error) {
+	c.magicLinkRepoOnce.Do(func() {
+		storage, err := c.GetStorage()
+		if err != nil {
+			c.setError("magic_link_repository", NewProviderError("magic_link_repository", err))
+			return
+		}
+		repo, err := MagicLinkRepositoryProvider(storage)
+		if err != nil {
+			c.setError("magic_link_repository", err)
+			return
+		}
+		c.magicLinkRepo = repo
+	})
+
+	if err := c.getError("magic_link_repository"); err != nil {
+		return nil, err
+	}
+	return c.magicLinkRepo, nil
+}
+
 // GetBillingService returns the billing service instance, creating it if necessary
 func (c *Container) GetBillingService() (*application.BillingService, error) {
 	c.billingServiceOnce.Do(func() {
@@ -106,7 +393,112 @@ func (c *Container) GetBillingService() (*application.BillingService, error) {
 			c.setError("billing_service", NewProviderError("billing_service", err))
 			return
 		}
-		c.billingService = BillingServiceProvider(clientRepo)
+		changeRepo, err := c.GetClientChangeRepository()
+		if err != nil {
+			c.setError("billing_service", NewProviderError("billing_service", err))
+			return
+		}
+		riskService, err := c.GetRiskScoringService()
+		if err != nil {
+			c.setError("billing_service", NewProviderError("billing_service", err))
+			return
+		}
+		referenceService, err := c.GetExternalReferenceService()
+		if err != nil {
+			c.setError("billing_service", NewProviderError("billing_service", err))
+			return
+		}
+		invoiceRepo, err := c.GetInvoiceRepository()
+		if err != nil {
+			c.setError("billing_service", NewProviderError("billing_service", err))
+			return
+		}
+		paymentRepo, err := c.GetPaymentRepository()
+		if err != nil {
+			c.setError("billing_service", NewProviderError("billing_service", err))
+			return
+		}
+		summaryRepo, err := c.GetClientSummaryRepository()
+		if err != nil {
+			c.setError("billing_service", NewProviderError("billing_service", err))
+			return
+		}
+		contactRepo, err := c.GetClientContactRepository()
+		if err != nil {
+			c.setError("billing_service", NewProviderError("billing_service", err))
+			return
+		}
+		noteRepo, err := c.GetClientNoteRepository()
+		if err != nil {
+			c.setError("billing_service", NewProviderError("billing_service", err))
+			return
+		}
+		tombstoneRepo, err := c.GetClientTombstoneRepository()
+		if err != nil {
+			c.setError("billing_service", NewProviderError("billing_service", err))
+			return
+		}
+		eventStore, err := c.GetEventStore()
+		if err != nil {
+			c.setError("billing_service", NewProviderError("billing_service", err))
+			return
+		}
+		events, err := c.GetEventBus()
+		if err != nil {
+			c.setError("billing_service", NewProviderError("billing_service", err))
+			return
+		}
+		taxRates, err := TaxRateProviderProvider(c.config)
+		if err != nil {
+			c.setError("billing_service", err)
+			return
+		}
+		deletionPolicy, err := ClientDeletionPolicyProvider(c.config)
+		if err != nil {
+			c.setError("billing_service", err)
+			return
+		}
+		emails, err := EmailNormalizationProvider(c.config)
+		if err != nil {
+			c.setError("billing_service", err)
+			return
+		}
+		numbering, err := InvoiceNumberFormatProvider(c.config)
+		if err != nil {
+			c.setError("billing_service", err)
+			return
+		}
+		plugins, err := PluginsProvider(c.plugins)
+		if err != nil {
+			c.setError("billing_service", err)
+			return
+		}
+		tenantRules, err := c.GetTenantRuleService()
+		if err != nil {
+			c.setError("billing_service", NewProviderError("billing_service", err))
+			return
+		}
+		rules, err := RuleCheckerProvider(clientRepo, tenantRules)
+		if err != nil {
+			c.setError("billing_service", err)
+			return
+		}
+		legalEntityService, err := c.GetLegalEntityService()
+		if err != nil {
+			c.setError("billing_service", NewProviderError("billing_service", err))
+			return
+		}
+		fiscalCalendarService, err := c.GetFiscalCalendarService()
+		if err != nil {
+			c.setError("billing_service", NewProviderError("billing_service", err))
+			return
+		}
+		billingService := BillingServiceProvider(clientRepo, changeRepo, riskService, referenceService, invoiceRepo, paymentRepo, summaryRepo, contactRepo, noteRepo, tombstoneRepo, eventStore, events, taxRates, deletionPolicy, emails, numbering, plugins, rules, legalEntityService, fiscalCalendarService, c.config)
+		if err := DemoDataProvider(billingService, c.config); err != nil {
+			c.setError("billing_service", err)
+			return
+		}
+		c.billingService = billingService
 	})
 
 	if err := c.getError("billing_service"); err != nil {
@@ -115,19 +507,2141 @@ func (c *Container) GetBillingService() (*application.BillingService, error) {
 	return c.billingService, nil
 }
 
-// GetHTTPServer returns the HTTP server instance, creating it if necessary
-func (c *Container) GetHTTPServer() (*httpserver.Server, error) {
-	c.httpServerOnce.Do(func() {
+// GetAuditService returns the audit service instance, creating it if necessary
+func (c *Container) GetAuditService() (*application.AuditService, error) {
+	c.auditServiceOnce.Do(func() {
+		auditRepo, err := c.GetAuditRepository()
+		if err != nil {
+			c.setError("audit_service", NewProviderError("audit_service", err))
+			return
+		}
+		c.auditService = AuditServiceProvider(auditRepo)
+	})
+
+	if err := c.getError("audit_service"); err != nil {
+		return nil, err
+	}
+	return c.auditService, nil
+}
+
+// GetEventBus returns the in-process event bus, creating it if necessary
+func (c *Container) GetEventBus() (*eventbus.Bus, error) {
+	c.eventBusOnce.Do(func() {
+		auditService, err := c.GetAuditService()
+		if err != nil {
+			c.setError("event_bus", NewProviderError("event_bus", err))
+			return
+		}
+		c.eventBus = EventBusProvider(auditService)
+	})
+
+	if err := c.getError("event_bus"); err != nil {
+		return nil, err
+	}
+	return c.eventBus, nil
+}
+
+// GetAccessPolicyService returns the access policy service instance, creating it if necessary
+func (c *Container) GetAccessPolicyService() (*application.AccessPolicyService, error) {
+	c.policyServiceOnce.Do(func() {
+		policyRepo, err := c.GetIPAccessPolicyRepository()
+		if err != nil {
+			c.setError("access_policy_service", NewProviderError("access_policy_service", err))
+			return
+		}
+		auditService, err := c.GetAuditService()
+		if err != nil {
+			c.setError("access_policy_service", NewProviderError("access_policy_service", err))
+			return
+		}
+		c.policyService = AccessPolicyServiceProvider(policyRepo, auditService)
+	})
+
+	if err := c.getError("access_policy_service"); err != nil {
+		return nil, err
+	}
+	return c.policyService, nil
+}
+
+// GetFormTokenService returns the form token service instance, creating it if necessary
+func (c *Container) GetFormTokenService() (*application.FormTokenService, error) {
+	c.formTokenServiceOnce.Do(func() {
+		tokenRepo, err := c.GetFormTokenRepository()
+		if err != nil {
+			c.setError("form_token_service", NewProviderError("form_token_service", err))
+			return
+		}
+		c.formTokenService = FormTokenServiceProvider(tokenRepo, c.config)
+	})
+
+	if err := c.getError("form_token_service"); err != nil {
+		return nil, err
+	}
+	return c.formTokenService, nil
+}
+
+// GetMagicLinkService returns the magic link service instance (nil without a signing key), creating it if necessary
+func (c *Container) GetMagicLinkService() (*application.MagicLinkService, error) {
+	c.magicLinkServiceOnce.Do(func() {
+		linkRepo, err := c.GetMagicLinkRepository()
+		if err != nil {
+			c.setError("magic_link_service", NewProviderError("magic_link_service", err))
+			return
+		}
+		c.magicLinkService = MagicLinkServiceProvider(linkRepo, c.config)
+	})
+
+	if err := c.getError("magic_link_service"); err != nil {
+		return nil, err
+	}
+	return c.magicLinkService, nil
+}
+
+// GetPortalService returns the client portal service instance (nil when disabled), creating it if necessary
+func (c *Container) GetPortalService() (*application.PortalService, error) {
+	c.portalServiceOnce.Do(func() {
 		billingService, err := c.GetBillingService()
 		if err != nil {
-			c.setError("http_server", NewProviderError("http_server", err))
+			c.setError("portal_service", NewProviderError("portal_service", err))
+			return
+		}
+		magicLinkService, err := c.GetMagicLinkService()
+		if err != nil {
+			c.setError("portal_service", NewProviderError("portal_service", err))
+			return
+		}
+		documentService, err := c.GetDocumentTemplateService()
+		if err != nil {
+			c.setError("portal_service", NewProviderError("portal_service", err))
+			return
+		}
+		c.portalService = PortalServiceProvider(billingService, magicLinkService, documentService, c.config)
+	})
+
+	if err := c.getError("portal_service"); err != nil {
+		return nil, err
+	}
+	return c.portalService, nil
+}
+
+// GetUsageRecordRepository returns the usage record repository instance, creating it if necessary
+func (c *Container) GetUsageRecordRepository() (repository.UsageRecordRepository, error) {
+	c.usageRepoOnce.Do(func() {
+		storage, err := c.GetStorage()
+		if err != nil {
+			c.setError("usage_record_repository", NewProviderError("usage_record_repository", err))
+			return
+		}
+		repo, err := UsageRecordRepositoryProvider(storage)
+		if err != nil {
+			c.setError("usage_record_repository", err)
+			return
+		}
+		c.usageRepo = repo
+	})
+
+	if err := c.getError("usage_record_repository"); err != nil {
+		return nil, err
+	}
+	return c.usageRepo, nil
+}
+
+// GetUsageService returns the usage service instance, creating it if necessary
+func (c *Container) GetUsageService() (*application.UsageService, error) {
+	c.usageServiceOnce.Do(func() {
+		usageRepo, err := c.GetUsageRecordRepository()
+		if err != nil {
+			c.setError("usage_service", NewProviderError("usage_service", err))
+			return
+		}
+		subscriptionService, err := c.GetSubscriptionService()
+		if err != nil {
+			c.setError("usage_service", NewProviderError("usage_service", err))
+			return
+		}
+		c.usageService = UsageServiceProvider(usageRepo, subscriptionService)
+	})
+
+	if err := c.getError("usage_service"); err != nil {
+		return nil, err
+	}
+	return c.usageService, nil
+}
+
+// GetEventPublisher returns the integration event publisher, creating it if necessary
+func (c *Container) GetEventPublisher() messaging.Publisher {
+	c.eventPublisherOnce.Do(func() {
+		c.eventPublisher = EventPublisherProvider(c.config)
+	})
+	return c.eventPublisher
+}
+
+// GetOutboundClients returns the registry of outbound HTTP clients, creating it if necessary
+func (c *Container) GetOutboundClients() (*httpclient.Registry, error) {
+	c.outboundClientsOnce.Do(func() {
+		var logService *application.IntegrationLogService
+		if c.config.IntegrationLogsEnabled {
+			service, err := c.GetIntegrationLogService()
+			if err != nil {
+				c.setError("outbound_clients", NewProviderError("outbound_clients", err))
+				return
+			}
+			logService = service
+		}
+		c.outboundClients = OutboundClientsProvider(logService, c.config)
+	})
+
+	if err := c.getError("outbound_clients"); err != nil {
+		return nil, err
+	}
+	return c.outboundClients, nil
+}
+
+// GetContractRepository returns the contract repository instance, creating it if necessary
+func (c *Container) GetContractRepository() (repository.ContractRepository, error) {
+	c.contractRepoOnce.Do(func() {
+		storage, err := c.GetStorage()
+		if err != nil {
+			c.setError("contract_repository", NewProviderError("contract_repository", err))
+			return
+		}
+		repo, err := ContractRepositoryProvider(storage)
+		if err != nil {
+			c.setError("contract_repository", err)
 			return
 		}
-		version := c.config.Version
-		if version == "" {
-			version = "dev"
+		c.contractRepo = repo
+	})
+
+	if err := c.getError("contract_repository"); err != nil {
+		return nil, err
+	}
+	return c.contractRepo, nil
+}
+
+// GetContractService returns the contract service instance, creating it if necessary
+func (c *Container) GetContractService() (*application.ContractService, error) {
+	c.contractServiceOnce.Do(func() {
+		contractRepo, err := c.GetContractRepository()
+		if err != nil {
+			c.setError("contract_service", NewProviderError("contract_service", err))
+			return
+		}
+		billingService, err := c.GetBillingService()
+		if err != nil {
+			c.setError("contract_service", NewProviderError("contract_service", err))
+			return
+		}
+		publisher, err := c.GetIntegrationPublisher()
+		if err != nil {
+			c.setError("contract_service", NewProviderError("contract_service", err))
+			return
+		}
+		c.contractService = ContractServiceProvider(contractRepo, billingService, publisher, c.config)
+	})
+
+	if err := c.getError("contract_service"); err != nil {
+		return nil, err
+	}
+	return c.contractService, nil
+}
+
+// GetApprovalRequestRepository returns the approval request repository instance, creating it if necessary
+func (c *Container) GetApprovalRequestRepository() (repository.ApprovalRequestRepository, error) {
+	c.approvalRepoOnce.Do(func() {
+		storage, err := c.GetStorage()
+		if err != nil {
+			c.setError("approval_request_repository", NewProviderError("approval_request_repository", err))
+			return
+		}
+		repo, err := ApprovalRequestRepositoryProvider(storage)
+		if err != nil {
+			c.setError("approval_request_repository", err)
+			return
+		}
+		c.approvalRepo = repo
+	})
+
+	if err := c.getError("approval_request_repository"); err != nil {
+		return nil, err
+	}
+	return c.approvalRepo, nil
+}
+
+// GetApprovalService returns the approval service instance, creating it if necessary
+func (c *Container) GetApprovalService() (*application.ApprovalService, error) {
+	c.approvalServiceOnce.Do(func() {
+		approvalRepo, err := c.GetApprovalRequestRepository()
+		if err != nil {
+			c.setError("approval_service", NewProviderError("approval_service", err))
+			return
+		}
+		auditService, err := c.GetAuditService()
+		if err != nil {
+			c.setError("approval_service", NewProviderError("approval_service", err))
+			return
+		}
+		c.approvalService = ApprovalServiceProvider(approvalRepo, auditService, c.config)
+	})
+
+	if err := c.getError("approval_service"); err != nil {
+		return nil, err
+	}
+	return c.approvalService, nil
+}
+
+// GetRecurringInvoiceTemplateRepository returns the recurring invoice template repository instance, creating it if necessary
+func (c *Container) GetRecurringInvoiceTemplateRepository() (repository.RecurringInvoiceTemplateRepository, error) {
+	c.recurringRepoOnce.Do(func() {
+		storage, err := c.GetStorage()
+		if err != nil {
+			c.setError("recurring_invoice_template_repository", NewProviderError("recurring_invoice_template_repository", err))
+			return
+		}
+		repo, err := RecurringInvoiceTemplateRepositoryProvider(storage)
+		if err != nil {
+			c.setError("recurring_invoice_template_repository", err)
+			return
+		}
+		c.recurringRepo = repo
+	})
+
+	if err := c.getError("recurring_invoice_template_repository"); err != nil {
+		return nil, err
+	}
+	return c.recurringRepo, nil
+}
+
+// GetRecurringInvoiceService returns the recurring invoice service instance, creating it if necessary
+func (c *Container) GetRecurringInvoiceService() (*application.RecurringInvoiceService, error) {
+	c.recurringServiceOnce.Do(func() {
+		templateRepo, err := c.GetRecurringInvoiceTemplateRepository()
+		if err != nil {
+			c.setError("recurring_invoice_service", NewProviderError("recurring_invoice_service", err))
+			return
+		}
+		billingService, err := c.GetBillingService()
+		if err != nil {
+			c.setError("recurring_invoice_service", NewProviderError("recurring_invoice_service", err))
+			return
+		}
+		legalEntityService, err := c.GetLegalEntityService()
+		if err != nil {
+			c.setError("recurring_invoice_service", NewProviderError("recurring_invoice_service", err))
+			return
+		}
+		creditService, err := c.GetCreditControlService()
+		if err != nil {
+			c.setError("recurring_invoice_service", NewProviderError("recurring_invoice_service", err))
+			return
+		}
+		riskService, err := c.GetRiskScoringService()
+		if err != nil {
+			c.setError("recurring_invoice_service", NewProviderError("recurring_invoice_service", err))
+			return
+		}
+		currencies, err := CurrencyPoliciesProvider(c.config)
+		if err != nil {
+			c.setError("recurring_invoice_service", NewProviderError("recurring_invoice_service", err))
+			return
+		}
+		referenceService, err := c.GetExternalReferenceService()
+		if err != nil {
+			c.setError("recurring_invoice_service", NewProviderError("recurring_invoice_service", err))
+			return
+		}
+		publisher, err := c.GetIntegrationPublisher()
+		if err != nil {
+			c.setError("recurring_invoice_service", NewProviderError("recurring_invoice_service", err))
+			return
+		}
+		c.recurringService = RecurringInvoiceServiceProvider(templateRepo, billingService, legalEntityService, creditService, riskService, currencies, referenceService, publisher, c.config)
+	})
+
+	if err := c.getError("recurring_invoice_service"); err != nil {
+		return nil, err
+	}
+	return c.recurringService, nil
+}
+
+// GetSubscriptionRepository returns the subscription repository instance, creating it if necessary
+func (c *Container) GetSubscriptionRepository() (repository.SubscriptionRepository, error) {
+	c.subscriptionRepoOnce.Do(func() {
+		storage, err := c.GetStorage()
+		if err != nil {
+			c.setError("subscription_repository", NewProviderError("subscription_repository", err))
+			return
+		}
+		repo, err := SubscriptionRepositoryProvider(storage)
+		if err != nil {
+			c.setError("subscription_repository", err)
+			return
+		}
+		c.subscriptionRepo = repo
+	})
+
+	if err := c.getError("subscription_repository"); err != nil {
+		return nil, err
+	}
+	return c.subscriptionRepo, nil
+}
+
+// GetSubscriptionService returns the subscription service instance, creating it if necessary
+func (c *Container) GetSubscriptionService() (*application.SubscriptionService, error) {
+	c.subscriptionServiceOnce.Do(func() {
+		subscriptionRepo, err := c.GetSubscriptionRepository()
+		if err != nil {
+			c.setError("subscription_service", NewProviderError("subscription_service", err))
+			return
+		}
+		billingService, err := c.GetBillingService()
+		if err != nil {
+			c.setError("subscription_service", NewProviderError("subscription_service", err))
+			return
+		}
+		usageRepo, err := c.GetUsageRecordRepository()
+		if err != nil {
+			c.setError("subscription_service", NewProviderError("subscription_service", err))
+			return
+		}
+		subscriptionService, err := SubscriptionServiceProvider(subscriptionRepo, usageRepo, billingService, c.config)
+		if err != nil {
+			c.setError("subscription_service", err)
+			return
+		}
+		c.subscriptionService = subscriptionService
+	})
+
+	if err := c.getError("subscription_service"); err != nil {
+		return nil, err
+	}
+	return c.subscriptionService, nil
+}
+
+// GetQuoteRepository returns the quote repository instance, creating it if necessary
+func (c *Container) GetQuoteRepository() (repository.QuoteRepository, error) {
+	c.quoteRepoOnce.Do(func() {
+		storage, err := c.GetStorage()
+		if err != nil {
+			c.setError("quote_repository", NewProviderError("quote_repository", err))
+			return
+		}
+		repo, err := QuoteRepositoryProvider(storage)
+		if err != nil {
+			c.setError("quote_repository", err)
+			return
+		}
+		c.quoteRepo = repo
+	})
+
+	if err := c.getError("quote_repository"); err != nil {
+		return nil, err
+	}
+	return c.quoteRepo, nil
+}
+
+// GetQuoteService returns the quote service instance, creating it if necessary
+func (c *Container) GetQuoteService() (*application.QuoteService, error) {
+	c.quoteServiceOnce.Do(func() {
+		quoteRepo, err := c.GetQuoteRepository()
+		if err != nil {
+			c.setError("quote_service", NewProviderError("quote_service", err))
+			return
+		}
+		billingService, err := c.GetBillingService()
+		if err != nil {
+			c.setError("quote_service", NewProviderError("quote_service", err))
+			return
+		}
+		c.quoteService = QuoteServiceProvider(quoteRepo, billingService)
+	})
+
+	if err := c.getError("quote_service"); err != nil {
+		return nil, err
+	}
+	return c.quoteService, nil
+}
+
+// GetLegalEntityRepository returns the legal entity repository instance, creating it if necessary
+func (c *Container) GetLegalEntityRepository() (repository.LegalEntityRepository, error) {
+	c.legalEntityRepoOnce.Do(func() {
+		storage, err := c.GetStorage()
+		if err != nil {
+			c.setError("legal_entity_repository", NewProviderError("legal_entity_repository", err))
+			return
+		}
+		repo, err := LegalEntityRepositoryProvider(storage)
+		if err != nil {
+			c.setError("legal_entity_repository", err)
+			return
+		}
+		c.legalEntityRepo = repo
+	})
+
+	if err := c.getError("legal_entity_repository"); err != nil {
+		return nil, err
+	}
+	return c.legalEntityRepo, nil
+}
+
+// GetLegalEntityService returns the legal entity service instance, creating it if necessary
+func (c *Container) GetLegalEntityService() (*application.LegalEntityService, error) {
+	c.legalEntityServiceOnce.Do(func() {
+		entityRepo, err := c.GetLegalEntityRepository()
+		if err != nil {
+			c.setError("legal_entity_service", NewProviderError("legal_entity_service", err))
+			return
+		}
+		auditService, err := c.GetAuditService()
+		if err != nil {
+			c.setError("legal_entity_service", NewProviderError("legal_entity_service", err))
+			return
+		}
+		c.legalEntityService = LegalEntityServiceProvider(entityRepo, auditService)
+	})
+
+	if err := c.getError("legal_entity_service"); err != nil {
+		return nil, err
+	}
+	return c.legalEntityService, nil
+}
+
+// GetDocumentTemplateRepository returns the document template repository instance, creating it if necessary
+func (c *Container) GetDocumentTemplateRepository() (repository.DocumentTemplateRepository, error) {
+	c.documentRepoOnce.Do(func() {
+		storage, err := c.GetStorage()
+		if err != nil {
+			c.setError("document_template_repository", NewProviderError("document_template_repository", err))
+			return
+		}
+		repo, err := DocumentTemplateRepositoryProvider(storage)
+		if err != nil {
+			c.setError("document_template_repository", err)
+			return
+		}
+		c.documentRepo = repo
+	})
+
+	if err := c.getError("document_template_repository"); err != nil {
+		return nil, err
+	}
+	return c.documentRepo, nil
+}
+
+// GetDocumentTemplateService returns the document template service instance, creating it if necessary
+func (c *Container) GetDocumentTemplateService() (*application.DocumentTemplateService, error) {
+	c.documentServiceOnce.Do(func() {
+		templateRepo, err := c.GetDocumentTemplateRepository()
+		if err != nil {
+			c.setError("document_template_service", NewProviderError("document_template_service", err))
+			return
+		}
+		auditService, err := c.GetAuditService()
+		if err != nil {
+			c.setError("document_template_service", NewProviderError("document_template_service", err))
+			return
+		}
+		legalEntityService, err := c.GetLegalEntityService()
+		if err != nil {
+			c.setError("document_template_service", NewProviderError("document_template_service", err))
+			return
+		}
+		c.documentService = DocumentTemplateServiceProvider(templateRepo, auditService, legalEntityService, c.config)
+	})
+
+	if err := c.getError("document_template_service"); err != nil {
+		return nil, err
+	}
+	return c.documentService, nil
+}
+
+// GetClientCreditLimitRepository returns the client credit limit repository instance, creating it if necessary
+func (c *Container) GetClientCreditLimitRepository() (repository.ClientCreditLimitRepository, error) {
+	c.creditLimitRepoOnce.Do(func() {
+		storage, err := c.GetStorage()
+		if err != nil {
+			c.setError("client_credit_limit_repository", NewProviderError("client_credit_limit_repository", err))
+			return
+		}
+		repo, err := ClientCreditLimitRepositoryProvider(storage)
+		if err != nil {
+			c.setError("client_credit_limit_repository", err)
+			return
+		}
+		c.creditLimitRepo = repo
+	})
+
+	if err := c.getError("client_credit_limit_repository"); err != nil {
+		return nil, err
+	}
+	return c.creditLimitRepo, nil
+}
+
+// GetCreditControlService returns the credit control service instance, creating it if necessary
+func (c *Container) GetCreditControlService() (*application.CreditControlService, error) {
+	c.creditServiceOnce.Do(func() {
+		limitRepo, err := c.GetClientCreditLimitRepository()
+		if err != nil {
+			c.setError("credit_control_service", NewProviderError("credit_control_service", err))
+			return
+		}
+		billingService, err := c.GetBillingService()
+		if err != nil {
+			c.setError("credit_control_service", NewProviderError("credit_control_service", err))
+			return
+		}
+		auditService, err := c.GetAuditService()
+		if err != nil {
+			c.setError("credit_control_service", NewProviderError("credit_control_service", err))
+			return
+		}
+		approvalService, err := c.GetApprovalService()
+		if err != nil {
+			c.setError("credit_control_service", NewProviderError("credit_control_service", err))
+			return
+		}
+		c.creditService = CreditControlServiceProvider(limitRepo, billingService, auditService, approvalService)
+	})
+
+	if err := c.getError("credit_control_service"); err != nil {
+		return nil, err
+	}
+	return c.creditService, nil
+}
+
+// GetClientStatementJobRepository returns the client statement job repository instance, creating it if necessary
+func (c *Container) GetClientStatementJobRepository() (repository.ClientStatementJobRepository, error) {
+	c.statementJobRepoOnce.Do(func() {
+		storage, err := c.GetStorage()
+		if err != nil {
+			c.setError("client_statement_job_repository", NewProviderError("client_statement_job_repository", err))
+			return
+		}
+		repo, err := ClientStatementJobRepositoryProvider(storage)
+		if err != nil {
+			c.setError("client_statement_job_repository", err)
+			return
+		}
+		c.statementJobRepo = repo
+	})
+
+	if err := c.getError("client_statement_job_repository"); err != nil {
+		return nil, err
+	}
+	return c.statementJobRepo, nil
+}
+
+// GetClientStatementService returns the client statement service instance, creating it if necessary
+func (c *Container) GetClientStatementService() (*application.ClientStatementService, error) {
+	c.statementServiceOnce.Do(func() {
+		jobRepo, err := c.GetClientStatementJobRepository()
+		if err != nil {
+			c.setError("client_statement_service", NewProviderError("client_statement_service", err))
+			return
+		}
+		billingService, err := c.GetBillingService()
+		if err != nil {
+			c.setError("client_statement_service", NewProviderError("client_statement_service", err))
+			return
+		}
+		documentService, err := c.GetDocumentTemplateService()
+		if err != nil {
+			c.setError("client_statement_service", NewProviderError("client_statement_service", err))
+			return
+		}
+		legalEntityService, err := c.GetLegalEntityService()
+		if err != nil {
+			c.setError("client_statement_service", NewProviderError("client_statement_service", err))
+			return
+		}
+		currencies, err := CurrencyPoliciesProvider(c.config)
+		if err != nil {
+			c.setError("client_statement_service", NewProviderError("client_statement_service", err))
+			return
+		}
+		publisher, err := c.GetIntegrationPublisher()
+		if err != nil {
+			c.setError("client_statement_service", NewProviderError("client_statement_service", err))
+			return
+		}
+		c.statementService = ClientStatementServiceProvider(jobRepo, billingService, documentService, legalEntityService, currencies, publisher)
+	})
+
+	if err := c.getError("client_statement_service"); err != nil {
+		return nil, err
+	}
+	return c.statementService, nil
+}
+
+// GetExternalReferenceRepository returns the external reference repository instance, creating it if necessary
+func (c *Container) GetExternalReferenceRepository() (repository.ExternalReferenceRepository, error) {
+	c.externalRefRepoOnce.Do(func() {
+		storage, err := c.GetStorage()
+		if err != nil {
+			c.setError("external_reference_repository", NewProviderError("external_reference_repository", err))
+			return
+		}
+		repo, err := ExternalReferenceRepositoryProvider(storage)
+		if err != nil {
+			c.setError("external_reference_repository", err)
+			return
+		}
+		c.externalRefRepo = repo
+	})
+
+	if err := c.getError("external_reference_repository"); err != nil {
+		return nil, err
+	}
+	return c.externalRefRepo, nil
+}
+
+// GetInvoiceRepository returns the invoice repository instance, creating it if necessary
+func (c *Container) GetInvoiceRepository() (repository.InvoiceRepository, error) {
+	c.invoiceRepoOnce.Do(func() {
+		storage, err := c.GetStorage()
+		if err != nil {
+			c.setError("invoice_repository", NewProviderError("invoice_repository", err))
+			return
+		}
+		repo, err := InvoiceRepositoryProvider(storage)
+		if err != nil {
+			c.setError("invoice_repository", err)
+			return
+		}
+		c.invoiceRepo = repo
+	})
+
+	if err := c.getError("invoice_repository"); err != nil {
+		return nil, err
+	}
+	return c.invoiceRepo, nil
+}
+
+// GetPaymentRepository returns the payment repository instance, creating it if necessary
+func (c *Container) GetPaymentRepository() (repository.PaymentRepository, error) {
+	c.paymentRepoOnce.Do(func() {
+		storage, err := c.GetStorage()
+		if err != nil {
+			c.setError("payment_repository", NewProviderError("payment_repository", err))
+			return
+		}
+		repo, err := PaymentRepositoryProvider(storage)
+		if err != nil {
+			c.setError("payment_repository", err)
+			return
+		}
+		c.paymentRepo = repo
+	})
+
+	if err := c.getError("payment_repository"); err != nil {
+		return nil, err
+	}
+	return c.paymentRepo, nil
+}
+
+// GetClientSummaryRepository returns the client summary repository instance, creating it if necessary
+func (c *Container) GetClientSummaryRepository() (repository.ClientSummaryRepository, error) {
+	c.clientSummaryRepoOnce.Do(func() {
+		storage, err := c.GetStorage()
+		if err != nil {
+			c.setError("client_summary_repository", NewProviderError("client_summary_repository", err))
+			return
+		}
+		repo, err := ClientSummaryRepositoryProvider(storage)
+		if err != nil {
+			c.setError("client_summary_repository", err)
+			return
+		}
+		c.clientSummaryRepo = repo
+	})
+
+	if err := c.getError("client_summary_repository"); err != nil {
+		return nil, err
+	}
+	return c.clientSummaryRepo, nil
+}
+
+// GetClientContactRepository returns the client contact repository instance, creating it if necessary
+func (c *Container) GetClientContactRepository() (repository.ClientContactRepository, error) {
+	c.clientContactRepoOnce.Do(func() {
+		storage, err := c.GetStorage()
+		if err != nil {
+			c.setError("client_contact_repository", NewProviderError("client_contact_repository", err))
+			return
+		}
+		repo, err := ClientContactRepositoryProvider(storage)
+		if err != nil {
+			c.setError("client_contact_repository", err)
+			return
+		}
+		c.clientContactRepo = repo
+	})
+
+	if err := c.getError("client_contact_repository"); err != nil {
+		return nil, err
+	}
+	return c.clientContactRepo, nil
+}
+
+// GetClientNoteRepository returns the client note repository instance, creating it if necessary
+func (c *Container) GetClientNoteRepository() (repository.ClientNoteRepository, error) {
+	c.clientNoteRepoOnce.Do(func() {
+		storage, err := c.GetStorage()
+		if err != nil {
+			c.setError("client_note_repository", NewProviderError("client_note_repository", err))
+			return
+		}
+		repo, err := ClientNoteRepositoryProvider(storage)
+		if err != nil {
+			c.setError("client_note_repository", err)
+			return
+		}
+		c.clientNoteRepo = repo
+	})
+
+	if err := c.getError("client_note_repository"); err != nil {
+		return nil, err
+	}
+	return c.clientNoteRepo, nil
+}
+
+// GetClientTombstoneRepository returns the client tombstone repository instance, creating it if necessary
+func (c *Container) GetClientTombstoneRepository() (repository.ClientTombstoneRepository, error) {
+	c.clientTombstoneRepoOnce.Do(func() {
+		storage, err := c.GetStorage()
+		if err != nil {
+			c.setError("client_tombstone_repository", NewProviderError("client_tombstone_repository", err))
+			return
+		}
+		repo, err := ClientTombstoneRepositoryProvider(storage)
+		if err != nil {
+			c.setError("client_tombstone_repository", err)
+			return
+		}
+		c.clientTombstoneRepo = repo
+	})
+
+	if err := c.getError("client_tombstone_repository"); err != nil {
+		return nil, err
+	}
+	return c.clientTombstoneRepo, nil
+}
+
+// GetExternalReferenceService returns the external reference service instance, creating it if necessary
+func (c *Container) GetExternalReferenceService() (*application.ExternalReferenceService, error) {
+	c.externalRefServiceOnce.Do(func() {
+		referenceRepo, err := c.GetExternalReferenceRepository()
+		if err != nil {
+			c.setError("external_reference_service", NewProviderError("external_reference_service", err))
+			return
+		}
+		c.externalRefService = ExternalReferenceServiceProvider(referenceRepo)
+	})
+
+	if err := c.getError("external_reference_service"); err != nil {
+		return nil, err
+	}
+	return c.externalRefService, nil
+}
+
+// GetRiskAssessmentRepository returns the risk assessment repository instance, creating it if necessary
+func (c *Container) GetRiskAssessmentRepository() (repository.RiskAssessmentRepository, error) {
+	c.riskRepoOnce.Do(func() {
+		storage, err := c.GetStorage()
+		if err != nil {
+			c.setError("risk_assessment_repository", NewProviderError("risk_assessment_repository", err))
+			return
+		}
+		repo, err := RiskAssessmentRepositoryProvider(storage)
+		if err != nil {
+			c.setError("risk_assessment_repository", err)
+			return
+		}
+		c.riskRepo = repo
+	})
+
+	if err := c.getError("risk_assessment_repository"); err != nil {
+		return nil, err
+	}
+	return c.riskRepo, nil
+}
+
+// GetRiskScoringService returns the risk scoring service instance, creating it if necessary
+func (c *Container) GetRiskScoringService() (*application.RiskScoringService, error) {
+	c.riskServiceOnce.Do(func() {
+		assessmentRepo, err := c.GetRiskAssessmentRepository()
+		if err != nil {
+			c.setError("risk_scoring_service", NewProviderError("risk_scoring_service", err))
+			return
+		}
+		clients, err := c.GetOutboundClients()
+		if err != nil {
+			c.setError("risk_scoring_service", NewProviderError("risk_scoring_service", err))
+			return
+		}
+		c.riskService = RiskScoringServiceProvider(assessmentRepo, clients, c.config)
+	})
+
+	if err := c.getError("risk_scoring_service"); err != nil {
+		return nil, err
+	}
+	return c.riskService, nil
+}
+
+// GetInvoiceDeliveryEventRepository returns the invoice delivery event repository instance, creating it if necessary
+func (c *Container) GetInvoiceDeliveryEventRepository() (repository.InvoiceDeliveryEventRepository, error) {
+	c.deliveryRepoOnce.Do(func() {
+		storage, err := c.GetStorage()
+		if err != nil {
+			c.setError("invoice_delivery_event_repository", NewProviderError("invoice_delivery_event_repository", err))
+			return
+		}
+		repo, err := InvoiceDeliveryEventRepositoryProvider(storage)
+		if err != nil {
+			c.setError("invoice_delivery_event_repository", err)
+			return
+		}
+		c.deliveryRepo = repo
+	})
+
+	if err := c.getError("invoice_delivery_event_repository"); err != nil {
+		return nil, err
+	}
+	return c.deliveryRepo, nil
+}
+
+// GetInvoiceDeliveryService returns the invoice delivery service instance, creating it if necessary
+func (c *Container) GetInvoiceDeliveryService() (*application.InvoiceDeliveryService, error) {
+	c.deliveryServiceOnce.Do(func() {
+		eventRepo, err := c.GetInvoiceDeliveryEventRepository()
+		if err != nil {
+			c.setError("invoice_delivery_service", NewProviderError("invoice_delivery_service", err))
+			return
+		}
+		publisher, err := c.GetIntegrationPublisher()
+		if err != nil {
+			c.setError("invoice_delivery_service", NewProviderError("invoice_delivery_service", err))
+			return
+		}
+		outboxService, err := c.GetEmailOutboxService()
+		if err != nil {
+			c.setError("invoice_delivery_service", NewProviderError("invoice_delivery_service", err))
+			return
+		}
+		c.deliveryService = InvoiceDeliveryServiceProvider(eventRepo, publisher, outboxService, c.config)
+	})
+
+	if err := c.getError("invoice_delivery_service"); err != nil {
+		return nil, err
+	}
+	return c.deliveryService, nil
+}
+
+// GetDunningPolicyRepository returns the dunning policy repository instance, creating it if necessary
+func (c *Container) GetDunningPolicyRepository() (repository.DunningPolicyRepository, error) {
+	c.dunningRepoOnce.Do(func() {
+		storage, err := c.GetStorage()
+		if err != nil {
+			c.setError("dunning_policy_repository", NewProviderError("dunning_policy_repository", err))
+			return
+		}
+		repo, err := DunningPolicyRepositoryProvider(storage)
+		if err != nil {
+			c.setError("dunning_policy_repository", err)
+			return
+		}
+		c.dunningRepo = repo
+	})
+
+	if err := c.getError("dunning_policy_repository"); err != nil {
+		return nil, err
+	}
+	return c.dunningRepo, nil
+}
+
+// GetDunningPolicyService returns the dunning policy service instance, creating it if necessary
+func (c *Container) GetDunningPolicyService() (*application.DunningPolicyService, error) {
+	c.dunningServiceOnce.Do(func() {
+		policyRepo, err := c.GetDunningPolicyRepository()
+		if err != nil {
+			c.setError("dunning_policy_service", NewProviderError("dunning_policy_service", err))
+			return
+		}
+		auditService, err := c.GetAuditService()
+		if err != nil {
+			c.setError("dunning_policy_service", NewProviderError("dunning_policy_service", err))
+			return
+		}
+		c.dunningService = DunningPolicyServiceProvider(policyRepo, auditService)
+	})
+
+	if err := c.getError("dunning_policy_service"); err != nil {
+		return nil, err
+	}
+	return c.dunningService, nil
+}
+
+// GetTenantRuleSetRepository returns the tenant rule set repository instance, creating it if necessary
+func (c *Container) GetTenantRuleSetRepository() (repository.TenantRuleSetRepository, error) {
+	c.tenantRuleRepoOnce.Do(func() {
+		storage, err := c.GetStorage()
+		if err != nil {
+			c.setError("tenant_rule_set_repository", NewProviderError("tenant_rule_set_repository", err))
+			return
+		}
+		repo, err := TenantRuleSetRepositoryProvider(storage)
+		if err != nil {
+			c.setError("tenant_rule_set_repository", err)
+			return
+		}
+		c.tenantRuleRepo = repo
+	})
+
+	if err := c.getError("tenant_rule_set_repository"); err != nil {
+		return nil, err
+	}
+	return c.tenantRuleRepo, nil
+}
+
+// GetTenantRuleService returns the tenant rule service instance, creating it if necessary
+func (c *Container) GetTenantRuleService() (*application.TenantRuleService, error) {
+	c.tenantRuleServiceOnce.Do(func() {
+		ruleRepo, err := c.GetTenantRuleSetRepository()
+		if err != nil {
+			c.setError("tenant_rule_service", NewProviderError("tenant_rule_service", err))
+			return
+		}
+		auditService, err := c.GetAuditService()
+		if err != nil {
+			c.setError("tenant_rule_service", NewProviderError("tenant_rule_service", err))
+			return
+		}
+		c.tenantRuleService = TenantRuleServiceProvider(ruleRepo, auditService)
+	})
+
+	if err := c.getError("tenant_rule_service"); err != nil {
+		return nil, err
+	}
+	return c.tenantRuleService, nil
+}
+
+// GetDunningService returns the dunning service instance, creating it if necessary
+func (c *Container) GetDunningService() (*application.DunningService, error) {
+	c.dunningRunServiceOnce.Do(func() {
+		billingService, err := c.GetBillingService()
+		if err != nil {
+			c.setError("dunning_service", NewProviderError("dunning_service", err))
+			return
+		}
+		policyService, err := c.GetDunningPolicyService()
+		if err != nil {
+			c.setError("dunning_service", NewProviderError("dunning_service", err))
+			return
+		}
+		publisher, err := c.GetIntegrationPublisher()
+		if err != nil {
+			c.setError("dunning_service", NewProviderError("dunning_service", err))
+			return
+		}
+		lateFees, err := LateFeePolicyProvider(c.config)
+		if err != nil {
+			c.setError("dunning_service", err)
+			return
+		}
+		auditService, err := c.GetAuditService()
+		if err != nil {
+			c.setError("dunning_service", NewProviderError("dunning_service", err))
+			return
+		}
+		c.dunningRunService = DunningServiceProvider(billingService, policyService, publisher, lateFees, auditService)
+	})
+
+	if err := c.getError("dunning_service"); err != nil {
+		return nil, err
+	}
+	return c.dunningRunService, nil
+}
+
+// GetFiscalCalendarRepository returns the fiscal calendar repository instance, creating it if necessary
+func (c *Container) GetFiscalCalendarRepository() (repository.FiscalCalendarRepository, error) {
+	c.fiscalCalendarRepoOnce.Do(func() {
+		storage, err := c.GetStorage()
+		if err != nil {
+			c.setError("fiscal_calendar_repository", NewProviderError("fiscal_calendar_repository", err))
+			return
+		}
+		repo, err := FiscalCalendarRepositoryProvider(storage)
+		if err != nil {
+			c.setError("fiscal_calendar_repository", err)
+			return
+		}
+		c.fiscalCalendarRepo = repo
+	})
+
+	if err := c.getError("fiscal_calendar_repository"); err != nil {
+		return nil, err
+	}
+	return c.fiscalCalendarRepo, nil
+}
+
+// GetFiscalCalendarService returns the fiscal calendar service instance, creating it if necessary
+func (c *Container) GetFiscalCalendarService() (*application.FiscalCalendarService, error) {
+	c.fiscalCalendarServiceOnce.Do(func() {
+		calendarRepo, err := c.GetFiscalCalendarRepository()
+		if err != nil {
+			c.setError("fiscal_calendar_service", NewProviderError("fiscal_calendar_service", err))
+			return
+		}
+		auditService, err := c.GetAuditService()
+		if err != nil {
+			c.setError("fiscal_calendar_service", NewProviderError("fiscal_calendar_service", err))
+			return
+		}
+		c.fiscalCalendarService = FiscalCalendarServiceProvider(calendarRepo, auditService)
+	})
+
+	if err := c.getError("fiscal_calendar_service"); err != nil {
+		return nil, err
+	}
+	return c.fiscalCalendarService, nil
+}
+
+// GetBankTransactionRepository returns the bank transaction repository instance, creating it if necessary
+func (c *Container) GetBankTransactionRepository() (repository.BankTransactionRepository, error) {
+	c.bankTxRepoOnce.Do(func() {
+		storage, err := c.GetStorage()
+		if err != nil {
+			c.setError("bank_transaction_repository", NewProviderError("bank_transaction_repository", err))
+			return
+		}
+		repo, err := BankTransactionRepositoryProvider(storage)
+		if err != nil {
+			c.setError("bank_transaction_repository", err)
+			return
+		}
+		c.bankTxRepo = repo
+	})
+
+	if err := c.getError("bank_transaction_repository"); err != nil {
+		return nil, err
+	}
+	return c.bankTxRepo, nil
+}
+
+// GetCashApplicationService returns the cash application service instance, creating it if necessary
+func (c *Container) GetCashApplicationService() (*application.CashApplicationService, error) {
+	c.cashServiceOnce.Do(func() {
+		transactionRepo, err := c.GetBankTransactionRepository()
+		if err != nil {
+			c.setError("cash_application_service", NewProviderError("cash_application_service", err))
+			return
+		}
+		auditService, err := c.GetAuditService()
+		if err != nil {
+			c.setError("cash_application_service", NewProviderError("cash_application_service", err))
+			return
+		}
+		billingService, err := c.GetBillingService()
+		if err != nil {
+			c.setError("cash_application_service", NewProviderError("cash_application_service", err))
+			return
+		}
+		c.cashService = CashApplicationServiceProvider(transactionRepo, auditService, billingService)
+	})
+
+	if err := c.getError("cash_application_service"); err != nil {
+		return nil, err
+	}
+	return c.cashService, nil
+}
+
+// GetPayoutReconciliationRepository returns the payout reconciliation repository instance, creating it if necessary
+func (c *Container) GetPayoutReconciliationRepository() (repository.PayoutReconciliationRepository, error) {
+	c.payoutRepoOnce.Do(func() {
+		storage, err := c.GetStorage()
+		if err != nil {
+			c.setError("payout_reconciliation_repository", NewProviderError("payout_reconciliation_repository", err))
+			return
+		}
+		repo, err := PayoutReconciliationRepositoryProvider(storage)
+		if err != nil {
+			c.setError("payout_reconciliation_repository", err)
+			return
+		}
+		c.payoutRepo = repo
+	})
+
+	if err := c.getError("payout_reconciliation_repository"); err != nil {
+		return nil, err
+	}
+	return c.payoutRepo, nil
+}
+
+// GetPayoutReconciliationService returns the payout reconciliation service instance, creating it if necessary
+func (c *Container) GetPayoutReconciliationService() (*application.PayoutReconciliationService, error) {
+	c.payoutServiceOnce.Do(func() {
+		reportRepo, err := c.GetPayoutReconciliationRepository()
+		if err != nil {
+			c.setError("payout_reconciliation_service", NewProviderError("payout_reconciliation_service", err))
+			return
+		}
+		clients, err := c.GetOutboundClients()
+		if err != nil {
+			c.setError("payout_reconciliation_service", NewProviderError("payout_reconciliation_service", err))
+			return
+		}
+		c.payoutService = PayoutReconciliationServiceProvider(reportRepo, clients, c.config)
+	})
+
+	if err := c.getError("payout_reconciliation_service"); err != nil {
+		return nil, err
+	}
+	return c.payoutService, nil
+}
+
+// GetWebhookEventRepository returns the webhook event repository instance, creating it if necessary
+func (c *Container) GetWebhookEventRepository() (repository.WebhookEventRepository, error) {
+	c.webhookEventRepoOnce.Do(func() {
+		storage, err := c.GetStorage()
+		if err != nil {
+			c.setError("webhook_event_repository", NewProviderError("webhook_event_repository", err))
+			return
+		}
+		repo, err := WebhookEventRepositoryProvider(storage)
+		if err != nil {
+			c.setError("webhook_event_repository", err)
+			return
+		}
+		c.webhookEventRepo = repo
+	})
+
+	if err := c.getError("webhook_event_repository"); err != nil {
+		return nil, err
+	}
+	return c.webhookEventRepo, nil
+}
+
+// GetWebhookService returns the webhook service instance, creating it if necessary
+func (c *Container) GetWebhookService() (*application.WebhookService, error) {
+	c.webhookServiceOnce.Do(func() {
+		eventRepo, err := c.GetWebhookEventRepository()
+		if err != nil {
+			c.setError("webhook_service", NewProviderError("webhook_service", err))
+			return
+		}
+		auditService, err := c.GetAuditService()
+		if err != nil {
+			c.setError("webhook_service", NewProviderError("webhook_service", err))
+			return
+		}
+		consumer, err := c.GetIdempotentConsumer()
+		if err != nil {
+			c.setError("webhook_service", NewProviderError("webhook_service", err))
+			return
+		}
+		publisher, err := c.recordEvents(c.GetEventPublisher())
+		if err != nil {
+			c.setError("webhook_service", NewProviderError("webhook_service", err))
+			return
+		}
+		webhookService, err := WebhookServiceProvider(eventRepo, auditService, publisher, consumer, c.config)
+		if err != nil {
+			c.setError("webhook_service", err)
+			return
+		}
+		c.webhookService = webhookService
+	})
+
+	if err := c.getError("webhook_service"); err != nil {
+		return nil, err
+	}
+	return c.webhookService, nil
+}
+
+// GetWebhookSubscriptionRepository returns the webhook subscription repository instance, creating it if necessary
+func (c *Container) GetWebhookSubscriptionRepository() (repository.WebhookSubscriptionRepository, error) {
+	c.webhookSubscriptionRepoOnce.Do(func() {
+		storage, err := c.GetStorage()
+		if err != nil {
+			c.setError("webhook_subscription_repository", NewProviderError("webhook_subscription_repository", err))
+			return
+		}
+		repo, err := WebhookSubscriptionRepositoryProvider(storage)
+		if err != nil {
+			c.setError("webhook_subscription_repository", err)
+			return
+		}
+		c.webhookSubscriptionRepo = repo
+	})
+
+	if err := c.getError("webhook_subscription_repository"); err != nil {
+		return nil, err
+	}
+	return c.webhookSubscriptionRepo, nil
+}
+
+// GetWebhookSubscriptionService returns the webhook subscription service instance, creating it if necessary
+func (c *Container) GetWebhookSubscriptionService() (*application.WebhookSubscriptionService, error) {
+	c.webhookSubscriptionServiceOnce.Do(func() {
+		subscriptionRepo, err := c.GetWebhookSubscriptionRepository()
+		if err != nil {
+			c.setError("webhook_subscription_service", NewProviderError("webhook_subscription_service", err))
+			return
+		}
+		eventStore, err := c.GetEventStore()
+		if err != nil {
+			c.setError("webhook_subscription_service", NewProviderError("webhook_subscription_service", err))
+			return
+		}
+		clients, err := c.GetOutboundClients()
+		if err != nil {
+			c.setError("webhook_subscription_service", NewProviderError("webhook_subscription_service", err))
+			return
+		}
+		auditService, err := c.GetAuditService()
+		if err != nil {
+			c.setError("webhook_subscription_service", NewProviderError("webhook_subscription_service", err))
+			return
+		}
+		c.webhookSubscriptionService = WebhookSubscriptionServiceProvider(subscriptionRepo, eventStore, clients, auditService, c.config)
+	})
+
+	if err := c.getError("webhook_subscription_service"); err != nil {
+		return nil, err
+	}
+	return c.webhookSubscriptionService, nil
+}
+
+// GetFailedMessageRepository returns the failed message repository instance, creating it if necessary
+func (c *Container) GetFailedMessageRepository() (repository.FailedMessageRepository, error) {
+	c.failedMessageRepoOnce.Do(func() {
+		storage, err := c.GetStorage()
+		if err != nil {
+			c.setError("failed_message_repository", NewProviderError("failed_message_repository", err))
+			return
+		}
+		repo, err := FailedMessageRepositoryProvider(storage)
+		if err != nil {
+			c.setError("failed_message_repository", err)
+			return
+		}
+		c.failedMessageRepo = repo
+	})
+
+	if err := c.getError("failed_message_repository"); err != nil {
+		return nil, err
+	}
+	return c.failedMessageRepo, nil
+}
+
+// GetDeadLetterPublisher returns the integration event publisher parking failed messages, creating it if necessary
+func (c *Container) GetDeadLetterPublisher() (*application.DeadLetterPublisher, error) {
+	c.deadLetterPublisherOnce.Do(func() {
+		messageRepo, err := c.GetFailedMessageRepository()
+		if err != nil {
+			c.setError("dead_letter_publisher", NewProviderError("dead_letter_publisher", err))
+			return
+		}
+		c.deadLetterPublisher = DeadLetterPublisherProvider(c.GetEventPublisher(), messageRepo)
+	})
+
+	if err := c.getError("dead_letter_publisher"); err != nil {
+		return nil, err
+	}
+	return c.deadLetterPublisher, nil
+}
+
+// GetEventStoreRepository returns the event store repository instance, creating it if necessary
+func (c *Container) GetEventStoreRepository() (repository.EventStoreRepository, error) {
+	c.eventStoreRepoOnce.Do(func() {
+		storage, err := c.GetStorage()
+		if err != nil {
+			c.setError("event_store_repository", NewProviderError("event_store_repository", err))
+			return
+		}
+		repo, err := EventStoreRepositoryProvider(storage)
+		if err != nil {
+			c.setError("event_store_repository", err)
+			return
+		}
+		c.eventStoreRepo = repo
+	})
+
+	if err := c.getError("event_store_repository"); err != nil {
+		return nil, err
+	}
+	return c.eventStoreRepo, nil
+}
+
+// GetEventStore returns the event store, creating it if necessary
+// Returns nil when the event store is disabled
+func (c *Container) GetEventStore() (*application.EventStore, error) {
+	c.eventStoreOnce.Do(func() {
+		if !c.config.EventStoreEnabled {
+			return
+		}
+		eventRepo, err := c.GetEventStoreRepository()
+		if err != nil {
+			c.setError("event_store", NewProviderError("event_store", err))
+			return
+		}
+		c.eventStore = EventStoreProvider(eventRepo)
+	})
+
+	if err := c.getError("event_store"); err != nil {
+		return nil, err
+	}
+	return c.eventStore, nil
+}
+
+// GetPartitionMaintenanceService returns the partition maintenance service, creating it if necessary
+func (c *Container) GetPartitionMaintenanceService() (*application.PartitionMaintenanceService, error) {
+	c.partitionServiceOnce.Do(func() {
+		storage, err := c.GetStorage()
+		if err != nil {
+			c.setError("partition_maintenance_service", NewProviderError("partition_maintenance_service", err))
+			return
+		}
+		service, err := PartitionMaintenanceServiceProvider(storage, c.config)
+		if err != nil {
+			c.setError("partition_maintenance_service", err)
+			return
+		}
+		c.partitionService = service
+	})
+
+	if err := c.getError("partition_maintenance_service"); err != nil {
+		return nil, err
+	}
+	return c.partitionService, nil
+}
+
+// GetInvoiceArchiveRepository returns the invoice archive repository instance, creating it if necessary
+func (c *Container) GetInvoiceArchiveRepository() (repository.InvoiceArchiveRepository, error) {
+	c.invoiceArchiveRepoOnce.Do(func() {
+		storage, err := c.GetStorage()
+		if err != nil {
+			c.setError("invoice_archive_repository", NewProviderError("invoice_archive_repository", err))
+			return
+		}
+		repo, err := InvoiceArchiveRepositoryProvider(storage)
+		if err != nil {
+			c.setError("invoice_archive_repository", err)
+			return
+		}
+		c.invoiceArchiveRepo = repo
+	})
+
+	if err := c.getError("invoice_archive_repository"); err != nil {
+		return nil, err
+	}
+	return c.invoiceArchiveRepo, nil
+}
+
+// GetInvoiceArchiveService returns the invoice archive service, creating it if necessary
+func (c *Container) GetInvoiceArchiveService() (*application.InvoiceArchiveService, error) {
+	c.invoiceArchiveOnce.Do(func() {
+		invoiceRepo, err := c.GetInvoiceRepository()
+		if err != nil {
+			c.setError("invoice_archive_service", NewProviderError("invoice_archive_service", err))
+			return
+		}
+		paymentRepo, err := c.GetPaymentRepository()
+		if err != nil {
+			c.setError("invoice_archive_service", NewProviderError("invoice_archive_service", err))
+			return
+		}
+		archiveRepo, err := c.GetInvoiceArchiveRepository()
+		if err != nil {
+			c.setError("invoice_archive_service", NewProviderError("invoice_archive_service", err))
+			return
+		}
+		c.invoiceArchiveService = InvoiceArchiveServiceProvider(invoiceRepo, paymentRepo, archiveRepo, c.config)
+	})
+
+	if err := c.getError("invoice_archive_service"); err != nil {
+		return nil, err
+	}
+	return c.invoiceArchiveService, nil
+}
+
+// GetUploadRepository returns the upload repository instance, creating it if necessary
+func (c *Container) GetUploadRepository() (repository.UploadRepository, error) {
+	c.uploadRepoOnce.Do(func() {
+		storage, err := c.GetStorage()
+		if err != nil {
+			c.setError("upload_repository", NewProviderError("upload_repository", err))
+			return
+		}
+		repo, err := UploadRepositoryProvider(storage)
+		if err != nil {
+			c.setError("upload_repository", err)
+			return
+		}
+		c.uploadRepo = repo
+	})
+
+	if err := c.getError("upload_repository"); err != nil {
+		return nil, err
+	}
+	return c.uploadRepo, nil
+}
+
+// GetObjectStore returns the object store instance, creating it if necessary
+func (c *Container) GetObjectStore() (repository.ObjectStore, error) {
+	c.objectStoreOnce.Do(func() {
+		storage, err := c.GetStorage()
+		if err != nil {
+			c.setError("object_store", NewProviderError("object_store", err))
+			return
+		}
+		objects, err := ObjectStoreProvider(storage)
+		if err != nil {
+			c.setError("object_store", err)
+			return
+		}
+		c.objectStore = objects
+	})
+
+	if err := c.getError("object_store"); err != nil {
+		return nil, err
+	}
+	return c.objectStore, nil
+}
+
+// GetUploadService returns the resumable upload service, creating it if necessary
+func (c *Container) GetUploadService() (*application.UploadService, error) {
+	c.uploadServiceOnce.Do(func() {
+		uploadRepo, err := c.GetUploadRepository()
+		if err != nil {
+			c.setError("upload_service", NewProviderError("upload_service", err))
+			return
+		}
+		objects, err := c.GetObjectStore()
+		if err != nil {
+			c.setError("upload_service", NewProviderError("upload_service", err))
+			return
+		}
+		cashService, err := c.GetCashApplicationService()
+		if err != nil {
+			c.setError("upload_service", NewProviderError("upload_service", err))
+			return
+		}
+		c.uploadService = UploadServiceProvider(uploadRepo, objects, cashService)
+	})
+
+	if err := c.getError("upload_service"); err != nil {
+		return nil, err
+	}
+	return c.uploadService, nil
+}
+
+// GetIntegrationPublisher returns the publisher services publish integration events through, creating it if necessary
+// Events go to the dead letter publisher, after being appended to the event store when it is enabled
+func (c *Container) GetIntegrationPublisher() (messaging.Publisher, error) {
+	c.integrationPublisherOnce.Do(func() {
+		deadLetterPublisher, err := c.GetDeadLetterPublisher()
+		if err != nil {
+			c.setError("integration_publisher", NewProviderError("integration_publisher", err))
+			return
+		}
+		publisher, err := c.recordEvents(deadLetterPublisher)
+		if err != nil {
+			c.setError("integration_publisher", NewProviderError("integration_publisher", err))
+			return
+		}
+		outboxService, err := c.GetEmailOutboxService()
+		if err != nil {
+			c.setError("integration_publisher", NewProviderError("integration_publisher", err))
+			return
+		}
+		c.integrationPublisher = outboxService.Publisher(publisher)
+	})
+
+	if err := c.getError("integration_publisher"); err != nil {
+		return nil, err
+	}
+	return c.integrationPublisher, nil
+}
+
+// GetOutboundEmailRepository returns the outbound email repository instance, creating it if necessary
+func (c *Container) GetOutboundEmailRepository() (repository.OutboundEmailRepository, error) {
+	c.outboundEmailRepoOnce.Do(func() {
+		storage, err := c.GetStorage()
+		if err != nil {
+			c.setError("outbound_email_repository", NewProviderError("outbound_email_repository", err))
+			return
+		}
+		repo, err := OutboundEmailRepositoryProvider(storage)
+		if err != nil {
+			c.setError("outbound_email_repository", err)
+			return
+		}
+		c.outboundEmailRepo = repo
+	})
+
+	if err := c.getError("outbound_email_repository"); err != nil {
+		return nil, err
+	}
+	return c.outboundEmailRepo, nil
+}
+
+// GetEmailSuppressionRepository returns the email suppression repository instance, creating it if necessary
+func (c *Container) GetEmailSuppressionRepository() (repository.EmailSuppressionRepository, error) {
+	c.emailSuppressionRepoOnce.Do(func() {
+		storage, err := c.GetStorage()
+		if err != nil {
+			c.setError("email_suppression_repository", NewProviderError("email_suppression_repository", err))
+			return
+		}
+		repo, err := EmailSuppressionRepositoryProvider(storage)
+		if err != nil {
+			c.setError("email_suppression_repository", err)
+			return
+		}
+		c.emailSuppressionRepo = repo
+	})
+
+	if err := c.getError("email_suppression_repository"); err != nil {
+		return nil, err
+	}
+	return c.emailSuppressionRepo, nil
+}
+
+// GetEmailOutboxService returns the email outbox service instance, creating it if necessary
+// Emails are dispatched through the event publisher itself: the outbox retries them, so they are never dead-lettered
+func (c *Container) GetEmailOutboxService() (*application.EmailOutboxService, error) {
+	c.emailOutboxServiceOnce.Do(func() {
+		emailRepo, err := c.GetOutboundEmailRepository()
+		if err != nil {
+			c.setError("email_outbox_service", NewProviderError("email_outbox_service", err))
+			return
+		}
+		suppressionRepo, err := c.GetEmailSuppressionRepository()
+		if err != nil {
+			c.setError("email_outbox_service", NewProviderError("email_outbox_service", err))
+			return
+		}
+		clientRepo, err := c.GetClientRepository()
+		if err != nil {
+			c.setError("email_outbox_service", NewProviderError("email_outbox_service", err))
+			return
+		}
+		mailer, err := c.recordEvents(c.GetEventPublisher())
+		if err != nil {
+			c.setError("email_outbox_service", NewProviderError("email_outbox_service", err))
+			return
+		}
+		c.emailOutboxService = EmailOutboxServiceProvider(emailRepo, suppressionRepo, clientRepo, mailer, c.config)
+	})
+
+	if err := c.getError("email_outbox_service"); err != nil {
+		return nil, err
+	}
+	return c.emailOutboxService, nil
+}
+
+// recordEvents wraps publisher so the events it publishes are appended to the event store, when it is enabled
+// Dead letter retries go through the unwrapped publisher, so a retried event is not stored twice
+func (c *Container) recordEvents(publisher messaging.Publisher) (messaging.Publisher, error) {
+	eventStore, err := c.GetEventStore()
+	if err != nil || eventStore == nil {
+		return publisher, err
+	}
+	return eventStore.Publisher(publisher), nil
+}
+
+// GetDeadLetterService returns the dead letter service instance, creating it if necessary
+func (c *Container) GetDeadLetterService() (*application.DeadLetterService, error) {
+	c.deadLetterServiceOnce.Do(func() {
+		auditService, err := c.GetAuditService()
+		if err != nil {
+			c.setError("dead_letter_service", NewProviderError("dead_letter_service", err))
+			return
+		}
+		publisher, err := c.GetDeadLetterPublisher()
+		if err != nil {
+			c.setError("dead_letter_service", NewProviderError("dead_letter_service", err))
+			return
+		}
+		webhookService, err := c.GetWebhookService()
+		if err != nil {
+			c.setError("dead_letter_service", NewProviderError("dead_letter_service", err))
+			return
+		}
+		c.deadLetterService = DeadLetterServiceProvider(auditService, publisher, webhookService)
+	})
+
+	if err := c.getError("dead_letter_service"); err != nil {
+		return nil, err
+	}
+	return c.deadLetterService, nil
+}
+
+// GetDashboardService returns the operational dashboard service instance, creating it if necessary
+func (c *Container) GetDashboardService() (*application.DashboardService, error) {
+	c.dashboardServiceOnce.Do(func() {
+		billingService, err := c.GetBillingService()
+		if err != nil {
+			c.setError("dashboard_service", NewProviderError("dashboard_service", err))
+			return
+		}
+		emailOutboxService, err := c.GetEmailOutboxService()
+		if err != nil {
+			c.setError("dashboard_service", NewProviderError("dashboard_service", err))
+			return
+		}
+		webhookService, err := c.GetWebhookService()
+		if err != nil {
+			c.setError("dashboard_service", NewProviderError("dashboard_service", err))
+			return
+		}
+		statementService, err := c.GetClientStatementService()
+		if err != nil {
+			c.setError("dashboard_service", NewProviderError("dashboard_service", err))
+			return
+		}
+		deadLetterService, err := c.GetDeadLetterService()
+		if err != nil {
+			c.setError("dashboard_service", NewProviderError("dashboard_service", err))
+			return
+		}
+		c.dashboardService = DashboardServiceProvider(billingService, emailOutboxService, webhookService, statementService, deadLetterService)
+	})
+
+	if err := c.getError("dashboard_service"); err != nil {
+		return nil, err
+	}
+	return c.dashboardService, nil
+}
+
+// GetProcessedMessageRepository returns the processed message repository instance, creating it if necessary
+func (c *Container) GetProcessedMessageRepository() (repository.ProcessedMessageRepository, error) {
+	c.processedMessageRepoOnce.Do(func() {
+		storage, err := c.GetStorage()
+		if err != nil {
+			c.setError("processed_message_repository", NewProviderError("processed_message_repository", err))
+			return
+		}
+		repo, err := ProcessedMessageRepositoryProvider(storage)
+		if err != nil {
+			c.setError("processed_message_repository", err)
+			return
+		}
+		c.processedMessageRepo = repo
+	})
+
+	if err := c.getError("processed_message_repository"); err != nil {
+		return nil, err
+	}
+	return c.processedMessageRepo, nil
+}
+
+// GetIdempotentConsumer returns the idempotent consumer instance, creating it if necessary
+func (c *Container) GetIdempotentConsumer() (*application.IdempotentConsumer, error) {
+	c.idempotentConsumerOnce.Do(func() {
+		messageRepo, err := c.GetProcessedMessageRepository()
+		if err != nil {
+			c.setError("idempotent_consumer", NewProviderError("idempotent_consumer", err))
+			return
+		}
+		c.idempotentConsumer = IdempotentConsumerProvider(messageRepo, c.config)
+	})
+
+	if err := c.getError("idempotent_consumer"); err != nil {
+		return nil, err
+	}
+	return c.idempotentConsumer, nil
+}
+
+// GetIntegrationLogRepository returns the integration log repository instance, creating it if necessary
+func (c *Container) GetIntegrationLogRepository() (repository.IntegrationLogRepository, error) {
+	c.integrationLogRepoOnce.Do(func() {
+		storage, err := c.GetStorage()
+		if err != nil {
+			c.setError("integration_log_repository", NewProviderError("integration_log_repository", err))
+			return
+		}
+		repo, err := IntegrationLogRepositoryProvider(storage)
+		if err != nil {
+			c.setError("integration_log_repository", err)
+			return
+		}
+		c.integrationLogRepo = repo
+	})
+
+	if err := c.getError("integration_log_repository"); err != nil {
+		return nil, err
+	}
+	return c.integrationLogRepo, nil
+}
+
+// GetIntegrationLogService returns the integration log service instance, creating it if necessary
+func (c *Container) GetIntegrationLogService() (*application.IntegrationLogService, error) {
+	c.integrationLogServiceOnce.Do(func() {
+		logRepo, err := c.GetIntegrationLogRepository()
+		if err != nil {
+			c.setError("integration_log_service", NewProviderError("integration_log_service", err))
+			return
+		}
+		c.integrationLogService = IntegrationLogServiceProvider(logRepo, c.config)
+	})
+
+	if err := c.getError("integration_log_service"); err != nil {
+		return nil, err
+	}
+	return c.integrationLogService, nil
+}
+
+// GetSagaRepository returns the saga repository instance, creating it if necessary
+func (c *Container) GetSagaRepository() (repository.SagaRepository, error) {
+	c.sagaRepoOnce.Do(func() {
+		storage, err := c.GetStorage()
+		if err != nil {
+			c.setError("saga_repository", NewProviderError("saga_repository", err))
+			return
+		}
+		repo, err := SagaRepositoryProvider(storage)
+		if err != nil {
+			c.setError("saga_repository", err)
+			return
+		}
+		c.sagaRepo = repo
+	})
+
+	if err := c.getError("saga_repository"); err != nil {
+		return nil, err
+	}
+	return c.sagaRepo, nil
+}
+
+// GetSagaOrchestrator returns the saga orchestrator instance, creating it if necessary
+// The saga services are created with it, so their sagas can be resumed whichever is requested first
+func (c *Container) GetSagaOrchestrator() (*application.SagaOrchestrator, error) {
+	c.sagaOrchestratorOnce.Do(func() {
+		sagaRepo, err := c.GetSagaRepository()
+		if err != nil {
+			c.setError("saga_orchestrator", NewProviderError("saga_orchestrator", err))
+			return
+		}
+		auditService, err := c.GetAuditService()
+		if err != nil {
+			c.setError("saga_orchestrator", NewProviderError("saga_orchestrator", err))
+			return
+		}
+		billingService, err := c.GetBillingService()
+		if err != nil {
+			c.setError("saga_orchestrator", NewProviderError("saga_orchestrator", err))
+			return
+		}
+		clients, err := c.GetOutboundClients()
+		if err != nil {
+			c.setError("saga_orchestrator", NewProviderError("saga_orchestrator", err))
+			return
+		}
+		publisher, err := c.recordEvents(c.GetEventPublisher())
+		if err != nil {
+			c.setError("saga_orchestrator", NewProviderError("saga_orchestrator", err))
+			return
+		}
+		outboxService, err := c.GetEmailOutboxService()
+		if err != nil {
+			c.setError("saga_orchestrator", NewProviderError("saga_orchestrator", err))
+			return
+		}
+		c.sagaOrchestrator = SagaOrchestratorProvider(sagaRepo, auditService, c.config)
+		c.invoicePaymentService = InvoicePaymentServiceProvider(c.sagaOrchestrator, billingService, outboxService.Publisher(publisher), clients, c.config)
+	})
+
+	if err := c.getError("saga_orchestrator"); err != nil {
+		return nil, err
+	}
+	return c.sagaOrchestrator, nil
+}
+
+// GetInvoicePaymentService returns the invoice payment service instance, or nil when no payment gateway is configured
+func (c *Container) GetInvoicePaymentService() (*application.InvoicePaymentService, error) {
+	if _, err := c.GetSagaOrchestrator(); err != nil {
+		return nil, err
+	}
+	return c.invoicePaymentService, nil
+}
+
+// GetSandbox returns the sandbox environment, creating it if necessary
+// Returns nil when sandbox mode is disabled
+func (c *Container) GetSandbox() (*Sandbox, error) {
+	c.sandboxOnce.Do(func() {
+		if !c.config.SandboxEnabled {
+			return
+		}
+		auditService, err := c.GetAuditService()
+		if err != nil {
+			c.setError("sandbox", NewProviderError("sandbox", err))
+			return
+		}
+		sandbox, err := SandboxProvider(c.config, auditService)
+		if err != nil {
+			c.setError("sandbox", err)
+			return
+		}
+		c.sandbox = sandbox
+	})
+
+	if err := c.getError("sandbox"); err != nil {
+		return nil, err
+	}
+	return c.sandbox, nil
+}
+
+// GetHTTPServer returns the HTTP server instance, creating it if necessary
+func (c *Container) GetHTTPServer() (*httpserver.Server, error) {
+	c.httpServerOnce.Do(func() {
+		billingService, err := c.GetBillingService()
+		if err != nil {
+			c.setError("http_server", NewProviderError("http_server", err))
+			return
+		}
+		auditService, err := c.GetAuditService()
+		if err != nil {
+			c.setError("http_server", NewProviderError("http_server", err))
+			return
+		}
+		policyService, err := c.GetAccessPolicyService()
+		if err != nil {
+			c.setError("http_server", NewProviderError("http_server", err))
+			return
+		}
+		formTokenService, err := c.GetFormTokenService()
+		if err != nil {
+			c.setError("http_server", NewProviderError("http_server", err))
+			return
+		}
+		portalService, err := c.GetPortalService()
+		if err != nil {
+			c.setError("http_server", NewProviderError("http_server", err))
+			return
+		}
+		magicLinkService, err := c.GetMagicLinkService()
+		if err != nil {
+			c.setError("http_server", NewProviderError("http_server", err))
+			return
+		}
+		usageService, err := c.GetUsageService()
+		if err != nil {
+			c.setError("http_server", NewProviderError("http_server", err))
+			return
+		}
+		contractService, err := c.GetContractService()
+		if err != nil {
+			c.setError("http_server", NewProviderError("http_server", err))
+			return
+		}
+		approvalService, err := c.GetApprovalService()
+		if err != nil {
+			c.setError("http_server", NewProviderError("http_server", err))
+			return
+		}
+		recurringService, err := c.GetRecurringInvoiceService()
+		if err != nil {
+			c.setError("http_server", NewProviderError("http_server", err))
+			return
+		}
+		subscriptionService, err := c.GetSubscriptionService()
+		if err != nil {
+			c.setError("http_server", NewProviderError("http_server", err))
+			return
+		}
+		quoteService, err := c.GetQuoteService()
+		if err != nil {
+			c.setError("http_server", NewProviderError("http_server", err))
+			return
+		}
+		deliveryService, err := c.GetInvoiceDeliveryService()
+		if err != nil {
+			c.setError("http_server", NewProviderError("http_server", err))
+			return
+		}
+		dunningService, err := c.GetDunningPolicyService()
+		if err != nil {
+			c.setError("http_server", NewProviderError("http_server", err))
+			return
+		}
+		dunningRunService, err := c.GetDunningService()
+		if err != nil {
+			c.setError("http_server", NewProviderError("http_server", err))
+			return
+		}
+		tenantRuleService, err := c.GetTenantRuleService()
+		if err != nil {
+			c.setError("http_server", NewProviderError("http_server", err))
+			return
+		}
+		fiscalCalendarService, err := c.GetFiscalCalendarService()
+		if err != nil {
+			c.setError("http_server", NewProviderError("http_server", err))
+			return
+		}
+		cashService, err := c.GetCashApplicationService()
+		if err != nil {
+			c.setError("http_server", NewProviderError("http_server", err))
+			return
+		}
+		payoutService, err := c.GetPayoutReconciliationService()
+		if err != nil {
+			c.setError("http_server", NewProviderError("http_server", err))
+			return
+		}
+		legalEntityService, err := c.GetLegalEntityService()
+		if err != nil {
+			c.setError("http_server", NewProviderError("http_server", err))
+			return
+		}
+		documentService, err := c.GetDocumentTemplateService()
+		if err != nil {
+			c.setError("http_server", NewProviderError("http_server", err))
+			return
+		}
+		creditService, err := c.GetCreditControlService()
+		if err != nil {
+			c.setError("http_server", NewProviderError("http_server", err))
+			return
+		}
+		statementService, err := c.GetClientStatementService()
+		if err != nil {
+			c.setError("http_server", NewProviderError("http_server", err))
+			return
+		}
+		riskService, err := c.GetRiskScoringService()
+		if err != nil {
+			c.setError("http_server", NewProviderError("http_server", err))
+			return
+		}
+		webhookService, err := c.GetWebhookService()
+		if err != nil {
+			c.setError("http_server", NewProviderError("http_server", err))
+			return
+		}
+		webhookSubscriptionService, err := c.GetWebhookSubscriptionService()
+		if err != nil {
+			c.setError("http_server", NewProviderError("http_server", err))
+			return
+		}
+		deadLetterService, err := c.GetDeadLetterService()
+		if err != nil {
+			c.setError("http_server", NewProviderError("http_server", err))
+			return
+		}
+		emailOutboxService, err := c.GetEmailOutboxService()
+		if err != nil {
+			c.setError("http_server", NewProviderError("http_server", err))
+			return
+		}
+		dashboardService, err := c.GetDashboardService()
+		if err != nil {
+			c.setError("http_server", NewProviderError("http_server", err))
+			return
+		}
+		consumer, err := c.GetIdempotentConsumer()
+		if err != nil {
+			c.setError("http_server", NewProviderError("http_server", err))
+			return
+		}
+		sagaOrchestrator, err := c.GetSagaOrchestrator()
+		if err != nil {
+			c.setError("http_server", NewProviderError("http_server", err))
+			return
+		}
+		invoicePaymentService, err := c.GetInvoicePaymentService()
+		if err != nil {
+			c.setError("http_server", NewProviderError("http_server", err))
+			return
+		}
+		integrationLogService, err := c.GetIntegrationLogService()
+		if err != nil {
+			c.setError("http_server", NewProviderError("http_server", err))
+			return
+		}
+		clients, err := c.GetOutboundClients()
+		if err != nil {
+			c.setError("http_server", NewProviderError("http_server", err))
+			return
+		}
+		captchaVerifier, err := CaptchaVerifierProvider(clients, c.config)
+		if err != nil {
+			c.setError("http_server", NewProviderError("http_server", err))
+			return
+		}
+		eventStore, err := c.GetEventStore()
+		if err != nil {
+			c.setError("http_server", NewProviderError("http_server", err))
+			return
+		}
+		partitionService, err := c.GetPartitionMaintenanceService()
+		if err != nil {
+			c.setError("http_server", NewProviderError("http_server", err))
+			return
+		}
+		invoiceArchiveService, err := c.GetInvoiceArchiveService()
+		if err != nil {
+			c.setError("http_server", NewProviderError("http_server", err))
+			return
+		}
+		uploadService, err := c.GetUploadService()
+		if err != nil {
+			c.setError("http_server", NewProviderError("http_server", err))
+			return
+		}
+		sandbox, err := c.GetSandbox()
+		if err != nil {
+			c.setError("http_server", NewProviderError("http_server", err))
+			return
+		}
+		currencies, err := CurrencyPoliciesProvider(c.config)
+		if err != nil {
+			c.setError("http_server", NewProviderError("http_server", err))
+			return
 		}
-		c.httpServer = HTTPServerProvider(billingService, version)
+		c.httpServer = HTTPServerProvider(httpserver.Services{
+			Billing:              billingService,
+			AccessPolicies:       policyService,
+			Audit:                auditService,
+			FormTokens:           formTokenService,
+			Portal:               portalService,
+			MagicLinks:           magicLinkService,
+			Usage:                usageService,
+			Contracts:            contractService,
+			Approvals:            approvalService,
+			Recurring:            recurringService,
+			Subscriptions:        subscriptionService,
+			Quotes:               quoteService,
+			Delivery:             deliveryService,
+			Dunning:              dunningService,
+			DunningRuns:          dunningRunService,
+			TenantRules:          tenantRuleService,
+			FiscalCalendars:      fiscalCalendarService,
+			Cash:                 cashService,
+			Payouts:              payoutService,
+			LegalEntities:        legalEntityService,
+			Documents:            documentService,
+			Credit:               creditService,
+			Statements:           statementService,
+			Risk:                 riskService,
+			Webhooks:             webhookService,
+			WebhookSubscriptions: webhookSubscriptionService,
+			DeadLetters:          deadLetterService,
+			EmailOutbox:          emailOutboxService,
+			Dashboard:            dashboardService,
+			Idempotency:          consumer,
+			Sagas:                sagaOrchestrator,
+			InvoicePayments:      invoicePaymentService,
+			OutboundClients:      clients,
+			IntegrationLogs:      integrationLogService,
+			Events:               eventStore,
+			Partitions:           partitionService,
+			InvoiceArchive:       invoiceArchiveService,
+			Uploads:              uploadService,
+			Currencies:           currencies,
+		}, captchaVerifier, sandbox, c.config)
 	})
 
 	if err := c.getError("http_server"); err != nil {
@@ -141,14 +2655,177 @@ func (c *Container) Reset() {
 	c.storage = nil
 	c.migrationService = nil
 	c.clientRepo = nil
+	c.changeRepo = nil
+	c.auditRepo = nil
+	c.ipPolicyRepo = nil
+	c.formTokenRepo = nil
+	c.magicLinkRepo = nil
+	c.usageRepo = nil
+	c.contractRepo = nil
+	c.approvalRepo = nil
+	c.recurringRepo = nil
+	c.deliveryRepo = nil
+	c.dunningRepo = nil
+	c.fiscalCalendarRepo = nil
+	c.bankTxRepo = nil
+	c.payoutRepo = nil
+	c.legalEntityRepo = nil
+	c.documentRepo = nil
+	c.creditLimitRepo = nil
+	c.statementJobRepo = nil
+	c.externalRefRepo = nil
+	c.invoiceRepo = nil
+	c.paymentRepo = nil
+	c.clientSummaryRepo = nil
+	c.clientContactRepo = nil
+	c.clientNoteRepo = nil
+	c.clientTombstoneRepo = nil
+	c.eventStoreRepo = nil
+	c.riskRepo = nil
+	c.webhookEventRepo = nil
+	c.failedMessageRepo = nil
+	c.processedMessageRepo = nil
+	c.integrationLogRepo = nil
+	c.eventPublisher = nil
+	c.outboundClients = nil
 	c.billingService = nil
+	c.auditService = nil
+	c.eventBus = nil
+	c.policyService = nil
+	c.formTokenService = nil
+	c.portalService = nil
+	c.magicLinkService = nil
+	c.usageService = nil
+	c.contractService = nil
+	c.approvalService = nil
+	c.recurringService = nil
+	c.subscriptionRepo = nil
+	c.subscriptionService = nil
+	c.quoteRepo = nil
+	c.quoteService = nil
+	c.deliveryService = nil
+	c.dunningService = nil
+	c.dunningRunService = nil
+	c.tenantRuleRepo = nil
+	c.tenantRuleService = nil
+	c.fiscalCalendarService = nil
+	c.cashService = nil
+	c.payoutService = nil
+	c.legalEntityService = nil
+	c.documentService = nil
+	c.creditService = nil
+	c.statementService = nil
+	c.externalRefService = nil
+	c.eventStore = nil
+	c.partitionService = nil
+	c.invoiceArchiveRepo = nil
+	c.invoiceArchiveService = nil
+	c.uploadRepo = nil
+	c.objectStore = nil
+	c.uploadService = nil
+	c.integrationPublisher = nil
+	c.riskService = nil
+	c.webhookService = nil
+	c.deadLetterPublisher = nil
+	c.deadLetterService = nil
+	c.outboundEmailRepo = nil
+	c.emailSuppressionRepo = nil
+	c.emailOutboxService = nil
+	c.dashboardService = nil
+	c.idempotentConsumer = nil
+	c.integrationLogService = nil
+	c.sagaRepo = nil
+	c.sagaOrchestrator = nil
+	c.invoicePaymentService = nil
 	c.httpServer = nil
+	c.sandbox = nil
 
 	c.storageOnce = sync.Once{}
 	c.migrationServiceOnce = sync.Once{}
 	c.clientRepoOnce = sync.Once{}
+	c.changeRepoOnce = sync.Once{}
+	c.auditRepoOnce = sync.Once{}
+	c.ipPolicyRepoOnce = sync.Once{}
+	c.formTokenRepoOnce = sync.Once{}
+	c.magicLinkRepoOnce = sync.Once{}
+	c.usageRepoOnce = sync.Once{}
+	c.contractRepoOnce = sync.Once{}
+	c.approvalRepoOnce = sync.Once{}
+	c.recurringRepoOnce = sync.Once{}
+	c.deliveryRepoOnce = sync.Once{}
+	c.dunningRepoOnce = sync.Once{}
+	c.fiscalCalendarRepoOnce = sync.Once{}
+	c.bankTxRepoOnce = sync.Once{}
+	c.payoutRepoOnce = sync.Once{}
+	c.legalEntityRepoOnce = sync.Once{}
+	c.documentRepoOnce = sync.Once{}
+	c.creditLimitRepoOnce = sync.Once{}
+	c.statementJobRepoOnce = sync.Once{}
+	c.externalRefRepoOnce = sync.Once{}
+	c.invoiceRepoOnce = sync.Once{}
+	c.paymentRepoOnce = sync.Once{}
+	c.clientSummaryRepoOnce = sync.Once{}
+	c.clientContactRepoOnce = sync.Once{}
+	c.clientNoteRepoOnce = sync.Once{}
+	c.clientTombstoneRepoOnce = sync.Once{}
+	c.eventStoreRepoOnce = sync.Once{}
+	c.riskRepoOnce = sync.Once{}
+	c.webhookEventRepoOnce = sync.Once{}
+	c.failedMessageRepoOnce = sync.Once{}
+	c.processedMessageRepoOnce = sync.Once{}
+	c.integrationLogRepoOnce = sync.Once{}
+	c.eventPublisherOnce = sync.Once{}
+	c.outboundClientsOnce = sync.Once{}
 	c.billingServiceOnce = sync.Once{}
+	c.auditServiceOnce = sync.Once{}
+	c.eventBusOnce = sync.Once{}
+	c.policyServiceOnce = sync.Once{}
+	c.formTokenServiceOnce = sync.Once{}
+	c.portalServiceOnce = sync.Once{}
+	c.magicLinkServiceOnce = sync.Once{}
+	c.usageServiceOnce = sync.Once{}
+	c.contractServiceOnce = sync.Once{}
+	c.approvalServiceOnce = sync.Once{}
+	c.recurringServiceOnce = sync.Once{}
+	c.subscriptionRepoOnce = sync.Once{}
+	c.subscriptionServiceOnce = sync.Once{}
+	c.quoteRepoOnce = sync.Once{}
+	c.quoteServiceOnce = sync.Once{}
+	c.deliveryServiceOnce = sync.Once{}
+	c.dunningServiceOnce = sync.Once{}
+	c.dunningRunServiceOnce = sync.Once{}
+	c.tenantRuleRepoOnce = sync.Once{}
+	c.tenantRuleServiceOnce = sync.Once{}
+	c.fiscalCalendarServiceOnce = sync.Once{}
+	c.cashServiceOnce = sync.Once{}
+	c.payoutServiceOnce = sync.Once{}
+	c.legalEntityServiceOnce = sync.Once{}
+	c.documentServiceOnce = sync.Once{}
+	c.creditServiceOnce = sync.Once{}
+	c.statementServiceOnce = sync.Once{}
+	c.externalRefServiceOnce = sync.Once{}
+	c.eventStoreOnce = sync.Once{}
+	c.partitionServiceOnce = sync.Once{}
+	c.invoiceArchiveRepoOnce = sync.Once{}
+	c.invoiceArchiveOnce = sync.Once{}
+	c.uploadRepoOnce = sync.Once{}
+	c.objectStoreOnce = sync.Once{}
+	c.uploadServiceOnce = sync.Once{}
+	c.integrationPublisherOnce = sync.Once{}
+	c.riskServiceOnce = sync.Once{}
+	c.webhookServiceOnce = sync.Once{}
+	c.deadLetterPublisherOnce = sync.Once{}
+	c.deadLetterServiceOnce = sync.Once{}
+	c.outboundEmailRepoOnce = sync.Once{}
+	c.emailSuppressionRepoOnce = sync.Once{}
+	c.emailOutboxServiceOnce = sync.Once{}
+	c.dashboardServiceOnce = sync.Once{}
+	c.idempotentConsumerOnce = sync.Once{}
+	c.integrationLogServiceOnce = sync.Once{}
+	c.sagaRepoOnce = sync.Once{}
+	c.sagaOrchestratorOnce = sync.Once{}
 	c.httpServerOnce = sync.Once{}
+	c.sandboxOnce = sync.Once{}
 
 	c.errorsMutex.Lock()
 	c.errors = make(map[string]error)
@@ -9,16 +9,31 @@ package di
 import (
 	"fmt"
 	"log"
+	"net/http"
+	"os"
 	"time"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 
 	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/middleware"
 	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/demo"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
 	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/bureau"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/captcha"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/eventbus"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/gateway"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/httpclient"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/messaging"
 	infrarepo "github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
 	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/tracing"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/webhook"
 	"github.com/gjaminon-go-labs/billing-api/internal/migration"
 	testinfra "github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
 )
@@ -153,14 +168,439 @@ func ClientRepositoryProvider(storage storage.Storage) repository.ClientReposito
 	return infrarepo.NewClientRepository(storage)
 }
 
-// BillingServiceProvider creates a billing service with the given repository
-func BillingServiceProvider(clientRepo repository.ClientRepository) *application.BillingService {
-	return application.NewBillingService(clientRepo)
+// ClientChangeRepositoryProvider creates a client change log repository on its collection of the given storage
+func ClientChangeRepositoryProvider(baseStorage storage.Storage) (repository.ClientChangeRepository, error) {
+	changeStorage, err := storage.ForCollection(baseStorage, infrarepo.ClientChangeCollection)
+	if err != nil {
+		return nil, NewProviderError("client_change_repository", err)
+	}
+	return infrarepo.NewClientChangeRepository(changeStorage), nil
+}
+
+// BillingServiceProvider creates a billing service with the given repositories
+func BillingServiceProvider(clientRepo repository.ClientRepository, changeRepo repository.ClientChangeRepository, riskService *application.RiskScoringService, referenceService *application.ExternalReferenceService, invoiceRepo repository.InvoiceRepository, paymentRepo repository.PaymentRepository, summaryRepo repository.ClientSummaryRepository, contactRepo repository.ClientContactRepository, noteRepo repository.ClientNoteRepository, tombstoneRepo repository.ClientTombstoneRepository, eventStore *application.EventStore, events *eventbus.Bus, taxRates service.TaxRateProvider, deletionPolicy service.ClientDeletionPolicy, emails valueobject.EmailNormalization, numbering valueobject.InvoiceNumberFormat, plugins *application.Plugins, rules *application.RuleChecker, legalEntityService *application.LegalEntityService, fiscalCalendars *application.FiscalCalendarService, config *ContainerConfig) *application.BillingService {
+	return application.NewBillingService(clientRepo).WithChangeLog(changeRepo).WithRiskScoring(riskService).WithExternalReferences(referenceService).WithInvoices(invoiceRepo).WithPayments(paymentRepo).WithClientSummaries(summaryRepo).WithContacts(contactRepo).WithNotes(noteRepo).WithTombstones(tombstoneRepo).WithEventStore(eventStore).WithEvents(events).WithTaxRates(taxRates).WithClientDeletionPolicy(deletionPolicy).WithEmailNormalization(emails).WithInvoiceNumbering(numbering).WithPlugins(plugins).WithRuleChecker(rules).
+		WithLegalEntities(legalEntityService).WithFiscalCalendars(fiscalCalendars).WithComplianceRules(ComplianceRulesProvider(config)).WithDuplicateCheck(DuplicateInvoiceCheckProvider(config))
+}
+
+// RuleCheckerProvider creates the business rules checked by the billing service: the default rules, then the
+// rules tenants configure
+func RuleCheckerProvider(clientRepo repository.ClientRepository, tenantRules *application.TenantRuleService) (*application.RuleChecker, error) {
+	rules := append(application.DefaultRules(clientRepo), application.NewTenantExpressionRule(tenantRules))
+	checker, err := application.NewRuleChecker(rules...)
+	if err != nil {
+		return nil, NewProviderError("rule_checker", err)
+	}
+	return checker, nil
+}
+
+// PluginsProvider registers the plugins hooked into the billing service
+func PluginsProvider(plugins []application.Plugin) (*application.Plugins, error) {
+	registered, err := application.NewPlugins(plugins...)
+	if err != nil {
+		return nil, NewProviderError("plugins", err)
+	}
+	return registered, nil
+}
+
+// InvoiceNumberFormatProvider creates the format of the numbers assigned to issued invoices (INV-{YYYY}-{00000} by default)
+func InvoiceNumberFormatProvider(config *ContainerConfig) (valueobject.InvoiceNumberFormat, error) {
+	pattern := config.InvoiceNumberFormat
+	if pattern == "" {
+		pattern = valueobject.DefaultInvoiceNumberFormat
+	}
+	format, err := valueobject.NewInvoiceNumberFormat(pattern)
+	if err != nil {
+		return valueobject.InvoiceNumberFormat{}, NewProviderError("invoice_number_format", err)
+	}
+	return format, nil
+}
+
+// ClientDeletionPolicyProvider creates the policy applied to the invoices of deleted clients from the configured rules
+func ClientDeletionPolicyProvider(config *ContainerConfig) (service.ClientDeletionPolicy, error) {
+	policy, err := service.NewClientDeletionPolicy(config.ClientDeletionDraftInvoices, config.ClientDeletionClosedInvoices)
+	if err != nil {
+		return service.ClientDeletionPolicy{}, NewProviderError("client_deletion_policy", err)
+	}
+	return policy, nil
+}
+
+// EmailNormalizationProvider creates the policy folding the spellings of an address in client email uniqueness checks
+func EmailNormalizationProvider(config *ContainerConfig) (valueobject.EmailNormalization, error) {
+	policy, err := valueobject.NewEmailNormalization(config.EmailFoldSubaddress, config.EmailFoldDotDomains)
+	if err != nil {
+		return valueobject.EmailNormalization{}, NewProviderError("email_normalization", err)
+	}
+	return policy, nil
+}
+
+// TaxRateProviderProvider creates the provider of the tax rates of invoice jurisdictions from the configured rate table
+func TaxRateProviderProvider(config *ContainerConfig) (service.TaxRateProvider, error) {
+	rates, err := service.NewStaticTaxRates(config.TaxRates)
+	if err != nil {
+		return nil, NewProviderError("tax_rates", err)
+	}
+	return rates, nil
+}
+
+// DemoDataProvider seeds sample data through the billing service when the demo profile enables it
+func DemoDataProvider(billingService *application.BillingService, config *ContainerConfig) error {
+	if !config.DemoSeedEnabled {
+		return nil
+	}
+	if config.StorageType != "memory" {
+		return NewProviderError("demo_data", fmt.Errorf("demo seeding requires memory storage, got %s", config.StorageType))
+	}
+
+	if _, err := demo.Seed(billingService, demo.Config{
+		Clients:    config.DemoClients,
+		RandomSeed: config.DemoRandomSeed,
+	}); err != nil {
+		return NewProviderError("demo_data", err)
+	}
+	return nil
+}
+
+// AuditRepositoryProvider creates an audit repository on the audit collection of the given storage
+func AuditRepositoryProvider(baseStorage storage.Storage) (repository.AuditRepository, error) {
+	auditStorage, err := storage.ForCollection(baseStorage, infrarepo.AuditCollection)
+	if err != nil {
+		return nil, NewProviderError("audit_repository", err)
+	}
+	return infrarepo.NewAuditRepository(auditStorage), nil
+}
+
+// IPAccessPolicyRepositoryProvider creates an IP access policy repository on its collection of the given storage
+func IPAccessPolicyRepositoryProvider(baseStorage storage.Storage) (repository.IPAccessPolicyRepository, error) {
+	policyStorage, err := storage.ForCollection(baseStorage, infrarepo.IPAccessPolicyCollection)
+	if err != nil {
+		return nil, NewProviderError("ip_access_policy_repository", err)
+	}
+	return infrarepo.NewIPAccessPolicyRepository(policyStorage), nil
+}
+
+// FormTokenRepositoryProvider creates a form token repository on its collection of the given storage
+func FormTokenRepositoryProvider(baseStorage storage.Storage) (repository.FormTokenRepository, error) {
+	tokenStorage, err := storage.ForCollection(baseStorage, infrarepo.FormTokenCollection)
+	if err != nil {
+		return nil, NewProviderError("form_token_repository", err)
+	}
+	return infrarepo.NewFormTokenRepository(tokenStorage), nil
+}
+
+// FormTokenServiceProvider creates a form token service with the given repository
+func FormTokenServiceProvider(tokenRepo repository.FormTokenRepository, config *ContainerConfig) *application.FormTokenService {
+	return application.NewFormTokenService(tokenRepo, config.FormTokenTTL)
+}
+
+// PortalServiceProvider creates the client portal service (nil when the portal is disabled)
+// Sign-in links are enabled when a magic link service is available
+func PortalServiceProvider(billingService *application.BillingService, magicLinks *application.MagicLinkService, documents *application.DocumentTemplateService, config *ContainerConfig) *application.PortalService {
+	if !config.PortalEnabled {
+		return nil
+	}
+	return application.NewPortalService(billingService, config.PortalTokenSecret, config.PortalTokenTTL).
+		WithClockSkew(config.PortalClockSkew).
+		WithMagicLinks(magicLinks).
+		WithDocuments(documents)
+}
+
+// MagicLinkRepositoryProvider creates a magic link repository on its collection of the given storage
+func MagicLinkRepositoryProvider(baseStorage storage.Storage) (repository.MagicLinkRepository, error) {
+	linkStorage, err := storage.ForCollection(baseStorage, infrarepo.MagicLinkCollection)
+	if err != nil {
+		return nil, err
+	}
+	return infrarepo.NewMagicLinkRepository(linkStorage), nil
+}
+
+// MagicLinkServiceProvider creates the magic link service (nil when no signing key is configured)
+func MagicLinkServiceProvider(linkRepo repository.MagicLinkRepository, config *ContainerConfig) *application.MagicLinkService {
+	if config.MagicLinkSecret == "" {
+		return nil
+	}
+	return application.NewMagicLinkService(linkRepo, config.MagicLinkSecret, config.MagicLinkTTL, config.MagicLinkBaseURL).
+		WithClockSkew(config.MagicLinkClockSkew)
+}
+
+// UsageRecordRepositoryProvider creates a usage record repository on its collection of the given storage
+func UsageRecordRepositoryProvider(baseStorage storage.Storage) (repository.UsageRecordRepository, error) {
+	usageStorage, err := storage.ForCollection(baseStorage, infrarepo.UsageRecordCollection)
+	if err != nil {
+		return nil, NewProviderError("usage_record_repository", err)
+	}
+	return infrarepo.NewUsageRecordRepository(usageStorage), nil
+}
+
+// UsageServiceProvider creates a usage service recording usage of the subscriptions of the subscription service
+func UsageServiceProvider(usageRepo repository.UsageRecordRepository, subscriptionService *application.SubscriptionService) *application.UsageService {
+	return application.NewUsageService(usageRepo, subscriptionService)
+}
+
+// EventPublisherProvider creates the message bus publisher for integration events
+// Events are written as JSON lines to stdout and shipped by the log pipeline, like the CDC relay
+// Sandbox containers mark every event as sandbox traffic so consumers suppress real emails
+// Every event carries the request ID and trace headers of the request that published it
+func EventPublisherProvider(config *ContainerConfig) messaging.Publisher {
+	var publisher messaging.Publisher = messaging.NewWriterPublisher(os.Stdout)
+	if config.SandboxMode {
+		publisher = messaging.NewSandboxPublisher(publisher, config.SandboxCatchAllEmail)
+	}
+	return tracing.NewPublisher(publisher)
+}
+
+// OutboundClientsProvider creates the registry of HTTP clients the outbound adapters call their destination with
+// Every call is recorded (redacted) through logService when integration logs are enabled; a failed recording
+// is logged and does not fail the call
+func OutboundClientsProvider(logService *application.IntegrationLogService, config *ContainerConfig) *httpclient.Registry {
+	registry := httpclient.NewRegistry(config.OutboundHTTP, config.OutboundHTTPDestinations)
+	if logService == nil {
+		return registry
+	}
+
+	return registry.WithRecorder(func(call httpclient.Call) {
+		_, err := logService.Record(entity.IntegrationCall{
+			Destination:  call.Destination,
+			Method:       call.Method,
+			URL:          call.URL,
+			StatusCode:   call.StatusCode,
+			Duration:     call.Duration,
+			RequestBody:  call.RequestBody,
+			ResponseBody: call.ResponseBody,
+			Error:        call.Error,
+			RequestID:    call.RequestID,
+		}, call.RequestContentType, call.ResponseContentType)
+		if err != nil {
+			log.Printf("failed to record %s call to %s: %v", call.Method, call.Destination, err)
+		}
+	}, config.IntegrationLogMaxBodyBytes)
+}
+
+// ContractRepositoryProvider creates a contract repository on its collection of the given storage
+func ContractRepositoryProvider(baseStorage storage.Storage) (repository.ContractRepository, error) {
+	contractStorage, err := storage.ForCollection(baseStorage, infrarepo.ContractCollection)
+	if err != nil {
+		return nil, NewProviderError("contract_repository", err)
+	}
+	return infrarepo.NewContractRepository(contractStorage), nil
+}
+
+// ContractServiceProvider creates a contract service with the given dependencies
+func ContractServiceProvider(contractRepo repository.ContractRepository, billingService *application.BillingService, publisher messaging.Publisher, config *ContainerConfig) *application.ContractService {
+	return application.NewContractService(contractRepo, billingService, publisher, config.ContractRenewalReminderLead)
+}
+
+// AuditServiceProvider creates an audit service with the given repository
+func AuditServiceProvider(auditRepo repository.AuditRepository) *application.AuditService {
+	return application.NewAuditService(auditRepo)
+}
+
+// EventBusProvider creates the in-process event bus, with the audit log subscribed to billing events
+func EventBusProvider(auditService *application.AuditService) *eventbus.Bus {
+	bus := eventbus.New()
+	auditService.SubscribeAuditLog(bus)
+	return bus
+}
+
+// AccessPolicyServiceProvider creates an access policy service with the given dependencies
+func AccessPolicyServiceProvider(policyRepo repository.IPAccessPolicyRepository, auditService *application.AuditService) *application.AccessPolicyService {
+	return application.NewAccessPolicyService(policyRepo, auditService)
+}
+
+// CaptchaVerifierProvider creates the challenge verifier for the configured provider
+// Returns nil when captcha verification is disabled
+func CaptchaVerifierProvider(clients *httpclient.Registry, config *ContainerConfig) (middleware.ChallengeVerifier, error) {
+	if !config.CaptchaEnabled {
+		return nil, nil
+	}
+
+	if config.CaptchaSecret == "" {
+		return nil, NewProviderError("captcha_verifier", fmt.Errorf("captcha secret is required when captcha is enabled"))
+	}
+
+	verifier, err := captcha.NewVerifier(config.CaptchaProvider, config.CaptchaSecret, clients.Client(httpclient.DestinationCaptcha))
+	if err != nil {
+		return nil, NewProviderError("captcha_verifier", err)
+	}
+	return verifier, nil
+}
+
+// WebhookEventRepositoryProvider creates a webhook event repository on its collection of the given storage
+func WebhookEventRepositoryProvider(baseStorage storage.Storage) (repository.WebhookEventRepository, error) {
+	eventStorage, err := storage.ForCollection(baseStorage, infrarepo.WebhookEventCollection)
+	if err != nil {
+		return nil, NewProviderError("webhook_event_repository", err)
+	}
+	return infrarepo.NewWebhookEventRepository(eventStorage), nil
+}
+
+// WebhookServiceProvider creates a webhook service accepting deliveries from the configured providers
+// Processed events are published on the message bus; processors run once per event through consumer
+func WebhookServiceProvider(eventRepo repository.WebhookEventRepository, auditService *application.AuditService, publisher messaging.Publisher, consumer *application.IdempotentConsumer, config *ContainerConfig) (*application.WebhookService, error) {
+	webhookService := application.NewWebhookService(eventRepo, auditService, publisher, config.WebhookMaxAttempts).
+		WithIdempotentConsumer(consumer)
+	for provider, scheme := range config.WebhookSchemes {
+		verifier, err := webhook.NewVerifier(scheme, config.WebhookSecrets[provider], config.WebhookSignatureHeaders[provider], config.WebhookTimestampHeaders[provider])
+		if err != nil {
+			return nil, NewProviderError("webhook_service", err)
+		}
+		webhookService.WithProvider(provider, verifier)
+	}
+	return webhookService, nil
+}
+
+// WebhookSubscriptionRepositoryProvider creates a webhook subscription repository on its collection of the given storage
+func WebhookSubscriptionRepositoryProvider(baseStorage storage.Storage) (repository.WebhookSubscriptionRepository, error) {
+	subscriptionStorage, err := storage.ForCollection(baseStorage, infrarepo.WebhookSubscriptionCollection)
+	if err != nil {
+		return nil, NewProviderError("webhook_subscription_repository", err)
+	}
+	return infrarepo.NewWebhookSubscriptionRepository(subscriptionStorage), nil
+}
+
+// WebhookSubscriptionServiceProvider creates the webhook subscription service replaying events of the event store
+// (replays are rejected while the event store is disabled) to consumer endpoints, each host with its own client
+func WebhookSubscriptionServiceProvider(subscriptionRepo repository.WebhookSubscriptionRepository, eventStore *application.EventStore, clients *httpclient.Registry, auditService *application.AuditService, config *ContainerConfig) *application.WebhookSubscriptionService {
+	sender := webhook.NewSender(func(host string) *http.Client {
+		return clients.Client(httpclient.DestinationWebhooks + ":" + host)
+	})
+	return application.NewWebhookSubscriptionService(subscriptionRepo, eventStore, sender, auditService, config.WebhookReplayCooldown).
+		WithReplayTimeout(config.WebhookReplayTimeout)
+}
+
+// FailedMessageRepositoryProvider creates a failed message repository on its collection of the given storage
+func FailedMessageRepositoryProvider(baseStorage storage.Storage) (repository.FailedMessageRepository, error) {
+	messageStorage, err := storage.ForCollection(baseStorage, infrarepo.FailedMessageCollection)
+	if err != nil {
+		return nil, NewProviderError("failed_message_repository", err)
+	}
+	return infrarepo.NewFailedMessageRepository(messageStorage), nil
+}
+
+// DeadLetterPublisherProvider creates the publisher services publish integration events through
+// Messages the message bus does not accept are parked in the dead letter queue
+func DeadLetterPublisherProvider(publisher messaging.Publisher, messageRepo repository.FailedMessageRepository) *application.DeadLetterPublisher {
+	return application.NewDeadLetterPublisher(publisher, messageRepo)
+}
+
+// DeadLetterServiceProvider creates a dead letter service over the failed messages and the dead-lettered webhook events
+func DeadLetterServiceProvider(auditService *application.AuditService, publisher *application.DeadLetterPublisher, webhookService *application.WebhookService) *application.DeadLetterService {
+	return application.NewDeadLetterService(auditService).
+		WithSource(application.DeadLetterSourceMessages, publisher).
+		WithSource(application.DeadLetterSourceWebhooks, webhookService)
+}
+
+// OutboundEmailRepositoryProvider creates an outbound email repository on its collection of the given storage
+func OutboundEmailRepositoryProvider(baseStorage storage.Storage) (repository.OutboundEmailRepository, error) {
+	emailStorage, err := storage.ForCollection(baseStorage, infrarepo.OutboundEmailCollection)
+	if err != nil {
+		return nil, NewProviderError("outbound_email_repository", err)
+	}
+	return infrarepo.NewOutboundEmailRepository(emailStorage), nil
+}
+
+// EmailSuppressionRepositoryProvider creates an email suppression repository on its collection of the given storage
+func EmailSuppressionRepositoryProvider(baseStorage storage.Storage) (repository.EmailSuppressionRepository, error) {
+	suppressionStorage, err := storage.ForCollection(baseStorage, infrarepo.EmailSuppressionCollection)
+	if err != nil {
+		return nil, NewProviderError("email_suppression_repository", err)
+	}
+	return infrarepo.NewEmailSuppressionRepository(suppressionStorage), nil
+}
+
+// EmailOutboxServiceProvider creates the email outbox service dispatching notification emails through mailer
+// Payloads naming only a client are sent to the client's email address
+func EmailOutboxServiceProvider(emailRepo repository.OutboundEmailRepository, suppressionRepo repository.EmailSuppressionRepository, clientRepo repository.ClientRepository, mailer messaging.Publisher, config *ContainerConfig) *application.EmailOutboxService {
+	return application.NewEmailOutboxService(emailRepo, suppressionRepo, mailer, config.EmailOutboxMaxAttempts, config.EmailOutboxRetryBackoff, config.EmailOutboxMaxBackoff, config.EmailSoftBounceLimit).
+		WithClients(clientRepo)
+}
+
+// DashboardServiceProvider creates the operational dashboard service over the asynchronous queues of the API
+func DashboardServiceProvider(billingService *application.BillingService, emailOutboxService *application.EmailOutboxService, webhookService *application.WebhookService, statementService *application.ClientStatementService, deadLetterService *application.DeadLetterService) *application.DashboardService {
+	return application.NewDashboardService(billingService).
+		WithQueue(application.DashboardQueueEmailOutbox, emailOutboxService).
+		WithQueue(application.DashboardQueueWebhookEvents, webhookService).
+		WithQueue(application.DashboardQueueStatementJobs, statementService).
+		WithQueue(application.DashboardQueueDeadLetters, deadLetterService)
+}
+
+// ProcessedMessageRepositoryProvider creates a processed message repository on its collection of the given storage
+func ProcessedMessageRepositoryProvider(baseStorage storage.Storage) (repository.ProcessedMessageRepository, error) {
+	messageStorage, err := storage.ForCollection(baseStorage, infrarepo.ProcessedMessageCollection)
+	if err != nil {
+		return nil, NewProviderError("processed_message_repository", err)
+	}
+	return infrarepo.NewProcessedMessageRepository(messageStorage), nil
+}
+
+// IntegrationLogRepositoryProvider creates an integration log repository on its collection of the given storage
+func IntegrationLogRepositoryProvider(baseStorage storage.Storage) (repository.IntegrationLogRepository, error) {
+	logStorage, err := storage.ForCollection(baseStorage, infrarepo.IntegrationLogCollection)
+	if err != nil {
+		return nil, NewProviderError("integration_log_repository", err)
+	}
+	return infrarepo.NewIntegrationLogRepository(logStorage), nil
+}
+
+// IntegrationLogServiceProvider creates the integration log service keeping outbound calls for the configured retention
+func IntegrationLogServiceProvider(logRepo repository.IntegrationLogRepository, config *ContainerConfig) *application.IntegrationLogService {
+	return application.NewIntegrationLogService(logRepo, config.IntegrationLogRetention)
+}
+
+// IdempotentConsumerProvider creates the idempotent consumer event consumers and webhook processors run through
+func IdempotentConsumerProvider(messageRepo repository.ProcessedMessageRepository, config *ContainerConfig) *application.IdempotentConsumer {
+	return application.NewIdempotentConsumer(messageRepo, config.ProcessedMessageTTL)
 }
 
 // HTTPServerProvider creates an HTTP server with the given services
-func HTTPServerProvider(billingService *application.BillingService, version string) *httpserver.Server {
-	return httpserver.NewServerWithVersion(billingService, version)
+func HTTPServerProvider(services httpserver.Services, captchaVerifier middleware.ChallengeVerifier, sandbox *Sandbox, config *ContainerConfig) *httpserver.Server {
+	sandboxConfig := middleware.SandboxConfig{
+		Tenants: config.SandboxTenants,
+		APIKeys: config.SandboxAPIKeys,
+	}
+	if sandbox != nil {
+		sandboxConfig.Environment = sandbox
+	}
+
+	return httpserver.NewServerWithServices(services, httpserver.ServerOptions{
+		Version:       config.Version,
+		DefaultLocale: config.DefaultLocale,
+		TenantLocales: config.TenantLocales,
+		RequestSigning: middleware.SignatureConfig{
+			Enabled:      config.RequestSigningEnabled,
+			RouteGroups:  config.RequestSigningRouteGroups,
+			Secrets:      config.RequestSigningSecrets,
+			Tolerance:    config.RequestSigningTolerance,
+			MaxBodyBytes: config.RequestSigningMaxBody,
+		},
+		AdminTokens:  config.AdminTokens,
+		AdminScopes:  config.AdminScopes,
+		AdminTenants: config.AdminTenants,
+		Captcha: middleware.CaptchaConfig{
+			Enabled:        config.CaptchaEnabled,
+			Routes:         config.CaptchaRoutes,
+			TrustedAPIKeys: config.CaptchaTrustedAPIKeys,
+			Verifier:       captchaVerifier,
+		},
+		Sandbox: sandboxConfig,
+		Tracing: middleware.TracingConfig{
+			RequestIDHeader: config.RequestIDHeader,
+			IgnoreIncoming:  config.IgnoreIncomingTraceHeaders,
+		},
+		TrustedProxies:         config.TrustedProxies,
+		IdempotentDeletes:      config.ClientDeletionIdempotent,
+		DeprecatedParameters:   config.DeprecatedParameters,
+		CreditWarningThreshold: config.CreditWarningThreshold,
+		EnablePlayground:       config.Environment == "development",
+		SeparateAdminListener:  config.SeparateAdminListener,
+		EnableProfiling:        config.Profiling,
+		DocsAssetsURL:          config.DocsAssetsURL,
+	})
+}
+
+// SandboxProvider creates the sandbox environment of the given live configuration
+func SandboxProvider(config *ContainerConfig, auditService *application.AuditService) (*Sandbox, error) {
+	return NewSandbox(config, auditService)
 }
 
 // ProviderError represents an error in provider creation
@@ -180,3 +620,488 @@ func NewProviderError(component string, err error) *ProviderError {
 		Err:       err,
 	}
 }
+
+// ApprovalRequestRepositoryProvider creates an approval request repository on its collection of the given storage
+func ApprovalRequestRepositoryProvider(baseStorage storage.Storage) (repository.ApprovalRequestRepository, error) {
+	approvalStorage, err := storage.ForCollection(baseStorage, infrarepo.ApprovalRequestCollection)
+	if err != nil {
+		return nil, NewProviderError("approval_request_repository", err)
+	}
+	return infrarepo.NewApprovalRequestRepository(approvalStorage), nil
+}
+
+// ApprovalServiceProvider creates an approval service with the given dependencies
+func ApprovalServiceProvider(approvalRepo repository.ApprovalRequestRepository, auditService *application.AuditService, config *ContainerConfig) *application.ApprovalService {
+	return application.NewApprovalService(approvalRepo, auditService, config.ApprovalThreshold, config.Approvers)
+}
+
+// RecurringInvoiceTemplateRepositoryProvider creates a recurring invoice template repository on its collection of the given storage
+func RecurringInvoiceTemplateRepositoryProvider(baseStorage storage.Storage) (repository.RecurringInvoiceTemplateRepository, error) {
+	templateStorage, err := storage.ForCollection(baseStorage, infrarepo.RecurringInvoiceTemplateCollection)
+	if err != nil {
+		return nil, NewProviderError("recurring_invoice_template_repository", err)
+	}
+	return infrarepo.NewRecurringInvoiceTemplateRepository(templateStorage), nil
+}
+
+// RecurringInvoiceServiceProvider creates a recurring invoice service issuing invoices through the billing service
+// from the legal entity of their template and checking them against client credit limits
+// Duplicate templates are rejected when duplicate invoice detection is enabled
+func RecurringInvoiceServiceProvider(templateRepo repository.RecurringInvoiceTemplateRepository, billingService *application.BillingService, legalEntityService *application.LegalEntityService, creditService *application.CreditControlService, riskService *application.RiskScoringService, currencies *application.CurrencyPolicies, referenceService *application.ExternalReferenceService, publisher messaging.Publisher, config *ContainerConfig) *application.RecurringInvoiceService {
+	return application.NewRecurringInvoiceService(templateRepo, billingService, publisher).
+		WithLegalEntities(legalEntityService).
+		WithCreditControl(creditService).
+		WithRiskScoring(riskService).
+		WithCurrencyPolicies(currencies).
+		WithDuplicateCheck(DuplicateInvoiceCheckProvider(config)).
+		WithExternalReferences(referenceService)
+}
+
+// SubscriptionRepositoryProvider creates a subscription repository on its collection of the given storage
+func SubscriptionRepositoryProvider(baseStorage storage.Storage) (repository.SubscriptionRepository, error) {
+	subscriptionStorage, err := storage.ForCollection(baseStorage, infrarepo.SubscriptionCollection)
+	if err != nil {
+		return nil, NewProviderError("subscription_repository", err)
+	}
+	return infrarepo.NewSubscriptionRepository(subscriptionStorage), nil
+}
+
+// SubscriptionServiceProvider creates the subscription service, billing periods as invoices of the billing service
+// with their metered usage, and prorating plan changes with the configured strategy (day_based by default)
+func SubscriptionServiceProvider(subscriptionRepo repository.SubscriptionRepository, usageRepo repository.UsageRecordRepository, billingService *application.BillingService, config *ContainerConfig) (*application.SubscriptionService, error) {
+	strategy := service.ProrationStrategy(config.SubscriptionProration)
+	if strategy == "" {
+		strategy = service.ProrationDayBased
+	}
+	prorator, err := service.NewProrator(strategy)
+	if err != nil {
+		return nil, NewProviderError("subscription_proration", err)
+	}
+	return application.NewSubscriptionService(subscriptionRepo, billingService).WithUsage(usageRepo).WithProration(prorator), nil
+}
+
+// QuoteRepositoryProvider creates a quote repository on its collection of the given storage
+func QuoteRepositoryProvider(baseStorage storage.Storage) (repository.QuoteRepository, error) {
+	quoteStorage, err := storage.ForCollection(baseStorage, infrarepo.QuoteCollection)
+	if err != nil {
+		return nil, NewProviderError("quote_repository", err)
+	}
+	return infrarepo.NewQuoteRepository(quoteStorage), nil
+}
+
+// QuoteServiceProvider creates the quote service, converting accepted quotes to invoices of the billing service
+func QuoteServiceProvider(quoteRepo repository.QuoteRepository, billingService *application.BillingService) *application.QuoteService {
+	return application.NewQuoteService(quoteRepo, billingService)
+}
+
+// DuplicateInvoiceCheckProvider returns the duplicate invoice detection settings (nil when disabled)
+func DuplicateInvoiceCheckProvider(config *ContainerConfig) *service.DuplicateInvoiceCheck {
+	if !config.DuplicateInvoicesEnabled {
+		return nil
+	}
+	return &service.DuplicateInvoiceCheck{LineItemWindow: config.DuplicateInvoiceWindow}
+}
+
+// ComplianceRulesProvider returns the built-in and configured invoice compliance rules (nil when disabled)
+func ComplianceRulesProvider(config *ContainerConfig) []service.ComplianceRule {
+	if !config.ComplianceEnabled {
+		return nil
+	}
+	return append(service.DefaultComplianceRules(), config.ComplianceRules...)
+}
+
+// CurrencyPoliciesProvider creates the per-tenant currency policies (nil without a default currency)
+// Tenants inherit the default currency or rounding they do not override
+func CurrencyPoliciesProvider(config *ContainerConfig) (*application.CurrencyPolicies, error) {
+	if config.DefaultCurrency == "" {
+		return nil, nil
+	}
+
+	defaultPolicy, err := valueobject.NewCurrencyPolicy(config.DefaultCurrency, config.CurrencyRounding)
+	if err != nil {
+		return nil, NewProviderError("currency_policies", err)
+	}
+
+	tenantIDs := make(map[string]struct{})
+	for tenantID := range config.TenantCurrencies {
+		tenantIDs[tenantID] = struct{}{}
+	}
+	for tenantID := range config.TenantRoundings {
+		tenantIDs[tenantID] = struct{}{}
+	}
+
+	tenants := make(map[string]valueobject.CurrencyPolicy, len(tenantIDs))
+	for tenantID := range tenantIDs {
+		currency, rounding := config.TenantCurrencies[tenantID], config.TenantRoundings[tenantID]
+		if currency == "" {
+			currency = defaultPolicy.Currency()
+		}
+		if rounding == "" {
+			rounding = string(defaultPolicy.Rounding())
+		}
+		policy, err := valueobject.NewCurrencyPolicy(currency, rounding)
+		if err != nil {
+			return nil, NewProviderError("currency_policies", err)
+		}
+		tenants[tenantID] = policy
+	}
+
+	return application.NewCurrencyPolicies(defaultPolicy, tenants), nil
+}
+
+// InvoiceDeliveryEventRepositoryProvider creates an invoice delivery event repository on its collection of the given storage
+func InvoiceDeliveryEventRepositoryProvider(baseStorage storage.Storage) (repository.InvoiceDeliveryEventRepository, error) {
+	eventStorage, err := storage.ForCollection(baseStorage, infrarepo.InvoiceDeliveryEventCollection)
+	if err != nil {
+		return nil, NewProviderError("invoice_delivery_event_repository", err)
+	}
+	return infrarepo.NewInvoiceDeliveryEventRepository(eventStorage), nil
+}
+
+// InvoiceDeliveryServiceProvider creates an invoice delivery service with the given dependencies
+// Bounces and deliveries of invoice emails are reported to the suppression list of the email outbox
+func InvoiceDeliveryServiceProvider(eventRepo repository.InvoiceDeliveryEventRepository, publisher messaging.Publisher, outboxService *application.EmailOutboxService, config *ContainerConfig) *application.InvoiceDeliveryService {
+	return application.NewInvoiceDeliveryService(eventRepo, publisher, config.InvoiceTrackingSecret, config.BounceFollowUpRules).
+		WithBounceRecorder(outboxService)
+}
+
+// DunningPolicyRepositoryProvider creates a dunning policy repository on its collection of the given storage
+func DunningPolicyRepositoryProvider(baseStorage storage.Storage) (repository.DunningPolicyRepository, error) {
+	policyStorage, err := storage.ForCollection(baseStorage, infrarepo.DunningPolicyCollection)
+	if err != nil {
+		return nil, NewProviderError("dunning_policy_repository", err)
+	}
+	return infrarepo.NewDunningPolicyRepository(policyStorage), nil
+}
+
+// DunningPolicyServiceProvider creates a dunning policy service with the given dependencies
+func DunningPolicyServiceProvider(policyRepo repository.DunningPolicyRepository, auditService *application.AuditService) *application.DunningPolicyService {
+	return application.NewDunningPolicyService(policyRepo, auditService)
+}
+
+// TenantRuleSetRepositoryProvider creates a tenant rule set repository on its collection of the given storage
+func TenantRuleSetRepositoryProvider(baseStorage storage.Storage) (repository.TenantRuleSetRepository, error) {
+	ruleStorage, err := storage.ForCollection(baseStorage, infrarepo.TenantRuleSetCollection)
+	if err != nil {
+		return nil, NewProviderError("tenant_rule_set_repository", err)
+	}
+	return infrarepo.NewTenantRuleSetRepository(ruleStorage), nil
+}
+
+// TenantRuleServiceProvider creates a tenant rule service with the given dependencies
+func TenantRuleServiceProvider(ruleRepo repository.TenantRuleSetRepository, auditService *application.AuditService) *application.TenantRuleService {
+	return application.NewTenantRuleService(ruleRepo, auditService)
+}
+
+// DunningServiceProvider creates a dunning service publishing payment reminders on the integration bus
+func DunningServiceProvider(billingService *application.BillingService, policyService *application.DunningPolicyService, publisher messaging.Publisher, lateFees *valueobject.LateFeePolicy, auditService *application.AuditService) *application.DunningService {
+	return application.NewDunningService(billingService, policyService, publisher).WithLateFees(lateFees).WithAudit(auditService)
+}
+
+// LateFeePolicyProvider creates the policy of the late fees announced by payment reminders (nil when disabled)
+func LateFeePolicyProvider(config *ContainerConfig) (*valueobject.LateFeePolicy, error) {
+	if !config.LateFeesEnabled {
+		return nil, nil
+	}
+	policy, err := valueobject.NewLateFeePolicy(config.LateFees)
+	if err != nil {
+		return nil, NewProviderError("late_fee_policy", err)
+	}
+	return &policy, nil
+}
+
+// EventStoreRepositoryProvider creates an event store repository on its collection of the given storage
+func EventStoreRepositoryProvider(baseStorage storage.Storage) (repository.EventStoreRepository, error) {
+	eventStorage, err := storage.ForCollection(baseStorage, infrarepo.EventStoreCollection)
+	if err != nil {
+		return nil, NewProviderError("event_store_repository", err)
+	}
+	return infrarepo.NewEventStoreRepository(eventStorage), nil
+}
+
+// EventStoreProvider creates the event store integration events are appended to
+func EventStoreProvider(eventRepo repository.EventStoreRepository) *application.EventStore {
+	return application.NewEventStore(eventRepo)
+}
+
+// PartitionMaintenanceServiceProvider creates the maintenance service of the monthly partitions of usage records and
+// the audit log; storage backends without partitions (memory) yield a service with no table to maintain
+func PartitionMaintenanceServiceProvider(baseStorage storage.Storage, config *ContainerConfig) (*application.PartitionMaintenanceService, error) {
+	var tables []application.PartitionedTable
+	for _, collection := range []string{infrarepo.UsageRecordCollection, infrarepo.AuditCollection} {
+		tableStorage, err := storage.ForCollection(baseStorage, collection)
+		if err != nil {
+			return nil, NewProviderError("partition_maintenance_service", err)
+		}
+		if partitioner, ok := tableStorage.(storage.MonthlyPartitioner); ok {
+			tables = append(tables, application.PartitionedTable{Name: collection, Manager: partitioner})
+		}
+	}
+	return application.NewPartitionMaintenanceService(tables, config.PartitionMonthsAhead), nil
+}
+
+// ExternalReferenceRepositoryProvider creates an external reference repository on its collection of the given storage
+func ExternalReferenceRepositoryProvider(baseStorage storage.Storage) (repository.ExternalReferenceRepository, error) {
+	referenceStorage, err := storage.ForCollection(baseStorage, infrarepo.ExternalReferenceCollection)
+	if err != nil {
+		return nil, NewProviderError("external_reference_repository", err)
+	}
+	return infrarepo.NewExternalReferenceRepository(referenceStorage), nil
+}
+
+// InvoiceRepositoryProvider creates an invoice repository on its collection of the given storage
+func InvoiceRepositoryProvider(baseStorage storage.Storage) (repository.InvoiceRepository, error) {
+	invoiceStorage, err := storage.ForCollection(baseStorage, infrarepo.InvoiceCollection)
+	if err != nil {
+		return nil, NewProviderError("invoice_repository", err)
+	}
+	return infrarepo.NewInvoiceRepository(invoiceStorage), nil
+}
+
+// PaymentRepositoryProvider creates a payment repository on its collection of the given storage
+func PaymentRepositoryProvider(baseStorage storage.Storage) (repository.PaymentRepository, error) {
+	paymentStorage, err := storage.ForCollection(baseStorage, infrarepo.PaymentCollection)
+	if err != nil {
+		return nil, NewProviderError("payment_repository", err)
+	}
+	return infrarepo.NewPaymentRepository(paymentStorage), nil
+}
+
+// InvoiceArchiveRepositoryProvider creates an invoice archive repository on its collection of the given storage
+func InvoiceArchiveRepositoryProvider(baseStorage storage.Storage) (repository.InvoiceArchiveRepository, error) {
+	archiveStorage, err := storage.ForCollection(baseStorage, infrarepo.InvoiceArchiveCollection)
+	if err != nil {
+		return nil, NewProviderError("invoice_archive_repository", err)
+	}
+	return infrarepo.NewInvoiceArchiveRepository(archiveStorage), nil
+}
+
+// InvoiceArchiveServiceProvider creates the service archiving closed invoices with the configured archive age
+func InvoiceArchiveServiceProvider(invoiceRepo repository.InvoiceRepository, paymentRepo repository.PaymentRepository, archiveRepo repository.InvoiceArchiveRepository, config *ContainerConfig) *application.InvoiceArchiveService {
+	return application.NewInvoiceArchiveService(invoiceRepo, paymentRepo, archiveRepo, config.InvoiceArchiveAfterYears, config.InvoiceRehydrationHold)
+}
+
+// ClientSummaryRepositoryProvider creates a client summary repository on its collection of the given storage
+func ClientSummaryRepositoryProvider(baseStorage storage.Storage) (repository.ClientSummaryRepository, error) {
+	summaryStorage, err := storage.ForCollection(baseStorage, infrarepo.ClientSummaryCollection)
+	if err != nil {
+		return nil, NewProviderError("client_summary_repository", err)
+	}
+	return infrarepo.NewClientSummaryRepository(summaryStorage), nil
+}
+
+// ClientContactRepositoryProvider creates a client contact repository on its collection of the given storage
+func ClientContactRepositoryProvider(baseStorage storage.Storage) (repository.ClientContactRepository, error) {
+	contactStorage, err := storage.ForCollection(baseStorage, infrarepo.ClientContactCollection)
+	if err != nil {
+		return nil, NewProviderError("client_contact_repository", err)
+	}
+	return infrarepo.NewClientContactRepository(contactStorage), nil
+}
+
+// ClientNoteRepositoryProvider creates a client note repository on its collection of the given storage
+func ClientNoteRepositoryProvider(baseStorage storage.Storage) (repository.ClientNoteRepository, error) {
+	noteStorage, err := storage.ForCollection(baseStorage, infrarepo.ClientNoteCollection)
+	if err != nil {
+		return nil, NewProviderError("client_note_repository", err)
+	}
+	return infrarepo.NewClientNoteRepository(noteStorage), nil
+}
+
+// ClientTombstoneRepositoryProvider creates a client tombstone repository on its collection of the given storage
+func ClientTombstoneRepositoryProvider(baseStorage storage.Storage) (repository.ClientTombstoneRepository, error) {
+	tombstoneStorage, err := storage.ForCollection(baseStorage, infrarepo.ClientTombstoneCollection)
+	if err != nil {
+		return nil, NewProviderError("client_tombstone_repository", err)
+	}
+	return infrarepo.NewClientTombstoneRepository(tombstoneStorage), nil
+}
+
+// ExternalReferenceServiceProvider creates an external reference service with the given dependencies
+func ExternalReferenceServiceProvider(referenceRepo repository.ExternalReferenceRepository) *application.ExternalReferenceService {
+	return application.NewExternalReferenceService(referenceRepo)
+}
+
+// FiscalCalendarRepositoryProvider creates a fiscal calendar repository on its collection of the given storage
+func FiscalCalendarRepositoryProvider(baseStorage storage.Storage) (repository.FiscalCalendarRepository, error) {
+	calendarStorage, err := storage.ForCollection(baseStorage, infrarepo.FiscalCalendarCollection)
+	if err != nil {
+		return nil, NewProviderError("fiscal_calendar_repository", err)
+	}
+	return infrarepo.NewFiscalCalendarRepository(calendarStorage), nil
+}
+
+// FiscalCalendarServiceProvider creates a fiscal calendar service with the given dependencies
+func FiscalCalendarServiceProvider(calendarRepo repository.FiscalCalendarRepository, auditService *application.AuditService) *application.FiscalCalendarService {
+	return application.NewFiscalCalendarService(calendarRepo, auditService)
+}
+
+// BankTransactionRepositoryProvider creates a bank transaction repository on its collection of the given storage
+func BankTransactionRepositoryProvider(baseStorage storage.Storage) (repository.BankTransactionRepository, error) {
+	transactionStorage, err := storage.ForCollection(baseStorage, infrarepo.BankTransactionCollection)
+	if err != nil {
+		return nil, NewProviderError("bank_transaction_repository", err)
+	}
+	return infrarepo.NewBankTransactionRepository(transactionStorage), nil
+}
+
+// CashApplicationServiceProvider creates a cash application service with the given dependencies
+// Reconciled transactions are recorded as payments by the billing service, whose open invoices they are matched against
+func CashApplicationServiceProvider(transactionRepo repository.BankTransactionRepository, auditService *application.AuditService, billingService *application.BillingService) *application.CashApplicationService {
+	return application.NewCashApplicationService(transactionRepo, auditService, billingService)
+}
+
+// PayoutReconciliationRepositoryProvider creates a payout reconciliation repository on its collection of the given storage
+func PayoutReconciliationRepositoryProvider(baseStorage storage.Storage) (repository.PayoutReconciliationRepository, error) {
+	reportStorage, err := storage.ForCollection(baseStorage, infrarepo.PayoutReconciliationCollection)
+	if err != nil {
+		return nil, NewProviderError("payout_reconciliation_repository", err)
+	}
+	return infrarepo.NewPayoutReconciliationRepository(reportStorage), nil
+}
+
+// PayoutReconciliationServiceProvider creates a payout reconciliation service pulling payouts from the configured gateway
+// Runs are rejected until a gateway URL is configured and a ledger is available
+func PayoutReconciliationServiceProvider(reportRepo repository.PayoutReconciliationRepository, clients *httpclient.Registry, config *ContainerConfig) *application.PayoutReconciliationService {
+	reconciliationService := application.NewPayoutReconciliationService(reportRepo, config.PayoutLookback)
+	if config.PaymentGatewayURL != "" {
+		reconciliationService.WithGateway(gateway.NewPayoutClient(config.PaymentGatewayURL, config.PaymentGatewayAPIKey, clients.Client(httpclient.DestinationPaymentGateway)))
+	}
+	return reconciliationService
+}
+
+// LegalEntityRepositoryProvider creates a legal entity repository on its collection of the given storage
+func LegalEntityRepositoryProvider(baseStorage storage.Storage) (repository.LegalEntityRepository, error) {
+	entityStorage, err := storage.ForCollection(baseStorage, infrarepo.LegalEntityCollection)
+	if err != nil {
+		return nil, NewProviderError("legal_entity_repository", err)
+	}
+	return infrarepo.NewLegalEntityRepository(entityStorage), nil
+}
+
+// LegalEntityServiceProvider creates a legal entity service with the given dependencies
+func LegalEntityServiceProvider(entityRepo repository.LegalEntityRepository, auditService *application.AuditService) *application.LegalEntityService {
+	return application.NewLegalEntityService(entityRepo, auditService)
+}
+
+// DocumentTemplateRepositoryProvider creates a document template repository on its collection of the given storage
+func DocumentTemplateRepositoryProvider(baseStorage storage.Storage) (repository.DocumentTemplateRepository, error) {
+	templateStorage, err := storage.ForCollection(baseStorage, infrarepo.DocumentTemplateCollection)
+	if err != nil {
+		return nil, NewProviderError("document_template_repository", err)
+	}
+	return infrarepo.NewDocumentTemplateRepository(templateStorage), nil
+}
+
+// DocumentTemplateServiceProvider creates a document template service previewing invoices issued by the default legal entity
+// and printing the legal mentions its country requires
+func DocumentTemplateServiceProvider(templateRepo repository.DocumentTemplateRepository, auditService *application.AuditService, legalEntityService *application.LegalEntityService, config *ContainerConfig) *application.DocumentTemplateService {
+	return application.NewDocumentTemplateService(templateRepo, auditService).
+		WithLegalEntities(legalEntityService).
+		WithComplianceRules(ComplianceRulesProvider(config))
+}
+
+// ClientCreditLimitRepositoryProvider creates a client credit limit repository on its collection of the given storage
+func ClientCreditLimitRepositoryProvider(baseStorage storage.Storage) (repository.ClientCreditLimitRepository, error) {
+	limitStorage, err := storage.ForCollection(baseStorage, infrarepo.ClientCreditLimitCollection)
+	if err != nil {
+		return nil, NewProviderError("client_credit_limit_repository", err)
+	}
+	return infrarepo.NewClientCreditLimitRepository(limitStorage), nil
+}
+
+// CreditControlServiceProvider creates a credit control service raising credit limit overrides for approval
+// Client exposure counts the open invoices of the billing service, which checks the invoices it issues
+func CreditControlServiceProvider(limitRepo repository.ClientCreditLimitRepository, billingService *application.BillingService, auditService *application.AuditService, approvalService *application.ApprovalService) *application.CreditControlService {
+	creditService := application.NewCreditControlService(limitRepo, billingService, auditService).
+		WithOpenInvoices(billingService).
+		WithApprovals(approvalService)
+	billingService.WithCreditControl(creditService)
+	return creditService
+}
+
+// ClientStatementJobRepositoryProvider creates a client statement job repository on its collection of the given storage
+func ClientStatementJobRepositoryProvider(baseStorage storage.Storage) (repository.ClientStatementJobRepository, error) {
+	jobStorage, err := storage.ForCollection(baseStorage, infrarepo.ClientStatementJobCollection)
+	if err != nil {
+		return nil, NewProviderError("client_statement_job_repository", err)
+	}
+	return infrarepo.NewClientStatementJobRepository(jobStorage), nil
+}
+
+// ClientStatementServiceProvider creates a client statement service sent from the default legal entity
+// Statements list no entries until a client activity source is available
+func ClientStatementServiceProvider(jobRepo repository.ClientStatementJobRepository, billingService *application.BillingService, documentService *application.DocumentTemplateService, legalEntityService *application.LegalEntityService, currencies *application.CurrencyPolicies, publisher messaging.Publisher) *application.ClientStatementService {
+	return application.NewClientStatementService(jobRepo, billingService, documentService, publisher).
+		WithLegalEntities(legalEntityService).
+		WithCurrencyPolicies(currencies)
+}
+
+// UploadRepositoryProvider creates an upload repository on its collection of the given storage
+func UploadRepositoryProvider(baseStorage storage.Storage) (repository.UploadRepository, error) {
+	uploadStorage, err := storage.ForCollection(baseStorage, infrarepo.UploadCollection)
+	if err != nil {
+		return nil, NewProviderError("upload_repository", err)
+	}
+	return infrarepo.NewUploadRepository(uploadStorage), nil
+}
+
+// ObjectStoreProvider creates the object store keeping upload chunks on their collection of the given storage
+func ObjectStoreProvider(baseStorage storage.Storage) (repository.ObjectStore, error) {
+	chunkStorage, err := storage.ForCollection(baseStorage, infrarepo.UploadChunkCollection)
+	if err != nil {
+		return nil, NewProviderError("object_store", err)
+	}
+	return infrarepo.NewStorageObjectStore(chunkStorage), nil
+}
+
+// UploadServiceProvider creates the resumable upload service handing assembled bank statements to cash application
+func UploadServiceProvider(uploadRepo repository.UploadRepository, objects repository.ObjectStore, cashService *application.CashApplicationService) *application.UploadService {
+	return application.NewUploadService(uploadRepo, objects).
+		WithProcessor(entity.UploadBankStatement, application.BankStatementUploadProcessor(cashService))
+}
+
+// RiskAssessmentRepositoryProvider creates a risk assessment repository on its collection of the given storage
+func RiskAssessmentRepositoryProvider(baseStorage storage.Storage) (repository.RiskAssessmentRepository, error) {
+	assessmentStorage, err := storage.ForCollection(baseStorage, infrarepo.RiskAssessmentCollection)
+	if err != nil {
+		return nil, NewProviderError("risk_assessment_repository", err)
+	}
+	return infrarepo.NewRiskAssessmentRepository(assessmentStorage), nil
+}
+
+// RiskScoringServiceProvider creates a risk scoring service consulting the configured credit bureau
+// Clients are not scored until a provider URL is configured
+func RiskScoringServiceProvider(assessmentRepo repository.RiskAssessmentRepository, clients *httpclient.Registry, config *ContainerConfig) *application.RiskScoringService {
+	riskService := application.NewRiskScoringService(assessmentRepo, config.RiskScoreTTL).
+		WithLargeInvoiceThreshold(config.RiskLargeInvoiceThreshold)
+	if config.RiskProviderURL != "" {
+		riskService.WithProvider(bureau.NewClient(config.RiskProvider, config.RiskProviderURL, config.RiskProviderAPIKey, clients.Client(httpclient.DestinationRiskBureau)))
+	}
+	return riskService
+}
+
+// SagaRepositoryProvider creates a saga repository on its collection of the given storage
+func SagaRepositoryProvider(baseStorage storage.Storage) (repository.SagaRepository, error) {
+	sagaStorage, err := storage.ForCollection(baseStorage, infrarepo.SagaCollection)
+	if err != nil {
+		return nil, NewProviderError("saga_repository", err)
+	}
+	return infrarepo.NewSagaRepository(sagaStorage), nil
+}
+
+// SagaOrchestratorProvider creates the saga orchestrator running multi-step flows
+func SagaOrchestratorProvider(sagaRepo repository.SagaRepository, auditService *application.AuditService, config *ContainerConfig) *application.SagaOrchestrator {
+	return application.NewSagaOrchestrator(sagaRepo, auditService, config.SagaMaxAttempts, config.SagaStuckAfter)
+}
+
+// InvoicePaymentServiceProvider creates the invoice card payment service and registers its saga with orchestrator
+// Card payments are disabled (nil) when no payment gateway is configured. The saga publishes through the raw
+// publisher: a failed publish fails the step, which the orchestrator retries or compensates
+func InvoicePaymentServiceProvider(orchestrator *application.SagaOrchestrator, billingService *application.BillingService, publisher messaging.Publisher, clients *httpclient.Registry, config *ContainerConfig) *application.InvoicePaymentService {
+	if config.PaymentGatewayURL == "" {
+		return nil
+	}
+	charger := gateway.NewChargeClient(config.PaymentGatewayURL, config.PaymentGatewayAPIKey, clients.Client(httpclient.DestinationPaymentGateway))
+	return application.NewInvoicePaymentService(orchestrator, billingService, charger, publisher)
+}
@@ -0,0 +1,88 @@
+package di
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+)
+
+// sandboxResource is the audit resource type for the sandbox environment
+const sandboxResource = "sandbox"
+
+// Sandbox is the environment serving sandbox tenants and API keys
+// It runs a container of its own on in-memory storage, so sandbox writes never reach live data
+type Sandbox struct {
+	config       *ContainerConfig
+	auditService *application.AuditService
+	mutex        sync.RWMutex
+	handler      http.Handler
+}
+
+// NewSandbox creates the sandbox environment of a live configuration
+// Wipes are recorded in the live audit log when auditService is set
+func NewSandbox(live *ContainerConfig, auditService *application.AuditService) (*Sandbox, error) {
+	sandbox := &Sandbox{
+		config:       SandboxContainerConfig(live),
+		auditService: auditService,
+	}
+
+	handler, err := sandbox.build()
+	if err != nil {
+		return nil, err
+	}
+	sandbox.handler = handler
+	return sandbox, nil
+}
+
+// SandboxContainerConfig derives the configuration of the sandbox container from the live configuration
+// Storage is in memory, payouts come from the gateway test mode, published events are marked as sandbox traffic
+// and risk scoring is off so synthetic clients are never sent to the bureau
+func SandboxContainerConfig(live *ContainerConfig) *ContainerConfig {
+	config := *live
+	config.StorageType = "memory"
+	config.MigrationEnabled = false
+	config.PaymentGatewayURL = live.SandboxGatewayURL
+	config.PaymentGatewayAPIKey = live.SandboxGatewayAPIKey
+	config.RiskProviderURL = ""
+	config.DemoSeedEnabled = live.SandboxSeedClients > 0
+	config.DemoClients = live.SandboxSeedClients
+	config.SandboxEnabled = false
+	config.SandboxMode = true
+	return &config
+}
+
+// ServeHTTP serves a sandbox request
+func (s *Sandbox) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mutex.RLock()
+	handler := s.handler
+	s.mutex.RUnlock()
+
+	handler.ServeHTTP(w, r)
+}
+
+// Wipe replaces the sandbox with a freshly seeded environment, discarding all sandbox data
+func (s *Sandbox) Wipe(actor string) error {
+	handler, err := s.build()
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	s.handler = handler
+	s.mutex.Unlock()
+
+	if s.auditService != nil {
+		return s.auditService.Record(application.AuditActionSandboxWiped, actor, "", sandboxResource, "", nil)
+	}
+	return nil
+}
+
+// build creates the HTTP handler of a new sandbox container
+func (s *Sandbox) build() (http.Handler, error) {
+	server, err := NewContainer(s.config).GetHTTPServer()
+	if err != nil {
+		return nil, NewProviderError("sandbox", err)
+	}
+	return server.Handler(), nil
+}
@@ -0,0 +1,231 @@
+package entity
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/google/uuid"
+)
+
+// ApprovalKind is the kind of operation awaiting approval
+type ApprovalKind string
+
+const (
+	ApprovalKindCreditNote ApprovalKind = "credit_note"
+	ApprovalKindRefund     ApprovalKind = "refund"
+
+	// ApprovalKindCreditLimitOverride is an invoice issued although it takes the client over its credit limit
+	ApprovalKindCreditLimitOverride ApprovalKind = "credit_limit_override"
+)
+
+// ApprovalStatus is the state of an approval request
+type ApprovalStatus string
+
+const (
+	// ApprovalStatusPending means a second user with the approver role must confirm the operation
+	ApprovalStatusPending ApprovalStatus = "pending_approval"
+
+	// ApprovalStatusApproved means the operation may be issued (approved, or below the threshold)
+	ApprovalStatusApproved ApprovalStatus = "approved"
+)
+
+// ApprovalRequest is a credit note or refund held for two-step approval
+// Operations above the approval threshold start pending and need a second user to approve them;
+// smaller ones are approved on creation
+type ApprovalRequest struct {
+	id          string
+	kind        ApprovalKind
+	clientID    string
+	referenceID string // Invoice credited or payment refunded
+	amount      valueobject.Money
+	reason      string
+	status      ApprovalStatus
+	requestedBy string
+	approvedBy  string
+	approvedAt  *time.Time
+	createdAt   time.Time
+	updatedAt   time.Time
+}
+
+// NewApprovalRequest creates an approval request with validation
+// The request needs approval when amount exceeds threshold (minor units of the amount's currency)
+func NewApprovalRequest(kind ApprovalKind, clientID, referenceID string, amount valueobject.Money, reason, requestedBy string, threshold int64) (*ApprovalRequest, error) {
+	clientID = strings.TrimSpace(clientID)
+	referenceID = strings.TrimSpace(referenceID)
+	reason = strings.TrimSpace(reason)
+	requestedBy = strings.TrimSpace(requestedBy)
+
+	if kind != ApprovalKindCreditNote && kind != ApprovalKindRefund && kind != ApprovalKindCreditLimitOverride {
+		return nil, errors.NewValidationError("kind", kind, errors.ValidationFormat, "kind must be one of: credit_note, refund, credit_limit_override")
+	}
+	if clientID == "" {
+		return nil, errors.NewValidationError("client_id", clientID, errors.ValidationRequired, "client ID is required")
+	}
+	if referenceID == "" {
+		return nil, errors.NewValidationError("reference_id", referenceID, errors.ValidationRequired, "reference ID is required")
+	}
+	if !amount.IsPositive() {
+		return nil, errors.NewValidationError("amount", amount.Amount(), errors.ValidationRange, "amount must be positive")
+	}
+	if len(reason) > 500 {
+		return nil, errors.NewValidationError("reason", reason, errors.ValidationLength, "reason must be at most 500 characters")
+	}
+	if requestedBy == "" {
+		return nil, errors.NewValidationError("requested_by", requestedBy, errors.ValidationRequired, "requester is required")
+	}
+
+	now := time.Now().UTC()
+	request := &ApprovalRequest{
+		id:          uuid.New().String(),
+		kind:        kind,
+		clientID:    clientID,
+		referenceID: referenceID,
+		amount:      amount,
+		reason:      reason,
+		status:      ApprovalStatusPending,
+		requestedBy: requestedBy,
+		createdAt:   now,
+		updatedAt:   now,
+	}
+	if amount.Amount() <= threshold {
+		request.status = ApprovalStatusApproved
+		request.approvedAt = &now
+	}
+	return request, nil
+}
+
+// Getters
+func (a *ApprovalRequest) ID() string {
+	return a.id
+}
+
+func (a *ApprovalRequest) Kind() ApprovalKind {
+	return a.kind
+}
+
+func (a *ApprovalRequest) ClientID() string {
+	return a.clientID
+}
+
+func (a *ApprovalRequest) ReferenceID() string {
+	return a.referenceID
+}
+
+func (a *ApprovalRequest) Amount() valueobject.Money {
+	return a.amount
+}
+
+func (a *ApprovalRequest) Reason() string {
+	return a.reason
+}
+
+func (a *ApprovalRequest) Status() ApprovalStatus {
+	return a.status
+}
+
+func (a *ApprovalRequest) RequestedBy() string {
+	return a.requestedBy
+}
+
+// ApprovedBy returns the approver (empty when pending or approved automatically)
+func (a *ApprovalRequest) ApprovedBy() string {
+	return a.approvedBy
+}
+
+func (a *ApprovalRequest) ApprovedAt() *time.Time {
+	return a.approvedAt
+}
+
+func (a *ApprovalRequest) CreatedAt() time.Time {
+	return a.createdAt
+}
+
+func (a *ApprovalRequest) UpdatedAt() time.Time {
+	return a.updatedAt
+}
+
+// IsPending checks if the request still awaits approval
+func (a *ApprovalRequest) IsPending() bool {
+	return a.status == ApprovalStatusPending
+}
+
+// Approve confirms a pending request; the approver must not be the requester
+func (a *ApprovalRequest) Approve(approver string) error {
+	approver = strings.TrimSpace(approver)
+	if approver == "" {
+		return errors.NewValidationError("approved_by", approver, errors.ValidationRequired, "approver is required")
+	}
+	if !a.IsPending() {
+		return errors.ErrApprovalNotPending
+	}
+	if approver == a.requestedBy {
+		return errors.ErrSelfApproval
+	}
+
+	now := time.Now().UTC()
+	a.status = ApprovalStatusApproved
+	a.approvedBy = approver
+	a.approvedAt = &now
+	a.updatedAt = now
+	return nil
+}
+
+// approvalRequestJSON is the persisted form of an ApprovalRequest
+type approvalRequestJSON struct {
+	ID          string            `json:"id"`
+	Kind        ApprovalKind      `json:"kind"`
+	ClientID    string            `json:"clientId"`
+	ReferenceID string            `json:"referenceId"`
+	Amount      valueobject.Money `json:"amount"`
+	Reason      string            `json:"reason,omitempty"`
+	Status      ApprovalStatus    `json:"status"`
+	RequestedBy string            `json:"requestedBy"`
+	ApprovedBy  string            `json:"approvedBy,omitempty"`
+	ApprovedAt  *time.Time        `json:"approvedAt,omitempty"`
+	CreatedAt   time.Time         `json:"createdAt"`
+	UpdatedAt   time.Time         `json:"updatedAt"`
+}
+
+// MarshalJSON implements custom JSON marshaling for ApprovalRequest
+func (a *ApprovalRequest) MarshalJSON() ([]byte, error) {
+	return json.Marshal(approvalRequestJSON{
+		ID:          a.id,
+		Kind:        a.kind,
+		ClientID:    a.clientID,
+		ReferenceID: a.referenceID,
+		Amount:      a.amount,
+		Reason:      a.reason,
+		Status:      a.status,
+		RequestedBy: a.requestedBy,
+		ApprovedBy:  a.approvedBy,
+		ApprovedAt:  a.approvedAt,
+		CreatedAt:   a.createdAt,
+		UpdatedAt:   a.updatedAt,
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for ApprovalRequest
+func (a *ApprovalRequest) UnmarshalJSON(data []byte) error {
+	var jsonRequest approvalRequestJSON
+	if err := json.Unmarshal(data, &jsonRequest); err != nil {
+		return err
+	}
+
+	a.id = jsonRequest.ID
+	a.kind = jsonRequest.Kind
+	a.clientID = jsonRequest.ClientID
+	a.referenceID = jsonRequest.ReferenceID
+	a.amount = jsonRequest.Amount
+	a.reason = jsonRequest.Reason
+	a.status = jsonRequest.Status
+	a.requestedBy = jsonRequest.RequestedBy
+	a.approvedBy = jsonRequest.ApprovedBy
+	a.approvedAt = jsonRequest.ApprovedAt
+	a.createdAt = jsonRequest.CreatedAt
+	a.updatedAt = jsonRequest.UpdatedAt
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+)
+
+// ArchivedInvoice is a closed invoice moved to cold storage with its payments
+// The invoice and payments are kept as they were, so rehydrating restores them unchanged
+type ArchivedInvoice struct {
+	invoice    *Invoice
+	payments   []*Payment
+	archivedAt time.Time
+}
+
+// NewArchivedInvoice archives a paid or void invoice with its payments
+func NewArchivedInvoice(invoice *Invoice, payments []*Payment, at time.Time) (*ArchivedInvoice, error) {
+	if !invoice.IsClosed() {
+		return nil, errors.ErrInvoiceNotArchivable
+	}
+	return &ArchivedInvoice{
+		invoice:    invoice,
+		payments:   append([]*Payment(nil), payments...),
+		archivedAt: at.UTC(),
+	}, nil
+}
+
+// Getters
+func (a *ArchivedInvoice) InvoiceID() string {
+	return a.invoice.ID()
+}
+
+func (a *ArchivedInvoice) Invoice() *Invoice {
+	return a.invoice
+}
+
+// Payments returns a copy of the payments of the invoice, oldest first
+func (a *ArchivedInvoice) Payments() []*Payment {
+	return append([]*Payment(nil), a.payments...)
+}
+
+func (a *ArchivedInvoice) ArchivedAt() time.Time {
+	return a.archivedAt
+}
+
+// archivedInvoiceJSON is the persisted form of an ArchivedInvoice
+type archivedInvoiceJSON struct {
+	Invoice    *Invoice   `json:"invoice"`
+	Payments   []*Payment `json:"payments,omitempty"`
+	ArchivedAt time.Time  `json:"archivedAt"`
+}
+
+// MarshalJSON implements custom JSON marshaling for ArchivedInvoice
+func (a *ArchivedInvoice) MarshalJSON() ([]byte, error) {
+	return json.Marshal(archivedInvoiceJSON{
+		Invoice:    a.invoice,
+		Payments:   a.payments,
+		ArchivedAt: a.archivedAt,
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for ArchivedInvoice
+func (a *ArchivedInvoice) UnmarshalJSON(data []byte) error {
+	var jsonArchive archivedInvoiceJSON
+	if err := json.Unmarshal(data, &jsonArchive); err != nil {
+		return err
+	}
+
+	a.invoice = jsonArchive.Invoice
+	if a.invoice == nil {
+		a.invoice = &Invoice{}
+	}
+	a.payments = jsonArchive.Payments
+	a.archivedAt = jsonArchive.ArchivedAt
+
+	return nil
+}
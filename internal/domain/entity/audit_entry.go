@@ -0,0 +1,117 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditEntry records an administrative or security-relevant change
+// Entries are immutable once created
+type AuditEntry struct {
+	id           string
+	action       string
+	actor        string
+	tenantID     string
+	resourceType string
+	resourceID   string
+	details      map[string]interface{}
+	occurredAt   time.Time
+}
+
+// NewAuditEntry creates a new audit entry timestamped now
+func NewAuditEntry(action, actor, tenantID, resourceType, resourceID string, details map[string]interface{}) *AuditEntry {
+	if details == nil {
+		details = make(map[string]interface{})
+	}
+
+	return &AuditEntry{
+		id:           uuid.New().String(),
+		action:       action,
+		actor:        actor,
+		tenantID:     tenantID,
+		resourceType: resourceType,
+		resourceID:   resourceID,
+		details:      details,
+		occurredAt:   time.Now().UTC(),
+	}
+}
+
+// Getters
+func (a *AuditEntry) ID() string {
+	return a.id
+}
+
+func (a *AuditEntry) Action() string {
+	return a.action
+}
+
+func (a *AuditEntry) Actor() string {
+	return a.actor
+}
+
+func (a *AuditEntry) TenantID() string {
+	return a.tenantID
+}
+
+func (a *AuditEntry) ResourceType() string {
+	return a.resourceType
+}
+
+func (a *AuditEntry) ResourceID() string {
+	return a.resourceID
+}
+
+func (a *AuditEntry) Details() map[string]interface{} {
+	return a.details
+}
+
+func (a *AuditEntry) OccurredAt() time.Time {
+	return a.occurredAt
+}
+
+// auditEntryJSON is the persisted form of an AuditEntry
+type auditEntryJSON struct {
+	ID           string                 `json:"id"`
+	Action       string                 `json:"action"`
+	Actor        string                 `json:"actor"`
+	TenantID     string                 `json:"tenantId"`
+	ResourceType string                 `json:"resourceType"`
+	ResourceID   string                 `json:"resourceId"`
+	Details      map[string]interface{} `json:"details"`
+	OccurredAt   time.Time              `json:"occurredAt"`
+}
+
+// MarshalJSON implements custom JSON marshaling for AuditEntry
+func (a *AuditEntry) MarshalJSON() ([]byte, error) {
+	return json.Marshal(auditEntryJSON{
+		ID:           a.id,
+		Action:       a.action,
+		Actor:        a.actor,
+		TenantID:     a.tenantID,
+		ResourceType: a.resourceType,
+		ResourceID:   a.resourceID,
+		Details:      a.details,
+		OccurredAt:   a.occurredAt,
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for AuditEntry
+func (a *AuditEntry) UnmarshalJSON(data []byte) error {
+	var jsonEntry auditEntryJSON
+	if err := json.Unmarshal(data, &jsonEntry); err != nil {
+		return err
+	}
+
+	a.id = jsonEntry.ID
+	a.action = jsonEntry.Action
+	a.actor = jsonEntry.Actor
+	a.tenantID = jsonEntry.TenantID
+	a.resourceType = jsonEntry.ResourceType
+	a.resourceID = jsonEntry.ResourceID
+	a.details = jsonEntry.Details
+	a.occurredAt = jsonEntry.OccurredAt
+
+	return nil
+}
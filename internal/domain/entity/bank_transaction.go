@@ -0,0 +1,227 @@
+package entity
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/google/uuid"
+)
+
+// BankTransactionStatus is the cash application state of an imported bank transaction
+type BankTransactionStatus string
+
+const (
+	// BankTransactionUnmatched means the money received has not been applied to an invoice yet
+	BankTransactionUnmatched BankTransactionStatus = "unmatched"
+
+	// BankTransactionReconciled means finance confirmed the invoice the money pays
+	BankTransactionReconciled BankTransactionStatus = "reconciled"
+)
+
+// BankTransaction is money received on a company bank account, imported from a bank statement
+// and applied to the invoice it pays once finance confirms the match
+type BankTransaction struct {
+	id               string
+	accountIBAN      string
+	bankReference    string
+	bookingDate      time.Time
+	amount           valueobject.Money
+	reference        string
+	counterpartyName string
+	counterpartyIBAN string
+	status           BankTransactionStatus
+	invoiceID        string
+	clientID         string
+	reconciledBy     string
+	reconciledAt     *time.Time
+	importedAt       time.Time
+}
+
+// NewBankTransaction creates an unmatched bank transaction with validation
+// Only money received (positive amounts) can be applied to invoices
+func NewBankTransaction(accountIBAN, bankReference string, bookingDate time.Time, amount valueobject.Money, reference, counterpartyName, counterpartyIBAN string) (*BankTransaction, error) {
+	if !amount.IsPositive() {
+		return nil, errors.NewValidationError("amount", amount.Amount(), errors.ValidationRange, "amount must be positive")
+	}
+	if bookingDate.IsZero() {
+		return nil, errors.NewValidationError("booking_date", bookingDate, errors.ValidationRequired, "booking date is required")
+	}
+
+	return &BankTransaction{
+		id:               uuid.New().String(),
+		accountIBAN:      strings.TrimSpace(accountIBAN),
+		bankReference:    strings.TrimSpace(bankReference),
+		bookingDate:      bookingDate.UTC(),
+		amount:           amount,
+		reference:        strings.TrimSpace(reference),
+		counterpartyName: strings.TrimSpace(counterpartyName),
+		counterpartyIBAN: strings.TrimSpace(counterpartyIBAN),
+		status:           BankTransactionUnmatched,
+		importedAt:       time.Now().UTC(),
+	}, nil
+}
+
+// Getters
+func (t *BankTransaction) ID() string {
+	return t.id
+}
+
+func (t *BankTransaction) AccountIBAN() string {
+	return t.accountIBAN
+}
+
+// BankReference returns the reference the bank assigned to the booking (empty when the statement has none)
+func (t *BankTransaction) BankReference() string {
+	return t.bankReference
+}
+
+func (t *BankTransaction) BookingDate() time.Time {
+	return t.bookingDate
+}
+
+func (t *BankTransaction) Amount() valueobject.Money {
+	return t.amount
+}
+
+// Reference returns the remittance information entered by the payer
+func (t *BankTransaction) Reference() string {
+	return t.reference
+}
+
+func (t *BankTransaction) CounterpartyName() string {
+	return t.counterpartyName
+}
+
+func (t *BankTransaction) CounterpartyIBAN() string {
+	return t.counterpartyIBAN
+}
+
+func (t *BankTransaction) Status() BankTransactionStatus {
+	return t.status
+}
+
+// InvoiceID returns the invoice the transaction was applied to (empty until reconciled)
+func (t *BankTransaction) InvoiceID() string {
+	return t.invoiceID
+}
+
+func (t *BankTransaction) ClientID() string {
+	return t.clientID
+}
+
+func (t *BankTransaction) ReconciledBy() string {
+	return t.reconciledBy
+}
+
+func (t *BankTransaction) ReconciledAt() *time.Time {
+	return t.reconciledAt
+}
+
+func (t *BankTransaction) ImportedAt() time.Time {
+	return t.importedAt
+}
+
+// Fingerprint identifies the booking across statement imports so overlapping statements are not imported twice
+func (t *BankTransaction) Fingerprint() string {
+	return strings.Join([]string{
+		t.accountIBAN,
+		t.bankReference,
+		t.bookingDate.Format("2006-01-02"),
+		t.amount.String(),
+		t.reference,
+	}, "|")
+}
+
+// Reconcile applies the transaction to the invoice it pays, as confirmed by a finance user
+func (t *BankTransaction) Reconcile(invoiceID, clientID, reconciledBy string) error {
+	invoiceID = strings.TrimSpace(invoiceID)
+	clientID = strings.TrimSpace(clientID)
+	reconciledBy = strings.TrimSpace(reconciledBy)
+
+	if invoiceID == "" {
+		return errors.NewValidationError("invoice_id", invoiceID, errors.ValidationRequired, "invoice ID is required")
+	}
+	if clientID == "" {
+		return errors.NewValidationError("client_id", clientID, errors.ValidationRequired, "client ID is required")
+	}
+	if reconciledBy == "" {
+		return errors.NewValidationError("reconciled_by", reconciledBy, errors.ValidationRequired, "reconciling user is required")
+	}
+	if t.status != BankTransactionUnmatched {
+		return errors.ErrBankTransactionAlreadyReconciled
+	}
+
+	now := time.Now().UTC()
+	t.status = BankTransactionReconciled
+	t.invoiceID = invoiceID
+	t.clientID = clientID
+	t.reconciledBy = reconciledBy
+	t.reconciledAt = &now
+	return nil
+}
+
+// bankTransactionJSON is the persisted form of a BankTransaction
+type bankTransactionJSON struct {
+	ID               string                `json:"id"`
+	AccountIBAN      string                `json:"accountIban,omitempty"`
+	BankReference    string                `json:"bankReference,omitempty"`
+	BookingDate      time.Time             `json:"bookingDate"`
+	Amount           valueobject.Money     `json:"amount"`
+	Reference        string                `json:"reference,omitempty"`
+	CounterpartyName string                `json:"counterpartyName,omitempty"`
+	CounterpartyIBAN string                `json:"counterpartyIban,omitempty"`
+	Status           BankTransactionStatus `json:"status"`
+	InvoiceID        string                `json:"invoiceId,omitempty"`
+	ClientID         string                `json:"clientId,omitempty"`
+	ReconciledBy     string                `json:"reconciledBy,omitempty"`
+	ReconciledAt     *time.Time            `json:"reconciledAt,omitempty"`
+	ImportedAt       time.Time             `json:"importedAt"`
+}
+
+// MarshalJSON implements custom JSON marshaling for BankTransaction
+func (t *BankTransaction) MarshalJSON() ([]byte, error) {
+	return json.Marshal(bankTransactionJSON{
+		ID:               t.id,
+		AccountIBAN:      t.accountIBAN,
+		BankReference:    t.bankReference,
+		BookingDate:      t.bookingDate,
+		Amount:           t.amount,
+		Reference:        t.reference,
+		CounterpartyName: t.counterpartyName,
+		CounterpartyIBAN: t.counterpartyIBAN,
+		Status:           t.status,
+		InvoiceID:        t.invoiceID,
+		ClientID:         t.clientID,
+		ReconciledBy:     t.reconciledBy,
+		ReconciledAt:     t.reconciledAt,
+		ImportedAt:       t.importedAt,
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for BankTransaction
+func (t *BankTransaction) UnmarshalJSON(data []byte) error {
+	var jsonTransaction bankTransactionJSON
+	if err := json.Unmarshal(data, &jsonTransaction); err != nil {
+		return err
+	}
+
+	t.id = jsonTransaction.ID
+	t.accountIBAN = jsonTransaction.AccountIBAN
+	t.bankReference = jsonTransaction.BankReference
+	t.bookingDate = jsonTransaction.BookingDate
+	t.amount = jsonTransaction.Amount
+	t.reference = jsonTransaction.Reference
+	t.counterpartyName = jsonTransaction.CounterpartyName
+	t.counterpartyIBAN = jsonTransaction.CounterpartyIBAN
+	t.status = jsonTransaction.Status
+	t.invoiceID = jsonTransaction.InvoiceID
+	t.clientID = jsonTransaction.ClientID
+	t.reconciledBy = jsonTransaction.ReconciledBy
+	t.reconciledAt = jsonTransaction.ReconciledAt
+	t.importedAt = jsonTransaction.ImportedAt
+
+	return nil
+}
@@ -2,8 +2,11 @@ package entity
 
 import (
 	"encoding/json"
+	"fmt"
+	"sort"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
 	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
@@ -11,27 +14,104 @@ import (
 	"github.com/google/uuid"
 )
 
+// ClientStatus is the lifecycle state of a client
+type ClientStatus string
+
+const (
+	// ClientActive clients can be invoiced
+	ClientActive ClientStatus = "active"
+
+	// ClientSuspended clients keep their history but cannot be invoiced until they are reactivated
+	ClientSuspended ClientStatus = "suspended"
+
+	// ClientClosed clients are kept for their history only; closing is final
+	ClientClosed ClientStatus = "closed"
+)
+
+// clientStatusTransitions lists the statuses a client can move to from each status
+var clientStatusTransitions = map[ClientStatus][]ClientStatus{
+	ClientActive:    {ClientSuspended, ClientClosed},
+	ClientSuspended: {ClientActive, ClientClosed},
+}
+
+// ValidateClientStatus checks that status is a known client status
+func ValidateClientStatus(status ClientStatus) error {
+	switch status {
+	case ClientActive, ClientSuspended, ClientClosed:
+		return nil
+	default:
+		return errors.NewValidationError("status", status, errors.ValidationFormat, "status must be one of: active, suspended, closed")
+	}
+}
+
+const (
+	// MaxClientTags is the number of tags a client can carry
+	MaxClientTags = 20
+
+	// MaxClientTagLength is the longest tag a client can carry
+	MaxClientTagLength = 50
+)
+
+// NormalizeClientTag trims and lowercases a tag, so tags match whatever their case
+// Tags are free-form but cannot contain slashes or control characters, since they appear in URL paths
+func NormalizeClientTag(tag string) (string, error) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if tag == "" {
+		return "", errors.NewValidationError("tag", tag, errors.ValidationRequired, "tag is required")
+	}
+	if len(tag) > MaxClientTagLength {
+		return "", errors.NewValidationError("tag", tag, errors.ValidationLength, fmt.Sprintf("tag must be at most %d characters", MaxClientTagLength))
+	}
+	if strings.ContainsFunc(tag, func(r rune) bool { return r == '/' || unicode.IsControl(r) }) {
+		return "", errors.NewValidationError("tag", tag, errors.ValidationFormat, "tag cannot contain slashes or control characters")
+	}
+	return tag, nil
+}
+
 // Client represents a billing client aggregate root
 type Client struct {
-	id        string `validate:"required,min=2,max=100"`
-	name      string `validate:"required,min=2,max=100"`
-	email     valueobject.Email
-	phone     valueobject.Phone
-	address   string `validate:"omitempty,max=500"`
-	createdAt time.Time
-	updatedAt time.Time
+	id                string `validate:"required,min=2,max=100"`
+	name              string `validate:"required,min=2,max=100"`
+	email             valueobject.Email
+	emailKey          string // Canonical address compared by uniqueness checks, empty until normalized
+	phone             valueobject.Phone
+	phoneE164         string // E.164 form of the phone searched by support, resolved with the region it was entered in
+	address           string `validate:"omitempty,max=500"`
+	country           string // ISO 3166-1 alpha-2 country of the address, whose phone and address rules apply; empty applies none
+	tenantID          string // Tenant the client was created for, in which its external reference is unique
+	externalReference string // Order or contract ID of the client in the integrator's system
+	status            ClientStatus
+	tags              []string                 // Normalized tags segmenting the client base, sorted
+	paymentTerms      valueobject.PaymentTerms // Terms the due date of the client's invoices is computed from, empty without terms
+	createdAt         time.Time
+	updatedAt         time.Time
 }
 
 // NewClient creates a new Client with validation
 func NewClient(name, email, phone, address string) (*Client, error) {
+	return NewClientInCountry(name, email, phone, address, "")
+}
+
+// NewClientInCountry creates a new Client in a country (ISO 3166-1 alpha-2 code, optional), validating phone and
+// address against the country's regional rules
+func NewClientInCountry(name, email, phone, address, country string) (*Client, error) {
 	// Validate and create value objects
 	emailVO, err := valueobject.NewEmail(email)
 	if err != nil {
 		return nil, err // ValidationError already properly structured
 	}
 
-	phoneVO, err := valueobject.NewPhone(phone)
+	country, err = normalizeClientCountry(country)
 	if err != nil {
+		return nil, err
+	}
+
+	phoneVO, err := valueobject.NewPhoneInCountry(phone, country)
+	if err != nil {
+		return nil, err // ValidationError already properly structured
+	}
+
+	if err := valueobject.ValidateAddressForCountry(address, country); err != nil {
 		return nil, err // ValidationError already properly structured
 	}
 
@@ -45,7 +125,10 @@ func NewClient(name, email, phone, address string) (*Client, error) {
 		name:      normalizedName,
 		email:     emailVO,
 		phone:     phoneVO,
+		phoneE164: phoneVO.E164(),
 		address:   normalizedAddress,
+		country:   country,
+		status:    ClientActive,
 		createdAt: time.Now().UTC(),
 		updatedAt: time.Now().UTC(),
 	}
@@ -136,7 +219,9 @@ func NewClientWithID(id, name, email, phone, address string, createdAt, updatedA
 		name:      strings.TrimSpace(name),
 		email:     emailVO,
 		phone:     phoneVO,
+		phoneE164: phoneVO.E164(),
 		address:   strings.TrimSpace(address),
+		status:    ClientActive,
 		createdAt: createdAt,
 		updatedAt: updatedAt,
 	}
@@ -149,18 +234,35 @@ func NewClientWithID(id, name, email, phone, address string, createdAt, updatedA
 	return client, nil
 }
 
-// UpdateDetails updates client details with validation
+// UpdateDetails updates client details with validation, keeping the client's country
 func (c *Client) UpdateDetails(name, phone, address string) error {
+	return c.UpdateDetailsInCountry(name, phone, address, c.country)
+}
+
+// UpdateDetailsInCountry updates client details and country (optional), validating phone and address against the
+// country's regional rules
+func (c *Client) UpdateDetailsInCountry(name, phone, address, country string) error {
+	country, err := normalizeClientCountry(country)
+	if err != nil {
+		return err
+	}
+
 	// Create new phone value object
-	phoneVO, err := valueobject.NewPhone(phone)
+	phoneVO, err := valueobject.NewPhoneInCountry(phone, country)
 	if err != nil {
 		return err // ValidationError already properly structured
 	}
 
+	if err := valueobject.ValidateAddressForCountry(address, country); err != nil {
+		return err // ValidationError already properly structured
+	}
+
 	// Update fields (normalization + validation via struct tags)
 	c.name = strings.TrimSpace(name)
 	c.phone = phoneVO
+	c.phoneE164 = phoneVO.E164()
 	c.address = strings.TrimSpace(address)
+	c.country = country
 	c.updatedAt = time.Now().UTC()
 
 	// Validate the updated client using hybrid approach
@@ -176,11 +278,115 @@ func (c *Client) UpdateEmail(email string) error {
 	}
 
 	c.email = emailVO
+	c.emailKey = ""
 	c.updatedAt = time.Now().UTC()
 
 	return nil
 }
 
+// AssignExternalReference sets the tenant of the client and its reference in the integrator's system
+// Uniqueness in the tenant is enforced by the external reference index, not by the client
+func (c *Client) AssignExternalReference(tenantID, reference string) error {
+	reference, err := NormalizeExternalReference(reference)
+	if err != nil {
+		return err
+	}
+
+	c.tenantID = strings.TrimSpace(tenantID)
+	c.externalReference = reference
+	c.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// SetPaymentTerms validates and sets the payment terms of the client's invoices; empty terms remove them
+func (c *Client) SetPaymentTerms(terms string) error {
+	parsed, err := valueobject.ParsePaymentTerms(terms)
+	if err != nil {
+		return err
+	}
+
+	c.paymentTerms = parsed
+	c.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// ChangeStatus moves the client to another lifecycle status
+// Active and suspended clients can switch between each other or be closed; closed clients cannot change
+func (c *Client) ChangeStatus(status ClientStatus) error {
+	if err := ValidateClientStatus(status); err != nil {
+		return err
+	}
+	for _, allowed := range clientStatusTransitions[c.Status()] {
+		if allowed == status {
+			c.status = status
+			c.updatedAt = time.Now().UTC()
+			return nil
+		}
+	}
+
+	conflict := errors.NewBusinessRuleError("client_status_transition", errors.BusinessRuleConflict, fmt.Sprintf("a %s client cannot become %s", c.Status(), status))
+	conflict.Context["from"] = string(c.Status())
+	conflict.Context["to"] = string(status)
+	return conflict
+}
+
+// AddTags tags the client; tags it already carries are ignored
+func (c *Client) AddTags(tags ...string) error {
+	if len(tags) == 0 {
+		return errors.NewValidationError("tags", tags, errors.ValidationRequired, "at least one tag is required")
+	}
+
+	merged := append([]string(nil), c.tags...)
+	for _, tag := range tags {
+		normalized, err := NormalizeClientTag(tag)
+		if err != nil {
+			return err
+		}
+		if !containsTag(merged, normalized) {
+			merged = append(merged, normalized)
+		}
+	}
+	if len(merged) > MaxClientTags {
+		return errors.NewValidationError("tags", tags, errors.ValidationLength, fmt.Sprintf("a client can carry at most %d tags", MaxClientTags))
+	}
+	if len(merged) == len(c.tags) {
+		return nil
+	}
+
+	sort.Strings(merged)
+	c.tags = merged
+	c.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// RemoveTag removes a tag from the client and reports whether the client carried it
+func (c *Client) RemoveTag(tag string) bool {
+	normalized := strings.ToLower(strings.TrimSpace(tag))
+	for i, existing := range c.tags {
+		if existing == normalized {
+			c.tags = append(c.tags[:i:i], c.tags[i+1:]...)
+			c.updatedAt = time.Now().UTC()
+			return true
+		}
+	}
+	return false
+}
+
+// HasTag reports whether the client carries a tag (whatever its case)
+func (c *Client) HasTag(tag string) bool {
+	return containsTag(c.tags, strings.ToLower(strings.TrimSpace(tag)))
+}
+
+// containsTag reports whether normalized tags contain a normalized tag
+func containsTag(tags []string, tag string) bool {
+	for _, existing := range tags {
+		if existing == tag {
+			return true
+		}
+	}
+	return false
+}
+
 // Getters
 func (c *Client) ID() string {
 	return c.id
@@ -202,6 +408,51 @@ func (c *Client) Address() string {
 	return c.address
 }
 
+// Country returns the ISO 3166-1 alpha-2 country of the client's address (empty when unknown)
+func (c *Client) Country() string {
+	return c.country
+}
+
+// normalizeClientCountry upper-cases a client country, which must be an ISO 3166-1 alpha-2 code when given
+func normalizeClientCountry(country string) (string, error) {
+	country = strings.ToUpper(strings.TrimSpace(country))
+	if country != "" && !isCountryCode(country) {
+		return "", errors.NewValidationError("country", country, errors.ValidationFormat, "country must be an ISO 3166-1 alpha-2 code")
+	}
+	return country, nil
+}
+
+func (c *Client) TenantID() string {
+	return c.tenantID
+}
+
+func (c *Client) ExternalReference() string {
+	return c.externalReference
+}
+
+// Status returns the lifecycle status (clients stored before statuses existed are active)
+func (c *Client) Status() ClientStatus {
+	if c.status == "" {
+		return ClientActive
+	}
+	return c.status
+}
+
+// CanBeInvoiced reports whether new invoices can be created for the client
+func (c *Client) CanBeInvoiced() bool {
+	return c.Status() == ClientActive
+}
+
+// Tags returns the tags of the client, sorted
+func (c *Client) Tags() []string {
+	return append([]string(nil), c.tags...)
+}
+
+// PaymentTerms returns the payment terms of the client's invoices (empty without terms)
+func (c *Client) PaymentTerms() valueobject.PaymentTerms {
+	return c.paymentTerms
+}
+
 func (c *Client) CreatedAt() time.Time {
 	return c.createdAt
 }
@@ -215,6 +466,27 @@ func (c *Client) EmailString() string {
 	return c.email.String()
 }
 
+// NormalizeEmail sets the address the client is compared by in email uniqueness checks
+func (c *Client) NormalizeEmail(policy valueobject.EmailNormalization) {
+	c.emailKey = c.email.Canonical(policy)
+}
+
+// EmailKey returns the canonical address of the client (its lower-cased address when not normalized)
+func (c *Client) EmailKey() string {
+	if c.emailKey == "" {
+		return c.email.String()
+	}
+	return c.emailKey
+}
+
+// PhoneE164 returns the phone in E.164 form, as resolved when it was entered (empty without a phone)
+func (c *Client) PhoneE164() string {
+	if c.phoneE164 == "" {
+		return c.phone.E164()
+	}
+	return c.phoneE164
+}
+
 // PhoneString returns the phone as string (convenience method for compatibility)
 func (c *Client) PhoneString() string {
 	return c.phone.String()
@@ -237,21 +509,37 @@ func (c *Client) String() string {
 func (c *Client) MarshalJSON() ([]byte, error) {
 	// Create a struct with public fields for JSON marshaling
 	jsonClient := struct {
-		ID        string            `json:"id"`
-		Name      string            `json:"name"`
-		Email     valueobject.Email `json:"email"`
-		Phone     valueobject.Phone `json:"phone"`
-		Address   string            `json:"address"`
-		CreatedAt time.Time         `json:"createdAt"`
-		UpdatedAt time.Time         `json:"updatedAt"`
+		ID                string                   `json:"id"`
+		Name              string                   `json:"name"`
+		Email             valueobject.Email        `json:"email"`
+		EmailKey          string                   `json:"emailKey"`
+		Phone             valueobject.Phone        `json:"phone"`
+		PhoneE164         string                   `json:"phoneE164,omitempty"`
+		Address           string                   `json:"address"`
+		Country           string                   `json:"country,omitempty"`
+		TenantID          string                   `json:"tenantId,omitempty"`
+		ExternalReference string                   `json:"externalReference,omitempty"`
+		Status            ClientStatus             `json:"status"`
+		Tags              []string                 `json:"tags,omitempty"`
+		PaymentTerms      valueobject.PaymentTerms `json:"paymentTerms,omitempty"`
+		CreatedAt         time.Time                `json:"createdAt"`
+		UpdatedAt         time.Time                `json:"updatedAt"`
 	}{
-		ID:        c.id,
-		Name:      c.name,
-		Email:     c.email,
-		Phone:     c.phone,
-		Address:   c.address,
-		CreatedAt: c.createdAt,
-		UpdatedAt: c.updatedAt,
+		ID:                c.id,
+		Name:              c.name,
+		Email:             c.email,
+		EmailKey:          c.EmailKey(),
+		Phone:             c.phone,
+		PhoneE164:         c.PhoneE164(),
+		Address:           c.address,
+		Country:           c.country,
+		TenantID:          c.tenantID,
+		ExternalReference: c.externalReference,
+		Status:            c.Status(),
+		Tags:              c.tags,
+		PaymentTerms:      c.paymentTerms,
+		CreatedAt:         c.createdAt,
+		UpdatedAt:         c.updatedAt,
 	}
 
 	return json.Marshal(jsonClient)
@@ -261,13 +549,21 @@ func (c *Client) MarshalJSON() ([]byte, error) {
 func (c *Client) UnmarshalJSON(data []byte) error {
 	// Create a struct with public fields for JSON unmarshaling
 	var jsonClient struct {
-		ID        string            `json:"id"`
-		Name      string            `json:"name"`
-		Email     valueobject.Email `json:"email"`
-		Phone     valueobject.Phone `json:"phone"`
-		Address   string            `json:"address"`
-		CreatedAt time.Time         `json:"createdAt"`
-		UpdatedAt time.Time         `json:"updatedAt"`
+		ID                string                   `json:"id"`
+		Name              string                   `json:"name"`
+		Email             valueobject.Email        `json:"email"`
+		EmailKey          string                   `json:"emailKey,omitempty"`
+		Phone             valueobject.Phone        `json:"phone"`
+		PhoneE164         string                   `json:"phoneE164,omitempty"`
+		Address           string                   `json:"address"`
+		Country           string                   `json:"country,omitempty"`
+		TenantID          string                   `json:"tenantId,omitempty"`
+		ExternalReference string                   `json:"externalReference,omitempty"`
+		Status            ClientStatus             `json:"status,omitempty"`
+		Tags              []string                 `json:"tags,omitempty"`
+		PaymentTerms      valueobject.PaymentTerms `json:"paymentTerms,omitempty"`
+		CreatedAt         time.Time                `json:"createdAt"`
+		UpdatedAt         time.Time                `json:"updatedAt"`
 	}
 
 	if err := json.Unmarshal(data, &jsonClient); err != nil {
@@ -278,8 +574,16 @@ func (c *Client) UnmarshalJSON(data []byte) error {
 	c.id = jsonClient.ID
 	c.name = jsonClient.Name
 	c.email = jsonClient.Email
+	c.emailKey = jsonClient.EmailKey
 	c.phone = jsonClient.Phone
+	c.phoneE164 = jsonClient.PhoneE164
 	c.address = jsonClient.Address
+	c.country = jsonClient.Country
+	c.tenantID = jsonClient.TenantID
+	c.externalReference = jsonClient.ExternalReference
+	c.status = jsonClient.Status
+	c.tags = jsonClient.Tags
+	c.paymentTerms = jsonClient.PaymentTerms
 	c.createdAt = jsonClient.CreatedAt
 	c.updatedAt = jsonClient.UpdatedAt
 
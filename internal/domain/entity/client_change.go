@@ -0,0 +1,118 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ClientChangeType identifies what happened to a client
+type ClientChangeType string
+
+// Client change types
+const (
+	ClientCreated ClientChangeType = "created"
+	ClientUpdated ClientChangeType = "updated"
+	ClientDeleted ClientChangeType = "deleted"
+)
+
+// ClientChange is an entry of the client change log used for incremental sync
+// Deleted clients are represented by a tombstone: the change carries the ID and tenant but no snapshot
+type ClientChange struct {
+	sequence   int64
+	changeType ClientChangeType
+	clientID   string
+	tenantID   string
+	client     *Client
+	occurredAt time.Time
+}
+
+// NewClientChange creates a change log entry of a client of a tenant with the allocated sequence number
+func NewClientChange(sequence int64, changeType ClientChangeType, clientID, tenantID string, client *Client) *ClientChange {
+	if changeType == ClientDeleted {
+		client = nil
+	}
+
+	return &ClientChange{
+		sequence:   sequence,
+		changeType: changeType,
+		clientID:   clientID,
+		tenantID:   tenantID,
+		client:     client,
+		occurredAt: time.Now().UTC(),
+	}
+}
+
+// Getters
+func (c *ClientChange) Sequence() int64 {
+	return c.sequence
+}
+
+func (c *ClientChange) ChangeType() ClientChangeType {
+	return c.changeType
+}
+
+func (c *ClientChange) ClientID() string {
+	return c.clientID
+}
+
+// TenantID returns the tenant of the changed client
+// Changes logged before the tenant was recorded take it from their snapshot (tombstones: no tenant)
+func (c *ClientChange) TenantID() string {
+	if c.tenantID == "" && c.client != nil {
+		return c.client.TenantID()
+	}
+	return c.tenantID
+}
+
+// Client returns the client snapshot after the change (nil for tombstones)
+func (c *ClientChange) Client() *Client {
+	return c.client
+}
+
+func (c *ClientChange) OccurredAt() time.Time {
+	return c.occurredAt
+}
+
+// IsTombstone checks if the change records a deletion
+func (c *ClientChange) IsTombstone() bool {
+	return c.changeType == ClientDeleted
+}
+
+// clientChangeJSON is the persisted form of a ClientChange
+type clientChangeJSON struct {
+	Sequence   int64            `json:"sequence"`
+	ChangeType ClientChangeType `json:"changeType"`
+	ClientID   string           `json:"clientId"`
+	TenantID   string           `json:"tenantId,omitempty"`
+	Client     *Client          `json:"client,omitempty"`
+	OccurredAt time.Time        `json:"occurredAt"`
+}
+
+// MarshalJSON implements custom JSON marshaling for ClientChange
+func (c *ClientChange) MarshalJSON() ([]byte, error) {
+	return json.Marshal(clientChangeJSON{
+		Sequence:   c.sequence,
+		ChangeType: c.changeType,
+		ClientID:   c.clientID,
+		TenantID:   c.tenantID,
+		Client:     c.client,
+		OccurredAt: c.occurredAt,
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for ClientChange
+func (c *ClientChange) UnmarshalJSON(data []byte) error {
+	var jsonChange clientChangeJSON
+	if err := json.Unmarshal(data, &jsonChange); err != nil {
+		return err
+	}
+
+	c.sequence = jsonChange.Sequence
+	c.changeType = jsonChange.ChangeType
+	c.clientID = jsonChange.ClientID
+	c.tenantID = jsonChange.TenantID
+	c.client = jsonChange.Client
+	c.occurredAt = jsonChange.OccurredAt
+
+	return nil
+}
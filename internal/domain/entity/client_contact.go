@@ -0,0 +1,164 @@
+package entity
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/google/uuid"
+)
+
+// MaxClientContactRoleLength is the longest role a contact can hold
+const MaxClientContactRoleLength = 50
+
+// ClientContact is a person to reach at a client (accounts payable, purchasing, ...), in addition to the client's
+// own email address. Contacts belong to the Client aggregate: they are only reached through their client
+type ClientContact struct {
+	id        string
+	clientID  string
+	name      string
+	role      string // Free-form role at the client, e.g. "Accounts payable"
+	email     valueobject.Email
+	phone     valueobject.Phone
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+// NewClientContact creates a contact of a client with validation
+func NewClientContact(clientID, name, role, email, phone string) (*ClientContact, error) {
+	clientID = strings.TrimSpace(clientID)
+	if clientID == "" {
+		return nil, errors.NewValidationError("client_id", clientID, errors.ValidationRequired, "client ID is required")
+	}
+
+	now := time.Now().UTC()
+	contact := &ClientContact{
+		id:        uuid.New().String(),
+		clientID:  clientID,
+		createdAt: now,
+		updatedAt: now,
+	}
+	if err := contact.apply(name, role, email, phone); err != nil {
+		return nil, err
+	}
+	return contact, nil
+}
+
+// Update replaces the details of the contact
+func (c *ClientContact) Update(name, role, email, phone string) error {
+	if err := c.apply(name, role, email, phone); err != nil {
+		return err
+	}
+	c.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// apply validates and sets the details of the contact
+func (c *ClientContact) apply(name, role, email, phone string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.NewValidationError("name", name, errors.ValidationRequired, "name is required")
+	}
+	if len(name) < 2 || len(name) > 100 {
+		return errors.NewValidationError("name", name, errors.ValidationLength, "name must be between 2 and 100 characters")
+	}
+
+	role = strings.TrimSpace(role)
+	if len(role) > MaxClientContactRoleLength {
+		return errors.NewValidationError("role", role, errors.ValidationLength, "role must be at most 50 characters")
+	}
+
+	emailVO, err := valueobject.NewEmail(email)
+	if err != nil {
+		return err // ValidationError already properly structured
+	}
+	phoneVO, err := valueobject.NewPhone(phone)
+	if err != nil {
+		return err // ValidationError already properly structured
+	}
+
+	c.name = name
+	c.role = role
+	c.email = emailVO
+	c.phone = phoneVO
+	return nil
+}
+
+// Getters
+func (c *ClientContact) ID() string {
+	return c.id
+}
+
+func (c *ClientContact) ClientID() string {
+	return c.clientID
+}
+
+func (c *ClientContact) Name() string {
+	return c.name
+}
+
+func (c *ClientContact) Role() string {
+	return c.role
+}
+
+func (c *ClientContact) Email() valueobject.Email {
+	return c.email
+}
+
+func (c *ClientContact) Phone() valueobject.Phone {
+	return c.phone
+}
+
+func (c *ClientContact) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+func (c *ClientContact) UpdatedAt() time.Time {
+	return c.updatedAt
+}
+
+// clientContactJSON is the persisted form of a ClientContact
+type clientContactJSON struct {
+	ID        string            `json:"id"`
+	ClientID  string            `json:"clientId"`
+	Name      string            `json:"name"`
+	Role      string            `json:"role,omitempty"`
+	Email     valueobject.Email `json:"email"`
+	Phone     valueobject.Phone `json:"phone"`
+	CreatedAt time.Time         `json:"createdAt"`
+	UpdatedAt time.Time         `json:"updatedAt"`
+}
+
+// MarshalJSON implements custom JSON marshaling for ClientContact
+func (c *ClientContact) MarshalJSON() ([]byte, error) {
+	return json.Marshal(clientContactJSON{
+		ID:        c.id,
+		ClientID:  c.clientID,
+		Name:      c.name,
+		Role:      c.role,
+		Email:     c.email,
+		Phone:     c.phone,
+		CreatedAt: c.createdAt,
+		UpdatedAt: c.updatedAt,
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for ClientContact
+func (c *ClientContact) UnmarshalJSON(data []byte) error {
+	var jsonContact clientContactJSON
+	if err := json.Unmarshal(data, &jsonContact); err != nil {
+		return err
+	}
+
+	c.id = jsonContact.ID
+	c.clientID = jsonContact.ClientID
+	c.name = jsonContact.Name
+	c.role = jsonContact.Role
+	c.email = jsonContact.Email
+	c.phone = jsonContact.Phone
+	c.createdAt = jsonContact.CreatedAt
+	c.updatedAt = jsonContact.UpdatedAt
+	return nil
+}
@@ -0,0 +1,103 @@
+package entity
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/google/uuid"
+)
+
+// MaxClientNoteLength is the longest body a note can hold
+const MaxClientNoteLength = 5000
+
+// ClientNote records an interaction with a client (call, meeting, email) on its activity timeline. Notes belong to
+// the Client aggregate and are never edited: a correction is a new note
+type ClientNote struct {
+	id        string
+	clientID  string
+	body      string
+	author    string // Admin actor who wrote the note, empty when written without admin credentials
+	createdAt time.Time
+}
+
+// NewClientNote creates a note of a client with validation
+func NewClientNote(clientID, body, author string) (*ClientNote, error) {
+	clientID = strings.TrimSpace(clientID)
+	if clientID == "" {
+		return nil, errors.NewValidationError("client_id", clientID, errors.ValidationRequired, "client ID is required")
+	}
+
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil, errors.NewValidationError("body", body, errors.ValidationRequired, "body is required")
+	}
+	if len(body) > MaxClientNoteLength {
+		return nil, errors.NewValidationError("body", body, errors.ValidationLength, "body must be at most 5000 characters")
+	}
+
+	return &ClientNote{
+		id:        uuid.New().String(),
+		clientID:  clientID,
+		body:      body,
+		author:    strings.TrimSpace(author),
+		createdAt: time.Now().UTC(),
+	}, nil
+}
+
+// Getters
+func (n *ClientNote) ID() string {
+	return n.id
+}
+
+func (n *ClientNote) ClientID() string {
+	return n.clientID
+}
+
+func (n *ClientNote) Body() string {
+	return n.body
+}
+
+func (n *ClientNote) Author() string {
+	return n.author
+}
+
+func (n *ClientNote) CreatedAt() time.Time {
+	return n.createdAt
+}
+
+// clientNoteJSON is the persisted form of a ClientNote
+type clientNoteJSON struct {
+	ID        string    `json:"id"`
+	ClientID  string    `json:"clientId"`
+	Body      string    `json:"body"`
+	Author    string    `json:"author,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// MarshalJSON implements custom JSON marshaling for ClientNote
+func (n *ClientNote) MarshalJSON() ([]byte, error) {
+	return json.Marshal(clientNoteJSON{
+		ID:        n.id,
+		ClientID:  n.clientID,
+		Body:      n.body,
+		Author:    n.author,
+		CreatedAt: n.createdAt,
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for ClientNote
+func (n *ClientNote) UnmarshalJSON(data []byte) error {
+	var jsonNote clientNoteJSON
+	if err := json.Unmarshal(data, &jsonNote); err != nil {
+		return err
+	}
+
+	n.id = jsonNote.ID
+	n.clientID = jsonNote.ClientID
+	n.body = jsonNote.Body
+	n.author = jsonNote.Author
+	n.createdAt = jsonNote.CreatedAt
+	return nil
+}
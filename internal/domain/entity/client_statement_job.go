@@ -0,0 +1,233 @@
+package entity
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/google/uuid"
+)
+
+// StatementJobStatus is the progress of a client statement request
+type StatementJobStatus string
+
+const (
+	StatementJobPending   StatementJobStatus = "pending"
+	StatementJobCompleted StatementJobStatus = "completed"
+	StatementJobFailed    StatementJobStatus = "failed"
+)
+
+// maxStatementRange bounds the period a statement covers
+const maxStatementRange = 5 * 366 * 24 * time.Hour
+
+// ClientStatementJob is a request for the account statement of a client over a date range,
+// generated (and emailed when asked) by the statement job rather than while the client waits
+type ClientStatementJob struct {
+	id             string
+	tenantID       string
+	clientID       string
+	currency       string // Empty: the single currency the client has activity in
+	from           time.Time
+	to             time.Time
+	locale         string // Language and formats of the document
+	recipient      string // Email address the statement is sent to (empty = not emailed)
+	status         StatementJobStatus
+	failure        string
+	document       string // Print layout of the statement, once completed
+	closingBalance *valueobject.Money
+	createdAt      time.Time
+	completedAt    *time.Time
+}
+
+// NewClientStatementJob creates a pending statement request for a client from one date to another (both inclusive)
+func NewClientStatementJob(tenantID, clientID, currency string, from, to time.Time, locale, recipient string) (*ClientStatementJob, error) {
+	clientID = strings.TrimSpace(clientID)
+	if clientID == "" {
+		return nil, errors.NewValidationError("client_id", clientID, errors.ValidationRequired, "client ID is required")
+	}
+
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency != "" {
+		if _, err := valueobject.NewMoney(0, currency); err != nil {
+			return nil, err
+		}
+	}
+
+	if from.IsZero() {
+		return nil, errors.NewValidationError("from", from, errors.ValidationRequired, "statement start is required")
+	}
+	if to.IsZero() {
+		return nil, errors.NewValidationError("to", to, errors.ValidationRequired, "statement end is required")
+	}
+	if to.Before(from) {
+		return nil, errors.NewValidationError("to", to, errors.ValidationRange, "statement end must not be before its start")
+	}
+	if to.Sub(from) > maxStatementRange {
+		return nil, errors.NewValidationError("to", to, errors.ValidationRange, "statements cover at most 5 years")
+	}
+
+	recipient = strings.TrimSpace(recipient)
+	if recipient != "" {
+		if _, err := valueobject.NewEmail(recipient); err != nil {
+			return nil, err
+		}
+	}
+
+	return &ClientStatementJob{
+		id:        uuid.New().String(),
+		tenantID:  strings.TrimSpace(tenantID),
+		clientID:  clientID,
+		currency:  currency,
+		from:      from.UTC(),
+		to:        to.UTC(),
+		locale:    locale,
+		recipient: recipient,
+		status:    StatementJobPending,
+		createdAt: time.Now().UTC(),
+	}, nil
+}
+
+// Getters
+func (j *ClientStatementJob) ID() string {
+	return j.id
+}
+
+func (j *ClientStatementJob) TenantID() string {
+	return j.tenantID
+}
+
+func (j *ClientStatementJob) ClientID() string {
+	return j.clientID
+}
+
+func (j *ClientStatementJob) Currency() string {
+	return j.currency
+}
+
+func (j *ClientStatementJob) From() time.Time {
+	return j.from
+}
+
+func (j *ClientStatementJob) To() time.Time {
+	return j.to
+}
+
+func (j *ClientStatementJob) Locale() string {
+	return j.locale
+}
+
+func (j *ClientStatementJob) Recipient() string {
+	return j.recipient
+}
+
+func (j *ClientStatementJob) Status() StatementJobStatus {
+	return j.status
+}
+
+func (j *ClientStatementJob) Failure() string {
+	return j.failure
+}
+
+func (j *ClientStatementJob) Document() string {
+	return j.document
+}
+
+func (j *ClientStatementJob) ClosingBalance() *valueobject.Money {
+	return j.closingBalance
+}
+
+func (j *ClientStatementJob) CreatedAt() time.Time {
+	return j.createdAt
+}
+
+func (j *ClientStatementJob) CompletedAt() *time.Time {
+	return j.completedAt
+}
+
+// IsPending checks if the statement still has to be generated
+func (j *ClientStatementJob) IsPending() bool {
+	return j.status == StatementJobPending
+}
+
+// Complete records the generated statement document and the client balance at the end of its range
+func (j *ClientStatementJob) Complete(currency, document string, closingBalance valueobject.Money, at time.Time) {
+	completedAt := at.UTC()
+	j.currency = currency
+	j.document = document
+	j.closingBalance = &closingBalance
+	j.status = StatementJobCompleted
+	j.completedAt = &completedAt
+}
+
+// Fail records why the statement could not be generated
+func (j *ClientStatementJob) Fail(reason string, at time.Time) {
+	completedAt := at.UTC()
+	j.failure = reason
+	j.status = StatementJobFailed
+	j.completedAt = &completedAt
+}
+
+// clientStatementJobJSON is the persisted form of a ClientStatementJob
+type clientStatementJobJSON struct {
+	ID             string             `json:"id"`
+	TenantID       string             `json:"tenantId,omitempty"`
+	ClientID       string             `json:"clientId"`
+	Currency       string             `json:"currency,omitempty"`
+	From           time.Time          `json:"from"`
+	To             time.Time          `json:"to"`
+	Locale         string             `json:"locale,omitempty"`
+	Recipient      string             `json:"recipient,omitempty"`
+	Status         StatementJobStatus `json:"status"`
+	Failure        string             `json:"failure,omitempty"`
+	Document       string             `json:"document,omitempty"`
+	ClosingBalance *valueobject.Money `json:"closingBalance,omitempty"`
+	CreatedAt      time.Time          `json:"createdAt"`
+	CompletedAt    *time.Time         `json:"completedAt,omitempty"`
+}
+
+// MarshalJSON implements custom JSON marshaling for ClientStatementJob
+func (j *ClientStatementJob) MarshalJSON() ([]byte, error) {
+	return json.Marshal(clientStatementJobJSON{
+		ID:             j.id,
+		TenantID:       j.tenantID,
+		ClientID:       j.clientID,
+		Currency:       j.currency,
+		From:           j.from,
+		To:             j.to,
+		Locale:         j.locale,
+		Recipient:      j.recipient,
+		Status:         j.status,
+		Failure:        j.failure,
+		Document:       j.document,
+		ClosingBalance: j.closingBalance,
+		CreatedAt:      j.createdAt,
+		CompletedAt:    j.completedAt,
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for ClientStatementJob
+func (j *ClientStatementJob) UnmarshalJSON(data []byte) error {
+	var jsonJob clientStatementJobJSON
+	if err := json.Unmarshal(data, &jsonJob); err != nil {
+		return err
+	}
+
+	j.id = jsonJob.ID
+	j.tenantID = jsonJob.TenantID
+	j.clientID = jsonJob.ClientID
+	j.currency = jsonJob.Currency
+	j.from = jsonJob.From
+	j.to = jsonJob.To
+	j.locale = jsonJob.Locale
+	j.recipient = jsonJob.Recipient
+	j.status = jsonJob.Status
+	j.failure = jsonJob.Failure
+	j.document = jsonJob.Document
+	j.closingBalance = jsonJob.ClosingBalance
+	j.createdAt = jsonJob.CreatedAt
+	j.completedAt = jsonJob.CompletedAt
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package entity
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+)
+
+// ClientSummary is the client list read model: a client with its balances and last invoice, kept up to date
+// when the client or one of its invoices changes so a page of the list is read without aggregating invoices
+type ClientSummary struct {
+	clientID        string
+	tenantID        string
+	name            string
+	email           string
+	balances        []valueobject.Money // Outstanding amount of the issued invoices, one per currency in currency order
+	openInvoices    int                 // Issued invoices not paid in full
+	lastInvoiceID   string              // Most recently issued invoice (empty when none was issued)
+	lastInvoiceAt   *time.Time
+	lastInvoice     valueobject.Money // Total of the last invoice
+	clientCreatedAt time.Time
+	refreshedAt     time.Time
+}
+
+// NewClientSummary computes the summary of a client from its invoices
+// Drafts and voided invoices neither count toward the balance nor as the last invoice
+func NewClientSummary(client *Client, invoices []*Invoice, at time.Time) (*ClientSummary, error) {
+	summary := &ClientSummary{
+		clientID:        client.ID(),
+		tenantID:        client.TenantID(),
+		name:            client.Name(),
+		email:           client.EmailString(),
+		clientCreatedAt: client.CreatedAt(),
+		refreshedAt:     at.UTC(),
+	}
+
+	balances := make(map[string]valueobject.Money)
+	for _, invoice := range invoices {
+		if invoice.ClientID() != client.ID() || invoice.IssuedAt() == nil || invoice.Status() == InvoiceVoid {
+			continue
+		}
+
+		balance, err := invoice.Balance()
+		if err != nil {
+			return nil, err
+		}
+		if balance.IsPositive() {
+			summary.openInvoices++
+			current, ok := balances[balance.Currency()]
+			if !ok {
+				current = valueobject.ZeroMoney(balance.Currency())
+			}
+			if balances[balance.Currency()], err = current.Add(balance); err != nil {
+				return nil, err
+			}
+		}
+
+		if summary.lastInvoiceAt == nil || invoice.IssuedAt().After(*summary.lastInvoiceAt) {
+			total, err := invoice.Total()
+			if err != nil {
+				return nil, err
+			}
+			summary.lastInvoiceID = invoice.ID()
+			summary.lastInvoiceAt = invoice.IssuedAt()
+			summary.lastInvoice = total
+		}
+	}
+
+	for _, balance := range balances {
+		summary.balances = append(summary.balances, balance)
+	}
+	sort.Slice(summary.balances, func(i, j int) bool {
+		return summary.balances[i].Currency() < summary.balances[j].Currency()
+	})
+
+	return summary, nil
+}
+
+// Getters
+func (s *ClientSummary) ClientID() string {
+	return s.clientID
+}
+
+func (s *ClientSummary) TenantID() string {
+	return s.tenantID
+}
+
+func (s *ClientSummary) Name() string {
+	return s.name
+}
+
+func (s *ClientSummary) Email() string {
+	return s.email
+}
+
+// Balances returns a copy of the outstanding amounts, one per currency
+func (s *ClientSummary) Balances() []valueobject.Money {
+	return append([]valueobject.Money(nil), s.balances...)
+}
+
+func (s *ClientSummary) OpenInvoices() int {
+	return s.openInvoices
+}
+
+func (s *ClientSummary) LastInvoiceID() string {
+	return s.lastInvoiceID
+}
+
+func (s *ClientSummary) LastInvoiceAt() *time.Time {
+	return s.lastInvoiceAt
+}
+
+// LastInvoiceTotal returns the total of the last invoice (zero value when none was issued)
+func (s *ClientSummary) LastInvoiceTotal() valueobject.Money {
+	return s.lastInvoice
+}
+
+func (s *ClientSummary) ClientCreatedAt() time.Time {
+	return s.clientCreatedAt
+}
+
+func (s *ClientSummary) RefreshedAt() time.Time {
+	return s.refreshedAt
+}
+
+// clientSummaryJSON is the persisted form of a ClientSummary
+type clientSummaryJSON struct {
+	ClientID        string              `json:"clientId"`
+	TenantID        string              `json:"tenantId,omitempty"`
+	Name            string              `json:"name"`
+	Email           string              `json:"email"`
+	Balances        []valueobject.Money `json:"balances,omitempty"`
+	OpenInvoices    int                 `json:"openInvoices,omitempty"`
+	LastInvoiceID   string              `json:"lastInvoiceId,omitempty"`
+	LastInvoiceAt   *time.Time          `json:"lastInvoiceAt,omitempty"`
+	LastInvoice     *valueobject.Money  `json:"lastInvoice,omitempty"`
+	ClientCreatedAt time.Time           `json:"clientCreatedAt"`
+	RefreshedAt     time.Time           `json:"refreshedAt"`
+}
+
+// MarshalJSON implements custom JSON marshaling for ClientSummary
+func (s *ClientSummary) MarshalJSON() ([]byte, error) {
+	var lastInvoice *valueobject.Money
+	if s.lastInvoiceID != "" {
+		lastInvoice = &s.lastInvoice
+	}
+
+	return json.Marshal(clientSummaryJSON{
+		ClientID:        s.clientID,
+		TenantID:        s.tenantID,
+		Name:            s.name,
+		Email:           s.email,
+		Balances:        s.balances,
+		OpenInvoices:    s.openInvoices,
+		LastInvoiceID:   s.lastInvoiceID,
+		LastInvoiceAt:   s.lastInvoiceAt,
+		LastInvoice:     lastInvoice,
+		ClientCreatedAt: s.clientCreatedAt,
+		RefreshedAt:     s.refreshedAt,
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for ClientSummary
+func (s *ClientSummary) UnmarshalJSON(data []byte) error {
+	var jsonSummary clientSummaryJSON
+	if err := json.Unmarshal(data, &jsonSummary); err != nil {
+		return err
+	}
+
+	s.clientID = jsonSummary.ClientID
+	s.tenantID = jsonSummary.TenantID
+	s.name = jsonSummary.Name
+	s.email = jsonSummary.Email
+	s.balances = jsonSummary.Balances
+	s.openInvoices = jsonSummary.OpenInvoices
+	s.lastInvoiceID = jsonSummary.LastInvoiceID
+	s.lastInvoiceAt = jsonSummary.LastInvoiceAt
+	s.lastInvoice = valueobject.Money{}
+	if jsonSummary.LastInvoice != nil {
+		s.lastInvoice = *jsonSummary.LastInvoice
+	}
+	s.clientCreatedAt = jsonSummary.ClientCreatedAt
+	s.refreshedAt = jsonSummary.RefreshedAt
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ClientTombstone records that a client was deleted, so a repeated deletion can be told apart from the deletion of
+// a client that never existed
+type ClientTombstone struct {
+	clientID  string
+	deletedAt time.Time
+}
+
+// NewClientTombstone records the deletion of a client
+func NewClientTombstone(clientID string, deletedAt time.Time) *ClientTombstone {
+	return &ClientTombstone{
+		clientID:  clientID,
+		deletedAt: deletedAt.UTC(),
+	}
+}
+
+// Getters
+func (t *ClientTombstone) ClientID() string {
+	return t.clientID
+}
+
+func (t *ClientTombstone) DeletedAt() time.Time {
+	return t.deletedAt
+}
+
+// clientTombstoneJSON is the persisted form of a ClientTombstone
+type clientTombstoneJSON struct {
+	ClientID  string    `json:"clientId"`
+	DeletedAt time.Time `json:"deletedAt"`
+}
+
+// MarshalJSON implements custom JSON marshaling for ClientTombstone
+func (t *ClientTombstone) MarshalJSON() ([]byte, error) {
+	return json.Marshal(clientTombstoneJSON{
+		ClientID:  t.clientID,
+		DeletedAt: t.deletedAt,
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for ClientTombstone
+func (t *ClientTombstone) UnmarshalJSON(data []byte) error {
+	var jsonTombstone clientTombstoneJSON
+	if err := json.Unmarshal(data, &jsonTombstone); err != nil {
+		return err
+	}
+
+	t.clientID = jsonTombstone.ClientID
+	t.deletedAt = jsonTombstone.DeletedAt
+	return nil
+}
@@ -0,0 +1,352 @@
+package entity
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/google/uuid"
+)
+
+// ContractInvoice is an invoice counted towards a contract's committed spend
+type ContractInvoice struct {
+	InvoiceID  string
+	Amount     valueobject.Money
+	InvoicedAt time.Time
+}
+
+// ContractAttainment reports how much of a contract's commitment has been spent
+type ContractAttainment struct {
+	Committed      valueobject.Money
+	Spent          valueobject.Money
+	Remaining      valueobject.Money // Commitment not yet spent (zero once attained)
+	AttainedBps    int64             // Spent as basis points of the commitment (10000 = 100%)
+	TermElapsedBps int64             // Elapsed share of the term in basis points
+	Attained       bool
+	OnTrack        bool // Spend keeps pace with the elapsed share of the term
+}
+
+// Contract is a customer agreement committing a client to a minimum spend over a term
+// Subscriptions and invoices are linked by ID; invoices count towards the committed spend
+type Contract struct {
+	id                    string
+	clientID              string
+	name                  string
+	accountManager        string
+	startDate             time.Time
+	endDate               time.Time
+	renewalDate           time.Time
+	committedAmount       valueobject.Money
+	tenantID              string // Tenant the contract was created for (empty when created without a tenant)
+	subscriptionIDs       []string
+	invoices              []ContractInvoice
+	renewalReminderSentAt *time.Time
+	createdAt             time.Time
+	updatedAt             time.Time
+}
+
+// NewContract creates a contract with validation
+// The renewal date defaults to the end of the term
+func NewContract(clientID, name, accountManager string, startDate, endDate, renewalDate time.Time, committedAmount valueobject.Money) (*Contract, error) {
+	clientID = strings.TrimSpace(clientID)
+	name = strings.TrimSpace(name)
+	accountManager = strings.TrimSpace(accountManager)
+
+	if clientID == "" {
+		return nil, errors.NewValidationError("client_id", clientID, errors.ValidationRequired, "client ID is required")
+	}
+	if name == "" {
+		return nil, errors.NewValidationError("name", name, errors.ValidationRequired, "contract name is required")
+	}
+	if len(name) > 200 {
+		return nil, errors.NewValidationError("name", name, errors.ValidationLength, "contract name must be at most 200 characters")
+	}
+	if startDate.IsZero() {
+		return nil, errors.NewValidationError("start_date", startDate, errors.ValidationRequired, "start date is required")
+	}
+	if !endDate.After(startDate) {
+		return nil, errors.NewValidationError("end_date", endDate, errors.ValidationRange, "contract term must end after it starts")
+	}
+	if renewalDate.IsZero() {
+		renewalDate = endDate
+	}
+	if renewalDate.Before(startDate) {
+		return nil, errors.NewValidationError("renewal_date", renewalDate, errors.ValidationRange, "renewal date must not be before the start of the term")
+	}
+	if !committedAmount.IsPositive() {
+		return nil, errors.NewValidationError("committed_amount", committedAmount.Amount(), errors.ValidationRange, "committed amount must be positive")
+	}
+
+	now := time.Now().UTC()
+	return &Contract{
+		id:              uuid.New().String(),
+		clientID:        clientID,
+		name:            name,
+		accountManager:  accountManager,
+		startDate:       startDate.UTC(),
+		endDate:         endDate.UTC(),
+		renewalDate:     renewalDate.UTC(),
+		committedAmount: committedAmount,
+		subscriptionIDs: make([]string, 0),
+		invoices:        make([]ContractInvoice, 0),
+		createdAt:       now,
+		updatedAt:       now,
+	}, nil
+}
+
+// Getters
+func (c *Contract) ID() string {
+	return c.id
+}
+
+func (c *Contract) ClientID() string {
+	return c.clientID
+}
+
+func (c *Contract) Name() string {
+	return c.name
+}
+
+func (c *Contract) AccountManager() string {
+	return c.accountManager
+}
+
+func (c *Contract) StartDate() time.Time {
+	return c.startDate
+}
+
+func (c *Contract) EndDate() time.Time {
+	return c.endDate
+}
+
+func (c *Contract) RenewalDate() time.Time {
+	return c.renewalDate
+}
+
+func (c *Contract) CommittedAmount() valueobject.Money {
+	return c.committedAmount
+}
+
+func (c *Contract) TenantID() string {
+	return c.tenantID
+}
+
+// SubscriptionIDs returns a copy of the linked subscription IDs
+func (c *Contract) SubscriptionIDs() []string {
+	return append([]string(nil), c.subscriptionIDs...)
+}
+
+// Invoices returns a copy of the linked invoices
+func (c *Contract) Invoices() []ContractInvoice {
+	return append([]ContractInvoice(nil), c.invoices...)
+}
+
+func (c *Contract) RenewalReminderSentAt() *time.Time {
+	return c.renewalReminderSentAt
+}
+
+func (c *Contract) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+func (c *Contract) UpdatedAt() time.Time {
+	return c.updatedAt
+}
+
+// IsActive checks if the contract term covers the given time
+func (c *Contract) IsActive(now time.Time) bool {
+	return !now.Before(c.startDate) && now.Before(c.endDate)
+}
+
+// AssignTenant sets the tenant the contract belongs to, whose callers alone may see and change it
+func (c *Contract) AssignTenant(tenantID string) {
+	c.tenantID = strings.TrimSpace(tenantID)
+}
+
+// LinkSubscription attaches a subscription to the contract (linking twice is a no-op)
+func (c *Contract) LinkSubscription(subscriptionID string) error {
+	subscriptionID = strings.TrimSpace(subscriptionID)
+	if subscriptionID == "" {
+		return errors.NewValidationError("subscription_id", subscriptionID, errors.ValidationRequired, "subscription ID is required")
+	}
+
+	for _, linked := range c.subscriptionIDs {
+		if linked == subscriptionID {
+			return nil
+		}
+	}
+
+	c.subscriptionIDs = append(c.subscriptionIDs, subscriptionID)
+	c.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// LinkInvoice counts an invoice towards the committed spend
+// Each invoice is counted once and must be in the contract currency
+func (c *Contract) LinkInvoice(invoiceID string, amount valueobject.Money, invoicedAt time.Time) error {
+	invoiceID = strings.TrimSpace(invoiceID)
+	if invoiceID == "" {
+		return errors.NewValidationError("invoice_id", invoiceID, errors.ValidationRequired, "invoice ID is required")
+	}
+	if amount.IsNegative() {
+		return errors.NewValidationError("amount", amount.Amount(), errors.ValidationRange, "invoice amount must not be negative")
+	}
+	if amount.Currency() != c.committedAmount.Currency() {
+		return errors.NewBusinessRuleError("currency_mismatch", errors.BusinessRuleViolation, "invoice currency must match the contract currency")
+	}
+
+	for _, linked := range c.invoices {
+		if linked.InvoiceID == invoiceID {
+			return errors.NewBusinessRuleError("contract_invoice_uniqueness", errors.BusinessRuleConflict, "invoice is already linked to the contract")
+		}
+	}
+
+	if invoicedAt.IsZero() {
+		invoicedAt = time.Now()
+	}
+	c.invoices = append(c.invoices, ContractInvoice{
+		InvoiceID:  invoiceID,
+		Amount:     amount,
+		InvoicedAt: invoicedAt.UTC(),
+	})
+	c.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// Spent returns the total of the invoices counted towards the commitment
+func (c *Contract) Spent() valueobject.Money {
+	spent := valueobject.ZeroMoney(c.committedAmount.Currency())
+	for _, invoice := range c.invoices {
+		spent, _ = spent.Add(invoice.Amount)
+	}
+	return spent
+}
+
+// Attainment reports commitment attainment at the given time
+func (c *Contract) Attainment(now time.Time) ContractAttainment {
+	spent := c.Spent()
+	remaining, _ := c.committedAmount.Subtract(spent)
+	if remaining.IsNegative() {
+		remaining = valueobject.ZeroMoney(c.committedAmount.Currency())
+	}
+
+	// Rounded down so a contract never reports 100% before the commitment is actually met
+	attainedBps := spent.Amount() * 10000 / c.committedAmount.Amount()
+
+	var elapsedBps int64
+	switch {
+	case !now.After(c.startDate):
+		elapsedBps = 0
+	case !now.Before(c.endDate):
+		elapsedBps = 10000
+	default:
+		// Whole seconds keep the product within int64 for multi-year terms
+		elapsedBps = int64(now.Sub(c.startDate)/time.Second) * 10000 / int64(c.endDate.Sub(c.startDate)/time.Second)
+	}
+
+	return ContractAttainment{
+		Committed:      c.committedAmount,
+		Spent:          spent,
+		Remaining:      remaining,
+		AttainedBps:    attainedBps,
+		TermElapsedBps: elapsedBps,
+		Attained:       !remaining.IsPositive(),
+		OnTrack:        attainedBps >= elapsedBps,
+	}
+}
+
+// NeedsRenewalReminder checks if the account manager should be reminded of the upcoming renewal
+// A reminder is due once, from leadTime before the renewal date until the renewal date
+func (c *Contract) NeedsRenewalReminder(now time.Time, leadTime time.Duration) bool {
+	if c.renewalReminderSentAt != nil {
+		return false
+	}
+	return !now.Before(c.renewalDate.Add(-leadTime)) && now.Before(c.renewalDate)
+}
+
+// MarkRenewalReminderSent records that the renewal reminder went out
+func (c *Contract) MarkRenewalReminderSent(at time.Time) {
+	sentAt := at.UTC()
+	c.renewalReminderSentAt = &sentAt
+	c.updatedAt = time.Now().UTC()
+}
+
+// contractInvoiceJSON is the persisted form of a ContractInvoice
+type contractInvoiceJSON struct {
+	InvoiceID  string            `json:"invoiceId"`
+	Amount     valueobject.Money `json:"amount"`
+	InvoicedAt time.Time         `json:"invoicedAt"`
+}
+
+// contractJSON is the persisted form of a Contract
+type contractJSON struct {
+	ID                    string                `json:"id"`
+	ClientID              string                `json:"clientId"`
+	Name                  string                `json:"name"`
+	AccountManager        string                `json:"accountManager,omitempty"`
+	StartDate             time.Time             `json:"startDate"`
+	EndDate               time.Time             `json:"endDate"`
+	RenewalDate           time.Time             `json:"renewalDate"`
+	CommittedAmount       valueobject.Money     `json:"committedAmount"`
+	TenantID              string                `json:"tenantId,omitempty"`
+	SubscriptionIDs       []string              `json:"subscriptionIds"`
+	Invoices              []contractInvoiceJSON `json:"invoices"`
+	RenewalReminderSentAt *time.Time            `json:"renewalReminderSentAt,omitempty"`
+	CreatedAt             time.Time             `json:"createdAt"`
+	UpdatedAt             time.Time             `json:"updatedAt"`
+}
+
+// MarshalJSON implements custom JSON marshaling for Contract
+func (c *Contract) MarshalJSON() ([]byte, error) {
+	invoices := make([]contractInvoiceJSON, len(c.invoices))
+	for index, invoice := range c.invoices {
+		invoices[index] = contractInvoiceJSON(invoice)
+	}
+
+	return json.Marshal(contractJSON{
+		ID:                    c.id,
+		ClientID:              c.clientID,
+		Name:                  c.name,
+		AccountManager:        c.accountManager,
+		StartDate:             c.startDate,
+		EndDate:               c.endDate,
+		RenewalDate:           c.renewalDate,
+		CommittedAmount:       c.committedAmount,
+		TenantID:              c.tenantID,
+		SubscriptionIDs:       c.subscriptionIDs,
+		Invoices:              invoices,
+		RenewalReminderSentAt: c.renewalReminderSentAt,
+		CreatedAt:             c.createdAt,
+		UpdatedAt:             c.updatedAt,
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for Contract
+func (c *Contract) UnmarshalJSON(data []byte) error {
+	var jsonContract contractJSON
+	if err := json.Unmarshal(data, &jsonContract); err != nil {
+		return err
+	}
+
+	c.id = jsonContract.ID
+	c.clientID = jsonContract.ClientID
+	c.name = jsonContract.Name
+	c.accountManager = jsonContract.AccountManager
+	c.startDate = jsonContract.StartDate
+	c.endDate = jsonContract.EndDate
+	c.renewalDate = jsonContract.RenewalDate
+	c.committedAmount = jsonContract.CommittedAmount
+	c.tenantID = jsonContract.TenantID
+	c.subscriptionIDs = append(make([]string, 0, len(jsonContract.SubscriptionIDs)), jsonContract.SubscriptionIDs...)
+	c.invoices = make([]ContractInvoice, len(jsonContract.Invoices))
+	for index, invoice := range jsonContract.Invoices {
+		c.invoices[index] = ContractInvoice(invoice)
+	}
+	c.renewalReminderSentAt = jsonContract.RenewalReminderSentAt
+	c.createdAt = jsonContract.CreatedAt
+	c.updatedAt = jsonContract.UpdatedAt
+
+	return nil
+}
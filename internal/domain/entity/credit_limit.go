@@ -0,0 +1,135 @@
+package entity
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+)
+
+// CreditPolicy is what happens when issuing an invoice would take a client over its credit limit
+type CreditPolicy string
+
+const (
+	// CreditPolicyBlock holds the invoice until the client's exposure leaves room for it
+	CreditPolicyBlock CreditPolicy = "block"
+
+	// CreditPolicyWarn issues the invoice and flags it as over the limit
+	CreditPolicyWarn CreditPolicy = "warn"
+
+	// CreditPolicyRequireApproval holds the invoice until an approver confirms the override
+	CreditPolicyRequireApproval CreditPolicy = "require_approval"
+)
+
+// ClientCreditLimit is the maximum exposure allowed on a client before invoice issuance is checked against its policy
+// Exposure is counted in the currency of the limit; invoices in other currencies are not covered
+type ClientCreditLimit struct {
+	clientID  string
+	limit     valueobject.Money
+	policy    CreditPolicy
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+// NewClientCreditLimit creates the credit limit of a client with validation
+func NewClientCreditLimit(clientID string, limit valueobject.Money, policy CreditPolicy) (*ClientCreditLimit, error) {
+	clientID = strings.TrimSpace(clientID)
+	if clientID == "" {
+		return nil, errors.NewValidationError("client_id", clientID, errors.ValidationRequired, "client ID is required")
+	}
+
+	now := time.Now().UTC()
+	creditLimit := &ClientCreditLimit{
+		clientID:  clientID,
+		createdAt: now,
+		updatedAt: now,
+	}
+	if err := creditLimit.apply(limit, policy); err != nil {
+		return nil, err
+	}
+	return creditLimit, nil
+}
+
+// Update replaces the limit and the policy of the client
+func (c *ClientCreditLimit) Update(limit valueobject.Money, policy CreditPolicy) error {
+	if err := c.apply(limit, policy); err != nil {
+		return err
+	}
+	c.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// apply validates and sets the limit and the policy
+func (c *ClientCreditLimit) apply(limit valueobject.Money, policy CreditPolicy) error {
+	if limit.IsNegative() {
+		return errors.NewValidationError("amount", limit.Amount(), errors.ValidationRange, "credit limit must not be negative")
+	}
+	switch policy {
+	case CreditPolicyBlock, CreditPolicyWarn, CreditPolicyRequireApproval:
+	default:
+		return errors.NewValidationError("policy", policy, errors.ValidationFormat, "policy must be one of: block, warn, require_approval")
+	}
+
+	c.limit = limit
+	c.policy = policy
+	return nil
+}
+
+// Getters
+func (c *ClientCreditLimit) ClientID() string {
+	return c.clientID
+}
+
+func (c *ClientCreditLimit) Limit() valueobject.Money {
+	return c.limit
+}
+
+func (c *ClientCreditLimit) Policy() CreditPolicy {
+	return c.policy
+}
+
+func (c *ClientCreditLimit) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+func (c *ClientCreditLimit) UpdatedAt() time.Time {
+	return c.updatedAt
+}
+
+// clientCreditLimitJSON is the persisted form of a ClientCreditLimit
+type clientCreditLimitJSON struct {
+	ClientID  string            `json:"clientId"`
+	Limit     valueobject.Money `json:"limit"`
+	Policy    CreditPolicy      `json:"policy"`
+	CreatedAt time.Time         `json:"createdAt"`
+	UpdatedAt time.Time         `json:"updatedAt"`
+}
+
+// MarshalJSON implements custom JSON marshaling for ClientCreditLimit
+func (c *ClientCreditLimit) MarshalJSON() ([]byte, error) {
+	return json.Marshal(clientCreditLimitJSON{
+		ClientID:  c.clientID,
+		Limit:     c.limit,
+		Policy:    c.policy,
+		CreatedAt: c.createdAt,
+		UpdatedAt: c.updatedAt,
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for ClientCreditLimit
+func (c *ClientCreditLimit) UnmarshalJSON(data []byte) error {
+	var jsonLimit clientCreditLimitJSON
+	if err := json.Unmarshal(data, &jsonLimit); err != nil {
+		return err
+	}
+
+	c.clientID = jsonLimit.ClientID
+	c.limit = jsonLimit.Limit
+	c.policy = jsonLimit.Policy
+	c.createdAt = jsonLimit.CreatedAt
+	c.updatedAt = jsonLimit.UpdatedAt
+
+	return nil
+}
@@ -0,0 +1,231 @@
+package entity
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+)
+
+// InvoiceColumn is a column of the invoice line item table
+type InvoiceColumn string
+
+const (
+	InvoiceColumnDescription InvoiceColumn = "description"
+	InvoiceColumnQuantity    InvoiceColumn = "quantity"
+	InvoiceColumnUnitPrice   InvoiceColumn = "unit_price"
+	InvoiceColumnTaxRate     InvoiceColumn = "tax_rate"
+	InvoiceColumnTaxAmount   InvoiceColumn = "tax_amount"
+	InvoiceColumnAmount      InvoiceColumn = "amount"
+)
+
+// Document template defaults, used for tenants without a template and for unset fields
+const (
+	DefaultPrimaryColor = "#1F2937"
+	DefaultAccentColor  = "#2563EB"
+)
+
+// maxFooterTextLength bounds the footer printed on every invoice page
+const maxFooterTextLength = 1000
+
+// DefaultInvoiceColumns returns the line item columns shown by the default template, in order
+func DefaultInvoiceColumns() []InvoiceColumn {
+	return []InvoiceColumn{
+		InvoiceColumnDescription,
+		InvoiceColumnQuantity,
+		InvoiceColumnUnitPrice,
+		InvoiceColumnTaxRate,
+		InvoiceColumnAmount,
+	}
+}
+
+// DocumentTemplate is the invoice layout configured for a tenant, consumed by the document renderers
+type DocumentTemplate struct {
+	tenantID     string
+	logoURL      string // HTTPS URL of the logo printed in the header (empty = no logo)
+	primaryColor string // #RRGGBB, headings and table header
+	accentColor  string // #RRGGBB, totals and highlights
+	columns      []InvoiceColumn
+	footerText   string
+	updatedAt    time.Time
+}
+
+// NewDocumentTemplate creates a tenant document template with validation
+// Empty colors and columns fall back to the defaults; columns are shown in the given order and
+// must include the description and amount
+func NewDocumentTemplate(tenantID, logoURL, primaryColor, accentColor string, columns []InvoiceColumn, footerText string) (*DocumentTemplate, error) {
+	tenantID = strings.TrimSpace(tenantID)
+	if tenantID == "" {
+		return nil, errors.NewValidationError("tenant_id", tenantID, errors.ValidationRequired, "tenant ID is required")
+	}
+
+	logoURL = strings.TrimSpace(logoURL)
+	if logoURL != "" {
+		parsed, err := url.Parse(logoURL)
+		if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+			return nil, errors.NewValidationError("logo_url", logoURL, errors.ValidationFormat, "logo URL must be an absolute https URL")
+		}
+		if len(logoURL) > 2048 {
+			return nil, errors.NewValidationError("logo_url", logoURL, errors.ValidationLength, "logo URL must be at most 2048 characters")
+		}
+	}
+
+	primaryColor, err := normalizeColor("primary_color", primaryColor, DefaultPrimaryColor)
+	if err != nil {
+		return nil, err
+	}
+	accentColor, err = normalizeColor("accent_color", accentColor, DefaultAccentColor)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(columns) == 0 {
+		columns = DefaultInvoiceColumns()
+	}
+	seen := make(map[InvoiceColumn]bool, len(columns))
+	for i, column := range columns {
+		field := "columns[" + strconv.Itoa(i) + "]"
+		switch column {
+		case InvoiceColumnDescription, InvoiceColumnQuantity, InvoiceColumnUnitPrice,
+			InvoiceColumnTaxRate, InvoiceColumnTaxAmount, InvoiceColumnAmount:
+		default:
+			return nil, errors.NewValidationError(field, column, errors.ValidationFormat, "column must be one of: description, quantity, unit_price, tax_rate, tax_amount, amount")
+		}
+		if seen[column] {
+			return nil, errors.NewValidationError(field, column, errors.ValidationFormat, "column is listed more than once")
+		}
+		seen[column] = true
+	}
+	if !seen[InvoiceColumnDescription] || !seen[InvoiceColumnAmount] {
+		return nil, errors.NewValidationError("columns", columns, errors.ValidationRequired, "columns must include description and amount")
+	}
+
+	footerText = strings.TrimSpace(footerText)
+	if len(footerText) > maxFooterTextLength {
+		return nil, errors.NewValidationError("footer_text", footerText, errors.ValidationLength, "footer text must be at most 1000 characters")
+	}
+
+	return &DocumentTemplate{
+		tenantID:     tenantID,
+		logoURL:      logoURL,
+		primaryColor: primaryColor,
+		accentColor:  accentColor,
+		columns:      append([]InvoiceColumn(nil), columns...),
+		footerText:   footerText,
+		updatedAt:    time.Now().UTC(),
+	}, nil
+}
+
+// DefaultDocumentTemplateFor returns the layout rendered for a tenant without a document template
+func DefaultDocumentTemplateFor(tenantID string) *DocumentTemplate {
+	return &DocumentTemplate{
+		tenantID:     tenantID,
+		primaryColor: DefaultPrimaryColor,
+		accentColor:  DefaultAccentColor,
+		columns:      DefaultInvoiceColumns(),
+	}
+}
+
+// Getters
+func (t *DocumentTemplate) TenantID() string {
+	return t.tenantID
+}
+
+func (t *DocumentTemplate) LogoURL() string {
+	return t.logoURL
+}
+
+func (t *DocumentTemplate) PrimaryColor() string {
+	return t.primaryColor
+}
+
+func (t *DocumentTemplate) AccentColor() string {
+	return t.accentColor
+}
+
+// Columns returns the visible line item columns, in display order
+func (t *DocumentTemplate) Columns() []InvoiceColumn {
+	return append([]InvoiceColumn(nil), t.columns...)
+}
+
+func (t *DocumentTemplate) FooterText() string {
+	return t.footerText
+}
+
+func (t *DocumentTemplate) UpdatedAt() time.Time {
+	return t.updatedAt
+}
+
+// ShowsColumn checks if a line item column is visible
+func (t *DocumentTemplate) ShowsColumn(column InvoiceColumn) bool {
+	for _, visible := range t.columns {
+		if visible == column {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeColor validates a #RRGGBB color, returning fallback when empty
+func normalizeColor(field, color, fallback string) (string, error) {
+	color = strings.ToUpper(strings.TrimSpace(color))
+	if color == "" {
+		return fallback, nil
+	}
+
+	valid := len(color) == 7 && color[0] == '#'
+	for _, r := range color[1:] {
+		if !(r >= '0' && r <= '9' || r >= 'A' && r <= 'F') {
+			valid = false
+		}
+	}
+	if !valid {
+		return "", errors.NewValidationError(field, color, errors.ValidationFormat, "color must be a hex code like #1F2937")
+	}
+	return color, nil
+}
+
+// documentTemplateJSON is the persisted form of a DocumentTemplate
+type documentTemplateJSON struct {
+	TenantID     string          `json:"tenantId"`
+	LogoURL      string          `json:"logoUrl,omitempty"`
+	PrimaryColor string          `json:"primaryColor"`
+	AccentColor  string          `json:"accentColor"`
+	Columns      []InvoiceColumn `json:"columns"`
+	FooterText   string          `json:"footerText,omitempty"`
+	UpdatedAt    time.Time       `json:"updatedAt"`
+}
+
+// MarshalJSON implements custom JSON marshaling for DocumentTemplate
+func (t *DocumentTemplate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(documentTemplateJSON{
+		TenantID:     t.tenantID,
+		LogoURL:      t.logoURL,
+		PrimaryColor: t.primaryColor,
+		AccentColor:  t.accentColor,
+		Columns:      t.columns,
+		FooterText:   t.footerText,
+		UpdatedAt:    t.updatedAt,
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for DocumentTemplate
+func (t *DocumentTemplate) UnmarshalJSON(data []byte) error {
+	var jsonTemplate documentTemplateJSON
+	if err := json.Unmarshal(data, &jsonTemplate); err != nil {
+		return err
+	}
+
+	t.tenantID = jsonTemplate.TenantID
+	t.logoURL = jsonTemplate.LogoURL
+	t.primaryColor = jsonTemplate.PrimaryColor
+	t.accentColor = jsonTemplate.AccentColor
+	t.columns = jsonTemplate.Columns
+	t.footerText = jsonTemplate.FooterText
+	t.updatedAt = jsonTemplate.UpdatedAt
+
+	return nil
+}
@@ -0,0 +1,194 @@
+package entity
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+)
+
+// ReminderChannel is how a payment reminder reaches the client
+type ReminderChannel string
+
+const (
+	ReminderChannelEmail  ReminderChannel = "email"
+	ReminderChannelSMS    ReminderChannel = "sms"
+	ReminderChannelLetter ReminderChannel = "letter"
+)
+
+// maxReminderStages bounds the length of a reminder cadence
+const maxReminderStages = 10
+
+// ReminderStage is one escalation step of a payment reminder cadence
+type ReminderStage struct {
+	dayOffset int
+	channel   ReminderChannel
+	template  string
+}
+
+// NewReminderStage creates a stage sent dayOffset days after the invoice due date (negative before it)
+func NewReminderStage(dayOffset int, channel ReminderChannel, template string) (ReminderStage, error) {
+	template = strings.TrimSpace(template)
+
+	if dayOffset < -30 || dayOffset > 365 {
+		return ReminderStage{}, errors.NewValidationError("day_offset", dayOffset, errors.ValidationRange, "day offset must be between -30 and 365")
+	}
+	if channel != ReminderChannelEmail && channel != ReminderChannelSMS && channel != ReminderChannelLetter {
+		return ReminderStage{}, errors.NewValidationError("channel", channel, errors.ValidationFormat, "channel must be one of: email, sms, letter")
+	}
+	if template == "" {
+		return ReminderStage{}, errors.NewValidationError("template", template, errors.ValidationRequired, "template is required")
+	}
+	if len(template) > 100 {
+		return ReminderStage{}, errors.NewValidationError("template", template, errors.ValidationLength, "template must be at most 100 characters")
+	}
+
+	return ReminderStage{
+		dayOffset: dayOffset,
+		channel:   channel,
+		template:  template,
+	}, nil
+}
+
+// DayOffset returns the number of days after the due date the stage is sent
+func (s ReminderStage) DayOffset() int {
+	return s.dayOffset
+}
+
+// Channel returns how the stage reminder is sent
+func (s ReminderStage) Channel() ReminderChannel {
+	return s.channel
+}
+
+// Template returns the message template rendered for the stage
+func (s ReminderStage) Template() string {
+	return s.template
+}
+
+// DefaultReminderStages is the cadence used for tenants without a dunning policy
+func DefaultReminderStages() []ReminderStage {
+	return []ReminderStage{
+		{dayOffset: 1, channel: ReminderChannelEmail, template: "payment_reminder_friendly"},
+		{dayOffset: 7, channel: ReminderChannelEmail, template: "payment_reminder_firm"},
+		{dayOffset: 14, channel: ReminderChannelEmail, template: "payment_reminder_final"},
+		{dayOffset: 30, channel: ReminderChannelLetter, template: "payment_reminder_formal_notice"},
+	}
+}
+
+// CurrentReminderStage returns the latest stage of a cadence reached daysOverdue days after the due date
+// and its index; ok is false before the first stage
+func CurrentReminderStage(stages []ReminderStage, daysOverdue int) (stage ReminderStage, index int, ok bool) {
+	index = -1
+	for i, candidate := range stages {
+		if candidate.dayOffset > daysOverdue {
+			break
+		}
+		stage, index, ok = candidate, i, true
+	}
+	return stage, index, ok
+}
+
+// DunningPolicy is the payment reminder cadence configured for a tenant
+type DunningPolicy struct {
+	tenantID  string
+	stages    []ReminderStage
+	updatedAt time.Time
+}
+
+// NewDunningPolicy creates a tenant dunning policy
+// Stages must have strictly increasing day offsets
+func NewDunningPolicy(tenantID string, stages []ReminderStage) (*DunningPolicy, error) {
+	tenantID = strings.TrimSpace(tenantID)
+	if tenantID == "" {
+		return nil, errors.NewValidationError("tenant_id", tenantID, errors.ValidationRequired, "tenant ID is required")
+	}
+
+	if len(stages) == 0 {
+		return nil, errors.NewValidationError("stages", "", errors.ValidationRequired, "at least one stage is required")
+	}
+	if len(stages) > maxReminderStages {
+		return nil, errors.NewValidationError("stages", len(stages), errors.ValidationRange, "at most 10 stages are allowed")
+	}
+	for i := 1; i < len(stages); i++ {
+		if stages[i].dayOffset <= stages[i-1].dayOffset {
+			field := "stages[" + strconv.Itoa(i) + "].day_offset"
+			return nil, errors.NewValidationError(field, stages[i].dayOffset, errors.ValidationRange, "day offsets must be strictly increasing")
+		}
+	}
+
+	return &DunningPolicy{
+		tenantID:  tenantID,
+		stages:    stages,
+		updatedAt: time.Now().UTC(),
+	}, nil
+}
+
+// Getters
+func (p *DunningPolicy) TenantID() string {
+	return p.tenantID
+}
+
+func (p *DunningPolicy) Stages() []ReminderStage {
+	return p.stages
+}
+
+func (p *DunningPolicy) UpdatedAt() time.Time {
+	return p.updatedAt
+}
+
+// reminderStageJSON is the persisted form of a ReminderStage
+type reminderStageJSON struct {
+	DayOffset int             `json:"dayOffset"`
+	Channel   ReminderChannel `json:"channel"`
+	Template  string          `json:"template"`
+}
+
+// dunningPolicyJSON is the persisted form of a DunningPolicy
+type dunningPolicyJSON struct {
+	TenantID  string              `json:"tenantId"`
+	Stages    []reminderStageJSON `json:"stages"`
+	UpdatedAt time.Time           `json:"updatedAt"`
+}
+
+// MarshalJSON implements custom JSON marshaling for DunningPolicy
+func (p *DunningPolicy) MarshalJSON() ([]byte, error) {
+	stages := make([]reminderStageJSON, len(p.stages))
+	for i, stage := range p.stages {
+		stages[i] = reminderStageJSON{
+			DayOffset: stage.dayOffset,
+			Channel:   stage.channel,
+			Template:  stage.template,
+		}
+	}
+
+	return json.Marshal(dunningPolicyJSON{
+		TenantID:  p.tenantID,
+		Stages:    stages,
+		UpdatedAt: p.updatedAt,
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for DunningPolicy
+func (p *DunningPolicy) UnmarshalJSON(data []byte) error {
+	var jsonPolicy dunningPolicyJSON
+	if err := json.Unmarshal(data, &jsonPolicy); err != nil {
+		return err
+	}
+
+	stages := make([]ReminderStage, len(jsonPolicy.Stages))
+	for i, stored := range jsonPolicy.Stages {
+		stages[i] = ReminderStage{
+			dayOffset: stored.DayOffset,
+			channel:   stored.Channel,
+			template:  stored.Template,
+		}
+	}
+
+	p.tenantID = jsonPolicy.TenantID
+	p.stages = stages
+	p.updatedAt = jsonPolicy.UpdatedAt
+
+	return nil
+}
@@ -0,0 +1,166 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+)
+
+// SuppressionReason is why emails to an address are no longer sent
+type SuppressionReason string
+
+const (
+	SuppressionHardBounce  SuppressionReason = "hard_bounce"  // The mailbox does not exist or refuses mail
+	SuppressionSoftBounces SuppressionReason = "soft_bounces" // Temporary failures kept recurring
+	SuppressionManual      SuppressionReason = "manual"       // Added by an admin (complaint, unsubscribe request)
+)
+
+// EmailSuppression tracks the bounces of an email address and whether emails to it are suppressed
+// A hard bounce suppresses the address at once; soft bounces suppress it once they reach a limit
+// without a delivery in between
+type EmailSuppression struct {
+	address      string
+	softBounces  int // Consecutive soft bounces since the last delivery
+	reason       SuppressionReason
+	detail       string     // Mailer diagnostic of the bounce, or admin note
+	suppressedAt *time.Time // Nil while the address is only tracked
+	createdAt    time.Time
+	updatedAt    time.Time
+}
+
+// NewEmailSuppression starts tracking an address, not suppressed yet
+func NewEmailSuppression(address string, now time.Time) (*EmailSuppression, error) {
+	email, err := valueobject.NewEmail(address)
+	if err != nil {
+		return nil, err
+	}
+	return &EmailSuppression{
+		address:   email.String(),
+		createdAt: now.UTC(),
+		updatedAt: now.UTC(),
+	}, nil
+}
+
+// Getters
+func (s *EmailSuppression) Address() string {
+	return s.address
+}
+
+func (s *EmailSuppression) SoftBounces() int {
+	return s.softBounces
+}
+
+func (s *EmailSuppression) Reason() SuppressionReason {
+	return s.reason
+}
+
+func (s *EmailSuppression) Detail() string {
+	return s.detail
+}
+
+func (s *EmailSuppression) SuppressedAt() *time.Time {
+	return s.suppressedAt
+}
+
+func (s *EmailSuppression) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+func (s *EmailSuppression) UpdatedAt() time.Time {
+	return s.updatedAt
+}
+
+// IsSuppressed checks if emails to the address must not be sent
+func (s *EmailSuppression) IsSuppressed() bool {
+	return s.suppressedAt != nil
+}
+
+// RecordBounce counts a bounce of the address, suppressing it on a hard bounce or at softBounceLimit soft bounces
+// It returns whether the bounce suppressed the address
+func (s *EmailSuppression) RecordBounce(bounceType BounceType, detail string, softBounceLimit int, at time.Time) bool {
+	if s.IsSuppressed() {
+		return false
+	}
+	s.updatedAt = at.UTC()
+
+	switch bounceType {
+	case BounceHard:
+		s.suppress(SuppressionHardBounce, detail, at)
+	case BounceSoft:
+		s.softBounces++
+		if softBounceLimit <= 0 || s.softBounces < softBounceLimit {
+			return false
+		}
+		s.suppress(SuppressionSoftBounces, detail, at)
+	default:
+		return false
+	}
+	return true
+}
+
+// RecordDelivery resets the soft bounces of an address that accepted an email again
+func (s *EmailSuppression) RecordDelivery(at time.Time) {
+	s.softBounces = 0
+	s.updatedAt = at.UTC()
+}
+
+// Suppress adds the address to the suppression list by hand
+func (s *EmailSuppression) Suppress(note string, at time.Time) error {
+	if s.IsSuppressed() {
+		return errors.ErrEmailAlreadySuppressed
+	}
+	s.suppress(SuppressionManual, note, at)
+	s.updatedAt = at.UTC()
+	return nil
+}
+
+// suppress records why and when the address was suppressed
+func (s *EmailSuppression) suppress(reason SuppressionReason, detail string, at time.Time) {
+	suppressedAt := at.UTC()
+	s.reason = reason
+	s.detail = detail
+	s.suppressedAt = &suppressedAt
+}
+
+// emailSuppressionJSON is the persisted form of an EmailSuppression
+type emailSuppressionJSON struct {
+	Address      string            `json:"address"`
+	SoftBounces  int               `json:"softBounces,omitempty"`
+	Reason       SuppressionReason `json:"reason,omitempty"`
+	Detail       string            `json:"detail,omitempty"`
+	SuppressedAt *time.Time        `json:"suppressedAt,omitempty"`
+	CreatedAt    time.Time         `json:"createdAt"`
+	UpdatedAt    time.Time         `json:"updatedAt"`
+}
+
+// MarshalJSON implements custom JSON marshaling for EmailSuppression
+func (s *EmailSuppression) MarshalJSON() ([]byte, error) {
+	return json.Marshal(emailSuppressionJSON{
+		Address:      s.address,
+		SoftBounces:  s.softBounces,
+		Reason:       s.reason,
+		Detail:       s.detail,
+		SuppressedAt: s.suppressedAt,
+		CreatedAt:    s.createdAt,
+		UpdatedAt:    s.updatedAt,
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for EmailSuppression
+func (s *EmailSuppression) UnmarshalJSON(data []byte) error {
+	var jsonSuppression emailSuppressionJSON
+	if err := json.Unmarshal(data, &jsonSuppression); err != nil {
+		return err
+	}
+
+	s.address = jsonSuppression.Address
+	s.softBounces = jsonSuppression.SoftBounces
+	s.reason = jsonSuppression.Reason
+	s.detail = jsonSuppression.Detail
+	s.suppressedAt = jsonSuppression.SuppressedAt
+	s.createdAt = jsonSuppression.CreatedAt
+	s.updatedAt = jsonSuppression.UpdatedAt
+	return nil
+}
@@ -0,0 +1,137 @@
+package entity
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+)
+
+// ExternalReferenceKind is the kind of resource an external reference identifies
+type ExternalReferenceKind string
+
+const (
+	ExternalReferenceClient           ExternalReferenceKind = "client"
+	ExternalReferenceInvoice          ExternalReferenceKind = "invoice"
+	ExternalReferenceRecurringInvoice ExternalReferenceKind = "recurring_invoice"
+)
+
+// maxExternalReferenceLength bounds the IDs integrators store on clients and invoices
+const maxExternalReferenceLength = 100
+
+// ExternalReference is the entry of the per-tenant unique index of the references integrators store on
+// clients and invoices (their order or contract IDs): at most one resource of a kind has a reference in a tenant
+type ExternalReference struct {
+	kind       ExternalReferenceKind
+	tenantID   string // Empty for resources created without a tenant
+	reference  string
+	resourceID string
+	createdAt  time.Time
+}
+
+// NewExternalReference creates the index entry of a reference of a client or invoice of a tenant
+func NewExternalReference(kind ExternalReferenceKind, tenantID, reference, resourceID string) (*ExternalReference, error) {
+	switch kind {
+	case ExternalReferenceClient, ExternalReferenceInvoice, ExternalReferenceRecurringInvoice:
+	default:
+		return nil, errors.NewValidationError("kind", kind, errors.ValidationFormat, "external reference kind must be one of: client, invoice, recurring_invoice")
+	}
+
+	reference, err := NormalizeExternalReference(reference)
+	if err != nil {
+		return nil, err
+	}
+	if reference == "" {
+		return nil, errors.NewValidationError("external_ref", reference, errors.ValidationRequired, "external reference is required")
+	}
+
+	resourceID = strings.TrimSpace(resourceID)
+	if resourceID == "" {
+		return nil, errors.NewValidationError("resource_id", resourceID, errors.ValidationRequired, "resource ID is required")
+	}
+
+	return &ExternalReference{
+		kind:       kind,
+		tenantID:   strings.TrimSpace(tenantID),
+		reference:  reference,
+		resourceID: resourceID,
+		createdAt:  time.Now().UTC(),
+	}, nil
+}
+
+// NormalizeExternalReference trims a reference and checks its length (empty means no reference)
+func NormalizeExternalReference(reference string) (string, error) {
+	reference = strings.TrimSpace(reference)
+	if len(reference) > maxExternalReferenceLength {
+		return "", errors.NewValidationError("external_ref", reference, errors.ValidationLength, "external reference must be at most 100 characters")
+	}
+	return reference, nil
+}
+
+// ExternalReferenceKey returns the storage key of a reference of a kind in a tenant, unique by construction
+func ExternalReferenceKey(kind ExternalReferenceKind, tenantID, reference string) string {
+	return string(kind) + "|" + strings.TrimSpace(tenantID) + "|" + strings.TrimSpace(reference)
+}
+
+// Getters
+func (r *ExternalReference) Kind() ExternalReferenceKind {
+	return r.kind
+}
+
+func (r *ExternalReference) TenantID() string {
+	return r.tenantID
+}
+
+func (r *ExternalReference) Reference() string {
+	return r.reference
+}
+
+func (r *ExternalReference) ResourceID() string {
+	return r.resourceID
+}
+
+func (r *ExternalReference) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+// Key returns the storage key of the reference
+func (r *ExternalReference) Key() string {
+	return ExternalReferenceKey(r.kind, r.tenantID, r.reference)
+}
+
+// externalReferenceJSON is the persisted form of an ExternalReference
+type externalReferenceJSON struct {
+	Kind       ExternalReferenceKind `json:"kind"`
+	TenantID   string                `json:"tenantId,omitempty"`
+	Reference  string                `json:"reference"`
+	ResourceID string                `json:"resourceId"`
+	CreatedAt  time.Time             `json:"createdAt"`
+}
+
+// MarshalJSON implements custom JSON marshaling for ExternalReference
+func (r *ExternalReference) MarshalJSON() ([]byte, error) {
+	return json.Marshal(externalReferenceJSON{
+		Kind:       r.kind,
+		TenantID:   r.tenantID,
+		Reference:  r.reference,
+		ResourceID: r.resourceID,
+		CreatedAt:  r.createdAt,
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for ExternalReference
+func (r *ExternalReference) UnmarshalJSON(data []byte) error {
+	var jsonReference externalReferenceJSON
+	if err := json.Unmarshal(data, &jsonReference); err != nil {
+		return err
+	}
+
+	r.kind = jsonReference.Kind
+	r.tenantID = jsonReference.TenantID
+	r.reference = jsonReference.Reference
+	r.resourceID = jsonReference.ResourceID
+	r.createdAt = jsonReference.CreatedAt
+
+	return nil
+}
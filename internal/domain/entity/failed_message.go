@@ -0,0 +1,138 @@
+package entity
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/google/uuid"
+)
+
+// FailedMessage is an integration event the message bus did not accept
+// It is parked in the dead letter queue until an admin retries or discards it
+type FailedMessage struct {
+	id       string
+	topic    string
+	key      string
+	payload  json.RawMessage
+	headers  map[string]string
+	reason   string
+	attempts int
+	failedAt time.Time
+}
+
+// NewFailedMessage creates a failed message with validation
+func NewFailedMessage(topic, key string, payload []byte, headers map[string]string, reason string, failedAt time.Time) (*FailedMessage, error) {
+	topic = strings.TrimSpace(topic)
+	if topic == "" {
+		return nil, errors.NewValidationError("topic", topic, errors.ValidationRequired, "topic is required")
+	}
+	if failedAt.IsZero() {
+		return nil, errors.NewValidationError("failed_at", failedAt, errors.ValidationRequired, "failure time is required")
+	}
+
+	copied := make(map[string]string, len(headers))
+	for name, value := range headers {
+		copied[name] = value
+	}
+
+	return &FailedMessage{
+		id:       uuid.New().String(),
+		topic:    topic,
+		key:      key,
+		payload:  append(json.RawMessage(nil), payload...),
+		headers:  copied,
+		reason:   reason,
+		attempts: 1,
+		failedAt: failedAt.UTC(),
+	}, nil
+}
+
+// Getters
+func (m *FailedMessage) ID() string {
+	return m.id
+}
+
+func (m *FailedMessage) Topic() string {
+	return m.topic
+}
+
+func (m *FailedMessage) Key() string {
+	return m.key
+}
+
+func (m *FailedMessage) Payload() json.RawMessage {
+	return m.payload
+}
+
+func (m *FailedMessage) Headers() map[string]string {
+	return m.headers
+}
+
+// Reason returns the error of the last failed publication
+func (m *FailedMessage) Reason() string {
+	return m.reason
+}
+
+// Attempts returns the number of failed publications, the original one included
+func (m *FailedMessage) Attempts() int {
+	return m.attempts
+}
+
+// FailedAt returns the time of the last failed publication
+func (m *FailedMessage) FailedAt() time.Time {
+	return m.failedAt
+}
+
+// RecordFailure records a failed retry of the publication
+func (m *FailedMessage) RecordFailure(reason string, now time.Time) {
+	m.attempts++
+	m.reason = reason
+	m.failedAt = now.UTC()
+}
+
+// failedMessageJSON is the persisted form of a FailedMessage
+type failedMessageJSON struct {
+	ID       string            `json:"id"`
+	Topic    string            `json:"topic"`
+	Key      string            `json:"key,omitempty"`
+	Payload  json.RawMessage   `json:"payload,omitempty"`
+	Headers  map[string]string `json:"headers,omitempty"`
+	Reason   string            `json:"reason"`
+	Attempts int               `json:"attempts"`
+	FailedAt time.Time         `json:"failedAt"`
+}
+
+// MarshalJSON implements custom JSON marshaling for FailedMessage
+func (m *FailedMessage) MarshalJSON() ([]byte, error) {
+	return json.Marshal(failedMessageJSON{
+		ID:       m.id,
+		Topic:    m.topic,
+		Key:      m.key,
+		Payload:  m.payload,
+		Headers:  m.headers,
+		Reason:   m.reason,
+		Attempts: m.attempts,
+		FailedAt: m.failedAt,
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for FailedMessage
+func (m *FailedMessage) UnmarshalJSON(data []byte) error {
+	var jsonMessage failedMessageJSON
+	if err := json.Unmarshal(data, &jsonMessage); err != nil {
+		return err
+	}
+
+	m.id = jsonMessage.ID
+	m.topic = jsonMessage.Topic
+	m.key = jsonMessage.Key
+	m.payload = jsonMessage.Payload
+	m.headers = jsonMessage.Headers
+	m.reason = jsonMessage.Reason
+	m.attempts = jsonMessage.Attempts
+	m.failedAt = jsonMessage.FailedAt
+
+	return nil
+}
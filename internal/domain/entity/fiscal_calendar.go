@@ -0,0 +1,314 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+)
+
+// FiscalPeriod is an accounting period of a fiscal year
+type FiscalPeriod struct {
+	FiscalYear int
+	Number     int       // Position of the period in its fiscal year, from 1
+	Start      time.Time // First day of the period
+	End        time.Time // First day of the next period
+	Closed     bool      // Locked: nothing may be invoiced or posted on its dates
+}
+
+// Key returns the identifier of the period, e.g. 2026-P03
+func (p FiscalPeriod) Key() string {
+	return FiscalPeriodKey(p.FiscalYear, p.Number)
+}
+
+// LastDay returns the last day of the period
+func (p FiscalPeriod) LastDay() time.Time {
+	return p.End.AddDate(0, 0, -1)
+}
+
+// Contains checks if a date falls in the period
+func (p FiscalPeriod) Contains(date time.Time) bool {
+	date = date.UTC()
+	return !date.Before(p.Start) && date.Before(p.End)
+}
+
+// FiscalPeriodKey formats the identifier of a period of a fiscal year
+func FiscalPeriodKey(fiscalYear, number int) string {
+	return fmt.Sprintf("%d-P%02d", fiscalYear, number)
+}
+
+// ParseFiscalPeriodKey parses a period identifier (e.g. 2026-P03) into its fiscal year and number
+func ParseFiscalPeriodKey(key string) (fiscalYear, number int, err error) {
+	year, period, found := strings.Cut(strings.ToUpper(strings.TrimSpace(key)), "-P")
+	if found {
+		fiscalYear, err = strconv.Atoi(year)
+		if err == nil {
+			number, err = strconv.Atoi(period)
+		}
+	}
+	if !found || err != nil || fiscalYear < 1 || number < 1 {
+		return 0, 0, errors.NewValidationError("period", key, errors.ValidationFormat, "period must look like 2026-P03")
+	}
+	return fiscalYear, number, nil
+}
+
+// FiscalCalendar is how the fiscal years of a tenant are split into accounting periods, and which periods are closed
+// A fiscal year is named after the calendar year it ends in: with a July start, FY2027 runs from July 2026 to June 2027
+type FiscalCalendar struct {
+	tenantID      string
+	startMonth    time.Month
+	periodMonths  []int           // Length of every period of a year, in months (summing to 12)
+	closedPeriods map[string]bool // Keys of the closed periods
+	updatedAt     time.Time
+}
+
+// NewFiscalCalendar creates a tenant fiscal calendar whose years start on the first day of startMonth
+// Without period lengths the year is split into monthly periods
+func NewFiscalCalendar(tenantID string, startMonth int, periodMonths []int) (*FiscalCalendar, error) {
+	tenantID = strings.TrimSpace(tenantID)
+	if tenantID == "" {
+		return nil, errors.NewValidationError("tenant_id", tenantID, errors.ValidationRequired, "tenant ID is required")
+	}
+
+	calendar := &FiscalCalendar{
+		tenantID:      tenantID,
+		closedPeriods: make(map[string]bool),
+	}
+	if err := calendar.define(startMonth, periodMonths); err != nil {
+		return nil, err
+	}
+	return calendar, nil
+}
+
+// DefaultFiscalCalendar is the calendar of tenants without one: calendar years split into months
+func DefaultFiscalCalendar(tenantID string) *FiscalCalendar {
+	return &FiscalCalendar{
+		tenantID:      tenantID,
+		startMonth:    time.January,
+		periodMonths:  monthlyPeriods(),
+		closedPeriods: make(map[string]bool),
+	}
+}
+
+// Getters
+func (c *FiscalCalendar) TenantID() string {
+	return c.tenantID
+}
+
+func (c *FiscalCalendar) StartMonth() time.Month {
+	return c.startMonth
+}
+
+func (c *FiscalCalendar) PeriodMonths() []int {
+	return c.periodMonths
+}
+
+func (c *FiscalCalendar) UpdatedAt() time.Time {
+	return c.updatedAt
+}
+
+// ClosedPeriods returns the keys of the closed periods, in order
+func (c *FiscalCalendar) ClosedPeriods() []string {
+	keys := make([]string, 0, len(c.closedPeriods))
+	for key := range c.closedPeriods {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Redefine changes the start month and periods of the calendar
+// The definition cannot change while periods are closed, as their dates would move
+func (c *FiscalCalendar) Redefine(startMonth int, periodMonths []int) error {
+	previousStart, previousPeriods := c.startMonth, c.periodMonths
+	if err := c.define(startMonth, periodMonths); err != nil {
+		return err
+	}
+
+	if len(c.closedPeriods) > 0 && (c.startMonth != previousStart || !equalPeriods(c.periodMonths, previousPeriods)) {
+		c.startMonth, c.periodMonths = previousStart, previousPeriods
+		return errors.ErrFiscalCalendarHasClosedPeriods
+	}
+	c.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// FiscalYear returns the fiscal year a date falls in
+func (c *FiscalCalendar) FiscalYear(date time.Time) int {
+	date = date.UTC()
+	if c.startMonth != time.January && date.Month() >= c.startMonth {
+		return date.Year() + 1
+	}
+	return date.Year()
+}
+
+// YearStart returns the first day of a fiscal year
+func (c *FiscalCalendar) YearStart(fiscalYear int) time.Time {
+	year := fiscalYear
+	if c.startMonth != time.January {
+		year--
+	}
+	return time.Date(year, c.startMonth, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// Periods returns the periods of a fiscal year, in order
+func (c *FiscalCalendar) Periods(fiscalYear int) []FiscalPeriod {
+	periods := make([]FiscalPeriod, len(c.periodMonths))
+	start := c.YearStart(fiscalYear)
+	for i, months := range c.periodMonths {
+		end := start.AddDate(0, months, 0)
+		periods[i] = FiscalPeriod{
+			FiscalYear: fiscalYear,
+			Number:     i + 1,
+			Start:      start,
+			End:        end,
+			Closed:     c.closedPeriods[FiscalPeriodKey(fiscalYear, i+1)],
+		}
+		start = end
+	}
+	return periods
+}
+
+// PeriodOf returns the period a date falls in
+func (c *FiscalCalendar) PeriodOf(date time.Time) FiscalPeriod {
+	periods := c.Periods(c.FiscalYear(date))
+	for _, period := range periods {
+		if period.Contains(date) {
+			return period
+		}
+	}
+	return periods[len(periods)-1]
+}
+
+// Period returns the period identified by a key (e.g. 2026-P03)
+func (c *FiscalCalendar) Period(key string) (FiscalPeriod, error) {
+	fiscalYear, number, err := ParseFiscalPeriodKey(key)
+	if err != nil {
+		return FiscalPeriod{}, err
+	}
+	if number > len(c.periodMonths) {
+		return FiscalPeriod{}, errors.NewValidationError("period", key, errors.ValidationRange,
+			fmt.Sprintf("fiscal years have %d periods", len(c.periodMonths)))
+	}
+	return c.Periods(fiscalYear)[number-1], nil
+}
+
+// ClosePeriod locks a period, so nothing may be invoiced or posted on its dates
+func (c *FiscalCalendar) ClosePeriod(key string) (FiscalPeriod, error) {
+	period, err := c.Period(key)
+	if err != nil {
+		return FiscalPeriod{}, err
+	}
+	if period.Closed {
+		return FiscalPeriod{}, errors.ErrFiscalPeriodAlreadyClosed
+	}
+
+	c.closedPeriods[period.Key()] = true
+	c.updatedAt = time.Now().UTC()
+	period.Closed = true
+	return period, nil
+}
+
+// ReopenPeriod unlocks a closed period
+func (c *FiscalCalendar) ReopenPeriod(key string) (FiscalPeriod, error) {
+	period, err := c.Period(key)
+	if err != nil {
+		return FiscalPeriod{}, err
+	}
+	if !period.Closed {
+		return FiscalPeriod{}, errors.ErrFiscalPeriodNotClosed
+	}
+
+	delete(c.closedPeriods, period.Key())
+	c.updatedAt = time.Now().UTC()
+	period.Closed = false
+	return period, nil
+}
+
+// define validates and sets the start month and period lengths of the calendar
+func (c *FiscalCalendar) define(startMonth int, periodMonths []int) error {
+	if startMonth < 1 || startMonth > 12 {
+		return errors.NewValidationError("start_month", startMonth, errors.ValidationRange, "start month must be between 1 and 12")
+	}
+
+	if len(periodMonths) == 0 {
+		periodMonths = monthlyPeriods()
+	}
+	total := 0
+	for i, months := range periodMonths {
+		if months < 1 {
+			field := "period_months[" + strconv.Itoa(i) + "]"
+			return errors.NewValidationError(field, months, errors.ValidationRange, "periods must last at least one month")
+		}
+		total += months
+	}
+	if total != 12 {
+		return errors.NewValidationError("period_months", total, errors.ValidationRange, "periods must add up to 12 months")
+	}
+
+	c.startMonth = time.Month(startMonth)
+	c.periodMonths = append([]int(nil), periodMonths...)
+	c.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// monthlyPeriods splits a year into twelve one-month periods
+func monthlyPeriods() []int {
+	return []int{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}
+}
+
+// equalPeriods checks if two period definitions are the same
+func equalPeriods(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// fiscalCalendarJSON is the persisted form of a FiscalCalendar
+type fiscalCalendarJSON struct {
+	TenantID      string    `json:"tenantId"`
+	StartMonth    int       `json:"startMonth"`
+	PeriodMonths  []int     `json:"periodMonths"`
+	ClosedPeriods []string  `json:"closedPeriods,omitempty"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+// MarshalJSON implements custom JSON marshaling for FiscalCalendar
+func (c *FiscalCalendar) MarshalJSON() ([]byte, error) {
+	return json.Marshal(fiscalCalendarJSON{
+		TenantID:      c.tenantID,
+		StartMonth:    int(c.startMonth),
+		PeriodMonths:  c.periodMonths,
+		ClosedPeriods: c.ClosedPeriods(),
+		UpdatedAt:     c.updatedAt,
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for FiscalCalendar
+func (c *FiscalCalendar) UnmarshalJSON(data []byte) error {
+	var jsonCalendar fiscalCalendarJSON
+	if err := json.Unmarshal(data, &jsonCalendar); err != nil {
+		return err
+	}
+
+	c.tenantID = jsonCalendar.TenantID
+	c.startMonth = time.Month(jsonCalendar.StartMonth)
+	c.periodMonths = jsonCalendar.PeriodMonths
+	c.closedPeriods = make(map[string]bool, len(jsonCalendar.ClosedPeriods))
+	for _, key := range jsonCalendar.ClosedPeriods {
+		c.closedPeriods[key] = true
+	}
+	c.updatedAt = jsonCalendar.UpdatedAt
+
+	return nil
+}
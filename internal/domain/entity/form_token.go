@@ -0,0 +1,87 @@
+package entity
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"time"
+)
+
+// FormToken is a single-use token guarding a browser form against duplicate submission
+type FormToken struct {
+	token     string
+	purpose   string
+	issuedAt  time.Time
+	expiresAt time.Time
+}
+
+// NewFormToken issues a random token for purpose, valid for ttl
+func NewFormToken(purpose string, ttl time.Duration) (*FormToken, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	return &FormToken{
+		token:     base64.RawURLEncoding.EncodeToString(buf),
+		purpose:   purpose,
+		issuedAt:  now,
+		expiresAt: now.Add(ttl),
+	}, nil
+}
+
+// Getters
+func (t *FormToken) Token() string {
+	return t.token
+}
+
+func (t *FormToken) Purpose() string {
+	return t.purpose
+}
+
+func (t *FormToken) IssuedAt() time.Time {
+	return t.issuedAt
+}
+
+func (t *FormToken) ExpiresAt() time.Time {
+	return t.expiresAt
+}
+
+// IsExpired checks if the token is past its expiry at the given time
+func (t *FormToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.expiresAt)
+}
+
+// formTokenJSON is the persisted form of a FormToken
+type formTokenJSON struct {
+	Token     string    `json:"token"`
+	Purpose   string    `json:"purpose"`
+	IssuedAt  time.Time `json:"issuedAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// MarshalJSON implements custom JSON marshaling for FormToken
+func (t *FormToken) MarshalJSON() ([]byte, error) {
+	return json.Marshal(formTokenJSON{
+		Token:     t.token,
+		Purpose:   t.purpose,
+		IssuedAt:  t.issuedAt,
+		ExpiresAt: t.expiresAt,
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for FormToken
+func (t *FormToken) UnmarshalJSON(data []byte) error {
+	var jsonToken formTokenJSON
+	if err := json.Unmarshal(data, &jsonToken); err != nil {
+		return err
+	}
+
+	t.token = jsonToken.Token
+	t.purpose = jsonToken.Purpose
+	t.issuedAt = jsonToken.IssuedAt
+	t.expiresAt = jsonToken.ExpiresAt
+
+	return nil
+}
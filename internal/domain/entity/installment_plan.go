@@ -0,0 +1,357 @@
+package entity
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/google/uuid"
+)
+
+// InstallmentStatus is the payment state of a single installment
+type InstallmentStatus string
+
+const (
+	InstallmentPending       InstallmentStatus = "pending"
+	InstallmentPartiallyPaid InstallmentStatus = "partially_paid"
+	InstallmentPaid          InstallmentStatus = "paid"
+	InstallmentOverdue       InstallmentStatus = "overdue"
+)
+
+// InstallmentPlanStatus is the overall state of an installment plan
+type InstallmentPlanStatus string
+
+const (
+	InstallmentPlanActive    InstallmentPlanStatus = "active"
+	InstallmentPlanOverdue   InstallmentPlanStatus = "overdue"
+	InstallmentPlanCompleted InstallmentPlanStatus = "completed"
+)
+
+// ScheduledInstallment is the amount due on a date, used to build a plan
+type ScheduledInstallment struct {
+	DueDate time.Time
+	Amount  valueobject.Money
+}
+
+// Installment is one scheduled part of an invoice's balance
+type Installment struct {
+	sequence int
+	dueDate  time.Time
+	amount   valueobject.Money
+	paid     valueobject.Money
+	paidAt   *time.Time
+}
+
+// Getters
+func (i Installment) Sequence() int {
+	return i.sequence
+}
+
+func (i Installment) DueDate() time.Time {
+	return i.dueDate
+}
+
+func (i Installment) Amount() valueobject.Money {
+	return i.amount
+}
+
+func (i Installment) PaidAmount() valueobject.Money {
+	return i.paid
+}
+
+func (i Installment) PaidAt() *time.Time {
+	return i.paidAt
+}
+
+// Outstanding returns the part of the installment not yet paid
+func (i Installment) Outstanding() valueobject.Money {
+	outstanding, _ := i.amount.Subtract(i.paid)
+	return outstanding
+}
+
+// IsPaid checks if the installment is fully paid
+func (i Installment) IsPaid() bool {
+	return !i.Outstanding().IsPositive()
+}
+
+// IsOverdue checks if the installment is still owed after its due date
+func (i Installment) IsOverdue(now time.Time) bool {
+	return !i.IsPaid() && now.After(i.dueDate)
+}
+
+// Status returns the installment state at the given time
+func (i Installment) Status(now time.Time) InstallmentStatus {
+	switch {
+	case i.IsPaid():
+		return InstallmentPaid
+	case i.IsOverdue(now):
+		return InstallmentOverdue
+	case i.paid.IsPositive():
+		return InstallmentPartiallyPaid
+	default:
+		return InstallmentPending
+	}
+}
+
+// InstallmentPlan splits an invoice's balance into installments with their own due dates
+// Payments are applied to the earliest outstanding installment first and overdue
+// detection happens per installment rather than for the invoice as a whole
+type InstallmentPlan struct {
+	id           string
+	invoiceID    string
+	total        valueobject.Money
+	installments []Installment
+	createdAt    time.Time
+	updatedAt    time.Time
+}
+
+// NewInstallmentPlan creates a plan for invoiceID from an explicit schedule
+// The scheduled amounts must be positive, in the invoice currency, sum to total and have increasing due dates
+func NewInstallmentPlan(invoiceID string, total valueobject.Money, schedule []ScheduledInstallment) (*InstallmentPlan, error) {
+	invoiceID = strings.TrimSpace(invoiceID)
+	if invoiceID == "" {
+		return nil, errors.NewValidationError("invoice_id", invoiceID, errors.ValidationRequired, "invoice ID is required")
+	}
+
+	if !total.IsPositive() {
+		return nil, errors.NewValidationError("total", total.Amount(), errors.ValidationRange, "plan total must be positive")
+	}
+
+	if len(schedule) < 2 {
+		return nil, errors.NewValidationError("installments", len(schedule), errors.ValidationRange, "a plan needs at least two installments")
+	}
+
+	installments := make([]Installment, len(schedule))
+	sum := valueobject.ZeroMoney(total.Currency())
+	for index, scheduled := range schedule {
+		if !scheduled.Amount.IsPositive() {
+			return nil, errors.NewValidationError("installments", scheduled.Amount.Amount(), errors.ValidationRange, "installment amounts must be positive")
+		}
+		if index > 0 && !scheduled.DueDate.After(schedule[index-1].DueDate) {
+			return nil, errors.NewValidationError("installments", scheduled.DueDate, errors.ValidationFormat, "installment due dates must be strictly increasing")
+		}
+
+		var err error
+		if sum, err = sum.Add(scheduled.Amount); err != nil {
+			return nil, err
+		}
+
+		installments[index] = Installment{
+			sequence: index + 1,
+			dueDate:  scheduled.DueDate.UTC(),
+			amount:   scheduled.Amount,
+			paid:     valueobject.ZeroMoney(total.Currency()),
+		}
+	}
+
+	if !sum.Equals(total) {
+		return nil, errors.NewBusinessRuleError("installment_total", errors.BusinessRuleViolation, "installments must add up to the plan total")
+	}
+
+	now := time.Now().UTC()
+	return &InstallmentPlan{
+		id:           uuid.New().String(),
+		invoiceID:    invoiceID,
+		total:        total,
+		installments: installments,
+		createdAt:    now,
+		updatedAt:    now,
+	}, nil
+}
+
+// NewEvenInstallmentPlan splits total into count installments due every intervalMonths from firstDueDate
+// Rounding remainders go to the earliest installments
+func NewEvenInstallmentPlan(invoiceID string, total valueobject.Money, count int, firstDueDate time.Time, intervalMonths int) (*InstallmentPlan, error) {
+	if intervalMonths <= 0 {
+		return nil, errors.NewValidationError("interval_months", intervalMonths, errors.ValidationRange, "installment interval must be at least one month")
+	}
+
+	if count < 2 {
+		return nil, errors.NewValidationError("installments", count, errors.ValidationRange, "a plan needs at least two installments")
+	}
+
+	amounts, err := total.Split(count)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := make([]ScheduledInstallment, count)
+	for index, amount := range amounts {
+		schedule[index] = ScheduledInstallment{
+			DueDate: firstDueDate.AddDate(0, index*intervalMonths, 0),
+			Amount:  amount,
+		}
+	}
+
+	return NewInstallmentPlan(invoiceID, total, schedule)
+}
+
+// Getters
+func (p *InstallmentPlan) ID() string {
+	return p.id
+}
+
+func (p *InstallmentPlan) InvoiceID() string {
+	return p.invoiceID
+}
+
+func (p *InstallmentPlan) Total() valueobject.Money {
+	return p.total
+}
+
+// Installments returns a copy of the schedule
+func (p *InstallmentPlan) Installments() []Installment {
+	installments := make([]Installment, len(p.installments))
+	copy(installments, p.installments)
+	return installments
+}
+
+func (p *InstallmentPlan) CreatedAt() time.Time {
+	return p.createdAt
+}
+
+func (p *InstallmentPlan) UpdatedAt() time.Time {
+	return p.updatedAt
+}
+
+// Outstanding returns the unpaid balance of the plan
+func (p *InstallmentPlan) Outstanding() valueobject.Money {
+	outstanding := valueobject.ZeroMoney(p.total.Currency())
+	for _, installment := range p.installments {
+		outstanding, _ = outstanding.Add(installment.Outstanding())
+	}
+	return outstanding
+}
+
+// ApplyPayment allocates a payment to the earliest outstanding installments and returns any overpayment
+func (p *InstallmentPlan) ApplyPayment(amount valueobject.Money, paidAt time.Time) (valueobject.Money, error) {
+	if !amount.IsPositive() {
+		return valueobject.Money{}, errors.NewValidationError("amount", amount.Amount(), errors.ValidationRange, "payment amount must be positive")
+	}
+
+	if amount.Currency() != p.total.Currency() {
+		return valueobject.Money{}, errors.NewBusinessRuleError("currency_mismatch", errors.BusinessRuleViolation, "payment currency must match the plan currency")
+	}
+
+	remaining := amount
+	for index := range p.installments {
+		installment := &p.installments[index]
+		if installment.IsPaid() || !remaining.IsPositive() {
+			continue
+		}
+
+		applied, _ := remaining.Min(installment.Outstanding())
+		installment.paid, _ = installment.paid.Add(applied)
+		remaining, _ = remaining.Subtract(applied)
+
+		if installment.IsPaid() {
+			settledAt := paidAt.UTC()
+			installment.paidAt = &settledAt
+		}
+	}
+
+	p.updatedAt = time.Now().UTC()
+	return remaining, nil
+}
+
+// OverdueInstallments returns the installments still owed after their due date
+func (p *InstallmentPlan) OverdueInstallments(now time.Time) []Installment {
+	overdue := make([]Installment, 0)
+	for _, installment := range p.installments {
+		if installment.IsOverdue(now) {
+			overdue = append(overdue, installment)
+		}
+	}
+	return overdue
+}
+
+// NextDue returns the earliest installment not yet fully paid
+func (p *InstallmentPlan) NextDue() (Installment, bool) {
+	for _, installment := range p.installments {
+		if !installment.IsPaid() {
+			return installment, true
+		}
+	}
+	return Installment{}, false
+}
+
+// Status returns the plan state at the given time
+func (p *InstallmentPlan) Status(now time.Time) InstallmentPlanStatus {
+	if !p.Outstanding().IsPositive() {
+		return InstallmentPlanCompleted
+	}
+	if len(p.OverdueInstallments(now)) > 0 {
+		return InstallmentPlanOverdue
+	}
+	return InstallmentPlanActive
+}
+
+// installmentJSON is the persisted form of an Installment
+type installmentJSON struct {
+	Sequence int               `json:"sequence"`
+	DueDate  time.Time         `json:"dueDate"`
+	Amount   valueobject.Money `json:"amount"`
+	Paid     valueobject.Money `json:"paid"`
+	PaidAt   *time.Time        `json:"paidAt,omitempty"`
+}
+
+// installmentPlanJSON is the persisted form of an InstallmentPlan
+type installmentPlanJSON struct {
+	ID           string            `json:"id"`
+	InvoiceID    string            `json:"invoiceId"`
+	Total        valueobject.Money `json:"total"`
+	Installments []installmentJSON `json:"installments"`
+	CreatedAt    time.Time         `json:"createdAt"`
+	UpdatedAt    time.Time         `json:"updatedAt"`
+}
+
+// MarshalJSON implements custom JSON marshaling for InstallmentPlan
+func (p *InstallmentPlan) MarshalJSON() ([]byte, error) {
+	installments := make([]installmentJSON, len(p.installments))
+	for index, installment := range p.installments {
+		installments[index] = installmentJSON{
+			Sequence: installment.sequence,
+			DueDate:  installment.dueDate,
+			Amount:   installment.amount,
+			Paid:     installment.paid,
+			PaidAt:   installment.paidAt,
+		}
+	}
+
+	return json.Marshal(installmentPlanJSON{
+		ID:           p.id,
+		InvoiceID:    p.invoiceID,
+		Total:        p.total,
+		Installments: installments,
+		CreatedAt:    p.createdAt,
+		UpdatedAt:    p.updatedAt,
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for InstallmentPlan
+func (p *InstallmentPlan) UnmarshalJSON(data []byte) error {
+	var jsonPlan installmentPlanJSON
+	if err := json.Unmarshal(data, &jsonPlan); err != nil {
+		return err
+	}
+
+	p.id = jsonPlan.ID
+	p.invoiceID = jsonPlan.InvoiceID
+	p.total = jsonPlan.Total
+	p.createdAt = jsonPlan.CreatedAt
+	p.updatedAt = jsonPlan.UpdatedAt
+	p.installments = make([]Installment, len(jsonPlan.Installments))
+	for index, installment := range jsonPlan.Installments {
+		p.installments[index] = Installment{
+			sequence: installment.Sequence,
+			dueDate:  installment.DueDate,
+			amount:   installment.Amount,
+			paid:     installment.Paid,
+			paidAt:   installment.PaidAt,
+		}
+	}
+
+	return nil
+}
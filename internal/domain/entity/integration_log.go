@@ -0,0 +1,133 @@
+package entity
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/google/uuid"
+)
+
+// IntegrationCall describes an outbound call to an integration (payment gateway, credit bureau, captcha provider)
+// Bodies and URL are expected to be redacted before the call is logged
+type IntegrationCall struct {
+	Destination  string
+	Method       string
+	URL          string
+	StatusCode   int // 0 when no response was received
+	Duration     time.Duration
+	RequestBody  string
+	ResponseBody string
+	Error        string // Network error of calls without a response
+	RequestID    string // Request ID of the API request that made the call, if any
+}
+
+// IntegrationLog records an outbound integration call for support investigations
+// Logs are deleted once older than the retention period
+type IntegrationLog struct {
+	id        string
+	call      IntegrationCall
+	createdAt time.Time
+}
+
+// NewIntegrationLog records an outbound call made at now
+func NewIntegrationLog(call IntegrationCall, now time.Time) (*IntegrationLog, error) {
+	call.Destination = strings.TrimSpace(call.Destination)
+	call.Method = strings.ToUpper(strings.TrimSpace(call.Method))
+
+	if call.Destination == "" {
+		return nil, errors.NewValidationError("destination", call.Destination, errors.ValidationRequired, "destination is required")
+	}
+	if call.Method == "" {
+		return nil, errors.NewValidationError("method", call.Method, errors.ValidationRequired, "method is required")
+	}
+	if call.URL == "" {
+		return nil, errors.NewValidationError("url", call.URL, errors.ValidationRequired, "URL is required")
+	}
+
+	return &IntegrationLog{
+		id:        uuid.New().String(),
+		call:      call,
+		createdAt: now.UTC(),
+	}, nil
+}
+
+// Getters
+func (l *IntegrationLog) ID() string {
+	return l.id
+}
+
+func (l *IntegrationLog) Call() IntegrationCall {
+	return l.call
+}
+
+func (l *IntegrationLog) CreatedAt() time.Time {
+	return l.createdAt
+}
+
+// IsFailed reports whether the call got no response or a 5xx/429 response
+func (l *IntegrationLog) IsFailed() bool {
+	return l.call.StatusCode == 0 || l.call.StatusCode == 429 || l.call.StatusCode >= 500
+}
+
+// IsExpired reports whether the log is older than retention
+func (l *IntegrationLog) IsExpired(now time.Time, retention time.Duration) bool {
+	return now.Sub(l.createdAt) >= retention
+}
+
+// integrationLogJSON is the persisted form of an IntegrationLog
+type integrationLogJSON struct {
+	ID           string    `json:"id"`
+	Destination  string    `json:"destination"`
+	Method       string    `json:"method"`
+	URL          string    `json:"url"`
+	StatusCode   int       `json:"statusCode,omitempty"`
+	DurationMs   int64     `json:"durationMs"`
+	RequestBody  string    `json:"requestBody,omitempty"`
+	ResponseBody string    `json:"responseBody,omitempty"`
+	Error        string    `json:"error,omitempty"`
+	RequestID    string    `json:"requestId,omitempty"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// MarshalJSON implements custom JSON marshaling for IntegrationLog
+func (l *IntegrationLog) MarshalJSON() ([]byte, error) {
+	return json.Marshal(integrationLogJSON{
+		ID:           l.id,
+		Destination:  l.call.Destination,
+		Method:       l.call.Method,
+		URL:          l.call.URL,
+		StatusCode:   l.call.StatusCode,
+		DurationMs:   l.call.Duration.Milliseconds(),
+		RequestBody:  l.call.RequestBody,
+		ResponseBody: l.call.ResponseBody,
+		Error:        l.call.Error,
+		RequestID:    l.call.RequestID,
+		CreatedAt:    l.createdAt,
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for IntegrationLog
+func (l *IntegrationLog) UnmarshalJSON(data []byte) error {
+	var jsonLog integrationLogJSON
+	if err := json.Unmarshal(data, &jsonLog); err != nil {
+		return err
+	}
+
+	l.id = jsonLog.ID
+	l.call = IntegrationCall{
+		Destination:  jsonLog.Destination,
+		Method:       jsonLog.Method,
+		URL:          jsonLog.URL,
+		StatusCode:   jsonLog.StatusCode,
+		Duration:     time.Duration(jsonLog.DurationMs) * time.Millisecond,
+		RequestBody:  jsonLog.RequestBody,
+		ResponseBody: jsonLog.ResponseBody,
+		Error:        jsonLog.Error,
+		RequestID:    jsonLog.RequestID,
+	}
+	l.createdAt = jsonLog.CreatedAt
+
+	return nil
+}
@@ -0,0 +1,949 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/google/uuid"
+)
+
+// InvoiceStatus is the lifecycle state of an invoice
+type InvoiceStatus string
+
+const (
+	InvoiceDraft  InvoiceStatus = "draft"
+	InvoiceIssued InvoiceStatus = "issued"
+	InvoicePaid   InvoiceStatus = "paid"
+	InvoiceVoid   InvoiceStatus = "void"
+)
+
+// InvoiceLine is a line item billed on an invoice
+type InvoiceLine struct {
+	Description string
+	Quantity    int64
+	UnitAmount  int64 // Minor units of the invoice currency
+	TaxRateBps  int64 // Tax rate of the line (2000 = 20%, 0 = not taxed)
+}
+
+// DunningEvent records a payment reminder sent for an overdue invoice
+type DunningEvent struct {
+	Level       int // 1-based position of the reminder stage in the cadence; levels only escalate
+	Channel     ReminderChannel
+	Template    string
+	DaysOverdue int
+	SentAt      time.Time
+	LateFee     int64 // Late fee charged with the reminder, added to the balance (minor units)
+}
+
+// InvoiceInstallments splits the balance of an issued invoice into Count installments due every IntervalMonths,
+// the first one on the due date of the invoice
+type InvoiceInstallments struct {
+	Count          int // 0: the invoice is paid at once
+	IntervalMonths int
+}
+
+// InvoiceIssue is what was settled about an invoice when it was issued
+type InvoiceIssue struct {
+	LegalEntityID string   // Entity the invoice is issued from (empty when no legal entity is configured)
+	FiscalYear    int      // 0 when fiscal calendars are not configured
+	FiscalPeriod  string   // Key of the fiscal period (empty when fiscal calendars are not configured)
+	TaxBreakdown  string   // Empty when compliance rules are not configured
+	LegalMentions []string // Mentions to print on the document
+}
+
+// invoiceTaxRounding rounds the tax of each line item to the minor unit
+const invoiceTaxRounding = valueobject.RoundHalfUp
+
+// Invoice is a one-off invoice billed to a client
+// Drafts are editable and deletable; issuing freezes the line items, after which payments are applied until the
+// invoice is paid in full, or the invoice is voided
+type Invoice struct {
+	id              string
+	tenantID        string // Tenant the invoice was created for (empty when created without a tenant)
+	clientID        string
+	currency        string
+	lines           []InvoiceLine
+	taxPricing      valueobject.TaxPricing // Whether unit amounts include tax (empty = exclusive)
+	taxJurisdiction string                 // Jurisdiction the line tax rates were looked up for (empty when set per line)
+	externalRef     string                 // Reference of the invoice in the system that created it (empty when none)
+	legalEntityID   string                 // Legal entity the invoice is issued from (empty = the default entity)
+	buyerCountry    string                 // Country the client is taxed in, which compliance rules check (empty when unknown)
+	buyerTaxID      string
+	installments    InvoiceInstallments // Installments the balance is split into once issued (zero = paid at once)
+	status          InvoiceStatus
+	number          string                   // Sequential number assigned when issued (empty for drafts and unnumbered invoices)
+	paid            int64                    // Minor units received so far
+	dueDate         *time.Time               // Date payment is due (nil = due on receipt)
+	paymentTerms    valueobject.PaymentTerms // Terms the due date is computed from when issued without one
+	fiscalYear      int                      // Fiscal year the invoice is reported in, set when issued with fiscal calendars
+	fiscalPeriod    string                   // Key of the fiscal period the invoice is reported in (e.g. 2026-P03)
+	taxBreakdown    string                   // How taxes are itemized on the document, set when issued with compliance rules
+	legalMentions   []string                 // Mentions the seller and buyer countries require on the document
+	issuedAt        *time.Time
+	paidAt          *time.Time
+	voidedAt        *time.Time
+	rehydratedAt    *time.Time     // Last time the invoice was restored from the archive
+	dunning         []DunningEvent // Payment reminders sent while overdue, oldest first
+	createdAt       time.Time
+	updatedAt       time.Time
+}
+
+// NewInvoice creates a draft invoice with validation
+func NewInvoice(clientID, currency string, lines []InvoiceLine, dueDate *time.Time) (*Invoice, error) {
+	clientID = strings.TrimSpace(clientID)
+	if clientID == "" {
+		return nil, errors.NewValidationError("client_id", clientID, errors.ValidationRequired, "client ID is required")
+	}
+
+	now := time.Now().UTC()
+	invoice := &Invoice{
+		id:         uuid.New().String(),
+		clientID:   clientID,
+		taxPricing: valueobject.TaxExclusive,
+		status:     InvoiceDraft,
+		createdAt:  now,
+		updatedAt:  now,
+	}
+	if err := invoice.apply(currency, lines, dueDate); err != nil {
+		return nil, err
+	}
+	return invoice, nil
+}
+
+// Update replaces the currency, line items and due date of a draft invoice
+func (i *Invoice) Update(currency string, lines []InvoiceLine, dueDate *time.Time) error {
+	if i.status != InvoiceDraft {
+		return errors.ErrInvoiceNotDraft
+	}
+	if err := i.apply(currency, lines, dueDate); err != nil {
+		return err
+	}
+	i.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// apply validates and sets the editable fields
+func (i *Invoice) apply(currency string, lines []InvoiceLine, dueDate *time.Time) error {
+	money, err := valueobject.NewMoney(0, currency)
+	if err != nil {
+		return err
+	}
+	validated, err := validateInvoiceLines(lines, money.Currency())
+	if err != nil {
+		return err
+	}
+
+	var due *time.Time
+	if dueDate != nil {
+		utc := dueDate.UTC()
+		due = &utc
+	}
+
+	i.currency = money.Currency()
+	i.lines = validated
+	i.dueDate = due
+	return nil
+}
+
+// validateInvoiceLines validates line items billed in a currency and returns them with trimmed descriptions
+func validateInvoiceLines(lines []InvoiceLine, currency string) ([]InvoiceLine, error) {
+	if len(lines) == 0 {
+		return nil, errors.NewValidationError("line_items", 0, errors.ValidationRequired, "at least one line item is required")
+	}
+	validated := make([]InvoiceLine, len(lines))
+	for index, line := range lines {
+		field := fmt.Sprintf("line_items[%d]", index)
+		line.Description = strings.TrimSpace(line.Description)
+		if line.Description == "" {
+			return nil, errors.NewValidationError(field+".description", line.Description, errors.ValidationRequired, "line description is required")
+		}
+		if line.Quantity <= 0 {
+			return nil, errors.NewValidationError(field+".quantity", line.Quantity, errors.ValidationRange, "line quantity must be positive")
+		}
+		if line.UnitAmount < 0 {
+			return nil, errors.NewValidationError(field+".unit_amount", line.UnitAmount, errors.ValidationRange, "line unit amount must not be negative")
+		}
+		if line.TaxRateBps < 0 || line.TaxRateBps > 10000 {
+			return nil, errors.NewValidationError(field+".tax_rate_bps", line.TaxRateBps, errors.ValidationRange, "line tax rate must be between 0 and 10000 basis points")
+		}
+		if _, err := lineAmount(line, currency); err != nil {
+			return nil, errors.NewValidationError(field+".quantity", line.Quantity, errors.ValidationRange, "line amount is out of range")
+		}
+		validated[index] = line
+	}
+	// Tax added on top of the amounts gives the largest totals, so they bound the totals of either pricing
+	if _, err := taxedLineTotals(validated, currency, valueobject.TaxExclusive); err != nil {
+		return nil, errors.NewValidationError("line_items", len(lines), errors.ValidationRange, "total of the line items is out of range")
+	}
+	return validated, nil
+}
+
+// Getters
+func (i *Invoice) ID() string {
+	return i.id
+}
+
+func (i *Invoice) TenantID() string {
+	return i.tenantID
+}
+
+func (i *Invoice) ClientID() string {
+	return i.clientID
+}
+
+func (i *Invoice) Currency() string {
+	return i.currency
+}
+
+// Lines returns a copy of the line items
+func (i *Invoice) Lines() []InvoiceLine {
+	return append([]InvoiceLine(nil), i.lines...)
+}
+
+// TaxPricing returns whether the unit amounts include tax
+func (i *Invoice) TaxPricing() valueobject.TaxPricing {
+	if i.taxPricing == "" {
+		return valueobject.TaxExclusive
+	}
+	return i.taxPricing
+}
+
+func (i *Invoice) TaxJurisdiction() string {
+	return i.taxJurisdiction
+}
+
+// ExternalReference returns the reference of the invoice in the system that created it (empty when none)
+func (i *Invoice) ExternalReference() string {
+	return i.externalRef
+}
+
+// LegalEntityID returns the legal entity the invoice is issued from (empty for drafts of the default entity)
+func (i *Invoice) LegalEntityID() string {
+	return i.legalEntityID
+}
+
+func (i *Invoice) BuyerCountry() string {
+	return i.buyerCountry
+}
+
+func (i *Invoice) BuyerTaxID() string {
+	return i.buyerTaxID
+}
+
+// Installments returns how the balance is split once issued (zero when paid at once)
+func (i *Invoice) Installments() InvoiceInstallments {
+	return i.installments
+}
+
+// FiscalYear returns the fiscal year the invoice is reported in (0 unless issued with fiscal calendars)
+func (i *Invoice) FiscalYear() int {
+	return i.fiscalYear
+}
+
+func (i *Invoice) FiscalPeriod() string {
+	return i.fiscalPeriod
+}
+
+// TaxBreakdown returns how taxes are itemized on the document (empty unless issued with compliance rules)
+func (i *Invoice) TaxBreakdown() string {
+	return i.taxBreakdown
+}
+
+// LegalMentions returns a copy of the mentions to print on the document
+func (i *Invoice) LegalMentions() []string {
+	return append([]string(nil), i.legalMentions...)
+}
+
+func (i *Invoice) Status() InvoiceStatus {
+	return i.status
+}
+
+func (i *Invoice) Number() string {
+	return i.number
+}
+
+// AmountPaid returns the total of the payments applied to the invoice
+func (i *Invoice) AmountPaid() valueobject.Money {
+	paid, _ := valueobject.NewMoney(i.paid, i.currency)
+	return paid
+}
+
+func (i *Invoice) DueDate() *time.Time {
+	return i.dueDate
+}
+
+// PaymentTerms returns the terms the due date is computed from when issued without one (empty without terms)
+func (i *Invoice) PaymentTerms() valueobject.PaymentTerms {
+	return i.paymentTerms
+}
+
+func (i *Invoice) IssuedAt() *time.Time {
+	return i.issuedAt
+}
+
+func (i *Invoice) PaidAt() *time.Time {
+	return i.paidAt
+}
+
+func (i *Invoice) VoidedAt() *time.Time {
+	return i.voidedAt
+}
+
+func (i *Invoice) RehydratedAt() *time.Time {
+	return i.rehydratedAt
+}
+
+// DunningEvents returns a copy of the payment reminders sent for the invoice
+func (i *Invoice) DunningEvents() []DunningEvent {
+	return append([]DunningEvent(nil), i.dunning...)
+}
+
+// DunningLevel returns the level of the last payment reminder sent (0 when none was sent)
+func (i *Invoice) DunningLevel() int {
+	if len(i.dunning) == 0 {
+		return 0
+	}
+	return i.dunning[len(i.dunning)-1].Level
+}
+
+func (i *Invoice) CreatedAt() time.Time {
+	return i.createdAt
+}
+
+func (i *Invoice) UpdatedAt() time.Time {
+	return i.updatedAt
+}
+
+// DaysOverdue returns the number of whole days an issued invoice is past its due date at now
+// Invoices that are not issued, have no due date or are not past it yet are not overdue (0)
+// Invoices paid in installments are overdue from the due date of their earliest installment left unpaid
+func (i *Invoice) DaysOverdue(now time.Time) int {
+	if i.status != InvoiceIssued {
+		return 0
+	}
+	due := i.dueDate
+	plan, err := i.InstallmentPlan()
+	if err == nil && plan != nil {
+		overdue := plan.OverdueInstallments(now)
+		if len(overdue) == 0 {
+			return 0
+		}
+		first := overdue[0].DueDate()
+		due = &first
+	}
+	if due == nil {
+		return 0
+	}
+	today := now.UTC().Truncate(24 * time.Hour)
+	dueDate := due.UTC().Truncate(24 * time.Hour)
+	if days := int(today.Sub(dueDate) / (24 * time.Hour)); days > 0 {
+		return days
+	}
+	return 0
+}
+
+// IsDraft checks if the invoice can still be edited or deleted
+func (i *Invoice) IsDraft() bool {
+	return i.status == InvoiceDraft
+}
+
+// LineAmount returns the total of one line item at its unit amount (gross when prices include tax)
+func (i *Invoice) LineAmount(line InvoiceLine) (valueobject.Money, error) {
+	return lineAmount(line, i.currency)
+}
+
+// LineTax splits the amount of one line item into its net amount and tax at the line's tax rate
+func (i *Invoice) LineTax(line InvoiceLine) (valueobject.TaxedAmount, error) {
+	return lineTax(line, i.currency, i.TaxPricing())
+}
+
+// Subtotal returns the amount billed before tax
+func (i *Invoice) Subtotal() (valueobject.Money, error) {
+	totals, err := i.taxedTotals()
+	return totals.Net, err
+}
+
+// TaxTotal returns the tax billed on the line items
+func (i *Invoice) TaxTotal() (valueobject.Money, error) {
+	totals, err := i.taxedTotals()
+	return totals.Tax, err
+}
+
+// Total returns the amount billed by the invoice, tax included
+func (i *Invoice) Total() (valueobject.Money, error) {
+	totals, err := i.taxedTotals()
+	return totals.Gross, err
+}
+
+// taxedTotals sums the net amounts, taxes and gross amounts of the line items
+func (i *Invoice) taxedTotals() (valueobject.TaxedAmount, error) {
+	return taxedLineTotals(i.lines, i.currency, i.TaxPricing())
+}
+
+// lineTax splits the amount of one line item priced in a currency into its net amount and tax
+func lineTax(line InvoiceLine, currency string, pricing valueobject.TaxPricing) (valueobject.TaxedAmount, error) {
+	amount, err := lineAmount(line, currency)
+	if err != nil {
+		return valueobject.TaxedAmount{}, err
+	}
+	rate, err := valueobject.NewTaxRate(line.TaxRateBps)
+	if err != nil {
+		return valueobject.TaxedAmount{}, err
+	}
+	return rate.Apply(amount, pricing, invoiceTaxRounding)
+}
+
+// taxedLineTotals sums the net amounts, taxes and gross amounts of line items
+// Tax is rounded per line, so the totals always add up from the amounts shown on each line
+func taxedLineTotals(lines []InvoiceLine, currency string, pricing valueobject.TaxPricing) (valueobject.TaxedAmount, error) {
+	totals := valueobject.TaxedAmount{
+		Net:   valueobject.ZeroMoney(currency),
+		Tax:   valueobject.ZeroMoney(currency),
+		Gross: valueobject.ZeroMoney(currency),
+	}
+	for _, line := range lines {
+		taxed, err := lineTax(line, currency, pricing)
+		if err != nil {
+			return valueobject.TaxedAmount{}, err
+		}
+		if totals.Net, err = totals.Net.Add(taxed.Net); err != nil {
+			return valueobject.TaxedAmount{}, err
+		}
+		if totals.Tax, err = totals.Tax.Add(taxed.Tax); err != nil {
+			return valueobject.TaxedAmount{}, err
+		}
+		if totals.Gross, err = totals.Gross.Add(taxed.Gross); err != nil {
+			return valueobject.TaxedAmount{}, err
+		}
+	}
+	return totals, nil
+}
+
+// Balance returns the amount left to pay, late fees included (the total until the invoice is issued, zero once
+// voided)
+func (i *Invoice) Balance() (valueobject.Money, error) {
+	if i.status == InvoiceVoid {
+		return valueobject.ZeroMoney(i.currency), nil
+	}
+	total, err := i.Total()
+	if err != nil {
+		return valueobject.Money{}, err
+	}
+	if total, err = total.Add(i.LateFees()); err != nil {
+		return valueobject.Money{}, err
+	}
+	return total.Subtract(i.AmountPaid())
+}
+
+// LateFees returns the total of the late fees charged with the payment reminders of the invoice
+func (i *Invoice) LateFees() valueobject.Money {
+	var fees int64
+	for _, event := range i.dunning {
+		fees += event.LateFee
+	}
+	lateFees, _ := valueobject.NewMoney(fees, i.currency)
+	return lateFees
+}
+
+// SetTaxTerms sets whether the unit amounts of a draft include tax and the jurisdiction its tax rates come from
+func (i *Invoice) SetTaxTerms(pricing valueobject.TaxPricing, jurisdiction string) error {
+	if i.status != InvoiceDraft {
+		return errors.ErrInvoiceNotDraft
+	}
+	parsed, err := valueobject.ParseTaxPricing(string(pricing))
+	if err != nil {
+		return err
+	}
+	i.taxPricing = parsed
+	i.taxJurisdiction = strings.ToUpper(strings.TrimSpace(jurisdiction))
+	i.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// SetPaymentTerms sets the terms the due date of a draft is computed from when it is issued without one
+func (i *Invoice) SetPaymentTerms(terms valueobject.PaymentTerms) error {
+	if i.status != InvoiceDraft {
+		return errors.ErrInvoiceNotDraft
+	}
+	parsed, err := valueobject.ParsePaymentTerms(string(terms))
+	if err != nil {
+		return err
+	}
+	i.paymentTerms = parsed
+	i.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// SetExternalReference sets the reference of a draft in the system that created it (empty clears it)
+func (i *Invoice) SetExternalReference(reference string) error {
+	if i.status != InvoiceDraft {
+		return errors.ErrInvoiceNotDraft
+	}
+	reference, err := NormalizeExternalReference(reference)
+	if err != nil {
+		return err
+	}
+	i.externalRef = reference
+	i.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// AssignLegalEntity sets the legal entity a draft is issued from (empty issues it from the default entity)
+func (i *Invoice) AssignLegalEntity(legalEntityID string) error {
+	if i.status != InvoiceDraft {
+		return errors.ErrInvoiceNotDraft
+	}
+	i.legalEntityID = strings.TrimSpace(legalEntityID)
+	i.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// SetBuyerTaxDetails sets the country the client of a draft is taxed in and its tax number, which invoice compliance
+// rules check
+func (i *Invoice) SetBuyerTaxDetails(country, taxID string) error {
+	if i.status != InvoiceDraft {
+		return errors.ErrInvoiceNotDraft
+	}
+	country = strings.ToUpper(strings.TrimSpace(country))
+	if country != "" && !isCountryCode(country) {
+		return errors.NewValidationError("buyer_country", country, errors.ValidationFormat, "buyer country must be an ISO 3166-1 alpha-2 code")
+	}
+	taxID = strings.TrimSpace(taxID)
+	if len(taxID) > 50 {
+		return errors.NewValidationError("buyer_tax_id", taxID, errors.ValidationLength, "buyer tax ID must be at most 50 characters")
+	}
+
+	i.buyerCountry = country
+	i.buyerTaxID = taxID
+	i.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// SetInstallments splits the balance of a draft into installments once it is issued (a zero count clears them)
+// Installments are due monthly unless an interval is set
+func (i *Invoice) SetInstallments(installments InvoiceInstallments) error {
+	if i.status != InvoiceDraft {
+		return errors.ErrInvoiceNotDraft
+	}
+	if installments.Count != 0 {
+		if installments.Count < 2 || installments.Count > 60 {
+			return errors.NewValidationError("installments.count", installments.Count, errors.ValidationRange, "an invoice is split into 2 to 60 installments")
+		}
+		if installments.IntervalMonths == 0 {
+			installments.IntervalMonths = 1
+		}
+		if installments.IntervalMonths < 1 || installments.IntervalMonths > 12 {
+			return errors.NewValidationError("installments.interval_months", installments.IntervalMonths, errors.ValidationRange, "installments are due every 1 to 12 months")
+		}
+	} else {
+		installments.IntervalMonths = 0
+	}
+
+	i.installments = installments
+	i.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// InstallmentPlan returns the installments the balance of an issued invoice is split into, with the payments applied
+// to the earliest ones (nil for drafts and invoices paid at once)
+// The first installment is due on the due date of the invoice, or its issue date when it is due on receipt
+func (i *Invoice) InstallmentPlan() (*InstallmentPlan, error) {
+	if i.installments.Count == 0 || i.issuedAt == nil {
+		return nil, nil
+	}
+	total, err := i.Total()
+	if err != nil {
+		return nil, err
+	}
+	firstDueDate := *i.issuedAt
+	if i.dueDate != nil {
+		firstDueDate = *i.dueDate
+	}
+
+	plan, err := NewEvenInstallmentPlan(i.id, total, i.installments.Count, firstDueDate, i.installments.IntervalMonths)
+	if err != nil {
+		return nil, err
+	}
+	if i.paid > 0 {
+		paidAt := i.updatedAt
+		if i.paidAt != nil {
+			paidAt = *i.paidAt
+		}
+		if _, err := plan.ApplyPayment(i.AmountPaid(), paidAt); err != nil {
+			return nil, err
+		}
+	}
+	return plan, nil
+}
+
+// IsClosed checks if the invoice is settled: paid in full or voided
+func (i *Invoice) IsClosed() bool {
+	return i.status == InvoicePaid || i.status == InvoiceVoid
+}
+
+// IsOnRecord checks if the invoice was ever issued, so it has to be kept whatever its status
+// Drafts and drafts voided before being issued never reached the client and are not records
+func (i *Invoice) IsOnRecord() bool {
+	return i.issuedAt != nil
+}
+
+// ClosedBefore checks if the invoice is closed and was issued (created, when voided as a draft) before cutoff
+// Retention periods run from the issue date, so this decides when an invoice may leave the hot tables
+func (i *Invoice) ClosedBefore(cutoff time.Time) bool {
+	if !i.IsClosed() {
+		return false
+	}
+	reference := i.createdAt
+	if i.issuedAt != nil {
+		reference = *i.issuedAt
+	}
+	return reference.Before(cutoff)
+}
+
+// MarkRehydrated records that the invoice was restored from the archive at the given time
+func (i *Invoice) MarkRehydrated(at time.Time) {
+	rehydratedAt := at.UTC()
+	i.rehydratedAt = &rehydratedAt
+	i.updatedAt = time.Now().UTC()
+}
+
+// AssignTenant sets the tenant the invoice belongs to
+func (i *Invoice) AssignTenant(tenantID string) {
+	i.tenantID = strings.TrimSpace(tenantID)
+	i.updatedAt = time.Now().UTC()
+}
+
+// Issue finalizes a draft invoice; its line items can no longer change
+// Invoices without a due date become due when their payment terms say, counted from the issue date
+func (i *Invoice) Issue(at time.Time) error {
+	return i.IssueWith(at, InvoiceIssue{})
+}
+
+// IssueWith finalizes a draft invoice recording the legal entity, fiscal period and document requirements settled
+// for it (see Issue)
+// An invoice split into installments must have a balance to split
+func (i *Invoice) IssueWith(at time.Time, issue InvoiceIssue) error {
+	if i.status != InvoiceDraft {
+		return errors.ErrInvoiceNotDraft
+	}
+	issuedAt := at.UTC()
+	dueDate := i.dueDate
+	if dueDate == nil && i.paymentTerms.IsSet() {
+		termsDate := i.paymentTerms.DueDate(issuedAt)
+		dueDate = &termsDate
+	}
+	if i.installments.Count > 0 {
+		total, err := i.Total()
+		if err != nil {
+			return err
+		}
+		firstDueDate := issuedAt
+		if dueDate != nil {
+			firstDueDate = *dueDate
+		}
+		if _, err := NewEvenInstallmentPlan(i.id, total, i.installments.Count, firstDueDate, i.installments.IntervalMonths); err != nil {
+			return err
+		}
+	}
+
+	i.dueDate = dueDate
+	if issue.LegalEntityID != "" {
+		i.legalEntityID = issue.LegalEntityID
+	}
+	i.fiscalYear = issue.FiscalYear
+	i.fiscalPeriod = issue.FiscalPeriod
+	i.taxBreakdown = issue.TaxBreakdown
+	i.legalMentions = append([]string(nil), issue.LegalMentions...)
+	i.status = InvoiceIssued
+	i.issuedAt = &issuedAt
+	i.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// AssignNumber sets the sequential number of an issued invoice; it never changes afterwards
+func (i *Invoice) AssignNumber(number string) error {
+	if i.status != InvoiceIssued {
+		return errors.ErrInvoiceNotIssued
+	}
+	if i.number != "" {
+		return errors.ErrInvoiceAlreadyNumbered
+	}
+	number = strings.TrimSpace(number)
+	if number == "" {
+		return errors.NewValidationError("number", number, errors.ValidationRequired, "invoice number is required")
+	}
+	i.number = number
+	i.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// RecordDunning records the payment reminder of a stage sent for an overdue invoice at the given time
+// level is the 1-based position of the stage in the cadence and must escalate past the last reminder sent
+func (i *Invoice) RecordDunning(level int, stage ReminderStage, at time.Time) error {
+	return i.RecordDunningWithLateFee(level, stage, valueobject.ZeroMoney(i.currency), at)
+}
+
+// RecordDunningWithLateFee records the payment reminder of a stage and charges the late fee sent with it
+// The fee is recorded on the reminder, so it is charged once per stage however often a dunning run is retried
+func (i *Invoice) RecordDunningWithLateFee(level int, stage ReminderStage, lateFee valueobject.Money, at time.Time) error {
+	if lateFee.Currency() != i.currency {
+		return errors.NewValidationError("late_fee", lateFee.Currency(), errors.ValidationFormat, "late fee currency must be the invoice currency")
+	}
+	if lateFee.IsNegative() {
+		return errors.NewValidationError("late_fee", lateFee.Amount(), errors.ValidationRange, "late fee must not be negative")
+	}
+	if _, err := i.LateFees().Add(lateFee); err != nil {
+		return err
+	}
+	if i.status != InvoiceIssued {
+		return errors.ErrInvoiceNotIssued
+	}
+	daysOverdue := i.DaysOverdue(at)
+	if daysOverdue == 0 {
+		return errors.ErrInvoiceNotOverdue
+	}
+	if level <= i.DunningLevel() {
+		return errors.ErrDunningLevelNotEscalated
+	}
+
+	i.dunning = append(i.dunning, DunningEvent{
+		Level:       level,
+		Channel:     stage.Channel(),
+		Template:    stage.Template(),
+		DaysOverdue: daysOverdue,
+		SentAt:      at.UTC(),
+		LateFee:     lateFee.Amount(),
+	})
+	i.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// ApplyPayment reduces the balance of an issued invoice by a payment received at the given time
+// The invoice becomes paid when its balance reaches zero; payments over the balance are rejected
+func (i *Invoice) ApplyPayment(amount valueobject.Money, at time.Time) error {
+	if i.status != InvoiceIssued {
+		return errors.ErrInvoiceNotIssued
+	}
+	if amount.Currency() != i.currency {
+		return errors.NewValidationError("currency", amount.Currency(), errors.ValidationFormat, "payment currency must be the invoice currency")
+	}
+	if !amount.IsPositive() {
+		return errors.NewValidationError("amount", amount.Amount(), errors.ValidationRange, "amount must be positive")
+	}
+
+	balance, err := i.Balance()
+	if err != nil {
+		return err
+	}
+	if amount.Amount() > balance.Amount() {
+		return errors.ErrInvoiceOverpayment
+	}
+
+	i.paid += amount.Amount()
+	if amount.Amount() == balance.Amount() {
+		paidAt := at.UTC()
+		i.status = InvoicePaid
+		i.paidAt = &paidAt
+	}
+	i.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// ReversePayment restores to the balance of an invoice a payment applied to it and since reversed (a card charge
+// refunded before the payment completed); a paid invoice becomes issued again
+func (i *Invoice) ReversePayment(amount valueobject.Money) error {
+	if i.status != InvoiceIssued && i.status != InvoicePaid {
+		return errors.ErrInvoiceNotIssued
+	}
+	if amount.Currency() != i.currency {
+		return errors.NewValidationError("currency", amount.Currency(), errors.ValidationFormat, "payment currency must be the invoice currency")
+	}
+	if !amount.IsPositive() || amount.Amount() > i.paid {
+		return errors.NewValidationError("amount", amount.Amount(), errors.ValidationRange, "amount must be positive and at most the amount paid")
+	}
+
+	i.paid -= amount.Amount()
+	i.status = InvoiceIssued
+	i.paidAt = nil
+	i.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// Void cancels a draft, or an issued invoice without payments; paid invoices are refunded instead
+func (i *Invoice) Void(at time.Time) error {
+	if (i.status != InvoiceDraft && i.status != InvoiceIssued) || i.paid > 0 {
+		return errors.ErrInvoiceNotVoidable
+	}
+	voidedAt := at.UTC()
+	i.status = InvoiceVoid
+	i.voidedAt = &voidedAt
+	i.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// lineAmount returns the total of one line item in a currency
+func lineAmount(line InvoiceLine, currency string) (valueobject.Money, error) {
+	unit, err := valueobject.NewMoney(line.UnitAmount, currency)
+	if err != nil {
+		return valueobject.Money{}, err
+	}
+	return unit.MultiplyRatio(line.Quantity, 1)
+}
+
+// invoiceLineJSON is the persisted form of an InvoiceLine
+type invoiceLineJSON struct {
+	Description string `json:"description"`
+	Quantity    int64  `json:"quantity"`
+	UnitAmount  int64  `json:"unitAmount"`
+	TaxRateBps  int64  `json:"taxRateBps,omitempty"`
+}
+
+// dunningEventJSON is the persisted form of a DunningEvent
+type dunningEventJSON struct {
+	Level       int             `json:"level"`
+	Channel     ReminderChannel `json:"channel"`
+	Template    string          `json:"template"`
+	DaysOverdue int             `json:"daysOverdue"`
+	SentAt      time.Time       `json:"sentAt"`
+	LateFee     int64           `json:"lateFee,omitempty"`
+}
+
+// invoiceInstallmentsJSON is the persisted form of InvoiceInstallments
+type invoiceInstallmentsJSON struct {
+	Count          int `json:"count"`
+	IntervalMonths int `json:"intervalMonths"`
+}
+
+// invoiceJSON is the persisted form of an Invoice
+type invoiceJSON struct {
+	ID              string                   `json:"id"`
+	TenantID        string                   `json:"tenantId,omitempty"`
+	ClientID        string                   `json:"clientId"`
+	Currency        string                   `json:"currency"`
+	Lines           []invoiceLineJSON        `json:"lines"`
+	TaxPricing      valueobject.TaxPricing   `json:"taxPricing,omitempty"`
+	TaxJurisdiction string                   `json:"taxJurisdiction,omitempty"`
+	ExternalRef     string                   `json:"externalRef,omitempty"`
+	LegalEntityID   string                   `json:"legalEntityId,omitempty"`
+	BuyerCountry    string                   `json:"buyerCountry,omitempty"`
+	BuyerTaxID      string                   `json:"buyerTaxId,omitempty"`
+	Installments    *invoiceInstallmentsJSON `json:"installments,omitempty"`
+	Status          InvoiceStatus            `json:"status"`
+	Number          string                   `json:"number,omitempty"`
+	Paid            int64                    `json:"paid,omitempty"`
+	DueDate         *time.Time               `json:"dueDate,omitempty"`
+	PaymentTerms    valueobject.PaymentTerms `json:"paymentTerms,omitempty"`
+	FiscalYear      int                      `json:"fiscalYear,omitempty"`
+	FiscalPeriod    string                   `json:"fiscalPeriod,omitempty"`
+	TaxBreakdown    string                   `json:"taxBreakdown,omitempty"`
+	LegalMentions   []string                 `json:"legalMentions,omitempty"`
+	IssuedAt        *time.Time               `json:"issuedAt,omitempty"`
+	PaidAt          *time.Time               `json:"paidAt,omitempty"`
+	VoidedAt        *time.Time               `json:"voidedAt,omitempty"`
+	RehydratedAt    *time.Time               `json:"rehydratedAt,omitempty"`
+	Dunning         []dunningEventJSON       `json:"dunning,omitempty"`
+	CreatedAt       time.Time                `json:"createdAt"`
+	UpdatedAt       time.Time                `json:"updatedAt"`
+}
+
+// MarshalJSON implements custom JSON marshaling for Invoice
+func (i *Invoice) MarshalJSON() ([]byte, error) {
+	lines := make([]invoiceLineJSON, len(i.lines))
+	for index, line := range i.lines {
+		lines[index] = invoiceLineJSON(line)
+	}
+	var dunning []dunningEventJSON
+	for _, event := range i.dunning {
+		dunning = append(dunning, dunningEventJSON(event))
+	}
+	var installments *invoiceInstallmentsJSON
+	if i.installments.Count > 0 {
+		installments = &invoiceInstallmentsJSON{Count: i.installments.Count, IntervalMonths: i.installments.IntervalMonths}
+	}
+
+	return json.Marshal(invoiceJSON{
+		ID:              i.id,
+		TenantID:        i.tenantID,
+		ClientID:        i.clientID,
+		Currency:        i.currency,
+		Lines:           lines,
+		TaxPricing:      i.taxPricing,
+		TaxJurisdiction: i.taxJurisdiction,
+		ExternalRef:     i.externalRef,
+		LegalEntityID:   i.legalEntityID,
+		BuyerCountry:    i.buyerCountry,
+		BuyerTaxID:      i.buyerTaxID,
+		Installments:    installments,
+		Status:          i.status,
+		Number:          i.number,
+		Paid:            i.paid,
+		DueDate:         i.dueDate,
+		PaymentTerms:    i.paymentTerms,
+		FiscalYear:      i.fiscalYear,
+		FiscalPeriod:    i.fiscalPeriod,
+		TaxBreakdown:    i.taxBreakdown,
+		LegalMentions:   i.legalMentions,
+		IssuedAt:        i.issuedAt,
+		PaidAt:          i.paidAt,
+		VoidedAt:        i.voidedAt,
+		RehydratedAt:    i.rehydratedAt,
+		Dunning:         dunning,
+		CreatedAt:       i.createdAt,
+		UpdatedAt:       i.updatedAt,
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for Invoice
+func (i *Invoice) UnmarshalJSON(data []byte) error {
+	var jsonInvoice invoiceJSON
+	if err := json.Unmarshal(data, &jsonInvoice); err != nil {
+		return err
+	}
+
+	lines := make([]InvoiceLine, len(jsonInvoice.Lines))
+	for index, line := range jsonInvoice.Lines {
+		lines[index] = InvoiceLine(line)
+	}
+	var dunning []DunningEvent
+	for _, event := range jsonInvoice.Dunning {
+		dunning = append(dunning, DunningEvent(event))
+	}
+
+	i.id = jsonInvoice.ID
+	i.tenantID = jsonInvoice.TenantID
+	i.clientID = jsonInvoice.ClientID
+	i.currency = jsonInvoice.Currency
+	i.lines = lines
+	i.taxPricing = jsonInvoice.TaxPricing
+	i.taxJurisdiction = jsonInvoice.TaxJurisdiction
+	i.externalRef = jsonInvoice.ExternalRef
+	i.legalEntityID = jsonInvoice.LegalEntityID
+	i.buyerCountry = jsonInvoice.BuyerCountry
+	i.buyerTaxID = jsonInvoice.BuyerTaxID
+	i.installments = InvoiceInstallments{}
+	if jsonInvoice.Installments != nil {
+		i.installments = InvoiceInstallments{Count: jsonInvoice.Installments.Count, IntervalMonths: jsonInvoice.Installments.IntervalMonths}
+	}
+	i.status = jsonInvoice.Status
+	i.number = jsonInvoice.Number
+	i.paid = jsonInvoice.Paid
+	i.dueDate = jsonInvoice.DueDate
+	i.paymentTerms = jsonInvoice.PaymentTerms
+	i.fiscalYear = jsonInvoice.FiscalYear
+	i.fiscalPeriod = jsonInvoice.FiscalPeriod
+	i.taxBreakdown = jsonInvoice.TaxBreakdown
+	i.legalMentions = jsonInvoice.LegalMentions
+	i.issuedAt = jsonInvoice.IssuedAt
+	i.paidAt = jsonInvoice.PaidAt
+	i.voidedAt = jsonInvoice.VoidedAt
+	i.rehydratedAt = jsonInvoice.RehydratedAt
+	i.dunning = dunning
+	i.createdAt = jsonInvoice.CreatedAt
+	i.updatedAt = jsonInvoice.UpdatedAt
+
+	return nil
+}
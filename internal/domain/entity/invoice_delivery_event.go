@@ -0,0 +1,253 @@
+package entity
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/google/uuid"
+)
+
+// DeliveryEventType is what happened to a delivered invoice
+type DeliveryEventType string
+
+const (
+	DeliverySent      DeliveryEventType = "sent"
+	DeliveryDelivered DeliveryEventType = "delivered"
+	DeliveryViewed    DeliveryEventType = "viewed"
+	DeliveryBounced   DeliveryEventType = "bounced"
+)
+
+// DeliveryChannel is how the client received the invoice
+type DeliveryChannel string
+
+const (
+	DeliveryChannelEmail  DeliveryChannel = "email"
+	DeliveryChannelPortal DeliveryChannel = "portal"
+)
+
+// BounceType distinguishes permanent from temporary delivery failures
+type BounceType string
+
+const (
+	BounceHard BounceType = "hard"
+	BounceSoft BounceType = "soft"
+)
+
+// InvoiceDeliveryStatus is the overall delivery state of an invoice
+type InvoiceDeliveryStatus string
+
+const (
+	InvoiceDeliveryNotSent   InvoiceDeliveryStatus = "not_sent"
+	InvoiceDeliverySent      InvoiceDeliveryStatus = "sent"
+	InvoiceDeliveryDelivered InvoiceDeliveryStatus = "delivered"
+	InvoiceDeliveryBounced   InvoiceDeliveryStatus = "bounced"
+	InvoiceDeliveryViewed    InvoiceDeliveryStatus = "viewed"
+)
+
+// InvoiceDeliveryEvent records one step of an invoice reaching the client (email sent, bounced, viewed...)
+// Events are immutable once recorded
+type InvoiceDeliveryEvent struct {
+	id         string
+	invoiceID  string
+	eventType  DeliveryEventType
+	channel    DeliveryChannel
+	recipient  string
+	bounceType BounceType
+	detail     string
+	occurredAt time.Time
+	recordedAt time.Time
+}
+
+// NewInvoiceDeliveryEvent creates a delivery event with validation
+// Bounces must say whether they are hard or soft; occurredAt defaults to now
+func NewInvoiceDeliveryEvent(invoiceID string, eventType DeliveryEventType, channel DeliveryChannel, recipient string, bounceType BounceType, detail string, occurredAt time.Time) (*InvoiceDeliveryEvent, error) {
+	invoiceID = strings.TrimSpace(invoiceID)
+	recipient = strings.TrimSpace(recipient)
+	detail = strings.TrimSpace(detail)
+
+	if invoiceID == "" {
+		return nil, errors.NewValidationError("invoice_id", invoiceID, errors.ValidationRequired, "invoice ID is required")
+	}
+
+	switch eventType {
+	case DeliverySent, DeliveryDelivered, DeliveryViewed, DeliveryBounced:
+	default:
+		return nil, errors.NewValidationError("type", eventType, errors.ValidationFormat, "type must be one of: sent, delivered, viewed, bounced")
+	}
+
+	if channel == "" {
+		channel = DeliveryChannelEmail
+	}
+	if channel != DeliveryChannelEmail && channel != DeliveryChannelPortal {
+		return nil, errors.NewValidationError("channel", channel, errors.ValidationFormat, "channel must be one of: email, portal")
+	}
+	if channel == DeliveryChannelPortal && eventType != DeliveryViewed {
+		return nil, errors.NewValidationError("channel", channel, errors.ValidationFormat, "portal deliveries can only be viewed")
+	}
+
+	if eventType == DeliveryBounced {
+		if bounceType != BounceHard && bounceType != BounceSoft {
+			return nil, errors.NewValidationError("bounce_type", bounceType, errors.ValidationFormat, "bounce type must be one of: hard, soft")
+		}
+	} else if bounceType != "" {
+		return nil, errors.NewValidationError("bounce_type", bounceType, errors.ValidationFormat, "bounce type only applies to bounced events")
+	}
+
+	if len(recipient) > 254 {
+		return nil, errors.NewValidationError("recipient", recipient, errors.ValidationLength, "recipient must be at most 254 characters")
+	}
+	if len(detail) > 1000 {
+		return nil, errors.NewValidationError("detail", detail, errors.ValidationLength, "detail must be at most 1000 characters")
+	}
+
+	now := time.Now().UTC()
+	if occurredAt.IsZero() {
+		occurredAt = now
+	}
+
+	return &InvoiceDeliveryEvent{
+		id:         uuid.New().String(),
+		invoiceID:  invoiceID,
+		eventType:  eventType,
+		channel:    channel,
+		recipient:  recipient,
+		bounceType: bounceType,
+		detail:     detail,
+		occurredAt: occurredAt.UTC(),
+		recordedAt: now,
+	}, nil
+}
+
+// Getters
+func (e *InvoiceDeliveryEvent) ID() string {
+	return e.id
+}
+
+func (e *InvoiceDeliveryEvent) InvoiceID() string {
+	return e.invoiceID
+}
+
+func (e *InvoiceDeliveryEvent) Type() DeliveryEventType {
+	return e.eventType
+}
+
+func (e *InvoiceDeliveryEvent) Channel() DeliveryChannel {
+	return e.channel
+}
+
+func (e *InvoiceDeliveryEvent) Recipient() string {
+	return e.recipient
+}
+
+// BounceType returns the kind of bounce (empty unless the event is a bounce)
+func (e *InvoiceDeliveryEvent) BounceType() BounceType {
+	return e.bounceType
+}
+
+func (e *InvoiceDeliveryEvent) Detail() string {
+	return e.detail
+}
+
+func (e *InvoiceDeliveryEvent) OccurredAt() time.Time {
+	return e.occurredAt
+}
+
+func (e *InvoiceDeliveryEvent) RecordedAt() time.Time {
+	return e.recordedAt
+}
+
+// InvoiceDeliverySummary is the delivery state of an invoice derived from its events
+type InvoiceDeliverySummary struct {
+	Status       InvoiceDeliveryStatus
+	LastSentAt   *time.Time
+	FirstViewAt  *time.Time
+	LastViewAt   *time.Time
+	ViewCount    int
+	BounceCount  int
+	LastBounceAt *time.Time
+}
+
+// SummarizeInvoiceDelivery derives the delivery state from events ordered by occurrence
+// A view proves the client received the invoice; otherwise the latest email outcome wins,
+// so a successful resend after a bounce reports the invoice as sent again
+func SummarizeInvoiceDelivery(events []*InvoiceDeliveryEvent) InvoiceDeliverySummary {
+	summary := InvoiceDeliverySummary{Status: InvoiceDeliveryNotSent}
+	emailStatus := InvoiceDeliveryNotSent
+
+	for _, event := range events {
+		occurredAt := event.OccurredAt()
+		switch event.Type() {
+		case DeliverySent:
+			summary.LastSentAt = &occurredAt
+			emailStatus = InvoiceDeliverySent
+		case DeliveryDelivered:
+			emailStatus = InvoiceDeliveryDelivered
+		case DeliveryBounced:
+			summary.BounceCount++
+			summary.LastBounceAt = &occurredAt
+			emailStatus = InvoiceDeliveryBounced
+		case DeliveryViewed:
+			summary.ViewCount++
+			if summary.FirstViewAt == nil {
+				summary.FirstViewAt = &occurredAt
+			}
+			summary.LastViewAt = &occurredAt
+		}
+	}
+
+	summary.Status = emailStatus
+	if summary.ViewCount > 0 {
+		summary.Status = InvoiceDeliveryViewed
+	}
+	return summary
+}
+
+// invoiceDeliveryEventJSON is the persisted form of an InvoiceDeliveryEvent
+type invoiceDeliveryEventJSON struct {
+	ID         string            `json:"id"`
+	InvoiceID  string            `json:"invoiceId"`
+	Type       DeliveryEventType `json:"type"`
+	Channel    DeliveryChannel   `json:"channel"`
+	Recipient  string            `json:"recipient,omitempty"`
+	BounceType BounceType        `json:"bounceType,omitempty"`
+	Detail     string            `json:"detail,omitempty"`
+	OccurredAt time.Time         `json:"occurredAt"`
+	RecordedAt time.Time         `json:"recordedAt"`
+}
+
+// MarshalJSON implements custom JSON marshaling for InvoiceDeliveryEvent
+func (e *InvoiceDeliveryEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(invoiceDeliveryEventJSON{
+		ID:         e.id,
+		InvoiceID:  e.invoiceID,
+		Type:       e.eventType,
+		Channel:    e.channel,
+		Recipient:  e.recipient,
+		BounceType: e.bounceType,
+		Detail:     e.detail,
+		OccurredAt: e.occurredAt,
+		RecordedAt: e.recordedAt,
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for InvoiceDeliveryEvent
+func (e *InvoiceDeliveryEvent) UnmarshalJSON(data []byte) error {
+	var jsonEvent invoiceDeliveryEventJSON
+	if err := json.Unmarshal(data, &jsonEvent); err != nil {
+		return err
+	}
+
+	e.id = jsonEvent.ID
+	e.invoiceID = jsonEvent.InvoiceID
+	e.eventType = jsonEvent.Type
+	e.channel = jsonEvent.Channel
+	e.recipient = jsonEvent.Recipient
+	e.bounceType = jsonEvent.BounceType
+	e.detail = jsonEvent.Detail
+	e.occurredAt = jsonEvent.OccurredAt
+	e.recordedAt = jsonEvent.RecordedAt
+
+	return nil
+}
@@ -0,0 +1,217 @@
+package entity
+
+import (
+	"encoding/json"
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+)
+
+// IPAccessRule restricts source addresses for requests under a route prefix
+type IPAccessRule struct {
+	routePrefix string
+	allow       []netip.Prefix
+	deny        []netip.Prefix
+}
+
+// NewIPAccessRule creates a rule from CIDR (or single IP) strings
+// An empty route prefix applies the rule to every route
+func NewIPAccessRule(routePrefix string, allow, deny []string) (IPAccessRule, error) {
+	routePrefix = strings.TrimSpace(routePrefix)
+	if routePrefix != "" && !strings.HasPrefix(routePrefix, "/") {
+		return IPAccessRule{}, errors.NewValidationError("route_prefix", routePrefix, errors.ValidationFormat, "route prefix must start with /")
+	}
+
+	allowPrefixes, err := parseCIDRList("allow", allow)
+	if err != nil {
+		return IPAccessRule{}, err
+	}
+
+	denyPrefixes, err := parseCIDRList("deny", deny)
+	if err != nil {
+		return IPAccessRule{}, err
+	}
+
+	if len(allowPrefixes) == 0 && len(denyPrefixes) == 0 {
+		return IPAccessRule{}, errors.NewValidationError("rules", routePrefix, errors.ValidationRequired, "rule must define at least one allow or deny entry")
+	}
+
+	return IPAccessRule{
+		routePrefix: routePrefix,
+		allow:       allowPrefixes,
+		deny:        denyPrefixes,
+	}, nil
+}
+
+// parseCIDRList parses CIDR blocks, accepting bare IPs as single-address blocks
+func parseCIDRList(field string, values []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+
+		if prefix, err := netip.ParsePrefix(value); err == nil {
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+
+		addr, err := netip.ParseAddr(value)
+		if err != nil {
+			return nil, errors.NewValidationError(field, value, errors.ValidationFormat, field+" entries must be valid IP addresses or CIDR blocks")
+		}
+		prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return prefixes, nil
+}
+
+// RoutePrefix returns the route prefix the rule applies to
+func (r IPAccessRule) RoutePrefix() string {
+	return r.routePrefix
+}
+
+// Allow returns the allowed CIDR blocks as strings
+func (r IPAccessRule) Allow() []string {
+	return prefixStrings(r.allow)
+}
+
+// Deny returns the denied CIDR blocks as strings
+func (r IPAccessRule) Deny() []string {
+	return prefixStrings(r.deny)
+}
+
+// AppliesTo checks if the rule covers the given request path
+func (r IPAccessRule) AppliesTo(path string) bool {
+	return r.routePrefix == "" || strings.HasPrefix(path, r.routePrefix)
+}
+
+// IPAccessPolicy is the set of source IP rules configured for a tenant
+type IPAccessPolicy struct {
+	tenantID  string
+	rules     []IPAccessRule
+	updatedAt time.Time
+}
+
+// NewIPAccessPolicy creates a tenant IP access policy
+func NewIPAccessPolicy(tenantID string, rules []IPAccessRule) (*IPAccessPolicy, error) {
+	tenantID = strings.TrimSpace(tenantID)
+	if tenantID == "" {
+		return nil, errors.NewValidationError("tenant_id", tenantID, errors.ValidationRequired, "tenant ID is required")
+	}
+
+	return &IPAccessPolicy{
+		tenantID:  tenantID,
+		rules:     rules,
+		updatedAt: time.Now().UTC(),
+	}, nil
+}
+
+// Permits evaluates the policy for a request path and source address
+// Deny entries always win; when any applicable rule has allow entries the address must match one of them
+func (p *IPAccessPolicy) Permits(path string, addr netip.Addr) bool {
+	addr = addr.Unmap()
+	allowListed := false
+	matchedAllow := false
+
+	for _, rule := range p.rules {
+		if !rule.AppliesTo(path) {
+			continue
+		}
+
+		for _, prefix := range rule.deny {
+			if prefix.Contains(addr) {
+				return false
+			}
+		}
+
+		if len(rule.allow) > 0 {
+			allowListed = true
+			for _, prefix := range rule.allow {
+				if prefix.Contains(addr) {
+					matchedAllow = true
+				}
+			}
+		}
+	}
+
+	return !allowListed || matchedAllow
+}
+
+// Getters
+func (p *IPAccessPolicy) TenantID() string {
+	return p.tenantID
+}
+
+func (p *IPAccessPolicy) Rules() []IPAccessRule {
+	return p.rules
+}
+
+func (p *IPAccessPolicy) UpdatedAt() time.Time {
+	return p.updatedAt
+}
+
+// ipAccessRuleJSON is the persisted form of an IPAccessRule
+type ipAccessRuleJSON struct {
+	RoutePrefix string   `json:"routePrefix"`
+	Allow       []string `json:"allow"`
+	Deny        []string `json:"deny"`
+}
+
+// MarshalJSON implements custom JSON marshaling for IPAccessPolicy
+func (p *IPAccessPolicy) MarshalJSON() ([]byte, error) {
+	rules := make([]ipAccessRuleJSON, 0, len(p.rules))
+	for _, rule := range p.rules {
+		rules = append(rules, ipAccessRuleJSON{
+			RoutePrefix: rule.routePrefix,
+			Allow:       rule.Allow(),
+			Deny:        rule.Deny(),
+		})
+	}
+
+	return json.Marshal(struct {
+		TenantID  string             `json:"tenantId"`
+		Rules     []ipAccessRuleJSON `json:"rules"`
+		UpdatedAt time.Time          `json:"updatedAt"`
+	}{
+		TenantID:  p.tenantID,
+		Rules:     rules,
+		UpdatedAt: p.updatedAt,
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for IPAccessPolicy
+func (p *IPAccessPolicy) UnmarshalJSON(data []byte) error {
+	var jsonPolicy struct {
+		TenantID  string             `json:"tenantId"`
+		Rules     []ipAccessRuleJSON `json:"rules"`
+		UpdatedAt time.Time          `json:"updatedAt"`
+	}
+
+	if err := json.Unmarshal(data, &jsonPolicy); err != nil {
+		return err
+	}
+
+	rules := make([]IPAccessRule, 0, len(jsonPolicy.Rules))
+	for _, stored := range jsonPolicy.Rules {
+		rule, err := NewIPAccessRule(stored.RoutePrefix, stored.Allow, stored.Deny)
+		if err != nil {
+			return err
+		}
+		rules = append(rules, rule)
+	}
+
+	p.tenantID = jsonPolicy.TenantID
+	p.rules = rules
+	p.updatedAt = jsonPolicy.UpdatedAt
+
+	return nil
+}
+
+// prefixStrings converts prefixes to their string form
+func prefixStrings(prefixes []netip.Prefix) []string {
+	values := make([]string, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		values = append(values, prefix.String())
+	}
+	return values
+}
@@ -0,0 +1,331 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultInvoiceNumberPadding is the minimum number of digits of an invoice sequence number
+	DefaultInvoiceNumberPadding = 6
+
+	// DefaultDocumentTemplate is the invoice layout used by entities without their own
+	DefaultDocumentTemplate = "default"
+
+	// maxTaxRegistrations bounds the tax registrations of a legal entity
+	maxTaxRegistrations = 10
+)
+
+// TaxRegistration is a tax identifier a legal entity is registered under in a country
+type TaxRegistration struct {
+	Country string // ISO 3166-1 alpha-2 code
+	Number  string // VAT, GST or sales tax number as printed on invoices
+}
+
+// InvoiceNumbering defines how a legal entity numbers its invoices: "<prefix>[<year>-]<sequence>"
+type InvoiceNumbering struct {
+	Prefix      string // Distinguishes the entity's invoices from those of other entities
+	Padding     int    // Minimum digits of the sequence number, zero padded
+	YearlyReset bool   // Include the issue year and restart the sequence each year
+}
+
+// LegalEntity is a company of the deployment invoices are issued from, with its own
+// numbering sequence, bank details, tax registrations and invoice layout
+type LegalEntity struct {
+	id               string
+	name             string
+	address          string
+	country          string
+	taxRegistrations []TaxRegistration
+	bankAccount      valueobject.BankAccount
+	numbering        InvoiceNumbering
+	documentTemplate string
+	isDefault        bool  // Issues the invoices not assigned to an entity
+	issuedNumbers    int64 // Invoices numbered from the entity's sequence over its lifetime
+	createdAt        time.Time
+	updatedAt        time.Time
+}
+
+// NewLegalEntity creates a legal entity with validation
+func NewLegalEntity(name, address, country string, taxRegistrations []TaxRegistration, bankAccount valueobject.BankAccount, numbering InvoiceNumbering, documentTemplate string) (*LegalEntity, error) {
+	now := time.Now().UTC()
+	legalEntity := &LegalEntity{
+		id:        uuid.New().String(),
+		createdAt: now,
+		updatedAt: now,
+	}
+	if err := legalEntity.apply(name, address, country, taxRegistrations, bankAccount, numbering, documentTemplate); err != nil {
+		return nil, err
+	}
+	return legalEntity, nil
+}
+
+// Update replaces the details of the entity
+// The numbering sequence carries on, so numbers already issued are never reused
+func (e *LegalEntity) Update(name, address, country string, taxRegistrations []TaxRegistration, bankAccount valueobject.BankAccount, numbering InvoiceNumbering, documentTemplate string) error {
+	// Switching between yearly and continuous numbering could produce numbers issued before
+	if e.issuedNumbers > 0 && numbering.YearlyReset != e.numbering.YearlyReset {
+		return errors.NewValidationError("numbering.yearly_reset", numbering.YearlyReset, errors.ValidationFormat, "yearly reset cannot be changed once invoice numbers were issued")
+	}
+	if err := e.apply(name, address, country, taxRegistrations, bankAccount, numbering, documentTemplate); err != nil {
+		return err
+	}
+	e.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// apply validates and sets the editable details
+func (e *LegalEntity) apply(name, address, country string, taxRegistrations []TaxRegistration, bankAccount valueobject.BankAccount, numbering InvoiceNumbering, documentTemplate string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.NewValidationError("name", name, errors.ValidationRequired, "legal name is required")
+	}
+	if len(name) > 200 {
+		return errors.NewValidationError("name", name, errors.ValidationLength, "legal name must be at most 200 characters")
+	}
+
+	address = strings.TrimSpace(address)
+	if len(address) > 500 {
+		return errors.NewValidationError("address", address, errors.ValidationLength, "address must be at most 500 characters")
+	}
+
+	country = strings.ToUpper(strings.TrimSpace(country))
+	if !isCountryCode(country) {
+		return errors.NewValidationError("country", country, errors.ValidationFormat, "country must be an ISO 3166-1 alpha-2 code")
+	}
+
+	if len(taxRegistrations) > maxTaxRegistrations {
+		return errors.NewValidationError("tax_registrations", len(taxRegistrations), errors.ValidationRange, fmt.Sprintf("at most %d tax registrations are allowed", maxTaxRegistrations))
+	}
+	registrations := make([]TaxRegistration, len(taxRegistrations))
+	for index, registration := range taxRegistrations {
+		field := fmt.Sprintf("tax_registrations[%d]", index)
+		registration.Country = strings.ToUpper(strings.TrimSpace(registration.Country))
+		if !isCountryCode(registration.Country) {
+			return errors.NewValidationError(field+".country", registration.Country, errors.ValidationFormat, "country must be an ISO 3166-1 alpha-2 code")
+		}
+		registration.Number = strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(registration.Number), " ", ""))
+		if registration.Number == "" {
+			return errors.NewValidationError(field+".number", registration.Number, errors.ValidationRequired, "tax registration number is required")
+		}
+		if len(registration.Number) > 30 {
+			return errors.NewValidationError(field+".number", registration.Number, errors.ValidationLength, "tax registration number must be at most 30 characters")
+		}
+		registrations[index] = registration
+	}
+
+	numbering.Prefix = strings.TrimSpace(numbering.Prefix)
+	if numbering.Prefix == "" {
+		return errors.NewValidationError("numbering.prefix", numbering.Prefix, errors.ValidationRequired, "invoice number prefix is required")
+	}
+	if len(numbering.Prefix) > 20 || strings.IndexFunc(numbering.Prefix, func(r rune) bool {
+		return !(r >= 'A' && r <= 'Z' || r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '/' || r == '.')
+	}) != -1 {
+		return errors.NewValidationError("numbering.prefix", numbering.Prefix, errors.ValidationFormat, "invoice number prefix must be at most 20 letters, digits or - _ / . characters")
+	}
+	if numbering.Padding == 0 {
+		numbering.Padding = DefaultInvoiceNumberPadding
+	}
+	if numbering.Padding < 1 || numbering.Padding > 12 {
+		return errors.NewValidationError("numbering.padding", numbering.Padding, errors.ValidationRange, "invoice number padding must be between 1 and 12")
+	}
+
+	documentTemplate = strings.TrimSpace(documentTemplate)
+	if documentTemplate == "" {
+		documentTemplate = DefaultDocumentTemplate
+	}
+	if len(documentTemplate) > 100 {
+		return errors.NewValidationError("document_template", documentTemplate, errors.ValidationLength, "document template must be at most 100 characters")
+	}
+
+	e.name = name
+	e.address = address
+	e.country = country
+	e.taxRegistrations = registrations
+	e.bankAccount = bankAccount
+	e.numbering = numbering
+	e.documentTemplate = documentTemplate
+	return nil
+}
+
+// Getters
+func (e *LegalEntity) ID() string {
+	return e.id
+}
+
+func (e *LegalEntity) Name() string {
+	return e.name
+}
+
+func (e *LegalEntity) Address() string {
+	return e.address
+}
+
+func (e *LegalEntity) Country() string {
+	return e.country
+}
+
+// TaxRegistrations returns a copy of the tax registrations
+func (e *LegalEntity) TaxRegistrations() []TaxRegistration {
+	return append([]TaxRegistration(nil), e.taxRegistrations...)
+}
+
+// BankAccount returns the bank details printed on invoices (empty when not set)
+func (e *LegalEntity) BankAccount() valueobject.BankAccount {
+	return e.bankAccount
+}
+
+func (e *LegalEntity) Numbering() InvoiceNumbering {
+	return e.numbering
+}
+
+// DocumentTemplate returns the name of the invoice layout the entity's invoices are rendered with
+func (e *LegalEntity) DocumentTemplate() string {
+	return e.documentTemplate
+}
+
+func (e *LegalEntity) IsDefault() bool {
+	return e.isDefault
+}
+
+// HasIssuedInvoices reports whether invoices were numbered from the entity's sequence
+func (e *LegalEntity) HasIssuedInvoices() bool {
+	return e.issuedNumbers > 0
+}
+
+func (e *LegalEntity) CreatedAt() time.Time {
+	return e.createdAt
+}
+
+func (e *LegalEntity) UpdatedAt() time.Time {
+	return e.updatedAt
+}
+
+// SetDefault makes the entity issue (or stop issuing) the invoices not assigned to an entity
+func (e *LegalEntity) SetDefault(isDefault bool) {
+	if e.isDefault != isDefault {
+		e.isDefault = isDefault
+		e.updatedAt = time.Now().UTC()
+	}
+}
+
+// NumberingScope returns the gap-free counter the entity numbers invoices of a (fiscal) year from
+// With yearly reset, every year has its own counter, so the sequence restarts at 1 in a new year
+func (e *LegalEntity) NumberingScope(year int) string {
+	if e.numbering.YearlyReset {
+		return fmt.Sprintf("entity:%s:%d", e.id, year)
+	}
+	return "entity:" + e.id
+}
+
+// FormatInvoiceNumber returns the invoice number of a sequence number allocated from NumberingScope(year)
+func (e *LegalEntity) FormatInvoiceNumber(year int, sequence int64) string {
+	if e.numbering.YearlyReset {
+		return fmt.Sprintf("%s%d-%0*d", e.numbering.Prefix, year, e.numbering.Padding, sequence)
+	}
+	return fmt.Sprintf("%s%0*d", e.numbering.Prefix, e.numbering.Padding, sequence)
+}
+
+// RecordNumbering records that an invoice is being numbered from the entity's sequence, which fixes its yearly reset
+// It is recorded before the number is allocated, so a failed issue may count an invoice that was not numbered
+func (e *LegalEntity) RecordNumbering() {
+	e.issuedNumbers++
+	e.updatedAt = time.Now().UTC()
+}
+
+// isCountryCode checks for an upper case ISO 3166-1 alpha-2 shaped code
+func isCountryCode(code string) bool {
+	return len(code) == 2 && code[0] >= 'A' && code[0] <= 'Z' && code[1] >= 'A' && code[1] <= 'Z'
+}
+
+// taxRegistrationJSON is the persisted form of a TaxRegistration
+type taxRegistrationJSON struct {
+	Country string `json:"country"`
+	Number  string `json:"number"`
+}
+
+// invoiceNumberingJSON is the persisted form of an InvoiceNumbering
+type invoiceNumberingJSON struct {
+	Prefix      string `json:"prefix"`
+	Padding     int    `json:"padding"`
+	YearlyReset bool   `json:"yearlyReset"`
+}
+
+// legalEntityJSON is the persisted form of a LegalEntity
+type legalEntityJSON struct {
+	ID               string                   `json:"id"`
+	Name             string                   `json:"name"`
+	Address          string                   `json:"address,omitempty"`
+	Country          string                   `json:"country"`
+	TaxRegistrations []taxRegistrationJSON    `json:"taxRegistrations"`
+	BankAccount      *valueobject.BankAccount `json:"bankAccount,omitempty"`
+	Numbering        invoiceNumberingJSON     `json:"numbering"`
+	DocumentTemplate string                   `json:"documentTemplate"`
+	IsDefault        bool                     `json:"isDefault"`
+	IssuedNumbers    int64                    `json:"issuedNumbers"`
+	CreatedAt        time.Time                `json:"createdAt"`
+	UpdatedAt        time.Time                `json:"updatedAt"`
+}
+
+// MarshalJSON implements custom JSON marshaling for LegalEntity
+func (e *LegalEntity) MarshalJSON() ([]byte, error) {
+	registrations := make([]taxRegistrationJSON, len(e.taxRegistrations))
+	for index, registration := range e.taxRegistrations {
+		registrations[index] = taxRegistrationJSON(registration)
+	}
+
+	var bankAccount *valueobject.BankAccount
+	if !e.bankAccount.IsEmpty() {
+		bankAccount = &e.bankAccount
+	}
+
+	return json.Marshal(legalEntityJSON{
+		ID:               e.id,
+		Name:             e.name,
+		Address:          e.address,
+		Country:          e.country,
+		TaxRegistrations: registrations,
+		BankAccount:      bankAccount,
+		Numbering:        invoiceNumberingJSON(e.numbering),
+		DocumentTemplate: e.documentTemplate,
+		IsDefault:        e.isDefault,
+		IssuedNumbers:    e.issuedNumbers,
+		CreatedAt:        e.createdAt,
+		UpdatedAt:        e.updatedAt,
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for LegalEntity
+func (e *LegalEntity) UnmarshalJSON(data []byte) error {
+	var jsonEntity legalEntityJSON
+	if err := json.Unmarshal(data, &jsonEntity); err != nil {
+		return err
+	}
+
+	e.id = jsonEntity.ID
+	e.name = jsonEntity.Name
+	e.address = jsonEntity.Address
+	e.country = jsonEntity.Country
+	e.taxRegistrations = make([]TaxRegistration, len(jsonEntity.TaxRegistrations))
+	for index, registration := range jsonEntity.TaxRegistrations {
+		e.taxRegistrations[index] = TaxRegistration(registration)
+	}
+	e.bankAccount = valueobject.BankAccount{}
+	if jsonEntity.BankAccount != nil {
+		e.bankAccount = *jsonEntity.BankAccount
+	}
+	e.numbering = InvoiceNumbering(jsonEntity.Numbering)
+	e.documentTemplate = jsonEntity.DocumentTemplate
+	e.isDefault = jsonEntity.IsDefault
+	e.issuedNumbers = jsonEntity.IssuedNumbers
+	e.createdAt = jsonEntity.CreatedAt
+	e.updatedAt = jsonEntity.UpdatedAt
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package entity
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"time"
+)
+
+// MagicLink is a single-use, expiring link granting one action (e.g. portal sign-in) to a subject
+type MagicLink struct {
+	id         string
+	purpose    string
+	subject    string
+	issuedAt   time.Time
+	expiresAt  time.Time
+	consumedAt *time.Time
+}
+
+// NewMagicLink issues a link with a random ID for purpose and subject, valid for ttl
+func NewMagicLink(purpose, subject string, ttl time.Duration) (*MagicLink, error) {
+	buf := make([]byte, 18)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	return &MagicLink{
+		id:        base64.RawURLEncoding.EncodeToString(buf),
+		purpose:   purpose,
+		subject:   subject,
+		issuedAt:  now,
+		expiresAt: now.Add(ttl).Truncate(time.Second),
+	}, nil
+}
+
+// Getters
+func (l *MagicLink) ID() string {
+	return l.id
+}
+
+func (l *MagicLink) Purpose() string {
+	return l.purpose
+}
+
+func (l *MagicLink) Subject() string {
+	return l.subject
+}
+
+func (l *MagicLink) IssuedAt() time.Time {
+	return l.issuedAt
+}
+
+func (l *MagicLink) ExpiresAt() time.Time {
+	return l.expiresAt
+}
+
+func (l *MagicLink) ConsumedAt() *time.Time {
+	return l.consumedAt
+}
+
+// IsExpired checks if the link is past its expiry at the given time
+func (l *MagicLink) IsExpired(now time.Time) bool {
+	return !now.Before(l.expiresAt)
+}
+
+// IsConsumed checks if the link has already been used
+func (l *MagicLink) IsConsumed() bool {
+	return l.consumedAt != nil
+}
+
+// MarkConsumed records that the link was used at the given time
+func (l *MagicLink) MarkConsumed(at time.Time) {
+	consumedAt := at.UTC()
+	l.consumedAt = &consumedAt
+}
+
+// magicLinkJSON is the persisted form of a MagicLink
+type magicLinkJSON struct {
+	ID         string     `json:"id"`
+	Purpose    string     `json:"purpose"`
+	Subject    string     `json:"subject"`
+	IssuedAt   time.Time  `json:"issuedAt"`
+	ExpiresAt  time.Time  `json:"expiresAt"`
+	ConsumedAt *time.Time `json:"consumedAt,omitempty"`
+}
+
+// MarshalJSON implements custom JSON marshaling for MagicLink
+func (l *MagicLink) MarshalJSON() ([]byte, error) {
+	return json.Marshal(magicLinkJSON{
+		ID:         l.id,
+		Purpose:    l.purpose,
+		Subject:    l.subject,
+		IssuedAt:   l.issuedAt,
+		ExpiresAt:  l.expiresAt,
+		ConsumedAt: l.consumedAt,
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for MagicLink
+func (l *MagicLink) UnmarshalJSON(data []byte) error {
+	var jsonLink magicLinkJSON
+	if err := json.Unmarshal(data, &jsonLink); err != nil {
+		return err
+	}
+
+	l.id = jsonLink.ID
+	l.purpose = jsonLink.Purpose
+	l.subject = jsonLink.Subject
+	l.issuedAt = jsonLink.IssuedAt
+	l.expiresAt = jsonLink.ExpiresAt
+	l.consumedAt = jsonLink.ConsumedAt
+
+	return nil
+}
@@ -0,0 +1,226 @@
+package entity
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/google/uuid"
+)
+
+// OutboundEmailStatus is the delivery state of an email in the outbox
+type OutboundEmailStatus string
+
+const (
+	OutboundEmailPending    OutboundEmailStatus = "pending"    // Waiting for its first or next dispatch attempt
+	OutboundEmailSent       OutboundEmailStatus = "sent"       // Handed to the mailer
+	OutboundEmailFailed     OutboundEmailStatus = "failed"     // Attempts exhausted; retried only by an admin
+	OutboundEmailSuppressed OutboundEmailStatus = "suppressed" // Not sent, the recipient is on the suppression list
+)
+
+// OutboundEmail is a notification message persisted in the outbox until the mailer accepts it
+// Workers dispatch pending emails when their next attempt is due and back off between failed attempts
+type OutboundEmail struct {
+	id            string
+	topic         string
+	key           string
+	payload       json.RawMessage
+	headers       map[string]string
+	recipient     string // Address the email goes to (empty when it could not be resolved)
+	status        OutboundEmailStatus
+	attempts      int
+	lastError     string
+	nextAttemptAt time.Time
+	sentAt        *time.Time
+	createdAt     time.Time
+	updatedAt     time.Time
+}
+
+// NewOutboundEmail creates a pending email due immediately
+func NewOutboundEmail(topic, key string, payload []byte, headers map[string]string, recipient string, now time.Time) (*OutboundEmail, error) {
+	topic = strings.TrimSpace(topic)
+	if topic == "" {
+		return nil, errors.NewValidationError("topic", topic, errors.ValidationRequired, "topic is required")
+	}
+	if now.IsZero() {
+		return nil, errors.NewValidationError("created_at", now, errors.ValidationRequired, "creation time is required")
+	}
+
+	copied := make(map[string]string, len(headers))
+	for name, value := range headers {
+		copied[name] = value
+	}
+
+	return &OutboundEmail{
+		id:            uuid.New().String(),
+		topic:         topic,
+		key:           key,
+		payload:       append(json.RawMessage(nil), payload...),
+		headers:       copied,
+		recipient:     strings.ToLower(strings.TrimSpace(recipient)),
+		status:        OutboundEmailPending,
+		nextAttemptAt: now.UTC(),
+		createdAt:     now.UTC(),
+		updatedAt:     now.UTC(),
+	}, nil
+}
+
+// Getters
+func (e *OutboundEmail) ID() string {
+	return e.id
+}
+
+func (e *OutboundEmail) Topic() string {
+	return e.topic
+}
+
+func (e *OutboundEmail) Key() string {
+	return e.key
+}
+
+func (e *OutboundEmail) Payload() json.RawMessage {
+	return e.payload
+}
+
+func (e *OutboundEmail) Headers() map[string]string {
+	return e.headers
+}
+
+func (e *OutboundEmail) Recipient() string {
+	return e.recipient
+}
+
+func (e *OutboundEmail) Status() OutboundEmailStatus {
+	return e.status
+}
+
+// Attempts returns the number of dispatch attempts made so far
+func (e *OutboundEmail) Attempts() int {
+	return e.attempts
+}
+
+// LastError returns the error of the last failed attempt
+func (e *OutboundEmail) LastError() string {
+	return e.lastError
+}
+
+func (e *OutboundEmail) NextAttemptAt() time.Time {
+	return e.nextAttemptAt
+}
+
+func (e *OutboundEmail) SentAt() *time.Time {
+	return e.sentAt
+}
+
+func (e *OutboundEmail) CreatedAt() time.Time {
+	return e.createdAt
+}
+
+func (e *OutboundEmail) UpdatedAt() time.Time {
+	return e.updatedAt
+}
+
+// IsDue checks if a pending email should be dispatched at the given time
+func (e *OutboundEmail) IsDue(now time.Time) bool {
+	return e.status == OutboundEmailPending && !now.Before(e.nextAttemptAt)
+}
+
+// MarkSent records the attempt the mailer accepted the email on
+func (e *OutboundEmail) MarkSent(at time.Time) {
+	sentAt := at.UTC()
+	e.attempts++
+	e.status = OutboundEmailSent
+	e.lastError = ""
+	e.sentAt = &sentAt
+	e.updatedAt = at.UTC()
+}
+
+// RecordFailure records a failed attempt; the email is retried at retryAt, or fails for good when it is nil
+func (e *OutboundEmail) RecordFailure(reason string, at time.Time, retryAt *time.Time) {
+	e.attempts++
+	e.lastError = reason
+	if retryAt == nil {
+		e.status = OutboundEmailFailed
+	} else {
+		e.nextAttemptAt = retryAt.UTC()
+	}
+	e.updatedAt = at.UTC()
+}
+
+// MarkSuppressed records that the email was dropped because its recipient is suppressed
+func (e *OutboundEmail) MarkSuppressed(at time.Time) {
+	e.status = OutboundEmailSuppressed
+	e.updatedAt = at.UTC()
+}
+
+// Requeue makes a failed or suppressed email pending again, due immediately with a fresh attempt budget
+func (e *OutboundEmail) Requeue(now time.Time) error {
+	if e.status != OutboundEmailFailed && e.status != OutboundEmailSuppressed {
+		return errors.ErrOutboundEmailNotRetryable
+	}
+	e.status = OutboundEmailPending
+	e.attempts = 0
+	e.nextAttemptAt = now.UTC()
+	e.updatedAt = now.UTC()
+	return nil
+}
+
+// outboundEmailJSON is the persisted form of an OutboundEmail
+type outboundEmailJSON struct {
+	ID            string              `json:"id"`
+	Topic         string              `json:"topic"`
+	Key           string              `json:"key,omitempty"`
+	Payload       json.RawMessage     `json:"payload,omitempty"`
+	Headers       map[string]string   `json:"headers,omitempty"`
+	Recipient     string              `json:"recipient,omitempty"`
+	Status        OutboundEmailStatus `json:"status"`
+	Attempts      int                 `json:"attempts"`
+	LastError     string              `json:"lastError,omitempty"`
+	NextAttemptAt time.Time           `json:"nextAttemptAt"`
+	SentAt        *time.Time          `json:"sentAt,omitempty"`
+	CreatedAt     time.Time           `json:"createdAt"`
+	UpdatedAt     time.Time           `json:"updatedAt"`
+}
+
+// MarshalJSON implements custom JSON marshaling for OutboundEmail
+func (e *OutboundEmail) MarshalJSON() ([]byte, error) {
+	return json.Marshal(outboundEmailJSON{
+		ID:            e.id,
+		Topic:         e.topic,
+		Key:           e.key,
+		Payload:       e.payload,
+		Headers:       e.headers,
+		Recipient:     e.recipient,
+		Status:        e.status,
+		Attempts:      e.attempts,
+		LastError:     e.lastError,
+		NextAttemptAt: e.nextAttemptAt,
+		SentAt:        e.sentAt,
+		CreatedAt:     e.createdAt,
+		UpdatedAt:     e.updatedAt,
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for OutboundEmail
+func (e *OutboundEmail) UnmarshalJSON(data []byte) error {
+	var jsonEmail outboundEmailJSON
+	if err := json.Unmarshal(data, &jsonEmail); err != nil {
+		return err
+	}
+
+	e.id = jsonEmail.ID
+	e.topic = jsonEmail.Topic
+	e.key = jsonEmail.Key
+	e.payload = jsonEmail.Payload
+	e.headers = jsonEmail.Headers
+	e.recipient = jsonEmail.Recipient
+	e.status = jsonEmail.Status
+	e.attempts = jsonEmail.Attempts
+	e.lastError = jsonEmail.LastError
+	e.nextAttemptAt = jsonEmail.NextAttemptAt
+	e.sentAt = jsonEmail.SentAt
+	e.createdAt = jsonEmail.CreatedAt
+	e.updatedAt = jsonEmail.UpdatedAt
+	return nil
+}
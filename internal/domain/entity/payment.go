@@ -0,0 +1,164 @@
+package entity
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/google/uuid"
+)
+
+// PaymentMethod is how a payment was received
+type PaymentMethod string
+
+const (
+	PaymentBankTransfer PaymentMethod = "bank_transfer"
+	PaymentCard         PaymentMethod = "card"
+	PaymentCash         PaymentMethod = "cash"
+	PaymentCheck        PaymentMethod = "check"
+	PaymentOther        PaymentMethod = "other"
+)
+
+// Payment is an amount received against an invoice; an invoice is paid once its payments cover its total
+type Payment struct {
+	id         string
+	invoiceID  string
+	tenantID   string // Tenant of the invoice (empty when created without a tenant)
+	amount     valueobject.Money
+	method     PaymentMethod
+	reference  string // Bank transfer reference, check number or receipt number
+	receivedAt time.Time
+	createdAt  time.Time
+}
+
+// NewPayment creates a payment received against an invoice with validation
+func NewPayment(invoiceID string, amount valueobject.Money, method PaymentMethod, reference string, receivedAt time.Time) (*Payment, error) {
+	invoiceID = strings.TrimSpace(invoiceID)
+	if invoiceID == "" {
+		return nil, errors.NewValidationError("invoice_id", invoiceID, errors.ValidationRequired, "invoice ID is required")
+	}
+	if !amount.IsPositive() {
+		return nil, errors.NewValidationError("amount", amount.Amount(), errors.ValidationRange, "amount must be positive")
+	}
+
+	switch method {
+	case PaymentBankTransfer, PaymentCard, PaymentCash, PaymentCheck, PaymentOther:
+	default:
+		return nil, errors.NewValidationError("method", method, errors.ValidationFormat, "method must be one of: bank_transfer, card, cash, check, other")
+	}
+
+	reference = strings.TrimSpace(reference)
+	if len(reference) > 100 {
+		return nil, errors.NewValidationError("reference", reference, errors.ValidationLength, "reference must be at most 100 characters")
+	}
+
+	now := time.Now().UTC()
+	if receivedAt.IsZero() {
+		receivedAt = now
+	}
+	if receivedAt.After(now) {
+		return nil, errors.NewValidationError("received_at", receivedAt, errors.ValidationRange, "received date must not be in the future")
+	}
+
+	return &Payment{
+		id:         uuid.New().String(),
+		invoiceID:  invoiceID,
+		amount:     amount,
+		method:     method,
+		reference:  reference,
+		receivedAt: receivedAt.UTC(),
+		createdAt:  now,
+	}, nil
+}
+
+// Getters
+func (p *Payment) ID() string {
+	return p.id
+}
+
+func (p *Payment) InvoiceID() string {
+	return p.invoiceID
+}
+
+func (p *Payment) TenantID() string {
+	return p.tenantID
+}
+
+func (p *Payment) Amount() valueobject.Money {
+	return p.amount
+}
+
+func (p *Payment) Method() PaymentMethod {
+	return p.method
+}
+
+func (p *Payment) Reference() string {
+	return p.reference
+}
+
+func (p *Payment) ReceivedAt() time.Time {
+	return p.receivedAt
+}
+
+func (p *Payment) CreatedAt() time.Time {
+	return p.createdAt
+}
+
+// AssignTenant sets the tenant of the paid invoice
+func (p *Payment) AssignTenant(tenantID string) {
+	p.tenantID = strings.TrimSpace(tenantID)
+}
+
+// paymentJSON is the persisted form of a Payment
+type paymentJSON struct {
+	ID         string        `json:"id"`
+	InvoiceID  string        `json:"invoiceId"`
+	TenantID   string        `json:"tenantId,omitempty"`
+	Amount     int64         `json:"amount"`
+	Currency   string        `json:"currency"`
+	Method     PaymentMethod `json:"method"`
+	Reference  string        `json:"reference,omitempty"`
+	ReceivedAt time.Time     `json:"receivedAt"`
+	CreatedAt  time.Time     `json:"createdAt"`
+}
+
+// MarshalJSON implements custom JSON marshaling for Payment
+func (p *Payment) MarshalJSON() ([]byte, error) {
+	return json.Marshal(paymentJSON{
+		ID:         p.id,
+		InvoiceID:  p.invoiceID,
+		TenantID:   p.tenantID,
+		Amount:     p.amount.Amount(),
+		Currency:   p.amount.Currency(),
+		Method:     p.method,
+		Reference:  p.reference,
+		ReceivedAt: p.receivedAt,
+		CreatedAt:  p.createdAt,
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for Payment
+func (p *Payment) UnmarshalJSON(data []byte) error {
+	var jsonPayment paymentJSON
+	if err := json.Unmarshal(data, &jsonPayment); err != nil {
+		return err
+	}
+
+	amount, err := valueobject.NewMoney(jsonPayment.Amount, jsonPayment.Currency)
+	if err != nil {
+		return err
+	}
+
+	p.id = jsonPayment.ID
+	p.invoiceID = jsonPayment.InvoiceID
+	p.tenantID = jsonPayment.TenantID
+	p.amount = amount
+	p.method = jsonPayment.Method
+	p.reference = jsonPayment.Reference
+	p.receivedAt = jsonPayment.ReceivedAt
+	p.createdAt = jsonPayment.CreatedAt
+
+	return nil
+}
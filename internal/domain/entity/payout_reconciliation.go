@@ -0,0 +1,178 @@
+package entity
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+)
+
+// PayoutReconciliationStatus is the outcome of reconciling a gateway payout against the ledger
+type PayoutReconciliationStatus string
+
+const (
+	// PayoutBalanced means every payout item matches a recorded payment or fee
+	PayoutBalanced PayoutReconciliationStatus = "balanced"
+
+	// PayoutDiscrepant means finance has discrepancies to resolve before closing the books
+	PayoutDiscrepant PayoutReconciliationStatus = "discrepant"
+)
+
+// PayoutDiscrepancyKind classifies a difference between a gateway payout and the ledger
+type PayoutDiscrepancyKind string
+
+const (
+	// PayoutDiscrepancyMissingInLedger is a payout item without a recorded payment or fee
+	PayoutDiscrepancyMissingInLedger PayoutDiscrepancyKind = "missing_in_ledger"
+
+	// PayoutDiscrepancyAmountMismatch is a payout item whose gross amount differs from the recorded payment
+	PayoutDiscrepancyAmountMismatch PayoutDiscrepancyKind = "amount_mismatch"
+
+	// PayoutDiscrepancyFeeMismatch is a payout item whose gateway fee differs from the recorded fee
+	PayoutDiscrepancyFeeMismatch PayoutDiscrepancyKind = "fee_mismatch"
+
+	// PayoutDiscrepancyTotalMismatch is a payout amount that differs from the net sum of its items
+	PayoutDiscrepancyTotalMismatch PayoutDiscrepancyKind = "total_mismatch"
+)
+
+// PayoutDiscrepancy is a difference found while reconciling a payout
+// Amounts are in minor units of the payout currency; for total_mismatch, LedgerAmount is the net sum of the items
+type PayoutDiscrepancy struct {
+	Kind          PayoutDiscrepancyKind
+	Reference     string // Gateway reference of the item (empty for total_mismatch)
+	PaymentID     string // Recorded payment the item was matched to, if any
+	GatewayAmount int64
+	LedgerAmount  int64
+}
+
+// PayoutReconciliation is the discrepancy report of a gateway payout, replaced each time the payout is reconciled again
+type PayoutReconciliation struct {
+	payoutID      string
+	arrivalDate   time.Time
+	amount        valueobject.Money
+	itemCount     int
+	matchedCount  int
+	discrepancies []PayoutDiscrepancy
+	reconciledAt  time.Time
+}
+
+// NewPayoutReconciliation creates the report of a payout with validation
+func NewPayoutReconciliation(payoutID string, arrivalDate time.Time, amount valueobject.Money, itemCount, matchedCount int, discrepancies []PayoutDiscrepancy) (*PayoutReconciliation, error) {
+	payoutID = strings.TrimSpace(payoutID)
+	if payoutID == "" {
+		return nil, errors.NewValidationError("payout_id", payoutID, errors.ValidationRequired, "payout ID is required")
+	}
+	if itemCount < 0 || matchedCount < 0 || matchedCount > itemCount {
+		return nil, errors.NewValidationError("matched_count", matchedCount, errors.ValidationRange, "matched items must be between 0 and the number of payout items")
+	}
+
+	return &PayoutReconciliation{
+		payoutID:      payoutID,
+		arrivalDate:   arrivalDate.UTC(),
+		amount:        amount,
+		itemCount:     itemCount,
+		matchedCount:  matchedCount,
+		discrepancies: append([]PayoutDiscrepancy(nil), discrepancies...),
+		reconciledAt:  time.Now().UTC(),
+	}, nil
+}
+
+// Getters
+func (r *PayoutReconciliation) PayoutID() string {
+	return r.payoutID
+}
+
+func (r *PayoutReconciliation) ArrivalDate() time.Time {
+	return r.arrivalDate
+}
+
+// Amount returns the net amount the gateway paid out
+func (r *PayoutReconciliation) Amount() valueobject.Money {
+	return r.amount
+}
+
+func (r *PayoutReconciliation) ItemCount() int {
+	return r.itemCount
+}
+
+// MatchedCount returns the number of payout items found in the ledger
+func (r *PayoutReconciliation) MatchedCount() int {
+	return r.matchedCount
+}
+
+func (r *PayoutReconciliation) Discrepancies() []PayoutDiscrepancy {
+	return append([]PayoutDiscrepancy(nil), r.discrepancies...)
+}
+
+func (r *PayoutReconciliation) ReconciledAt() time.Time {
+	return r.reconciledAt
+}
+
+// Status reports whether the payout balances with the ledger
+func (r *PayoutReconciliation) Status() PayoutReconciliationStatus {
+	if len(r.discrepancies) == 0 {
+		return PayoutBalanced
+	}
+	return PayoutDiscrepant
+}
+
+// payoutDiscrepancyJSON is the persisted form of a PayoutDiscrepancy
+type payoutDiscrepancyJSON struct {
+	Kind          PayoutDiscrepancyKind `json:"kind"`
+	Reference     string                `json:"reference,omitempty"`
+	PaymentID     string                `json:"paymentId,omitempty"`
+	GatewayAmount int64                 `json:"gatewayAmount"`
+	LedgerAmount  int64                 `json:"ledgerAmount"`
+}
+
+// payoutReconciliationJSON is the persisted form of a PayoutReconciliation
+type payoutReconciliationJSON struct {
+	PayoutID      string                  `json:"payoutId"`
+	ArrivalDate   time.Time               `json:"arrivalDate"`
+	Amount        valueobject.Money       `json:"amount"`
+	ItemCount     int                     `json:"itemCount"`
+	MatchedCount  int                     `json:"matchedCount"`
+	Discrepancies []payoutDiscrepancyJSON `json:"discrepancies"`
+	ReconciledAt  time.Time               `json:"reconciledAt"`
+}
+
+// MarshalJSON implements custom JSON marshaling for PayoutReconciliation
+func (r *PayoutReconciliation) MarshalJSON() ([]byte, error) {
+	discrepancies := make([]payoutDiscrepancyJSON, len(r.discrepancies))
+	for i, discrepancy := range r.discrepancies {
+		discrepancies[i] = payoutDiscrepancyJSON(discrepancy)
+	}
+
+	return json.Marshal(payoutReconciliationJSON{
+		PayoutID:      r.payoutID,
+		ArrivalDate:   r.arrivalDate,
+		Amount:        r.amount,
+		ItemCount:     r.itemCount,
+		MatchedCount:  r.matchedCount,
+		Discrepancies: discrepancies,
+		ReconciledAt:  r.reconciledAt,
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for PayoutReconciliation
+func (r *PayoutReconciliation) UnmarshalJSON(data []byte) error {
+	var jsonReconciliation payoutReconciliationJSON
+	if err := json.Unmarshal(data, &jsonReconciliation); err != nil {
+		return err
+	}
+
+	r.payoutID = jsonReconciliation.PayoutID
+	r.arrivalDate = jsonReconciliation.ArrivalDate
+	r.amount = jsonReconciliation.Amount
+	r.itemCount = jsonReconciliation.ItemCount
+	r.matchedCount = jsonReconciliation.MatchedCount
+	r.discrepancies = make([]PayoutDiscrepancy, len(jsonReconciliation.Discrepancies))
+	for i, discrepancy := range jsonReconciliation.Discrepancies {
+		r.discrepancies[i] = PayoutDiscrepancy(discrepancy)
+	}
+	r.reconciledAt = jsonReconciliation.ReconciledAt
+
+	return nil
+}
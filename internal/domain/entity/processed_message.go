@@ -0,0 +1,82 @@
+package entity
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+)
+
+// ProcessedMessage records that a message (integration event, webhook event) was processed
+// Redeliveries of the message are skipped until the record expires
+type ProcessedMessage struct {
+	messageID   string
+	processedAt time.Time
+	expiresAt   time.Time
+}
+
+// NewProcessedMessage records the processing of a message, remembered for ttl
+func NewProcessedMessage(messageID string, processedAt time.Time, ttl time.Duration) (*ProcessedMessage, error) {
+	messageID = strings.TrimSpace(messageID)
+	if messageID == "" {
+		return nil, errors.NewValidationError("message_id", messageID, errors.ValidationRequired, "message ID is required")
+	}
+	if ttl <= 0 {
+		return nil, errors.NewValidationError("ttl", ttl, errors.ValidationRange, "ttl must be positive")
+	}
+
+	return &ProcessedMessage{
+		messageID:   messageID,
+		processedAt: processedAt.UTC(),
+		expiresAt:   processedAt.Add(ttl).UTC(),
+	}, nil
+}
+
+// Getters
+func (m *ProcessedMessage) MessageID() string {
+	return m.messageID
+}
+
+func (m *ProcessedMessage) ProcessedAt() time.Time {
+	return m.processedAt
+}
+
+func (m *ProcessedMessage) ExpiresAt() time.Time {
+	return m.expiresAt
+}
+
+// IsExpired reports whether the record no longer protects against redeliveries
+func (m *ProcessedMessage) IsExpired(now time.Time) bool {
+	return !now.Before(m.expiresAt)
+}
+
+// processedMessageJSON is the persisted form of a ProcessedMessage
+type processedMessageJSON struct {
+	MessageID   string    `json:"messageId"`
+	ProcessedAt time.Time `json:"processedAt"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+}
+
+// MarshalJSON implements custom JSON marshaling for ProcessedMessage
+func (m *ProcessedMessage) MarshalJSON() ([]byte, error) {
+	return json.Marshal(processedMessageJSON{
+		MessageID:   m.messageID,
+		ProcessedAt: m.processedAt,
+		ExpiresAt:   m.expiresAt,
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for ProcessedMessage
+func (m *ProcessedMessage) UnmarshalJSON(data []byte) error {
+	var jsonMessage processedMessageJSON
+	if err := json.Unmarshal(data, &jsonMessage); err != nil {
+		return err
+	}
+
+	m.messageID = jsonMessage.MessageID
+	m.processedAt = jsonMessage.ProcessedAt
+	m.expiresAt = jsonMessage.ExpiresAt
+
+	return nil
+}
@@ -0,0 +1,347 @@
+package entity
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/google/uuid"
+)
+
+// QuoteStatus is the lifecycle state of a quote
+type QuoteStatus string
+
+const (
+	QuoteOpen     QuoteStatus = "open"
+	QuoteAccepted QuoteStatus = "accepted"
+	QuoteRejected QuoteStatus = "rejected"
+	QuoteExpired  QuoteStatus = "expired"
+)
+
+// Quote is an estimate offered to a client until its validity date; an accepted quote converts to an invoice
+// Its line items are priced and taxed like invoice line items, so the invoice bills exactly the quoted total
+type Quote struct {
+	id              string
+	tenantID        string // Tenant the quote was created for (empty when created without a tenant)
+	clientID        string
+	currency        string
+	lines           []InvoiceLine
+	taxPricing      valueobject.TaxPricing // Whether unit amounts include tax (empty = exclusive)
+	taxJurisdiction string                 // Jurisdiction the line tax rates were looked up for (empty when set per line)
+	validUntil      time.Time              // Last moment the client can accept the quote
+	status          QuoteStatus
+	acceptedAt      *time.Time
+	rejectedAt      *time.Time
+	rejectionReason string
+	expiredAt       *time.Time
+	invoiceID       string // Invoice the accepted quote was converted to (empty until converted)
+	convertedAt     *time.Time
+	createdAt       time.Time
+	updatedAt       time.Time
+}
+
+// NewQuote creates an open quote with validation
+func NewQuote(clientID, currency string, lines []InvoiceLine, validUntil time.Time) (*Quote, error) {
+	clientID = strings.TrimSpace(clientID)
+	if clientID == "" {
+		return nil, errors.NewValidationError("client_id", clientID, errors.ValidationRequired, "client ID is required")
+	}
+
+	money, err := valueobject.NewMoney(0, currency)
+	if err != nil {
+		return nil, err
+	}
+	validated, err := validateInvoiceLines(lines, money.Currency())
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if validUntil.IsZero() {
+		return nil, errors.NewValidationError("valid_until", validUntil, errors.ValidationRequired, "validity date is required")
+	}
+	if !validUntil.After(now) {
+		return nil, errors.NewValidationError("valid_until", validUntil, errors.ValidationRange, "validity date must be in the future")
+	}
+
+	return &Quote{
+		id:         uuid.New().String(),
+		clientID:   clientID,
+		currency:   money.Currency(),
+		lines:      validated,
+		validUntil: validUntil.UTC(),
+		status:     QuoteOpen,
+		createdAt:  now,
+		updatedAt:  now,
+	}, nil
+}
+
+// Getters
+func (q *Quote) ID() string {
+	return q.id
+}
+
+func (q *Quote) TenantID() string {
+	return q.tenantID
+}
+
+func (q *Quote) ClientID() string {
+	return q.clientID
+}
+
+func (q *Quote) Currency() string {
+	return q.currency
+}
+
+// Lines returns a copy of the quote's line items
+func (q *Quote) Lines() []InvoiceLine {
+	return append([]InvoiceLine(nil), q.lines...)
+}
+
+// TaxPricing returns whether the unit amounts include tax (exclusive when unset)
+func (q *Quote) TaxPricing() valueobject.TaxPricing {
+	if q.taxPricing == "" {
+		return valueobject.TaxExclusive
+	}
+	return q.taxPricing
+}
+
+func (q *Quote) TaxJurisdiction() string {
+	return q.taxJurisdiction
+}
+
+func (q *Quote) ValidUntil() time.Time {
+	return q.validUntil
+}
+
+func (q *Quote) Status() QuoteStatus {
+	return q.status
+}
+
+func (q *Quote) AcceptedAt() *time.Time {
+	return q.acceptedAt
+}
+
+func (q *Quote) RejectedAt() *time.Time {
+	return q.rejectedAt
+}
+
+func (q *Quote) RejectionReason() string {
+	return q.rejectionReason
+}
+
+func (q *Quote) ExpiredAt() *time.Time {
+	return q.expiredAt
+}
+
+func (q *Quote) InvoiceID() string {
+	return q.invoiceID
+}
+
+func (q *Quote) ConvertedAt() *time.Time {
+	return q.convertedAt
+}
+
+func (q *Quote) CreatedAt() time.Time {
+	return q.createdAt
+}
+
+func (q *Quote) UpdatedAt() time.Time {
+	return q.updatedAt
+}
+
+// LineAmount returns the total of one line item at its unit amount (gross when prices include tax)
+func (q *Quote) LineAmount(line InvoiceLine) (valueobject.Money, error) {
+	return lineAmount(line, q.currency)
+}
+
+// LineTax splits the amount of one line item into its net amount and tax at the line's tax rate
+func (q *Quote) LineTax(line InvoiceLine) (valueobject.TaxedAmount, error) {
+	return lineTax(line, q.currency, q.TaxPricing())
+}
+
+// Totals returns the quoted amounts before tax, of tax, and tax included, rounded like an invoice of the same lines
+func (q *Quote) Totals() (valueobject.TaxedAmount, error) {
+	return taxedLineTotals(q.lines, q.currency, q.TaxPricing())
+}
+
+// IsLapsed checks if an open quote is past its validity date and can no longer be accepted
+func (q *Quote) IsLapsed(now time.Time) bool {
+	return q.status == QuoteOpen && now.After(q.validUntil)
+}
+
+// IsConverted checks if the quote was converted to an invoice
+func (q *Quote) IsConverted() bool {
+	return q.invoiceID != ""
+}
+
+// SetTaxTerms sets whether the unit amounts of an open quote include tax and the jurisdiction its tax rates come from
+func (q *Quote) SetTaxTerms(pricing valueobject.TaxPricing, jurisdiction string) error {
+	if q.status != QuoteOpen {
+		return errors.ErrQuoteNotOpen
+	}
+	parsed, err := valueobject.ParseTaxPricing(string(pricing))
+	if err != nil {
+		return err
+	}
+	q.taxPricing = parsed
+	q.taxJurisdiction = strings.ToUpper(strings.TrimSpace(jurisdiction))
+	q.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// AssignTenant sets the tenant the quote belongs to
+func (q *Quote) AssignTenant(tenantID string) {
+	q.tenantID = strings.TrimSpace(tenantID)
+	q.updatedAt = time.Now().UTC()
+}
+
+// Accept records the client's acceptance of an open quote within its validity
+func (q *Quote) Accept(at time.Time) error {
+	if q.status != QuoteOpen {
+		return errors.ErrQuoteNotOpen
+	}
+	if q.IsLapsed(at) {
+		return errors.ErrQuoteExpired
+	}
+	acceptedAt := at.UTC()
+	q.status = QuoteAccepted
+	q.acceptedAt = &acceptedAt
+	q.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// Reject records the client's refusal of an open quote, with an optional reason
+func (q *Quote) Reject(reason string, at time.Time) error {
+	if q.status != QuoteOpen {
+		return errors.ErrQuoteNotOpen
+	}
+	reason = strings.TrimSpace(reason)
+	if len(reason) > 500 {
+		return errors.NewValidationError("reason", reason, errors.ValidationLength, "rejection reason must be at most 500 characters")
+	}
+	rejectedAt := at.UTC()
+	q.status = QuoteRejected
+	q.rejectedAt = &rejectedAt
+	q.rejectionReason = reason
+	q.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// Expire closes an open quote, once past its validity date or when the offer is withdrawn earlier
+func (q *Quote) Expire(at time.Time) error {
+	if q.status != QuoteOpen {
+		return errors.ErrQuoteNotOpen
+	}
+	expiredAt := at.UTC()
+	q.status = QuoteExpired
+	q.expiredAt = &expiredAt
+	q.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// CanConvert checks that the quote is accepted and was not converted yet
+func (q *Quote) CanConvert() error {
+	if q.IsConverted() {
+		return errors.ErrQuoteAlreadyConverted
+	}
+	if q.status != QuoteAccepted {
+		return errors.ErrQuoteNotAccepted
+	}
+	return nil
+}
+
+// MarkConverted records the invoice an accepted quote was converted to
+func (q *Quote) MarkConverted(invoiceID string, at time.Time) error {
+	if err := q.CanConvert(); err != nil {
+		return err
+	}
+	convertedAt := at.UTC()
+	q.invoiceID = invoiceID
+	q.convertedAt = &convertedAt
+	q.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// quoteJSON is the persisted form of a Quote
+type quoteJSON struct {
+	ID              string                 `json:"id"`
+	TenantID        string                 `json:"tenantId,omitempty"`
+	ClientID        string                 `json:"clientId"`
+	Currency        string                 `json:"currency"`
+	Lines           []invoiceLineJSON      `json:"lines"`
+	TaxPricing      valueobject.TaxPricing `json:"taxPricing,omitempty"`
+	TaxJurisdiction string                 `json:"taxJurisdiction,omitempty"`
+	ValidUntil      time.Time              `json:"validUntil"`
+	Status          QuoteStatus            `json:"status"`
+	AcceptedAt      *time.Time             `json:"acceptedAt,omitempty"`
+	RejectedAt      *time.Time             `json:"rejectedAt,omitempty"`
+	RejectionReason string                 `json:"rejectionReason,omitempty"`
+	ExpiredAt       *time.Time             `json:"expiredAt,omitempty"`
+	InvoiceID       string                 `json:"invoiceId,omitempty"`
+	ConvertedAt     *time.Time             `json:"convertedAt,omitempty"`
+	CreatedAt       time.Time              `json:"createdAt"`
+	UpdatedAt       time.Time              `json:"updatedAt"`
+}
+
+// MarshalJSON implements custom JSON marshaling for Quote
+func (q *Quote) MarshalJSON() ([]byte, error) {
+	lines := make([]invoiceLineJSON, len(q.lines))
+	for index, line := range q.lines {
+		lines[index] = invoiceLineJSON(line)
+	}
+
+	return json.Marshal(quoteJSON{
+		ID:              q.id,
+		TenantID:        q.tenantID,
+		ClientID:        q.clientID,
+		Currency:        q.currency,
+		Lines:           lines,
+		TaxPricing:      q.taxPricing,
+		TaxJurisdiction: q.taxJurisdiction,
+		ValidUntil:      q.validUntil,
+		Status:          q.status,
+		AcceptedAt:      q.acceptedAt,
+		RejectedAt:      q.rejectedAt,
+		RejectionReason: q.rejectionReason,
+		ExpiredAt:       q.expiredAt,
+		InvoiceID:       q.invoiceID,
+		ConvertedAt:     q.convertedAt,
+		CreatedAt:       q.createdAt,
+		UpdatedAt:       q.updatedAt,
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for Quote
+func (q *Quote) UnmarshalJSON(data []byte) error {
+	var jsonQuote quoteJSON
+	if err := json.Unmarshal(data, &jsonQuote); err != nil {
+		return err
+	}
+
+	lines := make([]InvoiceLine, len(jsonQuote.Lines))
+	for index, line := range jsonQuote.Lines {
+		lines[index] = InvoiceLine(line)
+	}
+
+	q.id = jsonQuote.ID
+	q.tenantID = jsonQuote.TenantID
+	q.clientID = jsonQuote.ClientID
+	q.currency = jsonQuote.Currency
+	q.lines = lines
+	q.taxPricing = jsonQuote.TaxPricing
+	q.taxJurisdiction = jsonQuote.TaxJurisdiction
+	q.validUntil = jsonQuote.ValidUntil
+	q.status = jsonQuote.Status
+	q.acceptedAt = jsonQuote.AcceptedAt
+	q.rejectedAt = jsonQuote.RejectedAt
+	q.rejectionReason = jsonQuote.RejectionReason
+	q.expiredAt = jsonQuote.ExpiredAt
+	q.invoiceID = jsonQuote.InvoiceID
+	q.convertedAt = jsonQuote.ConvertedAt
+	q.createdAt = jsonQuote.CreatedAt
+	q.updatedAt = jsonQuote.UpdatedAt
+	return nil
+}
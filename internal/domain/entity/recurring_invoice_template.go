@@ -0,0 +1,473 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/google/uuid"
+)
+
+// RecurrenceFrequency is how often a recurring invoice is issued
+type RecurrenceFrequency string
+
+const (
+	RecurrenceWeekly    RecurrenceFrequency = "weekly"
+	RecurrenceMonthly   RecurrenceFrequency = "monthly"
+	RecurrenceQuarterly RecurrenceFrequency = "quarterly"
+	RecurrenceYearly    RecurrenceFrequency = "yearly"
+)
+
+// RecurringInvoiceLine is a fixed line item repeated on every issued invoice
+type RecurringInvoiceLine struct {
+	Description string
+	Quantity    int64
+	UnitAmount  int64 // Minor units of the template currency
+	TaxRateBps  int64 // Tax rate applied by invoicing (2000 = 20%, 0 = not taxed)
+}
+
+// RecurringInvoiceTemplate is a fixed invoice issued on a schedule, independent of subscription plans
+// Issue dates are derived from the first issue date, so month-end dates never drift (Jan 31, Feb 28, Mar 31)
+type RecurringInvoiceTemplate struct {
+	id                string
+	tenantID          string // Tenant the template was created for (empty when created without a tenant)
+	clientID          string
+	name              string
+	currency          string
+	lines             []RecurringInvoiceLine
+	frequency         RecurrenceFrequency
+	firstIssueDate    time.Time
+	endDate           *time.Time // Last day an invoice may be issued (nil = open-ended)
+	autoSend          bool       // Send issued invoices to the client without review
+	legalEntityID     string     // Legal entity invoices are issued from (empty = the default entity)
+	buyerCountry      string     // Country the client is taxed in (empty = unknown)
+	buyerTaxID        string     // VAT or tax number of the client printed on invoices
+	externalReference string     // Reference of the invoice in the system that created it (purchase order, order number)
+	active            bool
+	nextOccurrence    int // Index of the next scheduled issue date (skips dates missed while paused)
+	issuedCount       int
+	lastIssuedAt      *time.Time
+	createdAt         time.Time
+	updatedAt         time.Time
+}
+
+// NewRecurringInvoiceTemplate creates an active recurring invoice template with validation
+func NewRecurringInvoiceTemplate(clientID, name, currency string, lines []RecurringInvoiceLine, frequency RecurrenceFrequency, firstIssueDate time.Time, endDate *time.Time, autoSend bool) (*RecurringInvoiceTemplate, error) {
+	clientID = strings.TrimSpace(clientID)
+	if clientID == "" {
+		return nil, errors.NewValidationError("client_id", clientID, errors.ValidationRequired, "client ID is required")
+	}
+
+	money, err := valueobject.NewMoney(0, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	if firstIssueDate.IsZero() {
+		return nil, errors.NewValidationError("first_issue_date", firstIssueDate, errors.ValidationRequired, "first issue date is required")
+	}
+
+	now := time.Now().UTC()
+	template := &RecurringInvoiceTemplate{
+		id:             uuid.New().String(),
+		clientID:       clientID,
+		currency:       money.Currency(),
+		firstIssueDate: firstIssueDate.UTC(),
+		active:         true,
+		createdAt:      now,
+		updatedAt:      now,
+	}
+	if err := template.apply(name, lines, frequency, endDate, autoSend); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// Update replaces the editable settings of the template
+// Already issued invoices are unaffected; the schedule keeps its first issue date
+func (t *RecurringInvoiceTemplate) Update(name string, lines []RecurringInvoiceLine, frequency RecurrenceFrequency, endDate *time.Time, autoSend bool) error {
+	if err := t.apply(name, lines, frequency, endDate, autoSend); err != nil {
+		return err
+	}
+	t.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// apply validates and sets the editable settings
+func (t *RecurringInvoiceTemplate) apply(name string, lines []RecurringInvoiceLine, frequency RecurrenceFrequency, endDate *time.Time, autoSend bool) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.NewValidationError("name", name, errors.ValidationRequired, "template name is required")
+	}
+	if len(name) > 200 {
+		return errors.NewValidationError("name", name, errors.ValidationLength, "template name must be at most 200 characters")
+	}
+
+	switch frequency {
+	case RecurrenceWeekly, RecurrenceMonthly, RecurrenceQuarterly, RecurrenceYearly:
+	default:
+		return errors.NewValidationError("frequency", frequency, errors.ValidationFormat, "frequency must be one of: weekly, monthly, quarterly, yearly")
+	}
+
+	if len(lines) == 0 {
+		return errors.NewValidationError("line_items", 0, errors.ValidationRequired, "at least one line item is required")
+	}
+	validated := make([]RecurringInvoiceLine, len(lines))
+	for index, line := range lines {
+		field := fmt.Sprintf("line_items[%d]", index)
+		line.Description = strings.TrimSpace(line.Description)
+		if line.Description == "" {
+			return errors.NewValidationError(field+".description", line.Description, errors.ValidationRequired, "line description is required")
+		}
+		if line.Quantity <= 0 {
+			return errors.NewValidationError(field+".quantity", line.Quantity, errors.ValidationRange, "line quantity must be positive")
+		}
+		if line.UnitAmount < 0 {
+			return errors.NewValidationError(field+".unit_amount", line.UnitAmount, errors.ValidationRange, "line unit amount must not be negative")
+		}
+		if line.TaxRateBps < 0 || line.TaxRateBps > 10000 {
+			return errors.NewValidationError(field+".tax_rate_bps", line.TaxRateBps, errors.ValidationRange, "line tax rate must be between 0 and 10000 basis points")
+		}
+		if _, err := t.LineAmount(line); err != nil {
+			return errors.NewValidationError(field+".quantity", line.Quantity, errors.ValidationRange, "line amount is out of range")
+		}
+		validated[index] = line
+	}
+
+	var end *time.Time
+	if endDate != nil {
+		if endDate.Before(t.firstIssueDate) {
+			return errors.NewValidationError("end_date", *endDate, errors.ValidationRange, "end date must not be before the first issue date")
+		}
+		utc := endDate.UTC()
+		end = &utc
+	}
+
+	t.name = name
+	t.lines = validated
+	t.frequency = frequency
+	t.endDate = end
+	t.autoSend = autoSend
+	return nil
+}
+
+// Getters
+func (t *RecurringInvoiceTemplate) ID() string {
+	return t.id
+}
+
+func (t *RecurringInvoiceTemplate) ClientID() string {
+	return t.clientID
+}
+
+func (t *RecurringInvoiceTemplate) Name() string {
+	return t.name
+}
+
+func (t *RecurringInvoiceTemplate) Currency() string {
+	return t.currency
+}
+
+// Lines returns a copy of the line items
+func (t *RecurringInvoiceTemplate) Lines() []RecurringInvoiceLine {
+	return append([]RecurringInvoiceLine(nil), t.lines...)
+}
+
+func (t *RecurringInvoiceTemplate) Frequency() RecurrenceFrequency {
+	return t.frequency
+}
+
+func (t *RecurringInvoiceTemplate) FirstIssueDate() time.Time {
+	return t.firstIssueDate
+}
+
+func (t *RecurringInvoiceTemplate) EndDate() *time.Time {
+	return t.endDate
+}
+
+func (t *RecurringInvoiceTemplate) AutoSend() bool {
+	return t.autoSend
+}
+
+func (t *RecurringInvoiceTemplate) TenantID() string {
+	return t.tenantID
+}
+
+func (t *RecurringInvoiceTemplate) LegalEntityID() string {
+	return t.legalEntityID
+}
+
+func (t *RecurringInvoiceTemplate) BuyerCountry() string {
+	return t.buyerCountry
+}
+
+func (t *RecurringInvoiceTemplate) BuyerTaxID() string {
+	return t.buyerTaxID
+}
+
+func (t *RecurringInvoiceTemplate) ExternalReference() string {
+	return t.externalReference
+}
+
+func (t *RecurringInvoiceTemplate) IsActive() bool {
+	return t.active
+}
+
+func (t *RecurringInvoiceTemplate) IssuedCount() int {
+	return t.issuedCount
+}
+
+func (t *RecurringInvoiceTemplate) LastIssuedAt() *time.Time {
+	return t.lastIssuedAt
+}
+
+func (t *RecurringInvoiceTemplate) CreatedAt() time.Time {
+	return t.createdAt
+}
+
+func (t *RecurringInvoiceTemplate) UpdatedAt() time.Time {
+	return t.updatedAt
+}
+
+// LineAmount returns the total of one line item
+func (t *RecurringInvoiceTemplate) LineAmount(line RecurringInvoiceLine) (valueobject.Money, error) {
+	unit, err := valueobject.NewMoney(line.UnitAmount, t.currency)
+	if err != nil {
+		return valueobject.Money{}, err
+	}
+	return unit.MultiplyRatio(line.Quantity, 1)
+}
+
+// Total returns the amount of every issued invoice
+func (t *RecurringInvoiceTemplate) Total() (valueobject.Money, error) {
+	total := valueobject.ZeroMoney(t.currency)
+	for _, line := range t.lines {
+		amount, err := t.LineAmount(line)
+		if err != nil {
+			return valueobject.Money{}, err
+		}
+		if total, err = total.Add(amount); err != nil {
+			return valueobject.Money{}, err
+		}
+	}
+	return total, nil
+}
+
+// NextIssueDate returns the date of the next invoice (false once the schedule has ended)
+func (t *RecurringInvoiceTemplate) NextIssueDate() (time.Time, bool) {
+	next := t.issueDate(t.nextOccurrence)
+	if t.endDate != nil && next.After(*t.endDate) {
+		return time.Time{}, false
+	}
+	return next, true
+}
+
+// IsDue checks if the next invoice should be issued at the given time (paused templates are never due)
+func (t *RecurringInvoiceTemplate) IsDue(now time.Time) bool {
+	if !t.active {
+		return false
+	}
+	next, ok := t.NextIssueDate()
+	return ok && !now.Before(next)
+}
+
+// MarkIssued records that the invoice for the next issue date was issued
+func (t *RecurringInvoiceTemplate) MarkIssued(at time.Time) {
+	issuedAt := at.UTC()
+	t.nextOccurrence++
+	t.issuedCount++
+	t.lastIssuedAt = &issuedAt
+	t.updatedAt = time.Now().UTC()
+}
+
+// AssignTenant sets the tenant whose fiscal calendar dates and numbers the issued invoices
+func (t *RecurringInvoiceTemplate) AssignTenant(tenantID string) {
+	t.tenantID = strings.TrimSpace(tenantID)
+	t.updatedAt = time.Now().UTC()
+}
+
+// AssignLegalEntity sets the legal entity invoices are issued from (empty reverts to the default entity)
+func (t *RecurringInvoiceTemplate) AssignLegalEntity(legalEntityID string) {
+	t.legalEntityID = strings.TrimSpace(legalEntityID)
+	t.updatedAt = time.Now().UTC()
+}
+
+// SetBuyerTaxDetails sets the country the client is taxed in and its tax number, which invoice compliance rules check
+func (t *RecurringInvoiceTemplate) SetBuyerTaxDetails(country, taxID string) error {
+	country = strings.ToUpper(strings.TrimSpace(country))
+	if country != "" && !isCountryCode(country) {
+		return errors.NewValidationError("buyer_country", country, errors.ValidationFormat, "buyer country must be an ISO 3166-1 alpha-2 code")
+	}
+	taxID = strings.TrimSpace(taxID)
+	if len(taxID) > 50 {
+		return errors.NewValidationError("buyer_tax_id", taxID, errors.ValidationLength, "buyer tax ID must be at most 50 characters")
+	}
+
+	t.buyerCountry = country
+	t.buyerTaxID = taxID
+	t.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// SetExternalReference sets the reference of the invoice in the system that created it (empty clears it)
+func (t *RecurringInvoiceTemplate) SetExternalReference(reference string) error {
+	reference, err := NormalizeExternalReference(reference)
+	if err != nil {
+		return err
+	}
+
+	t.externalReference = reference
+	t.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// Pause stops issuing invoices until the template is resumed
+func (t *RecurringInvoiceTemplate) Pause() {
+	t.active = false
+	t.updatedAt = time.Now().UTC()
+}
+
+// Resume restarts issuing invoices; issue dates that passed while paused are skipped
+func (t *RecurringInvoiceTemplate) Resume(now time.Time) {
+	if t.active {
+		return
+	}
+	for !t.issueDate(t.nextOccurrence).After(now) {
+		t.nextOccurrence++
+	}
+	t.active = true
+	t.updatedAt = time.Now().UTC()
+}
+
+// issueDate returns the date of the n-th invoice (0-based)
+func (t *RecurringInvoiceTemplate) issueDate(n int) time.Time {
+	return recurrenceDate(t.firstIssueDate, t.frequency, n)
+}
+
+// recurrenceDate returns the n-th date (0-based) of a schedule starting on first
+// Each date is derived from first rather than from the previous one, so month-end dates never drift
+func recurrenceDate(first time.Time, frequency RecurrenceFrequency, n int) time.Time {
+	switch frequency {
+	case RecurrenceWeekly:
+		return first.AddDate(0, 0, 7*n)
+	case RecurrenceQuarterly:
+		return addMonthsClamped(first, 3*n)
+	case RecurrenceYearly:
+		return addMonthsClamped(first, 12*n)
+	default:
+		return addMonthsClamped(first, n)
+	}
+}
+
+// addMonthsClamped adds months keeping the day of month, clamped to the last day of shorter months
+func addMonthsClamped(date time.Time, months int) time.Time {
+	firstOfMonth := time.Date(date.Year(), date.Month(), 1, date.Hour(), date.Minute(), date.Second(), date.Nanosecond(), date.Location())
+	target := firstOfMonth.AddDate(0, months, 0)
+	lastDay := target.AddDate(0, 1, -1).Day()
+
+	day := date.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return target.AddDate(0, 0, day-1)
+}
+
+// recurringInvoiceLineJSON is the persisted form of a RecurringInvoiceLine
+type recurringInvoiceLineJSON struct {
+	Description string `json:"description"`
+	Quantity    int64  `json:"quantity"`
+	UnitAmount  int64  `json:"unitAmount"`
+	TaxRateBps  int64  `json:"taxRateBps,omitempty"`
+}
+
+// recurringInvoiceTemplateJSON is the persisted form of a RecurringInvoiceTemplate
+type recurringInvoiceTemplateJSON struct {
+	ID                string                     `json:"id"`
+	TenantID          string                     `json:"tenantId,omitempty"`
+	ClientID          string                     `json:"clientId"`
+	Name              string                     `json:"name"`
+	Currency          string                     `json:"currency"`
+	Lines             []recurringInvoiceLineJSON `json:"lines"`
+	Frequency         RecurrenceFrequency        `json:"frequency"`
+	FirstIssueDate    time.Time                  `json:"firstIssueDate"`
+	EndDate           *time.Time                 `json:"endDate,omitempty"`
+	AutoSend          bool                       `json:"autoSend"`
+	LegalEntityID     string                     `json:"legalEntityId,omitempty"`
+	BuyerCountry      string                     `json:"buyerCountry,omitempty"`
+	BuyerTaxID        string                     `json:"buyerTaxId,omitempty"`
+	ExternalReference string                     `json:"externalReference,omitempty"`
+	Active            bool                       `json:"active"`
+	NextOccurrence    int                        `json:"nextOccurrence"`
+	IssuedCount       int                        `json:"issuedCount"`
+	LastIssuedAt      *time.Time                 `json:"lastIssuedAt,omitempty"`
+	CreatedAt         time.Time                  `json:"createdAt"`
+	UpdatedAt         time.Time                  `json:"updatedAt"`
+}
+
+// MarshalJSON implements custom JSON marshaling for RecurringInvoiceTemplate
+func (t *RecurringInvoiceTemplate) MarshalJSON() ([]byte, error) {
+	lines := make([]recurringInvoiceLineJSON, len(t.lines))
+	for index, line := range t.lines {
+		lines[index] = recurringInvoiceLineJSON(line)
+	}
+
+	return json.Marshal(recurringInvoiceTemplateJSON{
+		ID:                t.id,
+		TenantID:          t.tenantID,
+		ClientID:          t.clientID,
+		Name:              t.name,
+		Currency:          t.currency,
+		Lines:             lines,
+		Frequency:         t.frequency,
+		FirstIssueDate:    t.firstIssueDate,
+		EndDate:           t.endDate,
+		AutoSend:          t.autoSend,
+		LegalEntityID:     t.legalEntityID,
+		BuyerCountry:      t.buyerCountry,
+		BuyerTaxID:        t.buyerTaxID,
+		ExternalReference: t.externalReference,
+		Active:            t.active,
+		NextOccurrence:    t.nextOccurrence,
+		IssuedCount:       t.issuedCount,
+		LastIssuedAt:      t.lastIssuedAt,
+		CreatedAt:         t.createdAt,
+		UpdatedAt:         t.updatedAt,
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for RecurringInvoiceTemplate
+func (t *RecurringInvoiceTemplate) UnmarshalJSON(data []byte) error {
+	var jsonTemplate recurringInvoiceTemplateJSON
+	if err := json.Unmarshal(data, &jsonTemplate); err != nil {
+		return err
+	}
+
+	t.id = jsonTemplate.ID
+	t.tenantID = jsonTemplate.TenantID
+	t.clientID = jsonTemplate.ClientID
+	t.name = jsonTemplate.Name
+	t.currency = jsonTemplate.Currency
+	t.lines = make([]RecurringInvoiceLine, len(jsonTemplate.Lines))
+	for index, line := range jsonTemplate.Lines {
+		t.lines[index] = RecurringInvoiceLine(line)
+	}
+	t.frequency = jsonTemplate.Frequency
+	t.firstIssueDate = jsonTemplate.FirstIssueDate
+	t.endDate = jsonTemplate.EndDate
+	t.autoSend = jsonTemplate.AutoSend
+	t.legalEntityID = jsonTemplate.LegalEntityID
+	t.buyerCountry = jsonTemplate.BuyerCountry
+	t.buyerTaxID = jsonTemplate.BuyerTaxID
+	t.externalReference = jsonTemplate.ExternalReference
+	t.active = jsonTemplate.Active
+	t.nextOccurrence = jsonTemplate.NextOccurrence
+	t.issuedCount = jsonTemplate.IssuedCount
+	t.lastIssuedAt = jsonTemplate.LastIssuedAt
+	t.createdAt = jsonTemplate.CreatedAt
+	t.updatedAt = jsonTemplate.UpdatedAt
+
+	return nil
+}
@@ -0,0 +1,194 @@
+package entity
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+)
+
+// RiskDecision is the recommendation attached to a client risk score
+type RiskDecision string
+
+const (
+	// RiskDecisionApprove means the client can be billed without further checks
+	RiskDecisionApprove RiskDecision = "approve"
+
+	// RiskDecisionReview means finance should review the client before extending more credit
+	RiskDecisionReview RiskDecision = "review"
+
+	// RiskDecisionDecline means large invoices are held until the client is scored again
+	RiskDecisionDecline RiskDecision = "decline"
+)
+
+// RiskTrigger is the event that led to a client being scored
+type RiskTrigger string
+
+const (
+	// RiskTriggerOnboarding scores a client when it is created
+	RiskTriggerOnboarding RiskTrigger = "onboarding"
+
+	// RiskTriggerLargeInvoice scores a client before an invoice above the configured threshold is issued
+	RiskTriggerLargeInvoice RiskTrigger = "large_invoice"
+)
+
+// MaxRiskScore is the highest score; higher scores mean riskier clients
+const MaxRiskScore = 100
+
+// RiskReason explains a factor behind a risk score
+type RiskReason struct {
+	Code        string
+	Description string
+}
+
+// RiskAssessment is the latest risk score of a client returned by the risk provider
+// It is cached until expiresAt; later triggers reuse it instead of consulting the provider again
+type RiskAssessment struct {
+	clientID   string
+	provider   string
+	score      int
+	decision   RiskDecision
+	reasons    []RiskReason
+	trigger    RiskTrigger
+	assessedAt time.Time
+	expiresAt  time.Time
+}
+
+// NewRiskAssessment creates the assessment of a client with validation, cached for ttl from assessedAt
+func NewRiskAssessment(clientID, provider string, score int, decision RiskDecision, reasons []RiskReason, trigger RiskTrigger, assessedAt time.Time, ttl time.Duration) (*RiskAssessment, error) {
+	clientID = strings.TrimSpace(clientID)
+	if clientID == "" {
+		return nil, errors.NewValidationError("client_id", clientID, errors.ValidationRequired, "client ID is required")
+	}
+	provider = strings.TrimSpace(provider)
+	if provider == "" {
+		return nil, errors.NewValidationError("provider", provider, errors.ValidationRequired, "risk provider is required")
+	}
+	if score < 0 || score > MaxRiskScore {
+		return nil, errors.NewValidationError("score", score, errors.ValidationRange, "risk score must be between 0 and 100")
+	}
+	switch decision {
+	case RiskDecisionApprove, RiskDecisionReview, RiskDecisionDecline:
+	default:
+		return nil, errors.NewValidationError("decision", decision, errors.ValidationFormat, "decision must be one of: approve, review, decline")
+	}
+	switch trigger {
+	case RiskTriggerOnboarding, RiskTriggerLargeInvoice:
+	default:
+		return nil, errors.NewValidationError("trigger", trigger, errors.ValidationFormat, "trigger must be one of: onboarding, large_invoice")
+	}
+	if ttl <= 0 {
+		return nil, errors.NewValidationError("ttl", ttl, errors.ValidationRange, "cache TTL must be positive")
+	}
+
+	assessedAt = assessedAt.UTC()
+	return &RiskAssessment{
+		clientID:   clientID,
+		provider:   provider,
+		score:      score,
+		decision:   decision,
+		reasons:    append([]RiskReason(nil), reasons...),
+		trigger:    trigger,
+		assessedAt: assessedAt,
+		expiresAt:  assessedAt.Add(ttl),
+	}, nil
+}
+
+// Getters
+func (a *RiskAssessment) ClientID() string {
+	return a.clientID
+}
+
+// Provider returns the name of the provider that scored the client
+func (a *RiskAssessment) Provider() string {
+	return a.provider
+}
+
+func (a *RiskAssessment) Score() int {
+	return a.score
+}
+
+func (a *RiskAssessment) Decision() RiskDecision {
+	return a.decision
+}
+
+func (a *RiskAssessment) Reasons() []RiskReason {
+	return append([]RiskReason(nil), a.reasons...)
+}
+
+func (a *RiskAssessment) Trigger() RiskTrigger {
+	return a.trigger
+}
+
+func (a *RiskAssessment) AssessedAt() time.Time {
+	return a.assessedAt
+}
+
+func (a *RiskAssessment) ExpiresAt() time.Time {
+	return a.expiresAt
+}
+
+// IsExpired reports whether the cached score must be refreshed from the provider
+func (a *RiskAssessment) IsExpired(now time.Time) bool {
+	return !now.Before(a.expiresAt)
+}
+
+// riskReasonJSON is the persisted form of a RiskReason
+type riskReasonJSON struct {
+	Code        string `json:"code"`
+	Description string `json:"description,omitempty"`
+}
+
+// riskAssessmentJSON is the persisted form of a RiskAssessment
+type riskAssessmentJSON struct {
+	ClientID   string           `json:"clientId"`
+	Provider   string           `json:"provider"`
+	Score      int              `json:"score"`
+	Decision   RiskDecision     `json:"decision"`
+	Reasons    []riskReasonJSON `json:"reasons"`
+	Trigger    RiskTrigger      `json:"trigger"`
+	AssessedAt time.Time        `json:"assessedAt"`
+	ExpiresAt  time.Time        `json:"expiresAt"`
+}
+
+// MarshalJSON implements custom JSON marshaling for RiskAssessment
+func (a *RiskAssessment) MarshalJSON() ([]byte, error) {
+	reasons := make([]riskReasonJSON, len(a.reasons))
+	for i, reason := range a.reasons {
+		reasons[i] = riskReasonJSON(reason)
+	}
+
+	return json.Marshal(riskAssessmentJSON{
+		ClientID:   a.clientID,
+		Provider:   a.provider,
+		Score:      a.score,
+		Decision:   a.decision,
+		Reasons:    reasons,
+		Trigger:    a.trigger,
+		AssessedAt: a.assessedAt,
+		ExpiresAt:  a.expiresAt,
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for RiskAssessment
+func (a *RiskAssessment) UnmarshalJSON(data []byte) error {
+	var jsonAssessment riskAssessmentJSON
+	if err := json.Unmarshal(data, &jsonAssessment); err != nil {
+		return err
+	}
+
+	a.clientID = jsonAssessment.ClientID
+	a.provider = jsonAssessment.Provider
+	a.score = jsonAssessment.Score
+	a.decision = jsonAssessment.Decision
+	a.reasons = make([]RiskReason, len(jsonAssessment.Reasons))
+	for i, reason := range jsonAssessment.Reasons {
+		a.reasons[i] = RiskReason(reason)
+	}
+	a.trigger = jsonAssessment.Trigger
+	a.assessedAt = jsonAssessment.AssessedAt
+	a.expiresAt = jsonAssessment.ExpiresAt
+
+	return nil
+}
@@ -0,0 +1,317 @@
+package entity
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/google/uuid"
+)
+
+// SagaStatus is the progress of a saga
+type SagaStatus string
+
+const (
+	// SagaRunning means the saga is executing its steps in order
+	SagaRunning SagaStatus = "running"
+
+	// SagaCompensating means a step failed for good and the completed steps are being undone in reverse order
+	SagaCompensating SagaStatus = "compensating"
+
+	// SagaCompleted means every step succeeded
+	SagaCompleted SagaStatus = "completed"
+
+	// SagaCompensated means every completed step was undone after a step failed for good
+	SagaCompensated SagaStatus = "compensated"
+)
+
+// SagaStepStatus is the progress of a saga step
+type SagaStepStatus string
+
+const (
+	// SagaStepPending means the step did not succeed yet
+	SagaStepPending SagaStepStatus = "pending"
+
+	// SagaStepCompleted means the step succeeded
+	SagaStepCompleted SagaStepStatus = "completed"
+
+	// SagaStepFailed means the step failed for good, which started the compensation of the saga
+	SagaStepFailed SagaStepStatus = "failed"
+
+	// SagaStepCompensated means the effects of the completed step were undone
+	SagaStepCompensated SagaStepStatus = "compensated"
+)
+
+// SagaStepState is the progress of a saga step
+type SagaStepState struct {
+	Name      string
+	Status    SagaStepStatus
+	Attempts  int    // Failed attempts of the step, or of its compensation while the saga compensates
+	LastError string // Error of the last failed attempt
+}
+
+// Saga is a business flow spanning several steps that can partially fail (e.g. charging a card, marking the invoice paid,
+// posting ledger entries, notifying the client). Its state is persisted after every step so it can be resumed,
+// and the completed steps are compensated when a step fails for good
+type Saga struct {
+	id          string
+	sagaType    string
+	reference   string
+	status      SagaStatus
+	data        map[string]string
+	steps       []SagaStepState
+	currentStep int
+	createdAt   time.Time
+	updatedAt   time.Time
+}
+
+// NewSaga creates a running saga of sagaType with its step names and initial data
+// reference identifies the business object the saga acts on (e.g. an invoice ID)
+func NewSaga(sagaType, reference string, stepNames []string, data map[string]string, now time.Time) (*Saga, error) {
+	sagaType = strings.TrimSpace(sagaType)
+	reference = strings.TrimSpace(reference)
+
+	if sagaType == "" {
+		return nil, errors.NewValidationError("type", sagaType, errors.ValidationRequired, "saga type is required")
+	}
+	if reference == "" {
+		return nil, errors.NewValidationError("reference", reference, errors.ValidationRequired, "reference is required")
+	}
+	if len(stepNames) == 0 {
+		return nil, errors.NewValidationError("steps", "", errors.ValidationRequired, "at least one step is required")
+	}
+
+	steps := make([]SagaStepState, len(stepNames))
+	for i, name := range stepNames {
+		steps[i] = SagaStepState{Name: name, Status: SagaStepPending}
+	}
+	copied := make(map[string]string, len(data))
+	for key, value := range data {
+		copied[key] = value
+	}
+
+	return &Saga{
+		id:          uuid.New().String(),
+		sagaType:    sagaType,
+		reference:   reference,
+		status:      SagaRunning,
+		data:        copied,
+		steps:       steps,
+		currentStep: 0,
+		createdAt:   now.UTC(),
+		updatedAt:   now.UTC(),
+	}, nil
+}
+
+// Getters
+func (s *Saga) ID() string {
+	return s.id
+}
+
+func (s *Saga) Type() string {
+	return s.sagaType
+}
+
+func (s *Saga) Reference() string {
+	return s.reference
+}
+
+func (s *Saga) Status() SagaStatus {
+	return s.status
+}
+
+// Data returns the data steps read and produced (e.g. the charge ID returned by the gateway)
+func (s *Saga) Data() map[string]string {
+	copied := make(map[string]string, len(s.data))
+	for key, value := range s.data {
+		copied[key] = value
+	}
+	return copied
+}
+
+// Value returns a data value of the saga
+func (s *Saga) Value(key string) string {
+	return s.data[key]
+}
+
+func (s *Saga) Steps() []SagaStepState {
+	return append([]SagaStepState(nil), s.steps...)
+}
+
+// CurrentStep returns the index of the step executed (running) or compensated (compensating) next; -1 once finished
+func (s *Saga) CurrentStep() int {
+	if s.IsFinished() {
+		return -1
+	}
+	return s.currentStep
+}
+
+func (s *Saga) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+func (s *Saga) UpdatedAt() time.Time {
+	return s.updatedAt
+}
+
+// LastError returns the error of the last failed attempt of the current step
+func (s *Saga) LastError() string {
+	if s.IsFinished() {
+		return ""
+	}
+	return s.steps[s.currentStep].LastError
+}
+
+// IsFinished reports whether the saga completed or was fully compensated
+func (s *Saga) IsFinished() bool {
+	return s.status == SagaCompleted || s.status == SagaCompensated
+}
+
+// IsStuck reports whether an unfinished saga made no progress for at least after
+func (s *Saga) IsStuck(now time.Time, after time.Duration) bool {
+	return !s.IsFinished() && now.Sub(s.updatedAt) >= after
+}
+
+// CompleteStep records the success of the current step, merging the data it produced
+func (s *Saga) CompleteStep(output map[string]string, now time.Time) error {
+	if s.status != SagaRunning {
+		return errors.ErrSagaNotRunning
+	}
+
+	for key, value := range output {
+		s.data[key] = value
+	}
+	s.steps[s.currentStep].Status = SagaStepCompleted
+	s.steps[s.currentStep].LastError = ""
+	s.currentStep++
+	if s.currentStep == len(s.steps) {
+		s.status = SagaCompleted
+	}
+	s.updatedAt = now.UTC()
+	return nil
+}
+
+// FailStep records a failed attempt of the current step
+// After maxAttempts failed attempts the saga starts compensating, unless the step is retriable (retried until it succeeds)
+func (s *Saga) FailStep(reason string, now time.Time, maxAttempts int, retriable bool) error {
+	if s.status != SagaRunning {
+		return errors.ErrSagaNotRunning
+	}
+
+	step := &s.steps[s.currentStep]
+	step.Attempts++
+	step.LastError = reason
+	s.updatedAt = now.UTC()
+
+	if retriable || step.Attempts < maxAttempts {
+		return nil
+	}
+
+	step.Status = SagaStepFailed
+	s.status = SagaCompensating
+	s.currentStep--
+	s.finishCompensation()
+	return nil
+}
+
+// CompensateStep records that the effects of the current completed step were undone
+func (s *Saga) CompensateStep(now time.Time) error {
+	if s.status != SagaCompensating {
+		return errors.ErrSagaNotCompensating
+	}
+
+	s.steps[s.currentStep].Status = SagaStepCompensated
+	s.steps[s.currentStep].LastError = ""
+	s.currentStep--
+	s.finishCompensation()
+	s.updatedAt = now.UTC()
+	return nil
+}
+
+// FailCompensation records a failed attempt to undo the current completed step; compensations are retried until they succeed
+func (s *Saga) FailCompensation(reason string, now time.Time) error {
+	if s.status != SagaCompensating {
+		return errors.ErrSagaNotCompensating
+	}
+
+	s.steps[s.currentStep].Attempts++
+	s.steps[s.currentStep].LastError = reason
+	s.updatedAt = now.UTC()
+	return nil
+}
+
+// finishCompensation marks the saga compensated once no completed step is left to undo
+func (s *Saga) finishCompensation() {
+	if s.currentStep < 0 {
+		s.currentStep = 0
+		s.status = SagaCompensated
+	}
+}
+
+// sagaStepJSON is the persisted form of a SagaStepState
+type sagaStepJSON struct {
+	Name      string         `json:"name"`
+	Status    SagaStepStatus `json:"status"`
+	Attempts  int            `json:"attempts,omitempty"`
+	LastError string         `json:"lastError,omitempty"`
+}
+
+// sagaJSON is the persisted form of a Saga
+type sagaJSON struct {
+	ID          string            `json:"id"`
+	Type        string            `json:"type"`
+	Reference   string            `json:"reference"`
+	Status      SagaStatus        `json:"status"`
+	Data        map[string]string `json:"data,omitempty"`
+	Steps       []sagaStepJSON    `json:"steps"`
+	CurrentStep int               `json:"currentStep"`
+	CreatedAt   time.Time         `json:"createdAt"`
+	UpdatedAt   time.Time         `json:"updatedAt"`
+}
+
+// MarshalJSON implements custom JSON marshaling for Saga
+func (s *Saga) MarshalJSON() ([]byte, error) {
+	steps := make([]sagaStepJSON, len(s.steps))
+	for i, step := range s.steps {
+		steps[i] = sagaStepJSON(step)
+	}
+
+	return json.Marshal(sagaJSON{
+		ID:          s.id,
+		Type:        s.sagaType,
+		Reference:   s.reference,
+		Status:      s.status,
+		Data:        s.data,
+		Steps:       steps,
+		CurrentStep: s.currentStep,
+		CreatedAt:   s.createdAt,
+		UpdatedAt:   s.updatedAt,
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for Saga
+func (s *Saga) UnmarshalJSON(data []byte) error {
+	var jsonSaga sagaJSON
+	if err := json.Unmarshal(data, &jsonSaga); err != nil {
+		return err
+	}
+
+	s.id = jsonSaga.ID
+	s.sagaType = jsonSaga.Type
+	s.reference = jsonSaga.Reference
+	s.status = jsonSaga.Status
+	s.data = jsonSaga.Data
+	if s.data == nil {
+		s.data = make(map[string]string)
+	}
+	s.steps = make([]SagaStepState, len(jsonSaga.Steps))
+	for i, step := range jsonSaga.Steps {
+		s.steps[i] = SagaStepState(step)
+	}
+	s.currentStep = jsonSaga.CurrentStep
+	s.createdAt = jsonSaga.CreatedAt
+	s.updatedAt = jsonSaga.UpdatedAt
+
+	return nil
+}
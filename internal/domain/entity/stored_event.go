@@ -0,0 +1,120 @@
+package entity
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+)
+
+// StoredEventTenantHeader is the message header naming the tenant an event belongs to
+const StoredEventTenantHeader = "tenant_id"
+
+// StoredEvent is an entry of the append-only event store: a domain event as published on the message bus,
+// kept for audits and downstream backfills independently of the message bus retention
+type StoredEvent struct {
+	sequence    int64
+	eventType   string // Topic the event was published on (e.g. "billing.invoices.recurring_issued")
+	aggregateID string // Key of the message: ID of the entity the event is about
+	payload     json.RawMessage
+	headers     map[string]string
+	occurredAt  time.Time
+}
+
+// NewStoredEvent creates an event store entry with the allocated sequence number
+func NewStoredEvent(sequence int64, eventType, aggregateID string, payload []byte, headers map[string]string, occurredAt time.Time) (*StoredEvent, error) {
+	eventType = strings.TrimSpace(eventType)
+	if eventType == "" {
+		return nil, errors.NewValidationError("type", eventType, errors.ValidationRequired, "event type is required")
+	}
+	if !json.Valid(payload) {
+		return nil, errors.NewValidationError("payload", "", errors.ValidationFormat, "payload must be valid JSON")
+	}
+
+	var copied map[string]string
+	if len(headers) > 0 {
+		copied = make(map[string]string, len(headers))
+		for key, value := range headers {
+			copied[key] = value
+		}
+	}
+
+	return &StoredEvent{
+		sequence:    sequence,
+		eventType:   eventType,
+		aggregateID: aggregateID,
+		payload:     append(json.RawMessage(nil), payload...),
+		headers:     copied,
+		occurredAt:  occurredAt.UTC(),
+	}, nil
+}
+
+// Getters
+func (e *StoredEvent) Sequence() int64 {
+	return e.sequence
+}
+
+func (e *StoredEvent) Type() string {
+	return e.eventType
+}
+
+func (e *StoredEvent) AggregateID() string {
+	return e.aggregateID
+}
+
+func (e *StoredEvent) Payload() json.RawMessage {
+	return e.payload
+}
+
+func (e *StoredEvent) Headers() map[string]string {
+	return e.headers
+}
+
+func (e *StoredEvent) OccurredAt() time.Time {
+	return e.occurredAt
+}
+
+// TenantID returns the tenant named by the StoredEventTenantHeader of the event, empty when it has none
+func (e *StoredEvent) TenantID() string {
+	return e.headers[StoredEventTenantHeader]
+}
+
+// storedEventJSON is the persisted form of a StoredEvent
+type storedEventJSON struct {
+	Sequence    int64             `json:"sequence"`
+	Type        string            `json:"type"`
+	AggregateID string            `json:"aggregateId"`
+	Payload     json.RawMessage   `json:"payload"`
+	Headers     map[string]string `json:"headers,omitempty"`
+	OccurredAt  time.Time         `json:"occurredAt"`
+}
+
+// MarshalJSON implements custom JSON marshaling for StoredEvent
+func (e *StoredEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(storedEventJSON{
+		Sequence:    e.sequence,
+		Type:        e.eventType,
+		AggregateID: e.aggregateID,
+		Payload:     e.payload,
+		Headers:     e.headers,
+		OccurredAt:  e.occurredAt,
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for StoredEvent
+func (e *StoredEvent) UnmarshalJSON(data []byte) error {
+	var jsonEvent storedEventJSON
+	if err := json.Unmarshal(data, &jsonEvent); err != nil {
+		return err
+	}
+
+	e.sequence = jsonEvent.Sequence
+	e.eventType = jsonEvent.Type
+	e.aggregateID = jsonEvent.AggregateID
+	e.payload = jsonEvent.Payload
+	e.headers = jsonEvent.Headers
+	e.occurredAt = jsonEvent.OccurredAt
+
+	return nil
+}
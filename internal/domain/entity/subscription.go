@@ -0,0 +1,569 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/google/uuid"
+)
+
+// SubscriptionStatus is the lifecycle state of a subscription
+type SubscriptionStatus string
+
+const (
+	SubscriptionActive   SubscriptionStatus = "active"
+	SubscriptionPaused   SubscriptionStatus = "paused"
+	SubscriptionCanceled SubscriptionStatus = "canceled"
+)
+
+// Subscription bills a client for a plan at the start of every interval, in advance
+// Billing dates are derived from the start date, so month-end dates never drift (Jan 31, Feb 28, Mar 31)
+type Subscription struct {
+	id              string
+	tenantID        string // Tenant the subscription was created for (empty when created without a tenant)
+	clientID        string
+	plan            string // Name of the plan, printed on the invoice line
+	currency        string
+	unitAmount      int64                              // Price of one unit of the plan per interval, in minor units of the currency
+	quantity        int64                              // Units (seats, licenses) billed
+	pricing         *valueobject.PriceScheme           // Tiered price of the quantity (nil: unit amount times quantity)
+	usagePrices     map[string]valueobject.PriceScheme // Price of the metered usage of each metric, billed in arrears
+	taxRateBps      int64                              // Tax rate of the invoice line (2000 = 20%, 0 = not taxed)
+	interval        RecurrenceFrequency
+	startDate       time.Time // First billing date
+	nextPeriod      int       // Index of the next billing date (skips dates missed while paused)
+	status          SubscriptionStatus
+	billedCount     int
+	lastInvoiceID   string // Invoice of the last billed period
+	prorationCredit int64  // Credit owed by mid-period downgrades, deducted from the next billed periods
+	lastBilledAt    *time.Time
+	canceledAt      *time.Time
+	createdAt       time.Time
+	updatedAt       time.Time
+}
+
+// NewSubscription creates an active subscription with validation
+func NewSubscription(clientID, plan, currency string, unitAmount, quantity, taxRateBps int64, interval RecurrenceFrequency, startDate time.Time) (*Subscription, error) {
+	clientID = strings.TrimSpace(clientID)
+	if clientID == "" {
+		return nil, errors.NewValidationError("client_id", clientID, errors.ValidationRequired, "client ID is required")
+	}
+
+	money, err := valueobject.NewMoney(0, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	switch interval {
+	case RecurrenceWeekly, RecurrenceMonthly, RecurrenceQuarterly, RecurrenceYearly:
+	default:
+		return nil, errors.NewValidationError("interval", interval, errors.ValidationFormat, "interval must be one of: weekly, monthly, quarterly, yearly")
+	}
+
+	if startDate.IsZero() {
+		return nil, errors.NewValidationError("start_date", startDate, errors.ValidationRequired, "start date is required")
+	}
+
+	now := time.Now().UTC()
+	subscription := &Subscription{
+		id:        uuid.New().String(),
+		clientID:  clientID,
+		currency:  money.Currency(),
+		interval:  interval,
+		startDate: startDate.UTC(),
+		status:    SubscriptionActive,
+		createdAt: now,
+		updatedAt: now,
+	}
+	if err := subscription.applyPlan(plan, unitAmount, quantity, taxRateBps); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// ChangePlan replaces the plan, price and quantity billed from the next billing date on
+// Periods already billed are unaffected; canceled subscriptions cannot change
+func (s *Subscription) ChangePlan(plan string, unitAmount, quantity, taxRateBps int64) error {
+	if s.status == SubscriptionCanceled {
+		return errors.ErrSubscriptionCanceled
+	}
+	if err := s.applyPlan(plan, unitAmount, quantity, taxRateBps); err != nil {
+		return err
+	}
+	s.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// ChangePricing prices the quantity with a tiered, volume or graduated scheme instead of the unit amount (nil reverts
+// to the unit amount), from the next billing date on
+func (s *Subscription) ChangePricing(pricing *valueobject.PriceScheme) error {
+	if s.status == SubscriptionCanceled {
+		return errors.ErrSubscriptionCanceled
+	}
+	if pricing != nil {
+		if pricing.Config().Currency != s.currency {
+			return errors.NewValidationError("pricing.currency", pricing.Config().Currency, errors.ValidationFormat, "pricing must use the subscription currency")
+		}
+		if _, err := pricing.Rate(s.quantity); err != nil {
+			return errors.NewValidationError("quantity", s.quantity, errors.ValidationRange, "subscription amount is out of range")
+		}
+	}
+	s.pricing = pricing
+	s.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// ChangeUsagePrices replaces the prices of metered usage per metric, from the next billing date on
+// Usage of metrics without a price is recorded but not billed
+func (s *Subscription) ChangeUsagePrices(prices map[string]valueobject.PriceScheme) error {
+	if s.status == SubscriptionCanceled {
+		return errors.ErrSubscriptionCanceled
+	}
+	usagePrices := make(map[string]valueobject.PriceScheme, len(prices))
+	for metric, price := range prices {
+		metric = strings.TrimSpace(metric)
+		if metric == "" || len(metric) > MaxUsageMetricLength {
+			return errors.NewValidationError("usage_prices", metric, errors.ValidationLength, "usage metrics must be between 1 and 100 characters")
+		}
+		if price.Config().Currency != s.currency {
+			return errors.NewValidationError("usage_prices."+metric+".currency", price.Config().Currency, errors.ValidationFormat, "usage prices must use the subscription currency")
+		}
+		usagePrices[metric] = price
+	}
+	s.usagePrices = usagePrices
+	s.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// applyPlan validates and sets the billed plan
+func (s *Subscription) applyPlan(plan string, unitAmount, quantity, taxRateBps int64) error {
+	plan = strings.TrimSpace(plan)
+	if plan == "" {
+		return errors.NewValidationError("plan", plan, errors.ValidationRequired, "plan is required")
+	}
+	if len(plan) > 200 {
+		return errors.NewValidationError("plan", plan, errors.ValidationLength, "plan must be at most 200 characters")
+	}
+	if unitAmount < 0 {
+		return errors.NewValidationError("unit_amount", unitAmount, errors.ValidationRange, "unit amount must not be negative")
+	}
+	if quantity <= 0 {
+		return errors.NewValidationError("quantity", quantity, errors.ValidationRange, "quantity must be positive")
+	}
+	if taxRateBps < 0 || taxRateBps > 10000 {
+		return errors.NewValidationError("tax_rate_bps", taxRateBps, errors.ValidationRange, "tax rate must be between 0 and 10000 basis points")
+	}
+	unit, err := valueobject.NewMoney(unitAmount, s.currency)
+	if err != nil {
+		return err
+	}
+	if _, err := unit.MultiplyRatio(quantity, 1); err != nil {
+		return errors.NewValidationError("quantity", quantity, errors.ValidationRange, "subscription amount is out of range")
+	}
+	if s.pricing != nil {
+		if _, err := s.pricing.Rate(quantity); err != nil {
+			return errors.NewValidationError("quantity", quantity, errors.ValidationRange, "subscription amount is out of range")
+		}
+	}
+
+	s.plan = plan
+	s.unitAmount = unitAmount
+	s.quantity = quantity
+	s.taxRateBps = taxRateBps
+	return nil
+}
+
+// Getters
+func (s *Subscription) ID() string {
+	return s.id
+}
+
+func (s *Subscription) TenantID() string {
+	return s.tenantID
+}
+
+func (s *Subscription) ClientID() string {
+	return s.clientID
+}
+
+func (s *Subscription) Plan() string {
+	return s.plan
+}
+
+func (s *Subscription) Currency() string {
+	return s.currency
+}
+
+func (s *Subscription) UnitAmount() int64 {
+	return s.unitAmount
+}
+
+func (s *Subscription) Quantity() int64 {
+	return s.quantity
+}
+
+func (s *Subscription) Pricing() *valueobject.PriceScheme {
+	return s.pricing
+}
+
+// UsagePrices returns the prices of metered usage per metric
+func (s *Subscription) UsagePrices() map[string]valueobject.PriceScheme {
+	prices := make(map[string]valueobject.PriceScheme, len(s.usagePrices))
+	for metric, price := range s.usagePrices {
+		prices[metric] = price
+	}
+	return prices
+}
+
+func (s *Subscription) TaxRateBps() int64 {
+	return s.taxRateBps
+}
+
+func (s *Subscription) Interval() RecurrenceFrequency {
+	return s.interval
+}
+
+func (s *Subscription) StartDate() time.Time {
+	return s.startDate
+}
+
+func (s *Subscription) Status() SubscriptionStatus {
+	return s.status
+}
+
+func (s *Subscription) BilledCount() int {
+	return s.billedCount
+}
+
+func (s *Subscription) LastInvoiceID() string {
+	return s.lastInvoiceID
+}
+
+func (s *Subscription) ProrationCredit() int64 {
+	return s.prorationCredit
+}
+
+func (s *Subscription) LastBilledAt() *time.Time {
+	return s.lastBilledAt
+}
+
+func (s *Subscription) CanceledAt() *time.Time {
+	return s.canceledAt
+}
+
+func (s *Subscription) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+func (s *Subscription) UpdatedAt() time.Time {
+	return s.updatedAt
+}
+
+// NextBillingDate returns the date the next period is billed (the start of that period)
+func (s *Subscription) NextBillingDate() time.Time {
+	return recurrenceDate(s.startDate, s.interval, s.nextPeriod)
+}
+
+// NextPeriodEnd returns the last day of the period billed on the next billing date
+func (s *Subscription) NextPeriodEnd() time.Time {
+	return recurrenceDate(s.startDate, s.interval, s.nextPeriod+1).AddDate(0, 0, -1)
+}
+
+// BilledPeriod returns the already billed period containing the given time, with its exclusive end
+// ok is false before the first billing date, once the subscription is behind schedule, and for periods skipped while
+// paused, which were never billed
+func (s *Subscription) BilledPeriod(at time.Time) (start, end time.Time, ok bool) {
+	if s.nextPeriod == 0 || s.lastBilledAt == nil {
+		return time.Time{}, time.Time{}, false
+	}
+	start = recurrenceDate(s.startDate, s.interval, s.nextPeriod-1)
+	end = s.NextBillingDate()
+	if at.Before(start) || !at.Before(end) || s.lastBilledAt.Before(start) {
+		return time.Time{}, time.Time{}, false
+	}
+	return start, end, true
+}
+
+// UsagePeriod returns the period whose metered usage is billed on the next billing date, with its exclusive end: the
+// period before it, since usage is billed in arrears (ok is false on the first billing date)
+func (s *Subscription) UsagePeriod() (start, end time.Time, ok bool) {
+	if s.nextPeriod == 0 {
+		return time.Time{}, time.Time{}, false
+	}
+	return recurrenceDate(s.startDate, s.interval, s.nextPeriod-1), s.NextBillingDate(), true
+}
+
+// IsDue checks if the next period should be billed at the given time (only active subscriptions are billed)
+func (s *Subscription) IsDue(now time.Time) bool {
+	return s.status == SubscriptionActive && !now.Before(s.NextBillingDate())
+}
+
+// Amount returns the price of one period (unit amount times quantity, or the rated quantity), before tax
+func (s *Subscription) Amount() (valueobject.Money, error) {
+	if s.pricing != nil {
+		rating, err := s.pricing.Rate(s.quantity)
+		if err != nil {
+			return valueobject.Money{}, err
+		}
+		return rating.Total, nil
+	}
+	unit, err := valueobject.NewMoney(s.unitAmount, s.currency)
+	if err != nil {
+		return valueobject.Money{}, err
+	}
+	return unit.MultiplyRatio(s.quantity, 1)
+}
+
+// PeriodDescription returns the invoice line description of the next period, e.g. "Pro (2026-01-01 to 2026-01-31)"
+func (s *Subscription) PeriodDescription() string {
+	return fmt.Sprintf("%s (%s to %s)", s.plan, s.NextBillingDate().Format("2006-01-02"), s.NextPeriodEnd().Format("2006-01-02"))
+}
+
+// MarkBilled records that the next period was billed with the given invoice
+func (s *Subscription) MarkBilled(invoiceID string, at time.Time) {
+	billedAt := at.UTC()
+	s.nextPeriod++
+	s.billedCount++
+	s.lastInvoiceID = invoiceID
+	s.lastBilledAt = &billedAt
+	s.updatedAt = time.Now().UTC()
+}
+
+// AddProrationCredit records a credit owed to the client, deducted from the next billed periods
+func (s *Subscription) AddProrationCredit(credit valueobject.Money) error {
+	if credit.IsNegative() {
+		return errors.NewValidationError("proration_credit", credit.Amount(), errors.ValidationRange, "proration credit must not be negative")
+	}
+	current, err := valueobject.NewMoney(s.prorationCredit, s.currency)
+	if err != nil {
+		return err
+	}
+	total, err := current.Add(credit)
+	if err != nil {
+		return err
+	}
+	s.prorationCredit = total.Amount()
+	s.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// ApplyProrationCredit deducts as much of the proration credit as possible from the price of a period and returns
+// the amount left to bill; the rest of the credit carries over to the following periods
+func (s *Subscription) ApplyProrationCredit(amount valueobject.Money) (valueobject.Money, error) {
+	credit, err := valueobject.NewMoney(s.prorationCredit, s.currency)
+	if err != nil {
+		return valueobject.Money{}, err
+	}
+	applied, err := credit.Min(amount)
+	if err != nil {
+		return valueobject.Money{}, err
+	}
+	if !applied.IsPositive() {
+		return amount, nil
+	}
+	due, err := amount.Subtract(applied)
+	if err != nil {
+		return valueobject.Money{}, err
+	}
+	s.prorationCredit -= applied.Amount()
+	s.updatedAt = time.Now().UTC()
+	return due, nil
+}
+
+// AssignTenant sets the tenant the invoices of the subscription are created for
+func (s *Subscription) AssignTenant(tenantID string) {
+	s.tenantID = strings.TrimSpace(tenantID)
+	s.updatedAt = time.Now().UTC()
+}
+
+// Pause stops billing until the subscription is resumed
+func (s *Subscription) Pause() error {
+	if s.status != SubscriptionActive {
+		return errors.ErrSubscriptionNotActive
+	}
+	s.status = SubscriptionPaused
+	s.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// Resume restarts billing; billing dates that passed while paused are skipped
+func (s *Subscription) Resume(now time.Time) error {
+	if s.status != SubscriptionPaused {
+		return errors.ErrSubscriptionNotPaused
+	}
+	for s.NextBillingDate().Before(now) {
+		s.nextPeriod++
+	}
+	s.status = SubscriptionActive
+	s.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// Cancel ends the subscription; periods already billed are not refunded
+func (s *Subscription) Cancel(at time.Time) error {
+	if s.status == SubscriptionCanceled {
+		return errors.ErrSubscriptionCanceled
+	}
+	canceledAt := at.UTC()
+	s.status = SubscriptionCanceled
+	s.canceledAt = &canceledAt
+	s.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// priceTierJSON is the persisted form of a PriceTier
+type priceTierJSON struct {
+	UpTo       int64 `json:"upTo,omitempty"`
+	UnitAmount int64 `json:"unitAmount,omitempty"`
+	FlatAmount int64 `json:"flatAmount,omitempty"`
+}
+
+// priceSchemeJSON is the persisted form of a PriceScheme
+type priceSchemeJSON struct {
+	Model      valueobject.PricingModel `json:"model"`
+	UnitAmount int64                    `json:"unitAmount,omitempty"`
+	UnitsPer   int64                    `json:"unitsPer"`
+	Tiers      []priceTierJSON          `json:"tiers,omitempty"`
+}
+
+// toPriceSchemeJSON converts a price scheme to its persisted form (nil stays nil)
+func toPriceSchemeJSON(scheme *valueobject.PriceScheme) *priceSchemeJSON {
+	if scheme == nil {
+		return nil
+	}
+	config := scheme.Config()
+	tiers := make([]priceTierJSON, len(config.Tiers))
+	for i, tier := range config.Tiers {
+		tiers[i] = priceTierJSON(tier)
+	}
+	return &priceSchemeJSON{Model: config.Model, UnitAmount: config.UnitAmount, UnitsPer: config.UnitsPer, Tiers: tiers}
+}
+
+// fromPriceSchemeJSON rebuilds a persisted price scheme in a currency (nil stays nil)
+func fromPriceSchemeJSON(persisted *priceSchemeJSON, currency string) (*valueobject.PriceScheme, error) {
+	if persisted == nil {
+		return nil, nil
+	}
+	tiers := make([]valueobject.PriceTier, len(persisted.Tiers))
+	for i, tier := range persisted.Tiers {
+		tiers[i] = valueobject.PriceTier(tier)
+	}
+	scheme, err := valueobject.NewPriceScheme(valueobject.PriceSchemeConfig{
+		Model:      persisted.Model,
+		Currency:   currency,
+		UnitAmount: persisted.UnitAmount,
+		UnitsPer:   persisted.UnitsPer,
+		Tiers:      tiers,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &scheme, nil
+}
+
+// subscriptionJSON is the persisted form of a Subscription
+type subscriptionJSON struct {
+	ID              string                      `json:"id"`
+	TenantID        string                      `json:"tenantId,omitempty"`
+	ClientID        string                      `json:"clientId"`
+	Plan            string                      `json:"plan"`
+	Currency        string                      `json:"currency"`
+	UnitAmount      int64                       `json:"unitAmount"`
+	Quantity        int64                       `json:"quantity"`
+	Pricing         *priceSchemeJSON            `json:"pricing,omitempty"`
+	UsagePrices     map[string]*priceSchemeJSON `json:"usagePrices,omitempty"`
+	TaxRateBps      int64                       `json:"taxRateBps,omitempty"`
+	Interval        RecurrenceFrequency         `json:"interval"`
+	StartDate       time.Time                   `json:"startDate"`
+	NextPeriod      int                         `json:"nextPeriod"`
+	Status          SubscriptionStatus          `json:"status"`
+	BilledCount     int                         `json:"billedCount"`
+	LastInvoiceID   string                      `json:"lastInvoiceId,omitempty"`
+	ProrationCredit int64                       `json:"prorationCredit,omitempty"`
+	LastBilledAt    *time.Time                  `json:"lastBilledAt,omitempty"`
+	CanceledAt      *time.Time                  `json:"canceledAt,omitempty"`
+	CreatedAt       time.Time                   `json:"createdAt"`
+	UpdatedAt       time.Time                   `json:"updatedAt"`
+}
+
+// MarshalJSON implements custom JSON marshaling for Subscription
+func (s *Subscription) MarshalJSON() ([]byte, error) {
+	var usagePricesJSON map[string]*priceSchemeJSON
+	if len(s.usagePrices) > 0 {
+		usagePricesJSON = make(map[string]*priceSchemeJSON, len(s.usagePrices))
+		for metric, price := range s.usagePrices {
+			usagePricesJSON[metric] = toPriceSchemeJSON(&price)
+		}
+	}
+	return json.Marshal(subscriptionJSON{
+		ID:              s.id,
+		TenantID:        s.tenantID,
+		ClientID:        s.clientID,
+		Plan:            s.plan,
+		Currency:        s.currency,
+		UnitAmount:      s.unitAmount,
+		Quantity:        s.quantity,
+		Pricing:         toPriceSchemeJSON(s.pricing),
+		UsagePrices:     usagePricesJSON,
+		TaxRateBps:      s.taxRateBps,
+		Interval:        s.interval,
+		StartDate:       s.startDate,
+		NextPeriod:      s.nextPeriod,
+		Status:          s.status,
+		BilledCount:     s.billedCount,
+		LastInvoiceID:   s.lastInvoiceID,
+		ProrationCredit: s.prorationCredit,
+		LastBilledAt:    s.lastBilledAt,
+		CanceledAt:      s.canceledAt,
+		CreatedAt:       s.createdAt,
+		UpdatedAt:       s.updatedAt,
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for Subscription
+func (s *Subscription) UnmarshalJSON(data []byte) error {
+	var jsonSubscription subscriptionJSON
+	if err := json.Unmarshal(data, &jsonSubscription); err != nil {
+		return err
+	}
+
+	s.id = jsonSubscription.ID
+	s.tenantID = jsonSubscription.TenantID
+	s.clientID = jsonSubscription.ClientID
+	s.plan = jsonSubscription.Plan
+	s.currency = jsonSubscription.Currency
+	s.unitAmount = jsonSubscription.UnitAmount
+	s.quantity = jsonSubscription.Quantity
+	pricing, err := fromPriceSchemeJSON(jsonSubscription.Pricing, jsonSubscription.Currency)
+	if err != nil {
+		return err
+	}
+	s.pricing = pricing
+	s.usagePrices = make(map[string]valueobject.PriceScheme, len(jsonSubscription.UsagePrices))
+	for metric, persisted := range jsonSubscription.UsagePrices {
+		price, err := fromPriceSchemeJSON(persisted, jsonSubscription.Currency)
+		if err != nil {
+			return err
+		}
+		if price != nil {
+			s.usagePrices[metric] = *price
+		}
+	}
+	s.taxRateBps = jsonSubscription.TaxRateBps
+	s.interval = jsonSubscription.Interval
+	s.startDate = jsonSubscription.StartDate
+	s.nextPeriod = jsonSubscription.NextPeriod
+	s.status = jsonSubscription.Status
+	s.billedCount = jsonSubscription.BilledCount
+	s.lastInvoiceID = jsonSubscription.LastInvoiceID
+	s.prorationCredit = jsonSubscription.ProrationCredit
+	s.lastBilledAt = jsonSubscription.LastBilledAt
+	s.canceledAt = jsonSubscription.CanceledAt
+	s.createdAt = jsonSubscription.CreatedAt
+	s.updatedAt = jsonSubscription.UpdatedAt
+	return nil
+}
@@ -0,0 +1,203 @@
+package entity
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+)
+
+// Bounds of a tenant rule set
+const (
+	maxTenantRules             = 20
+	MaxTenantRuleExpressionLen = 1000
+	maxTenantRuleMessageLen    = 300
+)
+
+// tenantRuleNamePattern is the form of tenant rule names (reported in refusals)
+var tenantRuleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+// TenantRule is a business rule a tenant configures as an expression: operations are refused when it is false
+type TenantRule struct {
+	name       string
+	operation  string
+	expression string
+	message    string
+}
+
+// NewTenantRule creates a tenant rule checked before operation (e.g. "invoice.create")
+// The expression is only checked for presence and length here, the application service compiles it
+func NewTenantRule(name, operation, expression, message string) (TenantRule, error) {
+	name = strings.TrimSpace(name)
+	operation = strings.TrimSpace(operation)
+	expression = strings.TrimSpace(expression)
+	message = strings.TrimSpace(message)
+
+	if name == "" {
+		return TenantRule{}, errors.NewValidationError("name", name, errors.ValidationRequired, "name is required")
+	}
+	if !tenantRuleNamePattern.MatchString(name) {
+		return TenantRule{}, errors.NewValidationError("name", name, errors.ValidationFormat, "name must be a lower case identifier of at most 64 characters")
+	}
+	if operation == "" {
+		return TenantRule{}, errors.NewValidationError("operation", operation, errors.ValidationRequired, "operation is required")
+	}
+	if expression == "" {
+		return TenantRule{}, errors.NewValidationError("expression", expression, errors.ValidationRequired, "expression is required")
+	}
+	if len(expression) > MaxTenantRuleExpressionLen {
+		return TenantRule{}, errors.NewValidationError("expression", expression, errors.ValidationLength, "expression must be at most 1000 characters")
+	}
+	if len(message) > maxTenantRuleMessageLen {
+		return TenantRule{}, errors.NewValidationError("message", message, errors.ValidationLength, "message must be at most 300 characters")
+	}
+
+	return TenantRule{
+		name:       name,
+		operation:  operation,
+		expression: expression,
+		message:    message,
+	}, nil
+}
+
+// Name returns the name identifying the rule in the tenant set
+func (r TenantRule) Name() string {
+	return r.name
+}
+
+// Operation returns the operation the rule is checked before
+func (r TenantRule) Operation() string {
+	return r.operation
+}
+
+// Expression returns the condition operations must meet
+func (r TenantRule) Expression() string {
+	return r.expression
+}
+
+// Message returns the explanation given when the rule refuses an operation (empty for a generic one)
+func (r TenantRule) Message() string {
+	return r.message
+}
+
+// TenantRuleSet is the set of business rules configured for a tenant
+type TenantRuleSet struct {
+	tenantID  string
+	rules     []TenantRule
+	updatedAt time.Time
+}
+
+// NewTenantRuleSet creates the rule set of a tenant
+// Rule names are unique within the set
+func NewTenantRuleSet(tenantID string, rules []TenantRule) (*TenantRuleSet, error) {
+	tenantID = strings.TrimSpace(tenantID)
+	if tenantID == "" {
+		return nil, errors.NewValidationError("tenant_id", tenantID, errors.ValidationRequired, "tenant ID is required")
+	}
+
+	if len(rules) == 0 {
+		return nil, errors.NewValidationError("rules", "", errors.ValidationRequired, "at least one rule is required")
+	}
+	if len(rules) > maxTenantRules {
+		return nil, errors.NewValidationError("rules", len(rules), errors.ValidationRange, "at most 20 rules are allowed")
+	}
+	names := make(map[string]bool, len(rules))
+	for i, rule := range rules {
+		if names[rule.name] {
+			field := "rules[" + strconv.Itoa(i) + "].name"
+			return nil, errors.NewValidationError(field, rule.name, errors.ValidationFormat, "rule names must be unique")
+		}
+		names[rule.name] = true
+	}
+
+	return &TenantRuleSet{
+		tenantID:  tenantID,
+		rules:     rules,
+		updatedAt: time.Now().UTC(),
+	}, nil
+}
+
+// Getters
+func (s *TenantRuleSet) TenantID() string {
+	return s.tenantID
+}
+
+func (s *TenantRuleSet) Rules() []TenantRule {
+	return s.rules
+}
+
+func (s *TenantRuleSet) UpdatedAt() time.Time {
+	return s.updatedAt
+}
+
+// RulesFor returns the rules checked before an operation, in the order of the set
+func (s *TenantRuleSet) RulesFor(operation string) []TenantRule {
+	var rules []TenantRule
+	for _, rule := range s.rules {
+		if rule.operation == operation {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// tenantRuleJSON is the persisted form of a TenantRule
+type tenantRuleJSON struct {
+	Name       string `json:"name"`
+	Operation  string `json:"operation"`
+	Expression string `json:"expression"`
+	Message    string `json:"message,omitempty"`
+}
+
+// tenantRuleSetJSON is the persisted form of a TenantRuleSet
+type tenantRuleSetJSON struct {
+	TenantID  string           `json:"tenantId"`
+	Rules     []tenantRuleJSON `json:"rules"`
+	UpdatedAt time.Time        `json:"updatedAt"`
+}
+
+// MarshalJSON implements custom JSON marshaling for TenantRuleSet
+func (s *TenantRuleSet) MarshalJSON() ([]byte, error) {
+	rules := make([]tenantRuleJSON, len(s.rules))
+	for i, rule := range s.rules {
+		rules[i] = tenantRuleJSON{
+			Name:       rule.name,
+			Operation:  rule.operation,
+			Expression: rule.expression,
+			Message:    rule.message,
+		}
+	}
+
+	return json.Marshal(tenantRuleSetJSON{
+		TenantID:  s.tenantID,
+		Rules:     rules,
+		UpdatedAt: s.updatedAt,
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for TenantRuleSet
+func (s *TenantRuleSet) UnmarshalJSON(data []byte) error {
+	var jsonSet tenantRuleSetJSON
+	if err := json.Unmarshal(data, &jsonSet); err != nil {
+		return err
+	}
+
+	rules := make([]TenantRule, len(jsonSet.Rules))
+	for i, stored := range jsonSet.Rules {
+		rules[i] = TenantRule{
+			name:       stored.Name,
+			operation:  stored.Operation,
+			expression: stored.Expression,
+			message:    stored.Message,
+		}
+	}
+
+	s.tenantID = jsonSet.TenantID
+	s.rules = rules
+	s.updatedAt = jsonSet.UpdatedAt
+
+	return nil
+}
@@ -0,0 +1,254 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/google/uuid"
+)
+
+// UploadKind is what an uploaded file is, which decides the job processing it once assembled
+type UploadKind string
+
+const (
+	// UploadBankStatement files are camt.053 or MT940 statements imported by cash application
+	UploadBankStatement UploadKind = "bank_statement"
+)
+
+// UploadStatus is the progress of a resumable upload
+type UploadStatus string
+
+const (
+	// UploadInProgress uploads accept the next chunk until the declared size is received
+	UploadInProgress UploadStatus = "uploading"
+	// UploadPending uploads are complete and wait for the next upload run to process the assembled file
+	UploadPending UploadStatus = "pending"
+	// UploadProcessed uploads were handed to their job, which accepted the file
+	UploadProcessed UploadStatus = "processed"
+	// UploadFailed uploads expired before completion or were refused by their job
+	UploadFailed UploadStatus = "failed"
+)
+
+// Upload is a large file sent in chunks, resumed from the last chunk received after a dropped connection
+// Chunks are kept in the object store until the assembled file is processed; the upload only tracks them
+type Upload struct {
+	id          string
+	kind        UploadKind
+	format      string // Hint passed to the job, e.g. the statement format (empty: detected)
+	size        int64  // Declared size of the whole file
+	offset      int64  // Bytes received so far
+	chunks      int    // Chunks received so far, stored under ChunkKey(0..chunks-1)
+	status      UploadStatus
+	result      string // Summary of the processing, once processed
+	failure     string
+	createdAt   time.Time
+	expiresAt   time.Time // Uploads still in progress at that time are abandoned
+	completedAt *time.Time
+}
+
+// NewUpload starts the upload of a file of the declared size, to be completed before expiresAt
+func NewUpload(kind UploadKind, format string, size, maxSize int64, expiresAt time.Time) (*Upload, error) {
+	switch kind {
+	case UploadBankStatement:
+	case "":
+		return nil, errors.NewValidationError("kind", kind, errors.ValidationRequired, "upload kind is required")
+	default:
+		return nil, errors.NewValidationError("kind", kind, errors.ValidationFormat, "kind must be one of: bank_statement")
+	}
+	if size <= 0 {
+		return nil, errors.NewValidationError("size", size, errors.ValidationRange, "size must be positive")
+	}
+	if size > maxSize {
+		return nil, errors.NewValidationError("size", size, errors.ValidationRange, fmt.Sprintf("uploads must be at most %d bytes", maxSize))
+	}
+
+	return &Upload{
+		id:        uuid.New().String(),
+		kind:      kind,
+		format:    strings.TrimSpace(format),
+		size:      size,
+		status:    UploadInProgress,
+		createdAt: time.Now().UTC(),
+		expiresAt: expiresAt.UTC(),
+	}, nil
+}
+
+// AppendChunk records a chunk of length bytes sent from offset and returns the object key to store it under
+// Chunks must follow each other: a client resuming an upload asks for the offset first. The upload becomes
+// pending once the declared size is received
+func (u *Upload) AppendChunk(offset, length int64, now time.Time) (string, error) {
+	if u.status != UploadInProgress {
+		violation := errors.NewBusinessRuleError("upload_in_progress", errors.BusinessRuleViolation, fmt.Sprintf("upload is %s and accepts no more chunks", u.status))
+		violation.Context["status"] = string(u.status)
+		return "", violation
+	}
+	if u.IsExpired(now) {
+		return "", errors.NewBusinessRuleError("upload_expiry", errors.BusinessRuleViolation, "upload expired before completion")
+	}
+	if offset != u.offset {
+		conflict := errors.NewBusinessRuleError("upload_offset", errors.BusinessRuleConflict, fmt.Sprintf("chunk sent from offset %d, upload continues from %d", offset, u.offset))
+		conflict.Context["offset"] = strconv.FormatInt(u.offset, 10)
+		return "", conflict
+	}
+	if length <= 0 {
+		return "", errors.NewValidationError("chunk", length, errors.ValidationRequired, "chunk is empty")
+	}
+	if offset+length > u.size {
+		return "", errors.NewValidationError("chunk", length, errors.ValidationRange, fmt.Sprintf("chunk exceeds the declared size of %d bytes", u.size))
+	}
+
+	key := u.ChunkKey(u.chunks)
+	u.chunks++
+	u.offset += length
+	if u.offset == u.size {
+		completedAt := now.UTC()
+		u.status = UploadPending
+		u.completedAt = &completedAt
+	}
+	return key, nil
+}
+
+// ChunkKey returns the object key of the chunk at index
+func (u *Upload) ChunkKey(index int) string {
+	return fmt.Sprintf("%s/%06d", u.id, index)
+}
+
+// ChunkKeys returns the object keys of the chunks received, in order
+func (u *Upload) ChunkKeys() []string {
+	keys := make([]string, u.chunks)
+	for i := range keys {
+		keys[i] = u.ChunkKey(i)
+	}
+	return keys
+}
+
+// IsExpired checks if the upload was abandoned before completion
+func (u *Upload) IsExpired(now time.Time) bool {
+	return u.status == UploadInProgress && now.After(u.expiresAt)
+}
+
+// MarkProcessed records the summary of the job that accepted the assembled file
+func (u *Upload) MarkProcessed(result string, at time.Time) {
+	completedAt := at.UTC()
+	u.result = result
+	u.status = UploadProcessed
+	u.completedAt = &completedAt
+}
+
+// Fail records why the upload was not processed
+func (u *Upload) Fail(reason string, at time.Time) {
+	completedAt := at.UTC()
+	u.failure = reason
+	u.status = UploadFailed
+	u.completedAt = &completedAt
+}
+
+// Getters
+func (u *Upload) ID() string {
+	return u.id
+}
+
+func (u *Upload) Kind() UploadKind {
+	return u.kind
+}
+
+func (u *Upload) Format() string {
+	return u.format
+}
+
+func (u *Upload) Size() int64 {
+	return u.size
+}
+
+func (u *Upload) Offset() int64 {
+	return u.offset
+}
+
+func (u *Upload) Chunks() int {
+	return u.chunks
+}
+
+func (u *Upload) Status() UploadStatus {
+	return u.status
+}
+
+func (u *Upload) Result() string {
+	return u.result
+}
+
+func (u *Upload) Failure() string {
+	return u.failure
+}
+
+func (u *Upload) CreatedAt() time.Time {
+	return u.createdAt
+}
+
+func (u *Upload) ExpiresAt() time.Time {
+	return u.expiresAt
+}
+
+func (u *Upload) CompletedAt() *time.Time {
+	return u.completedAt
+}
+
+// uploadJSON is the persisted form of an Upload
+type uploadJSON struct {
+	ID          string       `json:"id"`
+	Kind        UploadKind   `json:"kind"`
+	Format      string       `json:"format,omitempty"`
+	Size        int64        `json:"size"`
+	Offset      int64        `json:"offset"`
+	Chunks      int          `json:"chunks"`
+	Status      UploadStatus `json:"status"`
+	Result      string       `json:"result,omitempty"`
+	Failure     string       `json:"failure,omitempty"`
+	CreatedAt   time.Time    `json:"createdAt"`
+	ExpiresAt   time.Time    `json:"expiresAt"`
+	CompletedAt *time.Time   `json:"completedAt,omitempty"`
+}
+
+// MarshalJSON implements custom JSON marshaling for Upload
+func (u *Upload) MarshalJSON() ([]byte, error) {
+	return json.Marshal(uploadJSON{
+		ID:          u.id,
+		Kind:        u.kind,
+		Format:      u.format,
+		Size:        u.size,
+		Offset:      u.offset,
+		Chunks:      u.chunks,
+		Status:      u.status,
+		Result:      u.result,
+		Failure:     u.failure,
+		CreatedAt:   u.createdAt,
+		ExpiresAt:   u.expiresAt,
+		CompletedAt: u.completedAt,
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for Upload
+func (u *Upload) UnmarshalJSON(data []byte) error {
+	var jsonUpload uploadJSON
+	if err := json.Unmarshal(data, &jsonUpload); err != nil {
+		return err
+	}
+
+	u.id = jsonUpload.ID
+	u.kind = jsonUpload.Kind
+	u.format = jsonUpload.Format
+	u.size = jsonUpload.Size
+	u.offset = jsonUpload.Offset
+	u.chunks = jsonUpload.Chunks
+	u.status = jsonUpload.Status
+	u.result = jsonUpload.Result
+	u.failure = jsonUpload.Failure
+	u.createdAt = jsonUpload.CreatedAt
+	u.expiresAt = jsonUpload.ExpiresAt
+	u.completedAt = jsonUpload.CompletedAt
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package entity
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/google/uuid"
+)
+
+// Usage record field limits (the storage key combines subscription ID and idempotency key)
+const (
+	MaxUsageSubscriptionIDLength = 100
+	MaxUsageMetricLength         = 100
+	MaxUsageIdempotencyKeyLength = 128
+)
+
+// UsageRecord is a metered usage event (e.g. API calls, gigabytes stored) reported for a subscription
+// Records are immutable once ingested; the idempotency key lets producers retry without double counting
+type UsageRecord struct {
+	id             string
+	subscriptionID string
+	metric         string
+	quantity       int64
+	occurredAt     time.Time
+	idempotencyKey string
+	recordedAt     time.Time
+}
+
+// NewUsageRecord creates a usage record with validation
+func NewUsageRecord(subscriptionID, metric string, quantity int64, occurredAt time.Time, idempotencyKey string) (*UsageRecord, error) {
+	subscriptionID = strings.TrimSpace(subscriptionID)
+	metric = strings.TrimSpace(metric)
+	idempotencyKey = strings.TrimSpace(idempotencyKey)
+
+	if subscriptionID == "" {
+		return nil, errors.NewValidationError("subscription_id", subscriptionID, errors.ValidationRequired, "subscription ID is required")
+	}
+	if len(subscriptionID) > MaxUsageSubscriptionIDLength {
+		return nil, errors.NewValidationError("subscription_id", subscriptionID, errors.ValidationLength, "subscription ID must be at most 100 characters")
+	}
+
+	if metric == "" {
+		return nil, errors.NewValidationError("metric", metric, errors.ValidationRequired, "metric is required")
+	}
+	if len(metric) > MaxUsageMetricLength {
+		return nil, errors.NewValidationError("metric", metric, errors.ValidationLength, "metric must be at most 100 characters")
+	}
+
+	if quantity < 0 {
+		return nil, errors.NewValidationError("quantity", quantity, errors.ValidationRange, "quantity must not be negative")
+	}
+
+	if idempotencyKey == "" {
+		return nil, errors.NewValidationError("idempotency_key", idempotencyKey, errors.ValidationRequired, "idempotency key is required")
+	}
+	if len(idempotencyKey) > MaxUsageIdempotencyKeyLength {
+		return nil, errors.NewValidationError("idempotency_key", idempotencyKey, errors.ValidationLength, "idempotency key must be at most 128 characters")
+	}
+
+	if occurredAt.IsZero() {
+		return nil, errors.NewValidationError("timestamp", occurredAt, errors.ValidationRequired, "timestamp is required")
+	}
+
+	return &UsageRecord{
+		id:             uuid.New().String(),
+		subscriptionID: subscriptionID,
+		metric:         metric,
+		quantity:       quantity,
+		occurredAt:     occurredAt.UTC(),
+		idempotencyKey: idempotencyKey,
+		recordedAt:     time.Now().UTC(),
+	}, nil
+}
+
+// Getters
+func (u *UsageRecord) ID() string {
+	return u.id
+}
+
+func (u *UsageRecord) SubscriptionID() string {
+	return u.subscriptionID
+}
+
+func (u *UsageRecord) Metric() string {
+	return u.metric
+}
+
+func (u *UsageRecord) Quantity() int64 {
+	return u.quantity
+}
+
+func (u *UsageRecord) OccurredAt() time.Time {
+	return u.occurredAt
+}
+
+func (u *UsageRecord) IdempotencyKey() string {
+	return u.idempotencyKey
+}
+
+func (u *UsageRecord) RecordedAt() time.Time {
+	return u.recordedAt
+}
+
+// InPeriod checks if the usage occurred within [start, end)
+func (u *UsageRecord) InPeriod(start, end time.Time) bool {
+	return !u.occurredAt.Before(start) && u.occurredAt.Before(end)
+}
+
+// usageRecordJSON is the persisted form of a UsageRecord
+type usageRecordJSON struct {
+	ID             string    `json:"id"`
+	SubscriptionID string    `json:"subscriptionId"`
+	Metric         string    `json:"metric"`
+	Quantity       int64     `json:"quantity"`
+	OccurredAt     time.Time `json:"occurredAt"`
+	IdempotencyKey string    `json:"idempotencyKey"`
+	RecordedAt     time.Time `json:"recordedAt"`
+}
+
+// MarshalJSON implements custom JSON marshaling for UsageRecord
+func (u *UsageRecord) MarshalJSON() ([]byte, error) {
+	return json.Marshal(usageRecordJSON{
+		ID:             u.id,
+		SubscriptionID: u.subscriptionID,
+		Metric:         u.metric,
+		Quantity:       u.quantity,
+		OccurredAt:     u.occurredAt,
+		IdempotencyKey: u.idempotencyKey,
+		RecordedAt:     u.recordedAt,
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for UsageRecord
+func (u *UsageRecord) UnmarshalJSON(data []byte) error {
+	var jsonRecord usageRecordJSON
+	if err := json.Unmarshal(data, &jsonRecord); err != nil {
+		return err
+	}
+
+	u.id = jsonRecord.ID
+	u.subscriptionID = jsonRecord.SubscriptionID
+	u.metric = jsonRecord.Metric
+	u.quantity = jsonRecord.Quantity
+	u.occurredAt = jsonRecord.OccurredAt
+	u.idempotencyKey = jsonRecord.IdempotencyKey
+	u.recordedAt = jsonRecord.RecordedAt
+
+	return nil
+}
@@ -0,0 +1,228 @@
+package entity
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/google/uuid"
+)
+
+// WebhookEventStatus is the processing state of an inbound webhook event
+type WebhookEventStatus string
+
+const (
+	// WebhookEventPending means the event is queued for processing
+	WebhookEventPending WebhookEventStatus = "pending"
+
+	// WebhookEventProcessed means the event was processed successfully
+	WebhookEventProcessed WebhookEventStatus = "processed"
+
+	// WebhookEventDeadLetter means processing failed too many times and the event waits for an admin retry
+	WebhookEventDeadLetter WebhookEventStatus = "dead_letter"
+
+	// WebhookEventDiscarded means an admin gave up on a dead-lettered event; redeliveries are still dropped
+	WebhookEventDiscarded WebhookEventStatus = "discarded"
+)
+
+// webhookEventNamespace derives webhook event IDs from the provider event ID
+var webhookEventNamespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte("billing-api/webhook-events"))
+
+// WebhookEvent is an event delivered by an external provider (payment gateway, bank, e-invoicing network)
+// Its ID is derived from the provider and the provider's event ID, so redeliveries of an event map to the same record
+type WebhookEvent struct {
+	id          string
+	provider    string
+	eventID     string
+	eventType   string
+	payload     json.RawMessage
+	status      WebhookEventStatus
+	attempts    int
+	lastError   string
+	receivedAt  time.Time
+	failedAt    *time.Time
+	processedAt *time.Time
+}
+
+// WebhookEventID returns the ID of the event a provider identifies as eventID
+func WebhookEventID(provider, eventID string) string {
+	return uuid.NewSHA1(webhookEventNamespace, []byte(provider+"\x00"+eventID)).String()
+}
+
+// NewWebhookEvent creates a pending webhook event with validation
+func NewWebhookEvent(provider, eventID, eventType string, payload []byte, receivedAt time.Time) (*WebhookEvent, error) {
+	provider = strings.TrimSpace(provider)
+	eventID = strings.TrimSpace(eventID)
+	eventType = strings.TrimSpace(eventType)
+
+	if provider == "" {
+		return nil, errors.NewValidationError("provider", provider, errors.ValidationRequired, "provider is required")
+	}
+	if eventID == "" {
+		return nil, errors.NewValidationError("event_id", eventID, errors.ValidationRequired, "event ID is required")
+	}
+	if eventType == "" {
+		return nil, errors.NewValidationError("event_type", eventType, errors.ValidationRequired, "event type is required")
+	}
+	if !json.Valid(payload) {
+		return nil, errors.NewValidationError("payload", "", errors.ValidationFormat, "payload must be valid JSON")
+	}
+	if receivedAt.IsZero() {
+		return nil, errors.NewValidationError("received_at", receivedAt, errors.ValidationRequired, "received time is required")
+	}
+
+	return &WebhookEvent{
+		id:         WebhookEventID(provider, eventID),
+		provider:   provider,
+		eventID:    eventID,
+		eventType:  eventType,
+		payload:    append(json.RawMessage(nil), payload...),
+		status:     WebhookEventPending,
+		receivedAt: receivedAt.UTC(),
+	}, nil
+}
+
+// Getters
+func (e *WebhookEvent) ID() string {
+	return e.id
+}
+
+func (e *WebhookEvent) Provider() string {
+	return e.provider
+}
+
+// EventID returns the ID the provider assigned to the event
+func (e *WebhookEvent) EventID() string {
+	return e.eventID
+}
+
+func (e *WebhookEvent) EventType() string {
+	return e.eventType
+}
+
+func (e *WebhookEvent) Payload() json.RawMessage {
+	return e.payload
+}
+
+func (e *WebhookEvent) Status() WebhookEventStatus {
+	return e.status
+}
+
+// Attempts returns the number of failed processing attempts since the event was (re)queued
+func (e *WebhookEvent) Attempts() int {
+	return e.attempts
+}
+
+// LastError returns the error of the last failed processing attempt
+func (e *WebhookEvent) LastError() string {
+	return e.lastError
+}
+
+func (e *WebhookEvent) ReceivedAt() time.Time {
+	return e.receivedAt
+}
+
+// FailedAt returns the time of the last failed processing attempt
+func (e *WebhookEvent) FailedAt() *time.Time {
+	return e.failedAt
+}
+
+func (e *WebhookEvent) ProcessedAt() *time.Time {
+	return e.processedAt
+}
+
+// MarkProcessed records the successful processing of the event
+func (e *WebhookEvent) MarkProcessed(now time.Time) {
+	processedAt := now.UTC()
+	e.status = WebhookEventProcessed
+	e.lastError = ""
+	e.processedAt = &processedAt
+}
+
+// RecordFailure records a failed processing attempt
+// The event moves to the dead letter queue once maxAttempts attempts failed
+func (e *WebhookEvent) RecordFailure(reason string, now time.Time, maxAttempts int) {
+	failedAt := now.UTC()
+	e.attempts++
+	e.lastError = reason
+	e.failedAt = &failedAt
+	if e.attempts >= maxAttempts {
+		e.status = WebhookEventDeadLetter
+	}
+}
+
+// Requeue moves a dead-lettered event back to the processing queue with a fresh attempt budget
+func (e *WebhookEvent) Requeue() error {
+	if e.status != WebhookEventDeadLetter {
+		return errors.ErrWebhookEventNotDeadLettered
+	}
+
+	e.status = WebhookEventPending
+	e.attempts = 0
+	return nil
+}
+
+// Discard removes a dead-lettered event from the dead letter queue without processing it
+func (e *WebhookEvent) Discard() error {
+	if e.status != WebhookEventDeadLetter {
+		return errors.ErrWebhookEventNotDeadLettered
+	}
+
+	e.status = WebhookEventDiscarded
+	return nil
+}
+
+// webhookEventJSON is the persisted form of a WebhookEvent
+type webhookEventJSON struct {
+	ID          string             `json:"id"`
+	Provider    string             `json:"provider"`
+	EventID     string             `json:"eventId"`
+	EventType   string             `json:"eventType"`
+	Payload     json.RawMessage    `json:"payload"`
+	Status      WebhookEventStatus `json:"status"`
+	Attempts    int                `json:"attempts,omitempty"`
+	LastError   string             `json:"lastError,omitempty"`
+	ReceivedAt  time.Time          `json:"receivedAt"`
+	FailedAt    *time.Time         `json:"failedAt,omitempty"`
+	ProcessedAt *time.Time         `json:"processedAt,omitempty"`
+}
+
+// MarshalJSON implements custom JSON marshaling for WebhookEvent
+func (e *WebhookEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(webhookEventJSON{
+		ID:          e.id,
+		Provider:    e.provider,
+		EventID:     e.eventID,
+		EventType:   e.eventType,
+		Payload:     e.payload,
+		Status:      e.status,
+		Attempts:    e.attempts,
+		LastError:   e.lastError,
+		ReceivedAt:  e.receivedAt,
+		FailedAt:    e.failedAt,
+		ProcessedAt: e.processedAt,
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for WebhookEvent
+func (e *WebhookEvent) UnmarshalJSON(data []byte) error {
+	var jsonEvent webhookEventJSON
+	if err := json.Unmarshal(data, &jsonEvent); err != nil {
+		return err
+	}
+
+	e.id = jsonEvent.ID
+	e.provider = jsonEvent.Provider
+	e.eventID = jsonEvent.EventID
+	e.eventType = jsonEvent.EventType
+	e.payload = jsonEvent.Payload
+	e.status = jsonEvent.Status
+	e.attempts = jsonEvent.Attempts
+	e.lastError = jsonEvent.LastError
+	e.receivedAt = jsonEvent.ReceivedAt
+	e.failedAt = jsonEvent.FailedAt
+	e.processedAt = jsonEvent.ProcessedAt
+
+	return nil
+}
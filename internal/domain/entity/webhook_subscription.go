@@ -0,0 +1,176 @@
+package entity
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/google/uuid"
+)
+
+// maxWebhookEventTypes bounds the event types a subscription selects
+const maxWebhookEventTypes = 50
+
+// WebhookSubscription is an endpoint of a consumer the events of a tenant are delivered to, signed with the
+// subscription secret
+type WebhookSubscription struct {
+	id           string
+	tenantID     string
+	url          string
+	secret       string   // HMAC-SHA256 key deliveries are signed with, shown to the consumer once
+	eventTypes   []string // Event types (topics) delivered; empty delivers every type
+	createdAt    time.Time
+	lastReplayAt *time.Time
+	replayCursor string // Event ID the last replay stopped after, resumed once without waiting for the cooldown
+}
+
+// NewWebhookSubscription creates a subscription of a tenant with a random signing secret
+func NewWebhookSubscription(tenantID, endpointURL string, eventTypes []string) (*WebhookSubscription, error) {
+	tenantID = strings.TrimSpace(tenantID)
+	if tenantID == "" {
+		return nil, errors.NewValidationError("tenant_id", tenantID, errors.ValidationRequired, "tenant ID is required")
+	}
+
+	endpointURL = strings.TrimSpace(endpointURL)
+	if endpointURL == "" {
+		return nil, errors.NewValidationError("url", endpointURL, errors.ValidationRequired, "endpoint URL is required")
+	}
+	parsed, err := url.Parse(endpointURL)
+	if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+		return nil, errors.NewValidationError("url", endpointURL, errors.ValidationFormat, "endpoint URL must be an absolute https URL")
+	}
+	if len(endpointURL) > 2048 {
+		return nil, errors.NewValidationError("url", endpointURL, errors.ValidationLength, "endpoint URL must be at most 2048 characters")
+	}
+
+	if len(eventTypes) > maxWebhookEventTypes {
+		return nil, errors.NewValidationError("event_types", len(eventTypes), errors.ValidationRange, fmt.Sprintf("at most %d event types are allowed", maxWebhookEventTypes))
+	}
+	types := make([]string, 0, len(eventTypes))
+	for index, eventType := range eventTypes {
+		eventType = strings.TrimSpace(eventType)
+		if eventType == "" {
+			return nil, errors.NewValidationError(fmt.Sprintf("event_types[%d]", index), eventType, errors.ValidationRequired, "event type is required")
+		}
+		if !slices.Contains(types, eventType) {
+			types = append(types, eventType)
+		}
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, err
+	}
+
+	return &WebhookSubscription{
+		id:         uuid.New().String(),
+		tenantID:   tenantID,
+		url:        endpointURL,
+		secret:     hex.EncodeToString(buf),
+		eventTypes: types,
+		createdAt:  time.Now().UTC(),
+	}, nil
+}
+
+// Getters
+func (s *WebhookSubscription) ID() string {
+	return s.id
+}
+
+func (s *WebhookSubscription) TenantID() string {
+	return s.tenantID
+}
+
+func (s *WebhookSubscription) URL() string {
+	return s.url
+}
+
+func (s *WebhookSubscription) Secret() string {
+	return s.secret
+}
+
+// EventTypes returns a copy of the event types delivered (empty: every type)
+func (s *WebhookSubscription) EventTypes() []string {
+	return append(make([]string, 0, len(s.eventTypes)), s.eventTypes...)
+}
+
+func (s *WebhookSubscription) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+func (s *WebhookSubscription) LastReplayAt() *time.Time {
+	return s.lastReplayAt
+}
+
+func (s *WebhookSubscription) ReplayCursor() string {
+	return s.replayCursor
+}
+
+// Delivers reports whether events of a type are delivered to the subscription
+func (s *WebhookSubscription) Delivers(eventType string) bool {
+	return len(s.eventTypes) == 0 || slices.Contains(s.eventTypes, eventType)
+}
+
+// StartReplay records that historical events are replayed to the subscription from the given time, consuming the
+// cursor of the previous replay
+func (s *WebhookSubscription) StartReplay(at time.Time) {
+	at = at.UTC()
+	s.lastReplayAt = &at
+	s.replayCursor = ""
+}
+
+// StopReplay records the event ID a replay stopped after, with events remaining to be replayed
+func (s *WebhookSubscription) StopReplay(cursor string) {
+	s.replayCursor = cursor
+}
+
+// webhookSubscriptionJSON is the persisted form of a WebhookSubscription
+type webhookSubscriptionJSON struct {
+	ID           string     `json:"id"`
+	TenantID     string     `json:"tenantId"`
+	URL          string     `json:"url"`
+	Secret       string     `json:"secret"`
+	EventTypes   []string   `json:"eventTypes,omitempty"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	LastReplayAt *time.Time `json:"lastReplayAt,omitempty"`
+	ReplayCursor string     `json:"replayCursor,omitempty"`
+}
+
+// MarshalJSON implements custom JSON marshaling for WebhookSubscription
+func (s *WebhookSubscription) MarshalJSON() ([]byte, error) {
+	return json.Marshal(webhookSubscriptionJSON{
+		ID:           s.id,
+		TenantID:     s.tenantID,
+		URL:          s.url,
+		Secret:       s.secret,
+		EventTypes:   s.eventTypes,
+		CreatedAt:    s.createdAt,
+		LastReplayAt: s.lastReplayAt,
+		ReplayCursor: s.replayCursor,
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for WebhookSubscription
+func (s *WebhookSubscription) UnmarshalJSON(data []byte) error {
+	var jsonSubscription webhookSubscriptionJSON
+	if err := json.Unmarshal(data, &jsonSubscription); err != nil {
+		return err
+	}
+
+	s.id = jsonSubscription.ID
+	s.tenantID = jsonSubscription.TenantID
+	s.url = jsonSubscription.URL
+	s.secret = jsonSubscription.Secret
+	s.eventTypes = jsonSubscription.EventTypes
+	s.createdAt = jsonSubscription.CreatedAt
+	s.lastReplayAt = jsonSubscription.LastReplayAt
+	s.replayCursor = jsonSubscription.ReplayCursor
+
+	return nil
+}
@@ -3,27 +3,36 @@ package errors
 import (
 	"fmt"
 	"strings"
+
+	"github.com/gjaminon-go-labs/billing-api/pkg/billingerrors"
 )
 
 // ErrorCode represents a specific error code for programmatic handling
-type ErrorCode string
+// The codes are those of the public billingerrors package, so API consumers branch on the same values
+type ErrorCode = billingerrors.Code
 
 const (
 	// Validation error codes
-	ValidationRequired ErrorCode = "VALIDATION_REQUIRED"
-	ValidationFormat   ErrorCode = "VALIDATION_FORMAT"
-	ValidationLength   ErrorCode = "VALIDATION_LENGTH"
-	ValidationRange    ErrorCode = "VALIDATION_RANGE"
+	ValidationRequired = billingerrors.ValidationRequired
+	ValidationFormat   = billingerrors.ValidationFormat
+	ValidationLength   = billingerrors.ValidationLength
+	ValidationRange    = billingerrors.ValidationRange
 
 	// Business rule error codes
-	BusinessRuleViolation ErrorCode = "BUSINESS_RULE_VIOLATION"
-	BusinessRuleConflict  ErrorCode = "BUSINESS_RULE_CONFLICT"
+	BusinessRuleViolation = billingerrors.BusinessRuleViolation
+	BusinessRuleConflict  = billingerrors.BusinessRuleConflict
+	BusinessRuleDuplicate = billingerrors.BusinessRuleDuplicate
+	BusinessRuleDependent = billingerrors.BusinessRuleDependent
 
 	// Repository error codes
-	RepositoryNotFound   ErrorCode = "REPOSITORY_NOT_FOUND"
-	RepositoryConnection ErrorCode = "REPOSITORY_CONNECTION"
-	RepositoryConstraint ErrorCode = "REPOSITORY_CONSTRAINT"
-	RepositoryInternal   ErrorCode = "REPOSITORY_INTERNAL"
+	RepositoryNotFound   = billingerrors.RepositoryNotFound
+	RepositoryConnection = billingerrors.RepositoryConnection
+	RepositoryConstraint = billingerrors.RepositoryConstraint
+	RepositoryInternal   = billingerrors.RepositoryInternal
+
+	// Authorization error codes
+	AuthorizationRequired = billingerrors.Unauthorized
+	AuthorizationScope    = billingerrors.ScopeNotGranted
 )
 
 // ValidationError represents input validation failures
@@ -139,6 +148,34 @@ func NewRepositoryError(operation string, code ErrorCode, message string, cause
 	}
 }
 
+// AuthorizationError represents an operation the caller is not allowed to perform
+type AuthorizationError struct {
+	Permission string
+	Code       ErrorCode
+	Message    string
+}
+
+func (e AuthorizationError) Error() string {
+	return fmt.Sprintf("authorization failed for '%s': %s", e.Permission, e.Message)
+}
+
+func (e AuthorizationError) ErrorCode() ErrorCode {
+	return e.Code
+}
+
+func (e AuthorizationError) UserMessage() string {
+	return e.Message
+}
+
+// NewAuthorizationError creates a new authorization error
+func NewAuthorizationError(permission string, code ErrorCode, message string) *AuthorizationError {
+	return &AuthorizationError{
+		Permission: permission,
+		Code:       code,
+		Message:    message,
+	}
+}
+
 // ValidationErrors represents multiple validation errors
 type ValidationErrors struct {
 	Errors []ValidationError
@@ -190,11 +227,341 @@ func NewValidationErrors() *ValidationErrors {
 	}
 }
 
+// NewResultLimitError represents an unpaginated listing matching more than max results; advice tells callers how
+// to read them page by page
+func NewResultLimitError(max int, advice string) *BusinessRuleError {
+	err := NewBusinessRuleError("result_limit", BusinessRuleViolation,
+		fmt.Sprintf("more than %d results match, %s", max, advice))
+	err.Context["max_results"] = max
+	return err
+}
+
 // Common client domain errors
 var (
 	// ErrClientNotFound represents a client not found error
 	ErrClientNotFound = NewRepositoryError("get_client", RepositoryNotFound, "client not found", nil)
 
 	// ErrClientEmailExists represents a client email uniqueness violation
-	ErrClientEmailExists = NewBusinessRuleError("email_uniqueness", BusinessRuleConflict, "email address already exists")
+	ErrClientEmailExists = NewBusinessRuleError("email_uniqueness", BusinessRuleDuplicate, "email address already exists")
+
+	// ErrClientNotActive represents an invoice created for a suspended or closed client
+	ErrClientNotActive = NewBusinessRuleError("client_active", BusinessRuleConflict, "client is not active")
+)
+
+// Common access control domain errors
+var (
+	// ErrIPAccessPolicyNotFound represents a missing tenant IP access policy
+	ErrIPAccessPolicyNotFound = NewRepositoryError("get_ip_access_policy", RepositoryNotFound, "IP access policy not found", nil)
+
+	// ErrFormTokenInvalid represents an unknown or already redeemed form token (duplicate submission)
+	ErrFormTokenInvalid = NewBusinessRuleError("form_token_single_use", BusinessRuleConflict, "form token is invalid or has already been used")
+
+	// ErrFormTokenExpired represents a form token redeemed after its expiry
+	ErrFormTokenExpired = NewBusinessRuleError("form_token_expiry", BusinessRuleViolation, "form token has expired")
+
+	// ErrPortalTokenInvalid represents a portal access token that is malformed or not signed by this service
+	ErrPortalTokenInvalid = NewBusinessRuleError("portal_token_signature", BusinessRuleViolation, "portal access token is invalid")
+
+	// ErrPortalTokenExpired represents a portal access token used after its expiry
+	ErrPortalTokenExpired = NewBusinessRuleError("portal_token_expiry", BusinessRuleViolation, "portal access token has expired")
+
+	// ErrMagicLinkInvalid represents an unknown, forged or wrong-purpose magic link
+	ErrMagicLinkInvalid = NewBusinessRuleError("magic_link_signature", BusinessRuleViolation, "link is invalid")
+
+	// ErrMagicLinkUsed represents a magic link followed a second time
+	ErrMagicLinkUsed = NewBusinessRuleError("magic_link_single_use", BusinessRuleConflict, "link has already been used")
+
+	// ErrMagicLinkExpired represents a magic link followed after its expiry
+	ErrMagicLinkExpired = NewBusinessRuleError("magic_link_expiry", BusinessRuleViolation, "link has expired")
+)
+
+// Common contract domain errors
+var (
+	// ErrContractNotFound represents a contract not found error
+	ErrContractNotFound = NewRepositoryError("get_contract", RepositoryNotFound, "contract not found", nil)
+)
+
+// Common approval workflow domain errors
+var (
+	// ErrApprovalRequestNotFound represents an approval request not found error
+	ErrApprovalRequestNotFound = NewRepositoryError("get_approval_request", RepositoryNotFound, "approval request not found", nil)
+
+	// ErrApprovalNotPending represents an approval of a request that is no longer pending
+	ErrApprovalNotPending = NewBusinessRuleError("approval_state", BusinessRuleConflict, "approval request is not pending approval")
+
+	// ErrSelfApproval represents a requester trying to approve their own request
+	ErrSelfApproval = NewBusinessRuleError("approval_four_eyes", BusinessRuleViolation, "approval requires a second user")
+
+	// ErrApproverRoleRequired represents an approval by a user without the approver role
+	ErrApproverRoleRequired = NewBusinessRuleError("approval_role", BusinessRuleViolation, "the approver role is required to approve")
+)
+
+// Common recurring invoice domain errors
+var (
+	// ErrRecurringInvoiceTemplateNotFound represents a recurring invoice template not found error
+	ErrRecurringInvoiceTemplateNotFound = NewRepositoryError("get_recurring_invoice_template", RepositoryNotFound, "recurring invoice template not found", nil)
+)
+
+// Common subscription domain errors
+var (
+	// ErrSubscriptionNotFound represents a subscription that does not exist
+	ErrSubscriptionNotFound = NewRepositoryError("get_subscription", RepositoryNotFound, "subscription not found", nil)
+
+	// ErrSubscriptionCanceled represents a change of a subscription that was canceled
+	ErrSubscriptionCanceled = NewBusinessRuleError("subscription_canceled", BusinessRuleConflict, "subscription is canceled")
+
+	// ErrSubscriptionNotActive represents a pause of a subscription that is not active
+	ErrSubscriptionNotActive = NewBusinessRuleError("subscription_active", BusinessRuleConflict, "subscription is not active")
+
+	// ErrSubscriptionNotPaused represents a resume of a subscription that is not paused
+	ErrSubscriptionNotPaused = NewBusinessRuleError("subscription_paused", BusinessRuleConflict, "subscription is not paused")
+)
+
+// Common quote domain errors
+var (
+	// ErrQuoteNotFound represents a quote that does not exist
+	ErrQuoteNotFound = NewRepositoryError("get_quote", RepositoryNotFound, "quote not found", nil)
+
+	// ErrQuoteNotOpen represents an acceptance, rejection or expiry of a quote that was already answered or expired
+	ErrQuoteNotOpen = NewBusinessRuleError("quote_open", BusinessRuleConflict, "quote is no longer open")
+
+	// ErrQuoteExpired represents an acceptance of a quote past its validity date
+	ErrQuoteExpired = NewBusinessRuleError("quote_validity", BusinessRuleConflict, "quote has expired")
+
+	// ErrQuoteNotAccepted represents a conversion of a quote the client did not accept
+	ErrQuoteNotAccepted = NewBusinessRuleError("quote_accepted", BusinessRuleConflict, "only accepted quotes can be converted to an invoice")
+
+	// ErrQuoteAlreadyConverted represents a second conversion of a quote
+	ErrQuoteAlreadyConverted = NewBusinessRuleError("quote_converted", BusinessRuleDuplicate, "quote was already converted to an invoice")
+)
+
+// Common invoice delivery domain errors
+var (
+	// ErrInvoiceTrackingTokenInvalid represents a forged, malformed or foreign invoice view tracking token
+	ErrInvoiceTrackingTokenInvalid = NewBusinessRuleError("invoice_tracking_token_signature", BusinessRuleViolation, "tracking token is invalid")
+)
+
+// Common email outbox domain errors
+var (
+	// ErrOutboundEmailNotFound represents an email that is not in the outbox
+	ErrOutboundEmailNotFound = NewRepositoryError("get_outbound_email", RepositoryNotFound, "outbound email not found", nil)
+
+	// ErrOutboundEmailNotRetryable represents a retry of an email still pending or already sent
+	ErrOutboundEmailNotRetryable = NewBusinessRuleError("outbound_email_retryable", BusinessRuleConflict, "only failed and suppressed emails can be retried")
+
+	// ErrEmailSuppressionNotFound represents an address that is not on the suppression list
+	ErrEmailSuppressionNotFound = NewRepositoryError("get_email_suppression", RepositoryNotFound, "email address is not suppressed", nil)
+
+	// ErrEmailAlreadySuppressed represents a manual suppression of an address already on the suppression list
+	ErrEmailAlreadySuppressed = NewBusinessRuleError("email_suppressed", BusinessRuleDuplicate, "email address is already suppressed")
+)
+
+// Common dunning domain errors
+var (
+	// ErrDunningPolicyNotFound represents a tenant without a dunning policy (the default cadence applies)
+	ErrDunningPolicyNotFound = NewRepositoryError("get_dunning_policy", RepositoryNotFound, "dunning policy not found", nil)
+)
+
+// Common tenant rule domain errors
+var (
+	// ErrTenantRuleSetNotFound represents a tenant without business rules of its own
+	ErrTenantRuleSetNotFound = NewRepositoryError("get_tenant_rule_set", RepositoryNotFound, "tenant rule set not found", nil)
+)
+
+// Common cash application domain errors
+var (
+	// ErrBankTransactionNotFound represents a missing imported bank transaction
+	ErrBankTransactionNotFound = NewRepositoryError("get_bank_transaction", RepositoryNotFound, "bank transaction not found", nil)
+
+	// ErrBankTransactionAlreadyReconciled represents applying a bank transaction that was already applied to an invoice
+	ErrBankTransactionAlreadyReconciled = NewBusinessRuleError("bank_transaction_single_application", BusinessRuleConflict, "bank transaction has already been reconciled")
+)
+
+// Common payout reconciliation domain errors
+var (
+	// ErrPayoutReconciliationNotFound represents a payout that has not been reconciled
+	ErrPayoutReconciliationNotFound = NewRepositoryError("get_payout_reconciliation", RepositoryNotFound, "payout reconciliation not found", nil)
+
+	// ErrPayoutReconciliationUnavailable represents a reconciliation run without a payment gateway or ledger to compare
+	ErrPayoutReconciliationUnavailable = NewBusinessRuleError("payout_reconciliation_sources", BusinessRuleViolation, "payout reconciliation requires a configured payment gateway and ledger")
+)
+
+// Common legal entity domain errors
+var (
+	// ErrLegalEntityNotFound represents a legal entity not found error
+	ErrLegalEntityNotFound = NewRepositoryError("get_legal_entity", RepositoryNotFound, "legal entity not found", nil)
+
+	// ErrInvoiceNumberPrefixTaken represents two legal entities numbering invoices with the same prefix
+	ErrInvoiceNumberPrefixTaken = NewBusinessRuleError("invoice_number_prefix_unique", BusinessRuleConflict, "invoice number prefix is already used by another legal entity")
+
+	// ErrLegalEntityHasInvoices represents deleting an entity invoices were issued from
+	ErrLegalEntityHasInvoices = NewBusinessRuleError("legal_entity_retention", BusinessRuleViolation, "legal entity has issued invoices and cannot be deleted")
+
+	// ErrDefaultLegalEntityRequired represents deleting the default entity while other entities remain
+	ErrDefaultLegalEntityRequired = NewBusinessRuleError("legal_entity_default", BusinessRuleViolation, "make another legal entity the default before deleting this one")
+)
+
+// Common document template domain errors
+var (
+	// ErrDocumentTemplateNotFound represents a tenant without a document template (the default layout applies)
+	ErrDocumentTemplateNotFound = NewRepositoryError("get_document_template", RepositoryNotFound, "document template not found", nil)
+)
+
+// Common credit control domain errors
+var (
+	// ErrClientCreditLimitNotFound represents a client without a credit limit
+	ErrClientCreditLimitNotFound = NewRepositoryError("get_client_credit_limit", RepositoryNotFound, "client credit limit not found", nil)
+)
+
+// Common client contact domain errors
+var (
+	// ErrClientContactNotFound represents a contact missing from its client
+	ErrClientContactNotFound = NewRepositoryError("get_client_contact", RepositoryNotFound, "client contact not found", nil)
+)
+
+// Common client tombstone domain errors
+var (
+	// ErrClientTombstoneNotFound represents a client that was never deleted (or never existed)
+	ErrClientTombstoneNotFound = NewRepositoryError("get_client_tombstone", RepositoryNotFound, "client tombstone not found", nil)
+)
+
+// Common risk scoring domain errors
+var (
+	// ErrRiskAssessmentNotFound represents a client that was never scored
+	ErrRiskAssessmentNotFound = NewRepositoryError("get_risk_assessment", RepositoryNotFound, "risk assessment not found", nil)
+
+	// ErrRiskScoringUnavailable represents a scoring request without a configured risk provider
+	ErrRiskScoringUnavailable = NewBusinessRuleError("risk_provider", BusinessRuleViolation, "risk scoring requires a configured risk provider")
+)
+
+// Common inbound webhook domain errors
+var (
+	// ErrWebhookEventNotFound represents a webhook event that was never received
+	ErrWebhookEventNotFound = NewRepositoryError("get_webhook_event", RepositoryNotFound, "webhook event not found", nil)
+
+	// ErrWebhookProviderNotFound represents a delivery for a provider without a configured verifier
+	ErrWebhookProviderNotFound = NewRepositoryError("get_webhook_provider", RepositoryNotFound, "webhook provider not found", nil)
+
+	// ErrWebhookSignatureInvalid represents a delivery whose signature does not verify with the provider secret
+	ErrWebhookSignatureInvalid = NewBusinessRuleError("webhook_signature", BusinessRuleViolation, "webhook signature is invalid")
+
+	// ErrWebhookEventNotDeadLettered represents a retry or discard of an event that is not in the dead letter queue
+	ErrWebhookEventNotDeadLettered = NewBusinessRuleError("webhook_event_dead_letter", BusinessRuleConflict, "only dead-lettered webhook events can be retried or discarded")
+
+	// ErrWebhookSubscriptionNotFound represents a consumer webhook subscription that does not exist
+	ErrWebhookSubscriptionNotFound = NewRepositoryError("get_webhook_subscription", RepositoryNotFound, "webhook subscription not found", nil)
+)
+
+// Common dead letter queue domain errors
+var (
+	// ErrDeadLetterNotFound represents a dead letter that does not exist in any source (or was already retried or discarded)
+	ErrDeadLetterNotFound = NewRepositoryError("get_dead_letter", RepositoryNotFound, "dead letter not found", nil)
+
+	// ErrFailedMessageNotFound represents a failed message publication that is not in the dead letter queue
+	ErrFailedMessageNotFound = NewRepositoryError("get_failed_message", RepositoryNotFound, "failed message not found", nil)
+)
+
+// Common idempotent consumer domain errors
+var (
+	// ErrProcessedMessageNotFound represents a message that was not processed yet (or whose record expired)
+	ErrProcessedMessageNotFound = NewRepositoryError("get_processed_message", RepositoryNotFound, "processed message not found", nil)
+)
+
+// Common saga domain errors
+var (
+	// ErrSagaNotFound represents a saga that was never started
+	ErrSagaNotFound = NewRepositoryError("get_saga", RepositoryNotFound, "saga not found", nil)
+
+	// ErrSagaTypeNotFound represents a saga type without a registered definition
+	ErrSagaTypeNotFound = NewRepositoryError("get_saga_definition", RepositoryNotFound, "saga type not found", nil)
+
+	// ErrSagaNotRunning represents a step outcome recorded on a saga that is not executing its steps
+	ErrSagaNotRunning = NewBusinessRuleError("saga_running", BusinessRuleConflict, "saga is not running")
+
+	// ErrSagaNotCompensating represents a compensation outcome recorded on a saga that is not compensating
+	ErrSagaNotCompensating = NewBusinessRuleError("saga_compensating", BusinessRuleConflict, "saga is not compensating")
+
+	// ErrSagaFinished represents a resume of a saga that already completed or was compensated
+	ErrSagaFinished = NewBusinessRuleError("saga_finished", BusinessRuleConflict, "saga already finished")
+
+	// ErrInvoicePaymentInProgress represents a card payment of an invoice that already has a payment running or completed
+	ErrInvoicePaymentInProgress = NewBusinessRuleError("single_invoice_payment", BusinessRuleConflict, "invoice already has a card payment in progress or completed")
+)
+
+// Common integration log domain errors
+var (
+	// ErrIntegrationLogNotFound represents an integration call log that does not exist (or was deleted by the retention)
+	ErrIntegrationLogNotFound = NewRepositoryError("get_integration_log", RepositoryNotFound, "integration log not found", nil)
+)
+
+// Common fiscal calendar domain errors
+var (
+	// ErrFiscalCalendarNotFound represents a tenant without a fiscal calendar (calendar years split into months apply)
+	ErrFiscalCalendarNotFound = NewRepositoryError("get_fiscal_calendar", RepositoryNotFound, "fiscal calendar not found", nil)
+
+	// ErrFiscalCalendarHasClosedPeriods represents a change of the periods of a calendar, or its removal, while periods are closed
+	ErrFiscalCalendarHasClosedPeriods = NewBusinessRuleError("fiscal_calendar_unlocked", BusinessRuleConflict, "fiscal calendar has closed periods; reopen them first")
+
+	// ErrFiscalPeriodAlreadyClosed represents a close of a period that is already closed
+	ErrFiscalPeriodAlreadyClosed = NewBusinessRuleError("fiscal_period_open", BusinessRuleConflict, "fiscal period is already closed")
+
+	// ErrFiscalPeriodNotClosed represents a reopening of a period that is open
+	ErrFiscalPeriodNotClosed = NewBusinessRuleError("fiscal_period_closed", BusinessRuleConflict, "fiscal period is not closed")
+)
+
+// Common client statement domain errors
+var (
+	// ErrStatementJobNotFound represents a statement request that does not exist (or belongs to another client)
+	ErrStatementJobNotFound = NewRepositoryError("get_statement_job", RepositoryNotFound, "statement job not found", nil)
+
+	// ErrStatementNotReady represents a download of a statement that is pending or failed
+	ErrStatementNotReady = NewBusinessRuleError("statement_completed", BusinessRuleConflict, "statement is not generated yet")
+)
+
+// Common upload domain errors
+var (
+	// ErrUploadNotFound represents an upload that does not exist
+	ErrUploadNotFound = NewRepositoryError("get_upload", RepositoryNotFound, "upload not found", nil)
+
+	// ErrObjectNotFound represents an object missing from the object store
+	ErrObjectNotFound = NewRepositoryError("get_object", RepositoryNotFound, "object not found", nil)
+)
+
+// Common external reference domain errors
+var (
+	// ErrExternalReferenceNotFound represents an external reference no client or invoice of the tenant has
+	ErrExternalReferenceNotFound = NewRepositoryError("get_external_reference", RepositoryNotFound, "external reference not found", nil)
+)
+
+// Common invoice domain errors
+var (
+	// ErrInvoiceNotFound represents an invoice that does not exist
+	ErrInvoiceNotFound = NewRepositoryError("get_invoice", RepositoryNotFound, "invoice not found", nil)
+
+	// ErrInvoiceNotDraft represents a change, deletion or issue of an invoice that was already issued
+	ErrInvoiceNotDraft = NewBusinessRuleError("invoice_draft", BusinessRuleConflict, "invoice is not a draft")
+
+	// ErrInvoiceNotIssued represents a payment of an invoice that is not issued (drafts, paid and void invoices)
+	ErrInvoiceNotIssued = NewBusinessRuleError("invoice_issued", BusinessRuleConflict, "invoice is not issued")
+
+	// ErrInvoiceAlreadyNumbered represents a second number assigned to an invoice (numbers never change once assigned)
+	ErrInvoiceAlreadyNumbered = NewBusinessRuleError("invoice_number_immutable", BusinessRuleConflict, "invoice already has a number")
+
+	// ErrInvoiceNotOverdue represents a payment reminder recorded for an invoice that is not past its due date
+	ErrInvoiceNotOverdue = NewBusinessRuleError("invoice_overdue", BusinessRuleConflict, "invoice is not overdue")
+
+	// ErrDunningLevelNotEscalated represents a payment reminder at or below the level of the last reminder sent
+	ErrDunningLevelNotEscalated = NewBusinessRuleError("dunning_level_escalates", BusinessRuleConflict, "invoice was already reminded at this dunning level")
+
+	// ErrInvoiceNotVoidable represents a void of an invoice that has payments or is already void
+	ErrInvoiceNotVoidable = NewBusinessRuleError("invoice_voidable", BusinessRuleConflict, "only drafts and issued invoices without payments can be voided")
+
+	// ErrInvoiceOverpayment represents a payment larger than the balance left to pay on an invoice
+	ErrInvoiceOverpayment = NewBusinessRuleError("invoice_overpayment", BusinessRuleViolation, "payment exceeds the invoice balance")
+
+	// ErrInvoiceNotArchivable represents an archive of an invoice still open (drafts and issued invoices)
+	ErrInvoiceNotArchivable = NewBusinessRuleError("invoice_archivable", BusinessRuleConflict, "only paid and void invoices can be archived")
+
+	// ErrArchivedInvoiceNotFound represents an invoice that is not in the archive
+	ErrArchivedInvoiceNotFound = NewRepositoryError("get_archived_invoice", RepositoryNotFound, "archived invoice not found", nil)
 )
@@ -28,6 +28,12 @@ func IsRepositoryError(err error) bool {
 	return errors.As(err, &repoErr)
 }
 
+// IsAuthorizationError checks if an error is an AuthorizationError
+func IsAuthorizationError(err error) bool {
+	var authErr *AuthorizationError
+	return errors.As(err, &authErr)
+}
+
 // GetErrorCode extracts the error code from structured errors
 func GetErrorCode(err error) ErrorCode {
 	var validationErr *ValidationError
@@ -45,6 +51,11 @@ func GetErrorCode(err error) ErrorCode {
 		return repoErr.ErrorCode()
 	}
 
+	var authErr *AuthorizationError
+	if errors.As(err, &authErr) {
+		return authErr.ErrorCode()
+	}
+
 	return ""
 }
 
@@ -70,13 +81,18 @@ func GetUserMessage(err error) string {
 		return repoErr.UserMessage()
 	}
 
+	var authErr *AuthorizationError
+	if errors.As(err, &authErr) {
+		return authErr.UserMessage()
+	}
+
 	// Fallback for unstructured errors
 	return "An error occurred"
 }
 
-// IsClientError checks if the error is a client-side error (validation, business rules)
+// IsClientError checks if the error is a client-side error (validation, business rules, authorization)
 func IsClientError(err error) bool {
-	return IsValidationError(err) || IsValidationErrors(err) || IsBusinessRuleError(err)
+	return IsValidationError(err) || IsValidationErrors(err) || IsBusinessRuleError(err) || IsAuthorizationError(err)
 }
 
 // IsServerError checks if the error is a server-side error (repository, infrastructure)
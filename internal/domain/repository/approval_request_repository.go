@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// ApprovalRequestRepository defines the contract for credit note and refund approval persistence
+type ApprovalRequestRepository interface {
+	// Save persists a new or updated approval request
+	Save(request *entity.ApprovalRequest) error
+
+	// GetByID retrieves an approval request by its ID (ErrApprovalRequestNotFound when missing)
+	GetByID(id string) (*entity.ApprovalRequest, error)
+
+	// GetAll retrieves all approval requests, oldest first
+	GetAll() ([]*entity.ApprovalRequest, error)
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// AuditRepository defines the contract for the append-only audit log
+type AuditRepository interface {
+	// Append persists a new audit entry
+	Append(entry *entity.AuditEntry) error
+
+	// GetAll retrieves all audit entries, most recent first
+	GetAll() ([]*entity.AuditEntry, error)
+
+	// ListSince retrieves the audit entries that occurred at or after since, most recent first
+	ListSince(since time.Time) ([]*entity.AuditEntry, error)
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// BankTransactionRepository defines the contract for imported bank transaction persistence
+type BankTransactionRepository interface {
+	// Save persists a new or updated bank transaction
+	Save(transaction *entity.BankTransaction) error
+
+	// GetByID retrieves a bank transaction by its ID (ErrBankTransactionNotFound when missing)
+	GetByID(id string) (*entity.BankTransaction, error)
+
+	// GetAll retrieves all bank transactions ordered by booking date
+	GetAll() ([]*entity.BankTransaction, error)
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// ClientChangeRepository defines the contract for the client change log
+type ClientChangeRepository interface {
+	// NextSequence allocates the sequence number of the next change
+	NextSequence() (int64, error)
+
+	// Append persists a change log entry
+	Append(change *entity.ClientChange) error
+
+	// ListAfterSequence retrieves up to limit changes with a sequence greater than sequence, in sequence order
+	ListAfterSequence(sequence int64, limit int) ([]*entity.ClientChange, error)
+
+	// ListSince retrieves up to limit changes that occurred after since, in sequence order
+	ListSince(since time.Time, limit int) ([]*entity.ClientChange, error)
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// ClientContactRepository defines the contract for client contact persistence
+type ClientContactRepository interface {
+	// Save persists a contact (create or update)
+	Save(contact *entity.ClientContact) error
+
+	// GetByID retrieves a contact by its ID
+	GetByID(id string) (*entity.ClientContact, error)
+
+	// ListByClient retrieves the contacts of a client, oldest first
+	ListByClient(clientID string) ([]*entity.ClientContact, error)
+
+	// Delete removes a contact
+	Delete(id string) error
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// ClientCreditLimitRepository defines the contract for client credit limit persistence
+type ClientCreditLimitRepository interface {
+	// Save persists a limit (one limit per client)
+	Save(limit *entity.ClientCreditLimit) error
+
+	// GetByClientID retrieves the limit of a client
+	GetByClientID(clientID string) (*entity.ClientCreditLimit, error)
+
+	// GetAll retrieves all limits
+	GetAll() ([]*entity.ClientCreditLimit, error)
+
+	// Delete removes the limit of a client
+	Delete(clientID string) error
+}
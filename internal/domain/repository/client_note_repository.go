@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// ClientNoteRepository defines the contract for client note persistence
+type ClientNoteRepository interface {
+	// Save persists a note
+	Save(note *entity.ClientNote) error
+
+	// ListByClient retrieves the notes of a client, newest first
+	ListByClient(clientID string) ([]*entity.ClientNote, error)
+
+	// Delete removes a note
+	Delete(id string) error
+}
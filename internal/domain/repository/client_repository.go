@@ -1,26 +1,135 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
 )
 
+// MaxListResults bounds the entities a repository listing returns: unpaginated listings matching more fail with
+// a result limit error (errors.NewResultLimitError), and pages cannot be larger
+const MaxListResults = 1000
+
 // ClientRepository defines the contract for client persistence operations
+// Listings return at most MaxListResults clients
 type ClientRepository interface {
 	// Save persists a client entity
 	Save(client *entity.Client) error
 
-	// GetAll retrieves all client entities
+	// GetAll retrieves all client entities; beyond MaxListResults clients callers page with List or ListAfter
 	GetAll() ([]*entity.Client, error)
 
 	// GetByID retrieves a client entity by ID
 	GetByID(id string) (*entity.Client, error)
 
+	// GetByIDs retrieves the clients with the given IDs in one round trip, in the order of ids; IDs without a client
+	// are skipped
+	GetByIDs(ids []string) ([]*entity.Client, error)
+
+	// GetByEmailKey retrieves the client of a tenant with a canonical email address (see entity.Client.EmailKey);
+	// an empty tenant looks among the clients created without a tenant
+	GetByEmailKey(tenantID, key string) (*entity.Client, error)
+
+	// Exists checks if a client with the given ID exists without loading it
+	Exists(id string) (bool, error)
+
 	// Delete removes a client entity by ID
 	Delete(id string) error
 
 	// CountClients returns the total number of clients
 	CountClients() (int, error)
 
+	// Count returns the number of clients matching the filter
+	Count(filter ClientCountFilter) (int, error)
+
 	// ListClientsWithPagination retrieves clients with pagination
 	ListClientsWithPagination(offset, limit int) ([]*entity.Client, error)
+
+	// ListByStatus retrieves the clients in a lifecycle status, in insertion order
+	ListByStatus(status entity.ClientStatus) ([]*entity.Client, error)
+
+	// ListByTag retrieves the clients carrying a normalized tag, in insertion order
+	ListByTag(tag string) ([]*entity.Client, error)
+
+	// ListByPhone retrieves the clients whose phone has an E.164 form, in insertion order
+	ListByPhone(e164 string) ([]*entity.Client, error)
+
+	// List retrieves a page of the clients matching the filter, in the order of the filter, and the number of matching
+	// clients
+	List(filter ClientListFilter, offset, limit int) ([]*entity.Client, int, error)
+
+	// ListAfter retrieves up to limit clients matching the filter that come after a position (zero: from the first
+	// client) in creation order; filter.Sort is ignored
+	ListAfter(filter ClientListFilter, after ClientPosition, limit int) (*ClientPage, error)
+}
+
+// ClientPosition is where a client stands in creation order: when it was created, then its ID
+// Listings paged by position stay consistent while clients are added or removed between two pages
+type ClientPosition struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// IsZero checks if the position is the start of the creation order
+func (p ClientPosition) IsZero() bool {
+	return p.CreatedAt.IsZero() && p.ID == ""
+}
+
+// ClientPage is a page of clients listed after a position
+type ClientPage struct {
+	Clients []*entity.Client
+	Next    ClientPosition // Position of the last client of the page (the position listed after for an empty page)
+	HasMore bool           // More clients come after Next
+}
+
+// ClientListFilter narrows a client listing; empty fields match every client
+type ClientListFilter struct {
+	Status        entity.ClientStatus
+	Tag           string          // Normalized tag (see entity.NormalizeClientTag)
+	PhoneE164     string          // Phone number in E.164 form
+	EmailKey      string          // Canonical email address (see entity.Client.EmailKey)
+	Search        string          // Case-insensitive substring of the name or email
+	CreatedAfter  time.Time       // Clients created strictly after (zero: no lower bound)
+	CreatedBefore time.Time       // Clients created strictly before (zero: no upper bound)
+	Sort          []ClientSortKey // Ordering of the listing, insertion order breaking ties (empty: insertion order)
+	TenantScoped  bool            // Only the clients of TenantID (the clients created without a tenant when empty)
+	TenantID      string
+}
+
+// ClientSortField is a field client listings can be ordered by
+type ClientSortField string
+
+const (
+	ClientSortName      ClientSortField = "name"       // Case-insensitive
+	ClientSortEmail     ClientSortField = "email"      // Case-insensitive
+	ClientSortCreatedAt ClientSortField = "created_at" // Creation time
+	ClientSortUpdatedAt ClientSortField = "updated_at" // Time of the last update
+)
+
+// ClientSortFields lists the fields client listings can be ordered by
+var ClientSortFields = []ClientSortField{ClientSortName, ClientSortEmail, ClientSortCreatedAt, ClientSortUpdatedAt}
+
+// ClientSortKey orders a client listing by a field
+type ClientSortKey struct {
+	Field      ClientSortField
+	Descending bool
+}
+
+// CountMode selects how precisely records are counted
+type CountMode string
+
+const (
+	// CountExact counts every matching record
+	CountExact CountMode = "exact"
+	// CountEstimated returns the database planner's estimate, which is cheap on very large tables but may be stale
+	// Backends without statistics count exactly
+	CountEstimated CountMode = "estimated"
+)
+
+// ClientCountFilter narrows a client count
+type ClientCountFilter struct {
+	Search       string    // Case-insensitive substring of the name or email (empty matches every client)
+	Mode         CountMode // Empty counts exactly; estimates are only available without a search
+	TenantScoped bool      // Only the clients of TenantID, like ClientListFilter (always counted exactly)
+	TenantID     string
 }
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// ClientStatementJobRepository defines the contract for client statement job persistence
+type ClientStatementJobRepository interface {
+	// Save persists a new or updated statement job
+	Save(job *entity.ClientStatementJob) error
+
+	// GetByID retrieves a statement job by its ID (ErrStatementJobNotFound when missing)
+	GetByID(id string) (*entity.ClientStatementJob, error)
+
+	// GetPending retrieves the statement jobs still to be generated, oldest first
+	GetPending() ([]*entity.ClientStatementJob, error)
+}
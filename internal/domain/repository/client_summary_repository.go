@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// ClientSummaryRepository defines the contract for the client list read model
+type ClientSummaryRepository interface {
+	// Save persists the summary of a client, replacing the previous one
+	Save(summary *entity.ClientSummary) error
+
+	// Delete removes the summary of a client (no error when there is none)
+	Delete(clientID string) error
+
+	// GetAll retrieves every summary in client creation order
+	GetAll() ([]*entity.ClientSummary, error)
+
+	// ListPage retrieves a page of summaries in client creation order with the total number of summaries
+	ListPage(offset, limit int) ([]*entity.ClientSummary, int, error)
+}
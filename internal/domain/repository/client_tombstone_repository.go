@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// ClientTombstoneRepository defines the contract for the persistence of the tombstones of deleted clients
+type ClientTombstoneRepository interface {
+	// Save persists the tombstone of a deleted client
+	Save(tombstone *entity.ClientTombstone) error
+
+	// GetByClientID retrieves the tombstone of a client (ErrClientTombstoneNotFound when it was never deleted)
+	GetByClientID(clientID string) (*entity.ClientTombstone, error)
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// ContractRepository defines the contract for customer contract persistence
+type ContractRepository interface {
+	// Save persists a new or updated contract
+	Save(contract *entity.Contract) error
+
+	// GetByID retrieves a contract by its ID (ErrContractNotFound when missing)
+	GetByID(id string) (*entity.Contract, error)
+
+	// GetAll retrieves all contracts ordered by renewal date
+	GetAll() ([]*entity.Contract, error)
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// DocumentTemplateRepository defines the contract for tenant document template persistence
+type DocumentTemplateRepository interface {
+	// Save persists a template (one template per tenant)
+	Save(template *entity.DocumentTemplate) error
+
+	// GetByTenantID retrieves the template of a tenant
+	GetByTenantID(tenantID string) (*entity.DocumentTemplate, error)
+
+	// GetAll retrieves all templates
+	GetAll() ([]*entity.DocumentTemplate, error)
+
+	// Delete removes the template of a tenant
+	Delete(tenantID string) error
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// DunningPolicyRepository defines the contract for tenant dunning policy persistence
+type DunningPolicyRepository interface {
+	// Save persists a policy (one policy per tenant)
+	Save(policy *entity.DunningPolicy) error
+
+	// GetByTenantID retrieves the policy of a tenant
+	GetByTenantID(tenantID string) (*entity.DunningPolicy, error)
+
+	// GetAll retrieves all policies
+	GetAll() ([]*entity.DunningPolicy, error)
+
+	// Delete removes the policy of a tenant
+	Delete(tenantID string) error
+}
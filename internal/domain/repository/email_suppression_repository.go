@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// EmailSuppressionRepository defines the contract for the persistence of tracked and suppressed email addresses
+type EmailSuppressionRepository interface {
+	// Save persists a new or updated address
+	Save(suppression *entity.EmailSuppression) error
+
+	// GetByAddress retrieves an address by its normalized form (ErrEmailSuppressionNotFound when missing)
+	GetByAddress(address string) (*entity.EmailSuppression, error)
+
+	// GetAll retrieves all tracked addresses, oldest first
+	GetAll() ([]*entity.EmailSuppression, error)
+
+	// Delete stops tracking an address, lifting its suppression
+	Delete(address string) error
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// StoredEventFilter narrows the events listed from the event store (empty fields match every event)
+type StoredEventFilter struct {
+	Type        string
+	AggregateID string
+	TenantID    string // Only events whose tenant header names this tenant (see entity.StoredEventTenantHeader)
+}
+
+// EventStoreRepository defines the contract for the append-only event store
+// Events are never updated or deleted
+type EventStoreRepository interface {
+	// NextSequence allocates the sequence number of the next event
+	NextSequence() (int64, error)
+
+	// Append persists an event
+	Append(event *entity.StoredEvent) error
+
+	// ListAfterSequence retrieves up to limit events matching filter with a sequence greater than sequence, in sequence order
+	ListAfterSequence(sequence int64, filter StoredEventFilter, limit int) ([]*entity.StoredEvent, error)
+
+	// ListSince retrieves up to limit events matching filter that occurred after since, in sequence order
+	ListSince(since time.Time, filter StoredEventFilter, limit int) ([]*entity.StoredEvent, error)
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// ExternalReferenceRepository defines the contract for the per-tenant index of client and invoice external references
+type ExternalReferenceRepository interface {
+	// Save persists a reference (one resource per kind, tenant and reference)
+	Save(reference *entity.ExternalReference) error
+
+	// Get retrieves the reference of a kind in a tenant
+	Get(kind entity.ExternalReferenceKind, tenantID, reference string) (*entity.ExternalReference, error)
+
+	// Delete removes the reference of a kind in a tenant
+	Delete(kind entity.ExternalReferenceKind, tenantID, reference string) error
+}
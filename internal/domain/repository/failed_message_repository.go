@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// FailedMessageRepository defines the contract for the persistence of message bus publications that failed
+type FailedMessageRepository interface {
+	// Save persists a new or updated failed message
+	Save(message *entity.FailedMessage) error
+
+	// GetByID retrieves a failed message by its ID (ErrFailedMessageNotFound when missing)
+	GetByID(id string) (*entity.FailedMessage, error)
+
+	// GetAll retrieves all failed messages, oldest failure first
+	GetAll() ([]*entity.FailedMessage, error)
+
+	// Delete removes a failed message once it was published or discarded
+	Delete(id string) error
+}
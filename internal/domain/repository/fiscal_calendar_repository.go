@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// FiscalCalendarRepository defines the contract for tenant fiscal calendar persistence
+type FiscalCalendarRepository interface {
+	// Save persists a calendar (one calendar per tenant)
+	Save(calendar *entity.FiscalCalendar) error
+
+	// GetByTenantID retrieves the calendar of a tenant
+	GetByTenantID(tenantID string) (*entity.FiscalCalendar, error)
+
+	// GetAll retrieves all calendars
+	GetAll() ([]*entity.FiscalCalendar, error)
+
+	// Delete removes the calendar of a tenant
+	Delete(tenantID string) error
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// FormTokenRepository defines the contract for single-use form token persistence
+type FormTokenRepository interface {
+	// Save persists an issued token
+	Save(token *entity.FormToken) error
+
+	// Consume atomically removes and returns a token
+	// Only one caller can consume a given token; later calls get ErrFormTokenInvalid
+	Consume(token string) (*entity.FormToken, error)
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// IntegrationLogRepository defines the contract for the persistence of outbound integration call logs
+type IntegrationLogRepository interface {
+	// Save persists an integration log keyed by its ID
+	Save(log *entity.IntegrationLog) error
+
+	// GetByID retrieves an integration log by its ID (ErrIntegrationLogNotFound when missing)
+	GetByID(id string) (*entity.IntegrationLog, error)
+
+	// GetAll retrieves all integration logs, most recent first
+	GetAll() ([]*entity.IntegrationLog, error)
+
+	// Delete removes an integration log
+	Delete(id string) error
+}
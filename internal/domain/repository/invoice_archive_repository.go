@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// InvoiceArchiveRepository defines the contract for the cold storage of closed invoices
+type InvoiceArchiveRepository interface {
+	// Save persists an archived invoice keyed by its invoice ID (archiving again replaces it)
+	Save(archived *entity.ArchivedInvoice) error
+
+	// GetByInvoiceID retrieves an archived invoice (ErrArchivedInvoiceNotFound when missing)
+	GetByInvoiceID(invoiceID string) (*entity.ArchivedInvoice, error)
+
+	// Delete removes an archived invoice (ErrArchivedInvoiceNotFound when missing)
+	Delete(invoiceID string) error
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// InvoiceDeliveryEventRepository defines the contract for invoice delivery event persistence
+// Events are append-only
+type InvoiceDeliveryEventRepository interface {
+	// Append persists a new delivery event
+	Append(event *entity.InvoiceDeliveryEvent) error
+
+	// ListByInvoice retrieves the delivery events of an invoice, oldest first
+	ListByInvoice(invoiceID string) ([]*entity.InvoiceDeliveryEvent, error)
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// InvoiceRepository defines the contract for invoice persistence
+type InvoiceRepository interface {
+	// Save persists a new or updated invoice
+	Save(invoice *entity.Invoice) error
+
+	// GetByID retrieves an invoice by its ID (ErrInvoiceNotFound when missing)
+	GetByID(id string) (*entity.Invoice, error)
+
+	// GetAll retrieves all invoices, oldest first
+	GetAll() ([]*entity.Invoice, error)
+
+	// ListAfter retrieves up to limit invoices matching the filter that come after a position (zero: from the first
+	// invoice) in creation order
+	ListAfter(filter InvoiceListFilter, after InvoicePosition, limit int) (*InvoicePage, error)
+
+	// UpdateNumbered allocates the next gap-free number of scope, then loads, updates and saves an invoice in one
+	// transaction (ErrInvoiceNotFound when missing). An error of update releases the number for the next invoice
+	// The number is allocated first so concurrent updates of the invoice wait and see each other's result
+	UpdateNumbered(id, scope string, update func(invoice *entity.Invoice, number int64) error) (*entity.Invoice, error)
+
+	// Delete removes an invoice (ErrInvoiceNotFound when missing)
+	Delete(id string) error
+}
+
+// InvoiceListFilter narrows an invoice listing; empty fields match every invoice
+type InvoiceListFilter struct {
+	TenantScoped    bool // Only the invoices of TenantID (the invoices created without a tenant when empty)
+	TenantID        string
+	ClientID        string
+	Status          entity.InvoiceStatus
+	ExcludeStatus   entity.InvoiceStatus // Invoices in any other status (empty: no exclusion)
+	ReferenceSearch string               // Case-insensitive substring of the external reference
+	CreatedAfter    time.Time            // Invoices created strictly after (zero: no lower bound)
+}
+
+// InvoicePosition is where an invoice stands in creation order: when it was created, then its ID
+// Listings paged by position stay consistent while invoices are added or removed between two pages
+type InvoicePosition struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// IsZero checks if the position is the start of the creation order
+func (p InvoicePosition) IsZero() bool {
+	return p.CreatedAt.IsZero() && p.ID == ""
+}
+
+// InvoicePage is a page of invoices listed after a position
+type InvoicePage struct {
+	Invoices []*entity.Invoice
+	Next     InvoicePosition // Position of the last invoice of the page (the position listed after for an empty page)
+	HasMore  bool            // More invoices come after Next
+}
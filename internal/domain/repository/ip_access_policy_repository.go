@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// IPAccessPolicyRepository defines the contract for tenant IP access policy persistence
+type IPAccessPolicyRepository interface {
+	// Save persists a policy (one policy per tenant)
+	Save(policy *entity.IPAccessPolicy) error
+
+	// GetByTenantID retrieves the policy of a tenant
+	GetByTenantID(tenantID string) (*entity.IPAccessPolicy, error)
+
+	// GetAll retrieves all policies
+	GetAll() ([]*entity.IPAccessPolicy, error)
+
+	// Delete removes the policy of a tenant
+	Delete(tenantID string) error
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// LegalEntityRepository defines the contract for legal entity persistence
+type LegalEntityRepository interface {
+	// Save persists a new or updated legal entity
+	Save(legalEntity *entity.LegalEntity) error
+
+	// GetByID retrieves a legal entity by its ID (ErrLegalEntityNotFound when missing)
+	GetByID(id string) (*entity.LegalEntity, error)
+
+	// GetAll retrieves all legal entities ordered by creation time
+	GetAll() ([]*entity.LegalEntity, error)
+
+	// Delete removes a legal entity (ErrLegalEntityNotFound when missing)
+	Delete(id string) error
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// MagicLinkRepository defines the contract for magic link persistence
+type MagicLinkRepository interface {
+	// Save persists an issued link
+	Save(link *entity.MagicLink) error
+
+	// GetByID retrieves a link (consumed links are kept); unknown IDs get ErrMagicLinkInvalid
+	GetByID(id string) (*entity.MagicLink, error)
+
+	// Consume atomically marks a link as used at the given time and returns it
+	// Only one caller can consume a given link; later calls get ErrMagicLinkUsed
+	Consume(id string, at time.Time) (*entity.MagicLink, error)
+}
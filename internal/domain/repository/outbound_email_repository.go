@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// OutboundEmailRepository defines the contract for the persistence of emails in the outbox
+type OutboundEmailRepository interface {
+	// Save persists a new or updated outbound email
+	Save(email *entity.OutboundEmail) error
+
+	// GetByID retrieves an outbound email by its ID (ErrOutboundEmailNotFound when missing)
+	GetByID(id string) (*entity.OutboundEmail, error)
+
+	// GetAll retrieves all outbound emails, oldest first
+	GetAll() ([]*entity.OutboundEmail, error)
+}
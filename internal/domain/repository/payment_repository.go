@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// PaymentRepository defines the contract for invoice payment persistence
+type PaymentRepository interface {
+	// Save persists a payment
+	Save(payment *entity.Payment) error
+
+	// ListByInvoice retrieves the payments of an invoice, oldest first
+	ListByInvoice(invoiceID string) ([]*entity.Payment, error)
+
+	// Delete removes a payment (no error when it is missing)
+	Delete(id string) error
+}
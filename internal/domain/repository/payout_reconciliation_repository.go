@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// PayoutReconciliationRepository defines the contract for payout discrepancy report persistence
+type PayoutReconciliationRepository interface {
+	// Save persists the report of a payout, replacing any earlier report of the same payout
+	Save(reconciliation *entity.PayoutReconciliation) error
+
+	// GetByPayoutID retrieves the report of a payout (ErrPayoutReconciliationNotFound when missing)
+	GetByPayoutID(payoutID string) (*entity.PayoutReconciliation, error)
+
+	// GetAll retrieves all reports ordered by payout arrival date, most recent first
+	GetAll() ([]*entity.PayoutReconciliation, error)
+}
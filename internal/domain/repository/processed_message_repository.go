@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// ProcessedMessageRepository defines the contract for the persistence of processed message records
+type ProcessedMessageRepository interface {
+	// Save persists a processed message record keyed by its message ID
+	Save(message *entity.ProcessedMessage) error
+
+	// GetByMessageID retrieves the record of a message (ErrProcessedMessageNotFound when missing)
+	GetByMessageID(messageID string) (*entity.ProcessedMessage, error)
+
+	// GetAll retrieves all processed message records, in any order
+	GetAll() ([]*entity.ProcessedMessage, error)
+
+	// Delete removes the record of a message
+	Delete(messageID string) error
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// QuoteRepository defines the contract for quote persistence
+type QuoteRepository interface {
+	// Save persists a new or updated quote
+	Save(quote *entity.Quote) error
+
+	// GetByID retrieves a quote by its ID (ErrQuoteNotFound when missing)
+	GetByID(id string) (*entity.Quote, error)
+
+	// GetAll retrieves all quotes, oldest first
+	GetAll() ([]*entity.Quote, error)
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// RecurringInvoiceTemplateRepository defines the contract for recurring invoice template persistence
+type RecurringInvoiceTemplateRepository interface {
+	// Save persists a new or updated template
+	Save(template *entity.RecurringInvoiceTemplate) error
+
+	// GetByID retrieves a template by its ID (ErrRecurringInvoiceTemplateNotFound when missing)
+	GetByID(id string) (*entity.RecurringInvoiceTemplate, error)
+
+	// GetAll retrieves all templates, oldest first
+	GetAll() ([]*entity.RecurringInvoiceTemplate, error)
+
+	// Delete removes a template (ErrRecurringInvoiceTemplateNotFound when missing)
+	Delete(id string) error
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// RiskAssessmentRepository defines the contract for client risk assessment persistence
+type RiskAssessmentRepository interface {
+	// Save persists an assessment, replacing the previous assessment of the client
+	Save(assessment *entity.RiskAssessment) error
+
+	// GetByClientID retrieves the latest assessment of a client
+	GetByClientID(clientID string) (*entity.RiskAssessment, error)
+}
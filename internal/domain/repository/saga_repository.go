@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// SagaRepository defines the contract for saga persistence
+type SagaRepository interface {
+	// Save persists a new or updated saga
+	Save(saga *entity.Saga) error
+
+	// GetByID retrieves a saga by its ID (ErrSagaNotFound when missing)
+	GetByID(id string) (*entity.Saga, error)
+
+	// GetAll retrieves all sagas, oldest first
+	GetAll() ([]*entity.Saga, error)
+}
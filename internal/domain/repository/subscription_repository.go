@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// SubscriptionRepository defines the contract for subscription persistence
+type SubscriptionRepository interface {
+	// Save persists a new or updated subscription
+	Save(subscription *entity.Subscription) error
+
+	// GetByID retrieves a subscription by its ID (ErrSubscriptionNotFound when missing)
+	GetByID(id string) (*entity.Subscription, error)
+
+	// GetAll retrieves all subscriptions, oldest first
+	GetAll() ([]*entity.Subscription, error)
+}
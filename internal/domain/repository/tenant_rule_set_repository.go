@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// TenantRuleSetRepository defines the contract for tenant business rule persistence
+type TenantRuleSetRepository interface {
+	// Save persists a rule set (one set per tenant)
+	Save(set *entity.TenantRuleSet) error
+
+	// GetByTenantID retrieves the rule set of a tenant
+	GetByTenantID(tenantID string) (*entity.TenantRuleSet, error)
+
+	// GetAll retrieves all rule sets
+	GetAll() ([]*entity.TenantRuleSet, error)
+
+	// Delete removes the rule set of a tenant
+	Delete(tenantID string) error
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// UploadRepository defines the contract for resumable upload persistence
+type UploadRepository interface {
+	// Save persists a new or updated upload
+	Save(upload *entity.Upload) error
+
+	// GetByID retrieves an upload by its ID (ErrUploadNotFound when missing)
+	GetByID(id string) (*entity.Upload, error)
+
+	// GetByStatus retrieves the uploads in a status, oldest first
+	GetByStatus(status entity.UploadStatus) ([]*entity.Upload, error)
+}
+
+// ObjectStore is the port to the storage of opaque objects such as the chunks of uploaded files
+// Any backend works, e.g. a collection of the database or an object storage bucket
+type ObjectStore interface {
+	// Put stores an object under key, replacing any object stored under it
+	Put(key string, data []byte) error
+
+	// Get retrieves the object stored under key (ErrObjectNotFound when missing)
+	Get(key string) ([]byte, error)
+
+	// Delete removes the object stored under key; removing a missing object is not an error
+	Delete(key string) error
+}
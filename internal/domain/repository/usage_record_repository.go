@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// UsageRecordRepository defines the contract for metered usage persistence
+type UsageRecordRepository interface {
+	// Record persists a usage record unless the subscription already has one with the same idempotency key
+	// It returns the stored record (the earlier one for a duplicate) and whether it was newly created
+	Record(record *entity.UsageRecord) (*entity.UsageRecord, bool, error)
+
+	// ListBySubscription retrieves the records of a subscription that occurred within [from, to), oldest first
+	ListBySubscription(subscriptionID string, from, to time.Time) ([]*entity.UsageRecord, error)
+}
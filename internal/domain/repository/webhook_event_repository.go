@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// WebhookEventRepository defines the contract for inbound webhook event persistence
+type WebhookEventRepository interface {
+	// Save persists a new or updated webhook event
+	Save(event *entity.WebhookEvent) error
+
+	// GetByID retrieves a webhook event by its ID (ErrWebhookEventNotFound when missing)
+	GetByID(id string) (*entity.WebhookEvent, error)
+
+	// GetAll retrieves all webhook events in the order they were received
+	GetAll() ([]*entity.WebhookEvent, error)
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// WebhookSubscriptionRepository defines the contract for webhook subscription persistence
+type WebhookSubscriptionRepository interface {
+	// Save persists a new or updated subscription
+	Save(subscription *entity.WebhookSubscription) error
+
+	// GetByID retrieves a subscription by its ID (ErrWebhookSubscriptionNotFound when missing)
+	GetByID(id string) (*entity.WebhookSubscription, error)
+
+	// GetAll retrieves all subscriptions ordered by creation time
+	GetAll() ([]*entity.WebhookSubscription, error)
+
+	// Delete removes a subscription (ErrWebhookSubscriptionNotFound when missing)
+	Delete(id string) error
+}
@@ -0,0 +1,11 @@
+package service
+
+// CardCharge is a card payment of an invoice requested from the payment gateway
+type CardCharge struct {
+	Reference     string // Idempotency key: a retried charge is not charged twice
+	InvoiceID     string
+	ClientID      string
+	Amount        int64 // Minor units
+	Currency      string
+	PaymentMethod string // Gateway token of the client's card
+}
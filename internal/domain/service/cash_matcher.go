@@ -0,0 +1,92 @@
+package service
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+)
+
+// Match score contributions of the cash matching heuristics
+const (
+	matchScoreReference    = 60 // The remittance information quotes the invoice number
+	matchScoreExactAmount  = 30 // The amount received equals the amount outstanding
+	matchScoreCounterparty = 10 // The payer's name matches the client's name
+
+	// MinimumMatchScore is the score below which a candidate is not suggested (an exact amount alone qualifies)
+	MinimumMatchScore = matchScoreExactAmount
+
+	// maxMatchSuggestions bounds the candidates suggested per transaction
+	maxMatchSuggestions = 5
+)
+
+// OpenInvoice is an invoice awaiting payment that bank transactions can be applied to
+type OpenInvoice struct {
+	InvoiceID   string
+	Number      string
+	ClientID    string
+	ClientName  string
+	Outstanding valueobject.Money
+}
+
+// CashMatch is an open invoice suggested for a bank transaction, with the heuristics that matched
+type CashMatch struct {
+	Invoice OpenInvoice
+	Score   int
+	Reasons []string // reference, exact_amount, counterparty
+}
+
+// SuggestCashMatches ranks the open invoices a bank transaction may pay, best first
+// Only invoices in the transaction currency are considered
+func SuggestCashMatches(transaction *entity.BankTransaction, openInvoices []OpenInvoice) []CashMatch {
+	reference := normalizeMatchText(transaction.Reference())
+	counterparty := normalizeMatchText(transaction.CounterpartyName())
+
+	matches := make([]CashMatch, 0)
+	for _, invoice := range openInvoices {
+		if invoice.Outstanding.Currency() != transaction.Amount().Currency() {
+			continue
+		}
+
+		match := CashMatch{Invoice: invoice, Reasons: make([]string, 0, 3)}
+		if number := normalizeMatchText(invoice.Number); number != "" && strings.Contains(reference, number) {
+			match.Score += matchScoreReference
+			match.Reasons = append(match.Reasons, "reference")
+		}
+		if invoice.Outstanding.Equals(transaction.Amount()) {
+			match.Score += matchScoreExactAmount
+			match.Reasons = append(match.Reasons, "exact_amount")
+		}
+		if name := normalizeMatchText(invoice.ClientName); name != "" && counterparty != "" &&
+			(strings.Contains(counterparty, name) || strings.Contains(name, counterparty)) {
+			match.Score += matchScoreCounterparty
+			match.Reasons = append(match.Reasons, "counterparty")
+		}
+
+		if match.Score >= MinimumMatchScore {
+			matches = append(matches, match)
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+	if len(matches) > maxMatchSuggestions {
+		matches = matches[:maxMatchSuggestions]
+	}
+	return matches
+}
+
+// normalizeMatchText lowercases text and drops everything but letters and digits,
+// so "INV-2026/0042" matches a remittance of "inv 2026 0042"
+func normalizeMatchText(text string) string {
+	var builder strings.Builder
+	for _, r := range strings.ToLower(text) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
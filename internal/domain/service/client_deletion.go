@@ -0,0 +1,104 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+)
+
+// ClientDeletionRule is what happens to the invoices of a client when the client is deleted
+type ClientDeletionRule string
+
+const (
+	// ClientDeletionBlock refuses to delete a client that has such invoices
+	ClientDeletionBlock ClientDeletionRule = "block"
+
+	// ClientDeletionCascade voids the invoices with the client (drafts only), which keeps them as soft-deleted rows
+	ClientDeletionCascade ClientDeletionRule = "cascade"
+
+	// ClientDeletionRetain keeps the invoices on record, still referencing the deleted client (closed invoices only)
+	ClientDeletionRetain ClientDeletionRule = "retain"
+)
+
+// ClientDeletionPolicy decides whether a client can be deleted given its invoices
+// Issued invoices always block the deletion: they are still owed; drafts and closed invoices follow their rule
+// The zero policy is the default policy
+type ClientDeletionPolicy struct {
+	Drafts ClientDeletionRule // ClientDeletionCascade (default) or ClientDeletionBlock
+	Closed ClientDeletionRule // ClientDeletionBlock (default, orphan protection) or ClientDeletionRetain
+}
+
+// DefaultClientDeletionPolicy cascades drafts and protects paid and void invoices from being orphaned
+func DefaultClientDeletionPolicy() ClientDeletionPolicy {
+	return ClientDeletionPolicy{
+		Drafts: ClientDeletionCascade,
+		Closed: ClientDeletionBlock,
+	}
+}
+
+// NewClientDeletionPolicy validates the rules of drafts and closed invoices; empty rules take the default
+func NewClientDeletionPolicy(drafts, closed string) (ClientDeletionPolicy, error) {
+	policy := DefaultClientDeletionPolicy()
+
+	switch rule := ClientDeletionRule(strings.ToLower(strings.TrimSpace(drafts))); rule {
+	case "":
+	case ClientDeletionCascade, ClientDeletionBlock:
+		policy.Drafts = rule
+	default:
+		return ClientDeletionPolicy{}, errors.NewValidationError("draft_invoices", drafts, errors.ValidationFormat, "draft invoice rule must be one of: cascade, block")
+	}
+
+	switch rule := ClientDeletionRule(strings.ToLower(strings.TrimSpace(closed))); rule {
+	case "":
+	case ClientDeletionBlock, ClientDeletionRetain:
+		policy.Closed = rule
+	default:
+		return ClientDeletionPolicy{}, errors.NewValidationError("closed_invoices", closed, errors.ValidationFormat, "closed invoice rule must be one of: block, retain")
+	}
+
+	return policy, nil
+}
+
+// Cascade returns the invoices of the client to void with it, or the error refusing the deletion
+// Open invoices are reported first (409 BUSINESS_RULE_DEPENDENT), then blocked drafts (409), then invoices on record
+// that would be orphaned (422 BUSINESS_RULE_VIOLATION); each error lists the invoices in its context
+func (p ClientDeletionPolicy) Cascade(clientID string, invoices []*entity.Invoice) ([]*entity.Invoice, error) {
+	var open, drafts, closed []string
+	var cascade []*entity.Invoice
+	for _, invoice := range invoices {
+		if invoice.ClientID() != clientID {
+			continue
+		}
+		switch {
+		case invoice.Status() == entity.InvoiceIssued:
+			open = append(open, invoice.ID())
+		case invoice.IsDraft():
+			drafts = append(drafts, invoice.ID())
+			cascade = append(cascade, invoice)
+		case invoice.IsOnRecord():
+			closed = append(closed, invoice.ID())
+		}
+	}
+
+	if len(open) > 0 {
+		return nil, clientDeletionError("client_open_invoices", errors.BusinessRuleDependent,
+			"client has open invoices; collect or void them before deleting the client", open)
+	}
+	if len(drafts) > 0 && p.Drafts == ClientDeletionBlock {
+		return nil, clientDeletionError("client_draft_invoices", errors.BusinessRuleDependent,
+			"client has draft invoices; delete them before deleting the client", drafts)
+	}
+	if len(closed) > 0 && p.Closed != ClientDeletionRetain {
+		return nil, clientDeletionError("client_invoice_orphans", errors.BusinessRuleViolation,
+			"client has paid or void invoices on record, which would be orphaned by its deletion", closed)
+	}
+	return cascade, nil
+}
+
+// clientDeletionError creates the error refusing a client deletion because of the given invoices
+func clientDeletionError(rule string, code errors.ErrorCode, message string, invoiceIDs []string) error {
+	err := errors.NewBusinessRuleError(rule, code, message)
+	err.Context["invoice_ids"] = invoiceIDs
+	return err
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+)
+
+// StatementEntryKind is what a client account entry records
+type StatementEntryKind string
+
+const (
+	// StatementInvoice is an invoice issued to the client (increases the balance)
+	StatementInvoice StatementEntryKind = "invoice"
+
+	// StatementCreditNote is a credit note issued to the client (decreases the balance)
+	StatementCreditNote StatementEntryKind = "credit_note"
+
+	// StatementPayment is a payment received from the client (decreases the balance)
+	StatementPayment StatementEntryKind = "payment"
+)
+
+// StatementEntry is an invoice, credit note or payment on the account of a client
+type StatementEntry struct {
+	Date        time.Time
+	Kind        StatementEntryKind
+	Reference   string // Invoice or credit note number, payment reference
+	Description string
+	Amount      valueobject.Money // Positive; the kind decides whether it is owed or paid
+}
+
+// StatementLine is an entry of a statement with the client balance after it
+type StatementLine struct {
+	StatementEntry
+	Balance valueobject.Money
+}
+
+// ClientStatement is the account activity of a client in one currency over a date range
+type ClientStatement struct {
+	Currency       string
+	From           time.Time
+	To             time.Time
+	OpeningBalance valueobject.Money // Owed before From (negative when the client is in credit)
+	Lines          []StatementLine
+	Invoiced       valueobject.Money // Invoices of the range
+	Paid           valueobject.Money // Payments and credit notes of the range
+	ClosingBalance valueobject.Money
+}
+
+// BuildClientStatement lays out the entries of a client in a currency from one date to another (both inclusive)
+// Earlier entries make the opening balance; entries in other currencies or after the range are left out.
+// Lines are in date order, invoices before the payments and credit notes of the same day
+func BuildClientStatement(currency string, from, to time.Time, entries []StatementEntry) (ClientStatement, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if to.Before(from) {
+		return ClientStatement{}, errors.NewValidationError("to", to, errors.ValidationRange, "statement end must not be before its start")
+	}
+
+	statement := ClientStatement{
+		Currency:       currency,
+		From:           from,
+		To:             to,
+		OpeningBalance: valueobject.ZeroMoney(currency),
+		Lines:          make([]StatementLine, 0),
+		Invoiced:       valueobject.ZeroMoney(currency),
+		Paid:           valueobject.ZeroMoney(currency),
+	}
+
+	sorted := make([]StatementEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Amount.Currency() == currency && !entry.Date.After(to) {
+			sorted = append(sorted, entry)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if !sorted[i].Date.Equal(sorted[j].Date) {
+			return sorted[i].Date.Before(sorted[j].Date)
+		}
+		return sorted[i].Kind == StatementInvoice && sorted[j].Kind != StatementInvoice
+	})
+
+	balance := statement.OpeningBalance
+	for _, entry := range sorted {
+		var err error
+		switch entry.Kind {
+		case StatementInvoice:
+			balance, err = balance.Add(entry.Amount)
+		case StatementCreditNote, StatementPayment:
+			balance, err = balance.Subtract(entry.Amount)
+		default:
+			return ClientStatement{}, errors.NewValidationError("kind", entry.Kind, errors.ValidationFormat, "statement entries must be invoices, credit notes or payments")
+		}
+		if err != nil {
+			return ClientStatement{}, err
+		}
+
+		if entry.Date.Before(from) {
+			statement.OpeningBalance = balance
+			continue
+		}
+		if entry.Kind == StatementInvoice {
+			statement.Invoiced, err = statement.Invoiced.Add(entry.Amount)
+		} else {
+			statement.Paid, err = statement.Paid.Add(entry.Amount)
+		}
+		if err != nil {
+			return ClientStatement{}, err
+		}
+		statement.Lines = append(statement.Lines, StatementLine{StatementEntry: entry, Balance: balance})
+	}
+	statement.ClosingBalance = balance
+
+	return statement, nil
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"sort"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+)
+
+// UnappliedCredit is money a client holds against its invoices that was not applied yet
+// (credit notes issued and payments received but not allocated to an invoice)
+type UnappliedCredit struct {
+	Reference string
+	ClientID  string
+	Amount    valueobject.Money
+}
+
+// CreditExposure is what a client owes in a currency, net of the credit it has not used yet
+type CreditExposure struct {
+	Currency         string
+	OpenInvoices     valueobject.Money // Outstanding amount of the open invoices
+	OpenInvoiceCount int
+	UnappliedCredit  valueobject.Money
+}
+
+// Exposure returns the open invoices minus the unapplied credit (negative when the client is in credit)
+func (e CreditExposure) Exposure() valueobject.Money {
+	exposure, _ := e.OpenInvoices.Subtract(e.UnappliedCredit)
+	return exposure
+}
+
+// CreditDecision is the outcome of checking an invoice against the credit limit of its client
+type CreditDecision string
+
+const (
+	// CreditWithinLimit means the invoice keeps the client within its limit (or no limit applies)
+	CreditWithinLimit CreditDecision = "within_limit"
+
+	// CreditOverLimitWarned means the invoice exceeds the limit and is issued with a warning
+	CreditOverLimitWarned CreditDecision = "warned"
+
+	// CreditOverLimitBlocked means the invoice exceeds the limit and must not be issued
+	CreditOverLimitBlocked CreditDecision = "blocked"
+
+	// CreditApprovalRequired means the invoice exceeds the limit and needs an approved override
+	CreditApprovalRequired CreditDecision = "approval_required"
+)
+
+// CalculateExposure sums the open invoices and unapplied credit of a client per currency, ordered by currency
+func CalculateExposure(clientID string, openInvoices []OpenInvoice, credits []UnappliedCredit) []CreditExposure {
+	byCurrency := make(map[string]*CreditExposure)
+	exposureIn := func(currency string) *CreditExposure {
+		exposure, ok := byCurrency[currency]
+		if !ok {
+			exposure = &CreditExposure{
+				Currency:        currency,
+				OpenInvoices:    valueobject.ZeroMoney(currency),
+				UnappliedCredit: valueobject.ZeroMoney(currency),
+			}
+			byCurrency[currency] = exposure
+		}
+		return exposure
+	}
+
+	for _, invoice := range openInvoices {
+		if invoice.ClientID != clientID {
+			continue
+		}
+		exposure := exposureIn(invoice.Outstanding.Currency())
+		exposure.OpenInvoices, _ = exposure.OpenInvoices.Add(invoice.Outstanding)
+		exposure.OpenInvoiceCount++
+	}
+	for _, credit := range credits {
+		if credit.ClientID != clientID {
+			continue
+		}
+		exposure := exposureIn(credit.Amount.Currency())
+		exposure.UnappliedCredit, _ = exposure.UnappliedCredit.Add(credit.Amount)
+	}
+
+	exposures := make([]CreditExposure, 0, len(byCurrency))
+	for _, exposure := range byCurrency {
+		exposures = append(exposures, *exposure)
+	}
+	sort.Slice(exposures, func(i, j int) bool {
+		return exposures[i].Currency < exposures[j].Currency
+	})
+	return exposures
+}
+
+// ExposureIn returns the exposure in a currency (zero when the client owes nothing in it)
+func ExposureIn(exposures []CreditExposure, currency string) CreditExposure {
+	for _, exposure := range exposures {
+		if exposure.Currency == currency {
+			return exposure
+		}
+	}
+	return CreditExposure{
+		Currency:        currency,
+		OpenInvoices:    valueobject.ZeroMoney(currency),
+		UnappliedCredit: valueobject.ZeroMoney(currency),
+	}
+}
+
+// CheckCredit decides whether an invoice of amount may be issued to a client with the given exposure
+// Invoices in another currency than the limit are not covered by it
+func CheckCredit(limit *entity.ClientCreditLimit, exposure CreditExposure, amount valueobject.Money) CreditDecision {
+	if limit == nil || amount.Currency() != limit.Limit().Currency() {
+		return CreditWithinLimit
+	}
+	if exposure.Exposure().Amount()+amount.Amount() <= limit.Limit().Amount() {
+		return CreditWithinLimit
+	}
+
+	switch limit.Policy() {
+	case entity.CreditPolicyWarn:
+		return CreditOverLimitWarned
+	case entity.CreditPolicyRequireApproval:
+		return CreditApprovalRequired
+	default:
+		return CreditOverLimitBlocked
+	}
+}
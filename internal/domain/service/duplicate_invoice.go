@@ -0,0 +1,116 @@
+package service
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// DuplicateMatch is why a new invoice is considered a duplicate of an existing one
+type DuplicateMatch string
+
+const (
+	// DuplicateByReference means both invoices carry the same external reference
+	DuplicateByReference DuplicateMatch = "external_ref"
+
+	// DuplicateByLineItems means both invoices bill the same line items in the same currency
+	DuplicateByLineItems DuplicateMatch = "line_items"
+)
+
+// DuplicateInvoiceCheck recognizes invoices created twice for the same client of a tenant: invoices with the
+// same external reference, however long ago the other one was created, or with the same currency and line items
+// created within the window
+type DuplicateInvoiceCheck struct {
+	LineItemWindow time.Duration // 0: line items are not compared
+}
+
+// duplicateCandidate is what the check compares: an invoice, or a template invoices are issued from
+type duplicateCandidate interface {
+	ID() string
+	TenantID() string
+	ClientID() string
+	ExternalReference() string
+	Currency() string
+	CreatedAt() time.Time
+}
+
+// FindDuplicate returns the earliest existing template the candidate duplicates, and why
+// Only templates of the same tenant and client are compared; the candidate itself is skipped
+func (c DuplicateInvoiceCheck) FindDuplicate(candidate *entity.RecurringInvoiceTemplate, existing []*entity.RecurringInvoiceTemplate) (*entity.RecurringInvoiceTemplate, DuplicateMatch) {
+	return findDuplicate(c, candidate, existing, func(template *entity.RecurringInvoiceTemplate) []entity.InvoiceLine {
+		lines := make([]entity.InvoiceLine, 0, len(template.Lines()))
+		for _, line := range template.Lines() {
+			lines = append(lines, entity.InvoiceLine(line))
+		}
+		return lines
+	})
+}
+
+// FindDuplicateInvoice returns the earliest existing invoice the candidate duplicates, and why
+// Only invoices of the same tenant and client are compared, voided invoices and the candidate itself are skipped
+func (c DuplicateInvoiceCheck) FindDuplicateInvoice(candidate *entity.Invoice, existing []*entity.Invoice) (*entity.Invoice, DuplicateMatch) {
+	live := make([]*entity.Invoice, 0, len(existing))
+	for _, invoice := range existing {
+		if invoice.Status() != entity.InvoiceVoid {
+			live = append(live, invoice)
+		}
+	}
+	return findDuplicate(c, candidate, live, (*entity.Invoice).Lines)
+}
+
+// findDuplicate returns the earliest existing invoice or template the candidate duplicates, and why
+func findDuplicate[T duplicateCandidate](c DuplicateInvoiceCheck, candidate T, existing []T, lines func(T) []entity.InvoiceLine) (T, DuplicateMatch) {
+	sorted := make([]T, 0, len(existing))
+	for _, other := range existing {
+		if other.ID() != candidate.ID() && other.TenantID() == candidate.TenantID() && other.ClientID() == candidate.ClientID() {
+			sorted = append(sorted, other)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt().Before(sorted[j].CreatedAt())
+	})
+
+	reference := candidate.ExternalReference()
+	if reference != "" {
+		for _, other := range sorted {
+			if strings.EqualFold(other.ExternalReference(), reference) {
+				return other, DuplicateByReference
+			}
+		}
+	}
+
+	if c.LineItemWindow > 0 {
+		since := candidate.CreatedAt().Add(-c.LineItemWindow)
+		for _, other := range sorted {
+			if other.CreatedAt().After(since) && other.Currency() == candidate.Currency() && sameLines(lines(other), lines(candidate)) {
+				return other, DuplicateByLineItems
+			}
+		}
+	}
+
+	var none T
+	return none, ""
+}
+
+// sameLines checks if two invoices bill the same line items, in any order
+func sameLines(a, b []entity.InvoiceLine) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	counts := make(map[entity.InvoiceLine]int, len(a))
+	for _, line := range a {
+		line.Description = strings.ToLower(line.Description)
+		counts[line]++
+	}
+	for _, line := range b {
+		line.Description = strings.ToLower(line.Description)
+		if counts[line] == 0 {
+			return false
+		}
+		counts[line]--
+	}
+	return true
+}
@@ -0,0 +1,271 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+)
+
+// Country groups compliance rules can be keyed by, besides ISO 3166-1 alpha-2 codes
+const (
+	// ComplianceAnyCountry matches every country, including an unknown one
+	ComplianceAnyCountry = ""
+
+	// ComplianceEU matches the member states of the European Union
+	ComplianceEU = "EU"
+
+	// ComplianceNonEU matches known countries outside the European Union
+	ComplianceNonEU = "NON_EU"
+)
+
+// euMemberStates are the ISO codes of the European Union member states
+var euMemberStates = map[string]bool{
+	"AT": true, "BE": true, "BG": true, "CY": true, "CZ": true, "DE": true, "DK": true, "EE": true, "ES": true,
+	"FI": true, "FR": true, "GR": true, "HR": true, "HU": true, "IE": true, "IT": true, "LT": true, "LU": true,
+	"LV": true, "MT": true, "NL": true, "PL": true, "PT": true, "RO": true, "SE": true, "SI": true, "SK": true,
+}
+
+// IsEUMemberState checks if a country code belongs to the European Union
+func IsEUMemberState(country string) bool {
+	return euMemberStates[strings.ToUpper(strings.TrimSpace(country))]
+}
+
+// TaxBreakdownStyle is how the taxes of an invoice are itemized on its document
+type TaxBreakdownStyle string
+
+const (
+	// TaxBreakdownTotal prints the tax total only
+	TaxBreakdownTotal TaxBreakdownStyle = "total"
+
+	// TaxBreakdownPerRate prints the taxable amount and tax of every rate (EU VAT directive, article 226)
+	TaxBreakdownPerRate TaxBreakdownStyle = "per_rate"
+
+	// TaxBreakdownPerLine prints the rate and tax of every line item
+	TaxBreakdownPerLine TaxBreakdownStyle = "per_line"
+)
+
+// taxBreakdownDetail orders the breakdown styles from the least to the most detailed
+var taxBreakdownDetail = map[TaxBreakdownStyle]int{
+	TaxBreakdownTotal:   0,
+	TaxBreakdownPerRate: 1,
+	TaxBreakdownPerLine: 2,
+}
+
+// ParseTaxBreakdownStyle validates a tax breakdown style (empty means the tax total only)
+func ParseTaxBreakdownStyle(style string) (TaxBreakdownStyle, error) {
+	if style == "" {
+		return TaxBreakdownTotal, nil
+	}
+	if _, ok := taxBreakdownDetail[TaxBreakdownStyle(style)]; !ok {
+		return "", errors.NewValidationError("tax_breakdown", style, errors.ValidationFormat, "tax breakdown must be one of: total, per_rate, per_line")
+	}
+	return TaxBreakdownStyle(style), nil
+}
+
+// ComplianceRule is a legal requirement on the invoices a seller country issues to a buyer country
+// Sellers and buyers are matched by country code, ComplianceEU, ComplianceNonEU or ComplianceAnyCountry;
+// CrossBorder and ZeroRated narrow a rule to invoices between two countries, or charging no tax
+type ComplianceRule struct {
+	Name          string
+	SellerCountry string
+	BuyerCountry  string
+	CrossBorder   bool // Only when the buyer is in another country than the seller
+	ZeroRated     bool // Only when no line item is taxed
+
+	RequireSellerTaxID bool
+	RequireBuyerTaxID  bool
+	TaxBreakdown       TaxBreakdownStyle
+	Mentions           []string // Legal mentions printed on the invoice document
+}
+
+// Validate checks the countries and breakdown style of a rule
+func (r ComplianceRule) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return errors.NewValidationError("name", r.Name, errors.ValidationRequired, "compliance rule name is required")
+	}
+	if !isComplianceCountry(r.SellerCountry) {
+		return errors.NewValidationError("seller_country", r.SellerCountry, errors.ValidationFormat,
+			fmt.Sprintf("compliance rule %s must match sellers by country code, EU or NON_EU", r.Name))
+	}
+	if !isComplianceCountry(r.BuyerCountry) {
+		return errors.NewValidationError("buyer_country", r.BuyerCountry, errors.ValidationFormat,
+			fmt.Sprintf("compliance rule %s must match buyers by country code, EU or NON_EU", r.Name))
+	}
+	if _, err := ParseTaxBreakdownStyle(string(r.TaxBreakdown)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DefaultComplianceRules returns the built-in invoice requirements of the EU VAT directive and its member states
+func DefaultComplianceRules() []ComplianceRule {
+	return []ComplianceRule{
+		{
+			Name:               "eu_vat_invoice",
+			SellerCountry:      ComplianceEU,
+			RequireSellerTaxID: true,
+			TaxBreakdown:       TaxBreakdownPerRate,
+		},
+		{
+			Name:              "eu_reverse_charge",
+			SellerCountry:     ComplianceEU,
+			BuyerCountry:      ComplianceEU,
+			CrossBorder:       true,
+			ZeroRated:         true,
+			RequireBuyerTaxID: true,
+			Mentions:          []string{"Reverse charge: VAT to be accounted for by the recipient (Article 196, Directive 2006/112/EC)"},
+		},
+		{
+			Name:          "eu_export",
+			SellerCountry: ComplianceEU,
+			BuyerCountry:  ComplianceNonEU,
+			ZeroRated:     true,
+			Mentions:      []string{"Not subject to EU VAT: place of supply outside the European Union (Article 44, Directive 2006/112/EC)"},
+		},
+		{
+			Name:          "fr_late_payment",
+			SellerCountry: "FR",
+			Mentions: []string{
+				"Pénalités de retard : trois fois le taux d'intérêt légal",
+				"Indemnité forfaitaire pour frais de recouvrement : 40 €",
+			},
+		},
+		{
+			Name:               "gb_vat_invoice",
+			SellerCountry:      "GB",
+			RequireSellerTaxID: true,
+			TaxBreakdown:       TaxBreakdownPerLine,
+		},
+	}
+}
+
+// ComplianceInvoice is what compliance rules check on an invoice
+type ComplianceInvoice struct {
+	SellerCountry string
+	SellerTaxIDs  []string
+	BuyerCountry  string // Empty when unknown: only rules for any buyer apply
+	BuyerTaxID    string
+	TaxRatesBps   []int64 // Tax rate of every line item (2000 = 20%)
+}
+
+// ComplianceViolation is a requirement an invoice does not meet
+type ComplianceViolation struct {
+	Rule    string
+	Field   string
+	Message string
+}
+
+// ComplianceResult is what the rules matching an invoice require
+type ComplianceResult struct {
+	Rules        []string // Names of the matching rules, in rule order
+	TaxBreakdown TaxBreakdownStyle
+	Mentions     []string
+	Violations   []ComplianceViolation
+}
+
+// Compliant checks if the invoice meets every matching rule
+func (r ComplianceResult) Compliant() bool {
+	return len(r.Violations) == 0
+}
+
+// Err returns a validation error describing the first violation (nil when compliant)
+func (r ComplianceResult) Err() error {
+	if r.Compliant() {
+		return nil
+	}
+	violation := r.Violations[0]
+	return errors.NewValidationError(violation.Field, violation.Rule, errors.ValidationRequired, violation.Message)
+}
+
+// EvaluateCompliance checks an invoice against the rules matching its seller and buyer countries
+// The most detailed tax breakdown of the matching rules applies; their mentions are collected once each, in rule order
+func EvaluateCompliance(invoice ComplianceInvoice, rules []ComplianceRule) ComplianceResult {
+	seller := strings.ToUpper(strings.TrimSpace(invoice.SellerCountry))
+	buyer := strings.ToUpper(strings.TrimSpace(invoice.BuyerCountry))
+	zeroRated := true
+	for _, rate := range invoice.TaxRatesBps {
+		if rate != 0 {
+			zeroRated = false
+		}
+	}
+
+	result := ComplianceResult{
+		Rules:        make([]string, 0),
+		TaxBreakdown: TaxBreakdownTotal,
+		Mentions:     make([]string, 0),
+		Violations:   make([]ComplianceViolation, 0),
+	}
+	seen := make(map[string]bool)
+	for _, rule := range rules {
+		if !matchesCountry(rule.SellerCountry, seller) || !matchesCountry(rule.BuyerCountry, buyer) {
+			continue
+		}
+		if rule.CrossBorder && (buyer == "" || buyer == seller) {
+			continue
+		}
+		if rule.ZeroRated && !zeroRated {
+			continue
+		}
+
+		result.Rules = append(result.Rules, rule.Name)
+		if taxBreakdownDetail[rule.TaxBreakdown] > taxBreakdownDetail[result.TaxBreakdown] {
+			result.TaxBreakdown = rule.TaxBreakdown
+		}
+		for _, mention := range rule.Mentions {
+			if !seen[mention] {
+				seen[mention] = true
+				result.Mentions = append(result.Mentions, mention)
+			}
+		}
+
+		if rule.RequireSellerTaxID && !hasTaxID(invoice.SellerTaxIDs) {
+			result.Violations = append(result.Violations, ComplianceViolation{
+				Rule:    rule.Name,
+				Field:   "seller.tax_id",
+				Message: fmt.Sprintf("invoices issued from %s must show the seller's tax number", seller),
+			})
+		}
+		if rule.RequireBuyerTaxID && strings.TrimSpace(invoice.BuyerTaxID) == "" {
+			result.Violations = append(result.Violations, ComplianceViolation{
+				Rule:    rule.Name,
+				Field:   "buyer_tax_id",
+				Message: fmt.Sprintf("invoices issued from %s to %s must show the buyer's tax number", seller, buyer),
+			})
+		}
+	}
+	return result
+}
+
+// matchesCountry checks a country (empty when unknown) against the country a rule is keyed by
+func matchesCountry(ruleCountry, country string) bool {
+	switch ruleCountry {
+	case ComplianceAnyCountry:
+		return true
+	case ComplianceEU:
+		return IsEUMemberState(country)
+	case ComplianceNonEU:
+		return country != "" && !IsEUMemberState(country)
+	default:
+		return ruleCountry == country
+	}
+}
+
+// isComplianceCountry checks a country rules may be keyed by
+func isComplianceCountry(country string) bool {
+	switch country {
+	case ComplianceAnyCountry, ComplianceEU, ComplianceNonEU:
+		return true
+	}
+	return len(country) == 2 && country[0] >= 'A' && country[0] <= 'Z' && country[1] >= 'A' && country[1] <= 'Z'
+}
+
+// hasTaxID checks for at least one non-blank tax number
+func hasTaxID(taxIDs []string) bool {
+	for _, taxID := range taxIDs {
+		if strings.TrimSpace(taxID) != "" {
+			return true
+		}
+	}
+	return false
+}
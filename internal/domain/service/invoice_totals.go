@@ -0,0 +1,69 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+)
+
+// InvoiceLine is a line item priced in its own currency
+type InvoiceLine struct {
+	Quantity     int64
+	UnitAmount   valueobject.Money
+	ExchangeRate *valueobject.ExchangeRate // Required when the unit amount is not in the invoice currency
+}
+
+// InvoiceTotals are the amounts of an invoice in its currency
+type InvoiceTotals struct {
+	UnitAmounts []valueobject.Money // Unit amounts, converted to the invoice currency
+	LineAmounts []valueobject.Money
+	Total       valueobject.Money
+}
+
+// CalculateInvoiceTotals converts line items to the invoice currency and totals them
+// Lines in another currency must carry an explicit exchange rate to the invoice currency; their unit amounts are
+// converted once, rounded with rounding, so the line amounts always add up to the total
+func CalculateInvoiceTotals(currency string, lines []InvoiceLine, rounding valueobject.RoundingMode) (InvoiceTotals, error) {
+	totals := InvoiceTotals{
+		UnitAmounts: make([]valueobject.Money, len(lines)),
+		LineAmounts: make([]valueobject.Money, len(lines)),
+		Total:       valueobject.ZeroMoney(currency),
+	}
+
+	for i, line := range lines {
+		field := fmt.Sprintf("line_items[%d]", i)
+		unit := line.UnitAmount
+
+		if unit.Currency() != totals.Total.Currency() {
+			if line.ExchangeRate == nil {
+				return InvoiceTotals{}, errors.NewValidationError(field+".currency", unit.Currency(), errors.ValidationFormat,
+					fmt.Sprintf("line currency must be %s, or be converted with an exchange rate", totals.Total.Currency()))
+			}
+			if line.ExchangeRate.From() != unit.Currency() || line.ExchangeRate.To() != totals.Total.Currency() {
+				return InvoiceTotals{}, errors.NewValidationError(field+".exchange_rate", line.ExchangeRate.From()+"/"+line.ExchangeRate.To(), errors.ValidationFormat,
+					fmt.Sprintf("exchange rate must convert %s to %s", unit.Currency(), totals.Total.Currency()))
+			}
+
+			converted, err := line.ExchangeRate.Convert(unit, rounding)
+			if err != nil {
+				return InvoiceTotals{}, err
+			}
+			unit = converted
+		}
+
+		amount, err := unit.MultiplyRatioRounded(line.Quantity, 1, rounding)
+		if err != nil {
+			return InvoiceTotals{}, err
+		}
+		total, err := totals.Total.Add(amount)
+		if err != nil {
+			return InvoiceTotals{}, err
+		}
+
+		totals.UnitAmounts[i] = unit
+		totals.LineAmounts[i] = amount
+		totals.Total = total
+	}
+	return totals, nil
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+)
+
+// PayoutItem is a balance transaction settled by a gateway payout
+type PayoutItem struct {
+	Reference string            // Gateway reference of the charge, refund or fee
+	Type      string            // charge, refund, fee, adjustment (informational)
+	Amount    valueobject.Money // Gross amount, negative for refunds and fees
+	Fee       valueobject.Money // Fee the gateway withheld on the item
+}
+
+// GatewayPayout is a transfer from the payment gateway to the company bank account
+type GatewayPayout struct {
+	ID          string
+	ArrivalDate time.Time
+	Amount      valueobject.Money // Net amount paid out
+	Items       []PayoutItem
+}
+
+// LedgerEntry is a payment or fee recorded in the ledger for a gateway reference
+type LedgerEntry struct {
+	PaymentID        string
+	GatewayReference string
+	Amount           valueobject.Money // Gross amount recorded
+	Fee              valueobject.Money // Gateway fee recorded
+}
+
+// ReconcilePayout compares a payout with the ledger entries recorded for its items
+// Each item is matched by gateway reference; the payout amount must equal the net sum of its items
+func ReconcilePayout(payout GatewayPayout, ledger []LedgerEntry) (*entity.PayoutReconciliation, error) {
+	entries := make(map[string]LedgerEntry, len(ledger))
+	for _, entry := range ledger {
+		entries[entry.GatewayReference] = entry
+	}
+
+	currency := payout.Amount.Currency()
+	discrepancies := make([]entity.PayoutDiscrepancy, 0)
+	matched := 0
+	var itemsNet int64
+
+	for _, item := range payout.Items {
+		itemsNet += item.Amount.Amount() - item.Fee.Amount()
+
+		entry, found := entries[item.Reference]
+		if !found {
+			discrepancies = append(discrepancies, entity.PayoutDiscrepancy{
+				Kind:          entity.PayoutDiscrepancyMissingInLedger,
+				Reference:     item.Reference,
+				GatewayAmount: item.Amount.Amount(),
+			})
+			continue
+		}
+		matched++
+
+		// Entries recorded in another currency never match the payout
+		if entry.Amount.Currency() != currency || entry.Amount.Amount() != item.Amount.Amount() {
+			discrepancies = append(discrepancies, entity.PayoutDiscrepancy{
+				Kind:          entity.PayoutDiscrepancyAmountMismatch,
+				Reference:     item.Reference,
+				PaymentID:     entry.PaymentID,
+				GatewayAmount: item.Amount.Amount(),
+				LedgerAmount:  entry.Amount.Amount(),
+			})
+		}
+		if entry.Fee.Amount() != item.Fee.Amount() {
+			discrepancies = append(discrepancies, entity.PayoutDiscrepancy{
+				Kind:          entity.PayoutDiscrepancyFeeMismatch,
+				Reference:     item.Reference,
+				PaymentID:     entry.PaymentID,
+				GatewayAmount: item.Fee.Amount(),
+				LedgerAmount:  entry.Fee.Amount(),
+			})
+		}
+	}
+
+	if itemsNet != payout.Amount.Amount() {
+		discrepancies = append(discrepancies, entity.PayoutDiscrepancy{
+			Kind:          entity.PayoutDiscrepancyTotalMismatch,
+			GatewayAmount: payout.Amount.Amount(),
+			LedgerAmount:  itemsNet,
+		})
+	}
+
+	return entity.NewPayoutReconciliation(payout.ID, payout.ArrivalDate, payout.Amount, len(payout.Items), matched, discrepancies)
+}
@@ -0,0 +1,133 @@
+// Package service holds stateless domain services: business calculations that span
+// several value objects or entities and do not belong to any one of them.
+package service
+
+import (
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+)
+
+// ProrationStrategy decides how a mid-cycle plan change is charged
+type ProrationStrategy string
+
+const (
+	// ProrationDayBased credits the unused days of the old plan and charges the remaining days of the new one
+	ProrationDayBased ProrationStrategy = "day_based"
+
+	// ProrationNone ignores mid-cycle changes; the new price applies from the next cycle
+	ProrationNone ProrationStrategy = "none"
+
+	// ProrationCreditOnly credits the price difference for the remaining days of a downgrade and never charges mid-cycle
+	ProrationCreditOnly ProrationStrategy = "credit_only"
+)
+
+// PlanChange describes a subscription moving between prices during a billing period
+// Prices are for a full period; the period end is exclusive
+type PlanChange struct {
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	ChangeAt    time.Time
+	OldPrice    valueobject.Money
+	NewPrice    valueobject.Money
+}
+
+// ProrationResult is the adjustment owed for a plan change
+// Net is Charge minus Credit: positive amounts are invoiced, negative amounts are credited
+type ProrationResult struct {
+	PeriodDays    int
+	RemainingDays int
+	Credit        valueobject.Money
+	Charge        valueobject.Money
+	Net           valueobject.Money
+}
+
+// Prorator computes prorated charges and credits for plan changes
+type Prorator struct {
+	strategy ProrationStrategy
+}
+
+// NewProrator creates a prorator for a strategy
+func NewProrator(strategy ProrationStrategy) (*Prorator, error) {
+	switch strategy {
+	case ProrationDayBased, ProrationNone, ProrationCreditOnly:
+		return &Prorator{strategy: strategy}, nil
+	default:
+		return nil, errors.NewValidationError("proration_strategy", strategy, errors.ValidationFormat, "proration strategy must be one of: day_based, none, credit_only")
+	}
+}
+
+// Strategy returns the proration strategy
+func (p *Prorator) Strategy() ProrationStrategy {
+	return p.strategy
+}
+
+// Prorate computes the adjustment for a plan change; days are whole UTC calendar days
+func (p *Prorator) Prorate(change PlanChange) (ProrationResult, error) {
+	if change.OldPrice.Currency() != change.NewPrice.Currency() {
+		return ProrationResult{}, errors.NewBusinessRuleError("currency_mismatch", errors.BusinessRuleViolation, "old and new prices must use the same currency")
+	}
+	if change.OldPrice.IsNegative() || change.NewPrice.IsNegative() {
+		return ProrationResult{}, errors.NewValidationError("price", nil, errors.ValidationRange, "prices must not be negative")
+	}
+
+	periodStart, periodEnd, changeDay := utcDay(change.PeriodStart), utcDay(change.PeriodEnd), utcDay(change.ChangeAt)
+	if !periodEnd.After(periodStart) {
+		return ProrationResult{}, errors.NewValidationError("period_end", change.PeriodEnd, errors.ValidationRange, "billing period must end after it starts")
+	}
+	if changeDay.Before(periodStart) || !changeDay.Before(periodEnd) {
+		return ProrationResult{}, errors.NewValidationError("change_at", change.ChangeAt, errors.ValidationRange, "plan change must happen within the billing period")
+	}
+
+	periodDays := daysBetween(periodStart, periodEnd)
+	remainingDays := daysBetween(changeDay, periodEnd)
+
+	zero := valueobject.ZeroMoney(change.OldPrice.Currency())
+	result := ProrationResult{
+		PeriodDays:    periodDays,
+		RemainingDays: remainingDays,
+		Credit:        zero,
+		Charge:        zero,
+		Net:           zero,
+	}
+
+	var err error
+	switch p.strategy {
+	case ProrationDayBased:
+		if result.Credit, err = change.OldPrice.MultiplyRatio(int64(remainingDays), int64(periodDays)); err != nil {
+			return ProrationResult{}, err
+		}
+		if result.Charge, err = change.NewPrice.MultiplyRatio(int64(remainingDays), int64(periodDays)); err != nil {
+			return ProrationResult{}, err
+		}
+	case ProrationCreditOnly:
+		difference, err := change.OldPrice.Subtract(change.NewPrice)
+		if err != nil {
+			return ProrationResult{}, err
+		}
+		if difference.IsPositive() {
+			if result.Credit, err = difference.MultiplyRatio(int64(remainingDays), int64(periodDays)); err != nil {
+				return ProrationResult{}, err
+			}
+		}
+	case ProrationNone:
+		return result, nil
+	}
+
+	if result.Net, err = result.Charge.Subtract(result.Credit); err != nil {
+		return ProrationResult{}, err
+	}
+	return result, nil
+}
+
+// utcDay truncates a time to the start of its UTC calendar day
+func utcDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// daysBetween counts whole days between two UTC day starts
+func daysBetween(from, to time.Time) int {
+	return int(to.Sub(from).Hours() / 24)
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces sensitive values in logged payloads
+const Redacted = "[REDACTED]"
+
+// sensitiveKeyParts mark a field as sensitive when its normalized name (lowercase, without '_' and '-') contains one of them
+var sensitiveKeyParts = []string{
+	"password", "secret", "token", "apikey", "authorization", "signature",
+	"card", "cvc", "cvv", "iban", "bic", "accountnumber", "routingnumber",
+	"email", "phone", "address", "taxid", "vatnumber", "ssn", "birth",
+}
+
+// sensitiveKeys mark a field as sensitive when its normalized name is one of them
+var sensitiveKeys = map[string]bool{
+	"name":      true,
+	"firstname": true,
+	"lastname":  true,
+	"fullname":  true,
+}
+
+var (
+	// jsonFieldPattern matches "key": "value" pairs of payloads that are not valid JSON (e.g. truncated)
+	jsonFieldPattern = regexp.MustCompile(`"([^"]+)"\s*:\s*"(?:[^"\\]|\\.)*"`)
+
+	// emailPattern matches email addresses in free text
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+
+	// cardNumberPattern matches card-like numbers (13 to 19 digits, optionally grouped by spaces or dashes)
+	cardNumberPattern = regexp.MustCompile(`\b\d(?:[ \-]?\d){12,18}\b`)
+)
+
+// IsSensitiveField reports whether a field name holds personal data or credentials
+func IsSensitiveField(name string) bool {
+	normalized := strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(name))
+	if sensitiveKeys[normalized] {
+		return true
+	}
+	for _, part := range sensitiveKeyParts {
+		if strings.Contains(normalized, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactBody replaces the personal data and credentials of a request or response body
+// JSON and form bodies have their sensitive fields redacted; other bodies (and bodies that cannot be parsed,
+// e.g. truncated) have their email addresses, card-like numbers and quoted sensitive fields redacted
+func RedactBody(body, contentType string) string {
+	if body == "" {
+		return body
+	}
+
+	trimmed := strings.TrimSpace(body)
+	if strings.Contains(contentType, "json") || strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		var value interface{}
+		if err := json.Unmarshal([]byte(trimmed), &value); err == nil {
+			if redacted, err := json.Marshal(redactValue(value)); err == nil {
+				return string(redacted)
+			}
+		}
+	}
+	if strings.Contains(contentType, "x-www-form-urlencoded") {
+		if values, err := url.ParseQuery(trimmed); err == nil {
+			return redactValues(values).Encode()
+		}
+	}
+	return redactText(body)
+}
+
+// RedactURL replaces the credentials and sensitive query parameters of a URL
+func RedactURL(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return redactText(rawURL)
+	}
+
+	if parsed.User != nil {
+		parsed.User = url.User(Redacted)
+	}
+	if parsed.RawQuery != "" {
+		parsed.RawQuery = redactValues(parsed.Query()).Encode()
+	}
+	return parsed.String()
+}
+
+// redactValue redacts the sensitive fields of a decoded JSON value
+func redactValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, field := range typed {
+			if IsSensitiveField(key) {
+				typed[key] = Redacted
+				continue
+			}
+			typed[key] = redactValue(field)
+		}
+		return typed
+	case []interface{}:
+		for i, item := range typed {
+			typed[i] = redactValue(item)
+		}
+		return typed
+	case string:
+		return redactText(typed)
+	}
+	return value
+}
+
+// redactValues redacts the sensitive parameters of form values or a query
+func redactValues(values url.Values) url.Values {
+	for key, fields := range values {
+		for i, field := range fields {
+			if IsSensitiveField(key) {
+				fields[i] = Redacted
+				continue
+			}
+			fields[i] = redactText(field)
+		}
+	}
+	return values
+}
+
+// redactText redacts the quoted sensitive fields, email addresses and card-like numbers of free text
+func redactText(text string) string {
+	text = jsonFieldPattern.ReplaceAllStringFunc(text, func(match string) string {
+		key := jsonFieldPattern.FindStringSubmatch(match)[1]
+		if !IsSensitiveField(key) {
+			return match
+		}
+		return `"` + key + `":"` + Redacted + `"`
+	})
+	text = emailPattern.ReplaceAllString(text, Redacted)
+	return cardNumberPattern.ReplaceAllString(text, Redacted)
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+)
+
+// Score bands used when the risk provider returns a score without a decision
+const (
+	RiskReviewScore  = 40 // Scores from here are reviewed
+	RiskDeclineScore = 70 // Scores from here are declined
+)
+
+// RiskSubject is the client data sent to the risk provider
+type RiskSubject struct {
+	ClientID string
+	Name     string
+	Email    string
+	Phone    string
+	Address  string
+}
+
+// RiskScore is the answer of the risk provider for a subject
+type RiskScore struct {
+	Score    int                 // 0 (lowest risk) to entity.MaxRiskScore
+	Decision entity.RiskDecision // Empty when the provider only scores
+	Reasons  []entity.RiskReason
+}
+
+// RiskSubjectFor builds the risk provider subject of a client
+func RiskSubjectFor(client *entity.Client) RiskSubject {
+	return RiskSubject{
+		ClientID: client.ID(),
+		Name:     client.Name(),
+		Email:    client.EmailString(),
+		Phone:    client.PhoneString(),
+		Address:  client.Address(),
+	}
+}
+
+// DecideRisk returns the decision of a score, keeping the provider's own decision when it made one
+func DecideRisk(score RiskScore) entity.RiskDecision {
+	if score.Decision != "" {
+		return score.Decision
+	}
+
+	switch {
+	case score.Score >= RiskDeclineScore:
+		return entity.RiskDecisionDecline
+	case score.Score >= RiskReviewScore:
+		return entity.RiskDecisionReview
+	default:
+		return entity.RiskDecisionApprove
+	}
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+)
+
+// TaxRateProvider looks up the standard tax rate of a jurisdiction (e.g. "FR", "US-CA")
+// A configured table (StaticTaxRates) is the default; an external tax service can be plugged in instead
+type TaxRateProvider interface {
+	// TaxRate returns the rate of the jurisdiction, or a validation error when it has none
+	TaxRate(jurisdiction string) (valueobject.TaxRate, error)
+}
+
+// StaticTaxRates is a TaxRateProvider over a fixed table of jurisdictions
+type StaticTaxRates map[string]valueobject.TaxRate
+
+// NewStaticTaxRates creates a rate table from jurisdiction codes to basis points; codes are case-insensitive
+func NewStaticTaxRates(rates map[string]int64) (StaticTaxRates, error) {
+	table := make(StaticTaxRates, len(rates))
+	for jurisdiction, bps := range rates {
+		code := normalizeJurisdiction(jurisdiction)
+		if code == "" {
+			return nil, errors.NewValidationError("tax_jurisdiction", jurisdiction, errors.ValidationRequired, "tax jurisdiction code is required")
+		}
+		rate, err := valueobject.NewTaxRate(bps)
+		if err != nil {
+			return nil, err
+		}
+		table[code] = rate
+	}
+	return table, nil
+}
+
+// TaxRate returns the configured rate of a jurisdiction
+func (r StaticTaxRates) TaxRate(jurisdiction string) (valueobject.TaxRate, error) {
+	rate, ok := r[normalizeJurisdiction(jurisdiction)]
+	if !ok {
+		return valueobject.TaxRate{}, errors.NewValidationError("tax_jurisdiction", jurisdiction, errors.ValidationFormat,
+			fmt.Sprintf("no tax rate is configured for jurisdiction %q", jurisdiction))
+	}
+	return rate, nil
+}
+
+// TaxEngine resolves the tax rates of invoice line items
+// A line keeps the rate it was given; lines without one take the rate of the invoice's jurisdiction
+type TaxEngine struct {
+	rates TaxRateProvider
+}
+
+// NewTaxEngine creates a tax engine looking up jurisdiction rates with the given provider
+func NewTaxEngine(rates TaxRateProvider) *TaxEngine {
+	return &TaxEngine{
+		rates: rates,
+	}
+}
+
+// LineRates resolves the rate of each line item from its explicit rate (nil = unset)
+// Unset rates take the rate of the jurisdiction, looked up once; they are untaxed when no jurisdiction is given
+func (e *TaxEngine) LineRates(jurisdiction string, explicit []*int64) ([]int64, error) {
+	rates := make([]int64, len(explicit))
+	var jurisdictionRate *valueobject.TaxRate
+	for i, bps := range explicit {
+		if bps != nil {
+			rates[i] = *bps
+			continue
+		}
+		if normalizeJurisdiction(jurisdiction) == "" {
+			continue
+		}
+		if jurisdictionRate == nil {
+			rate, err := e.rates.TaxRate(jurisdiction)
+			if err != nil {
+				return nil, err
+			}
+			jurisdictionRate = &rate
+		}
+		rates[i] = jurisdictionRate.Bps()
+	}
+	return rates, nil
+}
+
+// TaxRateTotal is the taxable amount and tax of the line items taxed at one rate
+type TaxRateTotal struct {
+	RateBps int64
+	Taxable valueobject.Money // Net amount of the line items
+	Tax     valueobject.Money
+}
+
+// InvoiceTax is the tax computation of an invoice
+type InvoiceTax struct {
+	Lines    []valueobject.TaxedAmount // One per line item, in line order
+	Rates    []TaxRateTotal            // One per rate, in ascending rate order
+	Subtotal valueobject.Money         // Total before tax
+	Tax      valueobject.Money
+	Total    valueobject.Money // Total tax included
+}
+
+// CalculateInvoiceTax computes the tax of each line item of an invoice and totals it per rate
+// The totals are those the invoice bills (Invoice.Total), since both round the tax per line
+func CalculateInvoiceTax(invoice *entity.Invoice) (InvoiceTax, error) {
+	currency := invoice.Currency()
+	result := InvoiceTax{
+		Rates:    make([]TaxRateTotal, 0),
+		Subtotal: valueobject.ZeroMoney(currency),
+		Tax:      valueobject.ZeroMoney(currency),
+		Total:    valueobject.ZeroMoney(currency),
+	}
+
+	for _, line := range invoice.Lines() {
+		taxed, err := invoice.LineTax(line)
+		if err != nil {
+			return InvoiceTax{}, err
+		}
+		result.Lines = append(result.Lines, taxed)
+
+		index := sort.Search(len(result.Rates), func(i int) bool { return result.Rates[i].RateBps >= line.TaxRateBps })
+		if index == len(result.Rates) || result.Rates[index].RateBps != line.TaxRateBps {
+			result.Rates = append(result.Rates, TaxRateTotal{})
+			copy(result.Rates[index+1:], result.Rates[index:])
+			result.Rates[index] = TaxRateTotal{
+				RateBps: line.TaxRateBps,
+				Taxable: valueobject.ZeroMoney(currency),
+				Tax:     valueobject.ZeroMoney(currency),
+			}
+		}
+
+		rate := &result.Rates[index]
+		if rate.Taxable, err = rate.Taxable.Add(taxed.Net); err != nil {
+			return InvoiceTax{}, err
+		}
+		if rate.Tax, err = rate.Tax.Add(taxed.Tax); err != nil {
+			return InvoiceTax{}, err
+		}
+		if result.Subtotal, err = result.Subtotal.Add(taxed.Net); err != nil {
+			return InvoiceTax{}, err
+		}
+		if result.Tax, err = result.Tax.Add(taxed.Tax); err != nil {
+			return InvoiceTax{}, err
+		}
+		if result.Total, err = result.Total.Add(taxed.Gross); err != nil {
+			return InvoiceTax{}, err
+		}
+	}
+	return result, nil
+}
+
+// normalizeJurisdiction returns the canonical form of a jurisdiction code
+func normalizeJurisdiction(jurisdiction string) string {
+	return strings.ToUpper(strings.TrimSpace(jurisdiction))
+}
@@ -0,0 +1,22 @@
+package service
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// WebhookEnvelope identifies an event delivered by a webhook provider
+type WebhookEnvelope struct {
+	ID   string // Provider event ID, stable across redeliveries
+	Type string // Provider event type (e.g. "payout.paid")
+}
+
+// WebhookDelivery is an event delivered to the endpoint of a webhook subscription
+type WebhookDelivery struct {
+	ID          string // Event ID, stable across redeliveries so consumers drop the events they already have
+	Type        string // Event type (the message bus topic, e.g. "billing.invoices.state")
+	AggregateID string // ID of the entity the event is about
+	Payload     json.RawMessage
+	OccurredAt  time.Time
+	Replayed    bool // Delivered again by a replay
+}
@@ -0,0 +1,57 @@
+package valueobject
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+)
+
+// AddressFormat describes the regional rules a postal address must follow
+type AddressFormat struct {
+	Region            string
+	PostalCodePattern *regexp.Regexp
+	PostalCodeExample string
+}
+
+// addressFormats lists the supported regional address formats
+var addressFormats = map[string]AddressFormat{
+	"US": {Region: "US", PostalCodePattern: regexp.MustCompile(`\b\d{5}(-\d{4})?\b`), PostalCodeExample: "94105"},
+	"CA": {Region: "CA", PostalCodePattern: regexp.MustCompile(`(?i)\b[A-Z]\d[A-Z] ?\d[A-Z]\d\b`), PostalCodeExample: "K1A 0B1"},
+	"GB": {Region: "GB", PostalCodePattern: regexp.MustCompile(`(?i)\b[A-Z]{1,2}\d[A-Z\d]? ?\d[A-Z]{2}\b`), PostalCodeExample: "SW1A 1AA"},
+	"FR": {Region: "FR", PostalCodePattern: regexp.MustCompile(`\b\d{5}\b`), PostalCodeExample: "75001"},
+	"DE": {Region: "DE", PostalCodePattern: regexp.MustCompile(`\b\d{5}\b`), PostalCodeExample: "10115"},
+	"ES": {Region: "ES", PostalCodePattern: regexp.MustCompile(`\b\d{5}\b`), PostalCodeExample: "28001"},
+	"IT": {Region: "IT", PostalCodePattern: regexp.MustCompile(`\b\d{5}\b`), PostalCodeExample: "00118"},
+	"BE": {Region: "BE", PostalCodePattern: regexp.MustCompile(`\b\d{4}\b`), PostalCodeExample: "1000"},
+	"CH": {Region: "CH", PostalCodePattern: regexp.MustCompile(`\b\d{4}\b`), PostalCodeExample: "8001"},
+	"NL": {Region: "NL", PostalCodePattern: regexp.MustCompile(`(?i)\b\d{4} ?[A-Z]{2}\b`), PostalCodeExample: "1011 AB"},
+}
+
+// LookupAddressFormat returns the address format for a region code
+func LookupAddressFormat(region string) (AddressFormat, bool) {
+	format, ok := addressFormats[region]
+	return format, ok
+}
+
+// ValidateAddressForCountry checks a free-form address against the format of the country it is in (ISO 3166-1
+// alpha-2 code)
+// Empty addresses and countries without a known format are always accepted
+func ValidateAddressForCountry(address, country string) error {
+	normalized := strings.TrimSpace(address)
+	if normalized == "" {
+		return nil
+	}
+
+	format, ok := LookupAddressFormat(country)
+	if !ok {
+		return nil
+	}
+
+	if format.PostalCodePattern != nil && !format.PostalCodePattern.MatchString(normalized) {
+		return errors.NewValidationError("address", address, errors.ValidationFormat,
+			"address must include a valid "+format.Region+" postal code (e.g. "+format.PostalCodeExample+")")
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package valueobject
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+)
+
+// BankAccount represents validated bank details printed on invoices for payment by transfer
+type BankAccount struct {
+	accountHolder string
+	iban          string
+	bic           string
+	bankName      string
+}
+
+// NewBankAccount creates a new BankAccount value object with validation
+// The IBAN is normalized (spaces removed, upper case) and its check digits verified; the BIC is optional
+func NewBankAccount(accountHolder, iban, bic, bankName string) (BankAccount, error) {
+	accountHolder = strings.TrimSpace(accountHolder)
+	if accountHolder == "" {
+		return BankAccount{}, errors.NewValidationError("account_holder", accountHolder, errors.ValidationRequired, "account holder is required")
+	}
+	if len(accountHolder) > 200 {
+		return BankAccount{}, errors.NewValidationError("account_holder", accountHolder, errors.ValidationLength, "account holder must be at most 200 characters")
+	}
+
+	normalizedIBAN := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(iban), " ", ""))
+	if normalizedIBAN == "" {
+		return BankAccount{}, errors.NewValidationError("iban", iban, errors.ValidationRequired, "IBAN is required")
+	}
+	if !validIBAN(normalizedIBAN) {
+		return BankAccount{}, errors.NewValidationError("iban", iban, errors.ValidationFormat, "IBAN is invalid")
+	}
+
+	normalizedBIC := strings.ToUpper(strings.TrimSpace(bic))
+	if normalizedBIC != "" && !validBIC(normalizedBIC) {
+		return BankAccount{}, errors.NewValidationError("bic", bic, errors.ValidationFormat, "BIC must be 8 or 11 characters")
+	}
+
+	bankName = strings.TrimSpace(bankName)
+	if len(bankName) > 200 {
+		return BankAccount{}, errors.NewValidationError("bank_name", bankName, errors.ValidationLength, "bank name must be at most 200 characters")
+	}
+
+	return BankAccount{
+		accountHolder: accountHolder,
+		iban:          normalizedIBAN,
+		bic:           normalizedBIC,
+		bankName:      bankName,
+	}, nil
+}
+
+// AccountHolder returns the name the account is held in
+func (b BankAccount) AccountHolder() string {
+	return b.accountHolder
+}
+
+// IBAN returns the normalized IBAN
+func (b BankAccount) IBAN() string {
+	return b.iban
+}
+
+// BIC returns the BIC (empty when not provided)
+func (b BankAccount) BIC() string {
+	return b.bic
+}
+
+// BankName returns the name of the bank (empty when not provided)
+func (b BankAccount) BankName() string {
+	return b.bankName
+}
+
+// IsEmpty checks if no bank details are set
+func (b BankAccount) IsEmpty() bool {
+	return b.iban == ""
+}
+
+// validIBAN checks the structure and ISO 7064 mod 97-10 check digits of a normalized IBAN
+func validIBAN(iban string) bool {
+	if len(iban) < 15 || len(iban) > 34 {
+		return false
+	}
+	for i, r := range iban {
+		isLetter := r >= 'A' && r <= 'Z'
+		isDigit := r >= '0' && r <= '9'
+		if (i < 2 && !isLetter) || (i >= 2 && i < 4 && !isDigit) || (!isLetter && !isDigit) {
+			return false
+		}
+	}
+
+	// Move the country code and check digits to the end, convert letters to numbers (A=10) and take mod 97
+	remainder := 0
+	for _, r := range iban[4:] + iban[:4] {
+		if r >= 'A' && r <= 'Z' {
+			value := int(r-'A') + 10
+			remainder = (remainder*100 + value) % 97
+		} else {
+			remainder = (remainder*10 + int(r-'0')) % 97
+		}
+	}
+	return remainder == 1
+}
+
+// validBIC checks the structure of a normalized BIC (bank code, country code, location and optional branch)
+func validBIC(bic string) bool {
+	if len(bic) != 8 && len(bic) != 11 {
+		return false
+	}
+	for i, r := range bic {
+		isLetter := r >= 'A' && r <= 'Z'
+		isDigit := r >= '0' && r <= '9'
+		if (i < 6 && !isLetter) || (!isLetter && !isDigit) {
+			return false
+		}
+	}
+	return true
+}
+
+// bankAccountJSON is the serialized form of a BankAccount
+type bankAccountJSON struct {
+	AccountHolder string `json:"accountHolder"`
+	IBAN          string `json:"iban"`
+	BIC           string `json:"bic,omitempty"`
+	BankName      string `json:"bankName,omitempty"`
+}
+
+// MarshalJSON implements JSON marshaling for BankAccount
+func (b BankAccount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(bankAccountJSON{
+		AccountHolder: b.accountHolder,
+		IBAN:          b.iban,
+		BIC:           b.bic,
+		BankName:      b.bankName,
+	})
+}
+
+// UnmarshalJSON implements JSON unmarshaling for BankAccount
+func (b *BankAccount) UnmarshalJSON(data []byte) error {
+	var jsonAccount bankAccountJSON
+	if err := json.Unmarshal(data, &jsonAccount); err != nil {
+		return err
+	}
+
+	b.accountHolder = jsonAccount.AccountHolder
+	b.iban = jsonAccount.IBAN
+	b.bic = jsonAccount.BIC
+	b.bankName = jsonAccount.BankName
+	return nil
+}
@@ -0,0 +1,98 @@
+package valueobject
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+)
+
+// RoundingMode is how amounts computed with fractions of a minor unit are rounded to the minor unit
+type RoundingMode string
+
+const (
+	// RoundHalfUp rounds halves away from zero (2.5 -> 3, -2.5 -> -3), the default
+	RoundHalfUp RoundingMode = "half_up"
+
+	// RoundHalfEven rounds halves to the nearest even minor unit (2.5 -> 2, 3.5 -> 4), a.k.a. banker's rounding
+	RoundHalfEven RoundingMode = "half_even"
+
+	// RoundDown truncates toward zero (2.7 -> 2, -2.7 -> -2)
+	RoundDown RoundingMode = "down"
+
+	// RoundUp rounds away from zero (2.1 -> 3, -2.1 -> -3)
+	RoundUp RoundingMode = "up"
+)
+
+// ParseRoundingMode validates a rounding mode; an empty mode is RoundHalfUp
+func ParseRoundingMode(mode string) (RoundingMode, error) {
+	switch normalized := RoundingMode(strings.ToLower(strings.TrimSpace(mode))); normalized {
+	case "":
+		return RoundHalfUp, nil
+	case RoundHalfUp, RoundHalfEven, RoundDown, RoundUp:
+		return normalized, nil
+	}
+	return "", errors.NewValidationError("rounding", mode, errors.ValidationFormat, "rounding must be one of: half_up, half_even, down, up")
+}
+
+// roundQuotient divides numerator by a positive denominator, rounding the quotient with mode
+func roundQuotient(numerator, denominator *big.Int, mode RoundingMode) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(numerator, denominator, new(big.Int))
+	if remainder.Sign() == 0 {
+		return quotient
+	}
+
+	awayFromZero := false
+	switch mode {
+	case RoundDown:
+	case RoundUp:
+		awayFromZero = true
+	default:
+		// Compare twice the remainder with the denominator to find whether the fraction is below, at or above a half
+		half := new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2)).Cmp(denominator)
+		awayFromZero = half > 0 || (half == 0 && (mode != RoundHalfEven || quotient.Bit(0) == 1))
+	}
+
+	if awayFromZero {
+		quotient.Add(quotient, big.NewInt(int64(numerator.Sign())))
+	}
+	return quotient
+}
+
+// CurrencyPolicy is the default currency of a tenant and how its invoice amounts are rounded
+type CurrencyPolicy struct {
+	currency string
+	rounding RoundingMode
+}
+
+// NewCurrencyPolicy creates a currency policy from an ISO 4217 currency code and a rounding mode (half_up when empty)
+func NewCurrencyPolicy(currency, rounding string) (CurrencyPolicy, error) {
+	money, err := NewMoney(0, currency)
+	if err != nil {
+		return CurrencyPolicy{}, err
+	}
+	mode, err := ParseRoundingMode(rounding)
+	if err != nil {
+		return CurrencyPolicy{}, err
+	}
+
+	return CurrencyPolicy{currency: money.Currency(), rounding: mode}, nil
+}
+
+// Currency returns the default currency of invoices
+func (p CurrencyPolicy) Currency() string {
+	return p.currency
+}
+
+// Rounding returns how converted and prorated amounts are rounded (RoundHalfUp for a zero policy)
+func (p CurrencyPolicy) Rounding() RoundingMode {
+	if p.rounding == "" {
+		return RoundHalfUp
+	}
+	return p.rounding
+}
+
+// IsZero reports whether the policy was never set
+func (p CurrencyPolicy) IsZero() bool {
+	return p.currency == ""
+}
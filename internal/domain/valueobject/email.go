@@ -94,6 +94,57 @@ func (e Email) LocalPart() string {
 	return parts[0]
 }
 
+// Canonical returns the address email is compared by in uniqueness checks under policy
+// Spellings reaching the same mailbox share the canonical address; the address itself is kept as entered
+func (e Email) Canonical(policy EmailNormalization) string {
+	localPart, domain := e.LocalPart(), e.Domain()
+	if localPart == "" || domain == "" {
+		return e.value
+	}
+
+	if policy.FoldSubaddress {
+		if i := strings.Index(localPart, "+"); i > 0 {
+			localPart = localPart[:i]
+		}
+	}
+	if policy.foldsDots(domain) {
+		if folded := strings.ReplaceAll(localPart, ".", ""); folded != "" {
+			localPart = folded
+		}
+	}
+	return localPart + "@" + domain
+}
+
+// EmailNormalization decides which spellings of an address reach the same mailbox, so that they count as one
+// address in uniqueness checks. Addresses are always compared lower-cased (see NewEmail)
+type EmailNormalization struct {
+	FoldSubaddress bool     // "alice+billing@example.com" is "alice@example.com" (RFC 5233 subaddressing)
+	FoldDotDomains []string // Domains ignoring dots in the local part, e.g. gmail.com ("a.lice" is "alice")
+}
+
+// NewEmailNormalization creates a normalization policy with validation of the dot folding domains
+func NewEmailNormalization(foldSubaddress bool, foldDotDomains []string) (EmailNormalization, error) {
+	domains := make([]string, 0, len(foldDotDomains))
+	for _, domain := range foldDotDomains {
+		normalized := strings.ToLower(strings.TrimSpace(domain))
+		if normalized == "" || strings.Contains(normalized, "@") || !strings.Contains(normalized, ".") {
+			return EmailNormalization{}, errors.NewValidationError("fold_dot_domains", domain, errors.ValidationFormat, "dot folding domains must be domain names, e.g. gmail.com")
+		}
+		domains = append(domains, normalized)
+	}
+	return EmailNormalization{FoldSubaddress: foldSubaddress, FoldDotDomains: domains}, nil
+}
+
+// foldsDots reports whether dots in the local part are ignored by domain
+func (n EmailNormalization) foldsDots(domain string) bool {
+	for _, folded := range n.FoldDotDomains {
+		if folded == domain {
+			return true
+		}
+	}
+	return false
+}
+
 // MarshalJSON implements custom JSON marshaling for Email
 func (e Email) MarshalJSON() ([]byte, error) {
 	return json.Marshal(struct {
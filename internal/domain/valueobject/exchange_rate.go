@@ -0,0 +1,97 @@
+package valueobject
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+)
+
+// maxExchangeRateDecimals bounds the precision of exchange rates
+const maxExchangeRateDecimals = 10
+
+// ExchangeRate converts amounts from one currency to another: 1 unit of From is worth Rate units of To
+// The rate is kept as an exact fraction, so conversions only round once, to the minor unit of To
+type ExchangeRate struct {
+	from        string
+	to          string
+	numerator   int64
+	denominator int64
+}
+
+// NewExchangeRate creates an exchange rate from a positive decimal rate (e.g. "1.0835")
+func NewExchangeRate(from, to, rate string) (ExchangeRate, error) {
+	fromMoney, err := NewMoney(0, from)
+	if err != nil {
+		return ExchangeRate{}, err
+	}
+	toMoney, err := NewMoney(0, to)
+	if err != nil {
+		return ExchangeRate{}, err
+	}
+
+	rate = strings.TrimSpace(rate)
+	whole, fraction, _ := strings.Cut(rate, ".")
+	if whole == "" || len(fraction) > maxExchangeRateDecimals || strings.Trim(whole+fraction, "0123456789") != "" {
+		return ExchangeRate{}, errors.NewValidationError("exchange_rate", rate, errors.ValidationFormat,
+			fmt.Sprintf("exchange rate must be a decimal number with at most %d decimals", maxExchangeRateDecimals))
+	}
+
+	numerator, ok := new(big.Int).SetString(whole+fraction, 10)
+	if !ok || !numerator.IsInt64() {
+		return ExchangeRate{}, errors.NewValidationError("exchange_rate", rate, errors.ValidationRange, "exchange rate is out of range")
+	}
+	if numerator.Sign() <= 0 {
+		return ExchangeRate{}, errors.NewValidationError("exchange_rate", rate, errors.ValidationRange, "exchange rate must be positive")
+	}
+
+	return ExchangeRate{
+		from:        fromMoney.Currency(),
+		to:          toMoney.Currency(),
+		numerator:   numerator.Int64(),
+		denominator: pow10(len(fraction)),
+	}, nil
+}
+
+// From returns the currency converted from
+func (r ExchangeRate) From() string {
+	return r.from
+}
+
+// To returns the currency converted to
+func (r ExchangeRate) To() string {
+	return r.to
+}
+
+// Convert converts an amount in the From currency to the To currency, rounding to its minor unit with mode
+// Currencies with different minor units (e.g. EUR cents to JPY yen) are scaled accordingly
+func (r ExchangeRate) Convert(amount Money, mode RoundingMode) (Money, error) {
+	if amount.currency != r.from {
+		return Money{}, errors.NewBusinessRuleError("currency_mismatch", errors.BusinessRuleViolation,
+			fmt.Sprintf("cannot convert an amount in %s with a %s to %s rate", amount.currency, r.from, r.to))
+	}
+
+	numerator := new(big.Int).Mul(big.NewInt(amount.amount), big.NewInt(r.numerator))
+	denominator := big.NewInt(r.denominator)
+	if shift := CurrencyMinorDigits(r.to) - CurrencyMinorDigits(r.from); shift > 0 {
+		numerator.Mul(numerator, big.NewInt(pow10(shift)))
+	} else if shift < 0 {
+		denominator.Mul(denominator, big.NewInt(pow10(-shift)))
+	}
+
+	converted := roundQuotient(numerator, denominator, mode)
+	if !converted.IsInt64() {
+		return Money{}, errors.NewValidationError("amount", converted.String(), errors.ValidationRange, "amount is out of range")
+	}
+	return Money{amount: converted.Int64(), currency: r.to}, nil
+}
+
+// pow10 returns 10^exponent
+func pow10(exponent int) int64 {
+	result := int64(1)
+	for i := 0; i < exponent; i++ {
+		result *= 10
+	}
+	return result
+}
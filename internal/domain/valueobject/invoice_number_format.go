@@ -0,0 +1,71 @@
+package valueobject
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+)
+
+// DefaultInvoiceNumberFormat numbers invoices like INV-2026-00042
+const DefaultInvoiceNumberFormat = "INV-{YYYY}-{00000}"
+
+// invoiceNumberPlaceholder matches the {...} placeholders of an invoice number format
+var invoiceNumberPlaceholder = regexp.MustCompile(`\{[^{}]*\}`)
+
+// InvoiceNumberFormat renders the sequence numbers of a year as invoice numbers, e.g. "INV-{YYYY}-{00000}"
+// Placeholders: {YYYY} is the year, {YY} its last two digits, and {0...0} the sequence number zero padded to
+// as many digits as zeros (longer numbers are not truncated). Sequences restart every year, so the year is required
+type InvoiceNumberFormat struct {
+	pattern string
+}
+
+// NewInvoiceNumberFormat creates an invoice number format with validation
+func NewInvoiceNumberFormat(pattern string) (InvoiceNumberFormat, error) {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		return InvoiceNumberFormat{}, errors.NewValidationError("format", pattern, errors.ValidationRequired, "invoice number format is required")
+	}
+
+	sequences, years := 0, 0
+	for _, placeholder := range invoiceNumberPlaceholder.FindAllString(pattern, -1) {
+		switch name := placeholder[1 : len(placeholder)-1]; {
+		case name == "YYYY" || name == "YY":
+			years++
+		case name != "" && strings.Trim(name, "0") == "":
+			sequences++
+		default:
+			return InvoiceNumberFormat{}, errors.NewValidationError("format", pattern, errors.ValidationFormat, "unknown invoice number placeholder "+placeholder+" (use {YYYY}, {YY} or {00000})")
+		}
+	}
+	if strings.ContainsAny(invoiceNumberPlaceholder.ReplaceAllString(pattern, ""), "{}") {
+		return InvoiceNumberFormat{}, errors.NewValidationError("format", pattern, errors.ValidationFormat, "invoice number format has an unbalanced brace")
+	}
+	if sequences != 1 {
+		return InvoiceNumberFormat{}, errors.NewValidationError("format", pattern, errors.ValidationFormat, "invoice number format must contain exactly one sequence placeholder, e.g. {00000}")
+	}
+	if years == 0 {
+		return InvoiceNumberFormat{}, errors.NewValidationError("format", pattern, errors.ValidationFormat, "invoice number format must contain the year ({YYYY} or {YY}) since sequences restart every year")
+	}
+	return InvoiceNumberFormat{pattern: pattern}, nil
+}
+
+// Pattern returns the format string
+func (f InvoiceNumberFormat) Pattern() string {
+	return f.pattern
+}
+
+// Format renders the invoice number of a sequence number of a year
+func (f InvoiceNumberFormat) Format(year int, sequence int64) string {
+	return invoiceNumberPlaceholder.ReplaceAllStringFunc(f.pattern, func(placeholder string) string {
+		switch name := placeholder[1 : len(placeholder)-1]; name {
+		case "YYYY":
+			return fmt.Sprintf("%04d", year)
+		case "YY":
+			return fmt.Sprintf("%02d", year%100)
+		default:
+			return fmt.Sprintf("%0*d", len(name), sequence)
+		}
+	})
+}
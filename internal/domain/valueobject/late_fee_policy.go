@@ -0,0 +1,113 @@
+package valueobject
+
+import (
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+)
+
+// basisPointsPerUnit converts basis points to a ratio (10000 bps = 100%)
+const basisPointsPerUnit = 10000
+
+// daysPerYear is the day-count basis for converting an annual interest rate to daily interest
+const daysPerYear = 365
+
+// LateFeePolicyConfig describes how late payment is charged; every component is optional and they add up
+type LateFeePolicyConfig struct {
+	GraceDays          int   // Days after the due date before any fee applies
+	FlatFee            int64 // One-off fee in minor units once the grace period is over
+	PercentageBps      int64 // One-off fee as basis points of the overdue balance (150 = 1.5%)
+	AnnualInterestBps  int64 // Interest rate per year in basis points, accrued daily from the due date
+	MaxFee             int64 // Cap on the total fee in minor units (0 = uncapped)
+	InterestAfterGrace bool  // Accrue interest from the end of the grace period instead of the due date
+}
+
+// LateFeePolicy calculates fees owed on an overdue balance
+type LateFeePolicy struct {
+	config LateFeePolicyConfig
+}
+
+// LateFeeAssessment is the breakdown of the fees owed on an overdue balance at a point in time
+type LateFeeAssessment struct {
+	DaysLate       int
+	ChargeableDays int
+	FlatFee        Money
+	PercentageFee  Money
+	Interest       Money
+	Total          Money
+	Capped         bool
+}
+
+// NewLateFeePolicy creates a late fee policy with validation
+func NewLateFeePolicy(config LateFeePolicyConfig) (LateFeePolicy, error) {
+	if config.GraceDays < 0 {
+		return LateFeePolicy{}, errors.NewValidationError("grace_days", config.GraceDays, errors.ValidationRange, "grace days must not be negative")
+	}
+	if config.FlatFee < 0 {
+		return LateFeePolicy{}, errors.NewValidationError("flat_fee", config.FlatFee, errors.ValidationRange, "flat fee must not be negative")
+	}
+	if config.PercentageBps < 0 || config.PercentageBps > basisPointsPerUnit {
+		return LateFeePolicy{}, errors.NewValidationError("percentage_bps", config.PercentageBps, errors.ValidationRange, "percentage fee must be between 0 and 10000 basis points")
+	}
+	if config.AnnualInterestBps < 0 {
+		return LateFeePolicy{}, errors.NewValidationError("annual_interest_bps", config.AnnualInterestBps, errors.ValidationRange, "interest rate must not be negative")
+	}
+	if config.MaxFee < 0 {
+		return LateFeePolicy{}, errors.NewValidationError("max_fee", config.MaxFee, errors.ValidationRange, "fee cap must not be negative")
+	}
+
+	return LateFeePolicy{config: config}, nil
+}
+
+// Config returns the policy settings
+func (p LateFeePolicy) Config() LateFeePolicyConfig {
+	return p.config
+}
+
+// Assess calculates the fees owed at now on a balance that was due on dueDate
+// Days are counted in whole UTC calendar days; nothing is owed during the grace period
+func (p LateFeePolicy) Assess(balance Money, dueDate, now time.Time) (LateFeeAssessment, error) {
+	zero := ZeroMoney(balance.Currency())
+	assessment := LateFeeAssessment{
+		DaysLate:      calendarDaysBetween(dueDate, now),
+		FlatFee:       zero,
+		PercentageFee: zero,
+		Interest:      zero,
+		Total:         zero,
+	}
+
+	if !balance.IsPositive() || assessment.DaysLate <= p.config.GraceDays {
+		return assessment, nil
+	}
+
+	assessment.FlatFee = Money{amount: p.config.FlatFee, currency: balance.Currency()}
+
+	var err error
+	if assessment.PercentageFee, err = balance.MultiplyRatio(p.config.PercentageBps, basisPointsPerUnit); err != nil {
+		return LateFeeAssessment{}, err
+	}
+
+	assessment.ChargeableDays = assessment.DaysLate
+	if p.config.InterestAfterGrace {
+		assessment.ChargeableDays -= p.config.GraceDays
+	}
+	if assessment.Interest, err = balance.MultiplyRatio(p.config.AnnualInterestBps*int64(assessment.ChargeableDays), basisPointsPerUnit*daysPerYear); err != nil {
+		return LateFeeAssessment{}, err
+	}
+
+	total := assessment.FlatFee.amount + assessment.PercentageFee.amount + assessment.Interest.amount
+	if p.config.MaxFee > 0 && total > p.config.MaxFee {
+		total = p.config.MaxFee
+		assessment.Capped = true
+	}
+	assessment.Total = Money{amount: total, currency: balance.Currency()}
+
+	return assessment, nil
+}
+
+// calendarDaysBetween counts whole UTC calendar days from one date to another (negative when to is earlier)
+func calendarDaysBetween(from, to time.Time) int {
+	fromDay := time.Date(from.UTC().Year(), from.UTC().Month(), from.UTC().Day(), 0, 0, 0, 0, time.UTC)
+	toDay := time.Date(to.UTC().Year(), to.UTC().Month(), to.UTC().Day(), 0, 0, 0, 0, time.UTC)
+	return int(toDay.Sub(fromDay).Hours() / 24)
+}
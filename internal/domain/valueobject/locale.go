@@ -0,0 +1,145 @@
+package valueobject
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+)
+
+// Locale represents the caller's language and region preferences
+// The region is the default region of the national phone numbers the caller searches for; the regional rules of
+// client details follow the client's country, never the caller's locale
+type Locale struct {
+	language string
+	region   string
+}
+
+// DefaultLocale returns the locale used when no preference is known
+// It has no region, so no regional validation rules are applied
+func DefaultLocale() Locale {
+	return Locale{language: "en"}
+}
+
+// NewLocale creates a Locale from a BCP 47 style tag such as "fr-BE" or "en_US"
+func NewLocale(tag string) (Locale, error) {
+	normalized := strings.ReplaceAll(strings.TrimSpace(tag), "_", "-")
+	if normalized == "" {
+		return Locale{}, errors.NewValidationError("locale", tag, errors.ValidationRequired, "locale is required")
+	}
+
+	parts := strings.Split(normalized, "-")
+	language := strings.ToLower(parts[0])
+	if len(language) < 2 || len(language) > 3 || !isAlpha(language) {
+		return Locale{}, errors.NewValidationError("locale", tag, errors.ValidationFormat, "locale language must be a 2-3 letter code")
+	}
+
+	// Pick the first 2-letter subtag after the language as region (skips script subtags like "Latn")
+	region := ""
+	for _, part := range parts[1:] {
+		if len(part) == 2 && isAlpha(part) {
+			region = strings.ToUpper(part)
+			break
+		}
+	}
+
+	return Locale{language: language, region: region}, nil
+}
+
+// ParseAcceptLanguage returns the preferred locales of an Accept-Language header ordered by quality
+// Malformed entries and wildcards are ignored
+func ParseAcceptLanguage(header string) []Locale {
+	type weighted struct {
+		locale  Locale
+		quality float64
+	}
+
+	var candidates []weighted
+	for _, entry := range strings.Split(header, ",") {
+		fields := strings.Split(strings.TrimSpace(entry), ";")
+		tag := strings.TrimSpace(fields[0])
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if q, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64); err == nil {
+					quality = q
+				}
+			}
+		}
+		if quality <= 0 {
+			continue
+		}
+
+		locale, err := NewLocale(tag)
+		if err != nil {
+			continue
+		}
+		candidates = append(candidates, weighted{locale: locale, quality: quality})
+	}
+
+	// Stable sort keeps header order for equal qualities
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+
+	locales := make([]Locale, 0, len(candidates))
+	for _, candidate := range candidates {
+		locales = append(locales, candidate.locale)
+	}
+	return locales
+}
+
+// Language returns the lowercase language code
+func (l Locale) Language() string {
+	return l.language
+}
+
+// Region returns the uppercase region code, or empty when unknown
+func (l Locale) Region() string {
+	return l.region
+}
+
+// HasRegion checks if the locale carries a region
+func (l Locale) HasRegion() bool {
+	return l.region != ""
+}
+
+// WithRegion returns a copy of the locale with the given region
+func (l Locale) WithRegion(region string) Locale {
+	l.region = strings.ToUpper(strings.TrimSpace(region))
+	return l
+}
+
+// IsZero checks if the locale is unset
+func (l Locale) IsZero() bool {
+	return l.language == "" && l.region == ""
+}
+
+// Equals checks if two locales are equal
+func (l Locale) Equals(other Locale) bool {
+	return l.language == other.language && l.region == other.region
+}
+
+// String returns the locale tag (e.g. "fr-BE")
+func (l Locale) String() string {
+	if l.region == "" {
+		return l.language
+	}
+	return l.language + "-" + l.region
+}
+
+// isAlpha checks if a string only contains ASCII letters
+func isAlpha(s string) bool {
+	for _, char := range s {
+		if (char < 'a' || char > 'z') && (char < 'A' || char > 'Z') {
+			return false
+		}
+	}
+	return true
+}
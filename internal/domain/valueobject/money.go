@@ -0,0 +1,220 @@
+package valueobject
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+)
+
+// Money represents an amount in the minor unit of its currency (e.g. cents for EUR)
+// Amounts are integers so that sums and splits never lose a cent to floating point rounding
+type Money struct {
+	amount   int64
+	currency string
+}
+
+// NewMoney creates a Money value object from an amount in minor units and an ISO 4217 currency code
+func NewMoney(amount int64, currency string) (Money, error) {
+	normalized := strings.ToUpper(strings.TrimSpace(currency))
+
+	if normalized == "" {
+		return Money{}, errors.NewValidationError("currency", currency, errors.ValidationRequired, "currency is required")
+	}
+
+	if len(normalized) != 3 || strings.IndexFunc(normalized, func(r rune) bool { return r < 'A' || r > 'Z' }) != -1 {
+		return Money{}, errors.NewValidationError("currency", currency, errors.ValidationFormat, "currency must be a 3-letter ISO 4217 code")
+	}
+
+	return Money{amount: amount, currency: normalized}, nil
+}
+
+// ZeroMoney returns a zero amount in currency (the currency is expected to be already validated)
+func ZeroMoney(currency string) Money {
+	return Money{currency: strings.ToUpper(currency)}
+}
+
+// Amount returns the amount in minor units
+func (m Money) Amount() int64 {
+	return m.amount
+}
+
+// Currency returns the ISO 4217 currency code
+func (m Money) Currency() string {
+	return m.currency
+}
+
+// IsZero checks if the amount is zero
+func (m Money) IsZero() bool {
+	return m.amount == 0
+}
+
+// IsPositive checks if the amount is greater than zero
+func (m Money) IsPositive() bool {
+	return m.amount > 0
+}
+
+// IsNegative checks if the amount is lower than zero
+func (m Money) IsNegative() bool {
+	return m.amount < 0
+}
+
+// Equals checks if two amounts are equal in value and currency
+func (m Money) Equals(other Money) bool {
+	return m.amount == other.amount && m.currency == other.currency
+}
+
+// Add returns the sum of two amounts in the same currency; sums out of the int64 range are rejected
+func (m Money) Add(other Money) (Money, error) {
+	if err := m.requireSameCurrency(other); err != nil {
+		return Money{}, err
+	}
+	return m.withExactAmount(new(big.Int).Add(big.NewInt(m.amount), big.NewInt(other.amount)))
+}
+
+// Subtract returns the difference of two amounts in the same currency; differences out of the int64 range are
+// rejected
+func (m Money) Subtract(other Money) (Money, error) {
+	if err := m.requireSameCurrency(other); err != nil {
+		return Money{}, err
+	}
+	return m.withExactAmount(new(big.Int).Sub(big.NewInt(m.amount), big.NewInt(other.amount)))
+}
+
+// Min returns the smaller of two amounts in the same currency
+func (m Money) Min(other Money) (Money, error) {
+	if err := m.requireSameCurrency(other); err != nil {
+		return Money{}, err
+	}
+	if other.amount < m.amount {
+		return other, nil
+	}
+	return m, nil
+}
+
+// MultiplyRatio returns amount × numerator / denominator rounded half away from zero to the minor unit
+// Intermediate products use arbitrary precision so large amounts and rates cannot overflow
+func (m Money) MultiplyRatio(numerator, denominator int64) (Money, error) {
+	return m.MultiplyRatioRounded(numerator, denominator, RoundHalfUp)
+}
+
+// MultiplyRatioRounded returns amount × numerator / denominator rounded to the minor unit with mode
+func (m Money) MultiplyRatioRounded(numerator, denominator int64, mode RoundingMode) (Money, error) {
+	if denominator == 0 {
+		return Money{}, errors.NewValidationError("denominator", denominator, errors.ValidationRange, "ratio denominator must not be zero")
+	}
+
+	product := new(big.Int).Mul(big.NewInt(m.amount), big.NewInt(numerator))
+	divisor := big.NewInt(denominator)
+	if divisor.Sign() < 0 {
+		product.Neg(product)
+		divisor.Neg(divisor)
+	}
+
+	return m.withExactAmount(roundQuotient(product, divisor, mode))
+}
+
+// withExactAmount returns an amount in the currency of m, or a range error when it does not fit in int64
+func (m Money) withExactAmount(amount *big.Int) (Money, error) {
+	if !amount.IsInt64() {
+		return Money{}, errors.NewValidationError("amount", amount.String(), errors.ValidationRange, "amount is out of range")
+	}
+	return Money{amount: amount.Int64(), currency: m.currency}, nil
+}
+
+// Split divides the amount into parts that differ by at most one minor unit and sum to the original
+// Earlier parts receive the remainder, e.g. 100 split in 3 gives 34, 33, 33
+func (m Money) Split(parts int) ([]Money, error) {
+	if parts <= 0 {
+		return nil, errors.NewValidationError("parts", parts, errors.ValidationRange, "amount must be split in at least one part")
+	}
+
+	share := m.amount / int64(parts)
+	remainder := m.amount % int64(parts)
+
+	result := make([]Money, parts)
+	for i := range result {
+		amount := share
+		if int64(i) < remainder {
+			amount++
+		} else if -int64(i) > remainder {
+			amount--
+		}
+		result[i] = Money{amount: amount, currency: m.currency}
+	}
+	return result, nil
+}
+
+// String returns the amount in major units with its currency, e.g. "12.34 EUR"
+func (m Money) String() string {
+	sign := ""
+	// The magnitude is unsigned so that the minimum int64, whose negation overflows, is formatted too
+	amount := uint64(m.amount)
+	if m.amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	digits := CurrencyMinorDigits(m.currency)
+	if digits == 0 {
+		return fmt.Sprintf("%s%d %s", sign, amount, m.currency)
+	}
+
+	scale := uint64(1)
+	for i := 0; i < digits; i++ {
+		scale *= 10
+	}
+	return fmt.Sprintf("%s%d.%0*d %s", sign, amount/scale, digits, amount%scale, m.currency)
+}
+
+// currencyMinorDigits lists currencies whose minor unit is not the usual 2 decimal places
+var currencyMinorDigits = map[string]int{
+	"BHD": 3, "CLP": 0, "IQD": 3, "ISK": 0, "JOD": 3, "JPY": 0, "KRW": 0,
+	"KWD": 3, "LYD": 3, "OMR": 3, "TND": 3, "UGX": 0, "VND": 0, "XAF": 0, "XOF": 0,
+}
+
+// CurrencyMinorDigits returns the number of decimal places of a currency's minor unit
+func CurrencyMinorDigits(currency string) int {
+	if digits, ok := currencyMinorDigits[strings.ToUpper(currency)]; ok {
+		return digits
+	}
+	return 2
+}
+
+// requireSameCurrency rejects arithmetic across currencies
+func (m Money) requireSameCurrency(other Money) error {
+	if m.currency != other.currency {
+		return errors.NewBusinessRuleError("currency_mismatch", errors.BusinessRuleViolation,
+			fmt.Sprintf("cannot combine amounts in %s and %s", m.currency, other.currency))
+	}
+	return nil
+}
+
+// MarshalJSON implements custom JSON marshaling for Money
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Amount   int64  `json:"amount"`
+		Currency string `json:"currency"`
+	}{
+		Amount:   m.amount,
+		Currency: m.currency,
+	})
+}
+
+// UnmarshalJSON implements custom JSON unmarshaling for Money
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var temp struct {
+		Amount   int64  `json:"amount"`
+		Currency string `json:"currency"`
+	}
+
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+
+	m.amount = temp.Amount
+	m.currency = temp.Currency
+	return nil
+}
@@ -0,0 +1,80 @@
+package valueobject
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+)
+
+// MaxPaymentTermDays is the longest payment term a client can be given
+const MaxPaymentTermDays = 365
+
+// PaymentTerms is how long after an invoice is issued its payment is due
+// Terms are "due_on_receipt" or "net_<days>" (net_15, net_30, net_60 or custom days); empty means no terms
+type PaymentTerms string
+
+const (
+	// PaymentDueOnReceipt means invoices are due the day they are issued
+	PaymentDueOnReceipt PaymentTerms = "due_on_receipt"
+
+	// PaymentNet15 means invoices are due 15 days after they are issued
+	PaymentNet15 PaymentTerms = "net_15"
+
+	// PaymentNet30 means invoices are due 30 days after they are issued
+	PaymentNet30 PaymentTerms = "net_30"
+
+	// PaymentNet60 means invoices are due 60 days after they are issued
+	PaymentNet60 PaymentTerms = "net_60"
+)
+
+// paymentTermsPrefix starts the terms giving a number of days
+const paymentTermsPrefix = "net_"
+
+// NetPaymentTerms returns the terms making invoices due days after they are issued (due on receipt for 0 days)
+func NetPaymentTerms(days int) (PaymentTerms, error) {
+	if days < 0 || days > MaxPaymentTermDays {
+		return "", errors.NewValidationError("payment_terms", days, errors.ValidationRange,
+			fmt.Sprintf("payment terms must be between 0 and %d days", MaxPaymentTermDays))
+	}
+	if days == 0 {
+		return PaymentDueOnReceipt, nil
+	}
+	return PaymentTerms(paymentTermsPrefix + strconv.Itoa(days)), nil
+}
+
+// ParsePaymentTerms validates payment terms, case-insensitively; empty terms stay empty
+func ParsePaymentTerms(terms string) (PaymentTerms, error) {
+	normalized := strings.ToLower(strings.TrimSpace(terms))
+	switch {
+	case normalized == "":
+		return "", nil
+	case normalized == string(PaymentDueOnReceipt):
+		return PaymentDueOnReceipt, nil
+	case strings.HasPrefix(normalized, paymentTermsPrefix):
+		days, err := strconv.Atoi(strings.TrimPrefix(normalized, paymentTermsPrefix))
+		if err == nil {
+			return NetPaymentTerms(days)
+		}
+	}
+	return "", errors.NewValidationError("payment_terms", terms, errors.ValidationFormat,
+		"payment terms must be due_on_receipt or net_<days> (e.g. net_30)")
+}
+
+// Days returns the number of days invoices are due after they are issued (0 without terms)
+func (t PaymentTerms) Days() int {
+	days, _ := strconv.Atoi(strings.TrimPrefix(string(t), paymentTermsPrefix))
+	return days
+}
+
+// IsSet checks if terms were given
+func (t PaymentTerms) IsSet() bool {
+	return t != ""
+}
+
+// DueDate returns the date an invoice issued at issuedAt is due, counted in UTC calendar days
+func (t PaymentTerms) DueDate(issuedAt time.Time) time.Time {
+	return issuedAt.UTC().AddDate(0, 0, t.Days())
+}
@@ -9,7 +9,8 @@ import (
 
 // Phone represents a validated phone number value object
 type Phone struct {
-	value string
+	value  string
+	region string // Region used to interpret national numbers (empty for international input)
 }
 
 // NewPhone creates a new Phone value object with validation
@@ -23,11 +24,7 @@ func NewPhone(phone string) (Phone, error) {
 	}
 
 	// Remove common formatting characters for validation
-	cleanPhone := strings.ReplaceAll(normalized, " ", "")
-	cleanPhone = strings.ReplaceAll(cleanPhone, "-", "")
-	cleanPhone = strings.ReplaceAll(cleanPhone, "(", "")
-	cleanPhone = strings.ReplaceAll(cleanPhone, ")", "")
-	cleanPhone = strings.ReplaceAll(cleanPhone, ".", "")
+	cleanPhone := stripPhoneFormatting(normalized)
 
 	// Length check
 	if len(cleanPhone) < 7 || len(cleanPhone) > 15 {
@@ -42,6 +39,48 @@ func NewPhone(phone string) (Phone, error) {
 	return Phone{value: normalized}, nil
 }
 
+// NewPhoneWithLocale creates a new Phone value object using the locale region as default phone region
+// Numbers without a country code are validated against the region's national numbering rules
+func NewPhoneWithLocale(phone string, locale Locale) (Phone, error) {
+	return NewPhoneInCountry(phone, locale.Region())
+}
+
+// NewPhoneInCountry creates a new Phone value object using a country (ISO 3166-1 alpha-2 code) as default phone
+// region: numbers without a country code are validated against its national numbering rules
+func NewPhoneInCountry(phone, country string) (Phone, error) {
+	normalized := strings.TrimSpace(phone)
+
+	// International numbers and unknown regions keep the region-agnostic rules
+	region, known := LookupPhoneRegion(country)
+	if normalized == "" || strings.HasPrefix(normalized, "+") || !known {
+		return NewPhone(phone)
+	}
+
+	national := stripPhoneFormatting(normalized)
+	for _, char := range national {
+		if char < '0' || char > '9' {
+			return Phone{}, errors.NewValidationError("phone", phone, errors.ValidationFormat, "phone number must contain only digits and formatting characters")
+		}
+	}
+
+	// Drop the national trunk prefix when present
+	if region.TrunkPrefix != "" && len(national) > region.NationalMinDigits && strings.HasPrefix(national, region.TrunkPrefix) {
+		national = strings.TrimPrefix(national, region.TrunkPrefix)
+	}
+
+	if len(national) < region.NationalMinDigits || len(national) > region.NationalMaxDigits {
+		return Phone{}, errors.NewValidationError("phone", phone, errors.ValidationLength,
+			"phone number is not a valid "+region.Code+" national number")
+	}
+
+	return Phone{value: normalized, region: region.Code}, nil
+}
+
+// stripPhoneFormatting removes common formatting characters from a phone number
+func stripPhoneFormatting(phone string) string {
+	return strings.NewReplacer(" ", "", "-", "", "(", "", ")", "", ".", "").Replace(phone)
+}
+
 // String returns the string representation of the phone
 func (p Phone) String() string {
 	return p.value
@@ -67,6 +106,33 @@ func (p Phone) HasCountryCode() bool {
 	return strings.HasPrefix(p.value, "+")
 }
 
+// Region returns the default phone region the number was validated against (empty for international input)
+func (p Phone) Region() string {
+	return p.region
+}
+
+// E164 returns the number in E.164 form (e.g. "+33612345678"), comparable whatever the input formatting
+// National numbers are qualified with the calling code of their region; without a known region (numbers loaded
+// from storage or entered without a supported locale) only the formatting is removed
+func (p Phone) E164() string {
+	if p.value == "" {
+		return ""
+	}
+
+	digits := stripPhoneFormatting(p.value)
+	if strings.HasPrefix(digits, "+") {
+		return digits
+	}
+	region, known := LookupPhoneRegion(p.region)
+	if !known {
+		return digits
+	}
+	if region.TrunkPrefix != "" && len(digits) > region.NationalMinDigits && strings.HasPrefix(digits, region.TrunkPrefix) {
+		digits = strings.TrimPrefix(digits, region.TrunkPrefix)
+	}
+	return "+" + region.CallingCode + digits
+}
+
 // WithoutCountryCode returns the phone number without the country code
 func (p Phone) WithoutCountryCode() string {
 	if p.HasCountryCode() {
@@ -0,0 +1,32 @@
+package valueobject
+
+// PhoneRegion describes the national numbering rules of a region
+// Used to validate phone numbers entered without a country code
+type PhoneRegion struct {
+	Code              string // ISO 3166-1 alpha-2 region code
+	CallingCode       string // International calling code (without +)
+	TrunkPrefix       string // National trunk prefix dropped when dialing internationally
+	NationalMinDigits int    // Minimum significant national number length
+	NationalMaxDigits int    // Maximum significant national number length
+}
+
+// phoneRegions lists the supported default phone regions
+// NOTE: For full coverage consider github.com/nyaruka/phonenumbers metadata
+var phoneRegions = map[string]PhoneRegion{
+	"US": {Code: "US", CallingCode: "1", TrunkPrefix: "1", NationalMinDigits: 10, NationalMaxDigits: 10},
+	"CA": {Code: "CA", CallingCode: "1", TrunkPrefix: "1", NationalMinDigits: 10, NationalMaxDigits: 10},
+	"GB": {Code: "GB", CallingCode: "44", TrunkPrefix: "0", NationalMinDigits: 9, NationalMaxDigits: 10},
+	"FR": {Code: "FR", CallingCode: "33", TrunkPrefix: "0", NationalMinDigits: 9, NationalMaxDigits: 9},
+	"BE": {Code: "BE", CallingCode: "32", TrunkPrefix: "0", NationalMinDigits: 8, NationalMaxDigits: 9},
+	"NL": {Code: "NL", CallingCode: "31", TrunkPrefix: "0", NationalMinDigits: 9, NationalMaxDigits: 9},
+	"DE": {Code: "DE", CallingCode: "49", TrunkPrefix: "0", NationalMinDigits: 6, NationalMaxDigits: 13},
+	"CH": {Code: "CH", CallingCode: "41", TrunkPrefix: "0", NationalMinDigits: 9, NationalMaxDigits: 9},
+	"ES": {Code: "ES", CallingCode: "34", TrunkPrefix: "", NationalMinDigits: 9, NationalMaxDigits: 9},
+	"IT": {Code: "IT", CallingCode: "39", TrunkPrefix: "", NationalMinDigits: 6, NationalMaxDigits: 11},
+}
+
+// LookupPhoneRegion returns the numbering rules for a region code
+func LookupPhoneRegion(code string) (PhoneRegion, bool) {
+	region, ok := phoneRegions[code]
+	return region, ok
+}
@@ -0,0 +1,228 @@
+package valueobject
+
+import (
+	"fmt"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+)
+
+// PricingModel is how a catalog price turns a quantity into an amount
+type PricingModel string
+
+const (
+	// PricingPerUnit charges the same unit amount for every unit
+	PricingPerUnit PricingModel = "per_unit"
+
+	// PricingTiered (stairstep) charges the flat amount of the tier the whole quantity falls in
+	PricingTiered PricingModel = "tiered"
+
+	// PricingVolume charges every unit at the unit amount of the tier the whole quantity falls in
+	PricingVolume PricingModel = "volume"
+
+	// PricingGraduated charges the units within each tier at that tier's unit amount
+	PricingGraduated PricingModel = "graduated"
+)
+
+// PriceTier is one bracket of a tiered price
+type PriceTier struct {
+	UpTo       int64 // Inclusive upper bound of the bracket (0 = unbounded, last tier only)
+	UnitAmount int64 // Price in minor units per UnitsPer units
+	FlatAmount int64 // Fixed fee in minor units when the bracket is reached
+}
+
+// PriceSchemeConfig describes a catalog price
+type PriceSchemeConfig struct {
+	Model      PricingModel
+	Currency   string
+	UnitAmount int64       // Price in minor units per UnitsPer units (per_unit model)
+	UnitsPer   int64       // Units the unit amounts are quoted for, e.g. 1000 for "per 1000 API calls" (default 1)
+	Tiers      []PriceTier // Brackets in increasing order (tiered, volume and graduated models)
+}
+
+// PriceScheme rates quantities (usage or subscription seats) against a catalog price
+type PriceScheme struct {
+	config PriceSchemeConfig
+}
+
+// RatedLine is the charge for the units rated in one bracket (or the whole quantity for per-unit prices)
+type RatedLine struct {
+	Tier       int // 1-based bracket number (0 for per-unit prices)
+	Quantity   int64
+	UnitAmount int64
+	Amount     Money // Unit charge, rounded half away from zero to the minor unit
+	FlatAmount Money
+}
+
+// Rating is the priced breakdown of a quantity
+// Each line is rounded on its own, so the lines always add up to the total shown on an invoice
+type Rating struct {
+	Quantity int64
+	Lines    []RatedLine
+	Total    Money
+}
+
+// NewPriceScheme creates a price scheme with validation
+func NewPriceScheme(config PriceSchemeConfig) (PriceScheme, error) {
+	currency, err := NewMoney(0, config.Currency)
+	if err != nil {
+		return PriceScheme{}, err
+	}
+	config.Currency = currency.Currency()
+
+	if config.UnitsPer == 0 {
+		config.UnitsPer = 1
+	}
+	if config.UnitsPer < 0 {
+		return PriceScheme{}, errors.NewValidationError("units_per", config.UnitsPer, errors.ValidationRange, "units per price must be positive")
+	}
+
+	switch config.Model {
+	case PricingPerUnit:
+		if config.UnitAmount < 0 {
+			return PriceScheme{}, errors.NewValidationError("unit_amount", config.UnitAmount, errors.ValidationRange, "unit amount must not be negative")
+		}
+		if len(config.Tiers) > 0 {
+			return PriceScheme{}, errors.NewValidationError("tiers", len(config.Tiers), errors.ValidationFormat, "per-unit prices do not take tiers")
+		}
+	case PricingTiered, PricingVolume, PricingGraduated:
+		if err := validatePriceTiers(config.Model, config.Tiers); err != nil {
+			return PriceScheme{}, err
+		}
+		config.Tiers = append([]PriceTier(nil), config.Tiers...)
+	default:
+		return PriceScheme{}, errors.NewValidationError("model", config.Model, errors.ValidationFormat, "pricing model must be one of: per_unit, tiered, volume, graduated")
+	}
+
+	return PriceScheme{config: config}, nil
+}
+
+// validatePriceTiers checks that brackets are increasing and end with an unbounded tier
+func validatePriceTiers(model PricingModel, tiers []PriceTier) error {
+	if len(tiers) == 0 {
+		return errors.NewValidationError("tiers", 0, errors.ValidationRequired, "tiered prices need at least one tier")
+	}
+
+	for index, tier := range tiers {
+		field := fmt.Sprintf("tiers[%d]", index)
+		last := index == len(tiers)-1
+
+		if last && tier.UpTo != 0 {
+			return errors.NewValidationError(field+".up_to", tier.UpTo, errors.ValidationRange, "the last tier must be unbounded")
+		}
+		if !last && tier.UpTo <= 0 {
+			return errors.NewValidationError(field+".up_to", tier.UpTo, errors.ValidationRange, "only the last tier may be unbounded")
+		}
+		if index > 0 && !last && tier.UpTo <= tiers[index-1].UpTo {
+			return errors.NewValidationError(field+".up_to", tier.UpTo, errors.ValidationRange, "tier bounds must be strictly increasing")
+		}
+		if tier.UnitAmount < 0 || tier.FlatAmount < 0 {
+			return errors.NewValidationError(field, tier, errors.ValidationRange, "tier amounts must not be negative")
+		}
+		if model == PricingTiered && tier.UnitAmount != 0 {
+			return errors.NewValidationError(field+".unit_amount", tier.UnitAmount, errors.ValidationFormat, "stairstep tiers are priced with a flat amount only")
+		}
+	}
+
+	return nil
+}
+
+// Config returns the price settings
+func (p PriceScheme) Config() PriceSchemeConfig {
+	config := p.config
+	config.Tiers = append([]PriceTier(nil), p.config.Tiers...)
+	return config
+}
+
+// Model returns the pricing model
+func (p PriceScheme) Model() PricingModel {
+	return p.config.Model
+}
+
+// Rate prices a quantity; a zero quantity is never charged
+func (p PriceScheme) Rate(quantity int64) (Rating, error) {
+	if quantity < 0 {
+		return Rating{}, errors.NewValidationError("quantity", quantity, errors.ValidationRange, "quantity must not be negative")
+	}
+
+	rating := Rating{
+		Quantity: quantity,
+		Lines:    make([]RatedLine, 0),
+		Total:    ZeroMoney(p.config.Currency),
+	}
+	if quantity == 0 {
+		return rating, nil
+	}
+
+	switch p.config.Model {
+	case PricingPerUnit:
+		line, err := p.rateLine(0, quantity, p.config.UnitAmount, 0)
+		if err != nil {
+			return Rating{}, err
+		}
+		rating.Lines = append(rating.Lines, line)
+	case PricingTiered:
+		index := p.tierIndex(quantity)
+		line, err := p.rateLine(index+1, quantity, 0, p.config.Tiers[index].FlatAmount)
+		if err != nil {
+			return Rating{}, err
+		}
+		rating.Lines = append(rating.Lines, line)
+	case PricingVolume:
+		index := p.tierIndex(quantity)
+		tier := p.config.Tiers[index]
+		line, err := p.rateLine(index+1, quantity, tier.UnitAmount, tier.FlatAmount)
+		if err != nil {
+			return Rating{}, err
+		}
+		rating.Lines = append(rating.Lines, line)
+	case PricingGraduated:
+		var lower int64
+		for index, tier := range p.config.Tiers {
+			if quantity <= lower {
+				break
+			}
+			units := quantity - lower
+			if tier.UpTo != 0 && quantity > tier.UpTo {
+				units = tier.UpTo - lower
+			}
+
+			line, err := p.rateLine(index+1, units, tier.UnitAmount, tier.FlatAmount)
+			if err != nil {
+				return Rating{}, err
+			}
+			rating.Lines = append(rating.Lines, line)
+			lower = tier.UpTo
+		}
+	}
+
+	for _, line := range rating.Lines {
+		rating.Total.amount += line.Amount.amount + line.FlatAmount.amount
+	}
+	return rating, nil
+}
+
+// tierIndex returns the bracket a whole quantity falls in
+func (p PriceScheme) tierIndex(quantity int64) int {
+	for index, tier := range p.config.Tiers {
+		if tier.UpTo == 0 || quantity <= tier.UpTo {
+			return index
+		}
+	}
+	return len(p.config.Tiers) - 1
+}
+
+// rateLine prices units at unitAmount per UnitsPer units, plus a flat amount
+func (p PriceScheme) rateLine(tier int, units, unitAmount, flatAmount int64) (RatedLine, error) {
+	amount, err := Money{amount: unitAmount, currency: p.config.Currency}.MultiplyRatio(units, p.config.UnitsPer)
+	if err != nil {
+		return RatedLine{}, err
+	}
+
+	return RatedLine{
+		Tier:       tier,
+		Quantity:   units,
+		UnitAmount: unitAmount,
+		Amount:     amount,
+		FlatAmount: Money{amount: flatAmount, currency: p.config.Currency},
+	}, nil
+}
@@ -0,0 +1,89 @@
+package valueobject
+
+import (
+	"strings"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+)
+
+// maxTaxRateBps bounds tax rates to 100%
+const maxTaxRateBps = 10000
+
+// TaxPricing is whether the amounts a tax applies to already include it
+type TaxPricing string
+
+const (
+	// TaxExclusive adds the tax on top of the amounts (net prices), the default
+	TaxExclusive TaxPricing = "exclusive"
+
+	// TaxInclusive extracts the tax from the amounts (gross prices)
+	TaxInclusive TaxPricing = "inclusive"
+)
+
+// ParseTaxPricing validates a tax pricing; an empty pricing is TaxExclusive
+func ParseTaxPricing(pricing string) (TaxPricing, error) {
+	switch normalized := TaxPricing(strings.ToLower(strings.TrimSpace(pricing))); normalized {
+	case "":
+		return TaxExclusive, nil
+	case TaxExclusive, TaxInclusive:
+		return normalized, nil
+	}
+	return "", errors.NewValidationError("tax_pricing", pricing, errors.ValidationFormat, "tax pricing must be one of: exclusive, inclusive")
+}
+
+// TaxRate is a tax rate in basis points (2000 = 20%, 0 = not taxed)
+type TaxRate struct {
+	bps int64
+}
+
+// NewTaxRate creates a tax rate from basis points between 0 and 10000
+func NewTaxRate(bps int64) (TaxRate, error) {
+	if bps < 0 || bps > maxTaxRateBps {
+		return TaxRate{}, errors.NewValidationError("tax_rate_bps", bps, errors.ValidationRange, "tax rate must be between 0 and 10000 basis points")
+	}
+	return TaxRate{bps: bps}, nil
+}
+
+// Bps returns the rate in basis points
+func (r TaxRate) Bps() int64 {
+	return r.bps
+}
+
+// IsZero checks if the rate does not tax
+func (r TaxRate) IsZero() bool {
+	return r.bps == 0
+}
+
+// TaxedAmount is an amount split into its net amount and the tax on it (Net + Tax = Gross)
+type TaxedAmount struct {
+	Net   Money
+	Tax   Money
+	Gross Money
+}
+
+// Apply splits an amount priced with pricing into net, tax and gross, rounding the tax once with mode
+// Exclusive amounts are the net amount the tax is added to; inclusive amounts are the gross amount the tax is
+// extracted from, so a gross price stays the amount billed whatever the rounding
+func (r TaxRate) Apply(amount Money, pricing TaxPricing, mode RoundingMode) (TaxedAmount, error) {
+	if pricing == TaxInclusive {
+		tax, err := amount.MultiplyRatioRounded(r.bps, maxTaxRateBps+r.bps, mode)
+		if err != nil {
+			return TaxedAmount{}, err
+		}
+		net, err := amount.Subtract(tax)
+		if err != nil {
+			return TaxedAmount{}, err
+		}
+		return TaxedAmount{Net: net, Tax: tax, Gross: amount}, nil
+	}
+
+	tax, err := amount.MultiplyRatioRounded(r.bps, maxTaxRateBps, mode)
+	if err != nil {
+		return TaxedAmount{}, err
+	}
+	gross, err := amount.Add(tax)
+	if err != nil {
+		return TaxedAmount{}, err
+	}
+	return TaxedAmount{Net: amount, Tax: tax, Gross: gross}, nil
+}
@@ -0,0 +1,183 @@
+package bankstatement
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// camtDocument is the subset of an ISO 20022 camt.053 bank-to-customer statement read by the importer
+// Element names are matched regardless of the camt.053 schema version namespace
+type camtDocument struct {
+	Statements []struct {
+		Account struct {
+			IBAN string `xml:"Id>IBAN"`
+		} `xml:"Acct"`
+		Entries []camtEntry `xml:"Ntry"`
+	} `xml:"BkToCstmrStmt>Stmt"`
+}
+
+// camtEntry is a statement entry (Ntry)
+type camtEntry struct {
+	EntryRef string `xml:"NtryRef"`
+	Amount   struct {
+		Currency string `xml:"Ccy,attr"`
+		Value    string `xml:",chardata"`
+	} `xml:"Amt"`
+	CreditDebit string `xml:"CdtDbtInd"`
+	Status      struct {
+		Value string `xml:",chardata"` // camt.053.001.02-07
+		Code  string `xml:"Cd"`        // camt.053.001.08+
+	} `xml:"Sts"`
+	BookingDate struct {
+		Date     string `xml:"Dt"`
+		DateTime string `xml:"DtTm"`
+	} `xml:"BookgDt"`
+	ServicerRef    string `xml:"AcctSvcrRef"`
+	AdditionalInfo string `xml:"AddtlNtryInf"`
+	Details        []struct {
+		EndToEndID   string   `xml:"Refs>EndToEndId"`
+		DebtorName   string   `xml:"RltdPties>Dbtr>Nm"`
+		DebtorIBAN   string   `xml:"RltdPties>DbtrAcct>Id>IBAN"`
+		CreditorName string   `xml:"RltdPties>Cdtr>Nm"`
+		CreditorIBAN string   `xml:"RltdPties>CdtrAcct>Id>IBAN"`
+		Unstructured []string `xml:"RmtInf>Ustrd"`
+		Structured   []string `xml:"RmtInf>Strd>CdtrRefInf>Ref"`
+	} `xml:"NtryDtls>TxDtls"`
+}
+
+// parseCAMT053 parses the booked entries of a camt.053 statement
+// Batch entries are imported as a single transaction carrying the remittance information of every detail
+func parseCAMT053(data []byte) (*Statement, error) {
+	var document camtDocument
+	if err := xml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("invalid camt.053 document: %w", err)
+	}
+	if len(document.Statements) == 0 {
+		return nil, fmt.Errorf("invalid camt.053 document: no statement")
+	}
+
+	statement := &Statement{
+		Format:      FormatCAMT053,
+		AccountIBAN: strings.TrimSpace(document.Statements[0].Account.IBAN),
+	}
+
+	for _, stmt := range document.Statements {
+		for i, entry := range stmt.Entries {
+			status := strings.TrimSpace(entry.Status.Code)
+			if status == "" {
+				status = strings.TrimSpace(entry.Status.Value)
+			}
+			if status != "" && status != "BOOK" {
+				continue // Pending and informational entries are not booked yet
+			}
+
+			transaction, err := camtTransaction(entry)
+			if err != nil {
+				return nil, fmt.Errorf("entry %d: %w", i+1, err)
+			}
+			statement.Transactions = append(statement.Transactions, transaction)
+		}
+	}
+
+	return statement, nil
+}
+
+// camtTransaction converts a booked entry to a transaction
+func camtTransaction(entry camtEntry) (Transaction, error) {
+	currency := strings.ToUpper(strings.TrimSpace(entry.Amount.Currency))
+	amount, err := parseMinorUnits(entry.Amount.Value, currency)
+	if err != nil {
+		return Transaction{}, err
+	}
+
+	credit := entry.CreditDebit == "CRDT"
+	if !credit && entry.CreditDebit != "DBIT" {
+		return Transaction{}, fmt.Errorf("invalid credit/debit indicator %q", entry.CreditDebit)
+	}
+	if !credit {
+		amount = -amount
+	}
+
+	bookingDate, err := camtDate(entry.BookingDate.Date, entry.BookingDate.DateTime)
+	if err != nil {
+		return Transaction{}, err
+	}
+
+	transaction := Transaction{
+		BankReference: firstNonEmpty(entry.ServicerRef, entry.EntryRef),
+		BookingDate:   bookingDate,
+		Amount:        amount,
+		Currency:      currency,
+	}
+
+	references := make([]string, 0)
+	for _, details := range entry.Details {
+		references = append(references, details.Structured...)
+		references = append(references, details.Unstructured...)
+
+		// The counterparty is the debtor of money received and the creditor of money sent
+		if transaction.CounterpartyName == "" {
+			if credit {
+				transaction.CounterpartyName = strings.TrimSpace(details.DebtorName)
+				transaction.CounterpartyIBAN = strings.TrimSpace(details.DebtorIBAN)
+			} else {
+				transaction.CounterpartyName = strings.TrimSpace(details.CreditorName)
+				transaction.CounterpartyIBAN = strings.TrimSpace(details.CreditorIBAN)
+			}
+		}
+		if transaction.BankReference == "" && details.EndToEndID != "NOTPROVIDED" {
+			transaction.BankReference = strings.TrimSpace(details.EndToEndID)
+		}
+	}
+	if len(references) == 0 && entry.AdditionalInfo != "" {
+		references = append(references, entry.AdditionalInfo)
+	}
+	transaction.Reference = joinReferences(references)
+
+	return transaction, nil
+}
+
+// camtDate parses an ISO date or date-time booking date
+func camtDate(date, dateTime string) (time.Time, error) {
+	if date = strings.TrimSpace(date); date != "" {
+		parsed, err := time.Parse("2006-01-02", date)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid booking date %q", date)
+		}
+		return parsed, nil
+	}
+
+	if dateTime = strings.TrimSpace(dateTime); dateTime != "" {
+		parsed, err := time.Parse(time.RFC3339, dateTime)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid booking date %q", dateTime)
+		}
+		year, month, day := parsed.Date()
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC), nil
+	}
+
+	return time.Time{}, fmt.Errorf("missing booking date")
+}
+
+// joinReferences joins non-empty remittance lines with single spaces
+func joinReferences(lines []string) string {
+	parts := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			parts = append(parts, line)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// firstNonEmpty returns the first non-blank value, trimmed
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,189 @@
+package bankstatement
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// mt940StatementLine matches a :61: statement line: value date, optional entry date, debit/credit mark,
+// optional funds code, amount, transaction type, customer reference and optional bank reference
+var mt940StatementLine = regexp.MustCompile(`^(\d{6})(\d{4})?(R?[CD])([A-Z])?(\d+,\d*)([NSF][A-Z0-9]{3})([^/]*)(?://(.*))?$`)
+
+// mt940Balance matches the currency of an opening balance (:60F: or :60M:)
+var mt940Balance = regexp.MustCompile(`^[CD]\d{6}([A-Z]{3})`)
+
+// mt940Subfield matches the ?NN subfield separators of structured (German GVC) :86: information
+var mt940Subfield = regexp.MustCompile(`\?(\d{2})`)
+
+// mt940SEPATag matches the tags (EREF+, KREF+, ABWA+...) delimiting SEPA fields in :86: purpose text
+var mt940SEPATag = regexp.MustCompile(`[A-Z]{4}\+`)
+
+// mt940Field is a tagged field of an MT940 message, continuation lines included
+type mt940Field struct {
+	tag   string
+	lines []string
+}
+
+// parseMT940 parses the statement lines of one or more MT940 messages
+func parseMT940(data []byte) (*Statement, error) {
+	fields := splitMT940Fields(string(data))
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("invalid MT940 statement: no fields")
+	}
+
+	statement := &Statement{Format: FormatMT940}
+	currency := ""
+	var current *Transaction
+
+	flush := func() {
+		if current != nil {
+			statement.Transactions = append(statement.Transactions, *current)
+			current = nil
+		}
+	}
+
+	for _, field := range fields {
+		value := field.lines[0]
+		switch field.tag {
+		case "25":
+			if statement.AccountIBAN == "" {
+				statement.AccountIBAN = strings.TrimSpace(value)
+			}
+		case "60F", "60M":
+			match := mt940Balance.FindStringSubmatch(value)
+			if match == nil {
+				return nil, fmt.Errorf("invalid MT940 opening balance %q", value)
+			}
+			currency = match[1]
+		case "61":
+			flush()
+			if currency == "" {
+				return nil, fmt.Errorf("invalid MT940 statement: statement line before opening balance")
+			}
+			transaction, err := mt940Transaction(value, currency)
+			if err != nil {
+				return nil, err
+			}
+			current = &transaction
+		case "86":
+			if current != nil {
+				applyMT940Information(current, field.lines)
+				flush()
+			}
+		case "62F", "62M":
+			flush()
+		}
+	}
+	flush()
+
+	return statement, nil
+}
+
+// splitMT940Fields splits a statement into tagged fields, attaching continuation lines to their field
+// SWIFT block headers and message separators are ignored
+func splitMT940Fields(content string) []mt940Field {
+	fields := make([]mt940Field, 0)
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		line = strings.TrimRight(line, " \r")
+		switch {
+		case line == "" || line == "-" || line == "-}" || strings.HasPrefix(line, "{"):
+			continue
+		case strings.HasPrefix(line, ":"):
+			tag, value, found := strings.Cut(line[1:], ":")
+			if !found {
+				continue
+			}
+			fields = append(fields, mt940Field{tag: tag, lines: []string{value}})
+		case len(fields) > 0:
+			last := &fields[len(fields)-1]
+			last.lines = append(last.lines, line)
+		}
+	}
+	return fields
+}
+
+// mt940Transaction parses a :61: statement line
+func mt940Transaction(value, currency string) (Transaction, error) {
+	match := mt940StatementLine.FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil {
+		return Transaction{}, fmt.Errorf("invalid MT940 statement line %q", value)
+	}
+
+	bookingDate, err := time.Parse("060102", match[1])
+	if err != nil {
+		return Transaction{}, fmt.Errorf("invalid MT940 value date %q", match[1])
+	}
+
+	amount, err := parseMinorUnits(match[5], currency)
+	if err != nil {
+		return Transaction{}, err
+	}
+	// Debits and reversed credits take money out of the account
+	if mark := match[3]; mark == "D" || mark == "RC" {
+		amount = -amount
+	}
+
+	transaction := Transaction{
+		BankReference: strings.TrimSpace(match[8]),
+		BookingDate:   bookingDate,
+		Amount:        amount,
+		Currency:      currency,
+	}
+	if customerRef := strings.TrimSpace(match[7]); customerRef != "" && customerRef != "NONREF" {
+		transaction.Reference = customerRef
+	}
+	return transaction, nil
+}
+
+// applyMT940Information reads the remittance information and counterparty of an :86: field
+// Structured German (GVC) information is split into its ?NN subfields; anything else is free text
+func applyMT940Information(transaction *Transaction, lines []string) {
+	text := strings.Join(lines, "")
+	subfields := parseMT940Subfields(text)
+	if subfields == nil {
+		transaction.Reference = joinReferences(append([]string{transaction.Reference}, lines...))
+		return
+	}
+
+	purpose := ""
+	for _, key := range []string{"20", "21", "22", "23", "24", "25", "26", "27", "28", "29", "60", "61", "62", "63"} {
+		purpose += subfields[key]
+	}
+	// SEPA transfers carry the remitter's text after SVWZ+, followed by optional tagged fields
+	if _, remittance, found := strings.Cut(purpose, "SVWZ+"); found {
+		purpose = remittance
+		if index := mt940SEPATag.FindStringIndex(purpose); index != nil {
+			purpose = purpose[:index[0]]
+		}
+	}
+
+	transaction.Reference = joinReferences([]string{transaction.Reference, purpose})
+	transaction.CounterpartyName = strings.TrimSpace(subfields["32"] + subfields["33"])
+	transaction.CounterpartyIBAN = strings.TrimSpace(subfields["31"])
+}
+
+// parseMT940Subfields splits structured :86: information ("166?00GUTSCHRIFT?20...") into subfields by number
+// It returns nil for free text
+func parseMT940Subfields(text string) map[string]string {
+	if len(text) < 4 || text[3] != '?' {
+		return nil
+	}
+	if _, err := strconv.Atoi(text[:3]); err != nil {
+		return nil
+	}
+
+	indexes := mt940Subfield.FindAllStringSubmatchIndex(text, -1)
+	subfields := make(map[string]string, len(indexes))
+	for i, index := range indexes {
+		end := len(text)
+		if i+1 < len(indexes) {
+			end = indexes[i+1][0]
+		}
+		key := text[index[2]:index[3]]
+		subfields[key] += text[index[1]:end]
+	}
+	return subfields
+}
@@ -0,0 +1,88 @@
+// Package bankstatement parses bank statement files (ISO 20022 camt.053 and SWIFT MT940)
+// into a common list of booked transactions.
+package bankstatement
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+)
+
+// Format identifies a bank statement file format
+type Format string
+
+const (
+	FormatCAMT053 Format = "camt053"
+	FormatMT940   Format = "mt940"
+)
+
+// Transaction is a booked statement entry
+// Amount is in minor units of Currency: credits (money received) are positive, debits negative
+type Transaction struct {
+	BankReference    string
+	BookingDate      time.Time
+	Amount           int64
+	Currency         string
+	Reference        string // Remittance information, usually carrying the invoice number
+	CounterpartyName string
+	CounterpartyIBAN string
+}
+
+// Statement is a parsed bank statement
+type Statement struct {
+	Format       Format
+	AccountIBAN  string
+	Transactions []Transaction
+}
+
+// DetectFormat guesses the format of a statement file from its content
+func DetectFormat(data []byte) Format {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("<")) {
+		return FormatCAMT053
+	}
+	return FormatMT940
+}
+
+// Parse parses a statement file; an empty format is detected from the content
+func Parse(format Format, data []byte) (*Statement, error) {
+	if format == "" {
+		format = DetectFormat(data)
+	}
+
+	switch format {
+	case FormatCAMT053:
+		return parseCAMT053(data)
+	case FormatMT940:
+		return parseMT940(data)
+	default:
+		return nil, fmt.Errorf("unsupported statement format %q", format)
+	}
+}
+
+// parseMinorUnits converts a decimal amount ("1234.5" or "1234,5") to minor units of currency
+func parseMinorUnits(value, currency string) (int64, error) {
+	value = strings.ReplaceAll(strings.TrimSpace(value), ",", ".")
+	whole, fraction, _ := strings.Cut(value, ".")
+
+	digits := valueobject.CurrencyMinorDigits(currency)
+	if len(fraction) > digits {
+		if strings.Trim(fraction[digits:], "0") != "" {
+			return 0, fmt.Errorf("amount %q has more than %d decimals", value, digits)
+		}
+		fraction = fraction[:digits]
+	}
+	fraction += strings.Repeat("0", digits-len(fraction))
+
+	if whole == "" {
+		whole = "0"
+	}
+	amount, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil || amount < 0 {
+		return 0, fmt.Errorf("invalid amount %q", value)
+	}
+	return amount, nil
+}
@@ -0,0 +1,131 @@
+// Package bureau provides a risk provider adapter for credit bureau scoring APIs
+package bureau
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/tracing"
+)
+
+// defaultTimeout bounds a single scoring request
+const defaultTimeout = 10 * time.Second
+
+// DefaultName identifies the bureau on assessments when no name is configured
+const DefaultName = "bureau"
+
+// Client scores clients with a credit bureau
+// Subjects are posted to POST {baseURL}/scores; the bureau answers with a 0-100 score (higher is riskier),
+// an optional decision and the reasons behind the score
+type Client struct {
+	name       string
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+}
+
+// scoreRequest is the subject sent to the bureau
+type scoreRequest struct {
+	Reference string `json:"reference"`
+	Name      string `json:"name"`
+	Email     string `json:"email"`
+	Phone     string `json:"phone,omitempty"`
+	Address   string `json:"address,omitempty"`
+}
+
+// scoreResponse is the bureau answer
+type scoreResponse struct {
+	Score    int    `json:"score"`
+	Decision string `json:"decision"` // approve, review, decline or empty
+	Reasons  []struct {
+		Code        string `json:"code"`
+		Description string `json:"description"`
+	} `json:"reasons"`
+}
+
+// NewClient creates a client for the bureau API at baseURL authenticated with apiKey
+// name identifies the bureau on the assessments it produces (DefaultName when empty)
+func NewClient(name, baseURL, apiKey string, httpClient *http.Client) *Client {
+	if name == "" {
+		name = DefaultName
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: defaultTimeout}
+	}
+
+	return &Client{
+		name:       name,
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		apiKey:     apiKey,
+		httpClient: httpClient,
+	}
+}
+
+// Name returns the name of the bureau
+func (c *Client) Name() string {
+	return c.name
+}
+
+// ScoreClient requests the risk score of a subject
+func (c *Client) ScoreClient(ctx context.Context, subject service.RiskSubject) (service.RiskScore, error) {
+	body, err := json.Marshal(scoreRequest{
+		Reference: subject.ClientID,
+		Name:      subject.Name,
+		Email:     subject.Email,
+		Phone:     subject.Phone,
+		Address:   subject.Address,
+	})
+	if err != nil {
+		return service.RiskScore{}, fmt.Errorf("failed to encode risk score request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/scores", bytes.NewReader(body))
+	if err != nil {
+		return service.RiskScore{}, fmt.Errorf("failed to build risk score request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	tracing.InjectHTTP(ctx, req.Header)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return service.RiskScore{}, fmt.Errorf("risk score request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return service.RiskScore{}, fmt.Errorf("risk bureau returned status %d", resp.StatusCode)
+	}
+
+	var result scoreResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return service.RiskScore{}, fmt.Errorf("failed to decode risk score response: %w", err)
+	}
+	return toRiskScore(result), nil
+}
+
+// toRiskScore converts a bureau answer to the domain representation
+// Decisions the bureau uses beyond approve, review and decline are dropped so the score bands decide
+func toRiskScore(result scoreResponse) service.RiskScore {
+	score := service.RiskScore{
+		Score:   result.Score,
+		Reasons: make([]entity.RiskReason, len(result.Reasons)),
+	}
+
+	switch decision := entity.RiskDecision(strings.ToLower(result.Decision)); decision {
+	case entity.RiskDecisionApprove, entity.RiskDecisionReview, entity.RiskDecisionDecline:
+		score.Decision = decision
+	}
+	for i, reason := range result.Reasons {
+		score.Reasons[i] = entity.RiskReason{Code: reason.Code, Description: reason.Description}
+	}
+	return score
+}
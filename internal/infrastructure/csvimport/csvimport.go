@@ -0,0 +1,137 @@
+// Package csvimport reads CSV files row by row, so imports never hold a whole file in memory.
+// The delimiter (comma, semicolon or tab) is detected from the header row, which names the columns.
+package csvimport
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// delimiters are the delimiters detected, the most common first (spreadsheets of some locales export semicolons)
+var delimiters = []rune{',', ';', '\t'}
+
+// utf8BOM is the byte order mark spreadsheets prefix UTF-8 exports with
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// maxHeaderBytes bounds the header row read to detect the delimiter
+const maxHeaderBytes = 64 << 10
+
+// Row is a data row of a CSV file
+type Row struct {
+	Line   int      // Line of the file the row starts on (the header is line 1)
+	Values []string // Values in column order, empty for the columns missing from a short row
+}
+
+// Reader streams the data rows of a CSV file with a header row
+type Reader struct {
+	csv       *csv.Reader
+	columns   []string
+	delimiter rune
+}
+
+// NewReader reads the header row of a CSV file and detects its delimiter
+// Column names are trimmed and must be unique and not empty
+func NewReader(r io.Reader) (*Reader, error) {
+	buffered := bufio.NewReaderSize(r, maxHeaderBytes)
+	if bom, _ := buffered.Peek(len(utf8BOM)); bytes.Equal(bom, utf8BOM) {
+		buffered.Discard(len(utf8BOM))
+	}
+
+	header, err := buffered.Peek(maxHeaderBytes)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, err
+	}
+	if line, _, found := bytes.Cut(header, []byte("\n")); found {
+		header = line
+	}
+	delimiter := detectDelimiter(header)
+
+	reader := csv.NewReader(buffered)
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	columns, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("file is empty, the first row must name the columns")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid header row: %w", err)
+	}
+
+	seen := make(map[string]bool, len(columns))
+	for i, column := range columns {
+		column = strings.TrimSpace(column)
+		if column == "" {
+			return nil, fmt.Errorf("column %d of the header row has no name", i+1)
+		}
+		if seen[column] {
+			return nil, fmt.Errorf("column %q appears twice in the header row", column)
+		}
+		seen[column] = true
+		columns[i] = column
+	}
+
+	return &Reader{csv: reader, columns: columns, delimiter: delimiter}, nil
+}
+
+// detectDelimiter picks the delimiter appearing most often outside quotes in the header row (comma on a tie)
+func detectDelimiter(header []byte) rune {
+	counts := make(map[rune]int, len(delimiters))
+	quoted := false
+	for _, char := range string(header) {
+		if char == '"' {
+			quoted = !quoted
+			continue
+		}
+		if !quoted {
+			counts[char]++
+		}
+	}
+
+	best := delimiters[0]
+	for _, delimiter := range delimiters[1:] {
+		if counts[delimiter] > counts[best] {
+			best = delimiter
+		}
+	}
+	return best
+}
+
+// Columns returns the column names of the header row
+func (r *Reader) Columns() []string {
+	return r.columns
+}
+
+// Delimiter returns the detected delimiter
+func (r *Reader) Delimiter() rune {
+	return r.delimiter
+}
+
+// Next reads the next data row, skipping blank lines; it returns io.EOF after the last row
+// Malformed rows and rows longer than the header are an error, reported with their line so imports can skip them
+// and read on
+func (r *Reader) Next() (Row, error) {
+	record, err := r.csv.Read()
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return Row{Line: parseErr.StartLine}, fmt.Errorf("line %d is not valid CSV: %w", parseErr.StartLine, parseErr.Err)
+	}
+	if err != nil {
+		return Row{}, err
+	}
+	line, _ := r.csv.FieldPos(0)
+	if len(record) > len(r.columns) {
+		return Row{Line: line}, fmt.Errorf("line %d has %d values, the header names %d columns", line, len(record), len(r.columns))
+	}
+
+	values := make([]string, len(r.columns))
+	for i, value := range record {
+		values[i] = strings.TrimSpace(value)
+	}
+	return Row{Line: line, Values: values}, nil
+}
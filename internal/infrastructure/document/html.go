@@ -0,0 +1,202 @@
+// Package document renders invoices and client statements with the tenant document template
+package document
+
+import (
+	"html/template"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+)
+
+// Party is the seller or the buyer printed on an invoice
+type Party struct {
+	Name    string
+	Address string
+	TaxIDs  []string
+}
+
+// Line is a line item of a rendered invoice
+type Line struct {
+	Description string
+	Quantity    int64
+	UnitPrice   valueobject.Money
+	TaxRateBps  int64 // 2000 = 20%
+	TaxAmount   valueobject.Money
+	Amount      valueobject.Money // Excluding tax
+}
+
+// Invoice is the content of a rendered invoice
+type Invoice struct {
+	Number      string
+	IssueDate   time.Time
+	DueDate     time.Time
+	Seller      Party
+	Buyer       Party
+	BankAccount valueobject.BankAccount // Printed with the payment instructions when set
+	Lines       []Line
+	Subtotal    valueobject.Money
+	Tax         valueobject.Money
+	Total       valueobject.Money
+	Locale      valueobject.Locale // Language and regional formats of the buyer (English when zero)
+
+	// Set from the compliance rules of the seller and buyer countries
+	TaxBreakdown  service.TaxBreakdownStyle // per_rate adds a summary per tax rate, per_line forces the tax columns
+	LegalMentions []string
+}
+
+// TaxRateSummary is the taxable amount and tax of one tax rate of an invoice
+type TaxRateSummary struct {
+	RateBps int64
+	Taxable valueobject.Money
+	Tax     valueobject.Money
+}
+
+// htmlView is the data passed to the HTML template
+type htmlView struct {
+	Layout   *entity.DocumentTemplate
+	Invoice  Invoice
+	L        *Localizer
+	Headings []string
+	Rows     [][]string
+	TaxRates []TaxRateSummary // Printed with the per_rate breakdown
+}
+
+// invoiceHTML is the print layout of an invoice; the PDF pipeline prints this document
+// Template values are escaped by html/template; colors are validated #RRGGBB codes
+var invoiceHTML = template.Must(template.New("invoice").Funcs(template.FuncMap{
+	"color": func(c string) template.CSS { return template.CSS(c) },
+}).Parse(`<!DOCTYPE html>
+<html lang="{{.L.Locale}}">
+<head>
+<meta charset="utf-8">
+<title>{{.L.Label "invoice"}} {{.Invoice.Number}}</title>
+<style>
+body { font-family: Helvetica, Arial, sans-serif; font-size: 12px; color: #111827; }
+h1, th { color: {{color .Layout.PrimaryColor}}; }
+th { border-bottom: 2px solid {{color .Layout.PrimaryColor}}; text-align: left; }
+td, th { padding: 4px 8px; }
+.total { color: {{color .Layout.AccentColor}}; font-weight: bold; }
+footer { margin-top: 32px; font-size: 10px; white-space: pre-line; }
+</style>
+</head>
+<body>
+<header>
+{{if .Layout.LogoURL}}<img class="logo" src="{{.Layout.LogoURL}}" alt="{{.Invoice.Seller.Name}}">{{end}}
+<h1>{{.L.Label "invoice"}} {{.Invoice.Number}}</h1>
+<p>{{.L.Label "issued"}} {{.L.Date .Invoice.IssueDate}}{{if not .Invoice.DueDate.IsZero}} &middot; {{.L.Label "due"}} {{.L.Date .Invoice.DueDate}}{{end}}</p>
+</header>
+<section class="parties">
+<div class="seller"><strong>{{.Invoice.Seller.Name}}</strong><br>{{.Invoice.Seller.Address}}{{range .Invoice.Seller.TaxIDs}}<br>{{.}}{{end}}</div>
+<div class="buyer"><strong>{{.Invoice.Buyer.Name}}</strong><br>{{.Invoice.Buyer.Address}}{{range .Invoice.Buyer.TaxIDs}}<br>{{.}}{{end}}</div>
+</section>
+<table>
+<thead><tr>{{range .Headings}}<th>{{.}}</th>{{end}}</tr></thead>
+<tbody>{{range .Rows}}<tr>{{range .}}<td>{{.}}</td>{{end}}</tr>{{end}}</tbody>
+</table>
+{{if .TaxRates}}<table class="tax-breakdown">
+<thead><tr><th>{{.L.Label "tax_rate"}}</th><th>{{.L.Label "taxable_amount"}}</th><th>{{.L.Label "tax"}}</th></tr></thead>
+<tbody>{{range .TaxRates}}<tr><td>{{$.L.Percent .RateBps}}</td><td>{{$.L.Money .Taxable}}</td><td>{{$.L.Money .Tax}}</td></tr>{{end}}</tbody>
+</table>{{end}}
+<p>{{.L.Label "subtotal"}}: {{.L.Money .Invoice.Subtotal}}<br>{{.L.Label "tax"}}: {{.L.Money .Invoice.Tax}}<br><span class="total">{{.L.Label "total"}}: {{.L.Money .Invoice.Total}}</span></p>
+{{if not .Invoice.BankAccount.IsEmpty}}<p class="payment">{{.L.Label "pay_by_transfer"}} {{.Invoice.BankAccount.AccountHolder}}<br>{{.L.Label "iban"}} {{.Invoice.BankAccount.IBAN}}{{if .Invoice.BankAccount.BIC}} &middot; {{.L.Label "bic"}} {{.Invoice.BankAccount.BIC}}{{end}}</p>{{end}}
+{{if .Invoice.LegalMentions}}<section class="legal">{{range .Invoice.LegalMentions}}<p>{{.}}</p>{{end}}</section>{{end}}
+{{if .Layout.FooterText}}<footer>{{.Layout.FooterText}}</footer>{{end}}
+</body>
+</html>
+`))
+
+// RenderHTML writes the invoice laid out with the given document template
+// Only the columns the template shows are rendered, in its order; labels, amounts and dates follow the invoice locale
+// The tax columns hidden by the template are appended when the invoice requires a tax breakdown per line
+func RenderHTML(w io.Writer, layout *entity.DocumentTemplate, invoice Invoice) error {
+	columns := layout.Columns()
+	if invoice.TaxBreakdown == service.TaxBreakdownPerLine {
+		for _, column := range []entity.InvoiceColumn{entity.InvoiceColumnTaxRate, entity.InvoiceColumnTaxAmount} {
+			if !layout.ShowsColumn(column) {
+				columns = append(columns, column)
+			}
+		}
+	}
+	localizer := NewLocalizer(invoice.Locale)
+	view := htmlView{
+		Layout:   layout,
+		Invoice:  invoice,
+		L:        localizer,
+		Headings: make([]string, len(columns)),
+		Rows:     make([][]string, len(invoice.Lines)),
+	}
+	for i, column := range columns {
+		view.Headings[i] = localizer.Label(string(column))
+	}
+	for i, line := range invoice.Lines {
+		row := make([]string, len(columns))
+		for j, column := range columns {
+			row[j] = cellValue(localizer, column, line)
+		}
+		view.Rows[i] = row
+	}
+	if invoice.TaxBreakdown == service.TaxBreakdownPerRate {
+		summaries, err := SummarizeTaxRates(invoice.Lines)
+		if err != nil {
+			return err
+		}
+		view.TaxRates = summaries
+	}
+
+	return invoiceHTML.Execute(w, view)
+}
+
+// SummarizeTaxRates totals the taxable amount and tax of the line items per tax rate, in ascending rate order
+func SummarizeTaxRates(lines []Line) ([]TaxRateSummary, error) {
+	summaries := make([]TaxRateSummary, 0)
+	for _, line := range lines {
+		index := sort.Search(len(summaries), func(i int) bool { return summaries[i].RateBps >= line.TaxRateBps })
+		if index == len(summaries) || summaries[index].RateBps != line.TaxRateBps {
+			summaries = append(summaries, TaxRateSummary{})
+			copy(summaries[index+1:], summaries[index:])
+			summaries[index] = TaxRateSummary{
+				RateBps: line.TaxRateBps,
+				Taxable: valueobject.ZeroMoney(line.Amount.Currency()),
+				Tax:     valueobject.ZeroMoney(line.Amount.Currency()),
+			}
+		}
+
+		taxable, err := summaries[index].Taxable.Add(line.Amount)
+		if err != nil {
+			return nil, err
+		}
+		tax := summaries[index].Tax
+		if line.TaxAmount.Currency() != "" { // Lines without a tax amount leave it unset
+			if tax, err = tax.Add(line.TaxAmount); err != nil {
+				return nil, err
+			}
+		}
+		summaries[index].Taxable = taxable
+		summaries[index].Tax = tax
+	}
+	return summaries, nil
+}
+
+// cellValue formats the value of a line item column
+func cellValue(localizer *Localizer, column entity.InvoiceColumn, line Line) string {
+	switch column {
+	case entity.InvoiceColumnDescription:
+		return line.Description
+	case entity.InvoiceColumnQuantity:
+		return localizer.Quantity(line.Quantity)
+	case entity.InvoiceColumnUnitPrice:
+		return localizer.Money(line.UnitPrice)
+	case entity.InvoiceColumnTaxRate:
+		return localizer.Percent(line.TaxRateBps)
+	case entity.InvoiceColumnTaxAmount:
+		return localizer.Money(line.TaxAmount)
+	case entity.InvoiceColumnAmount:
+		return localizer.Money(line.Amount)
+	default:
+		return ""
+	}
+}
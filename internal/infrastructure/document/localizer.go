@@ -0,0 +1,241 @@
+package document
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+)
+
+// fallbackLanguage is the bundle used for unsupported languages and labels missing from a bundle
+const fallbackLanguage = "en"
+
+// bundleFiles holds one resource bundle per language (locales/{language}.json)
+//
+//go:embed locales/*.json
+var bundleFiles embed.FS
+
+// formatRules are the number and date conventions of a language, optionally overridden per region
+type formatRules struct {
+	DecimalSeparator    string   `json:"decimal_separator,omitempty"`
+	GroupSeparator      string   `json:"group_separator,omitempty"`
+	CurrencyPattern     string   `json:"currency_pattern,omitempty"`      // {symbol} and {amount}
+	CurrencyCodePattern string   `json:"currency_code_pattern,omitempty"` // {code} and {amount}, for currencies without a symbol
+	PercentPattern      string   `json:"percent_pattern,omitempty"`       // {value}
+	DatePattern         string   `json:"date_pattern,omitempty"`          // {d}, {dd}, {MM}, {MMMM}, {yyyy}
+	Months              []string `json:"months,omitempty"`
+}
+
+// bundle is the resource bundle of a language
+type bundle struct {
+	formatRules
+	Labels  map[string]string      `json:"labels"`
+	Regions map[string]formatRules `json:"regions"`
+}
+
+// bundles are the parsed resource bundles keyed by language; a malformed bundle is a build defect
+var bundles = mustLoadBundles()
+
+// currencySymbols are the symbols printed instead of the ISO code
+var currencySymbols = map[string]string{
+	"EUR": "€",
+	"GBP": "£",
+	"USD": "$",
+	"JPY": "¥",
+}
+
+// Localizer formats labels, amounts and dates of rendered documents for a locale
+// Languages without a bundle are rendered in English; the region selects regional conventions (e.g. de-CH separators)
+type Localizer struct {
+	locale valueobject.Locale
+	rules  formatRules
+	labels map[string]string
+}
+
+// NewLocalizer creates a localizer for the given locale (the default locale when zero)
+func NewLocalizer(locale valueobject.Locale) *Localizer {
+	if locale.IsZero() {
+		locale = valueobject.DefaultLocale()
+	}
+
+	languageBundle, ok := bundles[locale.Language()]
+	if !ok {
+		languageBundle = bundles[fallbackLanguage]
+		locale = valueobject.DefaultLocale().WithRegion(locale.Region())
+	}
+
+	rules := languageBundle.formatRules
+	if override, ok := languageBundle.Regions[locale.Region()]; ok {
+		rules = mergeRules(rules, override)
+	}
+
+	return &Localizer{
+		locale: locale,
+		rules:  rules,
+		labels: languageBundle.Labels,
+	}
+}
+
+// SupportedLanguages returns the languages with a resource bundle
+func SupportedLanguages() []string {
+	languages := make([]string, 0, len(bundles))
+	for language := range bundles {
+		languages = append(languages, language)
+	}
+	return languages
+}
+
+// Locale returns the locale documents are rendered in (English for unsupported languages)
+func (l *Localizer) Locale() valueobject.Locale {
+	return l.locale
+}
+
+// Label returns the translated label for key, falling back to English and then to the key itself
+func (l *Localizer) Label(key string) string {
+	if label, ok := l.labels[key]; ok {
+		return label
+	}
+	if label, ok := bundles[fallbackLanguage].Labels[key]; ok {
+		return label
+	}
+	return key
+}
+
+// Money formats an amount with the locale separators and the currency symbol (or code)
+func (l *Localizer) Money(m valueobject.Money) string {
+	amount := m.Amount()
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	formatted := l.decimal(amount, valueobject.CurrencyMinorDigits(m.Currency()))
+	if symbol, ok := currencySymbols[m.Currency()]; ok {
+		return sign + strings.NewReplacer("{symbol}", symbol, "{amount}", formatted).Replace(l.rules.CurrencyPattern)
+	}
+	return sign + strings.NewReplacer("{code}", m.Currency(), "{amount}", formatted).Replace(l.rules.CurrencyCodePattern)
+}
+
+// Quantity formats a whole quantity with the locale group separator
+func (l *Localizer) Quantity(quantity int64) string {
+	if quantity < 0 {
+		return "-" + l.decimal(-quantity, 0)
+	}
+	return l.decimal(quantity, 0)
+}
+
+// Percent formats a rate in basis points (2000 = 20%), without trailing zero decimals
+func (l *Localizer) Percent(bps int64) string {
+	sign := ""
+	if bps < 0 {
+		sign = "-"
+		bps = -bps
+	}
+
+	value := strconv.FormatInt(bps/100, 10)
+	if fraction := strings.TrimRight(fmt.Sprintf("%02d", bps%100), "0"); fraction != "" {
+		value += l.rules.DecimalSeparator + fraction
+	}
+	return sign + strings.ReplaceAll(l.rules.PercentPattern, "{value}", value)
+}
+
+// Date formats a calendar date with the locale pattern and month names
+func (l *Localizer) Date(t time.Time) string {
+	month := t.Month()
+	monthName := month.String()
+	if int(month) <= len(l.rules.Months) {
+		monthName = l.rules.Months[month-1]
+	}
+
+	return strings.NewReplacer(
+		"{dd}", fmt.Sprintf("%02d", t.Day()),
+		"{d}", strconv.Itoa(t.Day()),
+		"{MMMM}", monthName,
+		"{MM}", fmt.Sprintf("%02d", int(month)),
+		"{yyyy}", strconv.Itoa(t.Year()),
+	).Replace(l.rules.DatePattern)
+}
+
+// decimal formats a non-negative amount of minor units with digits decimal places
+func (l *Localizer) decimal(minorUnits int64, digits int) string {
+	scale := int64(1)
+	for i := 0; i < digits; i++ {
+		scale *= 10
+	}
+
+	whole := strconv.FormatInt(minorUnits/scale, 10)
+	var grouped strings.Builder
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			grouped.WriteString(l.rules.GroupSeparator)
+		}
+		grouped.WriteRune(digit)
+	}
+
+	if digits == 0 {
+		return grouped.String()
+	}
+	return fmt.Sprintf("%s%s%0*d", grouped.String(), l.rules.DecimalSeparator, digits, minorUnits%scale)
+}
+
+// mergeRules applies the fields set in a regional override
+func mergeRules(base, override formatRules) formatRules {
+	if override.DecimalSeparator != "" {
+		base.DecimalSeparator = override.DecimalSeparator
+	}
+	if override.GroupSeparator != "" {
+		base.GroupSeparator = override.GroupSeparator
+	}
+	if override.CurrencyPattern != "" {
+		base.CurrencyPattern = override.CurrencyPattern
+	}
+	if override.CurrencyCodePattern != "" {
+		base.CurrencyCodePattern = override.CurrencyCodePattern
+	}
+	if override.PercentPattern != "" {
+		base.PercentPattern = override.PercentPattern
+	}
+	if override.DatePattern != "" {
+		base.DatePattern = override.DatePattern
+	}
+	if len(override.Months) == 12 {
+		base.Months = override.Months
+	}
+	return base
+}
+
+// mustLoadBundles parses the embedded resource bundles
+func mustLoadBundles() map[string]bundle {
+	entries, err := bundleFiles.ReadDir("locales")
+	if err != nil {
+		panic(fmt.Sprintf("document: failed to read locale bundles: %v", err))
+	}
+
+	loaded := make(map[string]bundle, len(entries))
+	for _, entry := range entries {
+		data, err := bundleFiles.ReadFile(path.Join("locales", entry.Name()))
+		if err != nil {
+			panic(fmt.Sprintf("document: failed to read locale bundle %s: %v", entry.Name(), err))
+		}
+
+		var languageBundle bundle
+		if err := json.Unmarshal(data, &languageBundle); err != nil {
+			panic(fmt.Sprintf("document: invalid locale bundle %s: %v", entry.Name(), err))
+		}
+		if len(languageBundle.Months) != 12 {
+			panic(fmt.Sprintf("document: locale bundle %s must list 12 months", entry.Name()))
+		}
+		loaded[strings.TrimSuffix(entry.Name(), ".json")] = languageBundle
+	}
+
+	if _, ok := loaded[fallbackLanguage]; !ok {
+		panic("document: the English locale bundle is missing")
+	}
+	return loaded
+}
@@ -0,0 +1,105 @@
+package document
+
+import (
+	"html/template"
+	"io"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+)
+
+// Statement is the content of a rendered client account statement
+type Statement struct {
+	Seller    Party
+	Client    Party
+	Statement service.ClientStatement
+	Locale    valueobject.Locale // Language and regional formats of the client (English when zero)
+}
+
+// statementRow is a formatted line of a rendered statement
+type statementRow struct {
+	Date        string
+	Kind        string
+	Reference   string
+	Description string
+	Charge      string // Invoice amount
+	Credit      string // Payment or credit note amount
+	Balance     string
+}
+
+// statementView is the data passed to the statement HTML template
+type statementView struct {
+	Layout    *entity.DocumentTemplate
+	Statement Statement
+	L         *Localizer
+	Rows      []statementRow
+}
+
+// statementHTML is the print layout of a client statement; the PDF pipeline prints this document
+// Template values are escaped by html/template; colors are validated #RRGGBB codes
+var statementHTML = template.Must(template.New("statement").Funcs(template.FuncMap{
+	"color": func(c string) template.CSS { return template.CSS(c) },
+}).Parse(`<!DOCTYPE html>
+<html lang="{{.L.Locale}}">
+<head>
+<meta charset="utf-8">
+<title>{{.L.Label "statement"}} {{.Statement.Client.Name}}</title>
+<style>
+body { font-family: Helvetica, Arial, sans-serif; font-size: 12px; color: #111827; }
+h1, th { color: {{color .Layout.PrimaryColor}}; }
+th { border-bottom: 2px solid {{color .Layout.PrimaryColor}}; text-align: left; }
+td, th { padding: 4px 8px; }
+.total { color: {{color .Layout.AccentColor}}; font-weight: bold; }
+footer { margin-top: 32px; font-size: 10px; white-space: pre-line; }
+</style>
+</head>
+<body>
+<header>
+{{if .Layout.LogoURL}}<img class="logo" src="{{.Layout.LogoURL}}" alt="{{.Statement.Seller.Name}}">{{end}}
+<h1>{{.L.Label "statement"}}</h1>
+<p>{{.L.Label "period"}} {{.L.Date .Statement.Statement.From}} &ndash; {{.L.Date .Statement.Statement.To}}</p>
+</header>
+<section class="parties">
+<div class="seller"><strong>{{.Statement.Seller.Name}}</strong><br>{{.Statement.Seller.Address}}{{range .Statement.Seller.TaxIDs}}<br>{{.}}{{end}}</div>
+<div class="buyer"><strong>{{.Statement.Client.Name}}</strong><br>{{.Statement.Client.Address}}{{range .Statement.Client.TaxIDs}}<br>{{.}}{{end}}</div>
+</section>
+<p>{{.L.Label "opening_balance"}}: {{.L.Money .Statement.Statement.OpeningBalance}}</p>
+<table>
+<thead><tr><th>{{.L.Label "date"}}</th><th>{{.L.Label "entry"}}</th><th>{{.L.Label "reference"}}</th><th>{{.L.Label "description"}}</th><th>{{.L.Label "charges"}}</th><th>{{.L.Label "credits"}}</th><th>{{.L.Label "balance"}}</th></tr></thead>
+<tbody>{{range .Rows}}<tr><td>{{.Date}}</td><td>{{.Kind}}</td><td>{{.Reference}}</td><td>{{.Description}}</td><td>{{.Charge}}</td><td>{{.Credit}}</td><td>{{.Balance}}</td></tr>{{end}}</tbody>
+</table>
+<p>{{.L.Label "invoiced"}}: {{.L.Money .Statement.Statement.Invoiced}}<br>{{.L.Label "paid"}}: {{.L.Money .Statement.Statement.Paid}}<br><span class="total">{{.L.Label "closing_balance"}}: {{.L.Money .Statement.Statement.ClosingBalance}}</span></p>
+{{if .Layout.FooterText}}<footer>{{.Layout.FooterText}}</footer>{{end}}
+</body>
+</html>
+`))
+
+// RenderStatementHTML writes the client statement laid out with the colors, logo and footer of the document template
+// Every line shows the client balance after it; labels, amounts and dates follow the statement locale
+func RenderStatementHTML(w io.Writer, layout *entity.DocumentTemplate, statement Statement) error {
+	localizer := NewLocalizer(statement.Locale)
+	view := statementView{
+		Layout:    layout,
+		Statement: statement,
+		L:         localizer,
+		Rows:      make([]statementRow, len(statement.Statement.Lines)),
+	}
+	for i, line := range statement.Statement.Lines {
+		row := statementRow{
+			Date:        localizer.Date(line.Date),
+			Kind:        localizer.Label(string(line.Kind)),
+			Reference:   line.Reference,
+			Description: line.Description,
+			Balance:     localizer.Money(line.Balance),
+		}
+		if line.Kind == service.StatementInvoice {
+			row.Charge = localizer.Money(line.Amount)
+		} else {
+			row.Credit = localizer.Money(line.Amount)
+		}
+		view.Rows[i] = row
+	}
+
+	return statementHTML.Execute(w, view)
+}
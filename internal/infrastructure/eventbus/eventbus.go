@@ -0,0 +1,144 @@
+// Package eventbus dispatches events between the modules of the process, so a module reacts to what another does
+// (audit logging, cache invalidation, notifications) without the publisher knowing its subscribers.
+// Delivery is in memory and best effort: events are lost when the process stops, so anything that must survive a
+// restart belongs on the message bus (package messaging) instead.
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+)
+
+// Topic names the events of type T published together (e.g. "client.created")
+// Subscribers and publishers share the topic value, so events are typed end to end
+type Topic[T any] struct {
+	name string
+}
+
+// NewTopic creates a topic of events of type T
+func NewTopic[T any](name string) Topic[T] {
+	return Topic[T]{name: name}
+}
+
+// Name returns the name of the topic
+func (t Topic[T]) Name() string {
+	return t.name
+}
+
+// Handler handles an event of a topic
+type Handler[T any] func(ctx context.Context, event T) error
+
+// Mode is when a subscriber handles the events it receives
+type Mode int
+
+const (
+	// Sync subscribers handle events in Publish, before it returns, in subscription order
+	Sync Mode = iota
+
+	// Async subscribers handle events on their own goroutine, after Publish returns
+	Async
+)
+
+// Failure is a subscriber that failed to handle an event, by returning an error or panicking
+type Failure struct {
+	Topic      string
+	Subscriber string
+	Err        error
+}
+
+// subscriber is a handler subscribed to a topic, its event type erased
+type subscriber struct {
+	name    string
+	mode    Mode
+	handler func(ctx context.Context, event any) error
+}
+
+// Bus is an in-process publish/subscribe event bus
+// A failing subscriber never fails the publisher nor the other subscribers: failures are reported to the failure
+// handler (logged by default)
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers map[string][]subscriber
+	onFailure   func(Failure)
+	inFlight    sync.WaitGroup // Async deliveries not handled yet
+}
+
+// New creates an event bus with no subscribers
+func New() *Bus {
+	return &Bus{
+		subscribers: make(map[string][]subscriber),
+		onFailure: func(failure Failure) {
+			log.Printf("Event subscriber %s failed to handle %s: %v", failure.Subscriber, failure.Topic, failure.Err)
+		},
+	}
+}
+
+// WithFailureHandler sets what is done with subscriber failures instead of logging them
+func (b *Bus) WithFailureHandler(onFailure func(Failure)) *Bus {
+	b.onFailure = onFailure
+	return b
+}
+
+// Subscribe registers a handler of the events of a topic; name identifies the subscriber in failure reports
+func Subscribe[T any](b *Bus, topic Topic[T], name string, mode Mode, handler Handler[T]) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers[topic.name] = append(b.subscribers[topic.name], subscriber{
+		name: name,
+		mode: mode,
+		handler: func(ctx context.Context, event any) error {
+			return handler(ctx, event.(T))
+		},
+	})
+}
+
+// Publish delivers an event to the subscribers of its topic
+// Sync subscribers have handled it when Publish returns; async subscribers get a context that is not canceled with
+// ctx, since they usually outlive the request publishing the event
+func Publish[T any](ctx context.Context, b *Bus, topic Topic[T], event T) {
+	b.mu.RLock()
+	subscribers := b.subscribers[topic.name]
+	b.mu.RUnlock()
+
+	for _, sub := range subscribers {
+		if sub.mode == Async {
+			b.inFlight.Add(1)
+			go func() {
+				defer b.inFlight.Done()
+				b.deliver(context.WithoutCancel(ctx), topic.name, sub, event)
+			}()
+			continue
+		}
+		b.deliver(ctx, topic.name, sub, event)
+	}
+}
+
+// deliver hands an event to a subscriber, reporting its error or panic
+func (b *Bus) deliver(ctx context.Context, topic string, sub subscriber, event any) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			b.onFailure(Failure{Topic: topic, Subscriber: sub.name, Err: fmt.Errorf("panic: %v", recovered)})
+		}
+	}()
+	if err := sub.handler(ctx, event); err != nil {
+		b.onFailure(Failure{Topic: topic, Subscriber: sub.name, Err: err})
+	}
+}
+
+// Wait blocks until async subscribers have handled the events published so far, or ctx is done
+// Called on shutdown, so the events of the last requests are not lost
+func (b *Bus) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		b.inFlight.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,314 @@
+package expression
+
+import (
+	"context"
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+// evaluator is the state of one evaluation: its deadline and the values of the exposed fields
+// Values are bool, int64, string and []interface{} (lists)
+type evaluator struct {
+	ctx  context.Context
+	vars map[string]interface{}
+}
+
+// step checks the evaluation is still within its time limit
+func (ev *evaluator) step(pos int) error {
+	if err := ev.ctx.Err(); err != nil {
+		return errorAt(pos, "evaluation exceeded its time limit")
+	}
+	return nil
+}
+
+// node is a type checked node of the syntax tree
+type node interface {
+	typ() Type
+	eval(ev *evaluator) (interface{}, error)
+}
+
+// literal is a constant
+type literal struct {
+	resultType Type
+	value      interface{}
+}
+
+func (n *literal) typ() Type { return n.resultType }
+
+func (n *literal) eval(*evaluator) (interface{}, error) { return n.value, nil }
+
+// field reads an exposed field
+type field struct {
+	name       string
+	resultType Type
+}
+
+func (n *field) typ() Type { return n.resultType }
+
+func (n *field) eval(ev *evaluator) (interface{}, error) { return ev.vars[n.name], nil }
+
+// list is a list literal
+type list struct {
+	resultType Type
+	elems      []node
+}
+
+func (n *list) typ() Type { return n.resultType }
+
+func (n *list) eval(ev *evaluator) (interface{}, error) {
+	values := make([]interface{}, len(n.elems))
+	for i, elem := range n.elems {
+		value, err := elem.eval(ev)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// conditional is the cond ? then : otherwise operator
+type conditional struct {
+	cond, then, otherwise node
+}
+
+func (n *conditional) typ() Type { return n.then.typ() }
+
+func (n *conditional) eval(ev *evaluator) (interface{}, error) {
+	cond, err := n.cond.eval(ev)
+	if err != nil {
+		return nil, err
+	}
+	if cond.(bool) {
+		return n.then.eval(ev)
+	}
+	return n.otherwise.eval(ev)
+}
+
+// logical is a short-circuit && or || operator
+type logical struct {
+	op          string
+	left, right node
+}
+
+func (n *logical) typ() Type { return Bool }
+
+func (n *logical) eval(ev *evaluator) (interface{}, error) {
+	left, err := n.left.eval(ev)
+	if err != nil {
+		return nil, err
+	}
+	if left.(bool) == (n.op == "||") {
+		return left, nil
+	}
+	return n.right.eval(ev)
+}
+
+// unary is a ! or - operator
+type unary struct {
+	op      string
+	operand node
+	pos     int
+}
+
+func (n *unary) typ() Type { return n.operand.typ() }
+
+func (n *unary) eval(ev *evaluator) (interface{}, error) {
+	value, err := n.operand.eval(ev)
+	if err != nil {
+		return nil, err
+	}
+	if n.op == "!" {
+		return !value.(bool), nil
+	}
+	if value.(int64) == math.MinInt64 {
+		return nil, errorAt(n.pos, "integer overflow")
+	}
+	return -value.(int64), nil
+}
+
+// binary is a comparison or arithmetic operator
+type binary struct {
+	op          string
+	resultType  Type
+	left, right node
+	pos         int
+}
+
+func (n *binary) typ() Type { return n.resultType }
+
+func (n *binary) eval(ev *evaluator) (interface{}, error) {
+	if err := ev.step(n.pos); err != nil {
+		return nil, err
+	}
+	left, err := n.left.eval(ev)
+	if err != nil {
+		return nil, err
+	}
+	right, err := n.right.eval(ev)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "==":
+		return left == right, nil
+	case "!=":
+		return left != right, nil
+	case "in":
+		for _, elem := range right.([]interface{}) {
+			if elem == left {
+				return true, nil
+			}
+		}
+		return false, nil
+	case "<", "<=", ">", ">=":
+		return compare(n.op, left, right), nil
+	}
+
+	if s, ok := left.(string); ok {
+		if len(s)+len(right.(string)) > maxStringLength {
+			return nil, errorAt(n.pos, "string is longer than %d bytes", maxStringLength)
+		}
+		return s + right.(string), nil
+	}
+	return arithmetic(n.op, left.(int64), right.(int64), n.pos)
+}
+
+// compare orders two ints or two strings
+func compare(op string, left, right interface{}) bool {
+	var order int
+	switch l := left.(type) {
+	case int64:
+		r := right.(int64)
+		if l < r {
+			order = -1
+		} else if l > r {
+			order = 1
+		}
+	case string:
+		order = strings.Compare(l, right.(string))
+	}
+
+	switch op {
+	case "<":
+		return order < 0
+	case "<=":
+		return order <= 0
+	case ">":
+		return order > 0
+	default:
+		return order >= 0
+	}
+}
+
+// arithmetic applies an arithmetic operator, reporting overflows and divisions by zero instead of wrapping around
+func arithmetic(op string, a, b int64, pos int) (interface{}, error) {
+	switch op {
+	case "+":
+		r := a + b
+		if (a > 0 && b > 0 && r < 0) || (a < 0 && b < 0 && r >= 0) {
+			return nil, errorAt(pos, "integer overflow")
+		}
+		return r, nil
+	case "-":
+		r := a - b
+		if (b > 0 && r > a) || (b < 0 && r < a) {
+			return nil, errorAt(pos, "integer overflow")
+		}
+		return r, nil
+	case "*":
+		r := a * b
+		if a != 0 && (r/a != b || (a == -1 && b == math.MinInt64)) {
+			return nil, errorAt(pos, "integer overflow")
+		}
+		return r, nil
+	default:
+		if b == 0 {
+			return nil, errorAt(pos, "division by zero")
+		}
+		if a == math.MinInt64 && b == -1 {
+			return nil, errorAt(pos, "integer overflow")
+		}
+		if op == "/" {
+			return a / b, nil
+		}
+		return a % b, nil
+	}
+}
+
+// call is a function call, receiver first for method calls
+type call struct {
+	name       string
+	fn         func(args []interface{}) interface{}
+	resultType Type
+	args       []node
+	pos        int
+}
+
+func (n *call) typ() Type { return n.resultType }
+
+func (n *call) eval(ev *evaluator) (interface{}, error) {
+	if err := ev.step(n.pos); err != nil {
+		return nil, err
+	}
+	args := make([]interface{}, len(n.args))
+	for i, arg := range n.args {
+		value, err := arg.eval(ev)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = value
+	}
+	return n.fn(args), nil
+}
+
+// function is a function expressions may call
+// Functions take and return values of bounded size, so calls cannot outgrow the evaluation limits
+type function struct {
+	usage string
+	check func(args []Type) (Type, bool) // Result type of a call with arguments of the given types
+	call  func(args []interface{}) interface{}
+}
+
+// stringPredicate is a function of two strings returning a bool
+func stringPredicate(usage string, predicate func(s, arg string) bool) function {
+	return function{
+		usage: usage,
+		check: func(args []Type) (Type, bool) {
+			return Bool, len(args) == 2 && args[0] == String && args[1] == String
+		},
+		call: func(args []interface{}) interface{} {
+			return predicate(args[0].(string), args[1].(string))
+		},
+	}
+}
+
+// functions are the functions expressions may call, either as f(x, y) or as x.f(y)
+var functions = map[string]function{
+	"size": {
+		usage: "size(string) or size(list), the number of characters or elements",
+		check: func(args []Type) (Type, bool) {
+			return Int, len(args) == 1 && (args[0] == String || args[0].isList())
+		},
+		call: func(args []interface{}) interface{} {
+			if s, ok := args[0].(string); ok {
+				return int64(utf8.RuneCountInString(s))
+			}
+			return int64(len(args[0].([]interface{})))
+		},
+	},
+	"lower": {
+		usage: "lower(string), the string in lower case",
+		check: func(args []Type) (Type, bool) {
+			return String, len(args) == 1 && args[0] == String
+		},
+		call: func(args []interface{}) interface{} {
+			return strings.ToLower(args[0].(string))
+		},
+	},
+	"contains":   stringPredicate("string.contains(string)", strings.Contains),
+	"startsWith": stringPredicate("string.startsWith(string)", strings.HasPrefix),
+	"endsWith":   stringPredicate("string.endsWith(string)", strings.HasSuffix),
+}
@@ -0,0 +1,323 @@
+// Package expression evaluates small boolean or scalar expressions written in a subset of the CEL syntax, so that
+// rules changing often can be stored as data instead of code:
+//
+//	invoice.total <= 500000 || "trusted" in client.tags
+//
+// Expressions are sandboxed: they only read the fields an Env exposes, cannot loop, call out or change anything,
+// are type checked when compiled, and are bounded in length and size, and evaluated under a time limit.
+package expression
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Type is the type of a field or expression value
+type Type int
+
+const (
+	Bool Type = iota + 1
+	Int
+	String
+	StringList
+	IntList
+)
+
+// String returns the name of the type as reported by errors
+func (t Type) String() string {
+	switch t {
+	case Bool:
+		return "bool"
+	case Int:
+		return "int"
+	case String:
+		return "string"
+	case StringList:
+		return "list(string)"
+	case IntList:
+		return "list(int)"
+	default:
+		return "unknown"
+	}
+}
+
+// isList reports whether values of the type are lists
+func (t Type) isList() bool {
+	return t == StringList || t == IntList
+}
+
+// elem returns the type of the elements of a list type
+func (t Type) elem() Type {
+	switch t {
+	case StringList:
+		return String
+	case IntList:
+		return Int
+	default:
+		return 0
+	}
+}
+
+// listOf returns the list type of elements of type t (0 when t cannot be listed)
+func listOf(t Type) Type {
+	switch t {
+	case String:
+		return StringList
+	case Int:
+		return IntList
+	default:
+		return 0
+	}
+}
+
+// Limits bound the cost of compiling and evaluating an expression
+type Limits struct {
+	MaxLength int           // Bytes of source
+	MaxNodes  int           // Operands and operators; expressions have no loops, so this bounds the evaluation steps
+	MaxDepth  int           // Nesting of parentheses, lists and operators
+	Timeout   time.Duration // Evaluation time
+}
+
+// DefaultLimits returns limits fitting rules of a few conditions
+func DefaultLimits() Limits {
+	return Limits{
+		MaxLength: 1000,
+		MaxNodes:  200,
+		MaxDepth:  32,
+		Timeout:   10 * time.Millisecond,
+	}
+}
+
+// maxStringLength bounds the strings built by concatenation during an evaluation
+const maxStringLength = 64 << 10
+
+// Env is the environment expressions are compiled in: the fields they may read, and their limits
+type Env struct {
+	fields map[string]Type
+	limits Limits
+}
+
+// NewEnv creates an environment exposing fields, named by dotted paths (e.g. "client.name")
+func NewEnv(fields map[string]Type, limits Limits) (*Env, error) {
+	exposed := make(map[string]Type, len(fields))
+	for name, typ := range fields {
+		for _, segment := range strings.Split(name, ".") {
+			if !isIdentifier(segment) || keywords[segment] {
+				return nil, fmt.Errorf("invalid field name %q", name)
+			}
+		}
+		if typ.String() == "unknown" {
+			return nil, fmt.Errorf("field %s has an unknown type", name)
+		}
+		exposed[name] = typ
+	}
+	if limits.MaxLength <= 0 || limits.MaxNodes <= 0 || limits.MaxDepth <= 0 || limits.Timeout <= 0 {
+		return nil, fmt.Errorf("expression limits must be positive")
+	}
+	return &Env{fields: exposed, limits: limits}, nil
+}
+
+// Fields lists the names of the exposed fields, sorted
+func (e *Env) Fields() []string {
+	names := make([]string, 0, len(e.fields))
+	for name := range e.fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FieldType returns the type of an exposed field
+func (e *Env) FieldType(name string) (Type, bool) {
+	typ, ok := e.fields[name]
+	return typ, ok
+}
+
+// Error is an error compiling or evaluating an expression, at a byte offset of its source
+type Error struct {
+	Pos     int
+	Message string
+}
+
+// Error implements the error interface
+func (e *Error) Error() string {
+	return fmt.Sprintf("position %d: %s", e.Pos, e.Message)
+}
+
+// errorAt creates an expression error at a position
+func errorAt(pos int, format string, args ...interface{}) *Error {
+	return &Error{Pos: pos, Message: fmt.Sprintf(format, args...)}
+}
+
+// Program is a compiled expression, safe for concurrent evaluations
+type Program struct {
+	env    *Env
+	source string
+	root   node
+}
+
+// Compile parses and type checks an expression against the fields of env
+func Compile(env *Env, source string) (*Program, error) {
+	if len(source) > env.limits.MaxLength {
+		return nil, errorAt(env.limits.MaxLength, "expression is longer than %d characters", env.limits.MaxLength)
+	}
+	tokens, err := tokenize(source)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{env: env, tokens: tokens}
+	root, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, errorAt(tok.pos, "unexpected %s", tok)
+	}
+	return &Program{env: env, source: source, root: root}, nil
+}
+
+// Source returns the expression compiled
+func (p *Program) Source() string {
+	return p.source
+}
+
+// Type returns the type of the values the expression evaluates to
+func (p *Program) Type() Type {
+	return p.root.typ()
+}
+
+// Vars are the values of the exposed fields an expression is evaluated against, by field name
+// Values are bool, int or int64, string, []string and []int64 (or []int) following the field types; fields
+// without a value evaluate to the zero value of their type
+type Vars map[string]interface{}
+
+// Eval evaluates the expression against vars, within the timeout of the environment limits
+func (p *Program) Eval(ctx context.Context, vars Vars) (interface{}, error) {
+	values, err := p.bind(vars)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.env.limits.Timeout)
+	defer cancel()
+	result, err := p.root.eval(&evaluator{ctx: ctx, vars: values})
+	if err != nil {
+		return nil, err
+	}
+	return export(p.Type(), result), nil
+}
+
+// EvalBool evaluates a boolean expression
+func (p *Program) EvalBool(ctx context.Context, vars Vars) (bool, error) {
+	if p.Type() != Bool {
+		return false, errorAt(0, "expression evaluates to %s, not bool", p.Type())
+	}
+	result, err := p.Eval(ctx, vars)
+	if err != nil {
+		return false, err
+	}
+	return result.(bool), nil
+}
+
+// bind converts vars to the values of the exposed fields the evaluator reads
+func (p *Program) bind(vars Vars) (map[string]interface{}, error) {
+	for name := range vars {
+		if _, ok := p.env.fields[name]; !ok {
+			return nil, fmt.Errorf("%s is not a field of the expression environment", name)
+		}
+	}
+
+	values := make(map[string]interface{}, len(p.env.fields))
+	for name, typ := range p.env.fields {
+		value, err := convert(typ, vars[name])
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", name, err)
+		}
+		values[name] = value
+	}
+	return values, nil
+}
+
+// convert returns the evaluator value of a field value of type typ (the zero value for nil)
+func convert(typ Type, value interface{}) (interface{}, error) {
+	switch typ {
+	case Bool:
+		if value == nil {
+			return false, nil
+		}
+		if b, ok := value.(bool); ok {
+			return b, nil
+		}
+	case Int:
+		switch n := value.(type) {
+		case nil:
+			return int64(0), nil
+		case int64:
+			return n, nil
+		case int:
+			return int64(n), nil
+		}
+	case String:
+		if value == nil {
+			return "", nil
+		}
+		if s, ok := value.(string); ok {
+			return s, nil
+		}
+	case StringList:
+		switch list := value.(type) {
+		case nil:
+			return []interface{}{}, nil
+		case []string:
+			converted := make([]interface{}, len(list))
+			for i, s := range list {
+				converted[i] = s
+			}
+			return converted, nil
+		}
+	case IntList:
+		switch list := value.(type) {
+		case nil:
+			return []interface{}{}, nil
+		case []int64:
+			converted := make([]interface{}, len(list))
+			for i, n := range list {
+				converted[i] = n
+			}
+			return converted, nil
+		case []int:
+			converted := make([]interface{}, len(list))
+			for i, n := range list {
+				converted[i] = int64(n)
+			}
+			return converted, nil
+		}
+	}
+	return nil, fmt.Errorf("%T is not a %s value", value, typ)
+}
+
+// export returns an evaluator value of type typ as the Go value callers expect ([]string and []int64 for lists)
+func export(typ Type, value interface{}) interface{} {
+	switch typ {
+	case StringList:
+		list := value.([]interface{})
+		strs := make([]string, len(list))
+		for i, s := range list {
+			strs[i] = s.(string)
+		}
+		return strs
+	case IntList:
+		list := value.([]interface{})
+		ints := make([]int64, len(list))
+		for i, n := range list {
+			ints[i] = n.(int64)
+		}
+		return ints
+	default:
+		return value
+	}
+}
@@ -0,0 +1,157 @@
+package expression
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// tokenKind is the lexical class of a token
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenInt
+	tokenString
+	tokenIdent
+	tokenPunct // Operators and delimiters
+)
+
+// token is a lexical unit of an expression, at a byte offset of the source
+type token struct {
+	kind  tokenKind
+	text  string      // Identifier, operator or delimiter
+	value interface{} // Literal value (int64 or string)
+	pos   int
+}
+
+// String describes the token in syntax errors
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of expression"
+	case tokenInt:
+		return fmt.Sprintf("number %d", t.value)
+	case tokenString:
+		return fmt.Sprintf("string %q", t.value)
+	default:
+		return fmt.Sprintf("%q", t.text)
+	}
+}
+
+// keywords are the reserved identifiers
+var keywords = map[string]bool{"true": true, "false": true, "in": true}
+
+// punctuation lists the operators and delimiters, two-character ones first
+var punctuation = []string{"==", "!=", "<=", ">=", "&&", "||", "<", ">", "!", "+", "-", "*", "/", "%", "(", ")", "[", "]", ",", ".", "?", ":"}
+
+// isIdentifier reports whether s is a valid identifier
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		if !isIdentRune(r, i == 0) {
+			return false
+		}
+	}
+	return true
+}
+
+// isIdentRune reports whether r may appear in an identifier (first is the first rune of the identifier)
+func isIdentRune(r rune, first bool) bool {
+	return r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || !first && r >= '0' && r <= '9'
+}
+
+// tokenize splits an expression source into tokens, ending with an EOF token
+func tokenize(source string) ([]token, error) {
+	var tokens []token
+	pos := 0
+	for pos < len(source) {
+		r, size := utf8.DecodeRuneInString(source[pos:])
+		switch {
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			pos += size
+
+		case r >= '0' && r <= '9':
+			end := pos
+			for end < len(source) && source[end] >= '0' && source[end] <= '9' {
+				end++
+			}
+			if end < len(source) && (source[end] == '.' || isIdentRune(rune(source[end]), false)) {
+				return nil, errorAt(end, "invalid number (amounts are integers in minor units)")
+			}
+			n, err := strconv.ParseInt(source[pos:end], 10, 64)
+			if err != nil {
+				return nil, errorAt(pos, "number %s is out of range", source[pos:end])
+			}
+			tokens = append(tokens, token{kind: tokenInt, value: n, pos: pos})
+			pos = end
+
+		case r == '"' || r == '\'':
+			value, end, err := scanString(source, pos)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokenString, value: value, pos: pos})
+			pos = end
+
+		case isIdentRune(r, true):
+			end := pos
+			for end < len(source) && isIdentRune(rune(source[end]), false) {
+				end++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: source[pos:end], pos: pos})
+			pos = end
+
+		default:
+			punct := ""
+			for _, candidate := range punctuation {
+				if strings.HasPrefix(source[pos:], candidate) {
+					punct = candidate
+					break
+				}
+			}
+			if punct == "" {
+				return nil, errorAt(pos, "unexpected character %q", r)
+			}
+			tokens = append(tokens, token{kind: tokenPunct, text: punct, pos: pos})
+			pos += len(punct)
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(source)}), nil
+}
+
+// scanString reads the string literal starting at pos, returning its value and the offset after its closing quote
+func scanString(source string, pos int) (string, int, error) {
+	quote := source[pos]
+	var value strings.Builder
+	for i := pos + 1; i < len(source); i++ {
+		c := source[i]
+		switch {
+		case c == quote:
+			return value.String(), i + 1, nil
+		case c == '\n':
+			return "", 0, errorAt(i, "unterminated string")
+		case c == '\\':
+			if i+1 == len(source) {
+				return "", 0, errorAt(i, "unterminated string")
+			}
+			i++
+			switch source[i] {
+			case '\\', '"', '\'':
+				value.WriteByte(source[i])
+			case 'n':
+				value.WriteByte('\n')
+			case 't':
+				value.WriteByte('\t')
+			default:
+				return "", 0, errorAt(i-1, "unknown escape sequence \\%c", source[i])
+			}
+		default:
+			value.WriteByte(c)
+		}
+	}
+	return "", 0, errorAt(pos, "unterminated string")
+}
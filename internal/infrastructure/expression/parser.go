@@ -0,0 +1,438 @@
+package expression
+
+import (
+	"strings"
+)
+
+// parser builds the typed syntax tree of an expression by recursive descent:
+//
+//	expression  = or [ "?" expression ":" expression ]
+//	or          = and { "||" and }
+//	and         = relation { "&&" relation }
+//	relation    = sum [ ( "==" | "!=" | "<" | "<=" | ">" | ">=" | "in" ) sum ]
+//	sum         = product { ( "+" | "-" ) product }
+//	product     = unary { ( "*" | "/" | "%" ) unary }
+//	unary       = ( "!" | "-" ) unary | member
+//	member      = primary { "." ident "(" [ arguments ] ")" }
+//	primary     = int | string | "true" | "false" | "(" expression ")" | "[" arguments "]"
+//	            | ident { "." ident } [ "(" [ arguments ] ")" ]
+//	arguments   = expression { "," expression }
+type parser struct {
+	env    *Env
+	tokens []token
+	pos    int
+	nodes  int
+	depth  int
+}
+
+// peek returns the next token
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+// next consumes the next token
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// accept consumes the next token when it is the given operator or delimiter
+func (p *parser) accept(punct string) (token, bool) {
+	tok := p.peek()
+	if tok.kind == tokenPunct && tok.text == punct {
+		p.pos++
+		return tok, true
+	}
+	return tok, false
+}
+
+// expect consumes the given operator or delimiter
+func (p *parser) expect(punct string) error {
+	if tok, ok := p.accept(punct); !ok {
+		return errorAt(tok.pos, "expected %q, found %s", punct, tok)
+	}
+	return nil
+}
+
+// node counts a node of the tree against the size limit
+func (p *parser) node(pos int, n node) (node, error) {
+	p.nodes++
+	if p.nodes > p.env.limits.MaxNodes {
+		return nil, errorAt(pos, "expression has more than %d operands and operators", p.env.limits.MaxNodes)
+	}
+	return n, nil
+}
+
+// enter counts a nesting level against the depth limit; leave must follow
+func (p *parser) enter(pos int) error {
+	p.depth++
+	if p.depth > p.env.limits.MaxDepth {
+		return errorAt(pos, "expression is nested more than %d levels deep", p.env.limits.MaxDepth)
+	}
+	return nil
+}
+
+func (p *parser) leave() {
+	p.depth--
+}
+
+func (p *parser) parseExpression() (node, error) {
+	start := p.peek().pos
+	if err := p.enter(start); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+
+	cond, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := p.accept("?"); !ok {
+		return cond, nil
+	}
+	then, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(":"); err != nil {
+		return nil, err
+	}
+	otherwise, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+
+	if cond.typ() != Bool {
+		return nil, errorAt(start, "condition is %s, not bool", cond.typ())
+	}
+	if then.typ() != otherwise.typ() {
+		return nil, errorAt(start, "branches have different types (%s and %s)", then.typ(), otherwise.typ())
+	}
+	return p.node(start, &conditional{cond: cond, then: then, otherwise: otherwise})
+}
+
+func (p *parser) parseOr() (node, error) {
+	return p.parseLogical("||", p.parseAnd)
+}
+
+func (p *parser) parseAnd() (node, error) {
+	return p.parseLogical("&&", p.parseRelation)
+}
+
+// parseLogical parses a chain of operands of a short-circuit operator
+func (p *parser) parseLogical(op string, operand func() (node, error)) (node, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok, ok := p.accept(op)
+		if !ok {
+			return left, nil
+		}
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		if left.typ() != Bool || right.typ() != Bool {
+			return nil, errorAt(tok.pos, "%s needs bool operands, found %s and %s", op, left.typ(), right.typ())
+		}
+		if left, err = p.node(tok.pos, &logical{op: op, left: left, right: right}); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *parser) parseRelation() (node, error) {
+	left, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+
+	tok := p.peek()
+	op := relation(tok)
+	if op == "" {
+		return left, nil
+	}
+	p.next()
+
+	right, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	switch op {
+	case "in":
+		if !right.typ().isList() || right.typ().elem() != left.typ() {
+			return nil, errorAt(tok.pos, "in needs a value and a list of values of its type, found %s and %s", left.typ(), right.typ())
+		}
+	case "==", "!=":
+		if left.typ() != right.typ() || left.typ().isList() {
+			return nil, errorAt(tok.pos, "%s cannot compare %s and %s", op, left.typ(), right.typ())
+		}
+	default:
+		if left.typ() != right.typ() || (left.typ() != Int && left.typ() != String) {
+			return nil, errorAt(tok.pos, "%s cannot order %s and %s", op, left.typ(), right.typ())
+		}
+	}
+	if next := p.peek(); relation(next) != "" {
+		return nil, errorAt(next.pos, "comparisons cannot be chained, use && or parentheses")
+	}
+	return p.node(tok.pos, &binary{op: op, resultType: Bool, left: left, right: right, pos: tok.pos})
+}
+
+// relation returns the comparison operator of a token, "" for other tokens
+func relation(tok token) string {
+	switch {
+	case tok.kind == tokenPunct && contains([]string{"==", "!=", "<", "<=", ">", ">="}, tok.text):
+		return tok.text
+	case tok.kind == tokenIdent && tok.text == "in":
+		return "in"
+	default:
+		return ""
+	}
+}
+
+func (p *parser) parseSum() (node, error) {
+	return p.parseArithmetic([]string{"+", "-"}, p.parseProduct)
+}
+
+func (p *parser) parseProduct() (node, error) {
+	return p.parseArithmetic([]string{"*", "/", "%"}, p.parseUnary)
+}
+
+// parseArithmetic parses a chain of operands of left-associative arithmetic operators
+func (p *parser) parseArithmetic(ops []string, operand func() (node, error)) (node, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok := p.peek()
+		if tok.kind != tokenPunct || !contains(ops, tok.text) {
+			return left, nil
+		}
+		p.next()
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+
+		concat := tok.text == "+" && left.typ() == String && right.typ() == String
+		if !concat && (left.typ() != Int || right.typ() != Int) {
+			return nil, errorAt(tok.pos, "%s cannot apply to %s and %s", tok.text, left.typ(), right.typ())
+		}
+		if left, err = p.node(tok.pos, &binary{op: tok.text, resultType: left.typ(), left: left, right: right, pos: tok.pos}); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *parser) parseUnary() (node, error) {
+	tok := p.peek()
+	if tok.kind != tokenPunct || (tok.text != "!" && tok.text != "-") {
+		return p.parseMember()
+	}
+	p.next()
+	if err := p.enter(tok.pos); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+
+	operand, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	if tok.text == "!" && operand.typ() != Bool {
+		return nil, errorAt(tok.pos, "! needs a bool operand, found %s", operand.typ())
+	}
+	if tok.text == "-" && operand.typ() != Int {
+		return nil, errorAt(tok.pos, "- needs an int operand, found %s", operand.typ())
+	}
+	return p.node(tok.pos, &unary{op: tok.text, operand: operand, pos: tok.pos})
+}
+
+// parseMember parses a primary expression followed by method calls (receiver.function(arguments))
+func (p *parser) parseMember() (node, error) {
+	receiver, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.accept("."); !ok {
+			return receiver, nil
+		}
+		name := p.next()
+		if name.kind != tokenIdent {
+			return nil, errorAt(name.pos, "expected a function name, found %s", name)
+		}
+		if tok, ok := p.accept("("); !ok {
+			return nil, errorAt(tok.pos, "expected a call of %s, found %s", name.text, tok)
+		}
+		args, err := p.parseArguments(")")
+		if err != nil {
+			return nil, err
+		}
+		if receiver, err = p.call(name, append([]node{receiver}, args...)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokenInt:
+		return p.node(tok.pos, &literal{resultType: Int, value: tok.value})
+	case tokenString:
+		return p.node(tok.pos, &literal{resultType: String, value: tok.value})
+	case tokenIdent:
+		return p.parseIdentifier(tok)
+	case tokenPunct:
+		switch tok.text {
+		case "(":
+			inner, err := p.parseExpression()
+			if err != nil {
+				return nil, err
+			}
+			return inner, p.expect(")")
+		case "[":
+			return p.parseList(tok)
+		}
+	}
+	return nil, errorAt(tok.pos, "unexpected %s", tok)
+}
+
+// parseIdentifier parses a keyword, a field path or a function call starting with an identifier
+func (p *parser) parseIdentifier(first token) (node, error) {
+	switch first.text {
+	case "true", "false":
+		return p.node(first.pos, &literal{resultType: Bool, value: first.text == "true"})
+	case "in":
+		return nil, errorAt(first.pos, "unexpected %s", first)
+	}
+
+	if _, ok := p.accept("("); ok {
+		args, err := p.parseArguments(")")
+		if err != nil {
+			return nil, err
+		}
+		return p.call(first, args)
+	}
+
+	// Extend the field path while its segments name fields or their parents, leaving method calls to parseMember
+	path := first.text
+	for {
+		dot, name := p.peek(), p.tokens[min(p.pos+1, len(p.tokens)-1)]
+		if dot.kind != tokenPunct || dot.text != "." || name.kind != tokenIdent || !p.isPathPrefix(path+"."+name.text) {
+			break
+		}
+		p.pos += 2
+		path += "." + name.text
+	}
+
+	typ, ok := p.env.fields[path]
+	if !ok {
+		for p.pos+2 < len(p.tokens) && p.tokens[p.pos].text == "." && p.tokens[p.pos+1].kind == tokenIdent && p.tokens[p.pos+2].text != "(" {
+			path += "." + p.tokens[p.pos+1].text
+			p.pos += 2
+		}
+		return nil, errorAt(first.pos, "unknown field %s (fields: %s)", path, strings.Join(p.env.Fields(), ", "))
+	}
+	return p.node(first.pos, &field{name: path, resultType: typ})
+}
+
+// isPathPrefix reports whether path names an exposed field or a parent of exposed fields
+func (p *parser) isPathPrefix(path string) bool {
+	for name := range p.env.fields {
+		if name == path || strings.HasPrefix(name, path+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// parseList parses a list literal, whose elements have the same type
+func (p *parser) parseList(open token) (node, error) {
+	if err := p.enter(open.pos); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+
+	elems, err := p.parseArguments("]")
+	if err != nil {
+		return nil, err
+	}
+	if len(elems) == 0 {
+		return nil, errorAt(open.pos, "empty lists are not supported")
+	}
+	typ := listOf(elems[0].typ())
+	if typ == 0 {
+		return nil, errorAt(open.pos, "lists hold strings or ints, not %s", elems[0].typ())
+	}
+	for _, elem := range elems[1:] {
+		if elem.typ() != elems[0].typ() {
+			return nil, errorAt(open.pos, "list elements have different types (%s and %s)", elems[0].typ(), elem.typ())
+		}
+	}
+	return p.node(open.pos, &list{resultType: typ, elems: elems})
+}
+
+// parseArguments parses a comma-separated list of expressions up to the closing delimiter
+func (p *parser) parseArguments(closing string) ([]node, error) {
+	var args []node
+	if _, ok := p.accept(closing); ok {
+		return args, nil
+	}
+	for {
+		arg, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+		if _, ok := p.accept(closing); ok {
+			return args, nil
+		}
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// call type checks a function call
+func (p *parser) call(name token, args []node) (node, error) {
+	fn, ok := functions[name.text]
+	if !ok {
+		return nil, errorAt(name.pos, "unknown function %s", name.text)
+	}
+	types := make([]Type, len(args))
+	for i, arg := range args {
+		types[i] = arg.typ()
+	}
+	resultType, ok := fn.check(types)
+	if !ok {
+		return nil, errorAt(name.pos, "%s cannot apply to (%s), usage: %s", name.text, joinTypes(types), fn.usage)
+	}
+	return p.node(name.pos, &call{name: name.text, fn: fn.call, resultType: resultType, args: args, pos: name.pos})
+}
+
+// joinTypes lists types in error messages
+func joinTypes(types []Type) string {
+	names := make([]string, len(types))
+	for i, typ := range types {
+		names[i] = typ.String()
+	}
+	return strings.Join(names, ", ")
+}
+
+// contains reports whether values holds value
+func contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
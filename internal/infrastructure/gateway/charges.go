@@ -0,0 +1,121 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/tracing"
+)
+
+// chargeSucceeded is the gateway status of a captured charge
+const chargeSucceeded = "succeeded"
+
+// ChargeClient charges and refunds cards through the payment gateway
+// Charges are created with POST {baseURL}/charges and refunds with POST {baseURL}/refunds; the reference is sent
+// as the Idempotency-Key header, so a retried request is never charged or refunded twice
+type ChargeClient struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+}
+
+// chargeRequest is the body of a charge request
+type chargeRequest struct {
+	Amount        int64             `json:"amount"` // Minor units
+	Currency      string            `json:"currency"`
+	PaymentMethod string            `json:"payment_method"`
+	Metadata      map[string]string `json:"metadata,omitempty"`
+}
+
+// refundRequest is the body of a refund request
+type refundRequest struct {
+	Charge string `json:"charge"`
+}
+
+// chargeResult is the gateway response to a charge or refund request
+type chargeResult struct {
+	ID            string `json:"id"`
+	Status        string `json:"status"`
+	FailureReason string `json:"failure_reason"`
+}
+
+// NewChargeClient creates a client for the gateway charges API at baseURL authenticated with apiKey
+func NewChargeClient(baseURL, apiKey string, httpClient *http.Client) *ChargeClient {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: defaultTimeout}
+	}
+
+	return &ChargeClient{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		apiKey:     apiKey,
+		httpClient: httpClient,
+	}
+}
+
+// Charge charges a card and returns the gateway charge ID
+func (c *ChargeClient) Charge(ctx context.Context, charge service.CardCharge) (string, error) {
+	result, err := c.post(ctx, "/charges", charge.Reference, chargeRequest{
+		Amount:        charge.Amount,
+		Currency:      charge.Currency,
+		PaymentMethod: charge.PaymentMethod,
+		Metadata:      map[string]string{"invoice_id": charge.InvoiceID, "client_id": charge.ClientID},
+	})
+	if err != nil {
+		return "", err
+	}
+	if result.ID == "" {
+		return "", fmt.Errorf("payment gateway returned a charge without ID")
+	}
+	return result.ID, nil
+}
+
+// Refund refunds a charge in full
+func (c *ChargeClient) Refund(ctx context.Context, chargeID, reference string) error {
+	_, err := c.post(ctx, "/refunds", reference, refundRequest{Charge: chargeID})
+	return err
+}
+
+// post sends a charges API request and fails unless the gateway reports it succeeded
+func (c *ChargeClient) post(ctx context.Context, path, idempotencyKey string, body interface{}) (*chargeResult, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build gateway request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Idempotency-Key", idempotencyKey)
+	tracing.InjectHTTP(ctx, req.Header)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("gateway request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("payment gateway returned status %d", resp.StatusCode)
+	}
+
+	var result chargeResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode gateway response: %w", err)
+	}
+	if result.Status != chargeSucceeded {
+		if result.FailureReason != "" {
+			return nil, fmt.Errorf("payment gateway declined %s: %s", strings.TrimPrefix(path, "/"), result.FailureReason)
+		}
+		return nil, fmt.Errorf("payment gateway returned %s status %q", strings.TrimPrefix(path, "/"), result.Status)
+	}
+	return &result, nil
+}
@@ -0,0 +1,157 @@
+// Package gateway provides adapters for the payment gateway: card charges and the reporting API
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/tracing"
+)
+
+// defaultTimeout bounds a single gateway request
+const defaultTimeout = 30 * time.Second
+
+// maxPayoutPages bounds the pages followed by a single listing so a misbehaving cursor cannot loop forever
+const maxPayoutPages = 100
+
+// PayoutClient pulls payout reports from the payment gateway
+// Reports are read from GET {baseURL}/payouts?arrived_after=YYYY-MM-DD, following next_cursor while has_more is set
+type PayoutClient struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+}
+
+// payoutPage is a page of the gateway payout report
+type payoutPage struct {
+	Data       []payoutReport `json:"data"`
+	HasMore    bool           `json:"has_more"`
+	NextCursor string         `json:"next_cursor"`
+}
+
+// payoutReport is a payout with the balance transactions it settled
+type payoutReport struct {
+	ID          string `json:"id"`
+	ArrivalDate string `json:"arrival_date"` // YYYY-MM-DD
+	Amount      int64  `json:"amount"`       // Net, minor units
+	Currency    string `json:"currency"`
+	Items       []struct {
+		Reference string `json:"reference"`
+		Type      string `json:"type"`
+		Amount    int64  `json:"amount"` // Gross, minor units
+		Fee       int64  `json:"fee"`
+	} `json:"items"`
+}
+
+// NewPayoutClient creates a client for the gateway reporting API at baseURL authenticated with apiKey
+func NewPayoutClient(baseURL, apiKey string, httpClient *http.Client) *PayoutClient {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: defaultTimeout}
+	}
+
+	return &PayoutClient{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		apiKey:     apiKey,
+		httpClient: httpClient,
+	}
+}
+
+// ListPayouts retrieves the payouts that arrived on or after since, with their items
+func (c *PayoutClient) ListPayouts(ctx context.Context, since time.Time) ([]service.GatewayPayout, error) {
+	payouts := make([]service.GatewayPayout, 0)
+	cursor := ""
+
+	for page := 0; page < maxPayoutPages; page++ {
+		result, err := c.fetchPage(ctx, since, cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, report := range result.Data {
+			payout, err := toGatewayPayout(report)
+			if err != nil {
+				return nil, fmt.Errorf("invalid payout %s: %w", report.ID, err)
+			}
+			payouts = append(payouts, payout)
+		}
+
+		if !result.HasMore || result.NextCursor == "" {
+			return payouts, nil
+		}
+		cursor = result.NextCursor
+	}
+
+	return nil, fmt.Errorf("payout report exceeded %d pages", maxPayoutPages)
+}
+
+// fetchPage requests a page of the payout report
+func (c *PayoutClient) fetchPage(ctx context.Context, since time.Time, cursor string) (*payoutPage, error) {
+	query := url.Values{}
+	query.Set("arrived_after", since.UTC().Format("2006-01-02"))
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/payouts?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build payout report request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	req.Header.Set("Accept", "application/json")
+	tracing.InjectHTTP(ctx, req.Header)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("payout report request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("payment gateway returned status %d", resp.StatusCode)
+	}
+
+	var page payoutPage
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, fmt.Errorf("failed to decode payout report: %w", err)
+	}
+	return &page, nil
+}
+
+// toGatewayPayout converts a reported payout to the domain representation
+func toGatewayPayout(report payoutReport) (service.GatewayPayout, error) {
+	arrivalDate, err := time.Parse("2006-01-02", report.ArrivalDate)
+	if err != nil {
+		return service.GatewayPayout{}, fmt.Errorf("invalid arrival date %q", report.ArrivalDate)
+	}
+
+	amount, err := valueobject.NewMoney(report.Amount, report.Currency)
+	if err != nil {
+		return service.GatewayPayout{}, err
+	}
+
+	payout := service.GatewayPayout{
+		ID:          report.ID,
+		ArrivalDate: arrivalDate,
+		Amount:      amount,
+		Items:       make([]service.PayoutItem, len(report.Items)),
+	}
+	for i, item := range report.Items {
+		// Items are in the payout currency, which was validated above
+		gross, _ := valueobject.NewMoney(item.Amount, amount.Currency())
+		fee, _ := valueobject.NewMoney(item.Fee, amount.Currency())
+		payout.Items[i] = service.PayoutItem{
+			Reference: item.Reference,
+			Type:      item.Type,
+			Amount:    gross,
+			Fee:       fee,
+		}
+	}
+	return payout, nil
+}
@@ -0,0 +1,191 @@
+// Package httpclient provides the HTTP clients outbound adapters (payment gateway, credit bureau, captcha provider,
+// webhook consumers)
+// call their destination with: shared timeouts, retries of idempotent requests, connection pooling,
+// per-destination rate limits, a circuit breaker, call statistics and optional recording of every call
+package httpclient
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Destinations of the outbound adapters
+const (
+	DestinationPaymentGateway = "payment_gateway"
+	DestinationRiskBureau     = "risk_bureau"
+	DestinationCaptcha        = "captcha"
+	DestinationWebhooks       = "webhooks" // Consumer endpoints of webhook subscriptions
+)
+
+// Config configures the client of a destination
+// Zero values of a destination config inherit the registry defaults (see NewRegistry)
+type Config struct {
+	// Timeout bounds a call including its retries
+	Timeout time.Duration
+
+	// MaxAttempts bounds the attempts of idempotent requests (GET, HEAD, OPTIONS, PUT, DELETE,
+	// or any request with an Idempotency-Key header) failing with a network error, 429 or 5xx
+	MaxAttempts int
+
+	// RetryBackoff is the wait before the first retry, doubled on every further retry
+	RetryBackoff time.Duration
+
+	// RateLimit bounds the requests per second sent to the destination (0: unlimited); calls wait for their turn
+	RateLimit float64
+
+	// Burst is the number of requests sent at once before the rate limit applies
+	Burst int
+
+	// BreakerThreshold is the number of consecutive failed calls opening the circuit breaker (0: no breaker)
+	BreakerThreshold int
+
+	// BreakerCooldown is how long an open circuit rejects calls before a single trial call is let through
+	BreakerCooldown time.Duration
+
+	// MaxIdleConnsPerHost bounds the pooled idle connections to the destination
+	MaxIdleConnsPerHost int
+}
+
+// DefaultConfig returns the defaults of destinations without configuration
+func DefaultConfig() Config {
+	return Config{
+		Timeout:             10 * time.Second,
+		MaxAttempts:         3,
+		RetryBackoff:        200 * time.Millisecond,
+		Burst:               10,
+		BreakerThreshold:    5,
+		BreakerCooldown:     30 * time.Second,
+		MaxIdleConnsPerHost: 10,
+	}
+}
+
+// inherit returns c with its zero values taken from defaults
+func (c Config) inherit(defaults Config) Config {
+	if c.Timeout == 0 {
+		c.Timeout = defaults.Timeout
+	}
+	if c.MaxAttempts == 0 {
+		c.MaxAttempts = defaults.MaxAttempts
+	}
+	if c.RetryBackoff == 0 {
+		c.RetryBackoff = defaults.RetryBackoff
+	}
+	if c.RateLimit == 0 {
+		c.RateLimit = defaults.RateLimit
+	}
+	if c.Burst == 0 {
+		c.Burst = defaults.Burst
+	}
+	if c.BreakerThreshold == 0 {
+		c.BreakerThreshold = defaults.BreakerThreshold
+	}
+	if c.BreakerCooldown == 0 {
+		c.BreakerCooldown = defaults.BreakerCooldown
+	}
+	if c.MaxIdleConnsPerHost == 0 {
+		c.MaxIdleConnsPerHost = defaults.MaxIdleConnsPerHost
+	}
+	return c
+}
+
+// Stats are the call statistics of a destination
+type Stats struct {
+	Destination    string
+	Requests       int64         // Attempts sent, retries included
+	Failures       int64         // Attempts failing with a network error, 429 or 5xx
+	Retries        int64         // Attempts that were retries
+	Rejected       int64         // Calls rejected by the open circuit breaker
+	Circuit        string        // closed, open, half_open
+	AverageLatency time.Duration // Average attempt latency
+}
+
+// Registry hands out one client per destination; clients of a destination share their connection pool,
+// rate limit, circuit breaker and statistics
+type Registry struct {
+	defaults     Config
+	destinations map[string]Config
+	transports   map[string]*transport
+	clients      map[string]*http.Client
+	recorder     *recorder
+	mu           sync.Mutex
+}
+
+// NewRegistry creates a client registry; destinations override the defaults per destination
+// Zero defaults fall back to DefaultConfig
+func NewRegistry(defaults Config, destinations map[string]Config) *Registry {
+	defaults = defaults.inherit(DefaultConfig())
+	configs := make(map[string]Config, len(destinations))
+	for destination, config := range destinations {
+		configs[destination] = config.inherit(defaults)
+	}
+
+	return &Registry{
+		defaults:     defaults,
+		destinations: configs,
+		transports:   make(map[string]*transport),
+		clients:      make(map[string]*http.Client),
+	}
+}
+
+// WithRecorder reports every attempt of the clients handed out afterwards to record, with their bodies truncated
+// to maxBodyBytes (DefaultMaxRecordedBodyBytes when zero)
+func (r *Registry) WithRecorder(record Recorder, maxBodyBytes int) *Registry {
+	if maxBodyBytes <= 0 {
+		maxBodyBytes = DefaultMaxRecordedBodyBytes
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.recorder = &recorder{record: record, maxBodyBytes: maxBodyBytes}
+	return r
+}
+
+// Client returns the client of a destination, creating it if necessary
+// A destination "<destination>:<endpoint>" has its own client (pool, rate limit, circuit breaker, statistics)
+// configured like <destination>, so a failing endpoint does not open the circuit of the others
+func (r *Registry) Client(destination string) *http.Client {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if client, ok := r.clients[destination]; ok {
+		return client
+	}
+
+	config, ok := r.destinations[destination]
+	if !ok {
+		group, _, _ := strings.Cut(destination, ":")
+		if config, ok = r.destinations[group]; !ok {
+			config = r.defaults
+		}
+	}
+	base := http.DefaultTransport.(*http.Transport).Clone()
+	base.MaxIdleConnsPerHost = config.MaxIdleConnsPerHost
+
+	transport := newTransport(destination, config, base, r.recorder)
+	client := &http.Client{
+		Timeout:   config.Timeout,
+		Transport: transport,
+	}
+	r.transports[destination] = transport
+	r.clients[destination] = client
+	return client
+}
+
+// Stats returns the call statistics of the destinations called so far, by destination
+func (r *Registry) Stats() []Stats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats := make([]Stats, 0, len(r.transports))
+	for _, transport := range r.transports {
+		stats = append(stats, transport.stats())
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Destination < stats[j].Destination
+	})
+	return stats
+}
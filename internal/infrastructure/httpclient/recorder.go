@@ -0,0 +1,84 @@
+package httpclient
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/tracing"
+)
+
+// DefaultMaxRecordedBodyBytes bounds the request and response bodies reported to the recorder
+const DefaultMaxRecordedBodyBytes = 4096
+
+// Call describes an attempt sent to a destination; bodies are truncated and not redacted
+type Call struct {
+	Destination         string
+	Method              string
+	URL                 string
+	StatusCode          int // 0 when no response was received
+	Duration            time.Duration
+	RequestBody         string
+	RequestContentType  string
+	ResponseBody        string
+	ResponseContentType string
+	Error               string
+	RequestID           string // Request ID of the API request that made the call, if any
+}
+
+// Recorder receives every attempt sent by the clients of a registry, synchronously
+type Recorder func(call Call)
+
+// recorder captures the bodies of the attempts of a destination for the registry recorder
+type recorder struct {
+	record       Recorder
+	maxBodyBytes int
+}
+
+// requestBody returns the beginning of the body of a request that can be read again (GetBody)
+func (r *recorder) requestBody(req *http.Request) string {
+	if req.GetBody == nil {
+		return ""
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+
+	captured, _ := io.ReadAll(io.LimitReader(body, int64(r.maxBodyBytes)))
+	return string(captured)
+}
+
+// responseBody returns the beginning of the body of a response, leaving the body readable from the start
+func (r *recorder) responseBody(resp *http.Response) string {
+	captured, _ := io.ReadAll(io.LimitReader(resp.Body, int64(r.maxBodyBytes)))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(captured), resp.Body), resp.Body}
+	return string(captured)
+}
+
+// report sends an attempt and its outcome to the registry recorder
+func (r *recorder) report(destination string, req *http.Request, resp *http.Response, err error, duration time.Duration) {
+	call := Call{
+		Destination:        destination,
+		Method:             req.Method,
+		URL:                req.URL.String(),
+		Duration:           duration,
+		RequestBody:        r.requestBody(req),
+		RequestContentType: req.Header.Get("Content-Type"),
+		RequestID:          tracing.RequestIDFromContext(req.Context()),
+	}
+	if err != nil {
+		call.Error = err.Error()
+	}
+	if resp != nil {
+		call.StatusCode = resp.StatusCode
+		call.ResponseBody = r.responseBody(resp)
+		call.ResponseContentType = resp.Header.Get("Content-Type")
+	}
+	r.record(call)
+}
@@ -0,0 +1,158 @@
+package httpclient
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Circuit breaker states
+const (
+	circuitClosed   = "closed"
+	circuitOpen     = "open"
+	circuitHalfOpen = "half_open"
+)
+
+// limiter is a token bucket bounding the request rate of a destination
+type limiter struct {
+	rate   float64 // Tokens per second; 0 disables the limiter
+	burst  float64
+	tokens float64
+	last   time.Time
+	mu     sync.Mutex
+}
+
+// newLimiter creates a full token bucket
+func newLimiter(rate float64, burst int) *limiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &limiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// wait takes a token, waiting for one to be available or until ctx is done
+func (l *limiter) wait(ctx context.Context) error {
+	if l.rate <= 0 {
+		return nil
+	}
+
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+		l.last = now
+
+		if l.tokens >= 1 {
+			l.tokens--
+			l.mu.Unlock()
+			return nil
+		}
+		wait := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+		l.mu.Unlock()
+
+		if err := sleep(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// breaker opens after consecutive failed calls and lets a single trial call through once the cooldown is over
+type breaker struct {
+	threshold int // 0 disables the breaker
+	cooldown  time.Duration
+	current   string
+	failures  int
+	openedAt  time.Time
+	probing   bool
+	mu        sync.Mutex
+}
+
+// newBreaker creates a closed circuit breaker
+func newBreaker(threshold int, cooldown time.Duration) *breaker {
+	return &breaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		current:   circuitClosed,
+	}
+}
+
+// allow reports whether a call may be sent
+func (b *breaker) allow() bool {
+	if b.threshold <= 0 {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.current {
+	case circuitOpen:
+		if time.Since(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.current = circuitHalfOpen
+		b.probing = true
+		return true
+	case circuitHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	}
+	return true
+}
+
+// success records a successful call, closing the circuit
+func (b *breaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.current = circuitClosed
+	b.failures = 0
+	b.probing = false
+}
+
+// failure records a failed call; a failed trial call or too many consecutive failures open the circuit
+func (b *breaker) failure() {
+	if b.threshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.probing = false
+	if b.current == circuitHalfOpen || b.failures >= b.threshold {
+		b.current = circuitOpen
+		b.openedAt = time.Now()
+	}
+}
+
+// abort records a call that ended without telling whether the destination is healthy (e.g. cancelled)
+func (b *breaker) abort() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+// state returns the state of the circuit
+func (b *breaker) state() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.current == circuitOpen && time.Since(b.openedAt) >= b.cooldown {
+		return circuitHalfOpen
+	}
+	return b.current
+}
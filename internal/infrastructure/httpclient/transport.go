@@ -0,0 +1,185 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned for calls to a destination whose circuit breaker is open
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// maxDrainedBytes bounds the body read from a failed attempt so its connection can be reused
+const maxDrainedBytes = 4096
+
+// transport throttles, retries and guards the calls of a destination
+type transport struct {
+	destination string
+	config      Config
+	next        http.RoundTripper
+	limiter     *limiter
+	breaker     *breaker
+	recorder    *recorder // nil when calls are not recorded
+
+	mu       sync.Mutex
+	requests int64
+	failures int64
+	retries  int64
+	rejected int64
+	latency  time.Duration
+}
+
+// newTransport creates the transport of a destination sending requests through next
+func newTransport(destination string, config Config, next http.RoundTripper, recorder *recorder) *transport {
+	return &transport{
+		destination: destination,
+		config:      config,
+		next:        next,
+		limiter:     newLimiter(config.RateLimit, config.Burst),
+		breaker:     newBreaker(config.BreakerThreshold, config.BreakerCooldown),
+		recorder:    recorder,
+	}
+}
+
+// RoundTrip sends a request, waiting for the rate limit and retrying idempotent requests on transient failures
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.breaker.allow() {
+		t.mu.Lock()
+		t.rejected++
+		t.mu.Unlock()
+		return nil, fmt.Errorf("%w: %s", ErrCircuitOpen, t.destination)
+	}
+
+	maxAttempts := 1
+	if isRetriable(req) {
+		maxAttempts = t.config.MaxAttempts
+	}
+
+	for attempt := 1; ; attempt++ {
+		if err := t.limiter.wait(req.Context()); err != nil {
+			t.breaker.abort()
+			return nil, err
+		}
+
+		attemptReq := req
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				t.breaker.abort()
+				return nil, err
+			}
+			attemptReq = req.Clone(req.Context())
+			attemptReq.Body = body
+		}
+
+		start := time.Now()
+		resp, err := t.next.RoundTrip(attemptReq)
+		failed := err != nil || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+		t.record(time.Since(start), failed, attempt > 1)
+		if t.recorder != nil {
+			t.recorder.report(t.destination, attemptReq, resp, err, time.Since(start))
+		}
+
+		if !failed {
+			t.breaker.success()
+			return resp, nil
+		}
+		if attempt >= maxAttempts || req.Context().Err() != nil {
+			// Throttling says nothing about the health of the destination
+			if err != nil || resp.StatusCode != http.StatusTooManyRequests {
+				t.breaker.failure()
+			} else {
+				t.breaker.abort()
+			}
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+		if resp != nil {
+			io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainedBytes))
+			resp.Body.Close()
+		}
+		if err := sleep(req.Context(), wait); err != nil {
+			t.breaker.abort()
+			return nil, err
+		}
+	}
+}
+
+// backoff returns the wait before the next attempt: the Retry-After of the response when it fits in the timeout,
+// otherwise the retry backoff doubled on every retry
+func (t *transport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			if wait := time.Duration(seconds) * time.Second; wait < t.config.Timeout {
+				return wait
+			}
+		}
+	}
+	return t.config.RetryBackoff << (attempt - 1)
+}
+
+// record adds an attempt to the statistics
+func (t *transport) record(latency time.Duration, failed, retry bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.requests++
+	t.latency += latency
+	if failed {
+		t.failures++
+	}
+	if retry {
+		t.retries++
+	}
+}
+
+// stats returns the statistics of the destination
+func (t *transport) stats() Stats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stats := Stats{
+		Destination: t.destination,
+		Requests:    t.requests,
+		Failures:    t.failures,
+		Retries:     t.retries,
+		Rejected:    t.rejected,
+		Circuit:     t.breaker.state(),
+	}
+	if t.requests > 0 {
+		stats.AverageLatency = t.latency / time.Duration(t.requests)
+	}
+	return stats
+}
+
+// isRetriable reports whether a request can be sent again without side effects
+func isRetriable(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.Header.Get("Idempotency-Key") != ""
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,119 @@
+// Package listener opens the socket the API is served on: a TCP address, a Unix domain socket for deployments
+// fronted by a local proxy, or a socket inherited from systemd socket activation
+package listener
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// DefaultSocketMode lets the owner and group of a Unix socket connect (the proxy usually runs in the group)
+const DefaultSocketMode os.FileMode = 0o660
+
+// listenFDsStart is the first file descriptor passed by systemd (after stdin, stdout and stderr)
+const listenFDsStart = 3
+
+// Config selects the socket the API listens on
+// At most one of UnixSocket and SocketActivation is set; without either the API listens on Address
+type Config struct {
+	// Address is the TCP host:port listened on
+	Address string
+
+	// UnixSocket is the path of the Unix domain socket listened on, replacing a stale socket left at the path
+	UnixSocket string
+
+	// SocketMode is the permission of the Unix socket (0: DefaultSocketMode)
+	SocketMode os.FileMode
+
+	// SocketActivation inherits the listener systemd opened for the service (LISTEN_PID, LISTEN_FDS)
+	SocketActivation bool
+}
+
+// Listen opens the listener selected by cfg
+func Listen(cfg Config) (net.Listener, error) {
+	switch {
+	case cfg.SocketActivation && cfg.UnixSocket != "":
+		return nil, errors.New("a Unix socket cannot be configured with socket activation")
+	case cfg.SocketActivation:
+		return Activated()
+	case cfg.UnixSocket != "":
+		return listenUnix(cfg.UnixSocket, cfg.SocketMode)
+	default:
+		return net.Listen("tcp", cfg.Address)
+	}
+}
+
+// listenUnix listens on a Unix socket at path; the socket file is removed when the listener is closed
+func listenUnix(path string, mode os.FileMode) (net.Listener, error) {
+	// A socket left by a process that did not shut down cleanly would fail the bind, other files are kept
+	if info, err := os.Lstat(path); err == nil {
+		if info.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("unix socket path %s exists and is not a socket", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("failed to remove stale unix socket %s: %w", path, err)
+		}
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if mode == 0 {
+		mode = DefaultSocketMode
+	}
+	if err := os.Chmod(path, mode); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("failed to set unix socket permissions: %w", err)
+	}
+	return listener, nil
+}
+
+// Activated returns the listener systemd passed to the process through socket activation
+// The activation variables are cleared so processes started by the API do not inherit them
+func Activated() (net.Listener, error) {
+	defer func() {
+		os.Unsetenv("LISTEN_PID")
+		os.Unsetenv("LISTEN_FDS")
+		os.Unsetenv("LISTEN_FDNAMES")
+	}()
+
+	pid, err := strconv.Atoi(os.Getenv("LISTEN_PID"))
+	if err != nil || pid != os.Getpid() {
+		return nil, errors.New("no socket was passed by systemd (LISTEN_PID is not set for this process)")
+	}
+	count, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || count < 1 {
+		return nil, errors.New("no socket was passed by systemd (LISTEN_FDS is not set)")
+	}
+	if count > 1 {
+		return nil, fmt.Errorf("systemd passed %d sockets, the API listens on one", count)
+	}
+
+	name := "LISTEN_FD_" + strconv.Itoa(listenFDsStart)
+	if names := strings.Split(os.Getenv("LISTEN_FDNAMES"), ":"); names[0] != "" {
+		name = names[0]
+	}
+	// The listener works on a duplicate of the descriptor, closing the original keeps it from child processes
+	file := os.NewFile(uintptr(listenFDsStart), name)
+	defer file.Close()
+
+	listener, err := net.FileListener(file)
+	if err != nil {
+		return nil, fmt.Errorf("socket passed by systemd is not a listener: %w", err)
+	}
+	return listener, nil
+}
+
+// Describe returns the address a listener accepts connections on, for logs
+func Describe(listener net.Listener) string {
+	address := listener.Addr()
+	if address.Network() == "unix" {
+		return "unix:" + address.String()
+	}
+	return address.String()
+}
@@ -0,0 +1,138 @@
+// Package messaging provides the message bus abstraction used to publish integration events
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Message is an event published on the message bus
+type Message struct {
+	// Topic is the destination of the message (e.g. "billing.cdc.clients")
+	Topic string `json:"topic"`
+
+	// Key orders and partitions messages of the same entity
+	Key string `json:"key"`
+
+	// Payload is the JSON-encoded event body
+	Payload json.RawMessage `json:"payload"`
+
+	// Headers carries optional metadata (content type, source, ...)
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// Publisher publishes messages on the message bus
+type Publisher interface {
+	Publish(ctx context.Context, messages ...Message) error
+}
+
+// WriterPublisher writes messages as JSON lines to a writer
+// Useful as a sidecar-friendly transport (stdout shipped by the log pipeline) and for local development
+type WriterPublisher struct {
+	writer io.Writer
+	mutex  sync.Mutex
+}
+
+// NewWriterPublisher creates a publisher writing JSON lines to writer
+func NewWriterPublisher(writer io.Writer) *WriterPublisher {
+	return &WriterPublisher{
+		writer: writer,
+	}
+}
+
+// Publish writes each message on its own line
+func (p *WriterPublisher) Publish(ctx context.Context, messages ...Message) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	encoder := json.NewEncoder(p.writer)
+	for _, message := range messages {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := encoder.Encode(struct {
+			Message
+			PublishedAt time.Time `json:"published_at"`
+		}{message, time.Now().UTC()}); err != nil {
+			return fmt.Errorf("failed to write message to %s: %w", message.Topic, err)
+		}
+	}
+	return nil
+}
+
+// MemoryPublisher keeps published messages in memory (tests and embedded consumers)
+type MemoryPublisher struct {
+	messages []Message
+	mutex    sync.Mutex
+}
+
+// NewMemoryPublisher creates an empty in-memory publisher
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{}
+}
+
+// Publish appends messages
+func (p *MemoryPublisher) Publish(ctx context.Context, messages ...Message) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.messages = append(p.messages, messages...)
+	return nil
+}
+
+// Messages returns a copy of the published messages
+func (p *MemoryPublisher) Messages() []Message {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return append([]Message(nil), p.messages...)
+}
+
+// Sandbox message routing
+const (
+	// SandboxTopicPrefix keeps sandbox events off the topics production consumers subscribe to
+	SandboxTopicPrefix = "sandbox."
+
+	// SandboxHeader marks messages published by the sandbox environment
+	SandboxHeader = "sandbox"
+
+	// EmailCatchAllHeader tells mailers to deliver every email of the message to this address instead of its recipients
+	EmailCatchAllHeader = "email_catch_all"
+)
+
+// SandboxPublisher publishes sandbox events on sandbox topics, redirecting their emails to a catch-all address
+type SandboxPublisher struct {
+	next     Publisher
+	catchAll string
+}
+
+// NewSandboxPublisher wraps next so every message is marked as sandbox traffic
+// With an empty catchAll address, mailers are expected to drop sandbox emails
+func NewSandboxPublisher(next Publisher, catchAll string) *SandboxPublisher {
+	return &SandboxPublisher{
+		next:     next,
+		catchAll: catchAll,
+	}
+}
+
+// Publish rewrites the topic and headers of each message before passing it on
+func (p *SandboxPublisher) Publish(ctx context.Context, messages ...Message) error {
+	rewritten := make([]Message, len(messages))
+	for i, message := range messages {
+		headers := make(map[string]string, len(message.Headers)+2)
+		for key, value := range message.Headers {
+			headers[key] = value
+		}
+		headers[SandboxHeader] = "true"
+		headers[EmailCatchAllHeader] = p.catchAll
+
+		message.Topic = SandboxTopicPrefix + message.Topic
+		message.Headers = headers
+		rewritten[i] = message
+	}
+	return p.next.Publish(ctx, rewritten...)
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
+)
+
+// ApprovalRequestCollection is the storage collection holding credit note and refund approval requests
+const ApprovalRequestCollection = "approval_request_records"
+
+// ApprovalRequestRepositoryImpl implements the ApprovalRequestRepository interface using a storage backend
+type ApprovalRequestRepositoryImpl struct {
+	storage storage.Storage
+}
+
+// NewApprovalRequestRepository creates a new approval request repository with the given storage backend
+func NewApprovalRequestRepository(storage storage.Storage) repository.ApprovalRequestRepository {
+	return &ApprovalRequestRepositoryImpl{
+		storage: storage,
+	}
+}
+
+// Save persists an approval request keyed by its ID
+func (r *ApprovalRequestRepositoryImpl) Save(request *entity.ApprovalRequest) error {
+	if err := r.storage.Store(request.ID(), request); err != nil {
+		return domainErrors.NewRepositoryError(
+			"save_approval_request",
+			domainErrors.RepositoryInternal,
+			"failed to save approval request",
+			err,
+		)
+	}
+	return nil
+}
+
+// GetByID retrieves an approval request by its ID
+func (r *ApprovalRequestRepositoryImpl) GetByID(id string) (*entity.ApprovalRequest, error) {
+	value, err := r.storage.Get(id)
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return nil, domainErrors.ErrApprovalRequestNotFound
+		}
+		return nil, domainErrors.NewRepositoryError(
+			"get_approval_request",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve approval request",
+			err,
+		)
+	}
+
+	request, err := decodeStoredValue[entity.ApprovalRequest](value)
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"deserialize_approval_request",
+			domainErrors.RepositoryInternal,
+			"failed to deserialize approval request",
+			err,
+		)
+	}
+	return request, nil
+}
+
+// GetAll retrieves all approval requests, oldest first
+func (r *ApprovalRequestRepositoryImpl) GetAll() ([]*entity.ApprovalRequest, error) {
+	values, err := r.storage.ListAll()
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"get_all_approval_requests",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve approval requests",
+			err,
+		)
+	}
+
+	requests := make([]*entity.ApprovalRequest, 0, len(values))
+	for _, value := range values {
+		request, err := decodeStoredValue[entity.ApprovalRequest](value)
+		if err != nil {
+			return nil, domainErrors.NewRepositoryError(
+				"deserialize_approval_request",
+				domainErrors.RepositoryInternal,
+				"failed to deserialize approval request",
+				err,
+			)
+		}
+		requests = append(requests, request)
+	}
+
+	sort.SliceStable(requests, func(i, j int) bool {
+		return requests[i].CreatedAt().Before(requests[j].CreatedAt())
+	})
+
+	return requests, nil
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"sort"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
+)
+
+// AuditCollection is the storage collection holding audit log entries
+// The PostgreSQL table is partitioned by month of creation
+const AuditCollection = "audit_log_records"
+
+// AuditRepositoryImpl implements the AuditRepository interface using a storage backend
+type AuditRepositoryImpl struct {
+	storage storage.Storage
+}
+
+// NewAuditRepository creates a new audit repository with the given storage backend
+func NewAuditRepository(storage storage.Storage) repository.AuditRepository {
+	return &AuditRepositoryImpl{
+		storage: storage,
+	}
+}
+
+// Append persists a new audit entry
+func (r *AuditRepositoryImpl) Append(entry *entity.AuditEntry) error {
+	if err := r.storage.Store(entry.ID(), entry); err != nil {
+		return domainErrors.NewRepositoryError(
+			"append_audit_entry",
+			domainErrors.RepositoryInternal,
+			"failed to append audit entry",
+			err,
+		)
+	}
+	return nil
+}
+
+// GetAll retrieves all audit entries, most recent first
+func (r *AuditRepositoryImpl) GetAll() ([]*entity.AuditEntry, error) {
+	values, err := r.storage.ListAll()
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"get_all_audit_entries",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve audit entries",
+			err,
+		)
+	}
+	return r.decodeEntries(values, time.Time{})
+}
+
+// ListSince retrieves the audit entries that occurred at or after since, most recent first
+// Only the partitions of the months around since and later are read
+func (r *AuditRepositoryImpl) ListSince(since time.Time) ([]*entity.AuditEntry, error) {
+	values, err := storage.ListCreatedSince(r.storage, since.Add(-partitionLookback))
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"list_audit_entries",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve audit entries",
+			err,
+		)
+	}
+
+	return r.decodeEntries(values, since)
+}
+
+// decodeEntries converts stored values to audit entries that occurred at or after since, most recent first
+func (r *AuditRepositoryImpl) decodeEntries(values []interface{}, since time.Time) ([]*entity.AuditEntry, error) {
+	entries := make([]*entity.AuditEntry, 0, len(values))
+	for _, value := range values {
+		entry, err := decodeStoredValue[entity.AuditEntry](value)
+		if err != nil {
+			return nil, domainErrors.NewRepositoryError(
+				"deserialize_audit_entry",
+				domainErrors.RepositoryInternal,
+				"failed to deserialize audit entry",
+				err,
+			)
+		}
+		if entry.OccurredAt().Before(since) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].OccurredAt().After(entries[j].OccurredAt())
+	})
+
+	return entries, nil
+}
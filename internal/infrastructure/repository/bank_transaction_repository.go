@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
+)
+
+// BankTransactionCollection is the storage collection holding bank transactions imported from statements
+const BankTransactionCollection = "bank_transaction_records"
+
+// BankTransactionRepositoryImpl implements the BankTransactionRepository interface using a storage backend
+type BankTransactionRepositoryImpl struct {
+	storage storage.Storage
+}
+
+// NewBankTransactionRepository creates a new bank transaction repository with the given storage backend
+func NewBankTransactionRepository(storage storage.Storage) repository.BankTransactionRepository {
+	return &BankTransactionRepositoryImpl{
+		storage: storage,
+	}
+}
+
+// Save persists a bank transaction keyed by its ID
+func (r *BankTransactionRepositoryImpl) Save(transaction *entity.BankTransaction) error {
+	if err := r.storage.Store(transaction.ID(), transaction); err != nil {
+		return domainErrors.NewRepositoryError(
+			"save_bank_transaction",
+			domainErrors.RepositoryInternal,
+			"failed to save bank transaction",
+			err,
+		)
+	}
+	return nil
+}
+
+// GetByID retrieves a bank transaction by its ID
+func (r *BankTransactionRepositoryImpl) GetByID(id string) (*entity.BankTransaction, error) {
+	value, err := r.storage.Get(id)
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return nil, domainErrors.ErrBankTransactionNotFound
+		}
+		return nil, domainErrors.NewRepositoryError(
+			"get_bank_transaction",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve bank transaction",
+			err,
+		)
+	}
+
+	transaction, err := decodeStoredValue[entity.BankTransaction](value)
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"deserialize_bank_transaction",
+			domainErrors.RepositoryInternal,
+			"failed to deserialize bank transaction",
+			err,
+		)
+	}
+	return transaction, nil
+}
+
+// GetAll retrieves all bank transactions ordered by booking date, then import time
+func (r *BankTransactionRepositoryImpl) GetAll() ([]*entity.BankTransaction, error) {
+	values, err := r.storage.ListAll()
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"get_all_bank_transactions",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve bank transactions",
+			err,
+		)
+	}
+
+	transactions := make([]*entity.BankTransaction, 0, len(values))
+	for _, value := range values {
+		transaction, err := decodeStoredValue[entity.BankTransaction](value)
+		if err != nil {
+			return nil, domainErrors.NewRepositoryError(
+				"deserialize_bank_transaction",
+				domainErrors.RepositoryInternal,
+				"failed to deserialize bank transaction",
+				err,
+			)
+		}
+		transactions = append(transactions, transaction)
+	}
+
+	sort.SliceStable(transactions, func(i, j int) bool {
+		if !transactions[i].BookingDate().Equal(transactions[j].BookingDate()) {
+			return transactions[i].BookingDate().Before(transactions[j].BookingDate())
+		}
+		return transactions[i].ImportedAt().Before(transactions[j].ImportedAt())
+	})
+
+	return transactions, nil
+}
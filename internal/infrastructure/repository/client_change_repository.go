@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
+)
+
+// ClientChangeCollection is the storage collection holding the client change log
+const ClientChangeCollection = "client_change_records"
+
+// clientChangeOccurredAtField is the stored field holding when a change occurred
+const clientChangeOccurredAtField = "occurredAt"
+
+// ClientChangeRepositoryImpl implements the ClientChangeRepository interface using a storage backend
+type ClientChangeRepositoryImpl struct {
+	storage storage.Storage
+}
+
+// NewClientChangeRepository creates a new client change repository with the given storage backend
+// The storage must support sequences (see storage.Sequencer)
+func NewClientChangeRepository(storage storage.Storage) repository.ClientChangeRepository {
+	return &ClientChangeRepositoryImpl{
+		storage: storage,
+	}
+}
+
+// NextSequence allocates the sequence number of the next change
+func (r *ClientChangeRepositoryImpl) NextSequence() (int64, error) {
+	sequence, err := storage.NextSequence(r.storage)
+	if err != nil {
+		return 0, domainErrors.NewRepositoryError(
+			"next_client_change_sequence",
+			domainErrors.RepositoryInternal,
+			"failed to allocate change sequence",
+			err,
+		)
+	}
+	return sequence, nil
+}
+
+// Append persists a change log entry keyed by its zero-padded sequence
+func (r *ClientChangeRepositoryImpl) Append(change *entity.ClientChange) error {
+	if err := r.storage.Store(clientChangeKey(change.Sequence()), change); err != nil {
+		return domainErrors.NewRepositoryError(
+			"append_client_change",
+			domainErrors.RepositoryInternal,
+			"failed to append client change",
+			err,
+		)
+	}
+	return nil
+}
+
+// ListAfterSequence retrieves up to limit changes with a sequence greater than sequence
+// Backends listing by key range read from the key of sequence on; others are listed in full
+func (r *ClientChangeRepositoryImpl) ListAfterSequence(sequence int64, limit int) ([]*entity.ClientChange, error) {
+	if lister, ok := r.storage.(storage.KeyRangeLister); ok {
+		return r.listKeyRange(lister, storage.Query{Limit: limit}, clientChangeKey(sequence))
+	}
+	return r.list(limit, func(change *entity.ClientChange) bool {
+		return change.Sequence() > sequence
+	})
+}
+
+// ListSince retrieves up to limit changes that occurred after since
+func (r *ClientChangeRepositoryImpl) ListSince(since time.Time, limit int) ([]*entity.ClientChange, error) {
+	if lister, ok := r.storage.(storage.KeyRangeLister); ok {
+		return r.listKeyRange(lister, storage.Query{TimeField: clientChangeOccurredAtField, After: since, Limit: limit}, "")
+	}
+	return r.list(limit, func(change *entity.ClientChange) bool {
+		return change.OccurredAt().After(since)
+	})
+}
+
+// listKeyRange returns the changes matching query keyed after the key after, in sequence order
+func (r *ClientChangeRepositoryImpl) listKeyRange(lister storage.KeyRangeLister, query storage.Query, after string) ([]*entity.ClientChange, error) {
+	values, err := lister.ListKeyRange(query, after)
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"list_client_changes",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve client changes",
+			err,
+		)
+	}
+	return decodeClientChanges(values, func(*entity.ClientChange) bool { return true })
+}
+
+// list returns matching changes in sequence order, truncated to limit, for backends without key range listing
+func (r *ClientChangeRepositoryImpl) list(limit int, matches func(*entity.ClientChange) bool) ([]*entity.ClientChange, error) {
+	values, err := r.storage.ListAll()
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"list_client_changes",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve client changes",
+			err,
+		)
+	}
+
+	changes, err := decodeClientChanges(values, matches)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Sequence() < changes[j].Sequence()
+	})
+
+	if limit > 0 && len(changes) > limit {
+		changes = changes[:limit]
+	}
+	return changes, nil
+}
+
+// decodeClientChanges decodes stored change log entries, keeping the matching ones in their stored order
+func decodeClientChanges(values []interface{}, matches func(*entity.ClientChange) bool) ([]*entity.ClientChange, error) {
+	changes := make([]*entity.ClientChange, 0, len(values))
+	for _, value := range values {
+		change, err := decodeStoredValue[entity.ClientChange](value)
+		if err != nil {
+			return nil, domainErrors.NewRepositoryError(
+				"deserialize_client_change",
+				domainErrors.RepositoryInternal,
+				"failed to deserialize client change",
+				err,
+			)
+		}
+		if matches(change) {
+			changes = append(changes, change)
+		}
+	}
+	return changes, nil
+}
+
+// clientChangeKey is the storage key of the change with a sequence; zero-padding makes key order sequence order
+func clientChangeKey(sequence int64) string {
+	return fmt.Sprintf("%020d", sequence)
+}
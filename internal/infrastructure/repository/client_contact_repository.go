@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
+)
+
+// ClientContactCollection is the storage collection holding client contacts
+const ClientContactCollection = "client_contact_records"
+
+// clientContactClientField is the JSON path of the client of a stored contact (indexed by migration 039)
+const clientContactClientField = "clientId"
+
+// ClientContactRepositoryImpl implements the ClientContactRepository interface using a storage backend
+type ClientContactRepositoryImpl struct {
+	storage storage.Storage
+}
+
+// NewClientContactRepository creates a new client contact repository with the given storage backend
+func NewClientContactRepository(storage storage.Storage) repository.ClientContactRepository {
+	return &ClientContactRepositoryImpl{
+		storage: storage,
+	}
+}
+
+// Save persists a contact keyed by its ID
+func (r *ClientContactRepositoryImpl) Save(contact *entity.ClientContact) error {
+	if err := r.storage.Store(contact.ID(), contact); err != nil {
+		return domainErrors.NewRepositoryError(
+			"save_client_contact",
+			domainErrors.RepositoryInternal,
+			"failed to save client contact",
+			err,
+		)
+	}
+	return nil
+}
+
+// GetByID retrieves a contact by its ID
+func (r *ClientContactRepositoryImpl) GetByID(id string) (*entity.ClientContact, error) {
+	value, err := r.storage.Get(id)
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return nil, domainErrors.ErrClientContactNotFound
+		}
+
+		return nil, domainErrors.NewRepositoryError(
+			"get_client_contact",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve client contact",
+			err,
+		)
+	}
+
+	contact, err := decodeStoredValue[entity.ClientContact](value)
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"deserialize_client_contact",
+			domainErrors.RepositoryInternal,
+			"failed to deserialize client contact",
+			err,
+		)
+	}
+	return contact, nil
+}
+
+// ListByClient retrieves the contacts of a client ordered by creation
+// Backends able to match fields (PostgreSQL) use the client index; others load and match every contact
+func (r *ClientContactRepositoryImpl) ListByClient(clientID string) ([]*entity.ClientContact, error) {
+	var values []interface{}
+	var err error
+	matcher, canMatch := r.storage.(storage.FieldMatcher)
+	if canMatch {
+		values, err = matcher.ListMatching(clientContactClientField, clientID)
+	} else {
+		values, err = r.storage.ListAll()
+	}
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"list_client_contacts",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve client contacts",
+			err,
+		)
+	}
+
+	contacts := make([]*entity.ClientContact, 0, len(values))
+	for _, value := range values {
+		contact, err := decodeStoredValue[entity.ClientContact](value)
+		if err != nil {
+			return nil, domainErrors.NewRepositoryError(
+				"deserialize_client_contact",
+				domainErrors.RepositoryInternal,
+				"failed to deserialize client contact",
+				err,
+			)
+		}
+		if contact.ClientID() == clientID {
+			contacts = append(contacts, contact)
+		}
+	}
+
+	sort.SliceStable(contacts, func(i, j int) bool {
+		return contacts[i].CreatedAt().Before(contacts[j].CreatedAt())
+	})
+
+	return contacts, nil
+}
+
+// Delete removes a contact
+func (r *ClientContactRepositoryImpl) Delete(id string) error {
+	if err := r.storage.Delete(id); err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return domainErrors.ErrClientContactNotFound
+		}
+
+		return domainErrors.NewRepositoryError(
+			"delete_client_contact",
+			domainErrors.RepositoryInternal,
+			"failed to delete client contact",
+			err,
+		)
+	}
+	return nil
+}
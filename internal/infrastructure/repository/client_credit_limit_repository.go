@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
+)
+
+// ClientCreditLimitCollection is the storage collection holding client credit limits
+const ClientCreditLimitCollection = "client_credit_limit_records"
+
+// ClientCreditLimitRepositoryImpl implements the ClientCreditLimitRepository interface using a storage backend
+type ClientCreditLimitRepositoryImpl struct {
+	storage storage.Storage
+}
+
+// NewClientCreditLimitRepository creates a new client credit limit repository with the given storage backend
+func NewClientCreditLimitRepository(storage storage.Storage) repository.ClientCreditLimitRepository {
+	return &ClientCreditLimitRepositoryImpl{
+		storage: storage,
+	}
+}
+
+// Save persists a limit keyed by client ID
+func (r *ClientCreditLimitRepositoryImpl) Save(limit *entity.ClientCreditLimit) error {
+	if err := r.storage.Store(limit.ClientID(), limit); err != nil {
+		return domainErrors.NewRepositoryError(
+			"save_client_credit_limit",
+			domainErrors.RepositoryInternal,
+			"failed to save client credit limit",
+			err,
+		)
+	}
+	return nil
+}
+
+// GetByClientID retrieves the limit of a client
+func (r *ClientCreditLimitRepositoryImpl) GetByClientID(clientID string) (*entity.ClientCreditLimit, error) {
+	value, err := r.storage.Get(clientID)
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return nil, domainErrors.ErrClientCreditLimitNotFound
+		}
+
+		return nil, domainErrors.NewRepositoryError(
+			"get_client_credit_limit",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve client credit limit",
+			err,
+		)
+	}
+
+	limit, err := decodeStoredValue[entity.ClientCreditLimit](value)
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"deserialize_client_credit_limit",
+			domainErrors.RepositoryInternal,
+			"failed to deserialize client credit limit",
+			err,
+		)
+	}
+	return limit, nil
+}
+
+// GetAll retrieves all limits ordered by client ID
+func (r *ClientCreditLimitRepositoryImpl) GetAll() ([]*entity.ClientCreditLimit, error) {
+	values, err := r.storage.ListAll()
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"get_all_client_credit_limits",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve client credit limits",
+			err,
+		)
+	}
+
+	limits := make([]*entity.ClientCreditLimit, 0, len(values))
+	for _, value := range values {
+		limit, err := decodeStoredValue[entity.ClientCreditLimit](value)
+		if err != nil {
+			return nil, domainErrors.NewRepositoryError(
+				"deserialize_client_credit_limit",
+				domainErrors.RepositoryInternal,
+				"failed to deserialize client credit limit",
+				err,
+			)
+		}
+		limits = append(limits, limit)
+	}
+
+	sort.Slice(limits, func(i, j int) bool {
+		return limits[i].ClientID() < limits[j].ClientID()
+	})
+
+	return limits, nil
+}
+
+// Delete removes the limit of a client
+func (r *ClientCreditLimitRepositoryImpl) Delete(clientID string) error {
+	if err := r.storage.Delete(clientID); err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return domainErrors.ErrClientCreditLimitNotFound
+		}
+
+		return domainErrors.NewRepositoryError(
+			"delete_client_credit_limit",
+			domainErrors.RepositoryInternal,
+			"failed to delete client credit limit",
+			err,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
+)
+
+// ClientNoteCollection is the storage collection holding client notes
+const ClientNoteCollection = "client_note_records"
+
+// clientNoteClientField is the JSON path of the client of a stored note (indexed by migration 042)
+const clientNoteClientField = "clientId"
+
+// ClientNoteRepositoryImpl implements the ClientNoteRepository interface using a storage backend
+type ClientNoteRepositoryImpl struct {
+	storage storage.Storage
+}
+
+// NewClientNoteRepository creates a new client note repository with the given storage backend
+func NewClientNoteRepository(storage storage.Storage) repository.ClientNoteRepository {
+	return &ClientNoteRepositoryImpl{
+		storage: storage,
+	}
+}
+
+// Save persists a note keyed by its ID
+func (r *ClientNoteRepositoryImpl) Save(note *entity.ClientNote) error {
+	if err := r.storage.Store(note.ID(), note); err != nil {
+		return domainErrors.NewRepositoryError(
+			"save_client_note",
+			domainErrors.RepositoryInternal,
+			"failed to save client note",
+			err,
+		)
+	}
+	return nil
+}
+
+// ListByClient retrieves the notes of a client, newest first
+// Backends able to match fields (PostgreSQL) use the client index; others load and match every note
+func (r *ClientNoteRepositoryImpl) ListByClient(clientID string) ([]*entity.ClientNote, error) {
+	var values []interface{}
+	var err error
+	matcher, canMatch := r.storage.(storage.FieldMatcher)
+	if canMatch {
+		values, err = matcher.ListMatching(clientNoteClientField, clientID)
+	} else {
+		values, err = r.storage.ListAll()
+	}
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"list_client_notes",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve client notes",
+			err,
+		)
+	}
+
+	notes := make([]*entity.ClientNote, 0, len(values))
+	for _, value := range values {
+		note, err := decodeStoredValue[entity.ClientNote](value)
+		if err != nil {
+			return nil, domainErrors.NewRepositoryError(
+				"deserialize_client_note",
+				domainErrors.RepositoryInternal,
+				"failed to deserialize client note",
+				err,
+			)
+		}
+		if note.ClientID() == clientID {
+			notes = append(notes, note)
+		}
+	}
+
+	sort.SliceStable(notes, func(i, j int) bool {
+		return notes[i].CreatedAt().After(notes[j].CreatedAt())
+	})
+
+	return notes, nil
+}
+
+// Delete removes a note; removing a missing note is not an error
+func (r *ClientNoteRepositoryImpl) Delete(id string) error {
+	if err := r.storage.Delete(id); err != nil && !errors.Is(err, storage.ErrKeyNotFound) {
+		return domainErrors.NewRepositoryError(
+			"delete_client_note",
+			domainErrors.RepositoryInternal,
+			"failed to delete client note",
+			err,
+		)
+	}
+	return nil
+}
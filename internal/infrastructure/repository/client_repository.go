@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
 	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
@@ -27,6 +29,10 @@ func NewClientRepository(storage storage.Storage) repository.ClientRepository {
 func (r *ClientRepositoryImpl) Save(client *entity.Client) error {
 	// Single Save logic - works with any storage backend
 	err := r.storage.Store(client.ID(), client)
+	if errors.Is(err, storage.ErrDuplicateValue) {
+		// The unique email index caught a client of the tenant created concurrently with the same email
+		return domainErrors.ErrClientEmailExists
+	}
 	if err != nil {
 		// Wrap storage error with repository context
 		return domainErrors.NewRepositoryError(
@@ -39,8 +45,56 @@ func (r *ClientRepositoryImpl) Save(client *entity.Client) error {
 	return nil
 }
 
-// GetAll retrieves all client entities from storage
+// clientListAdvice tells callers of an unpaginated client listing matching too many clients how to read them
+const clientListAdvice = "list clients page by page (page and limit, or cursor)"
+
+// GetAll retrieves all client entities from storage, failing beyond repository.MaxListResults clients
+// Backends able to query (PostgreSQL) read one client past the bound rather than every client
 func (r *ClientRepositoryImpl) GetAll() ([]*entity.Client, error) {
+	if lister, ok := r.storage.(storage.QueryLister); ok {
+		values, _, err := lister.ListQuery(storage.Query{Limit: repository.MaxListResults + 1})
+		if err != nil {
+			return nil, domainErrors.NewRepositoryError(
+				"get_all_clients",
+				domainErrors.RepositoryInternal,
+				"failed to retrieve all clients",
+				err,
+			)
+		}
+		clients, err := r.deserializeClients(values)
+		if err != nil {
+			return nil, err
+		}
+		return boundedClients(clients)
+	}
+
+	clients, err := r.loadAll()
+	if err != nil {
+		return nil, err
+	}
+	return boundedClients(clients)
+}
+
+// boundedClients fails a listing matching more than repository.MaxListResults clients
+func boundedClients(clients []*entity.Client) ([]*entity.Client, error) {
+	if len(clients) > repository.MaxListResults {
+		return nil, domainErrors.NewResultLimitError(repository.MaxListResults, clientListAdvice)
+	}
+	return clients, nil
+}
+
+// checkPageLimit refuses unbounded pages and pages larger than repository.MaxListResults
+func checkPageLimit(limit int) error {
+	if limit < 1 || limit > repository.MaxListResults {
+		return domainErrors.NewValidationError("limit", limit, domainErrors.ValidationRange,
+			fmt.Sprintf("limit must be between 1 and %d", repository.MaxListResults))
+	}
+	return nil
+}
+
+// loadAll retrieves every client from storage, whatever their number
+// Only the fallbacks of backends unable to query use it, to match clients in memory
+func (r *ClientRepositoryImpl) loadAll() ([]*entity.Client, error) {
 	// Get all values from storage
 	values, err := r.storage.ListAll()
 	if err != nil {
@@ -141,6 +195,470 @@ func (r *ClientRepositoryImpl) GetByID(id string) (*entity.Client, error) {
 	)
 }
 
+// GetByIDs retrieves the clients with the given IDs, in the order of ids
+// Backends able to list keys (PostgreSQL) read them with a single IN query; others get each client in turn
+func (r *ClientRepositoryImpl) GetByIDs(ids []string) ([]*entity.Client, error) {
+	byID := make(map[string]*entity.Client, len(ids))
+	if lister, ok := r.storage.(storage.KeyLister); ok {
+		values, err := lister.ListKeys(ids)
+		if err != nil {
+			return nil, domainErrors.NewRepositoryError(
+				"get_clients_by_ids",
+				domainErrors.RepositoryInternal,
+				"failed to retrieve clients",
+				err,
+			)
+		}
+		clients, err := r.deserializeClients(values)
+		if err != nil {
+			return nil, err
+		}
+		for _, client := range clients {
+			byID[client.ID()] = client
+		}
+	} else {
+		for _, id := range ids {
+			client, err := r.GetByID(id)
+			if errors.Is(err, domainErrors.ErrClientNotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			byID[id] = client
+		}
+	}
+
+	clients := make([]*entity.Client, 0, len(byID))
+	for _, id := range ids {
+		if client, found := byID[id]; found {
+			clients = append(clients, client)
+			delete(byID, id) // An ID requested twice is returned once
+		}
+	}
+	return clients, nil
+}
+
+// clientEmailKeyField is the persisted path of the canonical client email, unique per tenant in PostgreSQL
+const clientEmailKeyField = "emailKey"
+
+// clientStatusField is the path of the lifecycle status in persisted clients
+const clientStatusField = "status"
+
+// clientTagsField is the path of the tags array in persisted clients (GIN index in PostgreSQL)
+const clientTagsField = "tags"
+
+// clientPhoneField is the path of the E.164 phone in persisted clients (indexed by migration 044)
+const clientPhoneField = "phoneE164"
+
+// clientTenantField is the path of the tenant in persisted clients (left out for clients without a tenant)
+const clientTenantField = "tenantId"
+
+// GetByEmailKey retrieves the client of a tenant with a canonical email address
+// Backends able to match fields (PostgreSQL) use the unique email key index; others load and match every client
+func (r *ClientRepositoryImpl) GetByEmailKey(tenantID, key string) (*entity.Client, error) {
+	key = strings.ToLower(strings.TrimSpace(key))
+	tenantID = strings.TrimSpace(tenantID)
+
+	if matcher, ok := r.storage.(storage.FieldMatcher); ok {
+		values, err := matcher.ListMatching(clientEmailKeyField, key)
+		if err != nil {
+			return nil, domainErrors.NewRepositoryError(
+				"get_client_by_email_key",
+				domainErrors.RepositoryInternal,
+				"failed to retrieve client by email key",
+				err,
+			)
+		}
+		for _, value := range values {
+			if clientMap, ok := value.(map[string]interface{}); ok {
+				client, err := r.deserializeClient(clientMap)
+				if err != nil {
+					return nil, domainErrors.NewRepositoryError(
+						"deserialize_client",
+						domainErrors.RepositoryInternal,
+						"failed to deserialize client",
+						err,
+					)
+				}
+				if client.TenantID() == tenantID {
+					return client, nil
+				}
+			}
+		}
+		return nil, domainErrors.ErrClientNotFound
+	}
+
+	clients, err := r.loadAll()
+	if err != nil {
+		return nil, err
+	}
+	for _, client := range clients {
+		if client.EmailKey() == key && client.TenantID() == tenantID {
+			return client, nil
+		}
+	}
+	return nil, domainErrors.ErrClientNotFound
+}
+
+// ListByStatus retrieves the clients in a lifecycle status, in insertion order, at most repository.MaxListResults
+// Backends able to match fields (PostgreSQL) use the status index; others load and match every client
+func (r *ClientRepositoryImpl) ListByStatus(status entity.ClientStatus) ([]*entity.Client, error) {
+	if matcher, ok := r.storage.(storage.FieldMatcher); ok {
+		values, err := matcher.ListMatching(clientStatusField, string(status))
+		if err != nil {
+			return nil, domainErrors.NewRepositoryError(
+				"list_clients_by_status",
+				domainErrors.RepositoryInternal,
+				"failed to retrieve clients by status",
+				err,
+			)
+		}
+		clients, err := r.deserializeClients(values)
+		if err != nil {
+			return nil, err
+		}
+		return boundedClients(clients)
+	}
+
+	all, err := r.loadAll()
+	if err != nil {
+		return nil, err
+	}
+	clients := make([]*entity.Client, 0, len(all))
+	for _, client := range all {
+		if client.Status() == status {
+			clients = append(clients, client)
+		}
+	}
+	return boundedClients(clients)
+}
+
+// ListByTag retrieves the clients carrying a normalized tag, in insertion order, at most repository.MaxListResults
+// Backends able to match array elements (PostgreSQL) use the tags index; others load and match every client
+func (r *ClientRepositoryImpl) ListByTag(tag string) ([]*entity.Client, error) {
+	if matcher, ok := r.storage.(storage.ElementMatcher); ok {
+		values, err := matcher.ListContaining(clientTagsField, tag)
+		if err != nil {
+			return nil, domainErrors.NewRepositoryError(
+				"list_clients_by_tag",
+				domainErrors.RepositoryInternal,
+				"failed to retrieve clients by tag",
+				err,
+			)
+		}
+		clients, err := r.deserializeClients(values)
+		if err != nil {
+			return nil, err
+		}
+		return boundedClients(clients)
+	}
+
+	all, err := r.loadAll()
+	if err != nil {
+		return nil, err
+	}
+	clients := make([]*entity.Client, 0, len(all))
+	for _, client := range all {
+		if client.HasTag(tag) {
+			clients = append(clients, client)
+		}
+	}
+	return boundedClients(clients)
+}
+
+// ListByPhone retrieves the clients whose phone has an E.164 form, in insertion order, at most repository.MaxListResults
+// Backends able to match fields (PostgreSQL) use the phone index; others load and match every client
+func (r *ClientRepositoryImpl) ListByPhone(e164 string) ([]*entity.Client, error) {
+	if matcher, ok := r.storage.(storage.FieldMatcher); ok {
+		values, err := matcher.ListMatching(clientPhoneField, e164)
+		if err != nil {
+			return nil, domainErrors.NewRepositoryError(
+				"list_clients_by_phone",
+				domainErrors.RepositoryInternal,
+				"failed to retrieve clients by phone",
+				err,
+			)
+		}
+		clients, err := r.deserializeClients(values)
+		if err != nil {
+			return nil, err
+		}
+		return boundedClients(clients)
+	}
+
+	all, err := r.loadAll()
+	if err != nil {
+		return nil, err
+	}
+	clients := make([]*entity.Client, 0, len(all))
+	for _, client := range all {
+		if client.PhoneE164() == e164 {
+			clients = append(clients, client)
+		}
+	}
+	return boundedClients(clients)
+}
+
+// clientNameField and clientEmailField are the paths of the name and email address in persisted clients
+const (
+	clientNameField  = "name"
+	clientEmailField = "email.value"
+)
+
+// clientCreatedAtField and clientUpdatedAtField are the paths of the timestamps in persisted clients
+const (
+	clientCreatedAtField = "createdAt"
+	clientUpdatedAtField = "updatedAt"
+)
+
+// clientSortKeys maps the fields client listings can be ordered by to the persisted field they order by
+var clientSortKeys = map[repository.ClientSortField]storage.OrderKey{
+	repository.ClientSortName:      {Path: clientNameField},
+	repository.ClientSortEmail:     {Path: clientEmailField},
+	repository.ClientSortCreatedAt: {Path: clientCreatedAtField, Timestamp: true},
+	repository.ClientSortUpdatedAt: {Path: clientUpdatedAtField, Timestamp: true},
+}
+
+// List retrieves a page of the clients matching the filter, in the order of the filter, and the number of matching
+// clients. Backends able to query (PostgreSQL) filter, sort and paginate in the database; others load, match and
+// sort every client
+func (r *ClientRepositoryImpl) List(filter repository.ClientListFilter, offset, limit int) ([]*entity.Client, int, error) {
+	if err := checkPageLimit(limit); err != nil {
+		return nil, 0, err
+	}
+	filter.Search = strings.TrimSpace(filter.Search)
+
+	if lister, ok := r.storage.(storage.QueryLister); ok {
+		values, count, err := lister.ListQuery(clientQuery(filter, offset, limit))
+		if err != nil {
+			return nil, 0, domainErrors.NewRepositoryError(
+				"list_clients",
+				domainErrors.RepositoryInternal,
+				"failed to retrieve clients",
+				err,
+			)
+		}
+		clients, err := r.deserializeClients(values)
+		if err != nil {
+			return nil, 0, err
+		}
+		return clients, int(count), nil
+	}
+
+	all, err := r.loadAll()
+	if err != nil {
+		return nil, 0, err
+	}
+	clients := make([]*entity.Client, 0, len(all))
+	for _, client := range all {
+		if clientMatches(client, filter) {
+			clients = append(clients, client)
+		}
+	}
+	if len(filter.Sort) > 0 {
+		slices.SortStableFunc(clients, func(a, b *entity.Client) int {
+			return compareClients(a, b, filter.Sort)
+		})
+	}
+	start := min(offset, len(clients))
+	end := min(start+limit, len(clients))
+	return clients[start:end], len(clients), nil
+}
+
+// ListAfter retrieves up to limit clients matching the filter that come after a position in creation order
+// Backends able to seek (PostgreSQL) order by when each client was first stored and read only the page; others load,
+// match and order every client by its creation time
+func (r *ClientRepositoryImpl) ListAfter(filter repository.ClientListFilter, after repository.ClientPosition, limit int) (*repository.ClientPage, error) {
+	if err := checkPageLimit(limit); err != nil {
+		return nil, err
+	}
+	filter.Search = strings.TrimSpace(filter.Search)
+	filter.Sort = nil
+
+	// One extra client tells whether more come after the page
+	var clients []*entity.Client
+	var positions []repository.ClientPosition
+	if seeker, ok := r.storage.(storage.SeekLister); ok {
+		values, storagePositions, err := seeker.ListAfter(clientQuery(filter, 0, limit+1), storage.Position{CreatedAt: after.CreatedAt, Key: after.ID})
+		if err != nil {
+			return nil, domainErrors.NewRepositoryError(
+				"list_clients",
+				domainErrors.RepositoryInternal,
+				"failed to retrieve clients",
+				err,
+			)
+		}
+		if clients, err = r.deserializeClients(values); err != nil {
+			return nil, err
+		}
+		for _, position := range storagePositions {
+			positions = append(positions, repository.ClientPosition{CreatedAt: position.CreatedAt, ID: position.Key})
+		}
+	} else {
+		all, err := r.loadAll()
+		if err != nil {
+			return nil, err
+		}
+		slices.SortFunc(all, func(a, b *entity.Client) int {
+			return comparePositions(clientPosition(a), clientPosition(b))
+		})
+		for _, client := range all {
+			if len(clients) > limit {
+				break
+			}
+			position := clientPosition(client)
+			if clientMatches(client, filter) && (after.IsZero() || comparePositions(position, after) > 0) {
+				clients = append(clients, client)
+				positions = append(positions, position)
+			}
+		}
+	}
+
+	page := &repository.ClientPage{Clients: clients, Next: after}
+	if len(clients) > limit {
+		page.Clients = clients[:limit]
+		page.HasMore = true
+	}
+	if len(page.Clients) > 0 {
+		page.Next = positions[len(page.Clients)-1]
+	}
+	return page, nil
+}
+
+// clientPosition returns the position of a loaded client in creation order
+func clientPosition(client *entity.Client) repository.ClientPosition {
+	return repository.ClientPosition{CreatedAt: client.CreatedAt(), ID: client.ID()}
+}
+
+// comparePositions compares two positions in creation order
+func comparePositions(a, b repository.ClientPosition) int {
+	if result := a.CreatedAt.Compare(b.CreatedAt); result != 0 {
+		return result
+	}
+	return strings.Compare(a.ID, b.ID)
+}
+
+// clientQuery converts a client listing filter to a storage query on the persisted client fields
+func clientQuery(filter repository.ClientListFilter, offset, limit int) storage.Query {
+	query := storage.Query{
+		Matching:   map[string]string{},
+		Containing: map[string]string{},
+		TimeField:  clientCreatedAtField,
+		After:      filter.CreatedAfter,
+		Before:     filter.CreatedBefore,
+		Offset:     offset,
+		Limit:      limit,
+	}
+	for _, key := range filter.Sort {
+		order := clientSortKeys[key.Field]
+		order.Descending = key.Descending
+		query.OrderBy = append(query.OrderBy, order)
+	}
+	if filter.Status != "" {
+		query.Matching[clientStatusField] = string(filter.Status)
+	}
+	if filter.TenantScoped {
+		query.Matching[clientTenantField] = filter.TenantID
+	}
+	if filter.PhoneE164 != "" {
+		query.Matching[clientPhoneField] = filter.PhoneE164
+	}
+	if filter.EmailKey != "" {
+		query.Matching[clientEmailKeyField] = filter.EmailKey
+	}
+	if filter.Tag != "" {
+		query.Containing[clientTagsField] = filter.Tag
+	}
+	if filter.Search != "" {
+		query.SearchFields = []string{clientNameField, clientEmailField}
+		query.Search = filter.Search
+	}
+	return query
+}
+
+// clientMatches checks a loaded client against a listing filter, like clientQuery does in the database
+func clientMatches(client *entity.Client, filter repository.ClientListFilter) bool {
+	if filter.Status != "" && client.Status() != filter.Status {
+		return false
+	}
+	if filter.TenantScoped && client.TenantID() != filter.TenantID {
+		return false
+	}
+	if filter.Tag != "" && !client.HasTag(filter.Tag) {
+		return false
+	}
+	if filter.PhoneE164 != "" && client.PhoneE164() != filter.PhoneE164 {
+		return false
+	}
+	if filter.EmailKey != "" && client.EmailKey() != filter.EmailKey {
+		return false
+	}
+	if filter.Search != "" {
+		search := strings.ToLower(filter.Search)
+		if !strings.Contains(strings.ToLower(client.Name()), search) &&
+			!strings.Contains(strings.ToLower(client.EmailString()), search) {
+			return false
+		}
+	}
+	if !filter.CreatedAfter.IsZero() && !client.CreatedAt().After(filter.CreatedAfter) {
+		return false
+	}
+	if !filter.CreatedBefore.IsZero() && !client.CreatedAt().Before(filter.CreatedBefore) {
+		return false
+	}
+	return true
+}
+
+// compareClients compares two loaded clients on sort keys, like clientSortKeys orders them in the database
+func compareClients(a, b *entity.Client, keys []repository.ClientSortKey) int {
+	for _, key := range keys {
+		var result int
+		switch key.Field {
+		case repository.ClientSortName:
+			result = strings.Compare(strings.ToLower(a.Name()), strings.ToLower(b.Name()))
+		case repository.ClientSortEmail:
+			result = strings.Compare(strings.ToLower(a.EmailString()), strings.ToLower(b.EmailString()))
+		case repository.ClientSortCreatedAt:
+			result = a.CreatedAt().Compare(b.CreatedAt())
+		case repository.ClientSortUpdatedAt:
+			result = a.UpdatedAt().Compare(b.UpdatedAt())
+		}
+		if key.Descending {
+			result = -result
+		}
+		if result != 0 {
+			return result
+		}
+	}
+	return 0
+}
+
+// deserializeClients converts the values of matched records back to clients
+func (r *ClientRepositoryImpl) deserializeClients(values []interface{}) ([]*entity.Client, error) {
+	clients := make([]*entity.Client, 0, len(values))
+	for _, value := range values {
+		if clientMap, ok := value.(map[string]interface{}); ok {
+			client, err := r.deserializeClient(clientMap)
+			if err != nil {
+				return nil, domainErrors.NewRepositoryError(
+					"deserialize_client",
+					domainErrors.RepositoryInternal,
+					"failed to deserialize client",
+					err,
+				)
+			}
+			clients = append(clients, client)
+		}
+	}
+	return clients, nil
+}
+
+// Exists checks if a client with the given ID exists without loading it
+func (r *ClientRepositoryImpl) Exists(id string) (bool, error) {
+	return r.storage.Exists(id), nil
+}
+
 // Delete removes a client entity by ID
 func (r *ClientRepositoryImpl) Delete(id string) error {
 	// Use storage Delete method
@@ -162,72 +680,100 @@ func (r *ClientRepositoryImpl) Delete(id string) error {
 	return nil
 }
 
+// clientSearchFields are the paths of the persisted client fields a count search matches
+var clientSearchFields = []string{clientNameField, clientEmailField}
+
 // CountClients returns the total number of clients
 func (r *ClientRepositoryImpl) CountClients() (int, error) {
-	// Get all values from storage
-	values, err := r.storage.ListAll()
-	if err != nil {
-		return 0, domainErrors.NewRepositoryError(
-			"count_clients",
-			domainErrors.RepositoryInternal,
-			"failed to count clients",
-			err,
-		)
-	}
-
-	return len(values), nil
+	return r.Count(repository.ClientCountFilter{})
 }
 
-// ListClientsWithPagination retrieves clients with pagination
-func (r *ClientRepositoryImpl) ListClientsWithPagination(offset, limit int) ([]*entity.Client, error) {
-	// Get all values from storage
-	values, err := r.storage.ListAll()
-	if err != nil {
-		return nil, domainErrors.NewRepositoryError(
-			"list_clients_paginated",
-			domainErrors.RepositoryInternal,
-			"failed to retrieve clients",
-			err,
-		)
+// Count returns the number of clients matching the filter
+// Backends able to count (PostgreSQL) do it in the database; others load and match every client
+func (r *ClientRepositoryImpl) Count(filter repository.ClientCountFilter) (int, error) {
+	search := strings.TrimSpace(filter.Search)
+	if filter.TenantScoped {
+		return r.countMatching(repository.ClientListFilter{Search: search, TenantScoped: true, TenantID: filter.TenantID})
 	}
 
-	// Apply pagination
-	start := offset
-	if start > len(values) {
-		// Return empty slice if offset is beyond data
-		return []*entity.Client{}, nil
+	if counter, ok := r.storage.(storage.Counter); ok {
+		var count int64
+		var err error
+		if filter.Mode == repository.CountEstimated && search == "" {
+			count, err = counter.EstimatedCount()
+		} else {
+			count, err = counter.CountMatching(clientSearchFields, search)
+		}
+		if err != nil {
+			return 0, domainErrors.NewRepositoryError(
+				"count_clients",
+				domainErrors.RepositoryInternal,
+				"failed to count clients",
+				err,
+			)
+		}
+		return int(count), nil
 	}
 
-	end := start + limit
-	if end > len(values) {
-		end = len(values)
+	if search == "" {
+		values, err := r.storage.ListAll()
+		if err != nil {
+			return 0, domainErrors.NewRepositoryError(
+				"count_clients",
+				domainErrors.RepositoryInternal,
+				"failed to count clients",
+				err,
+			)
+		}
+		return len(values), nil
 	}
 
-	// Convert storage values to domain entities for the requested page
-	paginatedValues := values[start:end]
-	clients := make([]*entity.Client, 0, len(paginatedValues))
+	clients, err := r.loadAll()
+	if err != nil {
+		return 0, err
+	}
+	search = strings.ToLower(search)
+	count := 0
+	for _, client := range clients {
+		if strings.Contains(strings.ToLower(client.Name()), search) ||
+			strings.Contains(strings.ToLower(client.EmailString()), search) {
+			count++
+		}
+	}
+	return count, nil
+}
 
-	for _, value := range paginatedValues {
-		// Try direct type assertion first (for in-memory storage)
-		if client, ok := value.(*entity.Client); ok {
-			clients = append(clients, client)
-			continue
+// countMatching returns the number of clients matching a listing filter
+// Backends able to query (PostgreSQL) count in the database; others load and match every client
+func (r *ClientRepositoryImpl) countMatching(filter repository.ClientListFilter) (int, error) {
+	if lister, ok := r.storage.(storage.QueryLister); ok {
+		_, count, err := lister.ListQuery(clientQuery(filter, 0, 1))
+		if err != nil {
+			return 0, domainErrors.NewRepositoryError(
+				"count_clients",
+				domainErrors.RepositoryInternal,
+				"failed to count clients",
+				err,
+			)
 		}
+		return int(count), nil
+	}
 
-		// Handle JSON deserialization (for PostgreSQL storage)
-		if clientMap, ok := value.(map[string]interface{}); ok {
-			client, err := r.deserializeClient(clientMap)
-			if err != nil {
-				return nil, domainErrors.NewRepositoryError(
-					"deserialize_client",
-					domainErrors.RepositoryInternal,
-					"failed to deserialize client",
-					err,
-				)
-			}
-			clients = append(clients, client)
+	clients, err := r.loadAll()
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, client := range clients {
+		if clientMatches(client, filter) {
+			count++
 		}
 	}
+	return count, nil
+}
 
-	return clients, nil
+// ListClientsWithPagination retrieves a page of the clients in insertion order
+func (r *ClientRepositoryImpl) ListClientsWithPagination(offset, limit int) ([]*entity.Client, error) {
+	clients, _, err := r.List(repository.ClientListFilter{}, offset, limit)
+	return clients, err
 }
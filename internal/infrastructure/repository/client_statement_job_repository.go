@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
+)
+
+// ClientStatementJobCollection is the storage collection holding client statement requests and documents
+const ClientStatementJobCollection = "client_statement_job_records"
+
+// ClientStatementJobRepositoryImpl implements the ClientStatementJobRepository interface using a storage backend
+type ClientStatementJobRepositoryImpl struct {
+	storage storage.Storage
+}
+
+// NewClientStatementJobRepository creates a new client statement job repository with the given storage backend
+func NewClientStatementJobRepository(storage storage.Storage) repository.ClientStatementJobRepository {
+	return &ClientStatementJobRepositoryImpl{
+		storage: storage,
+	}
+}
+
+// Save persists a statement job keyed by its ID
+func (r *ClientStatementJobRepositoryImpl) Save(job *entity.ClientStatementJob) error {
+	if err := r.storage.Store(job.ID(), job); err != nil {
+		return domainErrors.NewRepositoryError(
+			"save_statement_job",
+			domainErrors.RepositoryInternal,
+			"failed to save statement job",
+			err,
+		)
+	}
+	return nil
+}
+
+// GetByID retrieves a statement job by its ID
+func (r *ClientStatementJobRepositoryImpl) GetByID(id string) (*entity.ClientStatementJob, error) {
+	value, err := r.storage.Get(id)
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return nil, domainErrors.ErrStatementJobNotFound
+		}
+		return nil, domainErrors.NewRepositoryError(
+			"get_statement_job",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve statement job",
+			err,
+		)
+	}
+
+	job, err := decodeStoredValue[entity.ClientStatementJob](value)
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"deserialize_statement_job",
+			domainErrors.RepositoryInternal,
+			"failed to deserialize statement job",
+			err,
+		)
+	}
+	return job, nil
+}
+
+// GetPending retrieves the pending statement jobs ordered by creation time
+func (r *ClientStatementJobRepositoryImpl) GetPending() ([]*entity.ClientStatementJob, error) {
+	values, err := r.storage.ListAll()
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"get_pending_statement_jobs",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve statement jobs",
+			err,
+		)
+	}
+
+	jobs := make([]*entity.ClientStatementJob, 0)
+	for _, value := range values {
+		job, err := decodeStoredValue[entity.ClientStatementJob](value)
+		if err != nil {
+			return nil, domainErrors.NewRepositoryError(
+				"deserialize_statement_job",
+				domainErrors.RepositoryInternal,
+				"failed to deserialize statement job",
+				err,
+			)
+		}
+		if job.IsPending() {
+			jobs = append(jobs, job)
+		}
+	}
+
+	sort.SliceStable(jobs, func(i, j int) bool {
+		return jobs[i].CreatedAt().Before(jobs[j].CreatedAt())
+	})
+
+	return jobs, nil
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
+)
+
+// ClientSummaryCollection is the storage collection holding the client list read model
+const ClientSummaryCollection = "client_summary_records"
+
+// ClientSummaryRepositoryImpl implements the ClientSummaryRepository interface using a storage backend
+type ClientSummaryRepositoryImpl struct {
+	storage storage.Storage
+}
+
+// NewClientSummaryRepository creates a new client summary repository with the given storage backend
+func NewClientSummaryRepository(storage storage.Storage) repository.ClientSummaryRepository {
+	return &ClientSummaryRepositoryImpl{
+		storage: storage,
+	}
+}
+
+// Save persists a summary keyed by its client ID
+func (r *ClientSummaryRepositoryImpl) Save(summary *entity.ClientSummary) error {
+	if err := r.storage.Store(summary.ClientID(), summary); err != nil {
+		return domainErrors.NewRepositoryError(
+			"save_client_summary",
+			domainErrors.RepositoryInternal,
+			"failed to save client summary",
+			err,
+		)
+	}
+	return nil
+}
+
+// Delete removes the summary of a client
+func (r *ClientSummaryRepositoryImpl) Delete(clientID string) error {
+	if err := r.storage.Delete(clientID); err != nil && !errors.Is(err, storage.ErrKeyNotFound) {
+		return domainErrors.NewRepositoryError(
+			"delete_client_summary",
+			domainErrors.RepositoryInternal,
+			"failed to delete client summary",
+			err,
+		)
+	}
+	return nil
+}
+
+// GetAll retrieves every summary in client creation order
+func (r *ClientSummaryRepositoryImpl) GetAll() ([]*entity.ClientSummary, error) {
+	values, err := r.storage.ListAll()
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"get_all_client_summaries",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve client summaries",
+			err,
+		)
+	}
+
+	summaries := make([]*entity.ClientSummary, 0, len(values))
+	for _, value := range values {
+		summary, err := decodeStoredValue[entity.ClientSummary](value)
+		if err != nil {
+			return nil, domainErrors.NewRepositoryError(
+				"deserialize_client_summary",
+				domainErrors.RepositoryInternal,
+				"failed to deserialize client summary",
+				err,
+			)
+		}
+		summaries = append(summaries, summary)
+	}
+
+	// A rebuild stores summaries again, so the client creation time orders them rather than the storage
+	sort.SliceStable(summaries, func(i, j int) bool {
+		return summaries[i].ClientCreatedAt().Before(summaries[j].ClientCreatedAt())
+	})
+
+	return summaries, nil
+}
+
+// ListPage retrieves a page of summaries with the total number of summaries in a single storage read
+func (r *ClientSummaryRepositoryImpl) ListPage(offset, limit int) ([]*entity.ClientSummary, int, error) {
+	summaries, err := r.GetAll()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if offset > len(summaries) {
+		return []*entity.ClientSummary{}, len(summaries), nil
+	}
+	end := offset + limit
+	if end > len(summaries) {
+		end = len(summaries)
+	}
+	return summaries[offset:end], len(summaries), nil
+}
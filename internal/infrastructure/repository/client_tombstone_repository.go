@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
+)
+
+// ClientTombstoneCollection is the storage collection holding the tombstones of deleted clients
+const ClientTombstoneCollection = "client_tombstone_records"
+
+// ClientTombstoneRepositoryImpl implements the ClientTombstoneRepository interface using a storage backend
+type ClientTombstoneRepositoryImpl struct {
+	storage storage.Storage
+}
+
+// NewClientTombstoneRepository creates a new client tombstone repository with the given storage backend
+func NewClientTombstoneRepository(storage storage.Storage) repository.ClientTombstoneRepository {
+	return &ClientTombstoneRepositoryImpl{
+		storage: storage,
+	}
+}
+
+// Save persists a tombstone keyed by its client ID
+func (r *ClientTombstoneRepositoryImpl) Save(tombstone *entity.ClientTombstone) error {
+	if err := r.storage.Store(tombstone.ClientID(), tombstone); err != nil {
+		return domainErrors.NewRepositoryError(
+			"save_client_tombstone",
+			domainErrors.RepositoryInternal,
+			"failed to save client tombstone",
+			err,
+		)
+	}
+	return nil
+}
+
+// GetByClientID retrieves the tombstone of a client
+func (r *ClientTombstoneRepositoryImpl) GetByClientID(clientID string) (*entity.ClientTombstone, error) {
+	value, err := r.storage.Get(clientID)
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return nil, domainErrors.ErrClientTombstoneNotFound
+		}
+		return nil, domainErrors.NewRepositoryError(
+			"get_client_tombstone",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve client tombstone",
+			err,
+		)
+	}
+
+	tombstone, err := decodeStoredValue[entity.ClientTombstone](value)
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"deserialize_client_tombstone",
+			domainErrors.RepositoryInternal,
+			"failed to deserialize client tombstone",
+			err,
+		)
+	}
+	return tombstone, nil
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
+)
+
+// ContractCollection is the storage collection holding customer contracts
+const ContractCollection = "contract_records"
+
+// ContractRepositoryImpl implements the ContractRepository interface using a storage backend
+type ContractRepositoryImpl struct {
+	storage storage.Storage
+}
+
+// NewContractRepository creates a new contract repository with the given storage backend
+func NewContractRepository(storage storage.Storage) repository.ContractRepository {
+	return &ContractRepositoryImpl{
+		storage: storage,
+	}
+}
+
+// Save persists a contract keyed by its ID
+func (r *ContractRepositoryImpl) Save(contract *entity.Contract) error {
+	if err := r.storage.Store(contract.ID(), contract); err != nil {
+		return domainErrors.NewRepositoryError(
+			"save_contract",
+			domainErrors.RepositoryInternal,
+			"failed to save contract",
+			err,
+		)
+	}
+	return nil
+}
+
+// GetByID retrieves a contract by its ID
+func (r *ContractRepositoryImpl) GetByID(id string) (*entity.Contract, error) {
+	value, err := r.storage.Get(id)
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return nil, domainErrors.ErrContractNotFound
+		}
+		return nil, domainErrors.NewRepositoryError(
+			"get_contract",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve contract",
+			err,
+		)
+	}
+
+	contract, err := decodeStoredValue[entity.Contract](value)
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"deserialize_contract",
+			domainErrors.RepositoryInternal,
+			"failed to deserialize contract",
+			err,
+		)
+	}
+	return contract, nil
+}
+
+// GetAll retrieves all contracts ordered by renewal date
+func (r *ContractRepositoryImpl) GetAll() ([]*entity.Contract, error) {
+	values, err := r.storage.ListAll()
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"get_all_contracts",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve contracts",
+			err,
+		)
+	}
+
+	contracts := make([]*entity.Contract, 0, len(values))
+	for _, value := range values {
+		contract, err := decodeStoredValue[entity.Contract](value)
+		if err != nil {
+			return nil, domainErrors.NewRepositoryError(
+				"deserialize_contract",
+				domainErrors.RepositoryInternal,
+				"failed to deserialize contract",
+				err,
+			)
+		}
+		contracts = append(contracts, contract)
+	}
+
+	sort.SliceStable(contracts, func(i, j int) bool {
+		return contracts[i].RenewalDate().Before(contracts[j].RenewalDate())
+	})
+
+	return contracts, nil
+}
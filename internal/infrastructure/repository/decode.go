@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// decodeStoredValue converts a storage value back into an entity
+// In-memory storage returns the stored pointer; PostgreSQL storage returns a JSON map
+func decodeStoredValue[T any](value interface{}) (*T, error) {
+	// Try direct type assertion first (for in-memory storage)
+	if entity, ok := value.(*T); ok {
+		return entity, nil
+	}
+
+	// Handle JSON deserialization (for PostgreSQL storage)
+	jsonBytes, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal stored value to JSON: %w", err)
+	}
+
+	var entity T
+	if err := json.Unmarshal(jsonBytes, &entity); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON to %T: %w", entity, err)
+	}
+
+	return &entity, nil
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
+)
+
+// DocumentTemplateCollection is the storage collection holding tenant document templates
+const DocumentTemplateCollection = "document_template_records"
+
+// DocumentTemplateRepositoryImpl implements the DocumentTemplateRepository interface using a storage backend
+type DocumentTemplateRepositoryImpl struct {
+	storage storage.Storage
+}
+
+// NewDocumentTemplateRepository creates a new document template repository with the given storage backend
+func NewDocumentTemplateRepository(storage storage.Storage) repository.DocumentTemplateRepository {
+	return &DocumentTemplateRepositoryImpl{
+		storage: storage,
+	}
+}
+
+// Save persists a template keyed by tenant ID
+func (r *DocumentTemplateRepositoryImpl) Save(template *entity.DocumentTemplate) error {
+	if err := r.storage.Store(template.TenantID(), template); err != nil {
+		return domainErrors.NewRepositoryError(
+			"save_document_template",
+			domainErrors.RepositoryInternal,
+			"failed to save document template",
+			err,
+		)
+	}
+	return nil
+}
+
+// GetByTenantID retrieves the template of a tenant
+func (r *DocumentTemplateRepositoryImpl) GetByTenantID(tenantID string) (*entity.DocumentTemplate, error) {
+	value, err := r.storage.Get(tenantID)
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return nil, domainErrors.ErrDocumentTemplateNotFound
+		}
+
+		return nil, domainErrors.NewRepositoryError(
+			"get_document_template",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve document template",
+			err,
+		)
+	}
+
+	template, err := decodeStoredValue[entity.DocumentTemplate](value)
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"deserialize_document_template",
+			domainErrors.RepositoryInternal,
+			"failed to deserialize document template",
+			err,
+		)
+	}
+	return template, nil
+}
+
+// GetAll retrieves all templates ordered by tenant ID
+func (r *DocumentTemplateRepositoryImpl) GetAll() ([]*entity.DocumentTemplate, error) {
+	values, err := r.storage.ListAll()
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"get_all_document_templates",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve document templates",
+			err,
+		)
+	}
+
+	templates := make([]*entity.DocumentTemplate, 0, len(values))
+	for _, value := range values {
+		template, err := decodeStoredValue[entity.DocumentTemplate](value)
+		if err != nil {
+			return nil, domainErrors.NewRepositoryError(
+				"deserialize_document_template",
+				domainErrors.RepositoryInternal,
+				"failed to deserialize document template",
+				err,
+			)
+		}
+		templates = append(templates, template)
+	}
+
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].TenantID() < templates[j].TenantID()
+	})
+
+	return templates, nil
+}
+
+// Delete removes the template of a tenant
+func (r *DocumentTemplateRepositoryImpl) Delete(tenantID string) error {
+	if err := r.storage.Delete(tenantID); err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return domainErrors.ErrDocumentTemplateNotFound
+		}
+
+		return domainErrors.NewRepositoryError(
+			"delete_document_template",
+			domainErrors.RepositoryInternal,
+			"failed to delete document template",
+			err,
+		)
+	}
+	return nil
+}
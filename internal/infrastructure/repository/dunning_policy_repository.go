@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
+)
+
+// DunningPolicyCollection is the storage collection holding tenant dunning policies
+const DunningPolicyCollection = "dunning_policy_records"
+
+// DunningPolicyRepositoryImpl implements the DunningPolicyRepository interface using a storage backend
+type DunningPolicyRepositoryImpl struct {
+	storage storage.Storage
+}
+
+// NewDunningPolicyRepository creates a new dunning policy repository with the given storage backend
+func NewDunningPolicyRepository(storage storage.Storage) repository.DunningPolicyRepository {
+	return &DunningPolicyRepositoryImpl{
+		storage: storage,
+	}
+}
+
+// Save persists a policy keyed by tenant ID
+func (r *DunningPolicyRepositoryImpl) Save(policy *entity.DunningPolicy) error {
+	if err := r.storage.Store(policy.TenantID(), policy); err != nil {
+		return domainErrors.NewRepositoryError(
+			"save_dunning_policy",
+			domainErrors.RepositoryInternal,
+			"failed to save dunning policy",
+			err,
+		)
+	}
+	return nil
+}
+
+// GetByTenantID retrieves the policy of a tenant
+func (r *DunningPolicyRepositoryImpl) GetByTenantID(tenantID string) (*entity.DunningPolicy, error) {
+	value, err := r.storage.Get(tenantID)
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return nil, domainErrors.ErrDunningPolicyNotFound
+		}
+
+		return nil, domainErrors.NewRepositoryError(
+			"get_dunning_policy",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve dunning policy",
+			err,
+		)
+	}
+
+	policy, err := decodeStoredValue[entity.DunningPolicy](value)
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"deserialize_dunning_policy",
+			domainErrors.RepositoryInternal,
+			"failed to deserialize dunning policy",
+			err,
+		)
+	}
+	return policy, nil
+}
+
+// GetAll retrieves all policies ordered by tenant ID
+func (r *DunningPolicyRepositoryImpl) GetAll() ([]*entity.DunningPolicy, error) {
+	values, err := r.storage.ListAll()
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"get_all_dunning_policies",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve dunning policies",
+			err,
+		)
+	}
+
+	policies := make([]*entity.DunningPolicy, 0, len(values))
+	for _, value := range values {
+		policy, err := decodeStoredValue[entity.DunningPolicy](value)
+		if err != nil {
+			return nil, domainErrors.NewRepositoryError(
+				"deserialize_dunning_policy",
+				domainErrors.RepositoryInternal,
+				"failed to deserialize dunning policy",
+				err,
+			)
+		}
+		policies = append(policies, policy)
+	}
+
+	sort.Slice(policies, func(i, j int) bool {
+		return policies[i].TenantID() < policies[j].TenantID()
+	})
+
+	return policies, nil
+}
+
+// Delete removes the policy of a tenant
+func (r *DunningPolicyRepositoryImpl) Delete(tenantID string) error {
+	if err := r.storage.Delete(tenantID); err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return domainErrors.ErrDunningPolicyNotFound
+		}
+
+		return domainErrors.NewRepositoryError(
+			"delete_dunning_policy",
+			domainErrors.RepositoryInternal,
+			"failed to delete dunning policy",
+			err,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
+)
+
+// EmailSuppressionCollection is the storage collection holding tracked and suppressed email addresses
+const EmailSuppressionCollection = "email_suppression_records"
+
+// EmailSuppressionRepositoryImpl implements the EmailSuppressionRepository interface using a storage backend
+type EmailSuppressionRepositoryImpl struct {
+	storage storage.Storage
+}
+
+// NewEmailSuppressionRepository creates a new email suppression repository with the given storage backend
+func NewEmailSuppressionRepository(storage storage.Storage) repository.EmailSuppressionRepository {
+	return &EmailSuppressionRepositoryImpl{
+		storage: storage,
+	}
+}
+
+// Save persists an address keyed by itself
+func (r *EmailSuppressionRepositoryImpl) Save(suppression *entity.EmailSuppression) error {
+	if err := r.storage.Store(suppression.Address(), suppression); err != nil {
+		return domainErrors.NewRepositoryError(
+			"save_email_suppression",
+			domainErrors.RepositoryInternal,
+			"failed to save email suppression",
+			err,
+		)
+	}
+	return nil
+}
+
+// GetByAddress retrieves a tracked address
+func (r *EmailSuppressionRepositoryImpl) GetByAddress(address string) (*entity.EmailSuppression, error) {
+	value, err := r.storage.Get(address)
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return nil, domainErrors.ErrEmailSuppressionNotFound
+		}
+		return nil, domainErrors.NewRepositoryError(
+			"get_email_suppression",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve email suppression",
+			err,
+		)
+	}
+
+	suppression, err := decodeStoredValue[entity.EmailSuppression](value)
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"deserialize_email_suppression",
+			domainErrors.RepositoryInternal,
+			"failed to deserialize email suppression",
+			err,
+		)
+	}
+	return suppression, nil
+}
+
+// GetAll retrieves all tracked addresses, oldest first
+func (r *EmailSuppressionRepositoryImpl) GetAll() ([]*entity.EmailSuppression, error) {
+	values, err := r.storage.ListAll()
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"get_all_email_suppressions",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve email suppressions",
+			err,
+		)
+	}
+
+	suppressions := make([]*entity.EmailSuppression, 0, len(values))
+	for _, value := range values {
+		suppression, err := decodeStoredValue[entity.EmailSuppression](value)
+		if err != nil {
+			return nil, domainErrors.NewRepositoryError(
+				"deserialize_email_suppression",
+				domainErrors.RepositoryInternal,
+				"failed to deserialize email suppression",
+				err,
+			)
+		}
+		suppressions = append(suppressions, suppression)
+	}
+
+	sort.SliceStable(suppressions, func(i, j int) bool {
+		return suppressions[i].CreatedAt().Before(suppressions[j].CreatedAt())
+	})
+
+	return suppressions, nil
+}
+
+// Delete removes a tracked address
+func (r *EmailSuppressionRepositoryImpl) Delete(address string) error {
+	if err := r.storage.Delete(address); err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return domainErrors.ErrEmailSuppressionNotFound
+		}
+
+		return domainErrors.NewRepositoryError(
+			"delete_email_suppression",
+			domainErrors.RepositoryInternal,
+			"failed to delete email suppression",
+			err,
+		)
+	}
+	return nil
+}
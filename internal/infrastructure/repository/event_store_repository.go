@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
+)
+
+// EventStoreCollection is the storage collection holding the event store
+const EventStoreCollection = "event_store_records"
+
+// Stored fields of events, filtered on by key range listings
+const (
+	eventStoreTypeField        = "type"
+	eventStoreAggregateIDField = "aggregateId"
+	eventStoreOccurredAtField  = "occurredAt"
+	eventStoreTenantIDField    = "headers." + entity.StoredEventTenantHeader
+)
+
+// EventStoreRepositoryImpl implements the EventStoreRepository interface using a storage backend
+type EventStoreRepositoryImpl struct {
+	storage storage.Storage
+}
+
+// NewEventStoreRepository creates a new event store repository with the given storage backend
+// The storage must support sequences (see storage.Sequencer)
+func NewEventStoreRepository(storage storage.Storage) repository.EventStoreRepository {
+	return &EventStoreRepositoryImpl{
+		storage: storage,
+	}
+}
+
+// NextSequence allocates the sequence number of the next event
+func (r *EventStoreRepositoryImpl) NextSequence() (int64, error) {
+	sequence, err := storage.NextSequence(r.storage)
+	if err != nil {
+		return 0, domainErrors.NewRepositoryError(
+			"next_event_sequence",
+			domainErrors.RepositoryInternal,
+			"failed to allocate event sequence",
+			err,
+		)
+	}
+	return sequence, nil
+}
+
+// Append persists an event keyed by its zero-padded sequence
+func (r *EventStoreRepositoryImpl) Append(event *entity.StoredEvent) error {
+	if err := r.storage.Store(eventStoreKey(event.Sequence()), event); err != nil {
+		return domainErrors.NewRepositoryError(
+			"append_event",
+			domainErrors.RepositoryInternal,
+			"failed to append event",
+			err,
+		)
+	}
+	return nil
+}
+
+// ListAfterSequence retrieves up to limit events matching filter with a sequence greater than sequence
+// Backends listing by key range read from the key of sequence on; others are listed in full
+func (r *EventStoreRepositoryImpl) ListAfterSequence(sequence int64, filter repository.StoredEventFilter, limit int) ([]*entity.StoredEvent, error) {
+	if lister, ok := r.storage.(storage.KeyRangeLister); ok {
+		return r.listKeyRange(lister, eventStoreQuery(filter, limit), eventStoreKey(sequence))
+	}
+	return r.list(filter, limit, func(event *entity.StoredEvent) bool {
+		return event.Sequence() > sequence
+	})
+}
+
+// ListSince retrieves up to limit events matching filter that occurred after since
+func (r *EventStoreRepositoryImpl) ListSince(since time.Time, filter repository.StoredEventFilter, limit int) ([]*entity.StoredEvent, error) {
+	if lister, ok := r.storage.(storage.KeyRangeLister); ok {
+		query := eventStoreQuery(filter, limit)
+		query.TimeField = eventStoreOccurredAtField
+		query.After = since
+		return r.listKeyRange(lister, query, "")
+	}
+	return r.list(filter, limit, func(event *entity.StoredEvent) bool {
+		return event.OccurredAt().After(since)
+	})
+}
+
+// listKeyRange returns the events matching query keyed after the key after, in sequence order
+func (r *EventStoreRepositoryImpl) listKeyRange(lister storage.KeyRangeLister, query storage.Query, after string) ([]*entity.StoredEvent, error) {
+	values, err := lister.ListKeyRange(query, after)
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"list_events",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve events",
+			err,
+		)
+	}
+	return decodeStoredEvents(values, repository.StoredEventFilter{}, func(*entity.StoredEvent) bool { return true })
+}
+
+// list returns events matching filter and matches in sequence order, truncated to limit, for backends without
+// key range listing
+func (r *EventStoreRepositoryImpl) list(filter repository.StoredEventFilter, limit int, matches func(*entity.StoredEvent) bool) ([]*entity.StoredEvent, error) {
+	values, err := r.storage.ListAll()
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"list_events",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve events",
+			err,
+		)
+	}
+
+	events, err := decodeStoredEvents(values, filter, matches)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Sequence() < events[j].Sequence()
+	})
+
+	if limit > 0 && len(events) > limit {
+		events = events[:limit]
+	}
+	return events, nil
+}
+
+// decodeStoredEvents decodes stored events, keeping the ones matching filter and matches in their stored order
+func decodeStoredEvents(values []interface{}, filter repository.StoredEventFilter, matches func(*entity.StoredEvent) bool) ([]*entity.StoredEvent, error) {
+	events := make([]*entity.StoredEvent, 0, len(values))
+	for _, value := range values {
+		event, err := decodeStoredValue[entity.StoredEvent](value)
+		if err != nil {
+			return nil, domainErrors.NewRepositoryError(
+				"deserialize_event",
+				domainErrors.RepositoryInternal,
+				"failed to deserialize event",
+				err,
+			)
+		}
+		if filter.Type != "" && event.Type() != filter.Type {
+			continue
+		}
+		if filter.AggregateID != "" && event.AggregateID() != filter.AggregateID {
+			continue
+		}
+		if filter.TenantID != "" && event.TenantID() != filter.TenantID {
+			continue
+		}
+		if matches(event) {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+// eventStoreQuery is the storage query of the events matching filter, truncated to limit
+func eventStoreQuery(filter repository.StoredEventFilter, limit int) storage.Query {
+	matching := make(map[string]string)
+	if filter.Type != "" {
+		matching[eventStoreTypeField] = filter.Type
+	}
+	if filter.AggregateID != "" {
+		matching[eventStoreAggregateIDField] = filter.AggregateID
+	}
+	if filter.TenantID != "" {
+		matching[eventStoreTenantIDField] = filter.TenantID
+	}
+	return storage.Query{Matching: matching, Limit: limit}
+}
+
+// eventStoreKey is the storage key of the event with a sequence; zero-padding makes key order sequence order
+func eventStoreKey(sequence int64) string {
+	return fmt.Sprintf("%020d", sequence)
+}
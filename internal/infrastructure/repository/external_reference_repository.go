@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
+)
+
+// ExternalReferenceCollection is the storage collection indexing client and invoice external references per tenant
+const ExternalReferenceCollection = "external_reference_records"
+
+// ExternalReferenceRepositoryImpl implements the ExternalReferenceRepository interface using a storage backend
+type ExternalReferenceRepositoryImpl struct {
+	storage storage.Storage
+}
+
+// NewExternalReferenceRepository creates a new external reference repository with the given storage backend
+func NewExternalReferenceRepository(storage storage.Storage) repository.ExternalReferenceRepository {
+	return &ExternalReferenceRepositoryImpl{
+		storage: storage,
+	}
+}
+
+// Save persists a reference keyed by kind, tenant and reference
+func (r *ExternalReferenceRepositoryImpl) Save(reference *entity.ExternalReference) error {
+	if err := r.storage.Store(reference.Key(), reference); err != nil {
+		return domainErrors.NewRepositoryError(
+			"save_external_reference",
+			domainErrors.RepositoryInternal,
+			"failed to save external reference",
+			err,
+		)
+	}
+	return nil
+}
+
+// Get retrieves the reference of a kind in a tenant
+func (r *ExternalReferenceRepositoryImpl) Get(kind entity.ExternalReferenceKind, tenantID, reference string) (*entity.ExternalReference, error) {
+	value, err := r.storage.Get(entity.ExternalReferenceKey(kind, tenantID, reference))
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return nil, domainErrors.ErrExternalReferenceNotFound
+		}
+
+		return nil, domainErrors.NewRepositoryError(
+			"get_external_reference",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve external reference",
+			err,
+		)
+	}
+
+	stored, err := decodeStoredValue[entity.ExternalReference](value)
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"deserialize_external_reference",
+			domainErrors.RepositoryInternal,
+			"failed to deserialize external reference",
+			err,
+		)
+	}
+	return stored, nil
+}
+
+// Delete removes the reference of a kind in a tenant
+func (r *ExternalReferenceRepositoryImpl) Delete(kind entity.ExternalReferenceKind, tenantID, reference string) error {
+	if err := r.storage.Delete(entity.ExternalReferenceKey(kind, tenantID, reference)); err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return domainErrors.ErrExternalReferenceNotFound
+		}
+
+		return domainErrors.NewRepositoryError(
+			"delete_external_reference",
+			domainErrors.RepositoryInternal,
+			"failed to delete external reference",
+			err,
+		)
+	}
+	return nil
+}
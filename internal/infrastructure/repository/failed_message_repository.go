@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
+)
+
+// FailedMessageCollection is the storage collection holding the message bus publications that failed
+const FailedMessageCollection = "failed_message_records"
+
+// FailedMessageRepositoryImpl implements the FailedMessageRepository interface using a storage backend
+type FailedMessageRepositoryImpl struct {
+	storage storage.Storage
+}
+
+// NewFailedMessageRepository creates a new failed message repository with the given storage backend
+func NewFailedMessageRepository(storage storage.Storage) repository.FailedMessageRepository {
+	return &FailedMessageRepositoryImpl{
+		storage: storage,
+	}
+}
+
+// Save persists a failed message keyed by its ID
+func (r *FailedMessageRepositoryImpl) Save(message *entity.FailedMessage) error {
+	if err := r.storage.Store(message.ID(), message); err != nil {
+		return domainErrors.NewRepositoryError(
+			"save_failed_message",
+			domainErrors.RepositoryInternal,
+			"failed to save failed message",
+			err,
+		)
+	}
+	return nil
+}
+
+// GetByID retrieves a failed message by its ID
+func (r *FailedMessageRepositoryImpl) GetByID(id string) (*entity.FailedMessage, error) {
+	value, err := r.storage.Get(id)
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return nil, domainErrors.ErrFailedMessageNotFound
+		}
+		return nil, domainErrors.NewRepositoryError(
+			"get_failed_message",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve failed message",
+			err,
+		)
+	}
+
+	message, err := decodeStoredValue[entity.FailedMessage](value)
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"deserialize_failed_message",
+			domainErrors.RepositoryInternal,
+			"failed to deserialize failed message",
+			err,
+		)
+	}
+	return message, nil
+}
+
+// GetAll retrieves all failed messages ordered by failure time
+func (r *FailedMessageRepositoryImpl) GetAll() ([]*entity.FailedMessage, error) {
+	values, err := r.storage.ListAll()
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"get_all_failed_messages",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve failed messages",
+			err,
+		)
+	}
+
+	messages := make([]*entity.FailedMessage, 0, len(values))
+	for _, value := range values {
+		message, err := decodeStoredValue[entity.FailedMessage](value)
+		if err != nil {
+			return nil, domainErrors.NewRepositoryError(
+				"deserialize_failed_message",
+				domainErrors.RepositoryInternal,
+				"failed to deserialize failed message",
+				err,
+			)
+		}
+		messages = append(messages, message)
+	}
+
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].FailedAt().Before(messages[j].FailedAt())
+	})
+
+	return messages, nil
+}
+
+// Delete removes a failed message by its ID
+func (r *FailedMessageRepositoryImpl) Delete(id string) error {
+	if err := r.storage.Delete(id); err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return domainErrors.ErrFailedMessageNotFound
+		}
+
+		return domainErrors.NewRepositoryError(
+			"delete_failed_message",
+			domainErrors.RepositoryInternal,
+			"failed to delete failed message",
+			err,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
+)
+
+// FiscalCalendarCollection is the storage collection holding tenant fiscal calendars
+const FiscalCalendarCollection = "fiscal_calendar_records"
+
+// FiscalCalendarRepositoryImpl implements the FiscalCalendarRepository interface using a storage backend
+type FiscalCalendarRepositoryImpl struct {
+	storage storage.Storage
+}
+
+// NewFiscalCalendarRepository creates a new fiscal calendar repository with the given storage backend
+func NewFiscalCalendarRepository(storage storage.Storage) repository.FiscalCalendarRepository {
+	return &FiscalCalendarRepositoryImpl{
+		storage: storage,
+	}
+}
+
+// Save persists a calendar keyed by tenant ID
+func (r *FiscalCalendarRepositoryImpl) Save(calendar *entity.FiscalCalendar) error {
+	if err := r.storage.Store(calendar.TenantID(), calendar); err != nil {
+		return domainErrors.NewRepositoryError(
+			"save_fiscal_calendar",
+			domainErrors.RepositoryInternal,
+			"failed to save fiscal calendar",
+			err,
+		)
+	}
+	return nil
+}
+
+// GetByTenantID retrieves the calendar of a tenant
+func (r *FiscalCalendarRepositoryImpl) GetByTenantID(tenantID string) (*entity.FiscalCalendar, error) {
+	value, err := r.storage.Get(tenantID)
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return nil, domainErrors.ErrFiscalCalendarNotFound
+		}
+
+		return nil, domainErrors.NewRepositoryError(
+			"get_fiscal_calendar",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve fiscal calendar",
+			err,
+		)
+	}
+
+	calendar, err := decodeStoredValue[entity.FiscalCalendar](value)
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"deserialize_fiscal_calendar",
+			domainErrors.RepositoryInternal,
+			"failed to deserialize fiscal calendar",
+			err,
+		)
+	}
+	return calendar, nil
+}
+
+// GetAll retrieves all calendars ordered by tenant ID
+func (r *FiscalCalendarRepositoryImpl) GetAll() ([]*entity.FiscalCalendar, error) {
+	values, err := r.storage.ListAll()
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"get_all_fiscal_calendars",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve fiscal calendars",
+			err,
+		)
+	}
+
+	calendars := make([]*entity.FiscalCalendar, 0, len(values))
+	for _, value := range values {
+		calendar, err := decodeStoredValue[entity.FiscalCalendar](value)
+		if err != nil {
+			return nil, domainErrors.NewRepositoryError(
+				"deserialize_fiscal_calendar",
+				domainErrors.RepositoryInternal,
+				"failed to deserialize fiscal calendar",
+				err,
+			)
+		}
+		calendars = append(calendars, calendar)
+	}
+
+	sort.Slice(calendars, func(i, j int) bool {
+		return calendars[i].TenantID() < calendars[j].TenantID()
+	})
+
+	return calendars, nil
+}
+
+// Delete removes the calendar of a tenant
+func (r *FiscalCalendarRepositoryImpl) Delete(tenantID string) error {
+	if err := r.storage.Delete(tenantID); err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return domainErrors.ErrFiscalCalendarNotFound
+		}
+
+		return domainErrors.NewRepositoryError(
+			"delete_fiscal_calendar",
+			domainErrors.RepositoryInternal,
+			"failed to delete fiscal calendar",
+			err,
+		)
+	}
+	return nil
+}
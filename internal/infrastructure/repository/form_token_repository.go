@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
+)
+
+// FormTokenCollection is the storage collection holding issued form tokens
+const FormTokenCollection = "form_token_records"
+
+// FormTokenRepositoryImpl implements the FormTokenRepository interface using a storage backend
+type FormTokenRepositoryImpl struct {
+	storage storage.Storage
+}
+
+// NewFormTokenRepository creates a new form token repository with the given storage backend
+func NewFormTokenRepository(storage storage.Storage) repository.FormTokenRepository {
+	return &FormTokenRepositoryImpl{
+		storage: storage,
+	}
+}
+
+// Save persists an issued token keyed by its value
+func (r *FormTokenRepositoryImpl) Save(token *entity.FormToken) error {
+	if err := r.storage.Store(token.Token(), token); err != nil {
+		return domainErrors.NewRepositoryError(
+			"save_form_token",
+			domainErrors.RepositoryInternal,
+			"failed to save form token",
+			err,
+		)
+	}
+	return nil
+}
+
+// Consume reads and deletes a token; the delete is the atomic step deciding the single winner
+func (r *FormTokenRepositoryImpl) Consume(token string) (*entity.FormToken, error) {
+	value, err := r.storage.Get(token)
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return nil, domainErrors.ErrFormTokenInvalid
+		}
+		return nil, domainErrors.NewRepositoryError(
+			"get_form_token",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve form token",
+			err,
+		)
+	}
+
+	if err := r.storage.Delete(token); err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			// A concurrent submission consumed the token first
+			return nil, domainErrors.ErrFormTokenInvalid
+		}
+		return nil, domainErrors.NewRepositoryError(
+			"consume_form_token",
+			domainErrors.RepositoryInternal,
+			"failed to consume form token",
+			err,
+		)
+	}
+
+	formToken, err := decodeStoredValue[entity.FormToken](value)
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"deserialize_form_token",
+			domainErrors.RepositoryInternal,
+			"failed to deserialize form token",
+			err,
+		)
+	}
+	return formToken, nil
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
+)
+
+// IntegrationLogCollection is the storage collection holding the logs of outbound integration calls
+const IntegrationLogCollection = "integration_logs"
+
+// IntegrationLogRepositoryImpl implements the IntegrationLogRepository interface using a storage backend
+type IntegrationLogRepositoryImpl struct {
+	storage storage.Storage
+}
+
+// NewIntegrationLogRepository creates a new integration log repository with the given storage backend
+func NewIntegrationLogRepository(storage storage.Storage) repository.IntegrationLogRepository {
+	return &IntegrationLogRepositoryImpl{
+		storage: storage,
+	}
+}
+
+// Save persists an integration log keyed by its ID
+func (r *IntegrationLogRepositoryImpl) Save(log *entity.IntegrationLog) error {
+	if err := r.storage.Store(log.ID(), log); err != nil {
+		return domainErrors.NewRepositoryError(
+			"save_integration_log",
+			domainErrors.RepositoryInternal,
+			"failed to save integration log",
+			err,
+		)
+	}
+	return nil
+}
+
+// GetByID retrieves an integration log by its ID
+func (r *IntegrationLogRepositoryImpl) GetByID(id string) (*entity.IntegrationLog, error) {
+	value, err := r.storage.Get(id)
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return nil, domainErrors.ErrIntegrationLogNotFound
+		}
+		return nil, domainErrors.NewRepositoryError(
+			"get_integration_log",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve integration log",
+			err,
+		)
+	}
+
+	log, err := decodeStoredValue[entity.IntegrationLog](value)
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"deserialize_integration_log",
+			domainErrors.RepositoryInternal,
+			"failed to deserialize integration log",
+			err,
+		)
+	}
+	return log, nil
+}
+
+// GetAll retrieves all integration logs, most recent first
+func (r *IntegrationLogRepositoryImpl) GetAll() ([]*entity.IntegrationLog, error) {
+	values, err := r.storage.ListAll()
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"get_all_integration_logs",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve integration logs",
+			err,
+		)
+	}
+
+	logs := make([]*entity.IntegrationLog, 0, len(values))
+	for _, value := range values {
+		log, err := decodeStoredValue[entity.IntegrationLog](value)
+		if err != nil {
+			return nil, domainErrors.NewRepositoryError(
+				"deserialize_integration_log",
+				domainErrors.RepositoryInternal,
+				"failed to deserialize integration log",
+				err,
+			)
+		}
+		logs = append(logs, log)
+	}
+
+	sort.SliceStable(logs, func(i, j int) bool {
+		return logs[i].CreatedAt().After(logs[j].CreatedAt())
+	})
+	return logs, nil
+}
+
+// Delete removes an integration log
+func (r *IntegrationLogRepositoryImpl) Delete(id string) error {
+	if err := r.storage.Delete(id); err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return domainErrors.ErrIntegrationLogNotFound
+		}
+
+		return domainErrors.NewRepositoryError(
+			"delete_integration_log",
+			domainErrors.RepositoryInternal,
+			"failed to delete integration log",
+			err,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
+)
+
+// InvoiceArchiveCollection is the storage collection holding archived invoices
+const InvoiceArchiveCollection = "invoice_archive_records"
+
+// InvoiceArchiveRepositoryImpl implements the InvoiceArchiveRepository interface using a storage backend
+// Any storage backend works as the archive, e.g. a collection of the database or an object storage bucket
+type InvoiceArchiveRepositoryImpl struct {
+	storage storage.Storage
+}
+
+// NewInvoiceArchiveRepository creates a new invoice archive repository with the given storage backend
+func NewInvoiceArchiveRepository(storage storage.Storage) repository.InvoiceArchiveRepository {
+	return &InvoiceArchiveRepositoryImpl{
+		storage: storage,
+	}
+}
+
+// Save persists an archived invoice keyed by its invoice ID
+func (r *InvoiceArchiveRepositoryImpl) Save(archived *entity.ArchivedInvoice) error {
+	if err := r.storage.Store(archived.InvoiceID(), archived); err != nil {
+		return domainErrors.NewRepositoryError(
+			"save_archived_invoice",
+			domainErrors.RepositoryInternal,
+			"failed to save archived invoice",
+			err,
+		)
+	}
+	return nil
+}
+
+// GetByInvoiceID retrieves an archived invoice by its invoice ID
+func (r *InvoiceArchiveRepositoryImpl) GetByInvoiceID(invoiceID string) (*entity.ArchivedInvoice, error) {
+	value, err := r.storage.Get(invoiceID)
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return nil, domainErrors.ErrArchivedInvoiceNotFound
+		}
+		return nil, domainErrors.NewRepositoryError(
+			"get_archived_invoice",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve archived invoice",
+			err,
+		)
+	}
+
+	archived, err := decodeStoredValue[entity.ArchivedInvoice](value)
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"deserialize_archived_invoice",
+			domainErrors.RepositoryInternal,
+			"failed to deserialize archived invoice",
+			err,
+		)
+	}
+	return archived, nil
+}
+
+// Delete removes an archived invoice by its invoice ID
+func (r *InvoiceArchiveRepositoryImpl) Delete(invoiceID string) error {
+	if err := r.storage.Delete(invoiceID); err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return domainErrors.ErrArchivedInvoiceNotFound
+		}
+
+		return domainErrors.NewRepositoryError(
+			"delete_archived_invoice",
+			domainErrors.RepositoryInternal,
+			"failed to delete archived invoice",
+			err,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"sort"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
+)
+
+// InvoiceDeliveryEventCollection is the storage collection holding invoice delivery events
+const InvoiceDeliveryEventCollection = "invoice_delivery_event_records"
+
+// InvoiceDeliveryEventRepositoryImpl implements the InvoiceDeliveryEventRepository interface using a storage backend
+type InvoiceDeliveryEventRepositoryImpl struct {
+	storage storage.Storage
+}
+
+// NewInvoiceDeliveryEventRepository creates a new invoice delivery event repository with the given storage backend
+func NewInvoiceDeliveryEventRepository(storage storage.Storage) repository.InvoiceDeliveryEventRepository {
+	return &InvoiceDeliveryEventRepositoryImpl{
+		storage: storage,
+	}
+}
+
+// Append persists a new delivery event
+func (r *InvoiceDeliveryEventRepositoryImpl) Append(event *entity.InvoiceDeliveryEvent) error {
+	if err := r.storage.Store(event.ID(), event); err != nil {
+		return domainErrors.NewRepositoryError(
+			"append_invoice_delivery_event",
+			domainErrors.RepositoryInternal,
+			"failed to append invoice delivery event",
+			err,
+		)
+	}
+	return nil
+}
+
+// ListByInvoice retrieves the delivery events of an invoice, oldest first
+func (r *InvoiceDeliveryEventRepositoryImpl) ListByInvoice(invoiceID string) ([]*entity.InvoiceDeliveryEvent, error) {
+	values, err := r.storage.ListAll()
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"list_invoice_delivery_events",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve invoice delivery events",
+			err,
+		)
+	}
+
+	events := make([]*entity.InvoiceDeliveryEvent, 0)
+	for _, value := range values {
+		event, err := decodeStoredValue[entity.InvoiceDeliveryEvent](value)
+		if err != nil {
+			return nil, domainErrors.NewRepositoryError(
+				"deserialize_invoice_delivery_event",
+				domainErrors.RepositoryInternal,
+				"failed to deserialize invoice delivery event",
+				err,
+			)
+		}
+		if event.InvoiceID() == invoiceID {
+			events = append(events, event)
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].OccurredAt().Equal(events[j].OccurredAt()) {
+			return events[i].RecordedAt().Before(events[j].RecordedAt())
+		}
+		return events[i].OccurredAt().Before(events[j].OccurredAt())
+	})
+
+	return events, nil
+}
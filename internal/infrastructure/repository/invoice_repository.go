@@ -0,0 +1,302 @@
+package repository
+
+import (
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
+)
+
+// InvoiceCollection is the storage collection holding invoices
+const InvoiceCollection = "invoice_records"
+
+// InvoiceRepositoryImpl implements the InvoiceRepository interface using a storage backend
+type InvoiceRepositoryImpl struct {
+	storage storage.Storage
+}
+
+// NewInvoiceRepository creates a new invoice repository with the given storage backend
+func NewInvoiceRepository(storage storage.Storage) repository.InvoiceRepository {
+	return &InvoiceRepositoryImpl{
+		storage: storage,
+	}
+}
+
+// Save persists an invoice keyed by its ID
+func (r *InvoiceRepositoryImpl) Save(invoice *entity.Invoice) error {
+	if err := r.storage.Store(invoice.ID(), invoice); err != nil {
+		return domainErrors.NewRepositoryError(
+			"save_invoice",
+			domainErrors.RepositoryInternal,
+			"failed to save invoice",
+			err,
+		)
+	}
+	return nil
+}
+
+// GetByID retrieves an invoice by its ID
+func (r *InvoiceRepositoryImpl) GetByID(id string) (*entity.Invoice, error) {
+	value, err := r.storage.Get(id)
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return nil, domainErrors.ErrInvoiceNotFound
+		}
+		return nil, domainErrors.NewRepositoryError(
+			"get_invoice",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve invoice",
+			err,
+		)
+	}
+
+	invoice, err := decodeStoredValue[entity.Invoice](value)
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"deserialize_invoice",
+			domainErrors.RepositoryInternal,
+			"failed to deserialize invoice",
+			err,
+		)
+	}
+	return invoice, nil
+}
+
+// GetAll retrieves all invoices, oldest first
+func (r *InvoiceRepositoryImpl) GetAll() ([]*entity.Invoice, error) {
+	values, err := r.storage.ListAll()
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"get_all_invoices",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve invoices",
+			err,
+		)
+	}
+
+	invoices := make([]*entity.Invoice, 0, len(values))
+	for _, value := range values {
+		invoice, err := decodeStoredValue[entity.Invoice](value)
+		if err != nil {
+			return nil, domainErrors.NewRepositoryError(
+				"deserialize_invoice",
+				domainErrors.RepositoryInternal,
+				"failed to deserialize invoice",
+				err,
+			)
+		}
+		invoices = append(invoices, invoice)
+	}
+
+	sort.SliceStable(invoices, func(i, j int) bool {
+		return invoices[i].CreatedAt().Before(invoices[j].CreatedAt())
+	})
+
+	return invoices, nil
+}
+
+// Persisted invoice fields listings filter on
+const (
+	invoiceTenantIDField    = "tenantId"
+	invoiceClientIDField    = "clientId"
+	invoiceStatusField      = "status"
+	invoiceExternalRefField = "externalRef"
+	invoiceCreatedAtField   = "createdAt"
+)
+
+// ListAfter retrieves up to limit invoices matching the filter that come after a position in creation order
+// Backends able to seek (PostgreSQL) filter in the database and read only the page; others load, match and order
+// every invoice by its creation time
+func (r *InvoiceRepositoryImpl) ListAfter(filter repository.InvoiceListFilter, after repository.InvoicePosition, limit int) (*repository.InvoicePage, error) {
+	if err := checkPageLimit(limit); err != nil {
+		return nil, err
+	}
+	filter.ReferenceSearch = strings.TrimSpace(filter.ReferenceSearch)
+
+	// One extra invoice tells whether more come after the page
+	var invoices []*entity.Invoice
+	var positions []repository.InvoicePosition
+	if seeker, ok := r.storage.(storage.SeekLister); ok {
+		values, storagePositions, err := seeker.ListAfter(invoiceQuery(filter, limit+1), storage.Position{CreatedAt: after.CreatedAt, Key: after.ID})
+		if err != nil {
+			return nil, domainErrors.NewRepositoryError(
+				"list_invoices",
+				domainErrors.RepositoryInternal,
+				"failed to retrieve invoices",
+				err,
+			)
+		}
+		for _, value := range values {
+			invoice, err := decodeStoredValue[entity.Invoice](value)
+			if err != nil {
+				return nil, domainErrors.NewRepositoryError(
+					"deserialize_invoice",
+					domainErrors.RepositoryInternal,
+					"failed to deserialize invoice",
+					err,
+				)
+			}
+			invoices = append(invoices, invoice)
+		}
+		for _, position := range storagePositions {
+			positions = append(positions, repository.InvoicePosition{CreatedAt: position.CreatedAt, ID: position.Key})
+		}
+	} else {
+		all, err := r.GetAll()
+		if err != nil {
+			return nil, err
+		}
+		slices.SortFunc(all, func(a, b *entity.Invoice) int {
+			return compareInvoicePositions(invoicePosition(a), invoicePosition(b))
+		})
+		for _, invoice := range all {
+			if len(invoices) > limit {
+				break
+			}
+			position := invoicePosition(invoice)
+			if invoiceMatches(invoice, filter) && (after.IsZero() || compareInvoicePositions(position, after) > 0) {
+				invoices = append(invoices, invoice)
+				positions = append(positions, position)
+			}
+		}
+	}
+
+	page := &repository.InvoicePage{Invoices: invoices, Next: after}
+	if len(invoices) > limit {
+		page.Invoices = invoices[:limit]
+		page.HasMore = true
+	}
+	if len(page.Invoices) > 0 {
+		page.Next = positions[len(page.Invoices)-1]
+	}
+	return page, nil
+}
+
+// invoicePosition returns the position of a loaded invoice in creation order
+func invoicePosition(invoice *entity.Invoice) repository.InvoicePosition {
+	return repository.InvoicePosition{CreatedAt: invoice.CreatedAt(), ID: invoice.ID()}
+}
+
+// compareInvoicePositions compares two positions in creation order
+func compareInvoicePositions(a, b repository.InvoicePosition) int {
+	if result := a.CreatedAt.Compare(b.CreatedAt); result != 0 {
+		return result
+	}
+	return strings.Compare(a.ID, b.ID)
+}
+
+// invoiceQuery converts an invoice listing filter to a storage query on the persisted invoice fields
+func invoiceQuery(filter repository.InvoiceListFilter, limit int) storage.Query {
+	query := storage.Query{
+		Matching:  map[string]string{},
+		Excluding: map[string]string{},
+		TimeField: invoiceCreatedAtField,
+		After:     filter.CreatedAfter,
+		Limit:     limit,
+	}
+	if filter.TenantID != "" || filter.TenantScoped {
+		query.Matching[invoiceTenantIDField] = filter.TenantID
+	}
+	if filter.ClientID != "" {
+		query.Matching[invoiceClientIDField] = filter.ClientID
+	}
+	if filter.Status != "" {
+		query.Matching[invoiceStatusField] = string(filter.Status)
+	}
+	if filter.ExcludeStatus != "" {
+		query.Excluding[invoiceStatusField] = string(filter.ExcludeStatus)
+	}
+	if filter.ReferenceSearch != "" {
+		query.SearchFields = []string{invoiceExternalRefField}
+		query.Search = filter.ReferenceSearch
+	}
+	return query
+}
+
+// invoiceMatches checks a loaded invoice against a listing filter, like invoiceQuery does in the database
+func invoiceMatches(invoice *entity.Invoice, filter repository.InvoiceListFilter) bool {
+	if (filter.TenantID != "" || filter.TenantScoped) && invoice.TenantID() != filter.TenantID {
+		return false
+	}
+	if filter.ClientID != "" && invoice.ClientID() != filter.ClientID {
+		return false
+	}
+	if filter.Status != "" && invoice.Status() != filter.Status {
+		return false
+	}
+	if filter.ExcludeStatus != "" && invoice.Status() == filter.ExcludeStatus {
+		return false
+	}
+	if filter.ReferenceSearch != "" &&
+		!strings.Contains(strings.ToLower(invoice.ExternalReference()), strings.ToLower(filter.ReferenceSearch)) {
+		return false
+	}
+	if !filter.CreatedAfter.IsZero() && !invoice.CreatedAt().After(filter.CreatedAfter) {
+		return false
+	}
+	return true
+}
+
+// UpdateNumbered allocates the next number of scope from the invoice counters and updates the invoice in one transaction
+func (r *InvoiceRepositoryImpl) UpdateNumbered(id, scope string, update func(invoice *entity.Invoice, number int64) error) (*entity.Invoice, error) {
+	var updated *entity.Invoice
+	err := storage.RunInTransaction(r.storage, func(tx storage.Storage) error {
+		number, err := storage.NextCounterValue(tx, scope)
+		if err != nil {
+			return domainErrors.NewRepositoryError(
+				"allocate_invoice_number",
+				domainErrors.RepositoryInternal,
+				"failed to allocate invoice number",
+				err,
+			)
+		}
+
+		txRepo := &InvoiceRepositoryImpl{storage: tx}
+		invoice, err := txRepo.GetByID(id)
+		if err != nil {
+			return err
+		}
+		if err := update(invoice, number); err != nil {
+			return err
+		}
+		if err := txRepo.Save(invoice); err != nil {
+			return err
+		}
+		updated = invoice
+		return nil
+	})
+	if err != nil {
+		if domainErrors.GetErrorCode(err) != "" {
+			return nil, err
+		}
+		return nil, domainErrors.NewRepositoryError(
+			"update_numbered_invoice",
+			domainErrors.RepositoryInternal,
+			"failed to update invoice",
+			err,
+		)
+	}
+	return updated, nil
+}
+
+// Delete removes an invoice by its ID
+func (r *InvoiceRepositoryImpl) Delete(id string) error {
+	if err := r.storage.Delete(id); err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return domainErrors.ErrInvoiceNotFound
+		}
+
+		return domainErrors.NewRepositoryError(
+			"delete_invoice",
+			domainErrors.RepositoryInternal,
+			"failed to delete invoice",
+			err,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
+)
+
+// IPAccessPolicyCollection is the storage collection holding tenant IP access policies
+const IPAccessPolicyCollection = "ip_access_policy_records"
+
+// IPAccessPolicyRepositoryImpl implements the IPAccessPolicyRepository interface using a storage backend
+type IPAccessPolicyRepositoryImpl struct {
+	storage storage.Storage
+}
+
+// NewIPAccessPolicyRepository creates a new IP access policy repository with the given storage backend
+func NewIPAccessPolicyRepository(storage storage.Storage) repository.IPAccessPolicyRepository {
+	return &IPAccessPolicyRepositoryImpl{
+		storage: storage,
+	}
+}
+
+// Save persists a policy keyed by tenant ID
+func (r *IPAccessPolicyRepositoryImpl) Save(policy *entity.IPAccessPolicy) error {
+	if err := r.storage.Store(policy.TenantID(), policy); err != nil {
+		return domainErrors.NewRepositoryError(
+			"save_ip_access_policy",
+			domainErrors.RepositoryInternal,
+			"failed to save IP access policy",
+			err,
+		)
+	}
+	return nil
+}
+
+// GetByTenantID retrieves the policy of a tenant
+func (r *IPAccessPolicyRepositoryImpl) GetByTenantID(tenantID string) (*entity.IPAccessPolicy, error) {
+	value, err := r.storage.Get(tenantID)
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return nil, domainErrors.ErrIPAccessPolicyNotFound
+		}
+
+		return nil, domainErrors.NewRepositoryError(
+			"get_ip_access_policy",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve IP access policy",
+			err,
+		)
+	}
+
+	policy, err := decodeStoredValue[entity.IPAccessPolicy](value)
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"deserialize_ip_access_policy",
+			domainErrors.RepositoryInternal,
+			"failed to deserialize IP access policy",
+			err,
+		)
+	}
+	return policy, nil
+}
+
+// GetAll retrieves all policies ordered by tenant ID
+func (r *IPAccessPolicyRepositoryImpl) GetAll() ([]*entity.IPAccessPolicy, error) {
+	values, err := r.storage.ListAll()
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"get_all_ip_access_policies",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve IP access policies",
+			err,
+		)
+	}
+
+	policies := make([]*entity.IPAccessPolicy, 0, len(values))
+	for _, value := range values {
+		policy, err := decodeStoredValue[entity.IPAccessPolicy](value)
+		if err != nil {
+			return nil, domainErrors.NewRepositoryError(
+				"deserialize_ip_access_policy",
+				domainErrors.RepositoryInternal,
+				"failed to deserialize IP access policy",
+				err,
+			)
+		}
+		policies = append(policies, policy)
+	}
+
+	sort.Slice(policies, func(i, j int) bool {
+		return policies[i].TenantID() < policies[j].TenantID()
+	})
+
+	return policies, nil
+}
+
+// Delete removes the policy of a tenant
+func (r *IPAccessPolicyRepositoryImpl) Delete(tenantID string) error {
+	if err := r.storage.Delete(tenantID); err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return domainErrors.ErrIPAccessPolicyNotFound
+		}
+
+		return domainErrors.NewRepositoryError(
+			"delete_ip_access_policy",
+			domainErrors.RepositoryInternal,
+			"failed to delete IP access policy",
+			err,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
+)
+
+// LegalEntityCollection is the storage collection holding the legal entities invoices are issued from
+const LegalEntityCollection = "legal_entity_records"
+
+// LegalEntityRepositoryImpl implements the LegalEntityRepository interface using a storage backend
+type LegalEntityRepositoryImpl struct {
+	storage storage.Storage
+}
+
+// NewLegalEntityRepository creates a new legal entity repository with the given storage backend
+func NewLegalEntityRepository(storage storage.Storage) repository.LegalEntityRepository {
+	return &LegalEntityRepositoryImpl{
+		storage: storage,
+	}
+}
+
+// Save persists a legal entity keyed by its ID
+func (r *LegalEntityRepositoryImpl) Save(legalEntity *entity.LegalEntity) error {
+	if err := r.storage.Store(legalEntity.ID(), legalEntity); err != nil {
+		return domainErrors.NewRepositoryError(
+			"save_legal_entity",
+			domainErrors.RepositoryInternal,
+			"failed to save legal entity",
+			err,
+		)
+	}
+	return nil
+}
+
+// GetByID retrieves a legal entity by its ID
+func (r *LegalEntityRepositoryImpl) GetByID(id string) (*entity.LegalEntity, error) {
+	value, err := r.storage.Get(id)
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return nil, domainErrors.ErrLegalEntityNotFound
+		}
+		return nil, domainErrors.NewRepositoryError(
+			"get_legal_entity",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve legal entity",
+			err,
+		)
+	}
+
+	legalEntity, err := decodeStoredValue[entity.LegalEntity](value)
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"deserialize_legal_entity",
+			domainErrors.RepositoryInternal,
+			"failed to deserialize legal entity",
+			err,
+		)
+	}
+	return legalEntity, nil
+}
+
+// GetAll retrieves all legal entities, oldest first
+func (r *LegalEntityRepositoryImpl) GetAll() ([]*entity.LegalEntity, error) {
+	values, err := r.storage.ListAll()
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"get_all_legal_entities",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve legal entities",
+			err,
+		)
+	}
+
+	legalEntities := make([]*entity.LegalEntity, 0, len(values))
+	for _, value := range values {
+		legalEntity, err := decodeStoredValue[entity.LegalEntity](value)
+		if err != nil {
+			return nil, domainErrors.NewRepositoryError(
+				"deserialize_legal_entity",
+				domainErrors.RepositoryInternal,
+				"failed to deserialize legal entity",
+				err,
+			)
+		}
+		legalEntities = append(legalEntities, legalEntity)
+	}
+
+	sort.SliceStable(legalEntities, func(i, j int) bool {
+		return legalEntities[i].CreatedAt().Before(legalEntities[j].CreatedAt())
+	})
+
+	return legalEntities, nil
+}
+
+// Delete removes a legal entity
+func (r *LegalEntityRepositoryImpl) Delete(id string) error {
+	if err := r.storage.Delete(id); err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return domainErrors.ErrLegalEntityNotFound
+		}
+
+		return domainErrors.NewRepositoryError(
+			"delete_legal_entity",
+			domainErrors.RepositoryInternal,
+			"failed to delete legal entity",
+			err,
+		)
+	}
+	return nil
+}
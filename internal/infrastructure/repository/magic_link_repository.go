@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
+)
+
+// MagicLinkCollection is the storage collection holding issued magic links
+const MagicLinkCollection = "magic_link_records"
+
+// magicLinkConsumedPath is the field of a stored link set once it is consumed
+const magicLinkConsumedPath = "consumedAt"
+
+// MagicLinkRepositoryImpl implements the MagicLinkRepository interface using a storage backend
+type MagicLinkRepositoryImpl struct {
+	storage storage.Storage
+}
+
+// NewMagicLinkRepository creates a new magic link repository with the given storage backend
+// The storage backend must support conditional updates (storage.ConditionalUpdater) to consume links
+func NewMagicLinkRepository(storage storage.Storage) repository.MagicLinkRepository {
+	return &MagicLinkRepositoryImpl{
+		storage: storage,
+	}
+}
+
+// Save persists a link keyed by its ID
+func (r *MagicLinkRepositoryImpl) Save(link *entity.MagicLink) error {
+	if err := r.storage.Store(link.ID(), link); err != nil {
+		return domainErrors.NewRepositoryError(
+			"save_magic_link",
+			domainErrors.RepositoryInternal,
+			"failed to save magic link",
+			err,
+		)
+	}
+	return nil
+}
+
+// GetByID retrieves a pending or consumed link by its ID
+func (r *MagicLinkRepositoryImpl) GetByID(id string) (*entity.MagicLink, error) {
+	value, err := r.storage.Get(id)
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return nil, domainErrors.ErrMagicLinkInvalid
+		}
+		return nil, domainErrors.NewRepositoryError(
+			"get_magic_link",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve magic link",
+			err,
+		)
+	}
+
+	link, err := decodeStoredValue[entity.MagicLink](value)
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"deserialize_magic_link",
+			domainErrors.RepositoryInternal,
+			"failed to deserialize magic link",
+			err,
+		)
+	}
+	return link, nil
+}
+
+// Consume marks a link as used with a single update conditioned on it not being consumed yet, so of concurrent
+// calls exactly one succeeds; the consumed link is kept so later attempts are reported as reuse
+func (r *MagicLinkRepositoryImpl) Consume(id string, at time.Time) (*entity.MagicLink, error) {
+	link, err := r.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if link.IsConsumed() {
+		return nil, domainErrors.ErrMagicLinkUsed
+	}
+
+	// The stored link is left untouched (in-memory storage hands out the stored instance)
+	consumed := *link
+	consumed.MarkConsumed(at)
+	if err := storage.UpdateIfUnset(r.storage, id, magicLinkConsumedPath, &consumed); err != nil {
+		if errors.Is(err, storage.ErrFieldSet) {
+			// A concurrent request consumed the link first
+			return nil, domainErrors.ErrMagicLinkUsed
+		}
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return nil, domainErrors.ErrMagicLinkInvalid
+		}
+		return nil, domainErrors.NewRepositoryError(
+			"consume_magic_link",
+			domainErrors.RepositoryInternal,
+			"failed to consume magic link",
+			err,
+		)
+	}
+	return &consumed, nil
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"errors"
+
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
+)
+
+// UploadChunkCollection is the storage collection holding the chunks of resumable uploads
+const UploadChunkCollection = "upload_chunk_records"
+
+// StorageObjectStore implements the ObjectStore port on a storage backend collection
+// Objects are stored base64-encoded in the JSON values, which suits chunks of a few megabytes
+type StorageObjectStore struct {
+	storage storage.Storage
+}
+
+// storedObject is the persisted form of an object
+type storedObject struct {
+	Data []byte `json:"data"`
+}
+
+// NewStorageObjectStore creates an object store on the given storage backend
+func NewStorageObjectStore(storage storage.Storage) repository.ObjectStore {
+	return &StorageObjectStore{
+		storage: storage,
+	}
+}
+
+// Put stores an object under key
+func (s *StorageObjectStore) Put(key string, data []byte) error {
+	if err := s.storage.Store(key, &storedObject{Data: data}); err != nil {
+		return domainErrors.NewRepositoryError(
+			"put_object",
+			domainErrors.RepositoryInternal,
+			"failed to store object",
+			err,
+		)
+	}
+	return nil
+}
+
+// Get retrieves the object stored under key
+func (s *StorageObjectStore) Get(key string) ([]byte, error) {
+	value, err := s.storage.Get(key)
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return nil, domainErrors.ErrObjectNotFound
+		}
+		return nil, domainErrors.NewRepositoryError(
+			"get_object",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve object",
+			err,
+		)
+	}
+
+	object, err := decodeStoredValue[storedObject](value)
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"deserialize_object",
+			domainErrors.RepositoryInternal,
+			"failed to deserialize object",
+			err,
+		)
+	}
+	return object.Data, nil
+}
+
+// Delete removes the object stored under key
+func (s *StorageObjectStore) Delete(key string) error {
+	if err := s.storage.Delete(key); err != nil && !errors.Is(err, storage.ErrKeyNotFound) {
+		return domainErrors.NewRepositoryError(
+			"delete_object",
+			domainErrors.RepositoryInternal,
+			"failed to delete object",
+			err,
+		)
+	}
+	return nil
+}
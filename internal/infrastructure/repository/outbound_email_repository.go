@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
+)
+
+// OutboundEmailCollection is the storage collection holding the emails of the outbox
+const OutboundEmailCollection = "email_outbox_records"
+
+// OutboundEmailRepositoryImpl implements the OutboundEmailRepository interface using a storage backend
+type OutboundEmailRepositoryImpl struct {
+	storage storage.Storage
+}
+
+// NewOutboundEmailRepository creates a new outbound email repository with the given storage backend
+func NewOutboundEmailRepository(storage storage.Storage) repository.OutboundEmailRepository {
+	return &OutboundEmailRepositoryImpl{
+		storage: storage,
+	}
+}
+
+// Save persists an outbound email keyed by its ID
+func (r *OutboundEmailRepositoryImpl) Save(email *entity.OutboundEmail) error {
+	if err := r.storage.Store(email.ID(), email); err != nil {
+		return domainErrors.NewRepositoryError(
+			"save_outbound_email",
+			domainErrors.RepositoryInternal,
+			"failed to save outbound email",
+			err,
+		)
+	}
+	return nil
+}
+
+// GetByID retrieves an outbound email by its ID
+func (r *OutboundEmailRepositoryImpl) GetByID(id string) (*entity.OutboundEmail, error) {
+	value, err := r.storage.Get(id)
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return nil, domainErrors.ErrOutboundEmailNotFound
+		}
+		return nil, domainErrors.NewRepositoryError(
+			"get_outbound_email",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve outbound email",
+			err,
+		)
+	}
+
+	email, err := decodeStoredValue[entity.OutboundEmail](value)
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"deserialize_outbound_email",
+			domainErrors.RepositoryInternal,
+			"failed to deserialize outbound email",
+			err,
+		)
+	}
+	return email, nil
+}
+
+// GetAll retrieves all outbound emails, oldest first
+func (r *OutboundEmailRepositoryImpl) GetAll() ([]*entity.OutboundEmail, error) {
+	values, err := r.storage.ListAll()
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"get_all_outbound_emails",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve outbound emails",
+			err,
+		)
+	}
+
+	emails := make([]*entity.OutboundEmail, 0, len(values))
+	for _, value := range values {
+		email, err := decodeStoredValue[entity.OutboundEmail](value)
+		if err != nil {
+			return nil, domainErrors.NewRepositoryError(
+				"deserialize_outbound_email",
+				domainErrors.RepositoryInternal,
+				"failed to deserialize outbound email",
+				err,
+			)
+		}
+		emails = append(emails, email)
+	}
+
+	sort.SliceStable(emails, func(i, j int) bool {
+		return emails[i].CreatedAt().Before(emails[j].CreatedAt())
+	})
+
+	return emails, nil
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
+)
+
+// PaymentCollection is the storage collection holding invoice payments
+const PaymentCollection = "payment_records"
+
+// PaymentRepositoryImpl implements the PaymentRepository interface using a storage backend
+type PaymentRepositoryImpl struct {
+	storage storage.Storage
+}
+
+// NewPaymentRepository creates a new payment repository with the given storage backend
+func NewPaymentRepository(storage storage.Storage) repository.PaymentRepository {
+	return &PaymentRepositoryImpl{
+		storage: storage,
+	}
+}
+
+// Save persists a payment keyed by its ID
+func (r *PaymentRepositoryImpl) Save(payment *entity.Payment) error {
+	if err := r.storage.Store(payment.ID(), payment); err != nil {
+		return domainErrors.NewRepositoryError(
+			"save_payment",
+			domainErrors.RepositoryInternal,
+			"failed to save payment",
+			err,
+		)
+	}
+	return nil
+}
+
+// ListByInvoice retrieves the payments of an invoice, oldest first
+func (r *PaymentRepositoryImpl) ListByInvoice(invoiceID string) ([]*entity.Payment, error) {
+	values, err := r.storage.ListAll()
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"list_payments",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve payments",
+			err,
+		)
+	}
+
+	payments := make([]*entity.Payment, 0)
+	for _, value := range values {
+		payment, err := decodeStoredValue[entity.Payment](value)
+		if err != nil {
+			return nil, domainErrors.NewRepositoryError(
+				"deserialize_payment",
+				domainErrors.RepositoryInternal,
+				"failed to deserialize payment",
+				err,
+			)
+		}
+		if payment.InvoiceID() == invoiceID {
+			payments = append(payments, payment)
+		}
+	}
+
+	sort.SliceStable(payments, func(i, j int) bool {
+		return payments[i].ReceivedAt().Before(payments[j].ReceivedAt())
+	})
+
+	return payments, nil
+}
+
+// Delete removes a payment by its ID
+func (r *PaymentRepositoryImpl) Delete(id string) error {
+	if err := r.storage.Delete(id); err != nil && !errors.Is(err, storage.ErrKeyNotFound) {
+		return domainErrors.NewRepositoryError(
+			"delete_payment",
+			domainErrors.RepositoryInternal,
+			"failed to delete payment",
+			err,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
+)
+
+// PayoutReconciliationCollection is the storage collection holding gateway payout discrepancy reports
+const PayoutReconciliationCollection = "payout_reconciliation_records"
+
+// PayoutReconciliationRepositoryImpl implements the PayoutReconciliationRepository interface using a storage backend
+type PayoutReconciliationRepositoryImpl struct {
+	storage storage.Storage
+}
+
+// NewPayoutReconciliationRepository creates a new payout reconciliation repository with the given storage backend
+func NewPayoutReconciliationRepository(storage storage.Storage) repository.PayoutReconciliationRepository {
+	return &PayoutReconciliationRepositoryImpl{
+		storage: storage,
+	}
+}
+
+// Save persists a payout report keyed by its payout ID
+func (r *PayoutReconciliationRepositoryImpl) Save(reconciliation *entity.PayoutReconciliation) error {
+	if err := r.storage.Store(reconciliation.PayoutID(), reconciliation); err != nil {
+		return domainErrors.NewRepositoryError(
+			"save_payout_reconciliation",
+			domainErrors.RepositoryInternal,
+			"failed to save payout reconciliation",
+			err,
+		)
+	}
+	return nil
+}
+
+// GetByPayoutID retrieves the report of a payout
+func (r *PayoutReconciliationRepositoryImpl) GetByPayoutID(payoutID string) (*entity.PayoutReconciliation, error) {
+	value, err := r.storage.Get(payoutID)
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return nil, domainErrors.ErrPayoutReconciliationNotFound
+		}
+		return nil, domainErrors.NewRepositoryError(
+			"get_payout_reconciliation",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve payout reconciliation",
+			err,
+		)
+	}
+
+	reconciliation, err := decodeStoredValue[entity.PayoutReconciliation](value)
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"deserialize_payout_reconciliation",
+			domainErrors.RepositoryInternal,
+			"failed to deserialize payout reconciliation",
+			err,
+		)
+	}
+	return reconciliation, nil
+}
+
+// GetAll retrieves all payout reports, most recent payout first
+func (r *PayoutReconciliationRepositoryImpl) GetAll() ([]*entity.PayoutReconciliation, error) {
+	values, err := r.storage.ListAll()
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"get_all_payout_reconciliations",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve payout reconciliations",
+			err,
+		)
+	}
+
+	reconciliations := make([]*entity.PayoutReconciliation, 0, len(values))
+	for _, value := range values {
+		reconciliation, err := decodeStoredValue[entity.PayoutReconciliation](value)
+		if err != nil {
+			return nil, domainErrors.NewRepositoryError(
+				"deserialize_payout_reconciliation",
+				domainErrors.RepositoryInternal,
+				"failed to deserialize payout reconciliation",
+				err,
+			)
+		}
+		reconciliations = append(reconciliations, reconciliation)
+	}
+
+	sort.SliceStable(reconciliations, func(i, j int) bool {
+		if !reconciliations[i].ArrivalDate().Equal(reconciliations[j].ArrivalDate()) {
+			return reconciliations[i].ArrivalDate().After(reconciliations[j].ArrivalDate())
+		}
+		return reconciliations[i].PayoutID() < reconciliations[j].PayoutID()
+	})
+
+	return reconciliations, nil
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
+)
+
+// ProcessedMessageCollection is the storage collection holding the records of processed messages
+const ProcessedMessageCollection = "processed_message_records"
+
+// ProcessedMessageRepositoryImpl implements the ProcessedMessageRepository interface using a storage backend
+type ProcessedMessageRepositoryImpl struct {
+	storage storage.Storage
+}
+
+// NewProcessedMessageRepository creates a new processed message repository with the given storage backend
+func NewProcessedMessageRepository(storage storage.Storage) repository.ProcessedMessageRepository {
+	return &ProcessedMessageRepositoryImpl{
+		storage: storage,
+	}
+}
+
+// Save persists a processed message record keyed by its message ID
+func (r *ProcessedMessageRepositoryImpl) Save(message *entity.ProcessedMessage) error {
+	if err := r.storage.Store(message.MessageID(), message); err != nil {
+		return domainErrors.NewRepositoryError(
+			"save_processed_message",
+			domainErrors.RepositoryInternal,
+			"failed to save processed message",
+			err,
+		)
+	}
+	return nil
+}
+
+// GetByMessageID retrieves the record of a message
+func (r *ProcessedMessageRepositoryImpl) GetByMessageID(messageID string) (*entity.ProcessedMessage, error) {
+	value, err := r.storage.Get(messageID)
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return nil, domainErrors.ErrProcessedMessageNotFound
+		}
+		return nil, domainErrors.NewRepositoryError(
+			"get_processed_message",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve processed message",
+			err,
+		)
+	}
+
+	message, err := decodeStoredValue[entity.ProcessedMessage](value)
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"deserialize_processed_message",
+			domainErrors.RepositoryInternal,
+			"failed to deserialize processed message",
+			err,
+		)
+	}
+	return message, nil
+}
+
+// GetAll retrieves all processed message records
+func (r *ProcessedMessageRepositoryImpl) GetAll() ([]*entity.ProcessedMessage, error) {
+	values, err := r.storage.ListAll()
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"get_all_processed_messages",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve processed messages",
+			err,
+		)
+	}
+
+	messages := make([]*entity.ProcessedMessage, 0, len(values))
+	for _, value := range values {
+		message, err := decodeStoredValue[entity.ProcessedMessage](value)
+		if err != nil {
+			return nil, domainErrors.NewRepositoryError(
+				"deserialize_processed_message",
+				domainErrors.RepositoryInternal,
+				"failed to deserialize processed message",
+				err,
+			)
+		}
+		messages = append(messages, message)
+	}
+	return messages, nil
+}
+
+// Delete removes the record of a message
+func (r *ProcessedMessageRepositoryImpl) Delete(messageID string) error {
+	if err := r.storage.Delete(messageID); err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return domainErrors.ErrProcessedMessageNotFound
+		}
+
+		return domainErrors.NewRepositoryError(
+			"delete_processed_message",
+			domainErrors.RepositoryInternal,
+			"failed to delete processed message",
+			err,
+		)
+	}
+	return nil
+}
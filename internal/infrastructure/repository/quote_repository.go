@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
+)
+
+// QuoteCollection is the storage collection holding quotes
+const QuoteCollection = "quote_records"
+
+// QuoteRepositoryImpl implements the QuoteRepository interface using a storage backend
+type QuoteRepositoryImpl struct {
+	storage storage.Storage
+}
+
+// NewQuoteRepository creates a new quote repository with the given storage backend
+func NewQuoteRepository(storage storage.Storage) repository.QuoteRepository {
+	return &QuoteRepositoryImpl{
+		storage: storage,
+	}
+}
+
+// Save persists a quote keyed by its ID
+func (r *QuoteRepositoryImpl) Save(quote *entity.Quote) error {
+	if err := r.storage.Store(quote.ID(), quote); err != nil {
+		return domainErrors.NewRepositoryError(
+			"save_quote",
+			domainErrors.RepositoryInternal,
+			"failed to save quote",
+			err,
+		)
+	}
+	return nil
+}
+
+// GetByID retrieves a quote by its ID
+func (r *QuoteRepositoryImpl) GetByID(id string) (*entity.Quote, error) {
+	value, err := r.storage.Get(id)
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return nil, domainErrors.ErrQuoteNotFound
+		}
+		return nil, domainErrors.NewRepositoryError(
+			"get_quote",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve quote",
+			err,
+		)
+	}
+
+	quote, err := decodeStoredValue[entity.Quote](value)
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"deserialize_quote",
+			domainErrors.RepositoryInternal,
+			"failed to deserialize quote",
+			err,
+		)
+	}
+	return quote, nil
+}
+
+// GetAll retrieves all quotes, oldest first
+func (r *QuoteRepositoryImpl) GetAll() ([]*entity.Quote, error) {
+	values, err := r.storage.ListAll()
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"get_all_quotes",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve quotes",
+			err,
+		)
+	}
+
+	quotes := make([]*entity.Quote, 0, len(values))
+	for _, value := range values {
+		quote, err := decodeStoredValue[entity.Quote](value)
+		if err != nil {
+			return nil, domainErrors.NewRepositoryError(
+				"deserialize_quote",
+				domainErrors.RepositoryInternal,
+				"failed to deserialize quote",
+				err,
+			)
+		}
+		quotes = append(quotes, quote)
+	}
+
+	sort.SliceStable(quotes, func(i, j int) bool {
+		return quotes[i].CreatedAt().Before(quotes[j].CreatedAt())
+	})
+
+	return quotes, nil
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
+)
+
+// RecurringInvoiceTemplateCollection is the storage collection holding recurring invoice templates
+const RecurringInvoiceTemplateCollection = "recurring_invoice_template_records"
+
+// RecurringInvoiceTemplateRepositoryImpl implements the RecurringInvoiceTemplateRepository interface using a storage backend
+type RecurringInvoiceTemplateRepositoryImpl struct {
+	storage storage.Storage
+}
+
+// NewRecurringInvoiceTemplateRepository creates a new recurring invoice template repository with the given storage backend
+func NewRecurringInvoiceTemplateRepository(storage storage.Storage) repository.RecurringInvoiceTemplateRepository {
+	return &RecurringInvoiceTemplateRepositoryImpl{
+		storage: storage,
+	}
+}
+
+// Save persists a recurring invoice template keyed by its ID
+func (r *RecurringInvoiceTemplateRepositoryImpl) Save(template *entity.RecurringInvoiceTemplate) error {
+	if err := r.storage.Store(template.ID(), template); err != nil {
+		return domainErrors.NewRepositoryError(
+			"save_recurring_invoice_template",
+			domainErrors.RepositoryInternal,
+			"failed to save recurring invoice template",
+			err,
+		)
+	}
+	return nil
+}
+
+// GetByID retrieves a recurring invoice template by its ID
+func (r *RecurringInvoiceTemplateRepositoryImpl) GetByID(id string) (*entity.RecurringInvoiceTemplate, error) {
+	value, err := r.storage.Get(id)
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return nil, domainErrors.ErrRecurringInvoiceTemplateNotFound
+		}
+		return nil, domainErrors.NewRepositoryError(
+			"get_recurring_invoice_template",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve recurring invoice template",
+			err,
+		)
+	}
+
+	template, err := decodeStoredValue[entity.RecurringInvoiceTemplate](value)
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"deserialize_recurring_invoice_template",
+			domainErrors.RepositoryInternal,
+			"failed to deserialize recurring invoice template",
+			err,
+		)
+	}
+	return template, nil
+}
+
+// GetAll retrieves all recurring invoice templates, oldest first
+func (r *RecurringInvoiceTemplateRepositoryImpl) GetAll() ([]*entity.RecurringInvoiceTemplate, error) {
+	values, err := r.storage.ListAll()
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"get_all_recurring_invoice_templates",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve recurring invoice templates",
+			err,
+		)
+	}
+
+	templates := make([]*entity.RecurringInvoiceTemplate, 0, len(values))
+	for _, value := range values {
+		template, err := decodeStoredValue[entity.RecurringInvoiceTemplate](value)
+		if err != nil {
+			return nil, domainErrors.NewRepositoryError(
+				"deserialize_recurring_invoice_template",
+				domainErrors.RepositoryInternal,
+				"failed to deserialize recurring invoice template",
+				err,
+			)
+		}
+		templates = append(templates, template)
+	}
+
+	sort.SliceStable(templates, func(i, j int) bool {
+		return templates[i].CreatedAt().Before(templates[j].CreatedAt())
+	})
+
+	return templates, nil
+}
+
+// Delete removes a recurring invoice template by its ID
+func (r *RecurringInvoiceTemplateRepositoryImpl) Delete(id string) error {
+	if err := r.storage.Delete(id); err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return domainErrors.ErrRecurringInvoiceTemplateNotFound
+		}
+
+		return domainErrors.NewRepositoryError(
+			"delete_recurring_invoice_template",
+			domainErrors.RepositoryInternal,
+			"failed to delete recurring invoice template",
+			err,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
+)
+
+// RiskAssessmentCollection is the storage collection holding the latest risk assessment of each client
+const RiskAssessmentCollection = "risk_assessment_records"
+
+// RiskAssessmentRepositoryImpl implements the RiskAssessmentRepository interface using a storage backend
+type RiskAssessmentRepositoryImpl struct {
+	storage storage.Storage
+}
+
+// NewRiskAssessmentRepository creates a new risk assessment repository with the given storage backend
+func NewRiskAssessmentRepository(storage storage.Storage) repository.RiskAssessmentRepository {
+	return &RiskAssessmentRepositoryImpl{
+		storage: storage,
+	}
+}
+
+// Save persists an assessment keyed by client ID
+func (r *RiskAssessmentRepositoryImpl) Save(assessment *entity.RiskAssessment) error {
+	if err := r.storage.Store(assessment.ClientID(), assessment); err != nil {
+		return domainErrors.NewRepositoryError(
+			"save_risk_assessment",
+			domainErrors.RepositoryInternal,
+			"failed to save risk assessment",
+			err,
+		)
+	}
+	return nil
+}
+
+// GetByClientID retrieves the latest assessment of a client
+func (r *RiskAssessmentRepositoryImpl) GetByClientID(clientID string) (*entity.RiskAssessment, error) {
+	value, err := r.storage.Get(clientID)
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return nil, domainErrors.ErrRiskAssessmentNotFound
+		}
+
+		return nil, domainErrors.NewRepositoryError(
+			"get_risk_assessment",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve risk assessment",
+			err,
+		)
+	}
+
+	assessment, err := decodeStoredValue[entity.RiskAssessment](value)
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"deserialize_risk_assessment",
+			domainErrors.RepositoryInternal,
+			"failed to deserialize risk assessment",
+			err,
+		)
+	}
+	return assessment, nil
+}
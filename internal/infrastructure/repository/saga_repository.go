@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
+)
+
+// SagaCollection is the storage collection holding sagas and their progress
+const SagaCollection = "saga_records"
+
+// SagaRepositoryImpl implements the SagaRepository interface using a storage backend
+type SagaRepositoryImpl struct {
+	storage storage.Storage
+}
+
+// NewSagaRepository creates a new saga repository with the given storage backend
+func NewSagaRepository(storage storage.Storage) repository.SagaRepository {
+	return &SagaRepositoryImpl{
+		storage: storage,
+	}
+}
+
+// Save persists a saga keyed by its ID
+func (r *SagaRepositoryImpl) Save(saga *entity.Saga) error {
+	if err := r.storage.Store(saga.ID(), saga); err != nil {
+		return domainErrors.NewRepositoryError(
+			"save_saga",
+			domainErrors.RepositoryInternal,
+			"failed to save saga",
+			err,
+		)
+	}
+	return nil
+}
+
+// GetByID retrieves a saga by its ID
+func (r *SagaRepositoryImpl) GetByID(id string) (*entity.Saga, error) {
+	value, err := r.storage.Get(id)
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return nil, domainErrors.ErrSagaNotFound
+		}
+		return nil, domainErrors.NewRepositoryError(
+			"get_saga",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve saga",
+			err,
+		)
+	}
+
+	saga, err := decodeStoredValue[entity.Saga](value)
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"deserialize_saga",
+			domainErrors.RepositoryInternal,
+			"failed to deserialize saga",
+			err,
+		)
+	}
+	return saga, nil
+}
+
+// GetAll retrieves all sagas ordered by creation time
+func (r *SagaRepositoryImpl) GetAll() ([]*entity.Saga, error) {
+	values, err := r.storage.ListAll()
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"get_all_sagas",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve sagas",
+			err,
+		)
+	}
+
+	sagas := make([]*entity.Saga, 0, len(values))
+	for _, value := range values {
+		saga, err := decodeStoredValue[entity.Saga](value)
+		if err != nil {
+			return nil, domainErrors.NewRepositoryError(
+				"deserialize_saga",
+				domainErrors.RepositoryInternal,
+				"failed to deserialize saga",
+				err,
+			)
+		}
+		sagas = append(sagas, saga)
+	}
+
+	sort.SliceStable(sagas, func(i, j int) bool {
+		return sagas[i].CreatedAt().Before(sagas[j].CreatedAt())
+	})
+
+	return sagas, nil
+}
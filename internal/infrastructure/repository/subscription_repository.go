@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
+)
+
+// SubscriptionCollection is the storage collection holding subscriptions
+const SubscriptionCollection = "subscription_records"
+
+// SubscriptionRepositoryImpl implements the SubscriptionRepository interface using a storage backend
+type SubscriptionRepositoryImpl struct {
+	storage storage.Storage
+}
+
+// NewSubscriptionRepository creates a new subscription repository with the given storage backend
+func NewSubscriptionRepository(storage storage.Storage) repository.SubscriptionRepository {
+	return &SubscriptionRepositoryImpl{
+		storage: storage,
+	}
+}
+
+// Save persists a subscription keyed by its ID
+func (r *SubscriptionRepositoryImpl) Save(subscription *entity.Subscription) error {
+	if err := r.storage.Store(subscription.ID(), subscription); err != nil {
+		return domainErrors.NewRepositoryError(
+			"save_subscription",
+			domainErrors.RepositoryInternal,
+			"failed to save subscription",
+			err,
+		)
+	}
+	return nil
+}
+
+// GetByID retrieves a subscription by its ID
+func (r *SubscriptionRepositoryImpl) GetByID(id string) (*entity.Subscription, error) {
+	value, err := r.storage.Get(id)
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return nil, domainErrors.ErrSubscriptionNotFound
+		}
+		return nil, domainErrors.NewRepositoryError(
+			"get_subscription",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve subscription",
+			err,
+		)
+	}
+
+	subscription, err := decodeStoredValue[entity.Subscription](value)
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"deserialize_subscription",
+			domainErrors.RepositoryInternal,
+			"failed to deserialize subscription",
+			err,
+		)
+	}
+	return subscription, nil
+}
+
+// GetAll retrieves all subscriptions, oldest first
+func (r *SubscriptionRepositoryImpl) GetAll() ([]*entity.Subscription, error) {
+	values, err := r.storage.ListAll()
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"get_all_subscriptions",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve subscriptions",
+			err,
+		)
+	}
+
+	subscriptions := make([]*entity.Subscription, 0, len(values))
+	for _, value := range values {
+		subscription, err := decodeStoredValue[entity.Subscription](value)
+		if err != nil {
+			return nil, domainErrors.NewRepositoryError(
+				"deserialize_subscription",
+				domainErrors.RepositoryInternal,
+				"failed to deserialize subscription",
+				err,
+			)
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	sort.SliceStable(subscriptions, func(i, j int) bool {
+		return subscriptions[i].CreatedAt().Before(subscriptions[j].CreatedAt())
+	})
+
+	return subscriptions, nil
+}
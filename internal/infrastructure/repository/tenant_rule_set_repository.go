@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
+)
+
+// TenantRuleSetCollection is the storage collection holding tenant business rule sets
+const TenantRuleSetCollection = "tenant_rule_set_records"
+
+// TenantRuleSetRepositoryImpl implements the TenantRuleSetRepository interface using a storage backend
+type TenantRuleSetRepositoryImpl struct {
+	storage storage.Storage
+}
+
+// NewTenantRuleSetRepository creates a new tenant rule set repository with the given storage backend
+func NewTenantRuleSetRepository(storage storage.Storage) repository.TenantRuleSetRepository {
+	return &TenantRuleSetRepositoryImpl{
+		storage: storage,
+	}
+}
+
+// Save persists a rule set keyed by tenant ID
+func (r *TenantRuleSetRepositoryImpl) Save(set *entity.TenantRuleSet) error {
+	if err := r.storage.Store(set.TenantID(), set); err != nil {
+		return domainErrors.NewRepositoryError(
+			"save_tenant_rule_set",
+			domainErrors.RepositoryInternal,
+			"failed to save tenant rule set",
+			err,
+		)
+	}
+	return nil
+}
+
+// GetByTenantID retrieves the rule set of a tenant
+func (r *TenantRuleSetRepositoryImpl) GetByTenantID(tenantID string) (*entity.TenantRuleSet, error) {
+	value, err := r.storage.Get(tenantID)
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return nil, domainErrors.ErrTenantRuleSetNotFound
+		}
+
+		return nil, domainErrors.NewRepositoryError(
+			"get_tenant_rule_set",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve tenant rule set",
+			err,
+		)
+	}
+
+	set, err := decodeStoredValue[entity.TenantRuleSet](value)
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"deserialize_tenant_rule_set",
+			domainErrors.RepositoryInternal,
+			"failed to deserialize tenant rule set",
+			err,
+		)
+	}
+	return set, nil
+}
+
+// GetAll retrieves all rule sets ordered by tenant ID
+func (r *TenantRuleSetRepositoryImpl) GetAll() ([]*entity.TenantRuleSet, error) {
+	values, err := r.storage.ListAll()
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"get_all_tenant_rule_sets",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve tenant rule sets",
+			err,
+		)
+	}
+
+	sets := make([]*entity.TenantRuleSet, 0, len(values))
+	for _, value := range values {
+		set, err := decodeStoredValue[entity.TenantRuleSet](value)
+		if err != nil {
+			return nil, domainErrors.NewRepositoryError(
+				"deserialize_tenant_rule_set",
+				domainErrors.RepositoryInternal,
+				"failed to deserialize tenant rule set",
+				err,
+			)
+		}
+		sets = append(sets, set)
+	}
+
+	sort.Slice(sets, func(i, j int) bool {
+		return sets[i].TenantID() < sets[j].TenantID()
+	})
+
+	return sets, nil
+}
+
+// Delete removes the rule set of a tenant
+func (r *TenantRuleSetRepositoryImpl) Delete(tenantID string) error {
+	if err := r.storage.Delete(tenantID); err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return domainErrors.ErrTenantRuleSetNotFound
+		}
+
+		return domainErrors.NewRepositoryError(
+			"delete_tenant_rule_set",
+			domainErrors.RepositoryInternal,
+			"failed to delete tenant rule set",
+			err,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
+)
+
+// UploadCollection is the storage collection holding resumable uploads (their chunks live in the object store)
+const UploadCollection = "upload_records"
+
+// UploadRepositoryImpl implements the UploadRepository interface using a storage backend
+type UploadRepositoryImpl struct {
+	storage storage.Storage
+}
+
+// NewUploadRepository creates a new upload repository with the given storage backend
+func NewUploadRepository(storage storage.Storage) repository.UploadRepository {
+	return &UploadRepositoryImpl{
+		storage: storage,
+	}
+}
+
+// Save persists an upload keyed by its ID
+func (r *UploadRepositoryImpl) Save(upload *entity.Upload) error {
+	if err := r.storage.Store(upload.ID(), upload); err != nil {
+		return domainErrors.NewRepositoryError(
+			"save_upload",
+			domainErrors.RepositoryInternal,
+			"failed to save upload",
+			err,
+		)
+	}
+	return nil
+}
+
+// GetByID retrieves an upload by its ID
+func (r *UploadRepositoryImpl) GetByID(id string) (*entity.Upload, error) {
+	value, err := r.storage.Get(id)
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return nil, domainErrors.ErrUploadNotFound
+		}
+		return nil, domainErrors.NewRepositoryError(
+			"get_upload",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve upload",
+			err,
+		)
+	}
+
+	upload, err := decodeStoredValue[entity.Upload](value)
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"deserialize_upload",
+			domainErrors.RepositoryInternal,
+			"failed to deserialize upload",
+			err,
+		)
+	}
+	return upload, nil
+}
+
+// GetByStatus retrieves the uploads in a status ordered by creation time
+func (r *UploadRepositoryImpl) GetByStatus(status entity.UploadStatus) ([]*entity.Upload, error) {
+	values, err := r.storage.ListAll()
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"get_uploads",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve uploads",
+			err,
+		)
+	}
+
+	uploads := make([]*entity.Upload, 0)
+	for _, value := range values {
+		upload, err := decodeStoredValue[entity.Upload](value)
+		if err != nil {
+			return nil, domainErrors.NewRepositoryError(
+				"deserialize_upload",
+				domainErrors.RepositoryInternal,
+				"failed to deserialize upload",
+				err,
+			)
+		}
+		if upload.Status() == status {
+			uploads = append(uploads, upload)
+		}
+	}
+
+	sort.SliceStable(uploads, func(i, j int) bool {
+		return uploads[i].CreatedAt().Before(uploads[j].CreatedAt())
+	})
+
+	return uploads, nil
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
+)
+
+// UsageRecordCollection is the storage collection holding metered usage records
+// The PostgreSQL table is partitioned by month of creation
+const UsageRecordCollection = "usage_records"
+
+// partitionLookback widens creation-time bounds on collections partitioned by month of creation: a record is
+// expected to be stored within this long of the time it carries (usage reported ahead of the server clock,
+// transactions started before the entry was made)
+const partitionLookback = 31 * 24 * time.Hour
+
+// UsageRecordRepositoryImpl implements the UsageRecordRepository interface using a storage backend
+// Records are keyed by subscription ID and idempotency key, so a retried event maps to the same key
+type UsageRecordRepositoryImpl struct {
+	storage storage.Storage
+}
+
+// NewUsageRecordRepository creates a new usage record repository with the given storage backend
+func NewUsageRecordRepository(storage storage.Storage) repository.UsageRecordRepository {
+	return &UsageRecordRepositoryImpl{
+		storage: storage,
+	}
+}
+
+// Record persists a usage record unless one already exists for its idempotency key
+func (r *UsageRecordRepositoryImpl) Record(record *entity.UsageRecord) (*entity.UsageRecord, bool, error) {
+	key := usageRecordKey(record.SubscriptionID(), record.IdempotencyKey())
+
+	value, err := r.storage.Get(key)
+	if err == nil {
+		existing, err := decodeStoredValue[entity.UsageRecord](value)
+		if err != nil {
+			return nil, false, domainErrors.NewRepositoryError(
+				"deserialize_usage_record",
+				domainErrors.RepositoryInternal,
+				"failed to deserialize usage record",
+				err,
+			)
+		}
+		return existing, false, nil
+	}
+	if !errors.Is(err, storage.ErrKeyNotFound) {
+		return nil, false, domainErrors.NewRepositoryError(
+			"get_usage_record",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve usage record",
+			err,
+		)
+	}
+
+	if err := r.storage.Store(key, record); err != nil {
+		return nil, false, domainErrors.NewRepositoryError(
+			"save_usage_record",
+			domainErrors.RepositoryInternal,
+			"failed to save usage record",
+			err,
+		)
+	}
+	return record, true, nil
+}
+
+// ListBySubscription retrieves the records of a subscription that occurred within [from, to), oldest first
+// Only records stored from partitionLookback before the period on are read, usage is recorded after it occurs
+func (r *UsageRecordRepositoryImpl) ListBySubscription(subscriptionID string, from, to time.Time) ([]*entity.UsageRecord, error) {
+	values, err := storage.ListCreatedSince(r.storage, from.Add(-partitionLookback))
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"list_usage_records",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve usage records",
+			err,
+		)
+	}
+
+	records := make([]*entity.UsageRecord, 0)
+	for _, value := range values {
+		record, err := decodeStoredValue[entity.UsageRecord](value)
+		if err != nil {
+			return nil, domainErrors.NewRepositoryError(
+				"deserialize_usage_record",
+				domainErrors.RepositoryInternal,
+				"failed to deserialize usage record",
+				err,
+			)
+		}
+		if record.SubscriptionID() == subscriptionID && record.InPeriod(from, to) {
+			records = append(records, record)
+		}
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].OccurredAt().Before(records[j].OccurredAt())
+	})
+
+	return records, nil
+}
+
+// usageRecordKey scopes idempotency keys to their subscription
+func usageRecordKey(subscriptionID, idempotencyKey string) string {
+	return subscriptionID + "/" + idempotencyKey
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
+)
+
+// WebhookEventCollection is the storage collection holding inbound webhook events
+const WebhookEventCollection = "webhook_event_records"
+
+// WebhookEventRepositoryImpl implements the WebhookEventRepository interface using a storage backend
+type WebhookEventRepositoryImpl struct {
+	storage storage.Storage
+}
+
+// NewWebhookEventRepository creates a new webhook event repository with the given storage backend
+func NewWebhookEventRepository(storage storage.Storage) repository.WebhookEventRepository {
+	return &WebhookEventRepositoryImpl{
+		storage: storage,
+	}
+}
+
+// Save persists a webhook event keyed by its ID
+func (r *WebhookEventRepositoryImpl) Save(event *entity.WebhookEvent) error {
+	if err := r.storage.Store(event.ID(), event); err != nil {
+		return domainErrors.NewRepositoryError(
+			"save_webhook_event",
+			domainErrors.RepositoryInternal,
+			"failed to save webhook event",
+			err,
+		)
+	}
+	return nil
+}
+
+// GetByID retrieves a webhook event by its ID
+func (r *WebhookEventRepositoryImpl) GetByID(id string) (*entity.WebhookEvent, error) {
+	value, err := r.storage.Get(id)
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return nil, domainErrors.ErrWebhookEventNotFound
+		}
+		return nil, domainErrors.NewRepositoryError(
+			"get_webhook_event",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve webhook event",
+			err,
+		)
+	}
+
+	event, err := decodeStoredValue[entity.WebhookEvent](value)
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"deserialize_webhook_event",
+			domainErrors.RepositoryInternal,
+			"failed to deserialize webhook event",
+			err,
+		)
+	}
+	return event, nil
+}
+
+// GetAll retrieves all webhook events ordered by receipt time
+func (r *WebhookEventRepositoryImpl) GetAll() ([]*entity.WebhookEvent, error) {
+	values, err := r.storage.ListAll()
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"get_all_webhook_events",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve webhook events",
+			err,
+		)
+	}
+
+	events := make([]*entity.WebhookEvent, 0, len(values))
+	for _, value := range values {
+		event, err := decodeStoredValue[entity.WebhookEvent](value)
+		if err != nil {
+			return nil, domainErrors.NewRepositoryError(
+				"deserialize_webhook_event",
+				domainErrors.RepositoryInternal,
+				"failed to deserialize webhook event",
+				err,
+			)
+		}
+		events = append(events, event)
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].ReceivedAt().Before(events[j].ReceivedAt())
+	})
+
+	return events, nil
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
+)
+
+// WebhookSubscriptionCollection is the storage collection holding the consumer webhook subscriptions
+const WebhookSubscriptionCollection = "webhook_subscription_records"
+
+// WebhookSubscriptionRepositoryImpl implements the WebhookSubscriptionRepository interface using a storage backend
+type WebhookSubscriptionRepositoryImpl struct {
+	storage storage.Storage
+}
+
+// NewWebhookSubscriptionRepository creates a new webhook subscription repository with the given storage backend
+func NewWebhookSubscriptionRepository(storage storage.Storage) repository.WebhookSubscriptionRepository {
+	return &WebhookSubscriptionRepositoryImpl{
+		storage: storage,
+	}
+}
+
+// Save persists a webhook subscription keyed by its ID
+func (r *WebhookSubscriptionRepositoryImpl) Save(subscription *entity.WebhookSubscription) error {
+	if err := r.storage.Store(subscription.ID(), subscription); err != nil {
+		return domainErrors.NewRepositoryError(
+			"save_webhook_subscription",
+			domainErrors.RepositoryInternal,
+			"failed to save webhook subscription",
+			err,
+		)
+	}
+	return nil
+}
+
+// GetByID retrieves a webhook subscription by its ID
+func (r *WebhookSubscriptionRepositoryImpl) GetByID(id string) (*entity.WebhookSubscription, error) {
+	value, err := r.storage.Get(id)
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return nil, domainErrors.ErrWebhookSubscriptionNotFound
+		}
+		return nil, domainErrors.NewRepositoryError(
+			"get_webhook_subscription",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve webhook subscription",
+			err,
+		)
+	}
+
+	subscription, err := decodeStoredValue[entity.WebhookSubscription](value)
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"deserialize_webhook_subscription",
+			domainErrors.RepositoryInternal,
+			"failed to deserialize webhook subscription",
+			err,
+		)
+	}
+	return subscription, nil
+}
+
+// GetAll retrieves all webhook subscriptions, oldest first
+func (r *WebhookSubscriptionRepositoryImpl) GetAll() ([]*entity.WebhookSubscription, error) {
+	values, err := r.storage.ListAll()
+	if err != nil {
+		return nil, domainErrors.NewRepositoryError(
+			"get_all_webhook_subscriptions",
+			domainErrors.RepositoryInternal,
+			"failed to retrieve webhook subscriptions",
+			err,
+		)
+	}
+
+	subscriptions := make([]*entity.WebhookSubscription, 0, len(values))
+	for _, value := range values {
+		subscription, err := decodeStoredValue[entity.WebhookSubscription](value)
+		if err != nil {
+			return nil, domainErrors.NewRepositoryError(
+				"deserialize_webhook_subscription",
+				domainErrors.RepositoryInternal,
+				"failed to deserialize webhook subscription",
+				err,
+			)
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	sort.SliceStable(subscriptions, func(i, j int) bool {
+		return subscriptions[i].CreatedAt().Before(subscriptions[j].CreatedAt())
+	})
+
+	return subscriptions, nil
+}
+
+// Delete removes a webhook subscription
+func (r *WebhookSubscriptionRepositoryImpl) Delete(id string) error {
+	if err := r.storage.Delete(id); err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return domainErrors.ErrWebhookSubscriptionNotFound
+		}
+
+		return domainErrors.NewRepositoryError(
+			"delete_webhook_subscription",
+			domainErrors.RepositoryInternal,
+			"failed to delete webhook subscription",
+			err,
+		)
+	}
+	return nil
+}
@@ -8,14 +8,26 @@ package storage
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/gorm"
 )
 
+// DefaultStorageTable is the key-value table used for client records
+const DefaultStorageTable = "storage_records"
+
+// uniqueViolation is the PostgreSQL error code of a unique index violation
+const uniqueViolation = "23505"
+
 // PostgreSQLStorage provides a PostgreSQL implementation of the Storage interface
 type PostgreSQLStorage struct {
-	db *gorm.DB
+	db    *gorm.DB
+	table string
 }
 
 // StorageRecord represents a key-value record in the storage table
@@ -26,13 +38,14 @@ type StorageRecord struct {
 
 // TableName specifies the table name for GORM
 func (StorageRecord) TableName() string {
-	return "storage_records"
+	return DefaultStorageTable
 }
 
 // NewPostgreSQLStorage creates a new PostgreSQL storage instance
 func NewPostgreSQLStorage(db *gorm.DB) *PostgreSQLStorage {
 	storage := &PostgreSQLStorage{
-		db: db,
+		db:    db,
+		table: DefaultStorageTable,
 	}
 
 	// Note: Table creation is handled by the migration system using the migration user
@@ -47,6 +60,98 @@ func NewPostgreSQLStorageWithDB(db *gorm.DB) *PostgreSQLStorage {
 	return NewPostgreSQLStorage(db)
 }
 
+// Collection returns a storage backed by another key-value table sharing the same connection
+// The table must have the storage_records shape and is created by a migration
+func (s *PostgreSQLStorage) Collection(name string) Storage {
+	return &PostgreSQLStorage{
+		db:    s.db,
+		table: name,
+	}
+}
+
+// records returns a query scoped to this storage's table
+func (s *PostgreSQLStorage) records() *gorm.DB {
+	return s.db.Table(s.table)
+}
+
+// NextSequence allocates the next value of the "<table>_seq" database sequence
+// The sequence must be created by a migration; values are unique and increasing but may have gaps
+func (s *PostgreSQLStorage) NextSequence() (int64, error) {
+	var next int64
+	if err := s.db.Raw("SELECT nextval(?)", s.table+"_seq").Scan(&next).Error; err != nil {
+		return 0, fmt.Errorf("failed to allocate sequence for %s: %w", s.table, err)
+	}
+	return next, nil
+}
+
+// NextCounterValue increments the row of scope in the "<table>_counters" table and returns its new value
+// The table must be created by a migration. The upsert locks the row until the transaction ends, so allocations
+// are gap-free when the number is used in the same transaction (see InTransaction)
+func (s *PostgreSQLStorage) NextCounterValue(scope string) (int64, error) {
+	table := s.table + "_counters"
+	query := "INSERT INTO " + table + " (scope, value) VALUES (?, 1) " +
+		"ON CONFLICT (scope) DO UPDATE SET value = " + table + ".value + 1, updated_at = NOW() RETURNING value"
+
+	var next int64
+	if err := s.db.Raw(query, scope).Scan(&next).Error; err != nil {
+		return 0, fmt.Errorf("failed to allocate counter value %s of %s: %w", scope, s.table, err)
+	}
+	return next, nil
+}
+
+// savepointCounter makes savepoint names unique within a connection
+var savepointCounter atomic.Int64
+
+// InTransaction runs fn in a database transaction
+// When the storage is already bound to a transaction (nested service calls, or the
+// rollback-only transaction of an integration test) the unit of work runs under a
+// SAVEPOINT instead, so a failure rolls back to it and leaves the outer transaction usable
+func (s *PostgreSQLStorage) InTransaction(fn func(tx Storage) error) (err error) {
+	if !s.inTransaction() {
+		return s.db.Transaction(func(tx *gorm.DB) error {
+			return fn(s.withDB(tx))
+		})
+	}
+
+	name := fmt.Sprintf("billing_sp_%d", savepointCounter.Add(1))
+	if err := s.db.SavePoint(name).Error; err != nil {
+		return fmt.Errorf("failed to create savepoint %s: %w", name, err)
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			s.db.RollbackTo(name)
+			panic(recovered)
+		}
+	}()
+
+	if err := fn(s.withDB(s.db)); err != nil {
+		if rollbackErr := s.db.RollbackTo(name).Error; rollbackErr != nil {
+			return fmt.Errorf("failed to roll back to savepoint %s: %v (original error: %w)", name, rollbackErr, err)
+		}
+		return err
+	}
+
+	if err := s.db.Exec("RELEASE SAVEPOINT " + name).Error; err != nil {
+		return fmt.Errorf("failed to release savepoint %s: %w", name, err)
+	}
+	return nil
+}
+
+// inTransaction checks if the storage connection is an open transaction
+func (s *PostgreSQLStorage) inTransaction() bool {
+	committer, ok := s.db.Statement.ConnPool.(gorm.TxCommitter)
+	return ok && committer != nil
+}
+
+// withDB returns a storage for the same table bound to another connection or transaction
+func (s *PostgreSQLStorage) withDB(db *gorm.DB) *PostgreSQLStorage {
+	return &PostgreSQLStorage{
+		db:    db,
+		table: s.table,
+	}
+}
+
 // Store saves a value with the given key
 func (s *PostgreSQLStorage) Store(key string, value interface{}) error {
 	// Serialize value to JSON
@@ -62,7 +167,11 @@ func (s *PostgreSQLStorage) Store(key string, value interface{}) error {
 	}
 
 	// Use GORM's Save method which handles both create and update
-	if err := s.db.Save(&record).Error; err != nil {
+	if err := s.records().Save(&record).Error; err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return fmt.Errorf("%w: key %s violates %s", ErrDuplicateValue, key, pgErr.ConstraintName)
+		}
 		return fmt.Errorf("failed to store value for key %s: %w", key, err)
 	}
 
@@ -74,7 +183,7 @@ func (s *PostgreSQLStorage) Get(key string) (interface{}, error) {
 	var record StorageRecord
 
 	// Find record by key
-	if err := s.db.Where("key = ?", key).First(&record).Error; err != nil {
+	if err := s.records().Where("key = ?", key).First(&record).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, key)
 		}
@@ -95,17 +204,29 @@ func (s *PostgreSQLStorage) Exists(key string) bool {
 	var count int64
 
 	// Count records with the given key
-	s.db.Model(&StorageRecord{}).Where("key = ?", key).Count(&count)
+	s.records().Where("key = ?", key).Count(&count)
 
 	return count > 0
 }
 
-// ListAll retrieves all stored values
+// ListAll retrieves all stored values in insertion order (created_at, then key for records created together)
+// Updates keep created_at (Store never writes it), so an updated record keeps its position
 func (s *PostgreSQLStorage) ListAll() ([]interface{}, error) {
+	return s.listRecords(s.records())
+}
+
+// ListCreatedSince retrieves the values first stored at or after since, in insertion order
+// The created_at bound lets PostgreSQL skip the partitions of earlier months on partitioned tables
+func (s *PostgreSQLStorage) ListCreatedSince(since time.Time) ([]interface{}, error) {
+	return s.listRecords(s.records().Where("created_at >= ?", since))
+}
+
+// listRecords retrieves and deserializes the records of a query in insertion order
+func (s *PostgreSQLStorage) listRecords(query *gorm.DB) ([]interface{}, error) {
 	var records []StorageRecord
 
 	// Find all records
-	if err := s.db.Find(&records).Error; err != nil {
+	if err := query.Order("created_at, key").Find(&records).Error; err != nil {
 		return nil, fmt.Errorf("failed to retrieve all records: %w", err)
 	}
 
@@ -122,10 +243,248 @@ func (s *PostgreSQLStorage) ListAll() ([]interface{}, error) {
 	return values, nil
 }
 
+// ListMatching retrieves the values whose field at path equals value, in insertion order
+// Paths come from the repositories, never from requests, so they are safe to place in the query; the expression
+// matches the one of the expression indexes created by the migrations
+func (s *PostgreSQLStorage) ListMatching(path, value string) ([]interface{}, error) {
+	field := fmt.Sprintf("value::jsonb #>> '{%s}' = ?", jsonPath(path))
+	return s.listRecords(s.records().Where(field, value))
+}
+
+// ListKeys retrieves the values stored under keys with a single IN query, in insertion order
+func (s *PostgreSQLStorage) ListKeys(keys []string) ([]interface{}, error) {
+	if len(keys) == 0 {
+		return []interface{}{}, nil
+	}
+	return s.listRecords(s.records().Where("key IN ?", keys))
+}
+
+// ListContaining retrieves the values whose array field at path contains element, in insertion order
+// Like ListMatching, paths come from the repositories; the containment expression is served by the GIN indexes
+// created by the migrations
+func (s *PostgreSQLStorage) ListContaining(path, element string) ([]interface{}, error) {
+	array, err := json.Marshal([]string{element})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode element: %w", err)
+	}
+	field := fmt.Sprintf("(value::jsonb #> '{%s}') @> ?::jsonb", jsonPath(path))
+	return s.listRecords(s.records().Where(field, string(array)))
+}
+
+// ListQuery retrieves a page of the values matching query, in the order of query, and the number of matching values
+// Like ListMatching, paths come from the repositories; equality and containment use the same expressions, so the
+// expression indexes created by the migrations serve them
+func (s *PostgreSQLStorage) ListQuery(query Query) ([]interface{}, int64, error) {
+	filtered, err := s.filter(query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// A new session lets the count and the page reuse the conditions without sharing their statement
+	filtered = filtered.Session(&gorm.Session{})
+	var count int64
+	if err := filtered.Count(&count).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count records of %s: %w", s.table, err)
+	}
+
+	page := filtered.Offset(query.Offset)
+	for _, key := range query.OrderBy {
+		page = page.Order(orderExpression(key))
+	}
+	if query.Limit > 0 {
+		page = page.Limit(query.Limit)
+	}
+	values, err := s.listRecords(page)
+	if err != nil {
+		return nil, 0, err
+	}
+	return values, count, nil
+}
+
+// positionedRecord is a storage record read with its creation time, its position in insertion order
+type positionedRecord struct {
+	StorageRecord
+	CreatedAt time.Time
+}
+
+// ListAfter retrieves up to query.Limit values matching query stored after position, in insertion order
+// The (created_at, key) row comparison seeks through the created_at index instead of reading the skipped records
+func (s *PostgreSQLStorage) ListAfter(query Query, after Position) ([]interface{}, []Position, error) {
+	filtered, err := s.filter(query)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !after.IsZero() {
+		filtered = filtered.Where("(created_at, key) > (?, ?)", after.CreatedAt, after.Key)
+	}
+	if query.Limit > 0 {
+		filtered = filtered.Limit(query.Limit)
+	}
+
+	var records []positionedRecord
+	if err := filtered.Select("key, value, created_at").Order("created_at, key").Find(&records).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to retrieve records of %s: %w", s.table, err)
+	}
+
+	values := make([]interface{}, 0, len(records))
+	positions := make([]Position, 0, len(records))
+	for _, record := range records {
+		var value interface{}
+		if err := json.Unmarshal([]byte(record.Value), &value); err != nil {
+			return nil, nil, fmt.Errorf("failed to deserialize value for key %s: %w", record.Key, err)
+		}
+		values = append(values, value)
+		positions = append(positions, Position{CreatedAt: record.CreatedAt, Key: record.Key})
+	}
+	return values, positions, nil
+}
+
+// ListKeyRange retrieves up to query.Limit values matching query with a key greater than after, in key order
+// The key comparison seeks through the primary key index instead of reading the records before after
+func (s *PostgreSQLStorage) ListKeyRange(query Query, after string) ([]interface{}, error) {
+	filtered, err := s.filter(query)
+	if err != nil {
+		return nil, err
+	}
+	if after != "" {
+		filtered = filtered.Where("key > ?", after)
+	}
+	if query.Limit > 0 {
+		filtered = filtered.Limit(query.Limit)
+	}
+
+	var records []StorageRecord
+	if err := filtered.Order("key").Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve records of %s: %w", s.table, err)
+	}
+
+	values := make([]interface{}, 0, len(records))
+	for _, record := range records {
+		var value interface{}
+		if err := json.Unmarshal([]byte(record.Value), &value); err != nil {
+			return nil, fmt.Errorf("failed to deserialize value for key %s: %w", record.Key, err)
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// filter returns a query of the records matching the criteria of query
+func (s *PostgreSQLStorage) filter(query Query) (*gorm.DB, error) {
+	filtered := s.records()
+	for path, value := range query.Matching {
+		if value == "" {
+			// Empty fields are left out of the persisted values (omitempty)
+			filtered = filtered.Where(fmt.Sprintf("COALESCE(value::jsonb #>> '{%s}', '') = ''", jsonPath(path)))
+			continue
+		}
+		filtered = filtered.Where(fmt.Sprintf("value::jsonb #>> '{%s}' = ?", jsonPath(path)), value)
+	}
+	for path, value := range query.Excluding {
+		filtered = filtered.Where(fmt.Sprintf("value::jsonb #>> '{%s}' IS DISTINCT FROM ?", jsonPath(path)), value)
+	}
+	for path, element := range query.Containing {
+		array, err := json.Marshal([]string{element})
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode element: %w", err)
+		}
+		filtered = filtered.Where(fmt.Sprintf("(value::jsonb #> '{%s}') @> ?::jsonb", jsonPath(path)), string(array))
+	}
+	if query.Search != "" && len(query.SearchFields) > 0 {
+		pattern := "%" + likeEscaper.Replace(query.Search) + "%"
+		conditions := make([]string, len(query.SearchFields))
+		args := make([]interface{}, len(query.SearchFields))
+		for i, path := range query.SearchFields {
+			conditions[i] = fmt.Sprintf("value::jsonb #>> '{%s}' ILIKE ?", jsonPath(path))
+			args[i] = pattern
+		}
+		filtered = filtered.Where("("+strings.Join(conditions, " OR ")+")", args...)
+	}
+	if query.TimeField != "" {
+		timestamp := fmt.Sprintf("(value::jsonb #>> '{%s}')::timestamptz", jsonPath(query.TimeField))
+		if !query.After.IsZero() {
+			filtered = filtered.Where(timestamp+" > ?", query.After)
+		}
+		if !query.Before.IsZero() {
+			filtered = filtered.Where(timestamp+" < ?", query.Before)
+		}
+	}
+	return filtered, nil
+}
+
+// orderExpression returns the ORDER BY expression of an order key; listRecords then orders by insertion, so pages
+// of records sharing a key are stable
+func orderExpression(key OrderKey) string {
+	expression := fmt.Sprintf("lower(value::jsonb #>> '{%s}')", jsonPath(key.Path))
+	if key.Timestamp {
+		expression = fmt.Sprintf("(value::jsonb #>> '{%s}')::timestamptz", jsonPath(key.Path))
+	}
+	if key.Descending {
+		return expression + " DESC"
+	}
+	return expression
+}
+
+// jsonPath converts a dot-separated field path to the elements of a PostgreSQL JSON path ("a.b" -> "a,b")
+func jsonPath(path string) string {
+	return strings.ReplaceAll(path, ".", ",")
+}
+
+// likeEscaper escapes the LIKE wildcards of a search so it matches literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// CountMatching counts the records whose JSON value has one of the fields (dot-separated paths) containing search
+// (ILIKE). Field paths come from the repositories, never from requests, so they are safe to place in the query
+func (s *PostgreSQLStorage) CountMatching(fields []string, search string) (int64, error) {
+	query := s.records()
+	if search != "" && len(fields) > 0 {
+		pattern := "%" + likeEscaper.Replace(search) + "%"
+		conditions := make([]string, len(fields))
+		args := make([]interface{}, len(fields))
+		for i, field := range fields {
+			conditions[i] = fmt.Sprintf("value::jsonb #>> '{%s}' ILIKE ?", jsonPath(field))
+			args[i] = pattern
+		}
+		query = query.Where(strings.Join(conditions, " OR "), args...)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count records of %s: %w", s.table, err)
+	}
+	return count, nil
+}
+
+// EstimatedCount returns the row estimate of the table kept by ANALYZE and autovacuum (pg_class.reltuples)
+// A table never analyzed has no estimate (-1), so it is counted exactly instead
+func (s *PostgreSQLStorage) EstimatedCount() (int64, error) {
+	var estimate float64
+	if err := s.db.Raw("SELECT COALESCE((SELECT reltuples FROM pg_class WHERE oid = to_regclass(?)), -1)", s.table).Scan(&estimate).Error; err != nil {
+		return 0, fmt.Errorf("failed to estimate record count of %s: %w", s.table, err)
+	}
+	if estimate < 0 {
+		return s.CountMatching(nil, "")
+	}
+	return int64(estimate), nil
+}
+
+// EnsureMonthlyPartitions creates the missing monthly partitions of the table, which must have been partitioned
+// by created_at in a migration, through the ensure_monthly_partitions database function
+func (s *PostgreSQLStorage) EnsureMonthlyPartitions(from, through time.Time) ([]string, error) {
+	var created []string
+	if err := s.db.Raw(
+		"SELECT * FROM ensure_monthly_partitions(?::regclass, ?::date, ?::date)",
+		s.table, from.UTC().Format("2006-01-02"), through.UTC().Format("2006-01-02"),
+	).Scan(&created).Error; err != nil {
+		return nil, fmt.Errorf("failed to create partitions of %s: %w", s.table, err)
+	}
+	return created, nil
+}
+
 // Delete removes a value by key
 func (s *PostgreSQLStorage) Delete(key string) error {
 	// Delete record by key
-	result := s.db.Where("key = ?", key).Delete(&StorageRecord{})
+	result := s.records().Where("key = ?", key).Delete(&StorageRecord{})
 
 	if result.Error != nil {
 		return fmt.Errorf("failed to delete value for key %s: %w", key, result.Error)
@@ -139,6 +498,31 @@ func (s *PostgreSQLStorage) Delete(key string) error {
 	return nil
 }
 
+// UpdateIfUnset replaces the value of key with a single UPDATE conditioned on the field at path being null
+// Concurrent updates of the row are serialized by its lock and the condition is checked again once it is
+// acquired, so only the first one finds the field unset
+func (s *PostgreSQLStorage) UpdateIfUnset(key, path string, value interface{}) error {
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to serialize value for key %s: %w", key, err)
+	}
+
+	// #>> reads both an absent field and a JSON null as NULL
+	unset := fmt.Sprintf("value::jsonb #>> '{%s}' IS NULL", jsonPath(path))
+	result := s.records().Where("key = ?", key).Where(unset).Update("value", string(valueBytes))
+	if result.Error != nil {
+		return fmt.Errorf("failed to update value for key %s: %w", key, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		if s.Exists(key) {
+			return fmt.Errorf("%w: %s of %s", ErrFieldSet, path, key)
+		}
+		return fmt.Errorf("%w: %s", ErrKeyNotFound, key)
+	}
+	return nil
+}
+
 // Health checks the health of the PostgreSQL connection
 func (s *PostgreSQLStorage) Health() error {
 	sqlDB, err := s.db.DB()
@@ -172,7 +556,7 @@ func (s *PostgreSQLStorage) GetDB() *gorm.DB {
 // Stats returns storage statistics
 func (s *PostgreSQLStorage) Stats() (map[string]interface{}, error) {
 	var count int64
-	if err := s.db.Model(&StorageRecord{}).Count(&count).Error; err != nil {
+	if err := s.records().Count(&count).Error; err != nil {
 		return nil, fmt.Errorf("failed to get record count: %w", err)
 	}
 
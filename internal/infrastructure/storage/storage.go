@@ -1,10 +1,20 @@
 package storage
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
 // ErrKeyNotFound indicates that a requested key was not found in storage
 var ErrKeyNotFound = errors.New("key not found")
 
+// ErrDuplicateValue indicates that a stored value violates a unique index of the storage backend
+var ErrDuplicateValue = errors.New("duplicate value")
+
+// ErrFieldSet indicates that a conditional update found the field it requires unset already set
+var ErrFieldSet = errors.New("field already set")
+
 // Storage defines the contract for data storage backends
 type Storage interface {
 	// Store saves a value with the given key
@@ -16,9 +26,209 @@ type Storage interface {
 	// Exists checks if a key exists in storage
 	Exists(key string) bool
 
-	// ListAll retrieves all stored values
+	// ListAll retrieves all stored values in insertion order: the order their keys were first stored,
+	// updating a value keeps its position and deleting then storing a key again moves it last
+	// Values stored in the same database transaction share their creation time and come back in key order
 	ListAll() ([]interface{}, error)
 
 	// Delete removes a value by key
 	Delete(key string) error
 }
+
+// CollectionProvider is implemented by storage backends that can provide isolated key spaces
+// Each aggregate type gets its own collection so listing one never returns another's records
+type CollectionProvider interface {
+	// Collection returns the storage for the named collection
+	Collection(name string) Storage
+}
+
+// ForCollection returns the named collection of a storage backend
+func ForCollection(base Storage, name string) (Storage, error) {
+	provider, ok := base.(CollectionProvider)
+	if !ok {
+		return nil, fmt.Errorf("storage backend %T does not support collections", base)
+	}
+	return provider.Collection(name), nil
+}
+
+// Sequencer is implemented by storage backends that can allocate monotonically increasing numbers
+// Used for change logs whose consumers resume from the last sequence they processed
+type Sequencer interface {
+	// NextSequence allocates the next sequence number of the storage (collection)
+	NextSequence() (int64, error)
+}
+
+// NextSequence allocates the next sequence number of a storage backend
+func NextSequence(base Storage) (int64, error) {
+	sequencer, ok := base.(Sequencer)
+	if !ok {
+		return 0, fmt.Errorf("storage backend %T does not support sequences", base)
+	}
+	return sequencer.NextSequence()
+}
+
+// GaplessCounter is implemented by storage backends that can allocate gap-free numbers per scope
+// Unlike sequences, counters follow transactions: a number allocated by a unit of work that rolls back is
+// allocated again, and the counter stays locked until the unit of work ends so concurrent ones wait their turn
+type GaplessCounter interface {
+	// NextCounterValue increments the counter of scope (starting at 1) and returns its new value
+	NextCounterValue(scope string) (int64, error)
+}
+
+// NextCounterValue allocates the next gap-free number of a scope of a storage backend
+func NextCounterValue(base Storage, scope string) (int64, error) {
+	counter, ok := base.(GaplessCounter)
+	if !ok {
+		return 0, fmt.Errorf("storage backend %T does not support gap-free counters", base)
+	}
+	return counter.NextCounterValue(scope)
+}
+
+// Counter is implemented by storage backends that can count records without loading them
+type Counter interface {
+	// CountMatching counts the records with a field of their value (dot-separated path, e.g. "email.value")
+	// containing search (case-insensitive)
+	// An empty search counts every record
+	CountMatching(fields []string, search string) (int64, error)
+
+	// EstimatedCount returns an estimate of the number of records from database statistics
+	EstimatedCount() (int64, error)
+}
+
+// FieldMatcher is implemented by storage backends that can find records by a field of their value without loading
+// every record
+type FieldMatcher interface {
+	// ListMatching retrieves the values whose field at path (dot-separated, e.g. "email.value") equals value,
+	// in insertion order
+	ListMatching(path, value string) ([]interface{}, error)
+}
+
+// ElementMatcher is implemented by storage backends that can find records by an element of an array field of their
+// value without loading every record
+type ElementMatcher interface {
+	// ListContaining retrieves the values whose array field at path (dot-separated) contains element, in insertion
+	// order
+	ListContaining(path, element string) ([]interface{}, error)
+}
+
+// ConditionalUpdater is implemented by storage backends that can replace a value only while one of its fields is
+// unset, in a single atomic step
+// Used to claim a record once: of concurrent updates setting the field, exactly one succeeds
+type ConditionalUpdater interface {
+	// UpdateIfUnset replaces the value of key if the field at path (dot-separated) of the stored value is absent
+	// or null; it returns ErrFieldSet when the field is set and ErrKeyNotFound when nothing is stored under key
+	UpdateIfUnset(key, path string, value interface{}) error
+}
+
+// UpdateIfUnset replaces the value of key of a storage backend while its field at path is unset
+func UpdateIfUnset(base Storage, key, path string, value interface{}) error {
+	updater, ok := base.(ConditionalUpdater)
+	if !ok {
+		return fmt.Errorf("storage backend %T does not support conditional updates", base)
+	}
+	return updater.UpdateIfUnset(key, path, value)
+}
+
+// KeyLister is implemented by storage backends that can retrieve the values of several keys in one round trip
+type KeyLister interface {
+	// ListKeys retrieves the values stored under keys, in insertion order; keys without a value are skipped
+	ListKeys(keys []string) ([]interface{}, error)
+}
+
+// Query selects records by fields of their value; unset criteria match every record
+// Paths and fields come from the repositories, never from requests
+type Query struct {
+	Matching     map[string]string // Field path (dot-separated) -> value it equals; "" also matches records without the field
+	Excluding    map[string]string // Field path -> value it differs from; records without the field are kept
+	Containing   map[string]string // Array field path -> element it contains (see ElementMatcher)
+	SearchFields []string          // Field paths Search is looked for in
+	Search       string            // Case-insensitive substring of one of SearchFields
+	TimeField    string            // Path of an RFC 3339 timestamp field compared to After and Before
+	After        time.Time         // Records with a later timestamp (zero: no lower bound)
+	Before       time.Time         // Records with an earlier timestamp (zero: no upper bound)
+	OrderBy      []OrderKey        // Ordering of the records, insertion order breaking ties (empty: insertion order)
+	Offset       int
+	Limit        int // Zero returns every matching record from Offset
+}
+
+// OrderKey orders records by a field of their value
+type OrderKey struct {
+	Path       string // Dot-separated field path
+	Timestamp  bool   // The field holds an RFC 3339 timestamp, ordered as a time; other fields are ordered as lower-cased text
+	Descending bool
+}
+
+// QueryLister is implemented by storage backends that can filter and paginate records without loading them
+type QueryLister interface {
+	// ListQuery retrieves a page of the values matching query, in the order of query, and the number of matching
+	// values
+	ListQuery(query Query) ([]interface{}, int64, error)
+}
+
+// Position is where a record stands in insertion order: when it was first stored, then its key
+type Position struct {
+	CreatedAt time.Time
+	Key       string
+}
+
+// IsZero checks if the position is the start of the insertion order
+func (p Position) IsZero() bool {
+	return p.CreatedAt.IsZero() && p.Key == ""
+}
+
+// SeekLister is implemented by storage backends that can page through records by keyset: each page starts after
+// the position of the last record of the previous one, so reading a page does not scan the records before it and
+// records stored or deleted between two pages do not shift the pages
+type SeekLister interface {
+	// ListAfter retrieves up to query.Limit values matching query stored after position (zero: from the first
+	// record), in insertion order, and the position of each value; query.Offset and query.OrderBy are ignored
+	ListAfter(query Query, after Position) ([]interface{}, []Position, error)
+}
+
+// KeyRangeLister is implemented by storage backends that can page through records in key order
+// Repositories keying records by a monotonic key (a zero-padded sequence) read the records after the last key they
+// processed without loading the records before it
+type KeyRangeLister interface {
+	// ListKeyRange retrieves up to query.Limit values matching query with a key greater than after (empty: from the
+	// first key), in key order; query.Offset and query.OrderBy are ignored
+	ListKeyRange(query Query, after string) ([]interface{}, error)
+}
+
+// CreatedSinceLister is implemented by storage backends that keep when each value was first stored
+// Tables partitioned by month of creation then only read the partitions from that month on
+type CreatedSinceLister interface {
+	// ListCreatedSince retrieves the values first stored at or after since, in insertion order
+	ListCreatedSince(since time.Time) ([]interface{}, error)
+}
+
+// ListCreatedSince lists the values of a storage backend first stored at or after since
+// Backends that do not keep creation times list every value, callers filter on their own timestamps anyway
+func ListCreatedSince(base Storage, since time.Time) ([]interface{}, error) {
+	if lister, ok := base.(CreatedSinceLister); ok {
+		return lister.ListCreatedSince(since)
+	}
+	return base.ListAll()
+}
+
+// MonthlyPartitioner is implemented by storage backends whose tables can be partitioned by month of creation
+type MonthlyPartitioner interface {
+	// EnsureMonthlyPartitions creates the missing partitions from the month of from through the month of through
+	// and returns the names of the partitions it created
+	EnsureMonthlyPartitions(from, through time.Time) ([]string, error)
+}
+
+// Transactor is implemented by storage backends that can run a unit of work atomically
+// Calls may nest: an inner unit of work that fails is undone without aborting the outer one
+type Transactor interface {
+	// InTransaction runs fn against a transaction-bound storage, committing if it returns nil
+	InTransaction(fn func(tx Storage) error) error
+}
+
+// RunInTransaction runs fn atomically on a storage backend
+func RunInTransaction(base Storage, fn func(tx Storage) error) error {
+	transactor, ok := base.(Transactor)
+	if !ok {
+		return fmt.Errorf("storage backend %T does not support transactions", base)
+	}
+	return transactor.InTransaction(fn)
+}
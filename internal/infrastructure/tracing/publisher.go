@@ -0,0 +1,34 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/messaging"
+)
+
+// Publisher adds the request ID and trace headers of the publishing request to every message,
+// so consumers (e.g. the mailer calling the email provider) continue the same trace
+type Publisher struct {
+	next messaging.Publisher
+}
+
+// NewPublisher wraps next so every message carries the identifiers propagated by its context
+func NewPublisher(next messaging.Publisher) *Publisher {
+	return &Publisher{
+		next: next,
+	}
+}
+
+// Publish injects the headers of ctx into each message before passing it on
+func (p *Publisher) Publish(ctx context.Context, messages ...messaging.Message) error {
+	if _, ok := FromContext(ctx); !ok {
+		return p.next.Publish(ctx, messages...)
+	}
+
+	traced := make([]messaging.Message, len(messages))
+	for i, message := range messages {
+		message.Headers = InjectMessage(ctx, message.Headers)
+		traced[i] = message
+	}
+	return p.next.Publish(ctx, traced...)
+}
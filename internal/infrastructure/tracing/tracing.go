@@ -0,0 +1,111 @@
+// Package tracing carries the request ID and the distributed trace headers of an inbound request
+// to the downstream calls it makes (payment gateway, credit bureau, message bus consumers such as the mailer)
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// DefaultRequestIDHeader is the request ID header set by API gateways and load balancers
+const DefaultRequestIDHeader = "X-Request-ID"
+
+// RequestIDMessageHeader carries the request ID in message bus headers
+const RequestIDMessageHeader = "request_id"
+
+// Trace context headers propagated unchanged (W3C Trace Context, B3 single and multi header)
+const (
+	TraceparentHeader  = "traceparent"
+	TracestateHeader   = "tracestate"
+	B3Header           = "b3"
+	B3TraceIDHeader    = "X-B3-TraceId"
+	B3SpanIDHeader     = "X-B3-SpanId"
+	B3ParentSpanHeader = "X-B3-ParentSpanId"
+	B3SampledHeader    = "X-B3-Sampled"
+	B3FlagsHeader      = "X-B3-Flags"
+)
+
+// TraceHeaders lists the trace context headers propagated to downstream calls
+var TraceHeaders = []string{
+	TraceparentHeader,
+	TracestateHeader,
+	B3Header,
+	B3TraceIDHeader,
+	B3SpanIDHeader,
+	B3ParentSpanHeader,
+	B3SampledHeader,
+	B3FlagsHeader,
+}
+
+// Propagation holds the identifiers of a request propagated to its downstream calls
+type Propagation struct {
+	// RequestIDHeader is the header the request ID is sent in (DefaultRequestIDHeader when empty)
+	RequestIDHeader string
+
+	// RequestID identifies the request across services
+	RequestID string
+
+	// Trace maps the trace context headers of the request (see TraceHeaders) to their value
+	Trace map[string]string
+}
+
+// propagationKey is the context key of the request propagation
+type propagationKey struct{}
+
+// WithPropagation returns a context carrying the identifiers propagated to downstream calls
+func WithPropagation(ctx context.Context, propagation Propagation) context.Context {
+	return context.WithValue(ctx, propagationKey{}, propagation)
+}
+
+// FromContext returns the identifiers propagated to downstream calls, if any
+func FromContext(ctx context.Context) (Propagation, bool) {
+	propagation, ok := ctx.Value(propagationKey{}).(Propagation)
+	return propagation, ok
+}
+
+// RequestIDFromContext returns the request ID of the request being served, or an empty string
+func RequestIDFromContext(ctx context.Context) string {
+	propagation, _ := FromContext(ctx)
+	return propagation.RequestID
+}
+
+// InjectHTTP sets the request ID and trace headers of ctx on an outbound HTTP request
+func InjectHTTP(ctx context.Context, header http.Header) {
+	propagation, ok := FromContext(ctx)
+	if !ok {
+		return
+	}
+
+	if propagation.RequestID != "" {
+		name := propagation.RequestIDHeader
+		if name == "" {
+			name = DefaultRequestIDHeader
+		}
+		header.Set(name, propagation.RequestID)
+	}
+	for name, value := range propagation.Trace {
+		header.Set(name, value)
+	}
+}
+
+// InjectMessage returns message headers carrying the request ID and trace headers of ctx
+// Headers already set on the message win, so a republished message keeps the trace it was first published with
+func InjectMessage(ctx context.Context, headers map[string]string) map[string]string {
+	propagation, ok := FromContext(ctx)
+	if !ok {
+		return headers
+	}
+
+	injected := make(map[string]string, len(headers)+len(propagation.Trace)+1)
+	if propagation.RequestID != "" {
+		injected[RequestIDMessageHeader] = propagation.RequestID
+	}
+	for name, value := range propagation.Trace {
+		injected[strings.ToLower(name)] = value
+	}
+	for name, value := range headers {
+		injected[name] = value
+	}
+	return injected
+}
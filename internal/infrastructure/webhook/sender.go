@@ -0,0 +1,95 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+)
+
+// Headers of the deliveries sent to consumer endpoints
+const (
+	// DeliveryTimestampHeader carries the unix time of a delivery, signed with its body
+	DeliveryTimestampHeader = "X-Webhook-Timestamp"
+	// DeliveryIDHeader carries the event ID, also sent as Idempotency-Key so failed deliveries are retried
+	DeliveryIDHeader = "X-Webhook-Id"
+)
+
+// defaultSendTimeout bounds a delivery when no HTTP client is given
+const defaultSendTimeout = 10 * time.Second
+
+// deliveryBody is the JSON body of a delivery; id and type are those ParseEvent reads
+type deliveryBody struct {
+	ID          string          `json:"id"`
+	Type        string          `json:"type"`
+	AggregateID string          `json:"aggregate_id,omitempty"`
+	OccurredAt  time.Time       `json:"occurred_at"`
+	Replayed    bool            `json:"replayed,omitempty"`
+	Data        json.RawMessage `json:"data"`
+}
+
+// Sender delivers events to consumer endpoints signed with the hmac scheme: the DefaultSignatureHeader carries
+// "sha256=<hex>" over "<timestamp>.<body>", so consumers verify deliveries like HMACVerifier with
+// DeliveryTimestampHeader as timestamp header
+type Sender struct {
+	clients func(host string) *http.Client
+}
+
+// NewSender creates a sender posting deliveries with the client clients returns for the host of each endpoint
+// (a default client for every host when nil)
+func NewSender(clients func(host string) *http.Client) *Sender {
+	if clients == nil {
+		httpClient := &http.Client{Timeout: defaultSendTimeout}
+		clients = func(string) *http.Client {
+			return httpClient
+		}
+	}
+
+	return &Sender{
+		clients: clients,
+	}
+}
+
+// Send posts a delivery to endpointURL signed with secret; endpoints acknowledge it with a 2xx status
+func (s *Sender) Send(ctx context.Context, endpointURL, secret string, delivery service.WebhookDelivery, now time.Time) error {
+	body, err := json.Marshal(deliveryBody{
+		ID:          delivery.ID,
+		Type:        delivery.Type,
+		AggregateID: delivery.AggregateID,
+		OccurredAt:  delivery.OccurredAt.UTC(),
+		Replayed:    delivery.Replayed,
+		Data:        delivery.Payload,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpointURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", delivery.ID)
+	req.Header.Set(DeliveryIDHeader, delivery.ID)
+	req.Header.Set(DeliveryTimestampHeader, timestamp)
+	req.Header.Set(DefaultSignatureHeader, signaturePrefix+Sign(secret, timestamp+"."+string(body)))
+
+	resp, err := s.clients(req.URL.Host).Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook delivery failed: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook endpoint answered with status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,206 @@
+// Package webhook provides signature verifiers for inbound webhook providers and the sender of the signed
+// deliveries to consumer endpoints
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+)
+
+// Signature schemes
+const (
+	SchemeStripe = "stripe" // Stripe-Signature: t=<unix>,v1=<hex>
+	SchemeHMAC   = "hmac"   // <signature header>: sha256=<hex>, optionally over "<timestamp>.<body>"
+)
+
+// DefaultSignatureHeader carries the signature of hmac scheme deliveries when no header is configured
+const DefaultSignatureHeader = "X-Webhook-Signature"
+
+// DefaultTolerance is the accepted age (and clock skew) of a signed delivery timestamp
+const DefaultTolerance = 5 * time.Minute
+
+// stripeSignatureHeader carries the timestamp and signatures of Stripe deliveries
+const stripeSignatureHeader = "Stripe-Signature"
+
+// signaturePrefix identifies the HMAC algorithm in hmac scheme signatures
+const signaturePrefix = "sha256="
+
+// Verifier authenticates the deliveries of a webhook provider and identifies the event they carry
+type Verifier interface {
+	Verify(header func(name string) string, body []byte, now time.Time) error
+	ParseEvent(body []byte) (service.WebhookEnvelope, error)
+}
+
+// eventEnvelope is the part of a delivery body identifying the event
+// Payment gateways send id/type; bank and e-invoicing networks commonly send event_id/event_type
+type eventEnvelope struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	EventID   string `json:"event_id"`
+	EventType string `json:"event_type"`
+}
+
+// StripeVerifier verifies deliveries signed with the Stripe webhook scheme
+// The signed payload is "<t>.<body>"; any of the v1 signatures may match, so secrets can be rolled
+type StripeVerifier struct {
+	secret    string
+	tolerance time.Duration
+}
+
+// NewStripeVerifier creates a verifier for Stripe style deliveries signed with secret
+func NewStripeVerifier(secret string) *StripeVerifier {
+	return &StripeVerifier{
+		secret:    secret,
+		tolerance: DefaultTolerance,
+	}
+}
+
+// Verify checks the Stripe-Signature header of a delivery
+func (v *StripeVerifier) Verify(header func(name string) string, body []byte, now time.Time) error {
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(header(stripeSignatureHeader), ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+	if timestamp == "" || len(signatures) == 0 {
+		return fmt.Errorf("missing %s header", stripeSignatureHeader)
+	}
+
+	if err := checkTimestamp(timestamp, now, v.tolerance); err != nil {
+		return err
+	}
+
+	expected := Sign(v.secret, timestamp+"."+string(body))
+	for _, signature := range signatures {
+		if hmac.Equal([]byte(expected), []byte(signature)) {
+			return nil
+		}
+	}
+	return fmt.Errorf("no matching signature")
+}
+
+// ParseEvent extracts the event ID and type of a Stripe event
+func (v *StripeVerifier) ParseEvent(body []byte) (service.WebhookEnvelope, error) {
+	return parseEnvelope(body)
+}
+
+// HMACVerifier verifies deliveries carrying an HMAC-SHA256 signature of their body in a header
+// When a timestamp header is configured the signed payload is "<timestamp>.<body>" and stale deliveries are rejected
+type HMACVerifier struct {
+	secret          string
+	signatureHeader string
+	timestampHeader string
+	tolerance       time.Duration
+}
+
+// NewHMACVerifier creates a verifier for deliveries signed with secret
+// signatureHeader defaults to DefaultSignatureHeader; timestampHeader is optional
+func NewHMACVerifier(secret, signatureHeader, timestampHeader string) *HMACVerifier {
+	if signatureHeader == "" {
+		signatureHeader = DefaultSignatureHeader
+	}
+
+	return &HMACVerifier{
+		secret:          secret,
+		signatureHeader: signatureHeader,
+		timestampHeader: timestampHeader,
+		tolerance:       DefaultTolerance,
+	}
+}
+
+// Verify checks the signature header of a delivery
+func (v *HMACVerifier) Verify(header func(name string) string, body []byte, now time.Time) error {
+	signature := strings.TrimPrefix(header(v.signatureHeader), signaturePrefix)
+	if signature == "" {
+		return fmt.Errorf("missing %s header", v.signatureHeader)
+	}
+
+	payload := string(body)
+	if v.timestampHeader != "" {
+		timestamp := header(v.timestampHeader)
+		if err := checkTimestamp(timestamp, now, v.tolerance); err != nil {
+			return err
+		}
+		payload = timestamp + "." + payload
+	}
+
+	if !hmac.Equal([]byte(Sign(v.secret, payload)), []byte(strings.ToLower(signature))) {
+		return fmt.Errorf("signature does not match")
+	}
+	return nil
+}
+
+// ParseEvent extracts the event ID and type of a delivery
+func (v *HMACVerifier) ParseEvent(body []byte) (service.WebhookEnvelope, error) {
+	return parseEnvelope(body)
+}
+
+// NewVerifier creates the verifier for a named signature scheme ("stripe" or "hmac")
+func NewVerifier(scheme, secret, signatureHeader, timestampHeader string) (Verifier, error) {
+	switch strings.ToLower(scheme) {
+	case SchemeStripe:
+		return NewStripeVerifier(secret), nil
+	case SchemeHMAC:
+		return NewHMACVerifier(secret, signatureHeader, timestampHeader), nil
+	default:
+		return nil, fmt.Errorf("unknown webhook signature scheme: %s", scheme)
+	}
+}
+
+// Sign returns the hex HMAC-SHA256 signature of payload, as computed by the providers
+func Sign(secret, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// checkTimestamp rejects missing, malformed and stale unix timestamps
+func checkTimestamp(timestamp string, now time.Time, tolerance time.Duration) error {
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid signature timestamp: %q", timestamp)
+	}
+
+	signedAt := time.Unix(seconds, 0)
+	if signedAt.Before(now.Add(-tolerance)) || signedAt.After(now.Add(tolerance)) {
+		return fmt.Errorf("signature timestamp is outside the accepted window")
+	}
+	return nil
+}
+
+// parseEnvelope extracts the event ID and type of a JSON delivery body
+func parseEnvelope(body []byte) (service.WebhookEnvelope, error) {
+	var envelope eventEnvelope
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return service.WebhookEnvelope{}, fmt.Errorf("webhook body must be a JSON object")
+	}
+
+	result := service.WebhookEnvelope{ID: envelope.ID, Type: envelope.Type}
+	if result.ID == "" {
+		result.ID = envelope.EventID
+	}
+	if result.Type == "" {
+		result.Type = envelope.EventType
+	}
+	if result.ID == "" || result.Type == "" {
+		return service.WebhookEnvelope{}, fmt.Errorf("webhook body must carry an event id and type")
+	}
+	return result, nil
+}
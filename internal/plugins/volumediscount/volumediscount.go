@@ -0,0 +1,69 @@
+// Package volumediscount is an example billing plugin: it discounts the unit amount of invoice lines billing a
+// large quantity, and keeps discounted invoices from being issued below a minimum total.
+// Register it with the DI container before the billing service is resolved:
+//
+//	container.RegisterPlugin(volumediscount.New(100, 1000)) // 10% off lines of 100 units or more
+package volumediscount
+
+import (
+	"fmt"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+)
+
+// Name is the name of the plugin
+const Name = "volume_discount"
+
+// Plugin discounts the lines billing at least a minimum quantity
+type Plugin struct {
+	minQuantity int64
+	discountBps int64 // Discount on the unit amount (1000 = 10%)
+	minTotal    int64 // Minor units; invoices totaling less cannot be issued (0 for no minimum)
+}
+
+// New creates the plugin discounting lines of at least minQuantity units by discountBps
+func New(minQuantity, discountBps int64) *Plugin {
+	return &Plugin{minQuantity: minQuantity, discountBps: discountBps}
+}
+
+// WithMinimumTotal refuses to issue invoices totaling less than minTotal minor units
+func (p *Plugin) WithMinimumTotal(minTotal int64) *Plugin {
+	p.minTotal = minTotal
+	return p
+}
+
+// Name identifies the plugin
+func (p *Plugin) Name() string { return Name }
+
+// PriceInvoice discounts the unit amount of the lines billing at least the minimum quantity, rounding the
+// discount down to the minor unit
+func (p *Plugin) PriceInvoice(subject application.PricingSubject) ([]entity.InvoiceLine, error) {
+	lines := make([]entity.InvoiceLine, len(subject.Lines))
+	for i, line := range subject.Lines {
+		if line.Quantity >= p.minQuantity {
+			line.UnitAmount -= line.UnitAmount * p.discountBps / 10000
+		}
+		lines[i] = line
+	}
+	return lines, nil
+}
+
+// BeforeInvoiceIssue refuses invoices totaling less than the minimum total
+func (p *Plugin) BeforeInvoiceIssue(subject application.InvoiceIssueSubject) error {
+	if p.minTotal == 0 {
+		return nil
+	}
+	total, err := subject.Invoice.Total()
+	if err != nil {
+		return err
+	}
+	if total.Amount() < p.minTotal {
+		violation := errors.NewBusinessRuleError("minimum_invoice_total", errors.BusinessRuleViolation,
+			fmt.Sprintf("invoices must total at least %d minor units to be issued", p.minTotal))
+		violation.Context["minimum_total"] = p.minTotal
+		return violation
+	}
+	return nil
+}
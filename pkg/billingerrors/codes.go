@@ -0,0 +1,58 @@
+// Package billingerrors exports the error codes of the billing API and helpers to branch on them
+// It is shared by the service and its Go SDK so consumers don't string-match error codes,
+// and depends on the standard library only
+package billingerrors
+
+// Code identifies an error in the "code" field of API error responses
+type Code string
+
+// Validation error codes (400 Bad Request)
+const (
+	ValidationRequired Code = "VALIDATION_REQUIRED"
+	ValidationFormat   Code = "VALIDATION_FORMAT"
+	ValidationLength   Code = "VALIDATION_LENGTH"
+	ValidationRange    Code = "VALIDATION_RANGE"
+	ValidationFailed   Code = "VALIDATION_ERROR"
+)
+
+// Business rule error codes (409 Conflict for duplicates and dependents, 422 Unprocessable Entity otherwise)
+const (
+	BusinessRuleViolation Code = "BUSINESS_RULE_VIOLATION"
+	BusinessRuleConflict  Code = "BUSINESS_RULE_CONFLICT"
+	BusinessRuleDuplicate Code = "BUSINESS_RULE_DUPLICATE"
+	BusinessRuleDependent Code = "BUSINESS_RULE_DEPENDENT"
+)
+
+// Repository error codes (404 Not Found, 409 Conflict for constraints, 500 Internal Server Error otherwise)
+const (
+	RepositoryNotFound   Code = "REPOSITORY_NOT_FOUND"
+	RepositoryConnection Code = "REPOSITORY_CONNECTION"
+	RepositoryConstraint Code = "REPOSITORY_CONSTRAINT"
+	RepositoryInternal   Code = "REPOSITORY_INTERNAL"
+)
+
+// Request error codes, answered before the request reaches the domain
+const (
+	InvalidJSON         Code = "INVALID_JSON"
+	InvalidBody         Code = "INVALID_BODY"
+	InvalidPath         Code = "INVALID_PATH"
+	InvalidParameter    Code = "INVALID_PARAMETER"
+	DeprecatedParameter Code = "DEPRECATED_PARAMETER"
+	MethodNotAllowed    Code = "METHOD_NOT_ALLOWED"
+	NotFound            Code = "NOT_FOUND"
+	InternalError       Code = "INTERNAL_ERROR"
+)
+
+// Access error codes (401 Unauthorized, 403 Forbidden)
+const (
+	Unauthorized      Code = "UNAUTHORIZED"
+	ScopeNotGranted   Code = "SCOPE_NOT_GRANTED"
+	IPNotAllowed      Code = "IP_NOT_ALLOWED"
+	TenantNotAllowed  Code = "TENANT_NOT_ALLOWED"
+	SignatureMissing  Code = "SIGNATURE_MISSING"
+	SignatureInvalid  Code = "SIGNATURE_INVALID"
+	SignatureExpired  Code = "SIGNATURE_EXPIRED"
+	SignatureReplayed Code = "SIGNATURE_REPLAYED"
+	CaptchaRequired   Code = "CAPTCHA_REQUIRED"
+	CaptchaInvalid    Code = "CAPTCHA_INVALID"
+)
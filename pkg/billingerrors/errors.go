@@ -0,0 +1,138 @@
+package billingerrors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxErrorBodySize bounds the error response body read by FromResponse
+const maxErrorBodySize = 1 << 20
+
+// Coded is implemented by errors carrying a billing API error code
+// (API errors decoded by FromResponse and the service's domain errors)
+type Coded interface {
+	error
+	ErrorCode() Code
+}
+
+// Error is an error response of the billing API
+type Error struct {
+	StatusCode int                    // HTTP status of the response
+	Code       Code                   // Error code, see the constants of this package
+	Message    string                 // Human readable message
+	Field      string                 // Request field the error is about, if any
+	Details    map[string]interface{} // Context of the rule broken, e.g. the existing resource of a duplicate
+}
+
+func (e *Error) Error() string {
+	if e.Field != "" {
+		return fmt.Sprintf("billing api: %s (%d %s, field %s)", e.Message, e.StatusCode, e.Code, e.Field)
+	}
+	return fmt.Sprintf("billing api: %s (%d %s)", e.Message, e.StatusCode, e.Code)
+}
+
+// ErrorCode returns the error code of the response
+func (e *Error) ErrorCode() Code {
+	return e.Code
+}
+
+// FromResponse returns the error answered by the billing API, or nil for a successful response
+// The body of an error response is consumed; responses without an error envelope (e.g. from a proxy)
+// keep their status with an empty code
+func FromResponse(resp *http.Response) error {
+	if resp.StatusCode < http.StatusBadRequest {
+		return nil
+	}
+
+	apiErr := &Error{StatusCode: resp.StatusCode, Message: http.StatusText(resp.StatusCode)}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	if err != nil {
+		return apiErr
+	}
+
+	var envelope struct {
+		Error struct {
+			Code    Code                   `json:"code"`
+			Message string                 `json:"message"`
+			Field   string                 `json:"field"`
+			Details map[string]interface{} `json:"details"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(body, &envelope) != nil || envelope.Error.Code == "" {
+		if text := strings.TrimSpace(string(body)); text != "" {
+			apiErr.Message = text
+		}
+		return apiErr
+	}
+
+	apiErr.Code = envelope.Error.Code
+	apiErr.Message = envelope.Error.Message
+	apiErr.Field = envelope.Error.Field
+	apiErr.Details = envelope.Error.Details
+	return apiErr
+}
+
+// CodeOf returns the error code of the first coded error in err's chain, or "" when there is none
+func CodeOf(err error) Code {
+	var coded Coded
+	if errors.As(err, &coded) {
+		return coded.ErrorCode()
+	}
+	return ""
+}
+
+// Is reports whether err's chain carries one of the codes
+func Is(err error, codes ...Code) bool {
+	code := CodeOf(err)
+	if code == "" {
+		return false
+	}
+	for _, candidate := range codes {
+		if code == candidate {
+			return true
+		}
+	}
+	return false
+}
+
+// IsNotFound reports whether err is about a resource that does not exist
+func IsNotFound(err error) bool {
+	return Is(err, RepositoryNotFound, NotFound)
+}
+
+// IsValidation reports whether err rejects the request input (fix the request before sending it again)
+func IsValidation(err error) bool {
+	return Is(err, ValidationRequired, ValidationFormat, ValidationLength, ValidationRange, ValidationFailed,
+		InvalidJSON, InvalidBody, InvalidPath, InvalidParameter, DeprecatedParameter)
+}
+
+// IsBusinessRule reports whether err is a business rule the request breaks
+func IsBusinessRule(err error) bool {
+	return Is(err, BusinessRuleViolation, BusinessRuleConflict, BusinessRuleDuplicate, BusinessRuleDependent)
+}
+
+// IsConflict reports whether err conflicts with the current state of a resource
+// (a state change it does not allow, a duplicate, a dependent resource or a constraint)
+func IsConflict(err error) bool {
+	return Is(err, BusinessRuleConflict, BusinessRuleDuplicate, BusinessRuleDependent, RepositoryConstraint)
+}
+
+// IsDuplicate reports whether err is about a resource that already exists (see Error.Details for the existing one)
+func IsDuplicate(err error) bool {
+	return Is(err, BusinessRuleDuplicate)
+}
+
+// IsUnauthorized reports whether err denies access to the caller
+func IsUnauthorized(err error) bool {
+	return Is(err, Unauthorized, ScopeNotGranted, IPNotAllowed, TenantNotAllowed,
+		SignatureMissing, SignatureInvalid, SignatureExpired, SignatureReplayed)
+}
+
+// IsTemporary reports whether err is a transient failure worth retrying later
+func IsTemporary(err error) bool {
+	return Is(err, RepositoryConnection)
+}
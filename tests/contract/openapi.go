@@ -0,0 +1,227 @@
+// OpenAPI Conformance Checks
+//
+// This file validates recorded requests and responses against the published OpenAPI description.
+// Provides: Operation lookup for templated paths, documented status checks, JSON schema validation
+// Pattern: Minimal OpenAPI 3 subset (type, required, properties, items, enum, $ref)
+// Used by: Contract verification of consumer pacts
+package contract
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// OpenAPISpec is a parsed OpenAPI document
+type OpenAPISpec struct {
+	doc map[string]interface{}
+}
+
+// ParseOpenAPISpec parses an OpenAPI document (YAML or JSON)
+func ParseOpenAPISpec(data []byte) (*OpenAPISpec, error) {
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document: %w", err)
+	}
+	if _, ok := doc["paths"].(map[string]interface{}); !ok {
+		return nil, fmt.Errorf("OpenAPI document has no paths")
+	}
+	return &OpenAPISpec{doc: doc}, nil
+}
+
+// Operation returns the documented operation for a method and concrete request path
+func (s *OpenAPISpec) Operation(method, path string) (map[string]interface{}, error) {
+	paths := s.doc["paths"].(map[string]interface{})
+
+	// Literal paths win over templated ones (e.g. /clients/count over /clients/{id})
+	templates := make([]string, 0, len(paths))
+	for template := range paths {
+		templates = append(templates, template)
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		return strings.Count(templates[i], "{") < strings.Count(templates[j], "{")
+	})
+
+	for _, template := range templates {
+		if !matchesPathTemplate(template, path) {
+			continue
+		}
+		item, _ := paths[template].(map[string]interface{})
+		if operation, ok := item[strings.ToLower(method)].(map[string]interface{}); ok {
+			return operation, nil
+		}
+		return nil, fmt.Errorf("%s %s is not documented (path %s has no %s operation)", method, path, template, method)
+	}
+
+	return nil, fmt.Errorf("%s %s is not documented", method, path)
+}
+
+// ValidateResponse checks that a response status is documented for the operation and that a JSON body matches its schema
+func (s *OpenAPISpec) ValidateResponse(method, path string, status int, body []byte) error {
+	operation, err := s.Operation(method, path)
+	if err != nil {
+		return err
+	}
+
+	responses, _ := operation["responses"].(map[string]interface{})
+	response, ok := responses[strconv.Itoa(status)]
+	if !ok {
+		response, ok = responses["default"]
+	}
+	if !ok {
+		return fmt.Errorf("%s %s: status %d is not documented", method, path, status)
+	}
+
+	responseObject, err := s.resolve(response)
+	if err != nil {
+		return err
+	}
+
+	content, _ := responseObject["content"].(map[string]interface{})
+	media, _ := content["application/json"].(map[string]interface{})
+	schema, hasSchema := media["schema"]
+	if !hasSchema || len(body) == 0 || method == http.MethodHead {
+		return nil
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return fmt.Errorf("%s %s: response body is not JSON: %w", method, path, err)
+	}
+
+	return s.validate(schema, value, "$")
+}
+
+// validate checks a decoded JSON value against a schema
+func (s *OpenAPISpec) validate(rawSchema interface{}, value interface{}, location string) error {
+	schema, err := s.resolve(rawSchema)
+	if err != nil {
+		return err
+	}
+
+	if value == nil {
+		if nullable, _ := schema["nullable"].(bool); nullable {
+			return nil
+		}
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, allowed := range enum {
+			if fmt.Sprint(allowed) == fmt.Sprint(value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%s: %v is not one of %v", location, value, enum)
+		}
+	}
+
+	switch schema["type"] {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected object, got %T", location, value)
+		}
+		if required, ok := schema["required"].([]interface{}); ok {
+			for _, name := range required {
+				if _, present := object[fmt.Sprint(name)]; !present {
+					return fmt.Errorf("%s: missing required property %q", location, name)
+				}
+			}
+		}
+		properties, _ := schema["properties"].(map[string]interface{})
+		for name, propertySchema := range properties {
+			if propertyValue, present := object[name]; present {
+				if err := s.validate(propertySchema, propertyValue, location+"."+name); err != nil {
+					return err
+				}
+			}
+		}
+	case "array":
+		array, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected array, got %T", location, value)
+		}
+		if items, ok := schema["items"]; ok {
+			for index, element := range array {
+				if err := s.validate(items, element, fmt.Sprintf("%s[%d]", location, index)); err != nil {
+					return err
+				}
+			}
+		}
+	case "string":
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("%s: expected string, got %T", location, value)
+		}
+	case "integer":
+		number, ok := value.(float64)
+		if !ok || number != float64(int64(number)) {
+			return fmt.Errorf("%s: expected integer, got %v", location, value)
+		}
+	case "number":
+		if _, ok := value.(float64); !ok {
+			return fmt.Errorf("%s: expected number, got %T", location, value)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s: expected boolean, got %T", location, value)
+		}
+	}
+
+	return nil
+}
+
+// resolve follows a local $ref ("#/components/...") to the referenced object
+func (s *OpenAPISpec) resolve(raw interface{}) (map[string]interface{}, error) {
+	object, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid OpenAPI object: %v", raw)
+	}
+
+	ref, ok := object["$ref"].(string)
+	if !ok {
+		return object, nil
+	}
+	if !strings.HasPrefix(ref, "#/") {
+		return nil, fmt.Errorf("unsupported $ref %q", ref)
+	}
+
+	var current interface{} = s.doc
+	for _, segment := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		node, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("unresolvable $ref %q", ref)
+		}
+		current = node[segment]
+	}
+	return s.resolve(current)
+}
+
+// matchesPathTemplate checks a concrete path against an OpenAPI path template
+func matchesPathTemplate(template, path string) bool {
+	templateSegments := strings.Split(strings.Trim(template, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(templateSegments) != len(pathSegments) {
+		return false
+	}
+
+	for index, segment := range templateSegments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			if pathSegments[index] == "" {
+				return false
+			}
+			continue
+		}
+		if segment != pathSegments[index] {
+			return false
+		}
+	}
+	return true
+}
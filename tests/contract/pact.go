@@ -0,0 +1,320 @@
+// Pact Contract Model and Matching
+//
+// This file reads consumer contracts in the Pact v3 JSON format and compares provider responses with them.
+// Provides: Pact file parsing, provider state path generators, type/regex/integer/number matching rules
+// Pattern: Pact semantics - expected object keys must be present, extra keys are allowed
+// Used by: Contract verification of consumer pacts
+package contract
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Pact is a consumer contract with the provider
+type Pact struct {
+	Consumer     Participant   `json:"consumer"`
+	Provider     Participant   `json:"provider"`
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Participant names a pact consumer or provider
+type Participant struct {
+	Name string `json:"name"`
+}
+
+// Interaction is one expected request/response pair
+type Interaction struct {
+	Description    string          `json:"description"`
+	ProviderStates []ProviderState `json:"providerStates"`
+	Request        PactRequest     `json:"request"`
+	Response       PactResponse    `json:"response"`
+}
+
+// ProviderState is a named precondition the provider must set up before the request
+type ProviderState struct {
+	Name   string                 `json:"name"`
+	Params map[string]interface{} `json:"params,omitempty"`
+}
+
+// PactRequest is the request a consumer sends
+type PactRequest struct {
+	Method     string                `json:"method"`
+	Path       string                `json:"path"`
+	Query      map[string][]string   `json:"query,omitempty"`
+	Headers    map[string]string     `json:"headers,omitempty"`
+	Body       json.RawMessage       `json:"body,omitempty"`
+	Generators map[string]Generators `json:"generators,omitempty"`
+}
+
+// Generators holds value generators by category (only the "path" ProviderState generator is supported)
+type Generators struct {
+	Type       string `json:"type"`
+	Expression string `json:"expression"`
+}
+
+// PactResponse is the response a consumer expects
+type PactResponse struct {
+	Status        int                             `json:"status"`
+	Headers       map[string]string               `json:"headers,omitempty"`
+	Body          json.RawMessage                 `json:"body,omitempty"`
+	MatchingRules map[string]map[string]RuleGroup `json:"matchingRules,omitempty"`
+}
+
+// RuleGroup is the list of matchers applied to one path
+type RuleGroup struct {
+	Matchers []Matcher `json:"matchers"`
+}
+
+// Matcher is a Pact matching rule
+type Matcher struct {
+	Match string `json:"match"`
+	Regex string `json:"regex,omitempty"`
+	Min   *int   `json:"min,omitempty"`
+	Max   *int   `json:"max,omitempty"`
+}
+
+// LoadPact reads a pact file
+func LoadPact(path string) (*Pact, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pact %s: %w", path, err)
+	}
+
+	var pact Pact
+	if err := json.Unmarshal(data, &pact); err != nil {
+		return nil, fmt.Errorf("failed to parse pact %s: %w", path, err)
+	}
+	return &pact, nil
+}
+
+// placeholderPattern matches ${name} provider state expressions
+var placeholderPattern = regexp.MustCompile(`\$\{(\w+)\}`)
+
+// RequestPath returns the request path, applying a ProviderState path generator with the state values
+func (r PactRequest) RequestPath(values map[string]string) (string, error) {
+	generator, ok := r.Generators["path"]
+	if !ok || generator.Type != "ProviderState" {
+		return r.Path, nil
+	}
+
+	var missing []string
+	path := placeholderPattern.ReplaceAllStringFunc(generator.Expression, func(placeholder string) string {
+		name := placeholderPattern.FindStringSubmatch(placeholder)[1]
+		value, ok := values[name]
+		if !ok {
+			missing = append(missing, name)
+		}
+		return value
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("provider state did not supply %s", strings.Join(missing, ", "))
+	}
+	return path, nil
+}
+
+// CompareHeaders checks that every expected header is present; media types ignore parameters such as charset
+func CompareHeaders(expected map[string]string, actual func(string) string) []string {
+	var mismatches []string
+	for name, want := range expected {
+		got := actual(name)
+		if strings.EqualFold(name, "Content-Type") {
+			wantType, _, _ := mime.ParseMediaType(want)
+			gotType, _, _ := mime.ParseMediaType(got)
+			if wantType == gotType {
+				continue
+			}
+		} else if got == want {
+			continue
+		}
+		mismatches = append(mismatches, fmt.Sprintf("header %s: expected %q, got %q", name, want, got))
+	}
+	sort.Strings(mismatches)
+	return mismatches
+}
+
+// CompareBody compares an actual JSON body with the expected one using the body matching rules
+func CompareBody(expected, actual json.RawMessage, rules map[string]RuleGroup) []string {
+	if len(expected) == 0 {
+		return nil
+	}
+
+	var want, got interface{}
+	if err := json.Unmarshal(expected, &want); err != nil {
+		return []string{fmt.Sprintf("expected body is not JSON: %v", err)}
+	}
+	if err := json.Unmarshal(actual, &got); err != nil {
+		return []string{fmt.Sprintf("actual body is not JSON: %v", err)}
+	}
+
+	matcher := bodyMatcher{rules: compileRules(rules)}
+	matcher.compare("$", want, got)
+	return matcher.mismatches
+}
+
+// compiledRule is a matching rule with its path expression turned into a regular expression
+type compiledRule struct {
+	path     *regexp.Regexp
+	matchers []Matcher
+}
+
+func compileRules(rules map[string]RuleGroup) []compiledRule {
+	compiled := make([]compiledRule, 0, len(rules))
+	for path, group := range rules {
+		pattern := regexp.QuoteMeta(path)
+		pattern = strings.ReplaceAll(pattern, `\[\*\]`, `\[\d+\]`)
+		pattern = strings.ReplaceAll(pattern, `\.\*`, `\.[^.\[]+`)
+		compiled = append(compiled, compiledRule{
+			path:     regexp.MustCompile("^" + pattern + "$"),
+			matchers: group.Matchers,
+		})
+	}
+	return compiled
+}
+
+// bodyMatcher walks expected and actual values collecting mismatches
+type bodyMatcher struct {
+	rules      []compiledRule
+	mismatches []string
+}
+
+func (m *bodyMatcher) matchersFor(path string) []Matcher {
+	for _, rule := range m.rules {
+		if rule.path.MatchString(path) {
+			return rule.matchers
+		}
+	}
+	return nil
+}
+
+func (m *bodyMatcher) fail(path, format string, args ...interface{}) {
+	m.mismatches = append(m.mismatches, path+": "+fmt.Sprintf(format, args...))
+}
+
+func (m *bodyMatcher) compare(path string, want, got interface{}) {
+	matchers := m.matchersFor(path)
+
+	for _, matcher := range matchers {
+		switch matcher.Match {
+		case "type":
+			if jsonType(want) != jsonType(got) {
+				m.fail(path, "expected %s, got %s", jsonType(want), jsonType(got))
+				return
+			}
+		case "regex":
+			text, ok := got.(string)
+			if !ok || !regexp.MustCompile(matcher.Regex).MatchString(text) {
+				m.fail(path, "%v does not match /%s/", got, matcher.Regex)
+			}
+			return
+		case "integer":
+			number, ok := got.(float64)
+			if !ok || number != float64(int64(number)) {
+				m.fail(path, "expected integer, got %v", got)
+			}
+			return
+		case "number", "decimal":
+			if _, ok := got.(float64); !ok {
+				m.fail(path, "expected number, got %v", got)
+			}
+			return
+		case "include":
+			text, _ := got.(string)
+			if !strings.Contains(text, fmt.Sprint(want)) {
+				m.fail(path, "%q does not include %q", text, want)
+			}
+			return
+		default:
+			m.fail(path, "unsupported matcher %q", matcher.Match)
+			return
+		}
+	}
+
+	switch expected := want.(type) {
+	case map[string]interface{}:
+		actual, ok := got.(map[string]interface{})
+		if !ok {
+			m.fail(path, "expected object, got %s", jsonType(got))
+			return
+		}
+		keys := make([]string, 0, len(expected))
+		for key := range expected {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			value, present := actual[key]
+			if !present {
+				m.fail(path+"."+key, "missing")
+				continue
+			}
+			m.compare(path+"."+key, expected[key], value)
+		}
+	case []interface{}:
+		actual, ok := got.([]interface{})
+		if !ok {
+			m.fail(path, "expected array, got %s", jsonType(got))
+			return
+		}
+		m.compareArray(path, expected, actual, matchers)
+	default:
+		// Leaf values without a matcher must be equal
+		if len(matchers) == 0 && fmt.Sprint(want) != fmt.Sprint(got) {
+			m.fail(path, "expected %v, got %v", want, got)
+		}
+	}
+}
+
+// compareArray applies Pact array semantics: with a type matcher every element matches the first expected one
+func (m *bodyMatcher) compareArray(path string, expected, actual []interface{}, matchers []Matcher) {
+	for _, matcher := range matchers {
+		if matcher.Match != "type" {
+			continue
+		}
+		if matcher.Min != nil && len(actual) < *matcher.Min {
+			m.fail(path, "expected at least %d elements, got %d", *matcher.Min, len(actual))
+		}
+		if matcher.Max != nil && len(actual) > *matcher.Max {
+			m.fail(path, "expected at most %d elements, got %d", *matcher.Max, len(actual))
+		}
+		if len(expected) > 0 {
+			for index, element := range actual {
+				m.compare(fmt.Sprintf("%s[%d]", path, index), expected[0], element)
+			}
+		}
+		return
+	}
+
+	if len(expected) != len(actual) {
+		m.fail(path, "expected %d elements, got %d", len(expected), len(actual))
+		return
+	}
+	for index := range expected {
+		m.compare(fmt.Sprintf("%s[%d]", path, index), expected[index], actual[index])
+	}
+}
+
+// jsonType names the JSON type of a decoded value
+func jsonType(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
@@ -0,0 +1,49 @@
+package contract
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/gjaminon-go-labs/billing-api/api"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/tests/testhelpers"
+)
+
+// providerStates seeds the data each consumer expectation relies on
+var providerStates = map[string]StateHandler{
+	"a client exists": func(t *testing.T, stack *testhelpers.TestStack, params map[string]interface{}) map[string]string {
+		client, err := stack.BillingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: fmt.Sprint(params["name"]), Email: fmt.Sprint(params["email"]), Phone: "+15550009999", Address: "1 Provider State Rd"})
+		if err != nil {
+			t.Fatalf("Failed to seed client: %v", err)
+		}
+		return map[string]string{"clientId": client.ID()}
+	},
+	"clients exist": func(t *testing.T, stack *testhelpers.TestStack, params map[string]interface{}) map[string]string {
+		count, _ := params["count"].(float64)
+		for i := 1; i <= int(count); i++ {
+			if _, err := stack.BillingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: fmt.Sprintf("Client %d", i), Email: fmt.Sprintf("client%d@example.com", i), Phone: "+15550009999", Address: fmt.Sprintf("%d Provider State Rd", i)}); err != nil {
+				t.Fatalf("Failed to seed client %d: %v", i, err)
+			}
+		}
+		return nil
+	},
+}
+
+func TestProvider_VerifiesConsumerPacts(t *testing.T) {
+	spec, err := ParseOpenAPISpec(api.OpenAPISpec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	provider := &Provider{
+		NewStack: testhelpers.NewIsolatedUnitTestStack,
+		States:   providerStates,
+		Spec:     spec,
+	}
+
+	for _, pactFile := range PactFiles(t, filepath.Join("pacts", "*.json")) {
+		provider.VerifyPact(t, pactFile)
+	}
+}
@@ -0,0 +1,153 @@
+// Provider Contract Verifier
+//
+// This file replays consumer pacts against the API served by httptest and checks every response
+// against both the pact and the published OpenAPI description.
+// Provides: Provider state setup hooks, per-interaction fresh servers, readable mismatch reports
+// Pattern: CI-local verification (pact files on disk, no broker); PACT_FILES overrides the pact glob
+// Used by: tests/contract provider verification
+package contract
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/gjaminon-go-labs/billing-api/tests/testhelpers"
+)
+
+// PactFilesEnv overrides the pact files to verify (glob), e.g. pacts downloaded from a consumer's CI
+const PactFilesEnv = "PACT_FILES"
+
+// StateHandler seeds a provider state through the test stack and returns values for ProviderState generators
+type StateHandler func(t *testing.T, stack *testhelpers.TestStack, params map[string]interface{}) map[string]string
+
+// Provider describes how to run the API for verification
+type Provider struct {
+	// NewStack creates an isolated test stack for one interaction
+	NewStack func() *testhelpers.TestStack
+
+	// States maps provider state names to their setup functions
+	States map[string]StateHandler
+
+	// Spec is the OpenAPI description responses must conform to
+	Spec *OpenAPISpec
+}
+
+// PactFiles returns the pact files to verify: PACT_FILES if set, otherwise the default glob
+func PactFiles(t *testing.T, defaultGlob string) []string {
+	t.Helper()
+
+	pattern := defaultGlob
+	if override := os.Getenv(PactFilesEnv); override != "" {
+		pattern = override
+	}
+
+	files, err := filepath.Glob(pattern)
+	if err != nil {
+		t.Fatalf("Invalid pact glob %q: %v", pattern, err)
+	}
+	if len(files) == 0 {
+		t.Fatalf("No pact files match %q", pattern)
+	}
+	sort.Strings(files)
+	return files
+}
+
+// VerifyPact replays every interaction of a pact file as a subtest
+func (p *Provider) VerifyPact(t *testing.T, pactFile string) {
+	t.Helper()
+
+	pact, err := LoadPact(pactFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, interaction := range pact.Interactions {
+		interaction := interaction
+		t.Run(pact.Consumer.Name+"/"+interaction.Description, func(t *testing.T) {
+			p.verifyInteraction(t, interaction)
+		})
+	}
+}
+
+// verifyInteraction sets up provider states, sends the request and compares the response
+func (p *Provider) verifyInteraction(t *testing.T, interaction Interaction) {
+	stack := p.NewStack()
+
+	values := make(map[string]string)
+	for _, state := range interaction.ProviderStates {
+		setup, ok := p.States[state.Name]
+		if !ok {
+			t.Fatalf("Missing provider state handler for %q", state.Name)
+		}
+		for name, value := range setup(t, stack, state.Params) {
+			values[name] = value
+		}
+	}
+
+	path, err := interaction.Request.RequestPath(values)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := p.Spec.Operation(interaction.Request.Method, path); err != nil {
+		t.Fatalf("Consumer expects an undocumented operation: %v", err)
+	}
+
+	server := httptest.NewServer(stack.HTTPServer.Handler())
+	defer server.Close()
+
+	requestURL := server.URL + path
+	if len(interaction.Request.Query) > 0 {
+		requestURL += "?" + url.Values(interaction.Request.Query).Encode()
+	}
+
+	var body io.Reader
+	if len(interaction.Request.Body) > 0 {
+		body = bytes.NewReader(interaction.Request.Body)
+	}
+
+	req, err := http.NewRequest(interaction.Request.Method, requestURL, body)
+	if err != nil {
+		t.Fatalf("Failed to build request: %v", err)
+	}
+	for name, value := range interaction.Request.Headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := server.Client().Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Failed to read response: %v", err)
+	}
+
+	var mismatches []string
+	if resp.StatusCode != interaction.Response.Status {
+		mismatches = append(mismatches, fmt.Sprintf("status: expected %d, got %d", interaction.Response.Status, resp.StatusCode))
+	}
+	mismatches = append(mismatches, CompareHeaders(interaction.Response.Headers, resp.Header.Get)...)
+	mismatches = append(mismatches, CompareBody(interaction.Response.Body, responseBody, interaction.Response.MatchingRules["body"])...)
+
+	if err := p.Spec.ValidateResponse(interaction.Request.Method, path, resp.StatusCode, responseBody); err != nil {
+		mismatches = append(mismatches, "openapi: "+err.Error())
+	}
+
+	for _, mismatch := range mismatches {
+		t.Error(mismatch)
+	}
+	if len(mismatches) > 0 {
+		t.Logf("Response body: %s", responseBody)
+	}
+}
@@ -1,22 +1,198 @@
 package infrastructure
 
 import (
+	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
 	"sync"
 
 	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
 )
 
 // InMemoryStorage provides an in-memory implementation of the Storage interface for testing
+// Values are listed in insertion order, like the created_at order of the PostgreSQL storage
 type InMemoryStorage struct {
-	data  map[string]interface{}
-	mutex sync.RWMutex
+	data        map[string]interface{}
+	keys        []string // Keys in insertion order; updating a value keeps its position
+	collections map[string]*InMemoryStorage
+	sequence    int64
+	counters    map[string]int64 // Gap-free counters per scope, rewound with the unit of work that allocated a value
+	mutex       sync.RWMutex
+	units       *sync.Mutex // Serializes the units of work of a storage and its collections
 }
 
 // NewInMemoryStorage creates a new in-memory storage instance
 func NewInMemoryStorage() *InMemoryStorage {
 	return &InMemoryStorage{
-		data: make(map[string]interface{}),
+		data:        make(map[string]interface{}),
+		collections: make(map[string]*InMemoryStorage),
+		counters:    make(map[string]int64),
+		units:       &sync.Mutex{},
+	}
+}
+
+// Collection returns the named in-memory collection, creating it on first use
+func (s *InMemoryStorage) Collection(name string) storage.Storage {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	collection, exists := s.collections[name]
+	if !exists {
+		collection = NewInMemoryStorage()
+		collection.units = s.units
+		s.collections[name] = collection
+	}
+	return collection
+}
+
+// NextSequence allocates the next sequence number of this storage
+func (s *InMemoryStorage) NextSequence() (int64, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.sequence++
+	return s.sequence, nil
+}
+
+// NextCounterValue increments the gap-free counter of scope and returns its new value
+func (s *InMemoryStorage) NextCounterValue(scope string) (int64, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.counters[scope]++
+	return s.counters[scope], nil
+}
+
+// InTransaction runs fn against a transaction-bound view of this storage and undoes the writes made through the
+// view if it fails. Writes made meanwhile outside the unit of work are kept
+// Units of work run one at a time over the storage and its collections, like rows locked until commit, so a rewound
+// gap-free counter value is never handed out twice. Nested calls on the view undo only their own writes, mirroring
+// database savepoints
+func (s *InMemoryStorage) InTransaction(fn func(tx storage.Storage) error) error {
+	s.units.Lock()
+	defer s.units.Unlock()
+
+	return (&inMemoryTx{InMemoryStorage: s, unit: &inMemoryUnitOfWork{}}).InTransaction(fn)
+}
+
+// inMemoryUnitOfWork is the undo log of the writes of a unit of work, oldest first
+type inMemoryUnitOfWork struct {
+	undo []func()
+}
+
+// rollback undoes the writes logged after mark, newest first
+func (u *inMemoryUnitOfWork) rollback(mark int) {
+	for i := len(u.undo) - 1; i >= mark; i-- {
+		u.undo[i]()
+	}
+	u.undo = u.undo[:mark]
+}
+
+// inMemoryTx is the view of a storage or collection bound to a unit of work: its writes are logged to be undone
+type inMemoryTx struct {
+	*InMemoryStorage
+	unit *inMemoryUnitOfWork
+}
+
+// Collection returns the view of the named collection bound to the same unit of work
+func (t *inMemoryTx) Collection(name string) storage.Storage {
+	return &inMemoryTx{InMemoryStorage: t.InMemoryStorage.Collection(name).(*InMemoryStorage), unit: t.unit}
+}
+
+// InTransaction runs fn as a nested unit of work, undoing only its writes if it fails (or panics)
+func (t *inMemoryTx) InTransaction(fn func(tx storage.Storage) error) (err error) {
+	mark := len(t.unit.undo)
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			t.unit.rollback(mark)
+			panic(recovered)
+		}
+	}()
+
+	if err := fn(t); err != nil {
+		t.unit.rollback(mark)
+		return err
+	}
+	return nil
+}
+
+// Store saves a value, logging the previous one
+func (t *inMemoryTx) Store(key string, value interface{}) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.unit.undo = append(t.unit.undo, t.undoWrite(key))
+	t.store(key, value)
+	return nil
+}
+
+// UpdateIfUnset replaces the value of key if the field at path is unset, logging the previous value
+func (t *inMemoryTx) UpdateIfUnset(key, path string, value interface{}) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	undo := t.undoWrite(key)
+	if err := t.updateIfUnset(key, path, value); err != nil {
+		return err
+	}
+	t.unit.undo = append(t.unit.undo, undo)
+	return nil
+}
+
+// Delete removes a value, logging it
+func (t *inMemoryTx) Delete(key string) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	undo := t.undoWrite(key)
+	if err := t.delete(key); err != nil {
+		return err
+	}
+	t.unit.undo = append(t.unit.undo, undo)
+	return nil
+}
+
+// NextCounterValue allocates the next value of a gap-free counter, which is rewound if the unit of work fails
+// A counter that allocated another value since is not rewound: it keeps a gap rather than repeat a value
+func (t *inMemoryTx) NextCounterValue(scope string) (int64, error) {
+	value, err := t.InMemoryStorage.NextCounterValue(scope)
+	if err != nil {
+		return 0, err
+	}
+
+	s := t.InMemoryStorage
+	t.unit.undo = append(t.unit.undo, func() {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+
+		if s.counters[scope] == value {
+			s.counters[scope] = value - 1
+		}
+	})
+	return value, nil
+}
+
+// undoWrite returns the function restoring the current value of key, and its position, or removing a key that does
+// not exist yet. The caller holds the lock
+func (s *InMemoryStorage) undoWrite(key string) func() {
+	previous, existed := s.data[key]
+	position := slices.Index(s.keys, key)
+
+	return func() {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+
+		if !existed {
+			if _, exists := s.data[key]; exists {
+				s.delete(key)
+			}
+			return
+		}
+		if _, exists := s.data[key]; !exists {
+			s.keys = slices.Insert(s.keys, min(position, len(s.keys)), key)
+		}
+		s.data[key] = previous
 	}
 }
 
@@ -25,10 +201,18 @@ func (s *InMemoryStorage) Store(key string, value interface{}) error {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
-	s.data[key] = value
+	s.store(key, value)
 	return nil
 }
 
+// store saves a value with the given key; the caller holds the lock
+func (s *InMemoryStorage) store(key string, value interface{}) {
+	if _, exists := s.data[key]; !exists {
+		s.keys = append(s.keys, key)
+	}
+	s.data[key] = value
+}
+
 // Get retrieves a value by key
 func (s *InMemoryStorage) Get(key string) (interface{}, error) {
 	s.mutex.RLock()
@@ -51,28 +235,88 @@ func (s *InMemoryStorage) Exists(key string) bool {
 	return exists
 }
 
-// ListAll retrieves all stored values
+// ListAll retrieves all stored values in insertion order
 func (s *InMemoryStorage) ListAll() ([]interface{}, error) {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
-	values := make([]interface{}, 0, len(s.data))
-	for _, value := range s.data {
-		values = append(values, value)
+	values := make([]interface{}, 0, len(s.keys))
+	for _, key := range s.keys {
+		values = append(values, s.data[key])
 	}
 
 	return values, nil
 }
 
+// UpdateIfUnset replaces the value of key if the field at path of its JSON form is absent or null
+// The check and the update hold the lock together, like the conditional UPDATE of the PostgreSQL storage
+func (s *InMemoryStorage) UpdateIfUnset(key, path string, value interface{}) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.updateIfUnset(key, path, value)
+}
+
+// updateIfUnset replaces the value of key if the field at path is unset; the caller holds the lock
+func (s *InMemoryStorage) updateIfUnset(key, path string, value interface{}) error {
+	stored, exists := s.data[key]
+	if !exists {
+		return fmt.Errorf("%w: %s", storage.ErrKeyNotFound, key)
+	}
+
+	field, err := jsonField(stored, path)
+	if err != nil {
+		return err
+	}
+	if field != nil {
+		return fmt.Errorf("%w: %s of %s", storage.ErrFieldSet, path, key)
+	}
+
+	s.data[key] = value
+	return nil
+}
+
+// jsonField returns the field at path (dot-separated) of the JSON form of a value, nil when absent or null
+func jsonField(value interface{}, path string) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize value: %w", err)
+	}
+	var field interface{}
+	if err := json.Unmarshal(data, &field); err != nil {
+		return nil, fmt.Errorf("failed to deserialize value: %w", err)
+	}
+
+	for _, name := range strings.Split(path, ".") {
+		object, ok := field.(map[string]interface{})
+		if !ok {
+			return nil, nil
+		}
+		field = object[name]
+	}
+	return field, nil
+}
+
 // Delete removes a value by key
 func (s *InMemoryStorage) Delete(key string) error {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
+	return s.delete(key)
+}
+
+// delete removes a value by key; the caller holds the lock
+func (s *InMemoryStorage) delete(key string) error {
 	if _, exists := s.data[key]; !exists {
 		return fmt.Errorf("%w: %s", storage.ErrKeyNotFound, key)
 	}
 
 	delete(s.data, key)
+	for i, existing := range s.keys {
+		if existing == key {
+			s.keys = append(s.keys[:i:i], s.keys[i+1:]...)
+			break
+		}
+	}
 	return nil
 }
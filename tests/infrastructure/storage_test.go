@@ -1,8 +1,11 @@
 package infrastructure
 
 import (
+	"errors"
+	"sync"
 	"testing"
 
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -50,6 +53,41 @@ func TestInMemoryStorage_ListAll_WithMultipleItems(t *testing.T) {
 	}
 }
 
+func TestInMemoryStorage_ListAll_InsertionOrder(t *testing.T) {
+	// Arrange
+	memory := NewInMemoryStorage()
+	for _, key := range []string{"c", "a", "b"} {
+		assert.NoError(t, memory.Store(key, key+"1"))
+	}
+
+	// Act & Assert: updates keep their position, re-created keys move last
+	result, err := memory.ListAll()
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"c1", "a1", "b1"}, result)
+
+	assert.NoError(t, memory.Store("a", "a2"))
+	result, err = memory.ListAll()
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"c1", "a2", "b1"}, result)
+
+	assert.NoError(t, memory.Delete("c"))
+	assert.NoError(t, memory.Store("c", "c2"))
+	result, err = memory.ListAll()
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"a2", "b1", "c2"}, result)
+
+	// A rolled back transaction restores the order
+	err = memory.InTransaction(func(tx storage.Storage) error {
+		assert.NoError(t, tx.Delete("a"))
+		assert.NoError(t, tx.Store("a", "a3"))
+		return errors.New("rollback")
+	})
+	assert.Error(t, err)
+	result, err = memory.ListAll()
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"a2", "b1", "c2"}, result)
+}
+
 func TestInMemoryStorage_ListAll_SingleItem(t *testing.T) {
 	// Arrange
 	storage := NewInMemoryStorage()
@@ -67,3 +105,120 @@ func TestInMemoryStorage_ListAll_SingleItem(t *testing.T) {
 	assert.Len(t, result, 1)
 	assert.Equal(t, expectedValue, result[0])
 }
+
+func TestInMemoryStorage_InTransaction_NestedRollback(t *testing.T) {
+	// Arrange
+	memory := NewInMemoryStorage()
+	clients := memory.Collection("clients")
+	errInner := errors.New("inner failure")
+
+	// Act
+	err := storage.RunInTransaction(memory, func(tx storage.Storage) error {
+		txClients, err := storage.ForCollection(tx, "clients")
+		assert.NoError(t, err)
+		assert.NoError(t, txClients.Store("outer", "kept"))
+
+		innerErr := storage.RunInTransaction(tx, func(inner storage.Storage) error {
+			innerClients, err := storage.ForCollection(inner, "clients")
+			assert.NoError(t, err)
+			assert.NoError(t, innerClients.Store("inner", "discarded"))
+			assert.NoError(t, innerClients.Delete("outer"))
+			return errInner
+		})
+		assert.ErrorIs(t, innerErr, errInner)
+		return nil
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, clients.Exists("outer"))
+	assert.False(t, clients.Exists("inner"))
+}
+
+func TestInMemoryStorage_InTransaction_OuterRollback(t *testing.T) {
+	// Arrange
+	memory := NewInMemoryStorage()
+	assert.NoError(t, memory.Store("existing", "value"))
+
+	// Act
+	err := storage.RunInTransaction(memory, func(tx storage.Storage) error {
+		assert.NoError(t, tx.Store("new", "value"))
+		assert.NoError(t, storage.RunInTransaction(tx, func(inner storage.Storage) error {
+			return inner.Delete("existing")
+		}))
+		return errors.New("outer failure")
+	})
+
+	// Assert
+	assert.Error(t, err)
+	assert.True(t, memory.Exists("existing"))
+	assert.False(t, memory.Exists("new"))
+}
+
+func TestInMemoryStorage_InTransaction_ConcurrentUnitsOfWork(t *testing.T) {
+	// Arrange
+	memory := NewInMemoryStorage()
+	invoices := memory.Collection("invoices")
+	allocated := make(chan struct{})
+	release := make(chan struct{})
+	var wg sync.WaitGroup
+	var number int64
+
+	// Act: a unit of work allocates an invoice number and fails while another request writes and a second unit
+	// of work waits for it
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		err := storage.RunInTransaction(invoices, func(tx storage.Storage) error {
+			_, err := storage.NextCounterValue(tx, "invoices-2026")
+			assert.NoError(t, err)
+			assert.NoError(t, tx.Store("failed", "discarded"))
+			close(allocated)
+			<-release
+			return errors.New("failure")
+		})
+		assert.Error(t, err)
+	}()
+	<-allocated
+	assert.NoError(t, invoices.Store("concurrent", "kept"))
+	go func() {
+		defer wg.Done()
+		err := storage.RunInTransaction(invoices, func(tx storage.Storage) error {
+			var err error
+			number, err = storage.NextCounterValue(tx, "invoices-2026")
+			assert.NoError(t, err)
+			return tx.Store("numbered", number)
+		})
+		assert.NoError(t, err)
+	}()
+	close(release)
+	wg.Wait()
+
+	// Assert: only the writes of the failed unit of work are undone, and its number is not handed out twice
+	assert.True(t, invoices.Exists("concurrent"))
+	assert.True(t, invoices.Exists("numbered"))
+	assert.False(t, invoices.Exists("failed"))
+	assert.Equal(t, int64(1), number)
+	next, err := storage.NextCounterValue(invoices, "invoices-2026")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), next)
+}
+
+func TestInMemoryStorage_UpdateIfUnset(t *testing.T) {
+	// Arrange
+	memory := NewInMemoryStorage()
+	assert.NoError(t, memory.Store("link", map[string]interface{}{"id": "link"}))
+
+	// Act
+	first := storage.UpdateIfUnset(memory, "link", "consumedAt", map[string]interface{}{"id": "link", "consumedAt": "first"})
+	second := storage.UpdateIfUnset(memory, "link", "consumedAt", map[string]interface{}{"id": "link", "consumedAt": "second"})
+	missing := storage.UpdateIfUnset(memory, "unknown", "consumedAt", map[string]interface{}{"id": "unknown"})
+
+	// Assert
+	assert.NoError(t, first)
+	assert.ErrorIs(t, second, storage.ErrFieldSet)
+	assert.ErrorIs(t, missing, storage.ErrKeyNotFound)
+	value, err := memory.Get("link")
+	assert.NoError(t, err)
+	assert.Equal(t, "first", value.(map[string]interface{})["consumedAt"])
+}
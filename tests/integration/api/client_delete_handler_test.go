@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/di"
 	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
 	"github.com/gjaminon-go-labs/billing-api/tests/testhelpers"
 )
@@ -53,6 +54,7 @@ func TestClientHandler_DeleteClient_Success(t *testing.T) {
 	// Test DELETE request
 	url := fmt.Sprintf("/api/v1/clients/%s", validScenario.Client.ID)
 	req := httptest.NewRequest(http.MethodDelete, url, nil)
+	req.Header.Set("Authorization", "Bearer "+di.TestAdminToken)
 	req.RemoteAddr = "192.0.2.1:1234"
 
 	w := httptest.NewRecorder()
@@ -85,6 +87,7 @@ func TestClientHandler_DeleteClient_NotFound(t *testing.T) {
 	// Test DELETE request with non-existent ID
 	url := fmt.Sprintf("/api/v1/clients/%s", nonExistentID)
 	req := httptest.NewRequest(http.MethodDelete, url, nil)
+	req.Header.Set("Authorization", "Bearer "+di.TestAdminToken)
 	req.RemoteAddr = "192.0.2.1:1234"
 
 	w := httptest.NewRecorder()
@@ -119,6 +122,7 @@ func TestClientHandler_DeleteClient_InvalidUUID(t *testing.T) {
 			// Test DELETE request with invalid ID
 			url := fmt.Sprintf("/api/v1/clients/%s", invalidID)
 			req := httptest.NewRequest(http.MethodDelete, url, nil)
+			req.Header.Set("Authorization", "Bearer "+di.TestAdminToken)
 			req.RemoteAddr = "192.0.2.1:1234"
 
 			w := httptest.NewRecorder()
@@ -5,18 +5,16 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
-	"os"
-	"path/filepath"
-	"runtime"
 	"testing"
 	"time"
 
-	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/require"
-
 	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/di"
 	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/tests/testdata"
 	"github.com/gjaminon-go-labs/billing-api/tests/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // BUSINESS_TITLE: Retrieve Individual Client Information
@@ -52,6 +50,7 @@ func TestClientHandler_GetClient_Success(t *testing.T) {
 	// Test GET request
 	url := fmt.Sprintf("/api/v1/clients/%s", validScenario.Client.ID)
 	req := httptest.NewRequest(http.MethodGet, url, nil)
+	req.Header.Set("Authorization", "Bearer "+di.TestAdminToken)
 	req.RemoteAddr = "192.0.2.1:1234"
 
 	w := httptest.NewRecorder()
@@ -90,6 +89,7 @@ func TestClientHandler_GetClient_NotFound(t *testing.T) {
 	// Test GET request with non-existent ID
 	url := fmt.Sprintf("/api/v1/clients/%s", nonExistentID)
 	req := httptest.NewRequest(http.MethodGet, url, nil)
+	req.Header.Set("Authorization", "Bearer "+di.TestAdminToken)
 	req.RemoteAddr = "192.0.2.1:1234"
 
 	w := httptest.NewRecorder()
@@ -124,6 +124,7 @@ func TestClientHandler_GetClient_InvalidUUID(t *testing.T) {
 			// Test GET request with invalid ID
 			url := fmt.Sprintf("/api/v1/clients/%s", invalidID)
 			req := httptest.NewRequest(http.MethodGet, url, nil)
+			req.Header.Set("Authorization", "Bearer "+di.TestAdminToken)
 			req.RemoteAddr = "192.0.2.1:1234"
 
 			w := httptest.NewRecorder()
@@ -148,22 +149,7 @@ func TestClientHandler_GetClient_InvalidUUID(t *testing.T) {
 
 // loadGetClientScenarios loads test scenarios from JSON file
 func loadGetClientScenarios(t *testing.T) []GetClientScenario {
-	// Get current file directory
-	_, currentFile, _, ok := runtime.Caller(0)
-	require.True(t, ok, "Failed to get current file path")
-
-	// Build path to testdata
-	testDataPath := filepath.Join(filepath.Dir(currentFile), "..", "..", "testdata", "client", "get_client_scenarios.json")
-
-	// Read and parse JSON
-	data, err := os.ReadFile(testDataPath)
-	require.NoError(t, err, "Failed to read get client scenarios file")
-
-	var scenarios []GetClientScenario
-	err = json.Unmarshal(data, &scenarios)
-	require.NoError(t, err, "Failed to parse get client scenarios JSON")
-
-	return scenarios
+	return testdata.Load[[]GetClientScenario](t, "client/get_client_scenarios.json")
 }
 
 // GetClientScenario represents test data for get client operations
@@ -26,12 +26,11 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
-	"os"
-	"path/filepath"
-	"runtime"
 	"testing"
 
 	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/di"
+	"github.com/gjaminon-go-labs/billing-api/tests/testdata"
 	"github.com/gjaminon-go-labs/billing-api/tests/testhelpers"
 	"github.com/stretchr/testify/assert"
 )
@@ -66,6 +65,7 @@ func TestClientHandler_CreateClient(t *testing.T) {
 			assert.NoError(t, err)
 
 			req := httptest.NewRequest(http.MethodPost, "/api/v1/clients", bytes.NewReader(requestBody))
+			req.Header.Set("Authorization", "Bearer "+di.TestAdminToken)
 			req.Header.Set("Content-Type", "application/json")
 
 			// Create response recorder
@@ -130,6 +130,7 @@ func TestClientHandler_CreateClient_MethodNotAllowed(t *testing.T) {
 
 	// Test PUT method (should be method not allowed)
 	req := httptest.NewRequest(http.MethodPut, "/api/v1/clients", nil)
+	req.Header.Set("Authorization", "Bearer "+di.TestAdminToken)
 	rr := httptest.NewRecorder()
 
 	server.Handler().ServeHTTP(rr, req)
@@ -160,6 +161,7 @@ func TestClientHandler_CreateClient_InvalidJSON(t *testing.T) {
 
 	// Test invalid JSON
 	req := httptest.NewRequest(http.MethodPost, "/api/v1/clients", bytes.NewReader([]byte("invalid json")))
+	req.Header.Set("Authorization", "Bearer "+di.TestAdminToken)
 	req.Header.Set("Content-Type", "application/json")
 	rr := httptest.NewRecorder()
 
@@ -179,21 +181,5 @@ func TestClientHandler_CreateClient_InvalidJSON(t *testing.T) {
 }
 
 func loadHTTPTestCases(t *testing.T) []HTTPTestCase {
-	// Get current file directory
-	_, currentFile, _, ok := runtime.Caller(0)
-	assert.True(t, ok, "Failed to get current file path")
-
-	// Build path to HTTP test data
-	testDataPath := filepath.Join(filepath.Dir(currentFile), "..", "..", "testdata", "http", "create_client_requests.json")
-
-	// Read test data file
-	data, err := os.ReadFile(testDataPath)
-	assert.NoError(t, err, "Failed to read HTTP test data file")
-
-	// Parse JSON
-	var testCases []HTTPTestCase
-	err = json.Unmarshal(data, &testCases)
-	assert.NoError(t, err, "Failed to parse HTTP test data JSON")
-
-	return testCases
+	return testdata.Load[[]HTTPTestCase](t, "http/create_client_requests.json")
 }
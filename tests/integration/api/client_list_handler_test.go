@@ -4,12 +4,12 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
-	"os"
-	"path/filepath"
-	"runtime"
 	"testing"
 
 	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/di"
+	"github.com/gjaminon-go-labs/billing-api/tests/testdata"
 	"github.com/gjaminon-go-labs/billing-api/tests/testhelpers"
 	"github.com/stretchr/testify/assert"
 )
@@ -29,14 +29,15 @@ func TestClientHandler_ListClients_IntegrationTest(t *testing.T) {
 	fixtures := loadAPITestFixtures(t)
 
 	// Create test clients via service from fixtures
-	_, err := stack.BillingService.CreateClient(fixtures[0].Name, fixtures[0].Email, fixtures[0].Phone, fixtures[0].Address)
+	_, err := stack.BillingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: fixtures[0].Name, Email: fixtures[0].Email, Phone: fixtures[0].Phone, Address: fixtures[0].Address})
 	assert.NoError(t, err)
 
-	_, err = stack.BillingService.CreateClient(fixtures[1].Name, fixtures[1].Email, fixtures[1].Phone, fixtures[1].Address)
+	_, err = stack.BillingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: fixtures[1].Name, Email: fixtures[1].Email, Phone: fixtures[1].Phone, Address: fixtures[1].Address})
 	assert.NoError(t, err)
 
 	// Create HTTP request
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/clients", nil)
+	req.Header.Set("Authorization", "Bearer "+di.TestAdminToken)
 	rr := httptest.NewRecorder()
 
 	// Act
@@ -98,6 +99,7 @@ func TestClientHandler_ListClients_EmptyList_IntegrationTest(t *testing.T) {
 
 	// Create HTTP request
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/clients", nil)
+	req.Header.Set("Authorization", "Bearer "+di.TestAdminToken)
 	rr := httptest.NewRecorder()
 
 	// Act
@@ -129,21 +131,5 @@ type ClientFixture struct {
 }
 
 func loadAPITestFixtures(t *testing.T) []ClientFixture {
-	// Get current file directory
-	_, currentFile, _, ok := runtime.Caller(0)
-	assert.True(t, ok, "Failed to get current file path")
-
-	// Build path to fixture data
-	testDataPath := filepath.Join(filepath.Dir(currentFile), "..", "..", "testdata", "client", "client_fixtures.json")
-
-	// Read fixture data file
-	data, err := os.ReadFile(testDataPath)
-	assert.NoError(t, err, "Failed to read fixture data file")
-
-	// Parse JSON
-	var fixtures []ClientFixture
-	err = json.Unmarshal(data, &fixtures)
-	assert.NoError(t, err, "Failed to parse fixture data JSON")
-
-	return fixtures
+	return testdata.Load[[]ClientFixture](t, "client/client_fixtures.json")
 }
@@ -44,6 +44,7 @@ func TestClientHandler_ListClients_Pagination_IntegrationTest(t *testing.T) {
 		}`, i, i, i%10, i)
 
 		req := httptest.NewRequest("POST", "/api/v1/clients", strings.NewReader(clientData))
+		req.Header.Set("Authorization", "Bearer "+di.TestAdminToken)
 		req.Header.Set("Content-Type", "application/json")
 		rec := httptest.NewRecorder()
 		handler.ServeHTTP(rec, req)
@@ -137,6 +138,7 @@ func TestClientHandler_ListClients_Pagination_IntegrationTest(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			// Create request
 			req := httptest.NewRequest("GET", "/api/v1/clients"+tt.queryParams, nil)
+			req.Header.Set("Authorization", "Bearer "+di.TestAdminToken)
 			rec := httptest.NewRecorder()
 
 			// Execute
@@ -202,6 +204,7 @@ func TestClientHandler_Pagination_Consistency_IntegrationTest(t *testing.T) {
 		}`, i, i)
 
 		req := httptest.NewRequest("POST", "/api/v1/clients", strings.NewReader(clientData))
+		req.Header.Set("Authorization", "Bearer "+di.TestAdminToken)
 		req.Header.Set("Content-Type", "application/json")
 		rec := httptest.NewRecorder()
 		handler.ServeHTTP(rec, req)
@@ -221,6 +224,7 @@ func TestClientHandler_Pagination_Consistency_IntegrationTest(t *testing.T) {
 
 	for page := 1; page <= 3; page++ {
 		req := httptest.NewRequest("GET", fmt.Sprintf("/api/v1/clients?page=%d&limit=%d", page, pageSize), nil)
+		req.Header.Set("Authorization", "Bearer "+di.TestAdminToken)
 		rec := httptest.NewRecorder()
 		handler.ServeHTTP(rec, req)
 
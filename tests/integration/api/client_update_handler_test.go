@@ -6,18 +6,16 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
-	"os"
-	"path/filepath"
-	"runtime"
 	"testing"
 	"time"
 
-	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/require"
-
 	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/di"
 	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/tests/testdata"
 	"github.com/gjaminon-go-labs/billing-api/tests/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // BUSINESS_TITLE: Update Client Information
@@ -56,6 +54,7 @@ func TestClientHandler_UpdateClient_Success(t *testing.T) {
 	// Test PUT request
 	url := fmt.Sprintf("/api/v1/clients/%s", fullUpdateScenario.ExpectedClient.ID)
 	req := httptest.NewRequest(http.MethodPut, url, bytes.NewBuffer(requestBody))
+	req.Header.Set("Authorization", "Bearer "+di.TestAdminToken)
 	req.Header.Set("Content-Type", "application/json")
 	req.RemoteAddr = "192.0.2.1:1234"
 
@@ -116,6 +115,7 @@ func TestClientHandler_UpdateClient_PartialUpdate(t *testing.T) {
 	// Test PUT request
 	url := fmt.Sprintf("/api/v1/clients/%s", partialUpdateScenario.ExpectedClient.ID)
 	req := httptest.NewRequest(http.MethodPut, url, bytes.NewBuffer(requestBody))
+	req.Header.Set("Authorization", "Bearer "+di.TestAdminToken)
 	req.Header.Set("Content-Type", "application/json")
 	req.RemoteAddr = "192.0.2.1:1234"
 
@@ -163,6 +163,7 @@ func TestClientHandler_UpdateClient_NotFound(t *testing.T) {
 	// Test PUT request with non-existent ID
 	url := fmt.Sprintf("/api/v1/clients/%s", nonExistentID)
 	req := httptest.NewRequest(http.MethodPut, url, bytes.NewBuffer(requestBody))
+	req.Header.Set("Authorization", "Bearer "+di.TestAdminToken)
 	req.Header.Set("Content-Type", "application/json")
 	req.RemoteAddr = "192.0.2.1:1234"
 
@@ -217,6 +218,7 @@ func TestClientHandler_UpdateClient_ValidationError(t *testing.T) {
 			// Test PUT request with invalid data
 			url := fmt.Sprintf("/api/v1/clients/%s", validClient.ID())
 			req := httptest.NewRequest(http.MethodPut, url, bytes.NewBuffer(requestBody))
+			req.Header.Set("Authorization", "Bearer "+di.TestAdminToken)
 			req.Header.Set("Content-Type", "application/json")
 			req.RemoteAddr = "192.0.2.1:1234"
 
@@ -264,6 +266,7 @@ func TestClientHandler_UpdateClient_InvalidJSON(t *testing.T) {
 	invalidJSON := `{"name": "Test", "phone": "+123456789"` // Missing closing brace
 	url := fmt.Sprintf("/api/v1/clients/%s", validClient.ID())
 	req := httptest.NewRequest(http.MethodPut, url, bytes.NewBufferString(invalidJSON))
+	req.Header.Set("Authorization", "Bearer "+di.TestAdminToken)
 	req.Header.Set("Content-Type", "application/json")
 	req.RemoteAddr = "192.0.2.1:1234"
 
@@ -282,22 +285,7 @@ func TestClientHandler_UpdateClient_InvalidJSON(t *testing.T) {
 
 // loadUpdateClientScenarios loads test scenarios from JSON file
 func loadUpdateClientScenarios(t *testing.T) []UpdateClientScenario {
-	// Get current file directory
-	_, currentFile, _, ok := runtime.Caller(0)
-	require.True(t, ok, "Failed to get current file path")
-
-	// Build path to testdata
-	testDataPath := filepath.Join(filepath.Dir(currentFile), "..", "..", "testdata", "client", "update_client_requests.json")
-
-	// Read and parse JSON
-	data, err := os.ReadFile(testDataPath)
-	require.NoError(t, err, "Failed to read update client scenarios file")
-
-	var scenarios []UpdateClientScenario
-	err = json.Unmarshal(data, &scenarios)
-	require.NoError(t, err, "Failed to parse update client scenarios JSON")
-
-	return scenarios
+	return testdata.Load[[]UpdateClientScenario](t, "client/update_client_requests.json")
 }
 
 // UpdateClientScenario represents test data for update client operations
@@ -19,15 +19,13 @@
 package billing
 
 import (
-	"encoding/json"
-	"os"
-	"path/filepath"
-	"runtime"
 	"testing"
 
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
 	"github.com/gjaminon-go-labs/billing-api/internal/application"
 	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
 	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/gjaminon-go-labs/billing-api/tests/testdata"
 	"github.com/gjaminon-go-labs/billing-api/tests/testhelpers"
 	"github.com/stretchr/testify/assert"
 )
@@ -54,7 +52,7 @@ func TestBillingService_CreateClient(t *testing.T) {
 	for _, testCase := range testCases {
 		t.Run(testCase.Description, func(t *testing.T) {
 			// Act - attempt to create client via billing service orchestration
-			client, err := service.CreateClient(testCase.Name, testCase.Email, testCase.Phone, testCase.Address)
+			client, err := service.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: testCase.Name, Email: testCase.Email, Phone: testCase.Phone, Address: testCase.Address})
 
 			if testCase.ShouldFail {
 				// Should fail with validation error from domain layer
@@ -93,14 +91,14 @@ func TestBillingService_ListClients_IntegrationTest(t *testing.T) {
 	fixtures := loadClientFixtures(t)
 
 	// Create test clients from fixtures
-	client1, err := service.CreateClient(fixtures[0].Name, fixtures[0].Email, fixtures[0].Phone, fixtures[0].Address)
+	client1, err := service.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: fixtures[0].Name, Email: fixtures[0].Email, Phone: fixtures[0].Phone, Address: fixtures[0].Address})
 	assert.NoError(t, err)
 
-	client2, err := service.CreateClient(fixtures[1].Name, fixtures[1].Email, fixtures[1].Phone, fixtures[1].Address)
+	client2, err := service.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: fixtures[1].Name, Email: fixtures[1].Email, Phone: fixtures[1].Phone, Address: fixtures[1].Address})
 	assert.NoError(t, err)
 
 	// Act
-	clients, err := service.ListClients()
+	clients, err := service.ListClients(application.AdminContext("ops"))
 
 	// Assert
 	assert.NoError(t, err)
@@ -142,41 +140,9 @@ type ClientFixture struct {
 }
 
 func loadClientFixtures(t *testing.T) []ClientFixture {
-	// Get current file directory
-	_, currentFile, _, ok := runtime.Caller(0)
-	assert.True(t, ok, "Failed to get current file path")
-
-	// Build path to fixture data at tests root
-	testDataPath := filepath.Join(filepath.Dir(currentFile), "..", "..", "testdata", "client", "client_fixtures.json")
-
-	// Read fixture data file
-	data, err := os.ReadFile(testDataPath)
-	assert.NoError(t, err, "Failed to read fixture data file")
-
-	// Parse JSON
-	var fixtures []ClientFixture
-	err = json.Unmarshal(data, &fixtures)
-	assert.NoError(t, err, "Failed to parse fixture data JSON")
-
-	return fixtures
+	return testdata.Load[[]ClientFixture](t, "client/client_fixtures.json")
 }
 
 func loadClientTestCases(t *testing.T) []ClientTestCase {
-	// Get current file directory
-	_, currentFile, _, ok := runtime.Caller(0)
-	assert.True(t, ok, "Failed to get current file path")
-
-	// Build path to shared test data at tests root
-	testDataPath := filepath.Join(filepath.Dir(currentFile), "..", "..", "testdata", "client", "client_test_cases.json")
-
-	// Read test data file
-	data, err := os.ReadFile(testDataPath)
-	assert.NoError(t, err, "Failed to read test data file")
-
-	// Parse JSON
-	var testCases []ClientTestCase
-	err = json.Unmarshal(data, &testCases)
-	assert.NoError(t, err, "Failed to parse test data JSON")
-
-	return testCases
+	return testdata.Load[[]ClientTestCase](t, "client/client_test_cases.json")
 }
@@ -28,12 +28,10 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
-	"os"
-	"path/filepath"
-	"runtime"
 	"testing"
 
 	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/tests/testdata"
 	"github.com/gjaminon-go-labs/billing-api/tests/testhelpers"
 	"github.com/stretchr/testify/assert"
 )
@@ -62,7 +60,7 @@ func TestHTTPServer_Integration_CreateClient(t *testing.T) {
 			assert.NoError(t, err)
 
 			// Make actual HTTP request to test server
-			resp, err := http.Post(testServer.URL+"/api/v1/clients", "application/json", bytes.NewReader(requestBody))
+			resp, err := postAsAdmin(testServer.URL+"/api/v1/clients", bytes.NewReader(requestBody))
 			assert.NoError(t, err)
 			defer resp.Body.Close()
 
@@ -140,7 +138,7 @@ func TestHTTPServer_Integration_PersistenceAcrossRequests(t *testing.T) {
 	}
 	requestBody, _ := json.Marshal(firstClient)
 
-	resp1, err := http.Post(testServer.URL+"/api/v1/clients", "application/json", bytes.NewReader(requestBody))
+	resp1, err := postAsAdmin(testServer.URL+"/api/v1/clients", bytes.NewReader(requestBody))
 	assert.NoError(t, err)
 	defer resp1.Body.Close()
 	assert.Equal(t, http.StatusCreated, resp1.StatusCode)
@@ -154,7 +152,7 @@ func TestHTTPServer_Integration_PersistenceAcrossRequests(t *testing.T) {
 	}
 	requestBody, _ = json.Marshal(secondClient)
 
-	resp2, err := http.Post(testServer.URL+"/api/v1/clients", "application/json", bytes.NewReader(requestBody))
+	resp2, err := postAsAdmin(testServer.URL+"/api/v1/clients", bytes.NewReader(requestBody))
 	assert.NoError(t, err)
 	defer resp2.Body.Close()
 	assert.Equal(t, http.StatusCreated, resp2.StatusCode)
@@ -180,21 +178,5 @@ type ClientFixture struct {
 }
 
 func loadHTTPIntegrationFixtures(t *testing.T) []ClientFixture {
-	// Get current file directory
-	_, currentFile, _, ok := runtime.Caller(0)
-	assert.True(t, ok, "Failed to get current file path")
-
-	// Build path to fixture data
-	testDataPath := filepath.Join(filepath.Dir(currentFile), "..", "..", "testdata", "client", "http_integration_fixtures.json")
-
-	// Read fixture data file
-	data, err := os.ReadFile(testDataPath)
-	assert.NoError(t, err, "Failed to read fixture data file")
-
-	// Parse JSON
-	var fixtures []ClientFixture
-	err = json.Unmarshal(data, &fixtures)
-	assert.NoError(t, err, "Failed to parse fixture data JSON")
-
-	return fixtures
+	return testdata.Load[[]ClientFixture](t, "client/http_integration_fixtures.json")
 }
@@ -10,6 +10,7 @@
 // - loadHTTPIntegrationTestCases() function with file path resolution
 // - Common test data structures and utilities
 // - Shared test helper functions for HTTP testing
+// - postAsAdmin() for requests on admin-only routes
 //
 // Used By:
 // - client_integration_test.go (client use case tests)
@@ -18,14 +19,13 @@
 package http
 
 import (
-	"encoding/json"
-	"os"
-	"path/filepath"
-	"runtime"
+	"io"
+	"net/http"
 	"testing"
 
 	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
-	"github.com/stretchr/testify/assert"
+	"github.com/gjaminon-go-labs/billing-api/internal/di"
+	"github.com/gjaminon-go-labs/billing-api/tests/testdata"
 )
 
 // HTTPIntegrationTestCase represents a test case for HTTP integration tests
@@ -39,21 +39,16 @@ type HTTPIntegrationTestCase struct {
 
 // loadHTTPIntegrationTestCases loads test cases from external JSON file
 func loadHTTPIntegrationTestCases(t *testing.T) []HTTPIntegrationTestCase {
-	// Get current file directory
-	_, currentFile, _, ok := runtime.Caller(0)
-	assert.True(t, ok, "Failed to get current file path")
-
-	// Build path to HTTP test data
-	testDataPath := filepath.Join(filepath.Dir(currentFile), "..", "..", "testdata", "http", "create_client_requests.json")
-
-	// Read test data file
-	data, err := os.ReadFile(testDataPath)
-	assert.NoError(t, err, "Failed to read HTTP integration test data file")
-
-	// Parse JSON
-	var testCases []HTTPIntegrationTestCase
-	err = json.Unmarshal(data, &testCases)
-	assert.NoError(t, err, "Failed to parse HTTP integration test data JSON")
+	return testdata.Load[[]HTTPIntegrationTestCase](t, "http/create_client_requests.json")
+}
 
-	return testCases
+// postAsAdmin sends a JSON POST with the bearer token of the test admin (client routes require an admin)
+func postAsAdmin(url string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodPost, url, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+di.TestAdminToken)
+	return http.DefaultClient.Do(req)
 }
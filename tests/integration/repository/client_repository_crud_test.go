@@ -1,18 +1,16 @@
 package repository_test
 
 import (
-	"encoding/json"
-	"os"
-	"path/filepath"
-	"runtime"
+	"strings"
 	"testing"
 	"time"
 
-	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/require"
-
 	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/tests/testdata"
 	"github.com/gjaminon-go-labs/billing-api/tests/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // BUSINESS_TITLE: Database Client Retrieval by ID
@@ -146,24 +144,39 @@ func TestClientRepository_Delete_NotFound(t *testing.T) {
 	assert.Error(t, err, "Delete should fail for non-existent client")
 }
 
-// loadRepositoryTestScenarios loads test scenarios from JSON file
-func loadRepositoryTestScenarios(t *testing.T) []RepositoryTestClient {
-	// Get current file directory
-	_, currentFile, _, ok := runtime.Caller(0)
-	require.True(t, ok, "Failed to get current file path")
+// BUSINESS_TITLE: Unique Client Email Addresses
+// BUSINESS_DESCRIPTION: The database holds at most one client per email address, so statements and reminders never reach the wrong client
+// USER_STORY: As a billing operator, I want each email address to identify a single client so that duplicates cannot be created by concurrent sign-ups
+// BUSINESS_VALUE: Prevents duplicate client records, keeps client communication unambiguous
+// SCENARIOS_TESTED: Lookup by email key, lookup of an unknown key, unique index rejecting a second client with the same email
+func TestClientRepository_GetByEmailKey_UniqueIndex(t *testing.T) {
+	// Setup integration test stack
+	stack, cleanup := testhelpers.WithTransaction(t)
+	defer cleanup()
+	repo := stack.ClientRepo
 
-	// Build path to testdata
-	testDataPath := filepath.Join(filepath.Dir(currentFile), "..", "..", "testdata", "client", "repository_test_fixtures.json")
+	scenarios := loadRepositoryTestScenarios(t)
+	testClient := scenarios[0]
+	client, err := entity.NewClient(testClient.Name, testClient.Email, testClient.Phone, testClient.Address)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(client))
 
-	// Read and parse JSON
-	data, err := os.ReadFile(testDataPath)
-	require.NoError(t, err, "Failed to read repository test fixtures file")
+	found, err := repo.GetByEmailKey("", strings.ToUpper(testClient.Email))
+	require.NoError(t, err)
+	assert.Equal(t, client.ID(), found.ID())
 
-	var scenarios []RepositoryTestClient
-	err = json.Unmarshal(data, &scenarios)
-	require.NoError(t, err, "Failed to parse repository test fixtures JSON")
+	_, err = repo.GetByEmailKey("", "nobody@example.com")
+	assert.ErrorIs(t, err, domainErrors.ErrClientNotFound)
 
-	return scenarios
+	// The service checks first; the index catches clients created concurrently
+	duplicate, err := entity.NewClient("Other Name", testClient.Email, "", "")
+	require.NoError(t, err)
+	assert.ErrorIs(t, repo.Save(duplicate), domainErrors.ErrClientEmailExists)
+}
+
+// loadRepositoryTestScenarios loads test scenarios from JSON file
+func loadRepositoryTestScenarios(t *testing.T) []RepositoryTestClient {
+	return testdata.Load[[]RepositoryTestClient](t, "client/repository_test_fixtures.json")
 }
 
 // RepositoryTestClient represents a client in repository test data
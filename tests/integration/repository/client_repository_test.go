@@ -1,13 +1,10 @@
 package repository
 
 import (
-	"encoding/json"
-	"os"
-	"path/filepath"
-	"runtime"
 	"testing"
 
 	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/tests/testdata"
 	"github.com/gjaminon-go-labs/billing-api/tests/testhelpers"
 	"github.com/stretchr/testify/assert"
 )
@@ -85,21 +82,5 @@ type ClientFixture struct {
 }
 
 func loadIntegrationRepositoryFixtures(t *testing.T) []ClientFixture {
-	// Get current file directory
-	_, currentFile, _, ok := runtime.Caller(0)
-	assert.True(t, ok, "Failed to get current file path")
-
-	// Build path to fixture data
-	testDataPath := filepath.Join(filepath.Dir(currentFile), "..", "..", "testdata", "client", "client_fixtures.json")
-
-	// Read fixture data file
-	data, err := os.ReadFile(testDataPath)
-	assert.NoError(t, err, "Failed to read fixture data file")
-
-	// Parse JSON
-	var fixtures []ClientFixture
-	err = json.Unmarshal(data, &fixtures)
-	assert.NoError(t, err, "Failed to parse fixture data JSON")
-
-	return fixtures
+	return testdata.Load[[]ClientFixture](t, "client/client_fixtures.json")
 }
@@ -0,0 +1,59 @@
+// Schema Isolation Test - Runs against a disposable per-package schema instead of a rolled-back transaction
+package schema_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
+	"github.com/gjaminon-go-labs/billing-api/tests/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(testhelpers.RunWithIsolatedSchema(m))
+}
+
+// TestSchemaIsolation_CommittedDataVisibleAcrossConnections verifies writes are committed, unlike transaction isolation
+func TestSchemaIsolation_CommittedDataVisibleAcrossConnections(t *testing.T) {
+	writer := testhelpers.NewIsolatedSchemaStack(t)
+	reader := testhelpers.NewIsolatedSchemaStack(t)
+
+	client, err := entity.NewClient("Schema Client", "schema@example.com", "+15556660000", "1 Schema Way")
+	require.NoError(t, err)
+	require.NoError(t, writer.ClientRepo.Save(client))
+
+	// A separate connection sees the committed row
+	retrieved, err := reader.ClientRepo.GetByID(client.ID())
+	require.NoError(t, err)
+	assert.Equal(t, client.Name(), retrieved.Name())
+}
+
+// TestSchemaIsolation_DDL verifies tests can change the schema without affecting the shared billing schema
+func TestSchemaIsolation_DDL(t *testing.T) {
+	stack := testhelpers.NewIsolatedSchemaStack(t)
+
+	require.NoError(t, stack.DB.Exec(`CREATE TABLE scratch_records (
+		key VARCHAR(255) PRIMARY KEY,
+		value TEXT NOT NULL
+	)`).Error)
+	t.Cleanup(func() {
+		stack.DB.Exec("DROP TABLE IF EXISTS scratch_records")
+	})
+
+	scratch, err := storage.ForCollection(stack.Storage, "scratch_records")
+	require.NoError(t, err)
+
+	client, err := entity.NewClient("DDL Client", "ddl@example.com", "+15556660001", "2 Schema Way")
+	require.NoError(t, err)
+
+	scratchRepo := repository.NewClientRepository(scratch)
+	require.NoError(t, scratchRepo.Save(client))
+
+	clients, err := scratchRepo.GetAll()
+	require.NoError(t, err)
+	assert.Len(t, clients, 1)
+}
@@ -1,8 +1,12 @@
 package storage
 
 import (
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
 	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
 	"github.com/gjaminon-go-labs/billing-api/tests/testhelpers"
 	"github.com/stretchr/testify/assert"
@@ -93,3 +97,306 @@ func TestPostgreSQLStorage_ListAll_SingleItem(t *testing.T) {
 	assert.Equal(t, expectedValue["name"], resultValue["name"])
 	assert.Equal(t, expectedValue["email"], resultValue["email"])
 }
+
+func TestPostgreSQLStorage_ListAll_CreationOrder(t *testing.T) {
+	// Arrange: records created in the same transaction share created_at and come back in key order
+	stack, cleanup := testhelpers.WithTransaction(t)
+	defer cleanup()
+	postgresStorage, ok := stack.Storage.(*storage.PostgreSQLStorage)
+	assert.True(t, ok, "Expected PostgreSQL storage in integration test")
+
+	for _, key := range []string{"client3", "client1", "client2"} {
+		assert.NoError(t, postgresStorage.Store(key, map[string]string{"key": key}))
+	}
+	assert.NoError(t, postgresStorage.Store("client1", map[string]string{"key": "client1", "updated": "true"}))
+
+	// Act
+	result, err := postgresStorage.ListAll()
+
+	// Assert
+	assert.NoError(t, err)
+	keys := make([]interface{}, len(result))
+	for i, value := range result {
+		keys[i] = value.(map[string]interface{})["key"]
+	}
+	assert.Equal(t, []interface{}{"client1", "client2", "client3"}, keys)
+}
+
+func TestPostgreSQLStorage_CountMatching(t *testing.T) {
+	// Arrange
+	stack, cleanup := testhelpers.WithTransaction(t)
+	defer cleanup()
+	postgresStorage, ok := stack.Storage.(*storage.PostgreSQLStorage)
+	assert.True(t, ok, "Expected PostgreSQL storage in integration test")
+
+	// Clients are stored as the repository serializes them, with the email address nested in an object
+	for i, client := range []struct{ name, email string }{
+		{name: "John Doe", email: "john@acme.com"},
+		{name: "Jane 100% Smith", email: "jane@globex.com"},
+		{name: "Bob Wilson", email: "bob@ACME.com"},
+	} {
+		serialized, err := entity.NewClient(client.name, client.email, fmt.Sprintf("+1555666000%d", i), "1 Main Street")
+		assert.NoError(t, err)
+		assert.NoError(t, postgresStorage.Store(serialized.ID(), serialized))
+	}
+
+	fields := []string{"name", "email.value"}
+	testCases := []struct {
+		search   string
+		expected int64
+	}{
+		{search: "", expected: 3},
+		{search: "acme", expected: 2},
+		{search: "doe", expected: 1},
+		{search: "100%", expected: 1},
+		{search: "_", expected: 0},
+		{search: "initech", expected: 0},
+		{search: "value", expected: 0},
+		{search: `"`, expected: 0},
+	}
+
+	for _, testCase := range testCases {
+		// Act
+		count, err := postgresStorage.CountMatching(fields, testCase.search)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, testCase.expected, count, "search %q", testCase.search)
+	}
+
+	estimate, err := postgresStorage.EstimatedCount()
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, estimate, int64(0))
+}
+
+func TestPostgreSQLStorage_ListQuery(t *testing.T) {
+	// Arrange
+	stack, cleanup := testhelpers.WithTransaction(t)
+	defer cleanup()
+	postgresStorage, ok := stack.Storage.(*storage.PostgreSQLStorage)
+	assert.True(t, ok, "Expected PostgreSQL storage in integration test")
+
+	records := map[string]map[string]interface{}{
+		"client1": {"name": "Acme Corp", "status": "active", "tags": []string{"emea"}, "createdAt": "2026-01-10T09:00:00Z"},
+		"client2": {"name": "Acme 100% Labs", "status": "active", "tags": []string{"apac"}, "createdAt": "2026-03-05T09:00:00.5Z"},
+		"client3": {"name": "Globex", "status": "archived", "tags": []string{"emea"}, "createdAt": "2026-06-01T09:00:00Z"},
+	}
+	for _, key := range []string{"client1", "client2", "client3"} {
+		assert.NoError(t, postgresStorage.Store(key, records[key]))
+	}
+
+	names := func(values []interface{}) []interface{} {
+		result := make([]interface{}, len(values))
+		for i, value := range values {
+			result[i] = value.(map[string]interface{})["name"]
+		}
+		return result
+	}
+	testCases := []struct {
+		name     string
+		query    storage.Query
+		expected []interface{}
+		count    int64
+	}{
+		{name: "everything", query: storage.Query{}, expected: []interface{}{"Acme Corp", "Acme 100% Labs", "Globex"}, count: 3},
+		{name: "search", query: storage.Query{SearchFields: []string{"name"}, Search: "acme"}, expected: []interface{}{"Acme Corp", "Acme 100% Labs"}, count: 2},
+		{name: "literal wildcards", query: storage.Query{SearchFields: []string{"name"}, Search: "100%"}, expected: []interface{}{"Acme 100% Labs"}, count: 1},
+		{name: "matching", query: storage.Query{Matching: map[string]string{"status": "active"}}, expected: []interface{}{"Acme Corp", "Acme 100% Labs"}, count: 2},
+		{name: "containing", query: storage.Query{Containing: map[string]string{"tags": "emea"}, Matching: map[string]string{"status": "active"}}, expected: []interface{}{"Acme Corp"}, count: 1},
+		{
+			name: "time window",
+			query: storage.Query{
+				TimeField: "createdAt",
+				After:     time.Date(2026, time.January, 10, 9, 0, 0, 0, time.UTC),
+				Before:    time.Date(2026, time.June, 1, 9, 0, 0, 0, time.UTC),
+			},
+			expected: []interface{}{"Acme 100% Labs"},
+			count:    1,
+		},
+		{name: "page", query: storage.Query{Offset: 1, Limit: 1}, expected: []interface{}{"Acme 100% Labs"}, count: 3},
+		{name: "order by text", query: storage.Query{OrderBy: []storage.OrderKey{{Path: "name"}}}, expected: []interface{}{"Acme 100% Labs", "Acme Corp", "Globex"}, count: 3},
+		{name: "order by timestamp descending", query: storage.Query{OrderBy: []storage.OrderKey{{Path: "createdAt", Timestamp: true, Descending: true}}}, expected: []interface{}{"Globex", "Acme 100% Labs", "Acme Corp"}, count: 3},
+		{name: "ordered page", query: storage.Query{OrderBy: []storage.OrderKey{{Path: "status", Descending: true}, {Path: "name"}}, Offset: 1, Limit: 2}, expected: []interface{}{"Acme 100% Labs", "Acme Corp"}, count: 3},
+	}
+
+	for _, testCase := range testCases {
+		// Act
+		values, count, err := postgresStorage.ListQuery(testCase.query)
+
+		// Assert
+		assert.NoError(t, err, testCase.name)
+		assert.Equal(t, testCase.expected, names(values), testCase.name)
+		assert.Equal(t, testCase.count, count, testCase.name)
+	}
+}
+
+func TestPostgreSQLStorage_ListAfter(t *testing.T) {
+	// Arrange
+	stack, cleanup := testhelpers.WithTransaction(t)
+	defer cleanup()
+	postgresStorage, ok := stack.Storage.(*storage.PostgreSQLStorage)
+	assert.True(t, ok, "Expected PostgreSQL storage in integration test")
+
+	// Stored in one transaction, the records share their creation time and are positioned by key
+	for _, key := range []string{"client3", "client1", "client2", "client4"} {
+		assert.NoError(t, postgresStorage.Store(key, map[string]interface{}{"name": key, "status": "active"}))
+	}
+	assert.NoError(t, postgresStorage.Store("client5", map[string]interface{}{"name": "client5", "status": "archived"}))
+	active := storage.Query{Matching: map[string]string{"status": "active"}, Limit: 3}
+
+	// Act
+	first, firstPositions, err := postgresStorage.ListAfter(active, storage.Position{})
+	assert.NoError(t, err)
+	second, secondPositions, secondErr := postgresStorage.ListAfter(active, firstPositions[len(firstPositions)-1])
+
+	// Assert
+	names := func(values []interface{}) []interface{} {
+		result := make([]interface{}, len(values))
+		for i, value := range values {
+			result[i] = value.(map[string]interface{})["name"]
+		}
+		return result
+	}
+	assert.Equal(t, []interface{}{"client1", "client2", "client3"}, names(first))
+	assert.Equal(t, "client3", firstPositions[2].Key)
+	assert.False(t, firstPositions[2].CreatedAt.IsZero())
+	assert.NoError(t, secondErr)
+	assert.Equal(t, []interface{}{"client4"}, names(second))
+	assert.Len(t, secondPositions, 1)
+}
+
+func TestPostgreSQLStorage_ListKeyRange(t *testing.T) {
+	// Arrange
+	stack, cleanup := testhelpers.WithTransaction(t)
+	defer cleanup()
+	postgresStorage, ok := stack.Storage.(*storage.PostgreSQLStorage)
+	assert.True(t, ok, "Expected PostgreSQL storage in integration test")
+
+	for _, sequence := range []int{3, 1, 12, 2} {
+		key := fmt.Sprintf("%020d", sequence)
+		assert.NoError(t, postgresStorage.Store(key, map[string]interface{}{"sequence": sequence, "kind": "update"}))
+	}
+	assert.NoError(t, postgresStorage.Store(fmt.Sprintf("%020d", 20), map[string]interface{}{"sequence": 20, "kind": "delete"}))
+	updates := storage.Query{Matching: map[string]string{"kind": "update"}, Limit: 2}
+
+	// Act
+	first, err := postgresStorage.ListKeyRange(updates, "")
+	assert.NoError(t, err)
+	second, secondErr := postgresStorage.ListKeyRange(updates, fmt.Sprintf("%020d", 2))
+
+	// Assert
+	sequences := func(values []interface{}) []interface{} {
+		result := make([]interface{}, len(values))
+		for i, value := range values {
+			result[i] = value.(map[string]interface{})["sequence"]
+		}
+		return result
+	}
+	assert.Equal(t, []interface{}{float64(1), float64(2)}, sequences(first))
+	assert.NoError(t, secondErr)
+	assert.Equal(t, []interface{}{float64(3), float64(12)}, sequences(second))
+}
+
+func TestPostgreSQLStorage_ListKeys(t *testing.T) {
+	// Arrange
+	stack, cleanup := testhelpers.WithTransaction(t)
+	defer cleanup()
+	postgresStorage, ok := stack.Storage.(*storage.PostgreSQLStorage)
+	assert.True(t, ok, "Expected PostgreSQL storage in integration test")
+
+	for _, key := range []string{"client1", "client2", "client3"} {
+		assert.NoError(t, postgresStorage.Store(key, map[string]interface{}{"name": key}))
+	}
+
+	// Act
+	values, err := postgresStorage.ListKeys([]string{"client3", "missing", "client1"})
+	empty, emptyErr := postgresStorage.ListKeys(nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, values, 2)
+	assert.Equal(t, "client1", values[0].(map[string]interface{})["name"])
+	assert.Equal(t, "client3", values[1].(map[string]interface{})["name"])
+	assert.NoError(t, emptyErr)
+	assert.Empty(t, empty)
+}
+
+func TestPostgreSQLStorage_MonthlyPartitions(t *testing.T) {
+	// Arrange
+	stack, cleanup := testhelpers.WithTransaction(t)
+	defer cleanup()
+	usageStorage, err := storage.ForCollection(stack.Storage, "usage_records")
+	assert.NoError(t, err)
+	partitioner, ok := usageStorage.(storage.MonthlyPartitioner)
+	assert.True(t, ok, "Expected partitioned usage records in integration test")
+
+	from := time.Date(2031, time.January, 15, 0, 0, 0, 0, time.UTC)
+	through := from.AddDate(0, 2, 0)
+
+	// Act
+	created, err := partitioner.EnsureMonthlyPartitions(from, through)
+	assert.NoError(t, err)
+	again, err := partitioner.EnsureMonthlyPartitions(from, through)
+	assert.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, []string{"usage_records_p2031_01", "usage_records_p2031_02", "usage_records_p2031_03"}, created)
+	assert.Empty(t, again)
+
+	assert.NoError(t, usageStorage.Store("usage1", map[string]string{"metric": "api_calls"}))
+	recent, err := storage.ListCreatedSince(usageStorage, time.Now().Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.Len(t, recent, 1)
+	future, err := storage.ListCreatedSince(usageStorage, time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Empty(t, future)
+}
+
+func TestPostgreSQLStorage_GaplessCounter(t *testing.T) {
+	// Arrange
+	stack, cleanup := testhelpers.WithTransaction(t)
+	defer cleanup()
+	invoiceStorage, err := storage.ForCollection(stack.Storage, "invoice_records")
+	assert.NoError(t, err)
+	errRolledBack := errors.New("unit of work failed")
+
+	// Act
+	first, err := storage.NextCounterValue(invoiceStorage, "2031")
+	assert.NoError(t, err)
+	err = storage.RunInTransaction(invoiceStorage, func(tx storage.Storage) error {
+		_, err := storage.NextCounterValue(tx, "2031")
+		assert.NoError(t, err)
+		return errRolledBack
+	})
+	assert.ErrorIs(t, err, errRolledBack)
+	second, err := storage.NextCounterValue(invoiceStorage, "2031")
+	assert.NoError(t, err)
+	otherYear, err := storage.NextCounterValue(invoiceStorage, "2032")
+	assert.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, first+1, second, "A rolled back allocation should be allocated again")
+	assert.Equal(t, int64(1), otherYear)
+}
+
+func TestPostgreSQLStorage_UpdateIfUnset(t *testing.T) {
+	// Arrange
+	stack, cleanup := testhelpers.WithTransaction(t)
+	defer cleanup()
+	links, err := storage.ForCollection(stack.Storage, "magic_link_records")
+	assert.NoError(t, err)
+	assert.NoError(t, links.Store("link-1", map[string]interface{}{"id": "link-1", "consumedAt": nil}))
+
+	// Act
+	first := storage.UpdateIfUnset(links, "link-1", "consumedAt", map[string]interface{}{"id": "link-1", "consumedAt": "first"})
+	second := storage.UpdateIfUnset(links, "link-1", "consumedAt", map[string]interface{}{"id": "link-1", "consumedAt": "second"})
+	missing := storage.UpdateIfUnset(links, "link-2", "consumedAt", map[string]interface{}{"id": "link-2"})
+
+	// Assert
+	assert.NoError(t, first)
+	assert.ErrorIs(t, second, storage.ErrFieldSet)
+	assert.ErrorIs(t, missing, storage.ErrKeyNotFound)
+	value, err := links.Get("link-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "first", value.(map[string]interface{})["consumedAt"])
+}
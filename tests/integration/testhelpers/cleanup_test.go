@@ -8,6 +8,8 @@ package testhelpers
 import (
 	"testing"
 
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
 	"github.com/gjaminon-go-labs/billing-api/tests/testhelpers"
 	"github.com/stretchr/testify/assert"
 )
@@ -25,7 +27,7 @@ func TestDataCleanupIsolation(t *testing.T) {
 		}
 
 		// Create some test data through the application
-		result, err := stack.BillingService.CreateClient("Test Client 1", "test1@cleanup.test", "+1234567890", "123 Test St")
+		result, err := stack.BillingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Test Client 1", Email: "test1@cleanup.test", Phone: "+1234567890", Address: "123 Test St"})
 		assert.NoError(t, err)
 		assert.NotEmpty(t, result.ID)
 
@@ -50,7 +52,7 @@ func TestDataCleanupIsolation(t *testing.T) {
 		}
 
 		// Create different test data
-		result, err := stack.BillingService.CreateClient("Test Client 2", "test2@cleanup.test", "+0987654321", "456 Test Ave")
+		result, err := stack.BillingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Test Client 2", Email: "test2@cleanup.test", Phone: "+0987654321", Address: "456 Test Ave"})
 		assert.NoError(t, err)
 		assert.NotEmpty(t, result.ID)
 	})
@@ -65,7 +67,7 @@ func TestManualCleanup(t *testing.T) {
 	}
 
 	// Create some test data
-	_, err := stack.BillingService.CreateClient("Manual Cleanup Test", "manual@cleanup.test", "+1111111111", "789 Test Blvd")
+	_, err := stack.BillingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Manual Cleanup Test", Email: "manual@cleanup.test", Phone: "+1111111111", Address: "789 Test Blvd"})
 	assert.NoError(t, err)
 
 	// Manually trigger cleanup
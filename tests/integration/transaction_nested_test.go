@@ -0,0 +1,92 @@
+// Transaction Nested Test - Validates savepoint-based nested transactions under rollback isolation
+package integration_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/storage"
+	"github.com/gjaminon-go-labs/billing-api/tests/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTransactionNested_InnerRollback verifies a failed nested unit of work rolls back to its savepoint
+func TestTransactionNested_InnerRollback(t *testing.T) {
+	stack, cleanup := testhelpers.WithTransaction(t)
+	defer cleanup()
+
+	outer, err := entity.NewClient("Outer Client", "outer@example.com", "+15557770000", "1 Outer Rd")
+	require.NoError(t, err)
+	inner, err := entity.NewClient("Inner Client", "inner@example.com", "+15557770001", "2 Inner Rd")
+	require.NoError(t, err)
+
+	errInner := errors.New("inner unit of work failed")
+
+	err = stack.RunNested(func(tx storage.Storage) error {
+		if err := repository.NewClientRepository(tx).Save(outer); err != nil {
+			return err
+		}
+
+		// The nested call fails after writing; only its own changes are undone
+		nestedErr := storage.RunInTransaction(tx, func(nested storage.Storage) error {
+			if err := repository.NewClientRepository(nested).Save(inner); err != nil {
+				return err
+			}
+			return errInner
+		})
+		assert.ErrorIs(t, nestedErr, errInner)
+		return nil
+	})
+	require.NoError(t, err)
+
+	_, err = stack.ClientRepo.GetByID(outer.ID())
+	assert.NoError(t, err, "Outer client should survive the inner rollback")
+
+	_, err = stack.ClientRepo.GetByID(inner.ID())
+	assert.Error(t, err, "Inner client should be rolled back to the savepoint")
+}
+
+// TestTransactionNested_OuterTransactionStaysUsable verifies the test transaction keeps working after a nested failure
+func TestTransactionNested_OuterTransactionStaysUsable(t *testing.T) {
+	stack, cleanup := testhelpers.WithTransaction(t)
+	defer cleanup()
+
+	err := stack.RunNested(func(tx storage.Storage) error {
+		return errors.New("service failed")
+	})
+	require.Error(t, err)
+
+	client, err := entity.NewClient("After Failure", "after@example.com", "+15557770002", "3 After St")
+	require.NoError(t, err)
+	require.NoError(t, stack.ClientRepo.Save(client))
+
+	_, err = stack.ClientRepo.GetByID(client.ID())
+	assert.NoError(t, err)
+}
+
+// TestTransactionNested_WithSavepoint verifies subtests share setup but not each other's writes
+func TestTransactionNested_WithSavepoint(t *testing.T) {
+	stack, cleanup := testhelpers.WithTransaction(t)
+	defer cleanup()
+
+	shared, err := entity.NewClient("Shared Client", "shared@example.com", "+15557770003", "4 Shared Ave")
+	require.NoError(t, err)
+	require.NoError(t, stack.ClientRepo.Save(shared))
+
+	var scratchID string
+	testhelpers.WithSavepoint(t, stack, "scratch", func(t *testing.T) {
+		scratch, err := entity.NewClient("Scratch Client", "scratch@example.com", "+15557770004", "5 Scratch Ln")
+		require.NoError(t, err)
+		require.NoError(t, stack.ClientRepo.Save(scratch))
+		scratchID = scratch.ID()
+	})
+
+	_, err = stack.ClientRepo.GetByID(shared.ID())
+	assert.NoError(t, err, "Setup data should remain after rolling back to the savepoint")
+
+	_, err = stack.ClientRepo.GetByID(scratchID)
+	assert.Error(t, err, "Savepoint writes should be rolled back")
+}
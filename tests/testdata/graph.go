@@ -0,0 +1,157 @@
+// Fixture Graph Loading
+//
+// This file loads fixture files describing related entities and inserts them in dependency order.
+// Provides: Reference resolution between fixtures, topological insert ordering, cycle detection
+// Pattern: Kind-specific inserters registered on a GraphLoader
+// Used by: Tests that need connected data (e.g. a client and its invoices) in one step
+package testdata
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+// Fixture is one entity of a fixture graph
+type Fixture struct {
+	// Kind selects the inserter (e.g. "client", "invoice")
+	Kind string `json:"kind"`
+
+	// Ref names the fixture so others can depend on it
+	Ref string `json:"ref"`
+
+	// DependsOn lists refs that must be inserted first
+	DependsOn []string `json:"dependsOn,omitempty"`
+
+	// Data is the kind-specific payload
+	Data json.RawMessage `json:"data"`
+}
+
+// Graph is a fixture file of related entities
+type Graph struct {
+	Fixtures []Fixture `json:"fixtures"`
+}
+
+// Refs maps fixture refs to the IDs of the entities created for them
+type Refs map[string]string
+
+// ID returns the entity ID created for a ref
+func (r Refs) ID(ref string) (string, error) {
+	id, ok := r[ref]
+	if !ok {
+		return "", fmt.Errorf("fixture ref %q has not been inserted", ref)
+	}
+	return id, nil
+}
+
+// Inserter persists one fixture and returns the ID of the created entity
+// Refs already contains the IDs of every fixture it depends on
+type Inserter func(fixture Fixture, refs Refs) (string, error)
+
+// GraphLoader inserts fixture graphs using inserters registered per kind
+type GraphLoader struct {
+	inserters map[string]Inserter
+}
+
+// NewGraphLoader creates a loader without inserters
+func NewGraphLoader() *GraphLoader {
+	return &GraphLoader{
+		inserters: make(map[string]Inserter),
+	}
+}
+
+// Register sets the inserter for a fixture kind
+func (l *GraphLoader) Register(kind string, inserter Inserter) *GraphLoader {
+	l.inserters[kind] = inserter
+	return l
+}
+
+// Order returns the fixtures sorted so every fixture follows its dependencies
+// Independent fixtures keep their file order
+func (l *GraphLoader) Order(graph Graph) ([]Fixture, error) {
+	byRef := make(map[string]Fixture, len(graph.Fixtures))
+	for _, fixture := range graph.Fixtures {
+		if fixture.Ref == "" {
+			return nil, fmt.Errorf("%s fixture is missing a ref", fixture.Kind)
+		}
+		if _, duplicate := byRef[fixture.Ref]; duplicate {
+			return nil, fmt.Errorf("duplicate fixture ref %q", fixture.Ref)
+		}
+		byRef[fixture.Ref] = fixture
+	}
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int, len(graph.Fixtures))
+	ordered := make([]Fixture, 0, len(graph.Fixtures))
+
+	var visit func(ref string, path []string) error
+	visit = func(ref string, path []string) error {
+		switch state[ref] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("fixture dependency cycle: %v", append(path, ref))
+		}
+
+		fixture, ok := byRef[ref]
+		if !ok {
+			return fmt.Errorf("fixture %q depends on unknown ref %q", path[len(path)-1], ref)
+		}
+
+		state[ref] = visiting
+		for _, dependency := range fixture.DependsOn {
+			if err := visit(dependency, append(path, ref)); err != nil {
+				return err
+			}
+		}
+		state[ref] = visited
+		ordered = append(ordered, fixture)
+		return nil
+	}
+
+	for _, fixture := range graph.Fixtures {
+		if err := visit(fixture.Ref, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return ordered, nil
+}
+
+// Insert persists every fixture of the graph in dependency order
+func (l *GraphLoader) Insert(graph Graph) (Refs, error) {
+	ordered, err := l.Order(graph)
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make(Refs, len(ordered))
+	for _, fixture := range ordered {
+		inserter, ok := l.inserters[fixture.Kind]
+		if !ok {
+			return refs, fmt.Errorf("no inserter registered for fixture kind %q", fixture.Kind)
+		}
+
+		id, err := inserter(fixture, refs)
+		if err != nil {
+			return refs, fmt.Errorf("failed to insert %s fixture %q: %w", fixture.Kind, fixture.Ref, err)
+		}
+		refs[fixture.Ref] = id
+	}
+
+	return refs, nil
+}
+
+// Load reads a fixture graph file and inserts it, failing the test on error
+func (l *GraphLoader) Load(t testing.TB, name string) Refs {
+	t.Helper()
+
+	refs, err := l.Insert(Load[Graph](t, name))
+	if err != nil {
+		t.Fatalf("Failed to load fixture graph %s: %v", name, err)
+	}
+	return refs
+}
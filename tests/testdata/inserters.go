@@ -0,0 +1,36 @@
+package testdata
+
+import (
+	"encoding/json"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
+)
+
+// ClientData is the payload of a "client" fixture
+type ClientData struct {
+	Name    string `json:"name"`
+	Email   string `json:"email"`
+	Phone   string `json:"phone"`
+	Address string `json:"address"`
+}
+
+// ClientInserter creates "client" fixtures through the domain constructor and saves them
+func ClientInserter(repo repository.ClientRepository) Inserter {
+	return func(fixture Fixture, _ Refs) (string, error) {
+		var data ClientData
+		if err := json.Unmarshal(fixture.Data, &data); err != nil {
+			return "", err
+		}
+
+		client, err := entity.NewClient(data.Name, data.Email, data.Phone, data.Address)
+		if err != nil {
+			return "", err
+		}
+
+		if err := repo.Save(client); err != nil {
+			return "", err
+		}
+		return client.ID(), nil
+	}
+}
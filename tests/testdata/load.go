@@ -0,0 +1,48 @@
+// Test Fixture Loading
+//
+// This file provides typed loading of the JSON fixtures stored alongside it.
+// Provides: Path resolution independent of the calling test's directory, generic JSON decoding
+// Pattern: testdata.Load[T](t, "client/client_fixtures.json")
+// Used by: Unit and integration tests that keep their scenarios in tests/testdata
+package testdata
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// Path returns the absolute path of a fixture file relative to the testdata directory
+func Path(t testing.TB, name string) string {
+	t.Helper()
+
+	_, currentFile, _, ok := runtime.Caller(0)
+	if !ok {
+		t.Fatalf("Failed to locate testdata directory")
+	}
+	return filepath.Join(filepath.Dir(currentFile), filepath.FromSlash(name))
+}
+
+// Read returns the raw contents of a fixture file
+func Read(t testing.TB, name string) []byte {
+	t.Helper()
+
+	data, err := os.ReadFile(Path(t, name))
+	if err != nil {
+		t.Fatalf("Failed to read fixture %s: %v", name, err)
+	}
+	return data
+}
+
+// Load decodes a JSON fixture file into T
+func Load[T any](t testing.TB, name string) T {
+	t.Helper()
+
+	var value T
+	if err := json.Unmarshal(Read(t, name), &value); err != nil {
+		t.Fatalf("Failed to parse fixture %s: %v", name, err)
+	}
+	return value
+}
@@ -15,17 +15,24 @@ import (
 
 // DatabaseCleaner provides methods for cleaning up test data
 type DatabaseCleaner struct {
-	db *gorm.DB
+	db     *gorm.DB
+	schema string
 }
 
-// NewDatabaseCleaner creates a new database cleaner instance
+// NewDatabaseCleaner creates a new database cleaner instance for the billing schema
 func NewDatabaseCleaner(db *gorm.DB) *DatabaseCleaner {
+	return NewDatabaseCleanerForSchema(db, "billing")
+}
+
+// NewDatabaseCleanerForSchema creates a database cleaner for another schema (e.g. an isolated test schema)
+func NewDatabaseCleanerForSchema(db *gorm.DB, schema string) *DatabaseCleaner {
 	return &DatabaseCleaner{
-		db: db,
+		db:     db,
+		schema: schema,
 	}
 }
 
-// CleanupTestData removes all data from billing schema tables
+// CleanupTestData removes all data from the schema tables
 // This method deletes data in the correct order to handle foreign key constraints
 func (c *DatabaseCleaner) CleanupTestData() error {
 	log.Println("🧹 Cleaning up test data...")
@@ -33,8 +40,49 @@ func (c *DatabaseCleaner) CleanupTestData() error {
 	// List of tables in dependency order (child tables first)
 	// This ensures foreign key constraints are respected during cleanup
 	tablesToClean := []string{
-		"storage_records", // No foreign keys, safe to clean first
-		"clients",         // No foreign keys, safe to clean
+		"storage_records",                    // No foreign keys, safe to clean first
+		"ip_access_policy_records",           // No foreign keys, safe to clean
+		"audit_log_records",                  // No foreign keys, safe to clean
+		"form_token_records",                 // No foreign keys, safe to clean
+		"magic_link_records",                 // No foreign keys, safe to clean
+		"client_change_records",              // No foreign keys, safe to clean
+		"usage_records",                      // No foreign keys, safe to clean
+		"contract_records",                   // No foreign keys, safe to clean
+		"approval_request_records",           // No foreign keys, safe to clean
+		"recurring_invoice_template_records", // No foreign keys, safe to clean
+		"invoice_delivery_event_records",     // No foreign keys, safe to clean
+		"dunning_policy_records",             // No foreign keys, safe to clean
+		"bank_transaction_records",           // No foreign keys, safe to clean
+		"payout_reconciliation_records",      // No foreign keys, safe to clean
+		"legal_entity_records",               // No foreign keys, safe to clean
+		"document_template_records",          // No foreign keys, safe to clean
+		"client_credit_limit_records",        // No foreign keys, safe to clean
+		"risk_assessment_records",            // No foreign keys, safe to clean
+		"webhook_event_records",              // No foreign keys, safe to clean
+		"failed_message_records",             // No foreign keys, safe to clean
+		"processed_message_records",          // No foreign keys, safe to clean
+		"saga_records",                       // No foreign keys, safe to clean
+		"fiscal_calendar_records",            // No foreign keys, safe to clean
+		"client_statement_job_records",       // No foreign keys, safe to clean
+		"external_reference_records",         // No foreign keys, safe to clean
+		"event_store_records",                // No foreign keys, safe to clean
+		"invoice_records",                    // No foreign keys, safe to clean
+		"payment_records",                    // No foreign keys, safe to clean
+		"client_summary_records",             // No foreign keys, safe to clean
+		"invoice_archive_records",            // No foreign keys, safe to clean
+		"subscription_records",               // No foreign keys, safe to clean
+		"quote_records",                      // No foreign keys, safe to clean
+		"email_outbox_records",               // No foreign keys, safe to clean
+		"email_suppression_records",          // No foreign keys, safe to clean
+		"invoice_records_counters",           // No foreign keys, safe to clean
+		"client_contact_records",             // No foreign keys, safe to clean
+		"client_note_records",                // No foreign keys, safe to clean
+		"client_tombstone_records",           // No foreign keys, safe to clean
+		"tenant_rule_set_records",            // No foreign keys, safe to clean
+		"webhook_subscription_records",       // No foreign keys, safe to clean
+		"upload_records",                     // No foreign keys, safe to clean
+		"upload_chunk_records",               // No foreign keys, safe to clean
+		"clients",                            // No foreign keys, safe to clean
 	}
 
 	// Delete data from each table (safer than TRUNCATE for permissions)
@@ -51,14 +99,14 @@ func (c *DatabaseCleaner) CleanupTestData() error {
 // deleteFromTable deletes all data from a specific table
 // This is safer than TRUNCATE as it doesn't require special permissions
 func (c *DatabaseCleaner) deleteFromTable(tableName string) error {
-	query := fmt.Sprintf("DELETE FROM billing.%s", tableName)
+	query := fmt.Sprintf("DELETE FROM %s.%s", c.schema, tableName)
 
 	result := c.db.Exec(query)
 	if result.Error != nil {
 		return fmt.Errorf("failed to delete from table %s: %w", tableName, result.Error)
 	}
 
-	log.Printf("🗑️  Cleaned table: billing.%s (%d records deleted)", tableName, result.RowsAffected)
+	log.Printf("🗑️  Cleaned table: %s.%s (%d records deleted)", c.schema, tableName, result.RowsAffected)
 	return nil
 }
 
@@ -67,31 +115,31 @@ func (c *DatabaseCleaner) deleteFromTable(tableName string) error {
 func (c *DatabaseCleaner) truncateTable(tableName string) error {
 	// Use TRUNCATE with RESTART IDENTITY to reset any auto-incrementing sequences
 	// CASCADE option handles any remaining foreign key dependencies
-	query := fmt.Sprintf("TRUNCATE TABLE billing.%s RESTART IDENTITY CASCADE", tableName)
+	query := fmt.Sprintf("TRUNCATE TABLE %s.%s RESTART IDENTITY CASCADE", c.schema, tableName)
 
 	if err := c.db.Exec(query).Error; err != nil {
 		return fmt.Errorf("failed to truncate table %s: %w", tableName, err)
 	}
 
-	log.Printf("🗑️  Truncated table: billing.%s", tableName)
+	log.Printf("🗑️  Truncated table: %s.%s", c.schema, tableName)
 	return nil
 }
 
 // VerifyCleanState checks if all test tables are empty
 // This is useful for debugging and ensuring cleanup worked correctly
 func (c *DatabaseCleaner) VerifyCleanState() error {
-	tablesToCheck := []string{"clients", "storage_records"}
+	tablesToCheck := []string{"clients", "storage_records", "ip_access_policy_records", "audit_log_records", "form_token_records", "magic_link_records", "client_change_records", "usage_records", "contract_records", "approval_request_records", "recurring_invoice_template_records", "invoice_delivery_event_records", "dunning_policy_records", "bank_transaction_records", "payout_reconciliation_records", "legal_entity_records", "document_template_records", "client_credit_limit_records", "risk_assessment_records", "webhook_event_records", "failed_message_records", "processed_message_records", "saga_records", "fiscal_calendar_records", "client_statement_job_records", "external_reference_records", "event_store_records", "invoice_records", "payment_records", "client_summary_records", "invoice_archive_records", "subscription_records", "quote_records", "email_outbox_records", "email_suppression_records", "invoice_records_counters", "client_contact_records", "upload_records", "upload_chunk_records", "client_note_records", "client_tombstone_records", "tenant_rule_set_records", "webhook_subscription_records"}
 
 	for _, table := range tablesToCheck {
 		var count int64
-		query := fmt.Sprintf("SELECT COUNT(*) FROM billing.%s", table)
+		query := fmt.Sprintf("SELECT COUNT(*) FROM %s.%s", c.schema, table)
 
 		if err := c.db.Raw(query).Scan(&count).Error; err != nil {
 			return fmt.Errorf("failed to check table %s: %w", table, err)
 		}
 
 		if count > 0 {
-			return fmt.Errorf("table billing.%s is not empty: contains %d records", table, count)
+			return fmt.Errorf("table %s.%s is not empty: contains %d records", c.schema, table, count)
 		}
 	}
 
@@ -102,12 +150,12 @@ func (c *DatabaseCleaner) VerifyCleanState() error {
 // GetTableCounts returns the number of records in each test table
 // Useful for debugging and understanding test data state
 func (c *DatabaseCleaner) GetTableCounts() (map[string]int64, error) {
-	tablesToCheck := []string{"clients", "storage_records"}
+	tablesToCheck := []string{"clients", "storage_records", "ip_access_policy_records", "audit_log_records", "form_token_records", "magic_link_records", "client_change_records", "usage_records", "contract_records", "approval_request_records", "recurring_invoice_template_records", "invoice_delivery_event_records", "dunning_policy_records", "bank_transaction_records", "payout_reconciliation_records", "legal_entity_records", "document_template_records", "client_credit_limit_records", "risk_assessment_records", "webhook_event_records", "failed_message_records", "processed_message_records", "saga_records", "fiscal_calendar_records", "client_statement_job_records", "external_reference_records", "event_store_records", "invoice_records", "payment_records", "client_summary_records", "invoice_archive_records", "subscription_records", "quote_records", "email_outbox_records", "email_suppression_records", "invoice_records_counters", "client_contact_records", "upload_records", "upload_chunk_records", "client_note_records", "client_tombstone_records", "tenant_rule_set_records", "webhook_subscription_records"}
 	counts := make(map[string]int64)
 
 	for _, table := range tablesToCheck {
 		var count int64
-		query := fmt.Sprintf("SELECT COUNT(*) FROM billing.%s", table)
+		query := fmt.Sprintf("SELECT COUNT(*) FROM %s.%s", c.schema, table)
 
 		if err := c.db.Raw(query).Scan(&count).Error; err != nil {
 			return nil, fmt.Errorf("failed to count records in table %s: %w", table, err)
@@ -122,7 +170,7 @@ func (c *DatabaseCleaner) GetTableCounts() (map[string]int64, error) {
 // CleanupSpecificTable deletes data from only a specific table
 // Useful for targeted cleanup in specific test scenarios
 func (c *DatabaseCleaner) CleanupSpecificTable(tableName string) error {
-	log.Printf("🧹 Cleaning up table: billing.%s", tableName)
+	log.Printf("🧹 Cleaning up table: %s.%s", c.schema, tableName)
 
 	if err := c.deleteFromTable(tableName); err != nil {
 		return fmt.Errorf("failed to cleanup table %s: %w", tableName, err)
@@ -0,0 +1,236 @@
+// Disposable Schema Isolation for Integration Tests
+//
+// This file provides an alternative to transaction isolation: each test package gets its own
+// PostgreSQL schema (billing_test_<random>), migrated from scratch and dropped afterwards.
+// Provides: Schema creation, migration replay into the schema, test stacks bound to the schema
+// Pattern: TestMain wrapper (RunWithIsolatedSchema) plus per-test stack factory
+// Used by: Tests that need DDL, committed data, or visibility across connections/transactions
+//
+// The migration user must be allowed to CREATE schemas in the test database.
+package testhelpers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/di"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// IsolatedSchemaPrefix prefixes every disposable test schema so leftovers are easy to spot
+const IsolatedSchemaPrefix = "billing_test_"
+
+// migrationSchemaPattern matches the schema qualifier used by the migration files
+var migrationSchemaPattern = regexp.MustCompile(`\bbilling\.`)
+
+// IsolatedSchema is a disposable, fully migrated schema owned by one test package
+type IsolatedSchema struct {
+	Name   string
+	config *di.ContainerConfig
+	admin  *gorm.DB
+}
+
+// activeSchema is the schema created by RunWithIsolatedSchema for the current test package
+var activeSchema *IsolatedSchema
+
+// NewIsolatedSchema creates a uniquely named schema and applies all up migrations to it
+func NewIsolatedSchema() (*IsolatedSchema, error) {
+	suffix := make([]byte, 6)
+	if _, err := rand.Read(suffix); err != nil {
+		return nil, fmt.Errorf("failed to generate schema name: %w", err)
+	}
+	name := IsolatedSchemaPrefix + hex.EncodeToString(suffix)
+
+	base := di.IntegrationTestConfig()
+	// Migration files hold several statements each, so the DDL connection must not prepare statements
+	admin, err := gorm.Open(postgres.Open(postgresDSN(migrationConnectionConfig(base))), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect as migration user: %w", err)
+	}
+
+	schema := &IsolatedSchema{
+		Name:  name,
+		admin: admin,
+	}
+
+	if err := admin.Exec(fmt.Sprintf("CREATE SCHEMA %s", name)).Error; err != nil {
+		if sqlDB, dbErr := admin.DB(); dbErr == nil {
+			sqlDB.Close()
+		}
+		return nil, fmt.Errorf("failed to create schema %s: %w", name, err)
+	}
+
+	if err := schema.migrate(); err != nil {
+		schema.Drop()
+		return nil, err
+	}
+
+	if err := schema.grantApplicationAccess(base.DatabaseUser); err != nil {
+		schema.Drop()
+		return nil, err
+	}
+
+	config := *base
+	config.DatabaseSchema = name
+	config.MigrationDatabaseSchema = name
+	config.DatabaseURL = replaceSearchPath(base.DatabaseURL, name)
+	config.MigrationDatabaseURL = replaceSearchPath(base.MigrationDatabaseURL, name)
+	schema.config = &config
+
+	log.Printf("🧪 Created isolated test schema %s", name)
+	return schema, nil
+}
+
+// Config returns an integration test configuration pointing at the isolated schema
+func (s *IsolatedSchema) Config() *di.ContainerConfig {
+	config := *s.config
+	return &config
+}
+
+// Drop removes the schema and everything in it, then closes the admin connection
+func (s *IsolatedSchema) Drop() {
+	if err := s.admin.Exec(fmt.Sprintf("DROP SCHEMA IF EXISTS %s CASCADE", s.Name)).Error; err != nil {
+		log.Printf("⚠️ Failed to drop isolated test schema %s: %v", s.Name, err)
+	} else {
+		log.Printf("🧹 Dropped isolated test schema %s", s.Name)
+	}
+
+	if sqlDB, err := s.admin.DB(); err == nil {
+		sqlDB.Close()
+	}
+}
+
+// migrate replays the up migrations in version order, retargeted at the isolated schema
+func (s *IsolatedSchema) migrate() error {
+	files, err := filepath.Glob(filepath.Join(migrationsDir(), "*.up.sql"))
+	if err != nil {
+		return fmt.Errorf("failed to list migrations: %w", err)
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read migration %s: %w", filepath.Base(file), err)
+		}
+
+		statement := migrationSchemaPattern.ReplaceAllString(string(content), s.Name+".")
+		if err := s.admin.Exec(statement).Error; err != nil {
+			return fmt.Errorf("failed to apply migration %s to %s: %w", filepath.Base(file), s.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// grantApplicationAccess gives the application user DML rights like on the billing schema,
+// plus CREATE so tests can exercise DDL inside their disposable schema
+func (s *IsolatedSchema) grantApplicationAccess(appUser string) error {
+	statements := []string{
+		fmt.Sprintf("GRANT USAGE, CREATE ON SCHEMA %s TO %s", s.Name, appUser),
+		fmt.Sprintf("GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA %s TO %s", s.Name, appUser),
+		fmt.Sprintf("GRANT USAGE, SELECT ON ALL SEQUENCES IN SCHEMA %s TO %s", s.Name, appUser),
+	}
+
+	for _, statement := range statements {
+		if err := s.admin.Exec(statement).Error; err != nil {
+			return fmt.Errorf("failed to grant access on %s: %w", s.Name, err)
+		}
+	}
+	return nil
+}
+
+// RunWithIsolatedSchema wraps a package's TestMain: it creates a disposable schema,
+// runs the package's tests against it and drops it afterwards.
+//
+//	func TestMain(m *testing.M) {
+//		os.Exit(testhelpers.RunWithIsolatedSchema(m))
+//	}
+func RunWithIsolatedSchema(m *testing.M) int {
+	schema, err := NewIsolatedSchema()
+	if err != nil {
+		log.Printf("❌ Failed to set up isolated test schema: %v", err)
+		return 1
+	}
+	defer schema.Drop()
+
+	activeSchema = schema
+	defer func() { activeSchema = nil }()
+
+	return m.Run()
+}
+
+// NewIsolatedSchemaStack creates an IntegrationTestStack on the package's isolated schema.
+// Unlike transaction isolation, writes are committed and visible to other connections;
+// the stack's DatabaseCleaner empties the schema when the test finishes.
+func NewIsolatedSchemaStack(t *testing.T) *IntegrationTestStack {
+	t.Helper()
+
+	if activeSchema == nil {
+		t.Fatal("No isolated schema: call testhelpers.RunWithIsolatedSchema from the package's TestMain")
+	}
+
+	schemaName := activeSchema.Name
+	db := setupPostgreSQLConnection(activeSchema.Config())
+	stack := NewTransactionalTestStack(t, db)
+	stack.DatabaseCleaner = NewDatabaseCleanerForSchema(db, schemaName)
+
+	t.Cleanup(func() {
+		if err := stack.DatabaseCleaner.CleanupTestData(); err != nil {
+			t.Logf("Warning: Failed to clean isolated schema %s: %v", schemaName, err)
+		}
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	return stack
+}
+
+// migrationConnectionConfig returns the DDL connection settings as a container config
+func migrationConnectionConfig(config *di.ContainerConfig) *di.ContainerConfig {
+	return &di.ContainerConfig{
+		DatabaseHost:     config.MigrationDatabaseHost,
+		DatabasePort:     config.MigrationDatabasePort,
+		DatabaseName:     config.MigrationDatabaseName,
+		DatabaseUser:     config.MigrationDatabaseUser,
+		DatabasePassword: config.MigrationDatabasePassword,
+		DatabaseSchema:   config.MigrationDatabaseSchema,
+	}
+}
+
+// replaceSearchPath points a database URL's search_path at another schema
+func replaceSearchPath(databaseURL, schema string) string {
+	pattern := regexp.MustCompile(`search_path=[^&]*`)
+	if pattern.MatchString(databaseURL) {
+		return pattern.ReplaceAllString(databaseURL, "search_path="+schema)
+	}
+
+	separator := "?"
+	if strings.Contains(databaseURL, "?") {
+		separator = "&"
+	}
+	return databaseURL + separator + "search_path=" + schema
+}
+
+// migrationsDir locates database/migrations relative to this source file
+func migrationsDir() string {
+	_, currentFile, _, ok := runtime.Caller(0)
+	if !ok {
+		return filepath.Join("database", "migrations")
+	}
+	return filepath.Join(filepath.Dir(currentFile), "..", "..", "database", "migrations")
+}
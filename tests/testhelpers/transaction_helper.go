@@ -124,18 +124,36 @@ func WithTransaction(t *testing.T) (*IntegrationTestStack, func()) {
 	return stack, cleanup
 }
 
+// RunNested runs fn as a nested unit of work of the test transaction.
+// The storage issues a SAVEPOINT and rolls back to it when fn fails, so tests can exercise
+// service-level transactions and still observe the outer transaction's data afterwards.
+func (s *IntegrationTestStack) RunNested(fn func(tx storage.Storage) error) error {
+	return storage.RunInTransaction(s.Storage, fn)
+}
+
+// WithSavepoint runs testFunc inside a savepoint of the stack's transaction and always
+// rolls back to it afterwards. Subtests can share expensive setup from the outer
+// transaction while each one still starts from the same state.
+func WithSavepoint(t *testing.T, stack *IntegrationTestStack, name string, testFunc func(*testing.T)) {
+	t.Helper()
+
+	if err := stack.DB.SavePoint(name).Error; err != nil {
+		t.Fatalf("Failed to create savepoint %s: %v", name, err)
+	}
+
+	defer func() {
+		if err := stack.DB.RollbackTo(name).Error; err != nil {
+			t.Logf("Warning: Failed to roll back to savepoint %s: %v", name, err)
+		}
+	}()
+
+	testFunc(t)
+}
+
 // setupPostgreSQLConnection creates a base database connection for transactions
 func setupPostgreSQLConnection(config *di.ContainerConfig) *gorm.DB {
 	// Build database connection string
-	dsn := fmt.Sprintf(
-		"host=%s port=%d user=%s password=%s dbname=%s sslmode=disable search_path=%s",
-		config.DatabaseHost,
-		config.DatabasePort,
-		config.DatabaseUser,
-		config.DatabasePassword,
-		config.DatabaseName,
-		config.DatabaseSchema,
-	)
+	dsn := postgresDSN(config)
 
 	// GORM configuration
 	gormConfig := &gorm.Config{
@@ -165,3 +183,16 @@ func setupPostgreSQLConnection(config *di.ContainerConfig) *gorm.DB {
 
 	return db
 }
+
+// postgresDSN builds the connection string for the configured application database
+func postgresDSN(config *di.ContainerConfig) string {
+	return fmt.Sprintf(
+		"host=%s port=%d user=%s password=%s dbname=%s sslmode=disable search_path=%s",
+		config.DatabaseHost,
+		config.DatabasePort,
+		config.DatabaseUser,
+		config.DatabasePassword,
+		config.DatabaseName,
+		config.DatabaseSchema,
+	)
+}
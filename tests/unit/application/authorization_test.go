@@ -0,0 +1,65 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+)
+
+func TestRequestContext_Authorize(t *testing.T) {
+	testCases := []struct {
+		name     string
+		rc       application.RequestContext
+		expected errors.ErrorCode
+	}{
+		{name: "anonymous caller", rc: application.RequestContext{TenantID: "acme"}, expected: errors.AuthorizationRequired},
+		{name: "portal client", rc: application.RequestContext{Principal: application.Principal{Kind: application.PrincipalPortal, ID: "client-1"}}, expected: errors.AuthorizationRequired},
+		{name: "admin restricted to other scopes", rc: application.RequestContext{Principal: application.Principal{Kind: application.PrincipalAdmin, ID: "helpdesk", Scopes: []string{application.ScopeSupport}}}, expected: errors.AuthorizationScope},
+		{name: "admin restricted to no scope", rc: application.RequestContext{Principal: application.Principal{Kind: application.PrincipalAdmin, ID: "auditor", Scopes: []string{}}}, expected: errors.AuthorizationScope},
+		{name: "billing admin", rc: application.RequestContext{Principal: application.Principal{Kind: application.PrincipalAdmin, ID: "billing", Scopes: []string{application.ScopeFinance}}}},
+		{name: "unrestricted admin", rc: application.AdminContext("root")},
+		{name: "system component", rc: application.SystemContext("scheduler", "acme")},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.rc.Authorize(application.PermissionDeleteClient)
+			if testCase.expected == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.True(t, errors.IsAuthorizationError(err))
+			assert.Equal(t, testCase.expected, errors.GetErrorCode(err))
+		})
+	}
+}
+
+func TestBillingService_DeleteClient_RequiresBillingAdmin(t *testing.T) {
+	clientRepo := repository.NewClientRepository(infrastructure.NewInMemoryStorage())
+	billingService := application.NewBillingService(clientRepo)
+	client, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+	require.NoError(t, err)
+
+	err = billingService.DeleteClient(application.RequestContext{}, client.ID())
+	assert.Equal(t, errors.AuthorizationRequired, errors.GetErrorCode(err))
+	support := application.RequestContext{Principal: application.Principal{Kind: application.PrincipalAdmin, ID: "helpdesk", Scopes: []string{application.ScopeSupport}}}
+	err = billingService.DeleteClient(support, client.ID())
+	assert.Equal(t, errors.AuthorizationScope, errors.GetErrorCode(err))
+
+	exists, err := clientRepo.Exists(client.ID())
+	require.NoError(t, err)
+	assert.True(t, exists, "refused deletions leave the client in place")
+
+	require.NoError(t, billingService.DeleteClient(application.AdminContext("billing"), client.ID()))
+	exists, err = clientRepo.Exists(client.ID())
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
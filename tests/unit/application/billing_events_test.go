@@ -0,0 +1,43 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/eventbus"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+)
+
+func TestBillingService_PublishesClientChanges(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	auditService := application.NewAuditService(repository.NewAuditRepository(storage))
+	bus := eventbus.New()
+	auditService.SubscribeAuditLog(bus)
+	var deleted []string
+	eventbus.Subscribe(bus, application.ClientDeletedTopic, "test", eventbus.Sync, func(ctx context.Context, event application.ClientEvent) error {
+		deleted = append(deleted, event.ClientID)
+		return nil
+	})
+	service := application.NewBillingService(repository.NewClientRepository(storage)).WithEvents(bus)
+
+	client, err := service.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+	require.NoError(t, err)
+	require.NoError(t, service.DeleteClient(application.AdminContext("billing-admin"), client.ID()))
+
+	assert.Equal(t, []string{client.ID()}, deleted)
+	require.NoError(t, bus.Wait(context.Background()))
+	entries, err := auditService.ListEntries("")
+	require.NoError(t, err)
+	actions := make([]string, len(entries))
+	for i, entry := range entries {
+		assert.Equal(t, client.ID(), entry.ResourceID())
+		actions[i] = entry.Action()
+	}
+	assert.ElementsMatch(t, []string{application.AuditActionClientCreated, application.AuditActionClientDeleted}, actions)
+}
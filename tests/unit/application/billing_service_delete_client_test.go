@@ -4,18 +4,13 @@ import (
 	"testing"
 	"time"
 
-	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/require"
-
-	"encoding/json"
-	"os"
-	"path/filepath"
-	"runtime"
-
 	"github.com/gjaminon-go-labs/billing-api/internal/application"
 	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
 	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
 	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/gjaminon-go-labs/billing-api/tests/testdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestBillingService_DeleteClient_Success(t *testing.T) {
@@ -50,7 +45,7 @@ func TestBillingService_DeleteClient_Success(t *testing.T) {
 	require.NotNil(t, retrievedClient, "Client should exist before deletion")
 
 	// Test DeleteClient
-	err = billingService.DeleteClient(validScenario.Client.ID)
+	err = billingService.DeleteClient(application.AdminContext("billing-admin"), validScenario.Client.ID)
 
 	// Assertions - this should FAIL until implemented
 	assert.NoError(t, err, "DeleteClient should succeed for valid ID")
@@ -72,7 +67,7 @@ func TestBillingService_DeleteClient_NotFound(t *testing.T) {
 	billingService := application.NewBillingService(clientRepo)
 
 	// Test DeleteClient with non-existent ID
-	err := billingService.DeleteClient(nonExistentID)
+	err := billingService.DeleteClient(application.AdminContext("billing-admin"), nonExistentID)
 
 	// Assertions - this should FAIL until implemented
 	assert.Error(t, err, "DeleteClient should fail for non-existent ID")
@@ -91,7 +86,7 @@ func TestBillingService_DeleteClient_InvalidUUID(t *testing.T) {
 	for _, invalidID := range invalidIDs {
 		t.Run("InvalidID_"+invalidID, func(t *testing.T) {
 			// Test DeleteClient with invalid UUID
-			err := billingService.DeleteClient(invalidID)
+			err := billingService.DeleteClient(application.AdminContext("billing-admin"), invalidID)
 
 			// Assertions - this should FAIL until implemented
 			assert.Error(t, err, "DeleteClient should fail for invalid UUID: %s", invalidID)
@@ -118,20 +113,5 @@ type ClientTestData struct {
 }
 
 func loadGetClientScenarios(t *testing.T) []GetClientScenario {
-	// Get current file directory
-	_, currentFile, _, ok := runtime.Caller(0)
-	require.True(t, ok, "Failed to get current file path")
-
-	// Build path to testdata
-	testDataPath := filepath.Join(filepath.Dir(currentFile), "..", "..", "testdata", "client", "get_client_scenarios.json")
-
-	// Read and parse JSON
-	data, err := os.ReadFile(testDataPath)
-	require.NoError(t, err, "Failed to read get client scenarios file")
-
-	var scenarios []GetClientScenario
-	err = json.Unmarshal(data, &scenarios)
-	require.NoError(t, err, "Failed to parse get client scenarios JSON")
-
-	return scenarios
+	return testdata.Load[[]GetClientScenario](t, "client/get_client_scenarios.json")
 }
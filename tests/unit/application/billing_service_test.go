@@ -3,10 +3,14 @@ package application
 import (
 	"testing"
 
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
 	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
 	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
 	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestBillingService_ListClients_EmptyService(t *testing.T) {
@@ -16,7 +20,7 @@ func TestBillingService_ListClients_EmptyService(t *testing.T) {
 	service := application.NewBillingService(clientRepo)
 
 	// Act
-	clients, err := service.ListClients()
+	clients, err := service.ListClients(application.AdminContext("ops"))
 
 	// Assert
 	assert.NoError(t, err)
@@ -31,17 +35,17 @@ func TestBillingService_ListClients_WithMultipleClients(t *testing.T) {
 	service := application.NewBillingService(clientRepo)
 
 	// Create clients via service
-	_, err := service.CreateClient("John Doe", "john@example.com", "+1234567890", "123 Main St")
+	_, err := service.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "John Doe", Email: "john@example.com", Phone: "+1234567890", Address: "123 Main St"})
 	assert.NoError(t, err)
 
-	_, err = service.CreateClient("Jane Smith", "jane@example.com", "+0987654321", "456 Oak Ave")
+	_, err = service.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Jane Smith", Email: "jane@example.com", Phone: "+0987654321", Address: "456 Oak Ave"})
 	assert.NoError(t, err)
 
-	_, err = service.CreateClient("Bob Wilson", "bob@example.com", "", "")
+	_, err = service.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Bob Wilson", Email: "bob@example.com"})
 	assert.NoError(t, err)
 
 	// Act
-	clients, err := service.ListClients()
+	clients, err := service.ListClients(application.AdminContext("ops"))
 
 	// Assert
 	assert.NoError(t, err)
@@ -67,11 +71,11 @@ func TestBillingService_ListClients_SingleClient(t *testing.T) {
 	service := application.NewBillingService(clientRepo)
 
 	// Create client via service
-	client, err := service.CreateClient("Test User", "test@example.com", "+1111111111", "Test Address")
+	client, err := service.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Test User", Email: "test@example.com", Phone: "+1111111111", Address: "Test Address"})
 	assert.NoError(t, err)
 
 	// Act
-	clients, err := service.ListClients()
+	clients, err := service.ListClients(application.AdminContext("ops"))
 
 	// Assert
 	assert.NoError(t, err)
@@ -86,3 +90,53 @@ func TestBillingService_ListClients_SingleClient(t *testing.T) {
 	assert.Equal(t, client.PhoneString(), retrievedClient.PhoneString())
 	assert.Equal(t, client.Address(), retrievedClient.Address())
 }
+
+func TestBillingService_CreateClient_DuplicateEmail(t *testing.T) {
+	// Arrange
+	storage := infrastructure.NewInMemoryStorage()
+	clientRepo := repository.NewClientRepository(storage)
+	service := application.NewBillingService(clientRepo)
+
+	existing, err := service.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "John Doe", Email: "john@example.com"})
+	require.NoError(t, err)
+
+	// Act: emails are compared once normalized
+	_, err = service.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Johnny Doe", Email: " John@Example.com "})
+
+	// Assert
+	require.Error(t, err)
+	assert.Equal(t, errors.BusinessRuleDuplicate, errors.GetErrorCode(err))
+	ruleErr, ok := err.(*errors.BusinessRuleError)
+	require.True(t, ok)
+	assert.Equal(t, existing.ID(), ruleErr.Context["existing_id"])
+
+	clients, err := service.ListClients(application.AdminContext("ops"))
+	require.NoError(t, err)
+	assert.Len(t, clients, 1)
+}
+
+func TestBillingService_CreateClient_FoldsEmailSpellings(t *testing.T) {
+	policy, err := valueobject.NewEmailNormalization(true, []string{"gmail.com"})
+	require.NoError(t, err)
+	storage := infrastructure.NewInMemoryStorage()
+	service := application.NewBillingService(repository.NewClientRepository(storage)).WithEmailNormalization(policy)
+
+	alice, err := service.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Alice Martin", Email: "alice.martin@gmail.com"})
+	require.NoError(t, err)
+
+	for _, spelling := range []string{"Alice.Martin@gmail.com", "alicemartin+billing@gmail.com"} {
+		_, err := service.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Alice Martin", Email: spelling})
+		require.Error(t, err, spelling)
+		assert.Equal(t, errors.BusinessRuleDuplicate, errors.GetErrorCode(err))
+		ruleErr, ok := err.(*errors.BusinessRuleError)
+		require.True(t, ok)
+		assert.Equal(t, alice.ID(), ruleErr.Context["existing_id"])
+	}
+
+	// Only the configured domains ignore dots, and the address is kept as entered
+	other, err := service.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Alice Martin", Email: "alice.martin+billing@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "alice.martin+billing@example.com", other.EmailString())
+	_, err = service.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Alice Martin", Email: "alicemartin@example.com"})
+	assert.NoError(t, err)
+}
@@ -1,21 +1,17 @@
 package application
 
 import (
-	"encoding/json"
-	"os"
-	"path/filepath"
-	"runtime"
 	"testing"
 	"time"
 
-	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/require"
-
 	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
 	"github.com/gjaminon-go-labs/billing-api/internal/application"
 	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
 	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
 	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/gjaminon-go-labs/billing-api/tests/testdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // UpdateClientScenario represents test data for update client operations
@@ -35,22 +31,7 @@ type InvalidUpdateRequest struct {
 }
 
 func loadUpdateClientScenarios(t *testing.T) []UpdateClientScenario {
-	// Get current file directory
-	_, currentFile, _, ok := runtime.Caller(0)
-	require.True(t, ok, "Failed to get current file path")
-
-	// Build path to testdata
-	testDataPath := filepath.Join(filepath.Dir(currentFile), "..", "..", "testdata", "client", "update_client_requests.json")
-
-	// Read and parse JSON
-	data, err := os.ReadFile(testDataPath)
-	require.NoError(t, err, "Failed to read update client scenarios file")
-
-	var scenarios []UpdateClientScenario
-	err = json.Unmarshal(data, &scenarios)
-	require.NoError(t, err, "Failed to parse update client scenarios JSON")
-
-	return scenarios
+	return testdata.Load[[]UpdateClientScenario](t, "client/update_client_requests.json")
 }
 
 func TestBillingService_UpdateClient_Success(t *testing.T) {
@@ -80,7 +61,7 @@ func TestBillingService_UpdateClient_Success(t *testing.T) {
 	require.NoError(t, err, "Failed to save test client")
 
 	// Test UpdateClient
-	updatedClient, err := billingService.UpdateClient(
+	updatedClient, err := billingService.UpdateClient(application.AdminContext("ops"),
 		fullUpdateScenario.ExpectedClient.ID,
 		fullUpdateScenario.Request,
 	)
@@ -122,7 +103,7 @@ func TestBillingService_UpdateClient_PartialUpdate(t *testing.T) {
 	require.NoError(t, err, "Failed to save test client")
 
 	// Test UpdateClient with partial update
-	updatedClient, err := billingService.UpdateClient(
+	updatedClient, err := billingService.UpdateClient(application.AdminContext("ops"),
 		partialUpdateScenario.ExpectedClient.ID,
 		partialUpdateScenario.Request,
 	)
@@ -149,7 +130,7 @@ func TestBillingService_UpdateClient_NotFound(t *testing.T) {
 	billingService := application.NewBillingService(clientRepo)
 
 	// Test UpdateClient with non-existent ID
-	updatedClient, err := billingService.UpdateClient(nonExistentID, updateRequest)
+	updatedClient, err := billingService.UpdateClient(application.AdminContext("ops"), nonExistentID, updateRequest)
 
 	// Assertions - this should FAIL until implemented
 	assert.Error(t, err, "UpdateClient should fail for non-existent ID")
@@ -185,7 +166,7 @@ func TestBillingService_UpdateClient_ValidationError(t *testing.T) {
 	for _, invalidRequest := range invalidRequests {
 		t.Run(invalidRequest.Description, func(t *testing.T) {
 			// Test UpdateClient with invalid request
-			updatedClient, err := billingService.UpdateClient(
+			updatedClient, err := billingService.UpdateClient(application.AdminContext("ops"),
 				validClient.ID(),
 				invalidRequest.Request,
 			)
@@ -213,7 +194,7 @@ func TestBillingService_UpdateClient_InvalidUUID(t *testing.T) {
 	for _, invalidID := range invalidIDs {
 		t.Run("InvalidID_"+invalidID, func(t *testing.T) {
 			// Test UpdateClient with invalid UUID
-			updatedClient, err := billingService.UpdateClient(invalidID, updateRequest)
+			updatedClient, err := billingService.UpdateClient(application.AdminContext("ops"), invalidID, updateRequest)
 
 			// Assertions - this should FAIL until implemented
 			assert.Error(t, err, "UpdateClient should fail for invalid UUID: %s", invalidID)
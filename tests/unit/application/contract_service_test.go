@@ -0,0 +1,191 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/messaging"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingPublisher simulates a message bus outage
+type failingPublisher struct{}
+
+func (failingPublisher) Publish(ctx context.Context, messages ...messaging.Message) error {
+	return errors.New("bus unavailable")
+}
+
+func newContractService(t *testing.T, publisher messaging.Publisher) (*application.ContractService, *application.BillingService) {
+	t.Helper()
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage)).
+		WithInvoices(repository.NewInvoiceRepository(storage.Collection(repository.InvoiceCollection)))
+	contractRepo := repository.NewContractRepository(storage.Collection(repository.ContractCollection))
+	return application.NewContractService(contractRepo, billingService, publisher, 30*24*time.Hour), billingService
+}
+
+func contractRequest(clientID string, start, end time.Time) dtos.CreateContractRequest {
+	return dtos.CreateContractRequest{
+		ClientID:        clientID,
+		Name:            "Enterprise agreement",
+		AccountManager:  "jane.doe",
+		StartDate:       start,
+		EndDate:         end,
+		CommittedAmount: 50000_00,
+		Currency:        "eur",
+	}
+}
+
+func TestContractService_CreateAndLink(t *testing.T) {
+	service, billingService := newContractService(t, messaging.NewMemoryPublisher())
+	client, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+	require.NoError(t, err)
+
+	start := time.Now().UTC().AddDate(0, -1, 0)
+	contract, err := service.CreateContract(application.AdminContext("ops"), contractRequest(client.ID(), start, start.AddDate(1, 0, 0)))
+	require.NoError(t, err)
+	assert.Equal(t, "EUR", contract.CommittedAmount().Currency())
+
+	_, err = service.CreateContract(application.AdminContext("ops"), contractRequest("00000000-0000-0000-0000-000000000000", start, start.AddDate(1, 0, 0)))
+	assert.ErrorIs(t, err, domainErrors.ErrClientNotFound)
+
+	_, err = service.LinkSubscription(application.AdminContext("ops"), contract.ID(), dtos.LinkContractSubscriptionRequest{SubscriptionID: "sub-1"})
+	require.NoError(t, err)
+	invoice := issuedInvoice(t, billingService, client.ID(), 12500_00)
+	updated, err := service.LinkInvoice(application.AdminContext("ops"), contract.ID(), dtos.LinkContractInvoiceRequest{InvoiceID: invoice.ID()})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2500), updated.Attainment(time.Now()).AttainedBps, "the invoice counts for its total")
+	assert.True(t, invoice.IssuedAt().Equal(updated.Invoices()[0].InvoicedAt))
+
+	stored, err := service.GetContract(application.AdminContext("ops"), contract.ID())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sub-1"}, stored.SubscriptionIDs())
+	assert.Len(t, stored.Invoices(), 1)
+
+	_, err = service.GetContract(application.AdminContext("ops"), "missing")
+	assert.ErrorIs(t, err, domainErrors.ErrContractNotFound)
+
+	active, err := service.ActiveContracts(application.AdminContext("ops"), time.Now())
+	require.NoError(t, err)
+	assert.Len(t, active, 1)
+}
+
+// issuedInvoice issues an invoice of one line to a client
+func issuedInvoice(t *testing.T, billingService *application.BillingService, clientID string, amount int64) *entity.Invoice {
+	t.Helper()
+	rc := application.AdminContext("ops")
+	invoice, err := billingService.CreateInvoice(rc, dtos.CreateInvoiceRequest{
+		ClientID:  clientID,
+		Currency:  "EUR",
+		LineItems: []dtos.InvoiceLineRequest{{Description: "Consulting", Quantity: 1, UnitAmount: amount}},
+	})
+	require.NoError(t, err)
+	invoice, err = billingService.IssueInvoice(rc, invoice.ID(), time.Now())
+	require.NoError(t, err)
+	return invoice
+}
+
+func TestContractService_LinkInvoice(t *testing.T) {
+	service, billingService := newContractService(t, messaging.NewMemoryPublisher())
+	rc := application.AdminContext("ops")
+	client, err := billingService.CreateClient(rc, dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+	require.NoError(t, err)
+	other, err := billingService.CreateClient(rc, dtos.CreateClientRequest{Name: "Globex", Email: "billing@globex.example"})
+	require.NoError(t, err)
+	start := time.Now().UTC().AddDate(0, -1, 0)
+	contract, err := service.CreateContract(rc, contractRequest(client.ID(), start, start.AddDate(1, 0, 0)))
+	require.NoError(t, err)
+
+	t.Run("invoices of another client are refused", func(t *testing.T) {
+		invoice := issuedInvoice(t, billingService, other.ID(), 1000_00)
+		_, err := service.LinkInvoice(rc, contract.ID(), dtos.LinkContractInvoiceRequest{InvoiceID: invoice.ID()})
+		assert.True(t, domainErrors.IsBusinessRuleError(err), "%v", err)
+	})
+
+	t.Run("draft invoices are refused", func(t *testing.T) {
+		draft, err := billingService.CreateInvoice(rc, dtos.CreateInvoiceRequest{
+			ClientID:  client.ID(),
+			Currency:  "EUR",
+			LineItems: []dtos.InvoiceLineRequest{{Description: "Consulting", Quantity: 1, UnitAmount: 1000_00}},
+		})
+		require.NoError(t, err)
+		_, err = service.LinkInvoice(rc, contract.ID(), dtos.LinkContractInvoiceRequest{InvoiceID: draft.ID()})
+		assert.True(t, domainErrors.IsBusinessRuleError(err), "%v", err)
+	})
+
+	t.Run("unknown invoices are not found", func(t *testing.T) {
+		_, err := service.LinkInvoice(rc, contract.ID(), dtos.LinkContractInvoiceRequest{InvoiceID: "00000000-0000-0000-0000-000000000000"})
+		assert.ErrorIs(t, err, domainErrors.ErrInvoiceNotFound)
+	})
+
+	stored, err := service.GetContract(rc, contract.ID())
+	require.NoError(t, err)
+	assert.Empty(t, stored.Invoices())
+}
+
+func TestContractService_SendRenewalReminders(t *testing.T) {
+	publisher := messaging.NewMemoryPublisher()
+	service, billingService := newContractService(t, publisher)
+	client, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+	require.NoError(t, err)
+
+	now := time.Date(2026, time.October, 16, 9, 0, 0, 0, time.UTC)
+	renewingSoon, err := service.CreateContract(application.AdminContext("ops"), contractRequest(client.ID(), now.AddDate(-1, 0, 10), now.AddDate(0, 0, 10)))
+	require.NoError(t, err)
+	_, err = service.CreateContract(application.AdminContext("ops"), contractRequest(client.ID(), now.AddDate(0, -1, 0), now.AddDate(1, 0, 0)))
+	require.NoError(t, err)
+
+	reminded, err := service.SendRenewalReminders(context.Background(), now)
+	require.NoError(t, err)
+	require.Len(t, reminded, 1)
+	assert.Equal(t, renewingSoon.ID(), reminded[0].ID())
+
+	messages := publisher.Messages()
+	require.Len(t, messages, 1)
+	assert.Equal(t, application.ContractRenewalReminderTopic, messages[0].Topic)
+	assert.Equal(t, renewingSoon.ID(), messages[0].Key)
+
+	var event application.ContractRenewalReminderEvent
+	require.NoError(t, json.Unmarshal(messages[0].Payload, &event))
+	assert.Equal(t, client.ID(), event.ClientID)
+	assert.Equal(t, "jane.doe", event.AccountManager)
+	assert.Equal(t, int64(50000_00), event.CommittedAmount)
+
+	// Reminders are sent once per contract
+	reminded, err = service.SendRenewalReminders(context.Background(), now.Add(24*time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, reminded)
+	assert.Len(t, publisher.Messages(), 1)
+}
+
+func TestContractService_SendRenewalRemindersRetriesAfterPublishFailure(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage))
+	contractRepo := repository.NewContractRepository(storage.Collection(repository.ContractCollection))
+	client, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+	require.NoError(t, err)
+
+	now := time.Date(2026, time.October, 16, 9, 0, 0, 0, time.UTC)
+	failing := application.NewContractService(contractRepo, billingService, failingPublisher{}, 30*24*time.Hour)
+	_, err = failing.CreateContract(application.AdminContext("ops"), contractRequest(client.ID(), now.AddDate(-1, 0, 10), now.AddDate(0, 0, 10)))
+	require.NoError(t, err)
+
+	_, err = failing.SendRenewalReminders(context.Background(), now)
+	require.Error(t, err)
+
+	publisher := messaging.NewMemoryPublisher()
+	recovered := application.NewContractService(contractRepo, billingService, publisher, 30*24*time.Hour)
+	reminded, err := recovered.SendRenewalReminders(context.Background(), now)
+	require.NoError(t, err)
+	assert.Len(t, reminded, 1, "the reminder is not marked as sent when publishing fails")
+}
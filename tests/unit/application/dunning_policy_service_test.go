@@ -0,0 +1,62 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDunningPolicyService(t *testing.T) *application.DunningPolicyService {
+	t.Helper()
+	storage := infrastructure.NewInMemoryStorage()
+	return application.NewDunningPolicyService(
+		repository.NewDunningPolicyRepository(storage.Collection(repository.DunningPolicyCollection)),
+		application.NewAuditService(repository.NewAuditRepository(storage.Collection(repository.AuditCollection))),
+	)
+}
+
+func TestDunningPolicyService_DueReminder(t *testing.T) {
+	service := newDunningPolicyService(t)
+
+	t.Run("tenants without a policy use the default cadence", func(t *testing.T) {
+		reminder, err := service.DueReminder("acme", 8)
+
+		require.NoError(t, err)
+		require.NotNil(t, reminder)
+		assert.Equal(t, 1, reminder.Index)
+		assert.Equal(t, entity.DefaultReminderStages()[1].Template(), reminder.Stage.Template())
+	})
+
+	t.Run("a tenant cadence replaces the default stages", func(t *testing.T) {
+		_, err := service.SetPolicy("ops", "acme", dtos.SetDunningPolicyRequest{Stages: []dtos.ReminderStageRequest{
+			{DayOffset: 3, Channel: "sms", Template: "acme_nudge"},
+			{DayOffset: 45, Channel: "letter", Template: "acme_notice"},
+		}})
+		require.NoError(t, err)
+
+		reminder, err := service.DueReminder("acme", 8)
+		require.NoError(t, err)
+		require.NotNil(t, reminder)
+		assert.Equal(t, 0, reminder.Index)
+		assert.Equal(t, entity.ReminderChannelSMS, reminder.Stage.Channel())
+		assert.Equal(t, "acme_nudge", reminder.Stage.Template())
+
+		reminder, err = service.DueReminder("acme", 2)
+		require.NoError(t, err)
+		assert.Nil(t, reminder, "nothing is due before the first stage")
+	})
+
+	t.Run("deleting the policy reverts to the default cadence", func(t *testing.T) {
+		require.NoError(t, service.DeletePolicy("ops", "acme"))
+
+		stages, err := service.StagesFor("acme")
+		require.NoError(t, err)
+		assert.Equal(t, entity.DefaultReminderStages(), stages)
+	})
+}
@@ -0,0 +1,239 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/messaging"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDunningService_SendDueReminders(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	invoiceRepo := repository.NewInvoiceRepository(storage.Collection(repository.InvoiceCollection))
+	billingService := application.NewBillingService(repository.NewClientRepository(storage)).
+		WithInvoices(invoiceRepo).
+		WithPayments(repository.NewPaymentRepository(storage.Collection(repository.PaymentCollection)))
+	policies := application.NewDunningPolicyService(
+		repository.NewDunningPolicyRepository(storage.Collection(repository.DunningPolicyCollection)),
+		application.NewAuditService(repository.NewAuditRepository(storage.Collection(repository.AuditCollection))),
+	)
+	_, err := policies.SetPolicy("ops", "acme", dtos.SetDunningPolicyRequest{Stages: []dtos.ReminderStageRequest{
+		{DayOffset: 3, Channel: "sms", Template: "acme_nudge"},
+		{DayOffset: 45, Channel: "letter", Template: "acme_notice"},
+	}})
+	require.NoError(t, err)
+
+	// Invoices of each tenant bill a client of the tenant
+	clients := map[string]string{}
+	for _, tenantID := range []string{"", "acme"} {
+		client, err := billingService.CreateClient(application.SystemContext("test", tenantID), dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing" + tenantID + "@acme.example"})
+		require.NoError(t, err)
+		clients[tenantID] = client.ID()
+	}
+	now := time.Date(2026, time.October, 16, 9, 0, 0, 0, time.UTC)
+	issue := func(t *testing.T, tenantID string, daysOverdue int) *entity.Invoice {
+		t.Helper()
+		dueDate := now.AddDate(0, 0, -daysOverdue)
+		invoice, err := billingService.CreateInvoice(application.SystemContext("test", tenantID), dtos.CreateInvoiceRequest{
+			ClientID:  clients[tenantID],
+			Currency:  "EUR",
+			LineItems: []dtos.InvoiceLineRequest{{Description: "Consulting", Quantity: 1, UnitAmount: 10000}},
+			DueDate:   &dueDate,
+		})
+		require.NoError(t, err)
+		issued, err := billingService.IssueInvoice(application.SystemContext("test", tenantID), invoice.ID(), dueDate.AddDate(0, 0, -30))
+		require.NoError(t, err)
+		return issued
+	}
+	defaultCadence := issue(t, "", 8)    // Second default stage (7 days): the first one is skipped
+	tenantCadence := issue(t, "acme", 4) // First stage of the acme cadence (3 days)
+	notYetDue := issue(t, "", -20)
+	notReached := issue(t, "acme", 2)
+
+	publisher := messaging.NewMemoryPublisher()
+	service := application.NewDunningService(billingService, policies, publisher)
+
+	reminders, err := service.SendDueReminders(context.Background(), now)
+	require.NoError(t, err)
+	require.Len(t, reminders, 2)
+	reminded := map[string]entity.DunningEvent{}
+	for _, reminder := range reminders {
+		reminded[reminder.Invoice.ID()] = reminder.Event
+	}
+	assert.Equal(t, 2, reminded[defaultCadence.ID()].Level)
+	assert.Equal(t, "payment_reminder_firm", reminded[defaultCadence.ID()].Template)
+	assert.Equal(t, 1, reminded[tenantCadence.ID()].Level)
+	assert.Equal(t, entity.ReminderChannelSMS, reminded[tenantCadence.ID()].Channel)
+	assert.NotContains(t, reminded, notYetDue.ID())
+	assert.NotContains(t, reminded, notReached.ID())
+
+	t.Run("reminders are published for the mailer", func(t *testing.T) {
+		messages := publisher.Messages()
+		require.Len(t, messages, 2)
+		for _, message := range messages {
+			assert.Equal(t, application.InvoiceDunningReminderTopic, message.Topic)
+		}
+
+		var event application.InvoiceDunningReminderEvent
+		require.NoError(t, json.Unmarshal(messages[0].Payload, &event))
+		assert.Equal(t, clients[""], event.ClientID)
+		assert.Equal(t, int64(10000), event.Balance)
+		assert.Equal(t, "EUR", event.Currency)
+	})
+
+	t.Run("dunning events are recorded on the invoice", func(t *testing.T) {
+		stored, err := billingService.GetInvoice(application.AdminContext("ops"), defaultCadence.ID())
+		require.NoError(t, err)
+		assert.Equal(t, 2, stored.DunningLevel())
+		require.Len(t, stored.DunningEvents(), 1)
+		assert.Equal(t, 8, stored.DunningEvents()[0].DaysOverdue)
+	})
+
+	t.Run("each level is sent once", func(t *testing.T) {
+		reminders, err := service.SendDueReminders(context.Background(), now.Add(24*time.Hour))
+		require.NoError(t, err)
+		require.Len(t, reminders, 1)
+		assert.Equal(t, notReached.ID(), reminders[0].Invoice.ID()) // Reached the first acme stage overnight
+	})
+
+	t.Run("invoices escalate when they reach the next stage", func(t *testing.T) {
+		reminders, err := service.SendDueReminders(context.Background(), now.AddDate(0, 0, 7))
+		require.NoError(t, err)
+		require.Len(t, reminders, 1)
+		assert.Equal(t, defaultCadence.ID(), reminders[0].Invoice.ID())
+		assert.Equal(t, 3, reminders[0].Event.Level)
+		assert.Len(t, reminders[0].Invoice.DunningEvents(), 2)
+	})
+
+	t.Run("paid invoices are no longer reminded", func(t *testing.T) {
+		_, _, err := billingService.RecordPayment(application.AdminContext("billing-admin"), tenantCadence.ID(), dtos.RecordPaymentRequest{Amount: 10000, Currency: "EUR", Method: "bank_transfer"}, now)
+		require.NoError(t, err)
+		reminders, err := service.SendDueReminders(context.Background(), now.AddDate(0, 2, 0))
+		require.NoError(t, err)
+		for _, reminder := range reminders {
+			assert.NotEqual(t, tenantCadence.ID(), reminder.Invoice.ID())
+		}
+	})
+
+	t.Run("reminders announce the late fees owed", func(t *testing.T) {
+		late := issue(t, "", 10)
+		policy, err := valueobject.NewLateFeePolicy(valueobject.LateFeePolicyConfig{GraceDays: 5, FlatFee: 500})
+		require.NoError(t, err)
+		publisher := messaging.NewMemoryPublisher()
+		_, err = application.NewDunningService(billingService, policies, publisher).WithLateFees(&policy).SendDueReminders(context.Background(), now)
+		require.NoError(t, err)
+
+		var event application.InvoiceDunningReminderEvent
+		for _, message := range publisher.Messages() {
+			if message.Key == late.ID() {
+				require.NoError(t, json.Unmarshal(message.Payload, &event))
+			}
+		}
+		assert.Equal(t, late.ID(), event.InvoiceID)
+		assert.Equal(t, int64(500), event.LateFee)
+		assert.Equal(t, int64(10500), event.AmountDue)
+	})
+
+	t.Run("a failed publish records nothing, so the next run retries", func(t *testing.T) {
+		late := issue(t, "", 2)
+		failing := application.NewDunningService(billingService, policies, failingPublisher{})
+		_, err := failing.SendDueReminders(context.Background(), now)
+		require.Error(t, err)
+
+		stored, err := billingService.GetInvoice(application.AdminContext("ops"), late.ID())
+		require.NoError(t, err)
+		assert.Empty(t, stored.DunningEvents())
+	})
+}
+
+func TestDunningService_ChargesLateFees(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage)).
+		WithInvoices(repository.NewInvoiceRepository(storage.Collection(repository.InvoiceCollection))).
+		WithPayments(repository.NewPaymentRepository(storage.Collection(repository.PaymentCollection)))
+	audit := application.NewAuditService(repository.NewAuditRepository(storage.Collection(repository.AuditCollection)))
+	policies := application.NewDunningPolicyService(repository.NewDunningPolicyRepository(storage.Collection(repository.DunningPolicyCollection)), audit)
+
+	client, err := billingService.CreateClient(application.SystemContext("test", ""), dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+	require.NoError(t, err)
+	now := time.Date(2026, time.October, 16, 9, 0, 0, 0, time.UTC)
+	dueDate := now.AddDate(0, 0, -8)
+	invoice, err := billingService.CreateInvoice(application.SystemContext("test", ""), dtos.CreateInvoiceRequest{
+		ClientID:  client.ID(),
+		Currency:  "EUR",
+		LineItems: []dtos.InvoiceLineRequest{{Description: "Consulting", Quantity: 1, UnitAmount: 10000}},
+		DueDate:   &dueDate,
+	})
+	require.NoError(t, err)
+	_, err = billingService.IssueInvoice(application.SystemContext("test", ""), invoice.ID(), dueDate.AddDate(0, 0, -30))
+	require.NoError(t, err)
+
+	// 5.00 once overdue, plus interest of 0.10 a day on the 100.00 overdue
+	policy, err := valueobject.NewLateFeePolicy(valueobject.LateFeePolicyConfig{FlatFee: 500, AnnualInterestBps: 3650})
+	require.NoError(t, err)
+	service := application.NewDunningService(billingService, policies, messaging.NewMemoryPublisher()).WithLateFees(&policy).WithAudit(audit)
+	balance := func(t *testing.T) int64 {
+		t.Helper()
+		stored, err := billingService.GetInvoice(application.AdminContext("ops"), invoice.ID())
+		require.NoError(t, err)
+		balance, err := stored.Balance()
+		require.NoError(t, err)
+		return balance.Amount()
+	}
+
+	t.Run("the fee is added to the balance", func(t *testing.T) {
+		reminders, err := service.SendDueReminders(context.Background(), now)
+		require.NoError(t, err)
+		require.Len(t, reminders, 1)
+		assert.Equal(t, int64(580), reminders[0].Event.LateFee)
+		assert.Equal(t, int64(10580), balance(t))
+	})
+
+	t.Run("a stage charges its fee once", func(t *testing.T) {
+		reminders, err := service.SendDueReminders(context.Background(), now.Add(24*time.Hour))
+		require.NoError(t, err)
+		assert.Empty(t, reminders)
+		assert.Equal(t, int64(10580), balance(t))
+	})
+
+	t.Run("the next stage charges the interest accrued since, not fees on fees", func(t *testing.T) {
+		reminders, err := service.SendDueReminders(context.Background(), now.AddDate(0, 0, 7))
+		require.NoError(t, err)
+		require.Len(t, reminders, 1)
+		assert.Equal(t, int64(70), reminders[0].Event.LateFee)
+		assert.Equal(t, int64(10650), balance(t))
+	})
+
+	t.Run("every fee charged is audited", func(t *testing.T) {
+		entries, err := audit.ListEntries("")
+		require.NoError(t, err)
+		var charged []string
+		for _, entry := range entries {
+			if entry.Action() == application.AuditActionLateFeeCharged {
+				assert.Equal(t, invoice.ID(), entry.ResourceID())
+				charged = append(charged, fmt.Sprint(entry.Details()["lateFee"]))
+			}
+		}
+		assert.ElementsMatch(t, []string{"580", "70"}, charged)
+	})
+
+	t.Run("the fees are owed with the invoice", func(t *testing.T) {
+		_, _, err := billingService.RecordPayment(application.AdminContext("billing-admin"), invoice.ID(), dtos.RecordPaymentRequest{Amount: 10000, Currency: "EUR", Method: "bank_transfer"}, now)
+		require.NoError(t, err)
+		stored, err := billingService.GetInvoice(application.AdminContext("ops"), invoice.ID())
+		require.NoError(t, err)
+		assert.Equal(t, entity.InvoiceIssued, stored.Status())
+		assert.Equal(t, int64(650), balance(t))
+	})
+}
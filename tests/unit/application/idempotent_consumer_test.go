@@ -0,0 +1,167 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/messaging"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/webhook"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newIdempotentConsumer creates a consumer whose clock is read from now
+func newIdempotentConsumer(ttl time.Duration, now *time.Time) *application.IdempotentConsumer {
+	storage := infrastructure.NewInMemoryStorage()
+	messageRepo := repository.NewProcessedMessageRepository(storage.Collection(repository.ProcessedMessageCollection))
+	return application.NewIdempotentConsumer(messageRepo, ttl).WithClock(func() time.Time { return *now })
+}
+
+func TestIdempotentConsumer_ProcessOnce(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	consumer := newIdempotentConsumer(time.Hour, &now)
+	charges := 0
+	charge := func() error {
+		charges++
+		return nil
+	}
+
+	processed, err := consumer.ProcessOnce("msg-1", charge)
+	require.NoError(t, err)
+	assert.True(t, processed)
+
+	processed, err = consumer.ProcessOnce("msg-1", charge)
+	require.NoError(t, err)
+	assert.False(t, processed)
+	assert.Equal(t, 1, charges)
+
+	processed, err = consumer.ProcessOnce("msg-2", charge)
+	require.NoError(t, err)
+	assert.True(t, processed)
+	assert.Equal(t, 2, charges)
+}
+
+func TestIdempotentConsumer_FailedProcessingRunsAgain(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	consumer := newIdempotentConsumer(time.Hour, &now)
+
+	processed, err := consumer.ProcessOnce("msg-1", func() error { return fmt.Errorf("ledger unavailable") })
+	require.Error(t, err)
+	assert.False(t, processed)
+
+	processed, err = consumer.ProcessOnce("msg-1", func() error { return nil })
+	require.NoError(t, err)
+	assert.True(t, processed)
+}
+
+func TestIdempotentConsumer_ConcurrentDeliveries(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	consumer := newIdempotentConsumer(time.Hour, &now)
+
+	var charges int32
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := consumer.ProcessOnce("msg-1", func() error {
+				atomic.AddInt32(&charges, 1)
+				return nil
+			})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&charges))
+}
+
+func TestIdempotentConsumer_Cleanup(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	consumer := newIdempotentConsumer(time.Hour, &now)
+	noop := func() error { return nil }
+
+	_, err := consumer.ProcessOnce("msg-old", noop)
+	require.NoError(t, err)
+	now = now.Add(45 * time.Minute)
+	_, err = consumer.ProcessOnce("msg-new", noop)
+	require.NoError(t, err)
+
+	now = now.Add(30 * time.Minute)
+	removed, err := consumer.Cleanup()
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+
+	// Expired messages are processed again, records still protect the others
+	processed, err := consumer.ProcessOnce("msg-old", noop)
+	require.NoError(t, err)
+	assert.True(t, processed)
+	processed, err = consumer.ProcessOnce("msg-new", noop)
+	require.NoError(t, err)
+	assert.False(t, processed)
+}
+
+// countingWebhookProcessor counts the events it processed
+type countingWebhookProcessor struct {
+	processed int
+}
+
+func (p *countingWebhookProcessor) Process(ctx context.Context, event *entity.WebhookEvent) error {
+	p.processed++
+	return nil
+}
+
+// downPublisher rejects messages while down
+type downPublisher struct {
+	*messaging.MemoryPublisher
+	down bool
+}
+
+func (p *downPublisher) Publish(ctx context.Context, messages ...messaging.Message) error {
+	if p.down {
+		return fmt.Errorf("message bus unavailable")
+	}
+	return p.MemoryPublisher.Publish(ctx, messages...)
+}
+
+func TestWebhookService_ProcessorsRunOnceWhenPublicationFails(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	storage := infrastructure.NewInMemoryStorage()
+	publisher := &downPublisher{MemoryPublisher: messaging.NewMemoryPublisher(), down: true}
+	processor := &countingWebhookProcessor{}
+	webhookService := application.NewWebhookService(
+		repository.NewWebhookEventRepository(storage.Collection(repository.WebhookEventCollection)),
+		application.NewAuditService(repository.NewAuditRepository(storage.Collection(repository.AuditCollection))),
+		publisher,
+		3,
+	).
+		WithProvider("bank", webhook.NewHMACVerifier("bank-secret", "X-Bank-Signature", "")).
+		WithProcessor("bank", processor).
+		WithIdempotentConsumer(newIdempotentConsumer(time.Hour, &now))
+
+	body := []byte(`{"event_id":"bank-1","event_type":"credit_transfer.received"}`)
+	header := http.Header{}
+	header.Set("X-Bank-Signature", "sha256="+webhook.Sign("bank-secret", string(body)))
+
+	receipt, err := webhookService.Receive(context.Background(), "bank", header.Get, body, now)
+	require.NoError(t, err)
+	assert.Equal(t, entity.WebhookEventPending, receipt.Event.Status())
+	assert.Equal(t, 1, processor.processed)
+
+	publisher.down = false
+	processed, err := webhookService.ProcessPending(context.Background(), now)
+	require.NoError(t, err)
+	require.Len(t, processed, 1)
+	assert.Equal(t, entity.WebhookEventProcessed, processed[0].Status())
+	assert.Equal(t, 1, processor.processed)
+	assert.Len(t, publisher.Messages(), 1)
+}
@@ -0,0 +1,223 @@
+package application
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBillingService_InvoiceIssuance(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	auditService := application.NewAuditService(repository.NewAuditRepository(storage.Collection(repository.AuditCollection)))
+	legalEntities := application.NewLegalEntityService(
+		repository.NewLegalEntityRepository(storage.Collection(repository.LegalEntityCollection)),
+		auditService,
+	)
+	fiscal := application.NewFiscalCalendarService(
+		repository.NewFiscalCalendarRepository(storage.Collection(repository.FiscalCalendarCollection)),
+		auditService,
+	)
+	references := application.NewExternalReferenceService(
+		repository.NewExternalReferenceRepository(storage.Collection(repository.ExternalReferenceCollection)))
+	billingService := application.NewBillingService(repository.NewClientRepository(storage)).
+		WithInvoices(repository.NewInvoiceRepository(storage.Collection(repository.InvoiceCollection))).
+		WithPayments(repository.NewPaymentRepository(storage.Collection(repository.PaymentCollection))).
+		WithExternalReferences(references).
+		WithDuplicateCheck(&service.DuplicateInvoiceCheck{LineItemWindow: 24 * time.Hour}).
+		WithLegalEntities(legalEntities).
+		WithFiscalCalendars(fiscal).
+		WithComplianceRules(service.DefaultComplianceRules())
+
+	seller, err := legalEntities.CreateEntity("ops", dtos.LegalEntityRequest{
+		Name:             "Acme SAS",
+		Country:          "FR",
+		TaxRegistrations: []dtos.TaxRegistrationRequest{{Country: "FR", Number: "FR40303265045"}},
+		Numbering:        dtos.InvoiceNumberingRequest{Prefix: "FR-"},
+	})
+	require.NoError(t, err)
+	_, err = fiscal.SetCalendar("ops", "acme", dtos.SetFiscalCalendarRequest{StartMonth: 1})
+	require.NoError(t, err)
+
+	rc := application.SystemContext("test", "acme")
+	newClient := func(t *testing.T, name string) string {
+		t.Helper()
+		client, err := billingService.CreateClient(rc, dtos.CreateClientRequest{Name: name, Email: name + "@clients.example"})
+		require.NoError(t, err)
+		return client.ID()
+	}
+	issuedAt := time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC)
+
+	t.Run("external references are unique in the tenant", func(t *testing.T) {
+		first, err := billingService.CreateInvoice(rc, dtos.CreateInvoiceRequest{
+			ClientID:    newClient(t, "initech"),
+			Currency:    "EUR",
+			LineItems:   []dtos.InvoiceLineRequest{{Description: "Consulting", Quantity: 1, UnitAmount: 10000}},
+			ExternalRef: "PO-1001",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "PO-1001", first.ExternalReference())
+
+		_, err = billingService.CreateInvoice(rc, dtos.CreateInvoiceRequest{
+			ClientID:    newClient(t, "hooli"),
+			Currency:    "EUR",
+			LineItems:   []dtos.InvoiceLineRequest{{Description: "Support", Quantity: 1, UnitAmount: 20000}},
+			ExternalRef: "PO-1001",
+		})
+		var conflict *errors.BusinessRuleError
+		require.ErrorAs(t, err, &conflict)
+		assert.Equal(t, errors.BusinessRuleDuplicate, conflict.Code)
+		assert.Equal(t, "/api/v1/invoices/"+first.ID(), conflict.Context["existing_url"])
+
+		// Deleting the draft frees its reference
+		require.NoError(t, billingService.DeleteInvoice(rc, first.ID()))
+		_, err = billingService.CreateInvoice(rc, dtos.CreateInvoiceRequest{
+			ClientID:    newClient(t, "umbrella"),
+			Currency:    "EUR",
+			LineItems:   []dtos.InvoiceLineRequest{{Description: "Support", Quantity: 1, UnitAmount: 20000}},
+			ExternalRef: "PO-1001",
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("duplicates point to the existing invoice unless allowed", func(t *testing.T) {
+		clientID := newClient(t, "globex")
+		req := dtos.CreateInvoiceRequest{
+			ClientID:  clientID,
+			Currency:  "EUR",
+			LineItems: []dtos.InvoiceLineRequest{{Description: "Hosting", Quantity: 2, UnitAmount: 15000}},
+		}
+		first, err := billingService.CreateInvoice(rc, req)
+		require.NoError(t, err)
+
+		_, err = billingService.CreateInvoice(rc, req)
+		var duplicate *errors.BusinessRuleError
+		require.ErrorAs(t, err, &duplicate)
+		assert.Equal(t, string(service.DuplicateByLineItems), duplicate.Context["match"])
+		assert.Equal(t, first.ID(), duplicate.Context["existing_id"])
+
+		req.AllowDuplicate = true
+		_, err = billingService.CreateInvoice(rc, req)
+		assert.NoError(t, err)
+	})
+
+	t.Run("invoices are issued from their legal entity once compliant", func(t *testing.T) {
+		req := dtos.CreateInvoiceRequest{
+			ClientID:      newClient(t, "stark"),
+			Currency:      "EUR",
+			LineItems:     []dtos.InvoiceLineRequest{{Description: "Licences", Quantity: 1, UnitAmount: 50000}},
+			LegalEntityID: seller.ID(),
+			BuyerCountry:  "de",
+		}
+		missingTaxID, err := billingService.CreateInvoice(rc, req)
+		require.NoError(t, err)
+		assert.Equal(t, "DE", missingTaxID.BuyerCountry())
+
+		_, err = billingService.IssueInvoice(rc, missingTaxID.ID(), issuedAt)
+		var validation *errors.ValidationError
+		require.ErrorAs(t, err, &validation)
+		assert.Equal(t, "buyer_tax_id", validation.Field)
+
+		req.BuyerTaxID = "DE136695976"
+		req.AllowDuplicate = true
+		draft, err := billingService.CreateInvoice(rc, req)
+		require.NoError(t, err)
+		issued, err := billingService.IssueInvoice(rc, draft.ID(), issuedAt)
+		require.NoError(t, err)
+		assert.Equal(t, "FR-000001", issued.Number())
+		assert.Equal(t, seller.ID(), issued.LegalEntityID())
+		assert.Equal(t, "per_rate", issued.TaxBreakdown())
+		assert.NotEmpty(t, issued.LegalMentions())
+		assert.Equal(t, 2026, issued.FiscalYear())
+		assert.Equal(t, "2026-P03", issued.FiscalPeriod())
+
+		// Every legal entity numbers from its own counter
+		branch, err := legalEntities.CreateEntity("ops", dtos.LegalEntityRequest{
+			Name:             "Acme Lyon",
+			Country:          "FR",
+			TaxRegistrations: []dtos.TaxRegistrationRequest{{Country: "FR", Number: "FR40303265045"}},
+			Numbering:        dtos.InvoiceNumberingRequest{Prefix: "LY-", YearlyReset: true},
+		})
+		require.NoError(t, err)
+		req.LegalEntityID = branch.ID()
+		draft, err = billingService.CreateInvoice(rc, req)
+		require.NoError(t, err)
+		issued, err = billingService.IssueInvoice(rc, draft.ID(), issuedAt)
+		require.NoError(t, err)
+		assert.Equal(t, "LY-2026-000001", issued.Number())
+
+		numbered, err := legalEntities.GetEntity(branch.ID())
+		require.NoError(t, err)
+		assert.True(t, numbered.HasIssuedInvoices())
+	})
+
+	t.Run("invoices dated in a closed fiscal period are refused", func(t *testing.T) {
+		_, err := fiscal.ClosePeriod("ops", "acme", "2026-P03")
+		require.NoError(t, err)
+		draft, err := billingService.CreateInvoice(rc, dtos.CreateInvoiceRequest{
+			ClientID:  newClient(t, "wayne"),
+			Currency:  "EUR",
+			LineItems: []dtos.InvoiceLineRequest{{Description: "Audit", Quantity: 1, UnitAmount: 30000}},
+		})
+		require.NoError(t, err)
+
+		_, err = billingService.IssueInvoice(rc, draft.ID(), issuedAt)
+		var closed *errors.BusinessRuleError
+		require.ErrorAs(t, err, &closed)
+		assert.Equal(t, "fiscal_period_open", closed.Rule)
+		assert.Equal(t, "2026-P03", closed.Context["fiscal_period"])
+
+		stored, err := billingService.GetInvoice(rc, draft.ID())
+		require.NoError(t, err)
+		assert.True(t, stored.IsDraft(), "refused invoices stay drafts")
+
+		issued, err := billingService.IssueInvoice(rc, draft.ID(), issuedAt.AddDate(0, 1, 0))
+		require.NoError(t, err)
+		assert.Equal(t, "2026-P04", issued.FiscalPeriod())
+	})
+
+	t.Run("installments split the balance and drive overdue days", func(t *testing.T) {
+		dueDate := issuedAt.AddDate(0, 1, 0)
+		draft, err := billingService.CreateInvoice(rc, dtos.CreateInvoiceRequest{
+			ClientID:     newClient(t, "tyrell"),
+			Currency:     "EUR",
+			LineItems:    []dtos.InvoiceLineRequest{{Description: "Equipment", Quantity: 1, UnitAmount: 90000}},
+			DueDate:      &dueDate,
+			Installments: &dtos.InstallmentsRequest{Count: 3},
+		})
+		require.NoError(t, err)
+		issued, err := billingService.IssueInvoice(rc, draft.ID(), dueDate.AddDate(0, 0, -9))
+		require.NoError(t, err)
+
+		plan, err := issued.InstallmentPlan()
+		require.NoError(t, err)
+		require.NotNil(t, plan)
+		require.Len(t, plan.Installments(), 3)
+		assert.Equal(t, int64(30000), plan.Installments()[0].Amount().Amount())
+		assert.Equal(t, dueDate.AddDate(0, 2, 0), plan.Installments()[2].DueDate())
+		assert.Equal(t, 5, issued.DaysOverdue(dueDate.AddDate(0, 0, 5)))
+
+		_, paid, err := billingService.RecordPayment(application.AdminContext("billing-admin"), issued.ID(), dtos.RecordPaymentRequest{Amount: 30000, Currency: "EUR", Method: "bank_transfer"}, dueDate.AddDate(0, 0, 5))
+		require.NoError(t, err)
+		assert.Equal(t, 0, paid.DaysOverdue(dueDate.AddDate(0, 0, 10)), "the next installment is not due yet")
+		assert.Equal(t, 3, paid.DaysOverdue(dueDate.AddDate(0, 1, 3)))
+	})
+
+	t.Run("invalid installments are rejected", func(t *testing.T) {
+		_, err := billingService.CreateInvoice(rc, dtos.CreateInvoiceRequest{
+			ClientID:     newClient(t, "cyberdyne"),
+			Currency:     "EUR",
+			LineItems:    []dtos.InvoiceLineRequest{{Description: "Equipment", Quantity: 1, UnitAmount: 90000}},
+			Installments: &dtos.InstallmentsRequest{Count: 1},
+		})
+		var validation *errors.ValidationError
+		require.ErrorAs(t, err, &validation)
+	})
+}
@@ -0,0 +1,95 @@
+package application
+
+import (
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBillingService_IssueInvoice_Numbering(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	invoiceRepo := repository.NewInvoiceRepository(storage.Collection(repository.InvoiceCollection))
+	format, err := valueobject.NewInvoiceNumberFormat("INV-{YYYY}-{0000}")
+	require.NoError(t, err)
+	service := application.NewBillingService(repository.NewClientRepository(storage)).
+		WithInvoices(invoiceRepo).
+		WithInvoiceNumbering(format)
+
+	client, err := service.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+	require.NoError(t, err)
+	draft := func(t *testing.T) *entity.Invoice {
+		t.Helper()
+		invoice, err := service.CreateInvoice(application.AdminContext("ops"), dtos.CreateInvoiceRequest{
+			ClientID:  client.ID(),
+			Currency:  "EUR",
+			LineItems: []dtos.InvoiceLineRequest{{Description: "Consulting", Quantity: 1, UnitAmount: 10000}},
+		})
+		require.NoError(t, err)
+		return invoice
+	}
+	issuedIn2026 := time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC)
+
+	first, err := service.IssueInvoice(application.AdminContext("ops"), draft(t).ID(), issuedIn2026)
+	require.NoError(t, err)
+	assert.Equal(t, "INV-2026-0001", first.Number())
+	second, err := service.IssueInvoice(application.AdminContext("ops"), draft(t).ID(), issuedIn2026)
+	require.NoError(t, err)
+	assert.Equal(t, "INV-2026-0002", second.Number())
+
+	t.Run("numbers are persisted with the invoice", func(t *testing.T) {
+		stored, err := service.GetInvoice(application.AdminContext("ops"), first.ID())
+		require.NoError(t, err)
+		assert.Equal(t, "INV-2026-0001", stored.Number())
+		assert.Equal(t, entity.InvoiceIssued, stored.Status())
+	})
+
+	t.Run("drafts have no number", func(t *testing.T) {
+		assert.Empty(t, draft(t).Number())
+	})
+
+	t.Run("issuing twice keeps the number and consumes none", func(t *testing.T) {
+		_, err := service.IssueInvoice(application.AdminContext("ops"), first.ID(), issuedIn2026)
+		assert.ErrorIs(t, err, errors.ErrInvoiceNotDraft)
+
+		third, err := service.IssueInvoice(application.AdminContext("ops"), draft(t).ID(), issuedIn2026)
+		require.NoError(t, err)
+		assert.Equal(t, "INV-2026-0003", third.Number())
+	})
+
+	t.Run("a failed update releases its number", func(t *testing.T) {
+		pending := draft(t)
+		errSave := stderrors.New("save failed")
+		_, err := invoiceRepo.UpdateNumbered(pending.ID(), "2026", func(invoice *entity.Invoice, number int64) error {
+			assert.Equal(t, int64(4), number)
+			return errSave
+		})
+		assert.ErrorIs(t, err, errSave)
+
+		issued, err := service.IssueInvoice(application.AdminContext("ops"), pending.ID(), issuedIn2026)
+		require.NoError(t, err)
+		assert.Equal(t, "INV-2026-0004", issued.Number())
+	})
+
+	t.Run("sequences restart every year", func(t *testing.T) {
+		issued, err := service.IssueInvoice(application.AdminContext("ops"), draft(t).ID(), time.Date(2027, time.January, 2, 8, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.Equal(t, "INV-2027-0001", issued.Number())
+	})
+
+	t.Run("invoices are unnumbered without numbering", func(t *testing.T) {
+		unnumbered := application.NewBillingService(repository.NewClientRepository(storage)).WithInvoices(invoiceRepo)
+		issued, err := unnumbered.IssueInvoice(application.AdminContext("ops"), draft(t).ID(), issuedIn2026)
+		require.NoError(t, err)
+		assert.Empty(t, issued.Number())
+	})
+}
@@ -0,0 +1,129 @@
+package application
+
+import (
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMagicLinkService(ttl time.Duration) *application.MagicLinkService {
+	storage := infrastructure.NewInMemoryStorage()
+	linkRepo := repository.NewMagicLinkRepository(storage.Collection(repository.MagicLinkCollection))
+	return application.NewMagicLinkService(linkRepo, "link-secret", ttl, "https://billing.example.com/")
+}
+
+func TestMagicLinkService_IssueAndVerify(t *testing.T) {
+	service := newMagicLinkService(time.Hour)
+
+	issued, err := service.Issue(application.MagicLinkPurposePortalSignIn, "client-1", "/portal/sign-in")
+	require.NoError(t, err)
+
+	link, err := url.Parse(issued.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "billing.example.com", link.Host)
+	assert.Equal(t, "/portal/sign-in", link.Path)
+	assert.Equal(t, issued.Token, link.Query().Get("token"))
+
+	verified, err := service.Verify(issued.Token, application.MagicLinkPurposePortalSignIn)
+	require.NoError(t, err)
+	assert.Equal(t, "client-1", verified.Subject())
+	assert.True(t, verified.IsConsumed())
+
+	// Links are single-use
+	_, err = service.Verify(issued.Token, application.MagicLinkPurposePortalSignIn)
+	assert.ErrorIs(t, err, domainErrors.ErrMagicLinkUsed)
+}
+
+func TestMagicLinkService_RejectsTamperedAndMisusedLinks(t *testing.T) {
+	service := newMagicLinkService(time.Hour)
+
+	t.Run("tampered signature", func(t *testing.T) {
+		issued, err := service.Issue(application.MagicLinkPurposePortalSignIn, "client-1", "/portal/sign-in")
+		require.NoError(t, err)
+
+		id, _, _ := strings.Cut(issued.Token, ".")
+		_, err = service.Verify(id+".forged", application.MagicLinkPurposePortalSignIn)
+		assert.ErrorIs(t, err, domainErrors.ErrMagicLinkInvalid)
+
+		// The genuine link was not consumed by the forged attempt
+		_, err = service.Verify(issued.Token, application.MagicLinkPurposePortalSignIn)
+		assert.NoError(t, err)
+	})
+
+	t.Run("wrong purpose", func(t *testing.T) {
+		issued, err := service.Issue(application.MagicLinkPurposePortalSignIn, "client-1", "/portal/sign-in")
+		require.NoError(t, err)
+
+		_, err = service.Verify(issued.Token, "some.other_purpose")
+		assert.ErrorIs(t, err, domainErrors.ErrMagicLinkInvalid)
+	})
+
+	t.Run("password reset link used to sign in", func(t *testing.T) {
+		issued, err := service.Issue(application.MagicLinkPurposePasswordReset, "user-1", "/password-reset")
+		require.NoError(t, err)
+
+		_, err = service.Verify(issued.Token, application.MagicLinkPurposePortalSignIn)
+		assert.ErrorIs(t, err, domainErrors.ErrMagicLinkInvalid)
+
+		verified, err := service.Verify(issued.Token, application.MagicLinkPurposePasswordReset)
+		require.NoError(t, err)
+		assert.Equal(t, "user-1", verified.Subject())
+	})
+
+	t.Run("unknown link", func(t *testing.T) {
+		_, err := service.Verify("unknown.signature", application.MagicLinkPurposePortalSignIn)
+		assert.ErrorIs(t, err, domainErrors.ErrMagicLinkInvalid)
+	})
+
+	t.Run("expired link", func(t *testing.T) {
+		expiring := newMagicLinkService(time.Nanosecond)
+		issued, err := expiring.Issue(application.MagicLinkPurposePortalSignIn, "client-1", "/portal/sign-in")
+		require.NoError(t, err)
+
+		_, err = expiring.Verify(issued.Token, application.MagicLinkPurposePortalSignIn)
+		assert.ErrorIs(t, err, domainErrors.ErrMagicLinkExpired)
+	})
+
+	t.Run("link expired within the clock skew", func(t *testing.T) {
+		skewed := newMagicLinkService(time.Nanosecond).WithClockSkew(time.Minute)
+		issued, err := skewed.Issue(application.MagicLinkPurposePortalSignIn, "client-1", "/portal/sign-in")
+		require.NoError(t, err)
+
+		_, err = skewed.Verify(issued.Token, application.MagicLinkPurposePortalSignIn)
+		assert.NoError(t, err)
+	})
+}
+
+func TestMagicLinkService_ConcurrentVerificationHasSingleWinner(t *testing.T) {
+	service := newMagicLinkService(time.Hour)
+	issued, err := service.Issue(application.MagicLinkPurposePortalSignIn, "client-1", "/portal/sign-in")
+	require.NoError(t, err)
+
+	const attempts = 20
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	successes := 0
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := service.Verify(issued.Token, application.MagicLinkPurposePortalSignIn); err == nil {
+				mu.Lock()
+				successes++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, successes)
+}
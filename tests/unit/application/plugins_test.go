@@ -0,0 +1,114 @@
+package application
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/plugins/volumediscount"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// upperCaseNames stores client names in upper case and refuses names mentioning "test"
+type upperCaseNames struct{}
+
+func (upperCaseNames) Name() string { return "upper_case_names" }
+func (upperCaseNames) BeforeClientSave(subject application.RuleSubject) error {
+	if strings.Contains(strings.ToLower(subject.Client.Name()), "test") {
+		return errors.NewBusinessRuleError("no_test_clients", errors.BusinessRuleViolation, "test clients are not allowed")
+	}
+	return subject.Client.UpdateDetails(strings.ToUpper(subject.Client.Name()), subject.Client.Phone().String(), subject.Client.Address())
+}
+
+// namedOnly implements no hook
+type namedOnly struct{}
+
+func (namedOnly) Name() string { return "named_only" }
+
+func TestBillingService_Plugins(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	plugins, err := application.NewPlugins(upperCaseNames{}, volumediscount.New(100, 1000).WithMinimumTotal(50000))
+	require.NoError(t, err)
+	service := application.NewBillingService(repository.NewClientRepository(storage)).
+		WithInvoices(repository.NewInvoiceRepository(storage.Collection(repository.InvoiceCollection))).
+		WithPlugins(plugins)
+
+	client, err := service.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+	require.NoError(t, err)
+
+	t.Run("client pre-save hooks change and refuse clients", func(t *testing.T) {
+		assert.Equal(t, "ACME CORP", client.Name())
+
+		_, err := service.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Test Corp", Email: "billing@test.example"})
+		assert.Equal(t, errors.BusinessRuleViolation, errors.GetErrorCode(err))
+
+		updated, err := service.UpdateClient(application.AdminContext("ops"), client.ID(), dtos.UpdateClientRequest{Name: "Acme Group"})
+		require.NoError(t, err)
+		assert.Equal(t, "ACME GROUP", updated.Name())
+	})
+
+	t.Run("pricing hooks price the lines of drafts", func(t *testing.T) {
+		invoice, err := service.CreateInvoice(application.AdminContext("ops"), dtos.CreateInvoiceRequest{
+			ClientID: client.ID(),
+			Currency: "EUR",
+			LineItems: []dtos.InvoiceLineRequest{
+				{Description: "Licenses", Quantity: 100, UnitAmount: 999},
+				{Description: "Setup", Quantity: 1, UnitAmount: 20000},
+			},
+		})
+		require.NoError(t, err)
+		lines := invoice.Lines()
+		assert.Equal(t, int64(900), lines[0].UnitAmount, "10% off, rounded down to the cent")
+		assert.Equal(t, int64(20000), lines[1].UnitAmount)
+
+		stored, err := service.GetInvoice(application.AdminContext("ops"), invoice.ID())
+		require.NoError(t, err)
+		assert.Equal(t, int64(900), stored.Lines()[0].UnitAmount)
+
+		updated, err := service.UpdateInvoice(application.AdminContext("ops"), invoice.ID(), dtos.UpdateInvoiceRequest{
+			Currency:  "EUR",
+			LineItems: []dtos.InvoiceLineRequest{{Description: "Licenses", Quantity: 200, UnitAmount: 1000}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(900), updated.Lines()[0].UnitAmount)
+	})
+
+	t.Run("invoice pre-issue hooks refuse issues", func(t *testing.T) {
+		small, err := service.CreateInvoice(application.AdminContext("ops"), dtos.CreateInvoiceRequest{
+			ClientID:  client.ID(),
+			Currency:  "EUR",
+			LineItems: []dtos.InvoiceLineRequest{{Description: "Support", Quantity: 1, UnitAmount: 10000}},
+		})
+		require.NoError(t, err)
+		_, err = service.IssueInvoice(application.AdminContext("ops"), small.ID(), time.Now())
+		assert.Equal(t, errors.BusinessRuleViolation, errors.GetErrorCode(err))
+		stored, err := service.GetInvoice(application.AdminContext("ops"), small.ID())
+		require.NoError(t, err)
+		assert.Equal(t, entity.InvoiceDraft, stored.Status())
+
+		large, err := service.CreateInvoice(application.AdminContext("ops"), dtos.CreateInvoiceRequest{
+			ClientID:  client.ID(),
+			Currency:  "EUR",
+			LineItems: []dtos.InvoiceLineRequest{{Description: "Project", Quantity: 1, UnitAmount: 60000}},
+		})
+		require.NoError(t, err)
+		issued, err := service.IssueInvoice(application.AdminContext("ops"), large.ID(), time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, entity.InvoiceIssued, issued.Status())
+	})
+}
+
+func TestNewPlugins(t *testing.T) {
+	_, err := application.NewPlugins(upperCaseNames{}, upperCaseNames{})
+	assert.ErrorContains(t, err, "already registered")
+
+	_, err = application.NewPlugins(namedOnly{})
+	assert.ErrorContains(t, err, "implements no hook")
+}
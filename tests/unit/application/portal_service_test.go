@@ -0,0 +1,52 @@
+package application
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPortalService_TokenVerification(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage))
+	client, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Alice Martin", Email: "alice@example.com"})
+	require.NoError(t, err)
+
+	t.Run("tokens signed with another secret are rejected", func(t *testing.T) {
+		token, err := application.NewPortalService(billingService, "other-secret", time.Hour).IssueAccessToken(client.ID())
+		require.NoError(t, err)
+
+		_, err = application.NewPortalService(billingService, "portal-secret", time.Hour).Authenticate(token.Token)
+		assert.Error(t, err)
+	})
+
+	t.Run("expired tokens are rejected", func(t *testing.T) {
+		portalService := application.NewPortalService(billingService, "portal-secret", time.Nanosecond)
+		token, err := portalService.IssueAccessToken(client.ID())
+		require.NoError(t, err)
+
+		_, err = portalService.Authenticate(token.Token)
+		assert.ErrorContains(t, err, "expired")
+	})
+
+	t.Run("tokens expired within the clock skew are accepted", func(t *testing.T) {
+		portalService := application.NewPortalService(billingService, "portal-secret", time.Nanosecond).WithClockSkew(time.Minute)
+		token, err := portalService.IssueAccessToken(client.ID())
+		require.NoError(t, err)
+
+		clientID, err := portalService.Authenticate(token.Token)
+		require.NoError(t, err)
+		assert.Equal(t, client.ID(), clientID)
+	})
+
+	t.Run("tokens are only issued for existing clients", func(t *testing.T) {
+		_, err := application.NewPortalService(billingService, "portal-secret", time.Hour).IssueAccessToken("123e4567-e89b-12d3-a456-426614174000")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,131 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+)
+
+// vipOnlyRule refuses clients whose name does not start with "VIP"
+type vipOnlyRule struct{}
+
+func (vipOnlyRule) Name() string        { return "vip_only" }
+func (vipOnlyRule) Description() string { return "Only VIP clients are onboarded" }
+func (vipOnlyRule) Operations() []application.RuleOperation {
+	return []application.RuleOperation{application.RuleCreateClient}
+}
+func (vipOnlyRule) Check(subject application.RuleSubject) error {
+	if len(subject.Client.Name()) < 3 || subject.Client.Name()[:3] != "VIP" {
+		return errors.NewBusinessRuleError("vip_only", errors.BusinessRuleViolation, "only VIP clients are onboarded")
+	}
+	return nil
+}
+
+func TestUniqueClientEmailRule(t *testing.T) {
+	clientRepo := repository.NewClientRepository(infrastructure.NewInMemoryStorage())
+	existing, err := entity.NewClient("Acme Corp", "billing@acme.example", "", "")
+	require.NoError(t, err)
+	require.NoError(t, clientRepo.Save(existing))
+	rule := application.NewUniqueClientEmailRule(clientRepo)
+
+	newClient := func(email string) *entity.Client {
+		client, err := entity.NewClient("Other Corp", email, "", "")
+		require.NoError(t, err)
+		return client
+	}
+
+	t.Run("another client holds the address", func(t *testing.T) {
+		err := rule.Check(application.RuleSubject{Operation: application.RuleCreateClient, Context: application.AdminContext("ops"), Client: newClient("Billing@Acme.example")})
+		require.Error(t, err)
+		assert.Equal(t, errors.BusinessRuleDuplicate, errors.GetErrorCode(err))
+		assert.Equal(t, existing.ID(), err.(*errors.BusinessRuleError).Context["existing_id"])
+	})
+
+	t.Run("holder hidden from callers outside its tenant", func(t *testing.T) {
+		err := rule.Check(application.RuleSubject{Operation: application.RuleCreateClient, Context: application.RequestContext{TenantID: "initech"}, Client: newClient("billing@acme.example")})
+		require.Error(t, err)
+		assert.Equal(t, errors.BusinessRuleDuplicate, errors.GetErrorCode(err))
+		assert.NotContains(t, err.(*errors.BusinessRuleError).Context, "existing_id")
+		assert.NotContains(t, err.(*errors.BusinessRuleError).Context, "existing_url")
+	})
+
+	t.Run("address held in another tenant", func(t *testing.T) {
+		tenantClient := newClient("billing@acme.example")
+		require.NoError(t, tenantClient.AssignExternalReference("initech", ""))
+		assert.NoError(t, rule.Check(application.RuleSubject{Operation: application.RuleCreateClient, Context: application.SystemContext("test", "initech"), Client: tenantClient}))
+		require.NoError(t, clientRepo.Save(tenantClient))
+
+		sameTenant := newClient("Billing@acme.example")
+		require.NoError(t, sameTenant.AssignExternalReference("initech", ""))
+		err := rule.Check(application.RuleSubject{Operation: application.RuleCreateClient, Context: application.SystemContext("test", "initech"), Client: sameTenant})
+		require.Error(t, err)
+		assert.Equal(t, tenantClient.ID(), err.(*errors.BusinessRuleError).Context["existing_id"])
+	})
+
+	t.Run("free address", func(t *testing.T) {
+		assert.NoError(t, rule.Check(application.RuleSubject{Operation: application.RuleCreateClient, Client: newClient("ap@other.example")}))
+	})
+
+	t.Run("client keeping its own address", func(t *testing.T) {
+		assert.NoError(t, rule.Check(application.RuleSubject{Operation: application.RuleUpdateClient, Client: existing}))
+	})
+}
+
+func TestInvoiceableClientRule(t *testing.T) {
+	client, err := entity.NewClient("Acme Corp", "billing@acme.example", "", "")
+	require.NoError(t, err)
+	rule := application.InvoiceableClientRule{}
+
+	assert.NoError(t, rule.Check(application.RuleSubject{Operation: application.RuleCreateInvoice, Client: client}))
+
+	require.NoError(t, client.ChangeStatus(entity.ClientSuspended))
+	assert.Equal(t, errors.ErrClientNotActive, rule.Check(application.RuleSubject{Operation: application.RuleCreateInvoice, Client: client}))
+}
+
+func TestRuleChecker(t *testing.T) {
+	clientRepo := repository.NewClientRepository(infrastructure.NewInMemoryStorage())
+
+	t.Run("rules are listed in the order they are checked", func(t *testing.T) {
+		checker, err := application.NewRuleChecker(append(application.DefaultRules(clientRepo), vipOnlyRule{})...)
+		require.NoError(t, err)
+
+		var names []string
+		for _, rule := range checker.Rules() {
+			names = append(names, rule.Name)
+			assert.NotEmpty(t, rule.Description)
+			assert.NotEmpty(t, rule.Operations)
+		}
+		assert.Equal(t, []string{"email_uniqueness", "client_active", "vip_only"}, names)
+	})
+
+	t.Run("rule names are unique", func(t *testing.T) {
+		_, err := application.NewRuleChecker(vipOnlyRule{}, vipOnlyRule{})
+		assert.Error(t, err)
+	})
+
+	t.Run("services check the registered rules of their operations", func(t *testing.T) {
+		checker, err := application.NewRuleChecker(append(application.DefaultRules(clientRepo), vipOnlyRule{})...)
+		require.NoError(t, err)
+		service := application.NewBillingService(clientRepo).WithRuleChecker(checker)
+
+		_, err = service.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+		require.Error(t, err)
+		assert.Equal(t, "vip_only", err.(*errors.BusinessRuleError).Rule)
+
+		vip, err := service.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "VIP Acme", Email: "billing@acme.example"})
+		require.NoError(t, err)
+
+		// Updates are not checked by the onboarding rule
+		_, err = service.UpdateClient(application.AdminContext("ops"), vip.ID(), dtos.UpdateClientRequest{Name: "Acme Corp"})
+		assert.NoError(t, err)
+		assert.Len(t, service.BusinessRules(), 3)
+	})
+}
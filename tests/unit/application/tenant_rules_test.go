@@ -0,0 +1,102 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+)
+
+func TestBillingService_TenantRules(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	auditService := application.NewAuditService(repository.NewAuditRepository(storage.Collection(repository.AuditCollection)))
+	ruleService := application.NewTenantRuleService(repository.NewTenantRuleSetRepository(storage.Collection(repository.TenantRuleSetCollection)), auditService)
+	clientRepo := repository.NewClientRepository(storage)
+	rules, err := application.NewRuleChecker(append(application.DefaultRules(clientRepo), application.NewTenantExpressionRule(ruleService))...)
+	require.NoError(t, err)
+	service := application.NewBillingService(clientRepo).
+		WithInvoices(repository.NewInvoiceRepository(storage.Collection(repository.InvoiceCollection))).
+		WithRuleChecker(rules)
+
+	_, err = ruleService.SetRules("ops", "acme", dtos.SetTenantRulesRequest{Rules: []dtos.TenantRuleRequest{
+		{Name: "company_domains", Operation: "client.create", Expression: `!client.email.endsWith("@gmail.com")`},
+		{Name: "approval_threshold", Operation: "invoice.create", Expression: `invoice.total <= 100000 || "trusted" in client.tags`, Message: "invoices above 1000.00 need approval"},
+	}})
+	require.NoError(t, err)
+	rc := application.SystemContext("test", "acme")
+
+	t.Run("client rules refuse clients of the tenant", func(t *testing.T) {
+		_, err := service.CreateClient(rc, dtos.CreateClientRequest{Name: "Jane Doe", Email: "jane@gmail.com"})
+		require.Error(t, err)
+		assert.Equal(t, domainErrors.BusinessRuleViolation, domainErrors.GetErrorCode(err))
+		assert.Contains(t, err.Error(), "refused by tenant rule company_domains")
+
+		// Other tenants are not concerned
+		_, err = service.CreateClient(application.SystemContext("test", "globex"), dtos.CreateClientRequest{Name: "Jane Doe", Email: "jane@gmail.com"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("invoice rules refuse invoices of the tenant", func(t *testing.T) {
+		client, err := service.CreateClient(rc, dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+		require.NoError(t, err)
+
+		_, err = service.CreateInvoice(rc, dtos.CreateInvoiceRequest{
+			ClientID:  client.ID(),
+			Currency:  "EUR",
+			LineItems: []dtos.InvoiceLineRequest{{Description: "Project", Quantity: 1, UnitAmount: 150000}},
+		})
+		var violation *domainErrors.BusinessRuleError
+		require.ErrorAs(t, err, &violation)
+		assert.Equal(t, "invoices above 1000.00 need approval", violation.Message)
+		assert.Equal(t, "approval_threshold", violation.Context["tenant_rule"])
+
+		_, err = service.CreateInvoice(rc, dtos.CreateInvoiceRequest{
+			ClientID:  client.ID(),
+			Currency:  "EUR",
+			LineItems: []dtos.InvoiceLineRequest{{Description: "Support", Quantity: 1, UnitAmount: 50000}},
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid rules are refused when set", func(t *testing.T) {
+		tests := []struct {
+			rule  dtos.TenantRuleRequest
+			field string
+		}{
+			{dtos.TenantRuleRequest{Name: "bad_field", Operation: "invoice.create", Expression: "invoice.amount > 0"}, "rules[0].expression"},
+			{dtos.TenantRuleRequest{Name: "not_bool", Operation: "invoice.create", Expression: "invoice.total + 1"}, "rules[0].expression"},
+			{dtos.TenantRuleRequest{Name: "bad_operation", Operation: "invoice.void", Expression: "true"}, "rules[0].operation"},
+			{dtos.TenantRuleRequest{Name: "Bad Name", Operation: "invoice.create", Expression: "true"}, "name"},
+		}
+		for _, tt := range tests {
+			_, err := ruleService.SetRules("ops", "acme", dtos.SetTenantRulesRequest{Rules: []dtos.TenantRuleRequest{tt.rule}})
+			var validation *domainErrors.ValidationError
+			require.ErrorAs(t, err, &validation, tt.rule.Name)
+			assert.Equal(t, tt.field, validation.Field, tt.rule.Name)
+		}
+
+		set, err := ruleService.GetRules("acme")
+		require.NoError(t, err)
+		assert.Len(t, set.Rules(), 2, "the rules in place are kept")
+	})
+
+	t.Run("changes are audited", func(t *testing.T) {
+		require.NoError(t, ruleService.DeleteRules("ops", "acme"))
+		_, err := service.CreateClient(rc, dtos.CreateClientRequest{Name: "John Doe", Email: "john@gmail.com"})
+		assert.NoError(t, err)
+
+		entries, err := auditService.ListEntries("acme")
+		require.NoError(t, err)
+		actions := make([]string, len(entries))
+		for i, entry := range entries {
+			actions[i] = entry.Action()
+		}
+		assert.ElementsMatch(t, []string{application.AuditActionTenantRulesSet, application.AuditActionTenantRulesDeleted}, actions)
+	})
+}
@@ -0,0 +1,174 @@
+package application
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newUsageService creates a usage service with two subscriptions of a client of tenant acme
+func newUsageService(t *testing.T) (*application.UsageService, string, string) {
+	t.Helper()
+
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage)).
+		WithInvoices(repository.NewInvoiceRepository(storage.Collection(repository.InvoiceCollection)))
+	subscriptionService := application.NewSubscriptionService(repository.NewSubscriptionRepository(storage.Collection(repository.SubscriptionCollection)), billingService)
+	usageRepo := repository.NewUsageRecordRepository(storage.Collection(repository.UsageRecordCollection))
+
+	client, err := billingService.CreateClient(acmeAdmin, dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+	require.NoError(t, err)
+	subscriptionIDs := make([]string, 2)
+	for i := range subscriptionIDs {
+		subscription, err := subscriptionService.CreateSubscription(acmeAdmin, dtos.CreateSubscriptionRequest{
+			ClientID: client.ID(), Plan: "Pro", Currency: "EUR", UnitAmount: 4900, Interval: "monthly", StartDate: time.Now().UTC(),
+		})
+		require.NoError(t, err)
+		subscriptionIDs[i] = subscription.ID()
+	}
+	return application.NewUsageService(usageRepo, subscriptionService), subscriptionIDs[0], subscriptionIDs[1]
+}
+
+// acmeAdmin is an admin of tenant acme
+var acmeAdmin = application.RequestContext{Principal: application.Principal{Kind: application.PrincipalAdmin, ID: "ops"}, TenantID: "acme"}
+
+func usageAt(subscriptionID, metric string, quantity int64, at time.Time, key string) dtos.UsageRecordRequest {
+	return dtos.UsageRecordRequest{
+		SubscriptionID: subscriptionID,
+		Metric:         metric,
+		Quantity:       quantity,
+		Timestamp:      &at,
+		IdempotencyKey: key,
+	}
+}
+
+func TestUsageService_IngestBatchIsIdempotent(t *testing.T) {
+	service, sub1, sub2 := newUsageService(t)
+	at := time.Now().UTC().Add(-time.Hour)
+
+	batch := []dtos.UsageRecordRequest{
+		usageAt(sub1, "api_calls", 100, at, "evt-1"),
+		usageAt(sub1, "api_calls", 50, at, "evt-2"),
+		usageAt(sub2, "api_calls", 7, at, "evt-1"), // Same key, other subscription
+	}
+
+	first, err := service.IngestBatch(acmeAdmin, batch)
+	require.NoError(t, err)
+	assert.Equal(t, 3, first.Accepted)
+	assert.Equal(t, 0, first.Duplicates)
+
+	// Producer retries the batch with one new event
+	retry, err := service.IngestBatch(acmeAdmin, append(batch, usageAt(sub1, "api_calls", 25, at, "evt-3")))
+	require.NoError(t, err)
+	assert.Equal(t, 1, retry.Accepted)
+	assert.Equal(t, 3, retry.Duplicates)
+	assert.Equal(t, []string{application.UsageRecordDuplicate, application.UsageRecordDuplicate, application.UsageRecordDuplicate, application.UsageRecordCreated}, retry.Statuses)
+	assert.Equal(t, first.Records[0].ID(), retry.Records[0].ID(), "duplicates report the originally stored record")
+
+	summary, err := service.Summarize(acmeAdmin, sub1, at.Add(-time.Minute), at.Add(time.Minute))
+	require.NoError(t, err)
+	require.Len(t, summary, 1)
+	assert.Equal(t, int64(175), summary[0].Quantity)
+	assert.Equal(t, 3, summary[0].RecordCount)
+}
+
+func TestUsageService_SummarizePerPeriodAndMetric(t *testing.T) {
+	service, sub1, sub2 := newUsageService(t)
+	periodStart := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	periodEnd := time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := service.IngestBatch(acmeAdmin, []dtos.UsageRecordRequest{
+		usageAt(sub1, "storage_gb", 10, periodStart, "a"),                     // First instant is included
+		usageAt(sub1, "api_calls", 300, periodStart.Add(48*time.Hour), "b"),   // Inside
+		usageAt(sub1, "api_calls", 200, periodEnd.Add(-time.Second), "c"),     // Last second is included
+		usageAt(sub1, "api_calls", 999, periodEnd, "d"),                       // Next period
+		usageAt(sub1, "api_calls", 999, periodStart.Add(-time.Second), "e"),   // Previous period
+		usageAt(sub2, "api_calls", 999, periodStart.Add(24*time.Hour), "f"),   // Other subscription
+		usageAt(sub1, "storage_gb", 5, periodStart.Add(10*24*time.Hour), "g"), // Inside
+	})
+	require.NoError(t, err)
+
+	summary, err := service.Summarize(acmeAdmin, sub1, periodStart, periodEnd)
+	require.NoError(t, err)
+	assert.Equal(t, []application.UsageAggregate{
+		{Metric: "api_calls", Quantity: 500, RecordCount: 2},
+		{Metric: "storage_gb", Quantity: 15, RecordCount: 2},
+	}, summary)
+
+	_, err = service.Summarize(acmeAdmin, sub1, periodEnd, periodStart)
+	assert.True(t, domainErrors.IsValidationError(err))
+}
+
+func TestUsageService_RejectsInvalidBatches(t *testing.T) {
+	service, sub1, _ := newUsageService(t)
+	at := time.Now().UTC()
+	future := at.Add(time.Hour)
+
+	testCases := []struct {
+		name  string
+		batch []dtos.UsageRecordRequest
+		field string
+	}{
+		{name: "empty batch", batch: nil, field: "records"},
+		{name: "oversized batch", batch: make([]dtos.UsageRecordRequest, application.MaxUsageBatchSize+1), field: "records"},
+		{name: "missing idempotency key", batch: []dtos.UsageRecordRequest{usageAt(sub1, "api_calls", 1, at, "ok"), usageAt(sub1, "api_calls", 1, at, "")}, field: "records[1].idempotency_key"},
+		{name: "negative quantity", batch: []dtos.UsageRecordRequest{usageAt(sub1, "api_calls", -1, at, "k")}, field: "records[0].quantity"},
+		{name: "future timestamp", batch: []dtos.UsageRecordRequest{usageAt(sub1, "api_calls", 1, future, "k")}, field: "records[0].timestamp"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := service.IngestBatch(acmeAdmin, testCase.batch)
+			require.Error(t, err)
+			validationErr, ok := err.(*domainErrors.ValidationError)
+			require.True(t, ok)
+			assert.Equal(t, testCase.field, validationErr.Field)
+		})
+	}
+
+	// A rejected batch records nothing, not even its valid events
+	summary, err := service.Summarize(acmeAdmin, sub1, at.Add(-time.Hour), at.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, summary)
+}
+
+func TestUsageService_RequiresAnAccessibleSubscription(t *testing.T) {
+	service, sub1, _ := newUsageService(t)
+	globexAdmin := application.RequestContext{Principal: application.Principal{Kind: application.PrincipalAdmin, ID: "globex-ops"}, TenantID: "globex"}
+	at := time.Now().UTC().Add(-time.Hour)
+
+	testCases := []struct {
+		name  string
+		rc    application.RequestContext
+		batch []dtos.UsageRecordRequest
+		field string
+	}{
+		{name: "unknown subscription", rc: acmeAdmin, batch: []dtos.UsageRecordRequest{usageAt(sub1, "api_calls", 1, at, "a"), usageAt("2f1e1a52-8c39-4a2b-9a35-7b1a0f3c2d11", "api_calls", 1, at, "b")}, field: "records[1].subscription_id"},
+		{name: "subscription of another tenant", rc: globexAdmin, batch: []dtos.UsageRecordRequest{usageAt(sub1, "api_calls", 1, at, "a")}, field: "records[0].subscription_id"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := service.IngestBatch(testCase.rc, testCase.batch)
+			validationErr, ok := err.(*domainErrors.ValidationError)
+			require.True(t, ok, "%v", err)
+			assert.Equal(t, testCase.field, validationErr.Field)
+		})
+	}
+
+	_, err := service.IngestBatch(application.RequestContext{TenantID: "acme"}, []dtos.UsageRecordRequest{usageAt(sub1, "api_calls", 1, at, "a")})
+	assert.True(t, domainErrors.IsAuthorizationError(err), "anonymous callers cannot report usage")
+
+	_, err = service.Summarize(globexAdmin, sub1, at.Add(-time.Hour), at.Add(time.Hour))
+	assert.ErrorIs(t, err, domainErrors.ErrSubscriptionNotFound)
+
+	summary, err := service.Summarize(acmeAdmin, sub1, at.Add(-time.Hour), at.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, summary, "rejected batches record nothing")
+}
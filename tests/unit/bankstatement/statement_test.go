@@ -0,0 +1,121 @@
+package bankstatement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/bankstatement"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const camtSample = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.02">
+  <BkToCstmrStmt>
+    <Stmt>
+      <Acct><Id><IBAN>DE89370400440532013000</IBAN></Id></Acct>
+      <Ntry>
+        <Amt Ccy="EUR">1250.50</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <Sts>BOOK</Sts>
+        <BookgDt><Dt>2026-03-02</Dt></BookgDt>
+        <AcctSvcrRef>BANKREF-1</AcctSvcrRef>
+        <NtryDtls><TxDtls>
+          <RltdPties>
+            <Dbtr><Nm>Acme GmbH</Nm></Dbtr>
+            <DbtrAcct><Id><IBAN>DE02120300000000202051</IBAN></Id></DbtrAcct>
+          </RltdPties>
+          <RmtInf><Ustrd>Invoice INV-2026-0042</Ustrd></RmtInf>
+        </TxDtls></NtryDtls>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="EUR">99.00</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts>BOOK</Sts>
+        <BookgDt><Dt>2026-03-02</Dt></BookgDt>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="EUR">10.00</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <Sts>PDNG</Sts>
+        <BookgDt><Dt>2026-03-03</Dt></BookgDt>
+      </Ntry>
+    </Stmt>
+  </BkToCstmrStmt>
+</Document>`
+
+const mt940Sample = `{1:F01BANKDEFFXXXX0000000000}{4:
+:20:STMT0001
+:25:DE89370400440532013000
+:28C:00001/001
+:60F:C260301EUR1000,00
+:61:2603020302CR480,00NTRFNONREF//BANKREF-2
+:86:166?00GUTSCHRIFT?20EREF+NOTPROVIDED?21SVWZ+INV-2026-0043 Thank?22s?31DE02120300000000202051?32Globex
+?33 Corp
+:61:260303DR15,25NMSCNONREF
+:86:Bank fees March
+:62F:C260303EUR1464,75
+-}`
+
+func TestParse_CAMT053(t *testing.T) {
+	statement, err := bankstatement.Parse("", []byte(camtSample))
+	require.NoError(t, err)
+
+	assert.Equal(t, bankstatement.FormatCAMT053, statement.Format)
+	assert.Equal(t, "DE89370400440532013000", statement.AccountIBAN)
+	require.Len(t, statement.Transactions, 2, "pending entries are not booked yet")
+
+	credit := statement.Transactions[0]
+	assert.Equal(t, int64(125050), credit.Amount)
+	assert.Equal(t, "EUR", credit.Currency)
+	assert.Equal(t, "BANKREF-1", credit.BankReference)
+	assert.Equal(t, time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), credit.BookingDate)
+	assert.Equal(t, "Invoice INV-2026-0042", credit.Reference)
+	assert.Equal(t, "Acme GmbH", credit.CounterpartyName)
+	assert.Equal(t, "DE02120300000000202051", credit.CounterpartyIBAN)
+
+	assert.Equal(t, int64(-9900), statement.Transactions[1].Amount)
+}
+
+func TestParse_MT940(t *testing.T) {
+	statement, err := bankstatement.Parse("", []byte(mt940Sample))
+	require.NoError(t, err)
+
+	assert.Equal(t, bankstatement.FormatMT940, statement.Format)
+	assert.Equal(t, "DE89370400440532013000", statement.AccountIBAN)
+	require.Len(t, statement.Transactions, 2)
+
+	credit := statement.Transactions[0]
+	assert.Equal(t, int64(48000), credit.Amount)
+	assert.Equal(t, "EUR", credit.Currency)
+	assert.Equal(t, "BANKREF-2", credit.BankReference)
+	assert.Equal(t, time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), credit.BookingDate)
+	assert.Equal(t, "INV-2026-0043 Thanks", credit.Reference)
+	assert.Equal(t, "Globex Corp", credit.CounterpartyName)
+	assert.Equal(t, "DE02120300000000202051", credit.CounterpartyIBAN)
+
+	fee := statement.Transactions[1]
+	assert.Equal(t, int64(-1525), fee.Amount)
+	assert.Equal(t, "Bank fees March", fee.Reference)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		format bankstatement.Format
+		data   string
+	}{
+		{"malformed XML", bankstatement.FormatCAMT053, "<Document><BkToCstmrStmt>"},
+		{"XML without statement", bankstatement.FormatCAMT053, "<Document></Document>"},
+		{"MT940 without fields", bankstatement.FormatMT940, "not a statement"},
+		{"MT940 line before opening balance", bankstatement.FormatMT940, ":20:X\n:61:260302CR1,00NTRFNONREF\n"},
+		{"too many decimals", bankstatement.FormatMT940, ":60F:C260301EUR0,00\n:61:260302CR1,001NTRFNONREF\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := bankstatement.Parse(tt.format, []byte(tt.data))
+			assert.Error(t, err)
+		})
+	}
+}
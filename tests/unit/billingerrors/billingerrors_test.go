@@ -0,0 +1,96 @@
+package billingerrors
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	domainerrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/pkg/billingerrors"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromResponse_DecodesAPIErrors(t *testing.T) {
+	billingService := application.NewBillingService(repository.NewClientRepository(infrastructure.NewInMemoryStorage()))
+	handler := httpserver.NewServerWithServices(httpserver.Services{Billing: billingService}, httpserver.ServerOptions{
+		AdminTokens: map[string]string{"ops": "ops-token"},
+	}).Handler()
+	call := func(method, path, body string) error {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer ops-token")
+		handler.ServeHTTP(rr, req)
+		return billingerrors.FromResponse(rr.Result())
+	}
+
+	existing, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+	require.NoError(t, err)
+
+	t.Run("successful responses are not errors", func(t *testing.T) {
+		assert.NoError(t, call(http.MethodGet, "/api/v1/clients/"+existing.ID(), ""))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		err := call(http.MethodGet, "/api/v1/clients/7f3e1c2a-0000-4000-8000-000000000000", "")
+		require.Error(t, err)
+		assert.True(t, billingerrors.IsNotFound(err))
+		assert.False(t, billingerrors.IsValidation(err))
+		assert.Equal(t, billingerrors.RepositoryNotFound, billingerrors.CodeOf(err))
+
+		var apiErr *billingerrors.Error
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		err := call(http.MethodPost, "/api/v1/clients", `{"name":"Globex","email":"not-an-email"}`)
+		assert.True(t, billingerrors.IsValidation(err))
+
+		var apiErr *billingerrors.Error
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, "email", apiErr.Field)
+
+		assert.True(t, billingerrors.IsValidation(call(http.MethodPost, "/api/v1/clients", `{`)))
+	})
+
+	t.Run("duplicate", func(t *testing.T) {
+		err := call(http.MethodPost, "/api/v1/clients", `{"name":"Acme","email":"BILLING@acme.example"}`)
+		assert.True(t, billingerrors.IsDuplicate(err))
+		assert.True(t, billingerrors.IsConflict(err))
+		assert.True(t, billingerrors.IsBusinessRule(err))
+		assert.False(t, billingerrors.IsNotFound(err))
+	})
+}
+
+func TestFromResponse_WithoutErrorEnvelope(t *testing.T) {
+	rr := httptest.NewRecorder()
+	http.Error(rr, "upstream unavailable", http.StatusBadGateway)
+
+	err := billingerrors.FromResponse(rr.Result())
+	var apiErr *billingerrors.Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadGateway, apiErr.StatusCode)
+	assert.Equal(t, billingerrors.Code(""), apiErr.Code)
+	assert.Equal(t, "upstream unavailable", apiErr.Message)
+	assert.False(t, billingerrors.IsNotFound(err))
+}
+
+func TestPredicates_MatchDomainErrors(t *testing.T) {
+	wrapped := fmt.Errorf("loading invoice: %w", domainerrors.ErrInvoiceNotFound)
+	assert.True(t, billingerrors.IsNotFound(wrapped))
+	assert.True(t, billingerrors.IsDuplicate(domainerrors.ErrClientEmailExists))
+	assert.True(t, billingerrors.IsConflict(domainerrors.ErrInvoiceNotDraft))
+	assert.True(t, billingerrors.IsValidation(domainerrors.NewValidationError("name", "", domainerrors.ValidationRequired, "name is required")))
+	assert.True(t, billingerrors.IsTemporary(domainerrors.NewRepositoryError("save", domainerrors.RepositoryConnection, "connection refused", nil)))
+
+	assert.Equal(t, billingerrors.Code(""), billingerrors.CodeOf(fmt.Errorf("plain error")))
+	assert.False(t, billingerrors.IsNotFound(nil))
+}
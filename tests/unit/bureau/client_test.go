@@ -0,0 +1,79 @@
+package bureau
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/bureau"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ScoreClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/scores", r.URL.Path)
+		assert.Equal(t, "Bearer bureau-key", r.Header.Get("Authorization"))
+
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "client-1", body["reference"])
+		assert.Equal(t, "billing@acme.example", body["email"])
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"score":62,"decision":"REVIEW","reasons":[{"code":"LATE_PAYMENTS","description":"Two late payments"}]}`))
+	}))
+	defer server.Close()
+
+	client := bureau.NewClient("", server.URL+"/", "bureau-key", server.Client())
+	assert.Equal(t, bureau.DefaultName, client.Name())
+
+	score, err := client.ScoreClient(context.Background(), service.RiskSubject{ClientID: "client-1", Name: "Acme Corp", Email: "billing@acme.example"})
+	require.NoError(t, err)
+
+	assert.Equal(t, 62, score.Score)
+	assert.Equal(t, entity.RiskDecisionReview, score.Decision)
+	assert.Equal(t, []entity.RiskReason{{Code: "LATE_PAYMENTS", Description: "Two late payments"}}, score.Reasons)
+}
+
+func TestClient_UnknownDecisionFallsBackToScoreBands(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"score":80,"decision":"refer"}`))
+	}))
+	defer server.Close()
+
+	score, err := bureau.NewClient("acme-bureau", server.URL, "bureau-key", server.Client()).ScoreClient(context.Background(), service.RiskSubject{ClientID: "client-1"})
+	require.NoError(t, err)
+
+	assert.Empty(t, score.Decision)
+	assert.Equal(t, entity.RiskDecisionDecline, service.DecideRisk(score))
+}
+
+func TestClient_BureauErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{"rejected credentials", http.StatusUnauthorized, `{"error":"invalid api key"}`},
+		{"malformed score", http.StatusOK, `{"score":`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			_, err := bureau.NewClient("", server.URL, "bureau-key", server.Client()).ScoreClient(context.Background(), service.RiskSubject{ClientID: "client-1"})
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,109 @@
+// CDC Relay Unit Tests
+//
+// This file contains unit tests for the change data capture relay.
+// Tests: wal2json normalization, topic routing, at-least-once slot confirmation
+// Scope: Pure unit tests - fake replication source and in-memory publisher
+package cdc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/cdc"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSource serves pending changes until they are advanced past
+type fakeSource struct {
+	pending  []cdc.RawChange
+	advanced []string
+}
+
+func (s *fakeSource) Peek(ctx context.Context, limit int) ([]cdc.RawChange, error) {
+	if len(s.pending) < limit {
+		return s.pending, nil
+	}
+	return s.pending[:limit], nil
+}
+
+func (s *fakeSource) Advance(ctx context.Context, lsn string) error {
+	s.advanced = append(s.advanced, lsn)
+	for i, change := range s.pending {
+		if change.LSN == lsn {
+			s.pending = s.pending[i+1:]
+			break
+		}
+	}
+	return nil
+}
+
+// failingPublisher simulates a message bus outage
+type failingPublisher struct{}
+
+func (failingPublisher) Publish(ctx context.Context, messages ...messaging.Message) error {
+	return errors.New("broker unavailable")
+}
+
+func walChanges() []cdc.RawChange {
+	return []cdc.RawChange{
+		{LSN: "0/1A", XID: "701", Data: `{"action":"B"}`},
+		{LSN: "0/1B", XID: "701", Data: `{"action":"I","schema":"billing","table":"storage_records","columns":[{"name":"key","type":"character varying(255)","value":"c-1"},{"name":"value","type":"text","value":"{\"id\":\"c-1\",\"name\":\"Alice\"}"}]}`},
+		{LSN: "0/1C", XID: "702", Data: `{"action":"D","schema":"billing","table":"storage_records","identity":[{"name":"key","type":"character varying(255)","value":"c-2"}]}`},
+		{LSN: "0/1D", XID: "703", Data: `{"action":"U","schema":"billing","table":"audit_log_records","columns":[{"name":"key","type":"character varying(255)","value":"a-1"},{"name":"value","type":"text","value":"not json"}]}`},
+	}
+}
+
+func TestRelay_RelayOnce_PublishesNormalizedEvents(t *testing.T) {
+	// Arrange
+	source := &fakeSource{pending: walChanges()}
+	publisher := messaging.NewMemoryPublisher()
+	relay := cdc.NewRelay(cdc.Config{
+		TableEntities: map[string]string{"storage_records": "clients"},
+		TopicPrefix:   "billing.cdc",
+		BatchSize:     10,
+	}, source, publisher)
+
+	// Act
+	published, err := relay.RelayOnce(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 3, published, "transaction markers are not republished")
+	assert.Equal(t, []string{"0/1D"}, source.advanced)
+
+	messages := publisher.Messages()
+	require.Len(t, messages, 3)
+
+	var insert cdc.ChangeEvent
+	require.NoError(t, json.Unmarshal(messages[0].Payload, &insert))
+	assert.Equal(t, "billing.cdc.clients", messages[0].Topic)
+	assert.Equal(t, "c-1", messages[0].Key)
+	assert.Equal(t, cdc.OperationInsert, insert.Operation)
+	assert.JSONEq(t, `{"id":"c-1","name":"Alice"}`, string(insert.Data), "stored aggregate JSON is republished as-is")
+
+	var deletion cdc.ChangeEvent
+	require.NoError(t, json.Unmarshal(messages[1].Payload, &deletion))
+	assert.Equal(t, cdc.OperationDelete, deletion.Operation)
+	assert.Equal(t, "c-2", deletion.Key)
+	assert.Empty(t, deletion.Data)
+
+	var update cdc.ChangeEvent
+	require.NoError(t, json.Unmarshal(messages[2].Payload, &update))
+	assert.Equal(t, "billing.cdc.audit_log_records", messages[2].Topic, "unmapped tables use the table name")
+	assert.JSONEq(t, `{"key":"a-1","value":"not json"}`, string(update.Data))
+}
+
+func TestRelay_RelayOnce_DoesNotConfirmUnpublishedChanges(t *testing.T) {
+	source := &fakeSource{pending: walChanges()}
+	relay := cdc.NewRelay(cdc.Config{BatchSize: 10}, source, failingPublisher{})
+
+	_, err := relay.RelayOnce(context.Background())
+
+	assert.Error(t, err)
+	assert.Empty(t, source.advanced, "slot must not advance past unpublished changes")
+	assert.Len(t, source.pending, 4)
+}
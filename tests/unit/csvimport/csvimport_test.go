@@ -0,0 +1,75 @@
+package csvimport
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/csvimport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReader_DetectsDelimiter(t *testing.T) {
+	testCases := []struct {
+		name      string
+		file      string
+		delimiter rune
+	}{
+		{name: "comma", file: "Name,Email\nAcme,billing@acme.example\n", delimiter: ','},
+		{name: "semicolon", file: "Name;Email;\"Address, full\"\nAcme;billing@acme.example;\"1 Main St, Springfield\"\n", delimiter: ';'},
+		{name: "tab", file: "Name\tEmail\nAcme\tbilling@acme.example\n", delimiter: '\t'},
+		{name: "single column", file: "Name\nAcme\n", delimiter: ','},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			reader, err := csvimport.NewReader(strings.NewReader(testCase.file))
+			require.NoError(t, err)
+			assert.Equal(t, testCase.delimiter, reader.Delimiter())
+			row, err := reader.Next()
+			require.NoError(t, err)
+			assert.Equal(t, "Acme", row.Values[0])
+		})
+	}
+}
+
+func TestReader_StreamsRows(t *testing.T) {
+	file := "\xEF\xBB\xBF Name , Email ,Phone\r\n" +
+		"Acme, billing@acme.example ,+33612345678\r\n" +
+		"\r\n" +
+		"Globex,accounts@globex.example\r\n" +
+		"Initech,ap@initech.example,+1555,extra\r\n" +
+		"\"Umbrella, Inc\",\"ar@umbrella.example\"\r\n"
+	reader, err := csvimport.NewReader(strings.NewReader(file))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Name", "Email", "Phone"}, reader.Columns())
+
+	row, err := reader.Next()
+	require.NoError(t, err)
+	assert.Equal(t, csvimport.Row{Line: 2, Values: []string{"Acme", "billing@acme.example", "+33612345678"}}, row)
+
+	// Blank lines are skipped, short rows are padded
+	row, err = reader.Next()
+	require.NoError(t, err)
+	assert.Equal(t, csvimport.Row{Line: 4, Values: []string{"Globex", "accounts@globex.example", ""}}, row)
+
+	// Long rows are reported with their line, and reading goes on
+	row, err = reader.Next()
+	assert.Error(t, err)
+	assert.Equal(t, 5, row.Line)
+
+	row, err = reader.Next()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Umbrella, Inc", "ar@umbrella.example", ""}, row.Values)
+
+	_, err = reader.Next()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestReader_RejectsInvalidHeaders(t *testing.T) {
+	for _, file := range []string{"", "Name,,Email\n", "Email,Name,email ,Email\n"} {
+		_, err := csvimport.NewReader(strings.NewReader(file))
+		assert.Error(t, err, "%q", file)
+	}
+}
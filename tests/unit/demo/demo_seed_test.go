@@ -0,0 +1,59 @@
+package demo
+
+import (
+	"testing"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/di"
+	"github.com/gjaminon-go-labs/billing-api/internal/factory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientFactory_IsDeterministicAndValid(t *testing.T) {
+	first := factory.NewClientFactory(7)
+	second := factory.NewClientFactory(7)
+
+	emails := make(map[string]bool)
+	for i := 0; i < 50; i++ {
+		attributes := first.Next()
+		assert.Equal(t, attributes, second.Next())
+
+		assert.False(t, emails[attributes.Email], "emails must be unique")
+		emails[attributes.Email] = true
+	}
+
+	client, err := factory.NewClientFactory(7).Build()
+	require.NoError(t, err)
+	assert.NotEmpty(t, client.ID())
+}
+
+func TestContainer_SeedsDemoData(t *testing.T) {
+	// Arrange
+	config := di.UnitTestConfig()
+	config.DemoSeedEnabled = true
+	config.DemoClients = 12
+	config.DemoRandomSeed = 42
+	container := di.NewContainer(config)
+
+	// Act
+	billingService, err := container.GetBillingService()
+	require.NoError(t, err)
+
+	// Assert
+	clients, err := billingService.ListClients(application.AdminContext("ops"))
+	require.NoError(t, err)
+	assert.Len(t, clients, 12)
+
+	// Seeded clients show up in the sync feed like any API-created client
+	page, err := billingService.ListClientChanges(application.AdminContext("ops"), "", 100)
+	require.NoError(t, err)
+	assert.Len(t, page.Changes, 12)
+}
+
+func TestContainer_DemoDataRequiresMemoryStorage(t *testing.T) {
+	err := di.DemoDataProvider(nil, &di.ContainerConfig{StorageType: "postgres", DemoSeedEnabled: true})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "memory storage")
+}
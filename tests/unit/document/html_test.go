@@ -0,0 +1,115 @@
+package document
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/document"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func eur(t *testing.T, amount int64) valueobject.Money {
+	t.Helper()
+	money, err := valueobject.NewMoney(amount, "EUR")
+	require.NoError(t, err)
+	return money
+}
+
+func TestRenderHTML(t *testing.T) {
+	account, err := valueobject.NewBankAccount("Acme SAS", "FR1420041010050500013M02606", "", "")
+	require.NoError(t, err)
+
+	invoice := document.Invoice{
+		Number:      "FR-2026-000042",
+		IssueDate:   time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC),
+		Seller:      document.Party{Name: "Acme SAS", TaxIDs: []string{"FR40303265045"}},
+		Buyer:       document.Party{Name: "Globex <Holdings>"},
+		BankAccount: account,
+		Lines: []document.Line{
+			{Description: "Consulting", Quantity: 3, UnitPrice: eur(t, 50000), TaxRateBps: 2000, TaxAmount: eur(t, 30000), Amount: eur(t, 150000)},
+		},
+		Subtotal: eur(t, 150000),
+		Tax:      eur(t, 30000),
+		Total:    eur(t, 180000),
+	}
+
+	t.Run("renders the columns and colors of the template", func(t *testing.T) {
+		layout, err := entity.NewDocumentTemplate("acme", "https://cdn.acme.example/logo.png", "#123456", "#ABCDEF",
+			[]entity.InvoiceColumn{entity.InvoiceColumnDescription, entity.InvoiceColumnTaxRate, entity.InvoiceColumnAmount}, "Capital 10 000 EUR")
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		require.NoError(t, document.RenderHTML(&buf, layout, invoice))
+		html := buf.String()
+
+		assert.Contains(t, html, "Invoice FR-2026-000042")
+		assert.Contains(t, html, `src="https://cdn.acme.example/logo.png"`)
+		assert.Contains(t, html, "color: #123456")
+		assert.Contains(t, html, "color: #ABCDEF")
+		assert.Contains(t, html, "<th>Description</th><th>Tax rate</th><th>Amount</th>")
+		assert.Contains(t, html, "<td>Consulting</td><td>20%</td><td>€1,500.00</td>")
+		assert.NotContains(t, html, "<th>Qty</th>")
+		assert.Contains(t, html, "IBAN FR1420041010050500013M02606")
+		assert.Contains(t, html, "<footer>Capital 10 000 EUR</footer>")
+	})
+
+	t.Run("renders labels, amounts and dates in the invoice locale", func(t *testing.T) {
+		localized := invoice
+		localized.Locale, err = valueobject.NewLocale("de-DE")
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		require.NoError(t, document.RenderHTML(&buf, entity.DefaultDocumentTemplateFor("acme"), localized))
+		html := buf.String()
+
+		assert.Contains(t, html, `<html lang="de-DE">`)
+		assert.Contains(t, html, "Rechnung FR-2026-000042")
+		assert.Contains(t, html, "1. März 2026")
+		assert.Contains(t, html, "<td>Consulting</td><td>3</td><td>500,00\u00a0€</td><td>20\u00a0%</td><td>1.500,00\u00a0€</td>")
+	})
+
+	t.Run("escapes invoice content", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, document.RenderHTML(&buf, entity.DefaultDocumentTemplateFor("acme"), invoice))
+
+		assert.Contains(t, buf.String(), "Globex &lt;Holdings&gt;")
+		assert.NotContains(t, buf.String(), "<footer>")
+		assert.NotContains(t, buf.String(), `class="logo"`)
+	})
+
+	t.Run("prints the tax breakdown and legal mentions required by compliance rules", func(t *testing.T) {
+		compliant := invoice
+		compliant.Lines = append([]document.Line{
+			{Description: "Books", Quantity: 2, UnitPrice: eur(t, 2000), TaxRateBps: 550, TaxAmount: eur(t, 220), Amount: eur(t, 4000)},
+		}, invoice.Lines...)
+		compliant.TaxBreakdown = service.TaxBreakdownPerRate
+		compliant.LegalMentions = []string{"Indemnité forfaitaire pour frais de recouvrement : 40 €"}
+
+		var buf bytes.Buffer
+		require.NoError(t, document.RenderHTML(&buf, entity.DefaultDocumentTemplateFor("acme"), compliant))
+		html := buf.String()
+
+		assert.Contains(t, html, `<table class="tax-breakdown">`)
+		assert.Contains(t, html, "<tr><td>5.5%</td><td>€40.00</td><td>€2.20</td></tr><tr><td>20%</td><td>€1,500.00</td><td>€300.00</td></tr>")
+		assert.Contains(t, html, `<section class="legal"><p>Indemnité forfaitaire pour frais de recouvrement : 40 €</p></section>`)
+	})
+
+	t.Run("shows the tax columns when a breakdown per line is required", func(t *testing.T) {
+		layout, err := entity.NewDocumentTemplate("acme", "", "", "",
+			[]entity.InvoiceColumn{entity.InvoiceColumnDescription, entity.InvoiceColumnAmount}, "")
+		require.NoError(t, err)
+		perLine := invoice
+		perLine.TaxBreakdown = service.TaxBreakdownPerLine
+
+		var buf bytes.Buffer
+		require.NoError(t, document.RenderHTML(&buf, layout, perLine))
+
+		assert.Contains(t, buf.String(), "<th>Description</th><th>Amount</th><th>Tax rate</th><th>Tax</th>")
+		assert.NotContains(t, buf.String(), "tax-breakdown")
+	})
+}
@@ -0,0 +1,108 @@
+package document
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/document"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func localizerFor(t *testing.T, tag string) *document.Localizer {
+	t.Helper()
+	locale, err := valueobject.NewLocale(tag)
+	require.NoError(t, err)
+	return document.NewLocalizer(locale)
+}
+
+func TestLocalizer(t *testing.T) {
+	amount := eur(t, 123456789)
+	issued := time.Date(2026, time.March, 5, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		locale  string
+		money   string
+		percent string
+		date    string
+		label   string
+	}{
+		{locale: "en", money: "€1,234,567.89", percent: "5.5%", date: "March 5, 2026", label: "Invoice"},
+		{locale: "en-GB", money: "€1,234,567.89", percent: "5.5%", date: "5 March 2026", label: "Invoice"},
+		{locale: "fr-FR", money: "1 234 567,89 €", percent: "5,5 %", date: "5 mars 2026", label: "Facture"},
+		{locale: "fr-CH", money: "1’234’567.89 €", percent: "5.5 %", date: "5 mars 2026", label: "Facture"},
+		{locale: "de-DE", money: "1.234.567,89 €", percent: "5,5 %", date: "5. März 2026", label: "Rechnung"},
+		{locale: "de-AT", money: "1.234.567,89 €", percent: "5,5 %", date: "5. März 2026", label: "Rechnung"},
+		{locale: "de-CH", money: "1’234’567.89 €", percent: "5.5 %", date: "5. März 2026", label: "Rechnung"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			localizer := localizerFor(t, tt.locale)
+
+			assert.Equal(t, tt.money, localizer.Money(amount))
+			assert.Equal(t, tt.percent, localizer.Percent(550))
+			assert.Equal(t, tt.date, localizer.Date(issued))
+			assert.Equal(t, tt.label, localizer.Label("invoice"))
+		})
+	}
+
+	t.Run("uses regional month names", func(t *testing.T) {
+		january := time.Date(2026, time.January, 15, 0, 0, 0, 0, time.UTC)
+		assert.Equal(t, "15. Jänner 2026", localizerFor(t, "de-AT").Date(january))
+		assert.Equal(t, "15. Januar 2026", localizerFor(t, "de-DE").Date(january))
+	})
+
+	t.Run("formats currencies without a symbol with their code", func(t *testing.T) {
+		chf, err := valueobject.NewMoney(-150050, "CHF")
+		require.NoError(t, err)
+
+		assert.Equal(t, "-CHF 1,500.50", localizerFor(t, "en").Money(chf))
+		assert.Equal(t, "-1.500,50 CHF", localizerFor(t, "de").Money(chf))
+	})
+
+	t.Run("formats currencies without minor units", func(t *testing.T) {
+		yen, err := valueobject.NewMoney(1500, "JPY")
+		require.NoError(t, err)
+
+		assert.Equal(t, "¥1,500", localizerFor(t, "en").Money(yen))
+	})
+
+	t.Run("drops trailing zero decimals of rates", func(t *testing.T) {
+		localizer := localizerFor(t, "fr")
+
+		assert.Equal(t, "20 %", localizer.Percent(2000))
+		assert.Equal(t, "2,1 %", localizer.Percent(210))
+		assert.Equal(t, "0,05 %", localizer.Percent(5))
+	})
+
+	t.Run("groups quantities", func(t *testing.T) {
+		assert.Equal(t, "12,500", localizerFor(t, "en").Quantity(12500))
+		assert.Equal(t, "12.500", localizerFor(t, "de").Quantity(12500))
+		assert.Equal(t, "250", localizerFor(t, "fr").Quantity(250))
+	})
+
+	t.Run("falls back to English for unsupported languages", func(t *testing.T) {
+		localizer := localizerFor(t, "es-ES")
+
+		assert.Equal(t, "en-ES", localizer.Locale().String())
+		assert.Equal(t, "Invoice", localizer.Label("invoice"))
+		assert.Equal(t, "€1,234,567.89", localizer.Money(amount))
+	})
+
+	t.Run("falls back to the key for unknown labels", func(t *testing.T) {
+		assert.Equal(t, "purchase_order", localizerFor(t, "de").Label("purchase_order"))
+	})
+
+	t.Run("uses English for the zero locale", func(t *testing.T) {
+		localizer := document.NewLocalizer(valueobject.Locale{})
+
+		assert.Equal(t, "en", localizer.Locale().String())
+		assert.Equal(t, "March 5, 2026", localizer.Date(issued))
+	})
+
+	t.Run("ships bundles for en, fr and de", func(t *testing.T) {
+		assert.ElementsMatch(t, []string{"en", "fr", "de"}, document.SupportedLanguages())
+	})
+}
@@ -0,0 +1,57 @@
+// IP Access Policy Domain Unit Tests
+//
+// This file contains unit tests for tenant IP access policy evaluation.
+// Tests: Rule parsing and allow/deny evaluation per route prefix
+// Scope: Pure unit tests - single component (IPAccessPolicy entity) with no external dependencies
+package accesspolicy
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIPAccessPolicy_Permits(t *testing.T) {
+	adminRule, err := entity.NewIPAccessRule("/api/v1/admin", []string{"10.0.0.0/8", "192.168.1.10"}, []string{"10.0.0.13"})
+	require.NoError(t, err)
+	globalDeny, err := entity.NewIPAccessRule("", nil, []string{"203.0.113.0/24"})
+	require.NoError(t, err)
+
+	policy, err := entity.NewIPAccessPolicy("acme", []entity.IPAccessRule{adminRule, globalDeny})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		description string
+		path        string
+		addr        string
+		expected    bool
+	}{
+		{description: "allowed CIDR on restricted route", path: "/api/v1/admin/ip-access-policies", addr: "10.1.2.3", expected: true},
+		{description: "allowed single IP on restricted route", path: "/api/v1/admin/audit-log", addr: "192.168.1.10", expected: true},
+		{description: "address outside allow list", path: "/api/v1/admin/audit-log", addr: "192.168.1.11", expected: false},
+		{description: "deny wins over allow", path: "/api/v1/admin/audit-log", addr: "10.0.0.13", expected: false},
+		{description: "unrestricted route accepts any address", path: "/api/v1/clients", addr: "192.168.1.11", expected: true},
+		{description: "global deny applies to every route", path: "/api/v1/clients", addr: "203.0.113.7", expected: false},
+		{description: "IPv4-mapped IPv6 is matched as IPv4", path: "/api/v1/admin", addr: "::ffff:10.0.0.1", expected: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, policy.Permits(testCase.path, netip.MustParseAddr(testCase.addr)))
+		})
+	}
+}
+
+func TestNewIPAccessRule_Validation(t *testing.T) {
+	_, err := entity.NewIPAccessRule("/api", []string{"not-an-ip"}, nil)
+	assert.Error(t, err)
+
+	_, err = entity.NewIPAccessRule("api", []string{"10.0.0.0/8"}, nil)
+	assert.Error(t, err)
+
+	_, err = entity.NewIPAccessRule("/api", nil, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,121 @@
+// Approval Request Domain Unit Tests
+//
+// This file contains unit tests for the two-step approval of credit notes and refunds.
+// Tests: Validation, threshold handling, four-eyes approval, JSON round-trip
+// Scope: Pure unit tests - single component (ApprovalRequest entity) with no external dependencies
+package approval
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func eur(t *testing.T, amount int64) valueobject.Money {
+	t.Helper()
+	money, err := valueobject.NewMoney(amount, "EUR")
+	require.NoError(t, err)
+	return money
+}
+
+func TestNewApprovalRequest_Threshold(t *testing.T) {
+	t.Run("above the threshold the request is pending", func(t *testing.T) {
+		request, err := entity.NewApprovalRequest(entity.ApprovalKindRefund, "client-1", "pay-1", eur(t, 100001), "duplicate charge", "alice", 100000)
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.ApprovalStatusPending, request.Status())
+		assert.True(t, request.IsPending())
+		assert.Nil(t, request.ApprovedAt())
+	})
+
+	t.Run("at the threshold the request is approved immediately", func(t *testing.T) {
+		request, err := entity.NewApprovalRequest(entity.ApprovalKindCreditNote, "client-1", "inv-1", eur(t, 100000), "", "alice", 100000)
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.ApprovalStatusApproved, request.Status())
+		assert.Empty(t, request.ApprovedBy())
+		assert.NotNil(t, request.ApprovedAt())
+	})
+}
+
+func TestNewApprovalRequest_Validation(t *testing.T) {
+	tests := []struct {
+		name        string
+		kind        entity.ApprovalKind
+		clientID    string
+		referenceID string
+		amount      int64
+		requestedBy string
+		field       string
+	}{
+		{"unknown kind", "chargeback", "client-1", "inv-1", 100, "alice", "kind"},
+		{"missing client", entity.ApprovalKindCreditNote, " ", "inv-1", 100, "alice", "client_id"},
+		{"missing reference", entity.ApprovalKindCreditNote, "client-1", "", 100, "alice", "reference_id"},
+		{"zero amount", entity.ApprovalKindRefund, "client-1", "pay-1", 0, "alice", "amount"},
+		{"missing requester", entity.ApprovalKindRefund, "client-1", "pay-1", 100, "", "requested_by"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := entity.NewApprovalRequest(tt.kind, tt.clientID, tt.referenceID, eur(t, tt.amount), "", tt.requestedBy, 0)
+
+			require.Error(t, err)
+			var validationErr *domainErrors.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.field, validationErr.Field)
+		})
+	}
+}
+
+func TestApprovalRequest_Approve(t *testing.T) {
+	t.Run("a second user approves", func(t *testing.T) {
+		request, err := entity.NewApprovalRequest(entity.ApprovalKindRefund, "client-1", "pay-1", eur(t, 250000), "", "alice", 100000)
+		require.NoError(t, err)
+
+		require.NoError(t, request.Approve("bob"))
+		assert.Equal(t, entity.ApprovalStatusApproved, request.Status())
+		assert.Equal(t, "bob", request.ApprovedBy())
+		assert.NotNil(t, request.ApprovedAt())
+	})
+
+	t.Run("the requester cannot approve their own request", func(t *testing.T) {
+		request, err := entity.NewApprovalRequest(entity.ApprovalKindRefund, "client-1", "pay-1", eur(t, 250000), "", "alice", 100000)
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, request.Approve("alice"), domainErrors.ErrSelfApproval)
+		assert.True(t, request.IsPending())
+	})
+
+	t.Run("only pending requests can be approved", func(t *testing.T) {
+		request, err := entity.NewApprovalRequest(entity.ApprovalKindCreditNote, "client-1", "inv-1", eur(t, 500), "", "alice", 100000)
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, request.Approve("bob"), domainErrors.ErrApprovalNotPending)
+	})
+}
+
+func TestApprovalRequest_JSONRoundTrip(t *testing.T) {
+	request, err := entity.NewApprovalRequest(entity.ApprovalKindCreditNote, "client-1", "inv-1", eur(t, 250000), "service outage", "alice", 100000)
+	require.NoError(t, err)
+	require.NoError(t, request.Approve("bob"))
+
+	data, err := json.Marshal(request)
+	require.NoError(t, err)
+
+	var restored entity.ApprovalRequest
+	require.NoError(t, json.Unmarshal(data, &restored))
+
+	assert.Equal(t, request.ID(), restored.ID())
+	assert.Equal(t, request.Kind(), restored.Kind())
+	assert.Equal(t, request.ReferenceID(), restored.ReferenceID())
+	assert.True(t, request.Amount().Equals(restored.Amount()))
+	assert.Equal(t, "service outage", restored.Reason())
+	assert.Equal(t, entity.ApprovalStatusApproved, restored.Status())
+	assert.Equal(t, "bob", restored.ApprovedBy())
+	assert.True(t, request.ApprovedAt().Equal(*restored.ApprovedAt()))
+}
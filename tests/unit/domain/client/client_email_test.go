@@ -13,13 +13,10 @@
 package client
 
 import (
-	"encoding/json"
-	"os"
-	"path/filepath"
-	"runtime"
 	"testing"
 
 	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/tests/testdata"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -54,21 +51,5 @@ func TestClient_RequiresValidEmail(t *testing.T) {
 }
 
 func loadClientTestCases(t *testing.T) []ClientTestCase {
-	// Get current file directory
-	_, currentFile, _, ok := runtime.Caller(0)
-	assert.True(t, ok, "Failed to get current file path")
-
-	// Build path to shared test data at tests root
-	testDataPath := filepath.Join(filepath.Dir(currentFile), "..", "..", "..", "testdata", "client", "client_test_cases.json")
-
-	// Read test data file
-	data, err := os.ReadFile(testDataPath)
-	assert.NoError(t, err, "Failed to read test data file")
-
-	// Parse JSON
-	var testCases []ClientTestCase
-	err = json.Unmarshal(data, &testCases)
-	assert.NoError(t, err, "Failed to parse test data JSON")
-
-	return testCases
+	return testdata.Load[[]ClientTestCase](t, "client/client_test_cases.json")
 }
@@ -0,0 +1,83 @@
+// Client Status Domain Unit Tests
+//
+// This file contains unit tests for the client lifecycle (active, suspended, closed).
+// Tests: Allowed and refused transitions, invoicing by status, JSON round-trip of stored clients
+// Scope: Pure unit tests - single component (Client entity) with no external dependencies
+package client
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newActiveClient(t *testing.T) *entity.Client {
+	t.Helper()
+	client, err := entity.NewClient("Acme Corp", "billing@acme.example", "", "")
+	require.NoError(t, err)
+	return client
+}
+
+func TestClient_ChangeStatus(t *testing.T) {
+	t.Run("new clients are active and can be invoiced", func(t *testing.T) {
+		client := newActiveClient(t)
+		assert.Equal(t, entity.ClientActive, client.Status())
+		assert.True(t, client.CanBeInvoiced())
+	})
+
+	t.Run("suspended clients cannot be invoiced until reactivated", func(t *testing.T) {
+		client := newActiveClient(t)
+		require.NoError(t, client.ChangeStatus(entity.ClientSuspended))
+		assert.False(t, client.CanBeInvoiced())
+
+		require.NoError(t, client.ChangeStatus(entity.ClientActive))
+		assert.True(t, client.CanBeInvoiced())
+	})
+
+	t.Run("closing is final", func(t *testing.T) {
+		client := newActiveClient(t)
+		require.NoError(t, client.ChangeStatus(entity.ClientClosed))
+		assert.False(t, client.CanBeInvoiced())
+
+		for _, status := range []entity.ClientStatus{entity.ClientActive, entity.ClientSuspended, entity.ClientClosed} {
+			err := client.ChangeStatus(status)
+			require.Error(t, err, status)
+			assert.Equal(t, domainErrors.BusinessRuleConflict, domainErrors.GetErrorCode(err))
+		}
+		assert.Equal(t, entity.ClientClosed, client.Status())
+	})
+
+	t.Run("a client already in the status is refused", func(t *testing.T) {
+		client := newActiveClient(t)
+		err := client.ChangeStatus(entity.ClientActive)
+		require.Error(t, err)
+		assert.Equal(t, domainErrors.BusinessRuleConflict, domainErrors.GetErrorCode(err))
+	})
+
+	t.Run("unknown statuses are rejected", func(t *testing.T) {
+		client := newActiveClient(t)
+		err := client.ChangeStatus("archived")
+		require.Error(t, err)
+		assert.True(t, domainErrors.IsValidationError(err))
+	})
+}
+
+func TestClient_StatusJSON(t *testing.T) {
+	client := newActiveClient(t)
+	require.NoError(t, client.ChangeStatus(entity.ClientSuspended))
+
+	data, err := json.Marshal(client)
+	require.NoError(t, err)
+	var restored entity.Client
+	require.NoError(t, json.Unmarshal(data, &restored))
+	assert.Equal(t, entity.ClientSuspended, restored.Status())
+
+	// Clients stored before statuses existed are active
+	var legacy entity.Client
+	require.NoError(t, json.Unmarshal([]byte(`{"id":"legacy","name":"Legacy","email":{"value":"legacy@example.com"}}`), &legacy))
+	assert.Equal(t, entity.ClientActive, legacy.Status())
+}
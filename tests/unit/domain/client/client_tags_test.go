@@ -0,0 +1,72 @@
+// Client Tags Domain Unit Tests
+//
+// This file contains unit tests for the free-form tags segmenting the client base.
+// Tests: Normalization, duplicates, limits, removal, JSON round-trip of stored clients
+// Scope: Pure unit tests - single component (Client entity) with no external dependencies
+package client
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Tags(t *testing.T) {
+	t.Run("tags are normalized, deduplicated and sorted", func(t *testing.T) {
+		client := newActiveClient(t)
+		require.NoError(t, client.AddTags(" Key-Account ", "enterprise", "KEY-ACCOUNT"))
+		assert.Equal(t, []string{"enterprise", "key-account"}, client.Tags())
+		assert.True(t, client.HasTag("Enterprise"))
+
+		require.NoError(t, client.AddTags("Enterprise"))
+		assert.Len(t, client.Tags(), 2)
+	})
+
+	t.Run("invalid tags are rejected without tagging the client", func(t *testing.T) {
+		for _, tag := range []string{"", "  ", "emea/north", strings.Repeat("x", entity.MaxClientTagLength+1)} {
+			client := newActiveClient(t)
+			err := client.AddTags("vip", tag)
+			require.Error(t, err, tag)
+			assert.True(t, domainErrors.IsValidationError(err), tag)
+			assert.Empty(t, client.Tags(), tag)
+		}
+
+		client := newActiveClient(t)
+		assert.Error(t, client.AddTags())
+	})
+
+	t.Run("clients carry a bounded number of tags", func(t *testing.T) {
+		client := newActiveClient(t)
+		tags := make([]string, entity.MaxClientTags)
+		for i := range tags {
+			tags[i] = "segment-" + string(rune('a'+i))
+		}
+		require.NoError(t, client.AddTags(tags...))
+		assert.Error(t, client.AddTags("one-too-many"))
+		assert.NoError(t, client.AddTags("segment-a"), "tags already carried do not count")
+	})
+
+	t.Run("tags are removed whatever their case", func(t *testing.T) {
+		client := newActiveClient(t)
+		require.NoError(t, client.AddTags("vip", "emea"))
+		assert.True(t, client.RemoveTag("VIP"))
+		assert.False(t, client.RemoveTag("vip"))
+		assert.Equal(t, []string{"emea"}, client.Tags())
+	})
+
+	t.Run("tags survive a JSON round-trip", func(t *testing.T) {
+		client := newActiveClient(t)
+		require.NoError(t, client.AddTags("vip"))
+		data, err := json.Marshal(client)
+		require.NoError(t, err)
+
+		var stored entity.Client
+		require.NoError(t, json.Unmarshal(data, &stored))
+		assert.Equal(t, []string{"vip"}, stored.Tags())
+	})
+}
@@ -0,0 +1,157 @@
+// Contract Domain Unit Tests
+//
+// This file contains unit tests for customer contracts with committed spend.
+// Tests: Term validation, subscription and invoice linking, attainment, renewal reminder window
+// Scope: Pure unit tests - single component (Contract entity) with no external dependencies
+package contract
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func eur(t *testing.T, amount int64) valueobject.Money {
+	t.Helper()
+	money, err := valueobject.NewMoney(amount, "EUR")
+	require.NoError(t, err)
+	return money
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func newYearlyContract(t *testing.T, committed int64) *entity.Contract {
+	t.Helper()
+	contract, err := entity.NewContract("client-1", "Enterprise 2026", "jane.doe", date(2026, time.January, 1), date(2027, time.January, 1), time.Time{}, eur(t, committed))
+	require.NoError(t, err)
+	return contract
+}
+
+func TestNewContract(t *testing.T) {
+	contract := newYearlyContract(t, 120000_00)
+
+	assert.NotEmpty(t, contract.ID())
+	assert.Equal(t, date(2027, time.January, 1), contract.RenewalDate(), "renewal defaults to the end of the term")
+	assert.Empty(t, contract.SubscriptionIDs())
+	assert.True(t, contract.IsActive(date(2026, time.June, 1)))
+	assert.False(t, contract.IsActive(date(2027, time.January, 1)))
+
+	testCases := []struct {
+		name      string
+		clientID  string
+		start     time.Time
+		end       time.Time
+		renewal   time.Time
+		committed int64
+		field     string
+	}{
+		{name: "missing client", clientID: "", start: date(2026, 1, 1), end: date(2027, 1, 1), committed: 100, field: "client_id"},
+		{name: "term ends before it starts", clientID: "c", start: date(2026, 1, 1), end: date(2025, 1, 1), committed: 100, field: "end_date"},
+		{name: "renewal before term", clientID: "c", start: date(2026, 1, 1), end: date(2027, 1, 1), renewal: date(2025, 12, 1), committed: 100, field: "renewal_date"},
+		{name: "no commitment", clientID: "c", start: date(2026, 1, 1), end: date(2027, 1, 1), committed: 0, field: "committed_amount"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := entity.NewContract(testCase.clientID, "Contract", "", testCase.start, testCase.end, testCase.renewal, eur(t, testCase.committed))
+			require.Error(t, err)
+			validationErr, ok := err.(*domainErrors.ValidationError)
+			require.True(t, ok)
+			assert.Equal(t, testCase.field, validationErr.Field)
+		})
+	}
+}
+
+func TestContract_Linking(t *testing.T) {
+	contract := newYearlyContract(t, 1000_00)
+
+	require.NoError(t, contract.LinkSubscription("sub-1"))
+	require.NoError(t, contract.LinkSubscription("sub-1"))
+	assert.Equal(t, []string{"sub-1"}, contract.SubscriptionIDs(), "linking twice is a no-op")
+
+	require.NoError(t, contract.LinkInvoice("inv-1", eur(t, 300_00), date(2026, time.February, 1)))
+	assert.True(t, domainErrors.IsBusinessRuleError(contract.LinkInvoice("inv-1", eur(t, 300_00), date(2026, time.February, 1))), "an invoice counts once")
+
+	usd, err := valueobject.NewMoney(100, "USD")
+	require.NoError(t, err)
+	assert.True(t, domainErrors.IsBusinessRuleError(contract.LinkInvoice("inv-2", usd, time.Time{})))
+
+	assert.Equal(t, int64(300_00), contract.Spent().Amount())
+}
+
+func TestContract_Attainment(t *testing.T) {
+	midYear := date(2026, time.July, 2).Add(12 * time.Hour) // Half of the 365-day term has elapsed
+
+	testCases := []struct {
+		name              string
+		invoices          []int64
+		expectedBps       int64
+		expectedRemaining int64
+		attained          bool
+		onTrack           bool
+	}{
+		{name: "nothing spent", invoices: nil, expectedBps: 0, expectedRemaining: 1000_00, attained: false, onTrack: false},
+		{name: "behind pace", invoices: []int64{200_00, 100_00}, expectedBps: 3000, expectedRemaining: 700_00, attained: false, onTrack: false},
+		{name: "ahead of pace", invoices: []int64{600_00}, expectedBps: 6000, expectedRemaining: 400_00, attained: false, onTrack: true},
+		{name: "rounded down below 100%", invoices: []int64{999_99}, expectedBps: 9999, expectedRemaining: 1, attained: false, onTrack: true},
+		{name: "exceeded", invoices: []int64{800_00, 400_00}, expectedBps: 12000, expectedRemaining: 0, attained: true, onTrack: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			contract := newYearlyContract(t, 1000_00)
+			for index, amount := range testCase.invoices {
+				require.NoError(t, contract.LinkInvoice(string(rune('a'+index)), eur(t, amount), date(2026, time.March, 1)))
+			}
+
+			attainment := contract.Attainment(midYear)
+			assert.Equal(t, testCase.expectedBps, attainment.AttainedBps)
+			assert.Equal(t, testCase.expectedRemaining, attainment.Remaining.Amount())
+			assert.Equal(t, testCase.attained, attainment.Attained)
+			assert.Equal(t, testCase.onTrack, attainment.OnTrack)
+			assert.Equal(t, int64(5000), attainment.TermElapsedBps)
+		})
+	}
+
+	contract := newYearlyContract(t, 1000_00)
+	assert.Equal(t, int64(0), contract.Attainment(date(2025, time.December, 1)).TermElapsedBps)
+	assert.Equal(t, int64(10000), contract.Attainment(date(2027, time.March, 1)).TermElapsedBps)
+}
+
+func TestContract_RenewalReminder(t *testing.T) {
+	contract := newYearlyContract(t, 1000_00)
+	lead := 60 * 24 * time.Hour
+
+	assert.False(t, contract.NeedsRenewalReminder(date(2026, time.October, 1), lead), "too early")
+	assert.True(t, contract.NeedsRenewalReminder(date(2026, time.November, 2), lead), "window opens 60 days before renewal")
+	assert.False(t, contract.NeedsRenewalReminder(date(2027, time.January, 1), lead), "renewal date has passed")
+
+	contract.MarkRenewalReminderSent(date(2026, time.November, 2))
+	assert.False(t, contract.NeedsRenewalReminder(date(2026, time.December, 1), lead), "reminded once")
+}
+
+func TestContract_JSONRoundTrip(t *testing.T) {
+	contract := newYearlyContract(t, 1000_00)
+	require.NoError(t, contract.LinkSubscription("sub-1"))
+	require.NoError(t, contract.LinkInvoice("inv-1", eur(t, 250_00), date(2026, time.April, 1)))
+	contract.MarkRenewalReminderSent(date(2026, time.November, 5))
+
+	data, err := json.Marshal(contract)
+	require.NoError(t, err)
+
+	var decoded entity.Contract
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, contract.ID(), decoded.ID())
+	assert.Equal(t, contract.CommittedAmount(), decoded.CommittedAmount())
+	assert.Equal(t, contract.SubscriptionIDs(), decoded.SubscriptionIDs())
+	assert.Equal(t, contract.Invoices(), decoded.Invoices())
+	assert.Equal(t, contract.RenewalReminderSentAt(), decoded.RenewalReminderSentAt())
+}
@@ -0,0 +1,85 @@
+// Client Credit Limit Domain Unit Tests
+//
+// This file contains unit tests for client credit limits and their over-limit policies.
+// Tests: Limit and policy validation, updates, JSON round-trip
+// Scope: Pure unit tests - single component (ClientCreditLimit entity) with no external dependencies
+package credit
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func eur(t *testing.T, amount int64) valueobject.Money {
+	t.Helper()
+	money, err := valueobject.NewMoney(amount, "EUR")
+	require.NoError(t, err)
+	return money
+}
+
+func TestNewClientCreditLimit_Validation(t *testing.T) {
+	tests := []struct {
+		name     string
+		clientID string
+		limit    int64
+		policy   entity.CreditPolicy
+		field    string
+	}{
+		{name: "missing client", clientID: " ", limit: 1000, policy: entity.CreditPolicyBlock, field: "client_id"},
+		{name: "negative limit", clientID: "client-1", limit: -1, policy: entity.CreditPolicyBlock, field: "amount"},
+		{name: "unknown policy", clientID: "client-1", limit: 1000, policy: "ignore", field: "policy"},
+		{name: "missing policy", clientID: "client-1", limit: 1000, policy: "", field: "policy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := entity.NewClientCreditLimit(tt.clientID, eur(t, tt.limit), tt.policy)
+
+			var validationErr *domainErrors.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.field, validationErr.Field)
+		})
+	}
+
+	t.Run("a zero limit allows no credit", func(t *testing.T) {
+		limit, err := entity.NewClientCreditLimit("client-1", eur(t, 0), entity.CreditPolicyRequireApproval)
+		require.NoError(t, err)
+		assert.True(t, limit.Limit().IsZero())
+	})
+}
+
+func TestClientCreditLimit_Update(t *testing.T) {
+	limit, err := entity.NewClientCreditLimit("client-1", eur(t, 100000), entity.CreditPolicyBlock)
+	require.NoError(t, err)
+
+	require.NoError(t, limit.Update(eur(t, 250000), entity.CreditPolicyWarn))
+	assert.Equal(t, int64(250000), limit.Limit().Amount())
+	assert.Equal(t, entity.CreditPolicyWarn, limit.Policy())
+	assert.False(t, limit.UpdatedAt().Before(limit.CreatedAt()))
+
+	// A rejected update leaves the limit unchanged
+	assert.Error(t, limit.Update(eur(t, 1), "ignore"))
+	assert.Equal(t, int64(250000), limit.Limit().Amount())
+	assert.Equal(t, entity.CreditPolicyWarn, limit.Policy())
+}
+
+func TestClientCreditLimit_JSONRoundTrip(t *testing.T) {
+	limit, err := entity.NewClientCreditLimit("client-1", eur(t, 100000), entity.CreditPolicyRequireApproval)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(limit)
+	require.NoError(t, err)
+
+	var restored entity.ClientCreditLimit
+	require.NoError(t, json.Unmarshal(data, &restored))
+	assert.Equal(t, "client-1", restored.ClientID())
+	assert.True(t, limit.Limit().Equals(restored.Limit()))
+	assert.Equal(t, entity.CreditPolicyRequireApproval, restored.Policy())
+	assert.True(t, limit.CreatedAt().Equal(restored.CreatedAt()))
+}
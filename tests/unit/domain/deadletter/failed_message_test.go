@@ -0,0 +1,63 @@
+// Failed Message Domain Unit Tests
+//
+// This file contains unit tests for integration events parked in the dead letter queue.
+// Tests: Message validation, failure tracking across retries, JSON round-trip
+// Scope: Pure unit tests - FailedMessage entity with no external dependencies
+package deadletter
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var failedAt = time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+func TestNewFailedMessage_Validation(t *testing.T) {
+	_, err := entity.NewFailedMessage(" ", "key", []byte(`{}`), nil, "broker unavailable", failedAt)
+	require.Error(t, err)
+	assert.Equal(t, domainErrors.ValidationRequired, domainErrors.GetErrorCode(err))
+
+	_, err = entity.NewFailedMessage("billing.contracts", "key", []byte(`{}`), nil, "broker unavailable", time.Time{})
+	require.Error(t, err)
+	assert.Equal(t, domainErrors.ValidationRequired, domainErrors.GetErrorCode(err))
+}
+
+func TestFailedMessage_RecordFailure(t *testing.T) {
+	headers := map[string]string{"content-type": "application/json"}
+	message, err := entity.NewFailedMessage("billing.contracts", "contract-1", []byte(`{"id":"contract-1"}`), headers, "broker unavailable", failedAt)
+	require.NoError(t, err)
+	headers["content-type"] = "text/plain"
+
+	assert.Equal(t, 1, message.Attempts())
+	assert.Equal(t, "application/json", message.Headers()["content-type"])
+
+	message.RecordFailure("connection refused", failedAt.Add(time.Hour))
+	assert.Equal(t, 2, message.Attempts())
+	assert.Equal(t, "connection refused", message.Reason())
+	assert.Equal(t, failedAt.Add(time.Hour), message.FailedAt())
+}
+
+func TestFailedMessage_JSONRoundTrip(t *testing.T) {
+	message, err := entity.NewFailedMessage("billing.contracts", "contract-1", []byte(`{"id":"contract-1"}`), map[string]string{"event": "contract.signed"}, "broker unavailable", failedAt)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(message)
+	require.NoError(t, err)
+
+	var decoded entity.FailedMessage
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, message.ID(), decoded.ID())
+	assert.Equal(t, "billing.contracts", decoded.Topic())
+	assert.Equal(t, "contract-1", decoded.Key())
+	assert.JSONEq(t, `{"id":"contract-1"}`, string(decoded.Payload()))
+	assert.Equal(t, "contract.signed", decoded.Headers()["event"])
+	assert.Equal(t, "broker unavailable", decoded.Reason())
+	assert.Equal(t, 1, decoded.Attempts())
+	assert.Equal(t, failedAt, decoded.FailedAt())
+}
@@ -0,0 +1,122 @@
+// Invoice Delivery Event Domain Unit Tests
+//
+// This file contains unit tests for invoice delivery tracking.
+// Tests: Validation, delivery state summary, JSON round-trip
+// Scope: Pure unit tests - single component (InvoiceDeliveryEvent entity) with no external dependencies
+package delivery
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func event(t *testing.T, eventType entity.DeliveryEventType, bounceType entity.BounceType, occurredAt time.Time) *entity.InvoiceDeliveryEvent {
+	t.Helper()
+	e, err := entity.NewInvoiceDeliveryEvent("inv-1", eventType, "", "billing@acme.example", bounceType, "", occurredAt)
+	require.NoError(t, err)
+	return e
+}
+
+func TestNewInvoiceDeliveryEvent_Validation(t *testing.T) {
+	tests := []struct {
+		name       string
+		eventType  entity.DeliveryEventType
+		channel    entity.DeliveryChannel
+		bounceType entity.BounceType
+		field      string
+	}{
+		{name: "unknown type", eventType: "opened", field: "type"},
+		{name: "unknown channel", eventType: entity.DeliverySent, channel: "sms", field: "channel"},
+		{name: "portal only tracks views", eventType: entity.DeliverySent, channel: entity.DeliveryChannelPortal, field: "channel"},
+		{name: "bounce without type", eventType: entity.DeliveryBounced, field: "bounce_type"},
+		{name: "bounce type on a non-bounce", eventType: entity.DeliveryDelivered, bounceType: entity.BounceHard, field: "bounce_type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := entity.NewInvoiceDeliveryEvent("inv-1", tt.eventType, tt.channel, "", tt.bounceType, "", time.Time{})
+
+			var validationErr *domainErrors.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.field, validationErr.Field)
+		})
+	}
+
+	t.Run("defaults to email and now", func(t *testing.T) {
+		e, err := entity.NewInvoiceDeliveryEvent(" inv-1 ", entity.DeliverySent, "", "billing@acme.example", "", "", time.Time{})
+
+		require.NoError(t, err)
+		assert.Equal(t, "inv-1", e.InvoiceID())
+		assert.Equal(t, entity.DeliveryChannelEmail, e.Channel())
+		assert.False(t, e.OccurredAt().IsZero())
+	})
+}
+
+func TestSummarizeInvoiceDelivery(t *testing.T) {
+	base := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("no events", func(t *testing.T) {
+		summary := entity.SummarizeInvoiceDelivery(nil)
+		assert.Equal(t, entity.InvoiceDeliveryNotSent, summary.Status)
+	})
+
+	t.Run("a bounce after sending", func(t *testing.T) {
+		summary := entity.SummarizeInvoiceDelivery([]*entity.InvoiceDeliveryEvent{
+			event(t, entity.DeliverySent, "", base),
+			event(t, entity.DeliveryBounced, entity.BounceSoft, base.Add(time.Minute)),
+		})
+
+		assert.Equal(t, entity.InvoiceDeliveryBounced, summary.Status)
+		assert.Equal(t, 1, summary.BounceCount)
+		require.NotNil(t, summary.LastBounceAt)
+		assert.Equal(t, base.Add(time.Minute), *summary.LastBounceAt)
+	})
+
+	t.Run("a successful resend clears the bounce", func(t *testing.T) {
+		summary := entity.SummarizeInvoiceDelivery([]*entity.InvoiceDeliveryEvent{
+			event(t, entity.DeliverySent, "", base),
+			event(t, entity.DeliveryBounced, entity.BounceSoft, base.Add(time.Minute)),
+			event(t, entity.DeliverySent, "", base.Add(time.Hour)),
+			event(t, entity.DeliveryDelivered, "", base.Add(time.Hour+time.Second)),
+		})
+
+		assert.Equal(t, entity.InvoiceDeliveryDelivered, summary.Status)
+		assert.Equal(t, base.Add(time.Hour), *summary.LastSentAt)
+	})
+
+	t.Run("views win over the email outcome", func(t *testing.T) {
+		summary := entity.SummarizeInvoiceDelivery([]*entity.InvoiceDeliveryEvent{
+			event(t, entity.DeliverySent, "", base),
+			event(t, entity.DeliveryViewed, "", base.Add(time.Hour)),
+			event(t, entity.DeliveryViewed, "", base.Add(2*time.Hour)),
+			event(t, entity.DeliveryBounced, entity.BounceHard, base.Add(3*time.Hour)),
+		})
+
+		assert.Equal(t, entity.InvoiceDeliveryViewed, summary.Status)
+		assert.Equal(t, 2, summary.ViewCount)
+		assert.Equal(t, base.Add(time.Hour), *summary.FirstViewAt)
+		assert.Equal(t, base.Add(2*time.Hour), *summary.LastViewAt)
+	})
+}
+
+func TestInvoiceDeliveryEvent_JSONRoundTrip(t *testing.T) {
+	original := event(t, entity.DeliveryBounced, entity.BounceHard, time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC))
+
+	data, err := json.Marshal(original)
+	require.NoError(t, err)
+
+	var restored entity.InvoiceDeliveryEvent
+	require.NoError(t, json.Unmarshal(data, &restored))
+
+	assert.Equal(t, original.ID(), restored.ID())
+	assert.Equal(t, original.Type(), restored.Type())
+	assert.Equal(t, original.BounceType(), restored.BounceType())
+	assert.Equal(t, original.Recipient(), restored.Recipient())
+	assert.True(t, original.OccurredAt().Equal(restored.OccurredAt()))
+}
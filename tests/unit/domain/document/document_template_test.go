@@ -0,0 +1,90 @@
+// Document Template Domain Unit Tests
+//
+// This file contains unit tests for tenant invoice layouts.
+// Tests: Defaults, logo/color/column/footer validation, column visibility, JSON round-trip
+// Scope: Pure unit tests - single component (DocumentTemplate entity) with no external dependencies
+package document
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDocumentTemplate_Defaults(t *testing.T) {
+	template, err := entity.NewDocumentTemplate("acme", "", "", "", nil, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, entity.DefaultPrimaryColor, template.PrimaryColor())
+	assert.Equal(t, entity.DefaultAccentColor, template.AccentColor())
+	assert.Equal(t, entity.DefaultInvoiceColumns(), template.Columns())
+	assert.False(t, template.ShowsColumn(entity.InvoiceColumnTaxAmount))
+}
+
+func TestNewDocumentTemplate(t *testing.T) {
+	template, err := entity.NewDocumentTemplate("acme", "https://cdn.acme.example/logo.png", "#0a0a0a", "#ff6600",
+		[]entity.InvoiceColumn{entity.InvoiceColumnDescription, entity.InvoiceColumnAmount}, "  Thank you for your business  ")
+	require.NoError(t, err)
+
+	assert.Equal(t, "#0A0A0A", template.PrimaryColor())
+	assert.Equal(t, "#FF6600", template.AccentColor())
+	assert.Equal(t, "Thank you for your business", template.FooterText())
+	assert.True(t, template.ShowsColumn(entity.InvoiceColumnAmount))
+	assert.False(t, template.ShowsColumn(entity.InvoiceColumnQuantity))
+}
+
+func TestNewDocumentTemplate_Validation(t *testing.T) {
+	testCases := []struct {
+		name    string
+		tenant  string
+		logoURL string
+		color   string
+		columns []entity.InvoiceColumn
+		footer  string
+		field   string
+	}{
+		{"missing tenant", "", "", "", nil, "", "tenant_id"},
+		{"plain http logo", "acme", "http://cdn.acme.example/logo.png", "", nil, "", "logo_url"},
+		{"relative logo", "acme", "/logo.png", "", nil, "", "logo_url"},
+		{"named color", "acme", "", "red", nil, "", "primary_color"},
+		{"short hex color", "acme", "", "#FFF", nil, "", "primary_color"},
+		{"unknown column", "acme", "", "", []entity.InvoiceColumn{"description", "discount", "amount"}, "", "columns[1]"},
+		{"duplicate column", "acme", "", "", []entity.InvoiceColumn{"description", "amount", "amount"}, "", "columns[2]"},
+		{"missing amount column", "acme", "", "", []entity.InvoiceColumn{"description", "quantity"}, "", "columns"},
+		{"footer too long", "acme", "", "", nil, strings.Repeat("x", 1001), "footer_text"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := entity.NewDocumentTemplate(tc.tenant, tc.logoURL, tc.color, "", tc.columns, tc.footer)
+			require.Error(t, err)
+
+			var validationErr *domainErrors.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tc.field, validationErr.Field)
+		})
+	}
+}
+
+func TestDocumentTemplate_JSONRoundTrip(t *testing.T) {
+	template, err := entity.NewDocumentTemplate("acme", "https://cdn.acme.example/logo.png", "#112233", "",
+		[]entity.InvoiceColumn{entity.InvoiceColumnDescription, entity.InvoiceColumnTaxAmount, entity.InvoiceColumnAmount}, "Registered in Paris")
+	require.NoError(t, err)
+
+	data, err := json.Marshal(template)
+	require.NoError(t, err)
+
+	var restored entity.DocumentTemplate
+	require.NoError(t, json.Unmarshal(data, &restored))
+
+	assert.Equal(t, template.TenantID(), restored.TenantID())
+	assert.Equal(t, template.LogoURL(), restored.LogoURL())
+	assert.Equal(t, template.PrimaryColor(), restored.PrimaryColor())
+	assert.Equal(t, template.Columns(), restored.Columns())
+	assert.Equal(t, template.FooterText(), restored.FooterText())
+}
@@ -0,0 +1,121 @@
+// Dunning Policy Domain Unit Tests
+//
+// This file contains unit tests for tenant payment reminder cadences.
+// Tests: Stage validation, cadence ordering, current stage resolution, JSON round-trip
+// Scope: Pure unit tests - single component (DunningPolicy entity) with no external dependencies
+package dunning
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stage(t *testing.T, dayOffset int, channel entity.ReminderChannel, template string) entity.ReminderStage {
+	t.Helper()
+	s, err := entity.NewReminderStage(dayOffset, channel, template)
+	require.NoError(t, err)
+	return s
+}
+
+func TestNewReminderStage_Validation(t *testing.T) {
+	tests := []struct {
+		name      string
+		dayOffset int
+		channel   entity.ReminderChannel
+		template  string
+		field     string
+	}{
+		{name: "offset too far before due date", dayOffset: -31, channel: entity.ReminderChannelEmail, template: "t", field: "day_offset"},
+		{name: "offset too far after due date", dayOffset: 366, channel: entity.ReminderChannelEmail, template: "t", field: "day_offset"},
+		{name: "unknown channel", dayOffset: 1, channel: "fax", template: "t", field: "channel"},
+		{name: "missing template", dayOffset: 1, channel: entity.ReminderChannelSMS, template: "  ", field: "template"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := entity.NewReminderStage(tt.dayOffset, tt.channel, tt.template)
+
+			var validationErr *domainErrors.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.field, validationErr.Field)
+		})
+	}
+}
+
+func TestNewDunningPolicy(t *testing.T) {
+	t.Run("requires at least one stage", func(t *testing.T) {
+		_, err := entity.NewDunningPolicy("acme", nil)
+		assert.True(t, domainErrors.IsValidationError(err))
+	})
+
+	t.Run("requires strictly increasing offsets", func(t *testing.T) {
+		_, err := entity.NewDunningPolicy("acme", []entity.ReminderStage{
+			stage(t, 3, entity.ReminderChannelEmail, "first"),
+			stage(t, 3, entity.ReminderChannelSMS, "second"),
+		})
+
+		var validationErr *domainErrors.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "stages[1].day_offset", validationErr.Field)
+	})
+
+	t.Run("allows a reminder before the due date", func(t *testing.T) {
+		policy, err := entity.NewDunningPolicy(" acme ", []entity.ReminderStage{
+			stage(t, -3, entity.ReminderChannelEmail, "upcoming"),
+			stage(t, 5, entity.ReminderChannelSMS, "overdue"),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "acme", policy.TenantID())
+		assert.Len(t, policy.Stages(), 2)
+	})
+}
+
+func TestCurrentReminderStage(t *testing.T) {
+	stages := []entity.ReminderStage{
+		stage(t, -3, entity.ReminderChannelEmail, "upcoming"),
+		stage(t, 5, entity.ReminderChannelSMS, "overdue"),
+		stage(t, 20, entity.ReminderChannelLetter, "final"),
+	}
+
+	_, _, ok := entity.CurrentReminderStage(stages, -4)
+	assert.False(t, ok, "no stage before the first offset")
+
+	current, index, ok := entity.CurrentReminderStage(stages, 0)
+	require.True(t, ok)
+	assert.Equal(t, 0, index)
+	assert.Equal(t, "upcoming", current.Template())
+
+	current, index, ok = entity.CurrentReminderStage(stages, 19)
+	require.True(t, ok)
+	assert.Equal(t, 1, index)
+	assert.Equal(t, entity.ReminderChannelSMS, current.Channel())
+
+	current, index, ok = entity.CurrentReminderStage(stages, 90)
+	require.True(t, ok)
+	assert.Equal(t, 2, index)
+	assert.Equal(t, "final", current.Template())
+}
+
+func TestDunningPolicy_JSONRoundTrip(t *testing.T) {
+	original, err := entity.NewDunningPolicy("acme", []entity.ReminderStage{
+		stage(t, 1, entity.ReminderChannelEmail, "friendly"),
+		stage(t, 10, entity.ReminderChannelLetter, "formal"),
+	})
+	require.NoError(t, err)
+
+	data, err := json.Marshal(original)
+	require.NoError(t, err)
+
+	var restored entity.DunningPolicy
+	require.NoError(t, json.Unmarshal(data, &restored))
+
+	assert.Equal(t, original.TenantID(), restored.TenantID())
+	assert.Equal(t, original.Stages(), restored.Stages())
+	assert.True(t, original.UpdatedAt().Equal(restored.UpdatedAt()))
+}
@@ -0,0 +1,187 @@
+// Email Outbox Domain Unit Tests
+//
+// This file contains unit tests for notification emails persisted in the outbox and the email suppression list.
+// Tests: Outbox email validation, dispatch outcomes and requeue, bounce counting and suppression, JSON round-trips
+// Scope: Pure unit tests - OutboundEmail and EmailSuppression entities with no external dependencies
+package emailoutbox
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var createdAt = time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+func newEmail(t *testing.T) *entity.OutboundEmail {
+	t.Helper()
+	email, err := entity.NewOutboundEmail(
+		"billing.notifications.payment_receipts",
+		"invoice-1",
+		[]byte(`{"client_id":"client-1"}`),
+		map[string]string{"content-type": "application/json"},
+		" Jane@Example.com ",
+		createdAt,
+	)
+	require.NoError(t, err)
+	return email
+}
+
+func TestNewOutboundEmail(t *testing.T) {
+	email := newEmail(t)
+
+	assert.NotEmpty(t, email.ID())
+	assert.Equal(t, "billing.notifications.payment_receipts", email.Topic())
+	assert.Equal(t, "jane@example.com", email.Recipient())
+	assert.Equal(t, entity.OutboundEmailPending, email.Status())
+	assert.Equal(t, 0, email.Attempts())
+	assert.True(t, email.IsDue(createdAt))
+	assert.False(t, email.IsDue(createdAt.Add(-time.Second)))
+
+	_, err := entity.NewOutboundEmail(" ", "", nil, nil, "", createdAt)
+	assert.True(t, domainErrors.IsValidationError(err))
+	_, err = entity.NewOutboundEmail("billing.notifications.payment_receipts", "", nil, nil, "", time.Time{})
+	assert.True(t, domainErrors.IsValidationError(err))
+}
+
+func TestOutboundEmail_Dispatch(t *testing.T) {
+	t.Run("retried until sent", func(t *testing.T) {
+		email := newEmail(t)
+		retryAt := createdAt.Add(time.Minute)
+		email.RecordFailure("connection refused", createdAt, &retryAt)
+
+		assert.Equal(t, entity.OutboundEmailPending, email.Status())
+		assert.Equal(t, 1, email.Attempts())
+		assert.Equal(t, "connection refused", email.LastError())
+		assert.False(t, email.IsDue(createdAt.Add(30*time.Second)))
+		assert.True(t, email.IsDue(retryAt))
+
+		email.MarkSent(retryAt)
+		assert.Equal(t, entity.OutboundEmailSent, email.Status())
+		assert.Equal(t, 2, email.Attempts())
+		assert.Empty(t, email.LastError())
+		require.NotNil(t, email.SentAt())
+		assert.False(t, email.IsDue(retryAt))
+		assert.ErrorIs(t, email.Requeue(retryAt), domainErrors.ErrOutboundEmailNotRetryable)
+	})
+
+	t.Run("failed after the last attempt, then requeued", func(t *testing.T) {
+		email := newEmail(t)
+		email.RecordFailure("mailbox unavailable", createdAt, nil)
+		assert.Equal(t, entity.OutboundEmailFailed, email.Status())
+		assert.False(t, email.IsDue(createdAt.Add(time.Hour)))
+
+		requeuedAt := createdAt.Add(time.Hour)
+		require.NoError(t, email.Requeue(requeuedAt))
+		assert.Equal(t, entity.OutboundEmailPending, email.Status())
+		assert.Equal(t, 0, email.Attempts())
+		assert.True(t, email.IsDue(requeuedAt))
+	})
+
+	t.Run("suppressed emails can be requeued", func(t *testing.T) {
+		email := newEmail(t)
+		email.MarkSuppressed(createdAt)
+		assert.Equal(t, entity.OutboundEmailSuppressed, email.Status())
+		assert.NoError(t, email.Requeue(createdAt))
+	})
+
+	t.Run("pending emails cannot be requeued", func(t *testing.T) {
+		assert.ErrorIs(t, newEmail(t).Requeue(createdAt), domainErrors.ErrOutboundEmailNotRetryable)
+	})
+}
+
+func TestOutboundEmail_JSONRoundTrip(t *testing.T) {
+	email := newEmail(t)
+	retryAt := createdAt.Add(time.Minute)
+	email.RecordFailure("timeout", createdAt, &retryAt)
+
+	data, err := json.Marshal(email)
+	require.NoError(t, err)
+
+	var restored entity.OutboundEmail
+	require.NoError(t, json.Unmarshal(data, &restored))
+	assert.Equal(t, email.ID(), restored.ID())
+	assert.Equal(t, email.Topic(), restored.Topic())
+	assert.Equal(t, email.Key(), restored.Key())
+	assert.JSONEq(t, string(email.Payload()), string(restored.Payload()))
+	assert.Equal(t, email.Headers(), restored.Headers())
+	assert.Equal(t, email.Recipient(), restored.Recipient())
+	assert.Equal(t, email.Status(), restored.Status())
+	assert.Equal(t, 1, restored.Attempts())
+	assert.Equal(t, "timeout", restored.LastError())
+	assert.True(t, retryAt.Equal(restored.NextAttemptAt()))
+}
+
+func TestNewEmailSuppression(t *testing.T) {
+	suppression, err := entity.NewEmailSuppression(" Jane@Example.com ", createdAt)
+	require.NoError(t, err)
+	assert.Equal(t, "jane@example.com", suppression.Address())
+	assert.False(t, suppression.IsSuppressed())
+
+	_, err = entity.NewEmailSuppression("not-an-address", createdAt)
+	assert.True(t, domainErrors.IsValidationError(err))
+}
+
+func TestEmailSuppression_Bounces(t *testing.T) {
+	t.Run("a hard bounce suppresses the address", func(t *testing.T) {
+		suppression, err := entity.NewEmailSuppression("jane@example.com", createdAt)
+		require.NoError(t, err)
+
+		assert.True(t, suppression.RecordBounce(entity.BounceHard, "550 no such user", 3, createdAt))
+		assert.True(t, suppression.IsSuppressed())
+		assert.Equal(t, entity.SuppressionHardBounce, suppression.Reason())
+		assert.Equal(t, "550 no such user", suppression.Detail())
+
+		assert.False(t, suppression.RecordBounce(entity.BounceHard, "again", 3, createdAt), "an address is suppressed once")
+		assert.Equal(t, "550 no such user", suppression.Detail())
+	})
+
+	t.Run("soft bounces suppress the address at the limit", func(t *testing.T) {
+		suppression, err := entity.NewEmailSuppression("jane@example.com", createdAt)
+		require.NoError(t, err)
+
+		assert.False(t, suppression.RecordBounce(entity.BounceSoft, "mailbox full", 3, createdAt))
+		assert.False(t, suppression.RecordBounce(entity.BounceSoft, "mailbox full", 3, createdAt))
+		assert.Equal(t, 2, suppression.SoftBounces())
+
+		suppression.RecordDelivery(createdAt)
+		assert.Equal(t, 0, suppression.SoftBounces(), "a delivery resets the soft bounces")
+
+		for i := 0; i < 2; i++ {
+			assert.False(t, suppression.RecordBounce(entity.BounceSoft, "mailbox full", 3, createdAt))
+		}
+		assert.True(t, suppression.RecordBounce(entity.BounceSoft, "mailbox full", 3, createdAt))
+		assert.Equal(t, entity.SuppressionSoftBounces, suppression.Reason())
+	})
+
+	t.Run("manual suppression", func(t *testing.T) {
+		suppression, err := entity.NewEmailSuppression("jane@example.com", createdAt)
+		require.NoError(t, err)
+
+		require.NoError(t, suppression.Suppress("unsubscribe request", createdAt))
+		assert.Equal(t, entity.SuppressionManual, suppression.Reason())
+		assert.ErrorIs(t, suppression.Suppress("again", createdAt), domainErrors.ErrEmailAlreadySuppressed)
+	})
+}
+
+func TestEmailSuppression_JSONRoundTrip(t *testing.T) {
+	suppression, err := entity.NewEmailSuppression("jane@example.com", createdAt)
+	require.NoError(t, err)
+	suppression.RecordBounce(entity.BounceHard, "550 no such user", 3, createdAt)
+
+	data, err := json.Marshal(suppression)
+	require.NoError(t, err)
+
+	var restored entity.EmailSuppression
+	require.NoError(t, json.Unmarshal(data, &restored))
+	assert.Equal(t, suppression.Address(), restored.Address())
+	assert.Equal(t, suppression.Reason(), restored.Reason())
+	assert.Equal(t, suppression.Detail(), restored.Detail())
+	assert.True(t, restored.IsSuppressed())
+	assert.True(t, suppression.CreatedAt().Equal(restored.CreatedAt()))
+}
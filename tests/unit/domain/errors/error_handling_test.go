@@ -15,6 +15,7 @@ package errors
 import (
 	"testing"
 
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
 	"github.com/gjaminon-go-labs/billing-api/internal/application"
 	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
 	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
@@ -29,7 +30,7 @@ func TestErrorHandling_ValidationError(t *testing.T) {
 	service := application.NewBillingService(clientRepo)
 
 	// Act - create client with invalid email
-	client, err := service.CreateClient("John Doe", "", "", "")
+	client, err := service.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "John Doe"})
 
 	// Assert
 	assert.Error(t, err)
@@ -57,7 +58,7 @@ func TestErrorHandling_FormatValidationError(t *testing.T) {
 	service := application.NewBillingService(clientRepo)
 
 	// Act - create client with invalid email format
-	client, err := service.CreateClient("John Doe", "invalid-email", "", "")
+	client, err := service.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "John Doe", Email: "invalid-email"})
 
 	// Assert
 	assert.Error(t, err)
@@ -81,7 +82,7 @@ func TestErrorHandling_SuccessfulCreation(t *testing.T) {
 	service := application.NewBillingService(clientRepo)
 
 	// Act - create valid client
-	client, err := service.CreateClient("John Doe", "john@example.com", "+1234567890", "123 Main St")
+	client, err := service.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "John Doe", Email: "john@example.com", Phone: "+1234567890", Address: "123 Main St"})
 
 	// Assert
 	assert.NoError(t, err)
@@ -0,0 +1,124 @@
+// Fiscal Calendar Domain Unit Tests
+//
+// This file contains unit tests for tenant fiscal calendars.
+// Tests: Calendar validation, fiscal year naming, period boundaries, period locks, JSON round-trip
+// Scope: Pure unit tests - single component (FiscalCalendar entity) with no external dependencies
+package fiscal
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestNewFiscalCalendar_Validation(t *testing.T) {
+	tests := []struct {
+		name         string
+		startMonth   int
+		periodMonths []int
+		field        string
+	}{
+		{name: "start month out of range", startMonth: 13, field: "start_month"},
+		{name: "empty period", startMonth: 1, periodMonths: []int{3, 0, 3, 3, 3}, field: "period_months[1]"},
+		{name: "periods not covering a year", startMonth: 1, periodMonths: []int{3, 3, 3}, field: "period_months"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := entity.NewFiscalCalendar("acme", tt.startMonth, tt.periodMonths)
+			require.Error(t, err)
+			var validationErr *domainErrors.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.field, validationErr.Field)
+		})
+	}
+
+	t.Run("defaults to monthly periods", func(t *testing.T) {
+		calendar, err := entity.NewFiscalCalendar("acme", 4, nil)
+		require.NoError(t, err)
+		assert.Len(t, calendar.PeriodMonths(), 12)
+	})
+}
+
+func TestFiscalCalendar_Periods(t *testing.T) {
+	t.Run("fiscal years are named after the calendar year they end in", func(t *testing.T) {
+		calendar, err := entity.NewFiscalCalendar("acme", 7, []int{3, 3, 3, 3})
+		require.NoError(t, err)
+
+		assert.Equal(t, 2026, calendar.FiscalYear(date(2026, time.June, 30)))
+		assert.Equal(t, 2027, calendar.FiscalYear(date(2026, time.July, 1)))
+
+		periods := calendar.Periods(2027)
+		require.Len(t, periods, 4)
+		assert.Equal(t, date(2026, time.July, 1), periods[0].Start)
+		assert.Equal(t, date(2026, time.September, 30), periods[0].LastDay())
+		assert.Equal(t, date(2027, time.June, 30), periods[3].LastDay())
+		assert.Equal(t, "2027-P02", calendar.PeriodOf(date(2026, time.November, 15)).Key())
+	})
+
+	t.Run("the default calendar splits calendar years into months", func(t *testing.T) {
+		calendar := entity.DefaultFiscalCalendar("acme")
+
+		period := calendar.PeriodOf(date(2026, time.March, 31))
+		assert.Equal(t, "2026-P03", period.Key())
+		assert.Equal(t, date(2026, time.March, 1), period.Start)
+	})
+
+	t.Run("unknown periods are rejected", func(t *testing.T) {
+		calendar, err := entity.NewFiscalCalendar("acme", 1, []int{6, 6})
+		require.NoError(t, err)
+
+		_, err = calendar.Period("2026-P03")
+		assert.Error(t, err)
+		_, err = calendar.Period("March")
+		assert.Error(t, err)
+	})
+}
+
+func TestFiscalCalendar_PeriodLocks(t *testing.T) {
+	calendar, err := entity.NewFiscalCalendar("acme", 1, nil)
+	require.NoError(t, err)
+
+	period, err := calendar.ClosePeriod("2026-p01")
+	require.NoError(t, err)
+	assert.Equal(t, "2026-P01", period.Key())
+	assert.True(t, calendar.PeriodOf(date(2026, time.January, 31)).Closed)
+	assert.False(t, calendar.PeriodOf(date(2026, time.February, 1)).Closed)
+
+	_, err = calendar.ClosePeriod("2026-P01")
+	assert.ErrorIs(t, err, domainErrors.ErrFiscalPeriodAlreadyClosed)
+
+	t.Run("periods cannot be redefined while closed", func(t *testing.T) {
+		err := calendar.Redefine(1, []int{3, 3, 3, 3})
+		assert.ErrorIs(t, err, domainErrors.ErrFiscalCalendarHasClosedPeriods)
+		assert.Len(t, calendar.PeriodMonths(), 12)
+
+		assert.NoError(t, calendar.Redefine(1, nil))
+	})
+
+	t.Run("closed periods survive a JSON round-trip", func(t *testing.T) {
+		data, err := json.Marshal(calendar)
+		require.NoError(t, err)
+
+		var restored entity.FiscalCalendar
+		require.NoError(t, json.Unmarshal(data, &restored))
+		assert.Equal(t, []string{"2026-P01"}, restored.ClosedPeriods())
+		assert.True(t, restored.PeriodOf(date(2026, time.January, 15)).Closed)
+	})
+
+	_, err = calendar.ReopenPeriod("2026-P01")
+	require.NoError(t, err)
+	assert.Empty(t, calendar.ClosedPeriods())
+
+	_, err = calendar.ReopenPeriod("2026-P01")
+	assert.ErrorIs(t, err, domainErrors.ErrFiscalPeriodNotClosed)
+}
@@ -0,0 +1,150 @@
+// Installment Plan Domain Unit Tests
+//
+// This file contains unit tests for splitting an invoice balance into installments.
+// Tests: Schedule validation, even splits, payment allocation, per-installment overdue detection
+// Scope: Pure unit tests - single component (InstallmentPlan entity) with no external dependencies
+package installment
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func eur(t *testing.T, amount int64) valueobject.Money {
+	t.Helper()
+	money, err := valueobject.NewMoney(amount, "EUR")
+	require.NoError(t, err)
+	return money
+}
+
+func date(month time.Month, day int) time.Time {
+	return time.Date(2026, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestNewEvenInstallmentPlan(t *testing.T) {
+	plan, err := entity.NewEvenInstallmentPlan("inv-1", eur(t, 10000), 3, date(time.January, 31), 1)
+	require.NoError(t, err)
+
+	installments := plan.Installments()
+	require.Len(t, installments, 3)
+	assert.Equal(t, int64(3334), installments[0].Amount().Amount())
+	assert.Equal(t, int64(3333), installments[1].Amount().Amount())
+	assert.Equal(t, int64(3333), installments[2].Amount().Amount())
+	assert.Equal(t, date(time.January, 31), installments[0].DueDate())
+	assert.True(t, installments[1].DueDate().After(installments[0].DueDate()))
+	assert.Equal(t, entity.InstallmentPlanActive, plan.Status(date(time.January, 1)))
+}
+
+func TestNewInstallmentPlan_Validation(t *testing.T) {
+	testCases := []struct {
+		name     string
+		schedule []entity.ScheduledInstallment
+	}{
+		{
+			name:     "single installment",
+			schedule: []entity.ScheduledInstallment{{DueDate: date(time.March, 1), Amount: eur(t, 10000)}},
+		},
+		{
+			name: "amounts do not add up",
+			schedule: []entity.ScheduledInstallment{
+				{DueDate: date(time.March, 1), Amount: eur(t, 5000)},
+				{DueDate: date(time.April, 1), Amount: eur(t, 4000)},
+			},
+		},
+		{
+			name: "due dates out of order",
+			schedule: []entity.ScheduledInstallment{
+				{DueDate: date(time.April, 1), Amount: eur(t, 5000)},
+				{DueDate: date(time.March, 1), Amount: eur(t, 5000)},
+			},
+		},
+		{
+			name: "zero installment",
+			schedule: []entity.ScheduledInstallment{
+				{DueDate: date(time.March, 1), Amount: eur(t, 10000)},
+				{DueDate: date(time.April, 1), Amount: eur(t, 0)},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := entity.NewInstallmentPlan("inv-1", eur(t, 10000), testCase.schedule)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestInstallmentPlan_ApplyPayment(t *testing.T) {
+	plan, err := entity.NewEvenInstallmentPlan("inv-1", eur(t, 9000), 3, date(time.March, 1), 1)
+	require.NoError(t, err)
+
+	// A partial payment goes to the first installment
+	overpaid, err := plan.ApplyPayment(eur(t, 1000), date(time.February, 20))
+	require.NoError(t, err)
+	assert.True(t, overpaid.IsZero())
+	assert.Equal(t, entity.InstallmentPartiallyPaid, plan.Installments()[0].Status(date(time.February, 25)))
+
+	// A larger payment settles the first installment and spills into the second
+	_, err = plan.ApplyPayment(eur(t, 3500), date(time.February, 28))
+	require.NoError(t, err)
+	installments := plan.Installments()
+	assert.Equal(t, entity.InstallmentPaid, installments[0].Status(date(time.March, 2)))
+	require.NotNil(t, installments[0].PaidAt())
+	assert.Equal(t, int64(1500), installments[1].PaidAmount().Amount())
+	assert.Equal(t, int64(4500), plan.Outstanding().Amount())
+
+	// Overpayment is returned to the caller
+	overpaid, err = plan.ApplyPayment(eur(t, 5000), date(time.March, 15))
+	require.NoError(t, err)
+	assert.Equal(t, int64(500), overpaid.Amount())
+	assert.Equal(t, entity.InstallmentPlanCompleted, plan.Status(date(time.December, 31)))
+
+	// Payments in another currency are rejected
+	usd, err := valueobject.NewMoney(100, "USD")
+	require.NoError(t, err)
+	_, err = plan.ApplyPayment(usd, date(time.March, 15))
+	assert.Error(t, err)
+}
+
+func TestInstallmentPlan_OverduePerInstallment(t *testing.T) {
+	plan, err := entity.NewEvenInstallmentPlan("inv-1", eur(t, 9000), 3, date(time.March, 1), 1)
+	require.NoError(t, err)
+
+	_, err = plan.ApplyPayment(eur(t, 3000), date(time.February, 28))
+	require.NoError(t, err)
+
+	// First installment paid on time, second missed: only the second is overdue
+	now := date(time.April, 10)
+	overdue := plan.OverdueInstallments(now)
+	require.Len(t, overdue, 1)
+	assert.Equal(t, 2, overdue[0].Sequence())
+	assert.Equal(t, entity.InstallmentPending, plan.Installments()[2].Status(now))
+	assert.Equal(t, entity.InstallmentPlanOverdue, plan.Status(now))
+
+	next, ok := plan.NextDue()
+	require.True(t, ok)
+	assert.Equal(t, 2, next.Sequence())
+}
+
+func TestInstallmentPlan_JSONRoundTrip(t *testing.T) {
+	plan, err := entity.NewEvenInstallmentPlan("inv-1", eur(t, 9000), 3, date(time.March, 1), 1)
+	require.NoError(t, err)
+	_, err = plan.ApplyPayment(eur(t, 4000), date(time.February, 28))
+	require.NoError(t, err)
+
+	data, err := json.Marshal(plan)
+	require.NoError(t, err)
+
+	var restored entity.InstallmentPlan
+	require.NoError(t, json.Unmarshal(data, &restored))
+	assert.Equal(t, plan.ID(), restored.ID())
+	assert.Equal(t, plan.Outstanding(), restored.Outstanding())
+	assert.Equal(t, plan.Installments(), restored.Installments())
+}
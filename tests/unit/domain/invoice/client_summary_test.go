@@ -0,0 +1,80 @@
+package invoice
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewClientSummary(t *testing.T) {
+	client, err := entity.NewClient("Acme Corp", "billing@acme.example", "", "")
+	require.NoError(t, err)
+	issuedAt := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	newClientInvoice := func(currency string, issued time.Time) *entity.Invoice {
+		invoice, err := entity.NewInvoice(client.ID(), currency, consulting, nil)
+		require.NoError(t, err)
+		if !issued.IsZero() {
+			require.NoError(t, invoice.Issue(issued))
+		}
+		return invoice
+	}
+
+	partlyPaid := newClientInvoice("EUR", issuedAt)
+	require.NoError(t, partlyPaid.ApplyPayment(eur(t, 10500), issuedAt))
+	paid := newClientInvoice("EUR", issuedAt.AddDate(0, 1, 0))
+	require.NoError(t, paid.ApplyPayment(eur(t, 47700), issuedAt.AddDate(0, 1, 0)))
+	dollars := newClientInvoice("USD", issuedAt.AddDate(0, 0, 5))
+	voided := newClientInvoice("EUR", issuedAt.AddDate(0, 2, 0))
+	require.NoError(t, voided.Void(issuedAt.AddDate(0, 2, 1)))
+	draft := newClientInvoice("EUR", time.Time{})
+	otherClient, err := entity.NewInvoice("client-2", "EUR", consulting, nil)
+	require.NoError(t, err)
+	require.NoError(t, otherClient.Issue(issuedAt))
+
+	summary, err := entity.NewClientSummary(client, []*entity.Invoice{partlyPaid, paid, dollars, voided, draft, otherClient}, issuedAt)
+	require.NoError(t, err)
+
+	t.Run("sums the open balances per currency", func(t *testing.T) {
+		balances := summary.Balances()
+		require.Len(t, balances, 2)
+		assert.Equal(t, "EUR", balances[0].Currency())
+		assert.Equal(t, int64(37200), balances[0].Amount())
+		assert.Equal(t, "USD", balances[1].Currency())
+		assert.Equal(t, int64(47700), balances[1].Amount())
+		assert.Equal(t, 2, summary.OpenInvoices())
+	})
+
+	t.Run("keeps the most recently issued invoice that is not voided", func(t *testing.T) {
+		assert.Equal(t, paid.ID(), summary.LastInvoiceID())
+		assert.Equal(t, issuedAt.AddDate(0, 1, 0), *summary.LastInvoiceAt())
+		assert.Equal(t, int64(47700), summary.LastInvoiceTotal().Amount())
+	})
+
+	t.Run("round-trips through JSON", func(t *testing.T) {
+		data, err := json.Marshal(summary)
+		require.NoError(t, err)
+
+		var restored entity.ClientSummary
+		require.NoError(t, json.Unmarshal(data, &restored))
+		assert.Equal(t, summary.ClientID(), restored.ClientID())
+		assert.Equal(t, summary.Name(), restored.Name())
+		assert.Equal(t, summary.Balances(), restored.Balances())
+		assert.Equal(t, summary.LastInvoiceID(), restored.LastInvoiceID())
+		assert.Equal(t, summary.LastInvoiceTotal(), restored.LastInvoiceTotal())
+		assert.True(t, summary.ClientCreatedAt().Equal(restored.ClientCreatedAt()))
+	})
+
+	t.Run("a client without issued invoices has no balance or last invoice", func(t *testing.T) {
+		empty, err := entity.NewClientSummary(client, []*entity.Invoice{draft}, issuedAt)
+		require.NoError(t, err)
+		assert.Empty(t, empty.Balances())
+		assert.Zero(t, empty.OpenInvoices())
+		assert.Empty(t, empty.LastInvoiceID())
+		assert.Nil(t, empty.LastInvoiceAt())
+	})
+}
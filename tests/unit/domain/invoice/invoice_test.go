@@ -0,0 +1,261 @@
+// Invoice Domain Unit Tests
+//
+// This file contains unit tests for one-off invoices billed to clients.
+// Tests: Validation, status lifecycle (draft, issued, paid, void), partial payments and over-payment, totals, JSON round-trip,
+// archive eligibility
+// Scope: Pure unit tests - single component (Invoice entity) with no external dependencies
+package invoice
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var consulting = []entity.InvoiceLine{
+	{Description: "Consulting", Quantity: 3, UnitAmount: 12000, TaxRateBps: 2000},
+	{Description: "Travel", Quantity: 1, UnitAmount: 4500},
+}
+
+func newInvoice(t *testing.T) *entity.Invoice {
+	t.Helper()
+	invoice, err := entity.NewInvoice("client-1", "eur", consulting, nil)
+	require.NoError(t, err)
+	return invoice
+}
+
+func eur(t *testing.T, amount int64) valueobject.Money {
+	t.Helper()
+	money, err := valueobject.NewMoney(amount, "EUR")
+	require.NoError(t, err)
+	return money
+}
+
+func TestNewInvoice(t *testing.T) {
+	invoice := newInvoice(t)
+	assert.Equal(t, entity.InvoiceDraft, invoice.Status())
+	assert.Equal(t, "EUR", invoice.Currency())
+
+	total, err := invoice.Total()
+	require.NoError(t, err)
+	assert.Equal(t, int64(47700), total.Amount())
+
+	invalid := []struct {
+		name     string
+		clientID string
+		currency string
+		lines    []entity.InvoiceLine
+	}{
+		{"missing client", "", "EUR", consulting},
+		{"invalid currency", "client-1", "EURO", consulting},
+		{"no line items", "client-1", "EUR", nil},
+		{"blank description", "client-1", "EUR", []entity.InvoiceLine{{Description: " ", Quantity: 1, UnitAmount: 100}}},
+		{"zero quantity", "client-1", "EUR", []entity.InvoiceLine{{Description: "Consulting", UnitAmount: 100}}},
+		{"negative unit amount", "client-1", "EUR", []entity.InvoiceLine{{Description: "Consulting", Quantity: 1, UnitAmount: -1}}},
+		{"tax rate above 100%", "client-1", "EUR", []entity.InvoiceLine{{Description: "Consulting", Quantity: 1, UnitAmount: 100, TaxRateBps: 10001}}},
+		{"total out of range", "client-1", "EUR", []entity.InvoiceLine{
+			{Description: "Consulting", Quantity: 1, UnitAmount: math.MaxInt64 / 2},
+			{Description: "Travel", Quantity: 1, UnitAmount: math.MaxInt64 / 2},
+			{Description: "Hosting", Quantity: 1, UnitAmount: 2},
+		}},
+		{"total with tax out of range", "client-1", "EUR", []entity.InvoiceLine{{Description: "Consulting", Quantity: 1, UnitAmount: math.MaxInt64/2 + 1, TaxRateBps: 10000}}},
+	}
+	for _, tc := range invalid {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := entity.NewInvoice(tc.clientID, tc.currency, tc.lines, nil)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestInvoice_Lifecycle(t *testing.T) {
+	now := time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)
+
+	t.Run("draft, issued, paid by partial payments", func(t *testing.T) {
+		invoice := newInvoice(t)
+		assert.ErrorIs(t, invoice.ApplyPayment(eur(t, 100), now), domainErrors.ErrInvoiceNotIssued)
+
+		require.NoError(t, invoice.Issue(now))
+		assert.Equal(t, entity.InvoiceIssued, invoice.Status())
+		assert.Equal(t, now, *invoice.IssuedAt())
+		assert.ErrorIs(t, invoice.Update("EUR", consulting, nil), domainErrors.ErrInvoiceNotDraft)
+		assert.ErrorIs(t, invoice.Issue(now), domainErrors.ErrInvoiceNotDraft)
+
+		require.NoError(t, invoice.ApplyPayment(eur(t, 30000), now))
+		assert.Equal(t, entity.InvoiceIssued, invoice.Status())
+		balance, err := invoice.Balance()
+		require.NoError(t, err)
+		assert.Equal(t, int64(17700), balance.Amount())
+		assert.ErrorIs(t, invoice.Void(now), domainErrors.ErrInvoiceNotVoidable, "invoices with payments are not voided")
+
+		assert.ErrorIs(t, invoice.ApplyPayment(eur(t, 17701), now), domainErrors.ErrInvoiceOverpayment)
+		usd, err := valueobject.NewMoney(100, "USD")
+		require.NoError(t, err)
+		assert.Error(t, invoice.ApplyPayment(usd, now))
+
+		require.NoError(t, invoice.ApplyPayment(eur(t, 17700), now.Add(time.Hour)))
+		assert.Equal(t, entity.InvoicePaid, invoice.Status())
+		assert.Equal(t, now.Add(time.Hour), *invoice.PaidAt())
+		assert.Equal(t, int64(47700), invoice.AmountPaid().Amount())
+		assert.ErrorIs(t, invoice.ApplyPayment(eur(t, 1), now), domainErrors.ErrInvoiceNotIssued)
+		assert.ErrorIs(t, invoice.Void(now), domainErrors.ErrInvoiceNotVoidable)
+	})
+
+	t.Run("drafts and unpaid issued invoices can be voided once", func(t *testing.T) {
+		draft := newInvoice(t)
+		require.NoError(t, draft.Void(now))
+		assert.Equal(t, entity.InvoiceVoid, draft.Status())
+		assert.ErrorIs(t, draft.Void(now), domainErrors.ErrInvoiceNotVoidable)
+
+		issued := newInvoice(t)
+		require.NoError(t, issued.Issue(now))
+		require.NoError(t, issued.Void(now))
+		assert.ErrorIs(t, issued.ApplyPayment(eur(t, 100), now), domainErrors.ErrInvoiceNotIssued)
+	})
+}
+
+func TestInvoice_JSONRoundTrip(t *testing.T) {
+	due := time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC)
+	invoice, err := entity.NewInvoice("client-1", "EUR", consulting, &due)
+	require.NoError(t, err)
+	invoice.AssignTenant("acme")
+	require.NoError(t, invoice.Issue(time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)))
+	require.NoError(t, invoice.ApplyPayment(eur(t, 500), time.Date(2026, time.March, 3, 9, 0, 0, 0, time.UTC)))
+
+	data, err := json.Marshal(invoice)
+	require.NoError(t, err)
+	restored := &entity.Invoice{}
+	require.NoError(t, json.Unmarshal(data, restored))
+
+	assert.Equal(t, invoice.ID(), restored.ID())
+	assert.Equal(t, "acme", restored.TenantID())
+	assert.Equal(t, entity.InvoiceIssued, restored.Status())
+	assert.Equal(t, consulting, restored.Lines())
+	assert.Equal(t, due, *restored.DueDate())
+	assert.Equal(t, int64(500), restored.AmountPaid().Amount())
+	assert.Equal(t, invoice.IssuedAt().Unix(), restored.IssuedAt().Unix())
+}
+
+func TestInvoice_TaxTerms(t *testing.T) {
+	invoice := newInvoice(t)
+	assert.Equal(t, valueobject.TaxExclusive, invoice.TaxPricing())
+
+	assert.Error(t, invoice.SetTaxTerms("gross", "FR"))
+	require.NoError(t, invoice.SetTaxTerms(valueobject.TaxInclusive, " fr "))
+	assert.Equal(t, valueobject.TaxInclusive, invoice.TaxPricing())
+	assert.Equal(t, "FR", invoice.TaxJurisdiction())
+
+	subtotal, err := invoice.Subtotal()
+	require.NoError(t, err)
+	tax, err := invoice.TaxTotal()
+	require.NoError(t, err)
+	total, err := invoice.Total()
+	require.NoError(t, err)
+	assert.Equal(t, int64(34500), subtotal.Amount())
+	assert.Equal(t, int64(6000), tax.Amount())
+	assert.Equal(t, int64(40500), total.Amount())
+
+	data, err := json.Marshal(invoice)
+	require.NoError(t, err)
+	restored := &entity.Invoice{}
+	require.NoError(t, json.Unmarshal(data, restored))
+	assert.Equal(t, valueobject.TaxInclusive, restored.TaxPricing())
+	assert.Equal(t, "FR", restored.TaxJurisdiction())
+
+	require.NoError(t, invoice.Issue(time.Now()))
+	assert.ErrorIs(t, invoice.SetTaxTerms(valueobject.TaxExclusive, ""), domainErrors.ErrInvoiceNotDraft)
+}
+
+func TestInvoice_Archive(t *testing.T) {
+	issuedAt := time.Date(2022, 3, 15, 10, 0, 0, 0, time.UTC)
+	cutoff := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	invoice := newInvoice(t)
+	require.NoError(t, invoice.Issue(issuedAt))
+	assert.False(t, invoice.ClosedBefore(cutoff))
+	_, err := entity.NewArchivedInvoice(invoice, nil, time.Now())
+	assert.ErrorIs(t, err, domainErrors.ErrInvoiceNotArchivable)
+
+	require.NoError(t, invoice.Void(issuedAt.AddDate(0, 1, 0)))
+	assert.True(t, invoice.ClosedBefore(cutoff))
+	assert.False(t, invoice.ClosedBefore(issuedAt))
+
+	archived, err := entity.NewArchivedInvoice(invoice, nil, cutoff)
+	require.NoError(t, err)
+	data, err := json.Marshal(archived)
+	require.NoError(t, err)
+	restored := &entity.ArchivedInvoice{}
+	require.NoError(t, json.Unmarshal(data, restored))
+	assert.Equal(t, invoice.ID(), restored.InvoiceID())
+	assert.Equal(t, entity.InvoiceVoid, restored.Invoice().Status())
+	assert.True(t, cutoff.Equal(restored.ArchivedAt()))
+	assert.Empty(t, restored.Payments())
+}
+
+func TestInvoice_Dunning(t *testing.T) {
+	due := time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC)
+	friendly, err := entity.NewReminderStage(1, entity.ReminderChannelEmail, "payment_reminder_friendly")
+	require.NoError(t, err)
+	final, err := entity.NewReminderStage(14, entity.ReminderChannelLetter, "payment_reminder_final")
+	require.NoError(t, err)
+
+	newIssued := func(t *testing.T) *entity.Invoice {
+		t.Helper()
+		invoice, err := entity.NewInvoice("client-1", "EUR", consulting, &due)
+		require.NoError(t, err)
+		require.NoError(t, invoice.Issue(due.AddDate(0, 0, -30)))
+		return invoice
+	}
+
+	t.Run("days overdue count whole days past the due date", func(t *testing.T) {
+		invoice := newIssued(t)
+		assert.Equal(t, 0, invoice.DaysOverdue(due.Add(23*time.Hour)))
+		assert.Equal(t, 1, invoice.DaysOverdue(due.AddDate(0, 0, 1)))
+		assert.Equal(t, 0, invoice.DaysOverdue(due.AddDate(0, 0, -3)))
+
+		draft, err := entity.NewInvoice("client-1", "EUR", consulting, &due)
+		require.NoError(t, err)
+		assert.Equal(t, 0, draft.DaysOverdue(due.AddDate(0, 0, 10)))
+	})
+
+	t.Run("reminders escalate through the levels", func(t *testing.T) {
+		invoice := newIssued(t)
+		assert.Equal(t, 0, invoice.DunningLevel())
+
+		require.NoError(t, invoice.RecordDunning(1, friendly, due.AddDate(0, 0, 2)))
+		assert.ErrorIs(t, invoice.RecordDunning(1, friendly, due.AddDate(0, 0, 3)), domainErrors.ErrDunningLevelNotEscalated)
+		require.NoError(t, invoice.RecordDunning(3, final, due.AddDate(0, 0, 15)))
+
+		assert.Equal(t, 3, invoice.DunningLevel())
+		events := invoice.DunningEvents()
+		require.Len(t, events, 2)
+		assert.Equal(t, entity.DunningEvent{
+			Level:       3,
+			Channel:     entity.ReminderChannelLetter,
+			Template:    "payment_reminder_final",
+			DaysOverdue: 15,
+			SentAt:      due.AddDate(0, 0, 15),
+		}, events[1])
+
+		data, err := json.Marshal(invoice)
+		require.NoError(t, err)
+		restored := &entity.Invoice{}
+		require.NoError(t, json.Unmarshal(data, restored))
+		assert.Equal(t, events, restored.DunningEvents())
+	})
+
+	t.Run("only overdue issued invoices are reminded", func(t *testing.T) {
+		invoice := newIssued(t)
+		assert.ErrorIs(t, invoice.RecordDunning(1, friendly, due), domainErrors.ErrInvoiceNotOverdue)
+
+		require.NoError(t, invoice.ApplyPayment(eur(t, 47700), due))
+		assert.ErrorIs(t, invoice.RecordDunning(1, friendly, due.AddDate(0, 0, 2)), domainErrors.ErrInvoiceNotIssued)
+	})
+}
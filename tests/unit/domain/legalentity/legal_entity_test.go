@@ -0,0 +1,145 @@
+// Legal Entity Domain Unit Tests
+//
+// This file contains unit tests for the legal entities invoices are issued from.
+// Tests: Validation, invoice numbering (continuous and yearly reset), update guards, JSON round-trip
+// Scope: Pure unit tests - single component (LegalEntity entity) with no external dependencies
+package legalentity
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func newEntity(t *testing.T, numbering entity.InvoiceNumbering) *entity.LegalEntity {
+	t.Helper()
+	account, err := valueobject.NewBankAccount("Acme SAS", "FR1420041010050500013M02606", "", "")
+	require.NoError(t, err)
+
+	legalEntity, err := entity.NewLegalEntity("Acme SAS", "1 rue de la Paix, Paris", "fr",
+		[]entity.TaxRegistration{{Country: "fr", Number: "FR 40 303265045"}}, account, numbering, "")
+	require.NoError(t, err)
+	return legalEntity
+}
+
+func TestNewLegalEntity(t *testing.T) {
+	legalEntity := newEntity(t, entity.InvoiceNumbering{Prefix: "FR-"})
+
+	assert.NotEmpty(t, legalEntity.ID())
+	assert.Equal(t, "FR", legalEntity.Country())
+	assert.Equal(t, []entity.TaxRegistration{{Country: "FR", Number: "FR40303265045"}}, legalEntity.TaxRegistrations())
+	assert.Equal(t, entity.DefaultInvoiceNumberPadding, legalEntity.Numbering().Padding)
+	assert.Equal(t, entity.DefaultDocumentTemplate, legalEntity.DocumentTemplate())
+	assert.False(t, legalEntity.IsDefault())
+	assert.False(t, legalEntity.HasIssuedInvoices())
+}
+
+func TestNewLegalEntity_Validation(t *testing.T) {
+	testCases := []struct {
+		name      string
+		country   string
+		taxRegs   []entity.TaxRegistration
+		numbering entity.InvoiceNumbering
+		field     string
+	}{
+		{"invalid country", "FRA", nil, entity.InvoiceNumbering{Prefix: "FR-"}, "country"},
+		{"tax registration without number", "FR", []entity.TaxRegistration{{Country: "FR"}}, entity.InvoiceNumbering{Prefix: "FR-"}, "tax_registrations[0].number"},
+		{"missing prefix", "FR", nil, entity.InvoiceNumbering{}, "numbering.prefix"},
+		{"prefix with spaces", "FR", nil, entity.InvoiceNumbering{Prefix: "FR 1"}, "numbering.prefix"},
+		{"padding out of range", "FR", nil, entity.InvoiceNumbering{Prefix: "FR-", Padding: 13}, "numbering.padding"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := entity.NewLegalEntity("Acme SAS", "", tc.country, tc.taxRegs, valueobject.BankAccount{}, tc.numbering, "")
+			require.Error(t, err)
+
+			var validationErr *domainErrors.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tc.field, validationErr.Field)
+		})
+	}
+}
+
+func TestLegalEntity_InvoiceNumbering(t *testing.T) {
+	t.Run("continuous numbering", func(t *testing.T) {
+		legalEntity := newEntity(t, entity.InvoiceNumbering{Prefix: "INV-", Padding: 4})
+
+		assert.Equal(t, "entity:"+legalEntity.ID(), legalEntity.NumberingScope(2026))
+		assert.Equal(t, legalEntity.NumberingScope(2026), legalEntity.NumberingScope(2027))
+		assert.Equal(t, "INV-0001", legalEntity.FormatInvoiceNumber(2026, 1))
+		assert.Equal(t, "INV-12345", legalEntity.FormatInvoiceNumber(2027, 12345))
+	})
+
+	t.Run("yearly reset numbers every year from its own counter", func(t *testing.T) {
+		legalEntity := newEntity(t, entity.InvoiceNumbering{Prefix: "FR-", YearlyReset: true})
+
+		assert.Equal(t, "entity:"+legalEntity.ID()+":2026", legalEntity.NumberingScope(2026))
+		assert.NotEqual(t, legalEntity.NumberingScope(2026), legalEntity.NumberingScope(2027))
+		assert.Equal(t, "FR-2026-000002", legalEntity.FormatInvoiceNumber(2026, 2))
+		assert.Equal(t, "FR-2027-000001", legalEntity.FormatInvoiceNumber(2027, 1))
+	})
+
+	t.Run("numbering is recorded", func(t *testing.T) {
+		legalEntity := newEntity(t, entity.InvoiceNumbering{Prefix: "INV-"})
+		assert.False(t, legalEntity.HasIssuedInvoices())
+		legalEntity.RecordNumbering()
+		assert.True(t, legalEntity.HasIssuedInvoices())
+	})
+}
+
+func TestLegalEntity_Update(t *testing.T) {
+	legalEntity := newEntity(t, entity.InvoiceNumbering{Prefix: "FR-", YearlyReset: true})
+	legalEntity.RecordNumbering()
+
+	t.Run("yearly reset is fixed once numbers were issued", func(t *testing.T) {
+		err := legalEntity.Update("Acme SAS", "", "FR", nil, valueobject.BankAccount{}, entity.InvoiceNumbering{Prefix: "FR-"}, "")
+		require.Error(t, err)
+
+		var validationErr *domainErrors.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "numbering.yearly_reset", validationErr.Field)
+	})
+
+	t.Run("the counter carries on after a prefix change", func(t *testing.T) {
+		scope := legalEntity.NumberingScope(2026)
+		err := legalEntity.Update("Acme France SAS", "", "FR", nil, valueobject.BankAccount{}, entity.InvoiceNumbering{Prefix: "AF-", YearlyReset: true}, "letterhead")
+		require.NoError(t, err)
+
+		assert.Equal(t, "Acme France SAS", legalEntity.Name())
+		assert.Equal(t, "letterhead", legalEntity.DocumentTemplate())
+		assert.Equal(t, scope, legalEntity.NumberingScope(2026))
+		assert.Equal(t, "AF-2026-000002", legalEntity.FormatInvoiceNumber(2026, 2))
+	})
+}
+
+func TestLegalEntity_JSONRoundTrip(t *testing.T) {
+	legalEntity := newEntity(t, entity.InvoiceNumbering{Prefix: "FR-", Padding: 5, YearlyReset: true})
+	legalEntity.SetDefault(true)
+	legalEntity.RecordNumbering()
+
+	data, err := json.Marshal(legalEntity)
+	require.NoError(t, err)
+
+	var restored entity.LegalEntity
+	require.NoError(t, json.Unmarshal(data, &restored))
+
+	assert.Equal(t, legalEntity.ID(), restored.ID())
+	assert.Equal(t, legalEntity.TaxRegistrations(), restored.TaxRegistrations())
+	assert.Equal(t, legalEntity.BankAccount(), restored.BankAccount())
+	assert.Equal(t, legalEntity.Numbering(), restored.Numbering())
+	assert.True(t, restored.IsDefault())
+	assert.True(t, restored.HasIssuedInvoices())
+	assert.Equal(t, legalEntity.NumberingScope(2026), restored.NumberingScope(2026))
+	assert.Equal(t, "FR-2026-00002", restored.FormatInvoiceNumber(2026, 2))
+}
@@ -0,0 +1,135 @@
+// Quote Domain Unit Tests
+//
+// This file contains unit tests for quotes offered to clients and converted to invoices once accepted.
+// Tests: Validation, totals, accept/reject/expire transitions, conversion, JSON round-trip
+// Scope: Pure unit tests - single component (Quote entity) with no external dependencies
+package quote
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var lines = []entity.InvoiceLine{
+	{Description: " Design workshop ", Quantity: 2, UnitAmount: 45000, TaxRateBps: 2000},
+	{Description: "Travel", Quantity: 1, UnitAmount: 12050},
+}
+
+func newQuote(t *testing.T) *entity.Quote {
+	t.Helper()
+	quote, err := entity.NewQuote("client-1", "eur", lines, time.Now().Add(30*24*time.Hour))
+	require.NoError(t, err)
+	return quote
+}
+
+func TestNewQuote(t *testing.T) {
+	quote := newQuote(t)
+
+	assert.NotEmpty(t, quote.ID())
+	assert.Equal(t, "EUR", quote.Currency())
+	assert.Equal(t, entity.QuoteOpen, quote.Status())
+	assert.Equal(t, "Design workshop", quote.Lines()[0].Description)
+
+	totals, err := quote.Totals()
+	require.NoError(t, err)
+	assert.Equal(t, int64(102050), totals.Net.Amount())
+	assert.Equal(t, int64(18000), totals.Tax.Amount())
+	assert.Equal(t, int64(120050), totals.Gross.Amount())
+
+	require.NoError(t, quote.SetTaxTerms(valueobject.TaxInclusive, "fr"))
+	totals, err = quote.Totals()
+	require.NoError(t, err)
+	assert.Equal(t, int64(102050), totals.Gross.Amount())
+	assert.Equal(t, "FR", quote.TaxJurisdiction())
+}
+
+func TestNewQuote_Validation(t *testing.T) {
+	future := time.Now().Add(time.Hour)
+	cases := map[string]func() (*entity.Quote, error){
+		"client":     func() (*entity.Quote, error) { return entity.NewQuote("", "EUR", lines, future) },
+		"currency":   func() (*entity.Quote, error) { return entity.NewQuote("client-1", "EURO", lines, future) },
+		"line items": func() (*entity.Quote, error) { return entity.NewQuote("client-1", "EUR", nil, future) },
+		"quantity": func() (*entity.Quote, error) {
+			return entity.NewQuote("client-1", "EUR", []entity.InvoiceLine{{Description: "Work", Quantity: 0}}, future)
+		},
+		"valid until": func() (*entity.Quote, error) { return entity.NewQuote("client-1", "EUR", lines, time.Time{}) },
+		"past": func() (*entity.Quote, error) {
+			return entity.NewQuote("client-1", "EUR", lines, time.Now().Add(-time.Hour))
+		},
+	}
+	for name, create := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := create()
+			assert.True(t, domainErrors.IsValidationError(err), "expected a validation error, got %v", err)
+		})
+	}
+}
+
+func TestQuote_Transitions(t *testing.T) {
+	now := time.Now()
+
+	t.Run("accepted quotes convert once", func(t *testing.T) {
+		quote := newQuote(t)
+		assert.ErrorIs(t, quote.CanConvert(), domainErrors.ErrQuoteNotAccepted)
+
+		require.NoError(t, quote.Accept(now))
+		assert.Equal(t, entity.QuoteAccepted, quote.Status())
+		assert.ErrorIs(t, quote.Reject("", now), domainErrors.ErrQuoteNotOpen)
+
+		require.NoError(t, quote.MarkConverted("invoice-1", now))
+		assert.True(t, quote.IsConverted())
+		assert.Equal(t, "invoice-1", quote.InvoiceID())
+		assert.ErrorIs(t, quote.MarkConverted("invoice-2", now), domainErrors.ErrQuoteAlreadyConverted)
+	})
+
+	t.Run("rejected quotes cannot be accepted", func(t *testing.T) {
+		quote := newQuote(t)
+		require.NoError(t, quote.Reject(" Too expensive ", now))
+		assert.Equal(t, "Too expensive", quote.RejectionReason())
+		assert.ErrorIs(t, quote.Accept(now), domainErrors.ErrQuoteNotOpen)
+		assert.ErrorIs(t, quote.CanConvert(), domainErrors.ErrQuoteNotAccepted)
+	})
+
+	t.Run("quotes lapse after their validity date", func(t *testing.T) {
+		quote := newQuote(t)
+		later := quote.ValidUntil().Add(time.Second)
+		assert.False(t, quote.IsLapsed(quote.ValidUntil()))
+		assert.True(t, quote.IsLapsed(later))
+		assert.ErrorIs(t, quote.Accept(later), domainErrors.ErrQuoteExpired)
+
+		require.NoError(t, quote.Expire(later))
+		assert.Equal(t, entity.QuoteExpired, quote.Status())
+		assert.False(t, quote.IsLapsed(later))
+		assert.ErrorIs(t, quote.Expire(later), domainErrors.ErrQuoteNotOpen)
+	})
+}
+
+func TestQuote_JSONRoundTrip(t *testing.T) {
+	quote := newQuote(t)
+	quote.AssignTenant("acme")
+	require.NoError(t, quote.SetTaxTerms(valueobject.TaxInclusive, "FR"))
+	require.NoError(t, quote.Accept(time.Now()))
+	require.NoError(t, quote.MarkConverted("invoice-1", time.Now()))
+
+	data, err := json.Marshal(quote)
+	require.NoError(t, err)
+	restored := &entity.Quote{}
+	require.NoError(t, json.Unmarshal(data, restored))
+
+	assert.Equal(t, quote.ID(), restored.ID())
+	assert.Equal(t, "acme", restored.TenantID())
+	assert.Equal(t, quote.Lines(), restored.Lines())
+	assert.Equal(t, valueobject.TaxInclusive, restored.TaxPricing())
+	assert.Equal(t, entity.QuoteAccepted, restored.Status())
+	assert.True(t, quote.ValidUntil().Equal(restored.ValidUntil()))
+	assert.NotNil(t, restored.AcceptedAt())
+	assert.Equal(t, "invoice-1", restored.InvoiceID())
+	assert.NotNil(t, restored.ConvertedAt())
+}
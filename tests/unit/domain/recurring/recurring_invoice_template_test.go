@@ -0,0 +1,164 @@
+// Recurring Invoice Template Domain Unit Tests
+//
+// This file contains unit tests for fixed invoices issued on a schedule.
+// Tests: Validation, schedule computation (month-end clamping, end date), pause/resume, totals, JSON round-trip
+// Scope: Pure unit tests - single component (RecurringInvoiceTemplate entity) with no external dependencies
+package recurring
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+var retainer = []entity.RecurringInvoiceLine{
+	{Description: "Support retainer", Quantity: 1, UnitAmount: 150000},
+	{Description: "Hosting", Quantity: 3, UnitAmount: 2999},
+}
+
+func newTemplate(t *testing.T, frequency entity.RecurrenceFrequency, first time.Time, end *time.Time) *entity.RecurringInvoiceTemplate {
+	t.Helper()
+	template, err := entity.NewRecurringInvoiceTemplate("client-1", "Monthly retainer", "eur", retainer, frequency, first, end, true)
+	require.NoError(t, err)
+	return template
+}
+
+// issueAll marks every invoice due at now as issued and returns their issue dates
+func issueAll(template *entity.RecurringInvoiceTemplate, now time.Time) []time.Time {
+	var dates []time.Time
+	for template.IsDue(now) {
+		next, _ := template.NextIssueDate()
+		dates = append(dates, next)
+		template.MarkIssued(now)
+	}
+	return dates
+}
+
+func TestNewRecurringInvoiceTemplate(t *testing.T) {
+	template := newTemplate(t, entity.RecurrenceMonthly, date(2026, time.January, 15), nil)
+
+	assert.Equal(t, "EUR", template.Currency())
+	assert.True(t, template.IsActive())
+	assert.True(t, template.AutoSend())
+
+	total, err := template.Total()
+	require.NoError(t, err)
+	assert.Equal(t, int64(158997), total.Amount())
+
+	next, ok := template.NextIssueDate()
+	require.True(t, ok)
+	assert.Equal(t, date(2026, time.January, 15), next)
+}
+
+func TestNewRecurringInvoiceTemplate_Validation(t *testing.T) {
+	tests := []struct {
+		name      string
+		frequency entity.RecurrenceFrequency
+		lines     []entity.RecurringInvoiceLine
+		end       *time.Time
+		field     string
+	}{
+		{"unknown frequency", "daily", retainer, nil, "frequency"},
+		{"no line items", entity.RecurrenceMonthly, nil, nil, "line_items"},
+		{"line without description", entity.RecurrenceMonthly, []entity.RecurringInvoiceLine{{Quantity: 1, UnitAmount: 100}}, nil, "line_items[0].description"},
+		{"zero quantity", entity.RecurrenceMonthly, []entity.RecurringInvoiceLine{{Description: "Fee", UnitAmount: 100}}, nil, "line_items[0].quantity"},
+		{"negative unit amount", entity.RecurrenceMonthly, []entity.RecurringInvoiceLine{{Description: "Fee", Quantity: 1, UnitAmount: -1}}, nil, "line_items[0].unit_amount"},
+		{"end before start", entity.RecurrenceMonthly, retainer, func() *time.Time { d := date(2025, time.December, 31); return &d }(), "end_date"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := entity.NewRecurringInvoiceTemplate("client-1", "Retainer", "EUR", tt.lines, tt.frequency, date(2026, time.January, 1), tt.end, false)
+
+			require.Error(t, err)
+			var validationErr *domainErrors.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.field, validationErr.Field)
+		})
+	}
+}
+
+func TestRecurringInvoiceTemplate_Schedule(t *testing.T) {
+	t.Run("month-end dates are clamped without drifting", func(t *testing.T) {
+		template := newTemplate(t, entity.RecurrenceMonthly, date(2026, time.January, 31), nil)
+
+		dates := issueAll(template, date(2026, time.April, 30))
+		assert.Equal(t, []time.Time{
+			date(2026, time.January, 31),
+			date(2026, time.February, 28),
+			date(2026, time.March, 31),
+			date(2026, time.April, 30),
+		}, dates)
+		assert.Equal(t, 4, template.IssuedCount())
+	})
+
+	t.Run("quarterly, yearly and weekly", func(t *testing.T) {
+		quarterly := newTemplate(t, entity.RecurrenceQuarterly, date(2026, time.January, 1), nil)
+		assert.Len(t, issueAll(quarterly, date(2026, time.December, 31)), 4)
+
+		yearly := newTemplate(t, entity.RecurrenceYearly, date(2024, time.February, 29), nil)
+		assert.Equal(t, []time.Time{date(2024, time.February, 29), date(2025, time.February, 28)}, issueAll(yearly, date(2025, time.March, 1)))
+
+		weekly := newTemplate(t, entity.RecurrenceWeekly, date(2026, time.January, 5), nil)
+		assert.Len(t, issueAll(weekly, date(2026, time.January, 31)), 4)
+	})
+
+	t.Run("nothing is issued after the end date", func(t *testing.T) {
+		end := date(2026, time.March, 15)
+		template := newTemplate(t, entity.RecurrenceMonthly, date(2026, time.January, 1), &end)
+
+		assert.Len(t, issueAll(template, date(2026, time.December, 1)), 3)
+		_, ok := template.NextIssueDate()
+		assert.False(t, ok)
+	})
+
+	t.Run("not due before the first issue date", func(t *testing.T) {
+		template := newTemplate(t, entity.RecurrenceMonthly, date(2026, time.January, 15), nil)
+		assert.False(t, template.IsDue(date(2026, time.January, 14)))
+	})
+}
+
+func TestRecurringInvoiceTemplate_PauseResume(t *testing.T) {
+	template := newTemplate(t, entity.RecurrenceMonthly, date(2026, time.January, 1), nil)
+	issueAll(template, date(2026, time.January, 1))
+
+	template.Pause()
+	assert.False(t, template.IsDue(date(2026, time.April, 10)), "paused templates are never due")
+
+	template.Resume(date(2026, time.April, 10))
+	next, ok := template.NextIssueDate()
+	require.True(t, ok)
+	assert.Equal(t, date(2026, time.May, 1), next, "dates missed while paused are skipped")
+	assert.Equal(t, 1, template.IssuedCount())
+}
+
+func TestRecurringInvoiceTemplate_JSONRoundTrip(t *testing.T) {
+	end := date(2027, time.January, 1)
+	template := newTemplate(t, entity.RecurrenceMonthly, date(2026, time.January, 1), &end)
+	issueAll(template, date(2026, time.February, 1))
+
+	data, err := json.Marshal(template)
+	require.NoError(t, err)
+
+	var restored entity.RecurringInvoiceTemplate
+	require.NoError(t, json.Unmarshal(data, &restored))
+
+	assert.Equal(t, template.ID(), restored.ID())
+	assert.Equal(t, template.Lines(), restored.Lines())
+	assert.Equal(t, template.Frequency(), restored.Frequency())
+	assert.True(t, end.Equal(*restored.EndDate()))
+	assert.Equal(t, 2, restored.IssuedCount())
+
+	next, ok := restored.NextIssueDate()
+	require.True(t, ok)
+	assert.Equal(t, date(2026, time.March, 1), next)
+}
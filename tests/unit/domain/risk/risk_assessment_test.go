@@ -0,0 +1,98 @@
+// Client Risk Assessment Domain Unit Tests
+//
+// This file contains unit tests for cached client risk scores and the score bands deciding them.
+// Tests: Assessment validation, expiry, JSON round-trip, decisions derived from scores
+// Scope: Pure unit tests - RiskAssessment entity and risk scoring domain service with no external dependencies
+package risk
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRiskAssessment_Validation(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		clientID string
+		provider string
+		score    int
+		decision entity.RiskDecision
+		trigger  entity.RiskTrigger
+		ttl      time.Duration
+		field    string
+	}{
+		{name: "missing client", clientID: "", provider: "bureau", score: 10, decision: entity.RiskDecisionApprove, trigger: entity.RiskTriggerOnboarding, ttl: time.Hour, field: "client_id"},
+		{name: "missing provider", clientID: "client-1", provider: " ", score: 10, decision: entity.RiskDecisionApprove, trigger: entity.RiskTriggerOnboarding, ttl: time.Hour, field: "provider"},
+		{name: "negative score", clientID: "client-1", provider: "bureau", score: -1, decision: entity.RiskDecisionApprove, trigger: entity.RiskTriggerOnboarding, ttl: time.Hour, field: "score"},
+		{name: "score above the maximum", clientID: "client-1", provider: "bureau", score: 101, decision: entity.RiskDecisionDecline, trigger: entity.RiskTriggerOnboarding, ttl: time.Hour, field: "score"},
+		{name: "unknown decision", clientID: "client-1", provider: "bureau", score: 10, decision: "maybe", trigger: entity.RiskTriggerOnboarding, ttl: time.Hour, field: "decision"},
+		{name: "unknown trigger", clientID: "client-1", provider: "bureau", score: 10, decision: entity.RiskDecisionApprove, trigger: "manual", ttl: time.Hour, field: "trigger"},
+		{name: "no TTL", clientID: "client-1", provider: "bureau", score: 10, decision: entity.RiskDecisionApprove, trigger: entity.RiskTriggerOnboarding, ttl: 0, field: "ttl"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := entity.NewRiskAssessment(tt.clientID, tt.provider, tt.score, tt.decision, nil, tt.trigger, now, tt.ttl)
+
+			var validationErr *domainErrors.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.field, validationErr.Field)
+		})
+	}
+}
+
+func TestRiskAssessment_IsExpired(t *testing.T) {
+	assessedAt := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	assessment, err := entity.NewRiskAssessment("client-1", "bureau", 30, entity.RiskDecisionApprove, nil, entity.RiskTriggerOnboarding, assessedAt, 24*time.Hour)
+	require.NoError(t, err)
+
+	assert.Equal(t, assessedAt.Add(24*time.Hour), assessment.ExpiresAt())
+	assert.False(t, assessment.IsExpired(assessedAt.Add(23*time.Hour)))
+	assert.True(t, assessment.IsExpired(assessedAt.Add(24*time.Hour)))
+}
+
+func TestRiskAssessment_JSONRoundTrip(t *testing.T) {
+	reasons := []entity.RiskReason{{Code: "LATE_PAYMENTS", Description: "Two late payments in the last year"}, {Code: "NEW_COMPANY"}}
+	assessment, err := entity.NewRiskAssessment("client-1", "bureau", 55, entity.RiskDecisionReview, reasons, entity.RiskTriggerLargeInvoice, time.Now(), time.Hour)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(assessment)
+	require.NoError(t, err)
+
+	var restored entity.RiskAssessment
+	require.NoError(t, json.Unmarshal(data, &restored))
+	assert.Equal(t, "client-1", restored.ClientID())
+	assert.Equal(t, "bureau", restored.Provider())
+	assert.Equal(t, 55, restored.Score())
+	assert.Equal(t, entity.RiskDecisionReview, restored.Decision())
+	assert.Equal(t, reasons, restored.Reasons())
+	assert.Equal(t, entity.RiskTriggerLargeInvoice, restored.Trigger())
+	assert.True(t, assessment.ExpiresAt().Equal(restored.ExpiresAt()))
+}
+
+func TestDecideRisk(t *testing.T) {
+	tests := []struct {
+		name     string
+		score    service.RiskScore
+		decision entity.RiskDecision
+	}{
+		{name: "low score", score: service.RiskScore{Score: 10}, decision: entity.RiskDecisionApprove},
+		{name: "review band", score: service.RiskScore{Score: service.RiskReviewScore}, decision: entity.RiskDecisionReview},
+		{name: "decline band", score: service.RiskScore{Score: service.RiskDeclineScore}, decision: entity.RiskDecisionDecline},
+		{name: "provider decision wins", score: service.RiskScore{Score: 90, Decision: entity.RiskDecisionReview}, decision: entity.RiskDecisionReview},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.decision, service.DecideRisk(tt.score))
+		})
+	}
+}
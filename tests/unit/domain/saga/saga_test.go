@@ -0,0 +1,126 @@
+// Saga Domain Unit Tests
+//
+// This file contains unit tests for the persisted state of multi-step flows.
+// Tests: Step progression, failed attempts, compensation in reverse order, stuck detection, JSON round-trip
+// Scope: Pure unit tests - Saga entity with no external dependencies
+package saga
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var startedAt = time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+func newSaga(t *testing.T) *entity.Saga {
+	t.Helper()
+	saga, err := entity.NewSaga("invoice_payment", "INV-1", []string{"charge", "mark_paid", "notify"}, map[string]string{"amount": "1200"}, startedAt)
+	require.NoError(t, err)
+	return saga
+}
+
+func TestNewSaga_Validation(t *testing.T) {
+	_, err := entity.NewSaga(" ", "INV-1", []string{"charge"}, nil, startedAt)
+	assert.Equal(t, domainErrors.ValidationRequired, domainErrors.GetErrorCode(err))
+
+	_, err = entity.NewSaga("invoice_payment", "", []string{"charge"}, nil, startedAt)
+	assert.Equal(t, domainErrors.ValidationRequired, domainErrors.GetErrorCode(err))
+
+	_, err = entity.NewSaga("invoice_payment", "INV-1", nil, nil, startedAt)
+	assert.Equal(t, domainErrors.ValidationRequired, domainErrors.GetErrorCode(err))
+}
+
+func TestSaga_CompletesStepsInOrder(t *testing.T) {
+	saga := newSaga(t)
+	assert.Equal(t, entity.SagaRunning, saga.Status())
+	assert.Equal(t, 0, saga.CurrentStep())
+
+	require.NoError(t, saga.CompleteStep(map[string]string{"charge_id": "ch_1"}, startedAt.Add(time.Second)))
+	assert.Equal(t, "ch_1", saga.Value("charge_id"))
+	assert.Equal(t, "1200", saga.Value("amount"))
+	assert.Equal(t, 1, saga.CurrentStep())
+
+	require.NoError(t, saga.CompleteStep(nil, startedAt.Add(2*time.Second)))
+	require.NoError(t, saga.CompleteStep(nil, startedAt.Add(3*time.Second)))
+	assert.Equal(t, entity.SagaCompleted, saga.Status())
+	assert.True(t, saga.IsFinished())
+	assert.Equal(t, -1, saga.CurrentStep())
+
+	assert.Equal(t, domainErrors.ErrSagaNotRunning, saga.CompleteStep(nil, startedAt))
+}
+
+func TestSaga_CompensatesAfterMaxAttempts(t *testing.T) {
+	saga := newSaga(t)
+	require.NoError(t, saga.CompleteStep(nil, startedAt))
+
+	require.NoError(t, saga.FailStep("bus unavailable", startedAt.Add(time.Minute), 2, false))
+	assert.Equal(t, entity.SagaRunning, saga.Status())
+	assert.Equal(t, "bus unavailable", saga.LastError())
+
+	require.NoError(t, saga.FailStep("bus unavailable", startedAt.Add(2*time.Minute), 2, false))
+	assert.Equal(t, entity.SagaCompensating, saga.Status())
+	assert.Equal(t, 0, saga.CurrentStep(), "the completed charge is compensated next")
+	assert.Equal(t, entity.SagaStepFailed, saga.Steps()[1].Status)
+
+	require.NoError(t, saga.FailCompensation("gateway timeout", startedAt.Add(3*time.Minute)))
+	assert.Equal(t, entity.SagaCompensating, saga.Status())
+	assert.Equal(t, "gateway timeout", saga.LastError())
+
+	require.NoError(t, saga.CompensateStep(startedAt.Add(4*time.Minute)))
+	assert.Equal(t, entity.SagaCompensated, saga.Status())
+	assert.Equal(t, entity.SagaStepCompensated, saga.Steps()[0].Status)
+	assert.Equal(t, domainErrors.ErrSagaNotCompensating, saga.CompensateStep(startedAt))
+}
+
+func TestSaga_RetriableStepsNeverCompensate(t *testing.T) {
+	saga := newSaga(t)
+	require.NoError(t, saga.CompleteStep(nil, startedAt))
+	require.NoError(t, saga.CompleteStep(nil, startedAt))
+
+	for i := 0; i < 10; i++ {
+		require.NoError(t, saga.FailStep("mailer down", startedAt, 2, true))
+	}
+	assert.Equal(t, entity.SagaRunning, saga.Status())
+	assert.Equal(t, 10, saga.Steps()[2].Attempts)
+}
+
+func TestSaga_FirstStepFailingForGoodIsCompensatedRightAway(t *testing.T) {
+	saga := newSaga(t)
+	require.NoError(t, saga.FailStep("card declined", startedAt, 1, false))
+	assert.Equal(t, entity.SagaCompensated, saga.Status())
+}
+
+func TestSaga_IsStuck(t *testing.T) {
+	saga := newSaga(t)
+	assert.False(t, saga.IsStuck(startedAt.Add(10*time.Minute), 15*time.Minute))
+	assert.True(t, saga.IsStuck(startedAt.Add(15*time.Minute), 15*time.Minute))
+
+	require.NoError(t, saga.CompleteStep(nil, startedAt.Add(20*time.Minute)))
+	assert.False(t, saga.IsStuck(startedAt.Add(30*time.Minute), 15*time.Minute), "progress resets the threshold")
+}
+
+func TestSaga_JSONRoundTrip(t *testing.T) {
+	saga := newSaga(t)
+	require.NoError(t, saga.CompleteStep(map[string]string{"charge_id": "ch_1"}, startedAt.Add(time.Second)))
+	require.NoError(t, saga.FailStep("bus unavailable", startedAt.Add(time.Minute), 5, false))
+
+	data, err := json.Marshal(saga)
+	require.NoError(t, err)
+
+	var restored entity.Saga
+	require.NoError(t, json.Unmarshal(data, &restored))
+	assert.Equal(t, saga.ID(), restored.ID())
+	assert.Equal(t, saga.Type(), restored.Type())
+	assert.Equal(t, saga.Reference(), restored.Reference())
+	assert.Equal(t, saga.Status(), restored.Status())
+	assert.Equal(t, saga.Data(), restored.Data())
+	assert.Equal(t, saga.Steps(), restored.Steps())
+	assert.Equal(t, saga.CurrentStep(), restored.CurrentStep())
+	assert.Equal(t, saga.UpdatedAt(), restored.UpdatedAt())
+}
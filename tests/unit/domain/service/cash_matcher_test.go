@@ -0,0 +1,52 @@
+// Cash Matcher Domain Service Unit Tests
+//
+// This file contains tests for suggesting the open invoices a bank transaction pays.
+// Tests: Reference, exact amount and counterparty heuristics, ranking, currency filtering, threshold
+// Scope: Pure unit tests - single domain service with no external dependencies
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuggestCashMatches(t *testing.T) {
+	transaction, err := entity.NewBankTransaction("DE89370400440532013000", "BANKREF-1",
+		time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), eur(t, 48000), "Payment inv 2026 0043 thanks", "GLOBEX CORP", "")
+	require.NoError(t, err)
+
+	openInvoices := []service.OpenInvoice{
+		{InvoiceID: "inv-amount", Number: "INV-2026-0001", ClientID: "c1", ClientName: "Initech", Outstanding: eur(t, 48000)},
+		{InvoiceID: "inv-reference", Number: "INV-2026/0043", ClientID: "c2", ClientName: "Globex Corp", Outstanding: eur(t, 50000)},
+		{InvoiceID: "inv-name-only", Number: "INV-2026-0002", ClientID: "c2", ClientName: "Globex Corp", Outstanding: eur(t, 1000)},
+		{InvoiceID: "inv-other", Number: "INV-2026-0003", ClientID: "c3", ClientName: "Hooli", Outstanding: eur(t, 2000)},
+	}
+
+	matches := service.SuggestCashMatches(transaction, openInvoices)
+
+	require.Len(t, matches, 2, "a counterparty match alone is below the threshold")
+	assert.Equal(t, "inv-reference", matches[0].Invoice.InvoiceID)
+	assert.Equal(t, 70, matches[0].Score)
+	assert.Equal(t, []string{"reference", "counterparty"}, matches[0].Reasons)
+	assert.Equal(t, "inv-amount", matches[1].Invoice.InvoiceID)
+	assert.Equal(t, []string{"exact_amount"}, matches[1].Reasons)
+}
+
+func TestSuggestCashMatches_IgnoresOtherCurrencies(t *testing.T) {
+	transaction, err := entity.NewBankTransaction("", "", time.Now(), eur(t, 48000), "INV-1", "", "")
+	require.NoError(t, err)
+
+	dollars, err := valueobject.NewMoney(48000, "USD")
+	require.NoError(t, err)
+	openInvoices := []service.OpenInvoice{
+		{InvoiceID: "inv-usd", Number: "INV-1", Outstanding: dollars},
+	}
+
+	assert.Empty(t, service.SuggestCashMatches(transaction, openInvoices))
+}
@@ -0,0 +1,113 @@
+// Client Deletion Policy Domain Service Unit Tests
+//
+// This file contains tests for the rules applied to the invoices of a client being deleted.
+// Tests: Open invoices blocking, draft cascade and block, orphan protection of closed invoices, rule validation
+// Scope: Pure unit tests - single domain service with no external dependencies
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newClientInvoice creates an invoice of a client in the given status
+func newClientInvoice(t *testing.T, clientID string, status entity.InvoiceStatus) *entity.Invoice {
+	t.Helper()
+	invoice, err := entity.NewInvoice(clientID, "EUR", []entity.InvoiceLine{{Description: "Consulting", Quantity: 1, UnitAmount: 10000}}, nil)
+	require.NoError(t, err)
+	now := time.Now()
+	switch status {
+	case entity.InvoiceIssued:
+		require.NoError(t, invoice.Issue(now))
+	case entity.InvoiceVoid:
+		require.NoError(t, invoice.Issue(now))
+		require.NoError(t, invoice.Void(now))
+	}
+	return invoice
+}
+
+// ruleOf returns the rule and code of a business rule error
+func ruleOf(t *testing.T, err error) (string, domainErrors.ErrorCode) {
+	t.Helper()
+	ruleErr, ok := err.(*domainErrors.BusinessRuleError)
+	require.True(t, ok, "expected a business rule error, got %v", err)
+	return ruleErr.Rule, ruleErr.Code
+}
+
+func TestClientDeletionPolicy_Cascade(t *testing.T) {
+	draft := newClientInvoice(t, "client-1", entity.InvoiceDraft)
+	issued := newClientInvoice(t, "client-1", entity.InvoiceIssued)
+	void := newClientInvoice(t, "client-1", entity.InvoiceVoid)
+	other := newClientInvoice(t, "client-2", entity.InvoiceIssued)
+
+	// Voided before being issued: already soft-deleted, not a record
+	discarded := newClientInvoice(t, "client-1", entity.InvoiceDraft)
+	require.NoError(t, discarded.Void(time.Now()))
+
+	policy := service.DefaultClientDeletionPolicy()
+
+	t.Run("no invoices", func(t *testing.T) {
+		cascade, err := policy.Cascade("client-1", []*entity.Invoice{other, discarded})
+		require.NoError(t, err)
+		assert.Empty(t, cascade)
+	})
+
+	t.Run("drafts cascade", func(t *testing.T) {
+		cascade, err := policy.Cascade("client-1", []*entity.Invoice{draft, other})
+		require.NoError(t, err)
+		require.Len(t, cascade, 1)
+		assert.Equal(t, draft.ID(), cascade[0].ID())
+	})
+
+	t.Run("open invoices block", func(t *testing.T) {
+		_, err := policy.Cascade("client-1", []*entity.Invoice{draft, issued, void})
+		rule, code := ruleOf(t, err)
+		assert.Equal(t, "client_open_invoices", rule)
+		assert.Equal(t, domainErrors.BusinessRuleDependent, code)
+		assert.Equal(t, []string{issued.ID()}, err.(*domainErrors.BusinessRuleError).Context["invoice_ids"])
+	})
+
+	t.Run("orphan protection", func(t *testing.T) {
+		_, err := policy.Cascade("client-1", []*entity.Invoice{draft, void})
+		rule, code := ruleOf(t, err)
+		assert.Equal(t, "client_invoice_orphans", rule)
+		assert.Equal(t, domainErrors.BusinessRuleViolation, code)
+
+		retain, err := service.NewClientDeletionPolicy("", "retain")
+		require.NoError(t, err)
+		cascade, err := retain.Cascade("client-1", []*entity.Invoice{draft, void})
+		require.NoError(t, err)
+		assert.Len(t, cascade, 1)
+	})
+
+	t.Run("drafts block", func(t *testing.T) {
+		block, err := service.NewClientDeletionPolicy("block", "")
+		require.NoError(t, err)
+		_, err = block.Cascade("client-1", []*entity.Invoice{draft})
+		rule, code := ruleOf(t, err)
+		assert.Equal(t, "client_draft_invoices", rule)
+		assert.Equal(t, domainErrors.BusinessRuleDependent, code)
+	})
+}
+
+func TestNewClientDeletionPolicy(t *testing.T) {
+	policy, err := service.NewClientDeletionPolicy("", "")
+	require.NoError(t, err)
+	assert.Equal(t, service.DefaultClientDeletionPolicy(), policy)
+
+	policy, err = service.NewClientDeletionPolicy(" Block ", "RETAIN")
+	require.NoError(t, err)
+	assert.Equal(t, service.ClientDeletionBlock, policy.Drafts)
+	assert.Equal(t, service.ClientDeletionRetain, policy.Closed)
+
+	_, err = service.NewClientDeletionPolicy("retain", "")
+	assert.True(t, domainErrors.IsValidationError(err))
+	_, err = service.NewClientDeletionPolicy("", "cascade")
+	assert.True(t, domainErrors.IsValidationError(err))
+}
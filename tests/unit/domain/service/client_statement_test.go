@@ -0,0 +1,85 @@
+// Client Statement Domain Service Unit Tests
+//
+// This file contains tests for laying out the account activity of a client over a date range.
+// Tests: Opening balance, running balance, same-day ordering, range and currency filtering, invalid input
+// Scope: Pure unit tests - single domain service with no external dependencies
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildClientStatement(t *testing.T) {
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2026, month, d, 0, 0, 0, 0, time.UTC)
+	}
+	usd, err := valueobject.NewMoney(9900, "USD")
+	require.NoError(t, err)
+
+	entries := []service.StatementEntry{
+		{Date: day(3, 10), Kind: service.StatementPayment, Reference: "PAY-2", Amount: eur(t, 20000)},
+		{Date: day(1, 15), Kind: service.StatementInvoice, Reference: "INV-0001", Amount: eur(t, 50000)},
+		{Date: day(2, 1), Kind: service.StatementPayment, Reference: "PAY-1", Amount: eur(t, 30000)},
+		{Date: day(3, 10), Kind: service.StatementInvoice, Reference: "INV-0002", Amount: eur(t, 40000)},
+		{Date: day(3, 20), Kind: service.StatementCreditNote, Reference: "CN-0001", Amount: eur(t, 5000)},
+		{Date: day(3, 5), Kind: service.StatementInvoice, Reference: "INV-USD", Amount: usd},
+		{Date: day(4, 2), Kind: service.StatementInvoice, Reference: "INV-0003", Amount: eur(t, 70000)},
+	}
+
+	t.Run("opens with the balance before the range and runs it line by line", func(t *testing.T) {
+		statement, err := service.BuildClientStatement("eur", day(3, 1), day(3, 31), entries)
+		require.NoError(t, err)
+
+		assert.Equal(t, "EUR", statement.Currency)
+		assert.Equal(t, int64(20000), statement.OpeningBalance.Amount())
+		require.Len(t, statement.Lines, 3)
+
+		// Invoices come before the payments of the same day
+		assert.Equal(t, "INV-0002", statement.Lines[0].Reference)
+		assert.Equal(t, int64(60000), statement.Lines[0].Balance.Amount())
+		assert.Equal(t, "PAY-2", statement.Lines[1].Reference)
+		assert.Equal(t, int64(40000), statement.Lines[1].Balance.Amount())
+		assert.Equal(t, "CN-0001", statement.Lines[2].Reference)
+		assert.Equal(t, int64(35000), statement.Lines[2].Balance.Amount())
+
+		assert.Equal(t, int64(40000), statement.Invoiced.Amount())
+		assert.Equal(t, int64(25000), statement.Paid.Amount())
+		assert.Equal(t, int64(35000), statement.ClosingBalance.Amount())
+	})
+
+	t.Run("empty activity gives a zero statement", func(t *testing.T) {
+		statement, err := service.BuildClientStatement("EUR", day(1, 1), day(1, 31), nil)
+		require.NoError(t, err)
+
+		assert.Empty(t, statement.Lines)
+		assert.True(t, statement.OpeningBalance.IsZero())
+		assert.True(t, statement.ClosingBalance.IsZero())
+	})
+
+	t.Run("statement in the other currency only lists its entries", func(t *testing.T) {
+		statement, err := service.BuildClientStatement("USD", day(1, 1), day(12, 31), entries)
+		require.NoError(t, err)
+
+		require.Len(t, statement.Lines, 1)
+		assert.Equal(t, int64(9900), statement.ClosingBalance.Amount())
+	})
+
+	t.Run("rejects an end before the start", func(t *testing.T) {
+		_, err := service.BuildClientStatement("EUR", day(3, 31), day(3, 1), entries)
+		assert.True(t, errors.IsValidationError(err))
+	})
+
+	t.Run("rejects unknown entry kinds", func(t *testing.T) {
+		_, err := service.BuildClientStatement("EUR", day(1, 1), day(1, 31), []service.StatementEntry{
+			{Date: day(1, 2), Kind: "refund", Amount: eur(t, 100)},
+		})
+		assert.True(t, errors.IsValidationError(err))
+	})
+}
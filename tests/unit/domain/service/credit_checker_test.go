@@ -0,0 +1,87 @@
+// Credit Checker Domain Service Unit Tests
+//
+// This file contains tests for client exposure and credit limit checks on invoice issuance.
+// Tests: Exposure per currency, unapplied credit, limit boundary, over-limit policies, other currencies
+// Scope: Pure unit tests - single domain service with no external dependencies
+package service
+
+import (
+	"testing"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculateExposure(t *testing.T) {
+	usd, err := valueobject.NewMoney(9900, "USD")
+	require.NoError(t, err)
+
+	exposures := service.CalculateExposure("client-1",
+		[]service.OpenInvoice{
+			{InvoiceID: "inv-1", ClientID: "client-1", Outstanding: eur(t, 30000)},
+			{InvoiceID: "inv-2", ClientID: "client-1", Outstanding: eur(t, 18000)},
+			{InvoiceID: "inv-3", ClientID: "client-1", Outstanding: usd},
+			{InvoiceID: "inv-4", ClientID: "client-2", Outstanding: eur(t, 500000)},
+		},
+		[]service.UnappliedCredit{
+			{Reference: "CN-1", ClientID: "client-1", Amount: eur(t, 5000)},
+			{Reference: "CN-2", ClientID: "client-2", Amount: eur(t, 7000)},
+		},
+	)
+
+	require.Len(t, exposures, 2)
+	assert.Equal(t, "EUR", exposures[0].Currency)
+	assert.Equal(t, int64(48000), exposures[0].OpenInvoices.Amount())
+	assert.Equal(t, 2, exposures[0].OpenInvoiceCount)
+	assert.Equal(t, int64(5000), exposures[0].UnappliedCredit.Amount())
+	assert.Equal(t, int64(43000), exposures[0].Exposure().Amount())
+	assert.Equal(t, "USD", exposures[1].Currency)
+	assert.Equal(t, int64(9900), exposures[1].Exposure().Amount())
+
+	t.Run("clients in credit have a negative exposure", func(t *testing.T) {
+		exposures := service.CalculateExposure("client-1", nil, []service.UnappliedCredit{
+			{Reference: "CN-1", ClientID: "client-1", Amount: eur(t, 5000)},
+		})
+		assert.Equal(t, int64(-5000), service.ExposureIn(exposures, "EUR").Exposure().Amount())
+	})
+
+	t.Run("currencies without exposure are zero", func(t *testing.T) {
+		exposure := service.ExposureIn(exposures, "GBP")
+		assert.Equal(t, "GBP", exposure.Currency)
+		assert.True(t, exposure.Exposure().IsZero())
+	})
+}
+
+func TestCheckCredit(t *testing.T) {
+	exposure := service.ExposureIn(service.CalculateExposure("client-1",
+		[]service.OpenInvoice{{InvoiceID: "inv-1", ClientID: "client-1", Outstanding: eur(t, 40000)}}, nil), "EUR")
+
+	limitWith := func(policy entity.CreditPolicy) *entity.ClientCreditLimit {
+		limit, err := entity.NewClientCreditLimit("client-1", eur(t, 100000), policy)
+		require.NoError(t, err)
+		return limit
+	}
+
+	t.Run("without a limit", func(t *testing.T) {
+		assert.Equal(t, service.CreditWithinLimit, service.CheckCredit(nil, exposure, eur(t, 1000000)))
+	})
+
+	t.Run("up to the limit", func(t *testing.T) {
+		assert.Equal(t, service.CreditWithinLimit, service.CheckCredit(limitWith(entity.CreditPolicyBlock), exposure, eur(t, 60000)))
+	})
+
+	t.Run("over the limit follows the policy", func(t *testing.T) {
+		assert.Equal(t, service.CreditOverLimitBlocked, service.CheckCredit(limitWith(entity.CreditPolicyBlock), exposure, eur(t, 60001)))
+		assert.Equal(t, service.CreditOverLimitWarned, service.CheckCredit(limitWith(entity.CreditPolicyWarn), exposure, eur(t, 60001)))
+		assert.Equal(t, service.CreditApprovalRequired, service.CheckCredit(limitWith(entity.CreditPolicyRequireApproval), exposure, eur(t, 60001)))
+	})
+
+	t.Run("invoices in another currency are not covered", func(t *testing.T) {
+		usd, err := valueobject.NewMoney(1000000, "USD")
+		require.NoError(t, err)
+		assert.Equal(t, service.CreditWithinLimit, service.CheckCredit(limitWith(entity.CreditPolicyBlock), service.ExposureIn(nil, "USD"), usd))
+	})
+}
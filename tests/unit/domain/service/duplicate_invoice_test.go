@@ -0,0 +1,89 @@
+// Duplicate Invoice Check Domain Service Unit Tests
+//
+// This file contains tests for recognizing invoices created twice for the same client.
+// Tests: External reference match, identical line items within the window, other clients and tenants
+// Scope: Pure unit tests - single domain service with no external dependencies
+package service
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newInvoice creates a template of a client created age ago
+func newInvoice(t *testing.T, tenantID, clientID, reference string, lines []entity.RecurringInvoiceLine, age time.Duration) *entity.RecurringInvoiceTemplate {
+	t.Helper()
+	template, err := entity.NewRecurringInvoiceTemplate(clientID, "Retainer", "EUR", lines, entity.RecurrenceMonthly, time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC), nil, false)
+	require.NoError(t, err)
+	require.NoError(t, template.SetExternalReference(reference))
+	template.AssignTenant(tenantID)
+
+	// Backdate the creation through the persisted form
+	data, err := json.Marshal(template)
+	require.NoError(t, err)
+	var fields map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &fields))
+	fields["createdAt"] = template.CreatedAt().Add(-age)
+	data, err = json.Marshal(fields)
+	require.NoError(t, err)
+	backdated := &entity.RecurringInvoiceTemplate{}
+	require.NoError(t, json.Unmarshal(data, backdated))
+	return backdated
+}
+
+func TestDuplicateInvoiceCheck(t *testing.T) {
+	retainer := []entity.RecurringInvoiceLine{
+		{Description: "Retainer", Quantity: 1, UnitAmount: 150000},
+		{Description: "Hosting", Quantity: 2, UnitAmount: 2500, TaxRateBps: 2000},
+	}
+	reordered := []entity.RecurringInvoiceLine{retainer[1], {Description: "retainer", Quantity: 1, UnitAmount: 150000}}
+	other := []entity.RecurringInvoiceLine{{Description: "Retainer", Quantity: 1, UnitAmount: 160000}}
+	check := service.DuplicateInvoiceCheck{LineItemWindow: 24 * time.Hour}
+
+	t.Run("same external reference, however old", func(t *testing.T) {
+		existing := newInvoice(t, "acme", "client-1", "PO-1001", other, 90*24*time.Hour)
+		candidate := newInvoice(t, "acme", "client-1", "po-1001", retainer, 0)
+
+		duplicate, match := check.FindDuplicate(candidate, []*entity.RecurringInvoiceTemplate{existing, candidate})
+		require.NotNil(t, duplicate)
+		assert.Equal(t, existing.ID(), duplicate.ID())
+		assert.Equal(t, service.DuplicateByReference, match)
+	})
+
+	t.Run("identical line items in any order within the window", func(t *testing.T) {
+		older := newInvoice(t, "acme", "client-1", "", retainer, 48*time.Hour)
+		recent := newInvoice(t, "acme", "client-1", "", retainer, time.Hour)
+		earliest := newInvoice(t, "acme", "client-1", "", reordered, 2*time.Hour)
+		candidate := newInvoice(t, "acme", "client-1", "", retainer, 0)
+
+		duplicate, match := check.FindDuplicate(candidate, []*entity.RecurringInvoiceTemplate{older, recent, earliest})
+		require.NotNil(t, duplicate)
+		assert.Equal(t, earliest.ID(), duplicate.ID())
+		assert.Equal(t, service.DuplicateByLineItems, match)
+
+		duplicate, _ = check.FindDuplicate(candidate, []*entity.RecurringInvoiceTemplate{older})
+		assert.Nil(t, duplicate, "outside the window")
+
+		duplicate, _ = service.DuplicateInvoiceCheck{}.FindDuplicate(candidate, []*entity.RecurringInvoiceTemplate{recent})
+		assert.Nil(t, duplicate, "line items are not compared without a window")
+	})
+
+	t.Run("other clients, tenants and line items are not duplicates", func(t *testing.T) {
+		candidate := newInvoice(t, "acme", "client-1", "PO-1001", retainer, 0)
+		existing := []*entity.RecurringInvoiceTemplate{
+			newInvoice(t, "acme", "client-2", "PO-1001", retainer, time.Hour),
+			newInvoice(t, "globex", "client-1", "PO-1001", retainer, time.Hour),
+			newInvoice(t, "acme", "client-1", "PO-1002", other, time.Hour),
+		}
+
+		duplicate, match := check.FindDuplicate(candidate, existing)
+		assert.Nil(t, duplicate)
+		assert.Empty(t, match)
+	})
+}
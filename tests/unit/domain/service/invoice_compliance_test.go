@@ -0,0 +1,116 @@
+// Invoice Compliance Domain Service Unit Tests
+//
+// This file contains unit tests for the country-aware invoice compliance rules.
+// Tests: Rule matching by seller/buyer country, reverse charge, tax breakdown, legal mentions, rule validation
+// Scope: Pure unit tests - domain services with no external dependencies
+package service
+
+import (
+	"testing"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluateCompliance(t *testing.T) {
+	rules := service.DefaultComplianceRules()
+
+	t.Run("domestic EU invoices need the seller VAT number and a breakdown per rate", func(t *testing.T) {
+		result := service.EvaluateCompliance(service.ComplianceInvoice{
+			SellerCountry: "DE",
+			BuyerCountry:  "DE",
+			TaxRatesBps:   []int64{1900, 700},
+		}, rules)
+
+		assert.Equal(t, []string{"eu_vat_invoice"}, result.Rules)
+		assert.Equal(t, service.TaxBreakdownPerRate, result.TaxBreakdown)
+		assert.Empty(t, result.Mentions)
+		require.False(t, result.Compliant())
+		assert.Equal(t, "seller.tax_id", result.Violations[0].Field)
+		assert.Error(t, result.Err())
+	})
+
+	t.Run("untaxed cross-border EU invoices are reverse charged", func(t *testing.T) {
+		invoice := service.ComplianceInvoice{
+			SellerCountry: "FR",
+			SellerTaxIDs:  []string{"FR40303265045"},
+			BuyerCountry:  "de",
+			TaxRatesBps:   []int64{0},
+		}
+
+		result := service.EvaluateCompliance(invoice, rules)
+		assert.Equal(t, []string{"eu_vat_invoice", "eu_reverse_charge", "fr_late_payment"}, result.Rules)
+		assert.Len(t, result.Mentions, 3)
+		assert.Contains(t, result.Mentions[0], "Reverse charge")
+		require.Len(t, result.Violations, 1)
+		assert.Equal(t, "eu_reverse_charge", result.Violations[0].Rule)
+		assert.Equal(t, "buyer_tax_id", result.Violations[0].Field)
+
+		invoice.BuyerTaxID = "DE136695976"
+		result = service.EvaluateCompliance(invoice, rules)
+		assert.True(t, result.Compliant())
+		assert.NoError(t, result.Err())
+	})
+
+	t.Run("taxed cross-border invoices are not reverse charged", func(t *testing.T) {
+		result := service.EvaluateCompliance(service.ComplianceInvoice{
+			SellerCountry: "NL",
+			SellerTaxIDs:  []string{"NL123456789B01"},
+			BuyerCountry:  "BE",
+			TaxRatesBps:   []int64{2100},
+		}, rules)
+
+		assert.Equal(t, []string{"eu_vat_invoice"}, result.Rules)
+		assert.True(t, result.Compliant())
+	})
+
+	t.Run("exports outside the EU carry the out of scope mention", func(t *testing.T) {
+		result := service.EvaluateCompliance(service.ComplianceInvoice{
+			SellerCountry: "IE",
+			SellerTaxIDs:  []string{"IE6388047V"},
+			BuyerCountry:  "US",
+			TaxRatesBps:   []int64{0, 0},
+		}, rules)
+
+		assert.Equal(t, []string{"eu_vat_invoice", "eu_export"}, result.Rules)
+		require.Len(t, result.Mentions, 1)
+		assert.Contains(t, result.Mentions[0], "Article 44")
+	})
+
+	t.Run("an unknown buyer country only matches rules for any buyer", func(t *testing.T) {
+		result := service.EvaluateCompliance(service.ComplianceInvoice{
+			SellerCountry: "FR",
+			SellerTaxIDs:  []string{"FR40303265045"},
+		}, rules)
+
+		assert.Equal(t, []string{"eu_vat_invoice", "fr_late_payment"}, result.Rules)
+		assert.True(t, result.Compliant())
+	})
+
+	t.Run("the most detailed breakdown applies and mentions are printed once", func(t *testing.T) {
+		custom := append(rules,
+			service.ComplianceRule{Name: "gb_mention", SellerCountry: "GB", Mentions: []string{"Registered in England"}},
+			service.ComplianceRule{Name: "gb_mention_again", SellerCountry: "GB", TaxBreakdown: service.TaxBreakdownPerRate, Mentions: []string{"Registered in England"}},
+		)
+		result := service.EvaluateCompliance(service.ComplianceInvoice{
+			SellerCountry: "GB",
+			SellerTaxIDs:  []string{"GB980780684"},
+			BuyerCountry:  "GB",
+		}, custom)
+
+		assert.Equal(t, service.TaxBreakdownPerLine, result.TaxBreakdown)
+		assert.Equal(t, []string{"Registered in England"}, result.Mentions)
+	})
+}
+
+func TestComplianceRule_Validate(t *testing.T) {
+	for _, rule := range service.DefaultComplianceRules() {
+		assert.NoError(t, rule.Validate(), rule.Name)
+	}
+
+	assert.Error(t, service.ComplianceRule{SellerCountry: "FR"}.Validate())
+	assert.Error(t, service.ComplianceRule{Name: "bad_seller", SellerCountry: "France"}.Validate())
+	assert.Error(t, service.ComplianceRule{Name: "bad_buyer", BuyerCountry: "eu"}.Validate())
+	assert.Error(t, service.ComplianceRule{Name: "bad_breakdown", TaxBreakdown: "per_page"}.Validate())
+}
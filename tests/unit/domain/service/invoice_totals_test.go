@@ -0,0 +1,76 @@
+// Invoice Totals Domain Service Unit Tests
+//
+// This file contains unit tests for invoice totals across line item currencies.
+// Tests: Same-currency totals, explicit conversion with rounding, rejection of unconverted lines
+// Scope: Pure unit tests - domain services with no external dependencies
+package service
+
+import (
+	"testing"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculateInvoiceTotals(t *testing.T) {
+	eur := func(amount int64) valueobject.Money {
+		money, _ := valueobject.NewMoney(amount, "EUR")
+		return money
+	}
+	usd := func(amount int64) valueobject.Money {
+		money, _ := valueobject.NewMoney(amount, "USD")
+		return money
+	}
+
+	t.Run("totals lines in the invoice currency", func(t *testing.T) {
+		totals, err := service.CalculateInvoiceTotals("EUR", []service.InvoiceLine{
+			{Quantity: 1, UnitAmount: eur(150000)},
+			{Quantity: 2, UnitAmount: eur(2500)},
+		}, valueobject.RoundHalfUp)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(5000), totals.LineAmounts[1].Amount())
+		assert.Equal(t, "1550.00 EUR", totals.Total.String())
+	})
+
+	t.Run("converts the unit amount of lines with an exchange rate", func(t *testing.T) {
+		rate, err := valueobject.NewExchangeRate("USD", "EUR", "0.9235")
+		require.NoError(t, err)
+		lines := []service.InvoiceLine{
+			{Quantity: 1, UnitAmount: eur(10000)},
+			{Quantity: 3, UnitAmount: usd(1000), ExchangeRate: &rate}, // 9.235 EUR per unit
+		}
+
+		totals, err := service.CalculateInvoiceTotals("EUR", lines, valueobject.RoundHalfUp)
+		require.NoError(t, err)
+		assert.Equal(t, int64(924), totals.UnitAmounts[1].Amount())
+		assert.Equal(t, int64(2772), totals.LineAmounts[1].Amount())
+		assert.Equal(t, int64(12772), totals.Total.Amount())
+
+		totals, err = service.CalculateInvoiceTotals("EUR", lines, valueobject.RoundDown)
+		require.NoError(t, err)
+		assert.Equal(t, int64(12769), totals.Total.Amount())
+	})
+
+	t.Run("rejects lines in another currency without an exchange rate", func(t *testing.T) {
+		_, err := service.CalculateInvoiceTotals("EUR", []service.InvoiceLine{
+			{Quantity: 1, UnitAmount: eur(1000)},
+			{Quantity: 1, UnitAmount: usd(1000)},
+		}, valueobject.RoundHalfUp)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "line_items[1].currency")
+	})
+
+	t.Run("rejects an exchange rate to another currency", func(t *testing.T) {
+		rate, _ := valueobject.NewExchangeRate("USD", "GBP", "0.79")
+		_, err := service.CalculateInvoiceTotals("EUR", []service.InvoiceLine{
+			{Quantity: 1, UnitAmount: usd(1000), ExchangeRate: &rate},
+		}, valueobject.RoundHalfUp)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "line_items[0].exchange_rate")
+	})
+}
@@ -0,0 +1,67 @@
+// Payout Reconciler Domain Service Unit Tests
+//
+// This file contains tests for reconciling gateway payouts with recorded payments and fees.
+// Tests: Balanced payouts, missing ledger entries, amount/fee mismatches, payout totals
+// Scope: Pure unit tests - single domain service with no external dependencies
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconcilePayout(t *testing.T) {
+	arrival := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	payout := service.GatewayPayout{
+		ID:          "po_1",
+		ArrivalDate: arrival,
+		Amount:      eur(t, 14360), // (10000 - 320) + (5000 - 175) - 145
+		Items: []service.PayoutItem{
+			{Reference: "ch_1", Type: "charge", Amount: eur(t, 10000), Fee: eur(t, 320)},
+			{Reference: "ch_2", Type: "charge", Amount: eur(t, 5000), Fee: eur(t, 175)},
+			{Reference: "fee_1", Type: "fee", Amount: eur(t, -145), Fee: eur(t, 0)},
+		},
+	}
+	ledger := []service.LedgerEntry{
+		{PaymentID: "pay-1", GatewayReference: "ch_1", Amount: eur(t, 10000), Fee: eur(t, 320)},
+		{PaymentID: "pay-2", GatewayReference: "ch_2", Amount: eur(t, 5000), Fee: eur(t, 175)},
+		{PaymentID: "fee-1", GatewayReference: "fee_1", Amount: eur(t, -145), Fee: eur(t, 0)},
+	}
+
+	t.Run("balanced payout", func(t *testing.T) {
+		report, err := service.ReconcilePayout(payout, ledger)
+		require.NoError(t, err)
+
+		assert.Equal(t, entity.PayoutBalanced, report.Status())
+		assert.Equal(t, 3, report.ItemCount())
+		assert.Equal(t, 3, report.MatchedCount())
+		assert.Empty(t, report.Discrepancies())
+		assert.Equal(t, arrival, report.ArrivalDate())
+	})
+
+	t.Run("discrepancies", func(t *testing.T) {
+		drifted := []service.LedgerEntry{
+			{PaymentID: "pay-1", GatewayReference: "ch_1", Amount: eur(t, 9900), Fee: eur(t, 300)},
+			{PaymentID: "fee-1", GatewayReference: "fee_1", Amount: eur(t, -145), Fee: eur(t, 0)},
+		}
+		short := payout
+		short.Amount = eur(t, 14000)
+
+		report, err := service.ReconcilePayout(short, drifted)
+		require.NoError(t, err)
+
+		assert.Equal(t, entity.PayoutDiscrepant, report.Status())
+		assert.Equal(t, 2, report.MatchedCount())
+		assert.Equal(t, []entity.PayoutDiscrepancy{
+			{Kind: entity.PayoutDiscrepancyAmountMismatch, Reference: "ch_1", PaymentID: "pay-1", GatewayAmount: 10000, LedgerAmount: 9900},
+			{Kind: entity.PayoutDiscrepancyFeeMismatch, Reference: "ch_1", PaymentID: "pay-1", GatewayAmount: 320, LedgerAmount: 300},
+			{Kind: entity.PayoutDiscrepancyMissingInLedger, Reference: "ch_2", GatewayAmount: 5000},
+			{Kind: entity.PayoutDiscrepancyTotalMismatch, GatewayAmount: 14000, LedgerAmount: 14360},
+		}, report.Discrepancies())
+	})
+}
@@ -0,0 +1,104 @@
+// Prorator Domain Service Unit Tests
+//
+// This file contains table-driven tests for mid-cycle subscription plan changes.
+// Tests: Day-based, none and credit-only strategies, period boundaries, rounding, validation
+// Scope: Pure unit tests - single domain service with no external dependencies
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func eur(t *testing.T, amount int64) valueobject.Money {
+	t.Helper()
+	money, err := valueobject.NewMoney(amount, "EUR")
+	require.NoError(t, err)
+	return money
+}
+
+func TestProrator_Prorate(t *testing.T) {
+	// 30-day period: April 1st to May 1st (exclusive)
+	periodStart := time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC)
+	periodEnd := time.Date(2026, time.May, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		strategy       service.ProrationStrategy
+		changeDay      int
+		oldPrice       int64
+		newPrice       int64
+		expectedDays   int
+		expectedCredit int64
+		expectedCharge int64
+		expectedNet    int64
+	}{
+		{name: "day based upgrade mid-cycle", strategy: service.ProrationDayBased, changeDay: 16, oldPrice: 3000, newPrice: 6000, expectedDays: 15, expectedCredit: 1500, expectedCharge: 3000, expectedNet: 1500},
+		{name: "day based downgrade mid-cycle", strategy: service.ProrationDayBased, changeDay: 16, oldPrice: 6000, newPrice: 3000, expectedDays: 15, expectedCredit: 3000, expectedCharge: 1500, expectedNet: -1500},
+		{name: "day based change on first day", strategy: service.ProrationDayBased, changeDay: 1, oldPrice: 3000, newPrice: 6000, expectedDays: 30, expectedCredit: 3000, expectedCharge: 6000, expectedNet: 3000},
+		{name: "day based change on last day", strategy: service.ProrationDayBased, changeDay: 30, oldPrice: 3000, newPrice: 6000, expectedDays: 1, expectedCredit: 100, expectedCharge: 200, expectedNet: 100},
+		{name: "day based rounding", strategy: service.ProrationDayBased, changeDay: 21, oldPrice: 999, newPrice: 1999, expectedDays: 10, expectedCredit: 333, expectedCharge: 666, expectedNet: 333},
+		{name: "none ignores upgrade", strategy: service.ProrationNone, changeDay: 16, oldPrice: 3000, newPrice: 6000, expectedDays: 15},
+		{name: "none ignores downgrade", strategy: service.ProrationNone, changeDay: 16, oldPrice: 6000, newPrice: 3000, expectedDays: 15},
+		{name: "credit only downgrade", strategy: service.ProrationCreditOnly, changeDay: 16, oldPrice: 6000, newPrice: 3000, expectedDays: 15, expectedCredit: 1500, expectedNet: -1500},
+		{name: "credit only upgrade is not charged", strategy: service.ProrationCreditOnly, changeDay: 16, oldPrice: 3000, newPrice: 6000, expectedDays: 15},
+		{name: "cancellation to free plan", strategy: service.ProrationDayBased, changeDay: 11, oldPrice: 3000, newPrice: 0, expectedDays: 20, expectedCredit: 2000, expectedNet: -2000},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			prorator, err := service.NewProrator(testCase.strategy)
+			require.NoError(t, err)
+
+			result, err := prorator.Prorate(service.PlanChange{
+				PeriodStart: periodStart,
+				PeriodEnd:   periodEnd,
+				ChangeAt:    periodStart.AddDate(0, 0, testCase.changeDay-1).Add(15 * time.Hour),
+				OldPrice:    eur(t, testCase.oldPrice),
+				NewPrice:    eur(t, testCase.newPrice),
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, 30, result.PeriodDays)
+			assert.Equal(t, testCase.expectedDays, result.RemainingDays)
+			assert.Equal(t, testCase.expectedCredit, result.Credit.Amount(), "credit")
+			assert.Equal(t, testCase.expectedCharge, result.Charge.Amount(), "charge")
+			assert.Equal(t, testCase.expectedNet, result.Net.Amount(), "net")
+		})
+	}
+}
+
+func TestProrator_Validation(t *testing.T) {
+	periodStart := time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC)
+	periodEnd := time.Date(2026, time.May, 1, 0, 0, 0, 0, time.UTC)
+	usd, err := valueobject.NewMoney(1000, "USD")
+	require.NoError(t, err)
+
+	prorator, err := service.NewProrator(service.ProrationDayBased)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name   string
+		change service.PlanChange
+	}{
+		{name: "change before period", change: service.PlanChange{PeriodStart: periodStart, PeriodEnd: periodEnd, ChangeAt: periodStart.AddDate(0, 0, -1), OldPrice: eur(t, 1000), NewPrice: eur(t, 2000)}},
+		{name: "change at period end", change: service.PlanChange{PeriodStart: periodStart, PeriodEnd: periodEnd, ChangeAt: periodEnd, OldPrice: eur(t, 1000), NewPrice: eur(t, 2000)}},
+		{name: "empty period", change: service.PlanChange{PeriodStart: periodStart, PeriodEnd: periodStart, ChangeAt: periodStart, OldPrice: eur(t, 1000), NewPrice: eur(t, 2000)}},
+		{name: "currency mismatch", change: service.PlanChange{PeriodStart: periodStart, PeriodEnd: periodEnd, ChangeAt: periodStart, OldPrice: eur(t, 1000), NewPrice: usd}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := prorator.Prorate(testCase.change)
+			assert.Error(t, err)
+		})
+	}
+
+	_, err = service.NewProrator("monthly")
+	assert.Error(t, err)
+}
@@ -0,0 +1,97 @@
+// Tax Engine Domain Service Unit Tests
+//
+// This file contains unit tests for the tax engine of invoices.
+// Tests: Jurisdiction rate lookup, explicit line rates, exclusive and inclusive pricing, per-rate breakdown
+// Scope: Pure unit tests - domain services with no external dependencies
+package service
+
+import (
+	"testing"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaxEngine_LineRates(t *testing.T) {
+	rates, err := service.NewStaticTaxRates(map[string]int64{"fr": 2000, "US-CA": 725})
+	require.NoError(t, err)
+	engine := service.NewTaxEngine(rates)
+	reduced, exempt := int64(550), int64(0)
+
+	t.Run("unset rates take the rate of the jurisdiction", func(t *testing.T) {
+		lineRates, err := engine.LineRates(" Fr ", []*int64{nil, &reduced, &exempt})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{2000, 550, 0}, lineRates)
+	})
+
+	t.Run("unset rates are untaxed without a jurisdiction", func(t *testing.T) {
+		lineRates, err := engine.LineRates("", []*int64{nil, &reduced})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{0, 550}, lineRates)
+	})
+
+	t.Run("rejects a jurisdiction without a rate", func(t *testing.T) {
+		_, err := engine.LineRates("DE", []*int64{nil})
+		assert.Error(t, err)
+
+		lineRates, err := engine.LineRates("DE", []*int64{&reduced})
+		require.NoError(t, err, "explicit rates need no lookup")
+		assert.Equal(t, []int64{550}, lineRates)
+	})
+
+	t.Run("rejects invalid rate tables", func(t *testing.T) {
+		_, err := service.NewStaticTaxRates(map[string]int64{"FR": 10001})
+		assert.Error(t, err)
+		_, err = service.NewStaticTaxRates(map[string]int64{" ": 2000})
+		assert.Error(t, err)
+	})
+}
+
+func TestCalculateInvoiceTax(t *testing.T) {
+	lines := []entity.InvoiceLine{
+		{Description: "Consulting", Quantity: 3, UnitAmount: 12000, TaxRateBps: 2000},
+		{Description: "Books", Quantity: 1, UnitAmount: 1999, TaxRateBps: 550},
+		{Description: "Hosting", Quantity: 1, UnitAmount: 4900, TaxRateBps: 2000},
+		{Description: "Travel", Quantity: 1, UnitAmount: 4500},
+	}
+
+	t.Run("adds tax to exclusive prices", func(t *testing.T) {
+		invoice, err := entity.NewInvoice("client-1", "EUR", lines, nil)
+		require.NoError(t, err)
+
+		tax, err := service.CalculateInvoiceTax(invoice)
+		require.NoError(t, err)
+		require.Len(t, tax.Lines, 4)
+		assert.Equal(t, int64(7200), tax.Lines[0].Tax.Amount())
+		assert.Equal(t, int64(110), tax.Lines[1].Tax.Amount(), "19.99 × 5.5% = 1.09945 rounds half up per line")
+		assert.Equal(t, int64(47399), tax.Subtotal.Amount())
+		assert.Equal(t, int64(8290), tax.Tax.Amount())
+		assert.Equal(t, int64(55689), tax.Total.Amount())
+
+		require.Len(t, tax.Rates, 3)
+		assert.Equal(t, []int64{0, 550, 2000}, []int64{tax.Rates[0].RateBps, tax.Rates[1].RateBps, tax.Rates[2].RateBps})
+		assert.Equal(t, int64(40900), tax.Rates[2].Taxable.Amount())
+		assert.Equal(t, int64(8180), tax.Rates[2].Tax.Amount())
+
+		total, err := invoice.Total()
+		require.NoError(t, err)
+		assert.Equal(t, tax.Total, total, "the invoice bills the computed total")
+	})
+
+	t.Run("extracts tax from inclusive prices", func(t *testing.T) {
+		invoice, err := entity.NewInvoice("client-1", "EUR", lines, nil)
+		require.NoError(t, err)
+		require.NoError(t, invoice.SetTaxTerms(valueobject.TaxInclusive, "FR"))
+
+		tax, err := service.CalculateInvoiceTax(invoice)
+		require.NoError(t, err)
+		assert.Equal(t, int64(6000), tax.Lines[0].Tax.Amount())
+		assert.Equal(t, int64(30000), tax.Lines[0].Net.Amount())
+		assert.Equal(t, int64(36000), tax.Lines[0].Gross.Amount())
+		assert.Equal(t, int64(47399), tax.Total.Amount(), "gross prices are the amounts billed")
+		assert.Equal(t, tax.Total.Amount(), tax.Subtotal.Amount()+tax.Tax.Amount())
+	})
+}
@@ -0,0 +1,149 @@
+// Subscription Domain Unit Tests
+//
+// This file contains unit tests for clients subscribed to a plan billed every interval.
+// Tests: Validation, billing dates (month-end clamping, periods), plan changes, pause/resume/cancel, JSON round-trip
+// Scope: Pure unit tests - single component (Subscription entity) with no external dependencies
+package subscription
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func newSubscription(t *testing.T, interval entity.RecurrenceFrequency, start time.Time) *entity.Subscription {
+	t.Helper()
+	subscription, err := entity.NewSubscription("client-1", "Pro", "eur", 4900, 3, 2000, interval, start)
+	require.NoError(t, err)
+	return subscription
+}
+
+// billAll marks every period due at now as billed and returns their billing dates
+func billAll(subscription *entity.Subscription, now time.Time) []time.Time {
+	var dates []time.Time
+	for subscription.IsDue(now) {
+		dates = append(dates, subscription.NextBillingDate())
+		subscription.MarkBilled("invoice", now)
+	}
+	return dates
+}
+
+func TestNewSubscription(t *testing.T) {
+	subscription := newSubscription(t, entity.RecurrenceMonthly, date(2026, time.January, 31))
+
+	assert.NotEmpty(t, subscription.ID())
+	assert.Equal(t, "EUR", subscription.Currency())
+	assert.Equal(t, entity.SubscriptionActive, subscription.Status())
+	assert.Equal(t, date(2026, time.January, 31), subscription.NextBillingDate())
+	assert.Equal(t, date(2026, time.February, 27), subscription.NextPeriodEnd())
+	assert.Equal(t, "Pro (2026-01-31 to 2026-02-27)", subscription.PeriodDescription())
+
+	amount, err := subscription.Amount()
+	require.NoError(t, err)
+	assert.Equal(t, int64(14700), amount.Amount())
+}
+
+func TestNewSubscription_Validation(t *testing.T) {
+	start := date(2026, time.January, 1)
+	cases := map[string]func() (*entity.Subscription, error){
+		"client": func() (*entity.Subscription, error) {
+			return entity.NewSubscription(" ", "Pro", "EUR", 4900, 1, 0, entity.RecurrenceMonthly, start)
+		},
+		"plan": func() (*entity.Subscription, error) {
+			return entity.NewSubscription("client-1", "", "EUR", 4900, 1, 0, entity.RecurrenceMonthly, start)
+		},
+		"currency": func() (*entity.Subscription, error) {
+			return entity.NewSubscription("client-1", "Pro", "EURO", 4900, 1, 0, entity.RecurrenceMonthly, start)
+		},
+		"amount": func() (*entity.Subscription, error) {
+			return entity.NewSubscription("client-1", "Pro", "EUR", -1, 1, 0, entity.RecurrenceMonthly, start)
+		},
+		"quantity": func() (*entity.Subscription, error) {
+			return entity.NewSubscription("client-1", "Pro", "EUR", 4900, 0, 0, entity.RecurrenceMonthly, start)
+		},
+		"tax": func() (*entity.Subscription, error) {
+			return entity.NewSubscription("client-1", "Pro", "EUR", 4900, 1, 10001, entity.RecurrenceMonthly, start)
+		},
+		"interval": func() (*entity.Subscription, error) {
+			return entity.NewSubscription("client-1", "Pro", "EUR", 4900, 1, 0, "daily", start)
+		},
+		"start": func() (*entity.Subscription, error) {
+			return entity.NewSubscription("client-1", "Pro", "EUR", 4900, 1, 0, entity.RecurrenceMonthly, time.Time{})
+		},
+	}
+	for name, create := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := create()
+			assert.True(t, domainErrors.IsValidationError(err), "expected a validation error, got %v", err)
+		})
+	}
+}
+
+func TestSubscription_BillingDates(t *testing.T) {
+	subscription := newSubscription(t, entity.RecurrenceMonthly, date(2026, time.January, 31))
+	assert.False(t, subscription.IsDue(date(2026, time.January, 30)))
+
+	dates := billAll(subscription, date(2026, time.April, 1))
+	assert.Equal(t, []time.Time{date(2026, time.January, 31), date(2026, time.February, 28), date(2026, time.March, 31)}, dates)
+	assert.Equal(t, 3, subscription.BilledCount())
+	assert.Equal(t, date(2026, time.April, 30), subscription.NextBillingDate())
+
+	quarterly := newSubscription(t, entity.RecurrenceQuarterly, date(2026, time.January, 15))
+	quarterly.MarkBilled("invoice", date(2026, time.January, 15))
+	assert.Equal(t, date(2026, time.April, 15), quarterly.NextBillingDate())
+	assert.Equal(t, date(2026, time.July, 14), quarterly.NextPeriodEnd())
+}
+
+func TestSubscription_Lifecycle(t *testing.T) {
+	subscription := newSubscription(t, entity.RecurrenceMonthly, date(2026, time.January, 1))
+	subscription.MarkBilled("invoice-1", date(2026, time.January, 1))
+
+	require.NoError(t, subscription.ChangePlan("Business", 9900, 2, 2000))
+	assert.Equal(t, "Business", subscription.Plan())
+	assert.True(t, domainErrors.IsValidationError(subscription.ChangePlan("Business", 9900, -1, 2000)))
+
+	// Periods starting while paused are skipped
+	assert.ErrorIs(t, subscription.Resume(date(2026, time.January, 15)), domainErrors.ErrSubscriptionNotPaused)
+	require.NoError(t, subscription.Pause())
+	assert.ErrorIs(t, subscription.Pause(), domainErrors.ErrSubscriptionNotActive)
+	assert.False(t, subscription.IsDue(date(2026, time.March, 15)))
+	require.NoError(t, subscription.Resume(date(2026, time.March, 15)))
+	assert.Equal(t, date(2026, time.April, 1), subscription.NextBillingDate())
+
+	require.NoError(t, subscription.Cancel(date(2026, time.March, 20)))
+	assert.Equal(t, entity.SubscriptionCanceled, subscription.Status())
+	assert.False(t, subscription.IsDue(date(2026, time.May, 1)))
+	assert.ErrorIs(t, subscription.Cancel(date(2026, time.March, 21)), domainErrors.ErrSubscriptionCanceled)
+	assert.ErrorIs(t, subscription.ChangePlan("Pro", 4900, 1, 0), domainErrors.ErrSubscriptionCanceled)
+}
+
+func TestSubscription_JSONRoundTrip(t *testing.T) {
+	subscription := newSubscription(t, entity.RecurrenceYearly, date(2026, time.February, 28))
+	subscription.AssignTenant("acme")
+	subscription.MarkBilled("invoice-1", date(2026, time.February, 28))
+	require.NoError(t, subscription.Pause())
+
+	data, err := json.Marshal(subscription)
+	require.NoError(t, err)
+	restored := &entity.Subscription{}
+	require.NoError(t, json.Unmarshal(data, restored))
+
+	assert.Equal(t, subscription.ID(), restored.ID())
+	assert.Equal(t, "acme", restored.TenantID())
+	assert.Equal(t, "Pro", restored.Plan())
+	assert.Equal(t, int64(3), restored.Quantity())
+	assert.Equal(t, int64(2000), restored.TaxRateBps())
+	assert.Equal(t, entity.SubscriptionPaused, restored.Status())
+	assert.Equal(t, "invoice-1", restored.LastInvoiceID())
+	assert.Equal(t, 1, restored.BilledCount())
+	assert.Equal(t, date(2027, time.February, 28), restored.NextBillingDate())
+}
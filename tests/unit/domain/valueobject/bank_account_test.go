@@ -0,0 +1,55 @@
+// Bank Account Unit Tests
+//
+// This file contains unit tests for the bank details printed on invoices.
+// Tests: IBAN normalization and check digits, BIC structure, required fields
+// Scope: Pure unit tests - value objects with no external dependencies
+package valueobject
+
+import (
+	"testing"
+
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBankAccount(t *testing.T) {
+	account, err := valueobject.NewBankAccount(" Acme GmbH ", "de89 3704 0044 0532 0130 00", "deutdeff", "Deutsche Bank")
+	require.NoError(t, err)
+
+	assert.Equal(t, "Acme GmbH", account.AccountHolder())
+	assert.Equal(t, "DE89370400440532013000", account.IBAN())
+	assert.Equal(t, "DEUTDEFF", account.BIC())
+	assert.False(t, account.IsEmpty())
+	assert.True(t, valueobject.BankAccount{}.IsEmpty())
+}
+
+func TestNewBankAccount_Validation(t *testing.T) {
+	testCases := []struct {
+		name          string
+		accountHolder string
+		iban          string
+		bic           string
+		field         string
+	}{
+		{"missing account holder", "", "GB82WEST12345698765432", "", "account_holder"},
+		{"missing IBAN", "Acme Ltd", "", "", "iban"},
+		{"wrong check digits", "Acme Ltd", "GB83WEST12345698765432", "", "iban"},
+		{"too short", "Acme Ltd", "GB82WEST1234", "", "iban"},
+		{"invalid characters", "Acme Ltd", "GB82WEST1234569876543!", "", "iban"},
+		{"BIC of the wrong length", "Acme Ltd", "GB82WEST12345698765432", "WESTGB2", "bic"},
+		{"BIC with a numeric bank code", "Acme Ltd", "GB82WEST12345698765432", "W3STGB2L", "bic"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := valueobject.NewBankAccount(tc.accountHolder, tc.iban, tc.bic, "")
+			require.Error(t, err)
+
+			var validationErr *domainErrors.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tc.field, validationErr.Field)
+		})
+	}
+}
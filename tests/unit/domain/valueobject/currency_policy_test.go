@@ -0,0 +1,106 @@
+// Currency Policy Value Object Unit Tests
+//
+// This file contains unit tests for tenant currency policies, rounding modes and exchange rates.
+// Tests: Rounding mode parsing, rounded ratios, exact exchange rate conversion across minor units
+// Scope: Pure unit tests - value objects with no external dependencies
+package valueobject
+
+import (
+	"testing"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCurrencyPolicy(t *testing.T) {
+	policy, err := valueobject.NewCurrencyPolicy("usd", "")
+	require.NoError(t, err)
+	assert.Equal(t, "USD", policy.Currency())
+	assert.Equal(t, valueobject.RoundHalfUp, policy.Rounding())
+
+	_, err = valueobject.NewCurrencyPolicy("EUR", "bankers")
+	assert.Error(t, err)
+	_, err = valueobject.NewCurrencyPolicy("EURO", "half_even")
+	assert.Error(t, err)
+
+	assert.True(t, valueobject.CurrencyPolicy{}.IsZero())
+	assert.Equal(t, valueobject.RoundHalfUp, valueobject.CurrencyPolicy{}.Rounding())
+}
+
+func TestMoney_MultiplyRatioRounded(t *testing.T) {
+	cases := []struct {
+		amount int64
+		mode   valueobject.RoundingMode
+		want   int64
+	}{
+		{25, valueobject.RoundHalfUp, 13},
+		{25, valueobject.RoundHalfEven, 12},
+		{35, valueobject.RoundHalfEven, 18},
+		{25, valueobject.RoundDown, 12},
+		{21, valueobject.RoundUp, 11},
+		{-25, valueobject.RoundHalfUp, -13},
+		{-25, valueobject.RoundHalfEven, -12},
+		{-21, valueobject.RoundDown, -10},
+		{-21, valueobject.RoundUp, -11},
+	}
+
+	for _, tc := range cases {
+		money, _ := valueobject.NewMoney(tc.amount, "EUR")
+		half, err := money.MultiplyRatioRounded(1, 2, tc.mode)
+		require.NoError(t, err)
+		assert.Equal(t, tc.want, half.Amount(), "%d / 2 with %s", tc.amount, tc.mode)
+	}
+}
+
+func TestExchangeRate_Convert(t *testing.T) {
+	t.Run("converts and rounds to the minor unit of the target currency", func(t *testing.T) {
+		rate, err := valueobject.NewExchangeRate("USD", "EUR", "0.9235")
+		require.NoError(t, err)
+		assert.Equal(t, "USD", rate.From())
+		assert.Equal(t, "EUR", rate.To())
+
+		amount, _ := valueobject.NewMoney(1000, "USD") // 10.00 USD = 9.235 EUR
+		converted, err := rate.Convert(amount, valueobject.RoundHalfUp)
+		require.NoError(t, err)
+		assert.Equal(t, "9.24 EUR", converted.String())
+
+		converted, err = rate.Convert(amount, valueobject.RoundHalfEven)
+		require.NoError(t, err)
+		assert.Equal(t, int64(924), converted.Amount())
+
+		converted, err = rate.Convert(amount, valueobject.RoundDown)
+		require.NoError(t, err)
+		assert.Equal(t, int64(923), converted.Amount())
+	})
+
+	t.Run("scales between currencies with different minor units", func(t *testing.T) {
+		toYen, err := valueobject.NewExchangeRate("EUR", "JPY", "161.5")
+		require.NoError(t, err)
+		amount, _ := valueobject.NewMoney(1001, "EUR") // 10.01 EUR = 1616.615 JPY
+		converted, err := toYen.Convert(amount, valueobject.RoundHalfUp)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1617), converted.Amount())
+
+		toEuro, err := valueobject.NewExchangeRate("JPY", "EUR", "0.0062")
+		require.NoError(t, err)
+		yen, _ := valueobject.NewMoney(1617, "JPY") // 10.0254 EUR
+		converted, err = toEuro.Convert(yen, valueobject.RoundHalfUp)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1003), converted.Amount())
+	})
+
+	t.Run("rejects amounts in another currency", func(t *testing.T) {
+		rate, _ := valueobject.NewExchangeRate("USD", "EUR", "0.92")
+		amount, _ := valueobject.NewMoney(1000, "GBP")
+		_, err := rate.Convert(amount, valueobject.RoundHalfUp)
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects invalid rates", func(t *testing.T) {
+		for _, rate := range []string{"", "0", "0.000", "-1.2", "1,2", ".5", "1.12345678901", "abc"} {
+			_, err := valueobject.NewExchangeRate("USD", "EUR", rate)
+			assert.Error(t, err, rate)
+		}
+	})
+}
@@ -0,0 +1,57 @@
+// Email Normalization Unit Tests
+//
+// This file contains unit tests for the canonical addresses compared by email uniqueness checks.
+// Tests: Lower casing, subaddress folding, dot folding of configured domains, policy validation
+// Scope: Pure unit tests - value objects with no external dependencies
+package valueobject
+
+import (
+	"testing"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmail_Canonical(t *testing.T) {
+	folding, err := valueobject.NewEmailNormalization(true, []string{"Gmail.com", "googlemail.com"})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		email    string
+		policy   valueobject.EmailNormalization
+		expected string
+	}{
+		{"lower case by default", "Alice@Example.com", valueobject.EmailNormalization{}, "alice@example.com"},
+		{"tags kept by default", "alice+billing@example.com", valueobject.EmailNormalization{}, "alice+billing@example.com"},
+		{"tag folded", "Alice+Billing@example.com", folding, "alice@example.com"},
+		{"dots folded on configured domains", "a.l.ice+news@gmail.com", folding, "alice@gmail.com"},
+		{"dots kept on other domains", "a.lice@example.com", folding, "a.lice@example.com"},
+		{"leading plus is not a tag", "+alice@example.com", folding, "+alice@example.com"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			email, err := valueobject.NewEmail(tc.email)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, email.Canonical(tc.policy))
+		})
+	}
+
+	t.Run("the address is kept as entered", func(t *testing.T) {
+		email, err := valueobject.NewEmail("alice+billing@gmail.com")
+		require.NoError(t, err)
+		email.Canonical(folding)
+		assert.Equal(t, "alice+billing@gmail.com", email.String())
+	})
+}
+
+func TestEmailNormalization_Validation(t *testing.T) {
+	for _, domain := range []string{"", "gmail", "alice@gmail.com"} {
+		_, err := valueobject.NewEmailNormalization(false, []string{domain})
+		require.Error(t, err, domain)
+		assert.Equal(t, errors.ValidationFormat, errors.GetErrorCode(err))
+	}
+}
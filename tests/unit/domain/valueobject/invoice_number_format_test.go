@@ -0,0 +1,58 @@
+// Invoice Number Format Unit Tests
+//
+// This file contains unit tests for the format of sequential invoice numbers.
+// Tests: Year and sequence placeholders, zero padding, pattern validation
+// Scope: Pure unit tests - value objects with no external dependencies
+package valueobject
+
+import (
+	"testing"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvoiceNumberFormat_Format(t *testing.T) {
+	testCases := []struct {
+		pattern  string
+		sequence int64
+		expected string
+	}{
+		{valueobject.DefaultInvoiceNumberFormat, 42, "INV-2026-00042"},
+		{"{YY}/{000}", 7, "26/007"},
+		{"ACME-{YYYY}{0}", 12, "ACME-202612"},
+		{"F{YYYY}-{00}", 12345, "F2026-12345"}, // Longer numbers are not truncated
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.pattern, func(t *testing.T) {
+			format, err := valueobject.NewInvoiceNumberFormat(tc.pattern)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, format.Format(2026, tc.sequence))
+		})
+	}
+}
+
+func TestInvoiceNumberFormat_Validation(t *testing.T) {
+	testCases := []struct {
+		name    string
+		pattern string
+	}{
+		{"empty", "  "},
+		{"no sequence", "INV-{YYYY}"},
+		{"two sequences", "{YYYY}-{000}-{000}"},
+		{"no year", "INV-{00000}"},
+		{"unknown placeholder", "INV-{YYYY}-{MM}-{000}"},
+		{"unbalanced brace", "INV-{YYYY}-{000"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := valueobject.NewInvoiceNumberFormat(tc.pattern)
+			require.Error(t, err)
+			assert.True(t, errors.IsValidationError(err))
+		})
+	}
+}
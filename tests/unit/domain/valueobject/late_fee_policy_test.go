@@ -0,0 +1,148 @@
+// Late Fee Policy Unit Tests
+//
+// This file contains unit tests for late fee and interest calculation.
+// Tests: Grace periods, flat and percentage fees, daily interest accrual, caps, rounding
+// Scope: Pure unit tests - value objects with no external dependencies
+package valueobject
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLateFeePolicy_Assess(t *testing.T) {
+	dueDate := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name             string
+		config           valueobject.LateFeePolicyConfig
+		balance          int64
+		daysAfterDue     int
+		expectedInterest int64
+		expectedTotal    int64
+		expectedCapped   bool
+	}{
+		{
+			name:          "within grace period",
+			config:        valueobject.LateFeePolicyConfig{GraceDays: 10, FlatFee: 2500},
+			balance:       100000,
+			daysAfterDue:  10,
+			expectedTotal: 0,
+		},
+		{
+			name:          "flat fee after grace period",
+			config:        valueobject.LateFeePolicyConfig{GraceDays: 10, FlatFee: 2500},
+			balance:       100000,
+			daysAfterDue:  11,
+			expectedTotal: 2500,
+		},
+		{
+			name:          "percentage of overdue balance",
+			config:        valueobject.LateFeePolicyConfig{PercentageBps: 150},
+			balance:       33333,
+			daysAfterDue:  1,
+			expectedTotal: 500, // 499.995 rounds half away from zero
+		},
+		{
+			name:             "daily interest from the due date",
+			config:           valueobject.LateFeePolicyConfig{AnnualInterestBps: 1000},
+			balance:          365000,
+			daysAfterDue:     30,
+			expectedInterest: 3000,
+			expectedTotal:    3000,
+		},
+		{
+			name:             "interest after grace period",
+			config:           valueobject.LateFeePolicyConfig{GraceDays: 10, AnnualInterestBps: 1000, InterestAfterGrace: true},
+			balance:          365000,
+			daysAfterDue:     30,
+			expectedInterest: 2000,
+			expectedTotal:    2000,
+		},
+		{
+			name:             "components add up",
+			config:           valueobject.LateFeePolicyConfig{FlatFee: 1000, PercentageBps: 100, AnnualInterestBps: 1000},
+			balance:          365000,
+			daysAfterDue:     30,
+			expectedInterest: 3000,
+			expectedTotal:    1000 + 3650 + 3000,
+		},
+		{
+			name:             "total is capped",
+			config:           valueobject.LateFeePolicyConfig{FlatFee: 1000, AnnualInterestBps: 1000, MaxFee: 2000},
+			balance:          365000,
+			daysAfterDue:     30,
+			expectedInterest: 3000,
+			expectedTotal:    2000,
+			expectedCapped:   true,
+		},
+		{
+			name:          "not yet due",
+			config:        valueobject.LateFeePolicyConfig{FlatFee: 1000},
+			balance:       100000,
+			daysAfterDue:  -5,
+			expectedTotal: 0,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			policy, err := valueobject.NewLateFeePolicy(testCase.config)
+			require.NoError(t, err)
+			balance, err := valueobject.NewMoney(testCase.balance, "EUR")
+			require.NoError(t, err)
+
+			// Time of day does not matter, only calendar days
+			now := dueDate.AddDate(0, 0, testCase.daysAfterDue).Add(17 * time.Hour)
+			assessment, err := policy.Assess(balance, dueDate, now)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.daysAfterDue, assessment.DaysLate)
+			assert.Equal(t, testCase.expectedInterest, assessment.Interest.Amount())
+			assert.Equal(t, testCase.expectedTotal, assessment.Total.Amount())
+			assert.Equal(t, testCase.expectedCapped, assessment.Capped)
+			assert.Equal(t, "EUR", assessment.Total.Currency())
+		})
+	}
+}
+
+func TestNewLateFeePolicy_Validation(t *testing.T) {
+	invalid := []valueobject.LateFeePolicyConfig{
+		{GraceDays: -1},
+		{FlatFee: -100},
+		{PercentageBps: 10001},
+		{AnnualInterestBps: -5},
+		{MaxFee: -1},
+	}
+
+	for _, config := range invalid {
+		_, err := valueobject.NewLateFeePolicy(config)
+		assert.Error(t, err, "%+v", config)
+	}
+}
+
+func TestMoney_MultiplyRatio(t *testing.T) {
+	testCases := []struct {
+		amount      int64
+		numerator   int64
+		denominator int64
+		expected    int64
+	}{
+		{amount: 1000, numerator: 1, denominator: 3, expected: 333},
+		{amount: 1000, numerator: 2, denominator: 3, expected: 667},
+		{amount: 5, numerator: 1, denominator: 2, expected: 3},
+		{amount: -5, numerator: 1, denominator: 2, expected: -3},
+		{amount: 9_000_000_000_000, numerator: 3_650_000, denominator: 3_650_000_000, expected: 9_000_000_000},
+	}
+
+	for _, testCase := range testCases {
+		money, _ := valueobject.NewMoney(testCase.amount, "EUR")
+		result, err := money.MultiplyRatio(testCase.numerator, testCase.denominator)
+		require.NoError(t, err)
+		assert.Equal(t, testCase.expected, result.Amount(), "%d × %d / %d", testCase.amount, testCase.numerator, testCase.denominator)
+	}
+}
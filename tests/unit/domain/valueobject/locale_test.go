@@ -0,0 +1,118 @@
+// Locale Value Object Unit Tests
+//
+// This file contains unit tests for locale-aware value object validation.
+// Tests: Locale parsing, Accept-Language ordering, regional phone and address rules
+// Scope: Pure unit tests - value objects with no external dependencies
+// Use Cases: UC-B-001 (Create Client), UC-B-003 (Update Client) - Regional validation
+//
+// Test Scenarios:
+// - Locale tags with and without region
+// - Accept-Language quality ordering
+// - National phone numbers validated against the default phone region
+// - Postal code requirement per regional address format
+package valueobject
+
+import (
+	"testing"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLocale(t *testing.T) {
+	testCases := []struct {
+		tag            string
+		expectedString string
+		shouldFail     bool
+	}{
+		{tag: "fr-BE", expectedString: "fr-BE"},
+		{tag: "en_us", expectedString: "en-US"},
+		{tag: "de", expectedString: "de"},
+		{tag: "zh-Hant-TW", expectedString: "zh-TW"},
+		{tag: "", shouldFail: true},
+		{tag: "1234", shouldFail: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.tag, func(t *testing.T) {
+			locale, err := valueobject.NewLocale(testCase.tag)
+
+			if testCase.shouldFail {
+				assert.Error(t, err)
+				assert.True(t, errors.IsValidationError(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedString, locale.String())
+		})
+	}
+}
+
+func TestParseAcceptLanguage_OrdersByQuality(t *testing.T) {
+	locales := valueobject.ParseAcceptLanguage("en;q=0.5, fr-FR, *, de-DE;q=0.8, xx-invalid-1;q=0")
+
+	require.Len(t, locales, 3)
+	assert.Equal(t, "fr-FR", locales[0].String())
+	assert.Equal(t, "de-DE", locales[1].String())
+	assert.Equal(t, "en", locales[2].String())
+}
+
+func TestNewPhoneWithLocale(t *testing.T) {
+	french, err := valueobject.NewLocale("fr-FR")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		description    string
+		phone          string
+		locale         valueobject.Locale
+		expectedRegion string
+		shouldFail     bool
+	}{
+		{description: "national number with trunk prefix", phone: "06 12 34 56 78", locale: french, expectedRegion: "FR"},
+		{description: "national number without trunk prefix", phone: "612345678", locale: french, expectedRegion: "FR"},
+		{description: "international number ignores region", phone: "+1 555 123 4567", locale: french, expectedRegion: ""},
+		{description: "national number too short", phone: "0612", locale: french, shouldFail: true},
+		{description: "letters in national number", phone: "06 12 AB 56 78", locale: french, shouldFail: true},
+		{description: "default locale keeps legacy rules", phone: "5551234567", locale: valueobject.DefaultLocale(), expectedRegion: ""},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			phone, err := valueobject.NewPhoneWithLocale(testCase.phone, testCase.locale)
+
+			if testCase.shouldFail {
+				assert.Error(t, err)
+				assert.True(t, errors.IsValidationError(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedRegion, phone.Region())
+		})
+	}
+}
+
+func TestValidateAddressForCountry(t *testing.T) {
+	assert.NoError(t, valueobject.ValidateAddressForCountry("10 Downing Street, London SW1A 2AA", "GB"))
+	assert.NoError(t, valueobject.ValidateAddressForCountry("", "GB"))
+	assert.NoError(t, valueobject.ValidateAddressForCountry("123 Main St", ""))
+
+	err := valueobject.ValidateAddressForCountry("10 Downing Street, London", "GB")
+	assert.Error(t, err)
+	assert.True(t, errors.IsValidationError(err))
+}
+
+func TestNewClientInCountry_AppliesRegionalRules(t *testing.T) {
+	client, err := entity.NewClientInCountry("Max Mustermann", "max@example.de", "030 1234567", "Unter den Linden 1, 10117 Berlin", "de")
+	require.NoError(t, err)
+	assert.Equal(t, "DE", client.Phone().Region())
+	assert.Equal(t, "DE", client.Country())
+
+	_, err = entity.NewClientInCountry("Max Mustermann", "max@example.de", "030 1234567", "Unter den Linden 1, Berlin", "DE")
+	assert.Error(t, err)
+
+	_, err = entity.NewClientInCountry("Max Mustermann", "max@example.de", "+49 30 1234567", "Unter den Linden 1", "Germany")
+	assert.True(t, errors.IsValidationError(err))
+}
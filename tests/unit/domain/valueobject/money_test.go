@@ -0,0 +1,99 @@
+// Money Value Object Unit Tests
+//
+// This file contains unit tests for integer minor-unit money arithmetic.
+// Tests: Currency validation, same-currency arithmetic, lossless splitting, formatting
+// Scope: Pure unit tests - value objects with no external dependencies
+package valueobject
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMoney(t *testing.T) {
+	money, err := valueobject.NewMoney(1234, " eur ")
+	require.NoError(t, err)
+	assert.Equal(t, "EUR", money.Currency())
+	assert.Equal(t, "12.34 EUR", money.String())
+
+	for _, currency := range []string{"", "EURO", "E1R"} {
+		_, err := valueobject.NewMoney(100, currency)
+		assert.Error(t, err, currency)
+	}
+}
+
+func TestMoney_Arithmetic(t *testing.T) {
+	ten, _ := valueobject.NewMoney(1000, "EUR")
+	three, _ := valueobject.NewMoney(300, "EUR")
+	dollars, _ := valueobject.NewMoney(300, "USD")
+
+	sum, err := ten.Add(three)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1300), sum.Amount())
+
+	difference, err := three.Subtract(ten)
+	require.NoError(t, err)
+	assert.True(t, difference.IsNegative())
+	assert.Equal(t, "-7.00 EUR", difference.String())
+
+	_, err = ten.Add(dollars)
+	assert.Error(t, err)
+
+	largest, _ := valueobject.NewMoney(math.MaxInt64, "EUR")
+	_, err = largest.Add(ten)
+	assert.Error(t, err, "sums out of range must not wrap")
+	smallest, err := three.Subtract(largest)
+	require.NoError(t, err)
+	_, err = smallest.Subtract(ten)
+	assert.Error(t, err, "differences out of range must not wrap")
+}
+
+func TestMoney_Split(t *testing.T) {
+	testCases := []struct {
+		amount   int64
+		parts    int
+		expected []int64
+	}{
+		{amount: 100, parts: 3, expected: []int64{34, 33, 33}},
+		{amount: 90, parts: 3, expected: []int64{30, 30, 30}},
+		{amount: 2, parts: 4, expected: []int64{1, 1, 0, 0}},
+		{amount: -100, parts: 3, expected: []int64{-34, -33, -33}},
+	}
+
+	for _, testCase := range testCases {
+		money, _ := valueobject.NewMoney(testCase.amount, "EUR")
+		parts, err := money.Split(testCase.parts)
+		require.NoError(t, err)
+
+		amounts := make([]int64, len(parts))
+		for i, part := range parts {
+			amounts[i] = part.Amount()
+		}
+		assert.Equal(t, testCase.expected, amounts)
+	}
+}
+
+func TestMoney_StringUsesCurrencyMinorUnits(t *testing.T) {
+	yen, _ := valueobject.NewMoney(1500, "JPY")
+	dinars, _ := valueobject.NewMoney(1500, "KWD")
+
+	assert.Equal(t, "1500 JPY", yen.String())
+	assert.Equal(t, "1.500 KWD", dinars.String())
+}
+
+func TestMoney_StringFormatsExtremeAmounts(t *testing.T) {
+	lowest, err := valueobject.NewMoney(math.MinInt64, "EUR")
+	require.NoError(t, err)
+	highest, err := valueobject.NewMoney(math.MaxInt64, "EUR")
+	require.NoError(t, err)
+	lowestYen, err := valueobject.NewMoney(math.MinInt64, "JPY")
+	require.NoError(t, err)
+
+	assert.Equal(t, "-92233720368547758.08 EUR", lowest.String())
+	assert.Equal(t, "92233720368547758.07 EUR", highest.String())
+	assert.Equal(t, "-9223372036854775808 JPY", lowestYen.String())
+}
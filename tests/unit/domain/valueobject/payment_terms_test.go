@@ -0,0 +1,72 @@
+// Payment Terms Unit Tests
+//
+// This file contains unit tests for the payment terms of clients and invoices.
+// Tests: Parsing and validation of terms, day counts, due date computation
+// Scope: Pure unit tests - value objects with no external dependencies
+package valueobject
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePaymentTerms(t *testing.T) {
+	testCases := []struct {
+		name         string
+		terms        string
+		expected     valueobject.PaymentTerms
+		expectedDays int
+	}{
+		{name: "no terms", terms: "", expected: ""},
+		{name: "due on receipt", terms: "due_on_receipt", expected: valueobject.PaymentDueOnReceipt},
+		{name: "net 15", terms: "net_15", expected: valueobject.PaymentNet15, expectedDays: 15},
+		{name: "net 30 in any case", terms: " NET_30 ", expected: valueobject.PaymentNet30, expectedDays: 30},
+		{name: "net 60", terms: "net_60", expected: valueobject.PaymentNet60, expectedDays: 60},
+		{name: "custom days", terms: "net_45", expected: "net_45", expectedDays: 45},
+		{name: "net 0 is due on receipt", terms: "net_0", expected: valueobject.PaymentDueOnReceipt},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			terms, err := valueobject.ParsePaymentTerms(tc.terms)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, terms)
+			assert.Equal(t, tc.expectedDays, terms.Days())
+		})
+	}
+}
+
+func TestParsePaymentTerms_Invalid(t *testing.T) {
+	testCases := []struct {
+		name         string
+		terms        string
+		expectedCode errors.ErrorCode
+	}{
+		{name: "unknown terms", terms: "eom", expectedCode: errors.ValidationFormat},
+		{name: "days not a number", terms: "net_thirty", expectedCode: errors.ValidationFormat},
+		{name: "negative days", terms: "net_-5", expectedCode: errors.ValidationRange},
+		{name: "more than a year", terms: "net_366", expectedCode: errors.ValidationRange},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := valueobject.ParsePaymentTerms(tc.terms)
+			require.Error(t, err)
+			assert.Equal(t, tc.expectedCode, errors.GetErrorCode(err))
+		})
+	}
+}
+
+func TestPaymentTerms_DueDate(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+	issuedAt := time.Date(2026, time.January, 31, 23, 30, 0, 0, paris)
+
+	assert.Equal(t, time.Date(2026, time.March, 2, 22, 30, 0, 0, time.UTC), valueobject.PaymentNet30.DueDate(issuedAt))
+	assert.Equal(t, issuedAt.UTC(), valueobject.PaymentDueOnReceipt.DueDate(issuedAt))
+}
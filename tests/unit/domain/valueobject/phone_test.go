@@ -0,0 +1,38 @@
+package valueobject
+
+import (
+	"testing"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPhone_E164(t *testing.T) {
+	french, err := valueobject.NewLocale("fr-FR")
+	require.NoError(t, err)
+	american, err := valueobject.NewLocale("en-US")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		description string
+		phone       string
+		locale      valueobject.Locale
+		expected    string
+	}{
+		{description: "international number keeps its digits", phone: "+33 6 12 34 56 78", locale: american, expected: "+33612345678"},
+		{description: "formatting characters are dropped", phone: "+1 (555) 123-4567", locale: french, expected: "+15551234567"},
+		{description: "national number gains the region calling code", phone: "06.12.34.56.78", locale: french, expected: "+33612345678"},
+		{description: "national number without trunk prefix", phone: "(555) 123-4567", locale: american, expected: "+15551234567"},
+		{description: "unknown region keeps the digits", phone: "555 123 4567", locale: valueobject.Locale{}, expected: "5551234567"},
+		{description: "empty phone", phone: "", locale: french, expected: ""},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			phone, err := valueobject.NewPhoneWithLocale(testCase.phone, testCase.locale)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, phone.E164())
+		})
+	}
+}
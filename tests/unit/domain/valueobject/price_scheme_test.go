@@ -0,0 +1,146 @@
+// Price Scheme Unit Tests
+//
+// This file contains unit tests for rating quantities against catalog prices.
+// Tests: Per-unit, tiered (stairstep), volume and graduated pricing, flat fees, rounding, validation
+// Scope: Pure unit tests - value objects with no external dependencies
+package valueobject
+
+import (
+	"testing"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func money(t *testing.T, amount int64, currency string) valueobject.Money {
+	t.Helper()
+	value, err := valueobject.NewMoney(amount, currency)
+	require.NoError(t, err)
+	return value
+}
+
+func TestPriceScheme_Rate(t *testing.T) {
+	brackets := []valueobject.PriceTier{
+		{UpTo: 100, UnitAmount: 50},
+		{UpTo: 1000, UnitAmount: 40, FlatAmount: 1000},
+		{UnitAmount: 30},
+	}
+	stairs := []valueobject.PriceTier{
+		{UpTo: 10, FlatAmount: 1000},
+		{UpTo: 50, FlatAmount: 4000},
+		{FlatAmount: 7000},
+	}
+
+	testCases := []struct {
+		name          string
+		config        valueobject.PriceSchemeConfig
+		quantity      int64
+		expectedTotal int64
+		expectedLines int
+	}{
+		{name: "per unit", config: valueobject.PriceSchemeConfig{Model: valueobject.PricingPerUnit, UnitAmount: 1200}, quantity: 3, expectedTotal: 3600, expectedLines: 1},
+		{name: "per unit zero quantity", config: valueobject.PriceSchemeConfig{Model: valueobject.PricingPerUnit, UnitAmount: 1200}, quantity: 0, expectedTotal: 0, expectedLines: 0},
+
+		{name: "stairstep first tier upper bound", config: valueobject.PriceSchemeConfig{Model: valueobject.PricingTiered, Tiers: stairs}, quantity: 10, expectedTotal: 1000, expectedLines: 1},
+		{name: "stairstep second tier", config: valueobject.PriceSchemeConfig{Model: valueobject.PricingTiered, Tiers: stairs}, quantity: 11, expectedTotal: 4000, expectedLines: 1},
+		{name: "stairstep unbounded tier", config: valueobject.PriceSchemeConfig{Model: valueobject.PricingTiered, Tiers: stairs}, quantity: 5000, expectedTotal: 7000, expectedLines: 1},
+
+		{name: "volume within first tier", config: valueobject.PriceSchemeConfig{Model: valueobject.PricingVolume, Tiers: brackets}, quantity: 100, expectedTotal: 5000, expectedLines: 1},
+		{name: "volume all units at second tier price", config: valueobject.PriceSchemeConfig{Model: valueobject.PricingVolume, Tiers: brackets}, quantity: 101, expectedTotal: 101*40 + 1000, expectedLines: 1},
+		{name: "volume all units at last tier price", config: valueobject.PriceSchemeConfig{Model: valueobject.PricingVolume, Tiers: brackets}, quantity: 1500, expectedTotal: 1500 * 30, expectedLines: 1},
+
+		{name: "graduated within first tier", config: valueobject.PriceSchemeConfig{Model: valueobject.PricingGraduated, Tiers: brackets}, quantity: 50, expectedTotal: 2500, expectedLines: 1},
+		{name: "graduated across two tiers", config: valueobject.PriceSchemeConfig{Model: valueobject.PricingGraduated, Tiers: brackets}, quantity: 250, expectedTotal: 100*50 + 150*40 + 1000, expectedLines: 2},
+		{name: "graduated across all tiers", config: valueobject.PriceSchemeConfig{Model: valueobject.PricingGraduated, Tiers: brackets}, quantity: 1500, expectedTotal: 100*50 + 900*40 + 1000 + 500*30, expectedLines: 3},
+
+		// Rounding: 5 cents per 1000 units, half away from zero to the cent
+		{name: "sub-cent price rounds down", config: valueobject.PriceSchemeConfig{Model: valueobject.PricingPerUnit, UnitAmount: 5, UnitsPer: 1000}, quantity: 1499, expectedTotal: 7, expectedLines: 1},
+		{name: "sub-cent price rounds half up", config: valueobject.PriceSchemeConfig{Model: valueobject.PricingPerUnit, UnitAmount: 5, UnitsPer: 1000}, quantity: 1500, expectedTotal: 8, expectedLines: 1},
+		{name: "sub-cent price rounds tiny usage up", config: valueobject.PriceSchemeConfig{Model: valueobject.PricingPerUnit, UnitAmount: 5, UnitsPer: 1000}, quantity: 100, expectedTotal: 1, expectedLines: 1},
+		{name: "graduated lines are rounded one by one", config: valueobject.PriceSchemeConfig{Model: valueobject.PricingGraduated, UnitsPer: 1000, Tiers: []valueobject.PriceTier{{UpTo: 1000, UnitAmount: 3}, {UnitAmount: 1}}}, quantity: 1500, expectedTotal: 3 + 1, expectedLines: 2},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			config := testCase.config
+			config.Currency = "EUR"
+			scheme, err := valueobject.NewPriceScheme(config)
+			require.NoError(t, err)
+
+			rating, err := scheme.Rate(testCase.quantity)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.expectedTotal, rating.Total.Amount())
+			assert.Equal(t, "EUR", rating.Total.Currency())
+			assert.Len(t, rating.Lines, testCase.expectedLines)
+
+			// Lines always add up to the total and cover the whole quantity
+			var lineTotal, lineQuantity int64
+			for _, line := range rating.Lines {
+				lineTotal += line.Amount.Amount() + line.FlatAmount.Amount()
+				lineQuantity += line.Quantity
+			}
+			assert.Equal(t, rating.Total.Amount(), lineTotal)
+			assert.Equal(t, testCase.quantity, lineQuantity)
+
+			// Rating is deterministic
+			again, err := scheme.Rate(testCase.quantity)
+			require.NoError(t, err)
+			assert.Equal(t, rating, again)
+		})
+	}
+}
+
+func TestPriceScheme_GraduatedBreakdown(t *testing.T) {
+	scheme, err := valueobject.NewPriceScheme(valueobject.PriceSchemeConfig{
+		Model:    valueobject.PricingGraduated,
+		Currency: "usd",
+		Tiers: []valueobject.PriceTier{
+			{UpTo: 5, UnitAmount: 0},
+			{UpTo: 20, UnitAmount: 800},
+			{UnitAmount: 600},
+		},
+	})
+	require.NoError(t, err)
+
+	rating, err := scheme.Rate(25)
+	require.NoError(t, err)
+
+	require.Len(t, rating.Lines, 3)
+	assert.Equal(t, valueobject.RatedLine{Tier: 1, Quantity: 5, UnitAmount: 0, Amount: money(t, 0, "USD"), FlatAmount: money(t, 0, "USD")}, rating.Lines[0])
+	assert.Equal(t, valueobject.RatedLine{Tier: 2, Quantity: 15, UnitAmount: 800, Amount: money(t, 12000, "USD"), FlatAmount: money(t, 0, "USD")}, rating.Lines[1])
+	assert.Equal(t, valueobject.RatedLine{Tier: 3, Quantity: 5, UnitAmount: 600, Amount: money(t, 3000, "USD"), FlatAmount: money(t, 0, "USD")}, rating.Lines[2])
+	assert.Equal(t, "150.00 USD", rating.Total.String())
+}
+
+func TestPriceScheme_Validation(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config valueobject.PriceSchemeConfig
+	}{
+		{name: "unknown model", config: valueobject.PriceSchemeConfig{Model: "flat", Currency: "EUR"}},
+		{name: "invalid currency", config: valueobject.PriceSchemeConfig{Model: valueobject.PricingPerUnit, Currency: "EURO"}},
+		{name: "negative unit amount", config: valueobject.PriceSchemeConfig{Model: valueobject.PricingPerUnit, Currency: "EUR", UnitAmount: -1}},
+		{name: "negative units per", config: valueobject.PriceSchemeConfig{Model: valueobject.PricingPerUnit, Currency: "EUR", UnitsPer: -10}},
+		{name: "per unit with tiers", config: valueobject.PriceSchemeConfig{Model: valueobject.PricingPerUnit, Currency: "EUR", Tiers: []valueobject.PriceTier{{UnitAmount: 1}}}},
+		{name: "no tiers", config: valueobject.PriceSchemeConfig{Model: valueobject.PricingVolume, Currency: "EUR"}},
+		{name: "bounded last tier", config: valueobject.PriceSchemeConfig{Model: valueobject.PricingVolume, Currency: "EUR", Tiers: []valueobject.PriceTier{{UpTo: 10, UnitAmount: 1}}}},
+		{name: "unbounded middle tier", config: valueobject.PriceSchemeConfig{Model: valueobject.PricingVolume, Currency: "EUR", Tiers: []valueobject.PriceTier{{UnitAmount: 2}, {UnitAmount: 1}}}},
+		{name: "decreasing bounds", config: valueobject.PriceSchemeConfig{Model: valueobject.PricingGraduated, Currency: "EUR", Tiers: []valueobject.PriceTier{{UpTo: 10, UnitAmount: 2}, {UpTo: 5, UnitAmount: 1}, {UnitAmount: 1}}}},
+		{name: "negative tier amount", config: valueobject.PriceSchemeConfig{Model: valueobject.PricingGraduated, Currency: "EUR", Tiers: []valueobject.PriceTier{{UnitAmount: -1}}}},
+		{name: "stairstep with unit amount", config: valueobject.PriceSchemeConfig{Model: valueobject.PricingTiered, Currency: "EUR", Tiers: []valueobject.PriceTier{{UnitAmount: 1, FlatAmount: 100}}}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := valueobject.NewPriceScheme(testCase.config)
+			assert.Error(t, err)
+		})
+	}
+
+	scheme, err := valueobject.NewPriceScheme(valueobject.PriceSchemeConfig{Model: valueobject.PricingPerUnit, Currency: "EUR", UnitAmount: 1})
+	require.NoError(t, err)
+	_, err = scheme.Rate(-1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,119 @@
+// Inbound Webhook Event Domain Unit Tests
+//
+// This file contains unit tests for inbound webhook events and their processing state.
+// Tests: Event validation, stable IDs across redeliveries, dead-lettering, requeueing, discarding, JSON round-trip
+// Scope: Pure unit tests - WebhookEvent entity with no external dependencies
+package webhook
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var receivedAt = time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+func TestNewWebhookEvent_Validation(t *testing.T) {
+	tests := []struct {
+		name      string
+		provider  string
+		eventID   string
+		eventType string
+		payload   string
+		field     string
+	}{
+		{name: "missing provider", provider: " ", eventID: "evt_1", eventType: "payout.paid", payload: `{}`, field: "provider"},
+		{name: "missing event ID", provider: "stripe", eventID: "", eventType: "payout.paid", payload: `{}`, field: "event_id"},
+		{name: "missing event type", provider: "stripe", eventID: "evt_1", eventType: "", payload: `{}`, field: "event_type"},
+		{name: "invalid payload", provider: "stripe", eventID: "evt_1", eventType: "payout.paid", payload: `{`, field: "payload"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := entity.NewWebhookEvent(tt.provider, tt.eventID, tt.eventType, []byte(tt.payload), receivedAt)
+
+			require.Error(t, err)
+			validationErr, ok := err.(*domainErrors.ValidationError)
+			require.True(t, ok)
+			assert.Equal(t, tt.field, validationErr.Field)
+		})
+	}
+}
+
+func TestWebhookEvent_IDIsStablePerProviderEvent(t *testing.T) {
+	first, err := entity.NewWebhookEvent("stripe", "evt_1", "payout.paid", []byte(`{}`), receivedAt)
+	require.NoError(t, err)
+	redelivery, err := entity.NewWebhookEvent("stripe", "evt_1", "payout.paid", []byte(`{}`), receivedAt.Add(time.Minute))
+	require.NoError(t, err)
+	otherProvider, err := entity.NewWebhookEvent("bank", "evt_1", "payout.paid", []byte(`{}`), receivedAt)
+	require.NoError(t, err)
+
+	assert.Equal(t, first.ID(), redelivery.ID())
+	assert.Equal(t, entity.WebhookEventID("stripe", "evt_1"), first.ID())
+	assert.NotEqual(t, first.ID(), otherProvider.ID())
+	assert.Equal(t, entity.WebhookEventPending, first.Status())
+}
+
+func TestWebhookEvent_DeadLetterAndRequeue(t *testing.T) {
+	event, err := entity.NewWebhookEvent("bank", "bank-42", "credit_transfer.received", []byte(`{}`), receivedAt)
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, event.Requeue(), domainErrors.ErrWebhookEventNotDeadLettered)
+
+	event.RecordFailure("ledger unavailable", receivedAt, 2)
+	assert.Equal(t, entity.WebhookEventPending, event.Status())
+	event.RecordFailure("ledger unavailable", receivedAt, 2)
+	assert.Equal(t, entity.WebhookEventDeadLetter, event.Status())
+	assert.Equal(t, 2, event.Attempts())
+	assert.Equal(t, "ledger unavailable", event.LastError())
+
+	require.NoError(t, event.Requeue())
+	assert.Equal(t, entity.WebhookEventPending, event.Status())
+	assert.Zero(t, event.Attempts())
+
+	event.MarkProcessed(receivedAt.Add(time.Hour))
+	assert.Equal(t, entity.WebhookEventProcessed, event.Status())
+	assert.Empty(t, event.LastError())
+	require.NotNil(t, event.ProcessedAt())
+}
+
+func TestWebhookEvent_Discard(t *testing.T) {
+	event, err := entity.NewWebhookEvent("bank", "bank-43", "credit_transfer.received", []byte(`{}`), receivedAt)
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, event.Discard(), domainErrors.ErrWebhookEventNotDeadLettered)
+
+	failedAt := receivedAt.Add(time.Minute)
+	event.RecordFailure("ledger unavailable", failedAt, 1)
+	require.Equal(t, entity.WebhookEventDeadLetter, event.Status())
+	require.NotNil(t, event.FailedAt())
+	assert.Equal(t, failedAt, *event.FailedAt())
+
+	require.NoError(t, event.Discard())
+	assert.Equal(t, entity.WebhookEventDiscarded, event.Status())
+	assert.ErrorIs(t, event.Requeue(), domainErrors.ErrWebhookEventNotDeadLettered)
+}
+
+func TestWebhookEvent_JSONRoundTrip(t *testing.T) {
+	event, err := entity.NewWebhookEvent("stripe", "evt_1", "payout.paid", []byte(`{"id":"evt_1","amount":1200}`), receivedAt)
+	require.NoError(t, err)
+	event.RecordFailure("timeout", receivedAt, 5)
+
+	data, err := json.Marshal(event)
+	require.NoError(t, err)
+	var decoded entity.WebhookEvent
+	require.NoError(t, json.Unmarshal(data, &decoded))
+
+	assert.Equal(t, event.ID(), decoded.ID())
+	assert.Equal(t, "evt_1", decoded.EventID())
+	assert.Equal(t, "payout.paid", decoded.EventType())
+	assert.JSONEq(t, `{"id":"evt_1","amount":1200}`, string(decoded.Payload()))
+	assert.Equal(t, 1, decoded.Attempts())
+	assert.Equal(t, "timeout", decoded.LastError())
+	assert.True(t, receivedAt.Equal(decoded.ReceivedAt()))
+}
@@ -0,0 +1,102 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/eventbus"
+)
+
+type orderPlaced struct {
+	ID string
+}
+
+var (
+	orderPlacedTopic   = eventbus.NewTopic[orderPlaced]("order.placed")
+	orderCanceledTopic = eventbus.NewTopic[orderPlaced]("order.canceled")
+)
+
+func TestPublish_DeliversToSubscribersOfTheTopic(t *testing.T) {
+	bus := eventbus.New()
+	var received []string
+	eventbus.Subscribe(bus, orderPlacedTopic, "first", eventbus.Sync, func(ctx context.Context, event orderPlaced) error {
+		received = append(received, "first:"+event.ID)
+		return nil
+	})
+	eventbus.Subscribe(bus, orderPlacedTopic, "second", eventbus.Sync, func(ctx context.Context, event orderPlaced) error {
+		received = append(received, "second:"+event.ID)
+		return nil
+	})
+	eventbus.Subscribe(bus, orderCanceledTopic, "canceled", eventbus.Sync, func(ctx context.Context, event orderPlaced) error {
+		received = append(received, "canceled:"+event.ID)
+		return nil
+	})
+
+	eventbus.Publish(context.Background(), bus, orderPlacedTopic, orderPlaced{ID: "o-1"})
+
+	assert.Equal(t, []string{"first:o-1", "second:o-1"}, received)
+}
+
+func TestPublish_IsolatesFailingSubscribers(t *testing.T) {
+	var failures []eventbus.Failure
+	bus := eventbus.New().WithFailureHandler(func(failure eventbus.Failure) {
+		failures = append(failures, failure)
+	})
+	eventbus.Subscribe(bus, orderPlacedTopic, "erroring", eventbus.Sync, func(ctx context.Context, event orderPlaced) error {
+		return errors.New("boom")
+	})
+	eventbus.Subscribe(bus, orderPlacedTopic, "panicking", eventbus.Sync, func(ctx context.Context, event orderPlaced) error {
+		panic("unexpected")
+	})
+	delivered := false
+	eventbus.Subscribe(bus, orderPlacedTopic, "healthy", eventbus.Sync, func(ctx context.Context, event orderPlaced) error {
+		delivered = true
+		return nil
+	})
+
+	eventbus.Publish(context.Background(), bus, orderPlacedTopic, orderPlaced{ID: "o-1"})
+
+	assert.True(t, delivered, "a failing subscriber must not stop delivery to the others")
+	require.Len(t, failures, 2)
+	assert.Equal(t, "erroring", failures[0].Subscriber)
+	assert.Equal(t, "order.placed", failures[0].Topic)
+	assert.EqualError(t, failures[0].Err, "boom")
+	assert.Equal(t, "panicking", failures[1].Subscriber)
+	assert.ErrorContains(t, failures[1].Err, "unexpected")
+}
+
+func TestPublish_AsyncSubscribersOutliveThePublisherContext(t *testing.T) {
+	bus := eventbus.New()
+	var mu sync.Mutex
+	var received []string
+	release := make(chan struct{})
+	eventbus.Subscribe(bus, orderPlacedTopic, "async", eventbus.Async, func(ctx context.Context, event orderPlaced) error {
+		<-release
+		mu.Lock()
+		defer mu.Unlock()
+		if ctx.Err() == nil {
+			received = append(received, event.ID)
+		}
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	eventbus.Publish(ctx, bus, orderPlacedTopic, orderPlaced{ID: "o-1"})
+	cancel()
+
+	waitCtx, cancelWait := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancelWait()
+	assert.ErrorIs(t, bus.Wait(waitCtx), context.DeadlineExceeded, "Wait must block while a delivery is in flight")
+
+	close(release)
+	require.NoError(t, bus.Wait(context.Background()))
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"o-1"}, received)
+}
@@ -0,0 +1,251 @@
+package expression
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/expression"
+)
+
+func newEnv(t *testing.T, limits expression.Limits) *expression.Env {
+	env, err := expression.NewEnv(map[string]expression.Type{
+		"client.name":   expression.String,
+		"client.tags":   expression.StringList,
+		"invoice.total": expression.Int,
+		"invoice.lines": expression.Int,
+		"approved":      expression.Bool,
+	}, limits)
+	require.NoError(t, err)
+	return env
+}
+
+var vars = expression.Vars{
+	"client.name":   "Acme Corp",
+	"client.tags":   []string{"enterprise", "trusted"},
+	"invoice.total": int64(120000),
+	"invoice.lines": 3,
+}
+
+func TestProgram_Eval(t *testing.T) {
+	env := newEnv(t, expression.DefaultLimits())
+	tests := []struct {
+		source string
+		want   interface{}
+	}{
+		{`invoice.total <= 500000 && "trusted" in client.tags`, true},
+		{`invoice.total > 100000 && !("trusted" in client.tags)`, false},
+		{`approved || invoice.lines >= 3`, true},
+		{`invoice.total / invoice.lines % 7 + 2 * -3`, int64(40000%7 - 6)},
+		{`client.name.startsWith("Acme") && client.name.lower().endsWith("corp")`, true},
+		{`contains(client.name, "Globex")`, false},
+		{`size(client.tags) == 2 && size("héllo") == 5`, true},
+		{`invoice.total > 100000 ? "review" : 'auto'`, "review"},
+		{`client.name + " (" + client.tags.size().lower() + ")"`, nil},
+		{`invoice.lines in [1, 2, 3]`, true},
+		{`["a", "b"]`, []string{"a", "b"}},
+		{`"b" < "c" && (1 + 2) * 3 == 9`, true},
+		{`approved && invoice.total / 0 > 1`, false}, // && short-circuits
+	}
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			program, err := expression.Compile(env, tt.source)
+			if tt.want == nil {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			got, err := program.Eval(context.Background(), vars)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCompile_ReportsErrors(t *testing.T) {
+	env := newEnv(t, expression.DefaultLimits())
+	tests := []struct {
+		source string
+		err    string
+	}{
+		{`client.email == "a@b.c"`, "position 0: unknown field client.email"},
+		{`invoice.total > "100"`, "cannot order int and string"},
+		{`invoice.total + `, "unexpected end of expression"},
+		{`invoice.total > 1.5`, "amounts are integers"},
+		{`1 < 2 < 3`, "cannot be chained"},
+		{`client.name.matches("A.*")`, "unknown function matches"},
+		{`size(approved)`, "size cannot apply to (bool)"},
+		{`"unterminated`, "unterminated string"},
+		{`[1, "a"]`, "different types"},
+		{`approved ? 1 : "one"`, "different types"},
+		{`invoice.total; 1`, "unexpected character ';'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			_, err := expression.Compile(env, tt.source)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
+
+func TestProgram_OperatorPrecedence(t *testing.T) {
+	env := newEnv(t, expression.DefaultLimits())
+	tests := []struct {
+		source string
+		want   interface{}
+	}{
+		{`1 + 2 * 3`, int64(7)},
+		{`(1 + 2) * 3`, int64(9)},
+		{`10 - 4 - 3`, int64(3)}, // arithmetic is left-associative
+		{`12 / 2 / 3`, int64(2)},
+		{`7 % 4 * 2`, int64(6)},
+		{`-2 * 3 + 10`, int64(4)},
+		{`-(2 + 3)`, int64(-5)},
+		{`1 - -1`, int64(2)},
+		{`size(client.tags) * 2 + 1`, int64(5)},
+		{`client.name.size() - 1`, int64(8)},
+		{`!approved && false`, false},
+		{`true || false && false`, true},
+		{`false && true || true`, true},
+		{`1 + 1 == 2 && 3 > 2`, true},
+		{`2 + 3 in [5]`, true},
+		{`"a" + "b" == "ab"`, true},
+		{`approved || true ? 1 : 2`, int64(1)},
+		{`false ? 1 : true ? 2 : 3`, int64(2)}, // the conditional is right-associative
+	}
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			program, err := expression.Compile(env, tt.source)
+			require.NoError(t, err)
+			got, err := program.Eval(context.Background(), vars)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCompile_ReportsTypeErrors(t *testing.T) {
+	env := newEnv(t, expression.DefaultLimits())
+	tests := []struct {
+		source string
+		err    string
+	}{
+		{`"a" + 1`, "position 4: + cannot apply to string and int"},
+		{`approved + 1`, "+ cannot apply to bool and int"},
+		{`client.name * 2`, "* cannot apply to string and int"},
+		{`client.tags + client.tags`, "+ cannot apply to list(string) and list(string)"},
+		{`!invoice.total`, "! needs a bool operand, found int"},
+		{`-client.name`, "- needs an int operand, found string"},
+		{`invoice.total && approved`, "&& needs bool operands, found int and bool"},
+		{`approved || "yes"`, "|| needs bool operands, found bool and string"},
+		{`client.tags > 1`, "> cannot order list(string) and int"},
+		{`approved < true`, "< cannot order bool and bool"},
+		{`client.tags == client.tags`, "== cannot compare list(string) and list(string)"},
+		{`client.name != 1`, "!= cannot compare string and int"},
+		{`1 in client.tags`, "in needs a value and a list of values of its type, found int and list(string)"},
+		{`"a" in client.name`, "found string and string"},
+		{`invoice.total ? 1 : 2`, "condition is int, not bool"},
+		{`[approved]`, "lists hold strings or ints, not bool"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			_, err := expression.Compile(env, tt.source)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
+
+func TestCompile_EnforcesLimits(t *testing.T) {
+	env := newEnv(t, expression.Limits{MaxLength: 200, MaxNodes: 10, MaxDepth: 5, Timeout: time.Second})
+	tests := []struct {
+		name   string
+		source string
+		err    string // Empty when the source is within the limits
+	}{
+		{"length at the limit", strings.Repeat(" ", 192) + "approved", ""},
+		{"length over the limit", strings.Repeat(" ", 193) + "approved", "longer than 200 characters"},
+		{"long expression", strings.Repeat("approved || ", 20) + "approved", "longer than 200 characters"},
+		{"nodes at the limit", "-1 + 1 + 1 + 1 + 1", ""},
+		{"nodes over the limit", "1 + 1 + 1 + 1 + 1 + 1", "more than 10 operands and operators"},
+		{"depth at the limit", "((((approved))))", ""},
+		{"depth over the limit", "(((((approved)))))", "nested more than 5 levels deep"},
+		{"nested lists", "1 in [[[[[1]]]]]", "nested more than 5 levels deep"},
+		{"nested operators", "!!!!!!approved", "nested more than 5 levels deep"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := expression.Compile(env, tt.source)
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestProgram_EvalTimeout(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancelExpired := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancelExpired()
+
+	long := strings.TrimSuffix(strings.Repeat("invoice.lines + ", 60), " + ") + " > 0"
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		limits expression.Limits
+		source string
+		err    string // Empty when the evaluation completes
+	}{
+		{"within the limit", context.Background(), expression.DefaultLimits(), long, ""},
+		{"canceled context", canceled, expression.DefaultLimits(), "invoice.total > 0", "exceeded its time limit"},
+		{"expired deadline", expired, expression.DefaultLimits(), `client.name.startsWith("Acme")`, "exceeded its time limit"},
+		{"limit elapsed", context.Background(), expression.Limits{MaxLength: 1000, MaxNodes: 200, MaxDepth: 32, Timeout: time.Nanosecond}, long, "exceeded its time limit"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			program, err := expression.Compile(newEnv(t, tt.limits), tt.source)
+			require.NoError(t, err)
+			_, err = program.EvalBool(tt.ctx, vars)
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestProgram_EvalErrors(t *testing.T) {
+	env := newEnv(t, expression.DefaultLimits())
+
+	program, err := expression.Compile(env, "invoice.total / (invoice.lines - 3) > 0")
+	require.NoError(t, err)
+	_, err = program.EvalBool(context.Background(), vars)
+	assert.ErrorContains(t, err, "division by zero")
+
+	program, err = expression.Compile(env, "invoice.total * 9223372036854775807 > 0")
+	require.NoError(t, err)
+	_, err = program.EvalBool(context.Background(), vars)
+	assert.ErrorContains(t, err, "integer overflow")
+
+	_, err = program.Eval(context.Background(), expression.Vars{"client.email": "a@b.c"})
+	assert.ErrorContains(t, err, "not a field")
+
+	_, err = program.Eval(context.Background(), expression.Vars{"invoice.total": "120000"})
+	assert.ErrorContains(t, err, "field invoice.total")
+
+	// Fields without a value are zero
+	program, err = expression.Compile(env, `size(client.tags) == 0 && client.name == "" && !approved`)
+	require.NoError(t, err)
+	ok, err := program.EvalBool(context.Background(), nil)
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
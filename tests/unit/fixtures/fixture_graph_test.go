@@ -0,0 +1,113 @@
+package fixtures
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/gjaminon-go-labs/billing-api/tests/testdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// invoiceData is the payload of the "invoice" fixtures in client_graph_fixtures.json
+type invoiceData struct {
+	Client string `json:"client"`
+	Number string `json:"number"`
+}
+
+func TestGraphLoader_InsertsClientsBeforeTheirInvoices(t *testing.T) {
+	// Arrange
+	clientRepo := repository.NewClientRepository(infrastructure.NewInMemoryStorage())
+
+	var inserted []string
+	invoiceClients := make(map[string]string)
+	loader := testdata.NewGraphLoader().
+		Register("client", func(fixture testdata.Fixture, refs testdata.Refs) (string, error) {
+			inserted = append(inserted, fixture.Ref)
+			return testdata.ClientInserter(clientRepo)(fixture, refs)
+		}).
+		Register("invoice", func(fixture testdata.Fixture, refs testdata.Refs) (string, error) {
+			var data invoiceData
+			if err := json.Unmarshal(fixture.Data, &data); err != nil {
+				return "", err
+			}
+
+			// The owning client must already exist when the invoice is inserted
+			clientID, err := refs.ID(data.Client)
+			if err != nil {
+				return "", err
+			}
+			inserted = append(inserted, fixture.Ref)
+			invoiceClients[data.Number] = clientID
+			return data.Number, nil
+		})
+
+	// Act
+	refs := loader.Load(t, "client/client_graph_fixtures.json")
+
+	// Assert
+	assert.Equal(t, []string{"acme", "acme-invoice-1", "acme-invoice-2", "globex", "globex-invoice-1"}, inserted)
+
+	clients, err := clientRepo.GetAll()
+	require.NoError(t, err)
+	assert.Len(t, clients, 2)
+
+	acme, err := clientRepo.GetByID(refs["acme"])
+	require.NoError(t, err)
+	assert.Equal(t, "Acme Corporation", acme.Name())
+	assert.Equal(t, refs["acme"], invoiceClients["INV-0001"])
+	assert.Equal(t, refs["globex"], invoiceClients["INV-0003"])
+}
+
+func TestGraphLoader_Order_Errors(t *testing.T) {
+	loader := testdata.NewGraphLoader()
+
+	testCases := []struct {
+		description string
+		graph       testdata.Graph
+		expected    string
+	}{
+		{
+			description: "unknown dependency",
+			graph: testdata.Graph{Fixtures: []testdata.Fixture{
+				{Kind: "invoice", Ref: "inv", DependsOn: []string{"missing"}},
+			}},
+			expected: `unknown ref "missing"`,
+		},
+		{
+			description: "dependency cycle",
+			graph: testdata.Graph{Fixtures: []testdata.Fixture{
+				{Kind: "client", Ref: "a", DependsOn: []string{"b"}},
+				{Kind: "client", Ref: "b", DependsOn: []string{"a"}},
+			}},
+			expected: "cycle",
+		},
+		{
+			description: "duplicate ref",
+			graph: testdata.Graph{Fixtures: []testdata.Fixture{
+				{Kind: "client", Ref: "a"},
+				{Kind: "client", Ref: "a"},
+			}},
+			expected: "duplicate",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			_, err := loader.Order(testCase.graph)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), testCase.expected)
+		})
+	}
+}
+
+func TestGraphLoader_Insert_UnregisteredKind(t *testing.T) {
+	graph := testdata.Load[testdata.Graph](t, "client/client_graph_fixtures.json")
+
+	_, err := testdata.NewGraphLoader().Insert(graph)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no inserter registered")
+}
@@ -0,0 +1,73 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/gateway"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChargeClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "Bearer gateway-key", r.Header.Get("Authorization"))
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/charges":
+			if body["payment_method"] == "pm_declined" {
+				w.Write([]byte(`{"id":"ch_2","status":"failed","failure_reason":"card_declined"}`))
+				return
+			}
+			assert.Equal(t, "saga-1/charge", r.Header.Get("Idempotency-Key"))
+			assert.Equal(t, float64(12000), body["amount"])
+			assert.Equal(t, "EUR", body["currency"])
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"ch_1","status":"succeeded"}`))
+		case "/refunds":
+			assert.Equal(t, "saga-1/refund", r.Header.Get("Idempotency-Key"))
+			assert.Equal(t, "ch_1", body["charge"])
+			w.Write([]byte(`{"id":"re_1","status":"succeeded"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := gateway.NewChargeClient(server.URL+"/", "gateway-key", server.Client())
+	charge := service.CardCharge{
+		Reference:     "saga-1/charge",
+		InvoiceID:     "INV-1",
+		ClientID:      "client-1",
+		Amount:        12000,
+		Currency:      "EUR",
+		PaymentMethod: "pm_card",
+	}
+
+	t.Run("charges the card", func(t *testing.T) {
+		chargeID, err := client.Charge(context.Background(), charge)
+		require.NoError(t, err)
+		assert.Equal(t, "ch_1", chargeID)
+	})
+
+	t.Run("declined charges fail", func(t *testing.T) {
+		declined := charge
+		declined.PaymentMethod = "pm_declined"
+		_, err := client.Charge(context.Background(), declined)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "card_declined")
+	})
+
+	t.Run("refunds the charge", func(t *testing.T) {
+		require.NoError(t, client.Refund(context.Background(), "ch_1", "saga-1/refund"))
+	})
+}
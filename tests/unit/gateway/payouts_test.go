@@ -0,0 +1,71 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/gateway"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPayoutClient_ListPayouts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/payouts", r.URL.Path)
+		assert.Equal(t, "Bearer gateway-key", r.Header.Get("Authorization"))
+		assert.Equal(t, "2026-02-23", r.URL.Query().Get("arrived_after"))
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("cursor") == "" {
+			w.Write([]byte(`{"data":[{"id":"po_1","arrival_date":"2026-03-01","amount":9680,"currency":"eur","items":[{"reference":"ch_1","type":"charge","amount":10000,"fee":320}]}],"has_more":true,"next_cursor":"page-2"}`))
+			return
+		}
+		assert.Equal(t, "page-2", r.URL.Query().Get("cursor"))
+		w.Write([]byte(`{"data":[{"id":"po_2","arrival_date":"2026-03-02","amount":0,"currency":"EUR","items":[]}],"has_more":false}`))
+	}))
+	defer server.Close()
+
+	client := gateway.NewPayoutClient(server.URL+"/", "gateway-key", server.Client())
+
+	payouts, err := client.ListPayouts(context.Background(), time.Date(2026, 2, 23, 12, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	require.Len(t, payouts, 2)
+	assert.Equal(t, "po_1", payouts[0].ID)
+	assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), payouts[0].ArrivalDate)
+	assert.Equal(t, "EUR", payouts[0].Amount.Currency())
+	require.Len(t, payouts[0].Items, 1)
+	assert.Equal(t, int64(10000), payouts[0].Items[0].Amount.Amount())
+	assert.Equal(t, int64(320), payouts[0].Items[0].Fee.Amount())
+	assert.Equal(t, "po_2", payouts[1].ID)
+}
+
+func TestPayoutClient_GatewayErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{"rejected credentials", http.StatusUnauthorized, `{"error":"invalid api key"}`},
+		{"malformed report", http.StatusOK, `{"data":`},
+		{"invalid arrival date", http.StatusOK, `{"data":[{"id":"po_1","arrival_date":"March","amount":1,"currency":"EUR"}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := gateway.NewPayoutClient(server.URL, "gateway-key", server.Client())
+
+			_, err := client.ListPayouts(context.Background(), time.Now())
+			assert.Error(t, err)
+		})
+	}
+}
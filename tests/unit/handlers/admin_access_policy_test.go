@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/middleware"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAdminTestServer(t *testing.T) http.Handler {
+	t.Helper()
+
+	storage := infrastructure.NewInMemoryStorage()
+	auditService := application.NewAuditService(repository.NewAuditRepository(storage.Collection(repository.AuditCollection)))
+	policyService := application.NewAccessPolicyService(
+		repository.NewIPAccessPolicyRepository(storage.Collection(repository.IPAccessPolicyCollection)),
+		auditService,
+	)
+
+	server := httpserver.NewServerWithServices(httpserver.Services{
+		Billing:        application.NewBillingService(repository.NewClientRepository(storage)),
+		AccessPolicies: policyService,
+		Audit:          auditService,
+	}, httpserver.ServerOptions{
+		AdminTokens:  map[string]string{"ops": "admin-token", "globex-ops": "globex-token", "multi-ops": "multi-token"},
+		AdminTenants: map[string][]string{"globex-ops": {"globex"}, "multi-ops": {"acme", "globex"}},
+	})
+	return server.Handler()
+}
+
+func newAdminRequest(method, path, body, remoteAddr string) *http.Request {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer admin-token")
+	req.Header.Set(middleware.TenantHeader, "acme")
+	req.RemoteAddr = remoteAddr
+	return req
+}
+
+func TestAdminAPI_IPAccessPolicy(t *testing.T) {
+	handler := newAdminTestServer(t)
+
+	t.Run("admin routes require a valid token", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/ip-access-policies", nil)
+		req.Header.Set("Authorization", "Bearer wrong")
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("invalid CIDR is rejected", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newAdminRequest(http.MethodPut, "/api/v1/admin/ip-access-policies/acme",
+			`{"rules":[{"route_prefix":"/api/v1/admin","allow":["10.0.0.0/33"]}]}`, "10.0.0.5:4000"))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "VALIDATION_FORMAT")
+	})
+
+	t.Run("policy is stored, enforced and audited", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newAdminRequest(http.MethodPut, "/api/v1/admin/ip-access-policies/acme",
+			`{"rules":[{"route_prefix":"/api/v1/admin","allow":["10.0.0.0/24"]}]}`, "10.0.0.5:4000"))
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		// Same tenant from outside the allow list is blocked on admin routes
+		rr = httptest.NewRecorder()
+		handler.ServeHTTP(rr, newAdminRequest(http.MethodGet, "/api/v1/admin/ip-access-policies/acme", "", "198.51.100.1:4000"))
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		assert.Contains(t, rr.Body.String(), "IP_NOT_ALLOWED")
+
+		// Routes outside the rule prefix are unaffected
+		rr = httptest.NewRecorder()
+		handler.ServeHTTP(rr, newAdminRequest(http.MethodGet, "/api/v1/clients", "", "198.51.100.1:4000"))
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		// The change is recorded with the acting admin
+		rr = httptest.NewRecorder()
+		handler.ServeHTTP(rr, newAdminRequest(http.MethodGet, "/api/v1/admin/audit-log?tenant_id=acme", "", "10.0.0.5:4000"))
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var response struct {
+			Data []struct {
+				Action string `json:"action"`
+				Actor  string `json:"actor"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		require.Len(t, response.Data, 1)
+		assert.Equal(t, application.AuditActionIPAccessPolicySet, response.Data[0].Action)
+		assert.Equal(t, "ops", response.Data[0].Actor)
+	})
+
+	t.Run("the tenant is taken from the credentials, not the request", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newAdminRequest(http.MethodPut, "/api/v1/admin/ip-access-policies/globex",
+			`{"rules":[{"route_prefix":"/api/v1/clients","allow":["10.0.0.0/24"]}]}`, "10.0.0.5:4000"))
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		serve := func(token, tenantID string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/clients", nil)
+			if token != "" {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+			if tenantID != "" {
+				req.Header.Set(middleware.TenantHeader, tenantID)
+			}
+			req.RemoteAddr = "198.51.100.1:4000"
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+			return rr
+		}
+
+		// A globex admin is held to the globex policy, named or not
+		rr = serve("globex-token", "")
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		assert.Contains(t, rr.Body.String(), "IP_NOT_ALLOWED")
+
+		// Naming a tenant it does not own resolves none, which is refused rather than unrestricted
+		rr = serve("globex-token", "acme")
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		assert.Contains(t, rr.Body.String(), "TENANT_NOT_ALLOWED")
+
+		// An admin of several tenants must name the one it acts in
+		rr = serve("multi-token", "")
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		assert.Contains(t, rr.Body.String(), "TENANT_NOT_ALLOWED")
+		assert.Equal(t, http.StatusForbidden, serve("multi-token", "globex").Code)
+		assert.Equal(t, http.StatusOK, serve("multi-token", "acme").Code)
+
+		// Anonymous callers act in no tenant: naming one grants nothing
+		assert.Equal(t, http.StatusUnauthorized, serve("", "globex").Code)
+	})
+
+	t.Run("deleting a policy lifts the restriction", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newAdminRequest(http.MethodDelete, "/api/v1/admin/ip-access-policies/acme", "", "10.0.0.5:4000"))
+		require.Equal(t, http.StatusNoContent, rr.Code)
+
+		rr = httptest.NewRecorder()
+		handler.ServeHTTP(rr, newAdminRequest(http.MethodGet, "/api/v1/admin/ip-access-policies/acme", "", "198.51.100.1:4000"))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
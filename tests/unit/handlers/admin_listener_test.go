@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI_SeparateAdminListener(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage))
+	auditService := application.NewAuditService(repository.NewAuditRepository(storage.Collection(repository.AuditCollection)))
+	newServer := func(options httpserver.ServerOptions) *httpserver.Server {
+		options.AdminTokens = map[string]string{"ops": "ops-token"}
+		return httpserver.NewServerWithServices(httpserver.Services{Billing: billingService, Audit: auditService}, options)
+	}
+	serve := func(handler http.Handler, path, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("without an admin listener every route is public", func(t *testing.T) {
+		server := newServer(httpserver.ServerOptions{EnableProfiling: true})
+		assert.Nil(t, server.AdminHandler())
+
+		public := server.Handler()
+		assert.Equal(t, http.StatusOK, serve(public, "/health", "").Code)
+		assert.Equal(t, http.StatusOK, serve(public, "/api/v1/admin/audit-log", "ops-token").Code)
+		assert.Equal(t, http.StatusNotFound, serve(public, httpserver.ProfilingPath+"/", "ops-token").Code, "the profiler needs an admin listener")
+	})
+
+	server := newServer(httpserver.ServerOptions{SeparateAdminListener: true, EnableProfiling: true})
+	public := server.Handler()
+	admin := server.AdminHandler()
+	require.NotNil(t, admin)
+
+	t.Run("the public listener hides operational routes", func(t *testing.T) {
+		for _, path := range []string{"/health", "/api/v1/admin/audit-log", "/api/v1/admin", httpserver.ProfilingPath + "/heap"} {
+			assert.Equal(t, http.StatusNotFound, serve(public, path, "ops-token").Code, path)
+		}
+		assert.Equal(t, http.StatusOK, serve(public, "/api/v1/clients", "ops-token").Code)
+	})
+
+	t.Run("the admin listener serves operational routes only", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, serve(admin, "/health", "").Code)
+		assert.Equal(t, http.StatusOK, serve(admin, "/api/v1/admin/audit-log", "ops-token").Code)
+		assert.Equal(t, http.StatusUnauthorized, serve(admin, "/api/v1/admin/audit-log", "").Code)
+		assert.Equal(t, http.StatusNotFound, serve(admin, "/api/v1/clients", "").Code)
+	})
+
+	t.Run("the profiler requires the operations scope", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, serve(admin, httpserver.ProfilingPath+"/", "ops-token").Code)
+		assert.Equal(t, http.StatusOK, serve(admin, httpserver.ProfilingPath+"/goroutine?debug=1", "ops-token").Code)
+		assert.Equal(t, http.StatusUnauthorized, serve(admin, httpserver.ProfilingPath+"/heap", "").Code)
+	})
+}
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newApprovalTestServer(t *testing.T) http.Handler {
+	t.Helper()
+
+	storage := infrastructure.NewInMemoryStorage()
+	auditService := application.NewAuditService(repository.NewAuditRepository(storage.Collection(repository.AuditCollection)))
+	approvalService := application.NewApprovalService(
+		repository.NewApprovalRequestRepository(storage.Collection(repository.ApprovalRequestCollection)),
+		auditService,
+		100000,
+		[]string{"alice", "bob"},
+	)
+
+	server := httpserver.NewServerWithServices(httpserver.Services{
+		Billing:   application.NewBillingService(repository.NewClientRepository(storage)),
+		Audit:     auditService,
+		Approvals: approvalService,
+	}, httpserver.ServerOptions{
+		AdminTokens: map[string]string{"alice": "alice-token", "bob": "bob-token", "carol": "carol-token"},
+	})
+	return server.Handler()
+}
+
+func approvalRequest(method, path, body, token string) *http.Request {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req
+}
+
+type approvalEnvelope struct {
+	Data struct {
+		ID          string `json:"id"`
+		Status      string `json:"status"`
+		RequestedBy string `json:"requested_by"`
+		ApprovedBy  string `json:"approved_by"`
+	} `json:"data"`
+}
+
+func TestApprovalWorkflow(t *testing.T) {
+	handler := newApprovalTestServer(t)
+
+	t.Run("requires admin credentials", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, approvalRequest(http.MethodGet, "/api/v1/approvals", "", ""))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("small credit notes are approved on creation", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, approvalRequest(http.MethodPost, "/api/v1/approvals",
+			`{"kind":"credit_note","client_id":"client-1","reference_id":"inv-1","amount":5000,"currency":"EUR"}`, "carol-token"))
+
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		var response approvalEnvelope
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, "approved", response.Data.Status)
+		assert.Equal(t, "carol", response.Data.RequestedBy)
+	})
+
+	var refundID string
+	t.Run("large refunds wait for approval", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, approvalRequest(http.MethodPost, "/api/v1/approvals",
+			`{"kind":"refund","client_id":"client-1","reference_id":"pay-1","amount":250000,"currency":"EUR","reason":"double charge"}`, "alice-token"))
+
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		var response approvalEnvelope
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, "pending_approval", response.Data.Status)
+		refundID = response.Data.ID
+
+		rr = httptest.NewRecorder()
+		handler.ServeHTTP(rr, approvalRequest(http.MethodGet, "/api/v1/approvals?status=pending_approval", "", "bob-token"))
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), refundID)
+		assert.NotContains(t, rr.Body.String(), `"reference_id":"inv-1"`)
+	})
+
+	t.Run("the requester cannot approve", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, approvalRequest(http.MethodPost, "/api/v1/approvals/"+refundID+"/approve", "", "alice-token"))
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	})
+
+	t.Run("users without the approver role cannot approve", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, approvalRequest(http.MethodPost, "/api/v1/approvals/"+refundID+"/approve", "", "carol-token"))
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	})
+
+	t.Run("a second approver confirms", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, approvalRequest(http.MethodPost, "/api/v1/approvals/"+refundID+"/approve", "", "bob-token"))
+
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var response approvalEnvelope
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, "approved", response.Data.Status)
+		assert.Equal(t, "bob", response.Data.ApprovedBy)
+
+		rr = httptest.NewRecorder()
+		handler.ServeHTTP(rr, approvalRequest(http.MethodPost, "/api/v1/approvals/"+refundID+"/approve", "", "bob-token"))
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code, "approving twice is rejected")
+	})
+
+	t.Run("every step is in the audit log", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, approvalRequest(http.MethodGet, "/api/v1/admin/audit-log", "", "bob-token"))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		var response struct {
+			Data []struct {
+				Action     string `json:"action"`
+				Actor      string `json:"actor"`
+				ResourceID string `json:"resource_id"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+
+		actions := make(map[string]string)
+		for _, entry := range response.Data {
+			if entry.ResourceID == refundID {
+				actions[entry.Action] = entry.Actor
+			}
+		}
+		assert.Equal(t, map[string]string{
+			application.AuditActionApprovalRequested: "alice",
+			application.AuditActionApprovalApproved:  "bob",
+		}, actions)
+	})
+
+	t.Run("unknown approval", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, approvalRequest(http.MethodGet, "/api/v1/approvals/missing", "", "bob-token"))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
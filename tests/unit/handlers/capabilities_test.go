@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/di"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI_Capabilities(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage))
+
+	getCapabilities := func(t *testing.T, services httpserver.Services, options httpserver.ServerOptions) (dtos.CapabilitiesResponse, *httptest.ResponseRecorder) {
+		t.Helper()
+		handler := httpserver.NewServerWithServices(services, options).Handler()
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/capabilities", nil))
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var response struct {
+			Data dtos.CapabilitiesResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		return response.Data, rr
+	}
+
+	t.Run("a minimal deployment reports its limits and no optional feature", func(t *testing.T) {
+		capabilities, rr := getCapabilities(t, httpserver.Services{Billing: billingService}, httpserver.ServerOptions{Version: "1.4.0"})
+		assert.Equal(t, "1.4.0", capabilities.Version)
+		for feature, enabled := range capabilities.Features {
+			assert.False(t, enabled, feature)
+		}
+		assert.Contains(t, capabilities.Features, "grpc")
+		assert.Contains(t, capabilities.Features, "webhooks")
+
+		assert.Equal(t, []string{"page", "cursor"}, capabilities.Pagination.Modes)
+		assert.Equal(t, dtos.DefaultLimit, capabilities.Pagination.DefaultPageSize)
+		assert.Equal(t, dtos.MaxLimit, capabilities.Limits.MaxPageSize)
+		assert.Positive(t, capabilities.Limits.MaxWebhookBytes)
+		assert.Zero(t, capabilities.Limits.MaxUploadBytes)
+		assert.Empty(t, capabilities.Currencies.Default)
+		assert.Empty(t, capabilities.Currencies.Configured)
+
+		assert.Contains(t, rr.Header().Get("Cache-Control"), "max-age=300")
+	})
+
+	t.Run("configured features, uploads and currencies are reported", func(t *testing.T) {
+		currencies, err := di.CurrencyPoliciesProvider(&di.ContainerConfig{
+			DefaultCurrency:  "EUR",
+			TenantCurrencies: map[string]string{"acme-us": "USD", "acme-ch": "CHF"},
+		})
+		require.NoError(t, err)
+		uploads := application.NewUploadService(
+			repository.NewUploadRepository(storage.Collection(repository.UploadCollection)),
+			repository.NewStorageObjectStore(storage.Collection(repository.UploadChunkCollection)),
+		).WithLimits(50<<20, 0)
+
+		capabilities, _ := getCapabilities(t, httpserver.Services{
+			Billing:    billingService,
+			Uploads:    uploads,
+			Currencies: currencies,
+		}, httpserver.ServerOptions{})
+
+		assert.True(t, capabilities.Features["uploads"])
+		assert.False(t, capabilities.Features["sandbox"])
+		assert.Equal(t, int64(50<<20), capabilities.Limits.MaxUploadBytes)
+		assert.Positive(t, capabilities.Limits.MaxUploadChunkBytes)
+		assert.Equal(t, "EUR", capabilities.Currencies.Default)
+		assert.Equal(t, []string{"CHF", "EUR", "USD"}, capabilities.Currencies.Configured)
+	})
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/middleware"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/captcha"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubChallengeVerifier accepts a single token and can simulate provider outages
+type stubChallengeVerifier struct {
+	validToken string
+	err        error
+	calls      int
+}
+
+func (s *stubChallengeVerifier) Verify(ctx context.Context, token, remoteIP string) (bool, error) {
+	s.calls++
+	if s.err != nil {
+		return false, s.err
+	}
+	return token == s.validToken, nil
+}
+
+func TestCaptchaGuard_Middleware(t *testing.T) {
+	verifier := &stubChallengeVerifier{validToken: "solved"}
+	guard := middleware.NewCaptchaGuard(middleware.CaptchaConfig{
+		Enabled:        true,
+		Routes:         []string{"/api/v1/clients"},
+		TrustedAPIKeys: map[string]string{"crm": "trusted-key"},
+		Verifier:       verifier,
+	})
+	handler := guard.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	testCases := []struct {
+		description  string
+		method       string
+		path         string
+		headers      map[string]string
+		expectedCode int
+		expectedBody string
+	}{
+		{description: "missing token is rejected", method: http.MethodPost, path: "/api/v1/clients", expectedCode: http.StatusForbidden, expectedBody: "CAPTCHA_REQUIRED"},
+		{description: "invalid token is rejected", method: http.MethodPost, path: "/api/v1/clients", headers: map[string]string{middleware.CaptchaTokenHeader: "bot"}, expectedCode: http.StatusForbidden, expectedBody: "CAPTCHA_INVALID"},
+		{description: "solved token passes", method: http.MethodPost, path: "/api/v1/clients", headers: map[string]string{middleware.CaptchaTokenHeader: "solved"}, expectedCode: http.StatusOK},
+		{description: "trusted API key bypasses the challenge", method: http.MethodPost, path: "/api/v1/clients", headers: map[string]string{middleware.APIKeyHeader: "trusted-key"}, expectedCode: http.StatusOK},
+		{description: "unknown API key is still challenged", method: http.MethodPost, path: "/api/v1/clients", headers: map[string]string{middleware.APIKeyHeader: "guess"}, expectedCode: http.StatusForbidden, expectedBody: "CAPTCHA_REQUIRED"},
+		{description: "safe methods are not challenged", method: http.MethodGet, path: "/api/v1/clients", expectedCode: http.StatusOK},
+		{description: "unconfigured routes are not challenged", method: http.MethodPost, path: "/api/v1/webhooks", expectedCode: http.StatusOK},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			req := httptest.NewRequest(testCase.method, testCase.path, strings.NewReader("{}"))
+			for name, value := range testCase.headers {
+				req.Header.Set(name, value)
+			}
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, testCase.expectedCode, rr.Code)
+			if testCase.expectedBody != "" {
+				assert.Contains(t, rr.Body.String(), testCase.expectedBody)
+			}
+		})
+	}
+
+	t.Run("provider outage fails closed", func(t *testing.T) {
+		verifier.err = errors.New("timeout")
+		defer func() { verifier.err = nil }()
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/clients", strings.NewReader("{}"))
+		req.Header.Set(middleware.CaptchaTokenHeader, "solved")
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+		assert.Contains(t, rr.Body.String(), "CAPTCHA_UNAVAILABLE")
+	})
+}
+
+func TestSiteVerifier_Verify(t *testing.T) {
+	provider := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "provider-secret", r.PostForm.Get("secret"))
+		assert.Equal(t, "203.0.113.9", r.PostForm.Get("remoteip"))
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.PostForm.Get("response") == "solved" {
+			w.Write([]byte(`{"success":true}`))
+			return
+		}
+		w.Write([]byte(`{"success":false,"error-codes":["invalid-input-response"]}`))
+	}))
+	defer provider.Close()
+
+	verifier := captcha.NewSiteVerifier(provider.URL, "provider-secret", provider.Client())
+
+	valid, err := verifier.Verify(context.Background(), "solved", "203.0.113.9")
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	valid, err = verifier.Verify(context.Background(), "forged", "203.0.113.9")
+	require.NoError(t, err)
+	assert.False(t, valid)
+
+	_, err = captcha.NewVerifier("recaptcha", "secret", nil)
+	assert.Error(t, err)
+}
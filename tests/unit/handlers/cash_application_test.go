@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// staticOpenInvoices lists a fixed set of open invoices
+type staticOpenInvoices []service.OpenInvoice
+
+func (s staticOpenInvoices) OpenInvoices() ([]service.OpenInvoice, error) {
+	return s, nil
+}
+
+const cashStatement = `<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.08">
+  <BkToCstmrStmt><Stmt>
+    <Acct><Id><IBAN>DE89370400440532013000</IBAN></Id></Acct>
+    <Ntry>
+      <Amt Ccy="EUR">480.00</Amt>
+      <CdtDbtInd>CRDT</CdtDbtInd>
+      <Sts><Cd>BOOK</Cd></Sts>
+      <BookgDt><Dt>2026-03-02</Dt></BookgDt>
+      <AcctSvcrRef>BANKREF-7</AcctSvcrRef>
+      <NtryDtls><TxDtls>
+        <RltdPties><Dbtr><Nm>Globex Corp</Nm></Dbtr></RltdPties>
+        <RmtInf><Ustrd>INV-2026-0043</Ustrd></RmtInf>
+      </TxDtls></NtryDtls>
+    </Ntry>
+    <Ntry>
+      <Amt Ccy="EUR">15.25</Amt>
+      <CdtDbtInd>DBIT</CdtDbtInd>
+      <Sts><Cd>BOOK</Cd></Sts>
+      <BookgDt><Dt>2026-03-02</Dt></BookgDt>
+    </Ntry>
+  </Stmt></BkToCstmrStmt>
+</Document>`
+
+func TestAPI_CashApplication(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	auditService := application.NewAuditService(repository.NewAuditRepository(storage.Collection(repository.AuditCollection)))
+	billingService := application.NewBillingService(repository.NewClientRepository(storage)).
+		WithInvoices(repository.NewInvoiceRepository(storage.Collection(repository.InvoiceCollection))).
+		WithPayments(repository.NewPaymentRepository(storage.Collection(repository.PaymentCollection)))
+
+	client, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Globex Corp", Email: "billing@globex.example"})
+	require.NoError(t, err)
+	draft, err := billingService.CreateInvoice(application.SystemContext("test", ""), dtos.CreateInvoiceRequest{
+		ClientID:  client.ID(),
+		Currency:  "EUR",
+		LineItems: []dtos.InvoiceLineRequest{{Description: "Consulting", Quantity: 1, UnitAmount: 48000}},
+	})
+	require.NoError(t, err)
+	invoice, err := billingService.IssueInvoice(application.AdminContext("ops"), draft.ID(), time.Date(2026, time.February, 2, 9, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	outstanding, err := valueobject.NewMoney(48000, "EUR")
+	require.NoError(t, err)
+	cashService := application.NewCashApplicationService(
+		repository.NewBankTransactionRepository(storage.Collection(repository.BankTransactionCollection)),
+		auditService,
+		billingService,
+	).WithOpenInvoices(staticOpenInvoices{
+		{InvoiceID: invoice.ID(), Number: "INV-2026-0043", ClientID: client.ID(), ClientName: "Globex Corp", Outstanding: outstanding},
+	})
+
+	handler := httpserver.NewServerWithServices(httpserver.Services{
+		Billing: billingService,
+		Audit:   auditService,
+		Cash:    cashService,
+	}, httpserver.ServerOptions{
+		AdminTokens: map[string]string{"ops": "admin-token"},
+	}).Handler()
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newAdminRequest(method, path, body, "192.0.2.10:1234"))
+		return rr
+	}
+
+	type importResponse struct {
+		Data struct {
+			Format        string `json:"format"`
+			Imported      int    `json:"imported"`
+			Duplicates    int    `json:"duplicates"`
+			SkippedDebits int    `json:"skipped_debits"`
+			Transactions  []struct {
+				ID          string `json:"id"`
+				Suggestions []struct {
+					InvoiceID string   `json:"invoice_id"`
+					Score     int      `json:"score"`
+					Reasons   []string `json:"reasons"`
+				} `json:"suggestions"`
+			} `json:"transactions"`
+		} `json:"data"`
+	}
+
+	t.Run("requires admin credentials", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/bank-transactions", nil)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("rejects unreadable statements", func(t *testing.T) {
+		rr := serve(http.MethodPost, "/api/v1/bank-statements?format=mt940", "garbage")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		rr = serve(http.MethodPost, "/api/v1/bank-statements?format=bai2", cashStatement)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	var transactionID string
+	t.Run("imports money received with match suggestions", func(t *testing.T) {
+		rr := serve(http.MethodPost, "/api/v1/bank-statements", cashStatement)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		var response importResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, "camt053", response.Data.Format)
+		assert.Equal(t, 1, response.Data.Imported)
+		assert.Equal(t, 1, response.Data.SkippedDebits)
+		require.Len(t, response.Data.Transactions, 1)
+		require.Len(t, response.Data.Transactions[0].Suggestions, 1)
+		suggestion := response.Data.Transactions[0].Suggestions[0]
+		assert.Equal(t, invoice.ID(), suggestion.InvoiceID)
+		assert.Equal(t, []string{"reference", "exact_amount", "counterparty"}, suggestion.Reasons)
+
+		transactionID = response.Data.Transactions[0].ID
+	})
+
+	t.Run("overlapping statements are not imported twice", func(t *testing.T) {
+		rr := serve(http.MethodPost, "/api/v1/bank-statements", cashStatement)
+		require.Equal(t, http.StatusCreated, rr.Code)
+
+		var response importResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, 0, response.Data.Imported)
+		assert.Equal(t, 1, response.Data.Duplicates)
+	})
+
+	payments := func() []*entity.Payment {
+		t.Helper()
+		payments, err := billingService.ListPayments(invoice.ID())
+		require.NoError(t, err)
+		return payments
+	}
+
+	t.Run("the client must be the client of the invoice", func(t *testing.T) {
+		require.NotEmpty(t, transactionID)
+
+		rr := serve(http.MethodPost, "/api/v1/bank-transactions/"+transactionID+"/reconcile",
+			`{"invoice_id":"`+invoice.ID()+`","client_id":"client-initech"}`)
+		assert.Equal(t, http.StatusBadRequest, rr.Code, rr.Body.String())
+		assert.Empty(t, payments())
+	})
+
+	t.Run("reconciling records the payment of the invoice", func(t *testing.T) {
+		require.NotEmpty(t, transactionID)
+
+		rr := serve(http.MethodPost, "/api/v1/bank-transactions/"+transactionID+"/reconcile",
+			`{"invoice_id":"`+invoice.ID()+`","client_id":"`+client.ID()+`"}`)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), `"status":"reconciled"`)
+		assert.Contains(t, rr.Body.String(), `"reconciled_by":"ops"`)
+
+		recorded := payments()
+		require.Len(t, recorded, 1)
+		assert.Equal(t, entity.PaymentBankTransfer, recorded[0].Method())
+		assert.Equal(t, transactionID, recorded[0].Reference())
+		assert.Equal(t, int64(48000), recorded[0].Amount().Amount())
+		assert.Equal(t, time.Date(2026, time.March, 2, 0, 0, 0, 0, time.UTC), recorded[0].ReceivedAt())
+
+		paid, err := billingService.GetInvoice(application.AdminContext("ops"), invoice.ID())
+		require.NoError(t, err)
+		assert.Equal(t, entity.InvoicePaid, paid.Status())
+
+		rr = serve(http.MethodGet, "/api/v1/bank-transactions?status=unmatched", "")
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"data":[],"success":true}`, rr.Body.String())
+
+		rr = serve(http.MethodGet, "/api/v1/admin/audit-log", "")
+		assert.Contains(t, rr.Body.String(), application.AuditActionBankTransactionReconciled)
+	})
+
+	t.Run("a transaction is reconciled only once", func(t *testing.T) {
+		rr := serve(http.MethodPost, "/api/v1/bank-transactions/"+transactionID+"/reconcile",
+			`{"invoice_id":"`+invoice.ID()+`","client_id":"`+client.ID()+`"}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+		assert.Len(t, payments(), 1)
+	})
+
+	t.Run("unknown transactions are not found", func(t *testing.T) {
+		rr := serve(http.MethodGet, "/api/v1/bank-transactions/missing", "")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI_BatchGetClients(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage))
+	handler := httpserver.NewServerWithServices(httpserver.Services{Billing: billingService}, adminOptions()).Handler()
+
+	acme, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+	require.NoError(t, err)
+	globex, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Globex", Email: "ap@globex.example"})
+	require.NoError(t, err)
+
+	batchGet := func(body string) *httptest.ResponseRecorder {
+		req := asAdmin(httptest.NewRequest(http.MethodPost, "/api/v1/clients/batch-get", bytes.NewBufferString(body)))
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+	type batchResponse struct {
+		Data struct {
+			Clients []struct {
+				ID   string `json:"id"`
+				Name string `json:"name"`
+			} `json:"clients"`
+			NotFound []string `json:"not_found"`
+		} `json:"data"`
+	}
+
+	t.Run("clients come back in the order requested", func(t *testing.T) {
+		missing := uuid.New().String()
+		ids, _ := json.Marshal(map[string][]string{"ids": {globex.ID(), missing, acme.ID(), globex.ID()}})
+
+		rr := batchGet(string(ids))
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var body batchResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		require.Len(t, body.Data.Clients, 2)
+		assert.Equal(t, "Globex", body.Data.Clients[0].Name)
+		assert.Equal(t, "Acme Corp", body.Data.Clients[1].Name)
+		assert.Equal(t, []string{missing}, body.Data.NotFound)
+	})
+
+	t.Run("invalid requests are rejected", func(t *testing.T) {
+		tooMany := make([]string, 101)
+		for i := range tooMany {
+			tooMany[i] = uuid.New().String()
+		}
+		oversized, _ := json.Marshal(map[string][]string{"ids": tooMany})
+
+		for name, body := range map[string]string{
+			"no ids":       `{"ids": []}`,
+			"invalid id":   `{"ids": ["not-a-uuid"]}`,
+			"too many ids": string(oversized),
+			"invalid json": `{"ids":`,
+		} {
+			rr := batchGet(body)
+			assert.Equal(t, http.StatusBadRequest, rr.Code, name)
+		}
+	})
+
+	t.Run("only POST is allowed", func(t *testing.T) {
+		req := asAdmin(httptest.NewRequest(http.MethodGet, "/api/v1/clients/batch-get", nil))
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	})
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fetchClientChanges(t *testing.T, handler http.Handler, query string) dtos.ClientChangesResponse {
+	t.Helper()
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, asAdmin(httptest.NewRequest(http.MethodGet, "/api/v1/clients/changes"+query, nil)))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	var response struct {
+		Data dtos.ClientChangesResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	return response.Data
+}
+
+func TestServer_ClientChanges_DeltaSync(t *testing.T) {
+	// Arrange
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage)).
+		WithChangeLog(repository.NewClientChangeRepository(storage.Collection(repository.ClientChangeCollection)))
+	handler := httpserver.NewServerWithOptions(billingService, adminOptions()).Handler()
+
+	alice, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Alice Martin", Email: "alice@acme.com"})
+	require.NoError(t, err)
+	bob, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Bob Stone", Email: "bob@globex.com"})
+	require.NoError(t, err)
+	_, err = billingService.UpdateClient(application.AdminContext("ops"), alice.ID(), dtos.UpdateClientRequest{Name: "Alice Martin-Roy"})
+	require.NoError(t, err)
+	require.NoError(t, billingService.DeleteClient(application.AdminContext("billing-admin"), bob.ID()))
+
+	// Act: page through the feed two changes at a time
+	first := fetchClientChanges(t, handler, "?limit=2")
+	second := fetchClientChanges(t, handler, "?limit=2&since="+first.NextCursor)
+	caughtUp := fetchClientChanges(t, handler, "?since="+second.NextCursor)
+
+	// Assert
+	require.Len(t, first.Changes, 2)
+	assert.True(t, first.HasMore)
+	assert.Equal(t, "created", first.Changes[0].Type)
+	assert.Equal(t, "created", first.Changes[1].Type)
+
+	require.Len(t, second.Changes, 2)
+	assert.False(t, second.HasMore)
+	assert.Equal(t, "updated", second.Changes[0].Type)
+	assert.Equal(t, "Alice Martin-Roy", second.Changes[0].Client.Name)
+	assert.Equal(t, "deleted", second.Changes[1].Type)
+	assert.Equal(t, bob.ID(), second.Changes[1].ClientID)
+	assert.Nil(t, second.Changes[1].Client, "tombstones carry no snapshot")
+	assert.Less(t, first.Changes[1].Sequence, second.Changes[0].Sequence)
+
+	assert.Empty(t, caughtUp.Changes)
+	assert.Equal(t, second.NextCursor, caughtUp.NextCursor)
+
+	// Timestamps are accepted as well
+	all := fetchClientChanges(t, handler, "?since=2000-01-01T00:00:00Z")
+	assert.Len(t, all.Changes, 4)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, asAdmin(httptest.NewRequest(http.MethodGet, "/api/v1/clients/changes?since=yesterday", nil)))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestServer_ClientChanges_TenantIsolation(t *testing.T) {
+	// Arrange
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage)).
+		WithChangeLog(repository.NewClientChangeRepository(storage.Collection(repository.ClientChangeCollection)))
+	handler := httpserver.NewServerWithOptions(billingService, httpserver.ServerOptions{
+		AdminTokens:  map[string]string{"acme-admin": "acme-token", "globex-admin": "globex-token"},
+		AdminTenants: map[string][]string{"acme-admin": {"acme"}, "globex-admin": {"globex"}},
+	}).Handler()
+
+	acme := application.SystemContext("test", "acme")
+	alice, err := billingService.CreateClient(acme, dtos.CreateClientRequest{Name: "Alice Martin", Email: "alice@acme.com", Phone: "+33123456789"})
+	require.NoError(t, err)
+	bob, err := billingService.CreateClient(acme, dtos.CreateClientRequest{Name: "Bob Stone", Email: "bob@acme.com"})
+	require.NoError(t, err)
+	require.NoError(t, billingService.DeleteClient(acme, bob.ID()))
+
+	fetch := func(token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/clients/changes", nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+	changesOf := func(rr *httptest.ResponseRecorder) dtos.ClientChangesResponse {
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var response struct {
+			Data dtos.ClientChangesResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		return response.Data
+	}
+
+	t.Run("anonymous callers are refused", func(t *testing.T) {
+		rr := fetch("")
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.NotContains(t, rr.Body.String(), alice.Email())
+	})
+
+	t.Run("another tenant sees no change, tombstones included", func(t *testing.T) {
+		page := changesOf(fetch("globex-token"))
+		assert.Empty(t, page.Changes)
+		assert.NotEmpty(t, page.NextCursor, "the cursor moves past the changes of other tenants")
+	})
+
+	t.Run("the owning tenant sees every change", func(t *testing.T) {
+		page := changesOf(fetch("acme-token"))
+		require.Len(t, page.Changes, 3)
+		assert.Equal(t, alice.ID(), page.Changes[0].ClientID)
+		assert.Equal(t, "deleted", page.Changes[2].Type)
+		assert.Equal(t, bob.ID(), page.Changes[2].ClientID)
+	})
+}
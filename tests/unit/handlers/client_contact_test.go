@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/middleware"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI_ClientContacts(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage)).
+		WithContacts(repository.NewClientContactRepository(storage.Collection(repository.ClientContactCollection)))
+	handler := httpserver.NewServerWithServices(httpserver.Services{Billing: billingService}, httpserver.ServerOptions{
+		AdminTokens:  map[string]string{"billing": "billing-token", "globex-admin": "globex-token", "initech-admin": "initech-token"},
+		AdminTenants: map[string][]string{"globex-admin": {"globex"}, "initech-admin": {"initech"}},
+	}).Handler()
+
+	// Callers act in a tenant through the credentials of an admin of the tenant; clients without a tenant are
+	// reached by the billing admin
+	serve := func(method, path, tenantID, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer billing-token")
+		if tenantID != "" {
+			req.Header.Set("Authorization", "Bearer "+tenantID+"-token")
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+	decodeContact := func(t *testing.T, rr *httptest.ResponseRecorder) dtos.ClientContactResponse {
+		t.Helper()
+		var response struct {
+			Data dtos.ClientContactResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response), rr.Body.String())
+		return response.Data
+	}
+	listContacts := func(t *testing.T, clientID string) []dtos.ClientContactResponse {
+		t.Helper()
+		rr := serve(http.MethodGet, "/api/v1/clients/"+clientID+"/contacts", "", "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var response struct {
+			Data []dtos.ClientContactResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		return response.Data
+	}
+
+	acme, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+	require.NoError(t, err)
+	globex, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Globex", Email: "ap@globex.example"})
+	require.NoError(t, err)
+	contactsPath := "/api/v1/clients/" + acme.ID() + "/contacts"
+
+	var payable dtos.ClientContactResponse
+	t.Run("contacts are added to a client", func(t *testing.T) {
+		rr := serve(http.MethodPost, contactsPath, "", `{"name":"Jane Doe","role":"Accounts payable","email":"Jane@Acme.example","phone":"+33 1 23 45 67 89"}`)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		payable = decodeContact(t, rr)
+		assert.Equal(t, acme.ID(), payable.ClientID)
+		assert.Equal(t, "Accounts payable", payable.Role)
+		assert.Equal(t, "jane@acme.example", payable.Email)
+
+		rr = serve(http.MethodPost, contactsPath, "", `{"name":"John Roe","email":"john@acme.example"}`)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		contacts := listContacts(t, acme.ID())
+		require.Len(t, contacts, 2)
+		assert.Equal(t, payable.ID, contacts[0].ID, "oldest first")
+		assert.Empty(t, listContacts(t, globex.ID()))
+	})
+
+	t.Run("invalid contacts are rejected", func(t *testing.T) {
+		for _, body := range []string{
+			`{"name":"Jane Doe"}`,
+			`{"name":"J","email":"j@acme.example"}`,
+			`{"name":"Jane Doe","email":"not-an-email"}`,
+			`{"name":"Jane Doe","email":"jane@acme.example","role":"` + strings.Repeat("x", 51) + `"}`,
+		} {
+			rr := serve(http.MethodPost, contactsPath, "", body)
+			assert.Equal(t, http.StatusBadRequest, rr.Code, body)
+		}
+		rr := serve(http.MethodPost, "/api/v1/clients/00000000-0000-4000-8000-000000000000/contacts", "", `{"name":"Jane Doe","email":"jane@acme.example"}`)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("contacts are updated and deleted", func(t *testing.T) {
+		contactPath := contactsPath + "/" + payable.ID
+		rr := serve(http.MethodPut, contactPath, "", `{"name":"Jane Doe","role":"Controller","email":"jane@acme.example"}`)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Equal(t, "Controller", decodeContact(t, rr).Role)
+		assert.Empty(t, decodeContact(t, serve(http.MethodGet, contactPath, "", "")).Phone)
+
+		rr = serve(http.MethodDelete, contactPath, "", "")
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		rr = serve(http.MethodGet, contactPath, "", "")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Len(t, listContacts(t, acme.ID()), 1)
+	})
+
+	t.Run("contacts are only reached through their client", func(t *testing.T) {
+		contactID := listContacts(t, acme.ID())[0].ID
+		rr := serve(http.MethodGet, "/api/v1/clients/"+globex.ID()+"/contacts/"+contactID, "", "")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		rr = serve(http.MethodDelete, "/api/v1/clients/"+globex.ID()+"/contacts/"+contactID, "", "")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Len(t, listContacts(t, acme.ID()), 1)
+	})
+
+	t.Run("contacts of clients of another tenant are not found", func(t *testing.T) {
+		initech, err := billingService.CreateClient(application.SystemContext("test", "initech"), dtos.CreateClientRequest{Name: "Initech", Email: "ap@initech.example"})
+		require.NoError(t, err)
+		path := "/api/v1/clients/" + initech.ID() + "/contacts"
+		rr := serve(http.MethodPost, path, "globex", `{"name":"Peter Gibbons","email":"peter@initech.example"}`)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+
+		// Naming the tenant is not enough
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"name":"Peter Gibbons","email":"peter@initech.example"}`))
+		req.Header.Set(middleware.TenantHeader, "initech")
+		rr = httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+
+		rr = serve(http.MethodPost, path, "initech", `{"name":"Peter Gibbons","email":"peter@initech.example"}`)
+		assert.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	})
+
+	t.Run("contacts are deleted with their client", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/clients/"+acme.ID(), nil)
+		req.Header.Set("Authorization", "Bearer billing-token")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusNoContent, rr.Code, rr.Body.String())
+
+		remaining, err := repository.NewClientContactRepository(storage.Collection(repository.ClientContactCollection)).ListByClient(acme.ID())
+		require.NoError(t, err)
+		assert.Empty(t, remaining)
+		rr = serve(http.MethodGet, contactsPath, "", "")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI_ClientCursorPagination(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	clientRepo := repository.NewClientRepository(storage)
+	billingService := application.NewBillingService(clientRepo)
+	handler := httpserver.NewServerWithServices(httpserver.Services{Billing: billingService}, adminOptions()).Handler()
+
+	list := func(query string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, asAdmin(httptest.NewRequest(http.MethodGet, "/api/v1/clients"+query, nil)))
+		return rr
+	}
+	type cursorPage struct {
+		Data       []dtos.ClientResponse         `json:"data"`
+		Pagination dtos.CursorPaginationResponse `json:"pagination"`
+	}
+	listPage := func(t *testing.T, query string) cursorPage {
+		t.Helper()
+		rr := list(query)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var page cursorPage
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &page))
+		return page
+	}
+	ids := func(page cursorPage) []string {
+		result := make([]string, len(page.Data))
+		for i, client := range page.Data {
+			result[i] = client.ID
+		}
+		return result
+	}
+	save := func(name, email string, createdAt time.Time) *entity.Client {
+		client, err := entity.NewClientWithID(uuid.NewString(), name, email, "", "", createdAt, createdAt)
+		require.NoError(t, err)
+		require.NoError(t, clientRepo.Save(client))
+		return client
+	}
+
+	// Stored out of creation order, two clients created at the same time
+	globex := save("Globex", "accounts@globex.example", time.Date(2026, time.June, 1, 9, 0, 0, 0, time.UTC))
+	acme := save("Acme Corp", "billing@acme.example", time.Date(2026, time.January, 10, 9, 0, 0, 0, time.UTC))
+	initech := save("Initech", "ap@initech.example", time.Date(2026, time.March, 5, 9, 0, 0, 0, time.UTC))
+	twin := save("Acme Labs", "ap@labs.example", time.Date(2026, time.March, 5, 9, 0, 0, 0, time.UTC))
+	march := []string{initech.ID(), twin.ID()}
+	if twin.ID() < initech.ID() {
+		march = []string{twin.ID(), initech.ID()}
+	}
+
+	t.Run("walk every page in creation order", func(t *testing.T) {
+		first := listPage(t, "?cursor=&limit=2")
+		assert.Equal(t, []string{acme.ID(), march[0]}, ids(first))
+		assert.Equal(t, 2, first.Pagination.Limit)
+		assert.True(t, first.Pagination.HasMore)
+
+		second := listPage(t, "?limit=2&cursor="+url.QueryEscape(first.Pagination.NextCursor))
+		assert.Equal(t, []string{march[1], globex.ID()}, ids(second))
+		assert.False(t, second.Pagination.HasMore)
+
+		// The last cursor resumes after the last client, once newer clients exist
+		last := listPage(t, "?limit=2&cursor="+url.QueryEscape(second.Pagination.NextCursor))
+		assert.Empty(t, ids(last))
+		assert.Equal(t, second.Pagination.NextCursor, last.Pagination.NextCursor)
+		newer := save("Umbrella", "ar@umbrella.example", time.Date(2026, time.July, 1, 9, 0, 0, 0, time.UTC))
+		assert.Equal(t, []string{newer.ID()}, ids(listPage(t, "?limit=2&cursor="+url.QueryEscape(second.Pagination.NextCursor))))
+		require.NoError(t, clientRepo.Delete(newer.ID()))
+	})
+
+	t.Run("pages do not shift when clients change between them", func(t *testing.T) {
+		first := listPage(t, "?cursor=&limit=2")
+		require.NoError(t, clientRepo.Delete(acme.ID()))
+		defer func() { require.NoError(t, clientRepo.Save(acme)) }()
+
+		second := listPage(t, "?limit=2&cursor="+url.QueryEscape(first.Pagination.NextCursor))
+		assert.Equal(t, []string{march[1], globex.ID()}, ids(second))
+	})
+
+	t.Run("filters apply to every page", func(t *testing.T) {
+		first := listPage(t, "?q=acme&cursor=&limit=1")
+		assert.Equal(t, []string{acme.ID()}, ids(first))
+		assert.True(t, first.Pagination.HasMore)
+		second := listPage(t, "?q=acme&limit=1&cursor="+url.QueryEscape(first.Pagination.NextCursor))
+		assert.Equal(t, []string{twin.ID()}, ids(second))
+		assert.False(t, second.Pagination.HasMore)
+	})
+
+	t.Run("reject invalid cursors and mixed modes", func(t *testing.T) {
+		for _, query := range []string{"?cursor=bogus", "?cursor=" + url.QueryEscape("bm90LWEtY3Vyc29y"), "?cursor=&page=2", "?cursor=&sort=name"} {
+			rr := list(query)
+			assert.Equal(t, http.StatusBadRequest, rr.Code, query)
+		}
+	})
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI_ClientDeletionRules(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage)).
+		WithInvoices(repository.NewInvoiceRepository(storage.Collection(repository.InvoiceCollection))).
+		WithPayments(repository.NewPaymentRepository(storage.Collection(repository.PaymentCollection)))
+	handler := httpserver.NewServerWithServices(httpserver.Services{Billing: billingService}, httpserver.ServerOptions{
+		AdminTokens: map[string]string{"billing": "billing-token"},
+	}).Handler()
+
+	deleteClient := func(id string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/clients/"+id, nil)
+		req.Header.Set("Authorization", "Bearer billing-token")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+	newInvoice := func(clientID string) *entity.Invoice {
+		invoice, err := billingService.CreateInvoice(application.AdminContext("ops"), dtos.CreateInvoiceRequest{
+			ClientID:  clientID,
+			Currency:  "EUR",
+			LineItems: []dtos.InvoiceLineRequest{{Description: "Consulting", Quantity: 1, UnitAmount: 10000}},
+		})
+		require.NoError(t, err)
+		return invoice
+	}
+	type errorResponse struct {
+		Error struct {
+			Code    string `json:"code"`
+			Details struct {
+				InvoiceIDs []string `json:"invoice_ids"`
+			} `json:"details"`
+		} `json:"error"`
+	}
+
+	t.Run("drafts are voided with the client", func(t *testing.T) {
+		client, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+		require.NoError(t, err)
+		draft := newInvoice(client.ID())
+
+		rr := deleteClient(client.ID())
+		require.Equal(t, http.StatusNoContent, rr.Code, rr.Body.String())
+
+		voided, err := billingService.GetInvoice(application.AdminContext("ops"), draft.ID())
+		require.NoError(t, err)
+		assert.Equal(t, entity.InvoiceVoid, voided.Status())
+	})
+
+	t.Run("open invoices block the deletion", func(t *testing.T) {
+		client, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Globex", Email: "ap@globex.example"})
+		require.NoError(t, err)
+		open := newInvoice(client.ID())
+		_, err = billingService.IssueInvoice(application.AdminContext("ops"), open.ID(), time.Now())
+		require.NoError(t, err)
+
+		rr := deleteClient(client.ID())
+		require.Equal(t, http.StatusConflict, rr.Code, rr.Body.String())
+		var body errorResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		assert.Equal(t, "BUSINESS_RULE_DEPENDENT", body.Error.Code)
+		assert.Equal(t, []string{open.ID()}, body.Error.Details.InvoiceIDs)
+
+		// Voided, the invoice stays on record and is protected from being orphaned
+		_, err = billingService.VoidInvoice(application.AdminContext("ops"), open.ID(), time.Now())
+		require.NoError(t, err)
+		rr = deleteClient(client.ID())
+		require.Equal(t, http.StatusUnprocessableEntity, rr.Code, rr.Body.String())
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		assert.Equal(t, "BUSINESS_RULE_VIOLATION", body.Error.Code)
+		assert.Equal(t, []string{open.ID()}, body.Error.Details.InvoiceIDs)
+
+		_, err = billingService.GetOwnedClient(application.AdminContext("ops"), client.ID())
+		assert.NoError(t, err)
+
+		// Retained, closed invoices no longer block
+		billingService.WithClientDeletionPolicy(service.ClientDeletionPolicy{Closed: service.ClientDeletionRetain})
+		assert.Equal(t, http.StatusNoContent, deleteClient(client.ID()).Code)
+	})
+}
@@ -1,18 +1,16 @@
 package handlers
 
 import (
-	"encoding/json"
 	"net/http"
 	"net/http/httptest"
-	"os"
-	"path/filepath"
-	"runtime"
 	"testing"
 
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
 	"github.com/gjaminon-go-labs/billing-api/internal/api/http/handlers"
 	"github.com/gjaminon-go-labs/billing-api/internal/application"
 	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
 	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/gjaminon-go-labs/billing-api/tests/testdata"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -23,7 +21,7 @@ func TestClientHandler_ListClients_GET_EmptyList(t *testing.T) {
 	billingService := application.NewBillingService(clientRepo)
 	handler := handlers.NewClientHandler(billingService)
 
-	req := httptest.NewRequest(http.MethodGet, "/api/v1/clients", nil)
+	req := asHandlerAdmin(httptest.NewRequest(http.MethodGet, "/api/v1/clients", nil))
 	rr := httptest.NewRecorder()
 
 	// Act
@@ -49,13 +47,13 @@ func TestClientHandler_ListClients_GET_WithClients(t *testing.T) {
 	fixtures := loadHandlerTestFixtures(t)
 
 	// Create test clients from fixtures
-	_, err := billingService.CreateClient(fixtures[0].Name, fixtures[0].Email, fixtures[0].Phone, fixtures[0].Address)
+	_, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: fixtures[0].Name, Email: fixtures[0].Email, Phone: fixtures[0].Phone, Address: fixtures[0].Address})
 	assert.NoError(t, err)
 
-	_, err = billingService.CreateClient(fixtures[1].Name, fixtures[1].Email, fixtures[1].Phone, fixtures[1].Address)
+	_, err = billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: fixtures[1].Name, Email: fixtures[1].Email, Phone: fixtures[1].Phone, Address: fixtures[1].Address})
 	assert.NoError(t, err)
 
-	req := httptest.NewRequest(http.MethodGet, "/api/v1/clients", nil)
+	req := asHandlerAdmin(httptest.NewRequest(http.MethodGet, "/api/v1/clients", nil))
 	rr := httptest.NewRecorder()
 
 	// Act
@@ -122,21 +120,5 @@ type ClientFixture struct {
 }
 
 func loadHandlerTestFixtures(t *testing.T) []ClientFixture {
-	// Get current file directory
-	_, currentFile, _, ok := runtime.Caller(0)
-	assert.True(t, ok, "Failed to get current file path")
-
-	// Build path to fixture data
-	testDataPath := filepath.Join(filepath.Dir(currentFile), "..", "..", "testdata", "client", "client_fixtures.json")
-
-	// Read fixture data file
-	data, err := os.ReadFile(testDataPath)
-	assert.NoError(t, err, "Failed to read fixture data file")
-
-	// Parse JSON
-	var fixtures []ClientFixture
-	err = json.Unmarshal(data, &fixtures)
-	assert.NoError(t, err, "Failed to parse fixture data JSON")
-
-	return fixtures
+	return testdata.Load[[]ClientFixture](t, "client/client_fixtures.json")
 }
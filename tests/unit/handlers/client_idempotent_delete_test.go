@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI_IdempotentClientDelete(t *testing.T) {
+	newHandler := func(idempotent bool) (*application.BillingService, http.Handler) {
+		storage := infrastructure.NewInMemoryStorage()
+		billingService := application.NewBillingService(repository.NewClientRepository(storage)).
+			WithTombstones(repository.NewClientTombstoneRepository(storage.Collection(repository.ClientTombstoneCollection)))
+		return billingService, httpserver.NewServerWithServices(httpserver.Services{Billing: billingService}, httpserver.ServerOptions{
+			AdminTokens:       map[string]string{"billing": "billing-token"},
+			IdempotentDeletes: idempotent,
+		}).Handler()
+	}
+	deleteClient := func(handler http.Handler, id, mode string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/clients/"+id, nil)
+		req.Header.Set("Authorization", "Bearer billing-token")
+		if mode != "" {
+			req.Header.Set("X-Delete-Mode", mode)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("strict mode by default", func(t *testing.T) {
+		billingService, handler := newHandler(false)
+		client, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusNoContent, deleteClient(handler, client.ID(), "").Code)
+		assert.Equal(t, http.StatusNotFound, deleteClient(handler, client.ID(), "").Code)
+		assert.Equal(t, http.StatusNoContent, deleteClient(handler, client.ID(), "idempotent").Code)
+	})
+
+	t.Run("idempotent mode from the configuration", func(t *testing.T) {
+		billingService, handler := newHandler(true)
+		client, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusNoContent, deleteClient(handler, client.ID(), "").Code)
+		assert.Equal(t, http.StatusNoContent, deleteClient(handler, client.ID(), "").Code)
+		assert.Equal(t, http.StatusNotFound, deleteClient(handler, client.ID(), "strict").Code)
+	})
+
+	t.Run("clients that never existed are not found", func(t *testing.T) {
+		_, handler := newHandler(true)
+
+		assert.Equal(t, http.StatusNotFound, deleteClient(handler, uuid.New().String(), "").Code)
+	})
+
+	t.Run("unknown modes are rejected", func(t *testing.T) {
+		billingService, handler := newHandler(true)
+		client, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusBadRequest, deleteClient(handler, client.ID(), "lenient").Code)
+		_, err = billingService.GetOwnedClient(application.AdminContext("ops"), client.ID())
+		assert.NoError(t, err, "a rejected request must not delete the client")
+	})
+}
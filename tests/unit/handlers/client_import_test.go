@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const clientImportFile = "Company;E-mail Address;Tel;Notes\n" +
+	"Acme Corp;billing@acme.example;+33612345678;key account\n" +
+	"Globex;not-an-email;;\n" +
+	"Initech;ap@initech.example;;\n" +
+	"Acme Again;Billing@Acme.example;;\n"
+
+func TestAPI_ClientImport(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage))
+	handler := httpserver.NewServerWithServices(httpserver.Services{Billing: billingService}, adminOptions()).Handler()
+
+	importForm := func(parts ...[2]string) *http.Request {
+		var body bytes.Buffer
+		form := multipart.NewWriter(&body)
+		for _, part := range parts {
+			if part[0] == "file" {
+				file, err := form.CreateFormFile("file", "clients.csv")
+				require.NoError(t, err)
+				file.Write([]byte(part[1]))
+				continue
+			}
+			require.NoError(t, form.WriteField(part[0], part[1]))
+		}
+		require.NoError(t, form.Close())
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/imports/clients", &body)
+		req.Header.Set("Content-Type", form.FormDataContentType())
+		return asAdmin(req)
+	}
+
+	t.Run("preview detects the columns and suggests a mapping", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, asAdmin(httptest.NewRequest(http.MethodPost, "/api/v1/imports/preview?sample=2", strings.NewReader(clientImportFile))))
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var response struct {
+			Data dtos.ImportPreviewResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, ";", response.Data.Delimiter)
+		assert.Equal(t, []string{"Company", "E-mail Address", "Tel", "Notes"}, response.Data.Columns)
+		assert.Equal(t, [][]string{
+			{"Acme Corp", "billing@acme.example", "+33612345678", "key account"},
+			{"Globex", "not-an-email", "", ""},
+		}, response.Data.SampleRows)
+		assert.Equal(t, map[string]string{"name": "Company", "email": "E-mail Address", "phone": "Tel"}, response.Data.SuggestedMapping)
+		assert.Len(t, response.Data.Fields, len(application.ClientImportFields))
+
+		rr = httptest.NewRecorder()
+		handler.ServeHTTP(rr, asAdmin(httptest.NewRequest(http.MethodPost, "/api/v1/imports/preview", strings.NewReader(""))))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("confirmed mapping drives the import", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, importForm(
+			[2]string{"mapping", `{"name":"Company","email":"E-mail Address","address":"Notes"}`},
+			[2]string{"file", clientImportFile},
+		))
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var response struct {
+			Data dtos.ClientImportResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, 4, response.Data.Rows)
+		assert.Equal(t, 2, response.Data.Imported)
+		require.Len(t, response.Data.Failed, 2)
+		assert.Equal(t, 3, response.Data.Failed[0].Line)
+		assert.Equal(t, "email", response.Data.Failed[0].Error.Field)
+		assert.Equal(t, 5, response.Data.Failed[1].Line)
+		assert.Equal(t, "BUSINESS_RULE_DUPLICATE", response.Data.Failed[1].Error.Code)
+
+		clients, err := billingService.ListClients(application.AdminContext("ops"))
+		require.NoError(t, err)
+		require.Len(t, clients, 2)
+		assert.Equal(t, "Acme Corp", clients[0].Name())
+		assert.Equal(t, "key account", clients[0].Address())
+	})
+
+	t.Run("reject invalid mappings and forms", func(t *testing.T) {
+		testCases := []struct {
+			name string
+			req  *http.Request
+		}{
+			{name: "required field unmapped", req: importForm([2]string{"mapping", `{"name":"Company"}`}, [2]string{"file", clientImportFile})},
+			{name: "unknown column", req: importForm([2]string{"mapping", `{"name":"Company","email":"Mail"}`}, [2]string{"file", clientImportFile})},
+			{name: "unknown field", req: importForm([2]string{"mapping", `{"name":"Company","email":"E-mail Address","vat":"Tel"}`}, [2]string{"file", clientImportFile})},
+			{name: "column mapped twice", req: importForm([2]string{"mapping", `{"name":"Company","email":"E-mail Address","address":"Company"}`}, [2]string{"file", clientImportFile})},
+			{name: "file before mapping", req: importForm([2]string{"file", clientImportFile}, [2]string{"mapping", `{"name":"Company","email":"E-mail Address"}`})},
+			{name: "not a form", req: httptest.NewRequest(http.MethodPost, "/api/v1/imports/clients", strings.NewReader(clientImportFile))},
+		}
+		for _, testCase := range testCases {
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, asAdmin(testCase.req))
+			assert.Equal(t, http.StatusBadRequest, rr.Code, testCase.name)
+		}
+	})
+}
+
+func TestAPI_ClientImportReport(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage))
+	handler := httpserver.NewServerWithServices(httpserver.Services{Billing: billingService}, adminOptions()).Handler()
+
+	t.Run("every row is reported in file order", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, asAdmin(httptest.NewRequest(http.MethodPost, "/api/v1/clients/import", strings.NewReader(clientImportFile))))
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var response struct {
+			Data dtos.ClientImportReportResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, map[string]string{"name": "Company", "email": "E-mail Address", "phone": "Tel"}, response.Data.Mapping)
+		assert.Equal(t, 4, response.Data.Rows)
+		assert.Equal(t, 2, response.Data.Imported)
+		assert.Equal(t, 2, response.Data.Failed)
+		require.Len(t, response.Data.Results, 4)
+
+		acme := response.Data.Results[0]
+		assert.Equal(t, 2, acme.Line)
+		assert.Equal(t, "imported", acme.Status)
+		assert.NotEmpty(t, acme.ClientID)
+		assert.Nil(t, acme.Error)
+
+		assert.Equal(t, 3, response.Data.Results[1].Line)
+		assert.Equal(t, "failed", response.Data.Results[1].Status)
+		assert.Equal(t, "email", response.Data.Results[1].Error.Field)
+
+		assert.Equal(t, "imported", response.Data.Results[2].Status)
+
+		duplicate := response.Data.Results[3]
+		assert.Equal(t, 5, duplicate.Line)
+		assert.Equal(t, "failed", duplicate.Status)
+		assert.Equal(t, "BUSINESS_RULE_DUPLICATE", duplicate.Error.Code)
+		assert.EqualValues(t, 2, duplicate.Error.Details["duplicate_of_line"])
+
+		client, err := billingService.GetOwnedClient(application.AdminContext("ops"), acme.ClientID)
+		require.NoError(t, err)
+		assert.Equal(t, "Acme Corp", client.Name())
+	})
+
+	t.Run("files without the required columns are refused", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, asAdmin(httptest.NewRequest(http.MethodPost, "/api/v1/clients/import", strings.NewReader("Company;Notes\nAcme;\n"))))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		rr = httptest.NewRecorder()
+		handler.ServeHTTP(rr, asAdmin(httptest.NewRequest(http.MethodGet, "/api/v1/clients/import", nil)))
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	})
+}
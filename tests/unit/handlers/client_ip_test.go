@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"path/filepath"
+	"testing"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/middleware"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientIPResolver_Resolve(t *testing.T) {
+	resolver := middleware.NewClientIPResolver([]string{"10.0.0.0/8", "192.0.2.10", "not-a-proxy"})
+
+	testCases := []struct {
+		description string
+		remoteAddr  string
+		headers     map[string][]string
+		expected    string
+	}{
+		{description: "direct connections use the source address", remoteAddr: "198.51.100.7:4000", expected: "198.51.100.7"},
+		{description: "forwarding headers of untrusted callers are ignored", remoteAddr: "198.51.100.7:4000",
+			headers: map[string][]string{"X-Forwarded-For": {"203.0.113.9"}, "X-Real-IP": {"203.0.113.9"}}, expected: "198.51.100.7"},
+		{description: "the client behind a trusted load balancer is used", remoteAddr: "10.0.0.5:4000",
+			headers: map[string][]string{"X-Forwarded-For": {"203.0.113.9"}}, expected: "203.0.113.9"},
+		{description: "trusted proxies are skipped right to left", remoteAddr: "10.0.0.5:4000",
+			headers: map[string][]string{"X-Forwarded-For": {"203.0.113.9, 198.51.100.1, 10.1.2.3"}}, expected: "198.51.100.1"},
+		{description: "a spoofed leftmost entry is not trusted", remoteAddr: "10.0.0.5:4000",
+			headers: map[string][]string{"X-Forwarded-For": {"127.0.0.1, 203.0.113.9"}}, expected: "203.0.113.9"},
+		{description: "repeated headers are read as one list", remoteAddr: "192.0.2.10:4000",
+			headers: map[string][]string{"X-Forwarded-For": {"203.0.113.9", "10.1.2.3"}}, expected: "203.0.113.9"},
+		{description: "hops may carry a port", remoteAddr: "10.0.0.5:4000",
+			headers: map[string][]string{"X-Forwarded-For": {"[2001:db8::1]:443"}}, expected: "2001:db8::1"},
+		{description: "a malformed hop stops the walk", remoteAddr: "10.0.0.5:4000",
+			headers: map[string][]string{"X-Forwarded-For": {"203.0.113.9, garbage, 10.1.2.3"}}, expected: "10.1.2.3"},
+		{description: "X-Real-IP is used without X-Forwarded-For", remoteAddr: "10.0.0.5:4000",
+			headers: map[string][]string{"X-Real-IP": {"203.0.113.9"}}, expected: "203.0.113.9"},
+		{description: "a malformed X-Real-IP falls back to the source address", remoteAddr: "10.0.0.5:4000",
+			headers: map[string][]string{"X-Real-IP": {"unknown"}}, expected: "10.0.0.5"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/health", nil)
+			req.RemoteAddr = tc.remoteAddr
+			for name, values := range tc.headers {
+				for _, value := range values {
+					req.Header.Add(name, value)
+				}
+			}
+
+			addr, ok := resolver.Resolve(req)
+			require.True(t, ok)
+			assert.Equal(t, netip.MustParseAddr(tc.expected), addr)
+		})
+	}
+}
+
+func TestAPI_IPAccessPolicyBehindLoadBalancer(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	auditService := application.NewAuditService(repository.NewAuditRepository(storage.Collection(repository.AuditCollection)))
+	policyService := application.NewAccessPolicyService(
+		repository.NewIPAccessPolicyRepository(storage.Collection(repository.IPAccessPolicyCollection)),
+		auditService,
+	)
+	handler := httpserver.NewServerWithServices(httpserver.Services{
+		Billing:        application.NewBillingService(repository.NewClientRepository(storage)),
+		AccessPolicies: policyService,
+		Audit:          auditService,
+	}, httpserver.ServerOptions{
+		AdminTokens:    map[string]string{"ops": "admin-token"},
+		TrustedProxies: []string{"10.0.0.0/24"},
+	}).Handler()
+
+	// Every request arrives through the load balancer at 10.0.0.2
+	serve := func(method, path, body, forwardedFor string) *httptest.ResponseRecorder {
+		req := newAdminRequest(method, path, body, "10.0.0.2:4000")
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := serve(http.MethodPut, "/api/v1/admin/ip-access-policies/acme",
+		`{"rules":[{"route_prefix":"/api/v1/admin","allow":["203.0.113.0/24"]}]}`, "203.0.113.7")
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	rr = serve(http.MethodGet, "/api/v1/admin/ip-access-policies/acme", "", "203.0.113.7")
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	rr = serve(http.MethodGet, "/api/v1/admin/ip-access-policies/acme", "", "198.51.100.1")
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+
+	// A client cannot smuggle an allowed address past the load balancer
+	rr = serve(http.MethodGet, "/api/v1/admin/ip-access-policies/acme", "", "203.0.113.7, 198.51.100.1")
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+}
+
+func TestAPI_IPAccessPolicyOverUnixSocket(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	auditService := application.NewAuditService(repository.NewAuditRepository(storage.Collection(repository.AuditCollection)))
+	policyService := application.NewAccessPolicyService(
+		repository.NewIPAccessPolicyRepository(storage.Collection(repository.IPAccessPolicyCollection)),
+		auditService,
+	)
+	_, err := policyService.SetPolicy("ops", "acme", dtos.SetIPAccessPolicyRequest{
+		Rules: []dtos.IPAccessRuleRequest{{RoutePrefix: "/api/v1", Allow: []string{"203.0.113.0/24"}}},
+	})
+	require.NoError(t, err)
+
+	// The API is served on a Unix socket behind a local proxy, without any trusted proxy address configured
+	path := filepath.Join(t.TempDir(), "api.sock")
+	socket, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	server := &http.Server{Handler: httpserver.NewServerWithServices(httpserver.Services{
+		Billing:        application.NewBillingService(repository.NewClientRepository(storage)),
+		AccessPolicies: policyService,
+		Audit:          auditService,
+	}, httpserver.ServerOptions{
+		AdminTokens: map[string]string{"ops": "admin-token"},
+	}).Handler()}
+	go server.Serve(socket)
+	t.Cleanup(func() { server.Close() })
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, "unix", path)
+		},
+	}}
+	get := func(tenantID, forwardedFor string) int {
+		t.Helper()
+		req, err := http.NewRequest(http.MethodGet, "http://billing/api/v1/clients", nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer admin-token")
+		req.Header.Set(middleware.TenantHeader, tenantID)
+		if forwardedFor != "" {
+			req.Header.Set("X-Forwarded-For", forwardedFor)
+		}
+		response, err := client.Do(req)
+		require.NoError(t, err)
+		response.Body.Close()
+		return response.StatusCode
+	}
+
+	t.Run("tenants without a policy do not need the source address", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, get("globex", ""))
+	})
+
+	t.Run("the client forwarded by the local proxy is checked against the policy", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, get("acme", "203.0.113.7"))
+		assert.Equal(t, http.StatusForbidden, get("acme", "198.51.100.1"))
+	})
+
+	t.Run("tenants with a policy refuse unknown clients", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, get("acme", ""))
+	})
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_ClientLightweightEndpoints(t *testing.T) {
+	// Arrange
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage))
+	handler := httpserver.NewServerWithServices(httpserver.Services{Billing: billingService}, adminOptions()).Handler()
+
+	existing, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Alice Martin", Email: "alice@acme.com"})
+	require.NoError(t, err)
+	_, err = billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Bob Stone", Email: "bob@globex.com"})
+	require.NoError(t, err)
+
+	t.Run("HEAD returns 200 without body for an existing client", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, asAdmin(httptest.NewRequest(http.MethodHead, "/api/v1/clients/"+existing.ID(), nil)))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, rr.Body.String())
+	})
+
+	t.Run("HEAD returns 404 for an unknown client", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, asAdmin(httptest.NewRequest(http.MethodHead, "/api/v1/clients/6f1c7f3e-8a52-4c7b-9d7e-1b2a3c4d5e6f", nil)))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Empty(t, rr.Body.String())
+	})
+
+	testCases := []struct {
+		description string
+		query       string
+		expected    int
+	}{
+		{description: "count without filter", query: "", expected: 2},
+		{description: "filter matches email domain", query: "?filter=ACME", expected: 1},
+		{description: "filter matches name", query: "?filter=stone", expected: 1},
+		{description: "filter without match", query: "?filter=initech", expected: 0},
+		{description: "exact count with filter", query: "?filter=acme&count=exact", expected: 1},
+		{description: "estimated count falls back to exact in memory", query: "?count=estimated", expected: 2},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, asAdmin(httptest.NewRequest(http.MethodGet, "/api/v1/clients/count"+testCase.query, nil)))
+			require.Equal(t, http.StatusOK, rr.Code)
+
+			var response struct {
+				Data struct {
+					Count int `json:"count"`
+				} `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+			assert.Equal(t, testCase.expected, response.Data.Count)
+		})
+	}
+
+	for _, query := range []string{"?count=approximate", "?filter=acme&count=estimated"} {
+		t.Run("rejects count mode "+query, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, asAdmin(httptest.NewRequest(http.MethodGet, "/api/v1/clients/count"+query, nil)))
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+		})
+	}
+}
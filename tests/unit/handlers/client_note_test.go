@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI_ClientNotes(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	noteRepo := repository.NewClientNoteRepository(storage.Collection(repository.ClientNoteCollection))
+	billingService := application.NewBillingService(repository.NewClientRepository(storage)).WithNotes(noteRepo)
+	handler := httpserver.NewServerWithServices(httpserver.Services{Billing: billingService}, httpserver.ServerOptions{
+		AdminTokens: map[string]string{"alice": "admin-token"},
+	}).Handler()
+
+	serve := func(method, path, token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+	type notePage struct {
+		Data       []dtos.ClientNoteResponse `json:"data"`
+		Pagination dtos.PaginationResponse   `json:"pagination"`
+	}
+	listNotes := func(t *testing.T, path string) notePage {
+		t.Helper()
+		rr := serve(http.MethodGet, path, "admin-token", "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var page notePage
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &page))
+		return page
+	}
+
+	acme, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+	require.NoError(t, err)
+	notesPath := "/api/v1/clients/" + acme.ID() + "/notes"
+
+	t.Run("notes are attributed to the identified admin", func(t *testing.T) {
+		rr := serve(http.MethodPost, notesPath, "admin-token", `{"body":"Called about the overdue March invoice"}`)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		var created struct {
+			Data dtos.ClientNoteResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &created))
+		assert.Equal(t, "alice", created.Data.Author)
+		assert.Equal(t, acme.ID(), created.Data.ClientID)
+
+		// Anonymous callers reach no client
+		rr = serve(http.MethodPost, notesPath, "", `{"body":"Left a voicemail"}`)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code, rr.Body.String())
+	})
+
+	t.Run("the timeline is paginated, newest first", func(t *testing.T) {
+		for i := 1; i <= 3; i++ {
+			rr := serve(http.MethodPost, notesPath, "admin-token", fmt.Sprintf(`{"body":"Follow-up %d"}`, i))
+			require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		}
+
+		page := listNotes(t, notesPath+"?page=1&limit=2")
+		require.Len(t, page.Data, 2)
+		assert.Equal(t, "Follow-up 3", page.Data[0].Body)
+		assert.Equal(t, 4, page.Pagination.TotalCount)
+		assert.Equal(t, 2, page.Pagination.TotalPages)
+
+		page = listNotes(t, notesPath+"?page=2&limit=2")
+		require.Len(t, page.Data, 2)
+		assert.Equal(t, "Called about the overdue March invoice", page.Data[1].Body)
+
+		assert.Empty(t, listNotes(t, notesPath+"?page=3&limit=2").Data)
+	})
+
+	t.Run("invalid notes and unknown clients are rejected", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, serve(http.MethodPost, notesPath, "admin-token", `{"body":"  "}`).Code)
+		assert.Equal(t, http.StatusBadRequest, serve(http.MethodPost, notesPath, "admin-token", `{"body":"`+strings.Repeat("a", 5001)+`"}`).Code)
+		assert.Equal(t, http.StatusBadRequest, serve(http.MethodGet, notesPath+"?limit=500", "admin-token", "").Code)
+		assert.Equal(t, http.StatusNotFound, serve(http.MethodGet, "/api/v1/clients/8d9f5a8e-8a3b-4c4e-9e61-2f1f6f1f0a01/notes", "admin-token", "").Code)
+		assert.Equal(t, http.StatusMethodNotAllowed, serve(http.MethodDelete, notesPath, "admin-token", "").Code)
+	})
+
+	t.Run("notes are deleted along with their client", func(t *testing.T) {
+		require.NoError(t, billingService.DeleteClient(application.AdminContext("billing-admin"), acme.ID()))
+
+		notes, err := noteRepo.ListByClient(acme.ID())
+		require.NoError(t, err)
+		assert.Empty(t, notes)
+	})
+}
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI_ClientOwnership(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage))
+	handler := httpserver.NewServerWithServices(httpserver.Services{Billing: billingService}, httpserver.ServerOptions{
+		AdminTokens:  map[string]string{"billing": "billing-token", "globex-admin": "globex-token", "initech-admin": "initech-token"},
+		AdminTenants: map[string][]string{"globex-admin": {"globex"}, "initech-admin": {"initech"}},
+	}).Handler()
+
+	serve := func(method, path, token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+	countClients := func(t *testing.T, token string) int {
+		t.Helper()
+		rr := serve(http.MethodGet, "/api/v1/clients/count", token, "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var response struct {
+			Data struct {
+				Count int `json:"count"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		return response.Data.Count
+	}
+
+	initech, err := billingService.CreateClient(application.SystemContext("test", "initech"), dtos.CreateClientRequest{Name: "Initech", Email: "ap@initech.example"})
+	require.NoError(t, err)
+	_, err = billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+	require.NoError(t, err)
+	path := "/api/v1/clients/" + initech.ID()
+
+	t.Run("only the admins of the tenant read its clients", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, serve(http.MethodGet, path, "initech-token", "").Code)
+		assert.Equal(t, http.StatusNotFound, serve(http.MethodGet, path, "globex-token", "").Code)
+		assert.Equal(t, http.StatusUnauthorized, serve(http.MethodGet, path, "", "").Code)
+		assert.Equal(t, http.StatusNotFound, serve(http.MethodHead, path, "globex-token", "").Code)
+	})
+
+	t.Run("other callers cannot rename the client", func(t *testing.T) {
+		rr := serve(http.MethodPut, path, "globex-token", `{"name":"Initrode"}`)
+		assert.Equal(t, http.StatusNotFound, rr.Code, rr.Body.String())
+		rr = serve(http.MethodPut, path, "", `{"name":"Initrode"}`)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code, rr.Body.String())
+
+		rr = serve(http.MethodGet, path, "initech-token", "")
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"name":"Initech"`)
+	})
+
+	t.Run("lists and counts only hold the clients of the caller", func(t *testing.T) {
+		assert.Equal(t, 1, countClients(t, "initech-token"))
+		assert.Equal(t, 0, countClients(t, "globex-token"))
+		assert.Equal(t, http.StatusUnauthorized, serve(http.MethodGet, "/api/v1/clients/count", "", "").Code)
+		assert.Equal(t, 1, countClients(t, "billing-token"))
+
+		rr := serve(http.MethodGet, "/api/v1/clients", "globex-token", "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.NotContains(t, rr.Body.String(), initech.ID())
+	})
+
+	t.Run("batch get reports the clients of other tenants as not found", func(t *testing.T) {
+		rr := serve(http.MethodPost, "/api/v1/clients/batch-get", "globex-token", `{"ids":["`+initech.ID()+`"]}`)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var response struct {
+			Data dtos.BatchGetClientsResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Empty(t, response.Data.Clients)
+		assert.Equal(t, []string{initech.ID()}, response.Data.NotFound)
+	})
+}
@@ -128,17 +128,12 @@ func TestClientHandler_ListClients_WithPagination(t *testing.T) {
 
 			// Create test clients
 			for i := 0; i < tt.setupClients; i++ {
-				_, err := billingService.CreateClient(
-					fmt.Sprintf("Client %02d", i),
-					fmt.Sprintf("client%d@test.com", i),
-					"+1234567890",
-					fmt.Sprintf("Address %d", i),
-				)
+				_, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: fmt.Sprintf("Client %02d", i), Email: fmt.Sprintf("client%d@test.com", i), Phone: "+1234567890", Address: fmt.Sprintf("Address %d", i)})
 				require.NoError(t, err)
 			}
 
 			// Create request
-			req := httptest.NewRequest("GET", "/api/v1/clients"+tt.queryParams, nil)
+			req := asHandlerAdmin(httptest.NewRequest("GET", "/api/v1/clients"+tt.queryParams, nil))
 			rec := httptest.NewRecorder()
 
 			// Execute
@@ -244,17 +239,12 @@ func TestBillingService_ListClientsWithPagination(t *testing.T) {
 
 			// Create test clients
 			for i := 0; i < tt.totalClients; i++ {
-				_, err := service.CreateClient(
-					fmt.Sprintf("Client %03d", i),
-					fmt.Sprintf("client%d@test.com", i),
-					"+1234567890",
-					fmt.Sprintf("Address %d", i),
-				)
+				_, err := service.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: fmt.Sprintf("Client %03d", i), Email: fmt.Sprintf("client%d@test.com", i), Phone: "+1234567890", Address: fmt.Sprintf("Address %d", i)})
 				require.NoError(t, err)
 			}
 
 			// Execute
-			result, err := service.ListClientsWithPagination(tt.page, tt.limit)
+			result, err := service.ListClientsWithPagination(application.AdminContext("ops"), tt.page, tt.limit, "")
 
 			// Assert
 			assert.NoError(t, err)
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI_ClientPhoneSearch(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage))
+	handler := httpserver.NewServerWithServices(httpserver.Services{Billing: billingService}, adminOptions()).Handler()
+
+	search := func(query url.Values, acceptLanguage string) *httptest.ResponseRecorder {
+		req := asAdmin(httptest.NewRequest(http.MethodGet, "/api/v1/clients?"+query.Encode(), nil))
+		if acceptLanguage != "" {
+			req.Header.Set("Accept-Language", acceptLanguage)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+	searchIDs := func(t *testing.T, query url.Values, acceptLanguage string) []string {
+		t.Helper()
+		rr := search(query, acceptLanguage)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var response struct {
+			Data []dtos.ClientResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		ids := make([]string, len(response.Data))
+		for i, client := range response.Data {
+			ids[i] = client.ID
+		}
+		return ids
+	}
+
+	acme, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Acme SARL", Email: "compta@acme.example", Phone: "06 12 34 56 78", Country: "FR"})
+	require.NoError(t, err)
+	globex, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Globex", Email: "ap@globex.example", Phone: "+1 (555) 123-4567"})
+	require.NoError(t, err)
+	_, err = billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Initech", Email: "ap@initech.example"})
+	require.NoError(t, err)
+
+	t.Run("numbers match regardless of formatting", func(t *testing.T) {
+		for _, phone := range []string{"+33612345678", "+33 6 12 34 56 78", "+33 6-12-34-56-78"} {
+			assert.Equal(t, []string{acme.ID()}, searchIDs(t, url.Values{"phone": {phone}}, ""), phone)
+		}
+		assert.Equal(t, []string{globex.ID()}, searchIDs(t, url.Values{"phone": {"+1.555.123.4567"}}, ""))
+	})
+
+	t.Run("national numbers are read in the request region", func(t *testing.T) {
+		assert.Equal(t, []string{acme.ID()}, searchIDs(t, url.Values{"phone": {"06-12-34-56-78"}}, "fr-FR"))
+		assert.Equal(t, []string{globex.ID()}, searchIDs(t, url.Values{"phone": {"(555) 123-4567"}}, "en-US"))
+		assert.Empty(t, searchIDs(t, url.Values{"phone": {"06 12 34 56 78"}}, "en-GB"))
+	})
+
+	t.Run("the phone filter combines with the status filter", func(t *testing.T) {
+		_, err := billingService.ChangeClientStatus(application.AdminContext("ops"), acme.ID(), "suspended")
+		require.NoError(t, err)
+		assert.Equal(t, []string{acme.ID()}, searchIDs(t, url.Values{"phone": {"+33612345678"}, "status": {"suspended"}}, ""))
+		assert.Empty(t, searchIDs(t, url.Values{"phone": {"+33612345678"}, "status": {"active"}}, ""))
+	})
+
+	t.Run("invalid numbers are rejected", func(t *testing.T) {
+		for _, phone := range []string{"", "12", "06 12 AB 56 78"} {
+			rr := search(url.Values{"phone": {phone}}, "fr-FR")
+			assert.Equal(t, http.StatusBadRequest, rr.Code, phone)
+		}
+	})
+}
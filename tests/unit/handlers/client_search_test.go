@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI_ClientSearch(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	clientRepo := repository.NewClientRepository(storage)
+	billingService := application.NewBillingService(clientRepo)
+	handler := httpserver.NewServerWithServices(httpserver.Services{Billing: billingService}, adminOptions()).Handler()
+
+	list := func(query string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, asAdmin(httptest.NewRequest(http.MethodGet, "/api/v1/clients"+query, nil)))
+		return rr
+	}
+	listIDs := func(t *testing.T, query string) []string {
+		t.Helper()
+		rr := list(query)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var response struct {
+			Data       []dtos.ClientResponse   `json:"data"`
+			Pagination dtos.PaginationResponse `json:"pagination"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, len(response.Data), response.Pagination.TotalCount)
+		ids := make([]string, len(response.Data))
+		for i, client := range response.Data {
+			ids[i] = client.ID
+		}
+		return ids
+	}
+	save := func(name, email string, createdAt time.Time) *entity.Client {
+		client, err := entity.NewClientWithID(uuid.NewString(), name, email, "", "", createdAt, createdAt)
+		require.NoError(t, err)
+		require.NoError(t, clientRepo.Save(client))
+		return client
+	}
+
+	acme := save("Acme Corp", "billing@acme.example", time.Date(2026, time.January, 10, 9, 0, 0, 0, time.UTC))
+	acmeLabs := save("Acme Labs", "ap@labs.example", time.Date(2026, time.March, 5, 9, 0, 0, 0, time.UTC))
+	globex := save("Globex", "accounts@globex-acme.example", time.Date(2026, time.June, 1, 9, 0, 0, 0, time.UTC))
+
+	t.Run("full text search across name and email", func(t *testing.T) {
+		assert.Equal(t, []string{acme.ID(), acmeLabs.ID(), globex.ID()}, listIDs(t, "?q=ACME"))
+		assert.Equal(t, []string{acmeLabs.ID()}, listIDs(t, "?q=labs"))
+		assert.Empty(t, listIDs(t, "?q=initech"))
+	})
+
+	t.Run("exact email", func(t *testing.T) {
+		assert.Equal(t, []string{acme.ID()}, listIDs(t, "?email=Billing@Acme.example"))
+		assert.Empty(t, listIDs(t, "?email=billing@acme.test"))
+	})
+
+	t.Run("creation window", func(t *testing.T) {
+		assert.Equal(t, []string{acmeLabs.ID(), globex.ID()}, listIDs(t, "?created_after=2026-02-01T00:00:00Z"))
+		assert.Equal(t, []string{acme.ID(), acmeLabs.ID()}, listIDs(t, "?created_before=2026-06-01T09:00:00Z"))
+		assert.Equal(t, []string{acmeLabs.ID()}, listIDs(t, "?created_after=2026-02-01T00:00:00Z&created_before=2026-04-01T00:00:00%2B02:00"))
+	})
+
+	t.Run("filters combine", func(t *testing.T) {
+		assert.Equal(t, []string{globex.ID()}, listIDs(t, "?q=acme&created_after=2026-04-01T00:00:00Z"))
+		assert.Empty(t, listIDs(t, "?q=labs&email=billing@acme.example"))
+	})
+
+	t.Run("pagination applies to the matches", func(t *testing.T) {
+		rr := list("?q=acme&limit=2&page=2")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var response struct {
+			Data       []dtos.ClientResponse   `json:"data"`
+			Pagination dtos.PaginationResponse `json:"pagination"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		require.Len(t, response.Data, 1)
+		assert.Equal(t, globex.ID(), response.Data[0].ID)
+		assert.Equal(t, 3, response.Pagination.TotalCount)
+		assert.Equal(t, 2, response.Pagination.TotalPages)
+	})
+
+	t.Run("invalid parameters are rejected", func(t *testing.T) {
+		for _, query := range []string{
+			"?q=",
+			"?email=",
+			"?email=not-an-email",
+			"?created_after=2026-01-01",
+			"?created_before=yesterday",
+			"?created_after=2026-06-01T00:00:00Z&created_before=2026-01-01T00:00:00Z",
+		} {
+			rr := list(query)
+			assert.Equal(t, http.StatusBadRequest, rr.Code, query)
+		}
+	})
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI_ClientSort(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	clientRepo := repository.NewClientRepository(storage)
+	billingService := application.NewBillingService(clientRepo)
+	handler := httpserver.NewServerWithServices(httpserver.Services{Billing: billingService}, adminOptions()).Handler()
+
+	list := func(query string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, asAdmin(httptest.NewRequest(http.MethodGet, "/api/v1/clients"+query, nil)))
+		return rr
+	}
+	listIDs := func(t *testing.T, query string) []string {
+		t.Helper()
+		rr := list(query)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var response struct {
+			Data []dtos.ClientResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		ids := make([]string, len(response.Data))
+		for i, client := range response.Data {
+			ids[i] = client.ID
+		}
+		return ids
+	}
+	save := func(name, email string, createdAt time.Time) *entity.Client {
+		client, err := entity.NewClientWithID(uuid.NewString(), name, email, "", "", createdAt, createdAt)
+		require.NoError(t, err)
+		require.NoError(t, clientRepo.Save(client))
+		return client
+	}
+
+	initech := save("initech", "ap@initech.example", time.Date(2026, time.January, 10, 9, 0, 0, 0, time.UTC))
+	acme := save("Acme Corp", "billing@acme.example", time.Date(2026, time.March, 5, 9, 0, 0, 0, time.UTC))
+	globex := save("Globex", "accounts@globex.example", time.Date(2026, time.June, 1, 9, 0, 0, 0, time.UTC))
+	acmeTwin := save("Acme Corp", "ar@acme.example", time.Date(2026, time.July, 1, 9, 0, 0, 0, time.UTC))
+
+	t.Run("default to creation order", func(t *testing.T) {
+		assert.Equal(t, []string{initech.ID(), acme.ID(), globex.ID(), acmeTwin.ID()}, listIDs(t, ""))
+	})
+
+	t.Run("sort by name case-insensitively, ties in creation order", func(t *testing.T) {
+		assert.Equal(t, []string{acme.ID(), acmeTwin.ID(), globex.ID(), initech.ID()}, listIDs(t, "?sort=name"))
+	})
+
+	t.Run("descending and secondary keys", func(t *testing.T) {
+		assert.Equal(t, []string{acmeTwin.ID(), globex.ID(), acme.ID(), initech.ID()}, listIDs(t, "?sort=-created_at"))
+		assert.Equal(t, []string{acmeTwin.ID(), acme.ID(), globex.ID(), initech.ID()}, listIDs(t, "?sort=name,-created_at"))
+		assert.Equal(t, []string{globex.ID(), initech.ID(), acmeTwin.ID(), acme.ID()}, listIDs(t, "?sort=email"))
+	})
+
+	t.Run("pages stay stable", func(t *testing.T) {
+		var pages []string
+		for _, page := range []string{"1", "2"} {
+			pages = append(pages, listIDs(t, "?sort=name&limit=2&page="+page)...)
+		}
+		assert.Equal(t, listIDs(t, "?sort=name"), pages)
+	})
+
+	t.Run("combine with filters", func(t *testing.T) {
+		assert.Equal(t, []string{acme.ID(), acmeTwin.ID()}, listIDs(t, "?q=acme&sort=-email"))
+	})
+
+	t.Run("reject unsupported orderings", func(t *testing.T) {
+		for _, query := range []string{"?sort=", "?sort=phone", "?sort=name,-name", "?sort=name,", "?sort=%2Bname"} {
+			rr := list(query)
+			assert.Equal(t, http.StatusBadRequest, rr.Code, query)
+		}
+	})
+}
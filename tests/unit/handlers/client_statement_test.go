@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/messaging"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// staticClientActivity lists a fixed set of account entries for every client
+type staticClientActivity []service.StatementEntry
+
+func (s staticClientActivity) ClientActivity(clientID string) ([]service.StatementEntry, error) {
+	return s, nil
+}
+
+func TestAPI_ClientStatements(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	publisher := messaging.NewMemoryPublisher()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage))
+	auditService := application.NewAuditService(repository.NewAuditRepository(storage.Collection(repository.AuditCollection)))
+	documents := application.NewDocumentTemplateService(
+		repository.NewDocumentTemplateRepository(storage.Collection(repository.DocumentTemplateCollection)),
+		auditService,
+	)
+
+	client, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+	require.NoError(t, err)
+
+	money := func(amount int64, currency string) valueobject.Money {
+		m, err := valueobject.NewMoney(amount, currency)
+		require.NoError(t, err)
+		return m
+	}
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2026, month, d, 0, 0, 0, 0, time.UTC)
+	}
+	statements := application.NewClientStatementService(
+		repository.NewClientStatementJobRepository(storage.Collection(repository.ClientStatementJobCollection)),
+		billingService,
+		documents,
+		publisher,
+	).WithActivity(staticClientActivity{
+		{Date: day(1, 15), Kind: service.StatementInvoice, Reference: "INV-0001", Amount: money(50000, "EUR")},
+		{Date: day(2, 3), Kind: service.StatementInvoice, Reference: "INV-0002", Description: "February retainer", Amount: money(40000, "EUR")},
+		{Date: day(2, 20), Kind: service.StatementPayment, Reference: "PAY-0001", Amount: money(30000, "EUR")},
+	})
+
+	handler := httpserver.NewServerWithServices(httpserver.Services{
+		Billing:    billingService,
+		Audit:      auditService,
+		Documents:  documents,
+		Statements: statements,
+	}, httpserver.ServerOptions{
+		AdminTokens: map[string]string{"ops": "admin-token"},
+	}).Handler()
+
+	statementsPath := "/api/v1/clients/" + client.ID() + "/statements"
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		req := newAdminRequest(method, path, body, "192.0.2.10:1234")
+		req.Header.Set("Accept-Language", "fr-FR")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+	type jobResponse struct {
+		Data struct {
+			ID             string `json:"id"`
+			Status         string `json:"status"`
+			Recipient      string `json:"recipient"`
+			ClosingBalance *int64 `json:"closing_balance"`
+			Failure        string `json:"failure"`
+			DocumentURL    string `json:"document_url"`
+		} `json:"data"`
+	}
+	request := func(body string) jobResponse {
+		t.Helper()
+		rr := serve(http.MethodPost, statementsPath, body)
+		require.Equal(t, http.StatusAccepted, rr.Code, rr.Body.String())
+		var response jobResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		return response
+	}
+	runScheduler := func() {
+		t.Helper()
+		rr := serve(http.MethodPost, "/api/v1/admin/statements/run", "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	}
+
+	t.Run("generates and emails the statement on the next run", func(t *testing.T) {
+		job := request(`{"from":"2026-02-01T00:00:00Z","to":"2026-02-28T00:00:00Z","email":true}`)
+		assert.Equal(t, "pending", job.Data.Status)
+		assert.Equal(t, "billing@acme.example", job.Data.Recipient)
+
+		rr := serve(http.MethodGet, statementsPath+"/"+job.Data.ID+"/document", "")
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+
+		runScheduler()
+
+		rr = serve(http.MethodGet, statementsPath+"/"+job.Data.ID, "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var completed jobResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &completed))
+		assert.Equal(t, "completed", completed.Data.Status)
+		require.NotNil(t, completed.Data.ClosingBalance)
+		assert.Equal(t, int64(60000), *completed.Data.ClosingBalance)
+		assert.Equal(t, statementsPath+"/"+job.Data.ID+"/document", completed.Data.DocumentURL)
+
+		rr = serve(http.MethodGet, completed.Data.DocumentURL, "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Equal(t, "text/html; charset=utf-8", rr.Header().Get("Content-Type"))
+		assert.Contains(t, rr.Body.String(), "Relevé de compte")
+		assert.Contains(t, rr.Body.String(), "February retainer")
+		assert.NotContains(t, rr.Body.String(), "INV-0001") // Before the range, in the opening balance
+
+		messages := publisher.Messages()
+		require.Len(t, messages, 1)
+		assert.Equal(t, application.ClientStatementTopic, messages[0].Topic)
+		var event application.ClientStatementEvent
+		require.NoError(t, json.Unmarshal(messages[0].Payload, &event))
+		assert.Equal(t, job.Data.ID, event.StatementID)
+		assert.Equal(t, "billing@acme.example", event.Recipient)
+		assert.Equal(t, int64(60000), event.ClosingBalance)
+		assert.Contains(t, event.Document, "February retainer")
+	})
+
+	t.Run("statements not asked to be emailed are not sent", func(t *testing.T) {
+		job := request(`{"from":"2026-01-01T00:00:00Z","to":"2026-01-31T00:00:00Z"}`)
+		assert.Empty(t, job.Data.Recipient)
+
+		runScheduler()
+
+		rr := serve(http.MethodGet, statementsPath+"/"+job.Data.ID, "")
+		assert.Contains(t, rr.Body.String(), `"status":"completed"`)
+		assert.Len(t, publisher.Messages(), 1)
+	})
+
+	t.Run("statements in a currency without activity are empty", func(t *testing.T) {
+		job := request(`{"from":"2026-01-01T00:00:00Z","to":"2026-01-31T00:00:00Z","currency":"USD"}`)
+		runScheduler()
+
+		rr := serve(http.MethodGet, statementsPath+"/"+job.Data.ID, "")
+		assert.Contains(t, rr.Body.String(), `"status":"completed"`)
+		assert.Contains(t, rr.Body.String(), `"closing_balance":0`)
+	})
+
+	t.Run("statements of clients deleted before the run are marked failed", func(t *testing.T) {
+		globex, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Globex", Email: "ap@globex.example"})
+		require.NoError(t, err)
+		globexPath := "/api/v1/clients/" + globex.ID() + "/statements"
+		rr := serve(http.MethodPost, globexPath, `{"from":"2026-01-01T00:00:00Z","to":"2026-01-31T00:00:00Z"}`)
+		require.Equal(t, http.StatusAccepted, rr.Code, rr.Body.String())
+		var job jobResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &job))
+		require.NoError(t, billingService.DeleteClient(application.AdminContext("billing-admin"), globex.ID()))
+
+		runScheduler()
+
+		rr = serve(http.MethodGet, globexPath+"/"+job.Data.ID, "")
+		assert.Contains(t, rr.Body.String(), `"status":"failed"`)
+		assert.Contains(t, rr.Body.String(), `"failure":`)
+		assert.NotContains(t, rr.Body.String(), `"document_url"`)
+	})
+
+	t.Run("rejects invalid requests", func(t *testing.T) {
+		rr := serve(http.MethodPost, statementsPath, `{"from":"2026-03-01T00:00:00Z","to":"2026-02-01T00:00:00Z"}`)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		rr = serve(http.MethodPost, statementsPath, `{"from":"2026-02-01T00:00:00Z","to":"2026-02-28T00:00:00Z","recipient":"not-an-email"}`)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		rr = serve(http.MethodPost, "/api/v1/clients/00000000-0000-0000-0000-000000000000/statements", `{"from":"2026-02-01T00:00:00Z","to":"2026-02-28T00:00:00Z"}`)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("jobs of another client are not found", func(t *testing.T) {
+		job := request(`{"from":"2026-02-01T00:00:00Z","to":"2026-02-28T00:00:00Z"}`)
+
+		rr := serve(http.MethodGet, "/api/v1/clients/00000000-0000-0000-0000-000000000000/statements/"+job.Data.ID, "")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("only POST requests statements", func(t *testing.T) {
+		rr := serve(http.MethodGet, statementsPath, "")
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	})
+}
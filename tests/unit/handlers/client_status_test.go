@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI_ClientStatus(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage)).
+		WithInvoices(repository.NewInvoiceRepository(storage.Collection(repository.InvoiceCollection)))
+	handler := httpserver.NewServerWithServices(httpserver.Services{Billing: billingService}, httpserver.ServerOptions{
+		AdminTokens: map[string]string{"billing": "billing-token"},
+	}).Handler()
+
+	// Clients are created without a tenant, so only admins act on them
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer billing-token")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+	changeStatus := func(clientID, status string) *httptest.ResponseRecorder {
+		return serve(http.MethodPatch, "/api/v1/clients/"+clientID+"/status", `{"status":"`+status+`"}`)
+	}
+	createInvoice := func(clientID string) *httptest.ResponseRecorder {
+		return serve(http.MethodPost, "/api/v1/invoices", `{"client_id":"`+clientID+`","currency":"EUR","line_items":[{"description":"Consulting","quantity":1,"unit_amount":10000}]}`)
+	}
+	listIDs := func(query string) []string {
+		rr := serve(http.MethodGet, "/api/v1/clients"+query, "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var response struct {
+			Data       []dtos.ClientResponse   `json:"data"`
+			Pagination dtos.PaginationResponse `json:"pagination"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, len(response.Data), response.Pagination.TotalCount)
+		ids := make([]string, len(response.Data))
+		for i, client := range response.Data {
+			ids[i] = client.ID
+		}
+		return ids
+	}
+
+	acme, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+	require.NoError(t, err)
+	globex, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Globex", Email: "ap@globex.example"})
+	require.NoError(t, err)
+
+	t.Run("suspended clients cannot be invoiced", func(t *testing.T) {
+		rr := changeStatus(acme.ID(), "suspended")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), `"status":"suspended"`)
+
+		rr = createInvoice(acme.ID())
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code, rr.Body.String())
+		rr = createInvoice(globex.ID())
+		assert.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	})
+
+	t.Run("the list filters on the status", func(t *testing.T) {
+		assert.Equal(t, []string{acme.ID()}, listIDs("?status=suspended"))
+		assert.Equal(t, []string{globex.ID()}, listIDs("?status=active"))
+		assert.Empty(t, listIDs("?status=closed"))
+		assert.Len(t, listIDs(""), 2)
+
+		rr := serve(http.MethodGet, "/api/v1/clients?status=archived", "")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("reactivated clients can be invoiced again", func(t *testing.T) {
+		rr := changeStatus(acme.ID(), "active")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		rr = createInvoice(acme.ID())
+		assert.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	})
+
+	t.Run("closing is final", func(t *testing.T) {
+		rr := changeStatus(globex.ID(), "closed")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		rr = changeStatus(globex.ID(), "active")
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code, rr.Body.String())
+		rr = createInvoice(globex.ID())
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code, rr.Body.String())
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		rr := changeStatus(acme.ID(), "archived")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		rr = changeStatus("00000000-0000-0000-0000-000000000000", "suspended")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		rr = serve(http.MethodPut, "/api/v1/clients/"+acme.ID()+"/status", `{"status":"suspended"}`)
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	})
+}
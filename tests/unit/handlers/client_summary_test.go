@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientSummariesAPI(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	summaryRepo := repository.NewClientSummaryRepository(storage.Collection(repository.ClientSummaryCollection))
+	billingService := application.NewBillingService(repository.NewClientRepository(storage)).
+		WithInvoices(repository.NewInvoiceRepository(storage.Collection(repository.InvoiceCollection))).
+		WithPayments(repository.NewPaymentRepository(storage.Collection(repository.PaymentCollection))).
+		WithClientSummaries(summaryRepo).
+		WithEventStore(application.NewEventStore(repository.NewEventStoreRepository(storage.Collection(repository.EventStoreCollection))))
+	handler := httpserver.NewServerWithServices(httpserver.Services{Billing: billingService}, httpserver.ServerOptions{
+		AdminTokens: map[string]string{"ops": "admin-token"},
+	}).Handler()
+
+	acme, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+	require.NoError(t, err)
+	globex, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Globex", Email: "ap@globex.example"})
+	require.NoError(t, err)
+
+	invoice, err := billingService.CreateInvoice(application.AdminContext("ops"), dtos.CreateInvoiceRequest{
+		ClientID:  acme.ID(),
+		Currency:  "EUR",
+		LineItems: []dtos.InvoiceLineRequest{{Description: "Consulting", Quantity: 2, UnitAmount: 5000}},
+	})
+	require.NoError(t, err)
+
+	type summariesResponse struct {
+		Data []struct {
+			ClientID string `json:"client_id"`
+			Name     string `json:"name"`
+			Balances []struct {
+				Amount   int64  `json:"amount"`
+				Currency string `json:"currency"`
+			} `json:"balances"`
+			OpenInvoices int `json:"open_invoices"`
+			LastInvoice  *struct {
+				ID    string `json:"id"`
+				Total struct {
+					Amount int64 `json:"amount"`
+				} `json:"total"`
+			} `json:"last_invoice"`
+		} `json:"data"`
+		Pagination struct {
+			TotalCount int `json:"total_count"`
+			TotalPages int `json:"total_pages"`
+		} `json:"pagination"`
+	}
+	list := func(query string) summariesResponse {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/clients/summaries"+query, nil)
+		req.Header.Set("Authorization", "Bearer admin-token")
+		handler.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var response summariesResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		return response
+	}
+
+	t.Run("lists every client in creation order, drafts do not count", func(t *testing.T) {
+		response := list("")
+		require.Len(t, response.Data, 2)
+		assert.Equal(t, acme.ID(), response.Data[0].ClientID)
+		assert.Equal(t, globex.ID(), response.Data[1].ClientID)
+		assert.Empty(t, response.Data[0].Balances)
+		assert.Nil(t, response.Data[0].LastInvoice)
+		assert.Equal(t, 2, response.Pagination.TotalCount)
+	})
+
+	t.Run("issuing and paying an invoice refreshes the balance", func(t *testing.T) {
+		_, err := billingService.IssueInvoice(application.AdminContext("ops"), invoice.ID(), time.Now())
+		require.NoError(t, err)
+		_, _, err = billingService.RecordPayment(application.AdminContext("billing-admin"), invoice.ID(), dtos.RecordPaymentRequest{Amount: 4000, Currency: "EUR", Method: "bank_transfer"}, time.Now())
+		require.NoError(t, err)
+
+		row := list("?limit=1").Data[0]
+		require.Len(t, row.Balances, 1)
+		assert.Equal(t, int64(6000), row.Balances[0].Amount)
+		assert.Equal(t, "EUR", row.Balances[0].Currency)
+		assert.Equal(t, 1, row.OpenInvoices)
+		require.NotNil(t, row.LastInvoice)
+		assert.Equal(t, invoice.ID(), row.LastInvoice.ID)
+		assert.Equal(t, int64(10000), row.LastInvoice.Total.Amount)
+	})
+
+	t.Run("paginates", func(t *testing.T) {
+		response := list("?page=2&limit=1")
+		require.Len(t, response.Data, 1)
+		assert.Equal(t, "Globex", response.Data[0].Name)
+		assert.Equal(t, 2, response.Pagination.TotalPages)
+	})
+
+	t.Run("deleting a client removes its summary", func(t *testing.T) {
+		require.NoError(t, billingService.DeleteClient(application.AdminContext("billing-admin"), globex.ID()))
+
+		response := list("")
+		require.Len(t, response.Data, 1)
+		assert.Equal(t, acme.ID(), response.Data[0].ClientID)
+	})
+
+	t.Run("rebuild replays the client and invoice states of the event store", func(t *testing.T) {
+		require.NoError(t, summaryRepo.Delete(acme.ID()))
+		require.Empty(t, list("").Data)
+
+		rr := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodPost, "/api/v1/admin/read-models/client-summaries/rebuild", nil)
+		request.Header.Set("Authorization", "Bearer admin-token")
+		handler.ServeHTTP(rr, request)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var rebuilt struct {
+			Data struct {
+				Rebuilt int `json:"rebuilt"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &rebuilt))
+		assert.Equal(t, 1, rebuilt.Data.Rebuilt)
+
+		response := list("")
+		require.Len(t, response.Data, 1)
+		require.Len(t, response.Data[0].Balances, 1)
+		assert.Equal(t, int64(6000), response.Data[0].Balances[0].Amount)
+	})
+
+	t.Run("a snapshot records the states saved before the event store was enabled", func(t *testing.T) {
+		events := application.NewEventStore(repository.NewEventStoreRepository(storage.Collection("snapshot_" + repository.EventStoreCollection)))
+		snapshotted := application.NewBillingService(repository.NewClientRepository(storage)).
+			WithInvoices(repository.NewInvoiceRepository(storage.Collection(repository.InvoiceCollection))).
+			WithClientSummaries(summaryRepo).
+			WithEventStore(events)
+		rebuilt, err := snapshotted.RebuildClientSummaries(time.Now(), nil)
+		require.NoError(t, err)
+		assert.Zero(t, rebuilt, "the new event store holds no states yet")
+
+		var reported []int
+		recorded, err := snapshotted.SnapshotClientSummaryStates(func(recorded int) { reported = append(reported, recorded) })
+		require.NoError(t, err)
+		assert.Equal(t, 2, recorded, "the remaining client and its invoice")
+		assert.Equal(t, []int{2}, reported)
+
+		rebuilt, err = snapshotted.RebuildClientSummaries(time.Now(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, 1, rebuilt)
+		response := list("")
+		require.Len(t, response.Data, 1)
+		require.Len(t, response.Data[0].Balances, 1)
+		assert.Equal(t, int64(6000), response.Data[0].Balances[0].Amount)
+	})
+
+	t.Run("rebuild requires the event store", func(t *testing.T) {
+		_, err := application.NewBillingService(repository.NewClientRepository(storage)).
+			WithClientSummaries(summaryRepo).
+			RebuildClientSummaries(time.Now(), nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("rebuild requires admin credentials", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/api/v1/admin/read-models/client-summaries/rebuild", nil))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("rejects an invalid page", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/clients/summaries?page=0", nil)
+		req.Header.Set("Authorization", "Bearer admin-token")
+		handler.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("is unavailable without the read model", func(t *testing.T) {
+		plain := httpserver.NewServerWithOptions(application.NewBillingService(repository.NewClientRepository(infrastructure.NewInMemoryStorage())), adminOptions()).Handler()
+		rr := httptest.NewRecorder()
+		plain.ServeHTTP(rr, asAdmin(httptest.NewRequest(http.MethodGet, "/api/v1/clients/summaries", nil)))
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	})
+}
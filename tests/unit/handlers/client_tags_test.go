@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI_ClientTags(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage))
+	handler := httpserver.NewServerWithServices(httpserver.Services{Billing: billingService}, httpserver.ServerOptions{
+		AdminTokens: map[string]string{"billing": "billing-token"},
+	}).Handler()
+
+	// Clients are created without a tenant, so only admins act on them
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer billing-token")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+	decodeClient := func(t *testing.T, rr *httptest.ResponseRecorder) dtos.ClientResponse {
+		t.Helper()
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var response struct {
+			Data dtos.ClientResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		return response.Data
+	}
+	listIDs := func(t *testing.T, query string) []string {
+		t.Helper()
+		rr := serve(http.MethodGet, "/api/v1/clients"+query, "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var response struct {
+			Data       []dtos.ClientResponse   `json:"data"`
+			Pagination dtos.PaginationResponse `json:"pagination"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, len(response.Data), response.Pagination.TotalCount)
+		ids := make([]string, len(response.Data))
+		for i, client := range response.Data {
+			ids[i] = client.ID
+		}
+		return ids
+	}
+
+	acme, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+	require.NoError(t, err)
+	globex, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Globex", Email: "ap@globex.example"})
+	require.NoError(t, err)
+	_, err = billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Initech", Email: "ap@initech.example"})
+	require.NoError(t, err)
+
+	t.Run("clients are tagged", func(t *testing.T) {
+		client := decodeClient(t, serve(http.MethodPost, "/api/v1/clients/"+acme.ID()+"/tags", `{"tags":["Key Account","emea"]}`))
+		assert.Equal(t, []string{"emea", "key account"}, client.Tags)
+		decodeClient(t, serve(http.MethodPost, "/api/v1/clients/"+globex.ID()+"/tags", `{"tags":["emea"]}`))
+
+		client = decodeClient(t, serve(http.MethodGet, "/api/v1/clients/"+acme.ID(), ""))
+		assert.Equal(t, []string{"emea", "key account"}, client.Tags)
+	})
+
+	t.Run("invalid tags are rejected", func(t *testing.T) {
+		for _, body := range []string{`{"tags":[]}`, `{"tags":["emea/north"]}`, `{"tags":"emea"}`} {
+			rr := serve(http.MethodPost, "/api/v1/clients/"+acme.ID()+"/tags", body)
+			assert.Equal(t, http.StatusBadRequest, rr.Code, body)
+		}
+	})
+
+	t.Run("clients are filtered by tag", func(t *testing.T) {
+		assert.ElementsMatch(t, []string{acme.ID(), globex.ID()}, listIDs(t, "?tag=EMEA"))
+		assert.Equal(t, []string{acme.ID()}, listIDs(t, "?tag=key%20account"))
+		assert.Empty(t, listIDs(t, "?tag=apac"))
+		assert.Len(t, listIDs(t, ""), 3)
+
+		rr := serve(http.MethodGet, "/api/v1/clients?tag=", "")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("the tag filter combines with the status filter", func(t *testing.T) {
+		_, err := billingService.ChangeClientStatus(application.AdminContext("ops"), globex.ID(), "suspended")
+		require.NoError(t, err)
+		assert.Equal(t, []string{globex.ID()}, listIDs(t, "?tag=emea&status=suspended"))
+		assert.Equal(t, []string{acme.ID()}, listIDs(t, "?tag=emea&status=active"))
+	})
+
+	t.Run("tags are removed", func(t *testing.T) {
+		client := decodeClient(t, serve(http.MethodDelete, "/api/v1/clients/"+acme.ID()+"/tags/Key%20Account", ""))
+		assert.Equal(t, []string{"emea"}, client.Tags)
+		client = decodeClient(t, serve(http.MethodDelete, "/api/v1/clients/"+acme.ID()+"/tags/unknown", ""))
+		assert.Equal(t, []string{"emea"}, client.Tags)
+		assert.Empty(t, listIDs(t, "?tag=key%20account"))
+	})
+}
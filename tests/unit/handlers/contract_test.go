@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/messaging"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newContractTestServer(t *testing.T) (http.Handler, *application.BillingService, *messaging.MemoryPublisher) {
+	t.Helper()
+
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage)).
+		WithInvoices(repository.NewInvoiceRepository(storage.Collection(repository.InvoiceCollection)))
+	publisher := messaging.NewMemoryPublisher()
+	contractRepo := repository.NewContractRepository(storage.Collection(repository.ContractCollection))
+	contractService := application.NewContractService(contractRepo, billingService, publisher, 90*24*time.Hour)
+
+	server := httpserver.NewServerWithServices(httpserver.Services{
+		Billing:   billingService,
+		Contracts: contractService,
+	}, httpserver.ServerOptions{
+		AdminTokens: map[string]string{"scheduler": "admin-token"},
+	})
+	return server.Handler(), billingService, publisher
+}
+
+func TestContractAPI(t *testing.T) {
+	handler, billingService, publisher := newContractTestServer(t)
+
+	client, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+	require.NoError(t, err)
+
+	start := time.Now().UTC().AddDate(0, -10, 0).Truncate(time.Second)
+	end := start.AddDate(1, 0, 0)
+	body := fmt.Sprintf(`{"client_id":%q,"name":"Enterprise 2026","account_manager":"jane.doe","start_date":%q,"end_date":%q,"committed_amount":1000000,"currency":"EUR"}`,
+		client.ID(), start.Format(time.RFC3339), end.Format(time.RFC3339))
+
+	var contractID string
+	t.Run("creates a contract", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, asAdmin(httptest.NewRequest(http.MethodPost, "/api/v1/contracts", strings.NewReader(body))))
+
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		var response struct {
+			Data struct {
+				ID          string    `json:"id"`
+				RenewalDate time.Time `json:"renewal_date"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		contractID = response.Data.ID
+		assert.True(t, end.Equal(response.Data.RenewalDate))
+	})
+
+	t.Run("links subscriptions and invoices", func(t *testing.T) {
+		rc := application.AdminContext("ops")
+		invoice, err := billingService.CreateInvoice(rc, dtos.CreateInvoiceRequest{
+			ClientID:  client.ID(),
+			Currency:  "EUR",
+			LineItems: []dtos.InvoiceLineRequest{{Description: "Licenses", Quantity: 4, UnitAmount: 100000}},
+		})
+		require.NoError(t, err)
+		_, err = billingService.IssueInvoice(rc, invoice.ID(), time.Now())
+		require.NoError(t, err)
+		link := fmt.Sprintf(`{"invoice_id":%q}`, invoice.ID())
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, asAdmin(httptest.NewRequest(http.MethodPost, "/api/v1/contracts/"+contractID+"/subscriptions", strings.NewReader(`{"subscription_id":"sub-1"}`))))
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		rr = httptest.NewRecorder()
+		handler.ServeHTTP(rr, asAdmin(httptest.NewRequest(http.MethodPost, "/api/v1/contracts/"+contractID+"/invoices", strings.NewReader(link))))
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), `"attained_bps":4000`)
+		assert.Contains(t, rr.Body.String(), `"subscription_ids":["sub-1"]`)
+
+		rr = httptest.NewRecorder()
+		handler.ServeHTTP(rr, asAdmin(httptest.NewRequest(http.MethodPost, "/api/v1/contracts/"+contractID+"/invoices", strings.NewReader(link))))
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code, "an invoice counts once")
+	})
+
+	t.Run("reports attainment of active contracts", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, asAdmin(httptest.NewRequest(http.MethodGet, "/api/v1/contracts/attainment", nil)))
+
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var response struct {
+			Data []struct {
+				ContractID string `json:"contract_id"`
+				Attainment struct {
+					AttainedBps int64 `json:"attained_bps"`
+					OnTrack     bool  `json:"on_track"`
+				} `json:"attainment"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		require.Len(t, response.Data, 1)
+		assert.Equal(t, contractID, response.Data[0].ContractID)
+		assert.Equal(t, int64(4000), response.Data[0].Attainment.AttainedBps)
+		assert.False(t, response.Data[0].Attainment.OnTrack, "40% spent after 10 months of 12")
+	})
+
+	t.Run("renewal reminders require an admin token", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/api/v1/admin/contract-renewal-reminders", nil))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/contract-renewal-reminders", nil)
+		req.Header.Set("Authorization", "Bearer admin-token")
+		rr = httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), `"sent":1`)
+		assert.Len(t, publisher.Messages(), 1)
+	})
+
+	t.Run("unknown contract", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, asAdmin(httptest.NewRequest(http.MethodGet, "/api/v1/contracts/missing", nil)))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("unknown client", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, asAdmin(httptest.NewRequest(http.MethodPost, "/api/v1/contracts",
+			strings.NewReader(strings.Replace(body, client.ID(), "00000000-0000-0000-0000-000000000000", 1)))))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestContractAPI_Authorization(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage))
+	contractService := application.NewContractService(repository.NewContractRepository(storage.Collection(repository.ContractCollection)),
+		billingService, messaging.NewMemoryPublisher(), 90*24*time.Hour)
+	handler := httpserver.NewServerWithServices(httpserver.Services{Billing: billingService, Contracts: contractService}, httpserver.ServerOptions{
+		AdminTokens:  map[string]string{"initech-admin": "initech-token", "globex-admin": "globex-token", "support": "support-token"},
+		AdminTenants: map[string][]string{"initech-admin": {"initech"}, "globex-admin": {"globex"}, "support": {"initech"}},
+		AdminScopes:  map[string][]string{"support": {httpserver.ScopeSupport}},
+	}).Handler()
+
+	send := func(method, path, token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rc := application.SystemContext("test", "initech")
+	client, err := billingService.CreateClient(rc, dtos.CreateClientRequest{Name: "Initech", Email: "ap@initech.example"})
+	require.NoError(t, err)
+	start := time.Now().UTC().AddDate(0, -1, 0)
+	contract, err := contractService.CreateContract(rc, dtos.CreateContractRequest{
+		ClientID: client.ID(), Name: "Enterprise", StartDate: start, EndDate: start.AddDate(1, 0, 0), CommittedAmount: 1000000, Currency: "EUR",
+	})
+	require.NoError(t, err)
+	path := "/api/v1/contracts/" + contract.ID()
+	contractBody := fmt.Sprintf(`{"client_id":%q,"name":"Other","start_date":%q,"end_date":%q,"committed_amount":1000,"currency":"EUR"}`,
+		client.ID(), start.Format(time.RFC3339), start.AddDate(1, 0, 0).Format(time.RFC3339))
+
+	t.Run("anonymous callers are refused", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, send(http.MethodGet, "/api/v1/contracts", "", "").Code)
+		assert.Equal(t, http.StatusUnauthorized, send(http.MethodPost, "/api/v1/contracts", "", contractBody).Code)
+		assert.Equal(t, http.StatusUnauthorized, send(http.MethodGet, path, "", "").Code)
+		assert.Equal(t, http.StatusUnauthorized, send(http.MethodGet, "/api/v1/contracts/attainment", "", "").Code)
+		assert.Equal(t, http.StatusUnauthorized, send(http.MethodPost, path+"/subscriptions", "", `{"subscription_id":"sub-1"}`).Code)
+	})
+
+	t.Run("contracts and clients of other tenants are not found", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, send(http.MethodGet, path, "globex-token", "").Code)
+		assert.Equal(t, http.StatusNotFound, send(http.MethodPost, path+"/subscriptions", "globex-token", `{"subscription_id":"sub-1"}`).Code)
+		assert.Equal(t, http.StatusNotFound, send(http.MethodPost, "/api/v1/contracts", "globex-token", contractBody).Code)
+
+		for _, listing := range []string{"/api/v1/contracts", "/api/v1/contracts/attainment"} {
+			rr := send(http.MethodGet, listing, "globex-token", "")
+			require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+			assert.NotContains(t, rr.Body.String(), contract.ID(), listing)
+		}
+	})
+
+	t.Run("admins without the finance scope only read contracts", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, send(http.MethodGet, path, "support-token", "").Code)
+		assert.Equal(t, http.StatusForbidden, send(http.MethodPost, path+"/subscriptions", "support-token", `{"subscription_id":"sub-1"}`).Code)
+		assert.Equal(t, http.StatusForbidden, send(http.MethodPost, "/api/v1/contracts", "support-token", contractBody).Code)
+	})
+
+	t.Run("the owning tenant manages its contracts", func(t *testing.T) {
+		rr := send(http.MethodPost, path+"/subscriptions", "initech-token", `{"subscription_id":"sub-1"}`)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		rr = send(http.MethodGet, "/api/v1/contracts", "initech-token", "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), contract.ID())
+	})
+}
@@ -0,0 +1,249 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/messaging"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// staticUnappliedCredits lists a fixed set of unapplied credit
+type staticUnappliedCredits []service.UnappliedCredit
+
+func (s staticUnappliedCredits) UnappliedCredits() ([]service.UnappliedCredit, error) {
+	return s, nil
+}
+
+func TestAPI_ClientCredit(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	publisher := messaging.NewMemoryPublisher()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage)).WithInvoices(repository.NewInvoiceRepository(storage.Collection(repository.InvoiceCollection)))
+	auditService := application.NewAuditService(repository.NewAuditRepository(storage.Collection(repository.AuditCollection)))
+	approvalService := application.NewApprovalService(
+		repository.NewApprovalRequestRepository(storage.Collection(repository.ApprovalRequestCollection)),
+		auditService,
+		100000,
+		[]string{"alice"},
+	)
+
+	client, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+	require.NoError(t, err)
+
+	money := func(amount int64, currency string) valueobject.Money {
+		m, err := valueobject.NewMoney(amount, currency)
+		require.NoError(t, err)
+		return m
+	}
+	creditService := application.NewCreditControlService(
+		repository.NewClientCreditLimitRepository(storage.Collection(repository.ClientCreditLimitCollection)),
+		billingService,
+		auditService,
+	).WithOpenInvoices(staticOpenInvoices{
+		{InvoiceID: "invoice-1", Number: "INV-0001", ClientID: client.ID(), Outstanding: money(30000, "EUR")},
+		{InvoiceID: "invoice-2", Number: "INV-0002", ClientID: client.ID(), Outstanding: money(18000, "EUR")},
+		{InvoiceID: "invoice-3", Number: "INV-0003", ClientID: client.ID(), Outstanding: money(9900, "USD")},
+		{InvoiceID: "invoice-4", Number: "INV-0004", ClientID: "another-client", Outstanding: money(500000, "EUR")},
+	}).WithUnappliedCredits(staticUnappliedCredits{
+		{Reference: "CN-0001", ClientID: client.ID(), Amount: money(5000, "EUR")},
+	}).WithApprovals(approvalService)
+
+	handler := httpserver.NewServerWithServices(httpserver.Services{
+		Billing:   billingService,
+		Audit:     auditService,
+		Approvals: approvalService,
+		Credit:    creditService,
+		Recurring: application.NewRecurringInvoiceService(
+			repository.NewRecurringInvoiceTemplateRepository(storage.Collection(repository.RecurringInvoiceTemplateCollection)),
+			billingService,
+			publisher,
+		).WithCreditControl(creditService),
+	}, httpserver.ServerOptions{
+		AdminTokens: map[string]string{"ops": "admin-token", "alice": "alice-token"},
+	}).Handler()
+
+	creditPath := "/api/v1/clients/" + client.ID() + "/credit"
+	serve := func(method, path, body, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+	setLimit := func(policy string) {
+		t.Helper()
+		rr := serve(http.MethodPut, creditPath, `{"amount":100000,"currency":"EUR","policy":"`+policy+`"}`, "admin-token")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	}
+	createTemplate := func(unitAmount int64) {
+		t.Helper()
+		body := fmt.Sprintf(`{"client_id":%q,"name":"Retainer","currency":"EUR","frequency":"monthly","first_issue_date":%q,
+			"line_items":[{"description":"Retainer","quantity":1,"unit_amount":%d}]}`,
+			client.ID(), time.Now().UTC().Add(-time.Hour).Format(time.RFC3339), unitAmount)
+		rr := serve(http.MethodPost, "/api/v1/recurring-invoices", body, "admin-token")
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	}
+	type runResponse struct {
+		Data struct {
+			Issued   int `json:"issued"`
+			Invoices []struct {
+				CreditWarning bool `json:"credit_warning"`
+			} `json:"invoices"`
+			Held         int `json:"held"`
+			HeldInvoices []struct {
+				Decision   string `json:"decision"`
+				ApprovalID string `json:"approval_id"`
+			} `json:"held_invoices"`
+		} `json:"data"`
+	}
+	runScheduler := func() runResponse {
+		t.Helper()
+		rr := serve(http.MethodPost, "/api/v1/admin/recurring-invoices/run", "", "admin-token")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var response runResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		return response
+	}
+
+	t.Run("reports exposure per currency without a limit", func(t *testing.T) {
+		rr := serve(http.MethodGet, creditPath, "", "")
+		assert.Equal(t, http.StatusUnauthorized, rr.Code, "clients are only reached by admins")
+
+		rr = serve(http.MethodGet, creditPath, "", "alice-token")
+
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), `"credit_limit":null`)
+		assert.Contains(t, rr.Body.String(), `{"currency":"EUR","open_invoices":48000,"open_invoice_count":2,"unapplied_credit":5000,"exposure":43000}`)
+		assert.Contains(t, rr.Body.String(), `{"currency":"USD","open_invoices":9900,"open_invoice_count":1,"unapplied_credit":0,"exposure":9900}`)
+		assert.NotContains(t, rr.Body.String(), `"available"`)
+	})
+
+	t.Run("changing the limit requires admin credentials", func(t *testing.T) {
+		rr := serve(http.MethodPut, creditPath, `{"amount":100000,"currency":"EUR","policy":"block"}`, "")
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+
+		rr = serve(http.MethodDelete, creditPath, "", "")
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("rejects invalid limits", func(t *testing.T) {
+		rr := serve(http.MethodPut, creditPath, `{"amount":100000,"currency":"EUR","policy":"ignore"}`, "admin-token")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		rr = serve(http.MethodPut, creditPath, `{"amount":-1,"currency":"EUR","policy":"block"}`, "admin-token")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		rr = serve(http.MethodPut, "/api/v1/clients/00000000-0000-0000-0000-000000000000/credit", `{"amount":100000,"currency":"EUR","policy":"block"}`, "admin-token")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("sets a limit and reports the room left", func(t *testing.T) {
+		rr := serve(http.MethodPut, creditPath, `{"amount":100000,"currency":"EUR","policy":"block"}`, "admin-token")
+
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), `"amount":100000,"currency":"EUR","policy":"block"`)
+		assert.Contains(t, rr.Body.String(), `"available":57000`)
+		assert.Contains(t, rr.Body.String(), `"over_limit":false`)
+
+		entries, err := auditService.ListEntries("")
+		require.NoError(t, err)
+		require.NotEmpty(t, entries)
+		assert.Equal(t, application.AuditActionCreditLimitSet, entries[len(entries)-1].Action())
+	})
+
+	t.Run("the block policy holds invoices over the limit", func(t *testing.T) {
+		createTemplate(60000)
+
+		run := runScheduler()
+		assert.Equal(t, 0, run.Data.Issued)
+		require.Equal(t, 1, run.Data.Held)
+		assert.Equal(t, "blocked", run.Data.HeldInvoices[0].Decision)
+		assert.Empty(t, publisher.Messages())
+	})
+
+	t.Run("the warn policy issues invoices over the limit with a warning", func(t *testing.T) {
+		setLimit("warn")
+
+		run := runScheduler()
+		require.Equal(t, 1, run.Data.Issued)
+		assert.True(t, run.Data.Invoices[0].CreditWarning)
+
+		messages := publisher.Messages()
+		require.Len(t, messages, 1)
+		var event application.RecurringInvoiceIssuedEvent
+		require.NoError(t, json.Unmarshal(messages[0].Payload, &event))
+		assert.True(t, event.CreditWarning)
+	})
+
+	t.Run("invoices within the limit are issued without a warning", func(t *testing.T) {
+		createTemplate(20000)
+
+		run := runScheduler()
+		require.Equal(t, 1, run.Data.Issued)
+		assert.False(t, run.Data.Invoices[0].CreditWarning)
+	})
+
+	t.Run("the require_approval policy holds invoices until an override is approved", func(t *testing.T) {
+		setLimit("require_approval")
+		createTemplate(70000)
+
+		run := runScheduler()
+		require.Equal(t, 1, run.Data.Held)
+		assert.Equal(t, "approval_required", run.Data.HeldInvoices[0].Decision)
+		approvalID := run.Data.HeldInvoices[0].ApprovalID
+		require.NotEmpty(t, approvalID)
+
+		// Later runs wait for the same override instead of raising a new one
+		run = runScheduler()
+		require.Equal(t, 1, run.Data.Held)
+		assert.Equal(t, approvalID, run.Data.HeldInvoices[0].ApprovalID)
+
+		rr := serve(http.MethodPost, "/api/v1/approvals/"+approvalID+"/approve", "", "alice-token")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), `"kind":"credit_limit_override"`)
+
+		run = runScheduler()
+		assert.Equal(t, 1, run.Data.Issued)
+		assert.Equal(t, 0, run.Data.Held)
+	})
+
+	t.Run("overrides cannot be submitted directly", func(t *testing.T) {
+		rr := serve(http.MethodPost, "/api/v1/approvals",
+			fmt.Sprintf(`{"kind":"credit_limit_override","client_id":%q,"reference_id":"manual","amount":1000,"currency":"EUR"}`, client.ID()), "admin-token")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("removes the limit", func(t *testing.T) {
+		rr := serve(http.MethodDelete, creditPath, "", "admin-token")
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+
+		rr = serve(http.MethodDelete, creditPath, "", "admin-token")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+
+		rr = serve(http.MethodGet, creditPath, "", "alice-token")
+		assert.Contains(t, rr.Body.String(), `"credit_limit":null`)
+	})
+
+	t.Run("unknown clients and methods", func(t *testing.T) {
+		rr := serve(http.MethodGet, "/api/v1/clients/00000000-0000-0000-0000-000000000000/credit", "", "alice-token")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+
+		rr = serve(http.MethodPost, creditPath, "", "admin-token")
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	})
+}
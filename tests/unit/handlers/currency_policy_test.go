@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/di"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/messaging"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecurringInvoiceCurrencyPolicyAPI(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage)).WithInvoices(repository.NewInvoiceRepository(storage.Collection(repository.InvoiceCollection)))
+	currencies, err := di.CurrencyPoliciesProvider(&di.ContainerConfig{
+		DefaultCurrency:  "EUR",
+		CurrencyRounding: "half_up",
+		TenantCurrencies: map[string]string{"acme-us": "USD"},
+		TenantRoundings:  map[string]string{"acme-us": "down"},
+	})
+	require.NoError(t, err)
+	recurringService := application.NewRecurringInvoiceService(
+		repository.NewRecurringInvoiceTemplateRepository(storage.Collection(repository.RecurringInvoiceTemplateCollection)),
+		billingService,
+		messaging.NewMemoryPublisher(),
+	).WithCurrencyPolicies(currencies)
+	handler := httpserver.NewServerWithServices(httpserver.Services{
+		Billing:   billingService,
+		Recurring: recurringService,
+	}, httpserver.ServerOptions{
+		AdminTokens:  map[string]string{"ops": adminToken, "acme-us-admin": "acme-us-token"},
+		AdminTenants: map[string][]string{"acme-us-admin": {"acme-us"}},
+	}).Handler()
+
+	client, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+	require.NoError(t, err)
+
+	createTemplate := func(tenantID, lineItems string) *httptest.ResponseRecorder {
+		body := fmt.Sprintf(`{"client_id":%q,"name":"Support retainer","frequency":"monthly","first_issue_date":%q,"line_items":[%s]}`,
+			client.ID(), time.Now().UTC().AddDate(0, 1, 0).Format(time.RFC3339), lineItems)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/recurring-invoices", strings.NewReader(body))
+		if tenantID != "" {
+			req.Header.Set("Authorization", "Bearer "+tenantID+"-token")
+		} else {
+			asAdmin(req)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	type templateResponse struct {
+		Data struct {
+			Currency  string `json:"currency"`
+			LineItems []struct {
+				UnitAmount int64 `json:"unit_amount"`
+			} `json:"line_items"`
+			Total struct {
+				Amount int64 `json:"amount"`
+			} `json:"total"`
+		} `json:"data"`
+	}
+
+	t.Run("templates without a currency use the tenant currency", func(t *testing.T) {
+		rr := createTemplate("", `{"description":"Retainer","quantity":1,"unit_amount":150000}`)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		var response templateResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, "EUR", response.Data.Currency)
+
+		rr = createTemplate("acme-us", `{"description":"Retainer","quantity":1,"unit_amount":150000}`)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, "USD", response.Data.Currency)
+	})
+
+	t.Run("converts line items with an exchange rate using the tenant rounding", func(t *testing.T) {
+		lineItems := `{"description":"Retainer","quantity":1,"unit_amount":100000},
+			{"description":"Hosting","quantity":2,"unit_amount":1000,"currency":"EUR","exchange_rate":"1.0835"}`
+
+		rr := createTemplate("", lineItems)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		var response templateResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, "EUR", response.Data.Currency)
+		assert.Equal(t, int64(1000), response.Data.LineItems[1].UnitAmount) // Same currency: the rate is ignored
+
+		rr = createTemplate("acme-us", lineItems)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, int64(1083), response.Data.LineItems[1].UnitAmount) // 10.835 USD rounded down
+		assert.Equal(t, int64(102166), response.Data.Total.Amount)
+	})
+
+	t.Run("rejects line items in another currency without an exchange rate", func(t *testing.T) {
+		rr := createTemplate("", `{"description":"Hosting","quantity":1,"unit_amount":1000,"currency":"USD"}`)
+		require.Equal(t, http.StatusBadRequest, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), "line_items[0].currency")
+	})
+}
@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubQueue reports a fixed queue depth, or fails while down
+type stubQueue struct {
+	depth int
+	down  bool
+}
+
+func (q *stubQueue) QueueDepth() (int, error) {
+	if q.down {
+		return 0, stderrors.New("queue unavailable")
+	}
+	return q.depth, nil
+}
+
+func TestDashboardAPI(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage)).
+		WithInvoices(repository.NewInvoiceRepository(storage.Collection(repository.InvoiceCollection)))
+	outbox := &stubQueue{depth: 3}
+	webhooks := &stubQueue{depth: 1}
+	dashboard := application.NewDashboardService(billingService).
+		WithQueue(application.DashboardQueueWebhookEvents, webhooks).
+		WithQueue(application.DashboardQueueEmailOutbox, outbox)
+
+	handler := httpserver.NewServerWithServices(httpserver.Services{
+		Billing:   billingService,
+		Dashboard: dashboard,
+	}, httpserver.ServerOptions{
+		AdminTokens: map[string]string{"ops": "admin-token"},
+	}).Handler()
+
+	client, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+	require.NoError(t, err)
+	now := time.Now().UTC()
+	issueInvoice := func(t *testing.T, currency string, amount int64, daysPastDue int) {
+		t.Helper()
+		dueDate := now.AddDate(0, 0, -daysPastDue)
+		invoice, err := billingService.CreateInvoice(application.AdminContext("ops"), dtos.CreateInvoiceRequest{
+			ClientID:  client.ID(),
+			Currency:  currency,
+			LineItems: []dtos.InvoiceLineRequest{{Description: "Consulting", Quantity: 1, UnitAmount: amount}},
+			DueDate:   &dueDate,
+		})
+		require.NoError(t, err)
+		_, err = billingService.IssueInvoice(application.AdminContext("ops"), invoice.ID(), now.AddDate(0, 0, -daysPastDue-30))
+		require.NoError(t, err)
+	}
+	issueInvoice(t, "EUR", 10000, 5)
+	issueInvoice(t, "EUR", 2500, 45)
+	issueInvoice(t, "EUR", 4000, 120)
+	issueInvoice(t, "USD", 7000, 10)
+	issueInvoice(t, "USD", 9900, -3) // Not due yet
+	draft, err := billingService.CreateInvoice(application.AdminContext("ops"), dtos.CreateInvoiceRequest{
+		ClientID:  client.ID(),
+		Currency:  "EUR",
+		LineItems: []dtos.InvoiceLineRequest{{Description: "Draft", Quantity: 1, UnitAmount: 500}},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, draft.ID())
+
+	serve := func(method, path, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("overdue invoices are totaled per currency with aging", func(t *testing.T) {
+		rr := serve(http.MethodGet, "/api/v1/admin/dashboard/overdue-invoices", "admin-token")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var response struct {
+			Data []dtos.OverdueInvoiceTotalsResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		require.Len(t, response.Data, 2)
+
+		eur := response.Data[0]
+		assert.Equal(t, "EUR", eur.Currency)
+		assert.Equal(t, 3, eur.Invoices)
+		assert.Equal(t, int64(16500), eur.Outstanding.Amount)
+		assert.Equal(t, now.AddDate(0, 0, -120).Truncate(24*time.Hour), eur.OldestDueDate)
+		require.Len(t, eur.Aging, 4)
+		assert.Equal(t, "1-30", eur.Aging[0].Label)
+		assert.Equal(t, 1, eur.Aging[0].Invoices)
+		assert.Equal(t, int64(10000), eur.Aging[0].Outstanding.Amount)
+		assert.Equal(t, 1, eur.Aging[1].Invoices)
+		assert.Equal(t, 0, eur.Aging[2].Invoices)
+		assert.Equal(t, int64(0), eur.Aging[2].Outstanding.Amount)
+		assert.Equal(t, "90+", eur.Aging[3].Label)
+		assert.Nil(t, eur.Aging[3].MaxDays)
+		assert.Equal(t, int64(4000), eur.Aging[3].Outstanding.Amount)
+
+		usd := response.Data[1]
+		assert.Equal(t, "USD", usd.Currency)
+		assert.Equal(t, 1, usd.Invoices)
+		assert.Equal(t, int64(7000), usd.Outstanding.Amount)
+	})
+
+	t.Run("queues are reported by name", func(t *testing.T) {
+		rr := serve(http.MethodGet, "/api/v1/admin/dashboard/queues", "admin-token")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var response struct {
+			Data []dtos.QueueDepthResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, []dtos.QueueDepthResponse{
+			{Name: application.DashboardQueueEmailOutbox, Depth: 3},
+			{Name: application.DashboardQueueWebhookEvents, Depth: 1},
+		}, response.Data)
+	})
+
+	t.Run("a failing queue is a server error", func(t *testing.T) {
+		webhooks.down = true
+		defer func() { webhooks.down = false }()
+
+		rr := serve(http.MethodGet, "/api/v1/admin/dashboard/queues", "admin-token")
+		assert.Equal(t, http.StatusInternalServerError, rr.Code, rr.Body.String())
+	})
+
+	t.Run("dashboard endpoints require an admin token", func(t *testing.T) {
+		rr := serve(http.MethodGet, "/api/v1/admin/dashboard", "")
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("request statistics count the requests served", func(t *testing.T) {
+		// 2 successes, 1 server error and 1 unauthorized request so far
+		rr := serve(http.MethodGet, "/api/v1/admin/dashboard/requests?window=5m", "admin-token")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var response struct {
+			Data dtos.RequestStatsResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, int64(300), response.Data.WindowSeconds)
+		assert.Equal(t, 4, response.Data.Requests)
+		assert.Equal(t, 1, response.Data.ClientErrors)
+		assert.Equal(t, 1, response.Data.ServerErrors)
+		assert.InDelta(t, 0.8, response.Data.RequestsPerMinute, 0.001)
+		assert.InDelta(t, 0.25, response.Data.ErrorRate, 0.001)
+	})
+
+	t.Run("an invalid window is rejected", func(t *testing.T) {
+		for _, window := range []string{"soon", "-5m", "2h"} {
+			rr := serve(http.MethodGet, "/api/v1/admin/dashboard/requests?window="+window, "admin-token")
+			assert.Equal(t, http.StatusBadRequest, rr.Code, window)
+		}
+	})
+
+	t.Run("the dashboard combines every number", func(t *testing.T) {
+		rr := serve(http.MethodGet, "/api/v1/admin/dashboard", "admin-token")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var response struct {
+			Data dtos.DashboardResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, int64(900), response.Data.Requests.WindowSeconds)
+		assert.Equal(t, 8, response.Data.Requests.Requests)
+		assert.Len(t, response.Data.Queues, 2)
+		assert.Len(t, response.Data.OverdueInvoices, 2)
+		assert.False(t, response.Data.GeneratedAt.IsZero())
+	})
+
+	t.Run("dashboard endpoints are read-only", func(t *testing.T) {
+		rr := serve(http.MethodPost, "/api/v1/admin/dashboard/queues", "admin-token")
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	})
+}
@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/messaging"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/webhook"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// unavailablePublisher rejects every message until the message bus is back
+type unavailablePublisher struct {
+	*messaging.MemoryPublisher
+	down bool
+}
+
+func (p *unavailablePublisher) Publish(ctx context.Context, messages ...messaging.Message) error {
+	if p.down {
+		return fmt.Errorf("message bus unavailable")
+	}
+	return p.MemoryPublisher.Publish(ctx, messages...)
+}
+
+func TestAPI_DeadLetterQueue(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	bus := &unavailablePublisher{MemoryPublisher: messaging.NewMemoryPublisher(), down: true}
+	auditService := application.NewAuditService(repository.NewAuditRepository(storage.Collection(repository.AuditCollection)))
+	publisher := application.NewDeadLetterPublisher(bus, repository.NewFailedMessageRepository(storage.Collection(repository.FailedMessageCollection)))
+	bankProcessor := &failingWebhookProcessor{failing: true}
+	webhookService := application.NewWebhookService(
+		repository.NewWebhookEventRepository(storage.Collection(repository.WebhookEventCollection)),
+		auditService,
+		messaging.NewMemoryPublisher(),
+		1,
+	).
+		WithProvider("bank", webhook.NewHMACVerifier("bank-secret", "X-Bank-Signature", "")).
+		WithProcessor("bank", bankProcessor)
+
+	handler := httpserver.NewServerWithServices(httpserver.Services{
+		Billing:  application.NewBillingService(repository.NewClientRepository(storage)),
+		Audit:    auditService,
+		Webhooks: webhookService,
+		DeadLetters: application.NewDeadLetterService(auditService).
+			WithSource(application.DeadLetterSourceMessages, publisher).
+			WithSource(application.DeadLetterSourceWebhooks, webhookService),
+	}, httpserver.ServerOptions{
+		AdminTokens: map[string]string{"ops": "admin-token"},
+	}).Handler()
+
+	serve := func(method, path, body string, headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+	admin := map[string]string{"Authorization": "Bearer admin-token"}
+	type letterResponse struct {
+		ID        string          `json:"id"`
+		Source    string          `json:"source"`
+		Reference string          `json:"reference"`
+		Payload   json.RawMessage `json:"payload"`
+		Reason    string          `json:"reason"`
+		Attempts  int             `json:"attempts"`
+	}
+	list := func(query string) []letterResponse {
+		t.Helper()
+		rr := serve(http.MethodGet, "/api/v1/admin/dead-letters"+query, "", admin)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var response struct {
+			Data []letterResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		return response.Data
+	}
+	type bulkResponse struct {
+		Succeeded []string `json:"succeeded"`
+		Failed    []struct {
+			ID    string `json:"id"`
+			Error string `json:"error"`
+		} `json:"failed"`
+	}
+	bulk := func(operation, body string) bulkResponse {
+		t.Helper()
+		rr := serve(http.MethodPost, "/api/v1/admin/dead-letters/"+operation, body, admin)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var response struct {
+			Data bulkResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		return response.Data
+	}
+
+	// A message the bus rejects and a webhook event the processor rejects are both parked
+	require.NoError(t, publisher.Publish(context.Background(), messaging.Message{
+		Topic:   "billing.contracts",
+		Key:     "contract-1",
+		Payload: json.RawMessage(`{"id":"contract-1"}`),
+	}))
+	body := `{"event_id":"bank-1","event_type":"credit_transfer.received"}`
+	rr := serve(http.MethodPost, "/api/v1/webhooks/bank", body, map[string]string{
+		"X-Bank-Signature": "sha256=" + webhook.Sign("bank-secret", body),
+	})
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	var messageID, eventID string
+
+	t.Run("lists dead letters from every source", func(t *testing.T) {
+		letters := list("")
+		require.Len(t, letters, 2)
+
+		for _, letter := range letters {
+			switch letter.Source {
+			case application.DeadLetterSourceMessages:
+				messageID = letter.ID
+				assert.Equal(t, "billing.contracts", letter.Reference)
+				assert.Equal(t, "message bus unavailable", letter.Reason)
+			case application.DeadLetterSourceWebhooks:
+				eventID = letter.ID
+				assert.Equal(t, "bank/credit_transfer.received", letter.Reference)
+				assert.Equal(t, "ledger unavailable", letter.Reason)
+			}
+		}
+		require.NotEmpty(t, messageID)
+		require.NotEmpty(t, eventID)
+
+		assert.Len(t, list("?source=messages"), 1)
+		assert.Equal(t, http.StatusBadRequest, serve(http.MethodGet, "/api/v1/admin/dead-letters?source=outbox", "", admin).Code)
+	})
+
+	t.Run("shows the payload and failure reason of a dead letter", func(t *testing.T) {
+		rr := serve(http.MethodGet, "/api/v1/admin/dead-letters/"+messageID, "", admin)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var response struct {
+			Data letterResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.JSONEq(t, `{"id":"contract-1"}`, string(response.Data.Payload))
+		assert.Equal(t, 1, response.Data.Attempts)
+
+		assert.Equal(t, http.StatusNotFound, serve(http.MethodGet, "/api/v1/admin/dead-letters/unknown", "", admin).Code)
+	})
+
+	t.Run("requires admin authentication", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, serve(http.MethodGet, "/api/v1/admin/dead-letters", "", nil).Code)
+		assert.Equal(t, http.StatusUnauthorized, serve(http.MethodPost, "/api/v1/admin/dead-letters/discard", `{"source":"webhooks"}`, nil).Code)
+	})
+
+	t.Run("requires a selection for bulk operations", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, serve(http.MethodPost, "/api/v1/admin/dead-letters/retry", `{}`, admin).Code)
+	})
+
+	t.Run("failed retries stay in the queue", func(t *testing.T) {
+		result := bulk("retry", `{"source":"messages"}`)
+		assert.Empty(t, result.Succeeded)
+		require.Len(t, result.Failed, 1)
+		assert.Equal(t, messageID, result.Failed[0].ID)
+
+		letters := list("?source=messages")
+		require.Len(t, letters, 1)
+		assert.Equal(t, 2, letters[0].Attempts)
+	})
+
+	t.Run("retries publish the selected messages", func(t *testing.T) {
+		bus.down = false
+
+		result := bulk("retry", fmt.Sprintf(`{"ids":[%q,"unknown"]}`, messageID))
+		assert.Equal(t, []string{messageID}, result.Succeeded)
+		require.Len(t, result.Failed, 1)
+		assert.Equal(t, "unknown", result.Failed[0].ID)
+
+		require.Len(t, bus.Messages(), 1)
+		assert.Equal(t, "billing.contracts", bus.Messages()[0].Topic)
+		assert.Empty(t, list("?source=messages"))
+	})
+
+	t.Run("discards leave webhook events unprocessed", func(t *testing.T) {
+		result := bulk("discard", `{"source":"webhooks"}`)
+		assert.Equal(t, []string{eventID}, result.Succeeded)
+		assert.Empty(t, list(""))
+		assert.Empty(t, bankProcessor.processed)
+
+		rr := serve(http.MethodGet, "/api/v1/admin/webhooks/events/"+eventID, "", admin)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), `"status":"discarded"`)
+	})
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gjaminon-go-labs/billing-api/api"
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/handlers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestAPI_OpenAPIDocument(t *testing.T) {
+	handler := httpserver.NewServerWithServices(httpserver.Services{}, httpserver.ServerOptions{}).Handler()
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, handlers.OpenAPIPath, nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+	assert.NotEmpty(t, rr.Header().Get("Cache-Control"))
+
+	// The JSON document is the published YAML document
+	var served, published map[string]interface{}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &served))
+	var spec interface{}
+	require.NoError(t, yaml.Unmarshal(api.OpenAPISpec, &spec))
+	encoded, err := json.Marshal(spec)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(encoded, &published))
+	assert.Equal(t, published, served)
+	assert.Equal(t, "3.0.3", served["openapi"])
+	assert.Contains(t, served["paths"], handlers.OpenAPIPath)
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, handlers.OpenAPIPath, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+}
+
+func TestAPI_DocsPage(t *testing.T) {
+	t.Run("Swagger UI renders the OpenAPI document", func(t *testing.T) {
+		handler := httpserver.NewServerWithServices(httpserver.Services{}, httpserver.ServerOptions{}).Handler()
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, handlers.DocsPath, nil))
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Header().Get("Content-Type"), "text/html")
+		assert.Contains(t, rr.Body.String(), handlers.DefaultSwaggerUIAssets+"/swagger-ui-bundle.js")
+		assert.Contains(t, rr.Body.String(), `openapi.json`)
+		assert.Contains(t, rr.Header().Get("Content-Security-Policy"), "https://cdn.jsdelivr.net")
+	})
+
+	t.Run("assets can be hosted next to the API", func(t *testing.T) {
+		handler := httpserver.NewServerWithServices(httpserver.Services{}, httpserver.ServerOptions{
+			DocsAssetsURL: "/static/swagger-ui/",
+		}).Handler()
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, handlers.DocsPath, nil))
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `src="/static/swagger-ui/swagger-ui-bundle.js"`)
+		assert.NotContains(t, rr.Header().Get("Content-Security-Policy"), "cdn.jsdelivr.net")
+	})
+
+	t.Run("documents that are not OpenAPI 3 are refused", func(t *testing.T) {
+		_, err := handlers.NewDocsHandler([]byte("swagger: \"2.0\"\n"), "")
+		assert.Error(t, err)
+	})
+}
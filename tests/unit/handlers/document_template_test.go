@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminAPI_DocumentTemplates(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	auditService := application.NewAuditService(repository.NewAuditRepository(storage.Collection(repository.AuditCollection)))
+	legalEntityService := application.NewLegalEntityService(
+		repository.NewLegalEntityRepository(storage.Collection(repository.LegalEntityCollection)),
+		auditService,
+	)
+	handler := httpserver.NewServerWithServices(httpserver.Services{
+		Billing:       application.NewBillingService(repository.NewClientRepository(storage)),
+		Audit:         auditService,
+		LegalEntities: legalEntityService,
+		Documents: application.NewDocumentTemplateService(
+			repository.NewDocumentTemplateRepository(storage.Collection(repository.DocumentTemplateCollection)),
+			auditService,
+		).WithLegalEntities(legalEntityService),
+	}, httpserver.ServerOptions{
+		AdminTokens: map[string]string{"ops": "admin-token"},
+	}).Handler()
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newAdminRequest(method, path, body, "192.0.2.10:1234"))
+		return rr
+	}
+
+	t.Run("tenants without a template get the default layout", func(t *testing.T) {
+		rr := serve(http.MethodGet, "/api/v1/admin/document-templates/acme", "")
+
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), `"default":true`)
+		assert.Contains(t, rr.Body.String(), `"primary_color":"#1F2937"`)
+	})
+
+	t.Run("rejects invalid templates", func(t *testing.T) {
+		rr := serve(http.MethodPut, "/api/v1/admin/document-templates/acme", `{"columns":["description","quantity"]}`)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("previews a draft without saving it", func(t *testing.T) {
+		rr := serve(http.MethodPost, "/api/v1/admin/document-templates/acme/preview", `{"primary_color":"#AA0000","footer_text":"Draft footer"}`)
+
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Equal(t, "text/html; charset=utf-8", rr.Header().Get("Content-Type"))
+		assert.Contains(t, rr.Body.String(), "color: #AA0000")
+		assert.Contains(t, rr.Body.String(), "Draft footer")
+
+		rr = serve(http.MethodGet, "/api/v1/admin/document-templates/acme", "")
+		assert.Contains(t, rr.Body.String(), `"default":true`)
+	})
+
+	t.Run("previews in the requested locale", func(t *testing.T) {
+		rr := serve(http.MethodGet, "/api/v1/admin/document-templates/acme/preview?locale=fr-FR", "")
+
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), `<html lang="fr-FR">`)
+		assert.Contains(t, rr.Body.String(), "Facture PREVIEW-000001")
+		assert.Contains(t, rr.Body.String(), "Total TTC: 1\u202f498,80\u00a0€")
+
+		rr = serve(http.MethodGet, "/api/v1/admin/document-templates/acme/preview?locale=french", "")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("saves a template and previews it with the default legal entity as seller", func(t *testing.T) {
+		rr := serve(http.MethodPost, "/api/v1/admin/legal-entities", `{"name":"Acme SAS","country":"FR","numbering":{"prefix":"FR-"}}`)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		rr = serve(http.MethodPut, "/api/v1/admin/document-templates/acme",
+			`{"logo_url":"https://cdn.acme.example/logo.png","accent_color":"#00aa55","columns":["description","amount"],"footer_text":"Thank you"}`)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), `"columns":["description","amount"]`)
+		assert.Contains(t, rr.Body.String(), `"default":false`)
+
+		rr = serve(http.MethodGet, "/api/v1/admin/document-templates/acme/preview", "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), "<strong>Acme SAS</strong>")
+		assert.Contains(t, rr.Body.String(), "color: #00AA55")
+		assert.NotContains(t, rr.Body.String(), "<th>Qty</th>")
+
+		rr = serve(http.MethodGet, "/api/v1/admin/document-templates", "")
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"tenant_id":"acme"`)
+	})
+
+	t.Run("deleting reverts the tenant to the default layout", func(t *testing.T) {
+		rr := serve(http.MethodDelete, "/api/v1/admin/document-templates/acme", "")
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+
+		rr = serve(http.MethodDelete, "/api/v1/admin/document-templates/acme", "")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+
+		rr = serve(http.MethodPatch, "/api/v1/admin/document-templates/acme/preview", "")
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	})
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminAPI_DunningPolicy(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	auditService := application.NewAuditService(repository.NewAuditRepository(storage.Collection(repository.AuditCollection)))
+	handler := httpserver.NewServerWithServices(httpserver.Services{
+		Billing: application.NewBillingService(repository.NewClientRepository(storage)),
+		Audit:   auditService,
+		Dunning: application.NewDunningPolicyService(
+			repository.NewDunningPolicyRepository(storage.Collection(repository.DunningPolicyCollection)),
+			auditService,
+		),
+	}, httpserver.ServerOptions{
+		AdminTokens: map[string]string{"ops": "admin-token"},
+	}).Handler()
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newAdminRequest(method, path, body, "192.0.2.10:1234"))
+		return rr
+	}
+
+	type policyResponse struct {
+		Data struct {
+			TenantID string `json:"tenant_id"`
+			Default  bool   `json:"default"`
+			Stages   []struct {
+				DayOffset int    `json:"day_offset"`
+				Channel   string `json:"channel"`
+				Template  string `json:"template"`
+			} `json:"stages"`
+		} `json:"data"`
+	}
+
+	t.Run("tenants without a policy report the default cadence", func(t *testing.T) {
+		rr := serve(http.MethodGet, "/api/v1/admin/dunning-policies/acme", "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var response policyResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.True(t, response.Data.Default)
+		assert.NotEmpty(t, response.Data.Stages)
+	})
+
+	t.Run("invalid cadence is rejected", func(t *testing.T) {
+		rr := serve(http.MethodPut, "/api/v1/admin/dunning-policies/acme",
+			`{"stages":[{"day_offset":10,"channel":"email","template":"late"},{"day_offset":5,"channel":"email","template":"early"}]}`)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "stages[1].day_offset")
+	})
+
+	t.Run("sets, lists and deletes a tenant cadence", func(t *testing.T) {
+		rr := serve(http.MethodPut, "/api/v1/admin/dunning-policies/acme",
+			`{"stages":[{"day_offset":3,"channel":"email","template":"acme_friendly"},{"day_offset":21,"channel":"sms","template":"acme_final"}]}`)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		rr = serve(http.MethodGet, "/api/v1/admin/dunning-policies/acme", "")
+		var response policyResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.False(t, response.Data.Default)
+		require.Len(t, response.Data.Stages, 2)
+		assert.Equal(t, "sms", response.Data.Stages[1].Channel)
+
+		rr = serve(http.MethodGet, "/api/v1/admin/dunning-policies", "")
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"tenant_id":"acme"`)
+
+		rr = serve(http.MethodGet, "/api/v1/admin/audit-log", "")
+		assert.Contains(t, rr.Body.String(), application.AuditActionDunningPolicySet)
+
+		rr = serve(http.MethodDelete, "/api/v1/admin/dunning-policies/acme", "")
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+
+		rr = serve(http.MethodDelete, "/api/v1/admin/dunning-policies/acme", "")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
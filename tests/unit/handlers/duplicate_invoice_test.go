@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/di"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/messaging"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDuplicateInvoiceAPI(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage)).WithInvoices(repository.NewInvoiceRepository(storage.Collection(repository.InvoiceCollection)))
+	recurringService := application.NewRecurringInvoiceService(
+		repository.NewRecurringInvoiceTemplateRepository(storage.Collection(repository.RecurringInvoiceTemplateCollection)),
+		billingService,
+		messaging.NewMemoryPublisher(),
+	).WithDuplicateCheck(di.DuplicateInvoiceCheckProvider(&di.ContainerConfig{
+		DuplicateInvoicesEnabled: true,
+		DuplicateInvoiceWindow:   24 * time.Hour,
+	}))
+	handler := httpserver.NewServerWithServices(httpserver.Services{
+		Billing:   billingService,
+		Recurring: recurringService,
+	}, adminOptions()).Handler()
+
+	client, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+	require.NoError(t, err)
+	other, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Globex", Email: "billing@globex.example"})
+	require.NoError(t, err)
+
+	createTemplate := func(clientID, extra, lineItems string) *httptest.ResponseRecorder {
+		body := fmt.Sprintf(`{"client_id":%q,"name":"Support retainer","currency":"EUR","frequency":"monthly","first_issue_date":%q,%s"line_items":[%s]}`,
+			clientID, time.Now().UTC().AddDate(0, 1, 0).Format(time.RFC3339), extra, lineItems)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, asAdmin(httptest.NewRequest(http.MethodPost, "/api/v1/recurring-invoices", strings.NewReader(body))))
+		return rr
+	}
+	retainer := `{"description":"Retainer","quantity":1,"unit_amount":150000}`
+
+	type duplicateResponse struct {
+		Error struct {
+			Code    string `json:"code"`
+			Details struct {
+				Match       string `json:"match"`
+				ExistingID  string `json:"existing_id"`
+				ExistingURL string `json:"existing_url"`
+			} `json:"details"`
+		} `json:"error"`
+	}
+
+	rr := createTemplate(client.ID(), `"external_ref":"PO-1001",`, retainer)
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	var created struct {
+		Data struct {
+			ID          string `json:"id"`
+			ExternalRef string `json:"external_ref"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &created))
+	assert.Equal(t, "PO-1001", created.Data.ExternalRef)
+
+	t.Run("rejects a second invoice with the same external reference", func(t *testing.T) {
+		rr := createTemplate(client.ID(), `"external_ref":"po-1001",`, `{"description":"Hosting","quantity":2,"unit_amount":2500}`)
+		require.Equal(t, http.StatusConflict, rr.Code, rr.Body.String())
+
+		var response duplicateResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, "BUSINESS_RULE_DUPLICATE", response.Error.Code)
+		assert.Equal(t, "external_ref", response.Error.Details.Match)
+		assert.Equal(t, created.Data.ID, response.Error.Details.ExistingID)
+		assert.Equal(t, "/api/v1/recurring-invoices/"+created.Data.ID, response.Error.Details.ExistingURL)
+	})
+
+	t.Run("rejects identical line items within the window", func(t *testing.T) {
+		rr := createTemplate(client.ID(), "", retainer)
+		require.Equal(t, http.StatusConflict, rr.Code, rr.Body.String())
+
+		var response duplicateResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, "line_items", response.Error.Details.Match)
+		assert.Equal(t, created.Data.ID, response.Error.Details.ExistingID)
+	})
+
+	t.Run("allowed duplicates and other clients are created", func(t *testing.T) {
+		rr := createTemplate(client.ID(), `"allow_duplicate":true,`, retainer)
+		assert.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		rr = createTemplate(other.ID(), `"external_ref":"PO-1001",`, retainer)
+		assert.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	})
+
+	t.Run("allow_duplicate does not skip external references", func(t *testing.T) {
+		rr := createTemplate(client.ID(), `"external_ref":"PO-1001","allow_duplicate":true,`, retainer)
+		assert.Equal(t, http.StatusConflict, rr.Code, rr.Body.String())
+	})
+}
@@ -0,0 +1,251 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/messaging"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailOutboxAPI(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	clientRepo := repository.NewClientRepository(storage)
+	billingService := application.NewBillingService(clientRepo)
+	mailer := &unavailablePublisher{MemoryPublisher: messaging.NewMemoryPublisher(), down: true}
+	outbox := application.NewEmailOutboxService(
+		repository.NewOutboundEmailRepository(storage.Collection(repository.OutboundEmailCollection)),
+		repository.NewEmailSuppressionRepository(storage.Collection(repository.EmailSuppressionCollection)),
+		mailer,
+		3,
+		time.Minute,
+		90*time.Second,
+		2,
+	).WithClients(clientRepo)
+	bus := messaging.NewMemoryPublisher()
+	publisher := outbox.Publisher(bus)
+	deliveryService := application.NewInvoiceDeliveryService(
+		repository.NewInvoiceDeliveryEventRepository(storage.Collection(repository.InvoiceDeliveryEventCollection)),
+		publisher,
+		"",
+		nil,
+	).WithBounceRecorder(outbox)
+
+	handler := httpserver.NewServerWithServices(httpserver.Services{
+		Billing:     billingService,
+		Delivery:    deliveryService,
+		EmailOutbox: outbox,
+	}, httpserver.ServerOptions{
+		AdminTokens: map[string]string{"mailer": "admin-token"},
+	}).Handler()
+
+	client, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Acme Corp", Email: "Billing@Acme.example"})
+	require.NoError(t, err)
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer admin-token")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+	sendReceipt := func(t *testing.T) {
+		t.Helper()
+		require.NoError(t, publisher.Publish(context.Background(),
+			messaging.Message{Topic: application.PaymentReceiptTopic, Key: "inv-1", Payload: []byte(fmt.Sprintf(`{"client_id":%q}`, client.ID()))},
+			messaging.Message{Topic: application.LedgerPostingTopic, Key: "inv-1", Payload: []byte(`{}`)},
+		))
+	}
+	listEmails := func(t *testing.T, status string) []dtos.OutboundEmailResponse {
+		t.Helper()
+		rr := serve(http.MethodGet, "/api/v1/admin/email-outbox?status="+status, "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var response struct {
+			Data []dtos.OutboundEmailResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		return response.Data
+	}
+	runOutbox := func(t *testing.T) dtos.EmailOutboxRunResponse {
+		t.Helper()
+		rr := serve(http.MethodPost, "/api/v1/admin/email-outbox/run", "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var response struct {
+			Data dtos.EmailOutboxRunResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		return response.Data
+	}
+	listSuppressions := func(t *testing.T) []dtos.EmailSuppressionResponse {
+		t.Helper()
+		rr := serve(http.MethodGet, "/api/v1/admin/email-suppressions", "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var response struct {
+			Data []dtos.EmailSuppressionResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		return response.Data
+	}
+
+	var emailID string
+
+	t.Run("notifications are persisted in the outbox instead of published", func(t *testing.T) {
+		sendReceipt(t)
+
+		require.Len(t, bus.Messages(), 1, "other messages are published at once")
+		assert.Equal(t, application.LedgerPostingTopic, bus.Messages()[0].Topic)
+
+		emails := listEmails(t, "pending")
+		require.Len(t, emails, 1)
+		assert.Equal(t, application.PaymentReceiptTopic, emails[0].Topic)
+		assert.Equal(t, "billing@acme.example", emails[0].Recipient, "the recipient is the client's address")
+		assert.NotNil(t, emails[0].NextAttemptAt)
+		emailID = emails[0].ID
+	})
+
+	t.Run("failed dispatches back off until the last attempt", func(t *testing.T) {
+		now := time.Now().Add(time.Minute)
+		processed, err := outbox.ProcessOutbox(context.Background(), now)
+		require.NoError(t, err)
+		require.Len(t, processed, 1)
+		assert.Equal(t, entity.OutboundEmailPending, processed[0].Status())
+		assert.Equal(t, now.Add(time.Minute).UTC(), processed[0].NextAttemptAt())
+
+		processed, err = outbox.ProcessOutbox(context.Background(), now.Add(30*time.Second))
+		require.NoError(t, err)
+		assert.Empty(t, processed, "not due before the backoff elapsed")
+
+		processed, err = outbox.ProcessOutbox(context.Background(), now.Add(time.Minute))
+		require.NoError(t, err)
+		require.Len(t, processed, 1)
+		assert.Equal(t, now.Add(150*time.Second).UTC(), processed[0].NextAttemptAt(), "the doubled backoff is capped")
+
+		processed, err = outbox.ProcessOutbox(context.Background(), now.Add(150*time.Second))
+		require.NoError(t, err)
+		require.Len(t, processed, 1)
+		assert.Equal(t, entity.OutboundEmailFailed, processed[0].Status())
+		assert.Equal(t, "message bus unavailable", processed[0].LastError())
+		assert.Empty(t, mailer.Messages())
+	})
+
+	t.Run("metrics summarize the outbox", func(t *testing.T) {
+		rr := serve(http.MethodGet, "/api/v1/admin/email-outbox/metrics", "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var response struct {
+			Data dtos.EmailOutboxMetricsResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, map[string]int{"failed": 1}, response.Data.ByStatus)
+		assert.Equal(t, map[string]int{application.PaymentReceiptTopic: 1}, response.Data.ByTopic)
+		assert.Equal(t, 3, response.Data.Attempts)
+		assert.Equal(t, 2, response.Data.Retries)
+		assert.Nil(t, response.Data.OldestPendingAgeSeconds)
+	})
+
+	t.Run("a failed email is retried on demand", func(t *testing.T) {
+		mailer.down = false
+
+		rr := serve(http.MethodPost, "/api/v1/admin/email-outbox/"+emailID+"/retry", "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		result := runOutbox(t)
+		assert.Equal(t, []string{emailID}, result.Sent)
+		require.Len(t, mailer.Messages(), 1)
+		sent := mailer.Messages()[0]
+		assert.Equal(t, "billing@acme.example", sent.Headers[application.EmailOutboxRecipientHeader])
+		assert.Equal(t, emailID, sent.Headers[application.EmailOutboxIDHeader])
+
+		rr = serve(http.MethodPost, "/api/v1/admin/email-outbox/"+emailID+"/retry", "")
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code, "sent emails are not retried")
+
+		rr = serve(http.MethodGet, "/api/v1/admin/email-outbox/"+emailID, "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), `"status":"sent"`)
+	})
+
+	t.Run("a hard bounce suppresses the address", func(t *testing.T) {
+		rr := serve(http.MethodPost, "/api/v1/invoices/inv-1/delivery-events", `{"type":"bounced","bounce_type":"hard","recipient":"billing@acme.example","detail":"550 no such user"}`)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		suppressions := listSuppressions(t)
+		require.Len(t, suppressions, 1)
+		assert.Equal(t, "billing@acme.example", suppressions[0].Email)
+		assert.Equal(t, "hard_bounce", suppressions[0].Reason)
+
+		sendReceipt(t)
+		result := runOutbox(t)
+		assert.Len(t, result.Suppressed, 1)
+		assert.Empty(t, result.Sent)
+		assert.Len(t, mailer.Messages(), 1, "suppressed emails are not sent")
+	})
+
+	t.Run("an address removed from the suppression list receives emails again", func(t *testing.T) {
+		rr := serve(http.MethodDelete, "/api/v1/admin/email-suppressions/billing@acme.example", "")
+		require.Equal(t, http.StatusNoContent, rr.Code, rr.Body.String())
+		assert.Empty(t, listSuppressions(t))
+
+		rr = serve(http.MethodDelete, "/api/v1/admin/email-suppressions/billing@acme.example", "")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+
+		suppressed := listEmails(t, "suppressed")
+		require.Len(t, suppressed, 1)
+		rr = serve(http.MethodPost, "/api/v1/admin/email-outbox/"+suppressed[0].ID+"/retry", "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Len(t, runOutbox(t).Sent, 1)
+	})
+
+	t.Run("repeated soft bounces suppress the address", func(t *testing.T) {
+		report := `{"email":"ap@globex.example","bounce_type":"soft","detail":"mailbox full"}`
+		rr := serve(http.MethodPost, "/api/v1/admin/email-suppressions/bounces", report)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), `"soft_bounces":1`)
+		assert.Empty(t, listSuppressions(t))
+
+		rr = serve(http.MethodPost, "/api/v1/admin/email-suppressions/bounces", report)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), `"reason":"soft_bounces"`)
+		assert.Len(t, listSuppressions(t), 1)
+
+		rr = serve(http.MethodPost, "/api/v1/admin/email-suppressions/bounces", `{"email":"ap@globex.example","bounce_type":"blocked"}`)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("addresses are suppressed by hand", func(t *testing.T) {
+		rr := serve(http.MethodPost, "/api/v1/admin/email-suppressions", `{"email":"Ops@Initech.example","note":"unsubscribe request"}`)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), `"email":"ops@initech.example"`)
+
+		rr = serve(http.MethodPost, "/api/v1/admin/email-suppressions", `{"email":"ops@initech.example"}`)
+		assert.Equal(t, http.StatusConflict, rr.Code)
+
+		rr = serve(http.MethodPost, "/api/v1/admin/email-suppressions", `{"email":"not-an-address"}`)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("invalid requests are rejected", func(t *testing.T) {
+		assert.Equal(t, http.StatusMethodNotAllowed, serve(http.MethodGet, "/api/v1/admin/email-outbox/run", "").Code)
+		assert.Equal(t, http.StatusBadRequest, serve(http.MethodGet, "/api/v1/admin/email-outbox?status=queued", "").Code)
+		assert.Equal(t, http.StatusNotFound, serve(http.MethodGet, "/api/v1/admin/email-outbox/unknown", "").Code)
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/admin/email-outbox", nil))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
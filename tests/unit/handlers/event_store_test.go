@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/messaging"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI_EventLog(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	now := time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)
+	eventStore := application.NewEventStore(repository.NewEventStoreRepository(storage.Collection(repository.EventStoreCollection))).
+		WithClock(func() time.Time { return now })
+	bus := &unavailablePublisher{MemoryPublisher: messaging.NewMemoryPublisher(), down: true}
+	deadLetters := application.NewDeadLetterPublisher(bus, repository.NewFailedMessageRepository(storage.Collection(repository.FailedMessageCollection)))
+	publisher := eventStore.Publisher(deadLetters)
+
+	handler := httpserver.NewServerWithServices(httpserver.Services{
+		Billing: application.NewBillingService(repository.NewClientRepository(storage)),
+		Events:  eventStore,
+	}, httpserver.ServerOptions{
+		AdminTokens: map[string]string{"ops": "admin-token"},
+	}).Handler()
+
+	publish := func(topic, key string) {
+		t.Helper()
+		require.NoError(t, publisher.Publish(context.Background(), messaging.Message{
+			Topic:   topic,
+			Key:     key,
+			Payload: json.RawMessage(`{"id":"` + key + `"}`),
+		}))
+		now = now.Add(time.Hour)
+	}
+	publish(application.RecurringInvoiceIssuedTopic, "template-1")
+	publish(application.PaymentReceiptTopic, "payment-1")
+	publish(application.RecurringInvoiceIssuedTopic, "template-2")
+	publish(application.RecurringInvoiceIssuedTopic, "template-1")
+
+	type eventLog struct {
+		Data struct {
+			Events []struct {
+				Sequence    int64           `json:"sequence"`
+				Type        string          `json:"type"`
+				AggregateID string          `json:"aggregate_id"`
+				Payload     json.RawMessage `json:"payload"`
+				OccurredAt  time.Time       `json:"occurred_at"`
+			} `json:"events"`
+			NextCursor string `json:"next_cursor"`
+			HasMore    bool   `json:"has_more"`
+		} `json:"data"`
+	}
+	list := func(query string) eventLog {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/events"+query, nil)
+		req.Header.Set("Authorization", "Bearer admin-token")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var response eventLog
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		return response
+	}
+
+	t.Run("events are stored even when the message bus is down", func(t *testing.T) {
+		assert.Empty(t, bus.Messages())
+
+		log := list("")
+		require.Len(t, log.Data.Events, 4)
+		assert.Equal(t, application.RecurringInvoiceIssuedTopic, log.Data.Events[0].Type)
+		assert.Equal(t, "template-1", log.Data.Events[0].AggregateID)
+		assert.JSONEq(t, `{"id":"template-1"}`, string(log.Data.Events[0].Payload))
+		assert.False(t, log.Data.HasMore)
+	})
+
+	t.Run("pages through the log with the cursor", func(t *testing.T) {
+		first := list("?limit=3")
+		require.Len(t, first.Data.Events, 3)
+		assert.True(t, first.Data.HasMore)
+
+		second := list("?limit=3&since=" + first.Data.NextCursor)
+		require.Len(t, second.Data.Events, 1)
+		assert.False(t, second.Data.HasMore)
+		assert.Greater(t, second.Data.Events[0].Sequence, first.Data.Events[2].Sequence)
+	})
+
+	t.Run("filters by type, aggregate and time", func(t *testing.T) {
+		assert.Len(t, list("?type="+application.RecurringInvoiceIssuedTopic).Data.Events, 3)
+		assert.Len(t, list("?type="+application.RecurringInvoiceIssuedTopic+"&aggregate_id=template-1").Data.Events, 2)
+		assert.Len(t, list("?since=2026-03-02T10:30:00Z").Data.Events, 2)
+	})
+
+	t.Run("replays the events of the given types in sequence order", func(t *testing.T) {
+		var aggregates []string
+		var reported []int
+		replayed, err := eventStore.Replay([]string{application.RecurringInvoiceIssuedTopic}, func(event *entity.StoredEvent) error {
+			aggregates = append(aggregates, event.AggregateID())
+			return nil
+		}, func(replayed int) { reported = append(reported, replayed) })
+		require.NoError(t, err)
+		assert.Equal(t, 3, replayed)
+		assert.Equal(t, []string{"template-1", "template-2", "template-1"}, aggregates)
+		assert.Equal(t, []int{3}, reported)
+	})
+
+	t.Run("retrying a dead letter does not store the event again", func(t *testing.T) {
+		letters, err := deadLetters.DeadLetters()
+		require.NoError(t, err)
+		require.NotEmpty(t, letters)
+
+		bus.down = false
+		require.NoError(t, deadLetters.RetryDeadLetter(context.Background(), letters[0].ID, now))
+		assert.Len(t, bus.Messages(), 1)
+		assert.Len(t, list("").Data.Events, 4)
+	})
+
+	t.Run("rejects invalid queries and missing admin credentials", func(t *testing.T) {
+		for _, query := range []string{"?since=yesterday", "?limit=0", "?limit=1001"} {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/events"+query, nil)
+			req.Header.Set("Authorization", "Bearer admin-token")
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+			assert.Equal(t, http.StatusBadRequest, rr.Code, query)
+		}
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/events", nil))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
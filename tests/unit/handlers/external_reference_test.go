@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/messaging"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExternalReferenceAPI(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	references := application.NewExternalReferenceService(
+		repository.NewExternalReferenceRepository(storage.Collection(repository.ExternalReferenceCollection)))
+	billingService := application.NewBillingService(repository.NewClientRepository(storage)).WithInvoices(repository.NewInvoiceRepository(storage.Collection(repository.InvoiceCollection))).WithExternalReferences(references)
+	recurringService := application.NewRecurringInvoiceService(
+		repository.NewRecurringInvoiceTemplateRepository(storage.Collection(repository.RecurringInvoiceTemplateCollection)),
+		billingService,
+		messaging.NewMemoryPublisher(),
+	).WithExternalReferences(references)
+	handler := httpserver.NewServerWithServices(httpserver.Services{
+		Billing:   billingService,
+		Recurring: recurringService,
+	}, httpserver.ServerOptions{AdminTokens: map[string]string{"billing": "billing-token"}}).Handler()
+
+	serve := func(method, path, tenantID, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("X-Tenant-ID", tenantID)
+		req.Header.Set("Authorization", "Bearer billing-token")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+	createClient := func(tenantID, name, reference string) *httptest.ResponseRecorder {
+		return serve(http.MethodPost, "/api/v1/clients", tenantID,
+			fmt.Sprintf(`{"name":%q,"email":"billing@%s.example","external_ref":%q}`, name, strings.ToLower(name), reference))
+	}
+
+	type clientEnvelope struct {
+		Data struct {
+			ID          string `json:"id"`
+			ExternalRef string `json:"external_ref"`
+		} `json:"data"`
+	}
+	type conflictResponse struct {
+		Error struct {
+			Code    string `json:"code"`
+			Details struct {
+				Match       string `json:"match"`
+				ExistingID  string `json:"existing_id"`
+				ExistingURL string `json:"existing_url"`
+			} `json:"details"`
+		} `json:"error"`
+	}
+
+	rr := createClient("acme", "Initech", "CRM-42")
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	var created clientEnvelope
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &created))
+	assert.Equal(t, "CRM-42", created.Data.ExternalRef)
+
+	t.Run("rejects a client with a reference taken in the tenant", func(t *testing.T) {
+		rr := createClient("acme", "Hooli", " CRM-42 ")
+		require.Equal(t, http.StatusConflict, rr.Code, rr.Body.String())
+
+		var response conflictResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, "BUSINESS_RULE_DUPLICATE", response.Error.Code)
+		assert.Equal(t, "external_ref", response.Error.Details.Match)
+		assert.Equal(t, created.Data.ID, response.Error.Details.ExistingID)
+		assert.Equal(t, "/api/v1/clients/"+created.Data.ID, response.Error.Details.ExistingURL)
+	})
+
+	t.Run("references are unique per tenant only", func(t *testing.T) {
+		rr := createClient("globex", "Hooli", "CRM-42")
+		assert.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	})
+
+	t.Run("looks clients up by reference in the tenant", func(t *testing.T) {
+		rr := serve(http.MethodGet, "/api/v1/clients/by-external-ref/CRM-42", "acme", "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var found clientEnvelope
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &found))
+		assert.Equal(t, created.Data.ID, found.Data.ID)
+
+		rr = serve(http.MethodGet, "/api/v1/clients/by-external-ref/CRM-43", "acme", "")
+		assert.Equal(t, http.StatusNotFound, rr.Code, rr.Body.String())
+		rr = serve(http.MethodGet, "/api/v1/clients/by-external-ref/CRM-42", "umbrella", "")
+		assert.Equal(t, http.StatusNotFound, rr.Code, rr.Body.String())
+	})
+
+	t.Run("invoice references are unique per tenant across clients", func(t *testing.T) {
+		createTemplate := func(clientID string) *httptest.ResponseRecorder {
+			return serve(http.MethodPost, "/api/v1/recurring-invoices", "acme", fmt.Sprintf(
+				`{"client_id":%q,"name":"Support retainer","currency":"EUR","frequency":"monthly","first_issue_date":%q,"external_ref":"PO-7","line_items":[{"description":"Retainer","quantity":1,"unit_amount":150000}]}`,
+				clientID, time.Now().UTC().AddDate(0, 1, 0).Format(time.RFC3339)))
+		}
+		rr := createClient("acme", "Vandelay", "")
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		var other clientEnvelope
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &other))
+
+		rr = createTemplate(created.Data.ID)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		var template clientEnvelope
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &template))
+
+		rr = createTemplate(other.Data.ID)
+		require.Equal(t, http.StatusConflict, rr.Code, rr.Body.String())
+		var response conflictResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, "/api/v1/recurring-invoices/"+template.Data.ID, response.Error.Details.ExistingURL)
+	})
+
+	t.Run("deleting a client frees its reference", func(t *testing.T) {
+		rr := serve(http.MethodDelete, "/api/v1/clients/"+created.Data.ID, "acme", "")
+		require.Less(t, rr.Code, 300, rr.Body.String())
+
+		rr = createClient("acme", "Piedpiper", "CRM-42")
+		assert.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	})
+}
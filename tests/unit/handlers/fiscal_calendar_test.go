@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/messaging"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminAPI_FiscalCalendar(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage)).WithInvoices(repository.NewInvoiceRepository(storage.Collection(repository.InvoiceCollection)))
+	auditService := application.NewAuditService(repository.NewAuditRepository(storage.Collection(repository.AuditCollection)))
+	fiscalService := application.NewFiscalCalendarService(
+		repository.NewFiscalCalendarRepository(storage.Collection(repository.FiscalCalendarCollection)),
+		auditService,
+	)
+	billingService.WithFiscalCalendars(fiscalService)
+	publisher := messaging.NewMemoryPublisher()
+	handler := httpserver.NewServerWithServices(httpserver.Services{
+		Billing:         billingService,
+		Audit:           auditService,
+		FiscalCalendars: fiscalService,
+		Recurring: application.NewRecurringInvoiceService(
+			repository.NewRecurringInvoiceTemplateRepository(storage.Collection(repository.RecurringInvoiceTemplateCollection)),
+			billingService,
+			publisher,
+		),
+	}, httpserver.ServerOptions{
+		AdminTokens: map[string]string{"ops": "admin-token"},
+	}).Handler()
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newAdminRequest(method, path, body, "192.0.2.10:1234"))
+		return rr
+	}
+
+	type periodResponse struct {
+		Period     string `json:"period"`
+		FiscalYear int    `json:"fiscal_year"`
+		Status     string `json:"status"`
+	}
+	type calendarResponse struct {
+		Data struct {
+			StartMonth    int      `json:"start_month"`
+			PeriodMonths  []int    `json:"period_months"`
+			ClosedPeriods []string `json:"closed_periods"`
+			Default       bool     `json:"default"`
+		} `json:"data"`
+	}
+
+	t.Run("tenants without a calendar report calendar years split into months", func(t *testing.T) {
+		rr := serve(http.MethodGet, "/api/v1/admin/fiscal-calendars/acme", "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var response calendarResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.True(t, response.Data.Default)
+		assert.Equal(t, 1, response.Data.StartMonth)
+		assert.Len(t, response.Data.PeriodMonths, 12)
+	})
+
+	t.Run("invalid periods are rejected", func(t *testing.T) {
+		rr := serve(http.MethodPut, "/api/v1/admin/fiscal-calendars/acme", `{"start_month":4,"period_months":[4,4,5]}`)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "period_months")
+	})
+
+	t.Run("lists the quarters of a fiscal year and locks them", func(t *testing.T) {
+		rr := serve(http.MethodPut, "/api/v1/admin/fiscal-calendars/acme", `{"start_month":4,"period_months":[3,3,3,3]}`)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		rr = serve(http.MethodPost, "/api/v1/admin/fiscal-calendars/acme/periods/2027-P01/close", "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		rr = serve(http.MethodGet, "/api/v1/admin/fiscal-calendars/acme/periods?year=2027", "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var response struct {
+			Data struct {
+				FiscalYear int              `json:"fiscal_year"`
+				Periods    []periodResponse `json:"periods"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, 2027, response.Data.FiscalYear)
+		require.Len(t, response.Data.Periods, 4)
+		assert.Equal(t, "closed", response.Data.Periods[0].Status)
+		assert.Equal(t, "open", response.Data.Periods[1].Status)
+		assert.Contains(t, rr.Body.String(), `"start_date":"2026-04-01T00:00:00Z"`)
+
+		rr = serve(http.MethodPost, "/api/v1/admin/fiscal-calendars/acme/periods/2027-P01/close", "")
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+
+		rr = serve(http.MethodGet, "/api/v1/admin/fiscal-calendars/acme/periods?year=next", "")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("periods cannot change while a period is closed", func(t *testing.T) {
+		rr := serve(http.MethodPut, "/api/v1/admin/fiscal-calendars/acme", `{"start_month":1}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+
+		rr = serve(http.MethodDelete, "/api/v1/admin/fiscal-calendars/acme", "")
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+
+		rr = serve(http.MethodGet, "/api/v1/admin/fiscal-calendars", "")
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"closed_periods":["2027-P01"]`)
+	})
+
+	t.Run("holds recurring invoices dated in a closed period", func(t *testing.T) {
+		client, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Globex", Email: "billing@globex.example"})
+		require.NoError(t, err)
+
+		now := time.Now().UTC()
+		firstIssue := now.AddDate(0, 0, -1).Truncate(time.Second)
+		rr := serve(http.MethodPost, "/api/v1/recurring-invoices", fmt.Sprintf(`{"client_id":%q,"name":"Hosting","currency":"EUR",
+			"frequency":"monthly","first_issue_date":%q,"line_items":[{"description":"Hosting","quantity":1,"unit_amount":5000}]}`,
+			client.ID(), firstIssue.Format(time.RFC3339)))
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), `"tenant_id":"acme"`)
+
+		period, err := fiscalService.PeriodOf("acme", firstIssue)
+		require.NoError(t, err)
+		if !period.Closed {
+			_, err = fiscalService.ClosePeriod("ops", "acme", period.Key())
+			require.NoError(t, err)
+		}
+
+		rr = serve(http.MethodPost, "/api/v1/admin/recurring-invoices/run", "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), `"decision":"period_closed"`)
+		assert.Contains(t, rr.Body.String(), fmt.Sprintf(`"fiscal_period":%q`, period.Key()))
+		assert.Empty(t, publisher.Messages())
+
+		_, err = fiscalService.ReopenPeriod("ops", "acme", period.Key())
+		require.NoError(t, err)
+
+		rr = serve(http.MethodPost, "/api/v1/admin/recurring-invoices/run", "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), `"issued":1`)
+		require.Len(t, publisher.Messages(), 1)
+		assert.Contains(t, string(publisher.Messages()[0].Payload), fmt.Sprintf(`"fiscal_period":%q`, period.Key()))
+	})
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/handlers"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_FormTokens_PreventDuplicateSubmission(t *testing.T) {
+	// Arrange
+	storage := infrastructure.NewInMemoryStorage()
+	server := httpserver.NewServerWithServices(httpserver.Services{
+		Billing:    application.NewBillingService(repository.NewClientRepository(storage)),
+		FormTokens: application.NewFormTokenService(repository.NewFormTokenRepository(storage.Collection(repository.FormTokenCollection)), 0),
+	}, adminOptions())
+	handler := server.Handler()
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, asAdmin(httptest.NewRequest(http.MethodGet, "/api/v1/clients/new-token", nil)))
+	require.Equal(t, http.StatusCreated, rr.Code)
+	assert.Equal(t, "no-store", rr.Header().Get("Cache-Control"))
+
+	var issued struct {
+		Data struct {
+			Token string `json:"token"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &issued))
+	require.NotEmpty(t, issued.Data.Token)
+
+	submit := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/clients", strings.NewReader(body))
+		req.Header.Set(handlers.FormTokenHeader, issued.Data.Token)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, asAdmin(req))
+		return rr
+	}
+
+	// Act & Assert: a rejected submission does not burn the token
+	rr = submit(`{"name":"John Doe","email":"not-an-email"}`)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	rr = submit(`{"name":"John Doe","email":"john@example.com"}`)
+	assert.Equal(t, http.StatusCreated, rr.Code)
+
+	// A double click re-sends the same token
+	rr = submit(`{"name":"John Doe","email":"john.second@example.com"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	assert.Contains(t, rr.Body.String(), "BUSINESS_RULE_CONFLICT")
+}
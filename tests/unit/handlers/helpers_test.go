@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"net/http"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/middleware"
+)
+
+// adminToken authenticates the admin of the API tests
+// Clients, invoices and subscriptions created without a tenant are only visible to admins
+const adminToken = "admin-token"
+
+// adminOptions configures a test server accepting adminToken
+func adminOptions() httpserver.ServerOptions {
+	return httpserver.ServerOptions{AdminTokens: map[string]string{"ops": adminToken}}
+}
+
+// asAdmin sends req through the server as the admin holding adminToken
+func asAdmin(req *http.Request) *http.Request {
+	req.Header.Set("Authorization", "Bearer "+adminToken)
+	return req
+}
+
+// asHandlerAdmin sends req straight to a handler as an authenticated admin
+func asHandlerAdmin(req *http.Request) *http.Request {
+	return req.WithContext(middleware.WithAdminActor(req.Context(), "ops"))
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/di"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/httpclient"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI_IntegrationLogs(t *testing.T) {
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/refunds" {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"id":"ch_1","status":"succeeded","billing_details":{"email":"jane@example.com"}}`))
+	}))
+	defer gateway.Close()
+
+	storage := infrastructure.NewInMemoryStorage()
+	now := time.Now()
+	logService := application.NewIntegrationLogService(
+		repository.NewIntegrationLogRepository(storage.Collection(repository.IntegrationLogCollection)),
+		time.Hour,
+	).WithClock(func() time.Time { return now })
+	clients := di.OutboundClientsProvider(logService, &di.ContainerConfig{
+		OutboundHTTP: httpclient.Config{MaxAttempts: 1},
+	})
+
+	handler := httpserver.NewServerWithServices(httpserver.Services{
+		Billing:         application.NewBillingService(repository.NewClientRepository(storage)),
+		IntegrationLogs: logService,
+	}, httpserver.ServerOptions{
+		AdminTokens: map[string]string{"ops": "admin-token"},
+	}).Handler()
+
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", "Bearer admin-token")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	client := clients.Client(httpclient.DestinationPaymentGateway)
+	resp, err := client.Post(gateway.URL+"/charges?api_key=k1", "application/json", strings.NewReader(`{"amount":1200,"card_number":"4242424242424242"}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+	now = now.Add(time.Minute)
+	resp, err = client.Post(gateway.URL+"/refunds", "application/json", strings.NewReader(`{"charge":"ch_1"}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	t.Run("lists redacted calls most recent first", func(t *testing.T) {
+		rr := serve(http.MethodGet, "/api/v1/admin/integration-logs?destination=payment_gateway")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var body struct {
+			Data []struct {
+				ID           string `json:"id"`
+				Method       string `json:"method"`
+				URL          string `json:"url"`
+				StatusCode   int    `json:"status_code"`
+				RequestBody  string `json:"request_body"`
+				ResponseBody string `json:"response_body"`
+				Failed       bool   `json:"failed"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		require.Len(t, body.Data, 2)
+
+		assert.Equal(t, http.StatusBadGateway, body.Data[0].StatusCode)
+		assert.True(t, body.Data[0].Failed)
+
+		charge := body.Data[1]
+		assert.Equal(t, http.MethodPost, charge.Method)
+		assert.Equal(t, gateway.URL+"/charges?api_key=%5BREDACTED%5D", charge.URL)
+		assert.JSONEq(t, `{"amount":1200,"card_number":"[REDACTED]"}`, charge.RequestBody)
+		assert.JSONEq(t, `{"id":"ch_1","status":"succeeded","billing_details":{"email":"[REDACTED]"}}`, charge.ResponseBody)
+		assert.False(t, charge.Failed)
+
+		rr = serve(http.MethodGet, "/api/v1/admin/integration-logs/"+charge.ID)
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("filters failed calls", func(t *testing.T) {
+		rr := serve(http.MethodGet, "/api/v1/admin/integration-logs?failed=true")
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, 1, strings.Count(rr.Body.String(), `"id"`))
+
+		assert.Equal(t, http.StatusBadRequest, serve(http.MethodGet, "/api/v1/admin/integration-logs?failed=maybe").Code)
+		assert.Equal(t, http.StatusBadRequest, serve(http.MethodGet, "/api/v1/admin/integration-logs?since=yesterday").Code)
+	})
+
+	t.Run("returns 404 for unknown logs", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, serve(http.MethodGet, "/api/v1/admin/integration-logs/missing").Code)
+	})
+
+	t.Run("cleanup deletes logs older than the retention", func(t *testing.T) {
+		now = now.Add(time.Hour - time.Second)
+
+		rr := serve(http.MethodPost, "/api/v1/admin/integration-logs/cleanup")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.JSONEq(t, `{"data":{"removed":1},"success":true}`, rr.Body.String())
+	})
+}
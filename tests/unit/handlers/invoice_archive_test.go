@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI_InvoiceArchive(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	invoiceRepo := repository.NewInvoiceRepository(storage.Collection(repository.InvoiceCollection))
+	paymentRepo := repository.NewPaymentRepository(storage.Collection(repository.PaymentCollection))
+	archiveRepo := repository.NewInvoiceArchiveRepository(storage.Collection(repository.InvoiceArchiveCollection))
+
+	handler := httpserver.NewServerWithServices(httpserver.Services{
+		InvoiceArchive: application.NewInvoiceArchiveService(invoiceRepo, paymentRepo, archiveRepo, 2, 0),
+	}, httpserver.ServerOptions{
+		AdminTokens: map[string]string{"ops": "admin-token"},
+	}).Handler()
+
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", "Bearer admin-token")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	// newInvoice saves an invoice of 100.00 issued at issuedAt, paid in full with one payment when paid is set
+	newInvoice := func(issuedAt time.Time, paid bool) *entity.Invoice {
+		invoice, err := entity.NewInvoice("client-1", "EUR", []entity.InvoiceLine{{Description: "Consulting", Quantity: 1, UnitAmount: 10000}}, nil)
+		require.NoError(t, err)
+		require.NoError(t, invoice.Issue(issuedAt))
+		if paid {
+			amount, err := valueobject.NewMoney(10000, "EUR")
+			require.NoError(t, err)
+			payment, err := entity.NewPayment(invoice.ID(), amount, entity.PaymentBankTransfer, "wire-1", issuedAt.AddDate(0, 1, 0))
+			require.NoError(t, err)
+			require.NoError(t, invoice.ApplyPayment(amount, issuedAt.AddDate(0, 1, 0)))
+			require.NoError(t, paymentRepo.Save(payment))
+		}
+		require.NoError(t, invoiceRepo.Save(invoice))
+		return invoice
+	}
+
+	old := newInvoice(time.Now().AddDate(-3, 0, 0), true)
+	recent := newInvoice(time.Now().AddDate(0, -6, 0), true)
+	unpaid := newInvoice(time.Now().AddDate(-3, 0, 0), false)
+
+	t.Run("run archives old closed invoices only", func(t *testing.T) {
+		rr := serve(http.MethodPost, "/api/v1/admin/invoice-archive/run")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.JSONEq(t, `{"data":{"archived":1},"success":true}`, rr.Body.String())
+
+		_, err := invoiceRepo.GetByID(old.ID())
+		assert.Error(t, err)
+		payments, err := paymentRepo.ListByInvoice(old.ID())
+		require.NoError(t, err)
+		assert.Empty(t, payments)
+
+		_, err = invoiceRepo.GetByID(recent.ID())
+		assert.NoError(t, err)
+		_, err = invoiceRepo.GetByID(unpaid.ID())
+		assert.NoError(t, err)
+	})
+
+	t.Run("get archived invoice", func(t *testing.T) {
+		rr := serve(http.MethodGet, "/api/v1/admin/invoice-archive/"+old.ID())
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var body struct {
+			Data struct {
+				Invoice struct {
+					ID     string `json:"id"`
+					Status string `json:"status"`
+				} `json:"invoice"`
+				Payments []struct {
+					Reference string `json:"reference"`
+				} `json:"payments"`
+				ArchivedAt time.Time `json:"archived_at"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		assert.Equal(t, old.ID(), body.Data.Invoice.ID)
+		assert.Equal(t, "paid", body.Data.Invoice.Status)
+		require.Len(t, body.Data.Payments, 1)
+		assert.Equal(t, "wire-1", body.Data.Payments[0].Reference)
+		assert.False(t, body.Data.ArchivedAt.IsZero())
+	})
+
+	t.Run("rehydrate restores the invoice and its payments", func(t *testing.T) {
+		rr := serve(http.MethodPost, "/api/v1/admin/invoice-archive/"+old.ID()+"/rehydrate")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		invoice, err := invoiceRepo.GetByID(old.ID())
+		require.NoError(t, err)
+		assert.Equal(t, entity.InvoicePaid, invoice.Status())
+		assert.NotNil(t, invoice.RehydratedAt())
+		payments, err := paymentRepo.ListByInvoice(old.ID())
+		require.NoError(t, err)
+		assert.Len(t, payments, 1)
+
+		assert.Equal(t, http.StatusNotFound, serve(http.MethodGet, "/api/v1/admin/invoice-archive/"+old.ID()).Code)
+	})
+
+	t.Run("rehydrated invoices are held before being archived again", func(t *testing.T) {
+		rr := serve(http.MethodPost, "/api/v1/admin/invoice-archive/run")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.JSONEq(t, `{"data":{"archived":0},"success":true}`, rr.Body.String())
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, serve(http.MethodGet, "/api/v1/admin/invoice-archive/"+recent.ID()).Code)
+		assert.Equal(t, http.StatusNotFound, serve(http.MethodPost, "/api/v1/admin/invoice-archive/unknown/rehydrate").Code)
+		assert.Equal(t, http.StatusMethodNotAllowed, serve(http.MethodGet, "/api/v1/admin/invoice-archive/run").Code)
+		assert.Equal(t, http.StatusMethodNotAllowed, serve(http.MethodDelete, "/api/v1/admin/invoice-archive/"+old.ID()).Code)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/invoice-archive/run", nil)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/di"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/messaging"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecurringInvoiceComplianceAPI(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage)).WithInvoices(repository.NewInvoiceRepository(storage.Collection(repository.InvoiceCollection)))
+	auditService := application.NewAuditService(repository.NewAuditRepository(storage.Collection(repository.AuditCollection)))
+	legalEntityService := application.NewLegalEntityService(
+		repository.NewLegalEntityRepository(storage.Collection(repository.LegalEntityCollection)),
+		auditService,
+	)
+	rules := di.ComplianceRulesProvider(&di.ContainerConfig{ComplianceEnabled: true})
+	billingService.WithLegalEntities(legalEntityService).WithComplianceRules(rules)
+	publisher := messaging.NewMemoryPublisher()
+	handler := httpserver.NewServerWithServices(httpserver.Services{
+		Billing: billingService,
+		Recurring: application.NewRecurringInvoiceService(
+			repository.NewRecurringInvoiceTemplateRepository(storage.Collection(repository.RecurringInvoiceTemplateCollection)),
+			billingService,
+			publisher,
+		).WithLegalEntities(legalEntityService),
+	}, httpserver.ServerOptions{
+		AdminTokens: map[string]string{"scheduler": "admin-token"},
+	}).Handler()
+
+	_, err := legalEntityService.CreateEntity("ops", dtos.LegalEntityRequest{
+		Name:             "Acme SAS",
+		Country:          "FR",
+		TaxRegistrations: []dtos.TaxRegistrationRequest{{Country: "FR", Number: "FR40303265045"}},
+		Numbering:        dtos.InvoiceNumberingRequest{Prefix: "FR-"},
+	})
+	require.NoError(t, err)
+	client, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Globex GmbH", Email: "billing@globex.example"})
+	require.NoError(t, err)
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer admin-token")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	firstIssue := time.Now().UTC().AddDate(0, 0, -1).Truncate(time.Second)
+	rr := serve(http.MethodPost, "/api/v1/recurring-invoices", fmt.Sprintf(`{"client_id":%q,"name":"Hosting","currency":"EUR",
+		"frequency":"monthly","first_issue_date":%q,"buyer_country":"de",
+		"line_items":[{"description":"Hosting","quantity":1,"unit_amount":50000}]}`, client.ID(), firstIssue.Format(time.RFC3339)))
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	var created struct {
+		Data struct {
+			ID           string `json:"id"`
+			BuyerCountry string `json:"buyer_country"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &created))
+	assert.Equal(t, "DE", created.Data.BuyerCountry)
+
+	t.Run("holds invoices missing a field their countries require", func(t *testing.T) {
+		rr := serve(http.MethodPost, "/api/v1/admin/recurring-invoices/run", "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var response struct {
+			Data dtos.RecurringInvoiceRunResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, 0, response.Data.Issued)
+		require.Len(t, response.Data.HeldInvoices, 1)
+		hold := response.Data.HeldInvoices[0]
+		assert.Equal(t, dtos.NonCompliantHold, hold.Decision)
+		require.Len(t, hold.Violations, 1)
+		assert.Equal(t, "eu_reverse_charge", hold.Violations[0].Rule)
+		assert.Equal(t, "buyer_tax_id", hold.Violations[0].Field)
+		assert.Empty(t, publisher.Messages())
+	})
+
+	t.Run("issues compliant invoices with their legal mentions", func(t *testing.T) {
+		rr := serve(http.MethodPut, "/api/v1/recurring-invoices/"+created.Data.ID, `{"name":"Hosting","frequency":"monthly",
+			"buyer_country":"DE","buyer_tax_id":"DE136695976","line_items":[{"description":"Hosting","quantity":1,"unit_amount":50000}]}`)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		rr = serve(http.MethodPost, "/api/v1/admin/recurring-invoices/run", "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), `"issued":1`)
+
+		messages := publisher.Messages()
+		require.Len(t, messages, 1)
+		var event application.RecurringInvoiceIssuedEvent
+		require.NoError(t, json.Unmarshal(messages[0].Payload, &event))
+		assert.Equal(t, "FR-000001", event.InvoiceNumber)
+		assert.Equal(t, "DE136695976", event.BuyerTaxID)
+		assert.Equal(t, "per_rate", event.TaxBreakdown)
+		require.Len(t, event.LegalMentions, 3)
+		assert.Contains(t, event.LegalMentions[0], "Reverse charge")
+	})
+
+	t.Run("rejects an invalid buyer country", func(t *testing.T) {
+		rr := serve(http.MethodPost, "/api/v1/recurring-invoices", fmt.Sprintf(`{"client_id":%q,"name":"Hosting","currency":"EUR",
+			"frequency":"monthly","first_issue_date":%q,"buyer_country":"Germany",
+			"line_items":[{"description":"Hosting","quantity":1,"unit_amount":50000,"tax_rate_bps":1900}]}`, client.ID(), firstIssue.Format(time.RFC3339)))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "buyer_country")
+	})
+
+	t.Run("previews print the mentions of the seller country", func(t *testing.T) {
+		documents := application.NewDocumentTemplateService(
+			repository.NewDocumentTemplateRepository(storage.Collection(repository.DocumentTemplateCollection)),
+			auditService,
+		).WithLegalEntities(legalEntityService).WithComplianceRules(rules)
+
+		html, err := documents.Preview("acme", nil, valueobject.Locale{}, time.Now())
+		require.NoError(t, err)
+		assert.Contains(t, string(html), "Indemnité forfaitaire pour frais de recouvrement")
+		assert.Contains(t, string(html), `class="tax-breakdown"`)
+	})
+}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI_InvoiceIssueCreditLimit(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage)).
+		WithInvoices(repository.NewInvoiceRepository(storage.Collection(repository.InvoiceCollection)))
+	auditService := application.NewAuditService(repository.NewAuditRepository(storage.Collection(repository.AuditCollection)))
+	approvalService := application.NewApprovalService(
+		repository.NewApprovalRequestRepository(storage.Collection(repository.ApprovalRequestCollection)),
+		auditService,
+		100000,
+		[]string{"alice"},
+	)
+	creditService := application.NewCreditControlService(
+		repository.NewClientCreditLimitRepository(storage.Collection(repository.ClientCreditLimitCollection)),
+		billingService,
+		auditService,
+	).WithOpenInvoices(billingService).WithApprovals(approvalService)
+	billingService.WithCreditControl(creditService)
+
+	handler := httpserver.NewServerWithServices(httpserver.Services{
+		Billing:   billingService,
+		Audit:     auditService,
+		Approvals: approvalService,
+		Credit:    creditService,
+	}, httpserver.ServerOptions{
+		AdminTokens: map[string]string{"ops": "admin-token", "alice": "alice-token"},
+	}).Handler()
+
+	client, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+	require.NoError(t, err)
+
+	serve := func(method, path, body, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+	setLimit := func(t *testing.T, policy string) {
+		t.Helper()
+		rr := serve(http.MethodPut, "/api/v1/clients/"+client.ID()+"/credit", `{"amount":100000,"currency":"EUR","policy":"`+policy+`"}`, "admin-token")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	}
+	createInvoice := func(t *testing.T, unitAmount int64) string {
+		t.Helper()
+		body := fmt.Sprintf(`{"client_id":%q,"currency":"EUR","line_items":[{"description":"Consulting","quantity":1,"unit_amount":%d}]}`, client.ID(), unitAmount)
+		rr := serve(http.MethodPost, "/api/v1/invoices", body, "admin-token")
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		var response struct {
+			Data struct {
+				ID string `json:"id"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		return response.Data.ID
+	}
+	issue := func(invoiceID string) *httptest.ResponseRecorder {
+		return serve(http.MethodPost, "/api/v1/invoices/"+invoiceID+"/issue", "", "admin-token")
+	}
+	decodeError := func(t *testing.T, rr *httptest.ResponseRecorder) dtos.ErrorDetail {
+		t.Helper()
+		require.Equal(t, http.StatusUnprocessableEntity, rr.Code, rr.Body.String())
+		var response dtos.ErrorResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		return response.Error
+	}
+
+	setLimit(t, "block")
+	first := createInvoice(t, 60000)
+
+	t.Run("invoices within the limit are issued", func(t *testing.T) {
+		rr := issue(first)
+		assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	})
+
+	t.Run("invoices taking the outstanding balance over the limit are refused", func(t *testing.T) {
+		second := createInvoice(t, 50000)
+		detail := decodeError(t, issue(second))
+		assert.Equal(t, "BUSINESS_RULE_VIOLATION", detail.Code)
+		assert.Equal(t, "blocked", detail.Details["decision"])
+		assert.NotContains(t, detail.Details, "approval_id")
+
+		rr := serve(http.MethodGet, "/api/v1/invoices/"+second, "", "admin-token")
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"status":"draft"`)
+
+		// Voiding the first invoice clears the outstanding balance
+		rr = serve(http.MethodPost, "/api/v1/invoices/"+first+"/void", "", "admin-token")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		rr = issue(second)
+		assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	})
+
+	t.Run("the warn policy issues invoices over the limit", func(t *testing.T) {
+		setLimit(t, "warn")
+		rr := issue(createInvoice(t, 70000))
+		assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	})
+
+	t.Run("the require_approval policy issues invoices once the override is approved", func(t *testing.T) {
+		setLimit(t, "require_approval")
+		invoiceID := createInvoice(t, 20000)
+
+		detail := decodeError(t, issue(invoiceID))
+		assert.Equal(t, "approval_required", detail.Details["decision"])
+		approvalID, ok := detail.Details["approval_id"].(string)
+		require.True(t, ok, detail.Details)
+
+		// Retrying finds the pending override instead of requesting another one
+		detail = decodeError(t, issue(invoiceID))
+		assert.Equal(t, approvalID, detail.Details["approval_id"])
+
+		rr := serve(http.MethodPost, "/api/v1/approvals/"+approvalID+"/approve", "", "alice-token")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		rr = issue(invoiceID)
+		assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	})
+}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/messaging"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvoiceDeliveryAPI(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	publisher := messaging.NewMemoryPublisher()
+	deliveryService := application.NewInvoiceDeliveryService(
+		repository.NewInvoiceDeliveryEventRepository(storage.Collection(repository.InvoiceDeliveryEventCollection)),
+		publisher,
+		"tracking-secret",
+		nil,
+	)
+	handler := httpserver.NewServerWithServices(httpserver.Services{
+		Billing:  application.NewBillingService(repository.NewClientRepository(storage)),
+		Delivery: deliveryService,
+	}, httpserver.ServerOptions{
+		AdminTokens: map[string]string{"mailer": "admin-token"},
+	}).Handler()
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer admin-token")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	getDelivery := func(t *testing.T, invoiceID string) (status string, viewCount int, events int) {
+		t.Helper()
+		rr := serve(http.MethodGet, "/api/v1/invoices/"+invoiceID+"/delivery-events", "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var response struct {
+			Data struct {
+				Status    string            `json:"status"`
+				ViewCount int               `json:"view_count"`
+				Events    []json.RawMessage `json:"events"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		return response.Data.Status, response.Data.ViewCount, len(response.Data.Events)
+	}
+
+	t.Run("delivery events require admin credentials", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/invoices/inv-1/delivery-events", nil))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("a sent email is tracked until viewed", func(t *testing.T) {
+		rr := serve(http.MethodPost, "/api/v1/invoices/inv-1/delivery-events", `{"type":"sent","recipient":"billing@acme.example"}`)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		var response struct {
+			Data struct {
+				TrackingPixelPath string `json:"tracking_pixel_path"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		require.True(t, strings.HasPrefix(response.Data.TrackingPixelPath, "/api/v1/invoices/inv-1/view.gif?token="))
+
+		status, _, _ := getDelivery(t, "inv-1")
+		assert.Equal(t, "sent", status)
+
+		// The pixel is public: email clients fetch it without credentials
+		pixel := httptest.NewRecorder()
+		handler.ServeHTTP(pixel, httptest.NewRequest(http.MethodGet, response.Data.TrackingPixelPath, nil))
+		require.Equal(t, http.StatusOK, pixel.Code)
+		assert.Equal(t, "image/gif", pixel.Header().Get("Content-Type"))
+		assert.Equal(t, "no-store, max-age=0", pixel.Header().Get("Cache-Control"))
+
+		status, viewCount, events := getDelivery(t, "inv-1")
+		assert.Equal(t, "viewed", status)
+		assert.Equal(t, 1, viewCount)
+		assert.Equal(t, 2, events)
+	})
+
+	t.Run("invalid tokens get the pixel but record nothing", func(t *testing.T) {
+		token := deliveryService.TrackingToken("inv-2", "email", "billing@acme.example")
+
+		for _, path := range []string{
+			"/api/v1/invoices/inv-2/view.gif?token=forged",
+			"/api/v1/invoices/inv-3/view.gif?token=" + token, // Token of another invoice
+		} {
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+			assert.Equal(t, http.StatusOK, rr.Code)
+			assert.Equal(t, "image/gif", rr.Header().Get("Content-Type"))
+		}
+
+		for _, invoiceID := range []string{"inv-2", "inv-3"} {
+			status, _, events := getDelivery(t, invoiceID)
+			assert.Equal(t, "not_sent", status)
+			assert.Zero(t, events)
+		}
+	})
+
+	t.Run("a bounce publishes its follow-up actions", func(t *testing.T) {
+		rr := serve(http.MethodPost, "/api/v1/invoices/inv-4/delivery-events",
+			`{"type":"bounced","bounce_type":"hard","recipient":"gone@acme.example","detail":"550 5.1.1 user unknown"}`)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		actions := make([]string, 0)
+		for _, message := range publisher.Messages() {
+			require.Equal(t, application.DeliveryFollowUpTopic, message.Topic)
+			var followUp application.DeliveryFollowUpEvent
+			require.NoError(t, json.Unmarshal(message.Payload, &followUp))
+			assert.Equal(t, "inv-4", followUp.InvoiceID)
+			assert.Equal(t, "hard", followUp.BounceType)
+			actions = append(actions, followUp.Action)
+		}
+		assert.Equal(t, []string{"flag_client_email", "notify_account_manager"}, actions)
+
+		status, _, _ := getDelivery(t, "inv-4")
+		assert.Equal(t, "bounced", status)
+	})
+
+	t.Run("rejects invalid events", func(t *testing.T) {
+		rr := serve(http.MethodPost, "/api/v1/invoices/inv-5/delivery-events", `{"type":"bounced"}`)
+		assert.Equal(t, http.StatusBadRequest, rr.Code, rr.Body.String())
+	})
+}
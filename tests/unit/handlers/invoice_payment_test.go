@@ -0,0 +1,322 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/messaging"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubCardCharger charges every card and records charges and refunds
+type stubCardCharger struct {
+	charges []service.CardCharge
+	refunds []string
+}
+
+func (c *stubCardCharger) Charge(ctx context.Context, charge service.CardCharge) (string, error) {
+	c.charges = append(c.charges, charge)
+	return fmt.Sprintf("ch_%d", len(c.charges)), nil
+}
+
+func (c *stubCardCharger) Refund(ctx context.Context, chargeID, reference string) error {
+	c.refunds = append(c.refunds, chargeID)
+	return nil
+}
+
+// topicOutagePublisher rejects the messages of the topics that are down
+type topicOutagePublisher struct {
+	*messaging.MemoryPublisher
+	down map[string]bool
+}
+
+func (p *topicOutagePublisher) Publish(ctx context.Context, messages ...messaging.Message) error {
+	for _, message := range messages {
+		if p.down[message.Topic] {
+			return fmt.Errorf("topic %s unavailable", message.Topic)
+		}
+	}
+	return p.MemoryPublisher.Publish(ctx, messages...)
+}
+
+func TestAPI_InvoicePaymentSaga(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	bus := &topicOutagePublisher{MemoryPublisher: messaging.NewMemoryPublisher(), down: map[string]bool{}}
+	charger := &stubCardCharger{}
+	auditService := application.NewAuditService(repository.NewAuditRepository(storage.Collection(repository.AuditCollection)))
+	billingService := application.NewBillingService(repository.NewClientRepository(storage)).
+		WithInvoices(repository.NewInvoiceRepository(storage.Collection(repository.InvoiceCollection))).
+		WithPayments(repository.NewPaymentRepository(storage.Collection(repository.PaymentCollection)))
+	// Every unfinished saga counts as stuck right away
+	orchestrator := application.NewSagaOrchestrator(repository.NewSagaRepository(storage.Collection(repository.SagaCollection)), auditService, 2, time.Nanosecond)
+
+	handler := httpserver.NewServerWithServices(httpserver.Services{
+		Billing:         billingService,
+		Audit:           auditService,
+		Sagas:           orchestrator,
+		InvoicePayments: application.NewInvoicePaymentService(orchestrator, billingService, charger, bus),
+	}, httpserver.ServerOptions{
+		AdminTokens: map[string]string{"ops": "admin-token", "support-desk": "support-token"},
+		AdminScopes: map[string][]string{"support-desk": {application.ScopeSupport}},
+	}).Handler()
+
+	serveAs := func(token, method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		return serveAs("admin-token", method, path, body)
+	}
+	type sagaResponse struct {
+		ID          string `json:"id"`
+		Reference   string `json:"reference"`
+		Status      string `json:"status"`
+		CurrentStep string `json:"current_step"`
+		LastError   string `json:"last_error"`
+		Stuck       bool   `json:"stuck"`
+		Steps       []struct {
+			Name     string `json:"name"`
+			Status   string `json:"status"`
+			Attempts int    `json:"attempts"`
+		} `json:"steps"`
+	}
+	decodeSaga := func(rr *httptest.ResponseRecorder) sagaResponse {
+		t.Helper()
+		var response struct {
+			Data sagaResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		return response.Data
+	}
+	topics := func() []string {
+		messages := bus.Messages()
+		names := make([]string, len(messages))
+		for i, message := range messages {
+			names[i] = message.Topic
+		}
+		return names
+	}
+
+	client, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+	require.NoError(t, err)
+	createInvoice := func() *entity.Invoice {
+		t.Helper()
+		invoice, err := billingService.CreateInvoice(application.SystemContext("test", ""), dtos.CreateInvoiceRequest{
+			ClientID:  client.ID(),
+			Currency:  "EUR",
+			LineItems: []dtos.InvoiceLineRequest{{Description: "Consulting", Quantity: 1, UnitAmount: 12000}},
+		})
+		require.NoError(t, err)
+		return invoice
+	}
+	issueInvoice := func() string {
+		t.Helper()
+		invoice, err := billingService.IssueInvoice(application.AdminContext("ops"), createInvoice().ID(), time.Now())
+		require.NoError(t, err)
+		return invoice.ID()
+	}
+	pay := func(invoiceID string) *httptest.ResponseRecorder {
+		return serve(http.MethodPost, "/api/v1/invoices/"+invoiceID+"/payments", `{"payment_method":"pm_card"}`)
+	}
+	invoiceStatus := func(invoiceID string) (entity.InvoiceStatus, []*entity.Payment) {
+		t.Helper()
+		invoice, err := billingService.GetInvoice(application.AdminContext("ops"), invoiceID)
+		require.NoError(t, err)
+		payments, err := billingService.ListPayments(invoiceID)
+		require.NoError(t, err)
+		return invoice.Status(), payments
+	}
+	first, second, third := issueInvoice(), issueInvoice(), issueInvoice()
+
+	t.Run("charges, marks paid, posts the ledger and notifies the client", func(t *testing.T) {
+		// The balance is charged to the invoice client whatever the request says
+		rr := serve(http.MethodPost, "/api/v1/invoices/"+first+"/payments", `{"amount":1,"currency":"USD","payment_method":"pm_card"}`)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		saga := decodeSaga(rr)
+		assert.Equal(t, "completed", saga.Status)
+		assert.Equal(t, first, saga.Reference)
+
+		require.Len(t, charger.charges, 1)
+		assert.Equal(t, saga.ID+"/charge", charger.charges[0].Reference)
+		assert.Equal(t, int64(12000), charger.charges[0].Amount)
+		assert.Equal(t, "EUR", charger.charges[0].Currency)
+		assert.Equal(t, client.ID(), charger.charges[0].ClientID)
+		assert.Equal(t, []string{application.InvoicePaymentTopic, application.LedgerPostingTopic, application.PaymentReceiptTopic}, topics())
+
+		status, payments := invoiceStatus(first)
+		assert.Equal(t, entity.InvoicePaid, status)
+		require.Len(t, payments, 1)
+		assert.Equal(t, entity.PaymentCard, payments[0].Method())
+		assert.Equal(t, "ch_1", payments[0].Reference())
+		assert.Equal(t, int64(12000), payments[0].Amount().Amount())
+
+		var posting application.LedgerPostingEvent
+		require.NoError(t, json.Unmarshal(bus.Messages()[1].Payload, &posting))
+		assert.Equal(t, []application.LedgerPostingLine{
+			{Account: application.LedgerAccountGatewayClearing, Debit: 12000},
+			{Account: application.LedgerAccountAccountsReceivable, Credit: 12000},
+		}, posting.Lines)
+	})
+
+	t.Run("an invoice is paid once", func(t *testing.T) {
+		rr := pay(first)
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), "invoice is not issued")
+		assert.Len(t, charger.charges, 1)
+	})
+
+	t.Run("rejects invalid payments", func(t *testing.T) {
+		rr := pay(createInvoice().ID())
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code, rr.Body.String())
+
+		rr = pay("00000000-0000-4000-8000-000000000000")
+		assert.Equal(t, http.StatusNotFound, rr.Code, rr.Body.String())
+
+		rr = serveAs("support-token", http.MethodPost, "/api/v1/invoices/"+second+"/payments", `{"payment_method":"pm_card"}`)
+		assert.Equal(t, http.StatusForbidden, rr.Code, rr.Body.String())
+
+		rr = serve(http.MethodPut, "/api/v1/invoices/"+second+"/payments", "")
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+		assert.Len(t, charger.charges, 1)
+	})
+
+	t.Run("a failing step leaves the saga stuck until it is compensated", func(t *testing.T) {
+		bus.down[application.InvoicePaymentTopic] = true
+		defer delete(bus.down, application.InvoicePaymentTopic)
+
+		rr := pay(second)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		saga := decodeSaga(rr)
+		assert.Equal(t, "running", saga.Status)
+		assert.Equal(t, "mark_invoice_paid", saga.CurrentStep)
+		assert.Contains(t, saga.LastError, "unavailable")
+
+		// The payment of a failed step is not left on the invoice
+		status, payments := invoiceStatus(second)
+		assert.Equal(t, entity.InvoiceIssued, status)
+		assert.Empty(t, payments)
+
+		rr = pay(second)
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code, rr.Body.String())
+
+		rr = serve(http.MethodGet, "/api/v1/admin/sagas?stuck=true", "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var stuck struct {
+			Data []sagaResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &stuck))
+		require.Len(t, stuck.Data, 1)
+		assert.Equal(t, saga.ID, stuck.Data[0].ID)
+		assert.True(t, stuck.Data[0].Stuck)
+
+		// The second failed attempt refunds the charge
+		rr = serve(http.MethodPost, "/api/v1/admin/sagas/"+saga.ID+"/resume", "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		resumed := decodeSaga(rr)
+		assert.Equal(t, "compensated", resumed.Status)
+		assert.Equal(t, "compensated", resumed.Steps[0].Status)
+		assert.Equal(t, "failed", resumed.Steps[1].Status)
+		assert.Equal(t, 2, resumed.Steps[1].Attempts)
+		assert.Equal(t, []string{"ch_2"}, charger.refunds)
+
+		rr = serve(http.MethodPost, "/api/v1/admin/sagas/"+saga.ID+"/resume", "")
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+
+		rr = serve(http.MethodGet, "/api/v1/admin/audit-log", "")
+		assert.Contains(t, rr.Body.String(), application.AuditActionSagaResumed)
+	})
+
+	t.Run("a compensated payment can be paid again", func(t *testing.T) {
+		rr := pay(second)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		assert.Equal(t, "completed", decodeSaga(rr).Status)
+
+		status, payments := invoiceStatus(second)
+		assert.Equal(t, entity.InvoicePaid, status)
+		assert.Len(t, payments, 1)
+	})
+
+	t.Run("a step failing after the payment is recorded reverses it", func(t *testing.T) {
+		bus.down[application.LedgerPostingTopic] = true
+		defer delete(bus.down, application.LedgerPostingTopic)
+
+		rr := pay(third)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		saga := decodeSaga(rr)
+		assert.Equal(t, "post_ledger_entries", saga.CurrentStep)
+		status, _ := invoiceStatus(third)
+		assert.Equal(t, entity.InvoicePaid, status)
+
+		rr = serve(http.MethodPost, "/api/v1/admin/sagas/"+saga.ID+"/resume", "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Equal(t, "compensated", decodeSaga(rr).Status)
+
+		status, payments := invoiceStatus(third)
+		assert.Equal(t, entity.InvoiceIssued, status)
+		assert.Empty(t, payments)
+		assert.Equal(t, []string{"ch_2", "ch_4"}, charger.refunds)
+	})
+
+	t.Run("a failing notification is retried without refunding the payment", func(t *testing.T) {
+		bus.down[application.PaymentReceiptTopic] = true
+
+		rr := pay(third)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		saga := decodeSaga(rr)
+		assert.Equal(t, "notify_client", saga.CurrentStep)
+
+		run := func() int {
+			rr := serve(http.MethodPost, "/api/v1/admin/sagas/run", "")
+			require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+			var response struct {
+				Data struct {
+					Resumed int `json:"resumed"`
+				} `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+			return response.Data.Resumed
+		}
+		for i := 0; i < 3; i++ {
+			assert.Equal(t, 1, run())
+		}
+		assert.Equal(t, "running", decodeSaga(serve(http.MethodGet, "/api/v1/admin/sagas/"+saga.ID, "")).Status)
+
+		delete(bus.down, application.PaymentReceiptTopic)
+		assert.Equal(t, 1, run())
+		assert.Equal(t, "completed", decodeSaga(serve(http.MethodGet, "/api/v1/admin/sagas/"+saga.ID, "")).Status)
+		assert.Equal(t, []string{"ch_2", "ch_4"}, charger.refunds)
+		assert.Equal(t, 0, run())
+	})
+
+	t.Run("lists sagas by status", func(t *testing.T) {
+		rr := serve(http.MethodGet, "/api/v1/admin/sagas?status=compensated", "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var response struct {
+			Data []sagaResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		require.Len(t, response.Data, 2)
+		assert.ElementsMatch(t, []string{second, third}, []string{response.Data[0].Reference, response.Data[1].Reference})
+
+		assert.Equal(t, http.StatusBadRequest, serve(http.MethodGet, "/api/v1/admin/sagas?status=stuck", "").Code)
+		assert.Equal(t, http.StatusNotFound, serve(http.MethodGet, "/api/v1/admin/sagas/unknown", "").Code)
+	})
+}
@@ -0,0 +1,438 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvoiceAPI(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage)).
+		WithInvoices(repository.NewInvoiceRepository(storage.Collection(repository.InvoiceCollection)))
+	handler := httpserver.NewServerWithServices(httpserver.Services{Billing: billingService}, adminOptions()).Handler()
+
+	client, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+	require.NoError(t, err)
+
+	type invoiceResponse struct {
+		Data struct {
+			ID        string `json:"id"`
+			Status    string `json:"status"`
+			LineItems []struct {
+				Amount struct {
+					Amount int64 `json:"amount"`
+				} `json:"amount"`
+			} `json:"line_items"`
+			Total struct {
+				Amount   int64  `json:"amount"`
+				Currency string `json:"currency"`
+			} `json:"total"`
+			IssuedAt *string `json:"issued_at"`
+		} `json:"data"`
+	}
+
+	send := func(method, path, body string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, asAdmin(httptest.NewRequest(method, path, strings.NewReader(body))))
+		return rr
+	}
+	create := func() invoiceResponse {
+		rr := send(http.MethodPost, "/api/v1/invoices", fmt.Sprintf(`{"client_id":%q,"currency":"EUR","line_items":[{"description":"Consulting","quantity":3,"unit_amount":12000},{"description":"Travel","quantity":1,"unit_amount":4500}]}`, client.ID()))
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		var response invoiceResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		return response
+	}
+
+	t.Run("creates a draft with line amounts and total", func(t *testing.T) {
+		created := create()
+		assert.Equal(t, "draft", created.Data.Status)
+		require.Len(t, created.Data.LineItems, 2)
+		assert.Equal(t, int64(36000), created.Data.LineItems[0].Amount.Amount)
+		assert.Equal(t, int64(40500), created.Data.Total.Amount)
+		assert.Equal(t, "EUR", created.Data.Total.Currency)
+
+		rr := send(http.MethodGet, "/api/v1/invoices/"+created.Data.ID, "")
+		assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	})
+
+	t.Run("rejects invalid line items and unknown clients", func(t *testing.T) {
+		rr := send(http.MethodPost, "/api/v1/invoices", fmt.Sprintf(`{"client_id":%q,"currency":"EUR","line_items":[{"description":"Consulting","quantity":0,"unit_amount":12000}]}`, client.ID()))
+		assert.Equal(t, http.StatusBadRequest, rr.Code, rr.Body.String())
+
+		rr = send(http.MethodPost, "/api/v1/invoices", `{"client_id":"7f0c6a52-9a7e-4a4e-8d55-3f4b8f6f9c11","currency":"EUR","line_items":[{"description":"Consulting","quantity":1,"unit_amount":12000}]}`)
+		assert.Equal(t, http.StatusNotFound, rr.Code, rr.Body.String())
+	})
+
+	t.Run("drafts are editable until issued", func(t *testing.T) {
+		created := create()
+		path := "/api/v1/invoices/" + created.Data.ID
+
+		rr := send(http.MethodPut, path, `{"currency":"EUR","line_items":[{"description":"Consulting","quantity":1,"unit_amount":12000}]}`)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var updated invoiceResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &updated))
+		assert.Equal(t, int64(12000), updated.Data.Total.Amount)
+
+		rr = send(http.MethodPost, path+"/issue", "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var issued invoiceResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &issued))
+		assert.Equal(t, "issued", issued.Data.Status)
+		assert.NotNil(t, issued.Data.IssuedAt)
+
+		rr = send(http.MethodPut, path, `{"currency":"EUR","line_items":[{"description":"Consulting","quantity":2,"unit_amount":12000}]}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code, rr.Body.String())
+		rr = send(http.MethodDelete, path, "")
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code, rr.Body.String())
+		rr = send(http.MethodPost, path+"/issue", "")
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code, rr.Body.String())
+
+		rr = send(http.MethodPost, path+"/void", "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var voided invoiceResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &voided))
+		assert.Equal(t, "void", voided.Data.Status)
+
+		rr = send(http.MethodPost, path+"/void", "")
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code, rr.Body.String())
+	})
+
+	t.Run("deletes drafts", func(t *testing.T) {
+		created := create()
+		path := "/api/v1/invoices/" + created.Data.ID
+
+		rr := send(http.MethodDelete, path, "")
+		assert.Equal(t, http.StatusNoContent, rr.Code, rr.Body.String())
+		rr = send(http.MethodGet, path, "")
+		assert.Equal(t, http.StatusNotFound, rr.Code, rr.Body.String())
+	})
+
+	t.Run("lists invoices by status", func(t *testing.T) {
+		rr := send(http.MethodGet, "/api/v1/invoices?status=void&client_id="+client.ID(), "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var response struct {
+			Data []struct {
+				Status string `json:"status"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		require.Len(t, response.Data, 1)
+		assert.Equal(t, "void", response.Data[0].Status)
+
+		rr = send(http.MethodGet, "/api/v1/invoices?status=overdue", "")
+		assert.Equal(t, http.StatusBadRequest, rr.Code, rr.Body.String())
+	})
+
+	t.Run("pages invoices by cursor", func(t *testing.T) {
+		type invoicePage struct {
+			Data []struct {
+				ID string `json:"id"`
+			} `json:"data"`
+			Pagination struct {
+				Limit      int    `json:"limit"`
+				NextCursor string `json:"next_cursor"`
+				HasMore    bool   `json:"has_more"`
+			} `json:"pagination"`
+		}
+		list := func(query string) invoicePage {
+			t.Helper()
+			rr := send(http.MethodGet, "/api/v1/invoices?client_id="+client.ID()+query, "")
+			require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+			var page invoicePage
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &page))
+			return page
+		}
+
+		all := list("&limit=100")
+		require.Greater(t, len(all.Data), 1)
+		assert.False(t, all.Pagination.HasMore)
+
+		var paged []string
+		page := list("&limit=1")
+		for {
+			require.Len(t, page.Data, 1)
+			paged = append(paged, page.Data[0].ID)
+			if !page.Pagination.HasMore {
+				break
+			}
+			page = list("&limit=1&cursor=" + page.Pagination.NextCursor)
+		}
+		require.Len(t, paged, len(all.Data))
+		for i, invoice := range all.Data {
+			assert.Equal(t, invoice.ID, paged[i])
+		}
+
+		rr := send(http.MethodGet, "/api/v1/invoices?cursor=not-a-cursor", "")
+		assert.Equal(t, http.StatusBadRequest, rr.Code, rr.Body.String())
+		rr = send(http.MethodGet, "/api/v1/invoices?page=2", "")
+		assert.Equal(t, http.StatusBadRequest, rr.Code, rr.Body.String())
+	})
+}
+
+func TestInvoiceTaxAPI(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	rates, err := service.NewStaticTaxRates(map[string]int64{"FR": 2000})
+	require.NoError(t, err)
+	billingService := application.NewBillingService(repository.NewClientRepository(storage)).
+		WithInvoices(repository.NewInvoiceRepository(storage.Collection(repository.InvoiceCollection))).
+		WithTaxRates(rates)
+	handler := httpserver.NewServerWithServices(httpserver.Services{Billing: billingService}, adminOptions()).Handler()
+
+	client, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+	require.NoError(t, err)
+
+	type money struct {
+		Amount int64 `json:"amount"`
+	}
+	type invoiceResponse struct {
+		Data struct {
+			LineItems []struct {
+				TaxRateBps int64 `json:"tax_rate_bps"`
+				NetAmount  money `json:"net_amount"`
+				TaxAmount  money `json:"tax_amount"`
+			} `json:"line_items"`
+			TaxPricing      string `json:"tax_pricing"`
+			TaxJurisdiction string `json:"tax_jurisdiction"`
+			Subtotal        money  `json:"subtotal"`
+			Tax             money  `json:"tax"`
+			TaxBreakdown    []struct {
+				RateBps int64 `json:"rate_bps"`
+				Taxable money `json:"taxable"`
+				Tax     money `json:"tax"`
+			} `json:"tax_breakdown"`
+			Total   money `json:"total"`
+			Balance money `json:"balance"`
+		} `json:"data"`
+	}
+
+	create := func(body string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, asAdmin(httptest.NewRequest(http.MethodPost, "/api/v1/invoices", strings.NewReader(body))))
+		return rr
+	}
+
+	t.Run("rates lines without a rate at the jurisdiction rate and adds tax", func(t *testing.T) {
+		rr := create(fmt.Sprintf(`{"client_id":%q,"currency":"EUR","tax_jurisdiction":"fr","line_items":[{"description":"Consulting","quantity":3,"unit_amount":12000},{"description":"Training","quantity":1,"unit_amount":4500,"tax_rate_bps":0}]}`, client.ID()))
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		var response invoiceResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+
+		assert.Equal(t, "exclusive", response.Data.TaxPricing)
+		assert.Equal(t, "FR", response.Data.TaxJurisdiction)
+		require.Len(t, response.Data.LineItems, 2)
+		assert.Equal(t, int64(2000), response.Data.LineItems[0].TaxRateBps)
+		assert.Equal(t, int64(7200), response.Data.LineItems[0].TaxAmount.Amount)
+		assert.Equal(t, int64(0), response.Data.LineItems[1].TaxRateBps, "an explicit zero rate is exempt")
+		assert.Equal(t, int64(40500), response.Data.Subtotal.Amount)
+		assert.Equal(t, int64(7200), response.Data.Tax.Amount)
+		assert.Equal(t, int64(47700), response.Data.Total.Amount)
+		assert.Equal(t, int64(47700), response.Data.Balance.Amount)
+		require.Len(t, response.Data.TaxBreakdown, 2)
+		assert.Equal(t, int64(2000), response.Data.TaxBreakdown[1].RateBps)
+		assert.Equal(t, int64(36000), response.Data.TaxBreakdown[1].Taxable.Amount)
+	})
+
+	t.Run("extracts tax from inclusive prices", func(t *testing.T) {
+		rr := create(fmt.Sprintf(`{"client_id":%q,"currency":"EUR","tax_pricing":"inclusive","line_items":[{"description":"Consulting","quantity":1,"unit_amount":12000,"tax_rate_bps":2000}]}`, client.ID()))
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		var response invoiceResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+
+		assert.Equal(t, "inclusive", response.Data.TaxPricing)
+		assert.Equal(t, int64(10000), response.Data.LineItems[0].NetAmount.Amount)
+		assert.Equal(t, int64(2000), response.Data.Tax.Amount)
+		assert.Equal(t, int64(12000), response.Data.Total.Amount)
+	})
+
+	t.Run("rejects unknown jurisdictions and pricings", func(t *testing.T) {
+		rr := create(fmt.Sprintf(`{"client_id":%q,"currency":"EUR","tax_jurisdiction":"DE","line_items":[{"description":"Consulting","quantity":1,"unit_amount":12000}]}`, client.ID()))
+		assert.Equal(t, http.StatusBadRequest, rr.Code, rr.Body.String())
+
+		rr = create(fmt.Sprintf(`{"client_id":%q,"currency":"EUR","tax_pricing":"gross","line_items":[{"description":"Consulting","quantity":1,"unit_amount":12000}]}`, client.ID()))
+		assert.Equal(t, http.StatusBadRequest, rr.Code, rr.Body.String())
+	})
+}
+
+func TestInvoicePaymentsAPI(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage)).
+		WithInvoices(repository.NewInvoiceRepository(storage.Collection(repository.InvoiceCollection))).
+		WithPayments(repository.NewPaymentRepository(storage.Collection(repository.PaymentCollection)))
+	handler := httpserver.NewServerWithServices(httpserver.Services{Billing: billingService}, httpserver.ServerOptions{
+		AdminTokens: map[string]string{"ops": "admin-token"},
+	}).Handler()
+
+	client, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+	require.NoError(t, err)
+
+	send := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer admin-token")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := send(http.MethodPost, "/api/v1/invoices", fmt.Sprintf(`{"client_id":%q,"currency":"EUR","line_items":[{"description":"Consulting","quantity":1,"unit_amount":10000}]}`, client.ID()))
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	var created struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &created))
+	path := "/api/v1/invoices/" + created.Data.ID + "/payments"
+
+	type paymentResponse struct {
+		Data struct {
+			Payment struct {
+				ID     string `json:"id"`
+				Method string `json:"method"`
+			} `json:"payment"`
+			Invoice struct {
+				Status  string `json:"status"`
+				Balance struct {
+					Amount int64 `json:"amount"`
+				} `json:"balance"`
+			} `json:"invoice"`
+		} `json:"data"`
+	}
+
+	t.Run("drafts cannot be paid", func(t *testing.T) {
+		rr := send(http.MethodPost, path, `{"amount":4000,"currency":"EUR","method":"bank_transfer"}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code, rr.Body.String())
+	})
+
+	rr = send(http.MethodPost, "/api/v1/invoices/"+created.Data.ID+"/issue", "")
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	t.Run("partial payments reduce the balance", func(t *testing.T) {
+		rr := send(http.MethodPost, path, `{"amount":4000,"currency":"EUR","method":"bank_transfer","reference":"TRF-1"}`)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		var response paymentResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, "bank_transfer", response.Data.Payment.Method)
+		assert.Equal(t, "issued", response.Data.Invoice.Status)
+		assert.Equal(t, int64(6000), response.Data.Invoice.Balance.Amount)
+	})
+
+	t.Run("rejects over-payments and invalid payments", func(t *testing.T) {
+		rr := send(http.MethodPost, path, `{"amount":6001,"currency":"EUR","method":"bank_transfer"}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code, rr.Body.String())
+
+		rr = send(http.MethodPost, path, `{"amount":100,"currency":"USD","method":"bank_transfer"}`)
+		assert.Equal(t, http.StatusBadRequest, rr.Code, rr.Body.String())
+
+		rr = send(http.MethodPost, path, `{"amount":100,"currency":"EUR","method":"barter"}`)
+		assert.Equal(t, http.StatusBadRequest, rr.Code, rr.Body.String())
+
+		rr = send(http.MethodPost, path, `{"amount":100,"currency":"EUR","method":"card","payment_method":"pm_card"}`)
+		assert.Equal(t, http.StatusBadRequest, rr.Code, "card payments are not configured")
+	})
+
+	t.Run("the payment settling the balance marks the invoice paid", func(t *testing.T) {
+		rr := send(http.MethodPost, path, `{"amount":6000,"currency":"EUR","method":"cash"}`)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		var response paymentResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, "paid", response.Data.Invoice.Status)
+		assert.Equal(t, int64(0), response.Data.Invoice.Balance.Amount)
+
+		rr = send(http.MethodPost, path, `{"amount":1,"currency":"EUR","method":"cash"}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code, rr.Body.String())
+	})
+
+	t.Run("lists the payments of an invoice", func(t *testing.T) {
+		rr := send(http.MethodGet, path, "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var response struct {
+			Data []struct {
+				Method string `json:"method"`
+				Amount struct {
+					Amount int64 `json:"amount"`
+				} `json:"amount"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		require.Len(t, response.Data, 2)
+		assert.Equal(t, int64(4000), response.Data[0].Amount.Amount)
+		assert.Equal(t, "cash", response.Data[1].Method)
+
+		rr = httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
+
+func TestInvoiceAPI_Authorization(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage)).
+		WithInvoices(repository.NewInvoiceRepository(storage.Collection(repository.InvoiceCollection)))
+	handler := httpserver.NewServerWithServices(httpserver.Services{Billing: billingService}, httpserver.ServerOptions{
+		AdminTokens:  map[string]string{"initech-admin": "initech-token", "globex-admin": "globex-token", "support": "support-token"},
+		AdminTenants: map[string][]string{"initech-admin": {"initech"}, "globex-admin": {"globex"}, "support": {"initech"}},
+		AdminScopes:  map[string][]string{"support": {httpserver.ScopeSupport}},
+	}).Handler()
+
+	send := func(method, path, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rc := application.SystemContext("test", "initech")
+	client, err := billingService.CreateClient(rc, dtos.CreateClientRequest{Name: "Initech", Email: "ap@initech.example"})
+	require.NoError(t, err)
+	invoice, err := billingService.CreateInvoice(rc, dtos.CreateInvoiceRequest{
+		ClientID:  client.ID(),
+		Currency:  "EUR",
+		LineItems: []dtos.InvoiceLineRequest{{Description: "Consulting", Quantity: 1, UnitAmount: 12000}},
+	})
+	require.NoError(t, err)
+	path := "/api/v1/invoices/" + invoice.ID()
+
+	t.Run("anonymous callers are refused", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, send(http.MethodGet, "/api/v1/invoices", "").Code)
+		assert.Equal(t, http.StatusUnauthorized, send(http.MethodGet, path, "").Code)
+		assert.Equal(t, http.StatusUnauthorized, send(http.MethodPost, path+"/issue", "").Code)
+	})
+
+	t.Run("invoices of other tenants are not found", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, send(http.MethodGet, path, "globex-token").Code)
+		assert.Equal(t, http.StatusNotFound, send(http.MethodPost, path+"/void", "globex-token").Code)
+		assert.Equal(t, http.StatusNotFound, send(http.MethodDelete, path, "globex-token").Code)
+
+		rr := send(http.MethodGet, "/api/v1/invoices", "globex-token")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.NotContains(t, rr.Body.String(), invoice.ID())
+	})
+
+	t.Run("admins without the finance scope only read invoices", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, send(http.MethodGet, path, "support-token").Code)
+		assert.Equal(t, http.StatusForbidden, send(http.MethodPost, path+"/issue", "support-token").Code)
+		assert.Equal(t, http.StatusForbidden, send(http.MethodDelete, path, "support-token").Code)
+
+		rr := send(http.MethodPost, path+"/issue", "initech-token")
+		assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	})
+}
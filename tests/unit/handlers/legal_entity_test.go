@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/messaging"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminAPI_LegalEntities(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage)).WithInvoices(repository.NewInvoiceRepository(storage.Collection(repository.InvoiceCollection)))
+	auditService := application.NewAuditService(repository.NewAuditRepository(storage.Collection(repository.AuditCollection)))
+	legalEntityService := application.NewLegalEntityService(
+		repository.NewLegalEntityRepository(storage.Collection(repository.LegalEntityCollection)),
+		auditService,
+	)
+	billingService.WithLegalEntities(legalEntityService)
+	publisher := messaging.NewMemoryPublisher()
+	handler := httpserver.NewServerWithServices(httpserver.Services{
+		Billing: billingService,
+		Audit:   auditService,
+		Recurring: application.NewRecurringInvoiceService(
+			repository.NewRecurringInvoiceTemplateRepository(storage.Collection(repository.RecurringInvoiceTemplateCollection)),
+			billingService,
+			publisher,
+		).WithLegalEntities(legalEntityService),
+		LegalEntities: legalEntityService,
+	}, httpserver.ServerOptions{
+		AdminTokens: map[string]string{"ops": "admin-token"},
+	}).Handler()
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newAdminRequest(method, path, body, "192.0.2.10:1234"))
+		return rr
+	}
+	type legalEntityData struct {
+		ID          string `json:"id"`
+		Default     bool   `json:"default"`
+		BankAccount *struct {
+			IBAN string `json:"iban"`
+		} `json:"bank_account"`
+		HasIssuedInvoices bool `json:"has_issued_invoices"`
+	}
+	decode := func(t *testing.T, rr *httptest.ResponseRecorder) legalEntityData {
+		var response struct {
+			Data legalEntityData `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		return response.Data
+	}
+
+	var france, germany legalEntityData
+	t.Run("the first entity becomes the default", func(t *testing.T) {
+		rr := serve(http.MethodPost, "/api/v1/admin/legal-entities", `{"name":"Acme SAS","country":"FR",
+			"tax_registrations":[{"country":"FR","number":"FR40303265045"}],
+			"bank_account":{"account_holder":"Acme SAS","iban":"FR14 2004 1010 0505 0001 3M02 606"},
+			"numbering":{"prefix":"FR-","yearly_reset":true}}`)
+
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		france = decode(t, rr)
+		assert.True(t, france.Default)
+		require.NotNil(t, france.BankAccount)
+		assert.Equal(t, "FR1420041010050500013M02606", france.BankAccount.IBAN)
+	})
+
+	t.Run("rejects invalid bank details", func(t *testing.T) {
+		rr := serve(http.MethodPost, "/api/v1/admin/legal-entities", `{"name":"Acme GmbH","country":"DE",
+			"bank_account":{"account_holder":"Acme GmbH","iban":"DE00370400440532013000"},"numbering":{"prefix":"DE-"}}`)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "bank_account.iban")
+	})
+
+	t.Run("rejects a prefix used by another entity", func(t *testing.T) {
+		rr := serve(http.MethodPost, "/api/v1/admin/legal-entities", `{"name":"Acme GmbH","country":"DE","numbering":{"prefix":"fr-"}}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	})
+
+	t.Run("making another entity the default clears the previous one", func(t *testing.T) {
+		rr := serve(http.MethodPost, "/api/v1/admin/legal-entities", `{"name":"Acme GmbH","country":"DE","numbering":{"prefix":"DE-","padding":4},"default":true}`)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		germany = decode(t, rr)
+		assert.True(t, germany.Default)
+
+		rr = serve(http.MethodGet, "/api/v1/admin/legal-entities/"+france.ID, "")
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.False(t, decode(t, rr).Default)
+	})
+
+	t.Run("the default entity cannot be deleted while others remain", func(t *testing.T) {
+		rr := serve(http.MethodDelete, "/api/v1/admin/legal-entities/"+germany.ID, "")
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	})
+
+	client, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+	require.NoError(t, err)
+	firstIssue := time.Now().UTC().AddDate(0, 0, -1).Truncate(time.Second)
+	template := func(legalEntityID string) string {
+		return fmt.Sprintf(`{"client_id":%q,"name":"Retainer","currency":"EUR","frequency":"monthly","first_issue_date":%q,
+			"legal_entity_id":%q,"line_items":[{"description":"Retainer","quantity":1,"unit_amount":150000}]}`,
+			client.ID(), firstIssue.Format(time.RFC3339), legalEntityID)
+	}
+
+	t.Run("templates must reference an existing entity", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, asAdmin(httptest.NewRequest(http.MethodPost, "/api/v1/recurring-invoices",
+			strings.NewReader(template("00000000-0000-0000-0000-000000000000")))))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("issued invoices are numbered from the entity sequence", func(t *testing.T) {
+		for _, legalEntityID := range []string{france.ID, ""} {
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, asAdmin(httptest.NewRequest(http.MethodPost, "/api/v1/recurring-invoices", strings.NewReader(template(legalEntityID)))))
+			require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		}
+
+		rr := serve(http.MethodPost, "/api/v1/admin/recurring-invoices/run", "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		messages := publisher.Messages()
+		require.Len(t, messages, 2)
+		events := make(map[string]application.RecurringInvoiceIssuedEvent)
+		for _, message := range messages {
+			var event application.RecurringInvoiceIssuedEvent
+			require.NoError(t, json.Unmarshal(message.Payload, &event))
+			events[event.LegalEntityID] = event
+		}
+
+		assert.Equal(t, fmt.Sprintf("FR-%d-000001", firstIssue.Year()), events[france.ID].InvoiceNumber)
+		assert.Equal(t, "default", events[france.ID].DocumentTemplate)
+		// Templates without an entity are issued from the default one
+		assert.Equal(t, "DE-0001", events[germany.ID].InvoiceNumber)
+	})
+
+	t.Run("entities invoices were issued from cannot be deleted", func(t *testing.T) {
+		rr := serve(http.MethodDelete, "/api/v1/admin/legal-entities/"+france.ID, "")
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+
+		rr = serve(http.MethodGet, "/api/v1/admin/legal-entities", "")
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"has_issued_invoices":true`)
+	})
+
+	t.Run("changes are recorded in the audit log", func(t *testing.T) {
+		entries, err := auditService.ListEntries("")
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		for _, entry := range entries {
+			assert.Equal(t, application.AuditActionLegalEntityCreated, entry.Action())
+			assert.Equal(t, "ops", entry.Actor())
+		}
+	})
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/middleware"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocaleResolver_Resolve(t *testing.T) {
+	resolver := middleware.NewLocaleResolver("en", map[string]string{"acme": "fr-FR"})
+
+	testCases := []struct {
+		description    string
+		acceptLanguage string
+		tenantID       string
+		expected       string
+	}{
+		{description: "no preference falls back to global default", expected: "en"},
+		{description: "tenant default applies without header", tenantID: "acme", expected: "fr-FR"},
+		{description: "Accept-Language wins over tenant default", acceptLanguage: "de-DE", tenantID: "acme", expected: "de-DE"},
+		{description: "tenant region fills region-less header", acceptLanguage: "en", tenantID: "acme", expected: "en-FR"},
+		{description: "unknown tenant uses global default", tenantID: "unknown", expected: "en"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/clients", nil)
+			if testCase.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", testCase.acceptLanguage)
+			}
+			if testCase.tenantID != "" {
+				req.Header.Set(middleware.TenantHeader, testCase.tenantID)
+			}
+
+			assert.Equal(t, testCase.expected, resolver.Resolve(req).String())
+		})
+	}
+}
+
+func TestServer_CreateClient_UsesClientCountryNotCallerRegion(t *testing.T) {
+	// Arrange
+	storage := infrastructure.NewInMemoryStorage()
+	clientRepo := repository.NewClientRepository(storage)
+	billingService := application.NewBillingService(clientRepo)
+	server := httpserver.NewServerWithOptions(billingService, httpserver.ServerOptions{
+		AdminTokens:   map[string]string{"ops": adminToken},
+		TenantLocales: map[string]string{"acme": "fr-FR"},
+	})
+
+	body := `{"name":"Jean Dupont","email":"jean@example.fr","phone":"06 12 34 56 78","address":"1 rue de Rivoli, 75001 Paris","country":"FR"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/clients", strings.NewReader(body))
+	req.Header.Set(middleware.TenantHeader, "acme")
+	rr := httptest.NewRecorder()
+
+	// Act
+	server.Handler().ServeHTTP(rr, asAdmin(req))
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.Equal(t, "fr-FR", rr.Header().Get("Content-Language"))
+
+	assert.Contains(t, rr.Body.String(), `"country":"FR"`)
+
+	// A US caller may save a foreign client: the caller's locale sets no rules on the client's details
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/clients", strings.NewReader(
+		`{"name":"Hans Meier","email":"hans@example.de","phone":"+49 30 1234567","address":"Unter den Linden 1, Berlin"}`))
+	req.Header.Set("Accept-Language", "en-US")
+	rr = httptest.NewRecorder()
+
+	server.Handler().ServeHTTP(rr, asAdmin(req))
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+
+	// A 9-digit national number is valid in France but not under the US numbering rules of the client's country
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/clients", strings.NewReader(
+		`{"name":"John Doe","email":"john@example.com","phone":"612 345 678","address":"1 Main St, Springfield 12345","country":"US"}`))
+	req.Header.Set("Accept-Language", "fr-FR")
+	rr = httptest.NewRecorder()
+
+	server.Handler().ServeHTTP(rr, asAdmin(req))
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "VALIDATION_LENGTH")
+}
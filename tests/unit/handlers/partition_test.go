@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePartitionManager records the requested months and reports the partitions not created yet
+type fakePartitionManager struct {
+	table    string
+	existing map[string]bool
+	from     time.Time
+	through  time.Time
+}
+
+func (m *fakePartitionManager) EnsureMonthlyPartitions(from, through time.Time) ([]string, error) {
+	m.from, m.through = from, through
+	var created []string
+	for month := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC); !month.After(through); month = month.AddDate(0, 1, 0) {
+		name := m.table + month.Format("_p2006_01")
+		if !m.existing[name] {
+			m.existing[name] = true
+			created = append(created, name)
+		}
+	}
+	return created, nil
+}
+
+func TestAPI_PartitionMaintenance(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	usage := &fakePartitionManager{table: "usage_records", existing: map[string]bool{}}
+	audit := &fakePartitionManager{table: "audit_log_records", existing: map[string]bool{}}
+
+	handler := httpserver.NewServerWithServices(httpserver.Services{
+		Billing: application.NewBillingService(repository.NewClientRepository(storage)),
+		Partitions: application.NewPartitionMaintenanceService([]application.PartitionedTable{
+			{Name: "usage_records", Manager: usage},
+			{Name: "audit_log_records", Manager: audit},
+		}, 2),
+	}, httpserver.ServerOptions{
+		AdminTokens: map[string]string{"ops": "admin-token"},
+	}).Handler()
+
+	serve := func(method, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/api/v1/admin/partitions/run", nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("requires an admin token", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, serve(http.MethodPost, "").Code)
+	})
+
+	t.Run("rejects other methods", func(t *testing.T) {
+		assert.Equal(t, http.StatusMethodNotAllowed, serve(http.MethodGet, "admin-token").Code)
+	})
+
+	t.Run("creates the partitions of the current month and the months ahead", func(t *testing.T) {
+		rr := serve(http.MethodPost, "admin-token")
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var response struct {
+			Data struct {
+				Created []string `json:"created"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Len(t, response.Data.Created, 6)
+		assert.Contains(t, response.Data.Created, "usage_records"+time.Now().UTC().Format("_p2006_01"))
+		assert.Contains(t, response.Data.Created, "audit_log_records"+time.Now().UTC().AddDate(0, 2, 0).Format("_p2006_01"))
+		assert.Equal(t, time.UTC, usage.from.Location())
+	})
+
+	t.Run("running again creates nothing", func(t *testing.T) {
+		rr := serve(http.MethodPost, "admin-token")
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"success":true,"data":{"created":[]}}`, rr.Body.String())
+	})
+}
+
+func TestAPI_AuditLogSince(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	auditService := application.NewAuditService(repository.NewAuditRepository(storage.Collection(repository.AuditCollection)))
+	require.NoError(t, auditService.Record("client.deleted", "ops", "acme", "client", "c-1", nil))
+
+	handler := httpserver.NewServerWithServices(httpserver.Services{
+		Billing: application.NewBillingService(repository.NewClientRepository(storage)),
+		Audit:   auditService,
+	}, httpserver.ServerOptions{
+		AdminTokens: map[string]string{"ops": "admin-token"},
+	}).Handler()
+
+	serve := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/audit-log"+query, nil)
+		req.Header.Set("Authorization", "Bearer admin-token")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	count := func(rr *httptest.ResponseRecorder) int {
+		var response struct {
+			Data []json.RawMessage `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		return len(response.Data)
+	}
+
+	t.Run("keeps the entries that occurred since", func(t *testing.T) {
+		rr := serve("?since=" + time.Now().Add(-time.Hour).UTC().Format(time.RFC3339))
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, 1, count(rr))
+	})
+
+	t.Run("drops the entries that occurred before", func(t *testing.T) {
+		rr := serve("?since=" + time.Now().Add(time.Hour).UTC().Format(time.RFC3339))
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, 0, count(rr))
+	})
+
+	t.Run("rejects a malformed since", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, serve("?since=yesterday").Code)
+	})
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI_PaymentTerms(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage)).
+		WithInvoices(repository.NewInvoiceRepository(storage.Collection(repository.InvoiceCollection)))
+	handler := httpserver.NewServerWithServices(httpserver.Services{Billing: billingService}, adminOptions()).Handler()
+
+	type invoiceResponse struct {
+		Data struct {
+			ID           string     `json:"id"`
+			PaymentTerms string     `json:"payment_terms"`
+			DueDate      *time.Time `json:"due_date"`
+			IssuedAt     *time.Time `json:"issued_at"`
+		} `json:"data"`
+	}
+
+	send := func(method, path, body string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, asAdmin(httptest.NewRequest(method, path, strings.NewReader(body))))
+		return rr
+	}
+	createClient := func(t *testing.T, email, terms string) string {
+		t.Helper()
+		rr := send(http.MethodPost, "/api/v1/clients", fmt.Sprintf(`{"name":"Acme Corp","email":%q,"payment_terms":%q}`, email, terms))
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		var response struct {
+			Data struct {
+				ID           string `json:"id"`
+				PaymentTerms string `json:"payment_terms"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, terms, response.Data.PaymentTerms)
+		return response.Data.ID
+	}
+	decodeInvoice := func(t *testing.T, rr *httptest.ResponseRecorder) invoiceResponse {
+		t.Helper()
+		var response invoiceResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		return response
+	}
+	createInvoice := func(t *testing.T, clientID, extra string) invoiceResponse {
+		t.Helper()
+		rr := send(http.MethodPost, "/api/v1/invoices", fmt.Sprintf(`{"client_id":%q,"currency":"EUR","line_items":[{"description":"Consulting","quantity":1,"unit_amount":12000}]%s}`, clientID, extra))
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		return decodeInvoice(t, rr)
+	}
+	issue := func(t *testing.T, invoiceID string) invoiceResponse {
+		t.Helper()
+		rr := send(http.MethodPost, "/api/v1/invoices/"+invoiceID+"/issue", "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		return decodeInvoice(t, rr)
+	}
+
+	net30 := createClient(t, "net30@acme.example", "net_30")
+
+	t.Run("invoices of the client are due after its terms", func(t *testing.T) {
+		created := createInvoice(t, net30, "")
+		assert.Equal(t, "net_30", created.Data.PaymentTerms)
+		assert.Nil(t, created.Data.DueDate)
+
+		issued := issue(t, created.Data.ID)
+		require.NotNil(t, issued.Data.IssuedAt)
+		require.NotNil(t, issued.Data.DueDate)
+		assert.True(t, issued.Data.IssuedAt.AddDate(0, 0, 30).Equal(*issued.Data.DueDate))
+	})
+
+	t.Run("invoice terms override the client's", func(t *testing.T) {
+		created := createInvoice(t, net30, `,"payment_terms":"net_45"`)
+		assert.Equal(t, "net_45", created.Data.PaymentTerms)
+
+		issued := issue(t, created.Data.ID)
+		require.NotNil(t, issued.Data.DueDate)
+		assert.True(t, issued.Data.IssuedAt.AddDate(0, 0, 45).Equal(*issued.Data.DueDate))
+	})
+
+	t.Run("an explicit due date wins over the terms", func(t *testing.T) {
+		created := createInvoice(t, net30, `,"due_date":"2030-01-15T00:00:00Z"`)
+		issued := issue(t, created.Data.ID)
+		require.NotNil(t, issued.Data.DueDate)
+		assert.True(t, time.Date(2030, time.January, 15, 0, 0, 0, 0, time.UTC).Equal(*issued.Data.DueDate))
+	})
+
+	t.Run("clients without terms issue invoices without due dates", func(t *testing.T) {
+		clientID := createClient(t, "noterms@acme.example", "")
+		issued := issue(t, createInvoice(t, clientID, "").Data.ID)
+		assert.Empty(t, issued.Data.PaymentTerms)
+		assert.Nil(t, issued.Data.DueDate)
+	})
+
+	t.Run("updating the client replaces its terms", func(t *testing.T) {
+		clientID := createClient(t, "update@acme.example", "net_15")
+		rr := send(http.MethodPut, "/api/v1/clients/"+clientID, `{"name":"Acme Corp","payment_terms":"NET_60"}`)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), `"payment_terms":"net_60"`)
+
+		rr = send(http.MethodPut, "/api/v1/clients/"+clientID, `{"name":"Acme Corp"}`)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.NotContains(t, rr.Body.String(), "payment_terms")
+	})
+
+	t.Run("invalid terms are rejected", func(t *testing.T) {
+		rr := send(http.MethodPost, "/api/v1/clients", `{"name":"Acme Corp","email":"invalid@acme.example","payment_terms":"net_400"}`)
+		assert.Equal(t, http.StatusBadRequest, rr.Code, rr.Body.String())
+
+		rr = send(http.MethodPost, "/api/v1/invoices", fmt.Sprintf(`{"client_id":%q,"currency":"EUR","line_items":[{"description":"Consulting","quantity":1,"unit_amount":12000}],"payment_terms":"eom"}`, net30))
+		assert.Equal(t, http.StatusBadRequest, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), "payment_terms")
+	})
+}
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/gateway"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// staticLedger returns the recorded entries matching the requested gateway references
+type staticLedger []service.LedgerEntry
+
+func (l staticLedger) EntriesByGatewayReference(references []string) ([]service.LedgerEntry, error) {
+	entries := make([]service.LedgerEntry, 0)
+	for _, entry := range l {
+		for _, reference := range references {
+			if entry.GatewayReference == reference {
+				entries = append(entries, entry)
+			}
+		}
+	}
+	return entries, nil
+}
+
+func TestAdminAPI_PayoutReconciliation(t *testing.T) {
+	paymentGateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[
+			{"id":"po_balanced","arrival_date":"2026-03-01","amount":9680,"currency":"EUR","items":[{"reference":"ch_1","type":"charge","amount":10000,"fee":320}]},
+			{"id":"po_short","arrival_date":"2026-03-02","amount":4825,"currency":"EUR","items":[{"reference":"ch_2","type":"charge","amount":5000,"fee":175}]}
+		],"has_more":false}`))
+	}))
+	defer paymentGateway.Close()
+
+	money := func(amount int64) valueobject.Money {
+		value, err := valueobject.NewMoney(amount, "EUR")
+		require.NoError(t, err)
+		return value
+	}
+
+	storage := infrastructure.NewInMemoryStorage()
+	reportRepo := repository.NewPayoutReconciliationRepository(storage.Collection(repository.PayoutReconciliationCollection))
+	newServer := func(reconciliationService *application.PayoutReconciliationService) http.Handler {
+		return httpserver.NewServerWithServices(httpserver.Services{
+			Billing: application.NewBillingService(repository.NewClientRepository(storage)),
+			Payouts: reconciliationService,
+		}, httpserver.ServerOptions{
+			AdminTokens: map[string]string{"ops": "admin-token"},
+		}).Handler()
+	}
+	serve := func(handler http.Handler, method, path string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newAdminRequest(method, path, "", "192.0.2.10:1234"))
+		return rr
+	}
+
+	t.Run("runs are rejected without a gateway and ledger", func(t *testing.T) {
+		handler := newServer(application.NewPayoutReconciliationService(reportRepo, 0))
+
+		rr := serve(handler, http.MethodPost, "/api/v1/admin/payout-reconciliations/run")
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	})
+
+	handler := newServer(application.NewPayoutReconciliationService(reportRepo, 0).
+		WithGateway(gateway.NewPayoutClient(paymentGateway.URL, "gateway-key", paymentGateway.Client())).
+		WithLedger(staticLedger{
+			{PaymentID: "pay-1", GatewayReference: "ch_1", Amount: money(10000), Fee: money(320)},
+		}))
+
+	t.Run("run reports the discrepancies of each payout", func(t *testing.T) {
+		rr := serve(handler, http.MethodPost, "/api/v1/admin/payout-reconciliations/run")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var response struct {
+			Data struct {
+				Reconciled int `json:"reconciled"`
+				Discrepant int `json:"discrepant"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, 2, response.Data.Reconciled)
+		assert.Equal(t, 1, response.Data.Discrepant)
+
+		// Re-running replaces the reports instead of duplicating them
+		rr = serve(handler, http.MethodPost, "/api/v1/admin/payout-reconciliations/run")
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("discrepancy report", func(t *testing.T) {
+		rr := serve(handler, http.MethodGet, "/api/v1/admin/payout-reconciliations?status=discrepant")
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var response struct {
+			Data []struct {
+				PayoutID      string `json:"payout_id"`
+				Status        string `json:"status"`
+				Discrepancies []struct {
+					Kind          string `json:"kind"`
+					Reference     string `json:"reference"`
+					GatewayAmount int64  `json:"gateway_amount"`
+				} `json:"discrepancies"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		require.Len(t, response.Data, 1)
+		assert.Equal(t, "po_short", response.Data[0].PayoutID)
+		require.Len(t, response.Data[0].Discrepancies, 1)
+		assert.Equal(t, "missing_in_ledger", response.Data[0].Discrepancies[0].Kind)
+		assert.Equal(t, "ch_2", response.Data[0].Discrepancies[0].Reference)
+		assert.Equal(t, int64(5000), response.Data[0].Discrepancies[0].GatewayAmount)
+
+		rr = serve(handler, http.MethodGet, "/api/v1/admin/payout-reconciliations")
+		assert.Contains(t, rr.Body.String(), `"payout_id":"po_balanced"`)
+	})
+
+	t.Run("single payout report", func(t *testing.T) {
+		rr := serve(handler, http.MethodGet, "/api/v1/admin/payout-reconciliations/po_balanced")
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"status":"balanced"`)
+
+		rr = serve(handler, http.MethodGet, "/api/v1/admin/payout-reconciliations/po_unknown")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
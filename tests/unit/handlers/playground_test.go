@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gjaminon-go-labs/billing-api/api"
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/handlers"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPlaygroundServer(enabled bool) http.Handler {
+	storage := infrastructure.NewInMemoryStorage()
+	server := httpserver.NewServerWithServices(httpserver.Services{
+		Billing: application.NewBillingService(repository.NewClientRepository(storage)),
+	}, httpserver.ServerOptions{EnablePlayground: enabled})
+	return server.Handler()
+}
+
+func TestPlaygroundHandler_OperationsFromOpenAPI(t *testing.T) {
+	// Act
+	playground, err := handlers.NewPlaygroundHandler(api.OpenAPISpec)
+
+	// Assert
+	require.NoError(t, err)
+
+	var createClient *handlers.PlaygroundOperation
+	for i, operation := range playground.Operations() {
+		if operation.Method == http.MethodPost && operation.Path == "/api/v1/clients" {
+			createClient = &playground.Operations()[i]
+		}
+	}
+	require.NotNil(t, createClient, "POST /api/v1/clients should be rendered")
+
+	fields := make(map[string]bool)
+	for _, field := range createClient.BodyFields {
+		fields[field.Name] = field.Required
+	}
+	assert.Contains(t, fields, "email")
+	assert.True(t, fields["name"], "name is required by the request DTO")
+}
+
+func TestServer_Playground_ServedWhenEnabled(t *testing.T) {
+	// Arrange
+	handler := newPlaygroundServer(true)
+
+	// Act
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/playground", nil))
+
+	// Assert
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Header().Get("Content-Type"), "text/html")
+	assert.Contains(t, rr.Body.String(), `data-path="/api/v1/clients/{id}"`)
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/playground/openapi.yaml", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, api.OpenAPISpec, rr.Body.Bytes())
+}
+
+func TestServer_Playground_NotServedWhenDisabled(t *testing.T) {
+	// Arrange
+	handler := newPlaygroundServer(false)
+
+	for _, path := range []string{"/playground", "/playground/openapi.yaml"} {
+		// Act
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, rr.Code, path)
+	}
+}
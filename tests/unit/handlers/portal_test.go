@@ -0,0 +1,335 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/middleware"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPortalTestServer(t *testing.T) (http.Handler, *application.BillingService, *application.PortalService) {
+	t.Helper()
+
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage))
+	portalService := application.NewPortalService(billingService, "portal-secret", time.Hour)
+
+	server := httpserver.NewServerWithServices(httpserver.Services{
+		Billing: billingService,
+		Portal:  portalService,
+	}, httpserver.ServerOptions{
+		AdminTokens: map[string]string{"ops": "admin-token"},
+	})
+	return server.Handler(), billingService, portalService
+}
+
+func newPortalRequest(method, path, body, token string) *http.Request {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req
+}
+
+func TestPortalAPI(t *testing.T) {
+	handler, billingService, portalService := newPortalTestServer(t)
+
+	alice, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Alice Martin", Email: "alice@example.com", Phone: "+33 1 23 45 67 89", Address: "1 Rue de Rivoli, Paris"})
+	require.NoError(t, err)
+	bob, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Bob Smith", Email: "bob@example.com"})
+	require.NoError(t, err)
+
+	t.Run("admin issues a client-scoped token", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newPortalRequest(http.MethodPost, "/api/v1/admin/portal-tokens/"+alice.ID(), "", "admin-token"))
+
+		require.Equal(t, http.StatusCreated, rr.Code)
+		var issued struct {
+			Data struct {
+				Token    string `json:"token"`
+				ClientID string `json:"client_id"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &issued))
+		assert.Equal(t, alice.ID(), issued.Data.ClientID)
+
+		clientID, err := portalService.Authenticate(issued.Data.Token)
+		require.NoError(t, err)
+		assert.Equal(t, alice.ID(), clientID)
+	})
+
+	t.Run("portal requires a valid token", func(t *testing.T) {
+		for _, token := range []string{"", "not-a-token", "admin-token"} {
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, newPortalRequest(http.MethodGet, "/portal/v1/me", "", token))
+			assert.Equal(t, http.StatusUnauthorized, rr.Code, "token %q", token)
+		}
+	})
+
+	t.Run("profile is scoped to the token's client", func(t *testing.T) {
+		token, err := portalService.IssueAccessToken(bob.ID())
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newPortalRequest(http.MethodGet, "/portal/v1/me", "", token.Token))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), bob.ID())
+		assert.NotContains(t, rr.Body.String(), alice.ID())
+	})
+
+	t.Run("client updates its own contact details only", func(t *testing.T) {
+		token, err := portalService.IssueAccessToken(alice.ID())
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newPortalRequest(http.MethodPut, "/portal/v1/me/contact",
+			`{"phone":"+33 6 12 34 56 78","address":"2 Avenue Foch, Paris","name":"Mallory"}`, token.Token))
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		updated, err := billingService.GetOwnedClient(application.AdminContext("ops"), alice.ID())
+		require.NoError(t, err)
+		assert.Equal(t, "Alice Martin", updated.Name())
+		assert.Equal(t, "2 Avenue Foch, Paris", updated.Address())
+	})
+
+	t.Run("invalid contact details are rejected", func(t *testing.T) {
+		token, err := portalService.IssueAccessToken(alice.ID())
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newPortalRequest(http.MethodPut, "/portal/v1/me/contact", `{"phone":"call me"}`, token.Token))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestPortalAPI_Invoices(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage)).
+		WithInvoices(repository.NewInvoiceRepository(storage.Collection(repository.InvoiceCollection))).
+		WithPayments(repository.NewPaymentRepository(storage.Collection(repository.PaymentCollection)))
+	documents := application.NewDocumentTemplateService(
+		repository.NewDocumentTemplateRepository(storage.Collection(repository.DocumentTemplateCollection)),
+		application.NewAuditService(repository.NewAuditRepository(storage.Collection(repository.AuditCollection))),
+	)
+	portalService := application.NewPortalService(billingService, "portal-secret", time.Hour).WithDocuments(documents)
+	handler := httpserver.NewServerWithServices(httpserver.Services{
+		Billing: billingService,
+		Portal:  portalService,
+	}, httpserver.ServerOptions{}).Handler()
+
+	acme := application.SystemContext("test", "acme")
+	globex := application.SystemContext("test", "globex")
+	alice, err := billingService.CreateClient(acme, dtos.CreateClientRequest{Name: "Alice Martin", Email: "alice@example.com"})
+	require.NoError(t, err)
+	bob, err := billingService.CreateClient(acme, dtos.CreateClientRequest{Name: "Bob Smith", Email: "bob@example.com"})
+	require.NoError(t, err)
+
+	newInvoice := func(t *testing.T, rc application.RequestContext, clientID string, amount int64, issue bool) string {
+		t.Helper()
+		invoice, err := billingService.CreateInvoice(rc, dtos.CreateInvoiceRequest{
+			ClientID:       clientID,
+			Currency:       "EUR",
+			LineItems:      []dtos.InvoiceLineRequest{{Description: "Consulting", Quantity: 1, UnitAmount: amount}},
+			AllowDuplicate: true,
+		})
+		require.NoError(t, err)
+		if issue {
+			_, err = billingService.IssueInvoice(rc, invoice.ID(), time.Now())
+			require.NoError(t, err)
+		}
+		return invoice.ID()
+	}
+	draft := newInvoice(t, acme, alice.ID(), 1000, false)
+	first := newInvoice(t, acme, alice.ID(), 2000, true)
+	second := newInvoice(t, acme, alice.ID(), 3000, true)
+	paid := newInvoice(t, acme, alice.ID(), 4000, true)
+	_, _, err = billingService.RecordPayment(application.AdminContext("billing-admin"), paid, dtos.RecordPaymentRequest{Amount: 4000, Currency: "EUR", Method: "bank_transfer"}, time.Now())
+	require.NoError(t, err)
+	bobs := newInvoice(t, acme, bob.ID(), 5000, true)
+	globexClient, err := billingService.CreateClient(globex, dtos.CreateClientRequest{Name: "Alice Martin", Email: "alice@globex.example"})
+	require.NoError(t, err)
+	otherTenant := newInvoice(t, globex, globexClient.ID(), 6000, true)
+
+	token, err := portalService.IssueAccessToken(alice.ID())
+	require.NoError(t, err)
+
+	type invoicePage struct {
+		Data []struct {
+			ID      string `json:"id"`
+			Status  string `json:"status"`
+			Balance struct {
+				Amount int64 `json:"amount"`
+			} `json:"balance"`
+		} `json:"data"`
+		Pagination struct {
+			NextCursor string `json:"next_cursor"`
+			HasMore    bool   `json:"has_more"`
+		} `json:"pagination"`
+	}
+	list := func(t *testing.T, query string) invoicePage {
+		t.Helper()
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newPortalRequest(http.MethodGet, "/portal/v1/me/invoices"+query, "", token.Token))
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var page invoicePage
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &page))
+		return page
+	}
+	ids := func(page invoicePage) []string {
+		result := make([]string, len(page.Data))
+		for i, invoice := range page.Data {
+			result[i] = invoice.ID
+		}
+		return result
+	}
+
+	t.Run("lists the invoices issued to the client in its tenant", func(t *testing.T) {
+		page := list(t, "")
+		assert.Equal(t, []string{first, second, paid}, ids(page))
+		assert.False(t, page.Pagination.HasMore)
+		assert.Equal(t, int64(0), page.Data[2].Balance.Amount)
+	})
+
+	t.Run("pages by cursor and filters by status", func(t *testing.T) {
+		firstPage := list(t, "?limit=2")
+		assert.Equal(t, []string{first, second}, ids(firstPage))
+		require.True(t, firstPage.Pagination.HasMore)
+		assert.Equal(t, []string{paid}, ids(list(t, "?limit=2&cursor="+url.QueryEscape(firstPage.Pagination.NextCursor))))
+
+		assert.Equal(t, []string{paid}, ids(list(t, "?status=paid")))
+	})
+
+	t.Run("drafts cannot be listed", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newPortalRequest(http.MethodGet, "/portal/v1/me/invoices?status=draft", "", token.Token))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("shows an invoice of the client without admin details", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newPortalRequest(http.MethodGet, "/portal/v1/me/invoices/"+first, "", token.Token))
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var detail struct {
+			Data map[string]interface{} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &detail))
+		assert.Equal(t, first, detail.Data["id"])
+		assert.Equal(t, "issued", detail.Data["status"])
+		assert.NotContains(t, detail.Data, "tenant_id")
+		assert.NotContains(t, detail.Data, "client_id")
+	})
+
+	t.Run("drafts and invoices of other clients or tenants are not found", func(t *testing.T) {
+		for _, invoiceID := range []string{draft, bobs, otherTenant, "not-an-id"} {
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, newPortalRequest(http.MethodGet, "/portal/v1/me/invoices/"+invoiceID, "", token.Token))
+			assert.Equal(t, http.StatusNotFound, rr.Code, invoiceID)
+		}
+	})
+
+	t.Run("downloads the document of an invoice of the client", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newPortalRequest(http.MethodGet, "/portal/v1/me/invoices/"+first+"/document", "", token.Token))
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Equal(t, "text/html; charset=utf-8", rr.Header().Get("Content-Type"))
+		assert.Contains(t, rr.Body.String(), "Alice Martin")
+		assert.Contains(t, rr.Body.String(), "Consulting")
+	})
+
+	t.Run("documents of drafts and of invoices of other clients or tenants are not found", func(t *testing.T) {
+		for _, invoiceID := range []string{draft, bobs, otherTenant, "not-an-id"} {
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, newPortalRequest(http.MethodGet, "/portal/v1/me/invoices/"+invoiceID+"/document", "", token.Token))
+			assert.Equal(t, http.StatusNotFound, rr.Code, invoiceID)
+			assert.NotContains(t, rr.Body.String(), "Consulting")
+		}
+	})
+
+	t.Run("requires a portal token", func(t *testing.T) {
+		for _, path := range []string{"/portal/v1/me/invoices", "/portal/v1/me/invoices/" + first + "/document"} {
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, newPortalRequest(http.MethodGet, path, "", ""))
+			assert.Equal(t, http.StatusUnauthorized, rr.Code, path)
+		}
+	})
+}
+
+func TestPortalAPI_SignInLinks(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage))
+	magicLinks := application.NewMagicLinkService(
+		repository.NewMagicLinkRepository(storage.Collection(repository.MagicLinkCollection)),
+		"link-secret", time.Hour, "https://billing.example.com",
+	)
+	portalService := application.NewPortalService(billingService, "portal-secret", time.Hour).WithMagicLinks(magicLinks)
+	handler := httpserver.NewServerWithServices(httpserver.Services{
+		Billing:    billingService,
+		Portal:     portalService,
+		MagicLinks: magicLinks,
+	}, httpserver.ServerOptions{
+		AdminTokens: map[string]string{"ops": "admin-token"},
+	}).Handler()
+
+	client, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Alice Martin", Email: "alice@example.com"})
+	require.NoError(t, err)
+
+	// Admin issues a sign-in link
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, newPortalRequest(http.MethodPost, "/api/v1/admin/portal-links/"+client.ID(), "", "admin-token"))
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	var issued struct {
+		Data struct {
+			URL string `json:"url"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &issued))
+	signInURL, err := url.Parse(issued.Data.URL)
+	require.NoError(t, err)
+	token := signInURL.Query().Get("token")
+	require.NotEmpty(t, token)
+
+	exchange := func(token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/portal/v1/session", nil)
+		req.Header.Set(middleware.MagicLinkHeader, token)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	// The link is exchanged for an access token that opens the portal
+	rr = exchange(token)
+	require.Equal(t, http.StatusCreated, rr.Code)
+	var session struct {
+		Data struct {
+			Token string `json:"token"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &session))
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, newPortalRequest(http.MethodGet, "/portal/v1/me", "", session.Data.Token))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), client.ID())
+
+	// Following the same link again fails
+	rr = exchange(token)
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	assert.Contains(t, rr.Body.String(), "LINK_ALREADY_USED")
+
+	rr = exchange("")
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI_ProcessedMessageCleanup(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	now := time.Now()
+	consumer := application.NewIdempotentConsumer(
+		repository.NewProcessedMessageRepository(storage.Collection(repository.ProcessedMessageCollection)),
+		time.Hour,
+	).WithClock(func() time.Time { return now })
+
+	handler := httpserver.NewServerWithServices(httpserver.Services{
+		Billing:     application.NewBillingService(repository.NewClientRepository(storage)),
+		Idempotency: consumer,
+	}, httpserver.ServerOptions{
+		AdminTokens: map[string]string{"ops": "admin-token"},
+	}).Handler()
+
+	serve := func(method, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/api/v1/admin/processed-messages/cleanup", nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	for _, messageID := range []string{"msg-1", "msg-2"} {
+		_, err := consumer.ProcessOnce(messageID, func() error { return nil })
+		require.NoError(t, err)
+	}
+	now = now.Add(2 * time.Hour)
+
+	assert.Equal(t, http.StatusUnauthorized, serve(http.MethodPost, "").Code)
+	assert.Equal(t, http.StatusMethodNotAllowed, serve(http.MethodGet, "admin-token").Code)
+
+	rr := serve(http.MethodPost, "admin-token")
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	assert.JSONEq(t, `{"data":{"removed":2},"success":true}`, rr.Body.String())
+}
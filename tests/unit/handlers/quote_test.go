@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuoteAPI(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage)).
+		WithInvoices(repository.NewInvoiceRepository(storage.Collection(repository.InvoiceCollection))).
+		WithPayments(repository.NewPaymentRepository(storage.Collection(repository.PaymentCollection)))
+	quoteRepo := repository.NewQuoteRepository(storage.Collection(repository.QuoteCollection))
+	handler := httpserver.NewServerWithServices(httpserver.Services{
+		Billing: billingService,
+		Quotes:  application.NewQuoteService(quoteRepo, billingService),
+	}, httpserver.ServerOptions{
+		AdminTokens: map[string]string{"scheduler": "admin-token"},
+	}).Handler()
+
+	client, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+	require.NoError(t, err)
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer admin-token")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+	createQuote := func() dtos.QuoteResponse {
+		rr := serve(http.MethodPost, "/api/v1/quotes", fmt.Sprintf(`{"client_id":%q,"currency":"EUR","tax_pricing":"inclusive","valid_until":%q,
+			"line_items":[{"description":"Design workshop","quantity":2,"unit_amount":54000,"tax_rate_bps":2000},{"description":"Travel","quantity":1,"unit_amount":12050}]}`,
+			client.ID(), time.Now().UTC().AddDate(0, 0, 30).Format(time.RFC3339)))
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		var response struct {
+			Data dtos.QuoteResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		return response.Data
+	}
+	errorCode := func(rr *httptest.ResponseRecorder) string {
+		var response struct {
+			Error struct {
+				Code string `json:"code"`
+			} `json:"error"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		return response.Error.Code
+	}
+
+	t.Run("converts an accepted quote to a draft invoice", func(t *testing.T) {
+		quote := createQuote()
+		assert.Equal(t, "open", quote.Status)
+		assert.Equal(t, int64(120050), quote.Total.Amount)
+
+		rr := serve(http.MethodPost, "/api/v1/quotes/"+quote.ID+"/convert", "")
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code, rr.Body.String())
+
+		rr = serve(http.MethodPost, "/api/v1/quotes/"+quote.ID+"/accept", "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		rr = serve(http.MethodPost, "/api/v1/quotes/"+quote.ID+"/convert", "")
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		var response struct {
+			Data dtos.InvoiceResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		invoice := response.Data
+		assert.Equal(t, "draft", invoice.Status)
+		assert.Equal(t, client.ID(), invoice.ClientID)
+		assert.Equal(t, "inclusive", invoice.TaxPricing)
+		assert.Equal(t, quote.LineItems, invoice.LineItems)
+		assert.Equal(t, quote.Total, invoice.Total)
+
+		rr = serve(http.MethodGet, "/api/v1/quotes/"+quote.ID, "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), `"invoice_id":"`+invoice.ID+`"`)
+
+		rr = serve(http.MethodPost, "/api/v1/quotes/"+quote.ID+"/convert", "")
+		assert.Equal(t, http.StatusConflict, rr.Code, rr.Body.String())
+		assert.Equal(t, "BUSINESS_RULE_DUPLICATE", errorCode(rr))
+	})
+
+	t.Run("answered quotes cannot change", func(t *testing.T) {
+		quote := createQuote()
+		rr := serve(http.MethodPost, "/api/v1/quotes/"+quote.ID+"/reject", `{"reason":"Over budget"}`)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), `"rejection_reason":"Over budget"`)
+
+		rr = serve(http.MethodPost, "/api/v1/quotes/"+quote.ID+"/accept", "")
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code, rr.Body.String())
+
+		withdrawn := createQuote()
+		rr = serve(http.MethodPost, "/api/v1/quotes/"+withdrawn.ID+"/expire", "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), `"status":"expired"`)
+	})
+
+	t.Run("the scheduler expires lapsed quotes", func(t *testing.T) {
+		// Quotes cannot be created past their validity date, so lapsed quotes are stored directly
+		storeLapsed := func() *entity.Quote {
+			now := time.Now().UTC()
+			lapsed := &entity.Quote{}
+			require.NoError(t, json.Unmarshal([]byte(fmt.Sprintf(`{"id":%q,"clientId":%q,"currency":"EUR","lines":[{"description":"Audit","quantity":1,"unitAmount":50000}],
+				"validUntil":%q,"status":"open","createdAt":%q,"updatedAt":%q}`, uuid.New().String(), client.ID(),
+				now.Add(-time.Hour).Format(time.RFC3339), now.Format(time.RFC3339), now.Format(time.RFC3339))), lapsed))
+			require.NoError(t, quoteRepo.Save(lapsed))
+			return lapsed
+		}
+		accepted, lapsed := storeLapsed(), storeLapsed()
+
+		// Accepting a lapsed quote expires it
+		rr := serve(http.MethodPost, "/api/v1/quotes/"+accepted.ID()+"/accept", "")
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code, rr.Body.String())
+
+		rr = serve(http.MethodPost, "/api/v1/admin/quotes/run", "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), `"expired":["`+lapsed.ID()+`"]`)
+
+		rr = serve(http.MethodGet, "/api/v1/quotes?status=expired", "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), accepted.ID())
+		assert.Contains(t, rr.Body.String(), lapsed.ID())
+	})
+
+	t.Run("rejects unknown clients and unsupported methods", func(t *testing.T) {
+		rr := serve(http.MethodPost, "/api/v1/quotes", fmt.Sprintf(`{"client_id":%q,"currency":"EUR","valid_until":%q,"line_items":[{"description":"Work","quantity":1,"unit_amount":100}]}`,
+			uuid.New().String(), time.Now().UTC().AddDate(0, 0, 1).Format(time.RFC3339)))
+		assert.Equal(t, http.StatusNotFound, rr.Code, rr.Body.String())
+
+		rr = serve(http.MethodGet, "/api/v1/quotes?status=sent", "")
+		assert.Equal(t, http.StatusBadRequest, rr.Code, rr.Body.String())
+
+		rr = serve(http.MethodGet, "/api/v1/quotes/"+uuid.New().String()+"/convert", "")
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	})
+}
+
+func TestQuoteAPI_Authorization(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage)).
+		WithInvoices(repository.NewInvoiceRepository(storage.Collection(repository.InvoiceCollection)))
+	quoteService := application.NewQuoteService(repository.NewQuoteRepository(storage.Collection(repository.QuoteCollection)), billingService)
+	handler := httpserver.NewServerWithServices(httpserver.Services{Billing: billingService, Quotes: quoteService}, httpserver.ServerOptions{
+		AdminTokens:  map[string]string{"initech-admin": "initech-token", "globex-admin": "globex-token", "support": "support-token"},
+		AdminTenants: map[string][]string{"initech-admin": {"initech"}, "globex-admin": {"globex"}, "support": {"initech"}},
+		AdminScopes:  map[string][]string{"support": {httpserver.ScopeSupport}},
+	}).Handler()
+
+	send := func(method, path, token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rc := application.SystemContext("test", "initech")
+	client, err := billingService.CreateClient(rc, dtos.CreateClientRequest{Name: "Initech", Email: "ap@initech.example"})
+	require.NoError(t, err)
+	quote, err := quoteService.CreateQuote(rc, dtos.CreateQuoteRequest{
+		ClientID:   client.ID(),
+		Currency:   "EUR",
+		ValidUntil: time.Now().UTC().AddDate(0, 0, 30),
+		LineItems:  []dtos.InvoiceLineRequest{{Description: "Audit", Quantity: 1, UnitAmount: 50000}},
+	})
+	require.NoError(t, err)
+	path := "/api/v1/quotes/" + quote.ID()
+	quoteBody := fmt.Sprintf(`{"client_id":%q,"currency":"EUR","valid_until":%q,"line_items":[{"description":"Work","quantity":1,"unit_amount":100}]}`,
+		client.ID(), time.Now().UTC().AddDate(0, 0, 1).Format(time.RFC3339))
+
+	t.Run("anonymous callers are refused", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, send(http.MethodGet, "/api/v1/quotes", "", "").Code)
+		assert.Equal(t, http.StatusUnauthorized, send(http.MethodPost, "/api/v1/quotes", "", quoteBody).Code)
+		assert.Equal(t, http.StatusUnauthorized, send(http.MethodPost, path+"/accept", "", "").Code)
+		assert.Equal(t, http.StatusUnauthorized, send(http.MethodPost, path+"/convert", "", "").Code)
+	})
+
+	t.Run("quotes and clients of other tenants are not found", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, send(http.MethodGet, path, "globex-token", "").Code)
+		assert.Equal(t, http.StatusNotFound, send(http.MethodPost, path+"/accept", "globex-token", "").Code)
+		assert.Equal(t, http.StatusNotFound, send(http.MethodPost, path+"/convert", "globex-token", "").Code)
+		assert.Equal(t, http.StatusNotFound, send(http.MethodPost, "/api/v1/quotes", "globex-token", quoteBody).Code)
+
+		rr := send(http.MethodGet, "/api/v1/quotes", "globex-token", "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.NotContains(t, rr.Body.String(), quote.ID())
+	})
+
+	t.Run("admins without the finance scope only read quotes", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, send(http.MethodGet, path, "support-token", "").Code)
+		assert.Equal(t, http.StatusForbidden, send(http.MethodPost, path+"/accept", "support-token", "").Code)
+		assert.Equal(t, http.StatusForbidden, send(http.MethodPost, "/api/v1/quotes", "support-token", quoteBody).Code)
+	})
+
+	t.Run("the owning tenant converts its quotes into its invoices", func(t *testing.T) {
+		rr := send(http.MethodPost, path+"/accept", "initech-token", "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		rr = send(http.MethodPost, path+"/convert", "initech-token", "")
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		var response struct {
+			Data dtos.InvoiceResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		invoice, err := billingService.GetInvoice(application.SystemContext("test", "initech"), response.Data.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "initech", invoice.TenantID())
+	})
+}
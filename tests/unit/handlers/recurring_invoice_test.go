@@ -0,0 +1,208 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/messaging"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecurringInvoiceAPI(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage)).WithInvoices(repository.NewInvoiceRepository(storage.Collection(repository.InvoiceCollection)))
+	publisher := messaging.NewMemoryPublisher()
+	recurringService := application.NewRecurringInvoiceService(
+		repository.NewRecurringInvoiceTemplateRepository(storage.Collection(repository.RecurringInvoiceTemplateCollection)),
+		billingService,
+		publisher,
+	)
+	handler := httpserver.NewServerWithServices(httpserver.Services{
+		Billing:   billingService,
+		Recurring: recurringService,
+	}, httpserver.ServerOptions{
+		AdminTokens: map[string]string{"scheduler": "admin-token"},
+	}).Handler()
+
+	client, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+	require.NoError(t, err)
+
+	// Three monthly issue dates have passed, so the first scheduler run catches up on all of them
+	firstIssue := time.Now().UTC().AddDate(0, -2, -1).Truncate(time.Second)
+	body := fmt.Sprintf(`{"client_id":%q,"name":"Support retainer","currency":"EUR","frequency":"monthly","first_issue_date":%q,"auto_send":true,
+		"line_items":[{"description":"Retainer","quantity":1,"unit_amount":150000},{"description":"Hosting","quantity":2,"unit_amount":2500}]}`,
+		client.ID(), firstIssue.Format(time.RFC3339))
+
+	runScheduler := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/recurring-invoices/run", nil)
+		req.Header.Set("Authorization", "Bearer admin-token")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	var templateID string
+	t.Run("creates a template", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, asAdmin(httptest.NewRequest(http.MethodPost, "/api/v1/recurring-invoices", strings.NewReader(body))))
+
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		var response struct {
+			Data struct {
+				ID    string `json:"id"`
+				Total struct {
+					Amount int64 `json:"amount"`
+				} `json:"total"`
+				Active bool `json:"active"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		templateID = response.Data.ID
+		assert.Equal(t, int64(155000), response.Data.Total.Amount)
+		assert.True(t, response.Data.Active)
+	})
+
+	t.Run("the scheduler issues every due invoice once", func(t *testing.T) {
+		rr := runScheduler()
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), `"issued":3`)
+
+		messages := publisher.Messages()
+		require.Len(t, messages, 3)
+		var event application.RecurringInvoiceIssuedEvent
+		require.NoError(t, json.Unmarshal(messages[0].Payload, &event))
+		assert.Equal(t, application.RecurringInvoiceIssuedTopic, messages[0].Topic)
+		assert.Equal(t, templateID, event.TemplateID)
+		assert.Equal(t, int64(155000), event.Total)
+		assert.True(t, event.AutoSend)
+		assert.True(t, firstIssue.Equal(event.IssueDate))
+
+		// Each event announces an invoice issued by the billing service on its issue date
+		invoice, err := billingService.GetInvoice(application.AdminContext("ops"), event.InvoiceID)
+		require.NoError(t, err)
+		assert.Equal(t, entity.InvoiceIssued, invoice.Status())
+		assert.True(t, firstIssue.Equal(*invoice.IssuedAt()))
+		total, err := invoice.Total()
+		require.NoError(t, err)
+		assert.Equal(t, int64(155000), total.Amount())
+		page, err := billingService.ListInvoices(application.AdminContext("ops"), application.InvoiceFilter{ClientID: client.ID()}, "", 20)
+		require.NoError(t, err)
+		assert.Len(t, page.Invoices, 3)
+
+		rr = runScheduler()
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"issued":0`)
+		assert.Len(t, publisher.Messages(), 3)
+	})
+
+	t.Run("paused templates are skipped", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, asAdmin(httptest.NewRequest(http.MethodPut, "/api/v1/recurring-invoices/"+templateID, strings.NewReader(
+			`{"name":"Support retainer","frequency":"monthly","active":false,"line_items":[{"description":"Retainer","quantity":1,"unit_amount":160000}]}`))))
+
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), `"active":false`)
+		assert.Contains(t, rr.Body.String(), `"issued_count":3`)
+	})
+
+	t.Run("deletes a template", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, asAdmin(httptest.NewRequest(http.MethodDelete, "/api/v1/recurring-invoices/"+templateID, nil)))
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+
+		rr = httptest.NewRecorder()
+		handler.ServeHTTP(rr, asAdmin(httptest.NewRequest(http.MethodGet, "/api/v1/recurring-invoices/"+templateID, nil)))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("rejects templates for unknown clients", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, asAdmin(httptest.NewRequest(http.MethodPost, "/api/v1/recurring-invoices",
+			strings.NewReader(strings.Replace(body, client.ID(), "00000000-0000-0000-0000-000000000000", 1)))))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestRecurringInvoiceAPI_Authorization(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage)).WithInvoices(repository.NewInvoiceRepository(storage.Collection(repository.InvoiceCollection)))
+	recurringService := application.NewRecurringInvoiceService(
+		repository.NewRecurringInvoiceTemplateRepository(storage.Collection(repository.RecurringInvoiceTemplateCollection)),
+		billingService,
+		messaging.NewMemoryPublisher(),
+	)
+	handler := httpserver.NewServerWithServices(httpserver.Services{Billing: billingService, Recurring: recurringService}, httpserver.ServerOptions{
+		AdminTokens:  map[string]string{"initech-admin": "initech-token", "globex-admin": "globex-token", "support": "support-token"},
+		AdminTenants: map[string][]string{"initech-admin": {"initech"}, "globex-admin": {"globex"}, "support": {"initech"}},
+		AdminScopes:  map[string][]string{"support": {httpserver.ScopeSupport}},
+	}).Handler()
+
+	send := func(method, path, token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	client, err := billingService.CreateClient(application.SystemContext("test", "initech"), dtos.CreateClientRequest{Name: "Initech", Email: "ap@initech.example"})
+	require.NoError(t, err)
+	body := fmt.Sprintf(`{"client_id":%q,"name":"Retainer","currency":"EUR","frequency":"monthly","first_issue_date":%q,
+		"line_items":[{"description":"Retainer","quantity":1,"unit_amount":150000}]}`,
+		client.ID(), time.Now().UTC().Add(-time.Hour).Format(time.RFC3339))
+	update := `{"name":"Retainer","frequency":"monthly","line_items":[{"description":"Retainer","quantity":1,"unit_amount":1}]}`
+
+	t.Run("anonymous callers are refused", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, send(http.MethodGet, "/api/v1/recurring-invoices", "", "").Code)
+		assert.Equal(t, http.StatusUnauthorized, send(http.MethodPost, "/api/v1/recurring-invoices", "", body).Code)
+	})
+
+	rr := send(http.MethodPost, "/api/v1/recurring-invoices", "initech-token", body)
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	var created struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &created))
+	path := "/api/v1/recurring-invoices/" + created.Data.ID
+
+	t.Run("templates and clients of other tenants are not found", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, send(http.MethodGet, path, "globex-token", "").Code)
+		assert.Equal(t, http.StatusNotFound, send(http.MethodPut, path, "globex-token", update).Code)
+		assert.Equal(t, http.StatusNotFound, send(http.MethodDelete, path, "globex-token", "").Code)
+		assert.Equal(t, http.StatusNotFound, send(http.MethodPost, "/api/v1/recurring-invoices", "globex-token", body).Code)
+
+		rr := send(http.MethodGet, "/api/v1/recurring-invoices", "globex-token", "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.NotContains(t, rr.Body.String(), created.Data.ID)
+	})
+
+	t.Run("admins without the finance scope only read templates", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, send(http.MethodGet, path, "support-token", "").Code)
+		assert.Equal(t, http.StatusForbidden, send(http.MethodPut, path, "support-token", update).Code)
+		assert.Equal(t, http.StatusForbidden, send(http.MethodDelete, path, "support-token", "").Code)
+	})
+
+	t.Run("the scheduler invoices templates in their tenant", func(t *testing.T) {
+		run, err := recurringService.IssueDueInvoices(context.Background(), time.Now())
+		require.NoError(t, err)
+		require.Len(t, run.Issued, 1)
+		assert.Equal(t, "initech", run.Issued[0].Invoice.TenantID())
+	})
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/middleware"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestScope_BuildsRequestContext(t *testing.T) {
+	serve := func(decorate func(*http.Request) *http.Request) application.RequestContext {
+		var captured application.RequestContext
+		handler := middleware.RequestScope(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			captured = middleware.RequestContextFromContext(r.Context())
+		}))
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/clients", nil)
+		req.Header.Set(middleware.TenantHeader, "acme")
+		handler.ServeHTTP(httptest.NewRecorder(), decorate(req))
+		return captured
+	}
+
+	t.Run("anonymous callers carry no tenant, whatever they ask for", func(t *testing.T) {
+		rc := serve(func(r *http.Request) *http.Request { return r })
+		assert.Empty(t, rc.TenantID)
+		assert.Equal(t, application.PrincipalAnonymous, rc.Principal.Kind)
+		assert.Empty(t, rc.Actor())
+		assert.False(t, rc.IsAdmin())
+		assert.False(t, rc.Flags.Sandbox)
+	})
+
+	t.Run("admin actors", func(t *testing.T) {
+		rc := serve(func(r *http.Request) *http.Request {
+			return r.WithContext(middleware.WithAdminActor(r.Context(), "ops"))
+		})
+		assert.Equal(t, application.Principal{Kind: application.PrincipalAdmin, ID: "ops"}, rc.Principal)
+		assert.True(t, rc.IsAdmin())
+	})
+
+	t.Run("tenant resolved from the credentials", func(t *testing.T) {
+		rc := serve(func(r *http.Request) *http.Request {
+			return r.WithContext(middleware.WithTenantID(middleware.WithAdminActor(r.Context(), "acme-ops"), "acme"))
+		})
+		assert.Equal(t, "acme", rc.TenantID)
+	})
+
+	t.Run("portal clients", func(t *testing.T) {
+		rc := serve(func(r *http.Request) *http.Request {
+			return r.WithContext(middleware.WithPortalClient(r.Context(), "client-1"))
+		})
+		assert.Equal(t, application.Principal{Kind: application.PrincipalPortal, ID: "client-1"}, rc.Principal)
+		assert.False(t, rc.IsAdmin())
+	})
+
+	t.Run("resolved locale", func(t *testing.T) {
+		locale, err := valueobject.NewLocale("fr-BE")
+		require.NoError(t, err)
+		rc := serve(func(r *http.Request) *http.Request {
+			return r.WithContext(middleware.WithLocale(r.Context(), locale))
+		})
+		assert.Equal(t, locale, rc.Locale)
+	})
+}
+
+func TestRequestContext_CanAccessTenant(t *testing.T) {
+	rc := application.RequestContext{TenantID: "acme"}
+	assert.True(t, rc.CanAccessTenant("acme"))
+	assert.False(t, rc.CanAccessTenant(""), "resources without a tenant belong to no tenant caller")
+	assert.True(t, application.AdminContext("ops").CanAccessTenant(""))
+	assert.True(t, application.SystemContext("scheduler", "").CanAccessTenant(""))
+	assert.False(t, rc.CanAccessTenant("globex"))
+	assert.False(t, application.RequestContext{}.CanAccessTenant("acme"))
+}
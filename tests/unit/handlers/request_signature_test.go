@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+const signatureTestSecret = "s3cr3t"
+
+func newSignedRequest(t *testing.T, path, body string, signedAt time.Time, secret string) *http.Request {
+	t.Helper()
+
+	timestamp := strconv.FormatInt(signedAt.Unix(), 10)
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set(middleware.APIKeyHeader, "integration-1")
+	req.Header.Set(middleware.SignatureTimestampHeader, timestamp)
+	req.Header.Set(middleware.SignatureHeader, middleware.ComputeSignature(secret, timestamp, http.MethodPost, path, []byte(body)))
+	return req
+}
+
+func TestSignatureVerifier_Middleware(t *testing.T) {
+	now := time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC)
+	verifier := middleware.NewSignatureVerifier(middleware.SignatureConfig{
+		Enabled:      true,
+		RouteGroups:  []string{"/api/v1/webhooks"},
+		Secrets:      map[string]string{"integration-1": signatureTestSecret},
+		Tolerance:    time.Minute,
+		MaxBodyBytes: 64,
+	}).WithClock(func() time.Time { return now })
+
+	// Echo the body to prove it is still readable after verification
+	handler := verifier.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+		w.Write(body)
+	}))
+
+	t.Run("valid signature passes and body is preserved", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newSignedRequest(t, "/api/v1/webhooks/bank", `{"event":"a"}`, now, signatureTestSecret))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, `{"event":"a"}`, rr.Body.String())
+	})
+
+	t.Run("the query string is signed", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newSignedRequest(t, "/api/v1/webhooks/bank?account=1", `{"event":"q"}`, now, signatureTestSecret))
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		tampered := newSignedRequest(t, "/api/v1/webhooks/bank?account=1", `{"event":"r"}`, now, signatureTestSecret)
+		tampered.URL.RawQuery = "account=2"
+		rr = httptest.NewRecorder()
+		handler.ServeHTTP(rr, tampered)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Contains(t, rr.Body.String(), "SIGNATURE_INVALID")
+	})
+
+	t.Run("oversized body is refused before it is read", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newSignedRequest(t, "/api/v1/webhooks/bank", `{"event":"`+strings.Repeat("x", 64)+`"}`, now, signatureTestSecret))
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+		assert.Contains(t, rr.Body.String(), "BODY_TOO_LARGE")
+	})
+
+	t.Run("replayed signature is rejected", func(t *testing.T) {
+		first := httptest.NewRecorder()
+		handler.ServeHTTP(first, newSignedRequest(t, "/api/v1/webhooks/bank", `{"event":"b"}`, now, signatureTestSecret))
+		assert.Equal(t, http.StatusOK, first.Code)
+
+		replay := httptest.NewRecorder()
+		handler.ServeHTTP(replay, newSignedRequest(t, "/api/v1/webhooks/bank", `{"event":"b"}`, now, signatureTestSecret))
+		assert.Equal(t, http.StatusUnauthorized, replay.Code)
+		assert.Contains(t, replay.Body.String(), "SIGNATURE_REPLAYED")
+	})
+
+	t.Run("wrong secret is rejected", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newSignedRequest(t, "/api/v1/webhooks/bank", `{"event":"c"}`, now, "wrong"))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Contains(t, rr.Body.String(), "SIGNATURE_INVALID")
+	})
+
+	t.Run("stale timestamp is rejected", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newSignedRequest(t, "/api/v1/webhooks/bank", `{"event":"d"}`, now.Add(-2*time.Minute), signatureTestSecret))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Contains(t, rr.Body.String(), "SIGNATURE_EXPIRED")
+	})
+
+	t.Run("missing headers are rejected", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/api/v1/webhooks/bank", strings.NewReader("{}")))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Contains(t, rr.Body.String(), "SIGNATURE_MISSING")
+	})
+
+	t.Run("routes outside signed groups are not checked", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/api/v1/clients", strings.NewReader("{}")))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+}
+
+func TestReplayCache_Remember(t *testing.T) {
+	now := time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC)
+	cache := middleware.NewReplayCache()
+
+	assert.True(t, cache.Remember("late", now.Add(3*time.Minute), now))
+	assert.True(t, cache.Remember("early", now.Add(time.Minute), now))
+	assert.True(t, cache.Remember("middle", now.Add(2*time.Minute), now))
+	assert.False(t, cache.Remember("early", now.Add(time.Minute), now))
+
+	// Only the keys past their expiry are evicted, in expiry order
+	later := now.Add(90 * time.Second)
+	assert.True(t, cache.Remember("fresh", later.Add(time.Minute), later))
+	assert.Equal(t, 3, cache.Len())
+	assert.True(t, cache.Remember("early", later.Add(time.Minute), later), "expired keys can be used again")
+	assert.False(t, cache.Remember("middle", later.Add(time.Minute), later))
+
+	assert.True(t, cache.Remember("last", now.Add(time.Hour), now.Add(10*time.Minute)))
+	assert.Equal(t, 1, cache.Len())
+}
@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/middleware"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/gateway"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/messaging"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/tracing"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testTraceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	testTracestate  = "congo=t61rcWkgMzE"
+)
+
+func TestAPI_RequestTracing(t *testing.T) {
+	// The payment gateway records the headers of the calls it receives
+	var (
+		gatewayMutex   sync.Mutex
+		gatewayHeaders []http.Header
+	)
+	gatewayServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gatewayMutex.Lock()
+		gatewayHeaders = append(gatewayHeaders, r.Header.Clone())
+		gatewayMutex.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"ch_1","status":"succeeded"}`))
+	}))
+	defer gatewayServer.Close()
+	lastGatewayCall := func() http.Header {
+		gatewayMutex.Lock()
+		defer gatewayMutex.Unlock()
+		require.NotEmpty(t, gatewayHeaders)
+		return gatewayHeaders[len(gatewayHeaders)-1]
+	}
+
+	newHandler := func(config middleware.TracingConfig) (http.Handler, *messaging.MemoryPublisher, string) {
+		storage := infrastructure.NewInMemoryStorage()
+		bus := messaging.NewMemoryPublisher()
+		auditService := application.NewAuditService(repository.NewAuditRepository(storage.Collection(repository.AuditCollection)))
+		billingService := application.NewBillingService(repository.NewClientRepository(storage)).
+			WithInvoices(repository.NewInvoiceRepository(storage.Collection(repository.InvoiceCollection))).
+			WithPayments(repository.NewPaymentRepository(storage.Collection(repository.PaymentCollection)))
+		orchestrator := application.NewSagaOrchestrator(repository.NewSagaRepository(storage.Collection(repository.SagaCollection)), auditService, 0, 0)
+		payments := application.NewInvoicePaymentService(orchestrator, billingService,
+			gateway.NewChargeClient(gatewayServer.URL, "gateway-key", gatewayServer.Client()), tracing.NewPublisher(bus))
+
+		client, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+		require.NoError(t, err)
+		invoice, err := billingService.CreateInvoice(application.SystemContext("test", ""), dtos.CreateInvoiceRequest{
+			ClientID:  client.ID(),
+			Currency:  "EUR",
+			LineItems: []dtos.InvoiceLineRequest{{Description: "Consulting", Quantity: 1, UnitAmount: 12000}},
+		})
+		require.NoError(t, err)
+		_, err = billingService.IssueInvoice(application.AdminContext("ops"), invoice.ID(), time.Now())
+		require.NoError(t, err)
+
+		handler := httpserver.NewServerWithServices(httpserver.Services{
+			Billing:         billingService,
+			Audit:           auditService,
+			Sagas:           orchestrator,
+			InvoicePayments: payments,
+		}, httpserver.ServerOptions{
+			AdminTokens: map[string]string{"ops": "admin-token"},
+			Tracing:     config,
+		}).Handler()
+		return handler, bus, invoice.ID()
+	}
+	pay := func(handler http.Handler, invoiceID string, headers map[string]string) *httptest.ResponseRecorder {
+		body := `{"payment_method":"pm_card"}`
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/invoices/"+invoiceID+"/payments", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer admin-token")
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		return rr
+	}
+
+	t.Run("honors and propagates the gateway request ID and W3C trace headers", func(t *testing.T) {
+		handler, bus, invoiceID := newHandler(middleware.TracingConfig{})
+
+		rr := pay(handler, invoiceID, map[string]string{
+			"X-Request-ID": "gw-req-42",
+			"traceparent":  testTraceparent,
+			"tracestate":   testTracestate,
+		})
+		assert.Equal(t, "gw-req-42", rr.Header().Get("X-Request-ID"))
+
+		call := lastGatewayCall()
+		assert.Equal(t, "gw-req-42", call.Get("X-Request-ID"))
+		assert.Equal(t, testTraceparent, call.Get("traceparent"))
+		assert.Equal(t, testTracestate, call.Get("tracestate"))
+
+		messages := bus.Messages()
+		require.NotEmpty(t, messages)
+		for _, message := range messages {
+			assert.Equal(t, "gw-req-42", message.Headers[tracing.RequestIDMessageHeader], message.Topic)
+			assert.Equal(t, testTraceparent, message.Headers["traceparent"], message.Topic)
+			assert.Equal(t, testTracestate, message.Headers["tracestate"], message.Topic)
+			assert.Equal(t, "application/json", message.Headers["content-type"], message.Topic)
+		}
+	})
+
+	t.Run("passes B3 headers through", func(t *testing.T) {
+		handler, bus, invoiceID := newHandler(middleware.TracingConfig{})
+
+		pay(handler, invoiceID, map[string]string{
+			"X-B3-TraceId": "80f198ee56343ba864fe8b2a57d3eff7",
+			"X-B3-SpanId":  "e457b5a2e4d86bd1",
+			"X-B3-Sampled": "1",
+		})
+
+		call := lastGatewayCall()
+		assert.Equal(t, "80f198ee56343ba864fe8b2a57d3eff7", call.Get("X-B3-TraceId"))
+		assert.Equal(t, "e457b5a2e4d86bd1", call.Get("X-B3-SpanId"))
+		assert.Equal(t, "1", call.Get("X-B3-Sampled"))
+		assert.Equal(t, "80f198ee56343ba864fe8b2a57d3eff7", bus.Messages()[0].Headers["x-b3-traceid"])
+	})
+
+	t.Run("generates a request ID when none was received", func(t *testing.T) {
+		handler, bus, invoiceID := newHandler(middleware.TracingConfig{})
+
+		rr := pay(handler, invoiceID, nil)
+		requestID := rr.Header().Get("X-Request-ID")
+		_, err := uuid.Parse(requestID)
+		require.NoError(t, err)
+
+		call := lastGatewayCall()
+		assert.Equal(t, requestID, call.Get("X-Request-ID"))
+		assert.Empty(t, call.Get("traceparent"))
+		assert.Equal(t, requestID, bus.Messages()[0].Headers[tracing.RequestIDMessageHeader])
+	})
+
+	t.Run("drops malformed identifiers", func(t *testing.T) {
+		handler, _, invoiceID := newHandler(middleware.TracingConfig{})
+
+		rr := pay(handler, invoiceID, map[string]string{
+			"X-Request-ID": "bad id\twith spaces",
+			"traceparent":  "00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+			"tracestate":   testTracestate,
+		})
+		assert.NotEqual(t, "bad id\twith spaces", rr.Header().Get("X-Request-ID"))
+
+		call := lastGatewayCall()
+		assert.Empty(t, call.Get("traceparent"))
+		assert.Empty(t, call.Get("tracestate"), "a tracestate without its traceparent is dropped")
+	})
+
+	t.Run("uses the configured request ID header", func(t *testing.T) {
+		handler, _, invoiceID := newHandler(middleware.TracingConfig{RequestIDHeader: "X-Correlation-ID"})
+
+		rr := pay(handler, invoiceID, map[string]string{"X-Correlation-ID": "corr-7"})
+		assert.Equal(t, "corr-7", rr.Header().Get("X-Correlation-ID"))
+		assert.Equal(t, "corr-7", lastGatewayCall().Get("X-Correlation-ID"))
+	})
+
+	t.Run("ignores incoming identifiers when no trusted gateway is in front", func(t *testing.T) {
+		handler, _, invoiceID := newHandler(middleware.TracingConfig{IgnoreIncoming: true})
+
+		rr := pay(handler, invoiceID, map[string]string{
+			"X-Request-ID": "client-chosen",
+			"traceparent":  testTraceparent,
+		})
+		assert.NotEqual(t, "client-chosen", rr.Header().Get("X-Request-ID"))
+
+		call := lastGatewayCall()
+		assert.Equal(t, rr.Header().Get("X-Request-ID"), call.Get("X-Request-ID"))
+		assert.Empty(t, call.Get("traceparent"))
+	})
+
+	t.Run("requests without propagation leave downstream headers untouched", func(t *testing.T) {
+		bus := messaging.NewMemoryPublisher()
+		publisher := tracing.NewPublisher(bus)
+		require.NoError(t, publisher.Publish(context.Background(), messaging.Message{Topic: "billing.test", Headers: map[string]string{"content-type": "application/json"}}))
+		assert.Equal(t, map[string]string{"content-type": "application/json"}, bus.Messages()[0].Headers)
+	})
+}
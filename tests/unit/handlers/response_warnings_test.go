@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/middleware"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/valueobject"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI_ResponseWarnings(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage)).
+		WithInvoices(repository.NewInvoiceRepository(storage.Collection(repository.InvoiceCollection)))
+	auditService := application.NewAuditService(repository.NewAuditRepository(storage.Collection(repository.AuditCollection)))
+
+	approaching, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+	require.NoError(t, err)
+	over, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Globex", Email: "billing@globex.example"})
+	require.NoError(t, err)
+	comfortable, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Initech", Email: "billing@initech.example"})
+	require.NoError(t, err)
+
+	money := func(amount int64) valueobject.Money {
+		m, err := valueobject.NewMoney(amount, "EUR")
+		require.NoError(t, err)
+		return m
+	}
+	creditService := application.NewCreditControlService(
+		repository.NewClientCreditLimitRepository(storage.Collection(repository.ClientCreditLimitCollection)),
+		billingService,
+		auditService,
+	).WithOpenInvoices(staticOpenInvoices{
+		{InvoiceID: "invoice-1", Number: "INV-0001", ClientID: approaching.ID(), Outstanding: money(85000)},
+		{InvoiceID: "invoice-2", Number: "INV-0002", ClientID: over.ID(), Outstanding: money(120000)},
+		{InvoiceID: "invoice-3", Number: "INV-0003", ClientID: comfortable.ID(), Outstanding: money(10000)},
+	})
+	for _, clientID := range []string{approaching.ID(), over.ID(), comfortable.ID()} {
+		_, err := creditService.SetLimit("ops", clientID, dtos.SetCreditLimitRequest{Amount: 100000, Currency: "EUR", Policy: "warn"})
+		require.NoError(t, err)
+	}
+
+	handler := httpserver.NewServerWithServices(httpserver.Services{
+		Billing: billingService,
+		Audit:   auditService,
+		Credit:  creditService,
+	}, httpserver.ServerOptions{
+		AdminTokens:            map[string]string{"ops": "admin-token"},
+		CreditWarningThreshold: 0.8,
+		DeprecatedParameters:   map[string]string{"per_page": "use limit instead"},
+	}).Handler()
+
+	type envelope struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+		Warnings []dtos.Warning `json:"warnings"`
+	}
+	serve := func(method, path, body string) (*httptest.ResponseRecorder, envelope) {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer admin-token")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		var response envelope
+		_ = json.Unmarshal(rr.Body.Bytes(), &response)
+		return rr, response
+	}
+	createInvoice := func(t *testing.T, clientID string) envelope {
+		t.Helper()
+		body := fmt.Sprintf(`{"client_id":%q,"currency":"EUR","line_items":[{"description":"Consulting","quantity":1,"unit_amount":1000}]}`, clientID)
+		rr, response := serve(http.MethodPost, "/api/v1/invoices", body)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		return response
+	}
+
+	t.Run("an invoice of a client approaching its credit limit is created with a warning", func(t *testing.T) {
+		response := createInvoice(t, approaching.ID())
+		require.Len(t, response.Warnings, 1)
+		assert.Equal(t, "CREDIT_LIMIT_APPROACHING", response.Warnings[0].Code)
+		assert.Contains(t, response.Warnings[0].Message, "1000.00 EUR (85% used)")
+	})
+
+	t.Run("an invoice of a client over its credit limit is created with a warning", func(t *testing.T) {
+		response := createInvoice(t, over.ID())
+		require.Len(t, response.Warnings, 1)
+		assert.Equal(t, "CREDIT_LIMIT_EXCEEDED", response.Warnings[0].Code)
+	})
+
+	t.Run("responses within soft limits carry no warnings", func(t *testing.T) {
+		rr, _ := serve(http.MethodPost, "/api/v1/invoices",
+			fmt.Sprintf(`{"client_id":%q,"currency":"EUR","line_items":[{"description":"Consulting","quantity":1,"unit_amount":1000}]}`, comfortable.ID()))
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		assert.NotContains(t, rr.Body.String(), "warnings")
+	})
+
+	t.Run("reading an invoice does not check the credit limit", func(t *testing.T) {
+		created := createInvoice(t, approaching.ID())
+		rr, response := serve(http.MethodGet, "/api/v1/invoices/"+created.Data.ID, "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Empty(t, response.Warnings)
+	})
+
+	t.Run("a deprecated query parameter is reported", func(t *testing.T) {
+		rr, response := serve(http.MethodGet, "/api/v1/clients?per_page=10", "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		require.Len(t, response.Warnings, 1)
+		assert.Equal(t, dtos.Warning{
+			Code:    "DEPRECATED_PARAMETER",
+			Message: "Query parameter per_page is deprecated: use limit instead",
+			Field:   "per_page",
+		}, response.Warnings[0])
+	})
+
+	t.Run("errors carry no warnings", func(t *testing.T) {
+		rr, _ := serve(http.MethodGet, "/api/v1/invoices/unknown?per_page=10", "")
+		require.Equal(t, http.StatusNotFound, rr.Code, rr.Body.String())
+		assert.NotContains(t, rr.Body.String(), "warnings")
+	})
+}
+
+func TestResponseWarnings_AddWarning(t *testing.T) {
+	pipeline := middleware.NewResponseWarnings(func(r *http.Request, data interface{}) []middleware.Warning {
+		return []middleware.Warning{{Code: "NEAR_QUOTA", Message: "Quota nearly used"}}
+	})
+
+	var warnings []middleware.Warning
+	handler := pipeline.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		middleware.AddWarning(r.Context(), middleware.Warning{Code: "DEPRECATED_FIELD", Message: "po_number is deprecated", Field: "po_number"})
+		middleware.AddWarning(r.Context(), middleware.Warning{Code: "NEAR_QUOTA", Message: "Quota nearly used"})
+
+		source, ok := w.(middleware.WarningSource)
+		require.True(t, ok)
+		warnings = source.ResponseWarnings(nil)
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	// Handler warnings come first and duplicates are dropped
+	assert.Equal(t, []middleware.Warning{
+		{Code: "DEPRECATED_FIELD", Message: "po_number is deprecated", Field: "po_number"},
+		{Code: "NEAR_QUOTA", Message: "Quota nearly used"},
+	}, warnings)
+
+	// Outside the pipeline, warnings are ignored
+	middleware.AddWarning(httptest.NewRequest(http.MethodGet, "/", nil).Context(), middleware.Warning{Code: "IGNORED"})
+}
@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/messaging"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubRiskProvider scores every client with the next configured score and counts the calls
+type stubRiskProvider struct {
+	score service.RiskScore
+	err   error
+	calls int
+}
+
+func (p *stubRiskProvider) Name() string {
+	return "stub-bureau"
+}
+
+func (p *stubRiskProvider) ScoreClient(ctx context.Context, subject service.RiskSubject) (service.RiskScore, error) {
+	p.calls++
+	return p.score, p.err
+}
+
+func TestAPI_ClientRiskScoring(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	publisher := messaging.NewMemoryPublisher()
+	provider := &stubRiskProvider{score: service.RiskScore{
+		Score:   25,
+		Reasons: []entity.RiskReason{{Code: "PAYMENT_HISTORY", Description: "No late payments on file"}},
+	}}
+	riskService := application.NewRiskScoringService(
+		repository.NewRiskAssessmentRepository(storage.Collection(repository.RiskAssessmentCollection)),
+		time.Hour,
+	).WithProvider(provider).WithLargeInvoiceThreshold(50000)
+	billingService := application.NewBillingService(repository.NewClientRepository(storage)).WithInvoices(repository.NewInvoiceRepository(storage.Collection(repository.InvoiceCollection))).WithRiskScoring(riskService)
+
+	handler := httpserver.NewServerWithServices(httpserver.Services{
+		Billing: billingService,
+		Risk:    riskService,
+		Recurring: application.NewRecurringInvoiceService(
+			repository.NewRecurringInvoiceTemplateRepository(storage.Collection(repository.RecurringInvoiceTemplateCollection)),
+			billingService,
+			publisher,
+		).WithRiskScoring(riskService),
+	}, httpserver.ServerOptions{
+		AdminTokens: map[string]string{"ops": "admin-token"},
+	}).Handler()
+
+	serve := func(method, path, body, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+	type clientResponse struct {
+		Data struct {
+			ID   string `json:"id"`
+			Risk *struct {
+				Score    int    `json:"score"`
+				Decision string `json:"decision"`
+				Reasons  []struct {
+					Code string `json:"code"`
+				} `json:"reasons"`
+				Provider string `json:"provider"`
+				Trigger  string `json:"trigger"`
+				Expired  bool   `json:"expired"`
+			} `json:"risk"`
+		} `json:"data"`
+	}
+	decode := func(rr *httptest.ResponseRecorder) clientResponse {
+		t.Helper()
+		var response clientResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		return response
+	}
+
+	rr := serve(http.MethodPost, "/api/v1/clients", `{"name":"Acme Corp","email":"billing@acme.example"}`, "admin-token")
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	created := decode(rr)
+	clientID := created.Data.ID
+
+	t.Run("scores new clients on onboarding", func(t *testing.T) {
+		assert.Equal(t, 1, provider.calls)
+		require.NotNil(t, created.Data.Risk)
+		assert.Equal(t, 25, created.Data.Risk.Score)
+		assert.Equal(t, "approve", created.Data.Risk.Decision)
+		assert.Equal(t, "onboarding", created.Data.Risk.Trigger)
+		assert.Equal(t, "stub-bureau", created.Data.Risk.Provider)
+	})
+
+	t.Run("shows the latest score to admins only", func(t *testing.T) {
+		response := decode(serve(http.MethodGet, "/api/v1/clients/"+clientID, "", "admin-token"))
+		require.NotNil(t, response.Data.Risk)
+		require.Len(t, response.Data.Risk.Reasons, 1)
+		assert.Equal(t, "PAYMENT_HISTORY", response.Data.Risk.Reasons[0].Code)
+		assert.False(t, response.Data.Risk.Expired)
+
+		// Clients are only visible to admins
+		rr := serve(http.MethodGet, "/api/v1/clients/"+clientID, "", "")
+		require.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.NotContains(t, rr.Body.String(), `"risk"`)
+
+		rr = serve(http.MethodGet, "/api/v1/clients/"+clientID, "", "wrong-token")
+		require.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.NotContains(t, rr.Body.String(), `"risk"`)
+	})
+
+	t.Run("a provider outage does not block onboarding", func(t *testing.T) {
+		provider.err = fmt.Errorf("bureau unavailable")
+		defer func() { provider.err = nil }()
+
+		rr := serve(http.MethodPost, "/api/v1/clients", `{"name":"Globex","email":"billing@globex.example"}`, "admin-token")
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		assert.Nil(t, decode(rr).Data.Risk)
+	})
+
+	createTemplate := func(unitAmount int64) {
+		t.Helper()
+		body := fmt.Sprintf(`{"client_id":%q,"name":"Retainer","currency":"EUR","frequency":"monthly","first_issue_date":%q,
+			"line_items":[{"description":"Retainer","quantity":1,"unit_amount":%d}]}`,
+			clientID, time.Now().UTC().Add(-time.Hour).Format(time.RFC3339), unitAmount)
+		rr := serve(http.MethodPost, "/api/v1/recurring-invoices", body, "admin-token")
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	}
+	type runResponse struct {
+		Data struct {
+			Issued       int `json:"issued"`
+			Held         int `json:"held"`
+			HeldInvoices []struct {
+				Decision string `json:"decision"`
+			} `json:"held_invoices"`
+		} `json:"data"`
+	}
+	runScheduler := func() runResponse {
+		t.Helper()
+		rr := serve(http.MethodPost, "/api/v1/admin/recurring-invoices/run", "", "admin-token")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var response runResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		return response
+	}
+
+	t.Run("large invoices reuse the cached score", func(t *testing.T) {
+		calls := provider.calls
+		createTemplate(60000)
+
+		run := runScheduler()
+		assert.Equal(t, 1, run.Data.Issued)
+		assert.Equal(t, calls, provider.calls)
+	})
+
+	t.Run("large invoices of declined clients are held", func(t *testing.T) {
+		// A declined score is only picked up once the cached approval expires
+		assessment, err := entity.NewRiskAssessment(clientID, "stub-bureau", 25, entity.RiskDecisionApprove, nil, entity.RiskTriggerOnboarding, time.Now().Add(-2*time.Hour), time.Hour)
+		require.NoError(t, err)
+		require.NoError(t, repository.NewRiskAssessmentRepository(storage.Collection(repository.RiskAssessmentCollection)).Save(assessment))
+		provider.score = service.RiskScore{Score: 85}
+
+		createTemplate(80000)
+		run := runScheduler()
+		assert.Equal(t, 0, run.Data.Issued)
+		require.Equal(t, 1, run.Data.Held)
+		assert.Equal(t, "risk_declined", run.Data.HeldInvoices[0].Decision)
+
+		response := decode(serve(http.MethodGet, "/api/v1/clients/"+clientID, "", "admin-token"))
+		require.NotNil(t, response.Data.Risk)
+		assert.Equal(t, "decline", response.Data.Risk.Decision)
+		assert.Equal(t, "large_invoice", response.Data.Risk.Trigger)
+	})
+
+	t.Run("small invoices are not scored", func(t *testing.T) {
+		calls := provider.calls
+		createTemplate(1000)
+
+		run := runScheduler()
+		assert.Equal(t, 1, run.Data.Issued)
+		assert.Equal(t, calls, provider.calls)
+	})
+}
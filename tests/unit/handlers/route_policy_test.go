@@ -0,0 +1,249 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gjaminon-go-labs/billing-api/api"
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/middleware"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestRoutePolicies_EveryMutatingRouteIsExplicit(t *testing.T) {
+	var spec struct {
+		Paths map[string]map[string]yaml.Node `yaml:"paths"`
+	}
+	require.NoError(t, yaml.Unmarshal(api.OpenAPISpec, &spec))
+	require.NotEmpty(t, spec.Paths)
+
+	server := httpserver.NewServerWithServices(httpserver.Services{}, httpserver.ServerOptions{})
+	pathParameter := regexp.MustCompile(`\{[^}]+\}`)
+	mutating := map[string]bool{"post": true, "put": true, "patch": true, "delete": true}
+	for path, operations := range spec.Paths {
+		for method, node := range operations {
+			if !mutating[method] {
+				continue
+			}
+			var operation struct {
+				Security []map[string][]string `yaml:"security"`
+			}
+			require.NoError(t, node.Decode(&operation))
+			route := strings.ToUpper(method) + " " + path
+			policy, explicit := server.RoutePolicy(strings.ToUpper(method), pathParameter.ReplaceAllString(path, "sample"))
+			assert.True(t, explicit, "%s has no explicit authorization policy", route)
+
+			// Operations documented as admin only must require an admin
+			if len(operation.Security) == 1 {
+				if _, adminOnly := operation.Security[0]["adminToken"]; adminOnly {
+					assert.Equal(t, middleware.RoleAdmin, policy.Role, route)
+				}
+			}
+		}
+	}
+}
+
+func TestAPI_RouteAuthorization(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage))
+	auditService := application.NewAuditService(repository.NewAuditRepository(storage.Collection(repository.AuditCollection)))
+	handler := httpserver.NewServerWithServices(httpserver.Services{
+		Billing: billingService,
+		Audit:   auditService,
+		Dunning: application.NewDunningPolicyService(
+			repository.NewDunningPolicyRepository(storage.Collection(repository.DunningPolicyCollection)),
+			auditService,
+		),
+		Credit: application.NewCreditControlService(
+			repository.NewClientCreditLimitRepository(storage.Collection(repository.ClientCreditLimitCollection)),
+			billingService,
+			auditService,
+		),
+	}, httpserver.ServerOptions{
+		AdminTokens: map[string]string{
+			"ops":        "ops-token",
+			"scheduler":  "scheduler-token",
+			"acme-admin": "acme-token",
+		},
+		AdminScopes:  map[string][]string{"scheduler": {httpserver.ScopeOperations}},
+		AdminTenants: map[string][]string{"acme-admin": {"acme"}},
+	}).Handler()
+
+	client, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+	require.NoError(t, err)
+
+	serve := func(method, path, token, body string) (*httptest.ResponseRecorder, dtos.ErrorResponse) {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		var response dtos.ErrorResponse
+		_ = json.Unmarshal(rr.Body.Bytes(), &response)
+		return rr, response
+	}
+	creditPath := "/api/v1/clients/" + client.ID() + "/credit"
+	creditLimit := `{"amount":100000,"currency":"EUR","policy":"warn"}`
+	cadence := `{"stages":[{"day_offset":5,"channel":"email","template":"nudge"}]}`
+
+	t.Run("admin routes outside the admin prefix require credentials", func(t *testing.T) {
+		rr, response := serve(http.MethodPut, creditPath, "", creditLimit)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Equal(t, "UNAUTHORIZED", response.Error.Code)
+	})
+
+	t.Run("unrestricted admins reach every route", func(t *testing.T) {
+		rr, _ := serve(http.MethodPut, creditPath, "ops-token", creditLimit)
+		assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		rr, _ = serve(http.MethodGet, "/api/v1/admin/dunning-policies", "ops-token", "")
+		assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	})
+
+	t.Run("admins need the scope of the route", func(t *testing.T) {
+		rr, response := serve(http.MethodPut, creditPath, "scheduler-token", creditLimit)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		assert.Equal(t, "SCOPE_NOT_GRANTED", response.Error.Code)
+		assert.Contains(t, response.Error.Message, httpserver.ScopeFinance)
+
+		rr, _ = serve(http.MethodGet, "/api/v1/admin/dunning-policies/acme", "scheduler-token", "")
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("tenant admins only reach the tenants they own", func(t *testing.T) {
+		rr, _ := serve(http.MethodPut, "/api/v1/admin/dunning-policies/acme", "acme-token", cadence)
+		assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		rr, response := serve(http.MethodPut, "/api/v1/admin/dunning-policies/globex", "acme-token", cadence)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		assert.Equal(t, "TENANT_NOT_ALLOWED", response.Error.Code)
+
+		// Routes spanning every tenant are out of reach
+		rr, _ = serve(http.MethodGet, "/api/v1/admin/dunning-policies", "acme-token", "")
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		rr, _ = serve(http.MethodPut, creditPath, "acme-token", creditLimit)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("client routes require an admin of any scope", func(t *testing.T) {
+		rr, _ := serve(http.MethodGet, creditPath, "", "")
+		assert.Equal(t, http.StatusUnauthorized, rr.Code, rr.Body.String())
+		rr, _ = serve(http.MethodGet, creditPath, "scheduler-token", "")
+		assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		rr, _ = serve(http.MethodGet, "/api/v1/clients/"+client.ID(), "scheduler-token", "")
+		assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	})
+
+	t.Run("unknown admin routes still require an admin", func(t *testing.T) {
+		rr, _ := serve(http.MethodGet, "/api/v1/admin/unknown", "", "")
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		rr, _ = serve(http.MethodGet, "/api/v1/admin/unknown", "ops-token", "")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestAPI_ClientSubResourceOwnership(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage))
+	auditService := application.NewAuditService(repository.NewAuditRepository(storage.Collection(repository.AuditCollection)))
+	handler := httpserver.NewServerWithServices(httpserver.Services{
+		Billing: billingService,
+		Audit:   auditService,
+		Credit: application.NewCreditControlService(
+			repository.NewClientCreditLimitRepository(storage.Collection(repository.ClientCreditLimitCollection)),
+			billingService,
+			auditService,
+		),
+	}, httpserver.ServerOptions{AdminTokens: map[string]string{"ops": "ops-token"}}).Handler()
+
+	acmeClient, err := billingService.CreateClient(application.SystemContext("test", "acme"), dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+	require.NoError(t, err)
+	sharedClient, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Initech", Email: "billing@initech.example"})
+	require.NoError(t, err)
+
+	serve := func(method, path, tenantID, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer ops-token")
+		if tenantID != "" {
+			req.Header.Set(middleware.TenantHeader, tenantID)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+	creditPath := "/api/v1/clients/" + acmeClient.ID() + "/credit"
+
+	t.Run("the owning tenant reaches the sub-resources of its clients", func(t *testing.T) {
+		rr := serve(http.MethodGet, creditPath, "acme", "")
+		assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		rr = serve(http.MethodPut, creditPath, "acme", `{"amount":100000,"currency":"EUR","policy":"warn"}`)
+		assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	})
+
+	t.Run("clients of another tenant are not found", func(t *testing.T) {
+		for _, tenantID := range []string{"globex", ""} {
+			rr := serve(http.MethodGet, creditPath, tenantID, "")
+			assert.Equal(t, http.StatusNotFound, rr.Code, tenantID)
+			rr = serve(http.MethodDelete, creditPath, tenantID, "")
+			assert.Equal(t, http.StatusNotFound, rr.Code, tenantID)
+			rr = serve(http.MethodPost, "/api/v1/clients/"+acmeClient.ID()+"/statements", tenantID, `{}`)
+			assert.Equal(t, http.StatusNotFound, rr.Code, tenantID)
+		}
+
+		rr := serve(http.MethodGet, creditPath, "acme", "")
+		assert.Contains(t, rr.Body.String(), `"credit_limit"`, "the limit set by acme is kept")
+	})
+
+	t.Run("clients without a tenant stay shared", func(t *testing.T) {
+		rr := serve(http.MethodGet, "/api/v1/clients/"+sharedClient.ID()+"/credit", "globex", "")
+		assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	})
+}
+
+func TestAPI_CacheControl(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage))
+	handler := httpserver.NewServerWithServices(httpserver.Services{Billing: billingService}, httpserver.ServerOptions{
+		AdminTokens:      map[string]string{"ops": "ops-token"},
+		EnablePlayground: true,
+	}).Handler()
+
+	client, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+	require.NoError(t, err)
+
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(method, path, nil))
+		return rr
+	}
+
+	t.Run("client data is never stored", func(t *testing.T) {
+		for _, path := range []string{"/api/v1/clients", "/api/v1/clients/" + client.ID(), "/api/v1/clients/unknown", "/health"} {
+			rr := serve(http.MethodGet, path)
+			assert.Equal(t, "no-store", rr.Header().Get("Cache-Control"), path)
+		}
+		rr := serve(http.MethodGet, "/api/v1/admin/audit-log")
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Equal(t, "no-store", rr.Header().Get("Cache-Control"))
+	})
+
+	t.Run("reference data is cached for a short while", func(t *testing.T) {
+		rr := serve(http.MethodGet, "/playground/openapi.yaml")
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "public, max-age=300", rr.Header().Get("Cache-Control"))
+
+		rr = serve(http.MethodPost, "/playground/openapi.yaml")
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+		assert.Equal(t, "no-store", rr.Header().Get("Cache-Control"))
+	})
+}
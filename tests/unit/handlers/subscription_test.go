@@ -0,0 +1,369 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/service"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscriptionAPI(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage)).
+		WithInvoices(repository.NewInvoiceRepository(storage.Collection(repository.InvoiceCollection))).
+		WithPayments(repository.NewPaymentRepository(storage.Collection(repository.PaymentCollection)))
+	subscriptionService := application.NewSubscriptionService(
+		repository.NewSubscriptionRepository(storage.Collection(repository.SubscriptionCollection)),
+		billingService,
+	)
+	handler := httpserver.NewServerWithServices(httpserver.Services{
+		Billing:       billingService,
+		Subscriptions: subscriptionService,
+	}, httpserver.ServerOptions{
+		AdminTokens: map[string]string{"scheduler": "admin-token"},
+	}).Handler()
+
+	client, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+	require.NoError(t, err)
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer admin-token")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+	runScheduler := func() dtos.SubscriptionBillingRunResponse {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/subscriptions/run", nil)
+		req.Header.Set("Authorization", "Bearer admin-token")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var response struct {
+			Data dtos.SubscriptionBillingRunResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		return response.Data
+	}
+	createSubscription := func(clientID string, start time.Time) *httptest.ResponseRecorder {
+		return serve(http.MethodPost, "/api/v1/subscriptions", fmt.Sprintf(
+			`{"client_id":%q,"plan":"Pro","currency":"EUR","unit_amount":4900,"quantity":2,"tax_rate_bps":2000,"interval":"monthly","start_date":%q}`,
+			clientID, start.Format(time.RFC3339)))
+	}
+	decodeSubscription := func(rr *httptest.ResponseRecorder) dtos.SubscriptionResponse {
+		var response struct {
+			Data dtos.SubscriptionResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		return response.Data
+	}
+
+	// Three monthly billing dates have passed, so the first run catches up on all of them
+	start := time.Now().UTC().AddDate(0, -2, -1).Truncate(time.Second)
+
+	var subscriptionID string
+	t.Run("subscribes a client to a plan", func(t *testing.T) {
+		rr := createSubscription(client.ID(), start)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		subscription := decodeSubscription(rr)
+		subscriptionID = subscription.ID
+		assert.Equal(t, "active", subscription.Status)
+		assert.Equal(t, int64(9800), subscription.Amount.Amount)
+		require.NotNil(t, subscription.NextBillingDate)
+		assert.True(t, subscription.NextBillingDate.Equal(start))
+
+		rr = createSubscription("2f1e1a52-8c39-4a2b-9a35-7b1a0f3c2d11", start)
+		assert.Equal(t, http.StatusNotFound, rr.Code, rr.Body.String())
+	})
+
+	t.Run("the scheduler issues one invoice per billing date", func(t *testing.T) {
+		run := runScheduler()
+		require.Len(t, run.Billed, 3)
+		for _, bill := range run.Billed {
+			assert.Equal(t, subscriptionID, bill.SubscriptionID)
+			assert.Equal(t, int64(11760), bill.Total.Amount)
+
+			invoice, err := billingService.GetInvoice(application.AdminContext("ops"), bill.InvoiceID)
+			require.NoError(t, err)
+			assert.Equal(t, entity.InvoiceIssued, invoice.Status())
+		}
+
+		assert.Empty(t, runScheduler().Billed, "a second run bills nothing")
+
+		subscription := decodeSubscription(serve(http.MethodGet, "/api/v1/subscriptions/"+subscriptionID, ""))
+		assert.Equal(t, 3, subscription.BilledCount)
+		assert.Equal(t, run.Billed[2].InvoiceID, subscription.LastInvoiceID)
+	})
+
+	t.Run("changes the plan from the next billing date", func(t *testing.T) {
+		rr := serve(http.MethodPut, "/api/v1/subscriptions/"+subscriptionID, `{"plan":"Business","unit_amount":9900,"quantity":1,"tax_rate_bps":2000}`)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Equal(t, "Business", decodeSubscription(rr).Plan)
+	})
+
+	t.Run("pauses, resumes and cancels", func(t *testing.T) {
+		rr := serve(http.MethodPost, "/api/v1/subscriptions/"+subscriptionID+"/resume", "")
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code, rr.Body.String())
+
+		rr = serve(http.MethodPost, "/api/v1/subscriptions/"+subscriptionID+"/pause", "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Equal(t, "paused", decodeSubscription(rr).Status)
+
+		rr = serve(http.MethodPost, "/api/v1/subscriptions/"+subscriptionID+"/resume", "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Equal(t, "active", decodeSubscription(rr).Status)
+
+		rr = serve(http.MethodPost, "/api/v1/subscriptions/"+subscriptionID+"/cancel", "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		canceled := decodeSubscription(rr)
+		assert.Equal(t, "canceled", canceled.Status)
+		assert.Nil(t, canceled.NextBillingDate)
+
+		rr = serve(http.MethodPost, "/api/v1/subscriptions/"+subscriptionID+"/cancel", "")
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code, rr.Body.String())
+	})
+
+	t.Run("cancels the subscriptions of deleted clients", func(t *testing.T) {
+		other, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Globex", Email: "billing@globex.example"})
+		require.NoError(t, err)
+		rr := createSubscription(other.ID(), time.Now().UTC().AddDate(0, 0, -1))
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		otherID := decodeSubscription(rr).ID
+		require.NoError(t, billingService.DeleteClient(application.AdminContext("billing-admin"), other.ID()))
+
+		run := runScheduler()
+		assert.Empty(t, run.Billed)
+		assert.Equal(t, []string{otherID}, run.Canceled)
+
+		rr = serve(http.MethodGet, "/api/v1/subscriptions?client_id="+other.ID(), "")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), `"status":"canceled"`)
+	})
+
+	t.Run("rejects unsupported methods and unauthenticated callers", func(t *testing.T) {
+		rr := serve(http.MethodDelete, "/api/v1/subscriptions/"+subscriptionID, "")
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+
+		for _, path := range []string{"/api/v1/admin/subscriptions/run", "/api/v1/subscriptions"} {
+			rr = httptest.NewRecorder()
+			handler.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, path, nil))
+			assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		}
+	})
+}
+
+func TestSubscriptionAPI_Proration(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage)).
+		WithInvoices(repository.NewInvoiceRepository(storage.Collection(repository.InvoiceCollection))).
+		WithPayments(repository.NewPaymentRepository(storage.Collection(repository.PaymentCollection)))
+	prorator, err := service.NewProrator(service.ProrationDayBased)
+	require.NoError(t, err)
+	subscriptionService := application.NewSubscriptionService(
+		repository.NewSubscriptionRepository(storage.Collection(repository.SubscriptionCollection)),
+		billingService,
+	).WithProration(prorator)
+	handler := httpserver.NewServerWithServices(httpserver.Services{
+		Billing:       billingService,
+		Subscriptions: subscriptionService,
+	}, httpserver.ServerOptions{
+		AdminTokens: map[string]string{"scheduler": "admin-token"},
+	}).Handler()
+
+	client, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+	require.NoError(t, err)
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer admin-token")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+	decodeSubscription := func(rr *httptest.ResponseRecorder) dtos.SubscriptionResponse {
+		var response struct {
+			Data dtos.SubscriptionResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		return response.Data
+	}
+
+	// The first period started ten days ago and is billed before the plan changes
+	start := time.Now().UTC().AddDate(0, 0, -10).Truncate(time.Second)
+	rr := serve(http.MethodPost, "/api/v1/subscriptions", fmt.Sprintf(
+		`{"client_id":%q,"plan":"Pro","currency":"EUR","unit_amount":4900,"quantity":2,"tax_rate_bps":2000,"interval":"monthly","start_date":%q}`,
+		client.ID(), start.Format(time.RFC3339)))
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	subscriptionID := decodeSubscription(rr).ID
+
+	run, err := subscriptionService.BillDueSubscriptions(time.Now())
+	require.NoError(t, err)
+	require.Len(t, run.Billed, 1)
+
+	t.Run("invoices the remaining days of an upgrade right away", func(t *testing.T) {
+		rr := serve(http.MethodPut, "/api/v1/subscriptions/"+subscriptionID, `{"plan":"Business","unit_amount":9900,"quantity":2,"tax_rate_bps":2000}`)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		subscription := decodeSubscription(rr)
+		require.NotNil(t, subscription.Proration)
+		proration := subscription.Proration
+		assert.Equal(t, proration.PeriodDays-10, proration.RemainingDays)
+		assert.Equal(t, proration.Charge.Amount-proration.Credit.Amount, proration.Net.Amount)
+		assert.True(t, proration.Net.Amount > 0)
+		assert.Nil(t, subscription.ProrationCredit)
+		require.NotEmpty(t, proration.InvoiceID)
+
+		invoice, err := billingService.GetInvoice(application.AdminContext("ops"), proration.InvoiceID)
+		require.NoError(t, err)
+		assert.Equal(t, entity.InvoiceIssued, invoice.Status())
+		subtotal, err := invoice.Subtotal()
+		require.NoError(t, err)
+		assert.Equal(t, proration.Net.Amount, subtotal.Amount())
+	})
+
+	t.Run("credits a downgrade on the next billed periods", func(t *testing.T) {
+		rr := serve(http.MethodPut, "/api/v1/subscriptions/"+subscriptionID, `{"plan":"Starter","unit_amount":1900,"quantity":1,"tax_rate_bps":2000}`)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		subscription := decodeSubscription(rr)
+		require.NotNil(t, subscription.Proration)
+		assert.True(t, subscription.Proration.Net.Amount < 0)
+		assert.Empty(t, subscription.Proration.InvoiceID)
+		require.NotNil(t, subscription.ProrationCredit)
+		credit := -subscription.Proration.Net.Amount
+		assert.Equal(t, credit, subscription.ProrationCredit.Amount)
+
+		// The next periods bill the new price less the credit, until it is used up
+		remaining := credit
+		for remaining > 0 {
+			run, err := subscriptionService.BillDueSubscriptions(subscription.NextBillingDate.Add(time.Hour))
+			require.NoError(t, err)
+			require.Len(t, run.Billed, 1)
+
+			subtotal, err := run.Billed[0].Invoice.Subtotal()
+			require.NoError(t, err)
+			applied := min(remaining, 1900)
+			assert.Equal(t, 1900-applied, subtotal.Amount())
+			remaining -= applied
+
+			subscription = decodeSubscription(serve(http.MethodGet, "/api/v1/subscriptions/"+subscriptionID, ""))
+			if remaining > 0 {
+				require.NotNil(t, subscription.ProrationCredit)
+				assert.Equal(t, remaining, subscription.ProrationCredit.Amount)
+			}
+		}
+		assert.Nil(t, subscription.ProrationCredit)
+	})
+
+	t.Run("applies changes from the next billing date outside a billed period", func(t *testing.T) {
+		other, err := subscriptionService.CreateSubscription(application.AdminContext("ops"), dtos.CreateSubscriptionRequest{
+			ClientID: client.ID(), Plan: "Pro", Currency: "EUR", UnitAmount: 4900, Quantity: 1, Interval: "monthly",
+			StartDate: time.Now().UTC().AddDate(0, 0, 5),
+		})
+		require.NoError(t, err)
+
+		rr := serve(http.MethodPut, "/api/v1/subscriptions/"+other.ID(), `{"plan":"Business","unit_amount":9900,"quantity":1}`)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Nil(t, decodeSubscription(rr).Proration)
+	})
+}
+
+func TestSubscriptionAPI_TieredPricing(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage)).
+		WithInvoices(repository.NewInvoiceRepository(storage.Collection(repository.InvoiceCollection))).
+		WithPayments(repository.NewPaymentRepository(storage.Collection(repository.PaymentCollection)))
+	subscriptionService := application.NewSubscriptionService(
+		repository.NewSubscriptionRepository(storage.Collection(repository.SubscriptionCollection)),
+		billingService,
+	)
+	handler := httpserver.NewServerWithServices(httpserver.Services{
+		Billing:       billingService,
+		Subscriptions: subscriptionService,
+	}, httpserver.ServerOptions{
+		AdminTokens: map[string]string{"scheduler": "admin-token"},
+	}).Handler()
+
+	client, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+	require.NoError(t, err)
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer admin-token")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+	decodeSubscription := func(rr *httptest.ResponseRecorder) dtos.SubscriptionResponse {
+		var response struct {
+			Data dtos.SubscriptionResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		return response.Data
+	}
+
+	// Graduated seats: the first 5 at 10.00, the next ones at 8.00
+	pricing := `{"model":"graduated","tiers":[{"up_to":5,"unit_amount":1000},{"unit_amount":800}]}`
+	start := time.Now().UTC().AddDate(0, 0, -1).Truncate(time.Second)
+
+	var subscriptionID string
+	t.Run("prices the quantity by brackets", func(t *testing.T) {
+		rr := serve(http.MethodPost, "/api/v1/subscriptions", fmt.Sprintf(
+			`{"client_id":%q,"plan":"Team","currency":"EUR","unit_amount":0,"quantity":8,"interval":"monthly","start_date":%q,"pricing":%s}`,
+			client.ID(), start.Format(time.RFC3339), pricing))
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		subscription := decodeSubscription(rr)
+		subscriptionID = subscription.ID
+		assert.Equal(t, int64(5*1000+3*800), subscription.Amount.Amount)
+		require.NotNil(t, subscription.Pricing)
+		assert.Equal(t, "graduated", subscription.Pricing.Model)
+		assert.Len(t, subscription.Pricing.Tiers, 2)
+	})
+
+	t.Run("bills one line per bracket", func(t *testing.T) {
+		run, err := subscriptionService.BillDueSubscriptions(time.Now())
+		require.NoError(t, err)
+		require.Len(t, run.Billed, 1)
+
+		invoice := run.Billed[0].Invoice
+		require.Len(t, invoice.Lines(), 2)
+		assert.Contains(t, invoice.Lines()[0].Description, "tier 1: 5 units")
+		assert.Contains(t, invoice.Lines()[1].Description, "tier 2: 3 units")
+		subtotal, err := invoice.Subtotal()
+		require.NoError(t, err)
+		assert.Equal(t, int64(7400), subtotal.Amount())
+	})
+
+	t.Run("reverts to the unit amount when the pricing is omitted", func(t *testing.T) {
+		rr := serve(http.MethodPut, "/api/v1/subscriptions/"+subscriptionID, `{"plan":"Team","unit_amount":900,"quantity":8}`)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		subscription := decodeSubscription(rr)
+		assert.Nil(t, subscription.Pricing)
+		assert.Equal(t, int64(7200), subscription.Amount.Amount)
+	})
+
+	t.Run("rejects invalid pricing", func(t *testing.T) {
+		rr := serve(http.MethodPut, "/api/v1/subscriptions/"+subscriptionID, `{"plan":"Team","unit_amount":0,"quantity":8,"pricing":{"model":"graduated","tiers":[{"up_to":5,"unit_amount":1000}]}}`)
+		assert.Equal(t, http.StatusBadRequest, rr.Code, rr.Body.String())
+
+		rr = serve(http.MethodPut, "/api/v1/subscriptions/"+subscriptionID, `{"plan":"Team","unit_amount":0,"quantity":8,"pricing":{"model":"bulk"}}`)
+		assert.Equal(t, http.StatusBadRequest, rr.Code, rr.Body.String())
+	})
+}
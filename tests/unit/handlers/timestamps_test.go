@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfc3339Timestamps matches the timestamps of an encoded response, with their zone designator
+var rfc3339Timestamps = regexp.MustCompile(`"\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})"`)
+
+func assertUTCTimestamps(t *testing.T, encoded []byte, expected int) {
+	t.Helper()
+	timestamps := rfc3339Timestamps.FindAllSubmatch(encoded, -1)
+	require.Len(t, timestamps, expected, string(encoded))
+	for _, timestamp := range timestamps {
+		assert.Equal(t, "Z", string(timestamp[2]), "%s is not in UTC", timestamp[0])
+	}
+}
+
+func TestDTOs_TimestampsAreUTC(t *testing.T) {
+	paris := time.FixedZone("CEST", 2*3600)
+	createdAt := time.Date(2026, time.October, 16, 9, 30, 0, 0, paris)
+	assessedAt := time.Date(2026, time.October, 16, 10, 0, 0, 0, time.FixedZone("UTC-5", -5*3600))
+
+	client := dtos.ClientResponse{
+		ID:        "client-1",
+		Name:      "Acme Corp",
+		Email:     "billing@acme.example",
+		Status:    "active",
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt.Add(time.Hour),
+		Risk:      &dtos.ClientRiskResponse{Score: 12, AssessedAt: assessedAt, ExpiresAt: assessedAt.AddDate(0, 0, 30)},
+	}
+
+	t.Run("client responses", func(t *testing.T) {
+		encoded, err := json.Marshal(dtos.UTC(client))
+		require.NoError(t, err)
+		assertUTCTimestamps(t, encoded, 4)
+		assert.Contains(t, string(encoded), `"created_at":"2026-10-16T07:30:00Z"`)
+
+		// The response body itself is left untouched
+		assert.Equal(t, paris, client.CreatedAt.Location())
+		assert.Equal(t, assessedAt, client.Risk.AssessedAt)
+	})
+
+	t.Run("lists and nested values", func(t *testing.T) {
+		encoded, err := json.Marshal(dtos.UTC([]dtos.ClientResponse{client, client}))
+		require.NoError(t, err)
+		assertUTCTimestamps(t, encoded, 8)
+
+		encoded, err = json.Marshal(dtos.UTC(map[string]interface{}{"client": &client, "seen_at": createdAt}))
+		require.NoError(t, err)
+		assertUTCTimestamps(t, encoded, 5)
+	})
+
+	t.Run("portal token responses", func(t *testing.T) {
+		encoded, err := json.Marshal(dtos.UTC(dtos.PortalAccessTokenResponse{Token: "token", ClientID: "client-1", ExpiresAt: createdAt}))
+		require.NoError(t, err)
+		assertUTCTimestamps(t, encoded, 1)
+	})
+}
+
+func TestAPI_TimestampsAreUTC(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage))
+	handler := httpserver.NewServerWithServices(httpserver.Services{Billing: billingService}, adminOptions()).Handler()
+
+	client, err := billingService.CreateClient(application.AdminContext("ops"), dtos.CreateClientRequest{Name: "Acme Corp", Email: "billing@acme.example"})
+	require.NoError(t, err)
+
+	for _, path := range []string{"/api/v1/clients/" + client.ID(), "/api/v1/clients"} {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, asAdmin(httptest.NewRequest(http.MethodGet, path, nil)))
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assertUTCTimestamps(t, rr.Body.Bytes(), 2)
+	}
+}
@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI_ResumableUploads(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	auditService := application.NewAuditService(repository.NewAuditRepository(storage.Collection(repository.AuditCollection)))
+	cashService := application.NewCashApplicationService(
+		repository.NewBankTransactionRepository(storage.Collection(repository.BankTransactionCollection)),
+		auditService,
+		application.NewBillingService(repository.NewClientRepository(storage)),
+	)
+	uploadService := application.NewUploadService(
+		repository.NewUploadRepository(storage.Collection(repository.UploadCollection)),
+		repository.NewStorageObjectStore(storage.Collection(repository.UploadChunkCollection)),
+	).WithProcessor(entity.UploadBankStatement, application.BankStatementUploadProcessor(cashService))
+
+	handler := httpserver.NewServerWithServices(httpserver.Services{
+		Billing: application.NewBillingService(repository.NewClientRepository(storage)),
+		Audit:   auditService,
+		Cash:    cashService,
+		Uploads: uploadService,
+	}, httpserver.ServerOptions{
+		AdminTokens: map[string]string{"ops": "admin-token"},
+	}).Handler()
+
+	serve := func(method, path, body string, offset int64) *httptest.ResponseRecorder {
+		req := newAdminRequest(method, path, body, "192.0.2.10:1234")
+		if offset >= 0 {
+			req.Header.Set("Upload-Offset", strconv.FormatInt(offset, 10))
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	type uploadResponse struct {
+		Data struct {
+			ID     string `json:"id"`
+			Offset int64  `json:"offset"`
+			Status string `json:"status"`
+			Result string `json:"result"`
+		} `json:"data"`
+	}
+	create := func(t *testing.T, size int) string {
+		rr := serve(http.MethodPost, "/api/v1/uploads", fmt.Sprintf(`{"kind":"bank_statement","format":"camt053","size":%d}`, size), -1)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		var created uploadResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &created))
+		assert.Equal(t, "/api/v1/uploads/"+created.Data.ID, rr.Header().Get("Location"))
+		assert.Equal(t, "0", rr.Header().Get("Upload-Offset"))
+		return created.Data.ID
+	}
+
+	t.Run("a statement uploaded in chunks is imported by the run", func(t *testing.T) {
+		id := create(t, len(cashStatement))
+		path := "/api/v1/uploads/" + id
+		half := int64(len(cashStatement) / 2)
+
+		rr := serve(http.MethodPatch, path, cashStatement[:half], 0)
+		require.Equal(t, http.StatusNoContent, rr.Code, rr.Body.String())
+		assert.Equal(t, strconv.FormatInt(half, 10), rr.Header().Get("Upload-Offset"))
+
+		// A resuming client asks where to continue from
+		rr = serve(http.MethodHead, path, "", -1)
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, strconv.FormatInt(half, 10), rr.Header().Get("Upload-Offset"))
+		assert.Equal(t, strconv.Itoa(len(cashStatement)), rr.Header().Get("Upload-Length"))
+
+		rr = serve(http.MethodPatch, path, cashStatement[half:], half)
+		require.Equal(t, http.StatusNoContent, rr.Code, rr.Body.String())
+
+		rr = serve(http.MethodGet, path, "", -1)
+		require.Equal(t, http.StatusOK, rr.Code)
+		var pending uploadResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &pending))
+		assert.Equal(t, "pending", pending.Data.Status)
+
+		rr = serve(http.MethodPost, "/api/v1/admin/uploads/run", "", -1)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		rr = serve(http.MethodGet, path, "", -1)
+		var processed uploadResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &processed))
+		assert.Equal(t, "processed", processed.Data.Status)
+		assert.Contains(t, processed.Data.Result, "1 transactions imported")
+
+		rr = serve(http.MethodGet, "/api/v1/bank-transactions", "", -1)
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "BANKREF-7")
+	})
+
+	t.Run("a chunk sent from the wrong offset is rejected", func(t *testing.T) {
+		id := create(t, len(cashStatement))
+
+		rr := serve(http.MethodPatch, "/api/v1/uploads/"+id, cashStatement[10:20], 10)
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+
+		rr = serve(http.MethodPatch, "/api/v1/uploads/"+id, cashStatement[:10], -1)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("unknown kinds and oversized files are rejected", func(t *testing.T) {
+		rr := serve(http.MethodPost, "/api/v1/uploads", `{"kind":"client_import","size":10}`, -1)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+		rr = serve(http.MethodPost, "/api/v1/uploads", fmt.Sprintf(`{"kind":"bank_statement","size":%d}`, application.DefaultMaxUploadSize+1), -1)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("unknown uploads are not found", func(t *testing.T) {
+		rr := serve(http.MethodGet, "/api/v1/uploads/8d9f5a8e-8a3b-4c4e-9e61-2f1f6f1f0a01", "", -1)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("abandoned uploads expire", func(t *testing.T) {
+		id := create(t, len(cashStatement))
+		require.Equal(t, http.StatusNoContent, serve(http.MethodPatch, "/api/v1/uploads/"+id, cashStatement[:10], 0).Code)
+
+		expired, err := uploadService.ProcessPending(context.Background(), time.Now().Add(application.DefaultUploadExpiry+time.Hour))
+		require.NoError(t, err)
+
+		var found bool
+		for _, upload := range expired {
+			if upload.ID() == id {
+				found = true
+				assert.Equal(t, entity.UploadFailed, upload.Status())
+			}
+		}
+		assert.True(t, found)
+		assert.Equal(t, http.StatusUnprocessableEntity, serve(http.MethodPatch, "/api/v1/uploads/"+id, cashStatement[10:20], 10).Code)
+	})
+}
@@ -0,0 +1,227 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// usageTestServer is a server metering the usage of the subscriptions of tenant initech
+type usageTestServer struct {
+	handler       http.Handler
+	subscriptions *application.SubscriptionService
+	clientID      string
+}
+
+func newUsageTestServer(t *testing.T) usageTestServer {
+	t.Helper()
+
+	storage := infrastructure.NewInMemoryStorage()
+	billingService := application.NewBillingService(repository.NewClientRepository(storage)).
+		WithInvoices(repository.NewInvoiceRepository(storage.Collection(repository.InvoiceCollection)))
+	usageRepo := repository.NewUsageRecordRepository(storage.Collection(repository.UsageRecordCollection))
+	subscriptionService := application.NewSubscriptionService(repository.NewSubscriptionRepository(storage.Collection(repository.SubscriptionCollection)), billingService).
+		WithUsage(usageRepo)
+	usageService := application.NewUsageService(usageRepo, subscriptionService)
+
+	server := httpserver.NewServerWithServices(httpserver.Services{
+		Billing:       billingService,
+		Subscriptions: subscriptionService,
+		Usage:         usageService,
+	}, httpserver.ServerOptions{
+		AdminTokens:  map[string]string{"initech-ops": "initech-token", "globex-ops": "globex-token"},
+		AdminTenants: map[string][]string{"initech-ops": {"initech"}, "globex-ops": {"globex"}},
+	})
+
+	client, err := billingService.CreateClient(application.RequestContext{TenantID: "initech", Principal: application.Principal{Kind: application.PrincipalAdmin, ID: "initech-ops"}},
+		dtos.CreateClientRequest{Name: "Initech", Email: "billing@initech.example"})
+	require.NoError(t, err)
+
+	return usageTestServer{handler: server.Handler(), subscriptions: subscriptionService, clientID: client.ID()}
+}
+
+// serve sends a request with the bearer token of an admin (no token for anonymous requests)
+func (s usageTestServer) serve(method, path, body, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rr := httptest.NewRecorder()
+	s.handler.ServeHTTP(rr, req)
+	return rr
+}
+
+// subscribe creates a monthly subscription starting at the given date, with usage prices
+func (s usageTestServer) subscribe(t *testing.T, start time.Time, usagePrices string) string {
+	t.Helper()
+
+	rr := s.serve(http.MethodPost, "/api/v1/subscriptions", fmt.Sprintf(
+		`{"client_id":%q,"plan":"Pro","currency":"EUR","unit_amount":4900,"interval":"monthly","start_date":%q,"usage_prices":%s}`,
+		s.clientID, start.Format(time.RFC3339), usagePrices), "initech-token")
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+	var response struct {
+		Data dtos.SubscriptionResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	return response.Data.ID
+}
+
+func TestUsageAPI(t *testing.T) {
+	server := newUsageTestServer(t)
+	subscriptionID := server.subscribe(t, time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC), `{}`)
+
+	batch := fmt.Sprintf(`{"records":[
+		{"subscription_id":%[1]q,"metric":"api_calls","quantity":120,"timestamp":"2026-03-02T10:00:00Z","idempotency_key":"evt-1"},
+		{"subscription_id":%[1]q,"metric":"api_calls","quantity":80,"timestamp":"2026-03-15T10:00:00Z","idempotency_key":"evt-2"},
+		{"subscription_id":%[1]q,"metric":"seats","quantity":3,"timestamp":"2026-03-20T10:00:00Z","idempotency_key":"evt-3"}
+	]}`, subscriptionID)
+
+	t.Run("ingests a batch", func(t *testing.T) {
+		rr := server.serve(http.MethodPost, "/api/v1/usage-records", batch, "initech-token")
+
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var response struct {
+			Data struct {
+				Accepted   int `json:"accepted"`
+				Duplicates int `json:"duplicates"`
+				Records    []struct {
+					Status string `json:"status"`
+				} `json:"records"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, 3, response.Data.Accepted)
+		assert.Equal(t, 0, response.Data.Duplicates)
+		assert.Len(t, response.Data.Records, 3)
+	})
+
+	t.Run("replayed batch is acknowledged without double counting", func(t *testing.T) {
+		rr := server.serve(http.MethodPost, "/api/v1/usage-records", batch, "initech-token")
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"accepted":0`)
+		assert.Contains(t, rr.Body.String(), `"duplicates":3`)
+	})
+
+	t.Run("summarizes usage per billing period", func(t *testing.T) {
+		rr := server.serve(http.MethodGet,
+			"/api/v1/usage-records/summary?subscription_id="+subscriptionID+"&period_start=2026-03-01T00:00:00Z&period_end=2026-03-16T00:00:00Z", "", "initech-token")
+
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var response struct {
+			Data struct {
+				Metrics []struct {
+					Metric      string `json:"metric"`
+					Quantity    int64  `json:"quantity"`
+					RecordCount int    `json:"record_count"`
+				} `json:"metrics"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		require.Len(t, response.Data.Metrics, 1)
+		assert.Equal(t, "api_calls", response.Data.Metrics[0].Metric)
+		assert.Equal(t, int64(200), response.Data.Metrics[0].Quantity)
+		assert.Equal(t, 2, response.Data.Metrics[0].RecordCount)
+	})
+
+	t.Run("invalid event rejects the batch", func(t *testing.T) {
+		rr := server.serve(http.MethodPost, "/api/v1/usage-records",
+			fmt.Sprintf(`{"records":[{"subscription_id":%q,"metric":"api_calls","quantity":-5,"idempotency_key":"evt-9"}]}`, subscriptionID), "initech-token")
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"field":"records[0].quantity"`)
+	})
+
+	t.Run("rejects usage of unknown subscriptions", func(t *testing.T) {
+		rr := server.serve(http.MethodPost, "/api/v1/usage-records",
+			`{"records":[{"subscription_id":"sub-1","metric":"api_calls","quantity":5,"idempotency_key":"evt-10"}]}`, "initech-token")
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"field":"records[0].subscription_id"`)
+	})
+
+	t.Run("summary requires a valid period", func(t *testing.T) {
+		rr := server.serve(http.MethodGet, "/api/v1/usage-records/summary?subscription_id="+subscriptionID+"&period_start=yesterday", "", "initech-token")
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("only POST ingests", func(t *testing.T) {
+		rr := server.serve(http.MethodGet, "/api/v1/usage-records", "", "initech-token")
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	})
+}
+
+func TestUsageAPI_Authorization(t *testing.T) {
+	server := newUsageTestServer(t)
+	subscriptionID := server.subscribe(t, time.Now().UTC(), `{}`)
+	batch := fmt.Sprintf(`{"records":[{"subscription_id":%q,"metric":"api_calls","quantity":5,"idempotency_key":"evt-1"}]}`, subscriptionID)
+	summary := "/api/v1/usage-records/summary?subscription_id=" + subscriptionID + "&period_start=2026-01-01T00:00:00Z&period_end=2027-01-01T00:00:00Z"
+
+	t.Run("anonymous callers can neither report nor read usage", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, server.serve(http.MethodPost, "/api/v1/usage-records", batch, "").Code)
+		assert.Equal(t, http.StatusUnauthorized, server.serve(http.MethodGet, summary, "", "").Code)
+	})
+
+	t.Run("another tenant can neither report nor read usage", func(t *testing.T) {
+		rr := server.serve(http.MethodPost, "/api/v1/usage-records", batch, "globex-token")
+		assert.Equal(t, http.StatusBadRequest, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), `"field":"records[0].subscription_id"`)
+
+		assert.Equal(t, http.StatusNotFound, server.serve(http.MethodGet, summary, "", "globex-token").Code)
+	})
+}
+
+func TestUsageAPI_BillsUsageWithTheNextPeriod(t *testing.T) {
+	server := newUsageTestServer(t)
+
+	// api_calls cost 0.50 per 1000 calls; storage_gb is graduated: the first 10 GB are free, the next ones 0.20 each
+	start := time.Now().UTC().AddDate(0, -1, -1).Truncate(time.Second)
+	subscriptionID := server.subscribe(t, start, `{
+		"api_calls":{"model":"per_unit","unit_amount":50,"units_per":1000},
+		"storage_gb":{"model":"graduated","tiers":[{"up_to":10,"unit_amount":0},{"unit_amount":20}]}
+	}`)
+
+	rr := server.serve(http.MethodPost, "/api/v1/usage-records", fmt.Sprintf(`{"records":[
+		{"subscription_id":%[1]q,"metric":"api_calls","quantity":25000,"timestamp":%[2]q,"idempotency_key":"evt-1"},
+		{"subscription_id":%[1]q,"metric":"storage_gb","quantity":15,"timestamp":%[2]q,"idempotency_key":"evt-2"},
+		{"subscription_id":%[1]q,"metric":"exports","quantity":7,"timestamp":%[2]q,"idempotency_key":"evt-3"}
+	]}`, subscriptionID, start.Add(time.Hour).Format(time.RFC3339)), "initech-token")
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	run, err := server.subscriptions.BillDueSubscriptions(time.Now())
+	require.NoError(t, err)
+	require.Len(t, run.Billed, 2)
+
+	// The first period is billed in advance, before any usage
+	assert.Len(t, run.Billed[0].Invoice.Lines(), 1)
+
+	// The second period also bills the usage of the first one; unpriced metrics are not billed
+	lines := run.Billed[1].Invoice.Lines()
+	require.Len(t, lines, 4)
+	assert.Contains(t, lines[1].Description, "api_calls usage")
+	assert.Equal(t, int64(1250), lines[1].UnitAmount)
+	assert.Contains(t, lines[2].Description, "storage_gb usage")
+	assert.Contains(t, lines[2].Description, "tier 1: 10 units")
+	assert.Equal(t, int64(0), lines[2].UnitAmount)
+	assert.Contains(t, lines[3].Description, "tier 2: 5 units")
+	assert.Equal(t, int64(100), lines[3].UnitAmount)
+
+	subtotal, err := run.Billed[1].Invoice.Subtotal()
+	require.NoError(t, err)
+	assert.Equal(t, int64(4900+1250+100), subtotal.Amount())
+}
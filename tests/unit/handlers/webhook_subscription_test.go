@@ -0,0 +1,284 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/api/http/dtos"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/messaging"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/webhook"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// webhookConsumer is a consumer endpoint recording the deliveries it receives; deliveries to /fail are rejected and
+// deliveries to /slow time out
+type webhookConsumer struct {
+	server *httptest.Server
+
+	mu         sync.Mutex
+	deliveries []*http.Request
+	bodies     [][]byte
+}
+
+func newWebhookConsumer(t *testing.T) *webhookConsumer {
+	t.Helper()
+
+	consumer := &webhookConsumer{}
+	consumer.server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/fail":
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		case "/slow":
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		consumer.mu.Lock()
+		consumer.deliveries = append(consumer.deliveries, r)
+		consumer.bodies = append(consumer.bodies, body)
+		consumer.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(consumer.server.Close)
+	return consumer
+}
+
+// webhookSubscriptionTestServer is a server whose event store holds events of tenants initech and globex
+type webhookSubscriptionTestServer struct {
+	handler       http.Handler
+	audit         *application.AuditService
+	subscriptions *application.WebhookSubscriptionService
+	consumer      *webhookConsumer
+}
+
+func newWebhookSubscriptionTestServer(t *testing.T) webhookSubscriptionTestServer {
+	t.Helper()
+
+	storage := infrastructure.NewInMemoryStorage()
+	consumer := newWebhookConsumer(t)
+	auditService := application.NewAuditService(repository.NewAuditRepository(storage.Collection(repository.AuditCollection)))
+	eventStore := application.NewEventStore(repository.NewEventStoreRepository(storage.Collection(repository.EventStoreCollection)))
+	sender := webhook.NewSender(func(string) *http.Client { return consumer.server.Client() })
+	subscriptionService := application.NewWebhookSubscriptionService(
+		repository.NewWebhookSubscriptionRepository(storage.Collection(repository.WebhookSubscriptionCollection)), eventStore, sender, auditService, 0)
+
+	for _, event := range []struct{ topic, key, tenantID string }{
+		{application.RecurringInvoiceIssuedTopic, "template-1", "initech"},
+		{application.PaymentReceiptTopic, "payment-1", "initech"},
+		{application.RecurringInvoiceIssuedTopic, "template-2", "globex"},
+		{application.RecurringInvoiceIssuedTopic, "template-3", "initech"},
+		{application.RecurringInvoiceIssuedTopic, "template-4", ""},
+	} {
+		headers := map[string]string{}
+		if event.tenantID != "" {
+			headers[application.EventTenantHeader] = event.tenantID
+		}
+		_, err := eventStore.Append(messaging.Message{
+			Topic:   event.topic,
+			Key:     event.key,
+			Payload: json.RawMessage(`{"id":"` + event.key + `"}`),
+			Headers: headers,
+		})
+		require.NoError(t, err)
+	}
+
+	server := httpserver.NewServerWithServices(httpserver.Services{
+		Billing:              application.NewBillingService(repository.NewClientRepository(storage)),
+		WebhookSubscriptions: subscriptionService,
+	}, httpserver.ServerOptions{
+		AdminTokens:  map[string]string{"initech-ops": "initech-token", "globex-ops": "globex-token"},
+		AdminTenants: map[string][]string{"initech-ops": {"initech"}, "globex-ops": {"globex"}},
+	})
+
+	return webhookSubscriptionTestServer{handler: server.Handler(), audit: auditService, subscriptions: subscriptionService, consumer: consumer}
+}
+
+// serve sends a request with the bearer token of an admin (no token for anonymous requests)
+func (s webhookSubscriptionTestServer) serve(method, path, body, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rr := httptest.NewRecorder()
+	s.handler.ServeHTTP(rr, req)
+	return rr
+}
+
+// subscribe creates a subscription of tenant initech to an endpoint path of the consumer
+func (s webhookSubscriptionTestServer) subscribe(t *testing.T, path, eventTypes string) dtos.WebhookSubscriptionResponse {
+	t.Helper()
+
+	rr := s.serve(http.MethodPost, "/api/v1/webhooks",
+		`{"url":"`+s.consumer.server.URL+path+`","event_types":`+eventTypes+`}`, "initech-token")
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+	var response struct {
+		Data dtos.WebhookSubscriptionResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	return response.Data
+}
+
+func decodeWebhookReplay(t *testing.T, rr *httptest.ResponseRecorder) dtos.WebhookReplayResponse {
+	t.Helper()
+
+	var response struct {
+		Data dtos.WebhookReplayResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	return response.Data
+}
+
+func TestWebhookSubscriptionAPI(t *testing.T) {
+	server := newWebhookSubscriptionTestServer(t)
+
+	subscription := server.subscribe(t, "/events", `["`+application.RecurringInvoiceIssuedTopic+`"]`)
+	require.NotEmpty(t, subscription.Secret, "the secret is returned on creation")
+
+	t.Run("lists and gets subscriptions without their secret", func(t *testing.T) {
+		rr := server.serve(http.MethodGet, "/api/v1/webhooks", "", "initech-token")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), subscription.ID)
+		assert.NotContains(t, rr.Body.String(), subscription.Secret)
+
+		rr = server.serve(http.MethodGet, "/api/v1/webhooks/"+subscription.ID, "", "initech-token")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.NotContains(t, rr.Body.String(), subscription.Secret)
+	})
+
+	t.Run("rejects endpoints that are not https", func(t *testing.T) {
+		rr := server.serve(http.MethodPost, "/api/v1/webhooks", `{"url":"http://consumer.example/events"}`, "initech-token")
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"field":"url"`)
+	})
+
+	t.Run("another tenant can neither see nor replay the subscription", func(t *testing.T) {
+		rr := server.serve(http.MethodGet, "/api/v1/webhooks", "", "globex-token")
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.NotContains(t, rr.Body.String(), subscription.ID)
+
+		assert.Equal(t, http.StatusNotFound, server.serve(http.MethodGet, "/api/v1/webhooks/"+subscription.ID, "", "globex-token").Code)
+		assert.Equal(t, http.StatusNotFound, server.serve(http.MethodPost, "/api/v1/webhooks/"+subscription.ID+"/replay?from=0", "", "globex-token").Code)
+		assert.Equal(t, http.StatusNotFound, server.serve(http.MethodDelete, "/api/v1/webhooks/"+subscription.ID, "", "globex-token").Code)
+	})
+
+	t.Run("anonymous callers cannot manage subscriptions", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, server.serve(http.MethodGet, "/api/v1/webhooks", "", "").Code)
+		assert.Equal(t, http.StatusUnauthorized, server.serve(http.MethodPost, "/api/v1/webhooks/"+subscription.ID+"/replay?from=0", "", "").Code)
+	})
+
+	t.Run("replay requires a valid from", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, server.serve(http.MethodPost, "/api/v1/webhooks/"+subscription.ID+"/replay", "", "initech-token").Code)
+		assert.Equal(t, http.StatusBadRequest, server.serve(http.MethodPost, "/api/v1/webhooks/"+subscription.ID+"/replay?from=yesterday", "", "initech-token").Code)
+		future := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+		assert.Equal(t, http.StatusBadRequest, server.serve(http.MethodPost, "/api/v1/webhooks/"+subscription.ID+"/replay?from="+future, "", "initech-token").Code)
+	})
+
+	t.Run("replays the signed events of the tenant and subscribed types", func(t *testing.T) {
+		from := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+		rr := server.serve(http.MethodPost, "/api/v1/webhooks/"+subscription.ID+"/replay?from="+from, "", "initech-token")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		replay := decodeWebhookReplay(t, rr)
+		assert.Equal(t, 2, replay.Delivered)
+		assert.Equal(t, "4", replay.LastEventID)
+		assert.False(t, replay.HasMore)
+
+		require.Len(t, server.consumer.deliveries, 2)
+		verifier := webhook.NewHMACVerifier(subscription.Secret, "", webhook.DeliveryTimestampHeader)
+		for i, delivery := range server.consumer.deliveries {
+			assert.NoError(t, verifier.Verify(delivery.Header.Get, server.consumer.bodies[i], time.Now()))
+		}
+		assert.Equal(t, "1", server.consumer.deliveries[0].Header.Get(webhook.DeliveryIDHeader))
+		assert.Equal(t, "1", server.consumer.deliveries[0].Header.Get("Idempotency-Key"))
+		assert.Contains(t, string(server.consumer.bodies[0]), `"replayed":true`)
+		assert.Contains(t, string(server.consumer.bodies[0]), `"data":{"id":"template-1"}`)
+		assert.Contains(t, string(server.consumer.bodies[1]), `"aggregate_id":"template-3"`)
+
+		entries, err := server.audit.ListEntries("initech")
+		require.NoError(t, err)
+		var replayed bool
+		for _, entry := range entries {
+			if entry.Action() == application.AuditActionWebhookEventsReplayed {
+				replayed = true
+				assert.Equal(t, from, entry.Details()["from"])
+			}
+		}
+		assert.True(t, replayed, "the replay is audited")
+	})
+
+	t.Run("rejects another replay within the cooldown", func(t *testing.T) {
+		rr := server.serve(http.MethodPost, "/api/v1/webhooks/"+subscription.ID+"/replay?from=0", "", "initech-token")
+
+		assert.Equal(t, http.StatusTooManyRequests, rr.Code, rr.Body.String())
+		retryAfter, err := strconv.Atoi(rr.Header().Get("Retry-After"))
+		require.NoError(t, err)
+		assert.InDelta(t, application.DefaultWebhookReplayCooldown.Seconds(), retryAfter, 5)
+		assert.Len(t, server.consumer.deliveries, 2)
+	})
+
+	t.Run("deletes the subscription", func(t *testing.T) {
+		assert.Equal(t, http.StatusNoContent, server.serve(http.MethodDelete, "/api/v1/webhooks/"+subscription.ID, "", "initech-token").Code)
+		assert.Equal(t, http.StatusNotFound, server.serve(http.MethodGet, "/api/v1/webhooks/"+subscription.ID, "", "initech-token").Code)
+	})
+}
+
+func TestWebhookSubscriptionAPI_ReplayStopsAtTheFirstFailedDelivery(t *testing.T) {
+	server := newWebhookSubscriptionTestServer(t)
+	subscription := server.subscribe(t, "/fail", `[]`)
+
+	rr := server.serve(http.MethodPost, "/api/v1/webhooks/"+subscription.ID+"/replay?from=0", "", "initech-token")
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	replay := decodeWebhookReplay(t, rr)
+	assert.Equal(t, 0, replay.Delivered)
+	assert.Equal(t, "1", replay.FailedEventID)
+	assert.NotEmpty(t, replay.Error)
+	assert.True(t, replay.HasMore)
+	assert.Equal(t, "0", replay.NextFrom, "resuming from next_from delivers the failed event again")
+
+	t.Run("the replay resumes from where it stopped without waiting for the cooldown", func(t *testing.T) {
+		rr := server.serve(http.MethodPost, "/api/v1/webhooks/"+subscription.ID+"/replay?from="+replay.NextFrom, "", "initech-token")
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Equal(t, "1", decodeWebhookReplay(t, rr).FailedEventID)
+	})
+
+	t.Run("another replay waits for the cooldown", func(t *testing.T) {
+		rr := server.serve(http.MethodPost, "/api/v1/webhooks/"+subscription.ID+"/replay?from=2", "", "initech-token")
+		assert.Equal(t, http.StatusTooManyRequests, rr.Code, rr.Body.String())
+	})
+}
+
+func TestWebhookSubscriptionAPI_ReplayStopsAtTheReplayTimeout(t *testing.T) {
+	server := newWebhookSubscriptionTestServer(t)
+	server.subscriptions.WithReplayTimeout(100 * time.Millisecond)
+	subscription := server.subscribe(t, "/slow", `[]`)
+
+	start := time.Now()
+	rr := server.serve(http.MethodPost, "/api/v1/webhooks/"+subscription.ID+"/replay?from=0", "", "initech-token")
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	assert.Less(t, time.Since(start), 2*time.Second, "the request answers at the replay timeout")
+
+	replay := decodeWebhookReplay(t, rr)
+	assert.Equal(t, 0, replay.Delivered)
+	assert.Empty(t, replay.FailedEventID, "a delivery cut off by the timeout is not a failure of the endpoint")
+	assert.True(t, replay.HasMore)
+	assert.Equal(t, "0", replay.NextFrom)
+}
@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	httpserver "github.com/gjaminon-go-labs/billing-api/internal/api/http"
+	"github.com/gjaminon-go-labs/billing-api/internal/application"
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/messaging"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/webhook"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingWebhookProcessor fails every event until it is repaired
+type failingWebhookProcessor struct {
+	failing   bool
+	processed []string
+}
+
+func (p *failingWebhookProcessor) Process(ctx context.Context, event *entity.WebhookEvent) error {
+	if p.failing {
+		return fmt.Errorf("ledger unavailable")
+	}
+	p.processed = append(p.processed, event.EventID())
+	return nil
+}
+
+func TestAPI_InboundWebhooks(t *testing.T) {
+	storage := infrastructure.NewInMemoryStorage()
+	publisher := messaging.NewMemoryPublisher()
+	auditService := application.NewAuditService(repository.NewAuditRepository(storage.Collection(repository.AuditCollection)))
+	bankProcessor := &failingWebhookProcessor{}
+	webhookService := application.NewWebhookService(
+		repository.NewWebhookEventRepository(storage.Collection(repository.WebhookEventCollection)),
+		auditService,
+		publisher,
+		2,
+	).
+		WithProvider("stripe", webhook.NewStripeVerifier("whsec_test")).
+		WithProvider("bank", webhook.NewHMACVerifier("bank-secret", "X-Bank-Signature", "")).
+		WithProcessor("bank", bankProcessor)
+
+	handler := httpserver.NewServerWithServices(httpserver.Services{
+		Billing:  application.NewBillingService(repository.NewClientRepository(storage)),
+		Audit:    auditService,
+		Webhooks: webhookService,
+	}, httpserver.ServerOptions{
+		AdminTokens: map[string]string{"ops": "admin-token"},
+	}).Handler()
+
+	serve := func(method, path, body string, headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+	deliverStripe := func(body string) *httptest.ResponseRecorder {
+		timestamp := fmt.Sprint(time.Now().Unix())
+		return serve(http.MethodPost, "/api/v1/webhooks/stripe", body, map[string]string{
+			"Stripe-Signature": "t=" + timestamp + ",v1=" + webhook.Sign("whsec_test", timestamp+"."+body),
+		})
+	}
+	deliverBank := func(eventID string) *httptest.ResponseRecorder {
+		body := fmt.Sprintf(`{"event_id":%q,"event_type":"credit_transfer.received"}`, eventID)
+		return serve(http.MethodPost, "/api/v1/webhooks/bank", body, map[string]string{
+			"X-Bank-Signature": "sha256=" + webhook.Sign("bank-secret", body),
+		})
+	}
+	admin := map[string]string{"Authorization": "Bearer admin-token"}
+	type eventResponse struct {
+		ID        string `json:"id"`
+		EventID   string `json:"event_id"`
+		Status    string `json:"status"`
+		Attempts  int    `json:"attempts"`
+		LastError string `json:"last_error"`
+	}
+	decodeReceipt := func(rr *httptest.ResponseRecorder) (eventResponse, bool) {
+		t.Helper()
+		var response struct {
+			Data struct {
+				Event     eventResponse `json:"event"`
+				Duplicate bool          `json:"duplicate"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		return response.Data.Event, response.Data.Duplicate
+	}
+
+	t.Run("verified events are processed and published", func(t *testing.T) {
+		rr := deliverStripe(`{"id":"evt_1","type":"payout.paid"}`)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		event, duplicate := decodeReceipt(rr)
+		assert.False(t, duplicate)
+		assert.Equal(t, "processed", event.Status)
+
+		messages := publisher.Messages()
+		require.Len(t, messages, 1)
+		assert.Equal(t, application.WebhookEventTopic, messages[0].Topic)
+		assert.Equal(t, "stripe", messages[0].Headers["provider"])
+		assert.Equal(t, "payout.paid", messages[0].Headers["event_type"])
+	})
+
+	t.Run("redeliveries are acknowledged once", func(t *testing.T) {
+		rr := deliverStripe(`{"id":"evt_1","type":"payout.paid"}`)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		_, duplicate := decodeReceipt(rr)
+		assert.True(t, duplicate)
+		assert.Len(t, publisher.Messages(), 1)
+	})
+
+	t.Run("invalid signatures are rejected", func(t *testing.T) {
+		rr := serve(http.MethodPost, "/api/v1/webhooks/stripe", `{"id":"evt_2","type":"payout.paid"}`, map[string]string{
+			"Stripe-Signature": fmt.Sprintf("t=%d,v1=forged", time.Now().Unix()),
+		})
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Contains(t, rr.Body.String(), "WEBHOOK_SIGNATURE_INVALID")
+	})
+
+	t.Run("unknown providers are not found", func(t *testing.T) {
+		rr := serve(http.MethodPost, "/api/v1/webhooks/unknown", `{"id":"evt_3","type":"payout.paid"}`, nil)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("failing events are dead-lettered without blocking later events", func(t *testing.T) {
+		bankProcessor.failing = true
+		first, _ := decodeReceipt(deliverBank("bank-1"))
+		assert.Equal(t, "pending", first.Status)
+		assert.Equal(t, 1, first.Attempts)
+
+		// The queue head is retried first: bank-1 runs out of attempts, bank-2 is attempted after it
+		second, _ := decodeReceipt(deliverBank("bank-2"))
+		assert.Equal(t, "pending", second.Status)
+
+		bankProcessor.failing = false
+		rr := serve(http.MethodPost, "/api/v1/admin/webhooks/run", "", admin)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), `"processed":1`)
+
+		rr = serve(http.MethodGet, "/api/v1/admin/webhooks/dead-letters?provider=bank", "", admin)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var deadLetters struct {
+			Data []eventResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &deadLetters))
+		require.Len(t, deadLetters.Data, 1)
+		assert.Equal(t, first.ID, deadLetters.Data[0].ID)
+		assert.Equal(t, "ledger unavailable", deadLetters.Data[0].LastError)
+		assert.Equal(t, []string{"bank-2"}, bankProcessor.processed)
+	})
+
+	t.Run("admins retry dead-lettered events", func(t *testing.T) {
+		eventID := entity.WebhookEventID("bank", "bank-1")
+
+		rr := serve(http.MethodPost, "/api/v1/admin/webhooks/events/"+eventID+"/retry", "", nil)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+
+		rr = serve(http.MethodPost, "/api/v1/admin/webhooks/events/"+eventID+"/retry", "", admin)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Body.String(), `"status":"processed"`)
+		assert.Equal(t, []string{"bank-2", "bank-1"}, bankProcessor.processed)
+
+		rr = serve(http.MethodPost, "/api/v1/admin/webhooks/events/"+eventID+"/retry", "", admin)
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code, "only dead letters can be retried")
+
+		entries, err := auditService.ListEntries("")
+		require.NoError(t, err)
+		require.NotEmpty(t, entries)
+		assert.Equal(t, application.AuditActionWebhookEventRetried, entries[0].Action())
+		assert.Equal(t, "ops", entries[0].Actor())
+	})
+}
@@ -0,0 +1,159 @@
+package httpclient
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/httpclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testConfig retries fast and opens the circuit after two failed calls
+func testConfig() httpclient.Config {
+	return httpclient.Config{
+		Timeout:          time.Second,
+		MaxAttempts:      3,
+		RetryBackoff:     time.Millisecond,
+		BreakerThreshold: 2,
+		BreakerCooldown:  50 * time.Millisecond,
+	}
+}
+
+// flakyServer fails the first failures requests with status, then answers 200 with the request body
+func flakyServer(t *testing.T, failures int32, status int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= failures {
+			w.WriteHeader(status)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestClient_Retries(t *testing.T) {
+	t.Run("retries idempotent requests on 5xx", func(t *testing.T) {
+		server, calls := flakyServer(t, 2, http.StatusServiceUnavailable)
+		registry := httpclient.NewRegistry(testConfig(), nil)
+
+		resp, err := registry.Client("test").Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, int32(3), calls.Load())
+		stats := registry.Stats()
+		require.Len(t, stats, 1)
+		assert.Equal(t, int64(3), stats[0].Requests)
+		assert.Equal(t, int64(2), stats[0].Failures)
+		assert.Equal(t, int64(2), stats[0].Retries)
+	})
+
+	t.Run("does not retry POST without idempotency key", func(t *testing.T) {
+		server, calls := flakyServer(t, 1, http.StatusBadGateway)
+		registry := httpclient.NewRegistry(testConfig(), nil)
+
+		resp, err := registry.Client("test").Post(server.URL, "application/json", strings.NewReader(`{}`))
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("retries POST with idempotency key and replays the body", func(t *testing.T) {
+		server, calls := flakyServer(t, 1, http.StatusBadGateway)
+		registry := httpclient.NewRegistry(testConfig(), nil)
+
+		req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(`{"amount":10}`))
+		require.NoError(t, err)
+		req.Header.Set("Idempotency-Key", "charge-1")
+		resp, err := registry.Client("test").Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, `{"amount":10}`, string(body))
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("does not retry client errors", func(t *testing.T) {
+		server, calls := flakyServer(t, 1, http.StatusBadRequest)
+		registry := httpclient.NewRegistry(testConfig(), nil)
+
+		resp, err := registry.Client("test").Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+}
+
+func TestClient_CircuitBreaker(t *testing.T) {
+	var healthy atomic.Bool
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	config := testConfig()
+	config.MaxAttempts = 1
+	registry := httpclient.NewRegistry(config, nil)
+	client := registry.Client("test")
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	// The circuit is open: calls are rejected without reaching the destination
+	_, err := client.Get(server.URL)
+	assert.True(t, errors.Is(err, httpclient.ErrCircuitOpen))
+	assert.Equal(t, int32(2), calls.Load())
+	assert.Equal(t, "open", registry.Stats()[0].Circuit)
+	assert.Equal(t, int64(1), registry.Stats()[0].Rejected)
+
+	// After the cooldown a successful trial call closes the circuit
+	healthy.Store(true)
+	time.Sleep(60 * time.Millisecond)
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, "closed", registry.Stats()[0].Circuit)
+}
+
+func TestClient_RateLimit(t *testing.T) {
+	server, _ := flakyServer(t, 0, http.StatusOK)
+	registry := httpclient.NewRegistry(testConfig(), map[string]httpclient.Config{
+		"limited": {RateLimit: 20, Burst: 1},
+	})
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		resp, err := registry.Client("limited").Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	// The first request uses the burst, the next two wait 50ms each
+	assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+}
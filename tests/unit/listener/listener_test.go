@@ -0,0 +1,118 @@
+package listener
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/listener"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serve answers every request on socket with "ok" until the test ends
+func serve(t *testing.T, socket net.Listener) {
+	t.Helper()
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	})}
+	go server.Serve(socket)
+	t.Cleanup(func() { server.Close() })
+}
+
+// unixClient sends every request to the Unix socket at path
+func unixClient(path string) *http.Client {
+	return &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, "unix", path)
+		},
+	}}
+}
+
+func TestListen_UnixSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api.sock")
+
+	t.Run("serves the socket with the default permissions", func(t *testing.T) {
+		socket, err := listener.Listen(listener.Config{Address: "127.0.0.1:0", UnixSocket: path})
+		require.NoError(t, err)
+		serve(t, socket)
+		assert.Equal(t, "unix:"+path, listener.Describe(socket))
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, listener.DefaultSocketMode, info.Mode().Perm())
+
+		response, err := unixClient(path).Get("http://billing/health")
+		require.NoError(t, err)
+		defer response.Body.Close()
+		body, _ := io.ReadAll(response.Body)
+		assert.Equal(t, "ok", string(body))
+
+		socket.Close()
+		_, err = os.Stat(path)
+		assert.True(t, os.IsNotExist(err), "socket file is removed on close")
+	})
+
+	t.Run("replaces a stale socket", func(t *testing.T) {
+		stale, err := net.Listen("unix", path)
+		require.NoError(t, err)
+		stale.(*net.UnixListener).SetUnlinkOnClose(false)
+		stale.Close()
+
+		socket, err := listener.Listen(listener.Config{UnixSocket: path, SocketMode: 0o600})
+		require.NoError(t, err)
+		defer socket.Close()
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	})
+
+	t.Run("keeps files that are not sockets", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "api.conf")
+		require.NoError(t, os.WriteFile(file, []byte("keep"), 0o600))
+
+		_, err := listener.Listen(listener.Config{UnixSocket: file})
+		require.Error(t, err)
+		content, err := os.ReadFile(file)
+		require.NoError(t, err)
+		assert.Equal(t, "keep", string(content))
+	})
+}
+
+func TestListen_TCP(t *testing.T) {
+	socket, err := listener.Listen(listener.Config{Address: "127.0.0.1:0"})
+	require.NoError(t, err)
+	defer socket.Close()
+	assert.Equal(t, "tcp", socket.Addr().Network())
+}
+
+func TestListen_SocketActivation(t *testing.T) {
+	t.Run("requires the socket to be passed to this process", func(t *testing.T) {
+		t.Setenv("LISTEN_PID", strconv.Itoa(os.Getpid()+1))
+		t.Setenv("LISTEN_FDS", "1")
+
+		_, err := listener.Listen(listener.Config{SocketActivation: true})
+		require.Error(t, err)
+		assert.Empty(t, os.Getenv("LISTEN_FDS"), "activation variables are cleared")
+	})
+
+	t.Run("requires a single socket", func(t *testing.T) {
+		t.Setenv("LISTEN_PID", strconv.Itoa(os.Getpid()))
+		t.Setenv("LISTEN_FDS", "2")
+
+		_, err := listener.Listen(listener.Config{SocketActivation: true})
+		assert.ErrorContains(t, err, "2 sockets")
+	})
+
+	t.Run("cannot be combined with a Unix socket", func(t *testing.T) {
+		_, err := listener.Listen(listener.Config{SocketActivation: true, UnixSocket: "/tmp/api.sock"})
+		require.Error(t, err)
+	})
+}
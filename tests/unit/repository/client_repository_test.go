@@ -1,16 +1,17 @@
 package repository
 
 import (
-	"encoding/json"
-	"os"
-	"path/filepath"
-	"runtime"
+	"fmt"
 	"testing"
 
 	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	domainRepository "github.com/gjaminon-go-labs/billing-api/internal/domain/repository"
 	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
 	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/gjaminon-go-labs/billing-api/tests/testdata"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestClientRepository_GetAll_EmptyRepository(t *testing.T) {
@@ -110,41 +111,39 @@ type ClientFixture struct {
 }
 
 func loadRepositoryTestFixtures(t *testing.T) []ClientFixture {
-	// Get current file directory
-	_, currentFile, _, ok := runtime.Caller(0)
-	assert.True(t, ok, "Failed to get current file path")
-
-	// Build path to fixture data
-	testDataPath := filepath.Join(filepath.Dir(currentFile), "..", "..", "testdata", "client", "repository_test_fixtures.json")
-
-	// Read fixture data file
-	data, err := os.ReadFile(testDataPath)
-	assert.NoError(t, err, "Failed to read fixture data file")
-
-	// Parse JSON
-	var fixtures []ClientFixture
-	err = json.Unmarshal(data, &fixtures)
-	assert.NoError(t, err, "Failed to parse fixture data JSON")
-
-	return fixtures
+	return testdata.Load[[]ClientFixture](t, "client/repository_test_fixtures.json")
 }
 
 func loadSingleClientFixture(t *testing.T) ClientFixture {
-	// Get current file directory
-	_, currentFile, _, ok := runtime.Caller(0)
-	assert.True(t, ok, "Failed to get current file path")
-
-	// Build path to fixture data
-	testDataPath := filepath.Join(filepath.Dir(currentFile), "..", "..", "testdata", "client", "single_client_fixture.json")
+	return testdata.Load[ClientFixture](t, "client/single_client_fixture.json")
+}
 
-	// Read fixture data file
-	data, err := os.ReadFile(testDataPath)
-	assert.NoError(t, err, "Failed to read fixture data file")
+func TestClientRepository_ListingsAreBounded(t *testing.T) {
+	// Arrange
+	storage := infrastructure.NewInMemoryStorage()
+	repo := repository.NewClientRepository(storage)
+	for i := 0; i <= domainRepository.MaxListResults; i++ {
+		client, err := entity.NewClient(fmt.Sprintf("Client %04d", i), fmt.Sprintf("client%d@example.com", i), "", "")
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(client))
+	}
 
-	// Parse JSON
-	var fixture ClientFixture
-	err = json.Unmarshal(data, &fixture)
-	assert.NoError(t, err, "Failed to parse fixture data JSON")
+	// Act
+	_, getAllErr := repo.GetAll()
+	_, byStatusErr := repo.ListByStatus(entity.ClientActive)
+	page, _, pageErr := repo.List(domainRepository.ClientListFilter{}, 0, 100)
+	_, _, unboundedErr := repo.List(domainRepository.ClientListFilter{}, 0, 0)
+	_, oversizedErr := repo.ListAfter(domainRepository.ClientListFilter{}, domainRepository.ClientPosition{}, domainRepository.MaxListResults+1)
 
-	return fixture
+	// Assert
+	var ruleErr *domainErrors.BusinessRuleError
+	require.ErrorAs(t, getAllErr, &ruleErr, "unpaginated listings must tell callers to paginate")
+	assert.Equal(t, "result_limit", ruleErr.Rule)
+	assert.Equal(t, domainRepository.MaxListResults, ruleErr.Context["max_results"])
+	assert.Contains(t, ruleErr.Message, "page by page")
+	assert.ErrorAs(t, byStatusErr, &ruleErr)
+	assert.NoError(t, pageErr, "pages stay available whatever the number of clients")
+	assert.Len(t, page, 100)
+	assert.True(t, domainErrors.IsValidationError(unboundedErr))
+	assert.True(t, domainErrors.IsValidationError(oversizedErr))
 }
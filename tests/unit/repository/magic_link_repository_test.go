@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/domain/entity"
+	domainErrors "github.com/gjaminon-go-labs/billing-api/internal/domain/errors"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/repository"
+	"github.com/gjaminon-go-labs/billing-api/tests/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMagicLinkRepository_ParallelConsumeHasSingleWinner(t *testing.T) {
+	// Arrange
+	storage := infrastructure.NewInMemoryStorage()
+	repo := repository.NewMagicLinkRepository(storage.Collection(repository.MagicLinkCollection))
+	link, err := entity.NewMagicLink("portal.sign_in", "client-1", time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(link))
+
+	// Act
+	const attempts = 50
+	var wg sync.WaitGroup
+	errs := make([]error, attempts)
+	start := make(chan struct{})
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			_, errs[i] = repo.Consume(link.ID(), time.Now())
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	// Assert
+	successes := 0
+	for _, err := range errs {
+		if err == nil {
+			successes++
+			continue
+		}
+		assert.ErrorIs(t, err, domainErrors.ErrMagicLinkUsed)
+	}
+	assert.Equal(t, 1, successes)
+
+	stored, err := repo.GetByID(link.ID())
+	require.NoError(t, err)
+	assert.True(t, stored.IsConsumed())
+}
+
+func TestMagicLinkRepository_ConsumeUnknownLink(t *testing.T) {
+	// Arrange
+	storage := infrastructure.NewInMemoryStorage()
+	repo := repository.NewMagicLinkRepository(storage.Collection(repository.MagicLinkCollection))
+
+	// Act
+	_, err := repo.Consume("unknown", time.Now())
+
+	// Assert
+	assert.ErrorIs(t, err, domainErrors.ErrMagicLinkInvalid)
+}
@@ -0,0 +1,140 @@
+package sandbox
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/di"
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainer_SandboxIsolatesIntegratorTraffic(t *testing.T) {
+	config := di.UnitTestConfig()
+	config.AdminTokens = map[string]string{"ops": "admin-token"}
+	config.SandboxEnabled = true
+	config.SandboxTenants = []string{"acme-sandbox"}
+	config.SandboxAPIKeys = map[string]string{"acme": "sandbox-key"}
+	config.SandboxSeedClients = 3
+	server, err := di.NewContainer(config).GetHTTPServer()
+	require.NoError(t, err)
+	handler := server.Handler()
+
+	serve := func(method, path, body string, headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+	// Clients are only listed to admins; the sandbox seeds and creates them without a tenant
+	countClients := func(headers map[string]string) int {
+		t.Helper()
+		admin := map[string]string{"Authorization": "Bearer admin-token"}
+		for name, value := range headers {
+			admin[name] = value
+		}
+		rr := serve(http.MethodGet, "/api/v1/clients?limit=100", "", admin)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var response struct {
+			Pagination struct {
+				TotalCount int `json:"total_count"`
+			} `json:"pagination"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		return response.Pagination.TotalCount
+	}
+	sandboxKey := map[string]string{"X-API-Key": "sandbox-key"}
+	sandboxAdmin := map[string]string{"X-API-Key": "sandbox-key", "Authorization": "Bearer admin-token"}
+
+	t.Run("sandbox starts with synthetic clients", func(t *testing.T) {
+		assert.Equal(t, 3, countClients(sandboxKey))
+		assert.Equal(t, 0, countClients(nil))
+	})
+
+	t.Run("sandbox writes are invisible to live traffic", func(t *testing.T) {
+		rr := serve(http.MethodPost, "/api/v1/clients", `{"name":"Sandbox Corp","email":"billing@sandbox.example"}`, sandboxAdmin)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		assert.Equal(t, "true", rr.Header().Get("X-Sandbox"))
+
+		assert.Equal(t, 4, countClients(sandboxKey))
+		assert.Equal(t, 0, countClients(map[string]string{"X-Tenant-ID": "acme"}))
+	})
+
+	t.Run("live responses are not flagged", func(t *testing.T) {
+		rr := serve(http.MethodGet, "/api/v1/clients", "", map[string]string{"Authorization": "Bearer admin-token"})
+		assert.Empty(t, rr.Header().Get("X-Sandbox"))
+	})
+
+	t.Run("wipe requires an admin token", func(t *testing.T) {
+		rr := serve(http.MethodDelete, "/api/v1/admin/sandbox", "", nil)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Equal(t, 4, countClients(sandboxKey))
+	})
+
+	t.Run("wipe resets the sandbox to its synthetic clients", func(t *testing.T) {
+		rr := serve(http.MethodDelete, "/api/v1/admin/sandbox", "", map[string]string{"Authorization": "Bearer admin-token"})
+		require.Equal(t, http.StatusNoContent, rr.Code, rr.Body.String())
+
+		assert.Equal(t, 3, countClients(sandboxKey))
+	})
+}
+
+func TestContainer_SandboxDisabledByDefault(t *testing.T) {
+	container := di.NewContainer(di.UnitTestConfig())
+
+	sandbox, err := container.GetSandbox()
+	require.NoError(t, err)
+	assert.Nil(t, sandbox)
+}
+
+func TestSandboxContainerConfig_UsesTestModeAndMemoryStorage(t *testing.T) {
+	live := di.UnitTestConfig()
+	live.StorageType = "postgres"
+	live.PaymentGatewayURL = "https://gateway.example/live"
+	live.PaymentGatewayAPIKey = "live-key"
+	live.RiskProviderURL = "https://bureau.example"
+	live.SandboxEnabled = true
+	live.SandboxGatewayURL = "https://gateway.example/test"
+	live.SandboxGatewayAPIKey = "test-key"
+	live.SandboxSeedClients = 5
+
+	config := di.SandboxContainerConfig(live)
+
+	assert.Equal(t, "memory", config.StorageType)
+	assert.Equal(t, "https://gateway.example/test", config.PaymentGatewayURL)
+	assert.Equal(t, "test-key", config.PaymentGatewayAPIKey)
+	assert.Empty(t, config.RiskProviderURL)
+	assert.True(t, config.DemoSeedEnabled)
+	assert.Equal(t, 5, config.DemoClients)
+	assert.False(t, config.SandboxEnabled)
+	assert.True(t, config.SandboxMode)
+	assert.Equal(t, "https://gateway.example/live", live.PaymentGatewayURL, "the live configuration must not change")
+}
+
+func TestSandboxPublisher_MarksMessagesAsSandboxTraffic(t *testing.T) {
+	next := messaging.NewMemoryPublisher()
+	publisher := messaging.NewSandboxPublisher(next, "sandbox@billing.example")
+
+	err := publisher.Publish(context.Background(), messaging.Message{
+		Topic:   "invoice.sent",
+		Key:     "inv-1",
+		Headers: map[string]string{"tenant_id": "acme-sandbox"},
+	})
+
+	require.NoError(t, err)
+	messages := next.Messages()
+	require.Len(t, messages, 1)
+	assert.Equal(t, "sandbox.invoice.sent", messages[0].Topic)
+	assert.Equal(t, "true", messages[0].Headers[messaging.SandboxHeader])
+	assert.Equal(t, "sandbox@billing.example", messages[0].Headers[messaging.EmailCatchAllHeader])
+	assert.Equal(t, "acme-sandbox", messages[0].Headers["tenant_id"])
+}
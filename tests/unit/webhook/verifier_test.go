@@ -0,0 +1,117 @@
+package webhook
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gjaminon-go-labs/billing-api/internal/infrastructure/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+func headers(values map[string]string) func(string) string {
+	header := http.Header{}
+	for name, value := range values {
+		header.Set(name, value)
+	}
+	return header.Get
+}
+
+func TestStripeVerifier_Verify(t *testing.T) {
+	body := []byte(`{"id":"evt_1","type":"payout.paid"}`)
+	timestamp := fmt.Sprint(now.Unix())
+	signature := webhook.Sign("whsec_test", timestamp+"."+string(body))
+	verifier := webhook.NewStripeVerifier("whsec_test")
+
+	tests := []struct {
+		name    string
+		header  string
+		body    []byte
+		wantErr bool
+	}{
+		{name: "valid signature", header: "t=" + timestamp + ",v1=" + signature},
+		{name: "any v1 signature may match", header: "t=" + timestamp + ",v1=deadbeef,v1=" + signature},
+		{name: "missing header", header: "", wantErr: true},
+		{name: "tampered body", header: "t=" + timestamp + ",v1=" + signature, body: []byte(`{"id":"evt_2","type":"payout.paid"}`), wantErr: true},
+		{name: "wrong secret", header: "t=" + timestamp + ",v1=" + webhook.Sign("other", timestamp+"."+string(body)), wantErr: true},
+		{name: "stale timestamp", header: fmt.Sprintf("t=%d,v1=%s", now.Add(-time.Hour).Unix(), webhook.Sign("whsec_test", fmt.Sprintf("%d.%s", now.Add(-time.Hour).Unix(), body))), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delivered := body
+			if tt.body != nil {
+				delivered = tt.body
+			}
+
+			err := verifier.Verify(headers(map[string]string{"Stripe-Signature": tt.header}), delivered, now)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestHMACVerifier_Verify(t *testing.T) {
+	body := []byte(`{"event_id":"bank-42","event_type":"credit_transfer.received"}`)
+
+	t.Run("body signature in the default header", func(t *testing.T) {
+		verifier := webhook.NewHMACVerifier("bank-secret", "", "")
+
+		assert.NoError(t, verifier.Verify(headers(map[string]string{webhook.DefaultSignatureHeader: "sha256=" + webhook.Sign("bank-secret", string(body))}), body, now))
+		assert.NoError(t, verifier.Verify(headers(map[string]string{webhook.DefaultSignatureHeader: webhook.Sign("bank-secret", string(body))}), body, now))
+		assert.Error(t, verifier.Verify(headers(map[string]string{webhook.DefaultSignatureHeader: "sha256=" + webhook.Sign("other", string(body))}), body, now))
+		assert.Error(t, verifier.Verify(headers(nil), body, now))
+	})
+
+	t.Run("timestamped signature in custom headers", func(t *testing.T) {
+		verifier := webhook.NewHMACVerifier("network-secret", "X-Network-Signature", "X-Network-Timestamp")
+		timestamp := fmt.Sprint(now.Unix())
+
+		valid := headers(map[string]string{
+			"X-Network-Signature": "sha256=" + webhook.Sign("network-secret", timestamp+"."+string(body)),
+			"X-Network-Timestamp": timestamp,
+		})
+		assert.NoError(t, verifier.Verify(valid, body, now))
+		assert.Error(t, verifier.Verify(valid, body, now.Add(time.Hour)), "stale deliveries are rejected")
+
+		unsignedTimestamp := headers(map[string]string{
+			"X-Network-Signature": "sha256=" + webhook.Sign("network-secret", string(body)),
+			"X-Network-Timestamp": timestamp,
+		})
+		assert.Error(t, verifier.Verify(unsignedTimestamp, body, now))
+	})
+}
+
+func TestVerifier_ParseEvent(t *testing.T) {
+	verifier := webhook.NewHMACVerifier("secret", "", "")
+
+	envelope, err := verifier.ParseEvent([]byte(`{"id":"evt_1","type":"payout.paid","data":{}}`))
+	require.NoError(t, err)
+	assert.Equal(t, "evt_1", envelope.ID)
+	assert.Equal(t, "payout.paid", envelope.Type)
+
+	envelope, err = verifier.ParseEvent([]byte(`{"event_id":"inv-7","event_type":"invoice.delivered"}`))
+	require.NoError(t, err)
+	assert.Equal(t, "inv-7", envelope.ID)
+	assert.Equal(t, "invoice.delivered", envelope.Type)
+
+	_, err = verifier.ParseEvent([]byte(`{"type":"payout.paid"}`))
+	assert.Error(t, err)
+	_, err = verifier.ParseEvent([]byte(`not json`))
+	assert.Error(t, err)
+}
+
+func TestNewVerifier_UnknownScheme(t *testing.T) {
+	_, err := webhook.NewVerifier("basic", "secret", "", "")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown webhook signature scheme")
+}